@@ -2,8 +2,7 @@ package main
 
 import (
 	"context"
-	"database/sql"
-	"fmt"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,18 +10,30 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
-	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
+	"banking-service/internal/database"
 	"banking-service/internal/handler"
 	"banking-service/internal/middleware"
+	"banking-service/internal/models"
 	"banking-service/internal/repository"
 	"banking-service/internal/service"
-	"banking-service/pkg/scheduler"
+	"banking-service/pkg/antivirus"
+	"banking-service/pkg/captcha"
+	"banking-service/pkg/cbr"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/httpclient"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/ocr"
+	"banking-service/pkg/search"
+	"banking-service/pkg/storage"
 )
 
 func main() {
+	storageMode := flag.String("storage", "postgres", "data storage backend: postgres or memory")
+	flag.Parse()
+
 	// Initialize logger
 	log := logrus.New()
 	log.SetFormatter(&logrus.JSONFormatter{})
@@ -35,119 +46,619 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Connect to database
-	db, err := initDB(cfg)
+	log.Infof("Effective configuration:\n%s", cfg)
+	setLogLevel(log, cfg.Log.Level)
+
+	// Count business days in the bank's time zone
+	location, err := cfg.Bank.Location()
 	if err != nil {
-		log.Fatalf("Failed to initialize database: %v", err)
+		log.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	// Reloadable settings are read through live and updated on SIGHUP or via the admin endpoint
+	live := configs.NewLive(cfg)
+	live.OnReload(func(c *configs.Config) {
+		setLogLevel(log, c.Log.Level)
+	})
+
+	// Initialize repositories; memory storage runs the API without a database and loses all data on exit
+	var repos *repository.Repository
+	switch *storageMode {
+	case "postgres":
+		db, err := database.Open(cfg)
+		if err != nil {
+			log.Fatalf("Failed to initialize database: %v", err)
+		}
+		defer db.Close()
+
+		repos = repository.NewRepository(db, models.AccountNumberScheme{BIC: cfg.Bank.BIC, Branch: cfg.Bank.Branch}, location)
+	case "memory":
+		log.Warn("Using in-memory storage, all data is lost when the service stops")
+		repos = repository.NewMemoryRepository(models.AccountNumberScheme{BIC: cfg.Bank.BIC, Branch: cfg.Bank.Branch})
+	default:
+		log.Fatalf("Unknown storage %q, expected postgres or memory", *storageMode)
 	}
-	defer db.Close()
 
-	// Initialize repositories
-	repos := repository.NewRepository(db)
+	// Track background loops and notification sends so shutdown can wait for them
+	manager := lifecycle.NewManager(log)
+
+	// Client of the calls to external services, retrying them and stopping the calls to failing hosts
+	outbound := httpclient.New(outboundHTTPOptions(cfg.OutboundHTTP))
+
+	// Object storage for uploaded credit documents
+	documentStorage, err := storage.NewStorage(cfg.Storage.Backend, storageOptions(cfg.Storage, outbound))
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Virus scanning of uploaded documents
+	var scanner antivirus.Scanner
+	if cfg.Antivirus.Enabled {
+		scanner, err = antivirus.NewScanner(cfg.Antivirus.Provider, cfg.Antivirus.Address, time.Duration(cfg.Antivirus.Timeout)*time.Second)
+		if err != nil {
+			log.Fatalf("Failed to initialize antivirus: %v", err)
+		}
+	}
+
+	// Amount recognition of deposited cheques and payment orders
+	ocrReader, err := ocr.NewReader(cfg.Cheque.OCRProvider)
+	if err != nil {
+		log.Fatalf("Failed to initialize OCR: %v", err)
+	}
+
+	// Full-text search of transactions, searched in the database when it is disabled
+	var searchIndex search.Index
+	if cfg.Search.Enabled {
+		searchIndex, err = search.NewIndex(cfg.Search.Provider, searchIndexOptions(cfg.Search, outbound))
+		if err != nil {
+			log.Fatalf("Failed to initialize search index: %v", err)
+		}
+	}
+
+	// Key rate sources, tried in the configured order
+	keyRates, err := cbr.NewKeyRateProvider(cfg.CBR.KeyRateProviders, cbr.Options{
+		SOAPURL: cfg.CBR.APIURL,
+		JSONURL: cfg.CBR.KeyRateJSONURL,
+		Timeout: time.Duration(cfg.CBR.Timeout) * time.Second,
+		Client:  outbound,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize key rate provider: %v", err)
+	}
+
+	// Keys the JWT tokens are signed with
+	jwtKeys, err := crypto.NewJWTKeys(cfg.JWT.Algorithm, []byte(cfg.JWT.Secret), cfg.JWT.PrivateKeyFile, cfg.JWT.LegacyHMACDeadline())
+	if err != nil {
+		log.Fatalf("Failed to initialize JWT keys: %v", err)
+	}
 
 	// Initialize services
 	services := service.NewService(service.Dependencies{
 		Repos:       repos,
 		Logger:      log,
 		Config:      cfg,
+		Live:        live,
+		Lifecycle:   manager,
+		Storage:     documentStorage,
+		Scanner:     scanner,
+		OCR:         ocrReader,
+		Search:      searchIndex,
+		KeyRates:    keyRates,
+		HTTP:        outbound,
+		JWT:         jwtKeys,
+		Bank:        location,
 	})
 
+	// CAPTCHA verification for registration and repeated failed logins
+	var captchaVerifier captcha.Verifier
+	if cfg.Captcha.Enabled {
+		captchaVerifier, err = captcha.NewVerifier(cfg.Captcha.Provider, cfg.Captcha.SecretKey, time.Duration(cfg.Captcha.Timeout)*time.Second, outbound)
+		if err != nil {
+			log.Fatalf("Failed to initialize captcha: %v", err)
+		}
+	}
+
 	// Initialize handlers
 	handlers := handler.NewHandler(handler.Dependencies{
 		Services:    services,
 		Logger:      log,
 		Config:      cfg,
+		Live:        live,
+		Captcha:     captchaVerifier,
+		HTTP:        outbound,
+		Bank:        location,
 	})
 
 	// Initialize router
 	router := mux.NewRouter()
+	router.Use(middleware.RequestIDMiddleware())
+	router.Use(middleware.LocalizationMiddleware(cfg.Bank.DefaultLanguage(), nil))
+	router.Use(middleware.RateLimitMiddleware(live))
+	router.Use(middleware.TenantMiddleware(cfg))
 	
-	// Public routes
-	router.HandleFunc("/register", handlers.User.Register).Methods(http.MethodPost)
+	// Customer traffic is rejected with 503 while maintenance mode is on
+	maintenance := middleware.MaintenanceMiddleware(live)
+
+	// Public routes (login stays open so admins can sign in during maintenance)
+	router.HandleFunc("/health", handlers.Health.Check).Methods(http.MethodGet)
+	router.Handle("/register", maintenance(http.HandlerFunc(handlers.User.Register))).Methods(http.MethodPost)
+	router.Handle("/register/availability", middleware.AvailabilityRateLimitMiddleware(live)(maintenance(http.HandlerFunc(handlers.User.CheckAvailability)))).Methods(http.MethodGet)
 	router.HandleFunc("/login", handlers.User.Login).Methods(http.MethodPost)
+	router.Handle("/password/forgot", maintenance(http.HandlerFunc(handlers.User.ForgotPassword))).Methods(http.MethodPost)
+	router.Handle("/password/reset", maintenance(http.HandlerFunc(handlers.User.ConfirmPasswordReset))).Methods(http.MethodGet)
+	router.Handle("/password/reset", maintenance(http.HandlerFunc(handlers.User.ResetPassword))).Methods(http.MethodPost)
+	router.HandleFunc("/sessions/revoke", handlers.Session.ConfirmRevokeByToken).Methods(http.MethodGet)
+	router.HandleFunc("/sessions/revoke", handlers.Session.RevokeByToken).Methods(http.MethodPost)
+	router.HandleFunc("/email/verify", handlers.Onboarding.VerifyEmail).Methods(http.MethodGet)
+	router.HandleFunc("/receipts/verify", handlers.Receipt.Verify).Methods(http.MethodGet)
+	router.HandleFunc("/payments/{reference:[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}}", handlers.PaymentVerification.Verify).Methods(http.MethodGet)
+	router.HandleFunc("/calculator/credit", handlers.Credit.Calculate).Methods(http.MethodPost)
+	router.HandleFunc("/email/events", handlers.Email.HandleEvents).Methods(http.MethodPost)
 
 	// Protected routes with middleware
 	api := router.PathPrefix("/api").Subrouter()
-	api.Use(middleware.AuthMiddleware(cfg.JWT.Secret))
+	api.Use(middleware.APIKeyMiddleware(services.APIKey))
+	api.Use(middleware.AuthMiddleware(jwtKeys, services.Session))
+	api.Use(middleware.ScopeMiddleware())
+	api.Use(middleware.ImpersonationMiddleware(services.Impersonation))
+	api.Use(middleware.LocalizationMiddleware(cfg.Bank.DefaultLanguage(), services.User))
 	api.Use(middleware.LogMiddleware(log))
+	api.Use(maintenance)
+
+	// GET list endpoints are gzip-compressed and support ETag/If-None-Match; their messages are
+	// translated before compression in the language picked above
+	list := func(h http.HandlerFunc) http.Handler {
+		return middleware.GzipMiddleware()(middleware.ETagMiddleware()(middleware.LocalizationMiddleware(cfg.Bank.DefaultLanguage(), nil)(h)))
+	}
+
+	// Limited tokens may only use the routes declared with one of their scopes; tokens from login
+	// may use every route
+	read := func(h http.Handler) http.Handler { return middleware.RequireScope(models.ScopeAccountsRead, h) }
+	pay := func(h http.Handler) http.Handler { return middleware.RequireScope(models.ScopeTransfersWrite, h) }
+	cards := func(h http.Handler) http.Handler { return middleware.RequireScope(models.ScopeCardsManage, h) }
+
+	// Long-running endpoints get the extended read/write timeouts from server.long_running
+	long := middleware.TimeoutMiddleware(
+		time.Duration(cfg.Server.LongRunning.ReadTimeout)*time.Second,
+		time.Duration(cfg.Server.LongRunning.WriteTimeout)*time.Second,
+		log,
+	)
+
+	// Profile endpoints
+	api.HandleFunc("/me/profile", handlers.User.GetUser).Methods(http.MethodGet)
+	api.HandleFunc("/me/profile", handlers.User.UpdateProfile).Methods(http.MethodPut)
+	api.HandleFunc("/me/password", handlers.User.ChangePassword).Methods(http.MethodPut)
+	api.HandleFunc("/me/onboarding", handlers.Onboarding.GetStatus).Methods(http.MethodGet)
+	api.HandleFunc("/me/email/verification", handlers.Onboarding.SendEmailVerification).Methods(http.MethodPost)
+	api.HandleFunc("/me/tokens", handlers.User.IssueToken).Methods(http.MethodPost)
+	api.HandleFunc("/me/api-keys", handlers.APIKey.Create).Methods(http.MethodPost)
+	api.HandleFunc("/me/api-keys", handlers.APIKey.GetAll).Methods(http.MethodGet)
+	api.HandleFunc("/me/api-keys/{id}/rotate", handlers.APIKey.Rotate).Methods(http.MethodPost)
+	api.HandleFunc("/me/api-keys/{id}", handlers.APIKey.Revoke).Methods(http.MethodDelete)
+	api.HandleFunc("/me/timezone", handlers.User.SetTimezone).Methods(http.MethodPut)
+	api.HandleFunc("/me/language", handlers.User.SetLanguage).Methods(http.MethodPut)
+
+	// Session endpoints
+	api.HandleFunc("/logout", handlers.Session.Logout).Methods(http.MethodPost)
+	api.HandleFunc("/me/sessions", handlers.Session.GetAll).Methods(http.MethodGet)
+	api.HandleFunc("/me/sessions/{id}", handlers.Session.Revoke).Methods(http.MethodDelete)
+
+	// Notification endpoints
+	api.HandleFunc("/notifications", handlers.Notification.GetAll).Methods(http.MethodGet)
+	api.HandleFunc("/notifications/{id}/read", handlers.Notification.MarkRead).Methods(http.MethodPut)
+
+	// Secure message endpoints
+	api.HandleFunc("/messages", handlers.Message.CreateThread).Methods(http.MethodPost)
+	api.Handle("/messages", list(handlers.Message.GetThreads)).Methods(http.MethodGet)
+	api.HandleFunc("/messages/unread", handlers.Message.GetUnreadCount).Methods(http.MethodGet)
+	api.HandleFunc("/messages/{id:[0-9]+}", handlers.Message.GetThread).Methods(http.MethodGet)
+	api.HandleFunc("/messages/{id:[0-9]+}/reply", handlers.Message.Reply).Methods(http.MethodPost)
+	api.HandleFunc("/messages/{id:[0-9]+}/documents/{messageId}", handlers.Message.DownloadDocument).Methods(http.MethodGet)
 
 	// Account endpoints
+	api.HandleFunc("/account-plans", handlers.AccountPlan.GetAll).Methods(http.MethodGet)
 	api.HandleFunc("/accounts", handlers.Account.Create).Methods(http.MethodPost)
-	api.HandleFunc("/accounts", handlers.Account.GetAll).Methods(http.MethodGet)
-	api.HandleFunc("/accounts/{id}", handlers.Account.GetByID).Methods(http.MethodGet)
-	api.HandleFunc("/accounts/{id}/balance", handlers.Account.UpdateBalance).Methods(http.MethodPut)
-	api.HandleFunc("/accounts/{id}/predict", handlers.Analytics.PredictBalance).Methods(http.MethodGet)
+	api.Handle("/accounts", read(list(handlers.Account.GetAll))).Methods(http.MethodGet)
+	api.Handle("/accounts/default", read(http.HandlerFunc(handlers.Account.GetDefault))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}", read(http.HandlerFunc(handlers.Account.GetByID))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}/balance", pay(http.HandlerFunc(handlers.Account.UpdateBalance))).Methods(http.MethodPut)
+	api.Handle("/accounts/{id}/withdraw", pay(http.HandlerFunc(handlers.Account.Withdraw))).Methods(http.MethodPost)
+	api.HandleFunc("/accounts/{id}/reactivate", handlers.Account.Reactivate).Methods(http.MethodPost)
+	api.Handle("/accounts/{id}/holds", read(http.HandlerFunc(handlers.Account.GetHolds))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}/activity", read(http.HandlerFunc(handlers.Account.GetActivity))).Methods(http.MethodGet)
+	api.HandleFunc("/accounts/{id}/settings", handlers.Account.UpdateSettings).Methods(http.MethodPatch)
+	api.HandleFunc("/accounts/{id}/default", handlers.Account.SetDefault).Methods(http.MethodPut)
+	api.Handle("/accounts/{id}/plan", read(http.HandlerFunc(handlers.AccountPlan.GetForAccount))).Methods(http.MethodGet)
+	api.HandleFunc("/accounts/{id}/plan", handlers.AccountPlan.Switch).Methods(http.MethodPut)
+	api.Handle("/accounts/{id}/statements", read(list(handlers.Statement.GetAll))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}/statements/{statementId:[0-9]+}", read(http.HandlerFunc(handlers.Statement.GetByID))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}/statements/{statementId:[0-9]+}/camt053", read(http.HandlerFunc(handlers.Statement.DownloadCamt053))).Methods(http.MethodGet)
+	api.HandleFunc("/accounts/{id}/statements/regenerate", handlers.Statement.Regenerate).Methods(http.MethodPost)
+	api.Handle("/accounts/{id}/predict", read(long(http.HandlerFunc(handlers.Analytics.PredictBalance)))).Methods(http.MethodGet)
+	api.Handle("/accounts/{id}/transactions", read(list(handlers.Transaction.GetByAccount))).Methods(http.MethodGet)
+
+	// Organization endpoints
+	api.HandleFunc("/organizations", handlers.Organization.Create).Methods(http.MethodPost)
+	api.Handle("/organizations", list(handlers.Organization.GetAll)).Methods(http.MethodGet)
+	api.Handle("/organizations/invitations", list(handlers.Organization.GetMyInvitations)).Methods(http.MethodGet)
+	api.HandleFunc("/organizations/invitations/{id}/accept", handlers.Organization.AcceptInvitation).Methods(http.MethodPost)
+	api.HandleFunc("/organizations/invitations/{id}/decline", handlers.Organization.DeclineInvitation).Methods(http.MethodPost)
+	api.HandleFunc("/organizations/{id:[0-9]+}", handlers.Organization.GetByID).Methods(http.MethodGet)
+	api.Handle("/organizations/{id:[0-9]+}/accounts", list(handlers.Organization.GetAccounts)).Methods(http.MethodGet)
+	api.HandleFunc("/organizations/{id:[0-9]+}/members/{userId}", handlers.Organization.UpdateMemberRole).Methods(http.MethodPut)
+	api.HandleFunc("/organizations/{id:[0-9]+}/members/{userId}", handlers.Organization.RemoveMember).Methods(http.MethodDelete)
+	api.HandleFunc("/organizations/{id:[0-9]+}/invitations", handlers.Organization.Invite).Methods(http.MethodPost)
+	api.Handle("/organizations/{id:[0-9]+}/invitations", list(handlers.Organization.GetInvitations)).Methods(http.MethodGet)
+	api.HandleFunc("/organizations/{id:[0-9]+}/invitations/{invitationId}", handlers.Organization.RevokeInvitation).Methods(http.MethodDelete)
+	api.HandleFunc("/organizations/{id:[0-9]+}/approval-policies", handlers.Organization.GetApprovalPolicies).Methods(http.MethodGet)
+	api.HandleFunc("/organizations/{id:[0-9]+}/approval-policies", handlers.Organization.SetApprovalPolicies).Methods(http.MethodPut)
+	api.Handle("/organizations/{id:[0-9]+}/pending-transfers", list(handlers.Transaction.GetPendingTransfers)).Methods(http.MethodGet)
+	api.HandleFunc("/organizations/{id:[0-9]+}/payrolls", handlers.Payroll.Upload).Methods(http.MethodPost)
+	api.Handle("/organizations/{id:[0-9]+}/payrolls", list(handlers.Payroll.GetByOrganization)).Methods(http.MethodGet)
+	api.HandleFunc("/payrolls/{id:[0-9]+}", handlers.Payroll.GetByID).Methods(http.MethodGet)
+	api.HandleFunc("/payrolls/{id:[0-9]+}/execute", handlers.Payroll.Execute).Methods(http.MethodPost)
+	api.HandleFunc("/payrolls/{id:[0-9]+}/report", handlers.Payroll.DownloadReport).Methods(http.MethodGet)
+
+	// Power of attorney endpoints
+	api.HandleFunc("/delegations", handlers.Delegation.Grant).Methods(http.MethodPost)
+	api.Handle("/delegations", list(handlers.Delegation.GetGranted)).Methods(http.MethodGet)
+	api.Handle("/delegations/received", list(handlers.Delegation.GetReceived)).Methods(http.MethodGet)
+	api.HandleFunc("/delegations/{id:[0-9]+}", handlers.Delegation.Revoke).Methods(http.MethodDelete)
+	api.Handle("/delegations/{id:[0-9]+}/events", list(handlers.Delegation.GetEvents)).Methods(http.MethodGet)
 
 	// Card endpoints
-	api.HandleFunc("/cards", handlers.Card.Create).Methods(http.MethodPost)
-	api.HandleFunc("/cards", handlers.Card.GetAll).Methods(http.MethodGet)
-	api.HandleFunc("/cards/{id}", handlers.Card.GetByID).Methods(http.MethodGet)
+	api.HandleFunc("/card-products", handlers.CardProduct.GetAll).Methods(http.MethodGet)
+	api.Handle("/cards", cards(http.HandlerFunc(handlers.Card.Create))).Methods(http.MethodPost)
+	api.Handle("/cards", cards(list(handlers.Card.GetAll))).Methods(http.MethodGet)
+	api.Handle("/cards/{id}", cards(http.HandlerFunc(handlers.Card.GetByID))).Methods(http.MethodGet)
+	api.Handle("/cards/{id}/pin", cards(http.HandlerFunc(handlers.Card.SetPIN))).Methods(http.MethodPut)
+	api.Handle("/cards/{id}/transactions", cards(list(handlers.Card.GetTransactions))).Methods(http.MethodGet)
+
+	// ATM simulation
+	api.Handle("/atm/withdraw", pay(http.HandlerFunc(handlers.Card.ATMWithdraw))).Methods(http.MethodPost)
 
 	// Transaction endpoints
-	api.HandleFunc("/transfer", handlers.Transaction.Transfer).Methods(http.MethodPost)
-	api.HandleFunc("/transactions", handlers.Transaction.GetAll).Methods(http.MethodGet)
+	api.Handle("/transfer", pay(http.HandlerFunc(handlers.Transaction.Transfer))).Methods(http.MethodPost)
+	api.Handle("/transfer/confirm", pay(http.HandlerFunc(handlers.Transaction.ConfirmTransfer))).Methods(http.MethodPost)
+	api.HandleFunc("/pending-transfers/{id}", handlers.Transaction.GetPendingTransfer).Methods(http.MethodGet)
+	api.Handle("/pending-transfers/{id}/approve", pay(http.HandlerFunc(handlers.Transaction.ApproveTransfer))).Methods(http.MethodPost)
+	api.Handle("/pending-transfers/{id}/reject", pay(http.HandlerFunc(handlers.Transaction.RejectTransfer))).Methods(http.MethodPost)
+	api.Handle("/transactions", read(list(handlers.Transaction.GetAll))).Methods(http.MethodGet)
+	api.Handle("/transactions/search", read(list(handlers.Search.SearchTransactions))).Methods(http.MethodGet)
+	api.Handle("/transactions/{id:[0-9]+}", read(http.HandlerFunc(handlers.Transaction.GetByID))).Methods(http.MethodGet)
+	api.HandleFunc("/transactions/{id:[0-9]+}/description", handlers.Transaction.EditDescription).Methods(http.MethodPut)
+	api.Handle("/transactions/{id:[0-9]+}/description/history", read(http.HandlerFunc(handlers.Transaction.GetDescriptionHistory))).Methods(http.MethodGet)
+	api.Handle("/transactions/{id:[0-9]+}/receipt", read(http.HandlerFunc(handlers.Receipt.Download))).Methods(http.MethodGet)
+	api.Handle("/transactions/{id:[0-9]+}/verification", read(http.HandlerFunc(handlers.PaymentVerification.GetSecret))).Methods(http.MethodGet)
+
+	// Bill payment endpoints
+	api.Handle("/bills/providers", list(handlers.Bill.GetProviders)).Methods(http.MethodGet)
+	api.HandleFunc("/bills/providers/{id}", handlers.Bill.GetProvider).Methods(http.MethodGet)
+	api.Handle("/bills/pay", pay(http.HandlerFunc(handlers.Bill.Pay))).Methods(http.MethodPost)
+	api.Handle("/bills/payments", list(handlers.Bill.GetPayments)).Methods(http.MethodGet)
+	api.Handle("/bills/payments/{id}/repeat", pay(http.HandlerFunc(handlers.Bill.PayAgain))).Methods(http.MethodPost)
+	api.HandleFunc("/bills/templates", handlers.Bill.CreateTemplate).Methods(http.MethodPost)
+	api.Handle("/bills/templates", list(handlers.Bill.GetTemplates)).Methods(http.MethodGet)
+	api.HandleFunc("/bills/templates/{id}", handlers.Bill.DeleteTemplate).Methods(http.MethodDelete)
+	api.Handle("/bills/templates/{id}/pay", pay(http.HandlerFunc(handlers.Bill.PayTemplate))).Methods(http.MethodPost)
+
+	// Merchant endpoints
+	api.HandleFunc("/merchants", handlers.Merchant.Create).Methods(http.MethodPost)
+	api.Handle("/merchants", list(handlers.Merchant.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/merchants/{id}", handlers.Merchant.GetByID).Methods(http.MethodGet)
+	api.HandleFunc("/merchants/{id}/api-key", handlers.Merchant.RotateAPIKey).Methods(http.MethodPost)
+	api.Handle("/merchants/{id}/settlements", list(handlers.Merchant.GetSettlements)).Methods(http.MethodGet)
+
+	// Customer payment intent endpoints
+	api.HandleFunc("/payment-intents/{intentId}", handlers.Merchant.GetCustomerIntent).Methods(http.MethodGet)
+	api.Handle("/payment-intents/{intentId}/pay", pay(http.HandlerFunc(handlers.Merchant.PayIntent))).Methods(http.MethodPost)
+
+	// Chargeback endpoints
+	api.HandleFunc("/chargebacks", handlers.Chargeback.Create).Methods(http.MethodPost)
+	api.Handle("/chargebacks", list(handlers.Chargeback.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/chargebacks/{id}", handlers.Chargeback.GetByID).Methods(http.MethodGet)
+
+	// Escrow endpoints
+	api.Handle("/escrows", pay(http.HandlerFunc(handlers.Escrow.Create))).Methods(http.MethodPost)
+	api.Handle("/escrows", list(handlers.Escrow.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/escrows/{id}", handlers.Escrow.GetByID).Methods(http.MethodGet)
+	api.Handle("/escrows/{id}/confirm", pay(http.HandlerFunc(handlers.Escrow.Confirm))).Methods(http.MethodPost)
+
+	// Invoice endpoints
+	api.HandleFunc("/invoices", handlers.Invoice.Create).Methods(http.MethodPost)
+	api.Handle("/invoices", list(handlers.Invoice.GetIssued)).Methods(http.MethodGet)
+	api.Handle("/invoices/received", list(handlers.Invoice.GetReceived)).Methods(http.MethodGet)
+	api.HandleFunc("/invoices/{number}", handlers.Invoice.GetByNumber).Methods(http.MethodGet)
+	api.Handle("/invoices/{number}/pay", pay(http.HandlerFunc(handlers.Invoice.Pay))).Methods(http.MethodPost)
+	api.HandleFunc("/invoices/{number}/cancel", handlers.Invoice.Cancel).Methods(http.MethodPost)
+
+	// Subscription endpoints
+	api.HandleFunc("/subscription-plans/{id}", handlers.Subscription.GetPlan).Methods(http.MethodGet)
+	api.HandleFunc("/subscriptions", handlers.Subscription.Subscribe).Methods(http.MethodPost)
+	api.Handle("/subscriptions", list(handlers.Subscription.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/subscriptions/{id}/cancel", handlers.Subscription.Cancel).Methods(http.MethodPost)
+
+	// Referral program endpoints
+	api.HandleFunc("/referrals/summary", handlers.Referral.GetSummary).Methods(http.MethodGet)
+	api.Handle("/referrals", list(handlers.Referral.GetReferrals)).Methods(http.MethodGet)
+
+	// Tax document endpoints
+	api.Handle("/tax-documents", list(handlers.TaxDocument.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/tax-documents/{year:[0-9]{4}}", handlers.TaxDocument.GetByYear).Methods(http.MethodGet)
+	api.HandleFunc("/tax-documents/{year:[0-9]{4}}/download", handlers.TaxDocument.Download).Methods(http.MethodGet)
 
 	// Credit endpoints
 	api.HandleFunc("/credits", handlers.Credit.Create).Methods(http.MethodPost)
-	api.HandleFunc("/credits", handlers.Credit.GetAll).Methods(http.MethodGet)
+	api.Handle("/credits", list(handlers.Credit.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/credits/key-rate", handlers.Credit.GetKeyRate).Methods(http.MethodGet)
 	api.HandleFunc("/credits/{id}", handlers.Credit.GetByID).Methods(http.MethodGet)
-	api.HandleFunc("/credits/{id}/schedule", handlers.Credit.GetSchedule).Methods(http.MethodGet)
+	api.Handle("/credits/{id}/schedule", list(handlers.Credit.GetSchedule)).Methods(http.MethodGet)
+	api.HandleFunc("/credits/{id}/payoff-quote", handlers.Credit.GetPayoffQuote).Methods(http.MethodGet)
+	api.HandleFunc("/credits/{id}/insurance/cancel", handlers.Credit.CancelInsurance).Methods(http.MethodPost)
+	api.HandleFunc("/credits/{id}/agreement", handlers.CreditAgreement.Get).Methods(http.MethodGet)
+	api.HandleFunc("/credits/{id}/agreement/sign", handlers.CreditAgreement.Sign).Methods(http.MethodPost)
+	api.HandleFunc("/credits/{id}/agreement/confirm", handlers.CreditAgreement.Confirm).Methods(http.MethodPost)
+
+	// Credit application endpoints
+	api.HandleFunc("/credit-applications", handlers.CreditApplication.Create).Methods(http.MethodPost)
+	api.Handle("/credit-applications", list(handlers.CreditApplication.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/credit-applications/{id:[0-9]+}", handlers.CreditApplication.GetByID).Methods(http.MethodGet)
+	api.Handle("/credit-applications/{id:[0-9]+}/documents", long(http.HandlerFunc(handlers.CreditApplication.UploadDocument))).Methods(http.MethodPost)
+	api.HandleFunc("/credit-applications/{id:[0-9]+}/documents/{documentId:[0-9]+}", handlers.CreditApplication.DownloadDocument).Methods(http.MethodGet)
+
+	// Cheque deposit endpoints
+	api.Handle("/cheque-deposits", long(http.HandlerFunc(handlers.ChequeDeposit.Submit))).Methods(http.MethodPost)
+	api.Handle("/cheque-deposits", list(handlers.ChequeDeposit.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/cheque-deposits/{id:[0-9]+}", handlers.ChequeDeposit.GetByID).Methods(http.MethodGet)
+
+	// International transfer endpoints
+	api.Handle("/international-transfers", pay(http.HandlerFunc(handlers.InternationalTransfer.Create))).Methods(http.MethodPost)
+	api.Handle("/international-transfers/quote", pay(http.HandlerFunc(handlers.InternationalTransfer.Quote))).Methods(http.MethodPost)
+	api.Handle("/international-transfers", list(handlers.InternationalTransfer.GetAll)).Methods(http.MethodGet)
+	api.HandleFunc("/international-transfers/{id:[0-9]+}", handlers.InternationalTransfer.GetByID).Methods(http.MethodGet)
+	api.HandleFunc("/international-transfers/{id:[0-9]+}/tracking", handlers.InternationalTransfer.Tracking).Methods(http.MethodGet)
+	api.HandleFunc("/international-transfers/tracking/{reference}", handlers.InternationalTransfer.TrackByReference).Methods(http.MethodGet)
 
 	// Analytics endpoints
-	api.HandleFunc("/analytics", handlers.Analytics.GetStatistics).Methods(http.MethodGet)
-
-	// Start the payment scheduler
-	paymentScheduler := scheduler.NewScheduler(services.Credit, log)
-	paymentScheduler.Start(time.Hour * 24) // Check payments once per day
-	defer paymentScheduler.Stop()
-
-	// Configure and start server
-	srv := &http.Server{
-		Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
-		Handler:      router,
-		ReadTimeout:  time.Second * 15,
-		WriteTimeout: time.Second * 15,
-		IdleTimeout:  time.Second * 60,
-	}
-
-	// Start the server in a goroutine
-	go func() {
-		log.Infof("Starting server on port %d", cfg.Server.Port)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
-		}
-	}()
+	api.Handle("/analytics", read(long(http.HandlerFunc(handlers.Analytics.GetStatistics)))).Methods(http.MethodGet)
+	api.Handle("/analytics/cards/{id:[0-9]+}", read(long(http.HandlerFunc(handlers.Analytics.GetCardAnalytics)))).Methods(http.MethodGet)
+	api.Handle("/analytics/forecast", read(long(http.HandlerFunc(handlers.Analytics.GetSpendingForecast)))).Methods(http.MethodGet)
+	api.Handle("/analytics/recommendations", read(long(http.HandlerFunc(handlers.Analytics.GetRecommendations)))).Methods(http.MethodGet)
+	api.Handle("/analytics/cashflow", read(long(http.HandlerFunc(handlers.Analytics.GetCashFlow)))).Methods(http.MethodGet)
+	api.Handle("/analytics/credit-simulation", read(long(http.HandlerFunc(handlers.Analytics.SimulateCredit)))).Methods(http.MethodPost)
+
+	// Central bank rates
+	api.HandleFunc("/rates/history", handlers.Rate.GetHistory).Methods(http.MethodGet)
+
+	// Branch and ATM locator
+	api.HandleFunc("/locations", handlers.Location.GetNearby).Methods(http.MethodGet)
+
+	// Merchant API, authenticated by the merchant's API key
+	merchantAPI := router.PathPrefix("/merchant-api").Subrouter()
+	merchantAPI.Use(middleware.MerchantAuthMiddleware(services.Merchant))
+	merchantAPI.Use(middleware.LogMiddleware(log))
+	merchantAPI.Use(maintenance)
+	merchantAPI.HandleFunc("/payment-intents", handlers.Merchant.CreateIntent).Methods(http.MethodPost)
+	merchantAPI.Handle("/payment-intents", list(handlers.Merchant.GetIntents)).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/payment-intents/{intentId}", handlers.Merchant.GetIntent).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/payment-intents/{intentId}/cancel", handlers.Merchant.CancelIntent).Methods(http.MethodPost)
+	merchantAPI.Handle("/chargebacks", list(handlers.Chargeback.GetMerchantChargebacks)).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/chargebacks/{id}/evidence", handlers.Chargeback.SubmitEvidence).Methods(http.MethodPost)
+	merchantAPI.HandleFunc("/chargebacks/{id}/accept", handlers.Chargeback.Accept).Methods(http.MethodPost)
+	merchantAPI.HandleFunc("/invoices", handlers.Invoice.CreateForMerchant).Methods(http.MethodPost)
+	merchantAPI.Handle("/invoices", list(handlers.Invoice.GetForMerchant)).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/invoices/{number}/cancel", handlers.Invoice.CancelForMerchant).Methods(http.MethodPost)
+	merchantAPI.HandleFunc("/plans", handlers.Subscription.CreatePlan).Methods(http.MethodPost)
+	merchantAPI.Handle("/plans", list(handlers.Subscription.GetPlans)).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/plans/{id}/deactivate", handlers.Subscription.DeactivatePlan).Methods(http.MethodPost)
+	merchantAPI.Handle("/subscriptions", list(handlers.Subscription.GetForMerchant)).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/subscriptions/report", handlers.Subscription.GetReport).Methods(http.MethodGet)
+	merchantAPI.HandleFunc("/subscriptions/{id}/cancel", handlers.Subscription.CancelForMerchant).Methods(http.MethodPost)
+
+	// Admin endpoints (optionally restricted by source IP and client certificate)
+	adminNetworks, err := cfg.Admin.AllowedNetworks()
+	if err != nil {
+		log.Fatalf("Invalid admin allowlist: %v", err)
+	}
+	admin := api.PathPrefix("/admin").Subrouter()
+	admin.Use(middleware.RequireRole(string(models.UserRoleAdmin)))
+	if len(adminNetworks) > 0 {
+		admin.Use(middleware.IPAllowlistMiddleware(adminNetworks))
+	}
+	if cfg.Admin.ClientCAFile != "" {
+		admin.Use(middleware.ClientCertMiddleware())
+	}
+	admin.HandleFunc("/config/reload", handlers.Admin.ReloadConfig).Methods(http.MethodPost)
+	admin.HandleFunc("/outbound-http", handlers.Admin.GetOutboundHTTP).Methods(http.MethodGet)
+	admin.HandleFunc("/email-deliveries", handlers.Email.GetDeliveries).Methods(http.MethodGet)
+	admin.HandleFunc("/email-suppressions", handlers.Email.GetSuppressions).Methods(http.MethodGet)
+	admin.HandleFunc("/email-suppressions/{id:[0-9]+}", handlers.Email.DeleteSuppression).Methods(http.MethodDelete)
+	admin.HandleFunc("/announcements", handlers.Announcement.Create).Methods(http.MethodPost)
+	admin.Handle("/announcements", list(handlers.Announcement.GetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/announcements/{id:[0-9]+}", handlers.Announcement.GetByID).Methods(http.MethodGet)
+	admin.HandleFunc("/announcements/{id:[0-9]+}/cancel", handlers.Announcement.Cancel).Methods(http.MethodPost)
+	admin.HandleFunc("/maintenance", handlers.Admin.GetMaintenance).Methods(http.MethodGet)
+	admin.HandleFunc("/maintenance", handlers.Admin.SetMaintenance).Methods(http.MethodPut)
+	admin.Handle("/chargebacks", list(handlers.Chargeback.AdminGetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/chargebacks/{id}/resolve", handlers.Chargeback.Resolve).Methods(http.MethodPost)
+	admin.Handle("/escrows", list(handlers.Escrow.AdminGetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/escrows/{id}/resolve", handlers.Escrow.Resolve).Methods(http.MethodPost)
+	admin.HandleFunc("/messages", handlers.Message.AdminSend).Methods(http.MethodPost)
+	admin.Handle("/messages", list(handlers.Message.AdminGetThreads)).Methods(http.MethodGet)
+	admin.HandleFunc("/messages/{id:[0-9]+}", handlers.Message.AdminGetThread).Methods(http.MethodGet)
+	admin.HandleFunc("/messages/{id:[0-9]+}/reply", handlers.Message.AdminReply).Methods(http.MethodPost)
+	admin.HandleFunc("/messages/{id:[0-9]+}/documents/{messageId}", handlers.Message.AdminDownloadDocument).Methods(http.MethodGet)
+	admin.Handle("/credit-applications", list(handlers.CreditApplication.AdminGetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/credit-applications/{id:[0-9]+}", handlers.CreditApplication.AdminGetByID).Methods(http.MethodGet)
+	admin.HandleFunc("/credit-applications/{id:[0-9]+}/documents/{documentId:[0-9]+}", handlers.CreditApplication.AdminDownloadDocument).Methods(http.MethodGet)
+	admin.HandleFunc("/credit-applications/{id:[0-9]+}/documents/{documentId:[0-9]+}/review", handlers.CreditApplication.ReviewDocument).Methods(http.MethodPut)
+	admin.HandleFunc("/credit-applications/{id:[0-9]+}/approve", handlers.CreditApplication.Approve).Methods(http.MethodPost)
+	admin.HandleFunc("/credit-applications/{id:[0-9]+}/reject", handlers.CreditApplication.Reject).Methods(http.MethodPost)
+	admin.Handle("/cheque-deposits", list(handlers.ChequeDeposit.AdminGetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/cheque-deposits/{id:[0-9]+}/image", handlers.ChequeDeposit.AdminDownloadImage).Methods(http.MethodGet)
+	admin.HandleFunc("/cheque-deposits/{id:[0-9]+}/approve", handlers.ChequeDeposit.Approve).Methods(http.MethodPost)
+	admin.HandleFunc("/cheque-deposits/{id:[0-9]+}/reject", handlers.ChequeDeposit.Reject).Methods(http.MethodPost)
+	admin.Handle("/international-transfers", list(handlers.InternationalTransfer.AdminGetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/credits/{id:[0-9]+}", handlers.CreditAdjustment.Get).Methods(http.MethodGet)
+	admin.HandleFunc("/credits/{id:[0-9]+}/payments/{paymentId:[0-9]+}/waive-penalty", handlers.CreditAdjustment.WaivePenalty).Methods(http.MethodPost)
+	admin.HandleFunc("/credits/{id:[0-9]+}/payments/{paymentId:[0-9]+}/reschedule", handlers.CreditAdjustment.Reschedule).Methods(http.MethodPost)
+	admin.HandleFunc("/credits/{id:[0-9]+}/restructure", handlers.CreditAdjustment.Restructure).Methods(http.MethodPost)
+	admin.HandleFunc("/accounts/{id:[0-9]+}/ownership-transfers", handlers.AccountOwnership.Request).Methods(http.MethodPost)
+	admin.Handle("/accounts/{id:[0-9]+}/ownership-transfers", list(handlers.AccountOwnership.GetByAccountID)).Methods(http.MethodGet)
+	admin.HandleFunc("/accounts/{id:[0-9]+}/statements/regenerate", handlers.Statement.AdminRegenerate).Methods(http.MethodPost)
+	admin.Handle("/ownership-transfers", list(handlers.AccountOwnership.GetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/ownership-transfers/{id:[0-9]+}", handlers.AccountOwnership.Get).Methods(http.MethodGet)
+	admin.HandleFunc("/ownership-transfers/{id:[0-9]+}/approve", handlers.AccountOwnership.Approve).Methods(http.MethodPost)
+	admin.HandleFunc("/ownership-transfers/{id:[0-9]+}/reject", handlers.AccountOwnership.Reject).Methods(http.MethodPost)
+	admin.Handle("/accounting-export", long(http.HandlerFunc(handlers.Accounting.Export))).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/dashboard", handlers.Reporting.Dashboard).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/transactions", handlers.Reporting.TransactionVolume).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/users", handlers.Reporting.NewUsers).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/credits", handlers.Reporting.CreditsIssued).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/portfolio", handlers.Reporting.CreditPortfolio).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/portfolio/export", handlers.Reporting.StartCreditPortfolioExport).Methods(http.MethodPost)
+	admin.HandleFunc("/reports/portfolio/export", handlers.Reporting.DownloadCreditPortfolioExport).Methods(http.MethodGet)
+	admin.HandleFunc("/reports/deposits", handlers.Reporting.DepositBalances).Methods(http.MethodGet)
+	admin.HandleFunc("/locations", handlers.Location.AdminGetAll).Methods(http.MethodGet)
+	admin.HandleFunc("/locations", handlers.Location.Create).Methods(http.MethodPost)
+	admin.HandleFunc("/locations/{id:[0-9]+}", handlers.Location.Update).Methods(http.MethodPut)
+	admin.HandleFunc("/locations/{id:[0-9]+}", handlers.Location.Delete).Methods(http.MethodDelete)
+	admin.HandleFunc("/account-plans", handlers.AccountPlan.AdminGetAll).Methods(http.MethodGet)
+	admin.HandleFunc("/account-plans", handlers.AccountPlan.Create).Methods(http.MethodPost)
+	admin.HandleFunc("/account-plans/{id:[0-9]+}", handlers.AccountPlan.Update).Methods(http.MethodPut)
+	admin.HandleFunc("/card-products", handlers.CardProduct.AdminGetAll).Methods(http.MethodGet)
+	admin.HandleFunc("/card-products", handlers.CardProduct.Create).Methods(http.MethodPost)
+	admin.HandleFunc("/card-products/{id:[0-9]+}", handlers.CardProduct.Update).Methods(http.MethodPut)
+	admin.HandleFunc("/fee-charges", handlers.Fee.GetCharges).Methods(http.MethodGet)
+	admin.HandleFunc("/fee-runs", handlers.Fee.GetRuns).Methods(http.MethodGet)
+	admin.HandleFunc("/key-rate-overrides", handlers.Rate.GetKeyRateOverrides).Methods(http.MethodGet)
+	admin.HandleFunc("/key-rate-overrides", handlers.Rate.CreateKeyRateOverride).Methods(http.MethodPost)
+	admin.HandleFunc("/key-rate-overrides/{id:[0-9]+}", handlers.Rate.RevokeKeyRateOverride).Methods(http.MethodDelete)
+	admin.Handle("/impersonations", list(handlers.Impersonation.GetAll)).Methods(http.MethodGet)
+	admin.HandleFunc("/impersonations/{id:[0-9]+}", handlers.Impersonation.Get).Methods(http.MethodGet)
+
+	// Support endpoints (restricted like the admin endpoints)
+	support := api.PathPrefix("/support").Subrouter()
+	support.Use(middleware.RequireRole(string(models.UserRoleSupport)))
+	if len(adminNetworks) > 0 {
+		support.Use(middleware.IPAllowlistMiddleware(adminNetworks))
+	}
+	if cfg.Admin.ClientCAFile != "" {
+		support.Use(middleware.ClientCertMiddleware())
+	}
+	support.HandleFunc("/impersonations", handlers.Impersonation.Start).Methods(http.MethodPost)
+	support.HandleFunc("/impersonations/{id:[0-9]+}/end", handlers.Impersonation.End).Methods(http.MethodPost)
+
+	// Periodic jobs (payments, settlement, statements, ...) run in the separate worker binary, cmd/worker
 
-	// Wait for interrupt signal to gracefully shutdown the server
+	// Reload configuration on SIGHUP
+	manager.Go("config-reload", func(ctx context.Context) error {
+		return watchReload(ctx, live, log)
+	})
+
+	// Configure and start the server (HTTPS and the redirect server if TLS is enabled)
+	srv, err := newServers(cfg, router, log)
+	if err != nil {
+		log.Fatalf("Failed to configure server: %v", err)
+	}
+	srv.Start()
+
+	// Wait for interrupt signal (or a failed background loop) to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	select {
+	case <-quit:
+	case <-manager.Done():
+		log.Error("Background loop stopped unexpectedly")
+	}
 	log.Info("Shutting down server...")
 
 	// Create a deadline context for shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
-	// Shutdown the server
+	// Shutdown the server first so no new background work is submitted
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatalf("Server shutdown failed: %v", err)
+		log.Errorf("Server shutdown failed: %v", err)
+	}
+
+	// Wait for in-flight notifications to finish
+	if err := manager.Shutdown(ctx); err != nil {
+		log.Errorf("Background tasks did not stop cleanly: %v", err)
 	}
 
 	log.Info("Server gracefully stopped")
 }
 
-func initDB(cfg *configs.Config) (*sql.DB, error) {
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.User, cfg.Database.Password, cfg.Database.DBName)
-	
-	db, err := sql.Open("postgres", connStr)
-	if err != nil {
-		return nil, err
+// watchReload reloads the live configuration every time the process receives SIGHUP
+func watchReload(ctx context.Context, live *configs.Live, log *logrus.Logger) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			ignored, err := live.Reload()
+			if err != nil {
+				log.Errorf("Failed to reload configuration, keeping current settings: %v", err)
+				continue
+			}
+			if len(ignored) > 0 {
+				log.Warnf("Configuration sections changed but require a restart: %v", ignored)
+			}
+			log.Info("Configuration reloaded")
+		}
+	}
+}
+
+// storageOptions maps the storage settings to storage options
+func storageOptions(c configs.StorageConfig, client httpclient.Doer) storage.Options {
+	return storage.Options{
+		Dir:       c.Dir,
+		Endpoint:  c.S3.Endpoint,
+		Region:    c.S3.Region,
+		Bucket:    c.S3.Bucket,
+		AccessKey: c.S3.AccessKey,
+		SecretKey: c.S3.SecretKey,
+		Prefix:    c.Prefix,
+		Timeout:   time.Duration(c.Timeout) * time.Second,
+		Client:    client,
 	}
+}
 
-	if err = db.Ping(); err != nil {
-		return nil, err
+// searchIndexOptions maps the search settings to search index options
+func searchIndexOptions(c configs.SearchConfig, client httpclient.Doer) search.Options {
+	return search.Options{
+		URL:      c.URL,
+		Index:    c.Index,
+		Username: c.Username,
+		Password: c.Password,
+		Timeout:  time.Duration(c.Timeout) * time.Second,
+		Client:   client,
 	}
+}
 
-	return db, nil
+// outboundHTTPOptions maps the outbound HTTP settings to client options
+func outboundHTTPOptions(c configs.OutboundHTTPConfig) httpclient.Options {
+	return httpclient.Options{
+		Timeout:          time.Duration(c.Timeout) * time.Second,
+		MaxAttempts:      c.MaxAttempts,
+		BaseDelay:        time.Duration(c.BaseDelayMs) * time.Millisecond,
+		MaxDelay:         time.Duration(c.MaxDelayMs) * time.Millisecond,
+		BreakerThreshold: c.BreakerThreshold,
+		BreakerCooldown:  time.Duration(c.BreakerCooldown) * time.Second,
+	}
+}
+
+// setLogLevel applies a configured log level to the logger
+func setLogLevel(log *logrus.Logger, level string) {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		log.Warnf("Unknown log level %q, keeping %s", level, log.GetLevel())
+		return
+	}
+	log.SetLevel(parsed)
 }
\ No newline at end of file
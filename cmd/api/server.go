@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/acme/autocert"
+
+	"banking-service/configs"
+)
+
+// servers holds the API server and the optional plain HTTP server that redirects to HTTPS
+type servers struct {
+	api      *http.Server
+	redirect *http.Server
+	tls      configs.TLSConfig
+	log      *logrus.Logger
+}
+
+// newServers configures the API server (with TLS if enabled) and the redirect server
+func newServers(cfg *configs.Config, handler http.Handler, log *logrus.Logger) (*servers, error) {
+	s := &servers{
+		api: &http.Server{
+			Addr:         fmt.Sprintf(":%d", cfg.Server.Port),
+			Handler:      handler,
+			ReadTimeout:  time.Duration(cfg.Server.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(cfg.Server.WriteTimeout) * time.Second,
+			IdleTimeout:  time.Duration(cfg.Server.IdleTimeout) * time.Second,
+		},
+		tls: cfg.Server.TLS,
+		log: log,
+	}
+
+	if !s.tls.Enabled() {
+		return s, nil
+	}
+
+	s.api.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+
+	var redirect http.Handler = redirectToHTTPS(cfg.Server.Port)
+
+	if s.tls.UsesAutocert() {
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(s.tls.AutocertDomains...),
+			Cache:      autocert.DirCache(s.tls.AutocertCacheDir),
+		}
+		s.api.TLSConfig = manager.TLSConfig()
+		s.api.TLSConfig.MinVersion = tls.VersionTLS12
+
+		// The redirect server also answers ACME HTTP-01 challenges
+		redirect = manager.HTTPHandler(redirect)
+	}
+
+	// Client certificates are optional at the TLS level and enforced only on admin routes
+	if cfg.Admin.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.Admin.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		s.api.TLSConfig.ClientCAs = pool
+		s.api.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	if s.tls.RedirectPort != 0 {
+		s.redirect = &http.Server{
+			Addr:         fmt.Sprintf(":%d", s.tls.RedirectPort),
+			Handler:      redirect,
+			ReadTimeout:  time.Second * 5,
+			WriteTimeout: time.Second * 5,
+			IdleTimeout:  time.Second * 30,
+		}
+	}
+
+	return s, nil
+}
+
+// loadCertPool reads a PEM bundle of CA certificates
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", path)
+	}
+
+	return pool, nil
+}
+
+// Start runs the servers in background goroutines
+func (s *servers) Start() {
+	go func() {
+		var err error
+		if s.tls.Enabled() {
+			s.log.Infof("Starting HTTPS server on %s", s.api.Addr)
+			// With autocert the certificate comes from TLSConfig.GetCertificate
+			err = s.api.ListenAndServeTLS(s.tls.CertFile, s.tls.KeyFile)
+		} else {
+			s.log.Infof("Starting server on %s", s.api.Addr)
+			err = s.api.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			s.log.Fatalf("Server error: %v", err)
+		}
+	}()
+
+	if s.redirect == nil {
+		return
+	}
+
+	go func() {
+		s.log.Infof("Starting HTTP to HTTPS redirect on %s", s.redirect.Addr)
+		if err := s.redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.log.Fatalf("Redirect server error: %v", err)
+		}
+	}()
+}
+
+// Shutdown gracefully stops the servers
+func (s *servers) Shutdown(ctx context.Context) error {
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			s.log.Errorf("Redirect server shutdown failed: %v", err)
+		}
+	}
+	return s.api.Shutdown(ctx)
+}
+
+// redirectToHTTPS redirects every request to the same URL on the HTTPS port
+func redirectToHTTPS(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, fmt.Sprintf("%d", httpsPort))
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/search"
+)
+
+// createAdmin creates an administrator, or a support employee with -role SUPPORT. The password is
+// read from stdin so it does not end up in the shell history or the process list.
+func createAdmin(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	username := fs.String("username", "", "username of the administrator")
+	email := fs.String("email", "", "email of the administrator")
+	firstName := fs.String("first-name", "", "first name")
+	lastName := fs.String("last-name", "", "last name")
+	role := fs.String("role", string(models.UserRoleAdmin), "role of the employee: ADMIN or SUPPORT")
+
+	return func(ctx context.Context, env *environment) error {
+		if *role != string(models.UserRoleAdmin) && *role != string(models.UserRoleSupport) {
+			return errors.New("role must be ADMIN or SUPPORT")
+		}
+
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && password == "" {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+
+		registration := &models.UserRegistration{
+			Username:  *username,
+			Email:     *email,
+			Password:  strings.TrimRight(password, "\r\n"),
+			FirstName: *firstName,
+			LastName:  *lastName,
+		}
+		if err := registration.ValidateRegistration(); err != nil {
+			return fmt.Errorf("invalid user data: %w", err)
+		}
+
+		if _, err := env.repos.User.GetByUsername(ctx, registration.Username); err == nil {
+			return errors.New("username already exists")
+		}
+
+		if _, err := env.repos.User.GetByEmail(ctx, registration.Email); err == nil {
+			return errors.New("email already exists")
+		}
+
+		user := registration.ToUser()
+		user.Role = models.UserRole(*role)
+
+		params := crypto.DefaultArgon2Params()
+		params.Memory = uint32(env.cfg.Password.Memory)
+		params.Iterations = uint32(env.cfg.Password.Iterations)
+		params.Parallelism = uint8(env.cfg.Password.Parallelism)
+		user.PassHash, err = crypto.NewArgon2Hasher(params).HashPassword(user.Password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+
+		id, err := env.repos.User.Create(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		env.log.Infof("Employee %s created with role %s and ID %d", user.Username, user.Role, id)
+		return nil
+	}
+}
+
+// unlockAccount lifts the dormant flag of an account and activates it if it was deactivated
+func unlockAccount(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	id := fs.Int("id", 0, "account ID")
+
+	return func(ctx context.Context, env *environment) error {
+		account, err := env.repos.Account.GetByID(ctx, *id)
+		if err != nil {
+			return err
+		}
+
+		if account.DormantSince == nil && account.IsActive {
+			env.log.Infof("Account %d is not locked", account.ID)
+			return nil
+		}
+
+		if account.DormantSince != nil {
+			if _, err := env.repos.Account.Reactivate(ctx, account.ID); err != nil {
+				return err
+			}
+			env.log.Infof("Dormant account %d reactivated", account.ID)
+		}
+
+		if !account.IsActive {
+			account.IsActive = true
+			if err := env.repos.Account.Update(ctx, account); err != nil {
+				return err
+			}
+			env.log.Infof("Account %d activated", account.ID)
+		}
+
+		return nil
+	}
+}
+
+// processPayments runs the daily credit payment collection now
+func processPayments(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	return func(ctx context.Context, env *environment) error {
+		return service.NewCreditService(env.deps()).ProcessPayments(ctx)
+	}
+}
+
+// resendEmail sends a tax document again. Tax documents are the emails whose delivery is recorded,
+// so they are the ones an operator can find failed and resend.
+func resendEmail(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	userID := fs.Int("user", 0, "user ID")
+	year := fs.Int("year", 0, "tax year of the document")
+
+	return func(ctx context.Context, env *environment) error {
+		_, err := service.NewTaxDocumentService(env.deps()).Resend(ctx, *userID, *year)
+		return err
+	}
+}
+
+// rotateKeys re-encrypts the card numbers and expiry dates with a new PGP key pair. The current
+// keys come from the configuration and the new ones from the NEW_PGP_* environment variables.
+// Cards already encrypted with the new key are skipped, so an interrupted rotation can be run
+// again. Switch PGP_PUBLIC_KEY, PGP_PRIVATE_KEY and PGP_PASSPHRASE to the new keys afterwards.
+func rotateKeys(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	return func(ctx context.Context, env *environment) error {
+		current, err := crypto.NewPGPCrypto(env.cfg.PGP.PublicKey, env.cfg.PGP.PrivateKey, env.cfg.PGP.Passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to load current PGP keys: %w", err)
+		}
+
+		next, err := crypto.NewPGPCrypto(os.Getenv("NEW_PGP_PUBLIC_KEY"), os.Getenv("NEW_PGP_PRIVATE_KEY"), os.Getenv("NEW_PGP_PASSPHRASE"))
+		if err != nil {
+			return fmt.Errorf("failed to load new PGP keys: %w", err)
+		}
+
+		cards, err := env.repos.Card.GetAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		rotated, skipped := 0, 0
+		for _, card := range cards {
+			if _, err := next.Decrypt(card.CardNumberEncrypted); err == nil {
+				skipped++
+				continue
+			}
+
+			if card.CardNumberEncrypted, err = reencrypt(current, next, card.CardNumberEncrypted); err != nil {
+				return fmt.Errorf("failed to re-encrypt number of card %d: %w", card.ID, err)
+			}
+
+			if card.ExpiryDateEncrypted, err = reencrypt(current, next, card.ExpiryDateEncrypted); err != nil {
+				return fmt.Errorf("failed to re-encrypt expiry date of card %d: %w", card.ID, err)
+			}
+
+			if err := env.repos.Card.UpdateEncrypted(ctx, card); err != nil {
+				return err
+			}
+			rotated++
+		}
+
+		env.log.Infof("Re-encrypted %d cards, %d already used the new key", rotated, skipped)
+		return nil
+	}
+}
+
+// reencrypt decrypts data with the current key and encrypts it with the next one
+func reencrypt(current, next *crypto.PGPCrypto, data []byte) ([]byte, error) {
+	plain, err := current.Decrypt(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return next.Encrypt(plain)
+}
+
+// reconcile lists the accounts whose balance does not match their transactions and fails if there
+// are any, so it can run from cron
+func reconcile(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	return func(ctx context.Context, env *environment) error {
+		mismatches, err := env.repos.Accounting.GetBalanceMismatches(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(mismatches) == 0 {
+			env.log.Info("All account balances match their transactions")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "ACCOUNT\tNUMBER\tCURRENCY\tBALANCE\tLEDGER\tDIFFERENCE\t")
+		for _, m := range mismatches {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%.2f\t%.2f\t\n",
+				m.AccountID, m.AccountNumber, m.Currency, m.Balance, m.LedgerBalance, m.Difference())
+		}
+		w.Flush()
+
+		return fmt.Errorf("%d accounts do not reconcile", len(mismatches))
+	}
+}
+
+// searchReindex builds a new search index of all transactions and switches searches to it once it
+// is complete, so it can run while the API serves searches from the old one
+func searchReindex(fs *flag.FlagSet) func(ctx context.Context, env *environment) error {
+	return func(ctx context.Context, env *environment) error {
+		if !env.cfg.Search.Enabled {
+			return errors.New("search.enabled is false")
+		}
+
+		index, err := search.NewIndex(env.cfg.Search.Provider, search.Options{
+			URL:      env.cfg.Search.URL,
+			Index:    env.cfg.Search.Index,
+			Username: env.cfg.Search.Username,
+			Password: env.cfg.Search.Password,
+			Timeout:  time.Duration(env.cfg.Search.Timeout) * time.Second,
+		})
+		if err != nil {
+			return err
+		}
+
+		deps := env.deps()
+		deps.Search = index
+		_, err = service.NewSearchService(deps).Reindex(ctx)
+		return err
+	}
+}
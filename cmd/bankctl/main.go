@@ -0,0 +1,187 @@
+// Command bankctl runs operator tasks against the banking service. It uses the same configuration
+// as the API (config file and environment variables) and works directly on the database, so it
+// also works while the API is stopped or in maintenance mode:
+//
+//	bankctl create-admin -username ops -email ops@example.com < password.txt
+//	bankctl unlock-account -id 42
+//	bankctl process-payments
+//	bankctl resend-email -user 7 -year 2025
+//	bankctl rotate-keys
+//	bankctl reconcile
+//	bankctl search-reindex
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/database"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/internal/service"
+	"banking-service/pkg/lifecycle"
+)
+
+// command is an operator task. Its setup defines the command's flags and returns the task to run
+// once they are parsed.
+type command struct {
+	usage       string
+	description string
+	setup       func(fs *flag.FlagSet) func(ctx context.Context, env *environment) error
+}
+
+// commands are the tasks bankctl runs, by name
+var commands = map[string]command{
+	"create-admin": {
+		usage:       "-username NAME -email EMAIL [-first-name NAME] [-last-name NAME] [-role ADMIN|SUPPORT] (password on stdin)",
+		description: "create an administrator or a support employee",
+		setup:       createAdmin,
+	},
+	"unlock-account": {
+		usage:       "-id ID",
+		description: "reactivate a dormant or deactivated account",
+		setup:       unlockAccount,
+	},
+	"process-payments": {
+		description: "collect the credit payments due today, as the daily scheduler does",
+		setup:       processPayments,
+	},
+	"resend-email": {
+		usage:       "-user ID -year YEAR",
+		description: "send a tax document email again after delivery failed",
+		setup:       resendEmail,
+	},
+	"rotate-keys": {
+		usage:       "(new keys in NEW_PGP_PUBLIC_KEY, NEW_PGP_PRIVATE_KEY, NEW_PGP_PASSPHRASE)",
+		description: "re-encrypt the card data with a new PGP key pair",
+		setup:       rotateKeys,
+	},
+	"reconcile": {
+		description: "report accounts whose balance differs from the sum of their transactions",
+		setup:       reconcile,
+	},
+	"search-reindex": {
+		description: "rebuild the transaction search index from the database",
+		setup:       searchReindex,
+	},
+}
+
+// environment is what commands work with
+type environment struct {
+	log       *logrus.Logger
+	cfg       *configs.Config
+	repos     *repository.Repository
+	lifecycle *lifecycle.Manager
+	bank      *time.Location
+}
+
+// deps returns the dependencies for the services a command uses
+func (e *environment) deps() service.Dependencies {
+	return service.Dependencies{
+		Repos:     e.repos,
+		Logger:    e.log,
+		Config:    e.cfg,
+		Live:      configs.NewLive(e.cfg),
+		Lifecycle: e.lifecycle,
+		Bank:      e.bank,
+	}
+}
+
+func main() {
+	log := logrus.New()
+	log.SetOutput(os.Stderr)
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	fs := newFlagSet(flag.Arg(0), cmd.usage)
+	run := cmd.setup(fs)
+	fs.Parse(flag.Args()[1:])
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Count business days in the bank's time zone
+	location, err := cfg.Bank.Location()
+	if err != nil {
+		log.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	env := &environment{
+		log:       log,
+		cfg:       cfg,
+		repos:     repository.NewRepository(db, models.AccountNumberScheme{BIC: cfg.Bank.BIC, Branch: cfg.Bank.Branch}, location),
+		lifecycle: lifecycle.NewManager(log),
+		bank:      location,
+	}
+
+	ctx := context.Background()
+	runErr := run(ctx, env)
+
+	// Wait for the notifications the command sent in the background
+	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if err := env.lifecycle.Shutdown(shutdownCtx); err != nil {
+		log.Errorf("Background tasks did not stop cleanly: %v", err)
+	}
+
+	if runErr != nil {
+		db.Close()
+		log.Fatalf("%s: %v", flag.Arg(0), runErr)
+	}
+}
+
+// usage prints the commands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: bankctl COMMAND [flags]\n\nCommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-17s %s\n", name, commands[name].description)
+		if commands[name].usage != "" {
+			fmt.Fprintf(os.Stderr, "  %-17s %s\n", "", commands[name].usage)
+		}
+	}
+}
+
+// newFlagSet creates the flag set of a command that exits on bad flags
+func newFlagSet(name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("bankctl "+name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: bankctl %s %s\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
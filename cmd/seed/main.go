@@ -0,0 +1,481 @@
+// Command seed fills an empty database with demo data: customers with accounts in several
+// currencies, months of transactions, and active and overdue credits, so analytics, reports and
+// the payment scheduler have something to work with right away.
+//
+// It uses the same configuration as the API (config file and environment variables):
+//
+//	go run ./cmd/seed -users 20 -months 6
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/database"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+)
+
+// demoPrefix starts the usernames of the demo users; its presence marks a seeded database
+const demoPrefix = "demo"
+
+// spendingCategories are the kinds of card payments, with typical merchants and amount ranges in RUB
+var spendingCategories = []struct {
+	name      string
+	merchants []string
+	min, max  float64
+	perMonth  int
+}{
+	{"Groceries", []string{"Pyaterochka", "Perekrestok", "Magnit", "VkusVill"}, 300, 4500, 8},
+	{"Restaurants", []string{"Shokoladnitsa", "Teremok", "Vkusno i tochka", "Coffee House"}, 250, 3500, 4},
+	{"Transport", []string{"Moscow Metro", "Yandex Go", "Russian Railways"}, 60, 2500, 5},
+	{"Utilities", []string{"Mosenergosbyt", "MGTS", "Rostelecom"}, 700, 6000, 2},
+	{"Shopping", []string{"Wildberries", "Ozon", "M.Video"}, 500, 15000, 2},
+	{"Health", []string{"Rigla", "36.6", "Invitro"}, 300, 5000, 1},
+	{"Entertainment", []string{"Karo Film", "Kinopoisk", "Yandex Plus"}, 300, 2500, 1},
+}
+
+// firstNames and lastNames make up the names of the demo users, who live in one of the cities
+var (
+	firstNames = []string{"Ivan", "Anna", "Sergey", "Maria", "Dmitry", "Elena", "Alexey", "Olga", "Nikolay", "Tatiana"}
+	lastNames  = []string{"Ivanov", "Petrova", "Smirnov", "Kuznetsova", "Popov", "Volkova", "Sokolov", "Lebedeva", "Kozlov", "Novikova"}
+	cities     = []string{"Moscow", "Saint Petersburg", "Kazan", "Novosibirsk", "Yekaterinburg"}
+)
+
+func main() {
+	users := flag.Int("users", 10, "number of demo customers")
+	months := flag.Int("months", 6, "months of transaction history")
+	seed := flag.Int64("seed", 1, "random seed, the same seed produces the same data")
+	password := flag.String("password", "demo12345", "password of all demo users")
+	flag.Parse()
+
+	log := logrus.New()
+	log.SetOutput(os.Stdout)
+
+	if *users < 1 || *months < 1 || *months > 36 {
+		log.Fatal("users must be positive and months between 1 and 36")
+	}
+
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Count business days in the bank's time zone
+	location, err := cfg.Bank.Location()
+	if err != nil {
+		log.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	db, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	s := &seeder{
+		repos:       repository.NewRepository(db, models.AccountNumberScheme{BIC: cfg.Bank.BIC, Branch: cfg.Bank.Branch}, location),
+		rnd:         rand.New(rand.NewSource(*seed)),
+		now:         time.Now().In(location),
+		months:      *months,
+		penaltyRate: cfg.Credit.PenaltyRate,
+		balances:    map[int]float64{},
+	}
+
+	ctx := context.Background()
+	if _, err := s.repos.User.GetByUsername(ctx, demoPrefix+"1"); err == nil {
+		log.Fatal("Demo data is already seeded")
+	}
+
+	params := crypto.DefaultArgon2Params()
+	params.Memory = uint32(cfg.Password.Memory)
+	params.Iterations = uint32(cfg.Password.Iterations)
+	params.Parallelism = uint8(cfg.Password.Parallelism)
+	passHash, err := crypto.NewArgon2Hasher(params).HashPassword(*password)
+	if err != nil {
+		log.Fatalf("Failed to hash password: %v", err)
+	}
+
+	if err := s.seedAdmin(ctx, passHash); err != nil {
+		log.Fatalf("Failed to seed admin: %v", err)
+	}
+
+	for i := 1; i <= *users; i++ {
+		if err := s.seedCustomer(ctx, i, passHash); err != nil {
+			log.Fatalf("Failed to seed user %d: %v", i, err)
+		}
+
+		if err := s.flush(ctx); err != nil {
+			log.Fatalf("Failed to store transactions of user %d: %v", i, err)
+		}
+	}
+
+	if err := s.applyBalances(ctx); err != nil {
+		log.Fatalf("Failed to update balances: %v", err)
+	}
+
+	log.Infof("Seeded %d customers (%s1..%s%d) and %s-admin with %d months of history, %d transactions",
+		*users, demoPrefix, demoPrefix, *users, demoPrefix, *months, s.transactions)
+}
+
+// seeder creates the demo data, keeping the balances the generated transactions add up to
+type seeder struct {
+	repos        *repository.Repository
+	rnd          *rand.Rand
+	now          time.Time
+	months       int
+	penaltyRate  float64
+	balances     map[int]float64
+	pending      []*models.Transaction
+	transactions int
+}
+
+// seedAdmin creates an administrator for the admin endpoints and reports
+func (s *seeder) seedAdmin(ctx context.Context, passHash string) error {
+	_, err := s.repos.User.Create(ctx, &models.User{
+		Username:  demoPrefix + "-admin",
+		Email:     demoPrefix + "-admin@example.com",
+		PassHash:  passHash,
+		FirstName: "Demo",
+		LastName:  "Admin",
+		Role:      models.UserRoleAdmin,
+	})
+	return err
+}
+
+// seedCustomer creates a customer with accounts, transaction history and, for some, a credit
+func (s *seeder) seedCustomer(ctx context.Context, i int, passHash string) error {
+	birthDate := time.Date(1960+s.rnd.Intn(45), time.Month(1+s.rnd.Intn(12)), 1+s.rnd.Intn(28), 0, 0, 0, 0, time.UTC)
+	userID, err := s.repos.User.Create(ctx, &models.User{
+		Username:  fmt.Sprintf("%s%d", demoPrefix, i),
+		Email:     fmt.Sprintf("%s%d@example.com", demoPrefix, i),
+		PassHash:  passHash,
+		FirstName: firstNames[s.rnd.Intn(len(firstNames))],
+		LastName:  lastNames[s.rnd.Intn(len(lastNames))],
+		Phone:     fmt.Sprintf("+7916%07d", s.rnd.Intn(10000000)),
+		BirthDate: &birthDate,
+		Role:      models.UserRoleCustomer,
+		Address: models.Address{
+			Country:    "RU",
+			City:       cities[s.rnd.Intn(len(cities))],
+			Street:     fmt.Sprintf("Lenina st., %d, apt. %d", 1+s.rnd.Intn(120), 1+s.rnd.Intn(300)),
+			PostalCode: fmt.Sprintf("%06d", 100000+s.rnd.Intn(900000)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	checking, err := s.account(ctx, userID, models.CurrencyRUB, models.AccountTypeChecking)
+	if err != nil {
+		return err
+	}
+
+	savings, err := s.account(ctx, userID, models.CurrencyRUB, models.AccountTypeSavings)
+	if err != nil {
+		return err
+	}
+
+	if err := s.seedRUBHistory(ctx, checking, savings); err != nil {
+		return err
+	}
+
+	// Some customers also hold foreign currency
+	for _, currency := range []models.Currency{models.CurrencyUSD, models.CurrencyEUR} {
+		if s.rnd.Intn(3) != 0 {
+			continue
+		}
+
+		account, err := s.account(ctx, userID, currency, models.AccountTypeChecking)
+		if err != nil {
+			return err
+		}
+
+		if err := s.seedForeignHistory(ctx, account, currency); err != nil {
+			return err
+		}
+	}
+
+	// Every third customer repays a credit on schedule, the customer after each of them is behind on one
+	switch i % 3 {
+	case 0:
+		return s.seedCredit(ctx, userID, checking, false)
+	case 1:
+		if i > 1 {
+			return s.seedCredit(ctx, userID, checking, true)
+		}
+	}
+
+	return nil
+}
+
+// seedRUBHistory generates a salary, card spending, cash withdrawals, savings transfers and
+// interest for every month of the history
+func (s *seeder) seedRUBHistory(ctx context.Context, checking, savings int) error {
+	salary := roundAmount(60000 + s.rnd.Float64()*140000)
+	start := s.monthStart(s.months)
+
+	for month := start; month.Before(s.now); month = month.AddDate(0, 1, 0) {
+		if err := s.deposit(ctx, checking, models.CurrencyRUB, salary, "Salary", s.day(month, 5)); err != nil {
+			return err
+		}
+
+		for _, category := range spendingCategories {
+			for n := 0; n < category.perMonth; n++ {
+				amount := roundAmount(category.min + s.rnd.Float64()*(category.max-category.min))
+				merchant := category.merchants[s.rnd.Intn(len(category.merchants))]
+				description := fmt.Sprintf("%s: %s", category.name, merchant)
+				if err := s.debit(ctx, models.TransactionTypePayment, checking, models.CurrencyRUB, amount, description, s.day(month, 6+s.rnd.Intn(23))); err != nil {
+					return err
+				}
+			}
+		}
+
+		for n := s.rnd.Intn(3); n > 0; n-- {
+			amount := float64(1000 * (1 + s.rnd.Intn(10)))
+			if err := s.debit(ctx, models.TransactionTypeWithdrawal, checking, models.CurrencyRUB, amount, "ATM cash withdrawal", s.day(month, 1+s.rnd.Intn(28))); err != nil {
+				return err
+			}
+		}
+
+		if err := s.transfer(ctx, checking, savings, roundAmount(salary*0.1), "Monthly savings", s.day(month, 6)); err != nil {
+			return err
+		}
+
+		interest := roundAmount(s.balances[savings] * 0.08 / 12)
+		if interest > 0 {
+			if err := s.record(ctx, &models.Transaction{
+				TransactionType:      models.TransactionTypeInterest,
+				DestinationAccountID: &savings,
+				Amount:               interest,
+				Currency:             models.CurrencyRUB,
+				Description:          "Interest on savings",
+				Status:               models.TransactionStatusCompleted,
+				TransactionDate:      s.day(month, 28),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seedForeignHistory generates an initial deposit and occasional payments abroad
+func (s *seeder) seedForeignHistory(ctx context.Context, account int, currency models.Currency) error {
+	start := s.monthStart(s.months)
+	if err := s.deposit(ctx, account, currency, roundAmount(500+s.rnd.Float64()*2500), "Currency purchase", s.day(start, 2)); err != nil {
+		return err
+	}
+
+	for month := start; month.Before(s.now); month = month.AddDate(0, 1, 0) {
+		for n := s.rnd.Intn(4); n > 0; n-- {
+			amount := roundAmount(5 + s.rnd.Float64()*120)
+			if err := s.debit(ctx, models.TransactionTypePayment, account, currency, amount, "Shopping: online store", s.day(month, 3+s.rnd.Intn(25))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seedCredit issues a credit at the start of the history. Installments due so far are paid from
+// the credit account; an overdue credit instead had its funds moved to the checking account and
+// misses its last two installments, which are marked overdue with a penalty.
+func (s *seeder) seedCredit(ctx context.Context, userID, checking int, overdue bool) error {
+	creditAccount, err := s.account(ctx, userID, models.CurrencyRUB, models.AccountTypeCredit)
+	if err != nil {
+		return err
+	}
+
+	request := &models.CreditRequest{
+		UserID:       userID,
+		Amount:       float64(50000 * (2 + s.rnd.Intn(9))),
+		TermMonths:   []int{12, 24, 36}[s.rnd.Intn(3)],
+		InterestRate: 16 + float64(s.rnd.Intn(9)),
+	}
+
+	credit := request.ToCredit(creditAccount, 0)
+	credit.StartDate = s.day(s.monthStart(s.months), 10)
+	credit.EndDate = credit.StartDate.AddDate(0, credit.TermMonths, 0)
+
+	creditID, err := s.repos.Credit.Create(ctx, credit)
+	if err != nil {
+		return err
+	}
+	credit.ID = creditID
+
+	if err := s.deposit(ctx, creditAccount, models.CurrencyRUB, credit.Amount, fmt.Sprintf("Credit #%d issued", creditID), credit.StartDate); err != nil {
+		return err
+	}
+
+	schedule := models.GeneratePaymentSchedule(credit)
+
+	var due []*models.PaymentSchedule
+	for _, payment := range schedule {
+		if payment.PaymentDate.Before(s.now) {
+			due = append(due, payment)
+		}
+	}
+
+	missed := 0
+	if overdue {
+		missed = int(math.Min(2, float64(len(due))))
+		if err := s.transfer(ctx, creditAccount, checking, credit.Amount, "Credit funds", credit.StartDate.Add(time.Hour)); err != nil {
+			return err
+		}
+	}
+
+	for n, payment := range due {
+		if n >= len(due)-missed {
+			models.UpdateScheduleStatus(payment, models.BusinessDate(payment.PaymentDate, s.now.Location()), s.penaltyRate)
+			continue
+		}
+
+		if overdue {
+			// Installments paid on time were topped up from the checking account
+			if err := s.transfer(ctx, checking, creditAccount, payment.TotalAmount, "Credit repayment", payment.PaymentDate.Add(-time.Hour)); err != nil {
+				return err
+			}
+		}
+
+		description := fmt.Sprintf("Credit #%d payment #%d", creditID, n+1)
+		if err := s.debit(ctx, models.TransactionTypePayment, creditAccount, models.CurrencyRUB, payment.TotalAmount, description, payment.PaymentDate); err != nil {
+			return err
+		}
+		payment.Status = models.PaymentStatusPaid
+	}
+
+	if err := s.repos.PaymentSchedule.CreateBatch(ctx, schedule); err != nil {
+		return err
+	}
+
+	if missed > 0 {
+		credit.Status = models.CreditStatusOverdue
+		return s.repos.Credit.Update(ctx, credit)
+	}
+
+	return nil
+}
+
+// account opens an empty account; its balance is set once all transactions are generated
+func (s *seeder) account(ctx context.Context, userID int, currency models.Currency, accountType models.AccountType) (int, error) {
+	return s.repos.Account.Create(ctx, &models.Account{
+		UserID:        userID,
+		Currency:      currency,
+		AccountType:   accountType,
+		IsActive:      true,
+	})
+}
+
+// deposit records money coming into an account
+func (s *seeder) deposit(ctx context.Context, account int, currency models.Currency, amount float64, description string, date time.Time) error {
+	return s.record(ctx, &models.Transaction{
+		TransactionType:      models.TransactionTypeDeposit,
+		DestinationAccountID: &account,
+		Amount:               amount,
+		Currency:             currency,
+		Description:          description,
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      date,
+	})
+}
+
+// debit records money leaving an account, unless the account cannot cover it
+func (s *seeder) debit(ctx context.Context, transactionType models.TransactionType, account int, currency models.Currency, amount float64, description string, date time.Time) error {
+	return s.record(ctx, &models.Transaction{
+		TransactionType: transactionType,
+		SourceAccountID: &account,
+		Amount:          amount,
+		Currency:        currency,
+		Description:     description,
+		Status:          models.TransactionStatusCompleted,
+		TransactionDate: date,
+	})
+}
+
+// transfer records a transfer between two RUB accounts, unless the source cannot cover it
+func (s *seeder) transfer(ctx context.Context, from, to int, amount float64, description string, date time.Time) error {
+	return s.record(ctx, &models.Transaction{
+		TransactionType:      models.TransactionTypeTransfer,
+		SourceAccountID:      &from,
+		DestinationAccountID: &to,
+		Amount:               amount,
+		Currency:             models.CurrencyRUB,
+		Description:          description,
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      date,
+	})
+}
+
+// record queues a transaction dated in the past for flush and applies it to the tracked
+// balances. Debits the source account cannot cover are skipped, as the bank would have declined them.
+func (s *seeder) record(ctx context.Context, transaction *models.Transaction) error {
+	if transaction.TransactionDate.After(s.now) {
+		return nil
+	}
+
+	if transaction.SourceAccountID != nil && s.balances[*transaction.SourceAccountID] < transaction.Amount {
+		return nil
+	}
+
+	s.pending = append(s.pending, transaction)
+
+	if transaction.SourceAccountID != nil {
+		s.balances[*transaction.SourceAccountID] -= transaction.Amount
+	}
+	if transaction.DestinationAccountID != nil {
+		s.balances[*transaction.DestinationAccountID] += transaction.Amount
+	}
+
+	return nil
+}
+
+// flush stores the queued transactions with one batch insert
+func (s *seeder) flush(ctx context.Context) error {
+	if err := s.repos.Transaction.CreateBatch(ctx, s.pending); err != nil {
+		return err
+	}
+
+	s.transactions += len(s.pending)
+	s.pending = nil
+	return nil
+}
+
+// applyBalances sets the account balances to the sums of their transactions
+func (s *seeder) applyBalances(ctx context.Context) error {
+	for account, balance := range s.balances {
+		if balance = roundAmount(balance); balance > 0 {
+			if err := s.repos.Account.UpdateBalance(ctx, account, balance); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// monthStart returns the first day of the month the given number of months ago
+func (s *seeder) monthStart(monthsAgo int) time.Time {
+	return time.Date(s.now.Year(), s.now.Month(), 1, 0, 0, 0, 0, s.now.Location()).AddDate(0, -monthsAgo, 0)
+}
+
+// day returns a random time of the given day of a month
+func (s *seeder) day(month time.Time, day int) time.Time {
+	return month.AddDate(0, 0, day-1).Add(time.Duration(8*60+s.rnd.Intn(14*60)) * time.Minute)
+}
+
+// roundAmount rounds an amount to kopecks
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
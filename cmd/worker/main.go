@@ -0,0 +1,334 @@
+// Command worker runs the periodic background jobs of the banking service: credit payments,
+// merchant settlement, statements, tax documents and the rest. It shares the configuration and the
+// service layer with the API but runs as its own process, so background processing scales
+// independently of request handling.
+//
+// worker.jobs (WORKER_JOBS) selects the jobs an instance runs; all of them by default. The jobs
+// expect a single runner, so when several workers are deployed each job should be assigned to one
+// of them:
+//
+//	WORKER_JOBS=payment-scheduler,account-statements go run ./cmd/worker
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/database"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/internal/service"
+	"banking-service/pkg/cbr"
+	"banking-service/pkg/httpclient"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/search"
+	"banking-service/pkg/storage"
+	"banking-service/pkg/upload"
+)
+
+// job is a periodic background task
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+	disabled string // why the configuration turns the job off, empty if it is on
+}
+
+// jobs lists all background jobs in the order they are started
+func jobs(cfg *configs.Config, services *service.Service) []job {
+	all := []job{
+		{name: "payment-scheduler", interval: time.Hour * 24, run: services.Credit.ProcessPayments},              // Check payments once per day
+		{name: "payment-reminders", interval: time.Hour, run: services.Credit.SendReminders},                     // Remind of payments due in 3 days and in 1 day
+		{name: "merchant-settlement", interval: time.Hour * 24, run: services.Merchant.SettlePayments},           // Pay out merchants once per day
+		{name: "chargeback-deadlines", interval: time.Hour, run: services.Chargeback.ExpireEvidenceDeadlines},    // Close chargebacks merchants did not contest
+		{name: "escrow-timeouts", interval: time.Hour, run: services.Escrow.RefundExpired},                       // Refund escrow deals not settled in time
+		{name: "international-transfers", interval: time.Hour, run: services.InternationalTransfer.Advance},      // Dispatch and settle international transfers when due
+		{name: "overdue-invoices", interval: time.Hour, run: services.Invoice.MarkOverdue},                       // Flag invoices unpaid after their due date
+		{name: "subscription-billing", interval: time.Hour, run: services.Subscription.BillDue},                  // Invoice and charge subscriptions, retry failed charges
+		{name: "referral-rewards", interval: time.Hour, run: services.Referral.ProcessReferrals},                 // Expire referrals and retry bonus payouts
+		{name: "tax-documents", interval: time.Hour * 24, run: services.TaxDocument.DeliverYearly},               // Email last year's tax documents in January
+		{name: "account-statements", interval: time.Hour * 24, run: services.Statement.IssueMonthly},             // Issue last month's statements and lock the period
+		{name: "rates-history", interval: time.Hour * 6, run: services.Rate.RecordRates},                         // Store the key rate and exchange rates
+		{name: "dormant-accounts", interval: time.Hour * 24, run: services.Account.DetectDormant},                // Flag accounts without activity as dormant
+		{name: "account-plan-billing", interval: time.Hour * 24, run: services.AccountPlan.BillMonthly},          // Pay plan interest and assess last month's plan fees
+		{name: "recurring-fees", interval: time.Hour * 24, run: services.Fee.ChargeRecurring},                    // Charge card service and plan fees, retry those in dunning
+		{name: "accounting-export", interval: time.Hour * 24, run: services.Accounting.DropDaily},                // Upload yesterday's accounting export
+		{name: "currency-check", interval: time.Hour * 24, run: services.Account.CheckCurrencies},                // Correct transactions recorded in another currency than their account
+		{name: "credit-portfolio-export", interval: time.Hour * 24, run: services.Reporting.DropCreditPortfolio}, // Store today's credit portfolio for the risk models
+		{name: "search-index", interval: time.Minute, run: services.Search.IndexPending},                         // Index the transactions created or changed since the last run
+		{name: "warehouse-export", interval: time.Hour * 24, run: services.Warehouse.Export},                     // Export the rows changed since the last run as Parquet for BI
+		{name: "announcements", interval: time.Minute, run: services.Announcement.Deliver},                       // Deliver queued announcements at the throttled rate
+	}
+
+	for i := range all {
+		switch {
+		case all[i].name == "dormant-accounts" && cfg.Dormancy.Months == 0:
+			all[i].disabled = "dormancy.months is 0"
+		case all[i].name == "accounting-export" && !cfg.AccountingExport.Enabled:
+			all[i].disabled = "accounting_export.enabled is false"
+		case all[i].name == "search-index" && !cfg.Search.Enabled:
+			all[i].disabled = "search.enabled is false"
+		case all[i].name == "warehouse-export" && !cfg.WarehouseExport.Enabled:
+			all[i].disabled = "warehouse_export.enabled is false"
+		}
+	}
+
+	return all
+}
+
+// selectJobs returns the jobs named in the configuration, or all of them if none are named
+func selectJobs(all []job, names []string) ([]job, error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+
+	byName := make(map[string]job, len(all))
+	for _, j := range all {
+		byName[j.name] = j
+	}
+
+	selected := make([]job, 0, len(names))
+	for _, name := range names {
+		j, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown job %q", name)
+		}
+		selected = append(selected, j)
+	}
+
+	return selected, nil
+}
+
+func main() {
+	// Initialize logger
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+	log.SetOutput(os.Stdout)
+	log.SetLevel(logrus.InfoLevel)
+
+	// Load configuration
+	cfg, err := configs.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	setLogLevel(log, cfg.Log.Level)
+
+	// Count business days in the bank's time zone
+	location, err := cfg.Bank.Location()
+	if err != nil {
+		log.Fatalf("Failed to load time zone: %v", err)
+	}
+
+	// Reloadable settings are read through live and updated on SIGHUP
+	live := configs.NewLive(cfg)
+	live.OnReload(func(c *configs.Config) {
+		setLogLevel(log, c.Log.Level)
+	})
+
+	// Connect to database
+	db, err := database.Open(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	// Track the job loops and notification sends so shutdown can wait for them
+	manager := lifecycle.NewManager(log)
+
+	// Client of the calls to external services, retrying them and stopping the calls to failing hosts
+	outbound := httpclient.New(outboundHTTPOptions(cfg.OutboundHTTP))
+
+	// Daily accounting export drop to S3 or SFTP
+	var accountingUploader upload.Uploader
+	if cfg.AccountingExport.Enabled {
+		accountingUploader, err = upload.NewUploader(cfg.AccountingExport.Target, accountingUploadOptions(cfg.AccountingExport, outbound))
+		if err != nil {
+			log.Fatalf("Failed to initialize accounting export upload: %v", err)
+		}
+	}
+
+	// Object storage the credit portfolio and warehouse exports are written to, shared with the API's documents
+	objectStorage, err := storage.NewStorage(cfg.Storage.Backend, storageOptions(cfg.Storage, outbound))
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Index of transactions for full-text search, kept up to date by the search-index job
+	var searchIndex search.Index
+	if cfg.Search.Enabled {
+		searchIndex, err = search.NewIndex(cfg.Search.Provider, searchIndexOptions(cfg.Search, outbound))
+		if err != nil {
+			log.Fatalf("Failed to initialize search index: %v", err)
+		}
+	}
+
+	// Key rate sources, tried in the configured order
+	keyRates, err := cbr.NewKeyRateProvider(cfg.CBR.KeyRateProviders, cbr.Options{
+		SOAPURL: cfg.CBR.APIURL,
+		JSONURL: cfg.CBR.KeyRateJSONURL,
+		Timeout: time.Duration(cfg.CBR.Timeout) * time.Second,
+		Client:  outbound,
+	})
+	if err != nil {
+		log.Fatalf("Failed to initialize key rate provider: %v", err)
+	}
+
+	// Initialize services; virus scanning is only used by API requests
+	services := service.NewService(service.Dependencies{
+		Repos:     repository.NewRepository(db, models.AccountNumberScheme{BIC: cfg.Bank.BIC, Branch: cfg.Bank.Branch}, location),
+		Logger:    log,
+		Config:    cfg,
+		Live:      live,
+		Lifecycle: manager,
+		Uploader:  accountingUploader,
+		Storage:   objectStorage,
+		KeyRates:  keyRates,
+		Search:    searchIndex,
+		HTTP:      outbound,
+		Bank:      location,
+	})
+
+	// Start the selected jobs
+	selected, err := selectJobs(jobs(cfg, services), cfg.Worker.Jobs)
+	if err != nil {
+		log.Fatalf("Invalid worker.jobs: %v", err)
+	}
+
+	started := 0
+	for _, j := range selected {
+		if j.disabled != "" {
+			log.Infof("Job %s is disabled: %s", j.name, j.disabled)
+			continue
+		}
+		manager.Every(j.name, j.interval, j.run)
+		started++
+	}
+	if started == 0 {
+		log.Fatal("No jobs to run")
+	}
+	log.Infof("Worker started with %d jobs", started)
+
+	// Reload configuration on SIGHUP
+	manager.Go("config-reload", func(ctx context.Context) error {
+		return watchReload(ctx, live, log)
+	})
+
+	// Wait for interrupt signal (or a failed background loop) to shut down gracefully
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case <-quit:
+	case <-manager.Done():
+		log.Error("Background loop stopped unexpectedly")
+	}
+	log.Info("Shutting down worker...")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	// Wait for the running jobs and in-flight notifications to finish
+	if err := manager.Shutdown(ctx); err != nil {
+		log.Errorf("Background tasks did not stop cleanly: %v", err)
+	}
+
+	log.Info("Worker stopped")
+}
+
+// watchReload reloads the live configuration every time the process receives SIGHUP
+func watchReload(ctx context.Context, live *configs.Live, log *logrus.Logger) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			ignored, err := live.Reload()
+			if err != nil {
+				log.Errorf("Failed to reload configuration, keeping current settings: %v", err)
+				continue
+			}
+			if len(ignored) > 0 {
+				log.Warnf("Configuration sections changed but require a restart: %v", ignored)
+			}
+			log.Info("Configuration reloaded")
+		}
+	}
+}
+
+// accountingUploadOptions maps the accounting export settings to upload options
+func accountingUploadOptions(c configs.AccountingExportConfig, client httpclient.Doer) upload.Options {
+	return upload.Options{
+		Endpoint:       c.S3.Endpoint,
+		Region:         c.S3.Region,
+		Bucket:         c.S3.Bucket,
+		AccessKey:      c.S3.AccessKey,
+		SecretKey:      c.S3.SecretKey,
+		Host:           c.SFTP.Host,
+		User:           c.SFTP.User,
+		Password:       c.SFTP.Password,
+		PrivateKeyFile: c.SFTP.PrivateKeyFile,
+		HostKey:        c.SFTP.HostKey,
+		Prefix:         c.Prefix,
+		Timeout:        time.Duration(c.Timeout) * time.Second,
+		Client:         client,
+	}
+}
+
+// searchIndexOptions maps the search settings to search index options
+func searchIndexOptions(c configs.SearchConfig, client httpclient.Doer) search.Options {
+	return search.Options{
+		URL:      c.URL,
+		Index:    c.Index,
+		Username: c.Username,
+		Password: c.Password,
+		Timeout:  time.Duration(c.Timeout) * time.Second,
+		Client:   client,
+	}
+}
+
+// storageOptions maps the storage settings to storage options
+func storageOptions(c configs.StorageConfig, client httpclient.Doer) storage.Options {
+	return storage.Options{
+		Dir:       c.Dir,
+		Endpoint:  c.S3.Endpoint,
+		Region:    c.S3.Region,
+		Bucket:    c.S3.Bucket,
+		AccessKey: c.S3.AccessKey,
+		SecretKey: c.S3.SecretKey,
+		Prefix:    c.Prefix,
+		Timeout:   time.Duration(c.Timeout) * time.Second,
+		Client:    client,
+	}
+}
+
+// outboundHTTPOptions maps the outbound HTTP settings to client options
+func outboundHTTPOptions(c configs.OutboundHTTPConfig) httpclient.Options {
+	return httpclient.Options{
+		Timeout:          time.Duration(c.Timeout) * time.Second,
+		MaxAttempts:      c.MaxAttempts,
+		BaseDelay:        time.Duration(c.BaseDelayMs) * time.Millisecond,
+		MaxDelay:         time.Duration(c.MaxDelayMs) * time.Millisecond,
+		BreakerThreshold: c.BreakerThreshold,
+		BreakerCooldown:  time.Duration(c.BreakerCooldown) * time.Second,
+	}
+}
+
+// setLogLevel applies a configured log level to the logger
+func setLogLevel(log *logrus.Logger, level string) {
+	parsed, err := logrus.ParseLevel(level)
+	if err != nil {
+		log.Warnf("Unknown log level %q, keeping %s", level, log.GetLevel())
+		return
+	}
+	log.SetLevel(parsed)
+}
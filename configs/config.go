@@ -1,121 +1,1387 @@
 package configs
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // time zones load on hosts without a zoneinfo database
+
+	"gopkg.in/yaml.v3"
+
+	"banking-service/pkg/i18n"
 )
 
+// defaultConfigFile is used when CONFIG_FILE is not set and the file exists
+const defaultConfigFile = "configs/config.yaml"
+
+// redactedValue replaces secrets when the configuration is printed
+const redactedValue = "******"
+
+// insecureJWTSecrets are well-known placeholder secrets that must never be used
+var insecureJWTSecrets = map[string]bool{
+	"super_secret_key": true,
+	"secret":           true,
+	"changeme":         true,
+}
+
 // Config represents the application configuration
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Email    EmailConfig
-	PGP      PGPConfig
-	CBR      CBRConfig
+	Server       ServerConfig       `yaml:"server"`
+	Bank         BankConfig         `yaml:"bank"`
+	Database     DatabaseConfig     `yaml:"database"`
+	JWT          JWTConfig          `yaml:"jwt"`
+	Email        EmailConfig        `yaml:"email"`
+	PGP          PGPConfig          `yaml:"pgp"`
+	CBR          CBRConfig          `yaml:"cbr"`
+	OutboundHTTP OutboundHTTPConfig `yaml:"outbound_http"`
+	Log          LogConfig          `yaml:"log"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit"`
+	Credit       CreditConfig       `yaml:"credit"`
+	Maintenance  MaintenanceConfig  `yaml:"maintenance"`
+	Admin        AdminConfig        `yaml:"admin"`
+	Transfer     TransferConfig     `yaml:"transfer"`
+	Password     PasswordConfig     `yaml:"password"`
+	Captcha      CaptchaConfig      `yaml:"captcha"`
+	Merchant     MerchantConfig     `yaml:"merchant"`
+	Chargeback   ChargebackConfig   `yaml:"chargeback"`
+	Escrow       EscrowConfig       `yaml:"escrow"`
+	Subscription SubscriptionConfig `yaml:"subscription"`
+	Cheque       ChequeConfig       `yaml:"cheque"`
+	Referral     ReferralConfig     `yaml:"referral"`
+	Onboarding   OnboardingConfig   `yaml:"onboarding"`
+	Dormancy     DormancyConfig     `yaml:"dormancy"`
+	Reporting    ReportingConfig    `yaml:"reporting"`
+	Worker       WorkerConfig       `yaml:"worker"`
+
+	AccountingExport AccountingExportConfig `yaml:"accounting_export"`
+	WarehouseExport  WarehouseExportConfig  `yaml:"warehouse_export"`
+	Storage          StorageConfig          `yaml:"storage"`
+	Antivirus        AntivirusConfig        `yaml:"antivirus"`
+	Search           SearchConfig           `yaml:"search"`
+	Notification     NotificationConfig     `yaml:"notification"`
+	International    InternationalConfig    `yaml:"international"`
+	Tenants          []TenantConfig         `yaml:"tenants"` // brands served besides the default one
+}
+
+// BankConfig holds the settings of the bank as a business
+type BankConfig struct {
+	Timezone string `yaml:"timezone"` // IANA zone business days are counted in, e.g. Europe/Moscow
+	Language string `yaml:"language"` // language of users without a preference and clients without Accept-Language
+
+	// Bank details printed in payment QR codes of invoices; an empty BIC leaves the QR codes out
+	Name                 string `yaml:"name"`
+	BIC                  string `yaml:"bic"`                   // 9 digits
+	CorrespondentAccount string `yaml:"correspondent_account"` // 20 digits, at the Bank of Russia
+
+	// Branch code in new account numbers, whose control digit is keyed by the BIC
+	Branch string `yaml:"branch"` // 4 digits, 0000 for the head office
+}
+
+// DefaultLanguage returns the language responses, notifications and emails fall back to
+func (c BankConfig) DefaultLanguage() i18n.Language {
+	if lang, ok := i18n.Parse(c.Language); ok {
+		return lang
+	}
+	return i18n.English
+}
+
+// Location returns the business time zone. Due dates, statements and reports are given it so that
+// they start their days at the bank's midnight whatever the server's zone is.
+func (c BankConfig) Location() (*time.Location, error) {
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bank.timezone %q: %w", c.Timezone, err)
+	}
+
+	return location, nil
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Port int
+	Port         int       `yaml:"port"`
+	PublicURL    string    `yaml:"public_url"`    // base URL used in links sent to users
+	ReadTimeout  int       `yaml:"read_timeout"`  // in seconds
+	WriteTimeout int       `yaml:"write_timeout"` // in seconds
+	IdleTimeout  int       `yaml:"idle_timeout"`  // in seconds
+	LongRunning  Timeouts  `yaml:"long_running"`  // overrides for slow endpoints such as exports
+	TLS          TLSConfig `yaml:"tls"`
+}
+
+// Timeouts holds per-route read and write timeouts in seconds
+type Timeouts struct {
+	ReadTimeout  int `yaml:"read_timeout"`
+	WriteTimeout int `yaml:"write_timeout"`
+}
+
+// TLSConfig holds HTTPS configuration. TLS is enabled either with a certificate
+// and key pair or with automatic certificates from Let's Encrypt (autocert).
+type TLSConfig struct {
+	CertFile         string   `yaml:"cert_file"`
+	KeyFile          string   `yaml:"key_file"`
+	AutocertDomains  []string `yaml:"autocert_domains"`
+	AutocertCacheDir string   `yaml:"autocert_cache_dir"`
+	RedirectPort     int      `yaml:"redirect_port"` // plain HTTP port redirecting to HTTPS, 0 disables
+}
+
+// Enabled reports whether the server should serve HTTPS
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.UsesAutocert()
+}
+
+// UsesAutocert reports whether certificates are obtained automatically
+func (t TLSConfig) UsesAutocert() bool {
+	return len(t.AutocertDomains) > 0
 }
 
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	DBName   string
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"db_name"`
 }
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret string
-	TTL    int // in hours
+	Secret         string `yaml:"secret"`
+	TTL            int    `yaml:"ttl"`              // in hours
+	Algorithm      string `yaml:"algorithm"`        // HS256 signs with the secret, RS256 and EdDSA with private_key_file
+	PrivateKeyFile string `yaml:"private_key_file"` // PEM RSA or Ed25519 private key of RS256 and EdDSA
+
+	// RFC 3339 time until which RS256 and EdDSA still accept HS256 tokens issued before the switch,
+	// at most ttl hours ahead; empty rejects them
+	AcceptLegacyHMACUntil string `yaml:"accept_legacy_hmac_until"`
+}
+
+// LegacyHMACDeadline returns the time until which HS256 tokens are accepted with RS256 and EdDSA,
+// zero if they are not
+func (c JWTConfig) LegacyHMACDeadline() time.Time {
+	deadline, err := time.Parse(time.RFC3339, c.AcceptLegacyHMACUntil)
+	if err != nil {
+		return time.Time{}
+	}
+	return deadline
 }
 
 // EmailConfig holds email configuration
 type EmailConfig struct {
-	SMTPHost     string
-	SMTPPort     int
-	SMTPUser     string
-	SMTPPassword string
-	SenderEmail  string
+	SMTPHost     string `yaml:"smtp_host"`
+	SMTPPort     int    `yaml:"smtp_port"`
+	SMTPUser     string `yaml:"smtp_user"`
+	SMTPPassword string `yaml:"smtp_password"`
+	SenderEmail  string `yaml:"sender_email"`
+
+	// Secret the email provider signs its delivery webhooks with; empty disables the webhook
+	WebhookSecret string `yaml:"webhook_secret"`
 }
 
 // PGPConfig holds PGP encryption configuration
 type PGPConfig struct {
-	PublicKey  string
-	PrivateKey string
-	Passphrase string
+	PublicKey  string `yaml:"public_key"`
+	PrivateKey string `yaml:"private_key"`
+	Passphrase string `yaml:"passphrase"`
 }
 
 // CBRConfig holds Central Bank RF API configuration
 type CBRConfig struct {
-	APIURL string
+	APIURL           string   `yaml:"api_url"`
+	KeyRateProviders []string `yaml:"key_rate_providers"` // soap, json; tried in order until one returns the rate
+	KeyRateJSONURL   string   `yaml:"key_rate_json_url"`  // endpoint of the json provider, responding with {"key_rate": 16.0}
+	Timeout          int      `yaml:"timeout"`            // in seconds, per provider request
 }
 
-// LoadConfig loads configuration from environment variables
-func LoadConfig() (*Config, error) {
-	port, err := strconv.Atoi(getEnv("SERVER_PORT", "8080"))
-	if err != nil {
-		return nil, err
+// OutboundHTTPConfig holds the retries and circuit breakers of the client shared by the calls to
+// external services (CBR, webhooks, SMS)
+type OutboundHTTPConfig struct {
+	Timeout          int `yaml:"timeout"`           // in seconds, per attempt
+	MaxAttempts      int `yaml:"max_attempts"`      // including the first one
+	BaseDelayMs      int `yaml:"base_delay_ms"`     // backoff before the first retry, doubled on every next one
+	MaxDelayMs       int `yaml:"max_delay_ms"`      // cap of the backoff
+	BreakerThreshold int `yaml:"breaker_threshold"` // consecutive failures that stop the calls to a host
+	BreakerCooldown  int `yaml:"breaker_cooldown"`  // in seconds before a trial call to a stopped host
+}
+
+// LogConfig holds logging configuration (reloadable)
+type LogConfig struct {
+	Level string `yaml:"level"`
+}
+
+// RateLimitConfig holds per-client request rate limits (reloadable)
+type RateLimitConfig struct {
+	RequestsPerMinute int `yaml:"requests_per_minute"` // 0 disables rate limiting
+	Burst             int `yaml:"burst"`
+
+	// Stricter limits for GET /register/availability, which tells whether an account exists
+	AvailabilityRequestsPerMinute int `yaml:"availability_requests_per_minute"` // 0 disables
+	AvailabilityBurst             int `yaml:"availability_burst"`
+}
+
+// CreditConfig holds credit processing settings (reloadable)
+type CreditConfig struct {
+	PenaltyRate             float64        `yaml:"penalty_rate"`               // share of the overdue payment charged as penalty
+	DocumentThreshold       float64        `yaml:"document_threshold"`         // credits of at least this amount need reviewed documents, 0 disables
+	SignatureOTPTTL         int            `yaml:"signature_otp_ttl"`          // in seconds, how long an agreement signing code is valid
+	SignatureOTPMaxAttempts int            `yaml:"signature_otp_max_attempts"` // wrong codes allowed before the signing request is cancelled
+	InsuranceRate           float64        `yaml:"insurance_rate"`             // monthly insurance premium as a share of the credit amount, 0 disables insurance
+	PaymentHoldDays         int            `yaml:"payment_hold_days"`          // how many days before the due date a scheduled payment is held, 0 disables holds
+	DayCount                string         `yaml:"day_count"`                  // day-count convention of the interest of new credits: ACT/365, ACT/360 or 30/360
+	Calendar                CalendarConfig `yaml:"calendar"`
+}
+
+// CalendarConfig holds the business-day calendar of credit payment dates. Russian public holidays
+// are built in; the days off moved by the yearly government decree are listed here.
+type CalendarConfig struct {
+	Roll     string   `yaml:"roll"`     // none, following or modified_following
+	Holidays []string `yaml:"holidays"` // extra days off, YYYY-MM-DD
+	Workdays []string `yaml:"workdays"` // weekend days that are working days, YYYY-MM-DD
+}
+
+// PasswordConfig holds the Argon2id cost parameters for password hashing.
+// Existing hashes with other parameters are rehashed on the next successful login.
+type PasswordConfig struct {
+	Memory      int `yaml:"memory"` // in KiB
+	Iterations  int `yaml:"iterations"`
+	Parallelism int `yaml:"parallelism"`
+}
+
+// CaptchaConfig holds the CAPTCHA settings for registration and login
+type CaptchaConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Provider      string `yaml:"provider"` // recaptcha or hcaptcha
+	SiteKey       string `yaml:"site_key"`
+	SecretKey     string `yaml:"secret_key"`
+	LoginFailures int    `yaml:"login_failures"` // failed logins before a CAPTCHA is required
+	FailureWindow int    `yaml:"failure_window"` // in seconds
+	Timeout       int    `yaml:"timeout"`        // in seconds
+}
+
+// TransferConfig holds the one-time code confirmation settings for high-value transfers
+type TransferConfig struct {
+	OTPThreshold   float64 `yaml:"otp_threshold"`    // transfers of at least this amount need a code, 0 disables
+	OTPTTL         int     `yaml:"otp_ttl"`          // in seconds
+	OTPMaxAttempts int     `yaml:"otp_max_attempts"` // wrong codes allowed before the transfer is cancelled
+	ApprovalTTL    int     `yaml:"approval_ttl"`     // in seconds, how long organization transfers wait for approvals
+}
+
+// MerchantConfig holds merchant acquiring settings
+type MerchantConfig struct {
+	IntentTTL int `yaml:"intent_ttl"` // in seconds, how long a customer can pay a payment intent
+}
+
+// ChargebackConfig holds card chargeback settings
+type ChargebackConfig struct {
+	WindowDays   int `yaml:"window_days"`   // how long after a card payment the cardholder can charge it back
+	EvidenceDays int `yaml:"evidence_days"` // how long the merchant has to submit evidence
+}
+
+// EscrowConfig holds escrow payment settings
+type EscrowConfig struct {
+	TimeoutDays int `yaml:"timeout_days"` // how long a deal can stay unsettled before the buyer is refunded
+}
+
+// SubscriptionConfig holds recurring billing settings of merchant subscriptions
+type SubscriptionConfig struct {
+	RetryDays   int `yaml:"retry_days"`   // days between attempts to charge a failed subscription payment
+	MaxAttempts int `yaml:"max_attempts"` // failed charges of a period after which the subscription is cancelled
+}
+
+// ChequeConfig holds deposits of cheques and payment orders by image
+type ChequeConfig struct {
+	OCRProvider string  `yaml:"ocr_provider"` // recognizes the amount on the image
+	MaxAmount   float64 `yaml:"max_amount"`   // largest amount that can be deposited by image
+}
+
+// InternationalConfig holds the fees and settlement times of international transfers. Fees are
+// charged in the currency of the transfer.
+type InternationalConfig struct {
+	FeePercent       float64 `yaml:"fee_percent"`       // the bank's fee as a percentage of the amount
+	MinFee           float64 `yaml:"min_fee"`           // the bank's smallest fee
+	CorrespondentFee float64 `yaml:"correspondent_fee"` // flat fee of the correspondent bank
+	SettlementDays   int     `yaml:"settlement_days"`   // business days from dispatch until the beneficiary is credited
+}
+
+// ReferralConfig holds referral program settings
+type ReferralConfig struct {
+	ReferrerBonus float64 `yaml:"referrer_bonus"` // paid to the inviting user, in RUB
+	RefereeBonus  float64 `yaml:"referee_bonus"`  // paid to the invited user, in RUB, 0 disables
+	MinDeposit    float64 `yaml:"min_deposit"`    // smallest deposit that qualifies the referral
+	QualifyDays   int     `yaml:"qualify_days"`   // how long after registration the referee can qualify
+}
+
+// OnboardingConfig holds the onboarding of new customers
+type OnboardingConfig struct {
+	Required bool `yaml:"required"` // accounts and cards need a verified email and a complete profile
+}
+
+// DormancyConfig holds the detection of accounts without activity
+type DormancyConfig struct {
+	Months int `yaml:"months"` // inactivity after which an account becomes dormant, 0 disables detection
+}
+
+// NotificationConfig holds in-app notification settings
+type NotificationConfig struct {
+	DeclinesPerDay         int `yaml:"declines_per_day"`         // declined payment notifications a user gets per day, 0 disables them
+	AnnouncementsPerMinute int `yaml:"announcements_per_minute"` // customers the announcements job reaches per run, once a minute
+}
+
+// WorkerConfig holds the background worker (cmd/worker)
+type WorkerConfig struct {
+	Jobs []string `yaml:"jobs"` // names of the jobs this instance runs, empty runs all of them
+}
+
+// ReportingConfig holds the admin dashboard reports
+type ReportingConfig struct {
+	CacheTTL int `yaml:"cache_ttl"` // in seconds, how long a computed report is served from memory
+	NPLDays  int `yaml:"npl_days"`  // days a payment is overdue before its credit counts as non-performing
+}
+
+// AccountingExportConfig holds the daily drop of accounting exports to S3 or SFTP
+type AccountingExportConfig struct {
+	Enabled bool       `yaml:"enabled"`
+	Target  string     `yaml:"target"`  // s3 or sftp
+	Prefix  string     `yaml:"prefix"`  // S3 key prefix or SFTP directory
+	Timeout int        `yaml:"timeout"` // in seconds
+	S3      S3Config   `yaml:"s3"`
+	SFTP    SFTPConfig `yaml:"sftp"`
+}
+
+// WarehouseExportConfig holds the nightly export of transactions, credits and accounts as Parquet
+// files to the object storage for BI
+type WarehouseExportConfig struct {
+	Enabled      bool   `yaml:"enabled"`
+	Prefix       string `yaml:"prefix"`         // storage key prefix of the files and the manifest
+	RowGroupSize int    `yaml:"row_group_size"` // rows per Parquet row group
+}
+
+// S3Config holds the bucket and credentials of S3 or S3-compatible storage
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint"` // empty uses AWS for the region
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket"`
+	AccessKey string `yaml:"access_key"`
+	SecretKey string `yaml:"secret_key"`
+}
+
+// SFTPConfig holds the server and credentials of an SFTP drop
+type SFTPConfig struct {
+	Host           string `yaml:"host"` // host[:port]
+	User           string `yaml:"user"`
+	Password       string `yaml:"password"`
+	PrivateKeyFile string `yaml:"private_key_file"`
+	HostKey        string `yaml:"host_key"` // expected server key in authorized_keys format
+}
+
+// StorageConfig holds the object storage for uploaded documents and the credit portfolio and
+// warehouse exports
+type StorageConfig struct {
+	Backend string   `yaml:"backend"` // local or s3
+	Dir     string   `yaml:"dir"`     // base directory of the local backend
+	Prefix  string   `yaml:"prefix"`  // prepended to object keys
+	Timeout int      `yaml:"timeout"` // in seconds
+	S3      S3Config `yaml:"s3"`
+}
+
+// AntivirusConfig holds the malware scanner that checks uploaded documents
+type AntivirusConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Provider string `yaml:"provider"` // clamav
+	Address  string `yaml:"address"`  // clamd host:port or unix socket path
+	Timeout  int    `yaml:"timeout"`  // in seconds
+}
+
+// SearchConfig holds the Elasticsearch or OpenSearch index of transactions. When it is disabled
+// or unavailable, transactions are searched in the database.
+type SearchConfig struct {
+	Enabled  bool   `yaml:"enabled"`
+	Provider string `yaml:"provider"` // elasticsearch or opensearch
+	URL      string `yaml:"url"`
+	Index    string `yaml:"index"` // alias searches go through, rebuilt by bankctl search-reindex
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Timeout  int    `yaml:"timeout"` // in seconds
+}
+
+// MaintenanceConfig holds the maintenance mode state applied at startup.
+// At runtime it is switched through the admin endpoint.
+type MaintenanceConfig struct {
+	Enabled    bool   `yaml:"enabled" json:"enabled"`
+	Message    string `yaml:"message" json:"message"`
+	RetryAfter int    `yaml:"retry_after" json:"retry_after"` // in seconds, 0 omits the Retry-After header
+}
+
+// AdminConfig holds additional protection for the admin routes
+type AdminConfig struct {
+	AllowedIPs   []string `yaml:"allowed_ips"`    // IP addresses or CIDR ranges, empty allows any source
+	ClientCAFile string   `yaml:"client_ca_file"` // CA bundle for admin client certificates, empty disables mTLS
+}
+
+// AllowedNetworks parses AllowedIPs into networks; single addresses become /32 or /128 ranges
+func (a AdminConfig) AllowedNetworks() ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(a.AllowedIPs))
+
+	for _, entry := range a.AllowedIPs {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", entry)
+		}
+		networks = append(networks, network)
 	}
 
-	dbPort, err := strconv.Atoi(getEnv("DB_PORT", "5432"))
-	if err != nil {
+	return networks, nil
+}
+
+// LoadConfig loads configuration from defaults, an optional YAML file and
+// environment variables (in increasing order of precedence) and validates it
+func LoadConfig() (*Config, error) {
+	cfg := defaultConfig()
+
+	if err := loadFile(cfg); err != nil {
 		return nil, err
 	}
 
-	jwtTTL, err := strconv.Atoi(getEnv("JWT_TTL", "24"))
-	if err != nil {
+	if err := applyEnv(cfg); err != nil {
 		return nil, err
 	}
 
-	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "587"))
-	if err != nil {
-		return nil, err
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
+	return cfg, nil
+}
+
+// defaultConfig returns the configuration defaults (secrets have no defaults)
+func defaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: port,
+			Port:         8080,
+			PublicURL:    "http://localhost:8080",
+			ReadTimeout:  15,
+			WriteTimeout: 15,
+			IdleTimeout:  60,
+			LongRunning: Timeouts{
+				ReadTimeout:  30,
+				WriteTimeout: 300,
+			},
+			TLS: TLSConfig{
+				AutocertCacheDir: "certs",
+			},
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     dbPort,
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "banking_service"),
+			Host:     "localhost",
+			Port:     5432,
+			User:     "postgres",
+			Password: "postgres",
+			DBName:   "banking_service",
 		},
 		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", "super_secret_key"),
-			TTL:    jwtTTL,
+			TTL:       24,
+			Algorithm: "HS256",
 		},
 		Email: EmailConfig{
-			SMTPHost:     getEnv("SMTP_HOST", "smtp.example.com"),
-			SMTPPort:     smtpPort,
-			SMTPUser:     getEnv("SMTP_USER", "user"),
-			SMTPPassword: getEnv("SMTP_PASSWORD", "password"),
-			SenderEmail:  getEnv("SENDER_EMAIL", "no-reply@banking-service.com"),
-		},
-		PGP: PGPConfig{
-			PublicKey:  getEnv("PGP_PUBLIC_KEY", ""),
-			PrivateKey: getEnv("PGP_PRIVATE_KEY", ""),
-			Passphrase: getEnv("PGP_PASSPHRASE", ""),
+			SMTPHost:    "smtp.example.com",
+			SMTPPort:    587,
+			SMTPUser:    "user",
+			SenderEmail: "no-reply@banking-service.com",
 		},
 		CBR: CBRConfig{
-			APIURL: getEnv("CBR_API_URL", "https://www.cbr.ru/DailyInfoWebServ/DailyInfo.asmx"),
+			APIURL:           "https://www.cbr.ru/DailyInfoWebServ/DailyInfo.asmx",
+			KeyRateProviders: []string{"soap"},
+			Timeout:          10,
+		},
+		OutboundHTTP: OutboundHTTPConfig{
+			Timeout:          5,
+			MaxAttempts:      3,
+			BaseDelayMs:      200,
+			MaxDelayMs:       2000,
+			BreakerThreshold: 5,
+			BreakerCooldown:  30,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerMinute: 120,
+			Burst:             30,
+
+			AvailabilityRequestsPerMinute: 10,
+			AvailabilityBurst:             5,
+		},
+		Credit: CreditConfig{
+			PenaltyRate:             0.1,
+			DocumentThreshold:       1000000,
+			SignatureOTPTTL:         300,
+			SignatureOTPMaxAttempts: 5,
+			InsuranceRate:           0.002,
+			PaymentHoldDays:         3,
+			DayCount:                "30/360",
+			Calendar: CalendarConfig{
+				Roll: "modified_following",
+			},
+		},
+		Transfer: TransferConfig{
+			OTPThreshold:   100000,
+			OTPTTL:         300,
+			OTPMaxAttempts: 5,
+			ApprovalTTL:    72 * 60 * 60,
+		},
+		Password: PasswordConfig{
+			Memory:      64 * 1024,
+			Iterations:  3,
+			Parallelism: 2,
+		},
+		Merchant: MerchantConfig{
+			IntentTTL: 30 * 60,
+		},
+		Chargeback: ChargebackConfig{
+			WindowDays:   120,
+			EvidenceDays: 10,
+		},
+		Escrow: EscrowConfig{
+			TimeoutDays: 14,
+		},
+		Subscription: SubscriptionConfig{
+			RetryDays:   3,
+			MaxAttempts: 4,
+		},
+		Cheque: ChequeConfig{
+			OCRProvider: "stub",
+			MaxAmount:   1000000,
+		},
+		International: InternationalConfig{
+			FeePercent:       0.5,
+			MinFee:           15,
+			CorrespondentFee: 25,
+			SettlementDays:   2,
+		},
+		Referral: ReferralConfig{
+			ReferrerBonus: 1000,
+			RefereeBonus:  500,
+			MinDeposit:    1000,
+			QualifyDays:   30,
+		},
+		Dormancy: DormancyConfig{
+			Months: 12,
+		},
+		Bank: BankConfig{
+			Timezone: "Europe/Moscow",
+			Language: "en",
+		},
+		Notification: NotificationConfig{
+			DeclinesPerDay:         5,
+			AnnouncementsPerMinute: 100,
+		},
+		Reporting: ReportingConfig{
+			CacheTTL: 300,
+			NPLDays:  90,
+		},
+		Storage: StorageConfig{
+			Backend: "local",
+			Dir:     "data/storage",
+			Timeout: 30,
 		},
-	}, nil
+		Antivirus: AntivirusConfig{
+			Provider: "clamav",
+			Address:  "localhost:3310",
+			Timeout:  30,
+		},
+		Search: SearchConfig{
+			Provider: "elasticsearch",
+			URL:      "http://localhost:9200",
+			Index:    "transactions",
+			Timeout:  5,
+		},
+		AccountingExport: AccountingExportConfig{
+			Target:  "s3",
+			Timeout: 60,
+		},
+		WarehouseExport: WarehouseExportConfig{
+			Prefix:       "warehouse",
+			RowGroupSize: 50000,
+		},
+		Captcha: CaptchaConfig{
+			Provider:      "recaptcha",
+			LoginFailures: 3,
+			FailureWindow: 900,
+			Timeout:       5,
+		},
+		Maintenance: MaintenanceConfig{
+			Message: "The service is temporarily unavailable due to maintenance",
+		},
+	}
 }
 
-// getEnv gets an environment variable or returns a default value
-func getEnv(key, defaultValue string) string {
+// loadFile reads the YAML file from CONFIG_FILE (or the default path if present)
+func loadFile(cfg *Config) error {
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		if _, err := os.Stat(defaultConfigFile); err != nil {
+			return nil
+		}
+		path = defaultConfigFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// applyEnv overrides configuration values with environment variables that are set
+func applyEnv(cfg *Config) error {
+	ints := map[string]*int{
+		"SERVER_PORT": &cfg.Server.Port,
+
+		"SERVER_READ_TIMEOUT":       &cfg.Server.ReadTimeout,
+		"SERVER_WRITE_TIMEOUT":      &cfg.Server.WriteTimeout,
+		"SERVER_IDLE_TIMEOUT":       &cfg.Server.IdleTimeout,
+		"SERVER_LONG_READ_TIMEOUT":  &cfg.Server.LongRunning.ReadTimeout,
+		"SERVER_LONG_WRITE_TIMEOUT": &cfg.Server.LongRunning.WriteTimeout,
+		"TLS_REDIRECT_PORT":         &cfg.Server.TLS.RedirectPort,
+
+		"MAINTENANCE_RETRY_AFTER":           &cfg.Maintenance.RetryAfter,
+		"TRANSFER_OTP_TTL":                  &cfg.Transfer.OTPTTL,
+		"TRANSFER_OTP_MAX_ATTEMPTS":         &cfg.Transfer.OTPMaxAttempts,
+		"TRANSFER_APPROVAL_TTL":             &cfg.Transfer.ApprovalTTL,
+		"CREDIT_SIGNATURE_OTP_TTL":          &cfg.Credit.SignatureOTPTTL,
+		"CREDIT_SIGNATURE_OTP_MAX_ATTEMPTS": &cfg.Credit.SignatureOTPMaxAttempts,
+		"CREDIT_PAYMENT_HOLD_DAYS":          &cfg.Credit.PaymentHoldDays,
+		"MERCHANT_INTENT_TTL":               &cfg.Merchant.IntentTTL,
+		"CHARGEBACK_WINDOW_DAYS":            &cfg.Chargeback.WindowDays,
+		"CHARGEBACK_EVIDENCE_DAYS":          &cfg.Chargeback.EvidenceDays,
+		"ESCROW_TIMEOUT_DAYS":               &cfg.Escrow.TimeoutDays,
+		"INTERNATIONAL_SETTLEMENT_DAYS":     &cfg.International.SettlementDays,
+		"SUBSCRIPTION_RETRY_DAYS":           &cfg.Subscription.RetryDays,
+		"SUBSCRIPTION_MAX_ATTEMPTS":         &cfg.Subscription.MaxAttempts,
+		"REFERRAL_QUALIFY_DAYS":             &cfg.Referral.QualifyDays,
+		"DORMANCY_MONTHS":                   &cfg.Dormancy.Months,
+		"NOTIFICATION_DECLINES_PER_DAY":     &cfg.Notification.DeclinesPerDay,
+		"REPORTING_CACHE_TTL":               &cfg.Reporting.CacheTTL,
+		"REPORTING_NPL_DAYS":                &cfg.Reporting.NPLDays,
+		"PASSWORD_ARGON2_MEMORY":            &cfg.Password.Memory,
+		"PASSWORD_ARGON2_ITERATIONS":        &cfg.Password.Iterations,
+		"PASSWORD_ARGON2_PARALLELISM":       &cfg.Password.Parallelism,
+		"CAPTCHA_LOGIN_FAILURES":            &cfg.Captcha.LoginFailures,
+		"CAPTCHA_FAILURE_WINDOW":            &cfg.Captcha.FailureWindow,
+		"ACCOUNTING_EXPORT_TIMEOUT":         &cfg.AccountingExport.Timeout,
+		"WAREHOUSE_EXPORT_ROW_GROUP_SIZE":   &cfg.WarehouseExport.RowGroupSize,
+		"STORAGE_TIMEOUT":                   &cfg.Storage.Timeout,
+		"ANTIVIRUS_TIMEOUT":                 &cfg.Antivirus.Timeout,
+		"SEARCH_TIMEOUT":                    &cfg.Search.Timeout,
+		"CBR_TIMEOUT":                       &cfg.CBR.Timeout,
+		"OUTBOUND_HTTP_TIMEOUT":             &cfg.OutboundHTTP.Timeout,
+		"OUTBOUND_HTTP_MAX_ATTEMPTS":        &cfg.OutboundHTTP.MaxAttempts,
+		"OUTBOUND_HTTP_BASE_DELAY_MS":       &cfg.OutboundHTTP.BaseDelayMs,
+		"OUTBOUND_HTTP_MAX_DELAY_MS":        &cfg.OutboundHTTP.MaxDelayMs,
+		"OUTBOUND_HTTP_BREAKER_THRESHOLD":   &cfg.OutboundHTTP.BreakerThreshold,
+		"OUTBOUND_HTTP_BREAKER_COOLDOWN":    &cfg.OutboundHTTP.BreakerCooldown,
+		"DB_PORT":                           &cfg.Database.Port,
+		"JWT_TTL":                           &cfg.JWT.TTL,
+		"SMTP_PORT":                         &cfg.Email.SMTPPort,
+
+		"NOTIFICATION_ANNOUNCEMENTS_PER_MINUTE": &cfg.Notification.AnnouncementsPerMinute,
+
+		"RATE_LIMIT_RPM":   &cfg.RateLimit.RequestsPerMinute,
+		"RATE_LIMIT_BURST": &cfg.RateLimit.Burst,
+
+		"RATE_LIMIT_AVAILABILITY_RPM":   &cfg.RateLimit.AvailabilityRequestsPerMinute,
+		"RATE_LIMIT_AVAILABILITY_BURST": &cfg.RateLimit.AvailabilityBurst,
+	}
+
+	for key, target := range ints {
+		if err := overrideInt(target, key); err != nil {
+			return err
+		}
+	}
+
+	strs := map[string]*string{
+		"DB_HOST":         &cfg.Database.Host,
+		"DB_USER":         &cfg.Database.User,
+		"DB_PASSWORD":     &cfg.Database.Password,
+		"DB_NAME":         &cfg.Database.DBName,
+		"JWT_SECRET":      &cfg.JWT.Secret,
+		"SMTP_HOST":       &cfg.Email.SMTPHost,
+		"SMTP_USER":       &cfg.Email.SMTPUser,
+		"SMTP_PASSWORD":   &cfg.Email.SMTPPassword,
+		"SENDER_EMAIL":    &cfg.Email.SenderEmail,
+		"PGP_PUBLIC_KEY":  &cfg.PGP.PublicKey,
+		"PGP_PRIVATE_KEY": &cfg.PGP.PrivateKey,
+		"PGP_PASSPHRASE":  &cfg.PGP.Passphrase,
+		"CBR_API_URL":     &cfg.CBR.APIURL,
+		"LOG_LEVEL":       &cfg.Log.Level,
+
+		"JWT_ALGORITHM":                &cfg.JWT.Algorithm,
+		"JWT_PRIVATE_KEY_FILE":         &cfg.JWT.PrivateKeyFile,
+		"JWT_ACCEPT_LEGACY_HMAC_UNTIL": &cfg.JWT.AcceptLegacyHMACUntil,
+		"TLS_CERT_FILE":                &cfg.Server.TLS.CertFile,
+		"TLS_KEY_FILE":                 &cfg.Server.TLS.KeyFile,
+		"TLS_AUTOCERT_CACHE_DIR":       &cfg.Server.TLS.AutocertCacheDir,
+		"MAINTENANCE_MESSAGE":          &cfg.Maintenance.Message,
+		"PUBLIC_URL":                   &cfg.Server.PublicURL,
+		"ADMIN_CLIENT_CA_FILE":         &cfg.Admin.ClientCAFile,
+		"CAPTCHA_PROVIDER":             &cfg.Captcha.Provider,
+		"CAPTCHA_SITE_KEY":             &cfg.Captcha.SiteKey,
+		"CAPTCHA_SECRET_KEY":           &cfg.Captcha.SecretKey,
+		"CBR_KEY_RATE_JSON_URL":        &cfg.CBR.KeyRateJSONURL,
+		"EMAIL_WEBHOOK_SECRET":         &cfg.Email.WebhookSecret,
+
+		"ACCOUNTING_EXPORT_TARGET": &cfg.AccountingExport.Target,
+		"WAREHOUSE_EXPORT_PREFIX":  &cfg.WarehouseExport.Prefix,
+		"ACCOUNTING_S3_ACCESS_KEY": &cfg.AccountingExport.S3.AccessKey,
+		"ACCOUNTING_S3_SECRET_KEY": &cfg.AccountingExport.S3.SecretKey,
+		"ACCOUNTING_SFTP_PASSWORD": &cfg.AccountingExport.SFTP.Password,
+
+		"STORAGE_BACKEND":            &cfg.Storage.Backend,
+		"STORAGE_DIR":                &cfg.Storage.Dir,
+		"STORAGE_S3_ACCESS_KEY":      &cfg.Storage.S3.AccessKey,
+		"STORAGE_S3_SECRET_KEY":      &cfg.Storage.S3.SecretKey,
+		"ANTIVIRUS_ADDRESS":          &cfg.Antivirus.Address,
+		"CREDIT_DAY_COUNT":           &cfg.Credit.DayCount,
+		"CALENDAR_ROLL":              &cfg.Credit.Calendar.Roll,
+		"BANK_TIMEZONE":              &cfg.Bank.Timezone,
+		"BANK_LANGUAGE":              &cfg.Bank.Language,
+		"BANK_NAME":                  &cfg.Bank.Name,
+		"BANK_BIC":                   &cfg.Bank.BIC,
+		"BANK_BRANCH":                &cfg.Bank.Branch,
+		"BANK_CORRESPONDENT_ACCOUNT": &cfg.Bank.CorrespondentAccount,
+		"CHEQUE_OCR_PROVIDER":        &cfg.Cheque.OCRProvider,
+		"SEARCH_PROVIDER":            &cfg.Search.Provider,
+		"SEARCH_URL":                 &cfg.Search.URL,
+		"SEARCH_INDEX":               &cfg.Search.Index,
+		"SEARCH_USERNAME":            &cfg.Search.Username,
+		"SEARCH_PASSWORD":            &cfg.Search.Password,
+	}
+
+	for key, target := range strs {
+		overrideString(target, key)
+	}
+
+	overrideList(&cfg.Server.TLS.AutocertDomains, "TLS_AUTOCERT_DOMAINS")
+	overrideList(&cfg.Admin.AllowedIPs, "ADMIN_ALLOWED_IPS")
+	overrideList(&cfg.CBR.KeyRateProviders, "CBR_KEY_RATE_PROVIDERS")
+	overrideList(&cfg.Worker.Jobs, "WORKER_JOBS")
+	overrideList(&cfg.Credit.Calendar.Holidays, "CALENDAR_HOLIDAYS")
+	overrideList(&cfg.Credit.Calendar.Workdays, "CALENDAR_WORKDAYS")
+
+	if err := overrideBool(&cfg.Maintenance.Enabled, "MAINTENANCE_MODE"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.Captcha.Enabled, "CAPTCHA_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.AccountingExport.Enabled, "ACCOUNTING_EXPORT_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.WarehouseExport.Enabled, "WAREHOUSE_EXPORT_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.Antivirus.Enabled, "ANTIVIRUS_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.Search.Enabled, "SEARCH_ENABLED"); err != nil {
+		return err
+	}
+
+	if err := overrideBool(&cfg.Onboarding.Required, "ONBOARDING_REQUIRED"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Credit.DocumentThreshold, "CREDIT_DOCUMENT_THRESHOLD"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Credit.InsuranceRate, "CREDIT_INSURANCE_RATE"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Credit.PenaltyRate, "CREDIT_PENALTY_RATE"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Transfer.OTPThreshold, "TRANSFER_OTP_THRESHOLD"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Cheque.MaxAmount, "CHEQUE_MAX_AMOUNT"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.International.FeePercent, "INTERNATIONAL_FEE_PERCENT"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.International.MinFee, "INTERNATIONAL_MIN_FEE"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.International.CorrespondentFee, "INTERNATIONAL_CORRESPONDENT_FEE"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Referral.ReferrerBonus, "REFERRAL_REFERRER_BONUS"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Referral.RefereeBonus, "REFERRAL_REFEREE_BONUS"); err != nil {
+		return err
+	}
+
+	if err := overrideFloat(&cfg.Referral.MinDeposit, "REFERRAL_MIN_DEPOSIT"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate checks that required values are present and secrets are not placeholders
+func (c *Config) Validate() error {
+	var problems []string
+
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		problems = append(problems, "server.port must be between 1 and 65535")
+	}
+
+	if c.Server.ReadTimeout <= 0 || c.Server.WriteTimeout <= 0 || c.Server.IdleTimeout <= 0 ||
+		c.Server.LongRunning.ReadTimeout <= 0 || c.Server.LongRunning.WriteTimeout <= 0 {
+		problems = append(problems, "server timeouts must be positive")
+	}
+
+	problems = append(problems, c.Server.TLS.validate(c.Server.Port)...)
+
+	if c.Server.PublicURL == "" {
+		problems = append(problems, "server.public_url is required")
+	}
+
+	if c.Database.Host == "" || c.Database.DBName == "" {
+		problems = append(problems, "database.host and database.db_name are required")
+	}
+
+	if c.JWT.Secret == "" {
+		problems = append(problems, "jwt.secret (JWT_SECRET) is required")
+	} else if insecureJWTSecrets[c.JWT.Secret] {
+		problems = append(problems, "jwt.secret (JWT_SECRET) must not be a default placeholder")
+	} else if len(c.JWT.Secret) < 32 {
+		problems = append(problems, "jwt.secret (JWT_SECRET) must be at least 32 characters")
+	}
+
+	if c.JWT.TTL <= 0 {
+		problems = append(problems, "jwt.ttl must be positive")
+	}
+
+	switch c.JWT.Algorithm {
+	case "HS256":
+	case "RS256", "EdDSA":
+		if c.JWT.PrivateKeyFile == "" {
+			problems = append(problems, "jwt.private_key_file (JWT_PRIVATE_KEY_FILE) is required with "+c.JWT.Algorithm)
+		}
+	default:
+		problems = append(problems, "jwt.algorithm must be one of HS256, RS256, EdDSA")
+	}
+
+	if c.JWT.AcceptLegacyHMACUntil != "" {
+		deadline := c.JWT.LegacyHMACDeadline()
+		if deadline.IsZero() {
+			problems = append(problems, "jwt.accept_legacy_hmac_until must be an RFC 3339 time")
+		} else if c.JWT.Algorithm == "HS256" {
+			problems = append(problems, "jwt.accept_legacy_hmac_until only applies to RS256 and EdDSA")
+		} else if deadline.After(time.Now().Add(time.Duration(c.JWT.TTL) * time.Hour)) {
+			// Tokens signed with the secret were issued before the switch and expire within ttl
+			problems = append(problems, "jwt.accept_legacy_hmac_until must be at most jwt.ttl hours ahead")
+		}
+	}
+
+	if c.PGP.PublicKey == "" || c.PGP.PrivateKey == "" {
+		problems = append(problems, "pgp.public_key and pgp.private_key (PGP_PUBLIC_KEY, PGP_PRIVATE_KEY) are required")
+	}
+
+	if c.Email.SMTPHost == "" || c.Email.SenderEmail == "" {
+		problems = append(problems, "email.smtp_host and email.sender_email are required")
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "warning", "error":
+	default:
+		problems = append(problems, "log.level must be one of debug, info, warn, error")
+	}
+
+	if c.RateLimit.RequestsPerMinute < 0 || c.RateLimit.Burst < 0 ||
+		c.RateLimit.AvailabilityRequestsPerMinute < 0 || c.RateLimit.AvailabilityBurst < 0 {
+		problems = append(problems, "rate_limit values cannot be negative")
+	}
+
+	if c.Credit.PenaltyRate < 0 || c.Credit.PenaltyRate > 1 {
+		problems = append(problems, "credit.penalty_rate must be between 0 and 1")
+	}
+
+	if c.Transfer.OTPThreshold < 0 {
+		problems = append(problems, "transfer.otp_threshold cannot be negative")
+	}
+
+	if c.Transfer.OTPThreshold > 0 && (c.Transfer.OTPTTL <= 0 || c.Transfer.OTPMaxAttempts <= 0) {
+		problems = append(problems, "transfer.otp_ttl and transfer.otp_max_attempts must be positive")
+	}
+
+	if c.Transfer.ApprovalTTL <= 0 {
+		problems = append(problems, "transfer.approval_ttl must be positive")
+	}
+
+	if c.Merchant.IntentTTL <= 0 {
+		problems = append(problems, "merchant.intent_ttl must be positive")
+	}
+
+	if c.Chargeback.WindowDays <= 0 || c.Chargeback.EvidenceDays <= 0 {
+		problems = append(problems, "chargeback.window_days and chargeback.evidence_days must be positive")
+	}
+
+	if c.Escrow.TimeoutDays <= 0 {
+		problems = append(problems, "escrow.timeout_days must be positive")
+	}
+
+	if c.Subscription.RetryDays <= 0 || c.Subscription.MaxAttempts <= 0 {
+		problems = append(problems, "subscription.retry_days and subscription.max_attempts must be positive")
+	}
+
+	if strings.ToLower(c.Cheque.OCRProvider) != "stub" {
+		problems = append(problems, "cheque.ocr_provider must be stub")
+	}
+
+	if c.Cheque.MaxAmount <= 0 {
+		problems = append(problems, "cheque.max_amount must be positive")
+	}
+
+	if c.International.FeePercent < 0 || c.International.MinFee < 0 || c.International.CorrespondentFee < 0 {
+		problems = append(problems, "international.fee_percent, international.min_fee and international.correspondent_fee must not be negative")
+	}
+
+	if c.International.SettlementDays <= 0 {
+		problems = append(problems, "international.settlement_days must be positive")
+	}
+
+	if c.Referral.ReferrerBonus <= 0 || c.Referral.RefereeBonus < 0 || c.Referral.MinDeposit < 0 || c.Referral.QualifyDays <= 0 {
+		problems = append(problems, "referral.referrer_bonus and referral.qualify_days must be positive, referee_bonus and min_deposit not negative")
+	}
+
+	if c.Dormancy.Months < 0 {
+		problems = append(problems, "dormancy.months must not be negative")
+	}
+
+	if c.Notification.DeclinesPerDay < 0 {
+		problems = append(problems, "notification.declines_per_day must not be negative")
+	}
+
+	if c.Notification.AnnouncementsPerMinute <= 0 {
+		problems = append(problems, "notification.announcements_per_minute must be positive")
+	}
+
+	problems = append(problems, c.CBR.validate()...)
+
+	if c.OutboundHTTP.Timeout <= 0 || c.OutboundHTTP.MaxAttempts < 1 || c.OutboundHTTP.BreakerThreshold < 1 || c.OutboundHTTP.BreakerCooldown <= 0 {
+		problems = append(problems, "outbound_http.timeout, max_attempts, breaker_threshold and breaker_cooldown must be positive")
+	}
+
+	if c.OutboundHTTP.BaseDelayMs < 0 || c.OutboundHTTP.MaxDelayMs < c.OutboundHTTP.BaseDelayMs {
+		problems = append(problems, "outbound_http.base_delay_ms must not be negative and max_delay_ms must not be below it")
+	}
+
+	if c.Reporting.CacheTTL < 0 || c.Reporting.NPLDays <= 0 {
+		problems = append(problems, "reporting.cache_ttl must not be negative and reporting.npl_days must be positive")
+	}
+
+	if c.Password.Memory < 8*1024 || c.Password.Iterations < 1 || c.Password.Parallelism < 1 || c.Password.Parallelism > 255 {
+		problems = append(problems, "password.memory must be at least 8192 KiB, iterations at least 1 and parallelism between 1 and 255")
+	}
+
+	if c.Captcha.Enabled {
+		switch strings.ToLower(c.Captcha.Provider) {
+		case "recaptcha", "hcaptcha":
+		default:
+			problems = append(problems, "captcha.provider must be one of recaptcha, hcaptcha")
+		}
+
+		if c.Captcha.SiteKey == "" || c.Captcha.SecretKey == "" {
+			problems = append(problems, "captcha.site_key and captcha.secret_key (CAPTCHA_SITE_KEY, CAPTCHA_SECRET_KEY) are required")
+		}
+
+		if c.Captcha.LoginFailures < 0 || c.Captcha.FailureWindow <= 0 || c.Captcha.Timeout <= 0 {
+			problems = append(problems, "captcha.login_failures cannot be negative, failure_window and timeout must be positive")
+		}
+	}
+
+	if c.AccountingExport.Enabled {
+		problems = append(problems, c.AccountingExport.validate()...)
+	}
+
+	if c.WarehouseExport.Enabled && (strings.Trim(c.WarehouseExport.Prefix, "/") == "" || c.WarehouseExport.RowGroupSize <= 0) {
+		problems = append(problems, "warehouse_export.prefix is required and warehouse_export.row_group_size must be positive")
+	}
+
+	if c.Credit.DocumentThreshold < 0 {
+		problems = append(problems, "credit.document_threshold cannot be negative")
+	}
+
+	if c.Credit.InsuranceRate < 0 || c.Credit.InsuranceRate >= 1 {
+		problems = append(problems, "credit.insurance_rate must be at least 0 and below 1")
+	}
+
+	if c.Credit.PaymentHoldDays < 0 {
+		problems = append(problems, "credit.payment_hold_days cannot be negative")
+	}
+
+	switch c.Credit.DayCount {
+	case "ACT/365", "ACT/360", "30/360":
+	default:
+		problems = append(problems, "credit.day_count must be one of ACT/365, ACT/360, 30/360")
+	}
+
+	problems = append(problems, c.Credit.Calendar.validate()...)
+
+	if _, err := time.LoadLocation(c.Bank.Timezone); err != nil || c.Bank.Timezone == "" {
+		problems = append(problems, fmt.Sprintf("bank.timezone: unknown time zone %q", c.Bank.Timezone))
+	}
+
+	if _, ok := i18n.Parse(c.Bank.Language); !ok {
+		problems = append(problems, fmt.Sprintf("bank.language: unsupported language %q", c.Bank.Language))
+	}
+
+	if c.Bank.BIC != "" && (c.Bank.Name == "" || !isDigits(c.Bank.BIC, 9) || !isDigits(c.Bank.CorrespondentAccount, 20)) {
+		problems = append(problems, "bank.bic requires bank.name, a 9-digit bic and a 20-digit bank.correspondent_account")
+	}
+
+	if c.Bank.Branch != "" && !isDigits(c.Bank.Branch, 4) {
+		problems = append(problems, "bank.branch must have 4 digits")
+	}
+
+	if c.Credit.SignatureOTPTTL <= 0 || c.Credit.SignatureOTPMaxAttempts <= 0 {
+		problems = append(problems, "credit.signature_otp_ttl and credit.signature_otp_max_attempts must be positive")
+	}
+
+	problems = append(problems, c.Storage.validate()...)
+	problems = append(problems, validateTenants(c.Tenants)...)
+
+	if c.Antivirus.Enabled {
+		if strings.ToLower(c.Antivirus.Provider) != "clamav" {
+			problems = append(problems, "antivirus.provider must be clamav")
+		}
+
+		if c.Antivirus.Address == "" || c.Antivirus.Timeout <= 0 {
+			problems = append(problems, "antivirus.address is required and antivirus.timeout must be positive")
+		}
+	}
+
+	if c.Search.Enabled {
+		if provider := strings.ToLower(c.Search.Provider); provider != "elasticsearch" && provider != "opensearch" {
+			problems = append(problems, "search.provider must be elasticsearch or opensearch")
+		}
+
+		if c.Search.URL == "" || c.Search.Index == "" || c.Search.Timeout <= 0 {
+			problems = append(problems, "search.url and search.index are required and search.timeout must be positive")
+		}
+	}
+
+	if c.Maintenance.RetryAfter < 0 {
+		problems = append(problems, "maintenance.retry_after cannot be negative")
+	}
+
+	if _, err := c.Admin.AllowedNetworks(); err != nil {
+		problems = append(problems, fmt.Sprintf("admin.allowed_ips: %v", err))
+	}
+
+	if c.Admin.ClientCAFile != "" && !c.Server.TLS.Enabled() {
+		problems = append(problems, "admin.client_ca_file requires server.tls to be enabled")
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// validate checks that exactly one certificate source is configured and the redirect port is usable
+func (t TLSConfig) validate(serverPort int) []string {
+	var problems []string
+
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		problems = append(problems, "server.tls.cert_file and server.tls.key_file must be set together")
+	}
+
+	if t.CertFile != "" && t.UsesAutocert() {
+		problems = append(problems, "server.tls.cert_file and server.tls.autocert_domains are mutually exclusive")
+	}
+
+	if t.UsesAutocert() && t.AutocertCacheDir == "" {
+		problems = append(problems, "server.tls.autocert_cache_dir is required with autocert")
+	}
+
+	if t.RedirectPort != 0 {
+		if !t.Enabled() {
+			problems = append(problems, "server.tls.redirect_port requires TLS to be enabled")
+		} else if t.RedirectPort < 0 || t.RedirectPort > 65535 || t.RedirectPort == serverPort {
+			problems = append(problems, "server.tls.redirect_port must be a valid port different from server.port")
+		}
+	}
+
+	return problems
+}
+
+// validate checks that the chosen upload target has the settings it needs
+func (a AccountingExportConfig) validate() []string {
+	var problems []string
+
+	switch strings.ToLower(a.Target) {
+	case "s3":
+		if a.S3.Region == "" || a.S3.Bucket == "" || a.S3.AccessKey == "" || a.S3.SecretKey == "" {
+			problems = append(problems, "accounting_export.s3 region, bucket, access_key and secret_key (ACCOUNTING_S3_ACCESS_KEY, ACCOUNTING_S3_SECRET_KEY) are required")
+		}
+	case "sftp":
+		if a.SFTP.Host == "" || a.SFTP.User == "" || a.SFTP.HostKey == "" {
+			problems = append(problems, "accounting_export.sftp host, user and host_key are required")
+		}
+
+		if a.SFTP.Password == "" && a.SFTP.PrivateKeyFile == "" {
+			problems = append(problems, "accounting_export.sftp requires password (ACCOUNTING_SFTP_PASSWORD) or private_key_file")
+		}
+	default:
+		problems = append(problems, "accounting_export.target must be one of s3, sftp")
+	}
+
+	if a.Timeout <= 0 {
+		problems = append(problems, "accounting_export.timeout must be positive")
+	}
+
+	return problems
+}
+
+// validate checks that the chosen storage backend has the settings it needs
+func (s StorageConfig) validate() []string {
+	var problems []string
+
+	switch strings.ToLower(s.Backend) {
+	case "local":
+		if s.Dir == "" {
+			problems = append(problems, "storage.dir is required for the local backend")
+		}
+	case "s3":
+		if s.S3.Region == "" || s.S3.Bucket == "" || s.S3.AccessKey == "" || s.S3.SecretKey == "" {
+			problems = append(problems, "storage.s3 region, bucket, access_key and secret_key (STORAGE_S3_ACCESS_KEY, STORAGE_S3_SECRET_KEY) are required")
+		}
+	default:
+		problems = append(problems, "storage.backend must be one of local, s3")
+	}
+
+	if s.Timeout <= 0 {
+		problems = append(problems, "storage.timeout must be positive")
+	}
+
+	return problems
+}
+
+// validate checks the key rate providers
+func (c CBRConfig) validate() []string {
+	var problems []string
+
+	if len(c.KeyRateProviders) == 0 {
+		problems = append(problems, "cbr.key_rate_providers requires at least one provider")
+	}
+
+	for _, provider := range c.KeyRateProviders {
+		switch strings.ToLower(provider) {
+		case "soap":
+			if c.APIURL == "" {
+				problems = append(problems, "cbr.api_url (CBR_API_URL) is required for the soap key rate provider")
+			}
+		case "json":
+			if c.KeyRateJSONURL == "" {
+				problems = append(problems, "cbr.key_rate_json_url (CBR_KEY_RATE_JSON_URL) is required for the json key rate provider")
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("cbr.key_rate_providers: unsupported provider %q, expected soap or json", provider))
+		}
+	}
+
+	if c.Timeout <= 0 {
+		problems = append(problems, "cbr.timeout must be positive")
+	}
+
+	return problems
+}
+
+// validate checks the roll convention and the dates of the calendar
+func (c CalendarConfig) validate() []string {
+	var problems []string
+
+	switch c.Roll {
+	case "none", "following", "modified_following":
+	default:
+		problems = append(problems, "credit.calendar.roll must be one of none, following, modified_following")
+	}
+
+	for _, date := range append(append([]string{}, c.Holidays...), c.Workdays...) {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			problems = append(problems, fmt.Sprintf("credit.calendar: invalid date %q, expected YYYY-MM-DD", date))
+		}
+	}
+
+	return problems
+}
+
+// Redacted returns a copy of the configuration with secrets masked
+func (c *Config) Redacted() *Config {
+	redacted := *c
+
+	redacted.Database.Password = redact(c.Database.Password)
+	redacted.Search.Password = redact(c.Search.Password)
+	redacted.JWT.Secret = redact(c.JWT.Secret)
+	redacted.Email.SMTPPassword = redact(c.Email.SMTPPassword)
+	redacted.Email.WebhookSecret = redact(c.Email.WebhookSecret)
+	redacted.PGP.PublicKey = redact(c.PGP.PublicKey)
+	redacted.PGP.PrivateKey = redact(c.PGP.PrivateKey)
+	redacted.PGP.Passphrase = redact(c.PGP.Passphrase)
+	redacted.Captcha.SecretKey = redact(c.Captcha.SecretKey)
+	redacted.AccountingExport.S3.AccessKey = redact(c.AccountingExport.S3.AccessKey)
+	redacted.AccountingExport.S3.SecretKey = redact(c.AccountingExport.S3.SecretKey)
+	redacted.AccountingExport.SFTP.Password = redact(c.AccountingExport.SFTP.Password)
+	redacted.Storage.S3.AccessKey = redact(c.Storage.S3.AccessKey)
+	redacted.Storage.S3.SecretKey = redact(c.Storage.S3.SecretKey)
+
+	redacted.Tenants = make([]TenantConfig, len(c.Tenants))
+	for i, tenant := range c.Tenants {
+		tenant.Email.SMTPPassword = redact(tenant.Email.SMTPPassword)
+		tenant.Email.WebhookSecret = redact(tenant.Email.WebhookSecret)
+		redacted.Tenants[i] = tenant
+	}
+
+	return &redacted
+}
+
+// String renders the effective configuration as YAML with secrets masked
+func (c *Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("<failed to render config: %v>", err)
+	}
+	return string(data)
+}
+
+// redact masks a non-empty secret
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// overrideString sets target from an environment variable if it is set
+func overrideString(target *string, key string) {
+	if value := os.Getenv(key); value != "" {
+		*target = value
+	}
+}
+
+// overrideList sets target from a comma-separated environment variable if it is set
+func overrideList(target *[]string, key string) {
+	value := os.Getenv(key)
+	if value == "" {
+		return
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	*target = items
+}
+
+// overrideInt sets target from an integer environment variable if it is set
+func overrideInt(target *int, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	*target = parsed
+	return nil
+}
+
+// overrideBool sets target from a boolean environment variable if it is set
+func overrideBool(target *bool, key string) error {
 	value := os.Getenv(key)
 	if value == "" {
-		return defaultValue
+		return nil
 	}
-	return value
-}
\ No newline at end of file
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	*target = parsed
+	return nil
+}
+
+// overrideFloat sets target from a float environment variable if it is set
+func overrideFloat(target *float64, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	*target = parsed
+	return nil
+}
+
+// isDigits reports whether value consists of exactly n decimal digits
+func isDigits(value string, n int) bool {
+	if len(value) != n {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
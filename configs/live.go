@@ -0,0 +1,134 @@
+package configs
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Live holds the configuration that can change at runtime without a restart.
+// Structural settings (server, database, JWT, PGP, CBR) are read once at startup
+// from Config; reloadable settings must be read through Live on every use.
+type Live struct {
+	mu          sync.RWMutex
+	cfg         *Config
+	maintenance MaintenanceConfig
+	listeners   []func(*Config)
+}
+
+// NewLive creates a new Live configuration holder
+func NewLive(cfg *Config) *Live {
+	return &Live{
+		cfg:         cfg,
+		maintenance: cfg.Maintenance,
+	}
+}
+
+// Email returns the current SMTP settings
+func (l *Live) Email() EmailConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg.Email
+}
+
+// Log returns the current logging settings
+func (l *Live) Log() LogConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg.Log
+}
+
+// RateLimit returns the current rate limits
+func (l *Live) RateLimit() RateLimitConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg.RateLimit
+}
+
+// Credit returns the current credit processing settings
+func (l *Live) Credit() CreditConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg.Credit
+}
+
+// Maintenance returns the current maintenance mode state
+func (l *Live) Maintenance() MaintenanceConfig {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.maintenance
+}
+
+// SetMaintenance switches maintenance mode. The state is kept across reloads;
+// the maintenance section of the config only applies at startup.
+func (l *Live) SetMaintenance(m MaintenanceConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maintenance = m
+}
+
+// OnReload registers a function called with the new configuration after every successful reload
+func (l *Live) OnReload(fn func(*Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, fn)
+}
+
+// Reload loads the configuration again and applies its reloadable sections.
+// It returns the names of structural sections that changed and were ignored.
+func (l *Live) Reload() ([]string, error) {
+	loaded, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+
+	ignored := structuralChanges(l.cfg, loaded)
+
+	next := *l.cfg
+	next.Email = loaded.Email
+	next.Log = loaded.Log
+	next.RateLimit = loaded.RateLimit
+	next.Credit = loaded.Credit
+	l.cfg = &next
+
+	listeners := append([]func(*Config){}, l.listeners...)
+	l.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(&next)
+	}
+
+	return ignored, nil
+}
+
+// structuralChanges lists the sections that differ but require a restart to apply
+func structuralChanges(current, loaded *Config) []string {
+	var changed []string
+
+	sections := map[string][2]interface{}{
+		"server":    {current.Server, loaded.Server},
+		"bank":      {current.Bank, loaded.Bank},
+		"database":  {current.Database, loaded.Database},
+		"jwt":       {current.JWT, loaded.JWT},
+		"pgp":       {current.PGP, loaded.PGP},
+		"cbr":       {current.CBR, loaded.CBR},
+		"dormancy":  {current.Dormancy, loaded.Dormancy},
+		"reporting": {current.Reporting, loaded.Reporting},
+		"worker":    {current.Worker, loaded.Worker},
+
+		"accounting_export": {current.AccountingExport, loaded.AccountingExport},
+		"storage":           {current.Storage, loaded.Storage},
+		"outbound_http":     {current.OutboundHTTP, loaded.OutboundHTTP},
+		"antivirus":         {current.Antivirus, loaded.Antivirus},
+		"tenants":           {current.Tenants, loaded.Tenants},
+	}
+
+	for name, values := range sections {
+		if !reflect.DeepEqual(values[0], values[1]) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,90 @@
+package configs
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// DefaultTenant is the code of the tenant that serves requests without a tenant; it matches
+// models.DefaultTenant
+const DefaultTenant = "default"
+
+// defaultTenantName is the brand name of the default tenant when it is not configured
+const defaultTenantName = "Banking Service"
+
+// tenantCodePattern limits tenant codes to what fits the users.tenant and accounts.tenant columns
+var tenantCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// TenantConfig holds a bank brand served by the deployment
+type TenantConfig struct {
+	Code         string      `yaml:"code"`          // stored with users and accounts and carried in tokens
+	Name         string      `yaml:"name"`          // brand name used in emails
+	Hosts        []string    `yaml:"hosts"`         // request hosts that select the tenant
+	LogoURL      string      `yaml:"logo_url"`      // shown at the top of emails, empty omits the logo
+	SupportEmail string      `yaml:"support_email"` // shown in emails, empty omits it
+	Email        EmailConfig `yaml:"email"`         // SMTP settings of the brand, an empty smtp_host uses the global ones
+}
+
+// Tenant returns the tenant with the given code. The default tenant exists even if it is not configured.
+func (c *Config) Tenant(code string) (TenantConfig, bool) {
+	for _, tenant := range c.Tenants {
+		if tenant.Code == code {
+			return tenant, true
+		}
+	}
+
+	if code == DefaultTenant {
+		return TenantConfig{Code: DefaultTenant, Name: defaultTenantName}, true
+	}
+
+	return TenantConfig{}, false
+}
+
+// TenantForHost returns the tenant serving a request host, which may include a port
+func (c *Config) TenantForHost(host string) (TenantConfig, bool) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	for _, tenant := range c.Tenants {
+		for _, h := range tenant.Hosts {
+			if strings.EqualFold(h, host) {
+				return tenant, true
+			}
+		}
+	}
+
+	return TenantConfig{}, false
+}
+
+// validateTenants checks that tenant codes and hosts are unique and each tenant has a name
+func validateTenants(tenants []TenantConfig) []string {
+	var problems []string
+
+	codes := make(map[string]bool)
+	hosts := make(map[string]bool)
+	for i, tenant := range tenants {
+		if !tenantCodePattern.MatchString(tenant.Code) {
+			problems = append(problems, fmt.Sprintf("tenants[%d].code must be 1-50 lowercase letters, digits or dashes", i))
+		} else if codes[tenant.Code] {
+			problems = append(problems, fmt.Sprintf("tenants[%d].code %q is duplicated", i, tenant.Code))
+		}
+		codes[tenant.Code] = true
+
+		if tenant.Name == "" {
+			problems = append(problems, fmt.Sprintf("tenants[%d].name is required", i))
+		}
+
+		for _, host := range tenant.Hosts {
+			host = strings.ToLower(host)
+			if hosts[host] {
+				problems = append(problems, fmt.Sprintf("tenants[%d].hosts: %q is used by another tenant", i, host))
+			}
+			hosts[host] = true
+		}
+	}
+
+	return problems
+}
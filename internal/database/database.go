@@ -0,0 +1,32 @@
+// Package database connects the binaries to PostgreSQL
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "github.com/lib/pq"
+
+	"banking-service/configs"
+)
+
+// Open connects to the database of the configuration and checks the connection.
+//
+// Timestamps are stored as UTC instants; the session time zone is the bank's, and only decides
+// which business day CURRENT_DATE and day truncation in reports fall on.
+func Open(cfg *configs.Config) (*sql.DB, error) {
+	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable timezone=%s",
+		cfg.Database.Host, cfg.Database.Port, cfg.Database.User, cfg.Database.Password, cfg.Database.DBName, cfg.Bank.Timezone)
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -35,7 +35,7 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -43,7 +43,7 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var accountCreate models.AccountCreate
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&accountCreate); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -55,12 +55,12 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 	accountID, err := h.accountService.Create(r.Context(), &accountCreate)
 	if err != nil {
 		h.logger.Warnf("Failed to create account: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusCreated, "account created successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusCreated, "account created successfully", map[string]interface{}{
 		"account_id": accountID,
 	})
 }
@@ -70,7 +70,7 @@ func (h *AccountHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -78,12 +78,12 @@ func (h *AccountHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	accounts, err := h.accountService.GetByUserID(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get accounts: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get accounts")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get accounts")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "accounts retrieved successfully", accounts)
+	utils.RespondWithFields(w, r, http.StatusOK, "accounts retrieved successfully", accounts)
 }
 
 // GetByID handles retrieving a specific account by ID
@@ -91,7 +91,7 @@ func (h *AccountHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -99,7 +99,7 @@ func (h *AccountHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	accountID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 		return
 	}
 	
@@ -107,12 +107,88 @@ func (h *AccountHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	account, err := h.accountService.GetByID(r.Context(), accountID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get account: %v", err)
-		utils.RespondWithError(w, http.StatusNotFound, "account not found")
+		utils.RespondError(w, http.StatusNotFound, "account not found")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "account retrieved successfully", account)
+	utils.Respond(w, http.StatusOK, "account retrieved successfully", account)
+}
+
+// GetHolds handles listing the active holds of an account
+func (h *AccountHandler) GetHolds(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	holds, err := h.accountService.GetHolds(r.Context(), accountID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get account holds: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "account not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account holds retrieved successfully", holds)
+}
+
+// GetActivity handles listing the activity feed of an account: transactions, card events, limit
+// changes and status changes, newest first. A page holds up to limit items; next_cursor in the
+// response requests the page after it.
+func (h *AccountHandler) GetActivity(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	query := r.URL.Query()
+	
+	var after *models.AccountActivityCursor
+	if cursor := query.Get("cursor"); cursor != "" {
+		if after, err = models.ParseAccountActivityCursor(cursor); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+	}
+	
+	var limit int
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	
+	page, err := h.accountService.GetActivity(r.Context(), accountID, userID, after, limit)
+	if err != nil {
+		h.logger.Warnf("Failed to get account activity: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account activity retrieved successfully", page)
 }
 
 // UpdateBalance handles deposit and withdrawal operations
@@ -120,7 +196,7 @@ func (h *AccountHandler) UpdateBalance(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -128,7 +204,7 @@ func (h *AccountHandler) UpdateBalance(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	accountID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 		return
 	}
 	
@@ -136,7 +212,7 @@ func (h *AccountHandler) UpdateBalance(w http.ResponseWriter, r *http.Request) {
 	var balanceUpdate models.AccountBalance
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&balanceUpdate); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -149,23 +225,66 @@ func (h *AccountHandler) UpdateBalance(w http.ResponseWriter, r *http.Request) {
 		depositRequest := &models.DepositRequest{
 			AccountID:   accountID,
 			Amount:      balanceUpdate.Amount,
+			Currency:    balanceUpdate.Currency,
 			Description: balanceUpdate.Description,
 		}
 		
 		transactionID, err = h.accountService.Deposit(r.Context(), accountID, userID, depositRequest)
 	} else {
-		utils.RespondWithError(w, http.StatusBadRequest, "amount must be positive")
+		utils.RespondError(w, http.StatusBadRequest, "amount must be positive, use the withdraw endpoint for withdrawals")
 		return
 	}
 	
 	if err != nil {
 		h.logger.Warnf("Failed to update balance: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "balance updated successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusOK, "balance updated successfully", map[string]interface{}{
+		"transaction_id": transactionID,
+	})
+}
+
+// Withdraw handles withdrawing funds from an account
+func (h *AccountHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	// Parse request body
+	var withdrawal models.WithdrawalRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&withdrawal); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	// The account comes from the URL
+	withdrawal.AccountID = accountID
+	
+	transactionID, err := h.accountService.Withdraw(r.Context(), accountID, userID, &withdrawal)
+	if err != nil {
+		h.logger.Warnf("Failed to withdraw: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "withdrawal completed successfully", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
 }
@@ -175,7 +294,7 @@ func (h *AccountHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -183,7 +302,7 @@ func (h *AccountHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	accountID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 		return
 	}
 	
@@ -191,10 +310,138 @@ func (h *AccountHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	err = h.accountService.Delete(r.Context(), accountID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to delete account: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account deleted successfully", nil)
+}
+
+// GetDefault handles getting the user's default account in a currency
+func (h *AccountHandler) GetDefault(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	currency := models.Currency(r.URL.Query().Get("currency"))
+	if currency == "" {
+		utils.RespondError(w, http.StatusBadRequest, "currency is required")
+		return
+	}
+	
+	account, err := h.accountService.GetDefault(r.Context(), userID, currency)
+	if err != nil {
+		h.logger.Warnf("Failed to get default account: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "default account not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "default account retrieved successfully", account)
+}
+
+// SetDefault handles making an account the user's default in its currency
+func (h *AccountHandler) SetDefault(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	account, err := h.accountService.SetDefault(r.Context(), accountID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to set default account: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "default account set successfully", account)
+}
+
+// UpdateSettings handles changing the user's nickname, color, sort order and dashboard visibility of an account
+func (h *AccountHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	// Parse request body
+	var update models.AccountSettingsUpdate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&update); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	settings, err := h.accountService.UpdateSettings(r.Context(), accountID, userID, &update)
+	if err != nil {
+		h.logger.Warnf("Failed to update account settings: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account settings updated successfully", settings)
+}
+
+// Reactivate handles reactivating a dormant account, which requires the user's password
+func (h *AccountHandler) Reactivate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+	
+	// Parse request body
+	var reactivation models.AccountReactivation
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&reactivation); err != nil || reactivation.Password == "" {
+		utils.RespondError(w, http.StatusBadRequest, "password is required")
+		return
+	}
+	defer r.Body.Close()
+	
+	account, err := h.accountService.Reactivate(r.Context(), accountID, userID, &reactivation)
+	if err != nil {
+		h.logger.Warnf("Failed to reactivate account: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "account deleted successfully", nil)
+	utils.Respond(w, http.StatusOK, "account reactivated successfully", account)
 }
\ No newline at end of file
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// AccountOwnershipHandler handles the bank's account ownership transfer HTTP requests
+type AccountOwnershipHandler struct {
+	accountOwnershipService service.AccountOwnershipService
+	logger                  *logrus.Logger
+	config                  *configs.Config
+}
+
+// NewAccountOwnershipHandler creates a new AccountOwnershipHandler
+func NewAccountOwnershipHandler(accountOwnershipService service.AccountOwnershipService, logger *logrus.Logger, config *configs.Config) *AccountOwnershipHandler {
+	return &AccountOwnershipHandler{
+		accountOwnershipService: accountOwnershipService,
+		logger:                  logger,
+		config:                  config,
+	}
+}
+
+// Request handles asking to transfer an account to another user
+func (h *AccountOwnershipHandler) Request(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account ID from URL
+	accountID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	// Parse request body
+	var request models.OwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	transfer, err := h.accountOwnershipService.Request(r.Context(), accountID, &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to request ownership transfer of account %d: %v", accountID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "ownership transfer requested successfully", transfer)
+}
+
+// GetByAccountID handles listing the ownership transfers of an account
+func (h *AccountOwnershipHandler) GetByAccountID(w http.ResponseWriter, r *http.Request) {
+	// Get account ID from URL
+	accountID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	transfers, err := h.accountOwnershipService.GetByAccountID(r.Context(), accountID)
+	if err != nil {
+		h.logger.Warnf("Failed to get ownership transfers of account %d: %v", accountID, err)
+		utils.RespondError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "ownership transfers retrieved successfully", transfers)
+}
+
+// GetAll handles listing ownership transfers, optionally filtered by ?status=
+func (h *AccountOwnershipHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.OwnershipTransferStatus(r.URL.Query().Get("status"))
+
+	transfers, err := h.accountOwnershipService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get ownership transfers: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "ownership transfers retrieved successfully", transfers)
+}
+
+// Get handles retrieving an ownership transfer with its audit trail
+func (h *AccountOwnershipHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Get transfer ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid ownership transfer ID")
+		return
+	}
+
+	transfer, err := h.accountOwnershipService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get ownership transfer: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "ownership transfer not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "ownership transfer retrieved successfully", transfer)
+}
+
+// Approve handles a second employee approving an ownership transfer, which moves the account
+func (h *AccountOwnershipHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.accountOwnershipService.Approve, "ownership transfer approved successfully")
+}
+
+// Reject handles a second employee rejecting an ownership transfer
+func (h *AccountOwnershipHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.accountOwnershipService.Reject, "ownership transfer rejected successfully")
+}
+
+// decide applies an approval or rejection with a note in the body
+func (h *AccountOwnershipHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, id int, decision *models.OwnershipTransferDecision, adminID int) (*models.OwnershipTransfer, error),
+	success string,
+) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get transfer ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid ownership transfer ID")
+		return
+	}
+
+	// The note is optional for an approval, so an empty body is accepted
+	var decision models.OwnershipTransferDecision
+	if err := json.NewDecoder(r.Body).Decode(&decision); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	transfer, err := apply(r.Context(), id, &decision, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to decide on ownership transfer %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, success, transfer)
+}
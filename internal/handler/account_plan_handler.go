@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// AccountPlanHandler handles account plan HTTP requests
+type AccountPlanHandler struct {
+	accountPlanService service.AccountPlanService
+	logger             *logrus.Logger
+	config             *configs.Config
+}
+
+// NewAccountPlanHandler creates a new AccountPlanHandler
+func NewAccountPlanHandler(accountPlanService service.AccountPlanService, logger *logrus.Logger, config *configs.Config) *AccountPlanHandler {
+	return &AccountPlanHandler{
+		accountPlanService: accountPlanService,
+		logger:             logger,
+		config:             config,
+	}
+}
+
+// GetAll handles listing the account plans accounts can switch to
+func (h *AccountPlanHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.accountPlanService.GetActive(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get account plans: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get account plans")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account plans retrieved successfully", plans)
+}
+
+// GetForAccount handles getting the current plan of an account
+func (h *AccountPlanHandler) GetForAccount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account ID from URL parameters
+	accountID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	status, err := h.accountPlanService.GetForAccount(r.Context(), accountID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get plan of account %d: %v", accountID, err)
+		utils.RespondError(w, http.StatusNotFound, "account plan not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account plan retrieved successfully", status)
+}
+
+// Switch handles moving an account to another plan
+func (h *AccountPlanHandler) Switch(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account ID from URL parameters
+	accountID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	// Parse request body
+	var request models.AccountPlanSwitch
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	status, err := h.accountPlanService.Switch(r.Context(), accountID, userID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to switch plan of account %d: %v", accountID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account plan switched successfully", status)
+}
+
+// AdminGetAll handles listing all account plans, including inactive ones
+func (h *AccountPlanHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	plans, err := h.accountPlanService.GetAll(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get account plans: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get account plans")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account plans retrieved successfully", plans)
+}
+
+// Create handles adding an account plan
+func (h *AccountPlanHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var request models.AccountPlanRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	plan, err := h.accountPlanService.Create(r.Context(), &request)
+	if err != nil {
+		h.logger.Warnf("Failed to create account plan: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "account plan created successfully", plan)
+}
+
+// Update handles replacing the data of an account plan; set is_active to false to stop offering it
+func (h *AccountPlanHandler) Update(w http.ResponseWriter, r *http.Request) {
+	// Get account plan ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account plan ID")
+		return
+	}
+
+	// Parse request body
+	var request models.AccountPlanRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	plan, err := h.accountPlanService.Update(r.Context(), id, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to update account plan: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "account plan updated successfully", plan)
+}
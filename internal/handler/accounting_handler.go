@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// AccountingHandler handles accounting export HTTP requests for the accounting department
+type AccountingHandler struct {
+	accountingService service.AccountingService
+	logger            *logrus.Logger
+	config            *configs.Config
+	bank              *time.Location // periods are read in the bank's time zone
+}
+
+// NewAccountingHandler creates a new AccountingHandler
+func NewAccountingHandler(accountingService service.AccountingService, logger *logrus.Logger, config *configs.Config, bank *time.Location) *AccountingHandler {
+	return &AccountingHandler{
+		accountingService: accountingService,
+		logger:            logger,
+		config:            config,
+		bank:              bank,
+	}
+}
+
+// Export handles downloading the transactions and ledger entries of a period as a zip archive
+func (h *AccountingHandler) Export(w http.ResponseWriter, r *http.Request) {
+	// Get the inclusive period from the query
+	from, to, err := models.ParseAccountingPeriod(r.URL.Query().Get("from"), r.URL.Query().Get("to"), h.bank)
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	name, content, err := h.accountingService.Export(r.Context(), from, to)
+	if err != nil {
+		h.logger.Errorf("Failed to build accounting export: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to build accounting export")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
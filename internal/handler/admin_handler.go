@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/pkg/httpclient"
+	"banking-service/pkg/utils"
+)
+
+// AdminHandler handles administrative HTTP requests
+type AdminHandler struct {
+	live     *configs.Live
+	logger   *logrus.Logger
+	config   *configs.Config
+	outbound *httpclient.Client
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(live *configs.Live, logger *logrus.Logger, config *configs.Config, outbound *httpclient.Client) *AdminHandler {
+	return &AdminHandler{
+		live:     live,
+		logger:   logger,
+		config:   config,
+		outbound: outbound,
+	}
+}
+
+// ReloadConfig handles reloading the runtime-configurable settings
+func (h *AdminHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	ignored, err := h.live.Reload()
+	if err != nil {
+		h.logger.Warnf("Failed to reload configuration: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if len(ignored) > 0 {
+		h.logger.Warnf("Configuration sections changed but require a restart: %v", ignored)
+	}
+
+	h.logger.Info("Configuration reloaded via admin endpoint")
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "configuration reloaded successfully", map[string]interface{}{
+		"requires_restart": ignored,
+	})
+}
+
+// GetMaintenance handles retrieving the maintenance mode state
+func (h *AdminHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	utils.Respond(w, http.StatusOK, "maintenance state retrieved successfully", h.live.Maintenance())
+}
+
+// SetMaintenance handles switching maintenance mode on or off
+func (h *AdminHandler) SetMaintenance(w http.ResponseWriter, r *http.Request) {
+	var state configs.MaintenanceConfig
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if state.RetryAfter < 0 {
+		utils.RespondError(w, http.StatusBadRequest, "retry_after cannot be negative")
+		return
+	}
+
+	// Keep the configured message unless a new one is provided
+	if state.Message == "" {
+		state.Message = h.live.Maintenance().Message
+	}
+
+	h.live.SetMaintenance(state)
+
+	userID, _ := r.Context().Value("user_id").(int)
+	h.logger.Warnf("Maintenance mode set to %t by user %d", state.Enabled, userID)
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "maintenance state updated successfully", state)
+}
+
+// GetOutboundHTTP handles retrieving the retries and circuit breaker states of the hosts of the
+// external services called by the API
+func (h *AdminHandler) GetOutboundHTTP(w http.ResponseWriter, r *http.Request) {
+	stats := []httpclient.HostStats{}
+	if h.outbound != nil {
+		stats = h.outbound.Stats()
+	}
+
+	utils.Respond(w, http.StatusOK, "outbound http stats retrieved successfully", stats)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
+	"banking-service/internal/models"
 	"banking-service/internal/service"
 	"banking-service/pkg/utils"
 )
@@ -33,7 +35,7 @@ func (h *AnalyticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request)
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -52,7 +54,7 @@ func (h *AnalyticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request)
 	}
 	
 	if !validPeriods[period] {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid period. Must be one of: week, month, quarter, year")
+		utils.RespondError(w, http.StatusBadRequest, "invalid period. Must be one of: week, month, quarter, year")
 		return
 	}
 	
@@ -60,12 +62,12 @@ func (h *AnalyticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request)
 	statistics, err := h.analyticsService.GetStatistics(r.Context(), userID, period)
 	if err != nil {
 		h.logger.Warnf("Failed to get statistics: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get statistics")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get statistics")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "statistics retrieved successfully", statistics)
+	utils.Respond(w, http.StatusOK, "statistics retrieved successfully", statistics)
 }
 
 // PredictBalance handles predicting future account balance
@@ -73,7 +75,7 @@ func (h *AnalyticsHandler) PredictBalance(w http.ResponseWriter, r *http.Request
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -81,7 +83,7 @@ func (h *AnalyticsHandler) PredictBalance(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	accountID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 		return
 	}
 	
@@ -92,7 +94,7 @@ func (h *AnalyticsHandler) PredictBalance(w http.ResponseWriter, r *http.Request
 	if daysStr != "" {
 		days, err = strconv.Atoi(daysStr)
 		if err != nil || days <= 0 {
-			utils.RespondWithError(w, http.StatusBadRequest, "invalid days parameter")
+			utils.RespondError(w, http.StatusBadRequest, "invalid days parameter")
 			return
 		}
 	}
@@ -101,12 +103,59 @@ func (h *AnalyticsHandler) PredictBalance(w http.ResponseWriter, r *http.Request
 	prediction, err := h.analyticsService.PredictBalance(r.Context(), accountID, userID, days)
 	if err != nil {
 		h.logger.Warnf("Failed to predict balance: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to predict balance")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to predict balance")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "balance prediction retrieved successfully", prediction)
+	utils.Respond(w, http.StatusOK, "balance prediction retrieved successfully", prediction)
+}
+
+// GetCardAnalytics handles retrieving the spending breakdown of a card
+func (h *AnalyticsHandler) GetCardAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get card ID from URL parameters
+	vars := mux.Vars(r)
+	cardID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
+		return
+	}
+	
+	// Get period from query parameters (default is "year")
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "year"
+	}
+	
+	// Valid periods: week, month, quarter, year
+	validPeriods := map[string]bool{
+		"week":    true,
+		"month":   true,
+		"quarter": true,
+		"year":    true,
+	}
+	
+	if !validPeriods[period] {
+		utils.RespondError(w, http.StatusBadRequest, "invalid period. Must be one of: week, month, quarter, year")
+		return
+	}
+	
+	analytics, err := h.analyticsService.GetCardAnalytics(r.Context(), cardID, userID, period)
+	if err != nil {
+		h.logger.Warnf("Failed to get card analytics: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "card not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "card analytics retrieved successfully", analytics)
 }
 
 // GetCreditAnalytics handles retrieving credit analytics for a user
@@ -114,7 +163,7 @@ func (h *AnalyticsHandler) GetCreditAnalytics(w http.ResponseWriter, r *http.Req
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -122,10 +171,99 @@ func (h *AnalyticsHandler) GetCreditAnalytics(w http.ResponseWriter, r *http.Req
 	analytics, err := h.analyticsService.GetCreditAnalytics(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get credit analytics: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get credit analytics")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get credit analytics")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit analytics retrieved successfully", analytics)
+}
+
+// GetSpendingForecast handles forecasting the month-end spending by category
+func (h *AnalyticsHandler) GetSpendingForecast(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	forecast, err := h.analyticsService.GetSpendingForecast(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get spending forecast: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get spending forecast")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "spending forecast retrieved successfully", forecast)
+}
+
+// GetRecommendations handles suggesting changes that save the user money
+func (h *AnalyticsHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	recommendations, err := h.analyticsService.GetRecommendations(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get recommendations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get recommendations")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "recommendations retrieved successfully", recommendations)
+}
+
+// SimulateCredit handles simulating how a new credit would change the user's finances
+func (h *AnalyticsHandler) SimulateCredit(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var request models.CreditSimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	simulation, err := h.analyticsService.SimulateCredit(r.Context(), userID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to simulate credit: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit simulation retrieved successfully", simulation)
+}
+
+// GetCashFlow handles retrieving the cash flow statement of the user's accounts
+func (h *AnalyticsHandler) GetCashFlow(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	query := r.URL.Query()
+	statement, err := h.analyticsService.GetCashFlow(r.Context(), userID, query.Get("granularity"), query.Get("from"), query.Get("to"))
+	if err != nil {
+		h.logger.Warnf("Failed to get cash flow statement: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "credit analytics retrieved successfully", analytics)
+	utils.Respond(w, http.StatusOK, "cash flow statement retrieved successfully", statement)
 }
\ No newline at end of file
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// AnnouncementHandler handles the announcements admins send to segments of customers
+type AnnouncementHandler struct {
+	announcementService service.AnnouncementService
+	logger              *logrus.Logger
+	config              *configs.Config
+}
+
+// NewAnnouncementHandler creates a new AnnouncementHandler
+func NewAnnouncementHandler(announcementService service.AnnouncementService, logger *logrus.Logger, config *configs.Config) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		announcementService: announcementService,
+		logger:              logger,
+		config:              config,
+	}
+}
+
+// Create handles queuing an announcement for a segment of customers
+func (h *AnnouncementHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var request models.AnnouncementRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	announcement, err := h.announcementService.Create(r.Context(), &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to create announcement: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "announcement queued successfully", announcement)
+}
+
+// GetAll handles listing the announcements with their delivery progress
+func (h *AnnouncementHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	announcements, err := h.announcementService.GetAll(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get announcements: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get announcements")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "announcements retrieved successfully", announcements)
+}
+
+// GetByID handles retrieving an announcement with its delivery progress
+func (h *AnnouncementHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get announcement ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid announcement ID")
+		return
+	}
+
+	announcement, err := h.announcementService.GetByID(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get announcement %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "announcement not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "announcement retrieved successfully", announcement)
+}
+
+// Cancel handles stopping the delivery of a queued announcement
+func (h *AnnouncementHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get announcement ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid announcement ID")
+		return
+	}
+
+	announcement, err := h.announcementService.Cancel(r.Context(), id, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to cancel announcement %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "announcement cancelled successfully", announcement)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// APIKeyHandler handles the API keys users issue for backend integrations
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+	logger        *logrus.Logger
+	config        *configs.Config
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler
+func NewAPIKeyHandler(apiKeyService service.APIKeyService, logger *logrus.Logger, config *configs.Config) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// Create handles issuing an API key limited to some scopes
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var request models.APIKeyCreate
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	credentials, err := h.apiKeyService.Create(r.Context(), userID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to create API key: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response; the key is not shown again
+	utils.Respond(w, http.StatusCreated, "API key issued successfully, store it securely", credentials)
+}
+
+// GetAll handles listing the API keys of the user
+func (h *APIKeyHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	keys, err := h.apiKeyService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get API keys: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get API keys")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "API keys retrieved successfully", keys)
+}
+
+// Rotate handles replacing the key of an API key
+func (h *APIKeyHandler) Rotate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get API key ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid API key ID")
+		return
+	}
+
+	credentials, err := h.apiKeyService.Rotate(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to rotate API key %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response; the key is not shown again
+	utils.Respond(w, http.StatusOK, "API key rotated successfully, store it securely", credentials)
+}
+
+// Revoke handles revoking an API key
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get API key ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(r.Context(), id, userID); err != nil {
+		h.logger.Warnf("Failed to revoke API key %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "API key revoked successfully", nil)
+}
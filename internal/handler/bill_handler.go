@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// BillHandler handles bill payment HTTP requests
+type BillHandler struct {
+	billService service.BillService
+	logger      *logrus.Logger
+	config      *configs.Config
+}
+
+// NewBillHandler creates a new BillHandler
+func NewBillHandler(billService service.BillService, logger *logrus.Logger, config *configs.Config) *BillHandler {
+	return &BillHandler{
+		billService: billService,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+// GetProviders handles listing the bill provider catalog
+func (h *BillHandler) GetProviders(w http.ResponseWriter, r *http.Request) {
+	category := models.BillCategory(strings.ToUpper(r.URL.Query().Get("category")))
+
+	providers, err := h.billService.GetProviders(r.Context(), category)
+	if err != nil {
+		h.logger.Warnf("Failed to get bill providers: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "bill providers retrieved successfully", providers)
+}
+
+// GetProvider handles retrieving a bill provider with its payment fields
+func (h *BillHandler) GetProvider(w http.ResponseWriter, r *http.Request) {
+	// Get provider ID from URL
+	vars := mux.Vars(r)
+	providerID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid provider ID")
+		return
+	}
+
+	provider, err := h.billService.GetProvider(r.Context(), providerID)
+	if err != nil {
+		h.logger.Warnf("Failed to get bill provider: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "bill provider not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "bill provider retrieved successfully", provider)
+}
+
+// Pay handles paying a bill
+func (h *BillHandler) Pay(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var request models.BillPaymentRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	payment, err := h.billService.Pay(r.Context(), &request, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to pay bill: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "bill paid successfully", payment)
+}
+
+// GetPayments handles listing the bill payment history of the user
+func (h *BillHandler) GetPayments(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	payments, err := h.billService.GetPayments(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get bill payments: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get bill payments")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "bill payments retrieved successfully", payments)
+}
+
+// PayAgain handles repeating a previous bill payment
+func (h *BillHandler) PayAgain(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get payment ID from URL
+	vars := mux.Vars(r)
+	paymentID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid payment ID")
+		return
+	}
+
+	repeat, err := decodeRepeatRequest(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	payment, err := h.billService.PayAgain(r.Context(), paymentID, repeat, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to repeat bill payment: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "bill paid successfully", payment)
+}
+
+// CreateTemplate handles saving a bill template
+func (h *BillHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var templateCreate models.BillTemplateCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&templateCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	templateID, err := h.billService.CreateTemplate(r.Context(), &templateCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create bill template: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "bill template created successfully", map[string]interface{}{
+		"template_id": templateID,
+	})
+}
+
+// GetTemplates handles listing the saved bill templates of the user
+func (h *BillHandler) GetTemplates(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	templates, err := h.billService.GetTemplates(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get bill templates: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get bill templates")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "bill templates retrieved successfully", templates)
+}
+
+// DeleteTemplate handles deleting a saved bill template
+func (h *BillHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get template ID from URL
+	vars := mux.Vars(r)
+	templateID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	if err := h.billService.DeleteTemplate(r.Context(), templateID, userID); err != nil {
+		h.logger.Warnf("Failed to delete bill template: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "bill template not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "bill template deleted successfully", nil)
+}
+
+// PayTemplate handles paying a bill from a saved template
+func (h *BillHandler) PayTemplate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get template ID from URL
+	vars := mux.Vars(r)
+	templateID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid template ID")
+		return
+	}
+
+	repeat, err := decodeRepeatRequest(r)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	payment, err := h.billService.PayTemplate(r.Context(), templateID, repeat, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to pay bill template: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "bill paid successfully", payment)
+}
+
+// decodeRepeatRequest parses the optional overrides of a repeated payment; an empty body reuses the saved values
+func decodeRepeatRequest(r *http.Request) (*models.BillRepeatRequest, error) {
+	defer r.Body.Close()
+
+	repeat := &models.BillRepeatRequest{}
+	if err := json.NewDecoder(r.Body).Decode(repeat); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return repeat, nil
+}
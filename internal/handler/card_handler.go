@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
@@ -35,7 +36,7 @@ func (h *CardHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -43,7 +44,7 @@ func (h *CardHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var cardCreate models.CardCreate
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&cardCreate); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -52,12 +53,12 @@ func (h *CardHandler) Create(w http.ResponseWriter, r *http.Request) {
 	cardID, err := h.cardService.Create(r.Context(), &cardCreate, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to create card: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusCreated, "card created successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusCreated, "card created successfully", map[string]interface{}{
 		"card_id": cardID,
 	})
 }
@@ -67,7 +68,7 @@ func (h *CardHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -76,7 +77,7 @@ func (h *CardHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	if accountIDStr != "" {
 		accountID, err := strconv.Atoi(accountIDStr)
 		if err != nil {
-			utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+			utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 			return
 		}
 		
@@ -84,11 +85,11 @@ func (h *CardHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		cards, err := h.cardService.GetByAccountID(r.Context(), accountID, userID)
 		if err != nil {
 			h.logger.Warnf("Failed to get cards for account: %v", err)
-			utils.RespondWithError(w, http.StatusInternalServerError, "failed to get cards")
+			utils.RespondError(w, http.StatusInternalServerError, "failed to get cards")
 			return
 		}
 		
-		utils.RespondWithSuccess(w, http.StatusOK, "cards retrieved successfully", cards)
+		utils.Respond(w, http.StatusOK, "cards retrieved successfully", cards)
 		return
 	}
 	
@@ -96,12 +97,12 @@ func (h *CardHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	cards, err := h.cardService.GetByUserID(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get cards: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get cards")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get cards")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "cards retrieved successfully", cards)
+	utils.Respond(w, http.StatusOK, "cards retrieved successfully", cards)
 }
 
 // GetByID handles retrieving a specific card by ID
@@ -109,7 +110,7 @@ func (h *CardHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -117,7 +118,7 @@ func (h *CardHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	cardID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid card ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
 		return
 	}
 	
@@ -125,12 +126,12 @@ func (h *CardHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	card, err := h.cardService.GetByID(r.Context(), cardID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get card: %v", err)
-		utils.RespondWithError(w, http.StatusNotFound, "card not found")
+		utils.RespondError(w, http.StatusNotFound, "card not found")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "card retrieved successfully", card)
+	utils.Respond(w, http.StatusOK, "card retrieved successfully", card)
 }
 
 // Update handles updating card status
@@ -138,7 +139,7 @@ func (h *CardHandler) Update(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -146,7 +147,7 @@ func (h *CardHandler) Update(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	cardID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid card ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
 		return
 	}
 	
@@ -157,7 +158,7 @@ func (h *CardHandler) Update(w http.ResponseWriter, r *http.Request) {
 	
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&cardUpdate); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -172,12 +173,12 @@ func (h *CardHandler) Update(w http.ResponseWriter, r *http.Request) {
 	err = h.cardService.Update(r.Context(), card, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to update card: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "card updated successfully", nil)
+	utils.Respond(w, http.StatusOK, "card updated successfully", nil)
 }
 
 // Delete handles card deletion (deactivation)
@@ -185,7 +186,7 @@ func (h *CardHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -193,7 +194,7 @@ func (h *CardHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	cardID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid card ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
 		return
 	}
 	
@@ -201,10 +202,147 @@ func (h *CardHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	err = h.cardService.Delete(r.Context(), cardID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to delete card: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "card deleted successfully", nil)
+	utils.Respond(w, http.StatusOK, "card deleted successfully", nil)
+}
+
+// SetPIN handles setting the PIN of a card
+func (h *CardHandler) SetPIN(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get card ID from URL parameters
+	vars := mux.Vars(r)
+	cardID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
+		return
+	}
+	
+	// Parse request body
+	var pin models.CardPIN
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&pin); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	err = h.cardService.SetPIN(r.Context(), cardID, userID, &pin)
+	if err != nil {
+		h.logger.Warnf("Failed to set card PIN: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "PIN set successfully", nil)
+}
+
+// GetTransactions handles listing the transactions made with a card with optional from/to dates
+// (YYYY-MM-DD, both inclusive) and limit/offset paging
+func (h *CardHandler) GetTransactions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get card ID from URL parameters
+	vars := mux.Vars(r)
+	cardID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid card ID")
+		return
+	}
+	
+	filter := &models.CardTransactionFilter{}
+	query := r.URL.Query()
+	
+	if from := query.Get("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid from date format")
+			return
+		}
+		filter.From = &date
+	}
+	
+	if to := query.Get("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid to date format")
+			return
+		}
+		// Add one day to include transactions on that day
+		date = date.AddDate(0, 0, 1)
+		filter.To = &date
+	}
+	
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+	
+	if offset := query.Get("offset"); offset != "" {
+		if filter.Offset, err = strconv.Atoi(offset); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+	}
+	
+	page, err := h.cardService.GetTransactions(r.Context(), cardID, userID, filter)
+	if err != nil {
+		h.logger.Warnf("Failed to get card transactions: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.RespondPage(w, http.StatusOK, "card transactions retrieved successfully", page, utils.Pagination{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+// ATMWithdraw handles a simulated cash withdrawal at an ATM with a card and its PIN
+func (h *CardHandler) ATMWithdraw(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var withdrawal models.ATMWithdrawalRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&withdrawal); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	transactionID, err := h.cardService.ATMWithdraw(r.Context(), userID, &withdrawal)
+	if err != nil {
+		h.logger.Warnf("Failed to withdraw at ATM: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "cash withdrawn successfully", map[string]interface{}{
+		"transaction_id": transactionID,
+	})
 }
\ No newline at end of file
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// CardProductHandler handles card product catalog HTTP requests
+type CardProductHandler struct {
+	cardProductService service.CardProductService
+	logger             *logrus.Logger
+	config             *configs.Config
+}
+
+// NewCardProductHandler creates a new CardProductHandler
+func NewCardProductHandler(cardProductService service.CardProductService, logger *logrus.Logger, config *configs.Config) *CardProductHandler {
+	return &CardProductHandler{
+		cardProductService: cardProductService,
+		logger:             logger,
+		config:             config,
+	}
+}
+
+// GetAll handles listing the card products cards can be issued for
+func (h *CardProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	products, err := h.cardProductService.GetActive(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get card products: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get card products")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "card products retrieved successfully", products)
+}
+
+// AdminGetAll handles listing all card products, including inactive ones
+func (h *CardProductHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	products, err := h.cardProductService.GetAll(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get card products: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get card products")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "card products retrieved successfully", products)
+}
+
+// Create handles adding a card product
+func (h *CardProductHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var request models.CardProductRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	product, err := h.cardProductService.Create(r.Context(), &request)
+	if err != nil {
+		h.logger.Warnf("Failed to create card product: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "card product created successfully", product)
+}
+
+// Update handles replacing the data of a card product; set is_active to false to stop issuing it
+func (h *CardProductHandler) Update(w http.ResponseWriter, r *http.Request) {
+	// Get card product ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid card product ID")
+		return
+	}
+
+	// Parse request body
+	var request models.CardProductRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	product, err := h.cardProductService.Update(r.Context(), id, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to update card product: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "card product updated successfully", product)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// ChargebackHandler handles cardholder, merchant API and admin chargeback HTTP requests
+type ChargebackHandler struct {
+	chargebackService service.ChargebackService
+	logger            *logrus.Logger
+	config            *configs.Config
+}
+
+// NewChargebackHandler creates a new ChargebackHandler
+func NewChargebackHandler(chargebackService service.ChargebackService, logger *logrus.Logger, config *configs.Config) *ChargebackHandler {
+	return &ChargebackHandler{
+		chargebackService: chargebackService,
+		logger:            logger,
+		config:            config,
+	}
+}
+
+// Create handles a cardholder charging back a card payment
+func (h *ChargebackHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var chargebackCreate models.ChargebackCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&chargebackCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	chargeback, err := h.chargebackService.Create(r.Context(), &chargebackCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create chargeback: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "chargeback opened, the amount has been provisionally credited", chargeback)
+}
+
+// GetAll handles listing the user's chargebacks
+func (h *ChargebackHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	chargebacks, err := h.chargebackService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get chargebacks: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get chargebacks")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargebacks retrieved successfully", chargebacks)
+}
+
+// GetByID handles retrieving one of the user's chargebacks
+func (h *ChargebackHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get chargeback ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid chargeback ID")
+		return
+	}
+
+	chargeback, err := h.chargebackService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get chargeback: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "chargeback not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargeback retrieved successfully", chargeback)
+}
+
+// GetMerchantChargebacks handles a merchant listing the chargebacks against it
+func (h *ChargebackHandler) GetMerchantChargebacks(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	chargebacks, err := h.chargebackService.GetForMerchant(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get chargebacks: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get chargebacks")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargebacks retrieved successfully", chargebacks)
+}
+
+// SubmitEvidence handles a merchant contesting a chargeback
+func (h *ChargebackHandler) SubmitEvidence(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Get chargeback ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid chargeback ID")
+		return
+	}
+
+	// Parse request body
+	var evidence models.ChargebackEvidence
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&evidence); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.chargebackService.SubmitEvidence(r.Context(), id, merchantID, &evidence); err != nil {
+		h.logger.Warnf("Failed to submit chargeback evidence: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "evidence submitted, the chargeback is under review", nil)
+}
+
+// Accept handles a merchant accepting a chargeback
+func (h *ChargebackHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Get chargeback ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid chargeback ID")
+		return
+	}
+
+	if err := h.chargebackService.Accept(r.Context(), id, merchantID); err != nil {
+		h.logger.Warnf("Failed to accept chargeback: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargeback accepted", nil)
+}
+
+// AdminGetAll handles listing chargebacks for review, optionally filtered by status
+func (h *ChargebackHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.ChargebackStatus(strings.ToUpper(r.URL.Query().Get("status")))
+
+	chargebacks, err := h.chargebackService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get chargebacks: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get chargebacks")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargebacks retrieved successfully", chargebacks)
+}
+
+// Resolve handles the bank's final decision on a chargeback
+func (h *ChargebackHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	// Get chargeback ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid chargeback ID")
+		return
+	}
+
+	// Parse request body
+	var resolution models.ChargebackResolution
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&resolution); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	chargeback, err := h.chargebackService.Resolve(r.Context(), id, &resolution)
+	if err != nil {
+		h.logger.Warnf("Failed to resolve chargeback: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "chargeback resolved", chargeback)
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// maxChequeImagePayload fits the largest image plus the multipart framing and form fields
+const maxChequeImagePayload = models.MaxChequeImageSize + 64<<10
+
+// ChequeDepositHandler handles cheque deposit HTTP requests for customers and the bank
+type ChequeDepositHandler struct {
+	chequeDepositService service.ChequeDepositService
+	logger               *logrus.Logger
+	config               *configs.Config
+}
+
+// NewChequeDepositHandler creates a new ChequeDepositHandler
+func NewChequeDepositHandler(chequeDepositService service.ChequeDepositService, logger *logrus.Logger, config *configs.Config) *ChequeDepositHandler {
+	return &ChequeDepositHandler{
+		chequeDepositService: chequeDepositService,
+		logger:               logger,
+		config:               config,
+	}
+}
+
+// Submit handles a customer depositing a cheque or payment order by uploading its image
+func (h *ChequeDepositHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChequeImagePayload)
+	if err := r.ParseMultipartForm(maxChequeImagePayload); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid multipart form or file larger than 10 MB")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	accountID, err := strconv.Atoi(r.FormValue("account_id"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	// The amount is optional; without it the amount recognized on the image is deposited
+	var amount float64
+	if value := r.FormValue("amount"); value != "" {
+		amount, err = strconv.ParseFloat(value, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid amount")
+			return
+		}
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	deposit := &models.ChequeDeposit{
+		AccountID: accountID,
+		Amount:    amount,
+		FileName:  header.Filename,
+	}
+
+	deposit, err = h.chequeDepositService.Submit(r.Context(), deposit, content, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to submit cheque deposit: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "cheque deposit submitted successfully", deposit)
+}
+
+// GetAll handles listing the customer's cheque deposits
+func (h *ChequeDepositHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	deposits, err := h.chequeDepositService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get cheque deposits: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get cheque deposits")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "cheque deposits retrieved successfully", deposits)
+}
+
+// GetByID handles retrieving one of the customer's cheque deposits
+func (h *ChequeDepositHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get deposit ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid cheque deposit ID")
+		return
+	}
+
+	deposit, err := h.chequeDepositService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get cheque deposit: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "cheque deposit not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "cheque deposit retrieved successfully", deposit)
+}
+
+// AdminGetAll handles listing the cheque deposits of all customers, optionally by status
+func (h *ChequeDepositHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.ChequeDepositStatus(r.URL.Query().Get("status"))
+
+	deposits, err := h.chequeDepositService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get cheque deposits: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get cheque deposits")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "cheque deposits retrieved successfully", deposits)
+}
+
+// AdminDownloadImage handles downloading the image of any cheque deposit for review
+func (h *ChequeDepositHandler) AdminDownloadImage(w http.ResponseWriter, r *http.Request) {
+	// Get deposit ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid cheque deposit ID")
+		return
+	}
+
+	deposit, content, err := h.chequeDepositService.GetImage(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get cheque image: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "cheque deposit not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", deposit.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", deposit.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// Approve handles the bank clearing a cheque deposit
+func (h *ChequeDepositHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.chequeDepositService.Approve, "cheque deposit cleared successfully")
+}
+
+// Reject handles the bank rejecting a cheque deposit
+func (h *ChequeDepositHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.chequeDepositService.Reject, "cheque deposit rejected successfully")
+}
+
+// decide applies a clearing decision with a note in the body
+func (h *ChequeDepositHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, id int, decision *models.ChequeDepositDecision, adminID int) (*models.ChequeDeposit, error),
+	success string,
+) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get deposit ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid cheque deposit ID")
+		return
+	}
+
+	// The note is only required to reject, so an empty body is accepted
+	var decision models.ChequeDepositDecision
+	if err := json.NewDecoder(r.Body).Decode(&decision); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	deposit, err := apply(r.Context(), id, &decision, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to decide on cheque deposit %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, success, deposit)
+}
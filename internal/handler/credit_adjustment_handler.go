@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// CreditAdjustmentHandler handles the bank's penalty waiver, rescheduling and restructuring HTTP requests
+type CreditAdjustmentHandler struct {
+	creditAdjustmentService service.CreditAdjustmentService
+	logger                  *logrus.Logger
+	config                  *configs.Config
+}
+
+// NewCreditAdjustmentHandler creates a new CreditAdjustmentHandler
+func NewCreditAdjustmentHandler(creditAdjustmentService service.CreditAdjustmentService, logger *logrus.Logger, config *configs.Config) *CreditAdjustmentHandler {
+	return &CreditAdjustmentHandler{
+		creditAdjustmentService: creditAdjustmentService,
+		logger:                  logger,
+		config:                  config,
+	}
+}
+
+// Get handles retrieving any credit with its payment schedule and adjustment history
+func (h *CreditAdjustmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Get credit ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	credit, err := h.creditAdjustmentService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "credit not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit retrieved successfully", credit)
+}
+
+// WaivePenalty handles waiving the penalty of a schedule item
+func (h *CreditAdjustmentHandler) WaivePenalty(w http.ResponseWriter, r *http.Request) {
+	adminID, id, paymentID, ok := parseCreditPaymentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var request models.PenaltyWaiverRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	adjustment, err := h.creditAdjustmentService.WaivePenalty(r.Context(), id, paymentID, &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to waive penalty of payment %d: %v", paymentID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "penalty waived successfully", adjustment)
+}
+
+// Reschedule handles moving a schedule item to another date
+func (h *CreditAdjustmentHandler) Reschedule(w http.ResponseWriter, r *http.Request) {
+	adminID, id, paymentID, ok := parseCreditPaymentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var request models.RescheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	adjustment, err := h.creditAdjustmentService.Reschedule(r.Context(), id, paymentID, &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to reschedule payment %d: %v", paymentID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment rescheduled successfully", adjustment)
+}
+
+// Restructure handles spreading the unpaid principal of a credit over a new term
+func (h *CreditAdjustmentHandler) Restructure(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get credit ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	// Parse request body
+	var request models.RestructureRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	adjustment, err := h.creditAdjustmentService.Restructure(r.Context(), id, &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to restructure credit %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit restructured successfully", adjustment)
+}
+
+// parseCreditPaymentIDs reads the admin from the context and the credit and payment IDs from the URL
+func parseCreditPaymentIDs(w http.ResponseWriter, r *http.Request) (int, int, int, bool) {
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return 0, 0, 0, false
+	}
+
+	vars := mux.Vars(r)
+
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return 0, 0, 0, false
+	}
+
+	paymentID, err := strconv.Atoi(vars["paymentId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid payment ID")
+		return 0, 0, 0, false
+	}
+
+	return adminID, id, paymentID, true
+}
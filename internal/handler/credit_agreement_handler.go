@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// CreditAgreementHandler handles credit agreement signing HTTP requests
+type CreditAgreementHandler struct {
+	creditAgreementService service.CreditAgreementService
+	logger                 *logrus.Logger
+	config                 *configs.Config
+}
+
+// NewCreditAgreementHandler creates a new CreditAgreementHandler
+func NewCreditAgreementHandler(creditAgreementService service.CreditAgreementService, logger *logrus.Logger, config *configs.Config) *CreditAgreementHandler {
+	return &CreditAgreementHandler{
+		creditAgreementService: creditAgreementService,
+		logger:                 logger,
+		config:                 config,
+	}
+}
+
+// Get handles retrieving the agreement of a credit with its hash and signature
+func (h *CreditAgreementHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get credit ID from URL
+	creditID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	agreement, err := h.creditAgreementService.GetAgreement(r.Context(), creditID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit agreement: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "credit not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit agreement retrieved successfully", agreement)
+}
+
+// Sign handles a request to sign the agreement, which sends a one-time code to the user
+func (h *CreditAgreementHandler) Sign(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get credit ID from URL
+	creditID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	request, err := h.creditAgreementService.RequestSignature(r.Context(), creditID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to request credit signature: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusAccepted, "signing code sent, confirm it to sign the agreement", request)
+}
+
+// Confirm handles signing the agreement with the one-time code
+func (h *CreditAgreementHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get credit ID from URL
+	creditID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+
+	// Parse request body
+	var confirm models.SignatureConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&confirm); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	signature, err := h.creditAgreementService.ConfirmSignature(r.Context(), creditID, &confirm, userID, clientInfo(r))
+	if err != nil {
+		h.logger.Warnf("Failed to sign credit agreement: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "credit agreement signed successfully", signature)
+}
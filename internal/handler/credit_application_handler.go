@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// maxCreditDocumentPayload fits the largest document plus the multipart framing and form fields
+const maxCreditDocumentPayload = models.MaxCreditDocumentSize + 64<<10
+
+// CreditApplicationHandler handles credit application HTTP requests for customers and the bank
+type CreditApplicationHandler struct {
+	creditApplicationService service.CreditApplicationService
+	logger                   *logrus.Logger
+	config                   *configs.Config
+}
+
+// NewCreditApplicationHandler creates a new CreditApplicationHandler
+func NewCreditApplicationHandler(creditApplicationService service.CreditApplicationService, logger *logrus.Logger, config *configs.Config) *CreditApplicationHandler {
+	return &CreditApplicationHandler{
+		creditApplicationService: creditApplicationService,
+		logger:                   logger,
+		config:                   config,
+	}
+}
+
+// Create handles submitting a credit application
+func (h *CreditApplicationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var creditRequest models.CreditRequest
+	if err := json.NewDecoder(r.Body).Decode(&creditRequest); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	// Set the user ID from the authenticated user
+	creditRequest.UserID = userID
+
+	application, err := h.creditApplicationService.Create(r.Context(), &creditRequest)
+	if err != nil {
+		h.logger.Warnf("Failed to create credit application: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "credit application submitted successfully", application)
+}
+
+// GetAll handles listing the customer's credit applications
+func (h *CreditApplicationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	applications, err := h.creditApplicationService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit applications: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get credit applications")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit applications retrieved successfully", applications)
+}
+
+// GetByID handles retrieving one of the customer's credit applications with its documents
+func (h *CreditApplicationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get application ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit application ID")
+		return
+	}
+
+	application, err := h.creditApplicationService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit application: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "credit application not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit application retrieved successfully", application)
+}
+
+// UploadDocument handles a multipart upload of a supporting document with fields "type" and "file"
+func (h *CreditApplicationHandler) UploadDocument(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get application ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit application ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCreditDocumentPayload)
+	if err := r.ParseMultipartForm(maxCreditDocumentPayload); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid multipart form or file larger than 10 MB")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	doc := &models.CreditDocument{
+		Type:     models.CreditDocumentType(r.FormValue("type")),
+		FileName: header.Filename,
+	}
+
+	doc, err = h.creditApplicationService.UploadDocument(r.Context(), id, doc, content, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to upload credit document: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "document uploaded successfully", doc)
+}
+
+// DownloadDocument handles downloading a document of one of the customer's applications
+func (h *CreditApplicationHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	id, documentID, ok := parseCreditDocumentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.creditApplicationService.GetDocument(r.Context(), id, documentID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit document: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "document not found")
+		return
+	}
+
+	writeCreditDocument(w, doc, content)
+}
+
+// AdminGetAll handles listing credit applications for review, optionally filtered by ?status=
+func (h *CreditApplicationHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.CreditApplicationStatus(r.URL.Query().Get("status"))
+
+	applications, err := h.creditApplicationService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit applications: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get credit applications")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit applications retrieved successfully", applications)
+}
+
+// AdminGetByID handles retrieving any credit application with its documents
+func (h *CreditApplicationHandler) AdminGetByID(w http.ResponseWriter, r *http.Request) {
+	// Get application ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit application ID")
+		return
+	}
+
+	application, err := h.creditApplicationService.GetForReview(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit application: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "credit application not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit application retrieved successfully", application)
+}
+
+// AdminDownloadDocument handles downloading a document of any application
+func (h *CreditApplicationHandler) AdminDownloadDocument(w http.ResponseWriter, r *http.Request) {
+	id, documentID, ok := parseCreditDocumentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.creditApplicationService.GetDocumentForReview(r.Context(), id, documentID)
+	if err != nil {
+		h.logger.Warnf("Failed to get credit document: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "document not found")
+		return
+	}
+
+	writeCreditDocument(w, doc, content)
+}
+
+// ReviewDocument handles the bank accepting or rejecting a document
+func (h *CreditApplicationHandler) ReviewDocument(w http.ResponseWriter, r *http.Request) {
+	id, documentID, ok := parseCreditDocumentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	// Parse request body
+	var review models.CreditDocumentReview
+	if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	application, err := h.creditApplicationService.ReviewDocument(r.Context(), id, documentID, &review)
+	if err != nil {
+		h.logger.Warnf("Failed to review credit document: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "document reviewed successfully", application)
+}
+
+// Approve handles the bank approving an application and issuing its credit
+func (h *CreditApplicationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.creditApplicationService.Approve, "credit application approved successfully")
+}
+
+// Reject handles the bank rejecting an application
+func (h *CreditApplicationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.creditApplicationService.Reject, "credit application rejected successfully")
+}
+
+// decide applies an approval or rejection with an optional note in the body
+func (h *CreditApplicationHandler) decide(
+	w http.ResponseWriter,
+	r *http.Request,
+	apply func(ctx context.Context, id int, decision *models.CreditApplicationDecision, adminID int) (*models.CreditApplication, error),
+	success string,
+) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get application ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit application ID")
+		return
+	}
+
+	// The note is optional, so an empty body is accepted
+	var decision models.CreditApplicationDecision
+	if err := json.NewDecoder(r.Body).Decode(&decision); err != nil && err != io.EOF {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	application, err := apply(r.Context(), id, &decision, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to decide on credit application %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, success, application)
+}
+
+// parseCreditDocumentIDs reads the application and document IDs of a document URL
+func parseCreditDocumentIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit application ID")
+		return 0, 0, false
+	}
+
+	documentID, err := strconv.Atoi(vars["documentId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid document ID")
+		return 0, 0, false
+	}
+
+	return id, documentID, true
+}
+
+// writeCreditDocument sends a credit document as a file download
+func writeCreditDocument(w http.ResponseWriter, doc *models.CreditDocument, content []byte) {
+	w.Header().Set("Content-Type", doc.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", doc.FileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
@@ -35,7 +35,7 @@ func (h *CreditHandler) Create(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -43,7 +43,7 @@ func (h *CreditHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var creditRequest models.CreditRequest
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&creditRequest); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -55,22 +55,43 @@ func (h *CreditHandler) Create(w http.ResponseWriter, r *http.Request) {
 	creditID, err := h.creditService.Create(r.Context(), &creditRequest)
 	if err != nil {
 		h.logger.Warnf("Failed to create credit: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusCreated, "credit created successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusCreated, "credit created successfully", map[string]interface{}{
 		"credit_id": creditID,
 	})
 }
 
+// Calculate handles the public loan calculator; nothing is stored
+func (h *CreditHandler) Calculate(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var creditRequest models.CreditRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&creditRequest); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	calculation, err := h.creditService.Calculate(r.Context(), &creditRequest)
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit calculated successfully", calculation)
+}
+
 // GetAll handles retrieving all credits for a user
 func (h *CreditHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -78,12 +99,12 @@ func (h *CreditHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	credits, err := h.creditService.GetByUserID(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get credits: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get credits")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get credits")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "credits retrieved successfully", credits)
+	utils.RespondWithFields(w, r, http.StatusOK, "credits retrieved successfully", credits)
 }
 
 // GetByID handles retrieving a specific credit by ID
@@ -91,7 +112,7 @@ func (h *CreditHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -99,7 +120,7 @@ func (h *CreditHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	creditID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid credit ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
 		return
 	}
 	
@@ -107,12 +128,12 @@ func (h *CreditHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	credit, err := h.creditService.GetByID(r.Context(), creditID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get credit: %v", err)
-		utils.RespondWithError(w, http.StatusNotFound, "credit not found")
+		utils.RespondError(w, http.StatusNotFound, "credit not found")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "credit retrieved successfully", credit)
+	utils.Respond(w, http.StatusOK, "credit retrieved successfully", credit)
 }
 
 // GetSchedule handles retrieving the payment schedule for a credit
@@ -120,7 +141,7 @@ func (h *CreditHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -128,7 +149,7 @@ func (h *CreditHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	creditID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid credit ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
 		return
 	}
 	
@@ -136,7 +157,7 @@ func (h *CreditHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 	schedule, summary, err := h.creditService.GetSchedule(r.Context(), creditID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get payment schedule: %v", err)
-		utils.RespondWithError(w, http.StatusNotFound, "payment schedule not found")
+		utils.RespondError(w, http.StatusNotFound, "payment schedule not found")
 		return
 	}
 	
@@ -146,21 +167,82 @@ func (h *CreditHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
 		"summary":  summary,
 	}
 	
-	utils.RespondWithSuccess(w, http.StatusOK, "payment schedule retrieved successfully", response)
+	utils.Respond(w, http.StatusOK, "payment schedule retrieved successfully", response)
+}
+
+// GetPayoffQuote handles calculating the savings of an early repayment
+func (h *CreditHandler) GetPayoffQuote(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get credit ID from URL parameters
+	vars := mux.Vars(r)
+	creditID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+	
+	extraPayment, err := strconv.ParseFloat(r.URL.Query().Get("extra_payment"), 64)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "extra_payment must be a number")
+		return
+	}
+	
+	quote, err := h.creditService.GetPayoffQuote(r.Context(), creditID, userID, extraPayment)
+	if err != nil {
+		h.logger.Warnf("Failed to calculate payoff quote: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payoff quote calculated successfully", quote)
+}
+
+// CancelInsurance handles cancelling the insurance of a credit
+func (h *CreditHandler) CancelInsurance(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get credit ID from URL parameters
+	vars := mux.Vars(r)
+	creditID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid credit ID")
+		return
+	}
+	
+	// Cancel the insurance
+	policy, err := h.creditService.CancelInsurance(r.Context(), creditID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to cancel credit insurance: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit insurance cancelled successfully", policy)
 }
 
-// GetKeyRate handles retrieving the current central bank key rate
+// GetKeyRate handles retrieving the key rate credits are priced on and where it comes from
 func (h *CreditHandler) GetKeyRate(w http.ResponseWriter, r *http.Request) {
 	// Get the key rate
 	keyRate, err := h.creditService.GetKeyRate(r.Context())
 	if err != nil {
 		h.logger.Warnf("Failed to get key rate: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get key rate")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get key rate")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "key rate retrieved successfully", map[string]interface{}{
-		"key_rate": keyRate,
-	})
+	utils.Respond(w, http.StatusOK, "key rate retrieved successfully", keyRate)
 }
\ No newline at end of file
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// DelegationHandler handles power of attorney HTTP requests
+type DelegationHandler struct {
+	delegationService service.DelegationService
+	logger            *logrus.Logger
+	config            *configs.Config
+}
+
+// NewDelegationHandler creates a new DelegationHandler
+func NewDelegationHandler(delegationService service.DelegationService, logger *logrus.Logger, config *configs.Config) *DelegationHandler {
+	return &DelegationHandler{
+		delegationService: delegationService,
+		logger:            logger,
+		config:            config,
+	}
+}
+
+// Grant handles giving another user access to an account
+func (h *DelegationHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var request models.DelegationCreate
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	delegation, err := h.delegationService.Grant(r.Context(), &request, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to grant delegation: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "delegation granted successfully", delegation)
+}
+
+// GetGranted handles listing the delegations the user granted
+func (h *DelegationHandler) GetGranted(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	delegations, err := h.delegationService.GetGranted(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get delegations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get delegations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "delegations retrieved successfully", delegations)
+}
+
+// GetReceived handles listing the delegations granted to the user
+func (h *DelegationHandler) GetReceived(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	delegations, err := h.delegationService.GetReceived(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get delegations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get delegations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "delegations retrieved successfully", delegations)
+}
+
+// Revoke handles ending a delegation, by its owner or its delegate
+func (h *DelegationHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get delegation ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid delegation ID")
+		return
+	}
+
+	delegation, err := h.delegationService.Revoke(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to revoke delegation %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "delegation revoked successfully", delegation)
+}
+
+// GetEvents handles retrieving the audit log of a delegation
+func (h *DelegationHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get delegation ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid delegation ID")
+		return
+	}
+
+	events, err := h.delegationService.GetEvents(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get events of delegation %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "delegation not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "delegation events retrieved successfully", events)
+}
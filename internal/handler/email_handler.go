@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// maxEmailWebhookBody limits the size of a batch of provider events
+const maxEmailWebhookBody = 1 << 20
+
+// EmailHandler handles the email provider's delivery webhook and the admin views of email deliveries
+type EmailHandler struct {
+	emailService service.EmailService
+	logger       *logrus.Logger
+	config       *configs.Config
+}
+
+// NewEmailHandler creates a new EmailHandler
+func NewEmailHandler(emailService service.EmailService, logger *logrus.Logger, config *configs.Config) *EmailHandler {
+	return &EmailHandler{
+		emailService: emailService,
+		logger:       logger,
+		config:       config,
+	}
+}
+
+// HandleEvents handles a batch of delivery events posted by the email provider, signed in the
+// X-Email-Signature header
+func (h *EmailHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxEmailWebhookBody))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if err := h.emailService.VerifyWebhook(payload, r.Header.Get("X-Email-Signature")); err != nil {
+		if errors.Is(err, service.ErrEmailWebhookDisabled) {
+			utils.RespondErrorFrom(w, http.StatusNotFound, err)
+			return
+		}
+		h.logger.Warnf("Rejected email webhook from %s: %v", r.RemoteAddr, err)
+		utils.RespondErrorFrom(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	var events []*models.EmailEvent
+	if err := json.Unmarshal(payload, &events); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	if err := h.emailService.HandleEvents(r.Context(), events); err != nil {
+		h.logger.Warnf("Failed to handle email events: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "email events processed successfully", nil)
+}
+
+// GetDeliveries handles listing the most recent emails sent, optionally by status or recipient
+func (h *EmailHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	status := models.EmailDeliveryStatus(r.URL.Query().Get("status"))
+
+	deliveries, err := h.emailService.GetDeliveries(r.Context(), status, r.URL.Query().Get("recipient"))
+	if err != nil {
+		h.logger.Warnf("Failed to get email deliveries: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "email deliveries retrieved successfully", deliveries)
+}
+
+// GetSuppressions handles listing the addresses emails are no longer sent to
+func (h *EmailHandler) GetSuppressions(w http.ResponseWriter, r *http.Request) {
+	suppressions, err := h.emailService.GetSuppressions(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get email suppressions: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get email suppressions")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "email suppressions retrieved successfully", suppressions)
+}
+
+// DeleteSuppression handles lifting the suppression of an address
+func (h *EmailHandler) DeleteSuppression(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get suppression ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid email suppression ID")
+		return
+	}
+
+	if err := h.emailService.DeleteSuppression(r.Context(), id, adminID); err != nil {
+		h.logger.Warnf("Failed to delete email suppression %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusNotFound, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "email suppression deleted successfully", nil)
+}
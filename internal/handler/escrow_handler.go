@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// EscrowHandler handles customer and admin escrow payment HTTP requests
+type EscrowHandler struct {
+	escrowService service.EscrowService
+	logger        *logrus.Logger
+	config        *configs.Config
+}
+
+// NewEscrowHandler creates a new EscrowHandler
+func NewEscrowHandler(escrowService service.EscrowService, logger *logrus.Logger, config *configs.Config) *EscrowHandler {
+	return &EscrowHandler{
+		escrowService: escrowService,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// Create handles a buyer paying into escrow
+func (h *EscrowHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var escrowCreate models.EscrowCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&escrowCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	escrow, err := h.escrowService.Create(r.Context(), &escrowCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create escrow: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "escrow funded, the amount is held until both parties confirm the deal", escrow)
+}
+
+// GetAll handles listing the escrows the user is a party of
+func (h *EscrowHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	escrows, err := h.escrowService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get escrows: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get escrows")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "escrows retrieved successfully", escrows)
+}
+
+// GetByID handles retrieving an escrow the user is a party of
+func (h *EscrowHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get escrow ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid escrow ID")
+		return
+	}
+
+	escrow, err := h.escrowService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get escrow: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "escrow not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "escrow retrieved successfully", escrow)
+}
+
+// Confirm handles the buyer or the seller confirming the deal
+func (h *EscrowHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get escrow ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid escrow ID")
+		return
+	}
+
+	escrow, err := h.escrowService.Confirm(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to confirm escrow: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "escrow confirmed", escrow)
+}
+
+// AdminGetAll handles listing escrows for arbitration, optionally filtered by status
+func (h *EscrowHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.EscrowStatus(strings.ToUpper(r.URL.Query().Get("status")))
+
+	escrows, err := h.escrowService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get escrows: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "escrows retrieved successfully", escrows)
+}
+
+// Resolve handles an admin arbiter releasing or refunding an escrow
+func (h *EscrowHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get escrow ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid escrow ID")
+		return
+	}
+
+	// Parse request body
+	var resolution models.EscrowResolution
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&resolution); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	escrow, err := h.escrowService.Resolve(r.Context(), id, &resolution, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to resolve escrow: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "escrow resolved", escrow)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// FeeHandler handles the admin HTTP requests about recurring fees
+type FeeHandler struct {
+	feeService service.FeeService
+	logger     *logrus.Logger
+	config     *configs.Config
+}
+
+// NewFeeHandler creates a new FeeHandler
+func NewFeeHandler(feeService service.FeeService, logger *logrus.Logger, config *configs.Config) *FeeHandler {
+	return &FeeHandler{
+		feeService: feeService,
+		logger:     logger,
+		config:     config,
+	}
+}
+
+// GetCharges handles listing the recurring fee charges, optionally by status
+func (h *FeeHandler) GetCharges(w http.ResponseWriter, r *http.Request) {
+	status := models.FeeChargeStatus(r.URL.Query().Get("status"))
+
+	charges, err := h.feeService.GetCharges(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get fee charges: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "fee charges retrieved successfully", charges)
+}
+
+// GetRuns handles listing the summaries of the recent runs of the recurring fee job
+func (h *FeeHandler) GetRuns(w http.ResponseWriter, r *http.Request) {
+	runs, err := h.feeService.GetRuns(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get fee runs: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get fee runs")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "fee runs retrieved successfully", runs)
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
 	"banking-service/internal/service"
+	"banking-service/pkg/captcha"
+	"banking-service/pkg/httpclient"
 )
 
 // Dependencies contains handler dependencies
@@ -12,26 +16,106 @@ type Dependencies struct {
 	Services *service.Service
 	Logger   *logrus.Logger
 	Config   *configs.Config
+	Live     *configs.Live
+	Captcha  captcha.Verifier   // nil when CAPTCHA is disabled
+	HTTP     *httpclient.Client // client of the calls to external services, whose stats admins see
+	Bank     *time.Location     // the bank's time zone, in which report dates are read
 }
 
 // Handler contains all HTTP handlers for the application
 type Handler struct {
 	User       *UserHandler
+	Session    *SessionHandler
+	Onboarding *OnboardingHandler
+	APIKey     *APIKeyHandler
+	Notification *NotificationHandler
+	Email      *EmailHandler
+	Announcement *AnnouncementHandler
 	Account    *AccountHandler
+	AccountPlan *AccountPlanHandler
+	Organization *OrganizationHandler
+	Payroll *PayrollHandler
 	Card       *CardHandler
+	CardProduct *CardProductHandler
+	Fee        *FeeHandler
 	Transaction *TransactionHandler
+	Bill       *BillHandler
+	Merchant   *MerchantHandler
+	Chargeback *ChargebackHandler
+	Escrow     *EscrowHandler
+	Invoice    *InvoiceHandler
+	Subscription *SubscriptionHandler
+	ChequeDeposit *ChequeDepositHandler
+	InternationalTransfer *InternationalTransferHandler
+	Search     *SearchHandler
+	Referral   *ReferralHandler
+	TaxDocument *TaxDocumentHandler
+	Statement  *StatementHandler
+	Receipt    *ReceiptHandler
+	PaymentVerification *PaymentVerificationHandler
+	Accounting *AccountingHandler
+	Reporting  *ReportingHandler
+	Location   *LocationHandler
+	Rate       *RateHandler
+	Message    *MessageHandler
+	CreditApplication *CreditApplicationHandler
+	CreditAgreement *CreditAgreementHandler
+	CreditAdjustment *CreditAdjustmentHandler
+	AccountOwnership *AccountOwnershipHandler
+	Impersonation *ImpersonationHandler
+	Delegation *DelegationHandler
 	Credit     *CreditHandler
 	Analytics  *AnalyticsHandler
+	Admin      *AdminHandler
+	Health     *HealthHandler
 }
 
 // NewHandler creates a new Handler with all subhandlers
 func NewHandler(deps Dependencies) *Handler {
 	return &Handler{
-		User:       NewUserHandler(deps.Services.User, deps.Logger, deps.Config),
+		User:       NewUserHandler(deps.Services.User, deps.Captcha, deps.Logger, deps.Config),
+		Session:    NewSessionHandler(deps.Services.Session, deps.Logger, deps.Config),
+		Onboarding: NewOnboardingHandler(deps.Services.Onboarding, deps.Logger, deps.Config),
+		APIKey:     NewAPIKeyHandler(deps.Services.APIKey, deps.Logger, deps.Config),
+		Notification: NewNotificationHandler(deps.Services.Notification, deps.Logger, deps.Config),
+		Email:      NewEmailHandler(deps.Services.Email, deps.Logger, deps.Config),
+		Announcement: NewAnnouncementHandler(deps.Services.Announcement, deps.Logger, deps.Config),
 		Account:    NewAccountHandler(deps.Services.Account, deps.Logger, deps.Config),
+		AccountPlan: NewAccountPlanHandler(deps.Services.AccountPlan, deps.Logger, deps.Config),
+		Organization: NewOrganizationHandler(deps.Services.Organization, deps.Logger, deps.Config),
+		Payroll: NewPayrollHandler(deps.Services.Payroll, deps.Logger, deps.Config),
 		Card:       NewCardHandler(deps.Services.Card, deps.Logger, deps.Config),
+		CardProduct: NewCardProductHandler(deps.Services.CardProduct, deps.Logger, deps.Config),
+		Fee:        NewFeeHandler(deps.Services.Fee, deps.Logger, deps.Config),
 		Transaction: NewTransactionHandler(deps.Services.Transaction, deps.Logger, deps.Config),
+		Bill:       NewBillHandler(deps.Services.Bill, deps.Logger, deps.Config),
+		Merchant:   NewMerchantHandler(deps.Services.Merchant, deps.Logger, deps.Config),
+		Chargeback: NewChargebackHandler(deps.Services.Chargeback, deps.Logger, deps.Config),
+		Escrow:     NewEscrowHandler(deps.Services.Escrow, deps.Logger, deps.Config),
+		Invoice:    NewInvoiceHandler(deps.Services.Invoice, deps.Logger, deps.Config),
+		Subscription: NewSubscriptionHandler(deps.Services.Subscription, deps.Logger, deps.Config),
+		ChequeDeposit: NewChequeDepositHandler(deps.Services.ChequeDeposit, deps.Logger, deps.Config),
+		InternationalTransfer: NewInternationalTransferHandler(deps.Services.InternationalTransfer, deps.Logger, deps.Config),
+		Search:     NewSearchHandler(deps.Services.Search, deps.Logger, deps.Config),
+		Referral:   NewReferralHandler(deps.Services.Referral, deps.Logger, deps.Config),
+		TaxDocument: NewTaxDocumentHandler(deps.Services.TaxDocument, deps.Logger, deps.Config),
+		Statement:  NewStatementHandler(deps.Services.Statement, deps.Logger, deps.Config),
+		Receipt:    NewReceiptHandler(deps.Services.Receipt, deps.Logger, deps.Config),
+		PaymentVerification: NewPaymentVerificationHandler(deps.Services.PaymentVerification, deps.Logger, deps.Config),
+		Accounting: NewAccountingHandler(deps.Services.Accounting, deps.Logger, deps.Config, deps.Bank),
+		Reporting:  NewReportingHandler(deps.Services.Reporting, deps.Logger, deps.Config, deps.Bank),
+		Location:   NewLocationHandler(deps.Services.Location, deps.Logger, deps.Config),
+		Rate:       NewRateHandler(deps.Services.Rate, deps.Logger, deps.Config, deps.Bank),
+		Message:    NewMessageHandler(deps.Services.Message, deps.Logger, deps.Config),
+		CreditApplication: NewCreditApplicationHandler(deps.Services.CreditApplication, deps.Logger, deps.Config),
+		CreditAgreement: NewCreditAgreementHandler(deps.Services.CreditAgreement, deps.Logger, deps.Config),
+		CreditAdjustment: NewCreditAdjustmentHandler(deps.Services.CreditAdjustment, deps.Logger, deps.Config),
+		AccountOwnership: NewAccountOwnershipHandler(deps.Services.AccountOwnership, deps.Logger, deps.Config),
+		Impersonation: NewImpersonationHandler(deps.Services.Impersonation, deps.Logger, deps.Config),
+		Delegation: NewDelegationHandler(deps.Services.Delegation, deps.Logger, deps.Config),
 		Credit:     NewCreditHandler(deps.Services.Credit, deps.Logger, deps.Config),
 		Analytics:  NewAnalyticsHandler(deps.Services.Analytics, deps.Logger, deps.Config),
+		Admin:      NewAdminHandler(deps.Live, deps.Logger, deps.Config, deps.HTTP),
+		Health:     NewHealthHandler(deps.Live),
 	}
 }
\ No newline at end of file
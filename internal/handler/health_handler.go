@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"banking-service/configs"
+	"banking-service/pkg/utils"
+)
+
+// HealthHandler handles health check requests
+type HealthHandler struct {
+	live *configs.Live
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(live *configs.Live) *HealthHandler {
+	return &HealthHandler{
+		live: live,
+	}
+}
+
+// Check reports that the server is up. It stays available during maintenance.
+func (h *HealthHandler) Check(w http.ResponseWriter, r *http.Request) {
+	utils.Respond(w, http.StatusOK, "", map[string]interface{}{
+		"status":      "ok",
+		"maintenance": h.live.Maintenance().Enabled,
+	})
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// ImpersonationHandler handles the HTTP requests of support staff impersonating customers and of
+// admins reviewing the audit log
+type ImpersonationHandler struct {
+	impersonationService service.ImpersonationService
+	logger               *logrus.Logger
+	config               *configs.Config
+}
+
+// NewImpersonationHandler creates a new ImpersonationHandler
+func NewImpersonationHandler(impersonationService service.ImpersonationService, logger *logrus.Logger, config *configs.Config) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+		logger:               logger,
+		config:               config,
+	}
+}
+
+// Start handles a support employee asking for a read-only token of a customer
+func (h *ImpersonationHandler) Start(w http.ResponseWriter, r *http.Request) {
+	// Get user ID and session ID from context (set by auth middleware)
+	staffID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	sessionID, _ := r.Context().Value("session_id").(string)
+
+	// Parse request body
+	var request models.ImpersonationStart
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	token, err := h.impersonationService.Start(r.Context(), staffID, sessionID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to start impersonation by %d: %v", staffID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "impersonation started successfully", token)
+}
+
+// End handles a support employee revoking the token of their impersonation
+func (h *ImpersonationHandler) End(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	staffID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get impersonation ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid impersonation ID")
+		return
+	}
+
+	impersonation, err := h.impersonationService.End(r.Context(), id, staffID)
+	if err != nil {
+		h.logger.Warnf("Failed to end impersonation %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "impersonation ended successfully", impersonation)
+}
+
+// GetAll handles listing impersonations, optionally filtered by ?staff_id= and ?customer_id=
+func (h *ImpersonationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	var staffID, customerID int
+	var err error
+	if value := r.URL.Query().Get("staff_id"); value != "" {
+		if staffID, err = strconv.Atoi(value); err != nil || staffID <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "invalid staff_id")
+			return
+		}
+	}
+	if value := r.URL.Query().Get("customer_id"); value != "" {
+		if customerID, err = strconv.Atoi(value); err != nil || customerID <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "invalid customer_id")
+			return
+		}
+	}
+
+	impersonations, err := h.impersonationService.GetAll(r.Context(), staffID, customerID)
+	if err != nil {
+		h.logger.Warnf("Failed to get impersonations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get impersonations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "impersonations retrieved successfully", impersonations)
+}
+
+// Get handles retrieving an impersonation with the audit log of its requests
+func (h *ImpersonationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Get impersonation ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid impersonation ID")
+		return
+	}
+
+	impersonation, err := h.impersonationService.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get impersonation: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "impersonation not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "impersonation retrieved successfully", impersonation)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// InternationalTransferHandler handles international transfer HTTP requests
+type InternationalTransferHandler struct {
+	internationalTransferService service.InternationalTransferService
+	logger                       *logrus.Logger
+	config                       *configs.Config
+}
+
+// NewInternationalTransferHandler creates a new InternationalTransferHandler
+func NewInternationalTransferHandler(internationalTransferService service.InternationalTransferService, logger *logrus.Logger, config *configs.Config) *InternationalTransferHandler {
+	return &InternationalTransferHandler{
+		internationalTransferService: internationalTransferService,
+		logger:                       logger,
+		config:                       config,
+	}
+}
+
+// Create handles sending money to an account at a foreign bank
+func (h *InternationalTransferHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var transferCreate models.InternationalTransferCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&transferCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	transfer, err := h.internationalTransferService.Create(r.Context(), &transferCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create international transfer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "international transfer submitted, track it by its reference", transfer)
+}
+
+// Quote handles calculating the fees and amounts of an international transfer before sending it
+func (h *InternationalTransferHandler) Quote(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var transferCreate models.InternationalTransferCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&transferCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	transfer, err := h.internationalTransferService.Quote(r.Context(), &transferCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to quote international transfer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfer quoted successfully", transfer)
+}
+
+// GetAll handles listing the international transfers the user sent
+func (h *InternationalTransferHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	transfers, err := h.internationalTransferService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get international transfers: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get international transfers")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfers retrieved successfully", transfers)
+}
+
+// GetByID handles retrieving one of the international transfers the user sent
+func (h *InternationalTransferHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	transfer, ok := h.getTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfer retrieved successfully", transfer)
+}
+
+// Tracking handles showing the stages of one of the international transfers the user sent
+func (h *InternationalTransferHandler) Tracking(w http.ResponseWriter, r *http.Request) {
+	transfer, ok := h.getTransfer(w, r)
+	if !ok {
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfer tracking retrieved successfully", transfer.Tracking())
+}
+
+// TrackByReference handles tracking an international transfer the user sent by its UETR
+func (h *InternationalTransferHandler) TrackByReference(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	tracking, err := h.internationalTransferService.Track(r.Context(), mux.Vars(r)["reference"], userID)
+	if err != nil {
+		h.logger.Warnf("Failed to track international transfer: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "international transfer not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfer tracking retrieved successfully", tracking)
+}
+
+// AdminGetAll handles listing the international transfers of all customers, optionally by status
+func (h *InternationalTransferHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	status := models.InternationalTransferStatus(r.URL.Query().Get("status"))
+
+	transfers, err := h.internationalTransferService.GetAll(r.Context(), status)
+	if err != nil {
+		h.logger.Warnf("Failed to get international transfers: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "international transfers retrieved successfully", transfers)
+}
+
+// getTransfer gets the international transfer in the URL if the user sent it, responding with an
+// error otherwise
+func (h *InternationalTransferHandler) getTransfer(w http.ResponseWriter, r *http.Request) (*models.InternationalTransfer, bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return nil, false
+	}
+
+	// Get transfer ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid international transfer ID")
+		return nil, false
+	}
+
+	transfer, err := h.internationalTransferService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get international transfer: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "international transfer not found")
+		return nil, false
+	}
+
+	return transfer, true
+}
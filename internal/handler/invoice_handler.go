@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// InvoiceHandler handles customer and merchant invoice HTTP requests
+type InvoiceHandler struct {
+	invoiceService service.InvoiceService
+	logger         *logrus.Logger
+	config         *configs.Config
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler
+func NewInvoiceHandler(invoiceService service.InvoiceService, logger *logrus.Logger, config *configs.Config) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// Create handles a user issuing an invoice
+func (h *InvoiceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var invoiceCreate models.InvoiceCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&invoiceCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	invoice, err := h.invoiceService.Create(r.Context(), &invoiceCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create invoice: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "invoice issued successfully", invoice)
+}
+
+// GetIssued handles listing the invoices the user issued
+func (h *InvoiceHandler) GetIssued(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	invoices, err := h.invoiceService.GetIssued(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invoices: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get invoices")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoices retrieved successfully", invoices)
+}
+
+// GetReceived handles listing the invoices the user has to pay
+func (h *InvoiceHandler) GetReceived(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	invoices, err := h.invoiceService.GetReceived(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invoices: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get invoices")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoices retrieved successfully", invoices)
+}
+
+// GetByNumber handles retrieving an invoice the user issued or received
+func (h *InvoiceHandler) GetByNumber(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	invoice, err := h.invoiceService.GetByNumber(r.Context(), mux.Vars(r)["number"], userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invoice: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "invoice not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoice retrieved successfully", invoice)
+}
+
+// Pay handles the recipient paying an invoice with a transfer
+func (h *InvoiceHandler) Pay(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var payRequest models.InvoicePayRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&payRequest); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	result, err := h.invoiceService.Pay(r.Context(), mux.Vars(r)["number"], &payRequest, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to pay invoice: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Organization payments covered by an approval policy wait for other members
+	if result.ApprovalRequired {
+		utils.Respond(w, http.StatusAccepted, "transfer is waiting for approval", result)
+		return
+	}
+
+	// High-value payments wait for a one-time code
+	if result.ConfirmationRequired {
+		utils.Respond(w, http.StatusAccepted, "transfer requires confirmation, a code has been sent to your email", result)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoice paid successfully", result)
+}
+
+// Cancel handles the issuer cancelling an unpaid invoice
+func (h *InvoiceHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	if err := h.invoiceService.Cancel(r.Context(), mux.Vars(r)["number"], userID); err != nil {
+		h.logger.Warnf("Failed to cancel invoice: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoice cancelled successfully", nil)
+}
+
+// CreateForMerchant handles a merchant issuing an invoice
+func (h *InvoiceHandler) CreateForMerchant(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var invoiceCreate models.InvoiceCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&invoiceCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	invoice, err := h.invoiceService.CreateForMerchant(r.Context(), &invoiceCreate, merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to create invoice: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "invoice issued successfully", invoice)
+}
+
+// GetForMerchant handles listing the invoices a merchant issued
+func (h *InvoiceHandler) GetForMerchant(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	invoices, err := h.invoiceService.GetForMerchant(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invoices: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get invoices")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoices retrieved successfully", invoices)
+}
+
+// CancelForMerchant handles a merchant cancelling an unpaid invoice
+func (h *InvoiceHandler) CancelForMerchant(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	if err := h.invoiceService.CancelForMerchant(r.Context(), mux.Vars(r)["number"], merchantID); err != nil {
+		h.logger.Warnf("Failed to cancel invoice: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invoice cancelled successfully", nil)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// LocationHandler handles branch and ATM locator HTTP requests
+type LocationHandler struct {
+	locationService service.LocationService
+	logger          *logrus.Logger
+	config          *configs.Config
+}
+
+// NewLocationHandler creates a new LocationHandler
+func NewLocationHandler(locationService service.LocationService, logger *logrus.Logger, config *configs.Config) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+		logger:          logger,
+		config:          config,
+	}
+}
+
+// GetNearby handles searching the branches and ATMs around a point
+func (h *LocationHandler) GetNearby(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query, err := models.ParseLocationQuery(params.Get("lat"), params.Get("lng"), params.Get("radius"), params.Get("type"))
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	locations, err := h.locationService.GetNearby(r.Context(), query)
+	if err != nil {
+		h.logger.Warnf("Failed to get nearby locations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get locations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "locations retrieved successfully", locations)
+}
+
+// AdminGetAll handles listing all locations, including inactive ones
+func (h *LocationHandler) AdminGetAll(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.locationService.GetAll(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get locations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get locations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "locations retrieved successfully", locations)
+}
+
+// Create handles adding a branch or ATM
+func (h *LocationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var request models.LocationRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	location, err := h.locationService.Create(r.Context(), &request)
+	if err != nil {
+		h.logger.Warnf("Failed to create location: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "location created successfully", location)
+}
+
+// Update handles replacing the data of a branch or ATM
+func (h *LocationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	// Get location ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid location ID")
+		return
+	}
+
+	// Parse request body
+	var request models.LocationRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	location, err := h.locationService.Update(r.Context(), id, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to update location: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "location updated successfully", location)
+}
+
+// Delete handles removing a branch or ATM
+func (h *LocationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	// Get location ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid location ID")
+		return
+	}
+
+	if err := h.locationService.Delete(r.Context(), id); err != nil {
+		h.logger.Warnf("Failed to delete location: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "location not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "location deleted successfully", nil)
+}
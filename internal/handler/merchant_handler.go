@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// MerchantHandler handles merchant management, merchant API and customer payment intent HTTP requests
+type MerchantHandler struct {
+	merchantService service.MerchantService
+	logger          *logrus.Logger
+	config          *configs.Config
+}
+
+// NewMerchantHandler creates a new MerchantHandler
+func NewMerchantHandler(merchantService service.MerchantService, logger *logrus.Logger, config *configs.Config) *MerchantHandler {
+	return &MerchantHandler{
+		merchantService: merchantService,
+		logger:          logger,
+		config:          config,
+	}
+}
+
+// Create handles merchant registration
+func (h *MerchantHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var merchantCreate models.MerchantCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&merchantCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	credentials, err := h.merchantService.Create(r.Context(), &merchantCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create merchant: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response; the API key is not shown again
+	utils.Respond(w, http.StatusCreated, "merchant created successfully, store the API key securely", credentials)
+}
+
+// GetAll handles listing the merchants of the user
+func (h *MerchantHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	merchants, err := h.merchantService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get merchants: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get merchants")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "merchants retrieved successfully", merchants)
+}
+
+// GetByID handles retrieving a merchant
+func (h *MerchantHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get merchant ID from URL
+	vars := mux.Vars(r)
+	merchantID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid merchant ID")
+		return
+	}
+
+	merchant, err := h.merchantService.GetByID(r.Context(), merchantID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get merchant: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "merchant not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "merchant retrieved successfully", merchant)
+}
+
+// RotateAPIKey handles issuing a new API key for a merchant
+func (h *MerchantHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get merchant ID from URL
+	vars := mux.Vars(r)
+	merchantID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid merchant ID")
+		return
+	}
+
+	credentials, err := h.merchantService.RotateAPIKey(r.Context(), merchantID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to rotate merchant API key: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "merchant not found")
+		return
+	}
+
+	// Return success response; the API key is not shown again
+	utils.Respond(w, http.StatusOK, "API key rotated successfully, store it securely", credentials)
+}
+
+// GetSettlements handles listing the settlement batches of a merchant
+func (h *MerchantHandler) GetSettlements(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get merchant ID from URL
+	vars := mux.Vars(r)
+	merchantID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid merchant ID")
+		return
+	}
+
+	batches, err := h.merchantService.GetSettlements(r.Context(), merchantID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get settlements: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "merchant not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "settlements retrieved successfully", batches)
+}
+
+// CreateIntent handles a merchant creating a payment intent
+func (h *MerchantHandler) CreateIntent(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var intentCreate models.PaymentIntentCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&intentCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	intent, err := h.merchantService.CreateIntent(r.Context(), merchantID, &intentCreate)
+	if err != nil {
+		h.logger.Warnf("Failed to create payment intent: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "payment intent created successfully", intent)
+}
+
+// GetIntents handles a merchant listing its payment intents
+func (h *MerchantHandler) GetIntents(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	intents, err := h.merchantService.GetIntents(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get payment intents: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get payment intents")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment intents retrieved successfully", intents)
+}
+
+// GetIntent handles a merchant retrieving one of its payment intents
+func (h *MerchantHandler) GetIntent(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	intent, err := h.merchantService.GetIntent(r.Context(), merchantID, mux.Vars(r)["intentId"])
+	if err != nil {
+		h.logger.Warnf("Failed to get payment intent: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "payment intent not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment intent retrieved successfully", intent)
+}
+
+// CancelIntent handles a merchant cancelling an unpaid payment intent
+func (h *MerchantHandler) CancelIntent(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	if err := h.merchantService.CancelIntent(r.Context(), merchantID, mux.Vars(r)["intentId"]); err != nil {
+		h.logger.Warnf("Failed to cancel payment intent: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment intent cancelled", nil)
+}
+
+// GetCustomerIntent handles a customer viewing a payment intent before paying it
+func (h *MerchantHandler) GetCustomerIntent(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	intent, err := h.merchantService.GetIntentForCustomer(r.Context(), mux.Vars(r)["intentId"], userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get payment intent: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "payment intent not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment intent retrieved successfully", intent)
+}
+
+// PayIntent handles a customer paying a payment intent
+func (h *MerchantHandler) PayIntent(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var payRequest models.PaymentIntentPayRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&payRequest); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	intent, err := h.merchantService.PayIntent(r.Context(), mux.Vars(r)["intentId"], &payRequest, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to pay payment intent: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment completed successfully", intent)
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// maxMessagePayload fits the largest document once base64-encoded, plus the rest of the message
+const maxMessagePayload = models.MaxMessageDocumentSize/3*4 + 64<<10
+
+// MessageHandler handles secure messaging HTTP requests for customers and the bank
+type MessageHandler struct {
+	messageService service.MessageService
+	logger         *logrus.Logger
+	config         *configs.Config
+}
+
+// NewMessageHandler creates a new MessageHandler
+func NewMessageHandler(messageService service.MessageService, logger *logrus.Logger, config *configs.Config) *MessageHandler {
+	return &MessageHandler{
+		messageService: messageService,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// CreateThread handles a customer starting a conversation with the bank
+func (h *MessageHandler) CreateThread(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var create models.ThreadCreate
+	if !h.decode(w, r, &create) {
+		return
+	}
+
+	thread, err := h.messageService.CreateThread(r.Context(), &create, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create message thread: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "message sent successfully", thread)
+}
+
+// GetThreads handles listing the customer's message threads
+func (h *MessageHandler) GetThreads(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	threads, err := h.messageService.GetThreads(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get message threads: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get message threads")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "message threads retrieved successfully", threads)
+}
+
+// GetUnreadCount handles counting the customer's unread messages
+func (h *MessageHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	count, err := h.messageService.GetUnreadCount(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to count unread messages: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to count unread messages")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "unread messages counted successfully", map[string]int{"unread": count})
+}
+
+// GetThread handles retrieving one of the customer's threads with its messages
+func (h *MessageHandler) GetThread(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get thread ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid thread ID")
+		return
+	}
+
+	thread, err := h.messageService.GetThread(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get message thread: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "message thread not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "message thread retrieved successfully", thread)
+}
+
+// Reply handles a customer's reply in one of their threads
+func (h *MessageHandler) Reply(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get thread ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid thread ID")
+		return
+	}
+
+	// Parse request body
+	var request models.MessageRequest
+	if !h.decode(w, r, &request) {
+		return
+	}
+
+	message, err := h.messageService.Reply(r.Context(), id, &request, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to reply to message thread: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "message sent successfully", message)
+}
+
+// DownloadDocument handles downloading a document from one of the customer's threads
+func (h *MessageHandler) DownloadDocument(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	threadID, messageID, ok := parseMessageIDs(w, r)
+	if !ok {
+		return
+	}
+
+	message, err := h.messageService.GetDocument(r.Context(), threadID, messageID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get message document: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "document not found")
+		return
+	}
+
+	writeMessageDocument(w, message)
+}
+
+// AdminSend handles the bank starting a conversation with a customer
+func (h *MessageHandler) AdminSend(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var create models.ThreadCreate
+	if !h.decode(w, r, &create) {
+		return
+	}
+
+	thread, err := h.messageService.SendToCustomer(r.Context(), &create, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to send message to customer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "message sent successfully", thread)
+}
+
+// AdminGetThreads handles listing the threads of all customers
+func (h *MessageHandler) AdminGetThreads(w http.ResponseWriter, r *http.Request) {
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	threads, err := h.messageService.GetAllThreads(r.Context(), unreadOnly)
+	if err != nil {
+		h.logger.Warnf("Failed to get message threads: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get message threads")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "message threads retrieved successfully", threads)
+}
+
+// AdminGetThread handles retrieving any thread with its messages
+func (h *MessageHandler) AdminGetThread(w http.ResponseWriter, r *http.Request) {
+	// Get thread ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid thread ID")
+		return
+	}
+
+	thread, err := h.messageService.GetThreadForBank(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get message thread: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "message thread not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "message thread retrieved successfully", thread)
+}
+
+// AdminReply handles the bank's reply in a thread
+func (h *MessageHandler) AdminReply(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get thread ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid thread ID")
+		return
+	}
+
+	// Parse request body
+	var request models.MessageRequest
+	if !h.decode(w, r, &request) {
+		return
+	}
+
+	message, err := h.messageService.ReplyAsBank(r.Context(), id, &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to reply to message thread: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "message sent successfully", message)
+}
+
+// AdminDownloadDocument handles downloading a document from any thread
+func (h *MessageHandler) AdminDownloadDocument(w http.ResponseWriter, r *http.Request) {
+	threadID, messageID, ok := parseMessageIDs(w, r)
+	if !ok {
+		return
+	}
+
+	message, err := h.messageService.GetDocumentForBank(r.Context(), threadID, messageID)
+	if err != nil {
+		h.logger.Warnf("Failed to get message document: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "document not found")
+		return
+	}
+
+	writeMessageDocument(w, message)
+}
+
+// decode parses a message request body of limited size and reports whether it succeeded
+func (h *MessageHandler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxMessagePayload)).Decode(v); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return false
+	}
+
+	return true
+}
+
+// parseMessageIDs reads the thread and message IDs of a document URL
+func parseMessageIDs(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	vars := mux.Vars(r)
+
+	threadID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid thread ID")
+		return 0, 0, false
+	}
+
+	messageID, err := strconv.Atoi(vars["messageId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid message ID")
+		return 0, 0, false
+	}
+
+	return threadID, messageID, true
+}
+
+// writeMessageDocument sends a message's document as a file download
+func writeMessageDocument(w http.ResponseWriter, message *models.Message) {
+	w.Header().Set("Content-Type", message.DocumentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", message.DocumentName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(message.Document)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// NotificationHandler handles in-app notification HTTP requests
+type NotificationHandler struct {
+	notificationService service.NotificationService
+	logger              *logrus.Logger
+	config              *configs.Config
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(notificationService service.NotificationService, logger *logrus.Logger, config *configs.Config) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+		config:              config,
+	}
+}
+
+// GetAll handles listing the notifications of the user
+func (h *NotificationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	notifications, err := h.notificationService.GetByUserID(r.Context(), userID, unreadOnly)
+	if err != nil {
+		h.logger.Warnf("Failed to get notifications: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get notifications")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "notifications retrieved successfully", notifications)
+}
+
+// MarkRead handles marking a notification as read
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get notification ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid notification ID")
+		return
+	}
+
+	if err := h.notificationService.MarkRead(r.Context(), id, userID); err != nil {
+		h.logger.Warnf("Failed to mark notification as read: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "notification not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "notification marked as read", nil)
+}
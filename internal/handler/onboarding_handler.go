@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// OnboardingHandler handles the onboarding of new customers
+type OnboardingHandler struct {
+	onboardingService service.OnboardingService
+	logger            *logrus.Logger
+	config            *configs.Config
+}
+
+// NewOnboardingHandler creates a new OnboardingHandler
+func NewOnboardingHandler(onboardingService service.OnboardingService, logger *logrus.Logger, config *configs.Config) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingService: onboardingService,
+		logger:            logger,
+		config:            config,
+	}
+}
+
+// GetStatus handles retrieving how far the user has got through onboarding
+func (h *OnboardingHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	status, err := h.onboardingService.GetStatus(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get onboarding status of user %d: %v", userID, err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get onboarding status")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "onboarding status retrieved successfully", status)
+}
+
+// SendEmailVerification handles emailing the user a link that verifies their email address
+func (h *OnboardingHandler) SendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	if err := h.onboardingService.SendEmailVerification(r.Context(), userID); err != nil {
+		h.logger.Warnf("Failed to send email verification to user %d: %v", userID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "verification link sent successfully", nil)
+}
+
+// VerifyEmail handles the link from an email verification email
+func (h *OnboardingHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.RespondError(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	if err := h.onboardingService.VerifyEmail(r.Context(), token); err != nil {
+		h.logger.Warnf("Failed to verify email: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "email verified successfully", nil)
+}
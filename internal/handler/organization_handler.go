@@ -0,0 +1,440 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// OrganizationHandler handles organization, member and invitation HTTP requests
+type OrganizationHandler struct {
+	organizationService service.OrganizationService
+	logger              *logrus.Logger
+	config              *configs.Config
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler
+func NewOrganizationHandler(organizationService service.OrganizationService, logger *logrus.Logger, config *configs.Config) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		logger:              logger,
+		config:              config,
+	}
+}
+
+// Create handles organization creation
+func (h *OrganizationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var organizationCreate models.OrganizationCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&organizationCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	// Create the organization
+	organizationID, err := h.organizationService.Create(r.Context(), &organizationCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to create organization: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "organization created successfully", map[string]interface{}{
+		"organization_id": organizationID,
+	})
+}
+
+// GetAll handles listing the organizations the user is a member of
+func (h *OrganizationHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	organizations, err := h.organizationService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get organizations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get organizations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "organizations retrieved successfully", organizations)
+}
+
+// GetByID handles retrieving an organization with its members
+func (h *OrganizationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	organization, err := h.organizationService.GetByID(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get organization: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "organization retrieved successfully", organization)
+}
+
+// GetAccounts handles listing the accounts owned by an organization
+func (h *OrganizationHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	accounts, err := h.organizationService.GetAccounts(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get organization accounts: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "accounts retrieved successfully", accounts)
+}
+
+// GetApprovalPolicies handles listing the transfer approval policies of an organization
+func (h *OrganizationHandler) GetApprovalPolicies(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	policies, err := h.organizationService.GetApprovalPolicies(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get approval policies: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "approval policies retrieved successfully", policies)
+}
+
+// SetApprovalPolicies handles replacing the transfer approval policies of an organization
+func (h *OrganizationHandler) SetApprovalPolicies(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	// Parse request body
+	var update models.ApprovalPoliciesUpdate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&update); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.organizationService.SetApprovalPolicies(r.Context(), organizationID, &update, userID); err != nil {
+		h.logger.Warnf("Failed to update approval policies: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "approval policies updated successfully", nil)
+}
+
+// UpdateMemberRole handles changing the role of a member
+func (h *OrganizationHandler) UpdateMemberRole(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization and member IDs from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	memberUserID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	// Parse request body
+	var update models.MemberRoleUpdate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&update); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.organizationService.UpdateMemberRole(r.Context(), organizationID, memberUserID, &update, userID); err != nil {
+		h.logger.Warnf("Failed to update member role: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "member role updated successfully", nil)
+}
+
+// RemoveMember handles removing a member or leaving an organization
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization and member IDs from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	memberUserID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(r.Context(), organizationID, memberUserID, userID); err != nil {
+		h.logger.Warnf("Failed to remove member: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "member removed successfully", nil)
+}
+
+// Invite handles inviting a user to an organization by email
+func (h *OrganizationHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	// Parse request body
+	var invite models.InvitationCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&invite); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	invitationID, err := h.organizationService.Invite(r.Context(), organizationID, &invite, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to invite member: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "invitation sent successfully", map[string]interface{}{
+		"invitation_id": invitationID,
+	})
+}
+
+// GetInvitations handles listing the invitations of an organization
+func (h *OrganizationHandler) GetInvitations(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	invitations, err := h.organizationService.GetInvitations(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invitations: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invitations retrieved successfully", invitations)
+}
+
+// RevokeInvitation handles cancelling a pending invitation
+func (h *OrganizationHandler) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization and invitation IDs from URL
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	invitationID, err := strconv.Atoi(vars["invitationId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid invitation ID")
+		return
+	}
+
+	if err := h.organizationService.RevokeInvitation(r.Context(), organizationID, invitationID, userID); err != nil {
+		h.logger.Warnf("Failed to revoke invitation: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invitation revoked successfully", nil)
+}
+
+// GetMyInvitations handles listing the pending invitations sent to the user
+func (h *OrganizationHandler) GetMyInvitations(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	invitations, err := h.organizationService.GetPendingInvitations(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get invitations: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get invitations")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "invitations retrieved successfully", invitations)
+}
+
+// AcceptInvitation handles joining an organization through an invitation
+func (h *OrganizationHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	h.respondToInvitation(w, r, true)
+}
+
+// DeclineInvitation handles declining an invitation
+func (h *OrganizationHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	h.respondToInvitation(w, r, false)
+}
+
+// respondToInvitation accepts or declines the invitation from the URL
+func (h *OrganizationHandler) respondToInvitation(w http.ResponseWriter, r *http.Request, accept bool) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get invitation ID from URL
+	vars := mux.Vars(r)
+	invitationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid invitation ID")
+		return
+	}
+
+	if err := h.organizationService.RespondToInvitation(r.Context(), invitationID, userID, accept); err != nil {
+		h.logger.Warnf("Failed to respond to invitation: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	message := "invitation declined"
+	if accept {
+		message = "invitation accepted"
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, message, nil)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// PaymentVerificationHandler handles payment verification HTTP requests
+type PaymentVerificationHandler struct {
+	paymentVerificationService service.PaymentVerificationService
+	logger                     *logrus.Logger
+	config                     *configs.Config
+}
+
+// NewPaymentVerificationHandler creates a new PaymentVerificationHandler
+func NewPaymentVerificationHandler(paymentVerificationService service.PaymentVerificationService, logger *logrus.Logger, config *configs.Config) *PaymentVerificationHandler {
+	return &PaymentVerificationHandler{
+		paymentVerificationService: paymentVerificationService,
+		logger:                     logger,
+		config:                     config,
+	}
+}
+
+// GetSecret handles getting the reference and verification secret of a transaction
+func (h *PaymentVerificationHandler) GetSecret(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get transaction ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	secret, err := h.paymentVerificationService.GetSecret(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get verification secret of transaction %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment verification secret retrieved successfully", secret)
+}
+
+// Verify handles the public verification of a payment by its reference. The secret, if any, is
+// read from the X-Verification-Secret header so it stays out of links and access logs.
+func (h *PaymentVerificationHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	reference := mux.Vars(r)["reference"]
+	secret := r.Header.Get("X-Verification-Secret")
+
+	verification, err := h.paymentVerificationService.Verify(r.Context(), reference, secret)
+	if errors.Is(err, service.ErrVerificationSecretMismatch) {
+		utils.RespondError(w, http.StatusForbidden, "verification secret is invalid")
+		return
+	}
+	if err != nil {
+		h.logger.Warnf("Failed to verify payment %s: %v", reference, err)
+		utils.RespondError(w, http.StatusNotFound, "payment not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payment verified", verification)
+}
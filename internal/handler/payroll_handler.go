@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// maxPayrollPayload fits the largest payroll file plus the multipart framing and form fields
+const maxPayrollPayload = models.MaxPayrollFileSize + 64<<10
+
+// PayrollHandler handles salary payroll HTTP requests of organizations
+type PayrollHandler struct {
+	payrollService service.PayrollService
+	logger         *logrus.Logger
+	config         *configs.Config
+}
+
+// NewPayrollHandler creates a new PayrollHandler
+func NewPayrollHandler(payrollService service.PayrollService, logger *logrus.Logger, config *configs.Config) *PayrollHandler {
+	return &PayrollHandler{
+		payrollService: payrollService,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// Upload handles a multipart upload of a payroll file with fields "account_id", "description" and "file"
+func (h *PayrollHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	organizationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPayrollPayload)
+	if err := r.ParseMultipartForm(maxPayrollPayload); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid multipart form or file larger than 1 MB")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	accountID, err := strconv.Atoi(r.FormValue("account_id"))
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	payroll := &models.Payroll{
+		AccountID:   accountID,
+		FileName:    header.Filename,
+		Description: r.FormValue("description"),
+	}
+
+	payroll, err = h.payrollService.Upload(r.Context(), organizationID, payroll, content, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to upload payroll: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "payroll uploaded successfully", payroll)
+}
+
+// GetByOrganization handles listing the payrolls of an organization
+func (h *PayrollHandler) GetByOrganization(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get organization ID from URL
+	organizationID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+
+	payrolls, err := h.payrollService.GetByOrganizationID(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get payrolls of organization %d: %v", organizationID, err)
+		utils.RespondErrorFrom(w, http.StatusForbidden, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payrolls retrieved successfully", payrolls)
+}
+
+// GetByID handles retrieving a payroll with its payments
+func (h *PayrollHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get payroll ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid payroll ID")
+		return
+	}
+
+	payroll, err := h.payrollService.GetByID(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get payroll %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "payroll not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payroll retrieved successfully", payroll)
+}
+
+// Execute handles paying a validated payroll
+func (h *PayrollHandler) Execute(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get payroll ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid payroll ID")
+		return
+	}
+
+	payroll, err := h.payrollService.Execute(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to execute payroll %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "payroll executed successfully", payroll)
+}
+
+// DownloadReport handles downloading the settlement report of a payroll as CSV
+func (h *PayrollHandler) DownloadReport(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get payroll ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid payroll ID")
+		return
+	}
+
+	name, content, err := h.payrollService.GetReport(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to build report of payroll %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "payroll not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
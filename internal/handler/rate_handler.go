@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// RateHandler handles central bank rate HTTP requests
+type RateHandler struct {
+	rateService service.RateService
+	logger      *logrus.Logger
+	config      *configs.Config
+	bank        *time.Location // dates are read in the bank's time zone
+}
+
+// NewRateHandler creates a new RateHandler
+func NewRateHandler(rateService service.RateService, logger *logrus.Logger, config *configs.Config, bank *time.Location) *RateHandler {
+	return &RateHandler{
+		rateService: rateService,
+		logger:      logger,
+		config:      config,
+		bank:        bank,
+	}
+}
+
+// GetHistory handles retrieving the history of the key rate or of an exchange rate
+func (h *RateHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query, err := models.ParseRateHistoryQuery(params.Get("currency"), params.Get("from"), params.Get("to"), time.Now().In(h.bank))
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rates, err := h.rateService.GetHistory(r.Context(), query)
+	if err != nil {
+		h.logger.Warnf("Failed to get rate history: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get rate history")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "rate history retrieved successfully", rates)
+}
+
+// GetKeyRateOverrides handles listing the key rate overrides, including revoked ones
+func (h *RateHandler) GetKeyRateOverrides(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.rateService.GetKeyRateOverrides(r.Context())
+	if err != nil {
+		h.logger.Warnf("Failed to get key rate overrides: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get key rate overrides")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "key rate overrides retrieved successfully", overrides)
+}
+
+// CreateKeyRateOverride handles an admin pinning the base rate for a period
+func (h *RateHandler) CreateKeyRateOverride(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var request models.KeyRateOverrideRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	override, err := h.rateService.CreateKeyRateOverride(r.Context(), &request, adminID)
+	if err != nil {
+		h.logger.Warnf("Failed to create key rate override: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "key rate override created successfully", override)
+}
+
+// RevokeKeyRateOverride handles revoking a key rate override
+func (h *RateHandler) RevokeKeyRateOverride(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get override ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid key rate override ID")
+		return
+	}
+
+	if err := h.rateService.RevokeKeyRateOverride(r.Context(), id, adminID); err != nil {
+		h.logger.Warnf("Failed to revoke key rate override %d: %v", id, err)
+		utils.RespondErrorFrom(w, http.StatusNotFound, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "key rate override revoked successfully", nil)
+}
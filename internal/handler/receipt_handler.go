@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// ReceiptHandler handles transaction receipt HTTP requests
+type ReceiptHandler struct {
+	receiptService service.ReceiptService
+	logger         *logrus.Logger
+	config         *configs.Config
+}
+
+// NewReceiptHandler creates a new ReceiptHandler
+func NewReceiptHandler(receiptService service.ReceiptService, logger *logrus.Logger, config *configs.Config) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptService: receiptService,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// Download handles downloading the PDF receipt of a transaction
+func (h *ReceiptHandler) Download(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get transaction ID from URL
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+
+	fileName, content, err := h.receiptService.Get(r.Context(), id, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get receipt of transaction %d: %v", id, err)
+		utils.RespondError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// Verify handles the public verification link printed on receipts
+func (h *ReceiptHandler) Verify(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		utils.RespondError(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	verification, err := h.receiptService.Verify(r.Context(), token)
+	if err != nil {
+		h.logger.Warnf("Failed to verify receipt: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "receipt not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "receipt is genuine", verification)
+}
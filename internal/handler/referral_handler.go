@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// ReferralHandler handles referral program HTTP requests
+type ReferralHandler struct {
+	referralService service.ReferralService
+	logger          *logrus.Logger
+	config          *configs.Config
+}
+
+// NewReferralHandler creates a new ReferralHandler
+func NewReferralHandler(referralService service.ReferralService, logger *logrus.Logger, config *configs.Config) *ReferralHandler {
+	return &ReferralHandler{
+		referralService: referralService,
+		logger:          logger,
+		config:          config,
+	}
+}
+
+// GetSummary handles retrieving the user's referral code and earnings
+func (h *ReferralHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	summary, err := h.referralService.GetSummary(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get referral summary: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get referral summary")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "referral summary retrieved successfully", summary)
+}
+
+// GetReferrals handles listing the users the user has invited and their status
+func (h *ReferralHandler) GetReferrals(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	referrals, err := h.referralService.GetReferrals(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get referrals: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get referrals")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "referrals retrieved successfully", referrals)
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/storage"
+	"banking-service/pkg/utils"
+)
+
+// ReportingHandler handles admin dashboard report HTTP requests
+type ReportingHandler struct {
+	reportingService service.ReportingService
+	logger           *logrus.Logger
+	config           *configs.Config
+	bank             *time.Location // dates are read in the bank's time zone
+}
+
+// NewReportingHandler creates a new ReportingHandler
+func NewReportingHandler(reportingService service.ReportingService, logger *logrus.Logger, config *configs.Config, bank *time.Location) *ReportingHandler {
+	return &ReportingHandler{
+		reportingService: reportingService,
+		logger:           logger,
+		config:           config,
+		bank:             bank,
+	}
+}
+
+// Dashboard handles retrieving all reports for a period
+func (h *ReportingHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	period, ok := h.period(w, r)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.reportingService.GetDashboard(r.Context(), period)
+	if err != nil {
+		h.fail(w, "dashboard", "failed to get dashboard report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "dashboard retrieved successfully", dashboard)
+}
+
+// TransactionVolume handles retrieving the daily transaction volume and value
+func (h *ReportingHandler) TransactionVolume(w http.ResponseWriter, r *http.Request) {
+	period, ok := h.period(w, r)
+	if !ok {
+		return
+	}
+
+	volume, err := h.reportingService.GetTransactionVolume(r.Context(), period)
+	if err != nil {
+		h.fail(w, "transaction volume", "failed to get transaction volume report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "transaction volume retrieved successfully", volume)
+}
+
+// NewUsers handles retrieving the daily number of new users
+func (h *ReportingHandler) NewUsers(w http.ResponseWriter, r *http.Request) {
+	period, ok := h.period(w, r)
+	if !ok {
+		return
+	}
+
+	users, err := h.reportingService.GetNewUsers(r.Context(), period)
+	if err != nil {
+		h.fail(w, "new users", "failed to get new users report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "new users retrieved successfully", users)
+}
+
+// CreditsIssued handles retrieving the daily credits issued
+func (h *ReportingHandler) CreditsIssued(w http.ResponseWriter, r *http.Request) {
+	period, ok := h.period(w, r)
+	if !ok {
+		return
+	}
+
+	credits, err := h.reportingService.GetCreditsIssued(r.Context(), period)
+	if err != nil {
+		h.fail(w, "credits issued", "failed to get credits issued report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credits issued retrieved successfully", credits)
+}
+
+// CreditPortfolio handles retrieving the overdue portfolio and NPL ratio
+func (h *ReportingHandler) CreditPortfolio(w http.ResponseWriter, r *http.Request) {
+	portfolio, err := h.reportingService.GetCreditPortfolio(r.Context())
+	if err != nil {
+		h.fail(w, "credit portfolio", "failed to get credit portfolio report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "credit portfolio retrieved successfully", portfolio)
+}
+
+// StartCreditPortfolioExport handles exporting today's credit portfolio to object storage in the background
+func (h *ReportingHandler) StartCreditPortfolioExport(w http.ResponseWriter, r *http.Request) {
+	key := h.reportingService.StartCreditPortfolioExport(r.Context())
+
+	// Return success response
+	utils.Respond(w, http.StatusAccepted, "credit portfolio export started", map[string]string{"key": key})
+}
+
+// DownloadCreditPortfolioExport handles downloading the stored credit portfolio export of a day
+func (h *ReportingHandler) DownloadCreditPortfolioExport(w http.ResponseWriter, r *http.Request) {
+	day, err := models.ParseCreditPortfolioExportDate(r.URL.Query().Get("date"), time.Now().In(h.bank))
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	name, content, err := h.reportingService.GetCreditPortfolioExport(r.Context(), day)
+	if errors.Is(err, storage.ErrNotFound) {
+		utils.RespondError(w, http.StatusNotFound, "credit portfolio export not found")
+		return
+	}
+	if err != nil {
+		h.logger.Errorf("Failed to read credit portfolio export: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to read credit portfolio export")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// DepositBalances handles retrieving the deposit balances
+func (h *ReportingHandler) DepositBalances(w http.ResponseWriter, r *http.Request) {
+	balances, err := h.reportingService.GetDepositBalances(r.Context())
+	if err != nil {
+		h.fail(w, "deposit balances", "failed to get deposit balances report", err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "deposit balances retrieved successfully", balances)
+}
+
+// period reads the optional from and to dates from the query, responding with an error if they are invalid
+func (h *ReportingHandler) period(w http.ResponseWriter, r *http.Request) (models.ReportPeriod, bool) {
+	period, err := models.ParseReportPeriod(r.URL.Query().Get("from"), r.URL.Query().Get("to"), time.Now().In(h.bank))
+	if err != nil {
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return models.ReportPeriod{}, false
+	}
+
+	return period, true
+}
+
+// fail logs a failed report and responds with an internal error with the message of the report
+func (h *ReportingHandler) fail(w http.ResponseWriter, report, message string, err error) {
+	h.logger.Errorf("Failed to get %s report: %v", report, err)
+	utils.RespondError(w, http.StatusInternalServerError, message)
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// SearchHandler handles transaction search HTTP requests
+type SearchHandler struct {
+	searchService service.SearchService
+	logger        *logrus.Logger
+	config        *configs.Config
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(searchService service.SearchService, logger *logrus.Logger, config *configs.Config) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		logger:        logger,
+		config:        config,
+	}
+}
+
+// SearchTransactions handles searching the user's transactions by the words of their description
+// (q) with optional type, status, currency, account_id, from/to (YYYY-MM-DD, both inclusive) and
+// min_amount/max_amount filters and limit/offset paging. The response counts all matches by type,
+// status and currency.
+func (h *SearchHandler) SearchTransactions(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	query := r.URL.Query()
+	search := &models.TransactionSearch{
+		Query:    query.Get("q"),
+		Type:     models.TransactionType(query.Get("type")),
+		Status:   models.TransactionStatus(query.Get("status")),
+		Currency: models.Currency(query.Get("currency")),
+	}
+
+	var err error
+	if accountID := query.Get("account_id"); accountID != "" {
+		if search.AccountID, err = strconv.Atoi(accountID); err != nil || search.AccountID <= 0 {
+			utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+			return
+		}
+	}
+
+	if from := query.Get("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid from date format")
+			return
+		}
+		search.From = &date
+	}
+
+	if to := query.Get("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid to date format")
+			return
+		}
+		// Add one day to include transactions on that day
+		date = date.AddDate(0, 0, 1)
+		search.To = &date
+	}
+
+	if minAmount := query.Get("min_amount"); minAmount != "" {
+		amount, err := strconv.ParseFloat(minAmount, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid min_amount")
+			return
+		}
+		search.MinAmount = &amount
+	}
+
+	if maxAmount := query.Get("max_amount"); maxAmount != "" {
+		amount, err := strconv.ParseFloat(maxAmount, 64)
+		if err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid max_amount")
+			return
+		}
+		search.MaxAmount = &amount
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		if search.Limit, err = strconv.Atoi(limit); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		if search.Offset, err = strconv.Atoi(offset); err != nil {
+			utils.RespondError(w, http.StatusBadRequest, "invalid offset")
+			return
+		}
+	}
+
+	result, err := h.searchService.SearchTransactions(r.Context(), userID, search)
+	if err != nil {
+		h.logger.Warnf("Failed to search transactions: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.RespondPage(w, http.StatusOK, "transactions found successfully", result, utils.Pagination{
+		Limit:  result.Limit,
+		Offset: result.Offset,
+	})
+}
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/utils"
+)
+
+// SessionHandler handles session-related HTTP requests
+type SessionHandler struct {
+	sessionService service.SessionService
+	logger         *logrus.Logger
+	config         *configs.Config
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(sessionService service.SessionService, logger *logrus.Logger, config *configs.Config) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		logger:         logger,
+		config:         config,
+	}
+}
+
+// GetAll handles listing the active sessions (or the full login history) of the user
+func (h *SessionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	currentSessionID, _ := r.Context().Value("session_id").(string)
+	history := r.URL.Query().Get("history") == "true"
+
+	sessions, err := h.sessionService.GetByUserID(r.Context(), userID, currentSessionID, history)
+	if err != nil {
+		h.logger.Warnf("Failed to get sessions: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get sessions")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "sessions retrieved successfully", sessions)
+}
+
+// Revoke handles revoking a session of the user
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get session ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+
+	if err := h.sessionService.Revoke(r.Context(), id, userID); err != nil {
+		h.logger.Warnf("Failed to revoke session: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "session revoked successfully", nil)
+}
+
+// Logout handles ending the session of the request's token; with ?all=true every other session of
+// the user is ended too
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// Get user ID and session ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	sessionID, ok := r.Context().Value("session_id").(string)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "session ID not found in context")
+		return
+	}
+
+	everywhere := r.URL.Query().Get("all") == "true"
+
+	revoked, err := h.sessionService.Logout(r.Context(), sessionID, userID, everywhere)
+	if err != nil {
+		h.logger.Warnf("Failed to log out user %d: %v", userID, err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to log out")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "logged out successfully", map[string]int{"revoked_sessions": revoked})
+}
+
+// ConfirmRevokeByToken handles the revoke link from a new device alert email. Opening the link only
+// shows the session and asks to confirm, so mail scanners that follow links do not end it.
+func (h *SessionHandler) ConfirmRevokeByToken(w http.ResponseWriter, r *http.Request) {
+	page := h.revokePage(r)
+	token := r.URL.Query().Get("token")
+
+	session, err := h.sessionService.GetByRevokeToken(r.Context(), token)
+	if err != nil {
+		h.logger.Warnf("Failed to open revoke link: %v", err)
+		page.Text = i18n.Text(page.Lang, "page.revoke_session.invalid")
+		renderRevokePage(w, http.StatusBadRequest, page)
+		return
+	}
+
+	page.Session = session
+	if session.IsActive(time.Now()) {
+		page.Text = i18n.Text(page.Lang, "page.revoke_session.confirm")
+		page.Token = token
+	} else {
+		page.Text = i18n.Text(page.Lang, "page.revoke_session.ended")
+	}
+
+	renderRevokePage(w, http.StatusOK, page)
+}
+
+// RevokeByToken handles the confirmation form of the revoke page
+func (h *SessionHandler) RevokeByToken(w http.ResponseWriter, r *http.Request) {
+	page := h.revokePage(r)
+
+	if err := h.sessionService.RevokeByToken(r.Context(), r.PostFormValue("token")); err != nil {
+		h.logger.Warnf("Failed to revoke session by token: %v", err)
+		page.Text = i18n.Text(page.Lang, "page.revoke_session.invalid")
+		renderRevokePage(w, http.StatusBadRequest, page)
+		return
+	}
+
+	page.Text = i18n.Text(page.Lang, "page.revoke_session.done")
+	renderRevokePage(w, http.StatusOK, page)
+}
+
+// revokePageData is what the revoke page shows; the form is shown when Token is set
+type revokePageData struct {
+	Lang    i18n.Language
+	Text    string
+	Session *models.Session
+	Token   string
+}
+
+// revokePage starts the revoke page in the language of the request
+func (h *SessionHandler) revokePage(r *http.Request) *revokePageData {
+	lang, ok := i18n.FromContext(r.Context())
+	if !ok {
+		lang = h.config.Bank.DefaultLanguage()
+	}
+	return &revokePageData{Lang: lang}
+}
+
+// revokePageTemplate is the page of the revoke link
+var revokePageTemplate = template.Must(template.New("revoke").Funcs(template.FuncMap{
+	"text": i18n.Text,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>{{text .Lang "page.revoke_session.title"}}</title>
+</head>
+<body>
+	<h2>{{text .Lang "page.revoke_session.title"}}</h2>
+	<p>{{.Text}}</p>
+	{{with .Session}}
+	<table>
+		<tr><td><strong>{{text $.Lang "page.session.date"}}:</strong></td><td>{{.CreatedAt.Format "2006-01-02 15:04:05"}}</td></tr>
+		<tr><td><strong>{{text $.Lang "page.session.ip_address"}}:</strong></td><td>{{.IPAddress}}</td></tr>
+		<tr><td><strong>{{text $.Lang "page.session.location"}}:</strong></td><td>{{.Location}}</td></tr>
+		<tr><td><strong>{{text $.Lang "page.session.device"}}:</strong></td><td>{{.UserAgent}}</td></tr>
+	</table>
+	{{end}}
+	{{if .Token}}
+	<form method="post" action="/sessions/revoke">
+		<input type="hidden" name="token" value="{{.Token}}">
+		<button type="submit">{{text .Lang "page.revoke_session.button"}}</button>
+	</form>
+	{{end}}
+</body>
+</html>
+`))
+
+// renderRevokePage writes the revoke page. The token in its URL must not leak through the
+// Referer header or caches, and the page must not be framed to trick a click on the button.
+func renderRevokePage(w http.ResponseWriter, status int, page *revokePageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.WriteHeader(status)
+	revokePageTemplate.Execute(w, page)
+}
+
+// clientInfo collects the client details recorded with a session
+func clientInfo(r *http.Request) models.ClientInfo {
+	return models.ClientInfo{
+		IPAddress: utils.ClientIP(r),
+		UserAgent: r.UserAgent(),
+		Country:   r.Header.Get("CF-IPCountry"),
+	}
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// StatementHandler handles account statement HTTP requests
+type StatementHandler struct {
+	statementService service.StatementService
+	logger           *logrus.Logger
+	config           *configs.Config
+}
+
+// NewStatementHandler creates a new StatementHandler
+func NewStatementHandler(statementService service.StatementService, logger *logrus.Logger, config *configs.Config) *StatementHandler {
+	return &StatementHandler{
+		statementService: statementService,
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// GetAll handles listing the statements of an account
+func (h *StatementHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account ID from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	statements, err := h.statementService.GetByAccountID(r.Context(), accountID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get statements: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "account not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "statements retrieved successfully", statements)
+}
+
+// GetByID handles retrieving a statement with the transactions of its period
+func (h *StatementHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account and statement IDs from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	statementID, err := strconv.Atoi(vars["statementId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid statement ID")
+		return
+	}
+
+	statement, err := h.statementService.GetByID(r.Context(), accountID, statementID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get statement: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "statement not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "statement retrieved successfully", statement)
+}
+
+// DownloadCamt053 handles downloading a statement as an ISO 20022 camt.053 message
+func (h *StatementHandler) DownloadCamt053(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get account and statement IDs from URL parameters
+	vars := mux.Vars(r)
+	accountID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	statementID, err := strconv.Atoi(vars["statementId"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid statement ID")
+		return
+	}
+
+	fileName, content, err := h.statementService.GetCamt053(r.Context(), accountID, statementID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get camt.053 statement: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "statement not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
+
+// Regenerate handles rebuilding the statement of a past month of an account the user manages
+func (h *StatementHandler) Regenerate(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	h.regenerate(w, r, func(accountID int, request *models.StatementRegenerateRequest) (*models.Statement, error) {
+		return h.statementService.Regenerate(r.Context(), accountID, request, userID)
+	})
+}
+
+// AdminRegenerate handles an admin rebuilding the statement of a past month of any account
+func (h *StatementHandler) AdminRegenerate(w http.ResponseWriter, r *http.Request) {
+	// Get admin ID from context (set by auth middleware)
+	adminID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	h.regenerate(w, r, func(accountID int, request *models.StatementRegenerateRequest) (*models.Statement, error) {
+		return h.statementService.AdminRegenerate(r.Context(), accountID, request, adminID)
+	})
+}
+
+// regenerate parses a statement regeneration request and responds with the rebuilt statement
+func (h *StatementHandler) regenerate(w http.ResponseWriter, r *http.Request, rebuild func(int, *models.StatementRegenerateRequest) (*models.Statement, error)) {
+	// Get account ID from URL parameters
+	accountID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
+		return
+	}
+
+	// Parse request body
+	var request models.StatementRegenerateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	statement, err := rebuild(accountID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to regenerate statement of account %d: %v", accountID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "statement regenerated successfully", statement)
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// SubscriptionHandler handles customer and merchant subscription HTTP requests
+type SubscriptionHandler struct {
+	subscriptionService service.SubscriptionService
+	logger              *logrus.Logger
+	config              *configs.Config
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler
+func NewSubscriptionHandler(subscriptionService service.SubscriptionService, logger *logrus.Logger, config *configs.Config) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+		logger:              logger,
+		config:              config,
+	}
+}
+
+// GetPlan handles retrieving a plan customers can subscribe to
+func (h *SubscriptionHandler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	// Get plan ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid subscription plan ID")
+		return
+	}
+
+	plan, err := h.subscriptionService.GetPlan(r.Context(), id)
+	if err != nil {
+		h.logger.Warnf("Failed to get subscription plan: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "subscription plan not found")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription plan retrieved successfully", plan)
+}
+
+// Subscribe handles a user subscribing to a plan
+func (h *SubscriptionHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var subscriptionCreate models.SubscriptionCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&subscriptionCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	subscription, err := h.subscriptionService.Subscribe(r.Context(), &subscriptionCreate, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to subscribe: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "subscribed successfully", subscription)
+}
+
+// GetAll handles listing the subscriptions of the user
+func (h *SubscriptionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.GetByUserID(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get subscriptions: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get subscriptions")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscriptions retrieved successfully", subscriptions)
+}
+
+// Cancel handles a user cancelling a subscription
+func (h *SubscriptionHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get subscription ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	if err := h.subscriptionService.Cancel(r.Context(), id, userID); err != nil {
+		h.logger.Warnf("Failed to cancel subscription: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription cancelled successfully", nil)
+}
+
+// CreatePlan handles a merchant creating a subscription plan
+func (h *SubscriptionHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Parse request body
+	var planCreate models.SubscriptionPlanCreate
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&planCreate); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	plan, err := h.subscriptionService.CreatePlan(r.Context(), &planCreate, merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to create subscription plan: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusCreated, "subscription plan created successfully", plan)
+}
+
+// GetPlans handles listing the subscription plans of a merchant
+func (h *SubscriptionHandler) GetPlans(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	plans, err := h.subscriptionService.GetPlans(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get subscription plans: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get subscription plans")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription plans retrieved successfully", plans)
+}
+
+// DeactivatePlan handles a merchant closing a subscription plan to new subscribers
+func (h *SubscriptionHandler) DeactivatePlan(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Get plan ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid subscription plan ID")
+		return
+	}
+
+	if err := h.subscriptionService.DeactivatePlan(r.Context(), id, merchantID); err != nil {
+		h.logger.Warnf("Failed to deactivate subscription plan: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription plan deactivated successfully", nil)
+}
+
+// GetForMerchant handles listing the subscriptions to the plans of a merchant
+func (h *SubscriptionHandler) GetForMerchant(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.GetForMerchant(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get subscriptions: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get subscriptions")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscriptions retrieved successfully", subscriptions)
+}
+
+// CancelForMerchant handles a merchant cancelling a subscription
+func (h *SubscriptionHandler) CancelForMerchant(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	// Get subscription ID from URL
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid subscription ID")
+		return
+	}
+
+	if err := h.subscriptionService.CancelForMerchant(r.Context(), id, merchantID); err != nil {
+		h.logger.Warnf("Failed to cancel subscription: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription cancelled successfully", nil)
+}
+
+// GetReport handles retrieving the subscription revenue and churn report of a merchant
+func (h *SubscriptionHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	// Get merchant ID from context (set by merchant auth middleware)
+	merchantID, ok := r.Context().Value("merchant_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "merchant ID not found in context")
+		return
+	}
+
+	report, err := h.subscriptionService.Report(r.Context(), merchantID)
+	if err != nil {
+		h.logger.Warnf("Failed to get subscription report: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get subscription report")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "subscription report retrieved successfully", report)
+}
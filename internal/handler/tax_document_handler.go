@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/service"
+	"banking-service/pkg/utils"
+)
+
+// TaxDocumentHandler handles yearly tax document HTTP requests
+type TaxDocumentHandler struct {
+	taxDocumentService service.TaxDocumentService
+	logger             *logrus.Logger
+	config             *configs.Config
+}
+
+// NewTaxDocumentHandler creates a new TaxDocumentHandler
+func NewTaxDocumentHandler(taxDocumentService service.TaxDocumentService, logger *logrus.Logger, config *configs.Config) *TaxDocumentHandler {
+	return &TaxDocumentHandler{
+		taxDocumentService: taxDocumentService,
+		logger:             logger,
+		config:             config,
+	}
+}
+
+// GetAll handles listing the user's tax documents
+func (h *TaxDocumentHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	docs, err := h.taxDocumentService.GetDocuments(r.Context(), userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get tax documents: %v", err)
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get tax documents")
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "tax documents retrieved successfully", docs)
+}
+
+// GetByYear handles retrieving the user's tax document for a year
+func (h *TaxDocumentHandler) GetByYear(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get year from URL
+	vars := mux.Vars(r)
+	year, err := strconv.Atoi(vars["year"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid year")
+		return
+	}
+
+	doc, err := h.taxDocumentService.GetDocument(r.Context(), userID, year)
+	if err != nil {
+		h.logger.Warnf("Failed to get tax document: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Return success response
+	utils.Respond(w, http.StatusOK, "tax document retrieved successfully", doc)
+}
+
+// Download handles downloading the user's tax document for a year as a file
+func (h *TaxDocumentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+
+	// Get year from URL
+	vars := mux.Vars(r)
+	year, err := strconv.Atoi(vars["year"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid year")
+		return
+	}
+
+	name, content, err := h.taxDocumentService.Download(r.Context(), userID, year)
+	if err != nil {
+		h.logger.Warnf("Failed to download tax document: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
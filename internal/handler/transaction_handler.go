@@ -36,7 +36,7 @@ func (h *TransactionHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -44,21 +44,63 @@ func (h *TransactionHandler) Transfer(w http.ResponseWriter, r *http.Request) {
 	var transferReq models.TransferRequest
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&transferReq); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 	
 	// Execute the transfer
-	transactionID, err := h.transactionService.Transfer(r.Context(), &transferReq, userID)
+	result, err := h.transactionService.Transfer(r.Context(), &transferReq, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to execute transfer: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Organization transfers covered by an approval policy wait for other members
+	if result.ApprovalRequired {
+		utils.Respond(w, http.StatusAccepted, "transfer is waiting for approval", result)
+		return
+	}
+	
+	// High-value transfers wait for a one-time code
+	if result.ConfirmationRequired {
+		utils.Respond(w, http.StatusAccepted, "transfer requires confirmation, a code has been sent to your email", result)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "transfer completed successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusOK, "transfer completed successfully", result)
+}
+
+// ConfirmTransfer handles confirming a high-value transfer with a one-time code
+func (h *TransactionHandler) ConfirmTransfer(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var confirmReq models.TransferConfirmRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&confirmReq); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	// Verify the code and execute the transfer
+	transactionID, err := h.transactionService.ConfirmTransfer(r.Context(), &confirmReq, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to confirm transfer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "transfer completed successfully", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
 }
@@ -68,7 +110,7 @@ func (h *TransactionHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -76,7 +118,7 @@ func (h *TransactionHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	var paymentReq models.PaymentRequest
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&paymentReq); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -85,12 +127,12 @@ func (h *TransactionHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	transactionID, err := h.transactionService.Pay(r.Context(), &paymentReq, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to execute payment: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "payment completed successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusOK, "payment completed successfully", map[string]interface{}{
 		"transaction_id": transactionID,
 	})
 }
@@ -100,7 +142,7 @@ func (h *TransactionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -112,13 +154,13 @@ func (h *TransactionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	if startDateStr != "" && endDateStr != "" {
 		startDate, err := time.Parse("2006-01-02", startDateStr)
 		if err != nil {
-			utils.RespondWithError(w, http.StatusBadRequest, "invalid start date format")
+			utils.RespondError(w, http.StatusBadRequest, "invalid start date format")
 			return
 		}
 		
 		endDate, err := time.Parse("2006-01-02", endDateStr)
 		if err != nil {
-			utils.RespondWithError(w, http.StatusBadRequest, "invalid end date format")
+			utils.RespondError(w, http.StatusBadRequest, "invalid end date format")
 			return
 		}
 		
@@ -128,11 +170,11 @@ func (h *TransactionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		transactions, err := h.transactionService.GetByDateRange(r.Context(), userID, startDate, endDate)
 		if err != nil {
 			h.logger.Warnf("Failed to get transactions by date range: %v", err)
-			utils.RespondWithError(w, http.StatusInternalServerError, "failed to get transactions")
+			utils.RespondError(w, http.StatusInternalServerError, "failed to get transactions")
 			return
 		}
 		
-		utils.RespondWithSuccess(w, http.StatusOK, "transactions retrieved successfully", transactions)
+		utils.RespondWithFields(w, r, http.StatusOK, "transactions retrieved successfully", transactions)
 		return
 	}
 	
@@ -140,12 +182,12 @@ func (h *TransactionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	transactions, err := h.transactionService.GetByUserID(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get transactions: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get transactions")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get transactions")
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "transactions retrieved successfully", transactions)
+	utils.RespondWithFields(w, r, http.StatusOK, "transactions retrieved successfully", transactions)
 }
 
 // GetByID handles retrieving a specific transaction by ID
@@ -153,7 +195,7 @@ func (h *TransactionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -161,7 +203,7 @@ func (h *TransactionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	transactionID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid transaction ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid transaction ID")
 		return
 	}
 	
@@ -169,12 +211,74 @@ func (h *TransactionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	transaction, err := h.transactionService.GetByID(r.Context(), transactionID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get transaction: %v", err)
-		utils.RespondWithError(w, http.StatusNotFound, "transaction not found")
+		utils.RespondError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "transaction retrieved successfully", transaction)
+}
+
+// EditDescription handles the user changing the description they see for a transaction
+func (h *TransactionHandler) EditDescription(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get transaction ID from URL parameters
+	transactionID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	
+	// Parse request body
+	var update models.TransactionDescriptionUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	transaction, err := h.transactionService.EditDescription(r.Context(), transactionID, userID, &update)
+	if err != nil {
+		h.logger.Warnf("Failed to change the description of transaction %d: %v", transactionID, err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "transaction retrieved successfully", transaction)
+	utils.Respond(w, http.StatusOK, "transaction description updated successfully", transaction)
+}
+
+// GetDescriptionHistory handles retrieving the original description of a transaction with the user's edits
+func (h *TransactionHandler) GetDescriptionHistory(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get transaction ID from URL parameters
+	transactionID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid transaction ID")
+		return
+	}
+	
+	history, err := h.transactionService.GetDescriptionHistory(r.Context(), transactionID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get the description history of transaction %d: %v", transactionID, err)
+		utils.RespondError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "transaction description history retrieved successfully", history)
 }
 
 // GetByAccount handles retrieving all transactions for a specific account
@@ -182,7 +286,7 @@ func (h *TransactionHandler) GetByAccount(w http.ResponseWriter, r *http.Request
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -190,7 +294,7 @@ func (h *TransactionHandler) GetByAccount(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	accountID, err := strconv.Atoi(vars["id"])
 	if err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid account ID")
+		utils.RespondError(w, http.StatusBadRequest, "invalid account ID")
 		return
 	}
 	
@@ -198,10 +302,126 @@ func (h *TransactionHandler) GetByAccount(w http.ResponseWriter, r *http.Request
 	transactions, err := h.transactionService.GetByAccountID(r.Context(), accountID, userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get transactions for account: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get transactions")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get transactions")
+		return
+	}
+	
+	// Return success response
+	utils.RespondWithFields(w, r, http.StatusOK, "transactions retrieved successfully", transactions)
+}
+
+// GetPendingTransfer handles retrieving a transfer that waits for approvals
+func (h *TransactionHandler) GetPendingTransfer(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get pending transfer ID from URL parameters
+	vars := mux.Vars(r)
+	pendingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid pending transfer ID")
+		return
+	}
+	
+	pending, err := h.transactionService.GetPendingTransfer(r.Context(), pendingID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get pending transfer: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "pending transfer not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "pending transfer retrieved successfully", pending)
+}
+
+// GetPendingTransfers handles listing the pending transfers of an organization
+func (h *TransactionHandler) GetPendingTransfers(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get organization ID from URL parameters
+	vars := mux.Vars(r)
+	organizationID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid organization ID")
+		return
+	}
+	
+	transfers, err := h.transactionService.GetPendingTransfers(r.Context(), organizationID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to get pending transfers: %v", err)
+		utils.RespondError(w, http.StatusNotFound, "organization not found")
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "pending transfers retrieved successfully", transfers)
+}
+
+// ApproveTransfer handles approving a pending organization transfer
+func (h *TransactionHandler) ApproveTransfer(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get pending transfer ID from URL parameters
+	vars := mux.Vars(r)
+	pendingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid pending transfer ID")
 		return
 	}
 	
+	pending, err := h.transactionService.ApproveTransfer(r.Context(), pendingID, userID)
+	if err != nil {
+		h.logger.Warnf("Failed to approve transfer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	message := "approval recorded, transfer is waiting for more approvals"
+	if pending.TransactionID != nil {
+		message = "transfer approved and completed"
+	}
+	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "transactions retrieved successfully", transactions)
-}
\ No newline at end of file
+	utils.Respond(w, http.StatusOK, message, pending)
+}
+
+// RejectTransfer handles rejecting a pending organization transfer
+func (h *TransactionHandler) RejectTransfer(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Get pending transfer ID from URL parameters
+	vars := mux.Vars(r)
+	pendingID, err := strconv.Atoi(vars["id"])
+	if err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid pending transfer ID")
+		return
+	}
+	
+	if err := h.transactionService.RejectTransfer(r.Context(), pendingID, userID); err != nil {
+		h.logger.Warnf("Failed to reject transfer: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "transfer rejected", nil)
+}
@@ -2,29 +2,49 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"html/template"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/service"
+	"banking-service/pkg/captcha"
+	"banking-service/pkg/i18n"
 	"banking-service/pkg/utils"
 )
 
+// captchaTokenHeader carries the CAPTCHA token solved by the client
+const captchaTokenHeader = "X-Captcha-Token"
+
+// captchaDetails are the error details returned when a request needs a valid CAPTCHA token
+type captchaDetails struct {
+	CaptchaRequired bool   `json:"captcha_required"`
+	Provider        string `json:"provider"`
+	SiteKey         string `json:"site_key"`
+}
+
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	userService service.UserService
-	logger      *logrus.Logger
-	config      *configs.Config
+	userService   service.UserService
+	captcha       captcha.Verifier
+	loginFailures *captcha.FailureTracker
+	logger        *logrus.Logger
+	config        *configs.Config
 }
 
-// NewUserHandler creates a new UserHandler
-func NewUserHandler(userService service.UserService, logger *logrus.Logger, config *configs.Config) *UserHandler {
+// NewUserHandler creates a new UserHandler. A nil verifier disables CAPTCHA checks.
+func NewUserHandler(userService service.UserService, verifier captcha.Verifier, logger *logrus.Logger, config *configs.Config) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		logger:      logger,
-		config:      config,
+		userService:   userService,
+		captcha:       verifier,
+		loginFailures: captcha.NewFailureTracker(config.Captcha.LoginFailures, time.Duration(config.Captcha.FailureWindow)*time.Second),
+		logger:        logger,
+		config:        config,
 	}
 }
 
@@ -32,7 +52,7 @@ func NewUserHandler(userService service.UserService, logger *logrus.Logger, conf
 func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	// Only allow POST requests
 	if r.Method != http.MethodPost {
-		utils.RespondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		utils.RespondError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	
@@ -40,30 +60,53 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var userReg models.UserRegistration
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&userReg); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 	
+	// Registration always requires a CAPTCHA when it is enabled
+	if !h.verifyCaptcha(w, r) {
+		return
+	}
+	
 	// Register the user
 	userID, err := h.userService.Register(r.Context(), &userReg)
 	if err != nil {
 		h.logger.Warnf("Failed to register user: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusCreated, "user registered successfully", map[string]interface{}{
+	utils.Respond(w, http.StatusCreated, "user registered successfully", map[string]interface{}{
 		"user_id": userID,
 	})
 }
 
+// CheckAvailability handles checking during signup whether a username and an email are still free
+func (h *UserHandler) CheckAvailability(w http.ResponseWriter, r *http.Request) {
+	request := models.AvailabilityRequest{
+		Username: r.URL.Query().Get("username"),
+		Email:    r.URL.Query().Get("email"),
+	}
+	
+	availability, err := h.userService.CheckAvailability(r.Context(), &request)
+	if err != nil {
+		h.logger.Warnf("Failed to check availability: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "availability checked successfully", availability)
+}
+
 // Login handles user login
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	// Only allow POST requests
 	if r.Method != http.MethodPost {
-		utils.RespondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		utils.RespondError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	
@@ -71,35 +114,43 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var loginReq models.UserLogin
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&loginReq); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 	
+	// Require a CAPTCHA after repeated failures from this client or for this username
+	failureKeys := []string{"ip:" + utils.ClientIP(r), "login:" + strings.ToLower(loginReq.Username)}
+	if h.captcha != nil && h.loginFailures.Required(failureKeys...) && !h.verifyCaptcha(w, r) {
+		return
+	}
+	
 	// Authenticate the user
-	tokenResponse, err := h.userService.Login(r.Context(), &loginReq)
+	tokenResponse, err := h.userService.Login(r.Context(), &loginReq, clientInfo(r))
 	if err != nil {
 		h.logger.Warnf("Failed to login user: %v", err)
-		utils.RespondWithError(w, http.StatusUnauthorized, "invalid credentials")
+		h.loginFailures.Fail(failureKeys...)
+		utils.RespondError(w, http.StatusUnauthorized, "invalid credentials")
 		return
 	}
+	h.loginFailures.Reset(failureKeys...)
 	
 	// Return success response with token
-	utils.RespondWithSuccess(w, http.StatusOK, "login successful", tokenResponse)
+	utils.Respond(w, http.StatusOK, "login successful", tokenResponse)
 }
 
 // GetUser handles fetching user information
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	// Only allow GET requests
 	if r.Method != http.MethodGet {
-		utils.RespondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		utils.RespondError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -107,26 +158,26 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	user, err := h.userService.GetByID(r.Context(), userID)
 	if err != nil {
 		h.logger.Warnf("Failed to get user: %v", err)
-		utils.RespondWithError(w, http.StatusInternalServerError, "failed to get user details")
+		utils.RespondError(w, http.StatusInternalServerError, "failed to get user details")
 		return
 	}
 	
 	// Return success response with user details
-	utils.RespondWithSuccess(w, http.StatusOK, "user details retrieved successfully", user)
+	utils.Respond(w, http.StatusOK, "user details retrieved successfully", user)
 }
 
 // UpdateUser handles updating user information
 func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	// Only allow PUT requests
 	if r.Method != http.MethodPut {
-		utils.RespondWithError(w, http.StatusMethodNotAllowed, "method not allowed")
+		utils.RespondError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 	
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("user_id").(int)
 	if !ok {
-		utils.RespondWithError(w, http.StatusInternalServerError, "user ID not found in context")
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
 		return
 	}
 	
@@ -134,7 +185,7 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	var user models.User
 	decoder := json.NewDecoder(r.Body)
 	if err := decoder.Decode(&user); err != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, "invalid request payload")
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
 		return
 	}
 	defer r.Body.Close()
@@ -146,10 +197,328 @@ func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	err := h.userService.Update(r.Context(), &user)
 	if err != nil {
 		h.logger.Warnf("Failed to update user: %v", err)
-		utils.RespondWithError(w, http.StatusBadRequest, err.Error())
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "user updated successfully", nil)
+}
+
+// ChangePassword handles changing the password of the authenticated user
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	currentSessionID, _ := r.Context().Value("session_id").(string)
+	
+	// Parse request body
+	var change models.PasswordChangeRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&change); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	// Change the password
+	err := h.userService.ChangePassword(r.Context(), userID, currentSessionID, &change)
+	if err != nil {
+		h.logger.Warnf("Failed to change password: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "password changed successfully", nil)
+}
+
+// ForgotPassword handles requesting a password reset link by email
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	// Parse request body
+	var forgot models.PasswordForgotRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&forgot); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	// Reset links are emailed, so they require a CAPTCHA when it is enabled
+	if !h.verifyCaptcha(w, r) {
+		return
+	}
+	
+	// Send the reset link
+	if err := h.userService.ForgotPassword(r.Context(), &forgot); err != nil {
+		h.logger.Warnf("Failed to request password reset: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// The response is the same whether or not the email has an account
+	utils.Respond(w, http.StatusOK, "if the email is registered, a password reset link has been sent", nil)
+}
+
+// ResetPassword handles setting a new password with a reset token, from the API or from the form
+// of the reset page
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		h.resetPasswordForm(w, r)
+		return
+	}
+	
+	// Parse request body
+	var reset models.PasswordResetRequest
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&reset); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	// Reset the password
+	if err := h.userService.ResetPassword(r.Context(), &reset); err != nil {
+		h.logger.Warnf("Failed to reset password: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
 		return
 	}
 	
 	// Return success response
-	utils.RespondWithSuccess(w, http.StatusOK, "user updated successfully", nil)
-}
\ No newline at end of file
+	utils.Respond(w, http.StatusOK, "password reset successfully", nil)
+}
+
+// ConfirmPasswordReset handles the link from a password reset email. Opening the link only shows a
+// form for the new password, so mail scanners that follow links do not use the token up.
+func (h *UserHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	page := h.resetPage(r)
+	page.Token = r.URL.Query().Get("token")
+	
+	if page.Token == "" {
+		page.Text = i18n.Text(page.Lang, "page.reset_password.invalid")
+		renderResetPage(w, http.StatusBadRequest, page)
+		return
+	}
+	
+	page.Text = i18n.Text(page.Lang, "page.reset_password.prompt")
+	renderResetPage(w, http.StatusOK, page)
+}
+
+// resetPasswordForm handles the form of the reset page. A rejected password shows the form again
+// with the reason.
+func (h *UserHandler) resetPasswordForm(w http.ResponseWriter, r *http.Request) {
+	page := h.resetPage(r)
+	
+	reset := &models.PasswordResetRequest{
+		Token:       r.PostFormValue("token"),
+		NewPassword: r.PostFormValue("new_password"),
+	}
+	
+	if err := h.userService.ResetPassword(r.Context(), reset); err != nil {
+		h.logger.Warnf("Failed to reset password: %v", err)
+		page.Text = i18n.Translate(page.Lang, err.Error())
+		var message *i18n.Error
+		if errors.As(err, &message) {
+			page.Text = i18n.TranslateError(page.Lang, message.Code, err.Error(), message.Params)
+		}
+		page.Token = reset.Token
+		renderResetPage(w, http.StatusBadRequest, page)
+		return
+	}
+	
+	page.Text = i18n.Text(page.Lang, "page.reset_password.done")
+	renderResetPage(w, http.StatusOK, page)
+}
+
+// resetPageData is what the reset page shows; the form is shown when Token is set
+type resetPageData struct {
+	Lang  i18n.Language
+	Text  string
+	Token string
+}
+
+// resetPage starts the reset page in the language of the request
+func (h *UserHandler) resetPage(r *http.Request) *resetPageData {
+	lang, ok := i18n.FromContext(r.Context())
+	if !ok {
+		lang = h.config.Bank.DefaultLanguage()
+	}
+	return &resetPageData{Lang: lang}
+}
+
+// resetPageTemplate is the page of the password reset link
+var resetPageTemplate = template.Must(template.New("reset").Funcs(template.FuncMap{
+	"text": i18n.Text,
+}).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>{{text .Lang "page.reset_password.title"}}</title>
+</head>
+<body>
+	<h2>{{text .Lang "page.reset_password.title"}}</h2>
+	<p>{{.Text}}</p>
+	{{if .Token}}
+	<form method="post" action="/password/reset">
+		<input type="hidden" name="token" value="{{.Token}}">
+		<label>{{text .Lang "page.reset_password.new_password"}}: <input type="password" name="new_password" autocomplete="new-password" required></label>
+		<button type="submit">{{text .Lang "page.reset_password.button"}}</button>
+	</form>
+	{{end}}
+</body>
+</html>
+`))
+
+// renderResetPage writes the reset page. Like the revoke page, it keeps the token in its URL out of
+// the Referer header and caches and must not be framed.
+func renderResetPage(w http.ResponseWriter, status int, page *resetPageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.WriteHeader(status)
+	resetPageTemplate.Execute(w, page)
+}
+
+// IssueToken handles issuing a token limited to the requested scopes and bound to the current session
+func (h *UserHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	sessionID, _ := r.Context().Value("session_id").(string)
+	
+	// Parse request body
+	var request models.ScopedTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	tokenResponse, err := h.userService.IssueScopedToken(r.Context(), userID, sessionID, &request)
+	if err != nil {
+		h.logger.Warnf("Failed to issue scoped token: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response with token
+	utils.Respond(w, http.StatusCreated, "token issued successfully", tokenResponse)
+}
+
+// SetTimezone handles changing the time zone of the authenticated user
+func (h *UserHandler) SetTimezone(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var request models.TimezoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	if err := h.userService.SetTimezone(r.Context(), userID, request.Timezone); err != nil {
+		h.logger.Warnf("Failed to set timezone: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "timezone updated successfully", nil)
+}
+
+// UpdateProfile handles replacing the personal details of the authenticated user
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var profile models.ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&profile); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	user, err := h.userService.UpdateProfile(r.Context(), userID, &profile)
+	if err != nil {
+		h.logger.Warnf("Failed to update profile: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "profile updated successfully", user)
+}
+
+// SetLanguage handles changing the language of the authenticated user
+func (h *UserHandler) SetLanguage(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("user_id").(int)
+	if !ok {
+		utils.RespondError(w, http.StatusInternalServerError, "user ID not found in context")
+		return
+	}
+	
+	// Parse request body
+	var request models.LanguageRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		utils.RespondError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+	
+	if err := h.userService.SetLanguage(r.Context(), userID, request.Language); err != nil {
+		h.logger.Warnf("Failed to set language: %v", err)
+		utils.RespondErrorFrom(w, http.StatusBadRequest, err)
+		return
+	}
+	
+	// Return success response
+	utils.Respond(w, http.StatusOK, "language updated successfully", nil)
+}
+
+// verifyCaptcha checks the CAPTCHA token of the request and writes the error response
+// if it is missing or invalid. It reports whether the request may proceed.
+func (h *UserHandler) verifyCaptcha(w http.ResponseWriter, r *http.Request) bool {
+	if h.captcha == nil {
+		return true
+	}
+	
+	err := h.captcha.Verify(r.Context(), r.Header.Get(captchaTokenHeader), utils.ClientIP(r))
+	if err == nil {
+		return true
+	}
+	
+	if !errors.Is(err, captcha.ErrMissingToken) && !errors.Is(err, captcha.ErrInvalidToken) {
+		h.logger.Errorf("Failed to verify captcha: %v", err)
+		utils.RespondError(w, http.StatusServiceUnavailable, "captcha verification is unavailable")
+		return false
+	}
+	
+	utils.RespondErrorDetails(w, http.StatusForbidden, err.Error(), captchaDetails{
+		CaptchaRequired: true,
+		Provider:        h.config.Captcha.Provider,
+		SiteKey:         h.config.Captcha.SiteKey,
+	})
+	return false
+}
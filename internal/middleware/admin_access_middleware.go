@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"banking-service/pkg/utils"
+)
+
+// IPAllowlistMiddleware allows the request only if the client IP belongs to one of the networks
+func IPAllowlistMiddleware(networks []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := net.ParseIP(utils.ClientIP(r))
+			if ip == nil || !containsIP(networks, ip) {
+				utils.RespondError(w, http.StatusForbidden, "access denied: source address not allowed")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientCertMiddleware allows the request only if the client presented a certificate
+// that the TLS server verified against the configured client CA
+func ClientCertMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				utils.RespondError(w, http.StatusForbidden, "access denied: client certificate required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// containsIP reports whether any of the networks contains ip
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"banking-service/internal/models"
+	"banking-service/pkg/utils"
+)
+
+// APIKeyAuthenticator resolves an API key and the user it acts for
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*models.APIKey, *models.User, error)
+}
+
+// APIKeyMiddleware authenticates requests that carry an API key in the X-API-Key header instead of
+// a JWT token. It adds the user, the key and its scopes to the request context, so ScopeMiddleware
+// keeps the key to the routes its scopes allow. Requests without the header are left to
+// AuthMiddleware, which must be applied after it.
+func APIKeyMiddleware(keys APIKeyAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := r.Header.Get("X-API-Key")
+			if apiKey == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.Header.Get("Authorization") != "" {
+				utils.RespondError(w, http.StatusUnauthorized, "provide either an authorization header or an API key, not both")
+				return
+			}
+
+			key, user, err := keys.Authenticate(r.Context(), apiKey)
+			if err != nil {
+				utils.RespondErrorFrom(w, http.StatusUnauthorized, err)
+				return
+			}
+
+			// A key is valid only for the tenant of its user
+			if requested, ok := r.Context().Value("tenant").(string); ok && requested != user.Tenant {
+				utils.RespondError(w, http.StatusUnauthorized, "invalid API key: issued for another tenant")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "user_id", user.ID)
+			ctx = context.WithValue(ctx, "api_key_id", key.ID)
+			ctx = context.WithValue(ctx, "role", string(user.Role))
+			ctx = context.WithValue(ctx, "tenant", user.Tenant)
+			ctx = context.WithValue(ctx, "scopes", key.Scopes)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
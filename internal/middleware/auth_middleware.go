@@ -8,23 +8,41 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"banking-service/internal/models"
+	"banking-service/pkg/i18n"
 	"banking-service/pkg/utils"
 )
 
-// AuthMiddleware checks if the request has a valid JWT token
-func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
+// TokenParser parses a JWT token and verifies its signature
+type TokenParser interface {
+	Parse(tokenString string) (*jwt.Token, error)
+}
+
+// SessionValidator checks that the session a token was issued for is still active
+type SessionValidator interface {
+	Validate(ctx context.Context, sessionID string, userID int) error
+}
+
+// AuthMiddleware checks if the request has a valid JWT token bound to an active session. Requests
+// APIKeyMiddleware authenticated already are passed through.
+func AuthMiddleware(tokens TokenParser, sessions SessionValidator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.Context().Value("api_key_id").(int); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			
 			// Get the Authorization header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				utils.RespondWithError(w, http.StatusUnauthorized, "no authorization header provided")
+				utils.RespondError(w, http.StatusUnauthorized, "no authorization header provided")
 				return
 			}
 			
 			// Check if the Authorization header has the Bearer prefix
 			if !strings.HasPrefix(authHeader, "Bearer ") {
-				utils.RespondWithError(w, http.StatusUnauthorized, "invalid authorization header format")
+				utils.RespondError(w, http.StatusUnauthorized, "invalid authorization header format")
 				return
 			}
 			
@@ -32,17 +50,10 @@ func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
 			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 			
 			// Parse and validate the token
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				// Validate the signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, errors.New("unexpected signing method")
-				}
-				
-				return []byte(jwtSecret), nil
-			})
+			token, err := tokens.Parse(tokenString)
 			
 			if err != nil {
-				utils.RespondWithError(w, http.StatusUnauthorized, "invalid token: "+err.Error())
+				utils.RespondErrorFrom(w, http.StatusUnauthorized, i18n.Errorf("invalid_token_reason", err.Error()))
 				return
 			}
 			
@@ -51,26 +62,119 @@ func AuthMiddleware(jwtSecret string) func(http.Handler) http.Handler {
 				// Get user ID from claims
 				userID, ok := claims["user_id"]
 				if !ok {
-					utils.RespondWithError(w, http.StatusUnauthorized, "invalid token: missing user_id claim")
+					utils.RespondError(w, http.StatusUnauthorized, "invalid token: missing user_id claim")
 					return
 				}
 				
 				// Convert user ID to float64 (JSON numbers are float64)
 				userIDFloat, ok := userID.(float64)
 				if !ok {
-					utils.RespondWithError(w, http.StatusUnauthorized, "invalid token: user_id has wrong type")
+					utils.RespondError(w, http.StatusUnauthorized, "invalid token: user_id has wrong type")
+					return
+				}
+				
+				// Check that the session has not been revoked
+				sessionID, _ := claims["sid"].(string)
+				if sessionID == "" {
+					utils.RespondError(w, http.StatusUnauthorized, "invalid token: missing sid claim")
+					return
+				}
+				
+				// An impersonation token is bound to the session of the support employee behind it
+				impersonationID, impersonatorID, err := parseImpersonation(claims)
+				if err != nil {
+					utils.RespondErrorFrom(w, http.StatusUnauthorized, i18n.Errorf("invalid_token_reason", err.Error()))
+					return
+				}
+				sessionUserID := int(userIDFloat)
+				if impersonationID != 0 {
+					sessionUserID = impersonatorID
+				}
+				
+				if err := sessions.Validate(r.Context(), sessionID, sessionUserID); err != nil {
+					utils.RespondErrorFrom(w, http.StatusUnauthorized, i18n.Errorf("invalid_token_reason", err.Error()))
 					return
 				}
 				
-				// Add user ID to request context
+				// Add user ID and session ID to request context
 				ctx := context.WithValue(r.Context(), "user_id", int(userIDFloat))
+				ctx = context.WithValue(ctx, "session_id", sessionID)
+				if impersonationID != 0 {
+					ctx = context.WithValue(ctx, "impersonation_id", impersonationID)
+					ctx = context.WithValue(ctx, "impersonator_id", impersonatorID)
+				}
+				
+				// Add role to request context (tokens issued before roles existed are customers)
+				role, _ := claims["role"].(string)
+				if role == "" {
+					role = string(models.UserRoleCustomer)
+				}
+				ctx = context.WithValue(ctx, "role", role)
+				
+				// Tokens are valid only for the tenant they were issued for (older tokens belong to the default one)
+				tenant, _ := claims["tenant"].(string)
+				if tenant == "" {
+					tenant = models.DefaultTenant
+				}
+				if requested, ok := r.Context().Value("tenant").(string); ok && requested != tenant {
+					utils.RespondError(w, http.StatusUnauthorized, "invalid token: issued for another tenant")
+					return
+				}
+				ctx = context.WithValue(ctx, "tenant", tenant)
+				
+				// A limited token lists its scopes; tokens without the claim have full access
+				if rawScopes, ok := claims["scopes"]; ok {
+					scopes, err := parseScopes(rawScopes)
+					if err != nil {
+						utils.RespondErrorFrom(w, http.StatusUnauthorized, i18n.Errorf("invalid_token_reason", err.Error()))
+						return
+					}
+					ctx = context.WithValue(ctx, "scopes", scopes)
+				}
 				
 				// Call the next handler with the updated context
 				next.ServeHTTP(w, r.WithContext(ctx))
 			} else {
-				utils.RespondWithError(w, http.StatusUnauthorized, "invalid token")
+				utils.RespondError(w, http.StatusUnauthorized, "invalid token")
 				return
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// parseImpersonation reads the impersonation and the support employee behind a token, both zero
+// for a token the user obtained themselves
+func parseImpersonation(claims jwt.MapClaims) (int, int, error) {
+	rawID, impersonated := claims["impersonation_id"]
+	rawImpersonator, hasImpersonator := claims["impersonator_id"]
+	if !impersonated && !hasImpersonator {
+		return 0, 0, nil
+	}
+	
+	id, ok := rawID.(float64)
+	impersonatorID, ok2 := rawImpersonator.(float64)
+	if !ok || !ok2 || id <= 0 || impersonatorID <= 0 {
+		return 0, 0, errors.New("impersonation claims have wrong type")
+	}
+	
+	return int(id), int(impersonatorID), nil
+}
+
+// parseScopes reads the scopes claim of a token, a non-empty list of scope names
+func parseScopes(claim interface{}) ([]models.Scope, error) {
+	list, ok := claim.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, errors.New("scopes claim has wrong type")
+	}
+	
+	scopes := make([]models.Scope, len(list))
+	for i, value := range list {
+		name, ok := value.(string)
+		if !ok {
+			return nil, errors.New("scopes claim has wrong type")
+		}
+		scopes[i] = models.Scope(name)
+	}
+	
+	return scopes, nil
+}
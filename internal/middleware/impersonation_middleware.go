@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"banking-service/internal/models"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/utils"
+)
+
+// ImpersonationAuditor checks that an impersonation is still active and records the requests made
+// with its token
+type ImpersonationAuditor interface {
+	Audit(ctx context.Context, request *models.ImpersonationRequest) error
+}
+
+// readOnlyMethods are the methods an impersonation token may use
+var readOnlyMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// ImpersonationMiddleware records every request made with an impersonation token before serving
+// it, and refuses the ones that could change anything. A request that cannot be recorded is not
+// served. It must be applied after AuthMiddleware.
+func ImpersonationMiddleware(auditor ImpersonationAuditor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, impersonated := r.Context().Value("impersonation_id").(int)
+			if !impersonated {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entry := &models.ImpersonationRequest{
+				ImpersonationID: id,
+				Method:          r.Method,
+				Path:            r.URL.RequestURI(),
+				Allowed:         readOnlyMethods[r.Method],
+			}
+			if err := auditor.Audit(r.Context(), entry); err != nil {
+				utils.RespondErrorFrom(w, http.StatusUnauthorized, i18n.Errorf("invalid_token_reason", err.Error()))
+				return
+			}
+
+			if !entry.Allowed {
+				utils.RespondError(w, http.StatusForbidden, "access denied: impersonation tokens are read-only")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"banking-service/internal/models"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/utils"
+)
+
+// UserProvider looks up the user of an authenticated request for their language preference
+type UserProvider interface {
+	GetByID(ctx context.Context, id int) (*models.User, error)
+}
+
+// LocalizationMiddleware picks the language of a request and translates the message and the error
+// messages of its response envelope. The language is the preference of the signed in user when
+// users is set, else the one an outer instance picked, else the best match of the Accept-Language
+// header, else defaultLang. Handlers keep writing English messages; those with values are
+// i18n.Error ones, translated by their codes.
+func LocalizationMiddleware(defaultLang i18n.Language, users UserProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang := requestLanguage(r, defaultLang, users)
+			if _, ok := i18n.FromContext(r.Context()); !ok {
+				w.Header().Add("Vary", "Accept-Language")
+			}
+
+			lw := &localizedResponseWriter{ResponseWriter: w, lang: lang}
+			defer lw.Close()
+
+			next.ServeHTTP(lw, r.WithContext(i18n.WithLanguage(r.Context(), lang)))
+		})
+	}
+}
+
+// requestLanguage picks the language of a request
+func requestLanguage(r *http.Request, defaultLang i18n.Language, users UserProvider) i18n.Language {
+	if userID, ok := r.Context().Value("user_id").(int); ok && users != nil {
+		if user, err := users.GetByID(r.Context(), userID); err == nil {
+			if lang, ok := i18n.Parse(user.Language); ok {
+				return lang
+			}
+		}
+	}
+
+	if lang, ok := i18n.FromContext(r.Context()); ok {
+		return lang
+	}
+
+	if lang, ok := i18n.Negotiate(r.Header.Get("Accept-Language")); ok {
+		return lang
+	}
+
+	return defaultLang
+}
+
+// localizedResponseWriter buffers JSON responses that need translating and passes the rest through
+type localizedResponseWriter struct {
+	http.ResponseWriter
+	lang        i18n.Language
+	status      int
+	wroteHeader bool
+	buffer      *bytes.Buffer
+}
+
+// WriteHeader decides whether the response needs translating and forwards the status code otherwise
+func (w *localizedResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	// An inner instance has already translated the response
+	header := w.Header()
+	if header.Get("Content-Language") != "" {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	header.Set("Content-Language", string(w.lang))
+
+	// Encoded and non-JSON bodies are left alone, as are English ones, which need no translating
+	if header.Get("Content-Encoding") != "" ||
+		!strings.HasPrefix(header.Get("Content-Type"), "application/json") ||
+		w.lang == i18n.English {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+	w.buffer = &bytes.Buffer{}
+}
+
+// Write buffers the body of a response being translated and forwards it otherwise
+func (w *localizedResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.buffer == nil {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buffer.Write(b)
+}
+
+// Close writes the translated body of a buffered response
+func (w *localizedResponseWriter) Close() {
+	if w.buffer == nil {
+		return
+	}
+
+	body := localizeBody(w.buffer.Bytes(), w.lang)
+	w.buffer = nil
+
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(body)
+}
+
+// Unwrap returns the wrapped ResponseWriter so http.ResponseController can reach it
+func (w *localizedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// localizeBody translates the message and the error messages of a response envelope, the latter
+// by their codes when they have params. Other bodies are returned unchanged.
+func localizeBody(body []byte, lang i18n.Language) []byte {
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Meta   utils.Meta      `json:"meta"`
+		Errors []utils.Error   `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Errors == nil {
+		return body
+	}
+
+	if envelope.Meta.Message != "" {
+		envelope.Meta.Message = i18n.Translate(lang, envelope.Meta.Message)
+	}
+	for i := range envelope.Errors {
+		e := &envelope.Errors[i]
+		e.Message = i18n.TranslateError(lang, e.Code, e.Message, e.Params)
+	}
+
+	localized, err := json.Marshal(envelope)
+	if err != nil {
+		return body
+	}
+	return append(localized, '\n')
+}
@@ -22,6 +22,7 @@ func LogMiddleware(logger *logrus.Logger) func(http.Handler) http.Handler {
 			// Log the request details
 			duration := time.Since(start)
 			logger.WithFields(logrus.Fields{
+				"request_id": r.Context().Value("request_id"),
 				"method":     r.Method,
 				"path":       r.URL.Path,
 				"status":     rw.status,
@@ -61,4 +62,9 @@ func (rw *statusResponseWriter) Write(b []byte) (int, error) {
 // Header forwards the header to the wrapped ResponseWriter
 func (rw *statusResponseWriter) Header() http.Header {
 	return rw.ResponseWriter.Header()
+}
+
+// Unwrap returns the wrapped ResponseWriter so http.ResponseController can reach it
+func (rw *statusResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/pkg/utils"
+)
+
+// maintenanceDetails are the error details returned to blocked requests while maintenance mode is on
+type maintenanceDetails struct {
+	Maintenance bool   `json:"maintenance"`
+	Message     string `json:"message,omitempty"`
+	RetryAfter  int    `json:"retry_after,omitempty"`
+}
+
+// MaintenanceMiddleware answers customer requests with 503 Service Unavailable while
+// maintenance mode is on. Requests from admins pass through, so on protected routes
+// it must be applied after AuthMiddleware.
+func MaintenanceMiddleware(live *configs.Live) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := live.Maintenance()
+			if !state.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if role, ok := r.Context().Value("role").(string); ok && role == string(models.UserRoleAdmin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if state.RetryAfter > 0 {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", state.RetryAfter))
+			}
+
+			utils.RespondErrorDetails(w, http.StatusServiceUnavailable, "service is under maintenance", maintenanceDetails{
+				Maintenance: true,
+				Message:     state.Message,
+				RetryAfter:  state.RetryAfter,
+			})
+		})
+	}
+}
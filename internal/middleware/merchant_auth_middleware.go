@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"banking-service/internal/models"
+	"banking-service/pkg/utils"
+)
+
+// MerchantAuthenticator resolves a merchant from its API key
+type MerchantAuthenticator interface {
+	Authenticate(ctx context.Context, apiKey string) (*models.Merchant, error)
+}
+
+// MerchantAuthMiddleware authenticates merchant API requests by the X-API-Key header
+// and adds the merchant ID to the request context
+func MerchantAuthMiddleware(merchants MerchantAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := r.Header.Get("X-API-Key")
+			if apiKey == "" {
+				utils.RespondError(w, http.StatusUnauthorized, "no API key provided")
+				return
+			}
+
+			merchant, err := merchants.Authenticate(r.Context(), apiKey)
+			if err != nil {
+				utils.RespondErrorFrom(w, http.StatusUnauthorized, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "merchant_id", merchant.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
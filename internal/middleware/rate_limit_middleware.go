@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"banking-service/configs"
+	"banking-service/pkg/utils"
+)
+
+// bucketIdleTTL is how long an unused client bucket is kept before it is evicted
+const bucketIdleTTL = 10 * time.Minute
+
+// RateLimitMiddleware limits the number of requests per client IP using a token bucket.
+// Limits are read from the live configuration on every request so they can be reloaded.
+func RateLimitMiddleware(live *configs.Live) func(http.Handler) http.Handler {
+	return rateLimitMiddleware(live.RateLimit)
+}
+
+// AvailabilityRateLimitMiddleware applies the stricter per-client limit of the signup
+// availability check on top of the general one, so it cannot be used to enumerate accounts
+func AvailabilityRateLimitMiddleware(live *configs.Live) func(http.Handler) http.Handler {
+	return rateLimitMiddleware(func() configs.RateLimitConfig {
+		limits := live.RateLimit()
+		return configs.RateLimitConfig{
+			RequestsPerMinute: limits.AvailabilityRequestsPerMinute,
+			Burst:             limits.AvailabilityBurst,
+		}
+	})
+}
+
+// rateLimitMiddleware limits the requests per client IP to the limits returned by current,
+// with buckets of its own
+func rateLimitMiddleware(current func() configs.RateLimitConfig) func(http.Handler) http.Handler {
+	limiter := newRateLimiter()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limits := current()
+			if limits.RequestsPerMinute <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			allowed, retryAfter := limiter.allow(utils.ClientIP(r), limits, time.Now())
+			if !allowed {
+				w.Header().Set("Retry-After", fmt.Sprintf("%d", int(math.Ceil(retryAfter.Seconds()))))
+				utils.RespondError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tokenBucket tracks the available tokens for a single client
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter is an in-memory token bucket rate limiter keyed by client
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// newRateLimiter creates a new rateLimiter
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow takes a token from the client's bucket and reports how long to wait if none is left
+func (l *rateLimiter) allow(key string, limits configs.RateLimitConfig, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	capacity := float64(limits.Burst)
+	if capacity < 1 {
+		capacity = 1
+	}
+	ratePerSecond := float64(limits.RequestsPerMinute) / 60
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: capacity, lastSeen: now}
+		l.buckets[key] = bucket
+	}
+
+	// Refill tokens for the time elapsed since the last request
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	bucket.tokens = math.Min(capacity, bucket.tokens+elapsed*ratePerSecond)
+	bucket.lastSeen = now
+
+	if bucket.tokens < 1 {
+		wait := (1 - bucket.tokens) / ratePerSecond
+		return false, time.Duration(wait * float64(time.Second))
+	}
+
+	bucket.tokens--
+	return true, 0
+}
+
+// sweep evicts buckets that have been idle for a while
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > bucketIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+
+	"banking-service/pkg/utils"
+)
+
+// RequireRole allows the request only if the authenticated user has one of the given roles.
+// It must be applied after AuthMiddleware.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, ok := r.Context().Value("role").(string)
+			if !ok || !allowed[role] {
+				utils.RespondError(w, http.StatusForbidden, "access denied: insufficient role")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"banking-service/internal/models"
+	"banking-service/pkg/utils"
+)
+
+// scopedHandler is a route handler that a limited token may use only if it has the route's scope
+type scopedHandler struct {
+	scope models.Scope
+	next  http.Handler
+}
+
+// RequireScope declares the scope a limited token needs to use a route. Tokens without scopes have
+// full access. It must be applied after AuthMiddleware.
+func RequireScope(scope models.Scope, next http.Handler) http.Handler {
+	return &scopedHandler{scope: scope, next: next}
+}
+
+// ServeHTTP serves the request if the token may use the route
+func (h *scopedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !hasScope(r, h.scope) {
+		utils.RespondError(w, http.StatusForbidden, "access denied: token scope does not allow this endpoint")
+		return
+	}
+
+	h.next.ServeHTTP(w, r)
+}
+
+// ScopeMiddleware keeps limited tokens off the routes that do not declare a scope with
+// RequireScope, so a route added without one is never open to them. It must be applied to a router
+// after AuthMiddleware.
+func ScopeMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, limited := r.Context().Value("scopes").([]models.Scope); limited && !declaresScope(mux.CurrentRoute(r)) {
+				utils.RespondError(w, http.StatusForbidden, "access denied: token scope does not allow this endpoint")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// declaresScope reports whether a route was registered with RequireScope
+func declaresScope(route *mux.Route) bool {
+	if route == nil {
+		return false
+	}
+
+	_, scoped := route.GetHandler().(*scopedHandler)
+	return scoped
+}
+
+// hasScope reports whether the request's token may use an endpoint that requires the scope
+func hasScope(r *http.Request, scope models.Scope) bool {
+	scopes, limited := r.Context().Value("scopes").([]models.Scope)
+	if !limited {
+		return true
+	}
+
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"banking-service/configs"
+	"banking-service/pkg/utils"
+)
+
+// TenantHeader selects the tenant of a request explicitly, for clients that share a host
+const TenantHeader = "X-Tenant"
+
+// TenantMiddleware adds the tenant of the request to its context: the one named in the X-Tenant
+// header, else the one serving the request host, else the default tenant. AuthMiddleware then
+// rejects tokens issued for another tenant.
+func TenantMiddleware(cfg *configs.Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenant := configs.DefaultTenant
+
+			if code := r.Header.Get(TenantHeader); code != "" {
+				if _, ok := cfg.Tenant(code); !ok {
+					utils.RespondError(w, http.StatusBadRequest, "unknown tenant")
+					return
+				}
+				tenant = code
+			} else if t, ok := cfg.TenantForHost(r.Host); ok {
+				tenant = t.Code
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "tenant", tenant)))
+		})
+	}
+}
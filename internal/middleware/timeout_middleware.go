@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TimeoutMiddleware overrides the server-wide read and write deadlines for a single route.
+// It is meant for long-running endpoints (exports, reports) that need more time than
+// the defaults configured on http.Server.
+func TimeoutMiddleware(readTimeout, writeTimeout time.Duration, logger *logrus.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rc := http.NewResponseController(w)
+			now := time.Now()
+
+			if err := rc.SetReadDeadline(now.Add(readTimeout)); err != nil {
+				logger.Warnf("Failed to extend read deadline for %s: %v", r.URL.Path, err)
+			}
+			if err := rc.SetWriteDeadline(now.Add(writeTimeout)); err != nil {
+				logger.Warnf("Failed to extend write deadline for %s: %v", r.URL.Path, err)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package models
 
 import (
 	"errors"
-	"math/rand"
 	"time"
 )
 
@@ -28,41 +27,42 @@ const (
 type Account struct {
 	ID           int        `json:"id" db:"id"`
 	UserID       int        `json:"user_id" db:"user_id"`
+	OrganizationID *int     `json:"organization_id,omitempty" db:"organization_id"`
 	AccountNumber string     `json:"account_number" db:"account_number"`
-	Balance      float64    `json:"balance" db:"balance"`
+	Balance      float64    `json:"balance" db:"balance"` // ledger balance
+	AvailableBalance float64 `json:"available_balance" db:"-"` // ledger balance minus active holds
 	Currency     Currency   `json:"currency" db:"currency"`
 	AccountType  AccountType `json:"account_type" db:"account_type"`
 	IsActive     bool       `json:"is_active" db:"is_active"`
+	IsDefault    bool       `json:"is_default" db:"is_default"` // receives incoming transfers in its currency and is the default source in clients
+	DormantSince *time.Time `json:"dormant_since,omitempty" db:"dormant_since"` // set while outgoing operations are blocked for inactivity
+	ReactivatedAt *time.Time `json:"reactivated_at,omitempty" db:"reactivated_at"`
+	LockedUntil  *time.Time `json:"locked_until,omitempty" db:"locked_until"` // end of the last statement period; earlier transactions cannot change
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+	Settings     *AccountSettings `json:"settings,omitempty" db:"-"` // the requesting user's display settings, if any
 }
 
 // AccountCreate represents data for creating a new account
 type AccountCreate struct {
 	UserID      int        `json:"user_id" binding:"required"`
+	OrganizationID *int     `json:"organization_id,omitempty"` // set for accounts owned by an organization
 	Currency    Currency   `json:"currency" binding:"required"`
 	AccountType AccountType `json:"account_type" binding:"required"`
 	InitialBalance float64  `json:"initial_balance,omitempty"`
+	PlanID      *int       `json:"plan_id,omitempty"` // checking and savings accounts default to the cheapest active plan for their type
 }
 
-// AccountBalance represents a balance update request
-type AccountBalance struct {
-	Amount      float64 `json:"amount" binding:"required"`
-	Description string  `json:"description,omitempty"`
+// AccountReactivation represents the owner's password confirming the reactivation of a dormant account
+type AccountReactivation struct {
+	Password string `json:"password" binding:"required"`
 }
 
-// GenerateAccountNumber generates a random account number
-func GenerateAccountNumber() string {
-	rand.Seed(time.Now().UnixNano())
-	
-	// Format: 40817XXXXXXXXXX (16 digits)
-	accountNumber := "40817"
-	
-	for i := 0; i < 12; i++ {
-		accountNumber += string(rune('0' + rand.Intn(10)))
-	}
-	
-	return accountNumber
+// AccountBalance represents a balance update request
+type AccountBalance struct {
+	Amount      float64  `json:"amount" binding:"required"`
+	Currency    Currency `json:"currency,omitempty"` // Optional, must match the account currency
+	Description string   `json:"description,omitempty"`
 }
 
 // ValidateAccountCreate validates account creation data
@@ -91,11 +91,11 @@ func (a *AccountCreate) ValidateAccountCreate() error {
 	return nil
 }
 
-// ToAccount converts AccountCreate to Account
+// ToAccount converts AccountCreate to Account; the repository assigns the account number
 func (a *AccountCreate) ToAccount() *Account {
 	return &Account{
 		UserID:       a.UserID,
-		AccountNumber: GenerateAccountNumber(),
+		OrganizationID: a.OrganizationID,
 		Balance:      a.InitialBalance,
 		Currency:     a.Currency,
 		AccountType:  a.AccountType,
@@ -103,6 +103,11 @@ func (a *AccountCreate) ToAccount() *Account {
 	}
 }
 
+// IsDormant reports whether the account was flagged for inactivity and not reactivated yet
+func (a *Account) IsDormant() bool {
+	return a.DormantSince != nil
+}
+
 // ValidateBalanceUpdate validates a balance update request
 func (a *AccountBalance) ValidateBalanceUpdate() error {
 	if a.Amount <= 0 {
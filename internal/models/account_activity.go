@@ -0,0 +1,174 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// Account activity pages hold DefaultAccountActivityLimit items unless the request asks for another
+// size of at most MaxAccountActivityLimit
+const (
+	DefaultAccountActivityLimit = 50
+	MaxAccountActivityLimit     = 200
+)
+
+// AccountEventType defines a change to an account, its cards or its limits
+type AccountEventType string
+
+const (
+	AccountEventOpened        AccountEventType = "ACCOUNT_OPENED"
+	AccountEventActivated     AccountEventType = "ACCOUNT_ACTIVATED"
+	AccountEventDeactivated   AccountEventType = "ACCOUNT_DEACTIVATED"
+	AccountEventDormant       AccountEventType = "ACCOUNT_DORMANT"
+	AccountEventReactivated   AccountEventType = "ACCOUNT_REACTIVATED"
+	AccountEventOwnerChanged  AccountEventType = "OWNER_CHANGED"
+	AccountEventCardCreated   AccountEventType = "CARD_CREATED"
+	AccountEventCardFrozen    AccountEventType = "CARD_FROZEN"
+	AccountEventCardUnfrozen  AccountEventType = "CARD_UNFROZEN"
+	AccountEventLimitsChanged AccountEventType = "LIMITS_CHANGED" // the approval policies of the account's organization
+)
+
+// AccountEvent records a change to an account, one of its cards or the transfer limits that apply
+// to it. The database writes them as the account, card and approval policy rows change.
+type AccountEvent struct {
+	ID        int                  `json:"id" db:"id"`
+	AccountID int                  `json:"account_id" db:"account_id"`
+	Type      AccountEventType     `json:"type" db:"event_type"`
+	CardID    *int                 `json:"card_id,omitempty" db:"card_id"`
+	Limits    []ApprovalPolicyRule `json:"limits,omitempty" db:"limits"` // the approval thresholds after the change, none if removed
+	CreatedAt time.Time            `json:"created_at" db:"created_at"`
+}
+
+// Kinds of account activity items
+const (
+	AccountActivityTransaction = "transaction"
+	AccountActivityEvent       = "event"
+)
+
+// AccountActivityItem is a transaction or an event in the activity feed of an account
+type AccountActivityItem struct {
+	Kind        string        `json:"kind"`
+	At          time.Time     `json:"at"` // the transaction date or when the event happened
+	Transaction *Transaction  `json:"transaction,omitempty"`
+	Event       *AccountEvent `json:"event,omitempty"`
+}
+
+// AccountActivityPage represents a page of the activity feed of an account, newest first. At the
+// same time events come before transactions, and later IDs before earlier ones.
+type AccountActivityPage struct {
+	Items      []*AccountActivityItem `json:"items"`
+	NextCursor string                 `json:"next_cursor,omitempty"` // empty on the last page
+	Limit      int                    `json:"limit"`
+}
+
+// ActivityPosition bounds a page of one kind of activity items to those before it, newest first:
+// earlier than At, or at the same time with an ID less than ID
+type ActivityPosition struct {
+	At time.Time
+	ID int
+}
+
+// AccountActivityCursor is the last item of a page of account activity; the next page starts
+// after it
+type AccountActivityCursor struct {
+	Kind string
+	At   time.Time
+	ID   int
+}
+
+// CursorOf returns the cursor of an activity item
+func CursorOf(item *AccountActivityItem) *AccountActivityCursor {
+	cursor := &AccountActivityCursor{Kind: item.Kind, At: item.At}
+	if item.Transaction != nil {
+		cursor.ID = item.Transaction.ID
+	} else {
+		cursor.ID = item.Event.ID
+	}
+	return cursor
+}
+
+// String encodes the cursor as an opaque token
+func (c *AccountActivityCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%d:%d", c.Kind, c.At.UnixNano(), c.ID)))
+}
+
+// ParseAccountActivityCursor decodes a cursor token
+func ParseAccountActivityCursor(token string) (*AccountActivityCursor, error) {
+	invalid := errors.New("invalid cursor")
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 3 || (parts[0] != AccountActivityTransaction && parts[0] != AccountActivityEvent) {
+		return nil, invalid
+	}
+
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id <= 0 {
+		return nil, invalid
+	}
+
+	return &AccountActivityCursor{Kind: parts[0], At: time.Unix(0, nanos), ID: id}, nil
+}
+
+// Before returns the position in the items of a kind where the page after the cursor continues, or
+// nil for the first page. At the cursor's time, a page after an event still holds every
+// transaction, and a page after a transaction holds no events.
+func (c *AccountActivityCursor) Before(kind string) *ActivityPosition {
+	if c == nil {
+		return nil
+	}
+
+	switch {
+	case kind == c.Kind:
+		return &ActivityPosition{At: c.At, ID: c.ID}
+	case kind == AccountActivityTransaction:
+		return &ActivityPosition{At: c.At, ID: math.MaxInt32}
+	default:
+		return &ActivityPosition{At: c.At, ID: 0}
+	}
+}
+
+// Includes reports whether an item at a time with an ID comes after the position
+func (p *ActivityPosition) Includes(at time.Time, id int) bool {
+	return p == nil || at.Before(p.At) || (at.Equal(p.At) && id < p.ID)
+}
+
+// ValidateAccountActivityLimit applies the default page size and checks the requested one
+func ValidateAccountActivityLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultAccountActivityLimit, nil
+	}
+
+	if limit < 0 || limit > MaxAccountActivityLimit {
+		return 0, i18n.Errorf("limit_must_be_between_1_and", strconv.Itoa(MaxAccountActivityLimit))
+	}
+
+	return limit, nil
+}
+
+// Newer reports whether an activity item comes before another in the feed
+func (i *AccountActivityItem) Newer(other *AccountActivityItem) bool {
+	if !i.At.Equal(other.At) {
+		return i.At.After(other.At)
+	}
+	if i.Kind != other.Kind {
+		return i.Kind == AccountActivityEvent
+	}
+	return CursorOf(i).ID > CursorOf(other).ID
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// MaxAccountNumberAttempts is how many generated account numbers the repositories try before
+// giving up on creating an account; each attempt collides only if the number is already taken
+const MaxAccountNumberAttempts = 10
+
+// accountNumberPersonalDigits is the length of the random personal part of an account number
+const accountNumberPersonalDigits = 7
+
+// controlWeights weigh the last three digits of the BIC followed by the 20 digits of the account
+// number when computing the control digit
+const controlWeights = "71371371371371371371371"
+
+// AccountNumberScheme builds account numbers the way the Bank of Russia's chart of accounts lays
+// them out: the balance account (5 digits), the currency code (3), a control digit keyed by the
+// BIC (1), the branch (4) and a random personal number (7)
+type AccountNumberScheme struct {
+	BIC    string // 9 digits; only the last three take part in the control digit
+	Branch string // 4 digits
+}
+
+// Generate returns a new random account number for the account. The number is not checked for
+// uniqueness; the repositories retry with another one when it is taken.
+func (s AccountNumberScheme) Generate(account *Account) (string, error) {
+	currency, ok := accountNumberCurrencies[account.Currency]
+	if !ok {
+		return "", fmt.Errorf("no account number currency code for %s", account.Currency)
+	}
+
+	personal, err := rand.Int(rand.Reader, big.NewInt(10_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate account number: %w", err)
+	}
+
+	number := fmt.Sprintf("%s%s0%s%0*d", balanceAccount(account), currency, s.branch(), accountNumberPersonalDigits, personal)
+	digit, err := s.controlDigit(number)
+	if err != nil {
+		return "", err
+	}
+
+	return number[:8] + string(digit) + number[9:], nil
+}
+
+// controlDigit computes the control digit of an account number whose control digit is zero
+func (s AccountNumberScheme) controlDigit(number string) (byte, error) {
+	key := s.bicKey() + number
+	if len(key) != len(controlWeights) {
+		return 0, errors.New("account number must have 20 digits")
+	}
+
+	sum := 0
+	for i := 0; i < len(key); i++ {
+		if key[i] < '0' || key[i] > '9' {
+			return 0, errors.New("account number must only have digits")
+		}
+		sum += int(key[i]-'0') * int(controlWeights[i]-'0') % 10
+	}
+
+	return byte('0' + sum%10*3%10), nil
+}
+
+// bicKey returns the last three digits of the BIC, zeros without one
+func (s AccountNumberScheme) bicKey() string {
+	if len(s.BIC) < 3 {
+		return "000"
+	}
+	return s.BIC[len(s.BIC)-3:]
+}
+
+// branch returns the branch code, 0000 for the head office
+func (s AccountNumberScheme) branch() string {
+	if s.Branch == "" {
+		return "0000"
+	}
+	return s.Branch
+}
+
+// accountNumberCurrencies are the currency codes used in account numbers; rubles keep the code
+// 810 rather than the ISO 643
+var accountNumberCurrencies = map[Currency]string{
+	CurrencyRUB: "810",
+	CurrencyUSD: "840",
+	CurrencyEUR: "978",
+}
+
+// balanceAccount returns the balance account of the chart of accounts the account belongs to
+func balanceAccount(account *Account) string {
+	if account.OrganizationID != nil {
+		return "40702" // accounts of commercial organizations
+	}
+
+	switch account.AccountType {
+	case AccountTypeSavings:
+		return "42301" // demand deposits of individuals
+	case AccountTypeCredit:
+		return "45507" // loans to individuals
+	default:
+		return "40817" // current accounts of individuals
+	}
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// AccountPlan is a tariff plan of accounts: its monthly fee, the transfers a month free of the
+// transfer fee and the interest paid on the balance. Interest accrues daily on the end-of-day
+// balance. Amounts are in the currency of the account.
+type AccountPlan struct {
+	ID            int                `json:"id" db:"id"`
+	Code          string             `json:"code" db:"code"`
+	Name          string             `json:"name" db:"name"`
+	AccountTypes  []AccountType      `json:"account_types" db:"account_types"`
+	MonthlyFee    float64            `json:"monthly_fee" db:"monthly_fee"`
+	FreeTransfers int                `json:"free_transfers" db:"free_transfers"` // outgoing transfers per calendar month without transfer_fee
+	TransferFee   float64            `json:"transfer_fee" db:"transfer_fee"`     // per transfer beyond the free ones, 0 makes all transfers free
+	InterestTiers []InterestTier     `json:"interest_tiers" db:"interest_tiers"` // by ascending min_balance
+	DayCount      DayCountConvention `json:"day_count" db:"day_count"`           // how the days of the interest are counted
+	IsActive      bool               `json:"is_active" db:"is_active"`           // only active plans can be switched to
+	Tenant        string             `json:"tenant" db:"tenant"`                 // only its accounts can be switched to the plan
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultPlanDayCount is the day-count convention of plans created without one
+const DefaultPlanDayCount = DayCountACT365
+
+// InterestTier is the annual interest rate, in percent, of balances from MinBalance up to the next tier
+type InterestTier struct {
+	MinBalance float64 `json:"min_balance"`
+	Rate       float64 `json:"rate"`
+}
+
+// AccountPlanPeriod is the time an account was on a plan. The monthly fee and interest of the
+// period are settled in arrears up to BilledUntil, so switching plans mid-month prorates both.
+type AccountPlanPeriod struct {
+	ID          int        `json:"id" db:"id"`
+	AccountID   int        `json:"account_id" db:"account_id"`
+	PlanID      int        `json:"plan_id" db:"plan_id"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty" db:"ended_at"` // nil for the account's current plan
+	BilledUntil time.Time  `json:"billed_until" db:"billed_until"`
+}
+
+// AccountPlanStatus represents the current plan of an account and its use this month
+type AccountPlanStatus struct {
+	Plan          *AccountPlan `json:"plan"`
+	Since         time.Time    `json:"since"`
+	TransfersUsed int          `json:"transfers_used"` // outgoing transfers this calendar month
+	InterestRate  float64      `json:"interest_rate"`  // annual rate on the current balance
+}
+
+// AccountPlanSwitch represents a request to move an account to another plan
+type AccountPlanSwitch struct {
+	PlanID int `json:"plan_id" binding:"required"`
+}
+
+// AccountPlanRequest represents the data of an account plan created or updated by an admin
+type AccountPlanRequest struct {
+	Code          string             `json:"code"`
+	Name          string             `json:"name"`
+	AccountTypes  []AccountType      `json:"account_types"`
+	MonthlyFee    float64            `json:"monthly_fee"`
+	FreeTransfers int                `json:"free_transfers"`
+	TransferFee   float64            `json:"transfer_fee"`
+	InterestTiers []InterestTier     `json:"interest_tiers"`
+	DayCount      DayCountConvention `json:"day_count,omitempty"` // defaults to ACT/365
+	IsActive      *bool              `json:"is_active,omitempty"` // defaults to true
+}
+
+// ValidateAccountPlanRequest validates account plan data, normalizes its text fields and sorts its tiers
+func (p *AccountPlanRequest) ValidateAccountPlanRequest() error {
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	if !cardProductCodePattern.MatchString(p.Code) {
+		return errors.New("code must be 2 to 50 capital letters, digits and underscores, starting with a letter")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || len(p.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	if len(p.AccountTypes) == 0 {
+		return errors.New("at least one account type is required")
+	}
+	for _, accountType := range p.AccountTypes {
+		switch accountType {
+		case AccountTypeChecking, AccountTypeSavings:
+		default:
+			return i18n.Errorf("invalid_account_type_plans_apply_to_checking_and_savings_accounts", string(accountType))
+		}
+	}
+
+	if p.MonthlyFee < 0 || p.TransferFee < 0 || p.FreeTransfers < 0 {
+		return errors.New("monthly_fee, free_transfers and transfer_fee cannot be negative")
+	}
+
+	if p.DayCount == "" {
+		p.DayCount = DefaultPlanDayCount
+	}
+	if !p.DayCount.IsValid() {
+		return errors.New("day_count must be one of ACT/365, ACT/360, 30/360")
+	}
+
+	sort.SliceStable(p.InterestTiers, func(i, j int) bool { return p.InterestTiers[i].MinBalance < p.InterestTiers[j].MinBalance })
+	for i, tier := range p.InterestTiers {
+		if tier.MinBalance < 0 || tier.Rate < 0 || tier.Rate > 100 {
+			return errors.New("interest tiers need a min_balance not below 0 and a rate from 0 to 100")
+		}
+		if i > 0 && tier.MinBalance == p.InterestTiers[i-1].MinBalance {
+			return i18n.Errorf("more_than_one_interest_tier_starts_at", strconv.FormatFloat(tier.MinBalance, 'f', 2, 64))
+		}
+	}
+
+	return nil
+}
+
+// ToAccountPlan converts AccountPlanRequest to AccountPlan
+func (p *AccountPlanRequest) ToAccountPlan() *AccountPlan {
+	plan := &AccountPlan{
+		Code:          p.Code,
+		Name:          p.Name,
+		AccountTypes:  p.AccountTypes,
+		MonthlyFee:    p.MonthlyFee,
+		FreeTransfers: p.FreeTransfers,
+		TransferFee:   p.TransferFee,
+		InterestTiers: p.InterestTiers,
+		DayCount:      p.DayCount,
+		IsActive:      p.IsActive == nil || *p.IsActive,
+	}
+
+	if plan.InterestTiers == nil {
+		plan.InterestTiers = []InterestTier{}
+	}
+
+	return plan
+}
+
+// AllowsAccountType reports whether accounts of the type can be on the plan
+func (p *AccountPlan) AllowsAccountType(accountType AccountType) bool {
+	for _, allowed := range p.AccountTypes {
+		if allowed == accountType {
+			return true
+		}
+	}
+	return false
+}
+
+// TransferFeeAfter returns the fee of an outgoing transfer made after the given number of
+// transfers this month
+func (p *AccountPlan) TransferFeeAfter(transfers int) float64 {
+	if transfers < p.FreeTransfers {
+		return 0
+	}
+	return p.TransferFee
+}
+
+// AnnualInterest returns the interest a balance earns over a year at the plan's rates
+func (p *AccountPlan) AnnualInterest(balance float64) float64 {
+	return roundToTwoDecimal(p.annualInterest(balance))
+}
+
+// DailyInterest returns the interest accrued on the balance at the end of the bank day ending at
+// end, under the plan's day-count convention. It is not rounded, so that a period's daily amounts
+// are summed before rounding.
+func (p *AccountPlan) DailyInterest(balance float64, end time.Time) float64 {
+	return p.annualInterest(balance) * p.DayCount.YearFraction(end.AddDate(0, 0, -1), end)
+}
+
+// annualInterest returns the yearly interest of a balance: each part of the balance earns the
+// rate of its tier
+func (p *AccountPlan) annualInterest(balance float64) float64 {
+	annual := 0.0
+	for i, tier := range p.InterestTiers {
+		if balance <= tier.MinBalance {
+			break
+		}
+		upper := balance
+		if i+1 < len(p.InterestTiers) && p.InterestTiers[i+1].MinBalance < balance {
+			upper = p.InterestTiers[i+1].MinBalance
+		}
+		annual += (upper - tier.MinBalance) * tier.Rate / 100
+	}
+	return annual
+}
+
+// InterestRate returns the effective annual rate, in percent, of a balance
+func (p *AccountPlan) InterestRate(balance float64) float64 {
+	if balance <= 0 {
+		return 0
+	}
+	return math.Round(p.annualInterest(balance)/balance*10000) / 100
+}
+
+// MonthlyFeeBetween returns the monthly fee prorated to a time span, month by month
+func (p *AccountPlan) MonthlyFeeBetween(from, to time.Time) float64 {
+	return proratedMonthly(p.MonthlyFee, from, to)
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxAccountNicknameLength is the longest nickname a user may give an account, in characters
+const MaxAccountNicknameLength = 50
+
+// accountColorPattern matches a color as #RRGGBB
+var accountColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// AccountSettings represents how a user displays an account. Settings belong to the user, so the
+// members of an organization each arrange its accounts their own way.
+type AccountSettings struct {
+	AccountID int       `json:"-" db:"account_id"`
+	UserID    int       `json:"-" db:"user_id"`
+	Nickname  string    `json:"nickname,omitempty" db:"nickname"`
+	Color     string    `json:"color,omitempty" db:"color"` // #RRGGBB
+	SortOrder int       `json:"sort_order" db:"sort_order"` // accounts are listed in ascending order
+	Hidden    bool      `json:"hidden" db:"hidden"`         // hidden from the dashboard, still listed
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// AccountSettingsUpdate represents a change of account settings; fields left out are unchanged
+// and an empty nickname or color clears it
+type AccountSettingsUpdate struct {
+	Nickname  *string `json:"nickname,omitempty"`
+	Color     *string `json:"color,omitempty"`
+	SortOrder *int    `json:"sort_order,omitempty"`
+	Hidden    *bool   `json:"hidden,omitempty"`
+}
+
+// Validate validates an account settings update
+func (u *AccountSettingsUpdate) Validate() error {
+	if u.Nickname == nil && u.Color == nil && u.SortOrder == nil && u.Hidden == nil {
+		return errors.New("no settings to update")
+	}
+
+	if u.Nickname != nil && utf8.RuneCountInString(strings.TrimSpace(*u.Nickname)) > MaxAccountNicknameLength {
+		return errors.New("nickname is too long")
+	}
+
+	if u.Color != nil && *u.Color != "" && !accountColorPattern.MatchString(*u.Color) {
+		return errors.New("color must be in #RRGGBB format")
+	}
+
+	if u.SortOrder != nil && *u.SortOrder < 0 {
+		return errors.New("sort order cannot be negative")
+	}
+
+	return nil
+}
+
+// Apply changes the settings the update sets
+func (u *AccountSettingsUpdate) Apply(settings *AccountSettings) {
+	if u.Nickname != nil {
+		settings.Nickname = strings.TrimSpace(*u.Nickname)
+	}
+	if u.Color != nil {
+		settings.Color = strings.ToUpper(*u.Color)
+	}
+	if u.SortOrder != nil {
+		settings.SortOrder = *u.SortOrder
+	}
+	if u.Hidden != nil {
+		settings.Hidden = *u.Hidden
+	}
+}
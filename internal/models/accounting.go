@@ -0,0 +1,130 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxAccountingPeriodDays limits the period of a single accounting export
+const MaxAccountingPeriodDays = 366
+
+// Ledger accounts that stand for the bank's side of transactions with no second customer account
+const (
+	LedgerClearing        = "CLEARING"         // cash and card settlement: deposits, withdrawals, payments
+	LedgerFeeIncome       = "FEE_INCOME"       // fees charged to customers
+	LedgerInterestExpense = "INTEREST_EXPENSE" // interest credited to customers
+	LedgerBonusExpense    = "BONUS_EXPENSE"    // referral and other bonuses
+	LedgerPayrollClearing = "PAYROLL_CLEARING" // payroll funding debits and the salary credits they pay out
+	LedgerEscrow          = "ESCROW"           // funds held for escrow deals until they are released or refunded
+	LedgerCorrespondent   = "CORRESPONDENT"    // nostro accounts at the correspondent banks international transfers are paid out through
+)
+
+// AccountingEntry is a completed transaction as exported to the accounting department
+type AccountingEntry struct {
+	TransactionID      int             `json:"transaction_id"`
+	Date               time.Time       `json:"date"`
+	Type               TransactionType `json:"type"`
+	SourceAccount      string          `json:"source_account,omitempty"`      // empty when money comes from outside the bank
+	DestinationAccount string          `json:"destination_account,omitempty"` // empty when money leaves the bank
+	Amount             float64         `json:"amount"`
+	Currency           Currency        `json:"currency"`
+	Description        string          `json:"description,omitempty"`
+}
+
+// BalanceMismatch is an account whose balance differs from the sum of its transactions
+type BalanceMismatch struct {
+	AccountID     int      `json:"account_id"`
+	AccountNumber string   `json:"account_number"`
+	Currency      Currency `json:"currency"`
+	Balance       float64  `json:"balance"`
+	LedgerBalance float64  `json:"ledger_balance"` // incoming minus outgoing transactions that affect the balance
+}
+
+// Difference returns how much the balance exceeds the ledger balance
+func (m *BalanceMismatch) Difference() float64 {
+	return roundToTwoDecimal(m.Balance - m.LedgerBalance)
+}
+
+// LedgerEntry is a double-entry posting of a transaction
+type LedgerEntry struct {
+	TransactionID int       `json:"transaction_id"`
+	Date          time.Time `json:"date"`
+	Debit         string    `json:"debit"`
+	Credit        string    `json:"credit"`
+	Amount        float64   `json:"amount"`
+	Currency      Currency  `json:"currency"`
+	Description   string    `json:"description,omitempty"`
+}
+
+// ParseAccountingPeriod parses an inclusive period of YYYY-MM-DD dates in a time zone and returns it
+// as [from, to)
+func ParseAccountingPeriod(from, to string, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01-02", from, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid from date, expected YYYY-MM-DD")
+	}
+
+	end, err := time.ParseInLocation("2006-01-02", to, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid to date, expected YYYY-MM-DD")
+	}
+
+	end = end.AddDate(0, 0, 1)
+	if err := ValidateAccountingPeriod(start, end); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return start, end, nil
+}
+
+// ValidateAccountingPeriod checks an export period [from, to)
+func ValidateAccountingPeriod(from, to time.Time) error {
+	if !to.After(from) {
+		return errors.New("the period must end after it starts")
+	}
+
+	if to.Sub(from) > MaxAccountingPeriodDays*24*time.Hour {
+		return errors.New("the period cannot be longer than 366 days")
+	}
+
+	return nil
+}
+
+// ToLedgerEntry posts the transaction as a debit of the account money leaves and a credit of the
+// account it arrives at. Customer accounts are liabilities of the bank, so a deposit debits clearing
+// and credits the customer, while a fee debits the customer and credits fee income.
+func (e *AccountingEntry) ToLedgerEntry() LedgerEntry {
+	external := LedgerClearing
+	switch e.Type {
+	case TransactionTypeFee:
+		external = LedgerFeeIncome
+	case TransactionTypeInterest:
+		external = LedgerInterestExpense
+	case TransactionTypeBonus:
+		external = LedgerBonusExpense
+	case TransactionTypePayroll:
+		external = LedgerPayrollClearing
+	case TransactionTypeEscrow:
+		external = LedgerEscrow
+	case TransactionTypeInternational:
+		external = LedgerCorrespondent
+	}
+
+	debit, credit := e.SourceAccount, e.DestinationAccount
+	if debit == "" {
+		debit = external
+	}
+	if credit == "" {
+		credit = external
+	}
+
+	return LedgerEntry{
+		TransactionID: e.TransactionID,
+		Date:          e.Date,
+		Debit:         debit,
+		Credit:        credit,
+		Amount:        e.Amount,
+		Currency:      e.Currency,
+		Description:   e.Description,
+	}
+}
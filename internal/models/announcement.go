@@ -0,0 +1,144 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// AnnouncementStatus defines the status of an announcement sent to a segment of customers
+type AnnouncementStatus string
+
+const (
+	AnnouncementStatusQueued    AnnouncementStatus = "QUEUED" // being delivered by the announcements job
+	AnnouncementStatusCompleted AnnouncementStatus = "COMPLETED"
+	AnnouncementStatusCancelled AnnouncementStatus = "CANCELLED" // the recipients not reached yet are skipped
+)
+
+// AnnouncementAudience defines which customers an announcement is sent to
+type AnnouncementAudience string
+
+const (
+	AnnouncementAudienceAll            AnnouncementAudience = "ALL"
+	AnnouncementAudienceActiveCredits  AnnouncementAudience = "ACTIVE_CREDITS"  // customers repaying a credit, overdue or not
+	AnnouncementAudienceOverdueCredits AnnouncementAudience = "OVERDUE_CREDITS" // customers with an overdue credit
+)
+
+// AnnouncementSegment selects the customers an announcement is sent to
+type AnnouncementSegment struct {
+	Audience AnnouncementAudience `json:"audience"`
+	Tenant   string               `json:"tenant,omitempty"` // only the customers of a brand; empty sends to all brands
+}
+
+// Announcement is a message admins send to a segment of customers as an in-app notification and,
+// optionally, an email. The recipients are chosen when it is created and reached by the
+// announcements job at a throttled rate.
+type Announcement struct {
+	ID          int                 `json:"id" db:"id"`
+	Title       string              `json:"title" db:"title"`
+	Message     string              `json:"message" db:"message"` // may use the placeholders personalized per recipient
+	Segment     AnnouncementSegment `json:"segment" db:"segment"`
+	SendEmail   bool                `json:"send_email" db:"send_email"`
+	Status      AnnouncementStatus  `json:"status" db:"status"`
+	Recipients  int                 `json:"recipients" db:"-"`
+	Sent        int                 `json:"sent" db:"-"`
+	Failed      int                 `json:"failed" db:"-"`
+	CreatedBy   int                 `json:"created_by" db:"created_by"`
+	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// AnnouncementRecipientStatus defines whether an announcement reached a customer
+type AnnouncementRecipientStatus string
+
+const (
+	AnnouncementRecipientStatusPending AnnouncementRecipientStatus = "PENDING"
+	AnnouncementRecipientStatusSent    AnnouncementRecipientStatus = "SENT"
+	AnnouncementRecipientStatusFailed  AnnouncementRecipientStatus = "FAILED"
+)
+
+// AnnouncementRecipient is a customer an announcement is delivered to
+type AnnouncementRecipient struct {
+	ID             int                         `json:"id" db:"id"`
+	AnnouncementID int                         `json:"announcement_id" db:"announcement_id"`
+	UserID         int                         `json:"user_id" db:"user_id"`
+	Status         AnnouncementRecipientStatus `json:"status" db:"status"`
+	Error          string                      `json:"error,omitempty" db:"error"`
+	SentAt         *time.Time                  `json:"sent_at,omitempty" db:"sent_at"`
+}
+
+// AnnouncementRequest is the announcement an admin sends
+type AnnouncementRequest struct {
+	Title     string              `json:"title"`
+	Message   string              `json:"message"`
+	Segment   AnnouncementSegment `json:"segment"`
+	SendEmail bool                `json:"send_email"`
+}
+
+// announcementPlaceholder matches a placeholder such as {{first_name}}
+var announcementPlaceholder = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// announcementFields are the placeholders an announcement can be personalized with
+var announcementFields = map[string]func(user *User) string{
+	"last_name": func(user *User) string { return user.LastName },
+	"username":  func(user *User) string { return user.Username },
+	"first_name": func(user *User) string {
+		if user.FirstName != "" {
+			return user.FirstName
+		}
+		return user.Username
+	},
+	"name": func(user *User) string {
+		if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+			return name
+		}
+		return user.Username
+	},
+}
+
+// Validate checks the title, the message and its placeholders, and the segment
+func (r *AnnouncementRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Message = strings.TrimSpace(r.Message)
+
+	if r.Title == "" || len(r.Title) > 200 {
+		return errors.New("title is required and must be at most 200 characters")
+	}
+	if r.Message == "" || len(r.Message) > 5000 {
+		return errors.New("message is required and must be at most 5000 characters")
+	}
+
+	for _, text := range []string{r.Title, r.Message} {
+		for _, match := range announcementPlaceholder.FindAllStringSubmatch(text, -1) {
+			if _, ok := announcementFields[match[1]]; !ok {
+				return i18n.Errorf("unknown_placeholder", match[0])
+			}
+		}
+	}
+
+	switch r.Segment.Audience {
+	case AnnouncementAudienceAll, AnnouncementAudienceActiveCredits, AnnouncementAudienceOverdueCredits:
+	default:
+		return errors.New("segment.audience must be one of ALL, ACTIVE_CREDITS, OVERDUE_CREDITS")
+	}
+
+	return nil
+}
+
+// Personalize fills in the placeholders of the title and the message for a recipient
+func (a *Announcement) Personalize(user *User) (title, message string) {
+	fill := func(text string) string {
+		return announcementPlaceholder.ReplaceAllStringFunc(text, func(placeholder string) string {
+			field := announcementPlaceholder.FindStringSubmatch(placeholder)[1]
+			if value, ok := announcementFields[field]; ok {
+				return value(user)
+			}
+			return placeholder
+		})
+	}
+
+	return fill(a.Title), fill(a.Message)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// APIKey is a credential a backend partner uses to call the API on behalf of a user without
+// logging in. A user issues one per integration, limited to the scopes it needs.
+type APIKey struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"` // the integration using the key
+	KeyPrefix  string     `json:"key_prefix" db:"key_prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"` // SHA-256 of the key; the key itself is shown once
+	Scopes     []Scope    `json:"scopes" db:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// IsActive reports whether the key may still be used
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// APIKeyCreate represents a request to issue an API key
+type APIKeyCreate struct {
+	Name      string  `json:"name" binding:"required"`
+	Scopes    []Scope `json:"scopes" binding:"required"`
+	ExpiresIn int     `json:"expires_in,omitempty"` // in seconds; the key does not expire if omitted
+}
+
+// APIKeyCredentials is returned once when an API key is issued or rotated
+type APIKeyCredentials struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
+
+// ValidateAPIKeyCreate validates API key data: a name and the scopes the key is limited to
+func (k *APIKeyCreate) ValidateAPIKeyCreate() error {
+	k.Name = strings.TrimSpace(k.Name)
+	if k.Name == "" || len(k.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	scopes := ScopedTokenRequest{Scopes: k.Scopes, ExpiresIn: k.ExpiresIn}
+	return scopes.Validate()
+}
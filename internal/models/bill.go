@@ -0,0 +1,267 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// BillCategory defines the category of a bill provider
+type BillCategory string
+
+const (
+	BillCategoryUtilities BillCategory = "UTILITIES"
+	BillCategoryMobile    BillCategory = "MOBILE"
+	BillCategoryInternet  BillCategory = "INTERNET"
+	BillCategoryTV        BillCategory = "TV"
+)
+
+// IsValid reports whether the category is known
+func (c BillCategory) IsValid() bool {
+	switch c {
+	case BillCategoryUtilities, BillCategoryMobile, BillCategoryInternet, BillCategoryTV:
+		return true
+	}
+	return false
+}
+
+// BillField describes a provider-specific payment field, such as a phone or a personal account number
+type BillField struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Pattern  string `json:"pattern,omitempty"`
+	Required bool   `json:"required"`
+}
+
+// BillProvider represents a payee in the bill payment catalog
+type BillProvider struct {
+	ID        int          `json:"id" db:"id"`
+	Code      string       `json:"code" db:"code"`
+	Name      string       `json:"name" db:"name"`
+	Category  BillCategory `json:"category" db:"category"`
+	Fields    []BillField  `json:"fields" db:"fields"`
+	MinAmount float64      `json:"min_amount" db:"min_amount"`
+	MaxAmount float64      `json:"max_amount" db:"max_amount"`
+	IsActive  bool         `json:"is_active" db:"is_active"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
+
+// BillPaymentRequest represents a request to pay a bill
+type BillPaymentRequest struct {
+	ProviderID int               `json:"provider_id" binding:"required"`
+	AccountID  int               `json:"account_id" binding:"required"`
+	Amount     float64           `json:"amount" binding:"required"`
+	Fields     map[string]string `json:"fields"`
+}
+
+// BillRepeatRequest represents a "pay again" request. Zero values reuse the original payment's amount and account.
+type BillRepeatRequest struct {
+	AccountID int     `json:"account_id,omitempty"`
+	Amount    float64 `json:"amount,omitempty"`
+}
+
+// BillPayment represents a completed bill payment
+type BillPayment struct {
+	ID            int               `json:"id" db:"id"`
+	UserID        int               `json:"user_id" db:"user_id"`
+	ProviderID    int               `json:"provider_id" db:"provider_id"`
+	ProviderName  string            `json:"provider_name" db:"provider_name"`
+	AccountID     int               `json:"account_id" db:"account_id"`
+	TransactionID int               `json:"transaction_id" db:"transaction_id"`
+	Amount        float64           `json:"amount" db:"amount"`
+	Fields        map[string]string `json:"fields" db:"fields"`
+	CreatedAt     time.Time         `json:"created_at" db:"created_at"`
+}
+
+// BillTemplate represents a saved bill payment
+type BillTemplate struct {
+	ID           int               `json:"id" db:"id"`
+	UserID       int               `json:"user_id" db:"user_id"`
+	Name         string            `json:"name" db:"name"`
+	ProviderID   int               `json:"provider_id" db:"provider_id"`
+	ProviderName string            `json:"provider_name" db:"provider_name"`
+	AccountID    int               `json:"account_id" db:"account_id"`
+	Amount       float64           `json:"amount" db:"amount"`
+	Fields       map[string]string `json:"fields" db:"fields"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// BillTemplateCreate represents data for saving a bill template
+type BillTemplateCreate struct {
+	Name       string            `json:"name" binding:"required"`
+	ProviderID int               `json:"provider_id" binding:"required"`
+	AccountID  int               `json:"account_id" binding:"required"`
+	Amount     float64           `json:"amount" binding:"required"`
+	Fields     map[string]string `json:"fields"`
+}
+
+// ValidateBillPaymentRequest validates bill payment request data
+func (b *BillPaymentRequest) ValidateBillPaymentRequest() error {
+	if b.ProviderID <= 0 {
+		return errors.New("provider_id is required")
+	}
+
+	if b.AccountID <= 0 {
+		return errors.New("account_id is required")
+	}
+
+	if b.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+// ValidateBillRepeatRequest validates a "pay again" request
+func (b *BillRepeatRequest) ValidateBillRepeatRequest() error {
+	if b.Amount < 0 {
+		return errors.New("amount must be positive")
+	}
+
+	return nil
+}
+
+// ValidateBillTemplate validates bill template data
+func (b *BillTemplateCreate) ValidateBillTemplate() error {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(b.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+
+	return b.ToBillPaymentRequest().ValidateBillPaymentRequest()
+}
+
+// ToBillPaymentRequest converts BillTemplateCreate to the payment it describes
+func (b *BillTemplateCreate) ToBillPaymentRequest() *BillPaymentRequest {
+	return &BillPaymentRequest{
+		ProviderID: b.ProviderID,
+		AccountID:  b.AccountID,
+		Amount:     b.Amount,
+		Fields:     b.Fields,
+	}
+}
+
+// ToBillTemplate converts BillTemplateCreate to BillTemplate
+func (b *BillTemplateCreate) ToBillTemplate(userID int) *BillTemplate {
+	return &BillTemplate{
+		UserID:     userID,
+		Name:       b.Name,
+		ProviderID: b.ProviderID,
+		AccountID:  b.AccountID,
+		Amount:     b.Amount,
+		Fields:     b.Fields,
+	}
+}
+
+// ToBillPaymentRequest converts BillTemplate to a payment, applying the overrides of a repeat request
+func (t *BillTemplate) ToBillPaymentRequest(repeat *BillRepeatRequest) *BillPaymentRequest {
+	return repeatBillPayment(t.ProviderID, t.AccountID, t.Amount, t.Fields, repeat)
+}
+
+// ToBillPaymentRequest converts BillPayment to a new payment, applying the overrides of a repeat request
+func (p *BillPayment) ToBillPaymentRequest(repeat *BillRepeatRequest) *BillPaymentRequest {
+	return repeatBillPayment(p.ProviderID, p.AccountID, p.Amount, p.Fields, repeat)
+}
+
+// repeatBillPayment builds a payment request from saved data and optional overrides
+func repeatBillPayment(providerID, accountID int, amount float64, fields map[string]string, repeat *BillRepeatRequest) *BillPaymentRequest {
+	request := &BillPaymentRequest{
+		ProviderID: providerID,
+		AccountID:  accountID,
+		Amount:     amount,
+		Fields:     fields,
+	}
+
+	if repeat != nil {
+		if repeat.AccountID > 0 {
+			request.AccountID = repeat.AccountID
+		}
+		if repeat.Amount > 0 {
+			request.Amount = repeat.Amount
+		}
+	}
+
+	return request
+}
+
+// ValidatePayment checks the amount and the provider-specific fields of a payment.
+// Values are trimmed in place; fields the provider does not define are rejected.
+func (p *BillProvider) ValidatePayment(amount float64, values map[string]string) error {
+	if !p.IsActive {
+		return errors.New("provider is not available")
+	}
+
+	if p.MinAmount > 0 && amount < p.MinAmount {
+		return i18n.Errorf("amount_must_be_at_least", strconv.FormatFloat(p.MinAmount, 'f', 2, 64))
+	}
+
+	if p.MaxAmount > 0 && amount > p.MaxAmount {
+		return i18n.Errorf("amount_must_be_at_most", strconv.FormatFloat(p.MaxAmount, 'f', 2, 64))
+	}
+
+	known := make(map[string]bool, len(p.Fields))
+	for _, field := range p.Fields {
+		known[field.Name] = true
+
+		value := strings.TrimSpace(values[field.Name])
+		if value == "" {
+			if field.Required {
+				return i18n.Errorf("field_is_required", field.Name)
+			}
+			continue
+		}
+		values[field.Name] = value
+
+		if field.Pattern != "" {
+			pattern, err := regexp.Compile("^(?:" + field.Pattern + ")$")
+			if err != nil {
+				return fmt.Errorf("invalid pattern for field %s: %w", field.Name, err)
+			}
+			if !pattern.MatchString(value) {
+				return i18n.Errorf("invalid_field", field.Name)
+			}
+		}
+	}
+
+	for name := range values {
+		if !known[name] {
+			return i18n.Errorf("unknown_field", name)
+		}
+	}
+
+	return nil
+}
+
+// PaymentDescription builds the transaction description for a payment to the provider
+func (p *BillProvider) PaymentDescription(values map[string]string) string {
+	for _, field := range p.Fields {
+		if value := values[field.Name]; value != "" {
+			return fmt.Sprintf("Bill payment: %s, %s %s", p.Name, field.Label, value)
+		}
+	}
+
+	return "Bill payment: " + p.Name
+}
+
+// ToTransaction converts BillPaymentRequest to a PAYMENT transaction
+func (b *BillPaymentRequest) ToTransaction(provider *BillProvider) *Transaction {
+	return &Transaction{
+		TransactionType: TransactionTypePayment,
+		SourceAccountID: &b.AccountID,
+		Amount:          b.Amount,
+		Currency:        CurrencyRUB, // Bill providers are paid in rubles
+		Description:     provider.PaymentDescription(b.Fields),
+		Status:          TransactionStatusPending,
+		TransactionDate: time.Now(),
+	}
+}
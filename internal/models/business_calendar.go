@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// RollConvention defines how a payment date that is not a business day is moved
+type RollConvention string
+
+const (
+	RollNone              RollConvention = "none"               // keep the date
+	RollFollowing         RollConvention = "following"          // the next business day
+	RollModifiedFollowing RollConvention = "modified_following" // the next business day unless it is in the next month, then the previous one
+)
+
+// maxRollDays bounds the search for a business day; the longest Russian holidays are 10 days
+const maxRollDays = 31
+
+// russianHolidays are the non-working public holidays of the Labor Code of the Russian Federation
+// (article 112) as month and day. Days off moved by the yearly government decree are configured.
+var russianHolidays = [][2]int{
+	{1, 1}, {1, 2}, {1, 3}, {1, 4}, {1, 5}, {1, 6}, {1, 7}, {1, 8}, // New Year holidays and Christmas
+	{2, 23}, // Defender of the Fatherland Day
+	{3, 8},  // International Women's Day
+	{5, 1},  // Spring and Labor Day
+	{5, 9},  // Victory Day
+	{6, 12}, // Russia Day
+	{11, 4}, // Unity Day
+}
+
+// BusinessDate returns the start of a date's calendar day in the bank's time zone. Dates read from
+// DATE columns are UTC midnights, which east of UTC are hours after the bank's day has started.
+func BusinessDate(date time.Time, bank *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, bank)
+}
+
+// BusinessCalendar tells business days from weekends and holidays. Holidays are the Russian public
+// holidays and the configured extra days off; configured working days are business days even on a
+// weekend, like the Saturdays worked in exchange for moved days off.
+type BusinessCalendar struct {
+	holidays map[string]bool
+	workdays map[string]bool
+}
+
+// NewBusinessCalendar creates a calendar with extra days off and working days as YYYY-MM-DD dates
+func NewBusinessCalendar(holidays, workdays []string) (*BusinessCalendar, error) {
+	c := &BusinessCalendar{
+		holidays: make(map[string]bool, len(holidays)),
+		workdays: make(map[string]bool, len(workdays)),
+	}
+
+	for _, days := range []struct {
+		dates []string
+		set   map[string]bool
+	}{{holidays, c.holidays}, {workdays, c.workdays}} {
+		for _, date := range days.dates {
+			day, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid calendar date %q, expected YYYY-MM-DD", date)
+			}
+			days.set[day.Format("2006-01-02")] = true
+		}
+	}
+
+	return c, nil
+}
+
+// IsBusinessDay checks if payments can be made on the date
+func (c *BusinessCalendar) IsBusinessDay(date time.Time) bool {
+	key := date.Format("2006-01-02")
+	if c.workdays[key] {
+		return true
+	}
+
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday || c.holidays[key] {
+		return false
+	}
+
+	for _, holiday := range russianHolidays {
+		if int(date.Month()) == holiday[0] && date.Day() == holiday[1] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Roll moves a date that is not a business day according to the convention
+func (c *BusinessCalendar) Roll(date time.Time, convention RollConvention) time.Time {
+	if convention == RollNone || c.IsBusinessDay(date) {
+		return date
+	}
+
+	next := c.step(date, 1)
+	if convention == RollModifiedFollowing && next.Month() != date.Month() {
+		return c.step(date, -1)
+	}
+
+	return next
+}
+
+// RollSchedule moves the payment dates of a schedule that are not business days. Interest stays
+// calculated over the unadjusted monthly periods.
+func (c *BusinessCalendar) RollSchedule(schedule []*PaymentSchedule, convention RollConvention) {
+	for _, payment := range schedule {
+		payment.PaymentDate = c.Roll(payment.PaymentDate, convention)
+	}
+}
+
+// DueUntil returns the last payment date whose rolled due date is on or before today, so the
+// payments dated up to it are due. On a weekend it is the last business day; before a weekend that
+// ends the month, modified following makes the weekend's payments due already.
+func (c *BusinessCalendar) DueUntil(today time.Time, convention RollConvention) time.Time {
+	date := today
+	for i := 0; i < maxRollDays && c.Roll(date, convention).After(today); i++ {
+		date = date.AddDate(0, 0, -1)
+	}
+
+	for i := 0; i < maxRollDays; i++ {
+		next := date.AddDate(0, 0, 1)
+		if c.Roll(next, convention).After(today) {
+			break
+		}
+		date = next
+	}
+
+	return date
+}
+
+// AddBusinessDays returns the business day the given number of business days after a date
+func (c *BusinessCalendar) AddBusinessDays(date time.Time, days int) time.Time {
+	for i := 0; i < days; i++ {
+		date = c.step(date, 1)
+	}
+	return date
+}
+
+// step returns the nearest business day after (direction 1) or before (direction -1) a date
+func (c *BusinessCalendar) step(date time.Time, direction int) time.Time {
+	for i := 0; i < maxRollDays; i++ {
+		date = date.AddDate(0, 0, direction)
+		if c.IsBusinessDay(date) {
+			break
+		}
+	}
+	return date
+}
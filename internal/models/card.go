@@ -1,10 +1,15 @@
 package models
 
 import (
+	"crypto/rand"
 	"errors"
-	"math/rand"
+	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
+
+	"banking-service/pkg/i18n"
 )
 
 // CardType defines the type of card
@@ -20,6 +25,7 @@ const (
 type Card struct {
 	ID                 int       `json:"id" db:"id"`
 	AccountID          int       `json:"account_id" db:"account_id"`
+	ProductID          int       `json:"product_id" db:"product_id"`
 	CardNumberEncrypted []byte    `json:"-" db:"card_number_encrypted"`
 	CardNumberHMAC     string    `json:"-" db:"card_number_hmac"`
 	CardNumber         string    `json:"card_number,omitempty" db:"-"`
@@ -27,44 +33,103 @@ type Card struct {
 	ExpiryDate         string    `json:"expiry_date,omitempty" db:"-"`
 	CVVHash            string    `json:"-" db:"cvv_hash"`
 	CVV                string    `json:"cvv,omitempty" db:"-"`
+	PINHash            string    `json:"-" db:"pin_hash"`     // empty until the owner sets a PIN
+	PINAttempts        int       `json:"-" db:"pin_attempts"` // consecutive wrong PINs
 	CardType           CardType  `json:"card_type" db:"card_type"`
 	IsActive           bool      `json:"is_active" db:"is_active"`
 	CreatedAt          time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// CardCreate represents data for creating a new card
+// MaxPINAttempts is the number of consecutive wrong PINs after which a card is blocked at ATMs
+// until its owner sets a new PIN
+const MaxPINAttempts = 3
+
+// CardPIN represents a request to set the PIN of a card
+type CardPIN struct {
+	PIN string `json:"pin" binding:"required"`
+}
+
+// ATMWithdrawalRequest represents a cash withdrawal at an ATM with a card and its PIN
+type ATMWithdrawalRequest struct {
+	CardNumber string  `json:"card_number" binding:"required"`
+	PIN        string  `json:"pin" binding:"required"`
+	Amount     float64 `json:"amount" binding:"required"`
+}
+
+// Card transaction pages hold DefaultCardTransactionLimit transactions unless the request asks for
+// another size of at most MaxCardTransactionLimit
+const (
+	DefaultCardTransactionLimit = 50
+	MaxCardTransactionLimit     = 200
+)
+
+// CardTransactionFilter selects a page of a card's transactions, newest first
+type CardTransactionFilter struct {
+	From   *time.Time // inclusive
+	To     *time.Time // exclusive
+	Limit  int
+	Offset int
+}
+
+// CardSpending represents the totals of a card's transactions that match a filter
+type CardSpending struct {
+	Count      int      `json:"count"`       // transactions made with the card
+	TotalSpent float64  `json:"total_spent"` // completed payments and withdrawals made with the card
+	Currency   Currency `json:"currency"`
+}
+
+// CardTransactionPage represents a page of a card's transactions with the totals of all
+// transactions matching the filter
+type CardTransactionPage struct {
+	Transactions []*Transaction `json:"transactions"`
+	Spending     *CardSpending  `json:"spending"`
+	Limit        int            `json:"limit"`
+	Offset       int            `json:"offset"`
+}
+
+// CardCreate represents data for creating a new card of a card product
 type CardCreate struct {
-	AccountID int      `json:"account_id" binding:"required"`
-	CardType  CardType `json:"card_type" binding:"required"`
+	AccountID int `json:"account_id" binding:"required"`
+	ProductID int `json:"product_id" binding:"required"`
 }
 
 // CardResponse represents a sanitized card response
 type CardResponse struct {
 	ID           int      `json:"id"`
 	AccountID    int      `json:"account_id"`
+	ProductID    int      `json:"product_id"`
 	CardNumber   string   `json:"card_number"`
 	ExpiryDate   string   `json:"expiry_date"`
 	CardType     CardType `json:"card_type"`
 	IsActive     bool     `json:"is_active"`
 }
 
-// GenerateCardNumber generates a valid card number (using Luhn algorithm)
-func GenerateCardNumber() string {
-	// MIR cards start with 2200-2204
-	prefix := "2200"
+// CardNumberLength is the length of the card numbers the bank issues
+const CardNumberLength = 16
+
+// MaxCardNumberAttempts is how many generated card numbers are tried before giving up on issuing
+// a card; an attempt fails only if another card already has the number
+const MaxCardNumberAttempts = 10
+
+// GenerateCardNumber generates a random card number in the BIN with a Luhn check digit
+func GenerateCardNumber(bin string) (string, error) {
+	if len(bin) >= CardNumberLength || strings.Trim(bin, "0123456789") != "" {
+		return "", fmt.Errorf("invalid BIN %q", bin)
+	}
 	
-	// Generate remaining 12 digits (total 16 digits)
-	cardNumber := prefix
-	for i := 0; i < 11; i++ {
-		cardNumber += string(rune('0' + rand.Intn(10)))
+	// Random digits up to the check digit
+	digits, err := randomDigits(CardNumberLength - len(bin) - 1)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate card number: %w", err)
 	}
+	cardNumber := bin + digits
 	
 	// Apply Luhn algorithm to get the check digit
 	sum := 0
-	alternate := false
+	alternate := true
 	
-	// Process in reverse order
+	// Process in reverse order; the check digit will be the first, undoubled one
 	for i := len(cardNumber) - 1; i >= 0; i-- {
 		digit := int(cardNumber[i] - '0')
 		
@@ -83,7 +148,7 @@ func GenerateCardNumber() string {
 	checkDigit := (10 - (sum % 10)) % 10
 	cardNumber += string(rune('0' + checkDigit))
 	
-	return cardNumber
+	return cardNumber, nil
 }
 
 // GenerateExpiryDate generates a card expiry date (3 years from now)
@@ -94,37 +159,57 @@ func GenerateExpiryDate() string {
 }
 
 // GenerateCVV generates a random 3-digit CVV
-func GenerateCVV() string {
-	cvv := ""
-	for i := 0; i < 3; i++ {
-		cvv += string(rune('0' + rand.Intn(10)))
+func GenerateCVV() (string, error) {
+	cvv, err := randomDigits(3)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate CVV: %w", err)
+	}
+	return cvv, nil
+}
+
+// randomDigits returns n digits from the cryptographic random number generator
+func randomDigits(n int) (string, error) {
+	digits := make([]byte, n)
+	for i := range digits {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		digits[i] = byte('0' + digit.Int64())
 	}
-	return cvv
+	return string(digits), nil
 }
 
 // ValidateCardCreate validates card creation data
 func (c *CardCreate) ValidateCardCreate() error {
-	// Validate CardType
-	switch c.CardType {
-	case CardTypeVirtual, CardTypeDebit, CardTypeCredit:
-		// Valid card type
-	default:
-		return errors.New("invalid card type")
+	if c.ProductID <= 0 {
+		return errors.New("product_id is required")
 	}
 	
 	return nil
 }
 
-// ToCard converts CardCreate to Card
-func (c *CardCreate) ToCard() *Card {
+// ToCard converts CardCreate to Card of the product with a new number in the BIN
+func (c *CardCreate) ToCard(product *CardProduct, bin string) (*Card, error) {
+	cardNumber, err := GenerateCardNumber(bin)
+	if err != nil {
+		return nil, err
+	}
+	
+	cvv, err := GenerateCVV()
+	if err != nil {
+		return nil, err
+	}
+	
 	return &Card{
 		AccountID:   c.AccountID,
-		CardNumber:  GenerateCardNumber(),
+		ProductID:   product.ID,
+		CardNumber:  cardNumber,
 		ExpiryDate:  GenerateExpiryDate(),
-		CVV:         GenerateCVV(),
-		CardType:    c.CardType,
+		CVV:         cvv,
+		CardType:    product.CardType,
 		IsActive:    true,
-	}
+	}, nil
 }
 
 // ToCardResponse converts Card to CardResponse with masked card number
@@ -137,9 +222,53 @@ func (c *Card) ToCardResponse() *CardResponse {
 	return &CardResponse{
 		ID:           c.ID,
 		AccountID:    c.AccountID,
+		ProductID:    c.ProductID,
 		CardNumber:   maskedNumber,
 		ExpiryDate:   c.ExpiryDate,
 		CardType:     c.CardType,
 		IsActive:     c.IsActive,
 	}
+}
+
+// ValidatePIN validates a card PIN: exactly 4 digits
+func (p *CardPIN) ValidatePIN() error {
+	if len(p.PIN) != 4 || strings.Trim(p.PIN, "0123456789") != "" {
+		return errors.New("PIN must be 4 digits")
+	}
+	
+	return nil
+}
+
+// ValidateATMWithdrawalRequest validates ATM withdrawal request data
+func (a *ATMWithdrawalRequest) ValidateATMWithdrawalRequest() error {
+	if a.CardNumber == "" || a.PIN == "" {
+		return errors.New("card number and PIN are required")
+	}
+	
+	if a.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	
+	return nil
+}
+
+// ValidateCardTransactionFilter validates a card transaction filter and applies the default page size
+func (f *CardTransactionFilter) ValidateCardTransactionFilter() error {
+	if f.Limit == 0 {
+		f.Limit = DefaultCardTransactionLimit
+	}
+	
+	if f.Limit < 0 || f.Limit > MaxCardTransactionLimit {
+		return i18n.Errorf("limit_must_be_between_1_and", strconv.Itoa(MaxCardTransactionLimit))
+	}
+	
+	if f.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+	
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return errors.New("from must be before to")
+	}
+	
+	return nil
 }
\ No newline at end of file
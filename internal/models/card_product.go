@@ -0,0 +1,200 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// cardProductCodePattern matches product codes such as MIR_DEBIT
+var cardProductCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{1,49}$`)
+
+// CardProduct is a card the bank issues: the BINs its numbers start with, the account types it
+// can be linked to, its limits, fees and cashback. Amounts are in the currency of the card's account.
+type CardProduct struct {
+	ID           int           `json:"id" db:"id"`
+	Code         string        `json:"code" db:"code"`
+	Name         string        `json:"name" db:"name"`
+	CardType     CardType      `json:"card_type" db:"card_type"`
+	BINs         []string      `json:"bins" db:"bins"` // a new card's number starts with one of them, picked at random
+	AccountTypes []AccountType `json:"account_types" db:"account_types"`
+	Limits       CardLimits    `json:"limits" db:"limits"`
+	Fees         CardFees      `json:"fees" db:"fees"`
+	Cashback     CardCashback  `json:"cashback" db:"cashback"`
+	IsActive     bool          `json:"is_active" db:"is_active"` // only active products are issued
+	Tenant       string        `json:"tenant" db:"tenant"`       // only its customers are issued the product
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// CardLimits are the limits of a card product's cards; zero means no limit
+type CardLimits struct {
+	Purchase   float64 `json:"purchase"`   // largest single payment
+	Withdrawal float64 `json:"withdrawal"` // largest single ATM withdrawal
+	Daily      float64 `json:"daily"`      // payments and withdrawals per calendar day
+}
+
+// CardFees is the fee schedule of a card product
+type CardFees struct {
+	Issue      float64 `json:"issue"`       // charged when a card is issued
+	Monthly    float64 `json:"monthly"`     // service fee per month
+	ATMPercent float64 `json:"atm_percent"` // of each ATM withdrawal
+	ATMMin     float64 `json:"atm_min"`     // smallest ATM withdrawal fee when the percent applies
+}
+
+// CardCashback are the cashback rules of a card product. A payment earns the percent of the first
+// rule for its spending category, or else of the rule without a category.
+type CardCashback struct {
+	Rules      []CashbackRule `json:"rules"`
+	MonthlyCap float64        `json:"monthly_cap"` // most cashback a card earns per calendar month, 0 for no cap
+}
+
+// CashbackRule is the cashback percent of payments in a spending category
+type CashbackRule struct {
+	Category string  `json:"category,omitempty"` // as in analytics, e.g. Groceries; empty matches any payment
+	Percent  float64 `json:"percent"`
+}
+
+// CardProductRequest represents the data of a card product created or updated by an admin
+type CardProductRequest struct {
+	Code         string        `json:"code"`
+	Name         string        `json:"name"`
+	CardType     CardType      `json:"card_type"`
+	BINs         []string      `json:"bins"`
+	AccountTypes []AccountType `json:"account_types"`
+	Limits       CardLimits    `json:"limits"`
+	Fees         CardFees      `json:"fees"`
+	Cashback     CardCashback  `json:"cashback"`
+	IsActive     *bool         `json:"is_active,omitempty"` // defaults to true
+}
+
+// ValidateCardProductRequest validates card product data and normalizes its text fields
+func (p *CardProductRequest) ValidateCardProductRequest() error {
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	if !cardProductCodePattern.MatchString(p.Code) {
+		return errors.New("code must be 2 to 50 capital letters, digits and underscores, starting with a letter")
+	}
+
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" || len(p.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	switch p.CardType {
+	case CardTypeVirtual, CardTypeDebit, CardTypeCredit:
+	default:
+		return errors.New("invalid card type")
+	}
+
+	if len(p.BINs) == 0 {
+		return errors.New("at least one BIN is required")
+	}
+	for _, bin := range p.BINs {
+		if (len(bin) != 6 && len(bin) != 8) || strings.Trim(bin, "0123456789") != "" {
+			return i18n.Errorf("bin_must_have_6_or_8_digits", bin)
+		}
+	}
+
+	if len(p.AccountTypes) == 0 {
+		return errors.New("at least one account type is required")
+	}
+	for _, accountType := range p.AccountTypes {
+		switch accountType {
+		case AccountTypeChecking, AccountTypeSavings, AccountTypeCredit:
+		default:
+			return i18n.Errorf("invalid_account_type_named", string(accountType))
+		}
+	}
+
+	if p.Limits.Purchase < 0 || p.Limits.Withdrawal < 0 || p.Limits.Daily < 0 {
+		return errors.New("limits cannot be negative")
+	}
+
+	if p.Fees.Issue < 0 || p.Fees.Monthly < 0 || p.Fees.ATMMin < 0 || p.Fees.ATMPercent < 0 || p.Fees.ATMPercent > 100 {
+		return errors.New("fees cannot be negative and atm_percent must be at most 100")
+	}
+
+	if p.Cashback.MonthlyCap < 0 {
+		return errors.New("cashback monthly_cap cannot be negative")
+	}
+	categories := map[string]bool{}
+	for i := range p.Cashback.Rules {
+		rule := &p.Cashback.Rules[i]
+		rule.Category = strings.TrimSpace(rule.Category)
+		if rule.Percent <= 0 || rule.Percent > 100 {
+			return errors.New("cashback percent must be above 0 and at most 100")
+		}
+		if categories[rule.Category] {
+			return i18n.Errorf("cashback_has_more_than_one_rule_for_category", rule.Category)
+		}
+		categories[rule.Category] = true
+	}
+
+	return nil
+}
+
+// ToCardProduct converts CardProductRequest to CardProduct
+func (p *CardProductRequest) ToCardProduct() *CardProduct {
+	product := &CardProduct{
+		Code:         p.Code,
+		Name:         p.Name,
+		CardType:     p.CardType,
+		BINs:         p.BINs,
+		AccountTypes: p.AccountTypes,
+		Limits:       p.Limits,
+		Fees:         p.Fees,
+		Cashback:     p.Cashback,
+		IsActive:     p.IsActive == nil || *p.IsActive,
+	}
+
+	if product.Cashback.Rules == nil {
+		product.Cashback.Rules = []CashbackRule{}
+	}
+
+	return product
+}
+
+// AllowsAccountType reports whether the product's cards can be linked to accounts of the type
+func (p *CardProduct) AllowsAccountType(accountType AccountType) bool {
+	for _, allowed := range p.AccountTypes {
+		if allowed == accountType {
+			return true
+		}
+	}
+	return false
+}
+
+// ATMFee returns the fee of an ATM withdrawal of the amount, rounded to kopecks
+func (f CardFees) ATMFee(amount float64) float64 {
+	if f.ATMPercent == 0 {
+		return 0
+	}
+
+	fee := roundToTwoDecimal(amount * f.ATMPercent / 100)
+	if fee < f.ATMMin {
+		return f.ATMMin
+	}
+	return fee
+}
+
+// MonthlyBetween returns the monthly service fee prorated to a time span, month by month
+func (f CardFees) MonthlyBetween(from, to time.Time) float64 {
+	return proratedMonthly(f.Monthly, from, to)
+}
+
+// Percent returns the cashback percent of a payment in the spending category, 0 if no rule matches
+func (c CardCashback) Percent(category string) float64 {
+	percent := 0.0
+	for _, rule := range c.Rules {
+		if rule.Category == category {
+			return rule.Percent
+		}
+		if rule.Category == "" {
+			percent = rule.Percent
+		}
+	}
+	return percent
+}
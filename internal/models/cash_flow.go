@@ -0,0 +1,155 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// CashFlowGranularity is the length of the buckets of a cash flow statement
+type CashFlowGranularity string
+
+const (
+	CashFlowGranularityDay     CashFlowGranularity = "day"
+	CashFlowGranularityWeek    CashFlowGranularity = "week" // weeks start on Monday
+	CashFlowGranularityMonth   CashFlowGranularity = "month"
+	CashFlowGranularityQuarter CashFlowGranularity = "quarter"
+)
+
+// BucketStart returns the start of the bucket a time falls in
+func (g CashFlowGranularity) BucketStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch g {
+	case CashFlowGranularityDay:
+		return day
+	case CashFlowGranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case CashFlowGranularityQuarter:
+		return time.Date(t.Year(), t.Month()-(t.Month()-1)%3, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// Next returns the start of the bucket following the one starting at t
+func (g CashFlowGranularity) Next(t time.Time) time.Time {
+	switch g {
+	case CashFlowGranularityDay:
+		return t.AddDate(0, 0, 1)
+	case CashFlowGranularityWeek:
+		return t.AddDate(0, 0, 7)
+	case CashFlowGranularityQuarter:
+		return t.AddDate(0, 3, 0)
+	}
+	return t.AddDate(0, 1, 0)
+}
+
+// defaultCashFlowBuckets is how many buckets, the current one included, a cash flow statement
+// covers when no period is given
+var defaultCashFlowBuckets = map[CashFlowGranularity]int{
+	CashFlowGranularityDay:     30,
+	CashFlowGranularityWeek:    12,
+	CashFlowGranularityMonth:   12,
+	CashFlowGranularityQuarter: 4,
+}
+
+// CashFlowQuery selects the period and the bucket length of a cash flow statement
+type CashFlowQuery struct {
+	Granularity CashFlowGranularity
+	Period      ReportPeriod
+}
+
+// ParseCashFlowQuery parses the granularity, from and to query parameters. The granularity
+// defaults to month. The period is an inclusive YYYY-MM-DD range in the location of now, at most
+// 366 days long; without dates it is the current bucket and the ones before it, up to today.
+func ParseCashFlowQuery(granularity, from, to string, now time.Time) (*CashFlowQuery, error) {
+	query := &CashFlowQuery{Granularity: CashFlowGranularity(strings.ToLower(granularity))}
+	if query.Granularity == "" {
+		query.Granularity = CashFlowGranularityMonth
+	}
+
+	buckets, ok := defaultCashFlowBuckets[query.Granularity]
+	if !ok {
+		return nil, errors.New("granularity must be one of day, week, month, quarter")
+	}
+
+	if from == "" && to == "" {
+		end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+		start := query.Granularity.BucketStart(now)
+		for i := 1; i < buckets; i++ {
+			start = query.Granularity.BucketStart(start.AddDate(0, 0, -1))
+		}
+		query.Period = ReportPeriod{From: start, To: end}
+		return query, nil
+	}
+
+	start, err := time.ParseInLocation("2006-01-02", from, now.Location())
+	if err != nil {
+		return nil, errors.New("invalid from date, expected YYYY-MM-DD")
+	}
+
+	end, err := time.ParseInLocation("2006-01-02", to, now.Location())
+	if err != nil {
+		return nil, errors.New("invalid to date, expected YYYY-MM-DD")
+	}
+
+	end = end.AddDate(0, 0, 1)
+	if err := ValidateAccountingPeriod(start, end); err != nil {
+		return nil, err
+	}
+
+	query.Period = ReportPeriod{From: start, To: end}
+	return query, nil
+}
+
+// CashFlowStatement shows the money that came into and went out of a user's accounts bucket by
+// bucket, with the balances the buckets opened and closed with
+type CashFlowStatement struct {
+	Granularity CashFlowGranularity `json:"granularity"`
+	From        time.Time           `json:"from"`
+	To          time.Time           `json:"to"` // exclusive
+	Buckets     []*CashFlowBucket   `json:"buckets"`
+}
+
+// CashFlowBucket is the cash flow of one bucket [Start, End). The first and last buckets are cut
+// to the period.
+type CashFlowBucket struct {
+	Start    time.Time           `json:"start"`
+	End      time.Time           `json:"end"`
+	Totals   []*CashFlowTotal    `json:"totals"`   // of all accounts, by currency
+	Accounts []*CashFlowAccount  `json:"accounts"` // by account ID
+	Inflows  []*CashFlowCategory `json:"inflows"`  // largest first
+	Outflows []*CashFlowCategory `json:"outflows"` // largest first
+}
+
+// CashFlowBalances are the balances and flows of a bucket
+type CashFlowBalances struct {
+	OpeningBalance float64 `json:"opening_balance"`
+	Inflow         float64 `json:"inflow"`
+	Outflow        float64 `json:"outflow"`
+	NetFlow        float64 `json:"net_flow"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+// CashFlowAccount is the cash flow of one account in a bucket
+type CashFlowAccount struct {
+	AccountID     int         `json:"account_id"`
+	AccountNumber string      `json:"account_number"`
+	AccountType   AccountType `json:"account_type"`
+	Currency      Currency    `json:"currency"`
+	CashFlowBalances
+}
+
+// CashFlowTotal is the cash flow of all accounts in one currency in a bucket
+type CashFlowTotal struct {
+	Currency Currency `json:"currency"`
+	CashFlowBalances
+}
+
+// CashFlowCategory is the money that came in or went out for one category in one currency in a
+// bucket
+type CashFlowCategory struct {
+	Category         string   `json:"category"`
+	Currency         Currency `json:"currency"`
+	Amount           float64  `json:"amount"`
+	TransactionCount int      `json:"transaction_count"`
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ChargebackStatus defines the status of a card chargeback
+type ChargebackStatus string
+
+const (
+	ChargebackStatusOpen              ChargebackStatus = "OPEN"
+	ChargebackStatusEvidenceSubmitted ChargebackStatus = "EVIDENCE_SUBMITTED"
+	ChargebackStatusMerchantAccepted  ChargebackStatus = "MERCHANT_ACCEPTED"
+	ChargebackStatusCardholderWon     ChargebackStatus = "CARDHOLDER_WON"
+	ChargebackStatusMerchantWon       ChargebackStatus = "MERCHANT_WON"
+)
+
+// IsFinal reports whether the chargeback has been resolved
+func (s ChargebackStatus) IsFinal() bool {
+	switch s {
+	case ChargebackStatusMerchantAccepted, ChargebackStatusCardholderWon, ChargebackStatusMerchantWon:
+		return true
+	}
+	return false
+}
+
+// ChargebackReason defines why the cardholder disputes a card payment
+type ChargebackReason string
+
+const (
+	ChargebackReasonNotReceived    ChargebackReason = "NOT_RECEIVED"
+	ChargebackReasonNotAsDescribed ChargebackReason = "NOT_AS_DESCRIBED"
+	ChargebackReasonDuplicate      ChargebackReason = "DUPLICATE"
+	ChargebackReasonFraud          ChargebackReason = "FRAUD"
+	ChargebackReasonOther          ChargebackReason = "OTHER"
+)
+
+// IsValid reports whether the reason is known
+func (r ChargebackReason) IsValid() bool {
+	switch r {
+	case ChargebackReasonNotReceived, ChargebackReasonNotAsDescribed, ChargebackReasonDuplicate,
+		ChargebackReasonFraud, ChargebackReasonOther:
+		return true
+	}
+	return false
+}
+
+// Chargeback represents a cardholder's dispute of a card payment to a merchant. The provisional
+// credit, the merchant debit and any reversal are separate CHARGEBACK transactions linked here.
+type Chargeback struct {
+	ID                    int              `json:"id" db:"id"`
+	UserID                int              `json:"user_id" db:"user_id"`
+	MerchantID            int              `json:"merchant_id" db:"merchant_id"`
+	MerchantName          string           `json:"merchant_name" db:"merchant_name"`
+	PaymentIntentID       string           `json:"payment_intent_id" db:"payment_intent_id"`
+	OriginalTransactionID int              `json:"original_transaction_id" db:"original_transaction_id"`
+	AccountID             int              `json:"account_id" db:"account_id"`
+	Amount                float64          `json:"amount" db:"amount"`
+	Reason                ChargebackReason `json:"reason" db:"reason"`
+	Details               string           `json:"details,omitempty" db:"details"`
+	Status                ChargebackStatus `json:"status" db:"status"`
+	Evidence              string           `json:"evidence,omitempty" db:"evidence"`
+	ResolutionNote        string           `json:"resolution_note,omitempty" db:"resolution_note"`
+	ProvisionalCreditTxID *int             `json:"provisional_credit_transaction_id,omitempty" db:"provisional_credit_transaction_id"`
+	MerchantDebitTxID     *int             `json:"merchant_debit_transaction_id,omitempty" db:"merchant_debit_transaction_id"`
+	ReversalTxID          *int             `json:"reversal_transaction_id,omitempty" db:"reversal_transaction_id"`
+	EvidenceDueAt         time.Time        `json:"evidence_due_at" db:"evidence_due_at"`
+	EvidenceSubmittedAt   *time.Time       `json:"evidence_submitted_at,omitempty" db:"evidence_submitted_at"`
+	ResolvedAt            *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+	CreatedAt             time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// ChargebackCreate represents a cardholder's request to dispute a card payment
+type ChargebackCreate struct {
+	TransactionID int              `json:"transaction_id" binding:"required"`
+	Amount        float64          `json:"amount,omitempty"` // 0 disputes the full payment
+	Reason        ChargebackReason `json:"reason" binding:"required"`
+	Details       string           `json:"details,omitempty"`
+}
+
+// ChargebackEvidence represents the merchant's response to a chargeback
+type ChargebackEvidence struct {
+	Evidence string `json:"evidence" binding:"required"`
+}
+
+// ChargebackResolution represents the bank's final decision on a disputed chargeback
+type ChargebackResolution struct {
+	InFavorOf string `json:"in_favor_of" binding:"required"` // CARDHOLDER or MERCHANT
+	Note      string `json:"note,omitempty"`
+}
+
+// ValidateChargebackCreate validates chargeback request data
+func (c *ChargebackCreate) ValidateChargebackCreate() error {
+	if c.TransactionID <= 0 {
+		return errors.New("transaction_id is required")
+	}
+
+	if c.Amount < 0 {
+		return errors.New("amount must be positive")
+	}
+
+	c.Reason = ChargebackReason(strings.ToUpper(string(c.Reason)))
+	if !c.Reason.IsValid() {
+		return errors.New("reason must be one of NOT_RECEIVED, NOT_AS_DESCRIBED, DUPLICATE, FRAUD, OTHER")
+	}
+
+	if len(c.Details) > 2000 {
+		return errors.New("details must be at most 2000 characters")
+	}
+
+	return nil
+}
+
+// ValidateChargebackEvidence validates merchant evidence
+func (e *ChargebackEvidence) ValidateChargebackEvidence() error {
+	e.Evidence = strings.TrimSpace(e.Evidence)
+	if e.Evidence == "" {
+		return errors.New("evidence is required")
+	}
+
+	if len(e.Evidence) > 10000 {
+		return errors.New("evidence must be at most 10000 characters")
+	}
+
+	return nil
+}
+
+// ValidateChargebackResolution validates a resolution and returns the resulting status
+func (r *ChargebackResolution) ValidateChargebackResolution() (ChargebackStatus, error) {
+	if len(r.Note) > 2000 {
+		return "", errors.New("note must be at most 2000 characters")
+	}
+
+	switch strings.ToUpper(r.InFavorOf) {
+	case "CARDHOLDER":
+		return ChargebackStatusCardholderWon, nil
+	case "MERCHANT":
+		return ChargebackStatusMerchantWon, nil
+	}
+
+	return "", errors.New("in_favor_of must be CARDHOLDER or MERCHANT")
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxChequeImageSize is the largest cheque or payment order image that can be deposited
+const MaxChequeImageSize = 10 << 20
+
+// ChequeDepositStatus defines the clearing status of a deposited cheque
+type ChequeDepositStatus string
+
+const (
+	ChequeDepositStatusPending  ChequeDepositStatus = "PENDING"  // credited on hold until the bank clears it
+	ChequeDepositStatusCleared  ChequeDepositStatus = "CLEARED"  // the hold is released and the funds are available
+	ChequeDepositStatusRejected ChequeDepositStatus = "REJECTED" // the credit is reversed
+)
+
+// ChequeDeposit represents a cheque or payment order deposited by uploading its image. The amount
+// is credited at once by a pending deposit transaction and held until an admin clears the deposit.
+type ChequeDeposit struct {
+	ID               int                 `json:"id" db:"id"`
+	UserID           int                 `json:"user_id" db:"user_id"`
+	AccountID        int                 `json:"account_id" db:"account_id"`
+	Amount           float64             `json:"amount" db:"amount"`
+	Currency         Currency            `json:"currency" db:"currency"`
+	RecognizedAmount *float64            `json:"recognized_amount,omitempty" db:"recognized_amount"` // read from the image, if it could be
+	FileName         string              `json:"file_name" db:"file_name"`
+	ContentType      string              `json:"content_type" db:"content_type"`
+	Size             int                 `json:"size" db:"size"`
+	StorageKey       string              `json:"-" db:"storage_key"`
+	ScanStatus       ScanStatus          `json:"scan_status" db:"scan_status"`
+	TransactionID    int                 `json:"transaction_id" db:"transaction_id"`
+	Status           ChequeDepositStatus `json:"status" db:"status"`
+	ReviewedBy       *int                `json:"-" db:"reviewed_by"`
+	ReviewNote       string              `json:"review_note,omitempty" db:"review_note"`
+	ReviewedAt       *time.Time          `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt        time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// AmountMismatch reports whether the amount read from the image differs from the deposited one
+func (d *ChequeDeposit) AmountMismatch() bool {
+	return d.RecognizedAmount != nil && math.Abs(*d.RecognizedAmount-d.Amount) > 0.005
+}
+
+// ChequeDepositDecision represents an admin's note when clearing or rejecting a deposit
+type ChequeDepositDecision struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ValidateChequeDeposit validates an uploaded image and normalizes its file name. A zero amount
+// is accepted; it is then read from the image.
+func (d *ChequeDeposit) ValidateChequeDeposit() error {
+	if d.AccountID <= 0 {
+		return errors.New("account_id is required")
+	}
+
+	if d.Amount < 0 || d.Amount >= maxInvoiceAmount || math.Abs(d.Amount*100-math.Round(d.Amount*100)) > 1e-6 {
+		return errors.New("amount must be a positive number with at most 2 decimal places")
+	}
+
+	if d.Size == 0 {
+		return errors.New("file is empty")
+	}
+
+	if d.Size > MaxChequeImageSize {
+		return errors.New("file must be at most 10 MB")
+	}
+
+	d.FileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(d.FileName, "\\", "/")))
+	if d.FileName == "" || d.FileName == "." || d.FileName == "/" || utf8.RuneCountInString(d.FileName) > 255 {
+		return errors.New("file name is required and must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// ValidateChequeDepositDecision validates and normalizes an admin's note
+func (d *ChequeDepositDecision) ValidateChequeDepositDecision() error {
+	d.Note = strings.TrimSpace(d.Note)
+	if utf8.RuneCountInString(d.Note) > 500 {
+		return errors.New("note must be at most 500 characters")
+	}
+
+	return nil
+}
@@ -38,6 +38,9 @@ type Credit struct {
 	StartDate     time.Time    `json:"start_date" db:"start_date"`
 	EndDate       time.Time    `json:"end_date" db:"end_date"`
 	Status        CreditStatus `json:"status" db:"status"`
+	DayCount      DayCountConvention `json:"day_count" db:"day_count"` // how the days of an interest period are counted
+	Signature     *CreditSignature `json:"signature,omitempty" db:"-"` // set once the agreement is signed
+	Insurance     *InsurancePolicy `json:"insurance,omitempty" db:"-"` // set if insurance was taken at origination
 	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
 }
@@ -49,10 +52,12 @@ type PaymentSchedule struct {
 	PaymentDate    time.Time     `json:"payment_date" db:"payment_date"`
 	PrincipalAmount float64      `json:"principal_amount" db:"principal_amount"`
 	InterestAmount float64       `json:"interest_amount" db:"interest_amount"`
+	InsuranceAmount float64      `json:"insurance_amount,omitempty" db:"insurance_amount"` // insurance premium included in TotalAmount
 	TotalAmount    float64       `json:"total_amount" db:"total_amount"`
 	Status         PaymentStatus `json:"status" db:"status"`
 	IsOverdue      bool          `json:"is_overdue" db:"is_overdue"`
 	PenaltyAmount  float64       `json:"penalty_amount,omitempty" db:"penalty_amount"`
+	AccountID      int           `json:"-" db:"-"` // the credit's account, filled by pending payment lookups
 	CreatedAt      time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at" db:"updated_at"`
 }
@@ -63,6 +68,7 @@ type CreditRequest struct {
 	Amount      float64 `json:"amount" binding:"required"`
 	TermMonths  int     `json:"term_months" binding:"required"`
 	InterestRate float64 `json:"interest_rate,omitempty"` // Optional, can be calculated from CBR rate
+	Insurance   bool    `json:"insurance,omitempty"`     // Optional payment protection insurance
 }
 
 // ValidateCreditRequest validates credit request data
@@ -96,18 +102,30 @@ func CalculateMonthlyPayment(principal float64, annualInterestRate float64, term
 		(math.Pow(1+monthlyInterestRate, float64(termMonths)) - 1)
 }
 
-// GeneratePaymentSchedule generates a payment schedule for a credit
+// GeneratePaymentSchedule generates a payment schedule for a credit. The first payment is due on
+// the start date and every payment pays the interest of the month before it, counted with the
+// credit's day-count convention.
 func GeneratePaymentSchedule(credit *Credit) []*PaymentSchedule {
 	var schedule []*PaymentSchedule
 	
 	remainingPrincipal := credit.Amount
 	paymentDate := credit.StartDate
+	periodStart := paymentDate.AddDate(0, -1, 0)
 	
-	monthlyInterestRate := credit.InterestRate / 12 / 100
+	dayCount := credit.DayCount
+	if !dayCount.IsValid() {
+		dayCount = DefaultDayCount
+	}
 	
 	for i := 0; i < credit.TermMonths; i++ {
-		// Calculate interest for this period
-		interestAmount := remainingPrincipal * monthlyInterestRate
+		// Calculate interest for this period. Periods are whole months, which 30/360 counts as 30 days
+		// even where adding a month to a month-end date rolls over into the following month.
+		var interestAmount float64
+		if dayCount == DayCount30360 {
+			interestAmount = remainingPrincipal * credit.InterestRate / 12 / 100
+		} else {
+			interestAmount = dayCount.Interest(remainingPrincipal, credit.InterestRate, periodStart, paymentDate)
+		}
 		
 		// Calculate principal for this period
 		var principalAmount float64
@@ -139,6 +157,7 @@ func GeneratePaymentSchedule(credit *Credit) []*PaymentSchedule {
 		schedule = append(schedule, paymentScheduleItem)
 		
 		// Move to next month
+		periodStart = paymentDate
 		paymentDate = addOneMonth(paymentDate)
 	}
 	
@@ -155,12 +174,15 @@ func addOneMonth(date time.Time) time.Time {
 	return date.AddDate(0, 1, 0)
 }
 
+// CreditRateMargin is the margin, in percentage points, credits are priced at over the key rate
+const CreditRateMargin = 5.0
+
 // ToCredit converts CreditRequest to Credit
 func (c *CreditRequest) ToCredit(accountID int, baseInterestRate float64) *Credit {
-	// If interest rate is not provided, use base rate + 5%
+	// If interest rate is not provided, use base rate + margin
 	interestRate := c.InterestRate
 	if interestRate == 0 {
-		interestRate = baseInterestRate + 5.0
+		interestRate = baseInterestRate + CreditRateMargin
 	}
 	
 	startDate := time.Now()
@@ -178,5 +200,6 @@ func (c *CreditRequest) ToCredit(accountID int, baseInterestRate float64) *Credi
 		StartDate:      startDate,
 		EndDate:        endDate,
 		Status:         CreditStatusActive,
+		DayCount:       DefaultDayCount,
 	}
 }
\ No newline at end of file
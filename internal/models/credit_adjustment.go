@@ -0,0 +1,154 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// MaxRestructureTermMonths is the longest remaining term a credit can be restructured to
+const MaxRestructureTermMonths = 360
+
+// CreditAdjustmentType defines what a bank employee changed on a credit
+type CreditAdjustmentType string
+
+const (
+	CreditAdjustmentTypePenaltyWaiver CreditAdjustmentType = "PENALTY_WAIVER"
+	CreditAdjustmentTypeReschedule    CreditAdjustmentType = "RESCHEDULE"
+	CreditAdjustmentTypeRestructure   CreditAdjustmentType = "RESTRUCTURE"
+)
+
+// CreditAdjustmentReason defines why a credit was adjusted
+type CreditAdjustmentReason string
+
+const (
+	CreditAdjustmentReasonHardship    CreditAdjustmentReason = "FINANCIAL_HARDSHIP"
+	CreditAdjustmentReasonBankError   CreditAdjustmentReason = "BANK_ERROR"
+	CreditAdjustmentReasonGoodwill    CreditAdjustmentReason = "GOODWILL"
+	CreditAdjustmentReasonBorrowerAsk CreditAdjustmentReason = "BORROWER_REQUEST"
+	CreditAdjustmentReasonOther       CreditAdjustmentReason = "OTHER"
+)
+
+// IsValid reports whether the reason is known
+func (r CreditAdjustmentReason) IsValid() bool {
+	switch r {
+	case CreditAdjustmentReasonHardship, CreditAdjustmentReasonBankError, CreditAdjustmentReasonGoodwill,
+		CreditAdjustmentReasonBorrowerAsk, CreditAdjustmentReasonOther:
+		return true
+	}
+	return false
+}
+
+// CreditAdjustment is the audit entry of a penalty waiver, rescheduling or restructuring. Entries
+// are only ever added.
+type CreditAdjustment struct {
+	ID        int                    `json:"id" db:"id"`
+	CreditID  int                    `json:"credit_id" db:"credit_id"`
+	PaymentID *int                   `json:"payment_id,omitempty" db:"payment_id"` // the schedule item, unset for a restructuring
+	AdminID   int                    `json:"admin_id" db:"admin_id"`
+	Type      CreditAdjustmentType   `json:"type" db:"type"`
+	Reason    CreditAdjustmentReason `json:"reason" db:"reason"`
+	Note      string                 `json:"note,omitempty" db:"note"`
+	Details   string                 `json:"details" db:"details"` // what changed, e.g. old and new values
+	CreatedAt time.Time              `json:"created_at" db:"created_at"`
+}
+
+// CreditAdjustmentRequest holds the reason code and note every adjustment carries
+type CreditAdjustmentRequest struct {
+	Reason CreditAdjustmentReason `json:"reason" binding:"required"`
+	Note   string                 `json:"note,omitempty"`
+}
+
+// PenaltyWaiverRequest represents waiving the penalty of an overdue schedule item
+type PenaltyWaiverRequest struct {
+	CreditAdjustmentRequest
+	Amount float64 `json:"amount,omitempty"` // 0 waives the whole penalty
+}
+
+// RescheduleRequest represents moving an unpaid schedule item to another date
+type RescheduleRequest struct {
+	CreditAdjustmentRequest
+	PaymentDate string    `json:"payment_date" binding:"required"` // YYYY-MM-DD
+	Date        time.Time `json:"-"`                               // PaymentDate parsed by validation
+}
+
+// RestructureRequest represents spreading the unpaid principal of a credit over a new term
+type RestructureRequest struct {
+	CreditAdjustmentRequest
+	TermMonths int `json:"term_months" binding:"required"`
+}
+
+// CreditAdjustments represents a credit with its schedule and adjustment history, as shown to bank employees
+type CreditAdjustments struct {
+	Credit      *Credit             `json:"credit"`
+	Schedule    []*PaymentSchedule  `json:"schedule"`
+	Adjustments []*CreditAdjustment `json:"adjustments"`
+}
+
+// validate checks the reason code and note of an adjustment
+func (r *CreditAdjustmentRequest) validate() error {
+	r.Reason = CreditAdjustmentReason(strings.ToUpper(string(r.Reason)))
+	if !r.Reason.IsValid() {
+		return errors.New("reason must be one of FINANCIAL_HARDSHIP, BANK_ERROR, GOODWILL, BORROWER_REQUEST, OTHER")
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Reason == CreditAdjustmentReasonOther && r.Note == "" {
+		return errors.New("note is required when the reason is OTHER")
+	}
+
+	if len(r.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// ValidatePenaltyWaiverRequest validates penalty waiver request data
+func (r *PenaltyWaiverRequest) ValidatePenaltyWaiverRequest() error {
+	if r.Amount < 0 {
+		return errors.New("amount must be positive")
+	}
+
+	return r.validate()
+}
+
+// ValidateRescheduleRequest validates reschedule request data, reading the payment date in a time zone
+func (r *RescheduleRequest) ValidateRescheduleRequest(loc *time.Location) error {
+	date, err := time.ParseInLocation("2006-01-02", r.PaymentDate, loc)
+	if err != nil {
+		return errors.New("payment_date must be in YYYY-MM-DD format")
+	}
+	r.Date = date
+
+	return r.validate()
+}
+
+// ValidateRestructureRequest validates restructure request data
+func (r *RestructureRequest) ValidateRestructureRequest() error {
+	if r.TermMonths <= 0 || r.TermMonths > MaxRestructureTermMonths {
+		return i18n.Errorf("term_months_must_be_between_1_and", strconv.Itoa(MaxRestructureTermMonths))
+	}
+
+	return r.validate()
+}
+
+// RestructureSchedule spreads principal over termMonths annuity payments at the credit's interest
+// rate, the first due on first, and adds the insurance premium to each payment
+func RestructureSchedule(credit *Credit, principal float64, termMonths int, first time.Time, premium float64) []*PaymentSchedule {
+	restructured := *credit
+	restructured.Amount = principal
+	restructured.TermMonths = termMonths
+	restructured.StartDate = first
+	restructured.MonthlyPayment = CalculateMonthlyPayment(principal, credit.InterestRate, termMonths)
+
+	schedule := GeneratePaymentSchedule(&restructured)
+	if premium > 0 {
+		ApplyInsurance(schedule, premium)
+	}
+
+	return schedule
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CreditAgreementVersion identifies the agreement template; bump it when the wording changes
+const CreditAgreementVersion = 1
+
+// SignatureChannel defines how the one-time code of a signature was delivered
+type SignatureChannel string
+
+const (
+	SignatureChannelEmail SignatureChannel = "EMAIL"
+)
+
+// CreditAgreement is the contract of a credit as presented for signing
+type CreditAgreement struct {
+	CreditID  int              `json:"credit_id"`
+	Version   int              `json:"version"`
+	Text      string           `json:"text"`
+	Hash      string           `json:"hash"` // hex-encoded SHA-256 of Text
+	Signature *CreditSignature `json:"signature,omitempty"`
+}
+
+// SignatureRequest is a credit agreement waiting to be signed with a one-time code
+type SignatureRequest struct {
+	ID           int                `json:"id" db:"id"`
+	RequestID    string             `json:"request_id" db:"request_id"`
+	CreditID     int                `json:"credit_id" db:"credit_id"`
+	UserID       int                `json:"user_id" db:"user_id"`
+	DocumentHash string             `json:"document_hash" db:"document_hash"`
+	Channel      SignatureChannel   `json:"channel" db:"channel"`
+	CodeHash     string             `json:"-" db:"code_hash"`
+	Attempts     int                `json:"attempts" db:"attempts"`
+	Status       ConfirmationStatus `json:"status" db:"status"`
+	ExpiresAt    time.Time          `json:"expires_at" db:"expires_at"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+}
+
+// CreditSignature is the immutable record of a signed credit agreement
+type CreditSignature struct {
+	ID           int              `json:"id" db:"id"`
+	CreditID     int              `json:"credit_id" db:"credit_id"`
+	UserID       int              `json:"user_id" db:"user_id"`
+	Version      int              `json:"version" db:"version"`
+	DocumentHash string           `json:"document_hash" db:"document_hash"`
+	OTPChannel   SignatureChannel `json:"otp_channel" db:"otp_channel"`
+	IPAddress    string           `json:"ip_address" db:"ip_address"`
+	UserAgent    string           `json:"user_agent,omitempty" db:"user_agent"`
+	SignedAt     time.Time        `json:"signed_at" db:"signed_at"`
+}
+
+// SignatureConfirmRequest represents a request to sign an agreement with the code sent to the user
+type SignatureConfirmRequest struct {
+	RequestID string `json:"request_id" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+// ValidateSignatureConfirmRequest validates signature confirmation data
+func (c *SignatureConfirmRequest) ValidateSignatureConfirmRequest() error {
+	if c.RequestID == "" {
+		return errors.New("request_id is required")
+	}
+
+	if c.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+// NewCreditAgreement renders the agreement of a credit. The text only uses terms fixed at
+// origination, so the hash of a credit's agreement does not change over its life.
+func NewCreditAgreement(credit *Credit, borrower *User, account *Account) *CreditAgreement {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREDIT AGREEMENT No. %d (template version %d)\n\n", credit.ID, CreditAgreementVersion)
+	fmt.Fprintf(&b, "Borrower: %s %s (user %d)\n", borrower.FirstName, borrower.LastName, borrower.ID)
+	fmt.Fprintf(&b, "Credit account: %s\n", account.AccountNumber)
+	fmt.Fprintf(&b, "Principal: %.2f %s\n", credit.Amount, account.Currency)
+	fmt.Fprintf(&b, "Interest rate: %.2f%% per annum\n", credit.InterestRate)
+	fmt.Fprintf(&b, "Term: %d months, from %s to %s\n", credit.TermMonths,
+		credit.StartDate.Format("2006-01-02"), credit.EndDate.Format("2006-01-02"))
+	fmt.Fprintf(&b, "Monthly annuity payment: %.2f %s\n", credit.MonthlyPayment, account.Currency)
+	if credit.Insurance != nil {
+		fmt.Fprintf(&b, "Payment protection insurance: policy %s, premium %.2f %s per month, cancellable at any time\n",
+			credit.Insurance.PolicyNumber, credit.Insurance.MonthlyPremium, account.Currency)
+	}
+	b.WriteString("\n")
+	b.WriteString("The borrower undertakes to repay the principal and interest according to the payment schedule. " +
+		"Overdue payments are charged a penalty as published by the bank.\n" +
+		"By entering the one-time code the borrower signs this agreement with a simple electronic signature.\n")
+
+	text := b.String()
+	sum := sha256.Sum256([]byte(text))
+
+	return &CreditAgreement{
+		CreditID: credit.ID,
+		Version:  CreditAgreementVersion,
+		Text:     text,
+		Hash:     hex.EncodeToString(sum[:]),
+	}
+}
+
+// ToCreditSignature converts a confirmed SignatureRequest to the signature record
+func (r *SignatureRequest) ToCreditSignature(client ClientInfo) *CreditSignature {
+	return &CreditSignature{
+		CreditID:     r.CreditID,
+		UserID:       r.UserID,
+		Version:      CreditAgreementVersion,
+		DocumentHash: r.DocumentHash,
+		OTPChannel:   r.Channel,
+		IPAddress:    client.IPAddress,
+		UserAgent:    client.UserAgent,
+	}
+}
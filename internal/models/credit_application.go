@@ -0,0 +1,199 @@
+package models
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCreditDocumentSize is the largest document that can be uploaded to a credit application
+const MaxCreditDocumentSize = 10 << 20
+
+// CreditApplicationStatus defines the status of a credit application
+type CreditApplicationStatus string
+
+const (
+	CreditApplicationStatusPending  CreditApplicationStatus = "PENDING"
+	CreditApplicationStatusApproved CreditApplicationStatus = "APPROVED"
+	CreditApplicationStatusRejected CreditApplicationStatus = "REJECTED"
+)
+
+// CreditDocumentType defines what a credit application document proves
+type CreditDocumentType string
+
+const (
+	CreditDocumentTypePassport        CreditDocumentType = "PASSPORT"
+	CreditDocumentTypeIncomeStatement CreditDocumentType = "INCOME_STATEMENT"
+	CreditDocumentTypeOther           CreditDocumentType = "OTHER"
+)
+
+// RequiredCreditDocuments lists the document types that must be accepted before a large credit is approved
+var RequiredCreditDocuments = []CreditDocumentType{CreditDocumentTypePassport, CreditDocumentTypeIncomeStatement}
+
+// IsValid reports whether the document type is known
+func (t CreditDocumentType) IsValid() bool {
+	switch t {
+	case CreditDocumentTypePassport, CreditDocumentTypeIncomeStatement, CreditDocumentTypeOther:
+		return true
+	}
+	return false
+}
+
+// CreditDocumentStatus defines the review status of a credit application document
+type CreditDocumentStatus string
+
+const (
+	CreditDocumentStatusPending  CreditDocumentStatus = "PENDING"
+	CreditDocumentStatusAccepted CreditDocumentStatus = "ACCEPTED"
+	CreditDocumentStatusRejected CreditDocumentStatus = "REJECTED"
+)
+
+// ScanStatus defines the result of the malware scan of an upload
+type ScanStatus string
+
+const (
+	ScanStatusClean      ScanStatus = "CLEAN"
+	ScanStatusNotScanned ScanStatus = "NOT_SCANNED" // the scanner is disabled
+)
+
+// CreditApplication represents a credit request that waits for the bank's review
+type CreditApplication struct {
+	ID                int                     `json:"id" db:"id"`
+	UserID            int                     `json:"user_id" db:"user_id"`
+	Amount            float64                 `json:"amount" db:"amount"`
+	TermMonths        int                     `json:"term_months" db:"term_months"`
+	InterestRate      float64                 `json:"interest_rate,omitempty" db:"interest_rate"` // 0 uses the market rate
+	Insurance         bool                    `json:"insurance" db:"insurance"`
+	Status            CreditApplicationStatus `json:"status" db:"status"`
+	CreditID          *int                    `json:"credit_id,omitempty" db:"credit_id"`
+	ReviewNote        string                  `json:"review_note,omitempty" db:"review_note"`
+	ReviewedBy        *int                    `json:"-" db:"reviewed_by"`
+	DocumentsRequired bool                    `json:"documents_required" db:"-"`
+	MissingDocuments  []CreditDocumentType    `json:"missing_documents,omitempty" db:"-"`
+	Documents         []*CreditDocument       `json:"documents,omitempty" db:"-"`
+	CreatedAt         time.Time               `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time               `json:"updated_at" db:"updated_at"`
+}
+
+// CreditDocument is a file uploaded to support a credit application
+type CreditDocument struct {
+	ID            int                  `json:"id" db:"id"`
+	ApplicationID int                  `json:"application_id" db:"application_id"`
+	UserID        int                  `json:"user_id" db:"user_id"`
+	Type          CreditDocumentType   `json:"type" db:"type"`
+	FileName      string               `json:"file_name" db:"file_name"`
+	ContentType   string               `json:"content_type" db:"content_type"`
+	Size          int                  `json:"size" db:"size"`
+	StorageKey    string               `json:"-" db:"storage_key"`
+	ScanStatus    ScanStatus           `json:"scan_status" db:"scan_status"`
+	Status        CreditDocumentStatus `json:"status" db:"status"`
+	ReviewNote    string               `json:"review_note,omitempty" db:"review_note"`
+	ReviewedAt    *time.Time           `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt     time.Time            `json:"created_at" db:"created_at"`
+}
+
+// CreditDocumentReview represents the bank's review of an uploaded document
+type CreditDocumentReview struct {
+	Status CreditDocumentStatus `json:"status"`
+	Note   string               `json:"note,omitempty"`
+}
+
+// CreditApplicationDecision represents the bank's note when approving or rejecting an application
+type CreditApplicationDecision struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ValidateCreditDocument validates an uploaded document and normalizes its file name
+func (d *CreditDocument) ValidateCreditDocument() error {
+	if !d.Type.IsValid() {
+		return errors.New("type must be one of PASSPORT, INCOME_STATEMENT, OTHER")
+	}
+
+	if d.Size == 0 {
+		return errors.New("file is empty")
+	}
+
+	if d.Size > MaxCreditDocumentSize {
+		return errors.New("file must be at most 10 MB")
+	}
+
+	// Only the base name is kept so the name is safe to use in a download header
+	d.FileName = strings.Trim(filepath.Base(strings.ReplaceAll(d.FileName, `\`, "/")), ` ."`)
+	if d.FileName == "" || d.FileName == "/" || len(d.FileName) > 255 {
+		return errors.New("file name is required and must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// ValidateCreditDocumentReview validates a document review
+func (r *CreditDocumentReview) ValidateCreditDocumentReview() error {
+	if r.Status != CreditDocumentStatusAccepted && r.Status != CreditDocumentStatusRejected {
+		return errors.New("status must be ACCEPTED or REJECTED")
+	}
+
+	if utf8.RuneCountInString(r.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// ValidateCreditApplicationDecision validates a decision note
+func (d *CreditApplicationDecision) ValidateCreditApplicationDecision() error {
+	if utf8.RuneCountInString(d.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+
+	return nil
+}
+
+// ToCreditApplication converts CreditRequest to a pending CreditApplication
+func (c *CreditRequest) ToCreditApplication() *CreditApplication {
+	return &CreditApplication{
+		UserID:       c.UserID,
+		Amount:       c.Amount,
+		TermMonths:   c.TermMonths,
+		InterestRate: c.InterestRate,
+		Insurance:    c.Insurance,
+		Status:       CreditApplicationStatusPending,
+	}
+}
+
+// ToCreditRequest converts an approved application back to the request the credit is issued from
+func (a *CreditApplication) ToCreditRequest() *CreditRequest {
+	return &CreditRequest{
+		UserID:       a.UserID,
+		Amount:       a.Amount,
+		TermMonths:   a.TermMonths,
+		InterestRate: a.InterestRate,
+		Insurance:    a.Insurance,
+	}
+}
+
+// CheckDocuments fills DocumentsRequired and MissingDocuments for the given threshold; a threshold
+// of 0 never requires documents
+func (a *CreditApplication) CheckDocuments(threshold float64) {
+	a.DocumentsRequired = threshold > 0 && a.Amount >= threshold
+	a.MissingDocuments = nil
+
+	if !a.DocumentsRequired {
+		return
+	}
+
+	for _, required := range RequiredCreditDocuments {
+		accepted := false
+		for _, doc := range a.Documents {
+			if doc.Type == required && doc.Status == CreditDocumentStatusAccepted {
+				accepted = true
+				break
+			}
+		}
+
+		if !accepted {
+			a.MissingDocuments = append(a.MissingDocuments, required)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// CreditCalculation is a preview of a credit computed with the same math credits are issued with
+type CreditCalculation struct {
+	Amount         float64                    `json:"amount"`
+	InterestRate   float64                    `json:"interest_rate"`
+	TermMonths     int                        `json:"term_months"`
+	MonthlyPayment float64                    `json:"monthly_payment"`
+	MonthlyPremium float64                    `json:"monthly_premium,omitempty"` // insurance premium added to every payment
+	TotalInterest  float64                    `json:"total_interest"`
+	TotalInsurance float64                    `json:"total_insurance,omitempty"`
+	TotalAmount    float64                    `json:"total_amount"`
+	EffectiveRate  float64                    `json:"effective_rate"` // annual, in percent, including insurance
+	Schedule       []*PaymentScheduleResponse `json:"schedule"`
+}
+
+// ValidateCalculationRequest validates a calculator request. Unlike a credit application the
+// interest rate is required, as the calculator does not look up the key rate.
+func (c *CreditRequest) ValidateCalculationRequest() error {
+	if err := c.ValidateCreditRequest(); err != nil {
+		return err
+	}
+
+	if c.InterestRate <= 0 || c.InterestRate > 100 {
+		return errors.New("interest rate must be greater than 0 and at most 100")
+	}
+
+	return nil
+}
+
+// NewCreditCalculation builds the preview of a credit from its generated schedule
+func NewCreditCalculation(credit *Credit, schedule []*PaymentSchedule, premium float64) *CreditCalculation {
+	calculation := &CreditCalculation{
+		Amount:         credit.Amount,
+		InterestRate:   credit.InterestRate,
+		TermMonths:     credit.TermMonths,
+		MonthlyPayment: roundToTwoDecimal(credit.MonthlyPayment + premium),
+		MonthlyPremium: premium,
+		Schedule:       make([]*PaymentScheduleResponse, 0, len(schedule)),
+	}
+
+	payments := make([]float64, 0, len(schedule))
+	for i, payment := range schedule {
+		calculation.TotalInterest += payment.InterestAmount
+		calculation.TotalInsurance += payment.InsuranceAmount
+		calculation.TotalAmount += payment.TotalAmount
+		calculation.Schedule = append(calculation.Schedule, payment.ToPaymentScheduleResponse(i+1))
+		payments = append(payments, payment.TotalAmount)
+	}
+
+	calculation.TotalInterest = roundToTwoDecimal(calculation.TotalInterest)
+	calculation.TotalInsurance = roundToTwoDecimal(calculation.TotalInsurance)
+	calculation.TotalAmount = roundToTwoDecimal(calculation.TotalAmount)
+	calculation.EffectiveRate = effectiveAnnualRate(credit.Amount, payments)
+
+	return calculation
+}
+
+// effectiveAnnualRate finds the monthly rate at which the payments, made at the end of each
+// month, repay the amount, and compounds it over a year. The result is in percent.
+func effectiveAnnualRate(amount float64, payments []float64) float64 {
+	presentValue := func(rate float64) float64 {
+		value := 0.0
+		for i, payment := range payments {
+			value += payment / math.Pow(1+rate, float64(i+1))
+		}
+		return value
+	}
+
+	// Payments that do not exceed the amount carry no cost
+	if presentValue(0) <= amount {
+		return 0
+	}
+
+	// The present value falls as the rate grows, so bisect until it matches the amount
+	low, high := 0.0, 1.0
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if presentValue(mid) > amount {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return math.Round((math.Pow(1+low, 12)-1)*100*1000) / 1000
+}
+
+// CreditSimulationRequest asks how a credit the user has not taken would change their monthly
+// obligations, debt-to-income ratio and the balance of one of their accounts
+type CreditSimulationRequest struct {
+	Amount       float64 `json:"amount" binding:"required"`
+	TermMonths   int     `json:"term_months" binding:"required"`
+	InterestRate float64 `json:"interest_rate,omitempty"` // today's rate of a new credit if not given
+	Insurance    bool    `json:"insurance,omitempty"`
+	AccountID    int     `json:"account_id" binding:"required"` // the account the payments would be made from
+	Days         int     `json:"days,omitempty"`                // of the balance prediction, 90 by default
+}
+
+// ValidateCreditSimulation validates a credit simulation request and defaults its prediction days
+func (c *CreditSimulationRequest) ValidateCreditSimulation() error {
+	if err := c.CreditRequest().ValidateCreditRequest(); err != nil {
+		return err
+	}
+
+	if c.InterestRate > 100 {
+		return errors.New("interest rate must be at most 100")
+	}
+
+	if c.AccountID <= 0 {
+		return errors.New("account_id is required")
+	}
+
+	if c.Days == 0 {
+		c.Days = 90
+	}
+	if c.Days < 1 || c.Days > 365 {
+		return errors.New("days must be between 1 and 365")
+	}
+
+	return nil
+}
+
+// CreditRequest returns the credit the simulation is about
+func (c *CreditSimulationRequest) CreditRequest() *CreditRequest {
+	return &CreditRequest{
+		Amount:       c.Amount,
+		TermMonths:   c.TermMonths,
+		InterestRate: c.InterestRate,
+		Insurance:    c.Insurance,
+	}
+}
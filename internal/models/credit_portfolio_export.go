@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Days past due buckets of the credit portfolio export, the usual delinquency bands of risk models
+const (
+	DPDBucketCurrent = "0"
+	DPDBucket1To30   = "1-30"
+	DPDBucket31To60  = "31-60"
+	DPDBucket61To90  = "61-90"
+	DPDBucketOver90  = "90+"
+)
+
+// CreditPortfolioExportPrefix is the object storage prefix of the daily credit portfolio exports
+const CreditPortfolioExportPrefix = "risk/credit_portfolio"
+
+// CreditPortfolioItem is one outstanding credit of the portfolio export. Outstanding principal is
+// the principal of the unpaid installments; overdue amounts and penalties are those of the unpaid
+// installments past their date as of AsOf.
+type CreditPortfolioItem struct {
+	AsOf                 time.Time    `json:"as_of"`
+	CreditID             int          `json:"credit_id"`
+	UserID               int          `json:"user_id"`
+	AccountID            int          `json:"account_id"`
+	Currency             Currency     `json:"currency"`
+	Status               CreditStatus `json:"status"`
+	Amount               float64      `json:"amount"`
+	InterestRate         float64      `json:"interest_rate"`
+	TermMonths           int          `json:"term_months"`
+	MonthlyPayment       float64      `json:"monthly_payment"`
+	StartDate            time.Time    `json:"start_date"`
+	EndDate              time.Time    `json:"end_date"`
+	OutstandingPrincipal float64      `json:"outstanding_principal"`
+	OverdueAmount        float64      `json:"overdue_amount"`
+	PenaltyAmount        float64      `json:"penalty_amount"`
+	OverdueSince         *time.Time   `json:"overdue_since,omitempty"` // date of the oldest unpaid installment past its date
+	NextPaymentDate      *time.Time   `json:"next_payment_date,omitempty"`
+	Insured              bool         `json:"insured"`
+}
+
+// DaysPastDue returns how many days the oldest unpaid installment is past its date
+func (i *CreditPortfolioItem) DaysPastDue() int {
+	if i.OverdueSince == nil {
+		return 0
+	}
+	return int(i.AsOf.Sub(*i.OverdueSince).Hours() / 24)
+}
+
+// DPDBucket returns the days past due band of the credit
+func (i *CreditPortfolioItem) DPDBucket() string {
+	days := i.DaysPastDue()
+	switch {
+	case days <= 0:
+		return DPDBucketCurrent
+	case days <= 30:
+		return DPDBucket1To30
+	case days <= 60:
+		return DPDBucket31To60
+	case days <= 90:
+		return DPDBucket61To90
+	default:
+		return DPDBucketOver90
+	}
+}
+
+// ParseCreditPortfolioExportDate parses the YYYY-MM-DD date of a stored portfolio export in the
+// location of now; without a date it returns today
+func ParseCreditPortfolioExportDate(date string, now time.Time) (time.Time, error) {
+	if date == "" {
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()), nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return time.Time{}, errors.New("invalid date, expected YYYY-MM-DD")
+	}
+
+	return day, nil
+}
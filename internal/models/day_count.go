@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// DayCountConvention defines how the days of an interest period and of the year are counted
+type DayCountConvention string
+
+const (
+	DayCountACT365 DayCountConvention = "ACT/365" // actual days over a 365-day year
+	DayCountACT360 DayCountConvention = "ACT/360" // actual days over a 360-day year
+	DayCount30360  DayCountConvention = "30/360"  // 30-day months over a 360-day year (bond basis)
+)
+
+// DefaultDayCount is the convention of credits issued before conventions were configurable; it
+// charges every regular monthly period exactly a twelfth of the annual rate
+const DefaultDayCount = DayCount30360
+
+// IsValid checks if the day-count convention is supported
+func (c DayCountConvention) IsValid() bool {
+	switch c {
+	case DayCountACT365, DayCountACT360, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// YearFraction returns the share of a year between two dates under the convention
+func (c DayCountConvention) YearFraction(from, to time.Time) float64 {
+	switch c {
+	case DayCountACT365:
+		return actualDays(from, to) / 365
+	case DayCountACT360:
+		return actualDays(from, to) / 360
+	default:
+		return days30360(from, to) / 360
+	}
+}
+
+// Interest returns the interest on a principal at an annual percentage rate over [from, to)
+func (c DayCountConvention) Interest(principal float64, annualInterestRate float64, from, to time.Time) float64 {
+	return principal * annualInterestRate / 100 * c.YearFraction(from, to)
+}
+
+// actualDays counts the calendar days between two dates, ignoring the time of day
+func actualDays(from, to time.Time) float64 {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return end.Sub(start).Hours() / 24
+}
+
+// days30360 counts the days between two dates as if every month had 30 days
+func days30360(from, to time.Time) float64 {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return float64(360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + d2 - d1)
+}
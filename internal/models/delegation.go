@@ -0,0 +1,125 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxDelegationYears is how far ahead a delegation can expire
+const MaxDelegationYears = 3
+
+// DelegationScope defines what a delegate may do with an account
+type DelegationScope string
+
+const (
+	// DelegationScopeView allows reading balances, transactions and cards
+	DelegationScopeView DelegationScope = "VIEW"
+	// DelegationScopeTransfer also allows transfers totalling up to the delegation's limit
+	DelegationScopeTransfer DelegationScope = "TRANSFER"
+)
+
+// IsValid reports whether the scope is known
+func (s DelegationScope) IsValid() bool {
+	return s == DelegationScopeView || s == DelegationScopeTransfer
+}
+
+// DelegationAction defines a step recorded in the audit log of a delegation
+type DelegationAction string
+
+const (
+	DelegationActionGranted     DelegationAction = "GRANTED"
+	DelegationActionRevoked     DelegationAction = "REVOKED"
+	DelegationActionViewed      DelegationAction = "VIEWED"
+	DelegationActionTransferred DelegationAction = "TRANSFERRED"
+)
+
+// AccountDelegation represents a power of attorney: the owner of a personal account lets another
+// user see it or make transfers from it until the delegation expires or is revoked.
+//
+// TransferLimit caps the sum of all transfers the delegate makes under the delegation over its
+// whole life, not each transfer: once the transfers recorded in its audit log reach the limit, the
+// owner has to grant a new delegation. A transfer that is later reversed still counts.
+type AccountDelegation struct {
+	ID            int             `json:"id" db:"id"`
+	AccountID     int             `json:"account_id" db:"account_id"`
+	AccountNumber string          `json:"account_number,omitempty" db:"account_number"`
+	OwnerID       int             `json:"owner_id" db:"owner_id"`
+	DelegateID    int             `json:"delegate_id" db:"delegate_id"`
+	DelegateEmail string          `json:"delegate_email,omitempty" db:"delegate_email"`
+	Scope         DelegationScope `json:"scope" db:"scope"`
+	TransferLimit float64         `json:"transfer_limit,omitempty" db:"transfer_limit"` // the total of all transfers, for the TRANSFER scope
+	ExpiresAt     time.Time       `json:"expires_at" db:"expires_at"`
+	RevokedAt     *time.Time      `json:"revoked_at,omitempty" db:"revoked_at"`
+	RevokedBy     *int            `json:"revoked_by,omitempty" db:"revoked_by"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
+
+// IsActive reports whether the delegation grants access at a time
+func (d *AccountDelegation) IsActive(now time.Time) bool {
+	return d.RevokedAt == nil && now.Before(d.ExpiresAt)
+}
+
+// DelegationEvent is an entry of the audit log of a delegation: who did what, on whose behalf.
+// Entries are only ever added.
+type DelegationEvent struct {
+	ID            int              `json:"id" db:"id"`
+	DelegationID  int              `json:"delegation_id" db:"delegation_id"`
+	AccountID     int              `json:"account_id" db:"account_id"`
+	ActorID       int              `json:"actor_id" db:"actor_id"` // the user who acted: the owner for grants, else usually the delegate
+	Action        DelegationAction `json:"action" db:"action"`
+	TransactionID *int             `json:"transaction_id,omitempty" db:"transaction_id"`
+	Amount        float64          `json:"amount,omitempty" db:"amount"`
+	RequestID     string           `json:"request_id,omitempty" db:"request_id"`
+	Details       string           `json:"details,omitempty" db:"details"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+}
+
+// DelegationCreate represents an account owner granting another user access to an account
+type DelegationCreate struct {
+	AccountID     int             `json:"account_id" binding:"required"`
+	Email         string          `json:"email" binding:"required"` // of the delegate
+	Scope         DelegationScope `json:"scope" binding:"required"`
+	TransferLimit float64         `json:"transfer_limit,omitempty"`
+	ExpiresOn     string          `json:"expires_on" binding:"required"` // YYYY-MM-DD, the last day of access
+	ExpiresAt     time.Time       `json:"-"`                             // the end of ExpiresOn, set by validation
+}
+
+// ValidateDelegationCreate validates delegation data, reading the expiry date in the location of now
+func (d *DelegationCreate) ValidateDelegationCreate(now time.Time) error {
+	if d.AccountID <= 0 {
+		return errors.New("account_id is required")
+	}
+
+	d.Email = strings.ToLower(strings.TrimSpace(d.Email))
+	if err := ValidateEmail(d.Email); err != nil {
+		return err
+	}
+
+	d.Scope = DelegationScope(strings.ToUpper(string(d.Scope)))
+	if !d.Scope.IsValid() {
+		return errors.New("scope must be VIEW or TRANSFER")
+	}
+
+	switch {
+	case d.Scope == DelegationScopeTransfer && d.TransferLimit <= 0:
+		return errors.New("transfer_limit must be positive for the TRANSFER scope")
+	case d.Scope == DelegationScopeView && d.TransferLimit != 0:
+		return errors.New("transfer_limit is only allowed for the TRANSFER scope")
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", d.ExpiresOn, now.Location())
+	if err != nil {
+		return errors.New("expires_on must be in YYYY-MM-DD format")
+	}
+	d.ExpiresAt = day.AddDate(0, 0, 1)
+
+	if !d.ExpiresAt.After(now) {
+		return errors.New("expires_on must not be in the past")
+	}
+	if d.ExpiresAt.After(now.AddDate(MaxDelegationYears, 0, 0)) {
+		return errors.New("expires_on must be at most 3 years ahead")
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Device represents a device/IP fingerprint a user has logged in from
+type Device struct {
+	ID          int       `json:"id" db:"id"`
+	UserID      int       `json:"user_id" db:"user_id"`
+	Fingerprint string    `json:"-" db:"fingerprint"`
+	IPAddress   string    `json:"ip_address" db:"ip_address"`
+	UserAgent   string    `json:"user_agent" db:"user_agent"`
+	FirstSeenAt time.Time `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// Fingerprint identifies the device by its user agent and IP address
+func (c ClientInfo) Fingerprint() string {
+	sum := sha256.Sum256([]byte(c.UserAgent + "|" + c.IPAddress))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// EmailDeliveryStatus defines the outcome of an email sent to a customer
+type EmailDeliveryStatus string
+
+const (
+	EmailDeliveryStatusAccepted   EmailDeliveryStatus = "ACCEPTED"   // the SMTP server took the message
+	EmailDeliveryStatusDelivered  EmailDeliveryStatus = "DELIVERED"  // the provider reported delivery or an open
+	EmailDeliveryStatusFailed     EmailDeliveryStatus = "FAILED"     // not sent, or soft-bounced; later emails are still sent
+	EmailDeliveryStatusBounced    EmailDeliveryStatus = "BOUNCED"    // hard-bounced; the address is suppressed
+	EmailDeliveryStatusComplained EmailDeliveryStatus = "COMPLAINED" // marked as spam; the address is suppressed
+	EmailDeliveryStatusSuppressed EmailDeliveryStatus = "SUPPRESSED" // not sent because the address is suppressed
+)
+
+// EmailDelivery records an email sent to a customer and what became of it
+type EmailDelivery struct {
+	ID        int                 `json:"id" db:"id"`
+	MessageID string              `json:"message_id" db:"message_id"` // Message-ID header, which provider events refer to
+	UserID    *int                `json:"user_id,omitempty" db:"user_id"`
+	Tenant    string              `json:"tenant" db:"tenant"`
+	Recipient string              `json:"recipient" db:"recipient"`
+	Subject   string              `json:"subject" db:"subject"`
+	Status    EmailDeliveryStatus `json:"status" db:"status"`
+	Response  string              `json:"response,omitempty" db:"response"` // SMTP error or the reason the provider gave
+	OpenedAt  *time.Time          `json:"opened_at,omitempty" db:"opened_at"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// Final reports whether no later provider event can change the delivery: bounces and complaints
+// are not undone by a delivery or open reported out of order
+func (d *EmailDelivery) Final() bool {
+	switch d.Status {
+	case EmailDeliveryStatusBounced, EmailDeliveryStatusComplained, EmailDeliveryStatusSuppressed:
+		return true
+	}
+	return false
+}
+
+// EmailDeliveryFilter selects the deliveries admins list
+type EmailDeliveryFilter struct {
+	Status    EmailDeliveryStatus
+	Recipient string
+	Limit     int
+}
+
+// MaxEmailDeliveries is the number of most recent deliveries admins can list
+const MaxEmailDeliveries = 200
+
+// EmailSuppressionReason defines why emails to an address are no longer sent
+type EmailSuppressionReason string
+
+const (
+	EmailSuppressionReasonHardBounce EmailSuppressionReason = "HARD_BOUNCE"
+	EmailSuppressionReasonComplaint  EmailSuppressionReason = "COMPLAINT"
+)
+
+// EmailSuppression blocks the emails to an address that hard-bounced or complained
+type EmailSuppression struct {
+	ID         int                    `json:"id" db:"id"`
+	Email      string                 `json:"email" db:"email"`
+	Reason     EmailSuppressionReason `json:"reason" db:"reason"`
+	DeliveryID *int                   `json:"delivery_id,omitempty" db:"delivery_id"` // the delivery that caused it
+	Details    string                 `json:"details,omitempty" db:"details"`
+	CreatedAt  time.Time              `json:"created_at" db:"created_at"`
+}
+
+// EmailEventType defines a delivery outcome reported by the email provider
+type EmailEventType string
+
+const (
+	EmailEventDelivered  EmailEventType = "delivered"
+	EmailEventOpened     EmailEventType = "opened"
+	EmailEventBounced    EmailEventType = "bounced"
+	EmailEventComplained EmailEventType = "complained"
+)
+
+// EmailEvent is a delivery outcome the email provider posts to the webhook
+type EmailEvent struct {
+	Event      EmailEventType `json:"event"`
+	MessageID  string         `json:"message_id"`
+	Email      string         `json:"email"`
+	BounceType string         `json:"bounce_type,omitempty"` // hard or soft, for bounces
+	Reason     string         `json:"reason,omitempty"`
+	OccurredAt *time.Time     `json:"occurred_at,omitempty"`
+}
+
+// Validate checks the event names a known outcome and the email it is about
+func (e *EmailEvent) Validate() error {
+	switch e.Event {
+	case EmailEventDelivered, EmailEventOpened, EmailEventComplained:
+	case EmailEventBounced:
+		if e.BounceType != "hard" && e.BounceType != "soft" {
+			return errors.New("bounce_type must be hard or soft")
+		}
+	default:
+		return errors.New("event must be one of delivered, opened, bounced, complained")
+	}
+
+	if e.MessageID == "" && e.Email == "" {
+		return errors.New("message_id or email is required")
+	}
+
+	return nil
+}
+
+// Suppresses reports whether the event stops further emails to the address
+func (e *EmailEvent) Suppresses() bool {
+	return e.Event == EmailEventComplained || (e.Event == EmailEventBounced && e.BounceType == "hard")
+}
+
+// NormalizeEmail returns the form of an address deliveries and suppressions are matched by
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
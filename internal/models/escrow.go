@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// EscrowStatus defines the status of an escrow payment
+type EscrowStatus string
+
+const (
+	EscrowStatusFunded   EscrowStatus = "FUNDED"   // the buyer has been debited, the funds are held by the bank
+	EscrowStatusReleased EscrowStatus = "RELEASED" // the seller has been credited
+	EscrowStatusRefunded EscrowStatus = "REFUNDED" // the buyer has been credited back
+)
+
+// IsValid reports whether the status is known
+func (s EscrowStatus) IsValid() bool {
+	switch s {
+	case EscrowStatusFunded, EscrowStatusReleased, EscrowStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// Escrow represents a conditional payment: the buyer's funds are held by the bank and released to
+// the seller once both parties confirm the deal, or refunded to the buyer when an admin arbiter
+// decides so or nobody settles the deal before it expires. The funding debit and the settlement
+// credit are separate ESCROW transactions linked here.
+type Escrow struct {
+	ID                      int          `json:"id" db:"id"`
+	BuyerID                 int          `json:"buyer_id" db:"buyer_id"`
+	SellerID                int          `json:"seller_id" db:"seller_id"`
+	BuyerAccountID          int          `json:"buyer_account_id" db:"buyer_account_id"`
+	SellerAccountID         int          `json:"seller_account_id" db:"seller_account_id"`
+	Amount                  float64      `json:"amount" db:"amount"`
+	Currency                Currency     `json:"currency" db:"currency"`
+	Description             string       `json:"description,omitempty" db:"description"`
+	Status                  EscrowStatus `json:"status" db:"status"`
+	BuyerConfirmedAt        *time.Time   `json:"buyer_confirmed_at,omitempty" db:"buyer_confirmed_at"`
+	SellerConfirmedAt       *time.Time   `json:"seller_confirmed_at,omitempty" db:"seller_confirmed_at"`
+	ExpiresAt               time.Time    `json:"expires_at" db:"expires_at"` // the buyer is refunded if the deal is not settled by then
+	FundingTransactionID    *int         `json:"funding_transaction_id,omitempty" db:"funding_transaction_id"`
+	SettlementTransactionID *int         `json:"settlement_transaction_id,omitempty" db:"settlement_transaction_id"`
+	ResolvedBy              *int         `json:"resolved_by,omitempty" db:"resolved_by"` // the admin who arbitrated the deal
+	ResolutionNote          string       `json:"resolution_note,omitempty" db:"resolution_note"`
+	SettledAt               *time.Time   `json:"settled_at,omitempty" db:"settled_at"`
+	CreatedAt               time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt               time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// IsParty reports whether the user is the buyer or the seller of the escrow
+func (e *Escrow) IsParty(userID int) bool {
+	return e.BuyerID == userID || e.SellerID == userID
+}
+
+// EscrowCreate represents a buyer paying into escrow for a deal with the owner of another account
+type EscrowCreate struct {
+	SourceAccountID      int     `json:"source_account_id" binding:"required"`      // the buyer's account
+	DestinationAccountID int     `json:"destination_account_id" binding:"required"` // the seller's account
+	Amount               float64 `json:"amount" binding:"required"`
+	Description          string  `json:"description" binding:"required"` // what the deal is about
+}
+
+// EscrowResolution represents an admin arbiter settling a disputed escrow
+type EscrowResolution struct {
+	Action string `json:"action" binding:"required"` // RELEASE or REFUND
+	Note   string `json:"note" binding:"required"`
+}
+
+// ValidateEscrowCreate validates escrow request data
+func (e *EscrowCreate) ValidateEscrowCreate() error {
+	if e.SourceAccountID <= 0 || e.DestinationAccountID <= 0 {
+		return errors.New("source_account_id and destination_account_id are required")
+	}
+
+	if e.SourceAccountID == e.DestinationAccountID {
+		return errors.New("source and destination accounts cannot be the same")
+	}
+
+	if !(e.Amount > 0) || math.Abs(e.Amount*100-math.Round(e.Amount*100)) > 1e-6 {
+		return errors.New("amount must be a positive number with at most 2 decimal places")
+	}
+
+	e.Description = strings.TrimSpace(e.Description)
+	if e.Description == "" {
+		return errors.New("description is required")
+	}
+
+	if len(e.Description) > 500 {
+		return errors.New("description must be at most 500 characters")
+	}
+
+	return nil
+}
+
+// ValidateEscrowResolution validates a resolution and returns the resulting status
+func (r *EscrowResolution) ValidateEscrowResolution() (EscrowStatus, error) {
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Note == "" {
+		return "", errors.New("note is required")
+	}
+
+	if len(r.Note) > 2000 {
+		return "", errors.New("note must be at most 2000 characters")
+	}
+
+	switch strings.ToUpper(r.Action) {
+	case "RELEASE":
+		return EscrowStatusReleased, nil
+	case "REFUND":
+		return EscrowStatusRefunded, nil
+	}
+
+	return "", errors.New("action must be RELEASE or REFUND")
+}
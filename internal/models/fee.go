@@ -0,0 +1,105 @@
+package models
+
+import (
+	"time"
+)
+
+// FeeKind defines the product a recurring fee is charged for
+type FeeKind string
+
+const (
+	FeeKindCardService        FeeKind = "CARD_SERVICE"        // monthly service fee of a card product
+	FeeKindAccountMaintenance FeeKind = "ACCOUNT_MAINTENANCE" // monthly fee of an account plan
+)
+
+// FeeChargeStatus defines the status of a recurring fee charge
+type FeeChargeStatus string
+
+const (
+	FeeChargeStatusPending FeeChargeStatus = "PENDING" // assessed, not charged yet
+	FeeChargeStatusCharged FeeChargeStatus = "CHARGED"
+	FeeChargeStatusDunning FeeChargeStatus = "DUNNING" // the account could not cover it; retried on every run
+)
+
+// FeeCharge is a recurring fee assessed for a period, charged as a FEE transaction once the account
+// can cover it. A product is assessed once per period: ReferenceID is the card for card service fees
+// and the plan period for account maintenance fees.
+type FeeCharge struct {
+	ID            int             `json:"id" db:"id"`
+	Kind          FeeKind         `json:"kind" db:"kind"`
+	ReferenceID   int             `json:"reference_id" db:"reference_id"`
+	AccountID     int             `json:"account_id" db:"account_id"`
+	CardID        *int            `json:"card_id,omitempty" db:"card_id"`
+	PeriodStart   time.Time       `json:"period_start" db:"period_start"`
+	PeriodEnd     time.Time       `json:"period_end" db:"period_end"`
+	Amount        float64         `json:"amount" db:"amount"`
+	Currency      Currency        `json:"currency" db:"currency"`
+	Description   string          `json:"description" db:"description"`
+	Status        FeeChargeStatus `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"` // failed charge attempts
+	LastError     string          `json:"last_error,omitempty" db:"last_error"`
+	TransactionID *int            `json:"transaction_id,omitempty" db:"transaction_id"` // the FEE transaction once charged
+	ChargedAt     *time.Time      `json:"charged_at,omitempty" db:"charged_at"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// FeeRun summarizes a run of the recurring fee job for admins
+type FeeRun struct {
+	ID         int          `json:"id" db:"id"`
+	StartedAt  time.Time    `json:"started_at" db:"started_at"`
+	FinishedAt time.Time    `json:"finished_at" db:"finished_at"`
+	Assessed   int          `json:"assessed" db:"assessed"` // fees attempted for the first time
+	Charged    int          `json:"charged" db:"charged"`
+	Dunning    int          `json:"dunning" db:"dunning"` // charges the accounts could not cover
+	Failed     int          `json:"failed" db:"failed"`   // charges not attempted because of an error
+	Totals     []*FeeTotals `json:"totals" db:"totals"`
+}
+
+// FeeTotals are the amounts a fee run charged and left in dunning in a currency
+type FeeTotals struct {
+	Currency Currency `json:"currency"`
+	Charged  float64  `json:"charged"`
+	Dunning  float64  `json:"dunning"`
+}
+
+// MaxFeeRuns is the number of most recent fee runs admins can list
+const MaxFeeRuns = 100
+
+// Add counts a charge collected or left in dunning in the totals of its currency
+func (r *FeeRun) Add(charge *FeeCharge) {
+	var totals *FeeTotals
+	for _, t := range r.Totals {
+		if t.Currency == charge.Currency {
+			totals = t
+		}
+	}
+	if totals == nil {
+		totals = &FeeTotals{Currency: charge.Currency}
+		r.Totals = append(r.Totals, totals)
+	}
+
+	if charge.Status == FeeChargeStatusCharged {
+		r.Charged++
+		totals.Charged = roundToTwoDecimal(totals.Charged + charge.Amount)
+	} else {
+		r.Dunning++
+		totals.Dunning = roundToTwoDecimal(totals.Dunning + charge.Amount)
+	}
+}
+
+// proratedMonthly returns a monthly amount prorated to a time span, month by month
+func proratedMonthly(monthly float64, from, to time.Time) float64 {
+	amount := 0.0
+	for from.Before(to) {
+		monthStart := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+		monthEnd := monthStart.AddDate(0, 1, 0)
+		end := to
+		if monthEnd.Before(end) {
+			end = monthEnd
+		}
+		amount += monthly * end.Sub(from).Hours() / monthEnd.Sub(monthStart).Hours()
+		from = end
+	}
+	return roundToTwoDecimal(amount)
+}
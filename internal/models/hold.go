@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// HoldReason defines what an account hold reserves funds for
+type HoldReason string
+
+const (
+	HoldReasonTransferConfirmation HoldReason = "TRANSFER_CONFIRMATION" // a high-value transfer waiting for its code
+	HoldReasonTransferApproval     HoldReason = "TRANSFER_APPROVAL"     // an organization transfer waiting for approvals
+	HoldReasonCreditPayment        HoldReason = "CREDIT_PAYMENT"        // an upcoming scheduled credit payment
+	HoldReasonChequeDeposit        HoldReason = "CHEQUE_DEPOSIT"        // a deposited cheque waiting for clearing
+)
+
+// HoldStatus defines the status of an account hold
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "ACTIVE"
+	HoldStatusReleased HoldStatus = "RELEASED" // the operation was cancelled and the funds are available again
+	HoldStatusCaptured HoldStatus = "CAPTURED" // the held funds were debited
+)
+
+// AccountHold reserves part of an account's balance for a pending operation. Active holds
+// reduce the available balance until they are released, captured or expire.
+type AccountHold struct {
+	ID          int        `json:"id" db:"id"`
+	AccountID   int        `json:"account_id" db:"account_id"`
+	Amount      float64    `json:"amount" db:"amount"`
+	Reason      HoldReason `json:"reason" db:"reason"`
+	ReferenceID int        `json:"reference_id" db:"reference_id"` // the confirmation, pending transfer, scheduled payment or cheque deposit
+	Status      HoldStatus `json:"status" db:"status"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty" db:"released_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
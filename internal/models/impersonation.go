@@ -0,0 +1,82 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Impersonation tokens last DefaultImpersonationTTL seconds unless the request asks for another
+// lifetime of at most MaxImpersonationTTL, and never outlive the session of the employee
+const (
+	DefaultImpersonationTTL = 15 * 60
+	MaxImpersonationTTL     = 60 * 60
+)
+
+// Impersonation represents a support employee viewing the bank as one of its customers to reproduce
+// an issue. The token it issues is read-only, and every request made with it is recorded.
+type Impersonation struct {
+	ID         int                     `json:"id" db:"id"`
+	StaffID    int                     `json:"staff_id" db:"staff_id"`
+	CustomerID int                     `json:"customer_id" db:"customer_id"`
+	Reason     string                  `json:"reason" db:"reason"` // why, e.g. the support ticket being investigated
+	SessionID  string                  `json:"-" db:"session_id"`  // the employee's session; revoking it ends the impersonation
+	ExpiresAt  time.Time               `json:"expires_at" db:"expires_at"`
+	EndedAt    *time.Time              `json:"ended_at,omitempty" db:"ended_at"`
+	CreatedAt  time.Time               `json:"created_at" db:"created_at"`
+	Requests   []*ImpersonationRequest `json:"requests,omitempty" db:"-"`
+}
+
+// ImpersonationRequest is the audit entry of a request made with an impersonation token, including
+// the write requests it was refused. Entries are only ever added.
+type ImpersonationRequest struct {
+	ID              int       `json:"id" db:"id"`
+	ImpersonationID int       `json:"impersonation_id" db:"impersonation_id"`
+	Method          string    `json:"method" db:"method"`
+	Path            string    `json:"path" db:"path"` // with the query string
+	Allowed         bool      `json:"allowed" db:"allowed"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
+
+// ImpersonationStart represents a support employee asking for a token to act as a customer
+type ImpersonationStart struct {
+	CustomerID int    `json:"customer_id" binding:"required"`
+	Reason     string `json:"reason" binding:"required"`
+	ExpiresIn  int    `json:"expires_in,omitempty"` // in seconds
+}
+
+// ImpersonationToken is the read-only token of an impersonation
+type ImpersonationToken struct {
+	Token         string         `json:"token"`
+	ExpiresAt     int64          `json:"expires_at"`
+	Impersonation *Impersonation `json:"impersonation"`
+}
+
+// IsActive checks if the impersonation token can still be used
+func (i *Impersonation) IsActive(now time.Time) bool {
+	return i.EndedAt == nil && now.Before(i.ExpiresAt)
+}
+
+// ValidateImpersonationStart validates impersonation start data and applies the default lifetime
+func (s *ImpersonationStart) ValidateImpersonationStart() error {
+	if s.CustomerID <= 0 {
+		return errors.New("customer_id is required")
+	}
+
+	s.Reason = strings.TrimSpace(s.Reason)
+	if s.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if len(s.Reason) > 500 {
+		return errors.New("reason must be at most 500 characters")
+	}
+
+	if s.ExpiresIn == 0 {
+		s.ExpiresIn = DefaultImpersonationTTL
+	}
+	if s.ExpiresIn < 0 || s.ExpiresIn > MaxImpersonationTTL {
+		return errors.New("expires_in must be between 1 and 3600 seconds")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// InsurancePolicyStatus defines the status of a credit insurance policy
+type InsurancePolicyStatus string
+
+const (
+	InsurancePolicyStatusActive    InsurancePolicyStatus = "ACTIVE"
+	InsurancePolicyStatusCancelled InsurancePolicyStatus = "CANCELLED"
+)
+
+// InsurancePolicy is the payment protection insurance taken with a credit
+type InsurancePolicy struct {
+	ID             int                   `json:"id" db:"id"`
+	PolicyNumber   string                `json:"policy_number" db:"policy_number"`
+	CreditID       int                   `json:"credit_id" db:"credit_id"`
+	UserID         int                   `json:"user_id" db:"user_id"`
+	Rate           float64               `json:"rate" db:"rate"`                       // monthly premium as a share of the credit amount
+	MonthlyPremium float64               `json:"monthly_premium" db:"monthly_premium"` // added to every scheduled payment
+	Status         InsurancePolicyStatus `json:"status" db:"status"`
+	StartDate      time.Time             `json:"start_date" db:"start_date"`
+	EndDate        time.Time             `json:"end_date" db:"end_date"`
+	CancelledAt    *time.Time            `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+}
+
+// NewInsurancePolicy creates the policy for a credit covering its whole term
+func NewInsurancePolicy(credit *Credit, rate float64) *InsurancePolicy {
+	return &InsurancePolicy{
+		PolicyNumber:   fmt.Sprintf("PPI-%010d", credit.ID),
+		CreditID:       credit.ID,
+		UserID:         credit.UserID,
+		Rate:           rate,
+		MonthlyPremium: roundToTwoDecimal(credit.Amount * rate),
+		Status:         InsurancePolicyStatusActive,
+		StartDate:      credit.StartDate,
+		EndDate:        credit.EndDate,
+	}
+}
+
+// ApplyInsurance adds the monthly premium to every payment of a schedule
+func ApplyInsurance(schedule []*PaymentSchedule, premium float64) {
+	for _, payment := range schedule {
+		payment.InsuranceAmount = premium
+		payment.TotalAmount = roundToTwoDecimal(payment.TotalAmount + premium)
+	}
+}
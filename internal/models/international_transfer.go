@@ -0,0 +1,257 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// InternationalTransferStatus defines the settlement status of an international transfer
+type InternationalTransferStatus string
+
+const (
+	InternationalTransferStatusSubmitted InternationalTransferStatus = "SUBMITTED"  // the sender has been debited, the payment waits for the next business day
+	InternationalTransferStatusInTransit InternationalTransferStatus = "IN_TRANSIT" // sent to the correspondent bank
+	InternationalTransferStatusSettled   InternationalTransferStatus = "SETTLED"    // credited by the beneficiary's bank
+)
+
+// IsValid reports whether the status is known
+func (s InternationalTransferStatus) IsValid() bool {
+	switch s {
+	case InternationalTransferStatusSubmitted, InternationalTransferStatusInTransit, InternationalTransferStatusSettled:
+		return true
+	}
+	return false
+}
+
+// ChargeBearer defines who pays the fees of an international transfer, as in SWIFT field 71A
+type ChargeBearer string
+
+const (
+	ChargeBearerOur ChargeBearer = "OUR" // the sender pays the bank's and the correspondent's fees
+	ChargeBearerSha ChargeBearer = "SHA" // the sender pays the bank's fee, the correspondent's is deducted from the amount
+	ChargeBearerBen ChargeBearer = "BEN" // both fees are deducted from the amount
+)
+
+const (
+	maxBeneficiaryNameLength = 140 // the length of a name in ISO 20022 messages
+	maxTransferPurposeLength = 140 // the length of unstructured remittance information
+)
+
+// InternationalTransfer represents a SWIFT or SEPA transfer to an account at a foreign bank. The
+// sender is debited at once; the payout leaves the bank on the next business day and is credited
+// to the beneficiary some business days later, when its PENDING transaction completes.
+type InternationalTransfer struct {
+	ID                   int                         `json:"id" db:"id"`
+	UserID               int                         `json:"user_id" db:"user_id"`
+	SourceAccountID      int                         `json:"source_account_id" db:"source_account_id"`
+	Reference            string                      `json:"reference" db:"reference"` // UETR the transfer is tracked by
+	BeneficiaryName      string                      `json:"beneficiary_name" db:"beneficiary_name"`
+	BeneficiaryIBAN      string                      `json:"beneficiary_iban" db:"beneficiary_iban"`
+	BeneficiaryBIC       string                      `json:"beneficiary_bic" db:"beneficiary_bic"`
+	BeneficiaryCountry   string                      `json:"beneficiary_country" db:"beneficiary_country"`
+	Amount               float64                     `json:"amount" db:"amount"` // the amount the sender instructed
+	Currency             Currency                    `json:"currency" db:"currency"`
+	ChargeBearer         ChargeBearer                `json:"charge_bearer" db:"charge_bearer"`
+	Fee                  float64                     `json:"fee" db:"fee"`                             // the bank's fee
+	CorrespondentFee     float64                     `json:"correspondent_fee" db:"correspondent_fee"` // charged by the correspondent bank
+	DebitedAmount        float64                     `json:"debited_amount" db:"debited_amount"`       // taken from the sender's account
+	CreditedAmount       float64                     `json:"credited_amount" db:"credited_amount"`     // expected to reach the beneficiary
+	Purpose              string                      `json:"purpose" db:"purpose"`
+	Status               InternationalTransferStatus `json:"status" db:"status"`
+	TransactionID        *int                        `json:"transaction_id,omitempty" db:"transaction_id"`         // the payout, pending until settled
+	FeeTransactionID     *int                        `json:"fee_transaction_id,omitempty" db:"fee_transaction_id"` // the bank's fee
+	DispatchAt           time.Time                   `json:"dispatch_at" db:"dispatch_at"`                         // when the payout leaves the bank
+	ExpectedSettlementAt time.Time                   `json:"expected_settlement_at" db:"expected_settlement_at"`
+	InTransitAt          *time.Time                  `json:"in_transit_at,omitempty" db:"in_transit_at"`
+	SettledAt            *time.Time                  `json:"settled_at,omitempty" db:"settled_at"`
+	CreatedAt            time.Time                   `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time                   `json:"updated_at" db:"updated_at"`
+}
+
+// InternationalTransferCreate represents a request to send money abroad
+type InternationalTransferCreate struct {
+	SourceAccountID int          `json:"source_account_id" binding:"required"`
+	BeneficiaryName string       `json:"beneficiary_name" binding:"required"`
+	BeneficiaryIBAN string       `json:"beneficiary_iban" binding:"required"`
+	BeneficiaryBIC  string       `json:"beneficiary_bic" binding:"required"`
+	Amount          float64      `json:"amount" binding:"required"`
+	ChargeBearer    ChargeBearer `json:"charge_bearer,omitempty"` // OUR, SHA or BEN, SHA by default
+	Purpose         string       `json:"purpose" binding:"required"`
+}
+
+// InternationalTransferStep is a stage of an international transfer on its way to the beneficiary
+type InternationalTransferStep struct {
+	Status     InternationalTransferStatus `json:"status"`
+	Completed  bool                        `json:"completed"`
+	At         *time.Time                  `json:"at,omitempty"`          // when the stage was reached
+	ExpectedAt *time.Time                  `json:"expected_at,omitempty"` // when a stage not reached yet is expected
+}
+
+// InternationalTransferTracking shows where an international transfer is
+type InternationalTransferTracking struct {
+	Reference            string                      `json:"reference"`
+	Status               InternationalTransferStatus `json:"status"`
+	ExpectedSettlementAt time.Time                   `json:"expected_settlement_at"`
+	Steps                []InternationalTransferStep `json:"steps"`
+}
+
+// ValidateInternationalTransferCreate validates and normalizes international transfer request data
+func (c *InternationalTransferCreate) ValidateInternationalTransferCreate() error {
+	if c.SourceAccountID <= 0 {
+		return errors.New("source_account_id is required")
+	}
+
+	c.BeneficiaryName = strings.TrimSpace(c.BeneficiaryName)
+	if c.BeneficiaryName == "" {
+		return errors.New("beneficiary name is required")
+	}
+
+	if utf8.RuneCountInString(c.BeneficiaryName) > maxBeneficiaryNameLength {
+		return errors.New("beneficiary name must be at most 140 characters")
+	}
+
+	c.BeneficiaryIBAN = NormalizeIBAN(c.BeneficiaryIBAN)
+	if !ValidIBAN(c.BeneficiaryIBAN) {
+		return errors.New("beneficiary IBAN is invalid")
+	}
+
+	c.BeneficiaryBIC = strings.ToUpper(strings.TrimSpace(c.BeneficiaryBIC))
+	if !ValidBIC(c.BeneficiaryBIC) {
+		return errors.New("beneficiary BIC must have 8 or 11 characters: bank, country, location and optional branch codes")
+	}
+
+	// The bank of an account is in the country the IBAN was issued in
+	if c.BeneficiaryBIC[4:6] != c.BeneficiaryIBAN[:2] {
+		return errors.New("beneficiary BIC and IBAN are of different countries")
+	}
+
+	if !(c.Amount > 0) || math.Abs(c.Amount*100-math.Round(c.Amount*100)) > 1e-6 {
+		return errors.New("amount must be a positive number with at most 2 decimal places")
+	}
+
+	c.ChargeBearer = ChargeBearer(strings.ToUpper(strings.TrimSpace(string(c.ChargeBearer))))
+	switch c.ChargeBearer {
+	case "":
+		c.ChargeBearer = ChargeBearerSha
+	case ChargeBearerOur, ChargeBearerSha, ChargeBearerBen:
+	default:
+		return errors.New("charge bearer must be OUR, SHA or BEN")
+	}
+
+	c.Purpose = strings.TrimSpace(c.Purpose)
+	if c.Purpose == "" {
+		return errors.New("purpose is required")
+	}
+
+	if utf8.RuneCountInString(c.Purpose) > maxTransferPurposeLength {
+		return errors.New("purpose must be at most 140 characters")
+	}
+
+	return nil
+}
+
+// NormalizeIBAN removes the spaces an IBAN is printed with and upper-cases it
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// ValidIBAN checks the format and the ISO 13616 check digits of a normalized IBAN
+func ValidIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	// Move the country code and check digits to the end, read letters as numbers from 10 and take
+	// the remainder digit by digit
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for i, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9' && i < len(rearranged)-4, r >= '0' && r <= '9' && i >= len(rearranged)-2:
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z' && i < len(rearranged)-2:
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+
+	return remainder == 1
+}
+
+// ValidBIC checks the format of an upper-case ISO 9362 business identifier code
+func ValidBIC(bic string) bool {
+	if len(bic) != 8 && len(bic) != 11 {
+		return false
+	}
+
+	for i, r := range bic {
+		letter := r >= 'A' && r <= 'Z'
+		digit := r >= '0' && r <= '9'
+		if i < 6 && !letter || i >= 6 && !letter && !digit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ApplyCharges sets the fees and the amounts debited and credited by who bears the charges. The
+// bank's fee is a share of the amount but at least the minimum; the correspondent's fee is flat.
+func (t *InternationalTransfer) ApplyCharges(feePercent, minFee, correspondentFee float64) error {
+	t.Fee = math.Max(math.Round(t.Amount*feePercent)/100, minFee)
+	t.CorrespondentFee = correspondentFee
+
+	switch t.ChargeBearer {
+	case ChargeBearerOur:
+		t.DebitedAmount = t.Amount + t.Fee + t.CorrespondentFee
+		t.CreditedAmount = t.Amount
+	case ChargeBearerSha:
+		t.DebitedAmount = t.Amount + t.Fee
+		t.CreditedAmount = t.Amount - t.CorrespondentFee
+	default:
+		t.DebitedAmount = t.Amount
+		t.CreditedAmount = t.Amount - t.Fee - t.CorrespondentFee
+	}
+
+	t.DebitedAmount = math.Round(t.DebitedAmount*100) / 100
+	t.CreditedAmount = math.Round(t.CreditedAmount*100) / 100
+	if t.CreditedAmount <= 0 {
+		return errors.New("amount does not cover the fees deducted from it")
+	}
+
+	return nil
+}
+
+// PayoutAmount returns the amount sent to the correspondent bank: everything debited but the
+// bank's own fee
+func (t *InternationalTransfer) PayoutAmount() float64 {
+	return math.Round((t.DebitedAmount-t.Fee)*100) / 100
+}
+
+// Tracking lists the stages of the transfer with when each was or is expected to be reached
+func (t *InternationalTransfer) Tracking() *InternationalTransferTracking {
+	submittedAt, dispatchAt, settlementAt := t.CreatedAt, t.DispatchAt, t.ExpectedSettlementAt
+
+	steps := []InternationalTransferStep{
+		{Status: InternationalTransferStatusSubmitted, Completed: true, At: &submittedAt},
+		{Status: InternationalTransferStatusInTransit, Completed: t.InTransitAt != nil, At: t.InTransitAt},
+		{Status: InternationalTransferStatusSettled, Completed: t.SettledAt != nil, At: t.SettledAt},
+	}
+	if t.InTransitAt == nil {
+		steps[1].ExpectedAt = &dispatchAt
+	}
+	if t.SettledAt == nil {
+		steps[2].ExpectedAt = &settlementAt
+	}
+
+	return &InternationalTransferTracking{
+		Reference:            t.Reference,
+		Status:               t.Status,
+		ExpectedSettlementAt: t.ExpectedSettlementAt,
+		Steps:                steps,
+	}
+}
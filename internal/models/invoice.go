@@ -0,0 +1,163 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxInvoiceItems is the most line items an invoice may list
+	MaxInvoiceItems = 100
+	// MaxInvoiceDueDays is how far ahead the due date of an invoice may be
+	MaxInvoiceDueDays = 365
+	// maxInvoiceAmount is the first total that no longer fits the DECIMAL(15, 2) columns
+	maxInvoiceAmount = 1e13
+	// maxInvoiceTextLength caps the description of an invoice and of its line items
+	maxInvoiceTextLength = 255
+)
+
+// InvoiceStatus defines the status of an invoice
+type InvoiceStatus string
+
+const (
+	InvoiceStatusIssued    InvoiceStatus = "ISSUED"
+	InvoiceStatusPaid      InvoiceStatus = "PAID"
+	InvoiceStatusOverdue   InvoiceStatus = "OVERDUE" // unpaid after the due date, it can still be paid
+	InvoiceStatusCancelled InvoiceStatus = "CANCELLED"
+)
+
+// IsPayable reports whether an invoice in the status can still be paid
+func (s InvoiceStatus) IsPayable() bool {
+	return s == InvoiceStatusIssued || s == InvoiceStatusOverdue
+}
+
+// Invoice represents a request for payment a user or a merchant sends to another user of the bank.
+// The recipient pays it with a transfer to the issuer's account.
+type Invoice struct {
+	ID             int            `json:"-" db:"id"`
+	Number         string         `json:"number" db:"number"` // public identifier used in payment links
+	IssuerID       int            `json:"issuer_id" db:"issuer_id"`
+	MerchantID     *int           `json:"merchant_id,omitempty" db:"merchant_id"`         // set if a merchant issued the invoice
+	SubscriptionID *int           `json:"subscription_id,omitempty" db:"subscription_id"` // set if the invoice bills a subscription period
+	RecipientID    int            `json:"recipient_id" db:"recipient_id"`
+	RecipientEmail string         `json:"recipient_email" db:"recipient_email"`
+	AccountID      int            `json:"account_id" db:"account_id"` // the account the invoice is paid to
+	AccountNumber  string         `json:"account_number" db:"account_number"`
+	Amount         float64        `json:"amount" db:"amount"`
+	Currency       Currency       `json:"currency" db:"currency"`
+	Description    string         `json:"description,omitempty" db:"description"`
+	DueAt          time.Time      `json:"due_at" db:"due_at"` // the end of the due date
+	Status         InvoiceStatus  `json:"status" db:"status"`
+	PayerAccountID *int           `json:"payer_account_id,omitempty" db:"payer_account_id"`
+	TransactionID  *int           `json:"transaction_id,omitempty" db:"transaction_id"`
+	PaidAt         *time.Time     `json:"paid_at,omitempty" db:"paid_at"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" db:"updated_at"`
+	Items          []*InvoiceItem `json:"items,omitempty" db:"-"`
+	PaymentLink    string         `json:"payment_link" db:"-"`
+	QRPayload      string         `json:"qr_payload,omitempty" db:"-"` // ST00012 payment string for banking apps
+}
+
+// InvoiceItem represents a line of an invoice
+type InvoiceItem struct {
+	ID          int     `json:"-" db:"id"`
+	InvoiceID   int     `json:"-" db:"invoice_id"`
+	Position    int     `json:"position" db:"position"`
+	Description string  `json:"description" db:"description"`
+	Quantity    int     `json:"quantity" db:"quantity"`
+	UnitPrice   float64 `json:"unit_price" db:"unit_price"`
+	Amount      float64 `json:"amount" db:"amount"`
+}
+
+// InvoiceCreate represents data for issuing an invoice
+type InvoiceCreate struct {
+	AccountID      int            `json:"account_id"` // the issuer's account; merchants are paid to their settlement account
+	RecipientEmail string         `json:"recipient_email" binding:"required"`
+	DueDate        string         `json:"due_date" binding:"required"` // YYYY-MM-DD, the last day to pay
+	Description    string         `json:"description,omitempty"`
+	Items          []*InvoiceItem `json:"items" binding:"required"`
+	DueAt          time.Time      `json:"-"` // the end of DueDate, set by validation
+	Amount         float64        `json:"-"` // the total of the items, set by validation
+}
+
+// InvoicePayRequest represents a recipient paying an invoice
+type InvoicePayRequest struct {
+	AccountID int `json:"account_id" binding:"required"`
+}
+
+// ValidateInvoiceCreate validates invoice data and computes the amounts of its items. The due date
+// is read in the location of now.
+func (i *InvoiceCreate) ValidateInvoiceCreate(now time.Time) error {
+	i.RecipientEmail = strings.ToLower(strings.TrimSpace(i.RecipientEmail))
+	if err := ValidateEmail(i.RecipientEmail); err != nil {
+		return err
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", i.DueDate, now.Location())
+	if err != nil {
+		return errors.New("due_date must be in YYYY-MM-DD format")
+	}
+	i.DueAt = day.AddDate(0, 0, 1)
+
+	if !i.DueAt.After(now) {
+		return errors.New("due_date must not be in the past")
+	}
+	if i.DueAt.After(now.AddDate(0, 0, MaxInvoiceDueDays)) {
+		return errors.New("due_date must be at most a year ahead")
+	}
+
+	i.Description = strings.TrimSpace(i.Description)
+	if utf8.RuneCountInString(i.Description) > maxInvoiceTextLength {
+		return errors.New("description must be at most 255 characters")
+	}
+
+	if len(i.Items) == 0 {
+		return errors.New("invoice must have at least one item")
+	}
+	if len(i.Items) > MaxInvoiceItems {
+		return errors.New("invoice must have at most 100 items")
+	}
+
+	i.Amount = 0
+	for n, item := range i.Items {
+		if item == nil {
+			return errors.New("invoice items must not be empty")
+		}
+		if err := item.validate(); err != nil {
+			return err
+		}
+		item.Position = n + 1
+		i.Amount = math.Round((i.Amount+item.Amount)*100) / 100
+	}
+
+	if i.Amount >= maxInvoiceAmount {
+		return errors.New("invoice total is too large")
+	}
+
+	return nil
+}
+
+// validate checks a line item and computes its amount
+func (i *InvoiceItem) validate() error {
+	i.Description = strings.TrimSpace(i.Description)
+	if i.Description == "" {
+		return errors.New("item description is required")
+	}
+	if utf8.RuneCountInString(i.Description) > maxInvoiceTextLength {
+		return errors.New("item description must be at most 255 characters")
+	}
+
+	if i.Quantity <= 0 {
+		return errors.New("item quantity must be positive")
+	}
+
+	if !(i.UnitPrice > 0) || i.UnitPrice >= maxInvoiceAmount || math.Abs(i.UnitPrice*100-math.Round(i.UnitPrice*100)) > 1e-6 {
+		return errors.New("item unit_price must be a positive number with at most 2 decimal places")
+	}
+
+	i.Amount = math.Round(float64(i.Quantity)*i.UnitPrice*100) / 100
+	return nil
+}
@@ -0,0 +1,224 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+const (
+	// DefaultLocationRadius is the search radius in km when none is given
+	DefaultLocationRadius = 5.0
+	// MaxLocationRadius is the largest search radius in km
+	MaxLocationRadius = 50.0
+	// MaxNearbyLocations limits the number of locations a search returns
+	MaxNearbyLocations = 100
+
+	// earthRadiusKm is the mean radius of the Earth used for distances
+	earthRadiusKm = 6371.0
+)
+
+// LocationType defines the kind of a bank location
+type LocationType string
+
+const (
+	LocationTypeBranch LocationType = "BRANCH"
+	LocationTypeATM    LocationType = "ATM"
+)
+
+// LocationService is a service offered at a location
+type LocationService string
+
+const (
+	LocationServiceCashWithdrawal   LocationService = "CASH_WITHDRAWAL"
+	LocationServiceCashDeposit      LocationService = "CASH_DEPOSIT"
+	LocationServiceCurrencyExchange LocationService = "CURRENCY_EXCHANGE"
+	LocationServiceConsultation     LocationService = "CONSULTATION"
+	LocationServiceCredits          LocationService = "CREDITS"
+	LocationServiceSafeDeposit      LocationService = "SAFE_DEPOSIT"
+)
+
+// IsValid reports whether the service is known
+func (s LocationService) IsValid() bool {
+	switch s {
+	case LocationServiceCashWithdrawal, LocationServiceCashDeposit, LocationServiceCurrencyExchange,
+		LocationServiceConsultation, LocationServiceCredits, LocationServiceSafeDeposit:
+		return true
+	}
+	return false
+}
+
+// weekdays are the keys of opening hours
+var weekdays = map[string]bool{"mon": true, "tue": true, "wed": true, "thu": true, "fri": true, "sat": true, "sun": true}
+
+// openingHoursPattern matches "09:00-18:00", "24h" or "closed"
+var openingHoursPattern = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]-([01][0-9]|2[0-4]):[0-5][0-9]|24h|closed)$`)
+
+// Location is a bank branch or ATM
+type Location struct {
+	ID           int               `json:"id" db:"id"`
+	Type         LocationType      `json:"type" db:"type"`
+	Name         string            `json:"name" db:"name"`
+	Address      string            `json:"address" db:"address"`
+	Latitude     float64           `json:"latitude" db:"latitude"`
+	Longitude    float64           `json:"longitude" db:"longitude"`
+	Services     []LocationService `json:"services" db:"services"`
+	OpeningHours map[string]string `json:"opening_hours" db:"opening_hours"` // by weekday: mon..sun
+	IsActive     bool              `json:"is_active" db:"is_active"`
+	DistanceKm   *float64          `json:"distance_km,omitempty" db:"-"` // set in nearby searches
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// LocationRequest represents the data of a location created or updated by an admin
+type LocationRequest struct {
+	Type         LocationType      `json:"type"`
+	Name         string            `json:"name"`
+	Address      string            `json:"address"`
+	Latitude     float64           `json:"latitude"`
+	Longitude    float64           `json:"longitude"`
+	Services     []LocationService `json:"services"`
+	OpeningHours map[string]string `json:"opening_hours"`
+	IsActive     *bool             `json:"is_active,omitempty"` // defaults to true
+}
+
+// LocationQuery is a search for active locations around a point
+type LocationQuery struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+	Type      LocationType // empty matches both types
+}
+
+// ValidateLocationRequest validates location data and normalizes its text fields
+func (l *LocationRequest) ValidateLocationRequest() error {
+	if l.Type != LocationTypeBranch && l.Type != LocationTypeATM {
+		return errors.New("type must be BRANCH or ATM")
+	}
+
+	l.Name = strings.TrimSpace(l.Name)
+	if l.Name == "" || len(l.Name) > 100 {
+		return errors.New("name is required and must be at most 100 characters")
+	}
+
+	l.Address = strings.TrimSpace(l.Address)
+	if l.Address == "" || len(l.Address) > 255 {
+		return errors.New("address is required and must be at most 255 characters")
+	}
+
+	if err := validateCoordinates(l.Latitude, l.Longitude); err != nil {
+		return err
+	}
+
+	for _, service := range l.Services {
+		if !service.IsValid() {
+			return i18n.Errorf("unknown_service", string(service))
+		}
+	}
+
+	for day, hours := range l.OpeningHours {
+		if !weekdays[day] {
+			return i18n.Errorf("opening_hours_unknown_day", day)
+		}
+
+		if !openingHoursPattern.MatchString(hours) {
+			return i18n.Errorf("opening_hours_must_be_hh_mm_hh_mm_24h_or_closed", day)
+		}
+	}
+
+	return nil
+}
+
+// ToLocation converts LocationRequest to Location
+func (l *LocationRequest) ToLocation() *Location {
+	location := &Location{
+		Type:         l.Type,
+		Name:         l.Name,
+		Address:      l.Address,
+		Latitude:     l.Latitude,
+		Longitude:    l.Longitude,
+		Services:     l.Services,
+		OpeningHours: l.OpeningHours,
+		IsActive:     l.IsActive == nil || *l.IsActive,
+	}
+
+	if location.Services == nil {
+		location.Services = []LocationService{}
+	}
+
+	if location.OpeningHours == nil {
+		location.OpeningHours = map[string]string{}
+	}
+
+	return location
+}
+
+// ParseLocationQuery parses the lat, lng, radius (km) and type query parameters
+func ParseLocationQuery(lat, lng, radius, locationType string) (*LocationQuery, error) {
+	query := &LocationQuery{RadiusKm: DefaultLocationRadius, Type: LocationType(strings.ToUpper(locationType))}
+
+	var err error
+	if query.Latitude, err = strconv.ParseFloat(lat, 64); err != nil {
+		return nil, errors.New("lat is required and must be a number")
+	}
+
+	if query.Longitude, err = strconv.ParseFloat(lng, 64); err != nil {
+		return nil, errors.New("lng is required and must be a number")
+	}
+
+	if err := validateCoordinates(query.Latitude, query.Longitude); err != nil {
+		return nil, err
+	}
+
+	if radius != "" {
+		query.RadiusKm, err = strconv.ParseFloat(radius, 64)
+		if err != nil || query.RadiusKm <= 0 || query.RadiusKm > MaxLocationRadius {
+			return nil, i18n.Errorf("radius_must_be_a_number_of_km_between_0_and", strconv.FormatFloat(MaxLocationRadius, 'f', 0, 64))
+		}
+	}
+
+	if query.Type != "" && query.Type != LocationTypeBranch && query.Type != LocationTypeATM {
+		return nil, errors.New("type must be BRANCH or ATM")
+	}
+
+	return query, nil
+}
+
+// BoundingBox returns the latitude and longitude ranges that contain the search circle, so the
+// distance only has to be computed for locations inside them
+func (q *LocationQuery) BoundingBox() (minLat, maxLat, minLng, maxLng float64) {
+	deltaLat := q.RadiusKm / earthRadiusKm * 180 / math.Pi
+	minLat, maxLat = math.Max(q.Latitude-deltaLat, -90), math.Min(q.Latitude+deltaLat, 90)
+
+	// Near the poles the circle spans all longitudes
+	cos := math.Cos(q.Latitude * math.Pi / 180)
+	if cos < 0.01 || maxLat == 90 || minLat == -90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	// Circles crossing the antimeridian are not split, they search all longitudes instead
+	deltaLng := deltaLat / cos
+	if q.Longitude-deltaLng < -180 || q.Longitude+deltaLng > 180 {
+		return minLat, maxLat, -180, 180
+	}
+
+	return minLat, maxLat, q.Longitude - deltaLng, q.Longitude + deltaLng
+}
+
+// validateCoordinates checks that a point is on the globe
+func validateCoordinates(lat, lng float64) error {
+	if lat < -90 || lat > 90 || math.IsNaN(lat) {
+		return errors.New("latitude must be between -90 and 90")
+	}
+
+	if lng < -180 || lng > 180 || math.IsNaN(lng) {
+		return errors.New("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
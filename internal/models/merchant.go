@@ -0,0 +1,169 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PaymentIntentStatus defines the status of a payment intent
+type PaymentIntentStatus string
+
+const (
+	PaymentIntentStatusCreated   PaymentIntentStatus = "CREATED"
+	PaymentIntentStatusSucceeded PaymentIntentStatus = "SUCCEEDED"
+	PaymentIntentStatusCancelled PaymentIntentStatus = "CANCELLED"
+)
+
+// Merchant represents a business accepting payments from customers of the bank
+type Merchant struct {
+	ID                  int       `json:"id" db:"id"`
+	UserID              int       `json:"user_id" db:"user_id"`
+	Name                string    `json:"name" db:"name"`
+	SettlementAccountID int       `json:"settlement_account_id" db:"settlement_account_id"`
+	APIKeyHash          string    `json:"-" db:"api_key_hash"`
+	APIKeyPrefix        string    `json:"api_key_prefix" db:"api_key_prefix"`
+	IsActive            bool      `json:"is_active" db:"is_active"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// MerchantCreate represents data for registering a merchant
+type MerchantCreate struct {
+	Name                string `json:"name" binding:"required"`
+	SettlementAccountID int    `json:"settlement_account_id" binding:"required"`
+}
+
+// MerchantCredentials is returned once when a merchant is created or its API key is rotated
+type MerchantCredentials struct {
+	Merchant *Merchant `json:"merchant"`
+	APIKey   string    `json:"api_key"`
+}
+
+// PaymentIntent represents a merchant's request to be paid by a customer
+type PaymentIntent struct {
+	ID                int                 `json:"-" db:"id"`
+	IntentID          string              `json:"intent_id" db:"intent_id"`
+	MerchantID        int                 `json:"merchant_id" db:"merchant_id"`
+	MerchantName      string              `json:"merchant_name" db:"merchant_name"`
+	Amount            float64             `json:"amount" db:"amount"`
+	Currency          Currency            `json:"currency" db:"currency"`
+	Description       string              `json:"description,omitempty" db:"description"`
+	OrderReference    string              `json:"order_reference,omitempty" db:"order_reference"`
+	Status            PaymentIntentStatus `json:"status" db:"status"`
+	CustomerID        *int                `json:"customer_id,omitempty" db:"customer_id"`
+	SourceAccountID   *int                `json:"source_account_id,omitempty" db:"source_account_id"`
+	TransactionID     *int                `json:"transaction_id,omitempty" db:"transaction_id"`
+	SettlementBatchID *int                `json:"settlement_batch_id,omitempty" db:"settlement_batch_id"`
+	ExpiresAt         time.Time           `json:"expires_at" db:"expires_at"`
+	PaidAt            *time.Time          `json:"paid_at,omitempty" db:"paid_at"`
+	CreatedAt         time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// PaymentIntentCreate represents a merchant's request to create a payment intent
+type PaymentIntentCreate struct {
+	Amount         float64 `json:"amount" binding:"required"`
+	Description    string  `json:"description,omitempty"`
+	OrderReference string  `json:"order_reference,omitempty"`
+}
+
+// PaymentIntentPayRequest represents a customer paying a payment intent, optionally with a card of the account
+type PaymentIntentPayRequest struct {
+	AccountID int  `json:"account_id" binding:"required"`
+	CardID    *int `json:"card_id,omitempty"`
+}
+
+// SettlementBatch represents the daily payout of a merchant's captured payments
+type SettlementBatch struct {
+	ID             int       `json:"id" db:"id"`
+	MerchantID     int       `json:"merchant_id" db:"merchant_id"`
+	SettlementDate time.Time `json:"settlement_date" db:"settlement_date"`
+	PaymentCount   int       `json:"payment_count" db:"payment_count"`
+	Amount         float64   `json:"amount" db:"amount"`
+	TransactionID  *int      `json:"transaction_id,omitempty" db:"transaction_id"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+}
+
+// ValidateMerchantCreate validates merchant registration data
+func (m *MerchantCreate) ValidateMerchantCreate() error {
+	m.Name = strings.TrimSpace(m.Name)
+	if m.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(m.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+
+	if m.SettlementAccountID <= 0 {
+		return errors.New("settlement_account_id is required")
+	}
+
+	return nil
+}
+
+// ToMerchant converts MerchantCreate to Merchant
+func (m *MerchantCreate) ToMerchant(userID int) *Merchant {
+	return &Merchant{
+		UserID:              userID,
+		Name:                m.Name,
+		SettlementAccountID: m.SettlementAccountID,
+		IsActive:            true,
+	}
+}
+
+// ValidatePaymentIntentCreate validates payment intent data
+func (p *PaymentIntentCreate) ValidatePaymentIntentCreate() error {
+	if p.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+
+	if len(p.Description) > 255 {
+		return errors.New("description must be at most 255 characters")
+	}
+
+	if len(p.OrderReference) > 100 {
+		return errors.New("order_reference must be at most 100 characters")
+	}
+
+	return nil
+}
+
+// ToPaymentIntent converts PaymentIntentCreate to PaymentIntent
+func (p *PaymentIntentCreate) ToPaymentIntent(merchantID int, intentID string, expiresAt time.Time) *PaymentIntent {
+	return &PaymentIntent{
+		IntentID:       intentID,
+		MerchantID:     merchantID,
+		Amount:         p.Amount,
+		Currency:       CurrencyRUB,
+		Description:    p.Description,
+		OrderReference: p.OrderReference,
+		Status:         PaymentIntentStatusCreated,
+		ExpiresAt:      expiresAt,
+	}
+}
+
+// IsPayable reports whether the intent can still be paid at the given time
+func (p *PaymentIntent) IsPayable(now time.Time) bool {
+	return p.Status == PaymentIntentStatusCreated && now.Before(p.ExpiresAt)
+}
+
+// ToTransaction converts a paid PaymentIntent to the customer's PAYMENT transaction
+func (p *PaymentIntent) ToTransaction(accountID int, cardID *int) *Transaction {
+	description := "Payment to " + p.MerchantName
+	if p.OrderReference != "" {
+		description += ", order " + p.OrderReference
+	}
+
+	return &Transaction{
+		TransactionType: TransactionTypePayment,
+		SourceAccountID: &accountID,
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		Description:     description,
+		Status:          TransactionStatusCompleted,
+		CardID:          cardID,
+		TransactionDate: time.Now(),
+	}
+}
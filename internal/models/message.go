@@ -0,0 +1,129 @@
+package models
+
+import (
+	"errors"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxMessageDocumentSize is the largest document that can be attached to a secure message
+const MaxMessageDocumentSize = 5 << 20
+
+// MessageSender defines who wrote a secure message
+type MessageSender string
+
+const (
+	MessageSenderBank     MessageSender = "BANK"
+	MessageSenderCustomer MessageSender = "CUSTOMER"
+)
+
+// MessageThread is a secure conversation between the bank and a customer
+type MessageThread struct {
+	ID            int           `json:"id" db:"id"`
+	UserID        int           `json:"user_id" db:"user_id"`
+	Subject       string        `json:"subject" db:"subject"`
+	StartedBy     MessageSender `json:"started_by" db:"started_by"`
+	UnreadCount   int           `json:"unread_count" db:"-"` // messages from the other side not read yet
+	LastMessageAt time.Time     `json:"last_message_at" db:"last_message_at"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	Messages      []*Message    `json:"messages,omitempty" db:"-"`
+}
+
+// Message is a single secure message, optionally carrying a document
+type Message struct {
+	ID           int           `json:"id" db:"id"`
+	ThreadID     int           `json:"thread_id" db:"thread_id"`
+	Sender       MessageSender `json:"sender" db:"sender"`
+	AuthorID     int           `json:"-" db:"author_id"` // the customer or the bank employee who wrote it
+	Body         string        `json:"body" db:"body"`
+	DocumentName string        `json:"document_name,omitempty" db:"document_name"`
+	DocumentType string        `json:"document_type,omitempty" db:"document_type"`
+	DocumentSize int           `json:"document_size,omitempty" db:"-"`
+	Document     []byte        `json:"-" db:"document"`
+	ReadAt       *time.Time    `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+}
+
+// MessageRequest represents a new message or reply; the document is sent base64-encoded
+type MessageRequest struct {
+	Body         string `json:"body"`
+	DocumentName string `json:"document_name,omitempty"`
+	DocumentType string `json:"document_type,omitempty"`
+	Document     []byte `json:"document,omitempty"`
+}
+
+// ThreadCreate represents a request to start a message thread. UserID is only used when the bank
+// writes to a customer.
+type ThreadCreate struct {
+	UserID  int    `json:"user_id,omitempty"`
+	Subject string `json:"subject"`
+	MessageRequest
+}
+
+// ValidateMessageRequest validates message data and normalizes the document name and type
+func (m *MessageRequest) ValidateMessageRequest() error {
+	m.Body = strings.TrimSpace(m.Body)
+	if m.Body == "" {
+		return errors.New("body is required")
+	}
+
+	if utf8.RuneCountInString(m.Body) > 5000 {
+		return errors.New("body must be at most 5000 characters")
+	}
+
+	if len(m.Document) == 0 {
+		m.DocumentName, m.DocumentType = "", ""
+		return nil
+	}
+
+	if len(m.Document) > MaxMessageDocumentSize {
+		return errors.New("document must be at most 5 MB")
+	}
+
+	// Only the base name is kept so the name is safe to use in a download header
+	m.DocumentName = strings.Trim(filepath.Base(strings.ReplaceAll(m.DocumentName, `\`, "/")), ` ."`)
+	if m.DocumentName == "" || m.DocumentName == "/" || len(m.DocumentName) > 255 {
+		return errors.New("document_name is required with a document and must be at most 255 characters")
+	}
+
+	if m.DocumentType == "" {
+		m.DocumentType = "application/octet-stream"
+	}
+
+	if _, _, err := mime.ParseMediaType(m.DocumentType); err != nil || len(m.DocumentType) > 100 {
+		return errors.New("document_type must be a valid MIME type")
+	}
+
+	return nil
+}
+
+// ValidateThreadCreate validates the subject and the first message of a thread
+func (t *ThreadCreate) ValidateThreadCreate() error {
+	t.Subject = strings.TrimSpace(t.Subject)
+	if t.Subject == "" {
+		return errors.New("subject is required")
+	}
+
+	if utf8.RuneCountInString(t.Subject) > 200 {
+		return errors.New("subject must be at most 200 characters")
+	}
+
+	return t.ValidateMessageRequest()
+}
+
+// ToMessage converts a MessageRequest to a Message
+func (m *MessageRequest) ToMessage(threadID int, sender MessageSender, authorID int) *Message {
+	return &Message{
+		ThreadID:     threadID,
+		Sender:       sender,
+		AuthorID:     authorID,
+		Body:         m.Body,
+		DocumentName: m.DocumentName,
+		DocumentType: m.DocumentType,
+		DocumentSize: len(m.Document),
+		Document:     m.Document,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// NotificationType defines the type of an in-app notification
+type NotificationType string
+
+const (
+	NotificationTypeSecurity     NotificationType = "SECURITY"
+	NotificationTypeOrganization NotificationType = "ORGANIZATION"
+	NotificationTypeApproval     NotificationType = "APPROVAL"
+	NotificationTypeChargeback   NotificationType = "CHARGEBACK"
+	NotificationTypeReferral     NotificationType = "REFERRAL"
+	NotificationTypeCredit       NotificationType = "CREDIT"
+	NotificationTypeAccount      NotificationType = "ACCOUNT"
+	NotificationTypeDecline      NotificationType = "DECLINE"
+	NotificationTypeEscrow       NotificationType = "ESCROW"
+	NotificationTypeInvoice      NotificationType = "INVOICE"
+	NotificationTypeSubscription NotificationType = "SUBSCRIPTION"
+	NotificationTypeTransfer     NotificationType = "TRANSFER"
+	NotificationTypeAnnouncement NotificationType = "ANNOUNCEMENT" // sent by admins to a segment of customers
+	NotificationTypeOnboarding   NotificationType = "ONBOARDING"
+)
+
+// Notification represents an in-app notification shown to a user
+type Notification struct {
+	ID        int              `json:"id" db:"id"`
+	UserID    int              `json:"user_id" db:"user_id"`
+	Type      NotificationType `json:"type" db:"type"`
+	Title     string           `json:"title" db:"title"`
+	Message   string           `json:"message" db:"message"`
+	IsRead    bool             `json:"is_read" db:"is_read"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
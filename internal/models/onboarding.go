@@ -0,0 +1,92 @@
+package models
+
+import "time"
+
+// OnboardingStage defines a step a new customer goes through
+type OnboardingStage string
+
+const (
+	OnboardingStageEmailVerified OnboardingStage = "EMAIL_VERIFIED" // the email address is confirmed by a link sent to it
+	OnboardingStageKYC           OnboardingStage = "KYC"            // the phone, the date of birth and the address are known
+	OnboardingStageFirstAccount  OnboardingStage = "FIRST_ACCOUNT"
+	OnboardingStageFirstCard     OnboardingStage = "FIRST_CARD"
+)
+
+// OnboardingStages lists the onboarding stages in the order they are completed
+var OnboardingStages = []OnboardingStage{
+	OnboardingStageEmailVerified,
+	OnboardingStageKYC,
+	OnboardingStageFirstAccount,
+	OnboardingStageFirstCard,
+}
+
+// OnboardingEvent records that a user completed an onboarding stage
+type OnboardingEvent struct {
+	ID          int             `json:"id" db:"id"`
+	UserID      int             `json:"user_id" db:"user_id"`
+	Stage       OnboardingStage `json:"stage" db:"stage"`
+	CompletedAt time.Time       `json:"completed_at" db:"completed_at"`
+}
+
+// OnboardingStep is the state of one onboarding stage of a user
+type OnboardingStep struct {
+	Stage       OnboardingStage `json:"stage"`
+	Completed   bool            `json:"completed"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+}
+
+// OnboardingStatus is how far a user has got through onboarding
+type OnboardingStatus struct {
+	Stage          OnboardingStage  `json:"stage,omitempty"` // the stage to complete next, empty once onboarding is done
+	Completed      bool             `json:"completed"`
+	Steps          []OnboardingStep `json:"steps"`
+	MissingProfile []string         `json:"missing_profile,omitempty"` // what the profile lacks for KYC
+}
+
+// ToOnboardingStatus builds the onboarding status of a user from the stages they completed. The
+// stages are completed in order, so the first one not completed is the current one.
+func ToOnboardingStatus(events []*OnboardingEvent) *OnboardingStatus {
+	completed := map[OnboardingStage]time.Time{}
+	for _, event := range events {
+		completed[event.Stage] = event.CompletedAt
+	}
+
+	status := &OnboardingStatus{Steps: []OnboardingStep{}}
+	for _, stage := range OnboardingStages {
+		step := OnboardingStep{Stage: stage}
+		if at, ok := completed[stage]; ok {
+			step.Completed = true
+			step.CompletedAt = &at
+		} else if status.Stage == "" {
+			status.Stage = stage
+		}
+		status.Steps = append(status.Steps, step)
+	}
+	status.Completed = status.Stage == ""
+
+	return status
+}
+
+// Done reports whether a stage is completed
+func (s *OnboardingStatus) Done(stage OnboardingStage) bool {
+	for _, step := range s.Steps {
+		if step.Stage == stage {
+			return step.Completed
+		}
+	}
+	return false
+}
+
+// Reached reports whether every stage before the given one is completed, so the user may go on
+// to it
+func (s *OnboardingStatus) Reached(stage OnboardingStage) bool {
+	for _, step := range s.Steps {
+		if step.Stage == stage {
+			return true
+		}
+		if !step.Completed {
+			return false
+		}
+	}
+	return true
+}
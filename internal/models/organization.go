@@ -0,0 +1,153 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// OrganizationRole defines the role of a member within an organization
+type OrganizationRole string
+
+const (
+	OrganizationRoleAdmin      OrganizationRole = "ADMIN"
+	OrganizationRoleAccountant OrganizationRole = "ACCOUNTANT"
+	OrganizationRoleViewer     OrganizationRole = "VIEWER"
+)
+
+// IsValid checks that the role is one of the known roles
+func (r OrganizationRole) IsValid() bool {
+	switch r {
+	case OrganizationRoleAdmin, OrganizationRoleAccountant, OrganizationRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanTransact reports whether the role may move money from organization accounts
+func (r OrganizationRole) CanTransact() bool {
+	return r == OrganizationRoleAdmin || r == OrganizationRoleAccountant
+}
+
+// CanManage reports whether the role may manage accounts, cards, members and invitations
+func (r OrganizationRole) CanManage() bool {
+	return r == OrganizationRoleAdmin
+}
+
+// InvitationStatus defines the status of an organization invitation
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "PENDING"
+	InvitationStatusAccepted InvitationStatus = "ACCEPTED"
+	InvitationStatusDeclined InvitationStatus = "DECLINED"
+	InvitationStatusRevoked  InvitationStatus = "REVOKED"
+)
+
+// Organization represents a business that owns accounts shared by its members
+type Organization struct {
+	ID        int                   `json:"id" db:"id"`
+	Name      string                `json:"name" db:"name"`
+	CreatedBy int                   `json:"created_by" db:"created_by"`
+	Role      OrganizationRole      `json:"role,omitempty" db:"role"` // role of the requesting user
+	Members   []*OrganizationMember `json:"members,omitempty" db:"-"`
+	CreatedAt time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// OrganizationCreate represents data for creating a new organization
+type OrganizationCreate struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// OrganizationMember represents a user's membership in an organization
+type OrganizationMember struct {
+	ID             int              `json:"id" db:"id"`
+	OrganizationID int              `json:"organization_id" db:"organization_id"`
+	UserID         int              `json:"user_id" db:"user_id"`
+	Username       string           `json:"username" db:"username"`
+	Email          string           `json:"email" db:"email"`
+	Role           OrganizationRole `json:"role" db:"role"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+}
+
+// MemberRoleUpdate represents a request to change a member's role
+type MemberRoleUpdate struct {
+	Role OrganizationRole `json:"role" binding:"required"`
+}
+
+// OrganizationInvitation represents an invitation for a user to join an organization
+type OrganizationInvitation struct {
+	ID               int              `json:"id" db:"id"`
+	OrganizationID   int              `json:"organization_id" db:"organization_id"`
+	OrganizationName string           `json:"organization_name,omitempty" db:"organization_name"`
+	Email            string           `json:"email" db:"email"`
+	Role             OrganizationRole `json:"role" db:"role"`
+	InvitedBy        int              `json:"invited_by" db:"invited_by"`
+	Status           InvitationStatus `json:"status" db:"status"`
+	ExpiresAt        time.Time        `json:"expires_at" db:"expires_at"`
+	RespondedAt      *time.Time       `json:"responded_at,omitempty" db:"responded_at"`
+	CreatedAt        time.Time        `json:"created_at" db:"created_at"`
+}
+
+// InvitationCreate represents data for inviting a user to an organization
+type InvitationCreate struct {
+	Email string           `json:"email" binding:"required"`
+	Role  OrganizationRole `json:"role" binding:"required"`
+}
+
+// ValidateOrganizationCreate validates organization creation data
+func (o *OrganizationCreate) ValidateOrganizationCreate() error {
+	o.Name = strings.TrimSpace(o.Name)
+
+	if len(o.Name) < 2 || len(o.Name) > 100 {
+		return errors.New("name must be between 2 and 100 characters")
+	}
+
+	return nil
+}
+
+// ToOrganization converts OrganizationCreate to Organization
+func (o *OrganizationCreate) ToOrganization(createdBy int) *Organization {
+	return &Organization{
+		Name:      o.Name,
+		CreatedBy: createdBy,
+	}
+}
+
+// ValidateMemberRoleUpdate validates a member role change
+func (m *MemberRoleUpdate) ValidateMemberRoleUpdate() error {
+	if !m.Role.IsValid() {
+		return errors.New("invalid role")
+	}
+
+	return nil
+}
+
+// ValidateInvitationCreate validates invitation data
+func (i *InvitationCreate) ValidateInvitationCreate() error {
+	i.Email = strings.ToLower(strings.TrimSpace(i.Email))
+
+	if err := ValidateEmail(i.Email); err != nil {
+		return err
+	}
+
+	if !i.Role.IsValid() {
+		return errors.New("invalid role")
+	}
+
+	return nil
+}
+
+// ToInvitation converts InvitationCreate to OrganizationInvitation
+func (i *InvitationCreate) ToInvitation(organizationID, invitedBy int, expiresAt time.Time) *OrganizationInvitation {
+	return &OrganizationInvitation{
+		OrganizationID: organizationID,
+		Email:          i.Email,
+		Role:           i.Role,
+		InvitedBy:      invitedBy,
+		Status:         InvitationStatusPending,
+		ExpiresAt:      expiresAt,
+	}
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxOwnershipTransferDocuments is the most document references an ownership transfer can carry
+const MaxOwnershipTransferDocuments = 10
+
+// OwnershipTransferStatus defines the status of an account ownership transfer
+type OwnershipTransferStatus string
+
+const (
+	OwnershipTransferStatusPending   OwnershipTransferStatus = "PENDING_APPROVAL"
+	OwnershipTransferStatusCompleted OwnershipTransferStatus = "COMPLETED"
+	OwnershipTransferStatusRejected  OwnershipTransferStatus = "REJECTED"
+)
+
+// IsValid reports whether the status is known
+func (s OwnershipTransferStatus) IsValid() bool {
+	switch s {
+	case OwnershipTransferStatusPending, OwnershipTransferStatusCompleted, OwnershipTransferStatusRejected:
+		return true
+	}
+	return false
+}
+
+// OwnershipTransferReason defines why an account changes owners
+type OwnershipTransferReason string
+
+const (
+	OwnershipTransferReasonEstate     OwnershipTransferReason = "ESTATE"
+	OwnershipTransferReasonCourtOrder OwnershipTransferReason = "COURT_ORDER"
+	OwnershipTransferReasonOther      OwnershipTransferReason = "OTHER"
+)
+
+// IsValid reports whether the reason is known
+func (r OwnershipTransferReason) IsValid() bool {
+	switch r {
+	case OwnershipTransferReasonEstate, OwnershipTransferReasonCourtOrder, OwnershipTransferReasonOther:
+		return true
+	}
+	return false
+}
+
+// OwnershipTransferAction defines a step of an ownership transfer recorded in its audit trail
+type OwnershipTransferAction string
+
+const (
+	OwnershipTransferActionRequested OwnershipTransferAction = "REQUESTED"
+	OwnershipTransferActionApproved  OwnershipTransferAction = "APPROVED"
+	OwnershipTransferActionRejected  OwnershipTransferAction = "REJECTED"
+)
+
+// OwnershipTransfer represents moving an account, with its cards and history, from one user to
+// another, e.g. to the heir of a deceased customer. One bank employee requests it and another one
+// approves it.
+type OwnershipTransfer struct {
+	ID          int                       `json:"id" db:"id"`
+	AccountID   int                       `json:"account_id" db:"account_id"`
+	FromUserID  int                       `json:"from_user_id" db:"from_user_id"`
+	ToUserID    int                       `json:"to_user_id" db:"to_user_id"`
+	Reason      OwnershipTransferReason   `json:"reason" db:"reason"`
+	Note        string                    `json:"note,omitempty" db:"note"`
+	Documents   []string                  `json:"documents" db:"documents"` // references to the supporting documents, e.g. a death certificate number
+	Status      OwnershipTransferStatus   `json:"status" db:"status"`
+	RequestedBy int                       `json:"requested_by" db:"requested_by"`
+	ReviewedBy  *int                      `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNote  string                    `json:"review_note,omitempty" db:"review_note"`
+	ReviewedAt  *time.Time                `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt   time.Time                 `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at" db:"updated_at"`
+	Events      []*OwnershipTransferEvent `json:"events,omitempty" db:"-"`
+}
+
+// OwnershipTransferEvent is the audit entry of a step of an ownership transfer. Entries are only
+// ever added.
+type OwnershipTransferEvent struct {
+	ID         int                     `json:"id" db:"id"`
+	TransferID int                     `json:"transfer_id" db:"transfer_id"`
+	AdminID    int                     `json:"admin_id" db:"admin_id"`
+	Action     OwnershipTransferAction `json:"action" db:"action"`
+	Note       string                  `json:"note,omitempty" db:"note"`
+	Details    string                  `json:"details" db:"details"` // what was done, e.g. the cards that moved
+	CreatedAt  time.Time               `json:"created_at" db:"created_at"`
+}
+
+// OwnershipTransferRequest represents a bank employee asking to transfer an account to another user
+type OwnershipTransferRequest struct {
+	ToUserID  int                     `json:"to_user_id" binding:"required"`
+	Reason    OwnershipTransferReason `json:"reason" binding:"required"`
+	Note      string                  `json:"note,omitempty"`
+	Documents []string                `json:"documents" binding:"required"`
+}
+
+// OwnershipTransferDecision represents a second bank employee approving or rejecting a transfer
+type OwnershipTransferDecision struct {
+	Note string `json:"note,omitempty"`
+}
+
+// ValidateOwnershipTransferRequest validates ownership transfer request data
+func (r *OwnershipTransferRequest) ValidateOwnershipTransferRequest() error {
+	if r.ToUserID <= 0 {
+		return errors.New("to_user_id is required")
+	}
+
+	r.Reason = OwnershipTransferReason(strings.ToUpper(string(r.Reason)))
+	if !r.Reason.IsValid() {
+		return errors.New("reason must be one of ESTATE, COURT_ORDER, OTHER")
+	}
+
+	r.Note = strings.TrimSpace(r.Note)
+	if r.Reason == OwnershipTransferReasonOther && r.Note == "" {
+		return errors.New("note is required when the reason is OTHER")
+	}
+	if len(r.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+
+	if len(r.Documents) == 0 || len(r.Documents) > MaxOwnershipTransferDocuments {
+		return errors.New("documents must list between 1 and 10 document references")
+	}
+	for i, document := range r.Documents {
+		document = strings.TrimSpace(document)
+		if document == "" || len(document) > 200 {
+			return errors.New("document references must be between 1 and 200 characters")
+		}
+		r.Documents[i] = document
+	}
+
+	return nil
+}
+
+// ValidateOwnershipTransferDecision validates ownership transfer decision data
+func (d *OwnershipTransferDecision) ValidateOwnershipTransferDecision() error {
+	d.Note = strings.TrimSpace(d.Note)
+	if len(d.Note) > 1000 {
+		return errors.New("note must be at most 1000 characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PasswordReset represents a one-time token, sent by email, that lets a user set a new password
+type PasswordReset struct {
+	ID        int        `json:"id" db:"id"`
+	UserID    int        `json:"user_id" db:"user_id"`
+	TokenHash string     `json:"-" db:"token_hash"` // SHA-256 of the token; the token itself is only in the email
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PasswordForgotRequest represents a request to email a password reset link
+type PasswordForgotRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+// PasswordResetRequest represents a request to set a new password with a reset token
+type PasswordResetRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// ValidatePasswordForgot validates password reset link request data
+func (p *PasswordForgotRequest) ValidatePasswordForgot() error {
+	p.Email = strings.TrimSpace(p.Email)
+	if p.Email == "" {
+		return errors.New("email is required")
+	}
+
+	return nil
+}
+
+// ValidatePasswordReset validates password reset data
+func (p *PasswordResetRequest) ValidatePasswordReset() error {
+	p.Token = strings.TrimSpace(p.Token)
+	if p.Token == "" {
+		return errors.New("token is required")
+	}
+
+	return ValidatePassword(p.NewPassword)
+}
@@ -12,6 +12,7 @@ type PaymentScheduleResponse struct {
 	PaymentDate    time.Time     `json:"payment_date"`
 	PrincipalAmount float64      `json:"principal_amount"`
 	InterestAmount float64       `json:"interest_amount"`
+	InsuranceAmount float64      `json:"insurance_amount,omitempty"`
 	TotalAmount    float64       `json:"total_amount"`
 	Status         PaymentStatus `json:"status"`
 	IsOverdue      bool          `json:"is_overdue"`
@@ -48,6 +49,7 @@ func (p *PaymentSchedule) ToPaymentScheduleResponse(paymentNumber int) *PaymentS
 		PaymentDate:     p.PaymentDate,
 		PrincipalAmount: p.PrincipalAmount,
 		InterestAmount:  p.InterestAmount,
+		InsuranceAmount: p.InsuranceAmount,
 		TotalAmount:     p.TotalAmount,
 		Status:          p.Status,
 		IsOverdue:       p.IsOverdue,
@@ -55,13 +57,17 @@ func (p *PaymentSchedule) ToPaymentScheduleResponse(paymentNumber int) *PaymentS
 	}
 }
 
-// CalculatePaymentScheduleSummary calculates summary statistics for a payment schedule
+// CalculatePaymentScheduleSummary calculates summary statistics for a payment schedule. Payments
+// cancelled by a restructuring are left out.
 func CalculatePaymentScheduleSummary(schedules []*PaymentSchedule) *PaymentScheduleSummary {
 	summary := &PaymentScheduleSummary{}
 	
-	summary.TotalPayments = len(schedules)
-	
 	for _, payment := range schedules {
+		if payment.Status == PaymentStatusCancelled {
+			continue
+		}
+		
+		summary.TotalPayments++
 		summary.TotalPrincipal += payment.PrincipalAmount
 		summary.TotalInterest += payment.InterestAmount
 		summary.TotalAmount += payment.TotalAmount
@@ -89,21 +95,41 @@ func CalculatePaymentScheduleSummary(schedules []*PaymentSchedule) *PaymentSched
 	return summary
 }
 
-// UpdateScheduleStatus updates the status of a payment schedule item based on the current date
-func UpdateScheduleStatus(schedule *PaymentSchedule) {
+// UpdateScheduleStatus updates the status of a payment schedule item based on the current date and
+// the date the payment is due: its payment date moved to a business day, as the start of that day
+// in the bank's time zone (see BusinessDate).
+// penaltyRate is the share of the total payment charged as a penalty (e.g. 0.1 for 10%).
+func UpdateScheduleStatus(schedule *PaymentSchedule, dueDate time.Time, penaltyRate float64) {
 	now := time.Now()
 	
 	// Check if payment is overdue
-	if schedule.Status == PaymentStatusPending && now.After(schedule.PaymentDate) {
+	if schedule.Status == PaymentStatusPending && now.After(dueDate) {
 		schedule.IsOverdue = true
 		schedule.Status = PaymentStatusOverdue
 		
 		// Calculate number of days overdue
-		daysOverdue := int(now.Sub(schedule.PaymentDate).Hours() / 24)
+		daysOverdue := int(now.Sub(dueDate).Hours() / 24)
 		
-		// Apply penalty (10% of total payment) if overdue more than 1 day
+		// Apply penalty if overdue more than 1 day
 		if daysOverdue > 1 {
-			schedule.PenaltyAmount = roundToTwoDecimal(schedule.TotalAmount * 0.1)
+			schedule.PenaltyAmount = roundToTwoDecimal(schedule.TotalAmount * penaltyRate)
 		}
 	}
-}
\ No newline at end of file
+}
+
+// DuePayment is a pending credit payment loaded together with its credit and the credit's account,
+// so the payment scheduler can collect it without further lookups
+type DuePayment struct {
+	Payment    *PaymentSchedule
+	Credit     *Credit
+	Account    *Account // ID, balance and available balance only
+	HeldAmount float64  // funds reserved for the payment by an active hold
+}
+
+// AmountDue returns the amount to debit for a payment, including the penalty of an overdue one
+func (d *DuePayment) AmountDue() float64 {
+	if d.Payment.IsOverdue {
+		return d.Payment.TotalAmount + d.Payment.PenaltyAmount
+	}
+	return d.Payment.TotalAmount
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// PaymentVerification is what the public payment endpoint tells anyone holding a transaction's
+// reference: whether the payment exists and went through. The details are only disclosed to those
+// who also have the verification secret the payer shared.
+type PaymentVerification struct {
+	Reference string          `json:"reference"`
+	Completed bool            `json:"completed"`
+	Details   *PaymentDetails `json:"details,omitempty"`
+}
+
+// PaymentDetails are the facts of a verified payment, with the accounts masked
+type PaymentDetails struct {
+	Amount          float64   `json:"amount"`
+	Currency        Currency  `json:"currency"`
+	TransactionDate time.Time `json:"transaction_date"`
+	PayerName       string    `json:"payer_name,omitempty"`
+	PayerAccount    string    `json:"payer_account,omitempty"`
+	PayeeName       string    `json:"payee_name,omitempty"`
+	PayeeAccount    string    `json:"payee_account,omitempty"`
+}
+
+// PaymentVerificationSecret is what a party to a transaction shares so a recipient can verify the
+// payment with its details
+type PaymentVerificationSecret struct {
+	Reference string `json:"reference"`
+	Secret    string `json:"secret"`
+	URL       string `json:"url"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// PayoffQuote shows what an extra payment towards the principal of a credit would save. The
+// monthly payment stays the same and the term gets shorter; nothing is changed by a quote.
+type PayoffQuote struct {
+	CreditID           int                        `json:"credit_id"`
+	ExtraPayment       float64                    `json:"extra_payment"`
+	RemainingPrincipal float64                    `json:"remaining_principal"`
+	PayoffAmount       float64                    `json:"payoff_amount"` // pays off the credit in full
+	PaidOff            bool                       `json:"paid_off"`      // the extra payment covers the remaining principal
+	MonthlyPayment     float64                    `json:"monthly_payment"`
+	CurrentPayments    int                        `json:"current_payments"`
+	NewPayments        int                        `json:"new_payments"`
+	MonthsSaved        int                        `json:"months_saved"`
+	CurrentInterest    float64                    `json:"current_interest"`
+	NewInterest        float64                    `json:"new_interest"`
+	InterestSaved      float64                    `json:"interest_saved"`
+	InsuranceSaved     float64                    `json:"insurance_saved,omitempty"`
+	CurrentEndDate     time.Time                  `json:"current_end_date"`
+	NewEndDate         *time.Time                 `json:"new_end_date,omitempty"`
+	Schedule           []*PaymentScheduleResponse `json:"schedule"`
+}
+
+// ValidateExtraPayment validates the amount of a payoff quote
+func ValidateExtraPayment(amount float64) error {
+	if amount <= 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return errors.New("extra_payment must be positive")
+	}
+	return nil
+}
+
+// PrepaymentSchedule recalculates the remaining payments of a credit after its principal was
+// reduced by a prepayment. The payments keep the credit's monthly payment, the first due on first,
+// and stop once the principal is repaid; the last one is smaller.
+func PrepaymentSchedule(credit *Credit, principal float64, first time.Time, premium float64) []*PaymentSchedule {
+	if principal <= 0 {
+		return []*PaymentSchedule{}
+	}
+
+	prepaid := *credit
+	prepaid.Amount = principal
+	prepaid.StartDate = first
+	prepaid.TermMonths = prepaymentTerm(principal, credit.InterestRate, credit.MonthlyPayment)
+
+	schedule := GeneratePaymentSchedule(&prepaid)
+	if premium > 0 {
+		ApplyInsurance(schedule, premium)
+	}
+
+	return schedule
+}
+
+// prepaymentTerm returns how many monthly payments of the given size repay the principal
+func prepaymentTerm(principal float64, annualInterestRate float64, monthlyPayment float64) int {
+	monthlyInterestRate := annualInterestRate / 12 / 100
+
+	var months float64
+	if monthlyInterestRate == 0 {
+		months = principal / monthlyPayment
+	} else {
+		months = -math.Log(1-principal*monthlyInterestRate/monthlyPayment) / math.Log(1+monthlyInterestRate)
+	}
+
+	// Rounding leaves a few kopecks that do not deserve a payment of their own
+	term := int(math.Ceil(months - 1e-6))
+	if term < 1 {
+		term = 1
+	}
+	return term
+}
@@ -0,0 +1,254 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxPayrollFileSize is the largest payroll file accepted for upload
+	MaxPayrollFileSize = 1 << 20
+	// MaxPayrollItems is the most payments a payroll file may contain
+	MaxPayrollItems = 5000
+	// maxPayrollAmount is the first amount that no longer fits the DECIMAL(15, 2) columns
+	maxPayrollAmount = 1e13
+	// maxEmployeeNameLength caps the optional employee name of a payroll line
+	maxEmployeeNameLength = 100
+	// maxPayrollTextLength caps the file name and the description of a payroll
+	maxPayrollTextLength = 255
+)
+
+// PayrollStatus defines the status of a payroll batch
+type PayrollStatus string
+
+const (
+	PayrollStatusValidated PayrollStatus = "VALIDATED" // every line is valid, the payroll can be executed
+	PayrollStatusInvalid   PayrollStatus = "INVALID"   // some lines are invalid, the file has to be fixed and uploaded again
+	PayrollStatusCompleted PayrollStatus = "COMPLETED"
+)
+
+// PayrollItemStatus defines the status of a single payment of a payroll
+type PayrollItemStatus string
+
+const (
+	PayrollItemStatusValid   PayrollItemStatus = "VALID"
+	PayrollItemStatusInvalid PayrollItemStatus = "INVALID"
+	PayrollItemStatusPaid    PayrollItemStatus = "PAID"
+	PayrollItemStatusFailed  PayrollItemStatus = "FAILED" // the account became unavailable between upload and execution
+)
+
+// Payroll represents a salary batch of an organization: one debit of its funding account pays
+// every employee listed in the uploaded file
+type Payroll struct {
+	ID                   int            `json:"id" db:"id"`
+	OrganizationID       int            `json:"organization_id" db:"organization_id"`
+	AccountID            int            `json:"account_id" db:"account_id"` // the funding account
+	Currency             Currency       `json:"currency" db:"currency"`
+	FileName             string         `json:"file_name" db:"file_name"`
+	Description          string         `json:"description,omitempty" db:"description"`
+	Status               PayrollStatus  `json:"status" db:"status"`
+	ItemCount            int            `json:"item_count" db:"item_count"`
+	TotalAmount          float64        `json:"total_amount" db:"total_amount"`
+	InvalidCount         int            `json:"invalid_count" db:"invalid_count"`
+	PaidCount            int            `json:"paid_count" db:"paid_count"`
+	PaidAmount           float64        `json:"paid_amount" db:"paid_amount"`
+	UploadedBy           int            `json:"uploaded_by" db:"uploaded_by"`
+	ExecutedBy           *int           `json:"executed_by,omitempty" db:"executed_by"`
+	FundingTransactionID *int           `json:"funding_transaction_id,omitempty" db:"funding_transaction_id"`
+	ExecutedAt           *time.Time     `json:"executed_at,omitempty" db:"executed_at"`
+	CreatedAt            time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at" db:"updated_at"`
+	Items                []*PayrollItem `json:"items,omitempty" db:"-"`
+}
+
+// PayrollItem represents one line of a payroll file: a salary payment to an employee's account
+type PayrollItem struct {
+	ID            int               `json:"id" db:"id"`
+	PayrollID     int               `json:"payroll_id" db:"payroll_id"`
+	LineNumber    int               `json:"line_number" db:"line_number"`
+	AccountNumber string            `json:"account_number" db:"account_number"`
+	EmployeeName  string            `json:"employee_name,omitempty" db:"employee_name"`
+	Amount        float64           `json:"amount" db:"amount"`
+	AccountID     *int              `json:"account_id,omitempty" db:"account_id"`
+	Status        PayrollItemStatus `json:"status" db:"status"`
+	Error         string            `json:"error,omitempty" db:"error"`
+	TransactionID *int              `json:"transaction_id,omitempty" db:"transaction_id"`
+}
+
+// Reject marks the item as invalid for a reason
+func (i *PayrollItem) Reject(reason string) {
+	i.Status = PayrollItemStatusInvalid
+	i.Error = reason
+}
+
+// ValidatePayrollUpload validates the funding account and the details uploaded with a payroll file
+func (p *Payroll) ValidatePayrollUpload() error {
+	if p.AccountID <= 0 {
+		return errors.New("account_id is required")
+	}
+
+	p.FileName = strings.TrimSpace(p.FileName)
+	if utf8.RuneCountInString(p.FileName) > maxPayrollTextLength {
+		return errors.New("file name must be at most 255 characters")
+	}
+
+	p.Description = strings.TrimSpace(p.Description)
+	if utf8.RuneCountInString(p.Description) > maxPayrollTextLength {
+		return errors.New("description must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// ParsePayrollFile reads the lines of a payroll CSV file: the employee's account number, the amount
+// and optionally the employee's name. Fields are separated by commas or, as spreadsheets export them
+// in Russian locales, by semicolons, in which case amounts may use a decimal comma. A header line
+// is skipped. Lines that cannot be paid are returned as invalid items rather than failing the file,
+// so that the uploader sees every problem at once.
+func ParsePayrollFile(content []byte) ([]*PayrollItem, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if line, _, _ := bytes.Cut(content, []byte("\n")); bytes.Contains(line, []byte(";")) {
+		reader.Comma = ';'
+	}
+
+	items := []*PayrollItem{}
+	seen := make(map[string]bool)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("payroll file is not a valid CSV file")
+		}
+
+		// A header names the columns instead of giving an amount
+		if first && len(record) >= 2 && !strings.ContainsAny(record[1], "0123456789") {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		if items, err = appendPayrollItem(items, seen, record, line); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("payroll file has no payments")
+	}
+
+	return items, nil
+}
+
+// PayrollItemsFromRecords validates payroll lines read from another file format, such as the
+// credit transfers of a pain.001 message, the same way as the lines of a CSV file. Each record holds
+// the account number, the amount and the name; lines are numbered from 1 in record order.
+func PayrollItemsFromRecords(records [][]string) ([]*PayrollItem, error) {
+	items := []*PayrollItem{}
+	seen := make(map[string]bool)
+	for i, record := range records {
+		var err error
+		if items, err = appendPayrollItem(items, seen, record, i+1); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, errors.New("payroll file has no payments")
+	}
+
+	return items, nil
+}
+
+// appendPayrollItem validates a payroll line and adds it to the items, rejecting an account that
+// was already listed
+func appendPayrollItem(items []*PayrollItem, seen map[string]bool, record []string, lineNumber int) ([]*PayrollItem, error) {
+	if len(items) == MaxPayrollItems {
+		return nil, errors.New("payroll file has more than 5000 payments")
+	}
+
+	item := parsePayrollLine(record)
+	item.LineNumber = lineNumber
+	if item.Status == PayrollItemStatusValid {
+		if seen[item.AccountNumber] {
+			item.Reject("account is listed more than once")
+		}
+		seen[item.AccountNumber] = true
+	}
+
+	return append(items, item), nil
+}
+
+// parsePayrollLine validates the fields of one payroll line
+func parsePayrollLine(record []string) *PayrollItem {
+	item := &PayrollItem{Status: PayrollItemStatusValid}
+	if len(record) < 2 || len(record) > 3 {
+		item.Reject("expected an account number, an amount and an optional name")
+		return item
+	}
+
+	item.AccountNumber = strings.ReplaceAll(strings.TrimSpace(record[0]), " ", "")
+	if len(record) == 3 {
+		item.EmployeeName = strings.TrimSpace(record[2])
+	}
+
+	if item.AccountNumber == "" || strings.Trim(item.AccountNumber, "0123456789") != "" {
+		item.Reject("account number must contain only digits")
+		return item
+	}
+
+	amount, err := parsePayrollAmount(record[1])
+	if err != nil {
+		item.Reject(err.Error())
+		return item
+	}
+	item.Amount = amount
+
+	if utf8.RuneCountInString(item.EmployeeName) > maxEmployeeNameLength {
+		item.Reject("name must be at most 100 characters")
+	}
+
+	return item
+}
+
+// parsePayrollAmount parses a positive amount with at most two decimals and a decimal point or comma
+func parsePayrollAmount(value string) (float64, error) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), " ", "")
+	amount, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil || !(amount > 0) || amount >= maxPayrollAmount || math.Abs(amount*100-math.Round(amount*100)) > 1e-6 {
+		return 0, errors.New("amount must be a positive number with at most 2 decimal places")
+	}
+
+	return amount, nil
+}
+
+// SummarizeItems sets the item count, the total amount and the status of a payroll from its items
+func (p *Payroll) SummarizeItems() {
+	p.ItemCount = len(p.Items)
+	p.TotalAmount = 0
+	p.InvalidCount = 0
+	for _, item := range p.Items {
+		if item.Status == PayrollItemStatusInvalid {
+			p.InvalidCount++
+			continue
+		}
+		p.TotalAmount += item.Amount
+	}
+	p.TotalAmount = math.Round(p.TotalAmount*100) / 100
+
+	p.Status = PayrollStatusValidated
+	if p.InvalidCount > 0 {
+		p.Status = PayrollStatusInvalid
+	}
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// RateType defines the kind of a central bank rate
+type RateType string
+
+const (
+	RateTypeKey RateType = "KEY" // key interest rate, in percent
+	RateTypeFX  RateType = "FX"  // exchange rate, in RUB for one unit of the currency
+)
+
+// ExchangeRateCurrencies are the currencies whose exchange rates are tracked
+var ExchangeRateCurrencies = []Currency{CurrencyUSD, CurrencyEUR}
+
+// Rate is a central bank rate as fetched at a point in time
+type Rate struct {
+	ID        int       `json:"id" db:"id"`
+	Type      RateType  `json:"type" db:"rate_type"`
+	Currency  Currency  `json:"currency,omitempty" db:"currency"` // empty for the key rate
+	Rate      float64   `json:"rate" db:"rate"`
+	FetchedAt time.Time `json:"fetched_at" db:"fetched_at"`
+}
+
+// RateHistoryQuery selects the history of one rate over a period
+type RateHistoryQuery struct {
+	Type     RateType
+	Currency Currency
+	Period   ReportPeriod
+}
+
+// ParseRateHistoryQuery parses the currency, from and to query parameters: a currency selects its
+// exchange rate, no currency the key rate. The period is parsed like ParseReportPeriod.
+func ParseRateHistoryQuery(currency, from, to string, now time.Time) (*RateHistoryQuery, error) {
+	query := &RateHistoryQuery{Type: RateTypeKey}
+
+	if currency != "" {
+		query.Type = RateTypeFX
+		query.Currency = Currency(strings.ToUpper(currency))
+		if !isExchangeRateCurrency(query.Currency) {
+			return nil, errors.New("currency must be USD or EUR")
+		}
+	}
+
+	period, err := ParseReportPeriod(from, to, now)
+	if err != nil {
+		return nil, err
+	}
+	query.Period = period
+
+	return query, nil
+}
+
+// isExchangeRateCurrency reports whether the exchange rate of a currency is tracked
+func isExchangeRateCurrency(currency Currency) bool {
+	for _, c := range ExchangeRateCurrencies {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyRateSource defines where the key rate in effect comes from
+type KeyRateSource string
+
+const (
+	KeyRateSourceCBR      KeyRateSource = "CBR"      // fetched from the central bank
+	KeyRateSourceOverride KeyRateSource = "OVERRIDE" // pinned by an admin
+)
+
+// KeyRate is the key rate the bank uses as its base rate
+type KeyRate struct {
+	Rate     float64          `json:"key_rate"`
+	Source   KeyRateSource    `json:"source"`
+	Override *KeyRateOverride `json:"override,omitempty"` // the override in effect when Source is OVERRIDE
+}
+
+// KeyRateOverride pins the base rate for the days from EffectiveFrom to EffectiveTo, when the
+// central bank's key rate is wrong or cannot be fetched
+type KeyRateOverride struct {
+	ID            int        `json:"id" db:"id"`
+	Rate          float64    `json:"rate" db:"rate"`
+	EffectiveFrom time.Time  `json:"effective_from" db:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty" db:"effective_to"` // last day in effect, nil until revoked
+	Reason        string     `json:"reason" db:"reason"`
+	CreatedBy     int        `json:"created_by" db:"created_by"`
+	RevokedBy     *int       `json:"revoked_by,omitempty" db:"revoked_by"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// KeyRateOverrideRequest represents an admin pinning the base rate
+type KeyRateOverrideRequest struct {
+	Rate          float64 `json:"rate" binding:"required"`
+	EffectiveFrom string  `json:"effective_from" binding:"required"` // YYYY-MM-DD
+	EffectiveTo   string  `json:"effective_to,omitempty"`            // YYYY-MM-DD, inclusive; empty until revoked
+	Reason        string  `json:"reason" binding:"required"`
+}
+
+// ValidateKeyRateOverrideRequest validates key rate override data and converts it to an override
+// whose days start in a time zone
+func (r *KeyRateOverrideRequest) ValidateKeyRateOverrideRequest(loc *time.Location) (*KeyRateOverride, error) {
+	if r.Rate <= 0 || r.Rate > 100 {
+		return nil, errors.New("rate must be above 0 and at most 100")
+	}
+
+	from, err := time.ParseInLocation("2006-01-02", r.EffectiveFrom, loc)
+	if err != nil {
+		return nil, errors.New("effective_from must be in YYYY-MM-DD format")
+	}
+
+	override := &KeyRateOverride{Rate: r.Rate, EffectiveFrom: from}
+
+	if r.EffectiveTo != "" {
+		to, err := time.ParseInLocation("2006-01-02", r.EffectiveTo, loc)
+		if err != nil {
+			return nil, errors.New("effective_to must be in YYYY-MM-DD format")
+		}
+		if to.Before(from) {
+			return nil, errors.New("effective_to cannot be before effective_from")
+		}
+		override.EffectiveTo = &to
+	}
+
+	override.Reason = strings.TrimSpace(r.Reason)
+	if override.Reason == "" || len(override.Reason) > 500 {
+		return nil, errors.New("reason is required and must be at most 500 characters")
+	}
+
+	return override, nil
+}
+
+// InEffect reports whether the override applies on the day of a time
+func (o *KeyRateOverride) InEffect(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, o.EffectiveFrom.Location())
+	return o.RevokedAt == nil && !day.Before(o.EffectiveFrom) && (o.EffectiveTo == nil || !day.After(*o.EffectiveTo))
+}
+
+// Overlaps reports whether two overrides are in effect on a common day
+func (o *KeyRateOverride) Overlaps(other *KeyRateOverride) bool {
+	return (o.EffectiveTo == nil || !o.EffectiveTo.Before(other.EffectiveFrom)) &&
+		(other.EffectiveTo == nil || !other.EffectiveTo.Before(o.EffectiveFrom))
+}
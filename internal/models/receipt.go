@@ -0,0 +1,27 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ReceiptVerification is what the public verification link of a receipt shows: the facts printed
+// on the receipt that let anyone check it against the bank's records, with the accounts masked
+type ReceiptVerification struct {
+	TransactionID   int               `json:"transaction_id"`
+	TransactionType TransactionType   `json:"transaction_type"`
+	Amount          float64           `json:"amount"`
+	Currency        Currency          `json:"currency"`
+	Status          TransactionStatus `json:"status"` // as of now, which may differ from the receipt
+	TransactionDate time.Time         `json:"transaction_date"`
+	PayerAccount    string            `json:"payer_account,omitempty"`
+	PayeeAccount    string            `json:"payee_account,omitempty"`
+}
+
+// MaskAccountNumber keeps only the last four digits of an account number
+func MaskAccountNumber(number string) string {
+	if len(number) <= 4 {
+		return number
+	}
+	return strings.Repeat("*", 4) + number[len(number)-4:]
+}
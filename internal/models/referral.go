@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// ReferralStatus defines the status of a referral
+type ReferralStatus string
+
+const (
+	ReferralStatusPending   ReferralStatus = "PENDING"   // waiting for the referee's first qualifying deposit
+	ReferralStatusQualified ReferralStatus = "QUALIFIED" // qualified, bonuses not paid out yet
+	ReferralStatusRewarded  ReferralStatus = "REWARDED"
+	ReferralStatusExpired   ReferralStatus = "EXPIRED"
+)
+
+// Referral represents a user who registered with another user's referral code
+type Referral struct {
+	ID                      int            `json:"id" db:"id"`
+	ReferrerID              int            `json:"-" db:"referrer_id"`
+	RefereeID               int            `json:"-" db:"referee_id"`
+	RefereeUsername         string         `json:"referee_username" db:"referee_username"`
+	Code                    string         `json:"code" db:"code"`
+	Status                  ReferralStatus `json:"status" db:"status"`
+	QualifyingTransactionID *int           `json:"-" db:"qualifying_transaction_id"`
+	ReferrerBonus           float64        `json:"referrer_bonus" db:"referrer_bonus"`
+	RefereeBonus            float64        `json:"referee_bonus" db:"referee_bonus"`
+	ReferrerBonusTxID       *int           `json:"referrer_bonus_transaction_id,omitempty" db:"referrer_bonus_transaction_id"`
+	RefereeBonusTxID        *int           `json:"-" db:"referee_bonus_transaction_id"`
+	QualifiedAt             *time.Time     `json:"qualified_at,omitempty" db:"qualified_at"`
+	RewardedAt              *time.Time     `json:"rewarded_at,omitempty" db:"rewarded_at"`
+	CreatedAt               time.Time      `json:"created_at" db:"created_at"`
+}
+
+// ReferralSummary represents a user's referral code and what it has earned so far
+type ReferralSummary struct {
+	Code          string  `json:"code"`
+	Invited       int     `json:"invited"`
+	Pending       int     `json:"pending"`
+	Rewarded      int     `json:"rewarded"`
+	Earnings      float64 `json:"earnings"`
+	ReferrerBonus float64 `json:"referrer_bonus"` // current bonus per qualified referral
+	RefereeBonus  float64 `json:"referee_bonus"`
+	MinDeposit    float64 `json:"min_deposit"` // first deposit the referee needs to qualify
+	QualifyDays   int     `json:"qualify_days"`
+}
+
+// ToReferralSummary builds a referrer's summary from its referrals
+func ToReferralSummary(code string, referrals []*Referral) *ReferralSummary {
+	summary := &ReferralSummary{Code: code, Invited: len(referrals)}
+	for _, referral := range referrals {
+		switch referral.Status {
+		case ReferralStatusPending, ReferralStatusQualified:
+			summary.Pending++
+		case ReferralStatusRewarded:
+			summary.Rewarded++
+			summary.Earnings += referral.ReferrerBonus
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,90 @@
+package models
+
+import "time"
+
+// DefaultReportDays is the period of a dashboard report requested without dates
+const DefaultReportDays = 30
+
+// DailyTransactionVolume is the number and value of completed transactions in a currency on a day
+type DailyTransactionVolume struct {
+	Date     time.Time `json:"date"`
+	Currency Currency  `json:"currency"`
+	Count    int       `json:"count"`
+	Value    float64   `json:"value"`
+}
+
+// DailyNewUsers is the number of users registered on a day
+type DailyNewUsers struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+}
+
+// DailyCreditsIssued is the number and principal of credits issued in a currency on a day
+type DailyCreditsIssued struct {
+	Date     time.Time `json:"date"`
+	Currency Currency  `json:"currency"`
+	Count    int       `json:"count"`
+	Amount   float64   `json:"amount"`
+}
+
+// CreditPortfolio summarizes the outstanding credits in a currency. Overdue amounts are the unpaid
+// installments and penalties past their date; non-performing credits have an installment overdue
+// for longer than the configured number of days.
+type CreditPortfolio struct {
+	Currency               Currency `json:"currency"`
+	ActiveCredits          int      `json:"active_credits"`
+	OutstandingPrincipal   float64  `json:"outstanding_principal"`
+	OverdueCredits         int      `json:"overdue_credits"`
+	OverdueAmount          float64  `json:"overdue_amount"`
+	NonPerformingCredits   int      `json:"non_performing_credits"`
+	NonPerformingPrincipal float64  `json:"non_performing_principal"`
+	NPLRatio               float64  `json:"npl_ratio"` // non-performing principal / outstanding principal
+}
+
+// DepositBalance is the total balance of the active non-credit accounts of a type and currency
+type DepositBalance struct {
+	Currency    Currency    `json:"currency"`
+	AccountType AccountType `json:"account_type"`
+	Accounts    int         `json:"accounts"`
+	Balance     float64     `json:"balance"`
+}
+
+// Dashboard combines all dashboard reports for a period
+type Dashboard struct {
+	Period            ReportPeriod              `json:"period"`
+	TransactionVolume []*DailyTransactionVolume `json:"transaction_volume"`
+	NewUsers          []*DailyNewUsers          `json:"new_users"`
+	CreditsIssued     []*DailyCreditsIssued     `json:"credits_issued"`
+	CreditPortfolio   []*CreditPortfolio        `json:"credit_portfolio"`
+	DepositBalances   []*DepositBalance         `json:"deposit_balances"`
+}
+
+// ReportPeriod is the period [From, To) a dashboard report covers
+type ReportPeriod struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ParseReportPeriod parses an inclusive period of YYYY-MM-DD dates in the location of now like
+// ParseAccountingPeriod; without dates it returns the last DefaultReportDays days including today
+func ParseReportPeriod(from, to string, now time.Time) (ReportPeriod, error) {
+	if from == "" && to == "" {
+		end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+		return ReportPeriod{From: end.AddDate(0, 0, -DefaultReportDays), To: end}, nil
+	}
+
+	start, end, err := ParseAccountingPeriod(from, to, now.Location())
+	if err != nil {
+		return ReportPeriod{}, err
+	}
+
+	return ReportPeriod{From: start, To: end}, nil
+}
+
+// SetNPLRatio computes the share of the outstanding principal that is non-performing
+func (p *CreditPortfolio) SetNPLRatio() {
+	p.NPLRatio = 0
+	if p.OutstandingPrincipal > 0 {
+		p.NPLRatio = p.NonPerformingPrincipal / p.OutstandingPrincipal
+	}
+}
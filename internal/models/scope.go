@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+
+	"banking-service/pkg/i18n"
+)
+
+// Scope is a permission a limited token grants. Tokens from login carry no scopes and may use every
+// endpoint; a limited token may only use the endpoints that require one of its scopes.
+type Scope string
+
+const (
+	ScopeAccountsRead   Scope = "accounts:read"   // view accounts, transactions, statements and analytics
+	ScopeTransfersWrite Scope = "transfers:write" // move money: transfers, withdrawals and payments
+	ScopeCardsManage    Scope = "cards:manage"    // issue cards, view them and set their PINs
+)
+
+// Scopes lists every scope a limited token may be issued with
+var Scopes = []Scope{ScopeAccountsRead, ScopeTransfersWrite, ScopeCardsManage}
+
+// ScopedTokenRequest represents a request for a limited token bound to the current session
+type ScopedTokenRequest struct {
+	Scopes    []Scope `json:"scopes" binding:"required"`
+	ExpiresIn int     `json:"expires_in,omitempty"` // in seconds; the token never outlives the session
+}
+
+// Validate checks that at least one scope is requested, each of them known and listed once
+func (r *ScopedTokenRequest) Validate() error {
+	if len(r.Scopes) == 0 {
+		return errors.New("at least one scope is required")
+	}
+
+	seen := make(map[Scope]bool, len(r.Scopes))
+	for _, scope := range r.Scopes {
+		if !scope.Valid() {
+			return i18n.Errorf("unknown_scope", string(scope))
+		}
+		if seen[scope] {
+			return i18n.Errorf("scope_is_listed_twice", string(scope))
+		}
+		seen[scope] = true
+	}
+
+	if r.ExpiresIn < 0 {
+		return errors.New("expires_in cannot be negative")
+	}
+
+	return nil
+}
+
+// Valid reports whether the scope is one tokens may be issued with
+func (s Scope) Valid() bool {
+	for _, scope := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"net"
+	"time"
+)
+
+// Session represents a login of a user on a device. Every successful login creates
+// a session; the session ID is embedded in the JWT ("sid" claim) so it can be revoked.
+type Session struct {
+	ID        int        `json:"id" db:"id"`
+	UserID    int        `json:"user_id" db:"user_id"`
+	SessionID string     `json:"-" db:"session_id"`
+	IPAddress string     `json:"ip_address" db:"ip_address"`
+	UserAgent string     `json:"user_agent" db:"user_agent"`
+	Location  string     `json:"location" db:"location"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	Current   bool       `json:"current" db:"-"`
+}
+
+// ClientInfo describes the client a request came from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+	Country   string // country code reported by a proxy/CDN, if any
+}
+
+// IsActive checks if the session can still be used
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// GuessLocation makes a best-effort guess of where the client is located.
+// Without a GeoIP database it relies on the country header set by a proxy/CDN.
+func (c ClientInfo) GuessLocation() string {
+	if c.Country != "" {
+		return c.Country
+	}
+
+	ip := net.ParseIP(c.IPAddress)
+	if ip != nil && (ip.IsLoopback() || ip.IsPrivate()) {
+		return "local network"
+	}
+
+	return "unknown"
+}
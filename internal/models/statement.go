@@ -0,0 +1,54 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Statement is the monthly statement of an account. Once it is issued, the transactions of its
+// period are locked and corrections are posted as adjustments in the current period.
+type Statement struct {
+	ID               int            `json:"id" db:"id"`
+	AccountID        int            `json:"account_id" db:"account_id"`
+	PeriodStart      time.Time      `json:"period_start" db:"period_start"`
+	PeriodEnd        time.Time      `json:"period_end" db:"period_end"` // exclusive
+	OpeningBalance   float64        `json:"opening_balance" db:"opening_balance"`
+	TotalCredits     float64        `json:"total_credits" db:"total_credits"`
+	TotalDebits      float64        `json:"total_debits" db:"total_debits"`
+	ClosingBalance   float64        `json:"closing_balance" db:"closing_balance"`
+	TransactionCount int            `json:"transaction_count" db:"transaction_count"`
+	Transactions     []*Transaction `json:"transactions,omitempty" db:"-"`
+	IssuedAt         time.Time      `json:"issued_at" db:"issued_at"`
+	RegeneratedAt    *time.Time     `json:"regenerated_at,omitempty" db:"regenerated_at"` // last rebuilt from the transactions of its period
+	DetailsURL       string         `json:"details_url,omitempty" db:"-"`
+	DownloadURL      string         `json:"download_url,omitempty" db:"-"` // the statement as a camt.053 file
+}
+
+// StatementRegenerateRequest represents a request to rebuild the statement of a past month
+type StatementRegenerateRequest struct {
+	Period string `json:"period" binding:"required"` // YYYY-MM
+}
+
+// ValidateStatementRegenerate checks that the period is a month that has ended and returns its
+// start and end in the location of now
+func (r *StatementRegenerateRequest) ValidateStatementRegenerate(now time.Time) (time.Time, time.Time, error) {
+	month, err := time.ParseInLocation("2006-01", r.Period, now.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("period must be in YYYY-MM format")
+	}
+
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 1, 0)
+	if _, current := StatementMonthBounds(now); to.After(current) {
+		return time.Time{}, time.Time{}, errors.New("period must be a month that has ended")
+	}
+
+	return from, to, nil
+}
+
+// StatementMonthBounds returns the start of the month before now and the start of the current month
+// in the location of now
+func StatementMonthBounds(now time.Time) (time.Time, time.Time) {
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return to.AddDate(0, -1, 0), to
+}
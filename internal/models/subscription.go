@@ -0,0 +1,138 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// BillingInterval defines how often a subscription plan is billed
+type BillingInterval string
+
+const (
+	BillingIntervalWeek  BillingInterval = "WEEK"
+	BillingIntervalMonth BillingInterval = "MONTH"
+	BillingIntervalYear  BillingInterval = "YEAR"
+)
+
+// Next returns the start of the billing period following the one starting at t
+func (i BillingInterval) Next(t time.Time) time.Time {
+	switch i {
+	case BillingIntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case BillingIntervalYear:
+		return t.AddDate(1, 0, 0)
+	}
+	return t.AddDate(0, 1, 0)
+}
+
+// MonthlyAmount converts an amount billed every interval to its monthly equivalent
+func (i BillingInterval) MonthlyAmount(amount float64) float64 {
+	switch i {
+	case BillingIntervalWeek:
+		return amount * 52 / 12
+	case BillingIntervalYear:
+		return amount / 12
+	}
+	return amount
+}
+
+// SubscriptionStatus defines the status of a subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive    SubscriptionStatus = "ACTIVE"
+	SubscriptionStatusPastDue   SubscriptionStatus = "PAST_DUE" // the last charge failed and is retried
+	SubscriptionStatusCancelled SubscriptionStatus = "CANCELLED"
+)
+
+// SubscriptionPlan represents a price a merchant bills its subscribers on a cadence
+type SubscriptionPlan struct {
+	ID         int             `json:"id" db:"id"`
+	MerchantID int             `json:"merchant_id" db:"merchant_id"`
+	Name       string          `json:"name" db:"name"`
+	Amount     float64         `json:"amount" db:"amount"`
+	Currency   Currency        `json:"currency" db:"currency"` // the currency of the merchant's settlement account
+	Interval   BillingInterval `json:"interval" db:"billing_interval"`
+	IsActive   bool            `json:"is_active" db:"is_active"` // inactive plans take no new subscribers
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Subscription represents a customer subscribed to a merchant's plan. Subscribing gives the
+// merchant a mandate to charge the customer's account: every period an invoice is issued and paid
+// from that account automatically. Failed charges are retried before the subscription is cancelled.
+type Subscription struct {
+	ID               int                `json:"id" db:"id"`
+	PlanID           int                `json:"plan_id" db:"plan_id"`
+	PlanName         string             `json:"plan_name" db:"plan_name"`
+	MerchantID       int                `json:"merchant_id" db:"merchant_id"`
+	UserID           int                `json:"user_id" db:"user_id"`
+	AccountID        int                `json:"account_id" db:"account_id"` // the account the mandate allows to charge
+	Amount           float64            `json:"amount" db:"amount"`
+	Currency         Currency           `json:"currency" db:"currency"`
+	Interval         BillingInterval    `json:"interval" db:"billing_interval"`
+	Status           SubscriptionStatus `json:"status" db:"status"`
+	NextBillingAt    time.Time          `json:"next_billing_at" db:"next_billing_at"` // the start of the next period
+	FailedAttempts   int                `json:"failed_attempts" db:"failed_attempts"`
+	NextRetryAt      *time.Time         `json:"next_retry_at,omitempty" db:"next_retry_at"`
+	LastPaymentError string             `json:"last_payment_error,omitempty" db:"last_payment_error"`
+	CancelReason     string             `json:"cancel_reason,omitempty" db:"cancel_reason"`
+	CancelledAt      *time.Time         `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt        time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// SubscriptionPlanCreate represents data for creating a subscription plan
+type SubscriptionPlanCreate struct {
+	Name     string          `json:"name" binding:"required"`
+	Amount   float64         `json:"amount" binding:"required"`
+	Interval BillingInterval `json:"interval" binding:"required"`
+}
+
+// SubscriptionCreate represents a customer subscribing to a plan
+type SubscriptionCreate struct {
+	PlanID    int `json:"plan_id" binding:"required"`
+	AccountID int `json:"account_id" binding:"required"` // the account to charge
+}
+
+// SubscriptionReport summarizes the subscriptions of a merchant
+type SubscriptionReport struct {
+	ActiveSubscriptions  int                 `json:"active_subscriptions"` // active and past due
+	PastDueSubscriptions int                 `json:"past_due_subscriptions"`
+	NewLast30Days        int                 `json:"new_last_30_days"`
+	CancelledLast30Days  int                 `json:"cancelled_last_30_days"`
+	ChurnRate            float64             `json:"churn_rate"` // share of the subscriptions active 30 days ago cancelled since
+	MRR                  []*RecurringRevenue `json:"mrr"`
+}
+
+// RecurringRevenue is the monthly recurring revenue in a currency
+type RecurringRevenue struct {
+	Currency Currency `json:"currency"`
+	Amount   float64  `json:"amount"`
+}
+
+// ValidateSubscriptionPlanCreate validates subscription plan data
+func (p *SubscriptionPlanCreate) ValidateSubscriptionPlanCreate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(p.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+
+	if !(p.Amount > 0) || p.Amount >= maxInvoiceAmount || math.Abs(p.Amount*100-math.Round(p.Amount*100)) > 1e-6 {
+		return errors.New("amount must be a positive number with at most 2 decimal places")
+	}
+
+	p.Interval = BillingInterval(strings.ToUpper(string(p.Interval)))
+	switch p.Interval {
+	case BillingIntervalWeek, BillingIntervalMonth, BillingIntervalYear:
+		return nil
+	}
+
+	return errors.New("interval must be WEEK, MONTH or YEAR")
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// TaxInterestLine is the interest credited to one account during the tax year
+type TaxInterestLine struct {
+	AccountNumber string      `json:"account_number"`
+	AccountType   AccountType `json:"account_type"`
+	Currency      Currency    `json:"currency"`
+	Amount        float64     `json:"amount"`
+}
+
+// TaxLoanLine is the interest and penalties paid on one credit during the tax year
+type TaxLoanLine struct {
+	CreditID      int     `json:"credit_id"`
+	InterestPaid  float64 `json:"interest_paid"`
+	PenaltiesPaid float64 `json:"penalties_paid"`
+}
+
+// TaxDocument represents a user's yearly summary of tax-relevant interest
+type TaxDocument struct {
+	ID                int               `json:"id" db:"id"`
+	UserID            int               `json:"user_id" db:"user_id"`
+	Year              int               `json:"year" db:"year"`
+	InterestEarned    float64           `json:"interest_earned" db:"interest_earned"` // on RUB accounts; other currencies are listed per account
+	LoanInterestPaid  float64           `json:"loan_interest_paid" db:"loan_interest_paid"`
+	LoanPenaltiesPaid float64           `json:"loan_penalties_paid" db:"loan_penalties_paid"`
+	InterestLines     []TaxInterestLine `json:"interest_lines" db:"-"`
+	LoanLines         []TaxLoanLine     `json:"loan_lines" db:"-"`
+	GeneratedAt       time.Time         `json:"generated_at" db:"generated_at"`
+	EmailedAt         *time.Time        `json:"emailed_at,omitempty" db:"emailed_at"`
+}
+
+// ValidateTaxYear checks that a tax document can be issued for the year; only completed years qualify
+func ValidateTaxYear(year int, now time.Time) error {
+	if year < 2000 || year >= now.Year() {
+		return i18n.Errorf("year_must_be_between_2000_and", strconv.Itoa(now.Year()-1))
+	}
+
+	return nil
+}
+
+// TaxYearBounds returns the start of the tax year and the start of the following year in a time zone
+func TaxYearBounds(year int, loc *time.Location) (time.Time, time.Time) {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	return from, from.AddDate(1, 0, 0)
+}
+
+// NewTaxDocument builds a tax document from its lines and computes the totals
+func NewTaxDocument(userID, year int, interestLines []TaxInterestLine, loanLines []TaxLoanLine) *TaxDocument {
+	doc := &TaxDocument{
+		UserID:        userID,
+		Year:          year,
+		InterestLines: interestLines,
+		LoanLines:     loanLines,
+	}
+
+	for _, line := range interestLines {
+		if line.Currency == CurrencyRUB {
+			doc.InterestEarned += line.Amount
+		}
+	}
+
+	for _, line := range loanLines {
+		doc.LoanInterestPaid += line.InterestPaid
+		doc.LoanPenaltiesPaid += line.PenaltiesPaid
+	}
+
+	return doc
+}
+
+// FileName returns the name the rendered document is downloaded as
+func (d *TaxDocument) FileName() string {
+	return fmt.Sprintf("tax-document-%d.txt", d.Year)
+}
+
+// Render renders the document as a plain-text certificate for the user's records
+func (d *TaxDocument) Render(user *User) ([]byte, error) {
+	if user == nil || user.ID != d.UserID {
+		return nil, errors.New("tax document belongs to another user")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "INTEREST CERTIFICATE FOR %d\n", d.Year)
+	fmt.Fprintf(&b, "Customer: %s %s (%s)\n", user.FirstName, user.LastName, user.Username)
+	fmt.Fprintf(&b, "Period: %d-01-01 - %d-12-31\n", d.Year, d.Year)
+	fmt.Fprintf(&b, "Generated: %s\n\n", d.GeneratedAt.Format("2006-01-02"))
+
+	b.WriteString("1. Interest earned on savings and deposits\n")
+	if len(d.InterestLines) == 0 {
+		b.WriteString("   none\n")
+	}
+	for _, line := range d.InterestLines {
+		fmt.Fprintf(&b, "   %-24s %-10s %15.2f %s\n", line.AccountNumber, line.AccountType, line.Amount, line.Currency)
+	}
+	fmt.Fprintf(&b, "   Total on RUB accounts %30.2f RUB\n\n", d.InterestEarned)
+
+	b.WriteString("2. Interest paid on loans\n")
+	if len(d.LoanLines) == 0 {
+		b.WriteString("   none\n")
+	}
+	for _, line := range d.LoanLines {
+		fmt.Fprintf(&b, "   Credit #%-15d interest %15.2f RUB, penalties %.2f RUB\n",
+			line.CreditID, line.InterestPaid, line.PenaltiesPaid)
+	}
+	fmt.Fprintf(&b, "   Total interest %37.2f RUB\n", d.LoanInterestPaid)
+	fmt.Fprintf(&b, "   Total penalties %36.2f RUB\n", d.LoanPenaltiesPaid)
+
+	return []byte(b.String()), nil
+}
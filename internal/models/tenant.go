@@ -0,0 +1,4 @@
+package models
+
+// DefaultTenant is the tenant of single-brand deployments and of data created before tenants existed
+const DefaultTenant = "default"
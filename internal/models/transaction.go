@@ -9,12 +9,18 @@ import (
 type TransactionType string
 
 const (
-	TransactionTypeDeposit    TransactionType = "DEPOSIT"
-	TransactionTypeWithdrawal TransactionType = "WITHDRAWAL"
-	TransactionTypeTransfer   TransactionType = "TRANSFER"
-	TransactionTypePayment    TransactionType = "PAYMENT"
-	TransactionTypeFee        TransactionType = "FEE"
-	TransactionTypeInterest   TransactionType = "INTEREST"
+	TransactionTypeDeposit       TransactionType = "DEPOSIT"
+	TransactionTypeWithdrawal    TransactionType = "WITHDRAWAL"
+	TransactionTypeTransfer      TransactionType = "TRANSFER"
+	TransactionTypePayment       TransactionType = "PAYMENT"
+	TransactionTypeFee           TransactionType = "FEE"
+	TransactionTypeInterest      TransactionType = "INTEREST"
+	TransactionTypeChargeback    TransactionType = "CHARGEBACK"
+	TransactionTypeBonus         TransactionType = "BONUS"
+	TransactionTypeAdjustment    TransactionType = "ADJUSTMENT"    // corrects a transaction of a period that already has a statement
+	TransactionTypePayroll       TransactionType = "PAYROLL"       // funds a payroll or pays out one of its salaries
+	TransactionTypeEscrow        TransactionType = "ESCROW"        // pays into escrow or settles it to one of the parties
+	TransactionTypeInternational TransactionType = "INTERNATIONAL" // pays out an international transfer through a correspondent bank
 )
 
 // TransactionStatus defines the status of transaction
@@ -27,6 +33,24 @@ const (
 	TransactionStatusCancelled TransactionStatus = "CANCELLED"
 )
 
+// AffectsBalance reports whether a transaction in the status counts towards account balances
+func (s TransactionStatus) AffectsBalance() bool {
+	return s != TransactionStatusFailed && s != TransactionStatusCancelled
+}
+
+// DeclineReason defines why a FAILED transaction was declined
+type DeclineReason string
+
+const (
+	DeclineReasonInsufficientFunds DeclineReason = "INSUFFICIENT_FUNDS"
+	DeclineReasonLimitExceeded     DeclineReason = "LIMIT_EXCEEDED"
+	DeclineReasonCardFrozen        DeclineReason = "CARD_FROZEN" // the card is blocked or not active
+	DeclineReasonCardExpired       DeclineReason = "CARD_EXPIRED"
+	DeclineReasonIncorrectPIN      DeclineReason = "INCORRECT_PIN"
+	DeclineReasonFraudBlock        DeclineReason = "FRAUD_BLOCK"    // the card was blocked by a security check
+	DeclineReasonAccountFrozen     DeclineReason = "ACCOUNT_FROZEN" // the account is inactive or dormant
+)
+
 // Transaction represents a financial transaction
 type Transaction struct {
 	ID                  int               `json:"id" db:"id"`
@@ -36,10 +60,13 @@ type Transaction struct {
 	Amount              float64           `json:"amount" db:"amount"`
 	Currency            Currency          `json:"currency" db:"currency"`
 	Description         string            `json:"description,omitempty" db:"description"`
+	OriginalDescription *string           `json:"original_description,omitempty" db:"-"` // set when the user changed the description
 	Status              TransactionStatus `json:"status" db:"status"`
 	CardID              *int              `json:"card_id,omitempty" db:"card_id"`
 	TransactionDate     time.Time         `json:"transaction_date" db:"transaction_date"`
 	CreatedAt           time.Time         `json:"created_at" db:"created_at"`
+	Reference           string            `json:"reference,omitempty" db:"reference"` // public UUID payments are verified by
+	DeclineReason       DeclineReason     `json:"decline_reason,omitempty" db:"decline_reason"` // set on declined FAILED transactions
 }
 
 // TransferRequest represents a money transfer request
@@ -48,20 +75,25 @@ type TransferRequest struct {
 	DestinationAccountID int     `json:"destination_account_id" binding:"required"`
 	Amount               float64 `json:"amount" binding:"required"`
 	Description          string  `json:"description,omitempty"`
+	InvoiceID            *int    `json:"-"` // the invoice the transfer pays, marked paid together with the transfer
+	Fee                  float64 `json:"-"` // the source account plan's fee for the transfer, charged together with it
 }
 
 // DepositRequest represents a deposit request
 type DepositRequest struct {
-	AccountID    int     `json:"account_id" binding:"required"`
-	Amount       float64 `json:"amount" binding:"required"`
-	Description  string  `json:"description,omitempty"`
+	AccountID    int      `json:"account_id" binding:"required"`
+	Amount       float64  `json:"amount" binding:"required"`
+	Currency     Currency `json:"currency,omitempty"` // Optional, must match the account currency
+	Description  string   `json:"description,omitempty"`
 }
 
 // WithdrawalRequest represents a withdrawal request
 type WithdrawalRequest struct {
-	AccountID    int     `json:"account_id" binding:"required"`
-	Amount       float64 `json:"amount" binding:"required"`
-	Description  string  `json:"description,omitempty"`
+	AccountID    int      `json:"account_id" binding:"required"`
+	Amount       float64  `json:"amount" binding:"required"`
+	Currency     Currency `json:"currency,omitempty"` // Optional, must match the account currency
+	Description  string   `json:"description,omitempty"`
+	CardID       *int     `json:"-"`                  // the card used at an ATM
 }
 
 // PaymentRequest represents a payment request
@@ -108,13 +140,13 @@ func (d *DepositRequest) ValidateDepositRequest() error {
 	return nil
 }
 
-// ToTransaction converts DepositRequest to Transaction
-func (d *DepositRequest) ToTransaction() *Transaction {
+// ToTransaction converts DepositRequest to Transaction in the currency of the account
+func (d *DepositRequest) ToTransaction(currency Currency) *Transaction {
 	return &Transaction{
 		TransactionType:      TransactionTypeDeposit,
 		DestinationAccountID: &d.AccountID,
 		Amount:               d.Amount,
-		Currency:             CurrencyRUB, // Default currency, can be changed based on account
+		Currency:             currency,
 		Description:          d.Description,
 		Status:               TransactionStatusPending,
 		TransactionDate:      time.Now(),
@@ -130,14 +162,15 @@ func (w *WithdrawalRequest) ValidateWithdrawalRequest() error {
 	return nil
 }
 
-// ToTransaction converts WithdrawalRequest to Transaction
-func (w *WithdrawalRequest) ToTransaction() *Transaction {
+// ToTransaction converts WithdrawalRequest to Transaction in the currency of the account
+func (w *WithdrawalRequest) ToTransaction(currency Currency) *Transaction {
 	return &Transaction{
 		TransactionType:     TransactionTypeWithdrawal,
 		SourceAccountID:     &w.AccountID,
 		Amount:              w.Amount,
-		Currency:            CurrencyRUB, // Default currency, can be changed based on account
+		Currency:            currency,
 		Description:         w.Description,
+		CardID:              w.CardID,
 		Status:              TransactionStatusPending,
 		TransactionDate:     time.Now(),
 	}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTransactionDescriptionLength is the longest description a user can give a transaction, in characters
+const MaxTransactionDescriptionLength = 255
+
+// TransactionDescriptionEdit is an entry of the history of the description a user gives one of their
+// transactions. The description the transaction was made with never changes: statements, the
+// accounting export and categorization keep using it, and the user's latest edit is only shown to
+// them in place of it. Entries are only ever added.
+type TransactionDescriptionEdit struct {
+	ID            int       `json:"id" db:"id"`
+	TransactionID int       `json:"transaction_id" db:"transaction_id"`
+	UserID        int       `json:"user_id" db:"user_id"`
+	Description   string    `json:"description" db:"description"` // empty when the user restored the original
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// TransactionDescriptionUpdate represents a user changing the description of a transaction; an
+// empty description restores the original
+type TransactionDescriptionUpdate struct {
+	Description string `json:"description"`
+}
+
+// TransactionDescriptionHistory is the original description of a transaction with the user's edits,
+// oldest first
+type TransactionDescriptionHistory struct {
+	TransactionID int                           `json:"transaction_id"`
+	Original      string                        `json:"original"`
+	Current       string                        `json:"current"`
+	Edits         []*TransactionDescriptionEdit `json:"edits"`
+}
+
+// ValidateTransactionDescriptionUpdate validates transaction description data
+func (u *TransactionDescriptionUpdate) ValidateTransactionDescriptionUpdate() error {
+	u.Description = strings.TrimSpace(u.Description)
+	if utf8.RuneCountInString(u.Description) > MaxTransactionDescriptionLength {
+		return errors.New("description must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// ApplyDescriptionEdit shows the user's latest edit of the description of a transaction in place of
+// the original, which is kept in OriginalDescription. An edit that restored the original changes
+// nothing.
+func (t *Transaction) ApplyDescriptionEdit(edit *TransactionDescriptionEdit) {
+	if edit == nil || edit.Description == "" {
+		return
+	}
+
+	original := t.Description
+	t.OriginalDescription = &original
+	t.Description = edit.Description
+}
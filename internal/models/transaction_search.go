@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"banking-service/pkg/i18n"
+)
+
+// Transaction search pages hold DefaultTransactionSearchLimit transactions unless the request asks
+// for another size of at most MaxTransactionSearchLimit. Pages end at the MaxTransactionSearchDepth
+// result, as deep as the search engine pages.
+const (
+	DefaultTransactionSearchLimit = 50
+	MaxTransactionSearchLimit     = 200
+	MaxTransactionSearchDepth     = 10000
+	maxTransactionSearchText      = 200
+)
+
+// Where the results of a transaction search come from
+const (
+	SearchSourceIndex    = "index"    // the search engine
+	SearchSourceDatabase = "database" // the SQL fallback when the index is disabled or unavailable
+)
+
+// TransactionSearch selects a page of the transactions of the user's accounts, newest first
+type TransactionSearch struct {
+	Query     string // words that must all appear in the description
+	Type      TransactionType
+	Status    TransactionStatus
+	Currency  Currency
+	AccountID int        // one account the user can view, all personal accounts if 0
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+	MinAmount *float64
+	MaxAmount *float64
+	Limit     int
+	Offset    int
+}
+
+// TransactionFacets counts the transactions matching a search by type, status and currency
+type TransactionFacets struct {
+	Type     map[string]int `json:"type"`
+	Status   map[string]int `json:"status"`
+	Currency map[string]int `json:"currency"`
+}
+
+// TransactionSearchResult represents a page of found transactions with the total number and the
+// facets of all matching transactions
+type TransactionSearchResult struct {
+	Transactions []*Transaction    `json:"transactions"`
+	Total        int               `json:"total"`
+	Facets       TransactionFacets `json:"facets"`
+	Source       string            `json:"source"` // index or database
+	Limit        int               `json:"limit"`
+	Offset       int               `json:"offset"`
+}
+
+// TransactionEvent records that a transaction was created or changed. The database writes one for
+// every insert and update of a transaction; the search indexer consumes them.
+type TransactionEvent struct {
+	ID            int        `json:"id" db:"id"`
+	TransactionID int        `json:"transaction_id" db:"transaction_id"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty" db:"processed_at"`
+}
+
+// NewTransactionFacets creates empty facets
+func NewTransactionFacets() TransactionFacets {
+	return TransactionFacets{Type: map[string]int{}, Status: map[string]int{}, Currency: map[string]int{}}
+}
+
+// ValidateTransactionSearch validates and normalizes a transaction search and applies the default
+// page size
+func (s *TransactionSearch) ValidateTransactionSearch() error {
+	s.Query = strings.Join(strings.Fields(s.Query), " ")
+	if utf8.RuneCountInString(s.Query) > maxTransactionSearchText {
+		return i18n.Errorf("query_must_be_at_most_characters", strconv.Itoa(maxTransactionSearchText))
+	}
+
+	s.Type = TransactionType(strings.ToUpper(strings.TrimSpace(string(s.Type))))
+	s.Status = TransactionStatus(strings.ToUpper(strings.TrimSpace(string(s.Status))))
+	s.Currency = Currency(strings.ToUpper(strings.TrimSpace(string(s.Currency))))
+
+	if s.Limit == 0 {
+		s.Limit = DefaultTransactionSearchLimit
+	}
+
+	if s.Limit < 0 || s.Limit > MaxTransactionSearchLimit {
+		return i18n.Errorf("limit_must_be_between_1_and", strconv.Itoa(MaxTransactionSearchLimit))
+	}
+
+	if s.Offset < 0 {
+		return errors.New("offset cannot be negative")
+	}
+
+	if s.Offset+s.Limit > MaxTransactionSearchDepth {
+		return i18n.Errorf("offset_and_limit_must_not_go_past_the_first_results_narrow_the_search_instead", strconv.Itoa(MaxTransactionSearchDepth))
+	}
+
+	if s.From != nil && s.To != nil && !s.From.Before(*s.To) {
+		return errors.New("from must be before to")
+	}
+
+	if s.MinAmount != nil && s.MaxAmount != nil && *s.MinAmount > *s.MaxAmount {
+		return errors.New("min_amount cannot be greater than max_amount")
+	}
+
+	return nil
+}
+
+// Words returns the words of the query
+func (s *TransactionSearch) Words() []string {
+	return strings.Fields(s.Query)
+}
+
+// Matches reports whether a transaction matches the search, apart from its accounts
+func (s *TransactionSearch) Matches(t *Transaction) bool {
+	description := strings.ToLower(t.Description)
+	for _, word := range s.Words() {
+		if !strings.Contains(description, strings.ToLower(word)) {
+			return false
+		}
+	}
+
+	return (s.Type == "" || t.TransactionType == s.Type) &&
+		(s.Status == "" || t.Status == s.Status) &&
+		(s.Currency == "" || t.Currency == s.Currency) &&
+		(s.From == nil || !t.TransactionDate.Before(*s.From)) &&
+		(s.To == nil || t.TransactionDate.Before(*s.To)) &&
+		(s.MinAmount == nil || t.Amount >= *s.MinAmount) &&
+		(s.MaxAmount == nil || t.Amount <= *s.MaxAmount)
+}
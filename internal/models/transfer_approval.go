@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// PendingTransferStatus defines the status of a transfer waiting for approvals
+type PendingTransferStatus string
+
+const (
+	PendingTransferStatusPendingApproval PendingTransferStatus = "PENDING_APPROVAL"
+	PendingTransferStatusApproved        PendingTransferStatus = "APPROVED"
+	PendingTransferStatusRejected        PendingTransferStatus = "REJECTED"
+	PendingTransferStatusFailed          PendingTransferStatus = "FAILED"
+)
+
+// maxRequiredApprovals caps the approvers a policy can demand
+const maxRequiredApprovals = 10
+
+// ApprovalPolicy requires a number of approvals for organization transfers of at least MinAmount
+type ApprovalPolicy struct {
+	ID                int       `json:"id" db:"id"`
+	OrganizationID    int       `json:"organization_id" db:"organization_id"`
+	MinAmount         float64   `json:"min_amount" db:"min_amount"`
+	RequiredApprovals int       `json:"required_approvals" db:"required_approvals"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// ApprovalPolicyRule is a single rule in an approval policy update
+type ApprovalPolicyRule struct {
+	MinAmount         float64 `json:"min_amount" binding:"required"`
+	RequiredApprovals int     `json:"required_approvals" binding:"required"`
+}
+
+// ApprovalPoliciesUpdate replaces all approval policies of an organization
+type ApprovalPoliciesUpdate struct {
+	Policies []ApprovalPolicyRule `json:"policies"`
+}
+
+// PendingTransfer represents an organization transfer waiting for approvals
+type PendingTransfer struct {
+	ID                   int                   `json:"id" db:"id"`
+	OrganizationID       int                   `json:"organization_id" db:"organization_id"`
+	RequestedBy          int                   `json:"requested_by" db:"requested_by"`
+	SourceAccountID      int                   `json:"source_account_id" db:"source_account_id"`
+	DestinationAccountID int                   `json:"destination_account_id" db:"destination_account_id"`
+	Amount               float64               `json:"amount" db:"amount"`
+	Description          string                `json:"description,omitempty" db:"description"`
+	InvoiceID            *int                  `json:"invoice_id,omitempty" db:"invoice_id"`
+	RequiredApprovals    int                   `json:"required_approvals" db:"required_approvals"`
+	Approvals            []*TransferApproval   `json:"approvals" db:"-"`
+	Status               PendingTransferStatus `json:"status" db:"status"`
+	TransactionID        *int                  `json:"transaction_id,omitempty" db:"transaction_id"`
+	ExpiresAt            time.Time             `json:"expires_at" db:"expires_at"`
+	CreatedAt            time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// TransferApproval represents a member's approval of a pending transfer
+type TransferApproval struct {
+	ID                int       `json:"id" db:"id"`
+	PendingTransferID int       `json:"pending_transfer_id" db:"pending_transfer_id"`
+	UserID            int       `json:"user_id" db:"user_id"`
+	Username          string    `json:"username" db:"username"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+// ValidateApprovalPolicies validates an approval policy update
+func (u *ApprovalPoliciesUpdate) ValidateApprovalPolicies() error {
+	seen := make(map[float64]bool)
+
+	for _, rule := range u.Policies {
+		if rule.MinAmount <= 0 {
+			return errors.New("min_amount must be positive")
+		}
+
+		if rule.RequiredApprovals < 1 || rule.RequiredApprovals > maxRequiredApprovals {
+			return errors.New("required_approvals must be between 1 and 10")
+		}
+
+		if seen[rule.MinAmount] {
+			return errors.New("min_amount values must be unique")
+		}
+		seen[rule.MinAmount] = true
+	}
+
+	return nil
+}
+
+// RequiredApprovalsFor returns the approvals needed for an amount under the policies:
+// the rule with the highest MinAmount not above the amount applies, 0 if none does
+func RequiredApprovalsFor(policies []*ApprovalPolicy, amount float64) int {
+	required := 0
+	best := -1.0
+
+	for _, policy := range policies {
+		if amount >= policy.MinAmount && policy.MinAmount > best {
+			best = policy.MinAmount
+			required = policy.RequiredApprovals
+		}
+	}
+
+	return required
+}
+
+// ToTransferRequest converts PendingTransfer back to the original TransferRequest
+func (p *PendingTransfer) ToTransferRequest() *TransferRequest {
+	return &TransferRequest{
+		SourceAccountID:      p.SourceAccountID,
+		DestinationAccountID: p.DestinationAccountID,
+		Amount:               p.Amount,
+		Description:          p.Description,
+		InvoiceID:            p.InvoiceID,
+	}
+}
+
+// HasApproved reports whether the user already approved the transfer
+func (p *PendingTransfer) HasApproved(userID int) bool {
+	for _, approval := range p.Approvals {
+		if approval.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ConfirmationStatus defines the status of a transfer confirmation
+type ConfirmationStatus string
+
+const (
+	ConfirmationStatusPending   ConfirmationStatus = "PENDING"
+	ConfirmationStatusConfirmed ConfirmationStatus = "CONFIRMED"
+	ConfirmationStatusFailed    ConfirmationStatus = "FAILED"
+)
+
+// TransferConfirmation represents a high-value transfer waiting for a one-time code
+type TransferConfirmation struct {
+	ID                   int                `json:"id" db:"id"`
+	ConfirmationID       string             `json:"confirmation_id" db:"confirmation_id"`
+	UserID               int                `json:"user_id" db:"user_id"`
+	SourceAccountID      int                `json:"source_account_id" db:"source_account_id"`
+	DestinationAccountID int                `json:"destination_account_id" db:"destination_account_id"`
+	Amount               float64            `json:"amount" db:"amount"`
+	Description          string             `json:"description,omitempty" db:"description"`
+	InvoiceID            *int               `json:"invoice_id,omitempty" db:"invoice_id"`
+	CodeHash             string             `json:"-" db:"code_hash"`
+	Attempts             int                `json:"attempts" db:"attempts"`
+	Status               ConfirmationStatus `json:"status" db:"status"`
+	TransactionID        *int               `json:"transaction_id,omitempty" db:"transaction_id"`
+	ExpiresAt            time.Time          `json:"expires_at" db:"expires_at"`
+	CreatedAt            time.Time          `json:"created_at" db:"created_at"`
+}
+
+// TransferResult is the outcome of a transfer request. High-value transfers are not
+// executed immediately; they return a confirmation ID to be confirmed with a code.
+// Organization transfers covered by an approval policy return a pending transfer ID instead.
+type TransferResult struct {
+	TransactionID        int        `json:"transaction_id,omitempty"`
+	ConfirmationRequired bool       `json:"confirmation_required"`
+	ConfirmationID       string     `json:"confirmation_id,omitempty"`
+	ApprovalRequired     bool       `json:"approval_required,omitempty"`
+	PendingTransferID    int        `json:"pending_transfer_id,omitempty"`
+	RequiredApprovals    int        `json:"required_approvals,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
+}
+
+// TransferConfirmRequest represents a request to confirm a pending transfer
+type TransferConfirmRequest struct {
+	ConfirmationID string `json:"confirmation_id" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// ValidateTransferConfirmRequest validates transfer confirmation data
+func (c *TransferConfirmRequest) ValidateTransferConfirmRequest() error {
+	if c.ConfirmationID == "" {
+		return errors.New("confirmation_id is required")
+	}
+
+	if c.Code == "" {
+		return errors.New("code is required")
+	}
+
+	return nil
+}
+
+// ToTransferRequest converts TransferConfirmation back to the original TransferRequest
+func (c *TransferConfirmation) ToTransferRequest() *TransferRequest {
+	return &TransferRequest{
+		SourceAccountID:      c.SourceAccountID,
+		DestinationAccountID: c.DestinationAccountID,
+		Amount:               c.Amount,
+		Description:          c.Description,
+		InvoiceID:            c.InvoiceID,
+	}
+}
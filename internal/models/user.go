@@ -7,17 +7,33 @@ import (
 	"time"
 )
 
+// UserRole defines the role of a user
+type UserRole string
+
+const (
+	UserRoleCustomer UserRole = "CUSTOMER"
+	UserRoleAdmin    UserRole = "ADMIN"
+	UserRoleSupport  UserRole = "SUPPORT" // support staff, who may impersonate customers read-only
+)
+
 // User represents a user in the system
 type User struct {
-	ID        int       `json:"id" db:"id"`
-	Username  string    `json:"username" db:"username"`
-	Email     string    `json:"email" db:"email"`
-	Password  string    `json:"-" db:"-"`
-	PassHash  string    `json:"-" db:"password_hash"`
-	FirstName string    `json:"first_name,omitempty" db:"first_name"`
-	LastName  string    `json:"last_name,omitempty" db:"last_name"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID        int        `json:"id" db:"id"`
+	Username  string     `json:"username" db:"username"`
+	Email     string     `json:"email" db:"email"`
+	Password  string     `json:"-" db:"-"`
+	PassHash  string     `json:"-" db:"password_hash"`
+	FirstName string     `json:"first_name,omitempty" db:"first_name"`
+	LastName  string     `json:"last_name,omitempty" db:"last_name"`
+	Phone     string     `json:"phone,omitempty" db:"phone"`           // E.164
+	BirthDate *time.Time `json:"birth_date,omitempty" db:"birth_date"` // a day, at midnight
+	Address   Address    `json:"address"`
+	Role      UserRole   `json:"role" db:"role"`
+	Tenant    string     `json:"tenant" db:"tenant"`
+	Timezone  string     `json:"timezone,omitempty" db:"timezone"` // IANA name; empty means the bank's time zone
+	Language  string     `json:"language,omitempty" db:"language"` // en or ru; empty means the request's or the bank's language
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // UserRegistration represents user registration data
@@ -27,6 +43,10 @@ type UserRegistration struct {
 	Password  string `json:"password" binding:"required"`
 	FirstName string `json:"first_name,omitempty"`
 	LastName  string `json:"last_name,omitempty"`
+	Phone     string `json:"phone,omitempty"` // E.164
+	BirthDate string `json:"birth_date,omitempty"` // YYYY-MM-DD
+	Address   *Address `json:"address,omitempty"`
+	ReferralCode string `json:"referral_code,omitempty"` // code of the user who invited the new user
 }
 
 // UserLogin represents user login data
@@ -35,10 +55,39 @@ type UserLogin struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// PasswordChangeRequest represents a request to change the password
+type PasswordChangeRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+// AvailabilityRequest represents a signup check of whether a username and an email are still free
+type AvailabilityRequest struct {
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
+// Availability reports whether the checked username and email are free; unchecked ones are left out
+type Availability struct {
+	UsernameAvailable *bool `json:"username_available,omitempty"`
+	EmailAvailable    *bool `json:"email_available,omitempty"`
+}
+
+// TimezoneRequest represents a request to change the time zone of a user
+type TimezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+// LanguageRequest represents a request to change the language of a user
+type LanguageRequest struct {
+	Language string `json:"language"`
+}
+
 // TokenResponse represents the JWT token response
 type TokenResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expires_at"`
+	Token     string  `json:"token"`
+	ExpiresAt int64   `json:"expires_at"`
+	Scopes    []Scope `json:"scopes,omitempty"` // none for a token with full access
 }
 
 // ValidateRegistration validates user registration data
@@ -49,41 +98,140 @@ func (u *UserRegistration) ValidateRegistration() error {
 	}
 	
 	// Validate email
+	if err := ValidateEmail(u.Email); err != nil {
+		return err
+	}
+	
+	// Validate password
+	if err := ValidatePassword(u.Password); err != nil {
+		return err
+	}
+	
+	// Sanitize inputs
+	u.Username = strings.TrimSpace(u.Username)
+	u.Email = strings.TrimSpace(u.Email)
+	u.FirstName = strings.TrimSpace(u.FirstName)
+	u.LastName = strings.TrimSpace(u.LastName)
+	u.ReferralCode = strings.ToUpper(strings.TrimSpace(u.ReferralCode))
+	
+	// Validate the optional personal details
+	profile := u.profile()
+	if err := profile.ValidateProfile(time.Now()); err != nil {
+		return err
+	}
+	u.FirstName, u.LastName, u.Phone, u.Address = profile.FirstName, profile.LastName, profile.Phone, profile.Address
+	
+	return nil
+}
+
+// ValidateAvailability validates availability check data: at least one of the username and the
+// email, each in the format registration accepts
+func (a *AvailabilityRequest) ValidateAvailability() error {
+	a.Username = strings.TrimSpace(a.Username)
+	a.Email = strings.TrimSpace(a.Email)
+	
+	if a.Username == "" && a.Email == "" {
+		return errors.New("username or email is required")
+	}
+	
+	if a.Username != "" && (len(a.Username) < 3 || len(a.Username) > 50) {
+		return errors.New("username must be between 3 and 50 characters")
+	}
+	
+	if a.Email != "" {
+		if err := ValidateEmail(a.Email); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// profile returns the personal details given at registration
+func (u *UserRegistration) profile() *ProfileRequest {
+	return &ProfileRequest{
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Phone:     u.Phone,
+		BirthDate: u.BirthDate,
+		Address:   u.Address,
+	}
+}
+
+// ValidateEmail checks the email address format
+func ValidateEmail(email string) error {
 	emailPattern := `^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`
-	matched, err := regexp.MatchString(emailPattern, u.Email)
+	matched, err := regexp.MatchString(emailPattern, email)
 	if err != nil || !matched {
 		return errors.New("invalid email format")
 	}
 	
-	// Validate password
-	if len(u.Password) < 8 {
+	return nil
+}
+
+// ValidatePasswordChange validates password change data
+func (p *PasswordChangeRequest) ValidatePasswordChange() error {
+	if p.CurrentPassword == "" {
+		return errors.New("current password is required")
+	}
+	
+	if p.NewPassword == p.CurrentPassword {
+		return errors.New("new password must differ from the current password")
+	}
+	
+	return ValidatePassword(p.NewPassword)
+}
+
+// ValidatePassword checks the password complexity rules
+func ValidatePassword(password string) error {
+	if len(password) < 8 {
 		return errors.New("password must be at least 8 characters")
 	}
 	
-	hasUppercase := regexp.MustCompile(`[A-Z]`).MatchString(u.Password)
-	hasLowercase := regexp.MustCompile(`[a-z]`).MatchString(u.Password)
-	hasNumber := regexp.MustCompile(`[0-9]`).MatchString(u.Password)
+	hasUppercase := regexp.MustCompile(`[A-Z]`).MatchString(password)
+	hasLowercase := regexp.MustCompile(`[a-z]`).MatchString(password)
+	hasNumber := regexp.MustCompile(`[0-9]`).MatchString(password)
 	
 	if !hasUppercase || !hasLowercase || !hasNumber {
 		return errors.New("password must contain at least one uppercase letter, one lowercase letter, and one number")
 	}
 	
-	// Sanitize inputs
-	u.Username = strings.TrimSpace(u.Username)
-	u.Email = strings.TrimSpace(u.Email)
-	u.FirstName = strings.TrimSpace(u.FirstName)
-	u.LastName = strings.TrimSpace(u.LastName)
+	return nil
+}
+
+// ValidateTimezone checks that a time zone is an IANA name like Europe/Moscow. An empty name
+// resets the user to the bank's time zone.
+func ValidateTimezone(timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	
+	if _, err := time.LoadLocation(timezone); err != nil || strings.EqualFold(timezone, "local") {
+		return errors.New("invalid timezone, expected an IANA name like Europe/Moscow")
+	}
 	
 	return nil
 }
 
+// Location returns the time zone of the user, falling back to the bank's time zone
+func (u *User) Location(bank *time.Location) *time.Location {
+	if u.Timezone != "" {
+		if loc, err := time.LoadLocation(u.Timezone); err == nil {
+			return loc
+		}
+	}
+	return bank
+}
+
 // ToUser converts UserRegistration to User
 func (u *UserRegistration) ToUser() *User {
-	return &User{
+	user := &User{
 		Username:  u.Username,
 		Email:     u.Email,
 		Password:  u.Password,
-		FirstName: u.FirstName,
-		LastName:  u.LastName,
+		Role:      UserRoleCustomer,
 	}
+	u.profile().Apply(user)
+	
+	return user
 }
\ No newline at end of file
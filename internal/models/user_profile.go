@@ -0,0 +1,198 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MinCreditAge is the age a customer must have reached to take a credit
+const MinCreditAge = 18
+
+// e164Phone matches a phone number in E.164 format: a plus sign and up to 15 digits
+var e164Phone = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// countryCode matches an ISO 3166-1 alpha-2 country code
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Address is the postal address of a customer
+type Address struct {
+	Country    string `json:"country,omitempty" db:"address_country"` // ISO 3166-1 alpha-2, e.g. RU
+	City       string `json:"city,omitempty" db:"address_city"`
+	Street     string `json:"street,omitempty" db:"address_street"` // street, house and apartment
+	PostalCode string `json:"postal_code,omitempty" db:"address_postal_code"`
+}
+
+// IsZero reports whether no part of the address is filled in
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// ProfileRequest represents the personal details a customer gives at registration or changes later
+type ProfileRequest struct {
+	FirstName string   `json:"first_name,omitempty"`
+	LastName  string   `json:"last_name,omitempty"`
+	Phone     string   `json:"phone,omitempty"`      // E.164, e.g. +79161234567; spaces, dashes and brackets are ignored
+	BirthDate string   `json:"birth_date,omitempty"` // YYYY-MM-DD
+	Address   *Address `json:"address,omitempty"`
+}
+
+// ValidateProfile checks and normalizes the personal details. Every field is optional; the ones
+// given must be well-formed.
+func (p *ProfileRequest) ValidateProfile(now time.Time) error {
+	p.FirstName = strings.TrimSpace(p.FirstName)
+	p.LastName = strings.TrimSpace(p.LastName)
+	if len(p.FirstName) > 100 || len(p.LastName) > 100 {
+		return errors.New("first_name and last_name must be at most 100 characters")
+	}
+
+	if p.Phone != "" {
+		phone, err := NormalizePhone(p.Phone)
+		if err != nil {
+			return err
+		}
+		p.Phone = phone
+	}
+
+	if p.BirthDate != "" {
+		if _, err := ParseBirthDate(p.BirthDate, now); err != nil {
+			return err
+		}
+	}
+
+	if p.Address != nil {
+		if err := p.Address.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply copies the personal details to a user; an empty address clears it
+func (p *ProfileRequest) Apply(user *User) {
+	user.FirstName = p.FirstName
+	user.LastName = p.LastName
+	user.Phone = p.Phone
+
+	user.BirthDate = nil
+	if p.BirthDate != "" {
+		if day, err := time.ParseInLocation("2006-01-02", p.BirthDate, time.UTC); err == nil {
+			user.BirthDate = &day
+		}
+	}
+
+	user.Address = Address{}
+	if p.Address != nil {
+		user.Address = *p.Address
+	}
+}
+
+// NormalizePhone strips the formatting of a phone number and checks that it is in E.164 format
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, phone)
+
+	if !e164Phone.MatchString(phone) {
+		return "", errors.New("phone must be in E.164 format, e.g. +79161234567")
+	}
+
+	return phone, nil
+}
+
+// ParseBirthDate parses a date of birth in YYYY-MM-DD format, which must be in the past and
+// plausible. Dates of birth are calendar dates and are kept as UTC midnights.
+func ParseBirthDate(date string, now time.Time) (time.Time, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.UTC)
+	if err != nil {
+		return time.Time{}, errors.New("birth_date must be in YYYY-MM-DD format")
+	}
+
+	if !day.Before(now) {
+		return time.Time{}, errors.New("birth_date must be in the past")
+	}
+	if day.Before(now.AddDate(-120, 0, 0)) {
+		return time.Time{}, errors.New("birth_date must be at most 120 years ago")
+	}
+
+	return day, nil
+}
+
+// Validate checks and normalizes an address: a filled in address needs the country, the city
+// and the street
+func (a *Address) Validate() error {
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	a.City = strings.TrimSpace(a.City)
+	a.Street = strings.TrimSpace(a.Street)
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+
+	if a.IsZero() {
+		return nil
+	}
+
+	if !countryCode.MatchString(a.Country) {
+		return errors.New("address.country must be an ISO 3166-1 alpha-2 code, e.g. RU")
+	}
+	if a.City == "" || len(a.City) > 100 {
+		return errors.New("address.city is required and must be at most 100 characters")
+	}
+	if a.Street == "" || len(a.Street) > 200 {
+		return errors.New("address.street is required and must be at most 200 characters")
+	}
+	if len(a.PostalCode) > 20 {
+		return errors.New("address.postal_code must be at most 20 characters")
+	}
+
+	return nil
+}
+
+// Age returns the age of the user on a day, or -1 if their date of birth is not known
+func (u *User) Age(now time.Time) int {
+	if u.BirthDate == nil {
+		return -1
+	}
+
+	birth := *u.BirthDate
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+
+	return age
+}
+
+// MissingProfile lists the personal details a customer must give before they are identified:
+// the phone, the date of birth and the address
+func (u *User) MissingProfile() []string {
+	var missing []string
+	if u.Phone == "" {
+		missing = append(missing, "phone")
+	}
+	if u.BirthDate == nil {
+		missing = append(missing, "birth_date")
+	}
+	if u.Address.IsZero() {
+		missing = append(missing, "address")
+	}
+	return missing
+}
+
+// CheckCreditEligibility checks that the user's profile allows them to take a credit: the phone,
+// the date of birth and the address must be known, and the user must be of age
+func (u *User) CheckCreditEligibility(now time.Time) error {
+	if missing := u.MissingProfile(); len(missing) > 0 {
+		return errors.New("profile is incomplete for a credit: " + strings.Join(missing, ", "))
+	}
+
+	if u.Age(now) < MinCreditAge {
+		return errors.New("credits are available from the age of 18")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Tables exported to the data warehouse
+const (
+	WarehouseTableTransactions = "transactions"
+	WarehouseTableCredits      = "credits"
+	WarehouseTableAccounts     = "accounts"
+)
+
+// WarehouseColumn is a column of an exported table as recorded in the manifest
+type WarehouseColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+// WarehouseManifest lists the exported partitions of every table and the schema they were written
+// with. It is stored next to the files; BI tools read it to find new partitions.
+type WarehouseManifest struct {
+	Tables    map[string]*WarehouseTable `json:"tables"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// WarehouseTable is the export state of a table. Adding columns is a compatible change: the schema
+// version goes up and the files keep their location, so older files simply lack the new columns.
+// Removing, renaming or retyping a column is incompatible: the generation goes up, which starts a
+// full snapshot under a new location.
+type WarehouseTable struct {
+	Generation    int                   `json:"generation"`
+	SchemaVersion int                   `json:"schema_version"`
+	Columns       []WarehouseColumn     `json:"columns"`
+	Watermark     *time.Time            `json:"watermark,omitempty"` // rows changed before it are exported
+	Partitions    []*WarehousePartition `json:"partitions"`
+}
+
+// WarehousePartition is an exported file of rows changed in [ChangedFrom, ChangedTo). A row
+// changed again later appears in a later partition; the latest one holds its current state.
+type WarehousePartition struct {
+	Key           string     `json:"key"`
+	Date          string     `json:"date"` // dt= partition value, the day of the export
+	Generation    int        `json:"generation"`
+	SchemaVersion int        `json:"schema_version"`
+	Snapshot      bool       `json:"snapshot"` // a full snapshot rather than the changes since the previous export
+	Rows          int        `json:"rows"`
+	ChangedFrom   *time.Time `json:"changed_from,omitempty"`
+	ChangedTo     time.Time  `json:"changed_to"`
+	ExportedAt    time.Time  `json:"exported_at"`
+}
+
+// NewWarehouseManifest creates an empty manifest
+func NewWarehouseManifest() *WarehouseManifest {
+	return &WarehouseManifest{Tables: map[string]*WarehouseTable{}}
+}
+
+// Table returns the export state of a table with the given columns, evolving its schema if the
+// columns changed since the previous export, and how they changed
+func (m *WarehouseManifest) Table(name string, columns []WarehouseColumn) (*WarehouseTable, WarehouseSchemaChange) {
+	table, ok := m.Tables[name]
+	if !ok {
+		table = &WarehouseTable{Generation: 1, SchemaVersion: 1, Columns: columns, Partitions: []*WarehousePartition{}}
+		m.Tables[name] = table
+		return table, WarehouseSchemaUnchanged
+	}
+
+	change := CompareWarehouseColumns(table.Columns, columns)
+	switch change {
+	case WarehouseSchemaCompatible:
+		table.SchemaVersion++
+		table.Columns = columns
+	case WarehouseSchemaIncompatible:
+		table.Generation++
+		table.SchemaVersion++
+		table.Columns = columns
+		table.Watermark = nil
+	}
+
+	return table, change
+}
+
+// WarehouseSchemaChange is how a table schema changed between exports
+type WarehouseSchemaChange int
+
+const (
+	// WarehouseSchemaUnchanged means the columns are the same
+	WarehouseSchemaUnchanged WarehouseSchemaChange = iota
+	// WarehouseSchemaCompatible means columns were only added
+	WarehouseSchemaCompatible
+	// WarehouseSchemaIncompatible means a column was removed or its type changed
+	WarehouseSchemaIncompatible
+)
+
+// CompareWarehouseColumns compares the columns of a previous export with the current ones. A
+// column that became nullable is compatible; one that became required is not, as older files may
+// hold nulls in it.
+func CompareWarehouseColumns(previous, current []WarehouseColumn) WarehouseSchemaChange {
+	byName := make(map[string]WarehouseColumn, len(current))
+	for _, column := range current {
+		byName[column.Name] = column
+	}
+
+	change := WarehouseSchemaUnchanged
+	for _, old := range previous {
+		column, ok := byName[old.Name]
+		if !ok || column.Type != old.Type || (old.Nullable && !column.Nullable) {
+			return WarehouseSchemaIncompatible
+		}
+		if column.Nullable != old.Nullable {
+			change = WarehouseSchemaCompatible
+		}
+	}
+
+	if len(current) != len(previous) {
+		return WarehouseSchemaCompatible
+	}
+
+	return change
+}
+
+// WarehouseKey returns the object storage key of an exported file under a prefix
+func WarehouseKey(prefix, table string, generation int, exportedAt time.Time) string {
+	return fmt.Sprintf("%s/%s/v%d/dt=%s/part-%s.parquet",
+		prefix, table, generation, exportedAt.Format("2006-01-02"), exportedAt.UTC().Format("20060102T150405Z"))
+}
+
+// WarehouseManifestKey returns the object storage key of the manifest under a prefix
+func WarehouseManifestKey(prefix string) string {
+	return prefix + "/manifest.json"
+}
@@ -0,0 +1,232 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountDelegationRepo is an in-memory implementation of the repository.AccountDelegationRepository interface
+type AccountDelegationRepo struct {
+	s *Store
+}
+
+// NewAccountDelegationRepository creates a new AccountDelegationRepo
+func NewAccountDelegationRepository(s *Store) *AccountDelegationRepo {
+	return &AccountDelegationRepo{s: s}
+}
+
+// GetByID gets a delegation by ID
+func (r *AccountDelegationRepo) GetByID(ctx context.Context, id int) (*models.AccountDelegation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	delegation, ok := r.s.delegations[id]
+	if !ok {
+		return nil, fmt.Errorf("delegation not found: %w", sql.ErrNoRows)
+	}
+
+	return r.s.delegationView(delegation), nil
+}
+
+// GetByOwnerID gets the delegations an account owner granted, newest first
+func (r *AccountDelegationRepo) GetByOwnerID(ctx context.Context, ownerID int) ([]*models.AccountDelegation, error) {
+	return r.list(func(d *models.AccountDelegation) bool { return d.OwnerID == ownerID }), nil
+}
+
+// GetByDelegateID gets the delegations granted to a user, newest first
+func (r *AccountDelegationRepo) GetByDelegateID(ctx context.Context, delegateID int) ([]*models.AccountDelegation, error) {
+	return r.list(func(d *models.AccountDelegation) bool { return d.DelegateID == delegateID }), nil
+}
+
+// GetActive gets the unrevoked, unexpired delegation of an account to a user. The account must still
+// belong to the owner who granted it.
+func (r *AccountDelegationRepo) GetActive(ctx context.Context, accountID int, delegateID int) (*models.AccountDelegation, error) {
+	r.s.mu.RLock()
+	account, ok := r.s.accounts[accountID]
+	r.s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("delegation not found: %w", sql.ErrNoRows)
+	}
+
+	now := time.Now()
+	delegations := r.list(func(d *models.AccountDelegation) bool {
+		return d.AccountID == accountID && d.DelegateID == delegateID && d.OwnerID == account.UserID && d.IsActive(now)
+	})
+	if len(delegations) == 0 {
+		return nil, fmt.Errorf("delegation not found: %w", sql.ErrNoRows)
+	}
+
+	return delegations[0], nil
+}
+
+// list gets the delegations that match, newest first
+func (r *AccountDelegationRepo) list(match func(*models.AccountDelegation) bool) []*models.AccountDelegation {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	delegations := []*models.AccountDelegation{}
+	for _, delegation := range rowsOf(r.s.delegations, match) {
+		delegations = append(delegations, r.s.delegationView(delegation))
+	}
+	sort.SliceStable(delegations, func(i, j int) bool {
+		return delegations[i].ID > delegations[j].ID
+	})
+	return delegations
+}
+
+// GetEvents gets the audit log of a delegation, oldest first
+func (r *AccountDelegationRepo) GetEvents(ctx context.Context, delegationID int) ([]*models.DelegationEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*models.DelegationEvent{}
+	for _, event := range rowsOf(r.s.delegationEvents, func(e *models.DelegationEvent) bool {
+		return e.DelegationID == delegationID
+	}) {
+		event := clone(event)
+		event.TransactionID = intPtr(event.TransactionID)
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// AddEvent records an entry of the audit log of a delegation
+func (r *AccountDelegationRepo) AddEvent(ctx context.Context, event *models.DelegationEvent) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.delegations[event.DelegationID]; !ok {
+		return 0, fmt.Errorf("failed to create delegation event: %w", errNotExist("delegation", event.DelegationID))
+	}
+	if _, ok := r.s.accounts[event.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create delegation event: %w", errNotExist("account", event.AccountID))
+	}
+	if _, ok := r.s.users[event.ActorID]; !ok {
+		return 0, fmt.Errorf("failed to create delegation event: %w", errNotExist("user", event.ActorID))
+	}
+	if event.TransactionID != nil {
+		if _, ok := r.s.transactions[*event.TransactionID]; !ok {
+			return 0, fmt.Errorf("failed to create delegation event: %w", errNotExist("transaction", *event.TransactionID))
+		}
+	}
+
+	event.ID = r.s.nextID("account_delegation_events")
+	event.CreatedAt = time.Now()
+	row := clone(event)
+	row.TransactionID = intPtr(event.TransactionID)
+	r.s.delegationEvents[event.ID] = row
+
+	return event.ID, nil
+}
+
+// AddEventTx records an entry of the audit log of a delegation within an existing transaction
+func (r *AccountDelegationRepo) AddEventTx(ctx context.Context, tx *sql.Tx, event *models.DelegationEvent) (int, error) {
+	return r.AddEvent(ctx, event)
+}
+
+// SumTransferred sums the transfers made under a delegation
+func (r *AccountDelegationRepo) SumTransferred(ctx context.Context, delegationID int) (float64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var total float64
+	for _, event := range r.s.delegationEvents {
+		if event.DelegationID == delegationID && event.Action == models.DelegationActionTransferred {
+			total += event.Amount
+		}
+	}
+
+	return total, nil
+}
+
+// SumTransferredTx sums the transfers made under a delegation within an existing transaction
+func (r *AccountDelegationRepo) SumTransferredTx(ctx context.Context, tx *sql.Tx, delegationID int) (float64, error) {
+	return r.SumTransferred(ctx, delegationID)
+}
+
+// CreateTx creates a new delegation within an existing transaction
+func (r *AccountDelegationRepo) CreateTx(ctx context.Context, tx *sql.Tx, delegation *models.AccountDelegation) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[delegation.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create delegation: %w", errNotExist("account", delegation.AccountID))
+	}
+	for _, userID := range []int{delegation.OwnerID, delegation.DelegateID} {
+		if _, ok := r.s.users[userID]; !ok {
+			return 0, fmt.Errorf("failed to create delegation: %w", errNotExist("user", userID))
+		}
+	}
+	if delegation.OwnerID == delegation.DelegateID {
+		return 0, fmt.Errorf("failed to create delegation: owner and delegate must differ")
+	}
+
+	delegation.ID = r.s.nextID("account_delegations")
+	delegation.CreatedAt = time.Now()
+	row := clone(delegation)
+	row.AccountNumber = ""
+	row.DelegateEmail = ""
+	row.RevokedAt = nil
+	row.RevokedBy = nil
+	r.s.delegations[delegation.ID] = row
+
+	return delegation.ID, nil
+}
+
+// RevokeTx revokes a delegation within an existing transaction. It reports false if the
+// delegation was already revoked.
+func (r *AccountDelegationRepo) RevokeTx(ctx context.Context, tx *sql.Tx, id int, revokedBy int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delegation, ok := r.s.delegations[id]
+	if !ok || delegation.RevokedAt != nil {
+		return false, nil
+	}
+
+	delegation.RevokedAt = timePtr(time.Now())
+	delegation.RevokedBy = &revokedBy
+
+	return true, nil
+}
+
+// RevokeByAccountTx revokes the unexpired delegations of an account within an existing
+// transaction and returns their IDs
+func (r *AccountDelegationRepo) RevokeByAccountTx(ctx context.Context, tx *sql.Tx, accountID int, revokedBy int) ([]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	ids := []int{}
+	for _, delegation := range rowsOf(r.s.delegations, func(d *models.AccountDelegation) bool {
+		return d.AccountID == accountID && d.IsActive(now)
+	}) {
+		delegation.RevokedAt = timePtr(now)
+		delegation.RevokedBy = &revokedBy
+		ids = append(ids, delegation.ID)
+	}
+
+	return ids, nil
+}
+
+// delegationView copies a delegation and adds the account number and the delegate's email
+func (s *Store) delegationView(delegation *models.AccountDelegation) *models.AccountDelegation {
+	d := clone(delegation)
+	d.RevokedBy = intPtr(delegation.RevokedBy)
+	if delegation.RevokedAt != nil {
+		d.RevokedAt = timePtr(*delegation.RevokedAt)
+	}
+	if account, ok := s.accounts[delegation.AccountID]; ok {
+		d.AccountNumber = account.AccountNumber
+	}
+	if user, ok := s.users[delegation.DelegateID]; ok {
+		d.DelegateEmail = user.Email
+	}
+	return d
+}
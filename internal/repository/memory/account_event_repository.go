@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountEventRepo is an in-memory implementation of the repository.AccountEventRepository interface
+type AccountEventRepo struct {
+	s *Store
+}
+
+// NewAccountEventRepository creates a new AccountEventRepo
+func NewAccountEventRepository(s *Store) *AccountEventRepo {
+	return &AccountEventRepo{s: s}
+}
+
+// GetActivity gets up to limit events of an account before a position, newest first
+func (r *AccountEventRepo) GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.AccountEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*models.AccountEvent{}
+	for _, event := range rowsOf(r.s.accountEvents, func(e *models.AccountEvent) bool {
+		return e.AccountID == accountID && before.Includes(e.CreatedAt, e.ID)
+	}) {
+		events = append(events, accountEventRow(event))
+	}
+
+	// Newest first; the reverse ID order breaks ties
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].ID > events[j].ID
+		}
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// recordAccountEvent records a change to an account or one of its cards, as the triggers on the
+// accounts and cards tables do
+func (s *Store) recordAccountEvent(accountID int, eventType models.AccountEventType, cardID *int) {
+	id := s.nextID("account_events")
+	s.accountEvents[id] = &models.AccountEvent{ID: id, AccountID: accountID, Type: eventType, CardID: intPtr(cardID), CreatedAt: time.Now()}
+}
+
+// recordLimitsChanged records the new approval policies of an organization in the activity feed of
+// its accounts
+func (s *Store) recordLimitsChanged(organizationID int, rules []models.ApprovalPolicyRule) {
+	limits := append([]models.ApprovalPolicyRule{}, rules...)
+	sort.SliceStable(limits, func(i, j int) bool { return limits[i].MinAmount < limits[j].MinAmount })
+
+	now := time.Now()
+	for _, account := range rowsOf(s.accounts, func(a *accountRow) bool {
+		return a.OrganizationID != nil && *a.OrganizationID == organizationID
+	}) {
+		id := s.nextID("account_events")
+		s.accountEvents[id] = &models.AccountEvent{ID: id, AccountID: account.ID, Type: models.AccountEventLimitsChanged, Limits: limits, CreatedAt: now}
+	}
+}
+
+// accountEventRow returns a copy of a stored event
+func accountEventRow(event *models.AccountEvent) *models.AccountEvent {
+	row := clone(event)
+	row.CardID = intPtr(event.CardID)
+	row.Limits = append([]models.ApprovalPolicyRule(nil), event.Limits...)
+	return row
+}
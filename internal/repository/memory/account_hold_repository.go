@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountHoldRepo is an in-memory implementation of the repository.AccountHoldRepository interface
+type AccountHoldRepo struct {
+	s *Store
+}
+
+// NewAccountHoldRepository creates a new AccountHoldRepo
+func NewAccountHoldRepository(s *Store) *AccountHoldRepo {
+	return &AccountHoldRepo{s: s}
+}
+
+// Create places a hold if the account's available balance covers it. It returns 0 without
+// an error if the operation already has an active hold.
+func (r *AccountHoldRepo) Create(ctx context.Context, hold *models.AccountHold) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	account, ok := r.s.accounts[hold.AccountID]
+	if !ok {
+		return 0, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	if account.Balance-r.s.activeHolds(hold.AccountID) < hold.Amount {
+		return 0, errors.New("insufficient funds")
+	}
+
+	// Like the partial unique index, an operation has at most one active hold, expired or not
+	for _, other := range r.s.holds {
+		if other.Reason == hold.Reason && other.ReferenceID == hold.ReferenceID && other.Status == models.HoldStatusActive {
+			return 0, nil
+		}
+	}
+
+	if hold.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create account hold: amount must be positive")
+	}
+
+	row := clone(hold)
+	row.ID = r.s.nextID("account_holds")
+	row.Status = models.HoldStatusActive
+	row.ReleasedAt = nil
+	row.CreatedAt = time.Now()
+	r.s.holds[row.ID] = row
+
+	hold.ID, hold.CreatedAt, hold.Status = row.ID, row.CreatedAt, row.Status
+
+	return hold.ID, nil
+}
+
+// CreateTx places a hold within an existing transaction if the account's available balance covers
+// it. It returns 0 without an error if the operation already has an active hold.
+func (r *AccountHoldRepo) CreateTx(ctx context.Context, tx *sql.Tx, hold *models.AccountHold) (int, error) {
+	return r.Create(ctx, hold)
+}
+
+// GetActiveByAccountID gets the unexpired active holds of an account, newest first
+func (r *AccountHoldRepo) GetActiveByAccountID(ctx context.Context, accountID int) ([]*models.AccountHold, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	now := time.Now()
+	rows := rowsOf(r.s.holds, func(h *models.AccountHold) bool { return h.AccountID == accountID && holdActive(h, now) })
+
+	// Newest first; the reverse ID order breaks ties
+	holds := []*models.AccountHold{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		holds = append(holds, clone(rows[i]))
+	}
+	sort.SliceStable(holds, func(i, j int) bool { return holds[i].CreatedAt.After(holds[j].CreatedAt) })
+
+	return holds, nil
+}
+
+// Release ends the active hold of an operation with the given status; it reports false if there was none
+func (r *AccountHoldRepo) Release(ctx context.Context, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	released := false
+	for _, hold := range r.s.holds {
+		if hold.Reason == reason && hold.ReferenceID == referenceID && hold.Status == models.HoldStatusActive {
+			hold.Status = to
+			hold.ReleasedAt = timePtr(time.Now())
+			released = true
+		}
+	}
+
+	return released, nil
+}
+
+// ReleaseTx ends the active hold of an operation with the given status within a transaction
+func (r *AccountHoldRepo) ReleaseTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error) {
+	return r.Release(ctx, reason, referenceID, to)
+}
+
+// ReleaseBatchTx ends the active holds of several operations with the given status within a
+// transaction and returns how many holds it ended
+func (r *AccountHoldRepo) ReleaseBatchTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceIDs []int, to models.HoldStatus) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	references := make(map[int]bool, len(referenceIDs))
+	for _, id := range referenceIDs {
+		references[id] = true
+	}
+
+	var released int64
+	for _, hold := range r.s.holds {
+		if hold.Reason == reason && references[hold.ReferenceID] && hold.Status == models.HoldStatusActive {
+			hold.Status = to
+			hold.ReleasedAt = timePtr(time.Now())
+			released++
+		}
+	}
+
+	return released, nil
+}
+
+// holdActive reports whether a hold still reserves funds
+func holdActive(hold *models.AccountHold, now time.Time) bool {
+	return hold.Status == models.HoldStatusActive && (hold.ExpiresAt == nil || hold.ExpiresAt.After(now))
+}
@@ -0,0 +1,161 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountOwnershipRepo is an in-memory implementation of the repository.AccountOwnershipRepository interface
+type AccountOwnershipRepo struct {
+	s *Store
+}
+
+// NewAccountOwnershipRepository creates a new AccountOwnershipRepo
+func NewAccountOwnershipRepository(s *Store) *AccountOwnershipRepo {
+	return &AccountOwnershipRepo{s: s}
+}
+
+// GetByID gets an ownership transfer of the request's tenant, that of its account, by ID
+func (r *AccountOwnershipRepo) GetByID(ctx context.Context, id int) (*models.OwnershipTransfer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transfer, ok := r.s.ownershipTransfers[id]
+	if !ok || !r.s.accountInTenant(ctx, transfer.AccountID) {
+		return nil, fmt.Errorf("ownership transfer not found: %w", sql.ErrNoRows)
+	}
+
+	return ownershipTransferRow(transfer), nil
+}
+
+// GetByAccountID gets the ownership transfers of an account, newest first
+func (r *AccountOwnershipRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.OwnershipTransfer, error) {
+	transfers := r.list(func(t *models.OwnershipTransfer) bool { return t.AccountID == accountID })
+	sort.SliceStable(transfers, func(i, j int) bool {
+		return transfers[i].ID > transfers[j].ID
+	})
+	return transfers, nil
+}
+
+// GetByStatus gets the ownership transfers of the request's tenant in a status, all of them if the
+// status is empty, oldest first
+func (r *AccountOwnershipRepo) GetByStatus(ctx context.Context, status models.OwnershipTransferStatus) ([]*models.OwnershipTransfer, error) {
+	return r.list(func(t *models.OwnershipTransfer) bool {
+		return (status == "" || t.Status == status) && r.s.accountInTenant(ctx, t.AccountID)
+	}), nil
+}
+
+// list gets the ownership transfers that match in ID order
+func (r *AccountOwnershipRepo) list(match func(*models.OwnershipTransfer) bool) []*models.OwnershipTransfer {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transfers := []*models.OwnershipTransfer{}
+	for _, transfer := range rowsOf(r.s.ownershipTransfers, match) {
+		transfers = append(transfers, ownershipTransferRow(transfer))
+	}
+	return transfers
+}
+
+// GetEvents gets the audit trail of an ownership transfer, oldest first
+func (r *AccountOwnershipRepo) GetEvents(ctx context.Context, transferID int) ([]*models.OwnershipTransferEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*models.OwnershipTransferEvent{}
+	for _, event := range rowsOf(r.s.ownershipEvents, func(e *models.OwnershipTransferEvent) bool {
+		return e.TransferID == transferID
+	}) {
+		events = append(events, clone(event))
+	}
+
+	return events, nil
+}
+
+// CreateTx creates a new ownership transfer within an existing transaction. Like the unique index
+// in PostgreSQL, it refuses a second pending transfer of an account.
+func (r *AccountOwnershipRepo) CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.OwnershipTransfer) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[transfer.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create ownership transfer: %w", errNotExist("account", transfer.AccountID))
+	}
+	for _, userID := range []int{transfer.FromUserID, transfer.ToUserID, transfer.RequestedBy} {
+		if _, ok := r.s.users[userID]; !ok {
+			return 0, fmt.Errorf("failed to create ownership transfer: %w", errNotExist("user", userID))
+		}
+	}
+	if transfer.Status == models.OwnershipTransferStatusPending {
+		for _, existing := range r.s.ownershipTransfers {
+			if existing.AccountID == transfer.AccountID && existing.Status == models.OwnershipTransferStatusPending {
+				return 0, fmt.Errorf("failed to create ownership transfer: %w", errDuplicate("pending transfer of the account"))
+			}
+		}
+	}
+
+	now := time.Now()
+	transfer.ID = r.s.nextID("account_ownership_transfers")
+	transfer.CreatedAt = now
+	transfer.UpdatedAt = now
+	r.s.ownershipTransfers[transfer.ID] = ownershipTransferRow(transfer)
+
+	return transfer.ID, nil
+}
+
+// UpdateStatusTx moves an ownership transfer from one status to another and records the review
+// within an existing transaction. It reports false if the transfer was not in the expected status.
+func (r *AccountOwnershipRepo) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.OwnershipTransferStatus, reviewedBy int, note string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transfer, ok := r.s.ownershipTransfers[id]
+	if !ok || transfer.Status != from {
+		return false, nil
+	}
+	if _, ok := r.s.users[reviewedBy]; !ok {
+		return false, fmt.Errorf("failed to update ownership transfer: %w", errNotExist("user", reviewedBy))
+	}
+
+	now := time.Now()
+	transfer.Status = to
+	transfer.ReviewedBy = &reviewedBy
+	transfer.ReviewNote = note
+	transfer.ReviewedAt = timePtr(now)
+	transfer.UpdatedAt = now
+
+	return true, nil
+}
+
+// AddEventTx records a step of an ownership transfer within an existing transaction
+func (r *AccountOwnershipRepo) AddEventTx(ctx context.Context, tx *sql.Tx, event *models.OwnershipTransferEvent) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.ownershipTransfers[event.TransferID]; !ok {
+		return 0, fmt.Errorf("failed to create ownership transfer event: %w", errNotExist("ownership transfer", event.TransferID))
+	}
+	if _, ok := r.s.users[event.AdminID]; !ok {
+		return 0, fmt.Errorf("failed to create ownership transfer event: %w", errNotExist("user", event.AdminID))
+	}
+
+	event.ID = r.s.nextID("account_ownership_transfer_events")
+	event.CreatedAt = time.Now()
+	r.s.ownershipEvents[event.ID] = clone(event)
+
+	return event.ID, nil
+}
+
+// ownershipTransferRow copies an ownership transfer together with its document references
+func ownershipTransferRow(transfer *models.OwnershipTransfer) *models.OwnershipTransfer {
+	t := clone(transfer)
+	t.Documents = append([]string{}, transfer.Documents...)
+	t.ReviewedBy = intPtr(transfer.ReviewedBy)
+	t.Events = nil
+	return t
+}
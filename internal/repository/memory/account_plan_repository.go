@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountPlanRepo is an in-memory implementation of the repository.AccountPlanRepository interface
+type AccountPlanRepo struct {
+	s *Store
+}
+
+// NewAccountPlanRepository creates a new AccountPlanRepo
+func NewAccountPlanRepository(s *Store) *AccountPlanRepo {
+	return &AccountPlanRepo{s: s}
+}
+
+// Create saves a new account plan in the tenant of the request
+func (r *AccountPlanRepo) Create(ctx context.Context, plan *models.AccountPlan) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	plan.Tenant = newRecordTenant(ctx, plan.Tenant)
+	if r.s.accountPlanCodeTaken(plan.Tenant, plan.Code, 0) {
+		return 0, fmt.Errorf("failed to create account plan: %w", errDuplicate("account plan code"))
+	}
+
+	plan.ID = r.s.nextID("account_plans")
+	plan.CreatedAt = time.Now()
+	plan.UpdatedAt = plan.CreatedAt
+	r.s.accountPlans[plan.ID] = accountPlanRow(plan)
+
+	return plan.ID, nil
+}
+
+// GetByID gets an account plan by ID
+func (r *AccountPlanRepo) GetByID(ctx context.Context, id int) (*models.AccountPlan, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	plan, ok := r.s.accountPlans[id]
+	if !ok || !inTenant(ctx, plan.Tenant) {
+		return nil, fmt.Errorf("account plan not found: %w", sql.ErrNoRows)
+	}
+
+	return accountPlanRow(plan), nil
+}
+
+// GetAll gets all account plans of the request's tenant, including inactive ones
+func (r *AccountPlanRepo) GetAll(ctx context.Context) ([]*models.AccountPlan, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	plans := []*models.AccountPlan{}
+	for _, plan := range rowsOf(r.s.accountPlans, func(p *models.AccountPlan) bool { return inTenant(ctx, p.Tenant) }) {
+		plans = append(plans, accountPlanRow(plan))
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		if plans[i].MonthlyFee != plans[j].MonthlyFee {
+			return plans[i].MonthlyFee < plans[j].MonthlyFee
+		}
+		return plans[i].Name < plans[j].Name
+	})
+
+	return plans, nil
+}
+
+// Update replaces the data of an account plan
+func (r *AccountPlanRepo) Update(ctx context.Context, plan *models.AccountPlan) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.accountPlans[plan.ID]
+	if !ok || !inTenant(ctx, row.Tenant) {
+		return fmt.Errorf("account plan not found: %w", sql.ErrNoRows)
+	}
+	if r.s.accountPlanCodeTaken(row.Tenant, plan.Code, plan.ID) {
+		return fmt.Errorf("failed to update account plan: %w", errDuplicate("account plan code"))
+	}
+
+	updated := accountPlanRow(plan)
+	updated.Tenant = row.Tenant
+	updated.CreatedAt = row.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.s.accountPlans[plan.ID] = updated
+
+	plan.Tenant, plan.CreatedAt, plan.UpdatedAt = updated.Tenant, updated.CreatedAt, updated.UpdatedAt
+
+	return nil
+}
+
+// GetCurrentPeriod gets the open plan period of an account
+func (r *AccountPlanRepo) GetCurrentPeriod(ctx context.Context, accountID int) (*models.AccountPlanPeriod, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, period := range r.s.planPeriods {
+		if period.AccountID == accountID && period.EndedAt == nil {
+			return accountPlanPeriodRow(period), nil
+		}
+	}
+
+	return nil, fmt.Errorf("account has no plan: %w", sql.ErrNoRows)
+}
+
+// Switch ends the open plan period of an account at a time and opens one on another plan. The
+// ended period stays unbilled until the billing job settles it.
+func (r *AccountPlanRepo) Switch(ctx context.Context, accountID int, planID int, at time.Time) (*models.AccountPlanPeriod, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[accountID]; !ok {
+		return nil, fmt.Errorf("failed to create account plan period: %w", errNotExist("account", accountID))
+	}
+	if _, ok := r.s.accountPlans[planID]; !ok {
+		return nil, fmt.Errorf("failed to create account plan period: %w", errNotExist("account plan", planID))
+	}
+
+	for _, period := range r.s.planPeriods {
+		if period.AccountID == accountID && period.EndedAt == nil {
+			period.EndedAt = timePtr(at)
+		}
+	}
+
+	period := &models.AccountPlanPeriod{
+		ID:          r.s.nextID("account_plan_periods"),
+		AccountID:   accountID,
+		PlanID:      planID,
+		StartedAt:   at,
+		BilledUntil: at,
+	}
+	r.s.planPeriods[period.ID] = period
+
+	return accountPlanPeriodRow(period), nil
+}
+
+// GetUnbilled gets the plan periods not yet billed up to a time, or to their end if they ended before it
+func (r *AccountPlanRepo) GetUnbilled(ctx context.Context, until time.Time) ([]*models.AccountPlanPeriod, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var periods []*models.AccountPlanPeriod
+	for _, period := range rowsOf(r.s.planPeriods, func(p *models.AccountPlanPeriod) bool {
+		end := until
+		if p.EndedAt != nil && p.EndedAt.Before(end) {
+			end = *p.EndedAt
+		}
+		return p.BilledUntil.Before(end)
+	}) {
+		periods = append(periods, accountPlanPeriodRow(period))
+	}
+
+	return periods, nil
+}
+
+// MarkBilledTx records that a plan period is billed up to a time
+func (r *AccountPlanRepo) MarkBilledTx(ctx context.Context, tx *sql.Tx, periodID int, until time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if period, ok := r.s.planPeriods[periodID]; ok {
+		period.BilledUntil = until
+	}
+
+	return nil
+}
+
+// accountPlanCodeTaken reports whether an account plan of the tenant other than the one with the ID
+// has the code
+func (s *Store) accountPlanCodeTaken(tenant, code string, id int) bool {
+	for _, other := range s.accountPlans {
+		if other.Tenant == tenant && other.Code == code && other.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// accountPlanRow copies an account plan together with its lists
+func accountPlanRow(plan *models.AccountPlan) *models.AccountPlan {
+	p := clone(plan)
+	p.AccountTypes = append([]models.AccountType{}, plan.AccountTypes...)
+	p.InterestTiers = append([]models.InterestTier{}, plan.InterestTiers...)
+	return p
+}
+
+// accountPlanPeriodRow copies an account plan period
+func accountPlanPeriodRow(period *models.AccountPlanPeriod) *models.AccountPlanPeriod {
+	p := clone(period)
+	if period.EndedAt != nil {
+		p.EndedAt = timePtr(*period.EndedAt)
+	}
+	return p
+}
@@ -0,0 +1,525 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountRepo is an in-memory implementation of the repository.AccountRepository interface
+type AccountRepo struct {
+	s       *Store
+	numbers models.AccountNumberScheme
+}
+
+// NewAccountRepository creates a new AccountRepo that numbers new accounts with the scheme
+func NewAccountRepository(s *Store, numbers models.AccountNumberScheme) *AccountRepo {
+	return &AccountRepo{s: s, numbers: numbers}
+}
+
+// Create creates a new account; it belongs to the tenant of its owner. A personal account becomes
+// the default of its currency if the user has no other active account in it. An account without a
+// number gets a generated one, and another one is generated while the number is taken.
+func (r *AccountRepo) Create(ctx context.Context, account *models.Account) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[account.UserID]
+	if !ok {
+		return 0, fmt.Errorf("failed to create account: %w", errNotExist("user", account.UserID))
+	}
+	if account.OrganizationID != nil {
+		if _, ok := r.s.organizations[*account.OrganizationID]; !ok {
+			return 0, fmt.Errorf("failed to create account: %w", errNotExist("organization", *account.OrganizationID))
+		}
+	}
+	if account.AccountNumber == "" {
+		for attempt := 1; ; attempt++ {
+			number, err := r.numbers.Generate(account)
+			if err != nil {
+				return 0, fmt.Errorf("failed to create account: %w", err)
+			}
+			if !r.s.accountNumberTaken(number) {
+				account.AccountNumber = number
+				break
+			}
+			if attempt == models.MaxAccountNumberAttempts {
+				return 0, fmt.Errorf("failed to create account: %w", errDuplicate("account number"))
+			}
+		}
+	} else if r.s.accountNumberTaken(account.AccountNumber) {
+		return 0, fmt.Errorf("failed to create account: %w", errDuplicate("account number"))
+	}
+	if account.Balance < 0 {
+		return 0, fmt.Errorf("failed to create account: negative balance")
+	}
+
+	row := clone(account)
+	row.ID = r.s.nextID("accounts")
+	row.OrganizationID = intPtr(account.OrganizationID)
+	row.AvailableBalance = 0
+	row.IsDefault = false
+	row.DormantSince, row.ReactivatedAt, row.LockedUntil = nil, nil, nil
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.accounts[row.ID] = &accountRow{Account: row, tenant: user.Tenant}
+	if row.OrganizationID == nil {
+		r.s.assignDefault(row.UserID, row.Currency)
+	}
+	r.s.recordAccountEvent(row.ID, models.AccountEventOpened, nil)
+
+	return row.ID, nil
+}
+
+// accountNumberTaken reports whether an account has the number
+func (s *Store) accountNumberTaken(number string) bool {
+	for _, other := range s.accounts {
+		if other.AccountNumber == number {
+			return true
+		}
+	}
+	return false
+}
+
+// GetByID gets an account by ID
+func (r *AccountRepo) GetByID(ctx context.Context, id int) (*models.Account, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.accounts[id]
+	if !ok || !inTenant(ctx, row.tenant) {
+		return nil, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	return r.s.accountView(row), nil
+}
+
+// GetByUserID gets all personal accounts for a user
+func (r *AccountRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Account, error) {
+	return r.list(ctx, func(a *accountRow) bool { return a.UserID == userID && a.OrganizationID == nil })
+}
+
+// GetByOrganizationID gets all accounts owned by an organization
+func (r *AccountRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Account, error) {
+	return r.list(ctx, func(a *accountRow) bool {
+		return a.OrganizationID != nil && *a.OrganizationID == organizationID
+	})
+}
+
+// list gets the accounts of the request's tenant that match
+func (r *AccountRepo) list(ctx context.Context, match func(*accountRow) bool) ([]*models.Account, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var accounts []*models.Account
+	for _, row := range rowsOf(r.s.accounts, func(a *accountRow) bool { return match(a) && inTenant(ctx, a.tenant) }) {
+		accounts = append(accounts, r.s.accountView(row))
+	}
+
+	return accounts, nil
+}
+
+// GetChanged gets up to limit accounts created or changed in [from, to) with an ID greater than
+// afterID, in ID order
+func (r *AccountRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Account, error) {
+	accounts, err := r.list(ctx, func(a *accountRow) bool {
+		return a.ID > afterID && !a.UpdatedAt.Before(from) && a.UpdatedAt.Before(to)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accounts) > limit {
+		accounts = accounts[:limit]
+	}
+
+	return accounts, nil
+}
+
+// GetByAccountNumber gets an account by account number
+func (r *AccountRepo) GetByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, row := range r.s.accounts {
+		if row.AccountNumber == accountNumber && inTenant(ctx, row.tenant) {
+			return r.s.accountView(row), nil
+		}
+	}
+
+	return nil, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+}
+
+// UpdateBalance updates an account's balance
+func (r *AccountRepo) UpdateBalance(ctx context.Context, id int, amount float64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.updateBalance(id, amount)
+}
+
+// UpdateBalanceTx updates an account's balance within an existing transaction
+func (r *AccountRepo) UpdateBalanceTx(ctx context.Context, tx *sql.Tx, id int, amount float64) error {
+	return r.UpdateBalance(ctx, id, amount)
+}
+
+// TransferOwnershipTx moves a personal account from one user to another within an existing
+// transaction, dropping the previous owner's settings and bill templates for it. It reports false
+// if the account is not a personal account of fromUserID.
+func (r *AccountRepo) TransferOwnershipTx(ctx context.Context, tx *sql.Tx, accountID int, fromUserID int, toUserID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[toUserID]; !ok {
+		return false, fmt.Errorf("failed to transfer account: %w", errNotExist("user", toUserID))
+	}
+
+	row, ok := r.s.accounts[accountID]
+	if !ok || row.UserID != fromUserID || row.OrganizationID != nil {
+		return false, nil
+	}
+
+	row.UserID = toUserID
+	row.IsDefault = false
+	row.UpdatedAt = time.Now()
+	if toUserID != fromUserID {
+		r.s.recordAccountEvent(accountID, models.AccountEventOwnerChanged, nil)
+	}
+
+	delete(r.s.accountSettings, accountSettingsKey{accountID: accountID, userID: fromUserID})
+	for templateID, template := range r.s.billTemplates {
+		if template.AccountID == accountID && template.UserID == fromUserID {
+			delete(r.s.billTemplates, templateID)
+		}
+	}
+
+	r.s.assignDefault(fromUserID, row.Currency)
+	r.s.assignDefault(toUserID, row.Currency)
+
+	return true, nil
+}
+
+// Update updates an account. An account keeps its default flag only while it stays active and in
+// the same currency; the user's other accounts take over the default it gives up.
+func (r *AccountRepo) Update(ctx context.Context, account *models.Account) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.accounts[account.ID]
+	if !ok {
+		return fmt.Errorf("account not found")
+	}
+
+	currency := row.Currency
+	row.IsDefault = row.IsDefault && account.Currency == currency && account.IsActive
+	row.Currency = account.Currency
+	row.AccountType = account.AccountType
+	if row.IsActive != account.IsActive {
+		if account.IsActive {
+			r.s.recordAccountEvent(row.ID, models.AccountEventActivated, nil)
+		} else {
+			r.s.recordAccountEvent(row.ID, models.AccountEventDeactivated, nil)
+		}
+	}
+	row.IsActive = account.IsActive
+	row.UpdatedAt = time.Now()
+
+	if row.OrganizationID == nil {
+		r.s.assignDefault(row.UserID, currency)
+		r.s.assignDefault(row.UserID, row.Currency)
+	}
+
+	return nil
+}
+
+// Delete deletes an account without a balance that no other record refers to; the bill
+// templates paid from it are deleted with it
+func (r *AccountRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.accounts[id]
+	if !ok {
+		return fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	if row.Balance > 0 {
+		return fmt.Errorf("cannot delete account with non-zero balance")
+	}
+
+	if r.referenced(id) {
+		return fmt.Errorf("failed to delete account: account %d is still referenced", id)
+	}
+
+	delete(r.s.accounts, id)
+	if row.OrganizationID == nil {
+		r.s.assignDefault(row.UserID, row.Currency)
+	}
+	for templateID, template := range r.s.billTemplates {
+		if template.AccountID == id {
+			delete(r.s.billTemplates, templateID)
+		}
+	}
+	for key := range r.s.accountSettings {
+		if key.accountID == id {
+			delete(r.s.accountSettings, key)
+		}
+	}
+	for eventID, event := range r.s.accountEvents {
+		if event.AccountID == id {
+			delete(r.s.accountEvents, eventID)
+		}
+	}
+
+	return nil
+}
+
+// referenced reports whether any record refers to the account
+func (r *AccountRepo) referenced(id int) bool {
+	for _, card := range r.s.cards {
+		if card.AccountID == id {
+			return true
+		}
+	}
+	for _, transaction := range r.s.transactions {
+		if touches(transaction, id) {
+			return true
+		}
+	}
+	for _, credit := range r.s.credits {
+		if credit.AccountID == id {
+			return true
+		}
+	}
+	for _, hold := range r.s.holds {
+		if hold.AccountID == id {
+			return true
+		}
+	}
+	for _, merchant := range r.s.merchants {
+		if merchant.SettlementAccountID == id {
+			return true
+		}
+	}
+	for _, statement := range r.s.statements {
+		if statement.AccountID == id {
+			return true
+		}
+	}
+	for _, transfer := range r.s.ownershipTransfers {
+		if transfer.AccountID == id {
+			return true
+		}
+	}
+	for _, delegation := range r.s.delegations {
+		if delegation.AccountID == id {
+			return true
+		}
+	}
+	for _, payroll := range r.s.payrolls {
+		if payroll.AccountID == id {
+			return true
+		}
+	}
+	for _, item := range r.s.payrollItems {
+		if item.AccountID != nil && *item.AccountID == id {
+			return true
+		}
+	}
+	for _, escrow := range r.s.escrows {
+		if escrow.BuyerAccountID == id || escrow.SellerAccountID == id {
+			return true
+		}
+	}
+	for _, invoice := range r.s.invoices {
+		if invoice.AccountID == id || (invoice.PayerAccountID != nil && *invoice.PayerAccountID == id) {
+			return true
+		}
+	}
+	for _, subscription := range r.s.subscriptions {
+		if subscription.AccountID == id {
+			return true
+		}
+	}
+	for _, deposit := range r.s.chequeDeposits {
+		if deposit.AccountID == id {
+			return true
+		}
+	}
+	for _, transfer := range r.s.intlTransfers {
+		if transfer.SourceAccountID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MarkDormant flags active accounts without transactions since the cutoff as dormant and returns them.
+// Credit accounts are skipped, and a reactivation restarts the inactivity period.
+func (r *AccountRepo) MarkDormant(ctx context.Context, cutoff time.Time) ([]*models.Account, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	var accounts []*models.Account
+	for _, row := range rowsOf(r.s.accounts, func(a *accountRow) bool {
+		return a.IsActive && a.DormantSince == nil && a.AccountType != models.AccountTypeCredit
+	}) {
+		since := row.CreatedAt
+		if row.ReactivatedAt != nil {
+			since = *row.ReactivatedAt
+		}
+		if !since.Before(cutoff) || r.activeSince(row.ID, cutoff) {
+			continue
+		}
+
+		row.DormantSince = timePtr(now)
+		row.UpdatedAt = now
+		r.s.recordAccountEvent(row.ID, models.AccountEventDormant, nil)
+		accounts = append(accounts, r.s.accountView(row))
+	}
+
+	return accounts, nil
+}
+
+// activeSince reports whether the account has a transaction dated at or after the time
+func (r *AccountRepo) activeSince(id int, since time.Time) bool {
+	for _, transaction := range r.s.transactions {
+		if touches(transaction, id) && !transaction.TransactionDate.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reactivate lifts the dormant flag of an account; it reports false if the account was not dormant
+func (r *AccountRepo) Reactivate(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.accounts[id]
+	if !ok || row.DormantSince == nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	row.DormantSince = nil
+	row.ReactivatedAt = timePtr(now)
+	row.UpdatedAt = now
+	r.s.recordAccountEvent(id, models.AccountEventReactivated, nil)
+
+	return true, nil
+}
+
+// GetDefault gets the default personal account of a user in a currency
+func (r *AccountRepo) GetDefault(ctx context.Context, userID int, currency models.Currency) (*models.Account, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, row := range r.s.accounts {
+		if row.UserID == userID && row.Currency == currency && row.IsDefault && inTenant(ctx, row.tenant) {
+			return r.s.accountView(row), nil
+		}
+	}
+
+	return nil, fmt.Errorf("default account not found: %w", sql.ErrNoRows)
+}
+
+// SetDefault makes an active personal account the default of its currency in place of the
+// user's current default
+func (r *AccountRepo) SetDefault(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.accounts[id]
+	if !ok || row.OrganizationID != nil || !row.IsActive {
+		return fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	now := time.Now()
+	for _, other := range r.s.accounts {
+		if other.UserID == row.UserID && other.Currency == row.Currency && other.IsDefault && other.ID != id {
+			other.IsDefault = false
+			other.UpdatedAt = now
+		}
+	}
+	if !row.IsDefault {
+		row.IsDefault = true
+		row.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// assignDefault keeps exactly one default among a user's active personal accounts in a currency:
+// inactive accounts lose the flag, and the oldest active account gets it if none has it
+func (s *Store) assignDefault(userID int, currency models.Currency) {
+	rows := rowsOf(s.accounts, func(a *accountRow) bool { return a.UserID == userID && a.Currency == currency })
+
+	var oldest *accountRow
+	for _, row := range rows {
+		if row.IsDefault && !row.IsActive {
+			row.IsDefault = false
+		}
+		if row.IsDefault {
+			return
+		}
+		if oldest == nil && row.OrganizationID == nil && row.IsActive {
+			oldest = row
+		}
+	}
+
+	if oldest != nil {
+		oldest.IsDefault = true
+	}
+}
+
+// accountView copies an account and computes its available balance
+func (s *Store) accountView(row *accountRow) *models.Account {
+	account := clone(row.Account)
+	account.OrganizationID = intPtr(row.OrganizationID)
+	account.AvailableBalance = row.Balance - s.activeHolds(row.ID)
+	return account
+}
+
+// activeHolds sums the unexpired active holds of an account
+func (s *Store) activeHolds(accountID int) float64 {
+	now := time.Now()
+	var total float64
+	for _, hold := range s.holds {
+		if hold.AccountID == accountID && holdActive(hold, now) {
+			total += hold.Amount
+		}
+	}
+	return total
+}
+
+// updateBalance changes a balance unless it would go negative or a debit would use funds
+// reserved by active holds
+func (s *Store) updateBalance(id int, amount float64) error {
+	row, ok := s.accounts[id]
+	if !ok {
+		return fmt.Errorf("failed to get current balance: %w", sql.ErrNoRows)
+	}
+
+	newBalance := row.Balance + amount
+	available := row.Balance - s.activeHolds(id)
+	if newBalance < 0 || (amount < 0 && available+amount < 0) {
+		return fmt.Errorf("insufficient funds")
+	}
+
+	row.Balance = newBalance
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// touches reports whether a transaction moves money from or to the account
+func touches(transaction *models.Transaction, accountID int) bool {
+	return (transaction.SourceAccountID != nil && *transaction.SourceAccountID == accountID) ||
+		(transaction.DestinationAccountID != nil && *transaction.DestinationAccountID == accountID)
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountSettingsRepo is an in-memory implementation of the repository.AccountSettingsRepository interface
+type AccountSettingsRepo struct {
+	s *Store
+}
+
+// NewAccountSettingsRepository creates a new AccountSettingsRepo
+func NewAccountSettingsRepository(s *Store) *AccountSettingsRepo {
+	return &AccountSettingsRepo{s: s}
+}
+
+// Get gets a user's settings of an account
+func (r *AccountSettingsRepo) Get(ctx context.Context, accountID int, userID int) (*models.AccountSettings, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	settings, ok := r.s.accountSettings[accountSettingsKey{accountID: accountID, userID: userID}]
+	if !ok {
+		return nil, fmt.Errorf("account settings not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(settings), nil
+}
+
+// GetByUserID gets a user's settings of all accounts
+func (r *AccountSettingsRepo) GetByUserID(ctx context.Context, userID int) ([]*models.AccountSettings, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	settings := []*models.AccountSettings{}
+	for key, row := range r.s.accountSettings {
+		if key.userID == userID {
+			settings = append(settings, clone(row))
+		}
+	}
+	sort.Slice(settings, func(i, j int) bool { return settings[i].AccountID < settings[j].AccountID })
+
+	return settings, nil
+}
+
+// Save creates or replaces a user's settings of an account
+func (r *AccountSettingsRepo) Save(ctx context.Context, settings *models.AccountSettings) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[settings.AccountID]; !ok {
+		return fmt.Errorf("failed to save account settings: %w", errNotExist("account", settings.AccountID))
+	}
+	if _, ok := r.s.users[settings.UserID]; !ok {
+		return fmt.Errorf("failed to save account settings: %w", errNotExist("user", settings.UserID))
+	}
+
+	if settings.SortOrder < 0 {
+		return fmt.Errorf("failed to save account settings: sort order must not be negative")
+	}
+
+	row := clone(settings)
+	row.UpdatedAt = time.Now()
+	r.s.accountSettings[accountSettingsKey{accountID: row.AccountID, userID: row.UserID}] = row
+
+	settings.UpdatedAt = row.UpdatedAt
+
+	return nil
+}
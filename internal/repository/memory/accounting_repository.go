@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountingRepo is an in-memory implementation of the repository.AccountingRepository interface
+type AccountingRepo struct {
+	s *Store
+}
+
+// NewAccountingRepository creates a new AccountingRepo
+func NewAccountingRepository(s *Store) *AccountingRepo {
+	return &AccountingRepo{s: s}
+}
+
+// GetEntries gets the completed transactions of the request's tenant in [from, to) with the numbers
+// of their accounts
+func (r *AccountingRepo) GetEntries(ctx context.Context, from, to time.Time) ([]*models.AccountingEntry, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transactions := rowsOf(r.s.transactions, func(t *models.Transaction) bool {
+		return t.Status == models.TransactionStatusCompleted && !t.TransactionDate.Before(from) && t.TransactionDate.Before(to) &&
+			r.s.transactionInTenant(ctx, t)
+	})
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].TransactionDate.Before(transactions[j].TransactionDate)
+	})
+
+	entries := []*models.AccountingEntry{}
+	for _, transaction := range transactions {
+		entries = append(entries, &models.AccountingEntry{
+			TransactionID:      transaction.ID,
+			Date:               transaction.TransactionDate,
+			Type:               transaction.TransactionType,
+			SourceAccount:      r.s.accountNumber(transaction.SourceAccountID),
+			DestinationAccount: r.s.accountNumber(transaction.DestinationAccountID),
+			Amount:             transaction.Amount,
+			Currency:           transaction.Currency,
+			Description:        transaction.Description,
+		})
+	}
+
+	return entries, nil
+}
+
+// GetBalanceMismatches gets the accounts whose balance differs from the sum of their transactions
+// that affect balances
+func (r *AccountingRepo) GetBalanceMismatches(ctx context.Context) ([]*models.BalanceMismatch, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	net := make(map[int]float64)
+	for _, transaction := range r.s.transactions {
+		if !transaction.Status.AffectsBalance() {
+			continue
+		}
+		if transaction.DestinationAccountID != nil {
+			net[*transaction.DestinationAccountID] += transaction.Amount
+		}
+		if transaction.SourceAccountID != nil {
+			net[*transaction.SourceAccountID] -= transaction.Amount
+		}
+	}
+
+	mismatches := []*models.BalanceMismatch{}
+	for _, account := range rowsOf(r.s.accounts, func(*accountRow) bool { return true }) {
+		// Balances are kept in cents, so smaller differences are float rounding
+		if math.Abs(account.Balance-net[account.ID]) < 0.005 {
+			continue
+		}
+		mismatches = append(mismatches, &models.BalanceMismatch{
+			AccountID:     account.ID,
+			AccountNumber: account.AccountNumber,
+			Currency:      account.Currency,
+			Balance:       account.Balance,
+			LedgerBalance: net[account.ID],
+		})
+	}
+
+	return mismatches, nil
+}
+
+// accountNumber returns the number of an optional account, or "" if there is none
+func (s *Store) accountNumber(id *int) string {
+	if id == nil {
+		return ""
+	}
+	if account, ok := s.accounts[*id]; ok {
+		return account.AccountNumber
+	}
+	return ""
+}
@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AnnouncementRepo is an in-memory implementation of the repository.AnnouncementRepository interface
+type AnnouncementRepo struct {
+	s *Store
+}
+
+// NewAnnouncementRepository creates a new AnnouncementRepo
+func NewAnnouncementRepository(s *Store) *AnnouncementRepo {
+	return &AnnouncementRepo{s: s}
+}
+
+// Create saves a queued announcement together with its recipients: the customers in its segment
+func (r *AnnouncementRepo) Create(ctx context.Context, announcement *models.Announcement) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[announcement.CreatedBy]; !ok {
+		return 0, fmt.Errorf("failed to create announcement: %w", errNotExist("user", announcement.CreatedBy))
+	}
+
+	announcement.ID = r.s.nextID("announcements")
+	announcement.Status = models.AnnouncementStatusQueued
+	announcement.CreatedAt = time.Now()
+	announcement.CompletedAt = nil
+	announcement.Recipients, announcement.Sent, announcement.Failed = 0, 0, 0
+
+	for _, user := range rowsOf(r.s.users, func(u *models.User) bool { return r.inSegment(u, announcement.Segment) }) {
+		id := r.s.nextID("announcement_recipients")
+		r.s.announcementQueue[id] = &models.AnnouncementRecipient{
+			ID:             id,
+			AnnouncementID: announcement.ID,
+			UserID:         user.ID,
+			Status:         models.AnnouncementRecipientStatusPending,
+		}
+		announcement.Recipients++
+	}
+
+	r.s.announcements[announcement.ID] = announcementRow(announcement)
+
+	return announcement.ID, nil
+}
+
+// GetAll gets the announcements of the request's tenant, the one of their segment, newest first
+func (r *AnnouncementRepo) GetAll(ctx context.Context) ([]*models.Announcement, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	announcements := []*models.Announcement{}
+	for _, announcement := range rowsOf(r.s.announcements, func(a *models.Announcement) bool {
+		return inTenant(ctx, a.Segment.Tenant)
+	}) {
+		announcements = append(announcements, r.counted(announcement))
+	}
+	sort.SliceStable(announcements, func(i, j int) bool { return announcements[i].ID > announcements[j].ID })
+
+	return announcements, nil
+}
+
+// GetByID gets an announcement of the request's tenant, the one of its segment, by ID
+func (r *AnnouncementRepo) GetByID(ctx context.Context, id int) (*models.Announcement, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	announcement, ok := r.s.announcements[id]
+	if !ok || !inTenant(ctx, announcement.Segment.Tenant) {
+		return nil, fmt.Errorf("announcement not found: %w", sql.ErrNoRows)
+	}
+
+	return r.counted(announcement), nil
+}
+
+// Cancel stops the delivery of a queued announcement, reporting whether it was queued
+func (r *AnnouncementRepo) Cancel(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	announcement, ok := r.s.announcements[id]
+	if !ok || announcement.Status != models.AnnouncementStatusQueued {
+		return false, nil
+	}
+
+	announcement.Status = models.AnnouncementStatusCancelled
+	announcement.CompletedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// GetPendingRecipients gets the recipients of queued announcements not reached yet, those of the
+// oldest announcement first
+func (r *AnnouncementRepo) GetPendingRecipients(ctx context.Context, limit int) ([]*models.AnnouncementRecipient, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	recipients := []*models.AnnouncementRecipient{}
+	for _, recipient := range rowsOf(r.s.announcementQueue, func(rc *models.AnnouncementRecipient) bool {
+		announcement, ok := r.s.announcements[rc.AnnouncementID]
+		return rc.Status == models.AnnouncementRecipientStatusPending && ok && announcement.Status == models.AnnouncementStatusQueued
+	}) {
+		recipients = append(recipients, clone(recipient))
+	}
+	sort.SliceStable(recipients, func(i, j int) bool { return recipients[i].AnnouncementID < recipients[j].AnnouncementID })
+
+	if len(recipients) > limit {
+		recipients = recipients[:limit]
+	}
+
+	return recipients, nil
+}
+
+// MarkRecipient records whether an announcement reached a recipient
+func (r *AnnouncementRepo) MarkRecipient(ctx context.Context, id int, status models.AnnouncementRecipientStatus, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	recipient, ok := r.s.announcementQueue[id]
+	if !ok || recipient.Status != models.AnnouncementRecipientStatusPending {
+		return nil
+	}
+
+	recipient.Status = status
+	recipient.Error = reason
+	recipient.SentAt = timePtr(time.Now())
+
+	return nil
+}
+
+// CompleteDelivered completes the queued announcements with no recipients left to reach,
+// returning how many
+func (r *AnnouncementRepo) CompleteDelivered(ctx context.Context) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	pending := map[int]bool{}
+	for _, recipient := range r.s.announcementQueue {
+		if recipient.Status == models.AnnouncementRecipientStatusPending {
+			pending[recipient.AnnouncementID] = true
+		}
+	}
+
+	completed := 0
+	now := time.Now()
+	for _, announcement := range r.s.announcements {
+		if announcement.Status == models.AnnouncementStatusQueued && !pending[announcement.ID] {
+			announcement.Status = models.AnnouncementStatusCompleted
+			announcement.CompletedAt = timePtr(now)
+			completed++
+		}
+	}
+
+	return completed, nil
+}
+
+// inSegment reports whether a user is a customer in the segment of an announcement
+func (r *AnnouncementRepo) inSegment(user *models.User, segment models.AnnouncementSegment) bool {
+	if user.Role != models.UserRoleCustomer || (segment.Tenant != "" && user.Tenant != segment.Tenant) {
+		return false
+	}
+	if segment.Audience == models.AnnouncementAudienceAll {
+		return true
+	}
+
+	for _, credit := range r.s.credits {
+		if credit.UserID != user.ID {
+			continue
+		}
+		if credit.Status == models.CreditStatusOverdue ||
+			(segment.Audience == models.AnnouncementAudienceActiveCredits && credit.Status == models.CreditStatusActive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// counted copies an announcement with the counts of its recipients
+func (r *AnnouncementRepo) counted(announcement *models.Announcement) *models.Announcement {
+	a := announcementRow(announcement)
+	for _, recipient := range r.s.announcementQueue {
+		if recipient.AnnouncementID != a.ID {
+			continue
+		}
+		a.Recipients++
+		switch recipient.Status {
+		case models.AnnouncementRecipientStatusSent:
+			a.Sent++
+		case models.AnnouncementRecipientStatusFailed:
+			a.Failed++
+		}
+	}
+	return a
+}
+
+// announcementRow copies an announcement, leaving out the counts of its recipients
+func announcementRow(announcement *models.Announcement) *models.Announcement {
+	a := clone(announcement)
+	a.Recipients, a.Sent, a.Failed = 0, 0, 0
+	if announcement.CompletedAt != nil {
+		a.CompletedAt = timePtr(*announcement.CompletedAt)
+	}
+	return a
+}
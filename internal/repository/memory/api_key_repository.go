@@ -0,0 +1,155 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// APIKeyRepo is an in-memory implementation of the repository.APIKeyRepository interface
+type APIKeyRepo struct {
+	s *Store
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepo
+func NewAPIKeyRepository(s *Store) *APIKeyRepo {
+	return &APIKeyRepo{s: s}
+}
+
+// Create creates a new API key
+func (r *APIKeyRepo) Create(ctx context.Context, key *models.APIKey) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[key.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create API key: %w", errNotExist("user", key.UserID))
+	}
+	for _, other := range r.s.apiKeys {
+		if other.KeyHash == key.KeyHash {
+			return 0, fmt.Errorf("failed to create API key: %w", errDuplicate("key"))
+		}
+	}
+
+	now := time.Now()
+	key.ID = r.s.nextID("api_keys")
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+	key.CreatedAt = now
+	key.UpdatedAt = now
+	r.s.apiKeys[key.ID] = cloneAPIKey(key)
+
+	return key.ID, nil
+}
+
+// GetByID gets an API key by ID
+func (r *APIKeyRepo) GetByID(ctx context.Context, id int) (*models.APIKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	key, ok := r.s.apiKeys[id]
+	if !ok {
+		return nil, fmt.Errorf("API key not found: %w", sql.ErrNoRows)
+	}
+
+	return cloneAPIKey(key), nil
+}
+
+// GetByKeyHash gets an API key by the hash of the key
+func (r *APIKeyRepo) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, key := range r.s.apiKeys {
+		if key.KeyHash == keyHash {
+			return cloneAPIKey(key), nil
+		}
+	}
+
+	return nil, fmt.Errorf("API key not found: %w", sql.ErrNoRows)
+}
+
+// GetByUserID gets all API keys of a user, including revoked ones, newest first
+func (r *APIKeyRepo) GetByUserID(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	keys := []*models.APIKey{}
+	for _, key := range rowsOf(r.s.apiKeys, func(k *models.APIKey) bool { return k.UserID == userID }) {
+		keys = append(keys, cloneAPIKey(key))
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].ID > keys[j].ID })
+
+	return keys, nil
+}
+
+// UpdateKey replaces the key of an API key that is not revoked
+func (r *APIKeyRepo) UpdateKey(ctx context.Context, id int, keyHash string, keyPrefix string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	key, ok := r.s.apiKeys[id]
+	if !ok || key.RevokedAt != nil {
+		return fmt.Errorf("API key not found: %w", sql.ErrNoRows)
+	}
+	for _, other := range r.s.apiKeys {
+		if other.KeyHash == keyHash {
+			return fmt.Errorf("failed to update API key: %w", errDuplicate("key"))
+		}
+	}
+
+	key.KeyHash = keyHash
+	key.KeyPrefix = keyPrefix
+	key.LastUsedAt = nil
+	key.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Revoke revokes an API key. It reports false if the key was revoked already.
+func (r *APIKeyRepo) Revoke(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	key, ok := r.s.apiKeys[id]
+	if !ok || key.RevokedAt != nil {
+		return false, nil
+	}
+
+	now := time.Now()
+	key.RevokedAt = timePtr(now)
+	key.UpdatedAt = now
+
+	return true, nil
+}
+
+// MarkUsed records when an API key was last used
+func (r *APIKeyRepo) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if key, ok := r.s.apiKeys[id]; ok {
+		key.LastUsedAt = timePtr(usedAt)
+	}
+
+	return nil
+}
+
+// cloneAPIKey copies an API key with its scopes and times, so callers cannot change the stored one
+func cloneAPIKey(key *models.APIKey) *models.APIKey {
+	c := clone(key)
+	c.Scopes = append([]models.Scope(nil), key.Scopes...)
+	if key.ExpiresAt != nil {
+		c.ExpiresAt = timePtr(*key.ExpiresAt)
+	}
+	if key.LastUsedAt != nil {
+		c.LastUsedAt = timePtr(*key.LastUsedAt)
+	}
+	if key.RevokedAt != nil {
+		c.RevokedAt = timePtr(*key.RevokedAt)
+	}
+	return c
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ApprovalPolicyRepo is an in-memory implementation of the repository.ApprovalPolicyRepository interface
+type ApprovalPolicyRepo struct {
+	s *Store
+}
+
+// NewApprovalPolicyRepository creates a new ApprovalPolicyRepo
+func NewApprovalPolicyRepository(s *Store) *ApprovalPolicyRepo {
+	return &ApprovalPolicyRepo{s: s}
+}
+
+// GetByOrganizationID gets the approval policies of an organization ordered by minimum amount
+func (r *ApprovalPolicyRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.ApprovalPolicy, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var policies []*models.ApprovalPolicy
+	for _, policy := range rowsOf(r.s.approvalPolicies, func(p *models.ApprovalPolicy) bool {
+		return p.OrganizationID == organizationID
+	}) {
+		policies = append(policies, clone(policy))
+	}
+	sort.SliceStable(policies, func(i, j int) bool { return policies[i].MinAmount < policies[j].MinAmount })
+
+	return policies, nil
+}
+
+// Replace replaces all approval policies of an organization with the given rules and records the
+// new limits in the activity feed of the organization's accounts
+func (r *ApprovalPolicyRepo) Replace(ctx context.Context, organizationID int, rules []models.ApprovalPolicyRule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.organizations[organizationID]; !ok {
+		return fmt.Errorf("failed to create approval policy: %w", errNotExist("organization", organizationID))
+	}
+
+	seen := make(map[float64]bool, len(rules))
+	for _, rule := range rules {
+		if seen[rule.MinAmount] {
+			return fmt.Errorf("failed to create approval policy: %w", errDuplicate("minimum amount"))
+		}
+		if rule.MinAmount <= 0 || rule.RequiredApprovals <= 0 {
+			return fmt.Errorf("failed to create approval policy: invalid rule")
+		}
+		seen[rule.MinAmount] = true
+	}
+
+	for id, policy := range r.s.approvalPolicies {
+		if policy.OrganizationID == organizationID {
+			delete(r.s.approvalPolicies, id)
+		}
+	}
+
+	now := time.Now()
+	for _, rule := range rules {
+		policy := &models.ApprovalPolicy{
+			ID:                r.s.nextID("approval_policies"),
+			OrganizationID:    organizationID,
+			MinAmount:         rule.MinAmount,
+			RequiredApprovals: rule.RequiredApprovals,
+			CreatedAt:         now,
+		}
+		r.s.approvalPolicies[policy.ID] = policy
+	}
+	r.s.recordLimitsChanged(organizationID, rules)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// BillPaymentRepo is an in-memory implementation of the repository.BillPaymentRepository interface
+type BillPaymentRepo struct {
+	s *Store
+}
+
+// NewBillPaymentRepository creates a new BillPaymentRepo
+func NewBillPaymentRepository(s *Store) *BillPaymentRepo {
+	return &BillPaymentRepo{s: s}
+}
+
+// CreateTx records a bill payment within an existing transaction
+func (r *BillPaymentRepo) CreateTx(ctx context.Context, tx *sql.Tx, payment *models.BillPayment) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[payment.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create bill payment: %w", errNotExist("user", payment.UserID))
+	}
+	if _, ok := r.s.billProviders[payment.ProviderID]; !ok {
+		return 0, fmt.Errorf("failed to create bill payment: %w", errNotExist("bill provider", payment.ProviderID))
+	}
+	if _, ok := r.s.accounts[payment.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create bill payment: %w", errNotExist("account", payment.AccountID))
+	}
+	if _, ok := r.s.transactions[payment.TransactionID]; !ok {
+		return 0, fmt.Errorf("failed to create bill payment: %w", errNotExist("transaction", payment.TransactionID))
+	}
+	if payment.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create bill payment: amount must be positive")
+	}
+
+	row := clone(payment)
+	row.ID = r.s.nextID("bill_payments")
+	row.ProviderName = ""
+	row.Fields = copyFields(payment.Fields)
+	row.CreatedAt = time.Now()
+	r.s.billPayments[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets a bill payment by ID
+func (r *BillPaymentRepo) GetByID(ctx context.Context, id int) (*models.BillPayment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	payment, ok := r.s.billPayments[id]
+	if !ok {
+		return nil, fmt.Errorf("bill payment not found: %w", sql.ErrNoRows)
+	}
+
+	return r.view(payment), nil
+}
+
+// GetByUserID gets the bill payments of a user, newest first
+func (r *BillPaymentRepo) GetByUserID(ctx context.Context, userID int) ([]*models.BillPayment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	payments := []*models.BillPayment{}
+	for _, payment := range rowsOf(r.s.billPayments, func(p *models.BillPayment) bool { return p.UserID == userID }) {
+		payments = append(payments, r.view(payment))
+	}
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].CreatedAt.After(payments[j].CreatedAt) })
+
+	return payments, nil
+}
+
+// view copies a bill payment and fills in the provider name
+func (r *BillPaymentRepo) view(payment *models.BillPayment) *models.BillPayment {
+	p := clone(payment)
+	p.ProviderName = r.s.providerName(payment.ProviderID)
+	p.Fields = copyFields(payment.Fields)
+	return p
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"banking-service/internal/models"
+)
+
+// BillProviderRepo is an in-memory implementation of the repository.BillProviderRepository interface
+type BillProviderRepo struct {
+	s *Store
+}
+
+// NewBillProviderRepository creates a new BillProviderRepo
+func NewBillProviderRepository(s *Store) *BillProviderRepo {
+	return &BillProviderRepo{s: s}
+}
+
+// GetByID gets a bill provider by ID
+func (r *BillProviderRepo) GetByID(ctx context.Context, id int) (*models.BillProvider, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	provider, ok := r.s.billProviders[id]
+	if !ok {
+		return nil, fmt.Errorf("bill provider not found: %w", sql.ErrNoRows)
+	}
+
+	return billProviderRow(provider), nil
+}
+
+// GetActive gets the active providers, optionally of one category, ordered by category and name
+func (r *BillProviderRepo) GetActive(ctx context.Context, category models.BillCategory) ([]*models.BillProvider, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	providers := []*models.BillProvider{}
+	for _, provider := range rowsOf(r.s.billProviders, func(p *models.BillProvider) bool {
+		return p.IsActive && (category == "" || p.Category == category)
+	}) {
+		providers = append(providers, billProviderRow(provider))
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		if providers[i].Category != providers[j].Category {
+			return providers[i].Category < providers[j].Category
+		}
+		return providers[i].Name < providers[j].Name
+	})
+
+	return providers, nil
+}
+
+// billProviderRow copies a bill provider together with its fields
+func billProviderRow(provider *models.BillProvider) *models.BillProvider {
+	p := clone(provider)
+	p.Fields = append([]models.BillField(nil), provider.Fields...)
+	return p
+}
+
+// copyFields copies the account fields of a bill payment or template
+func copyFields(fields map[string]string) map[string]string {
+	if fields == nil {
+		return nil
+	}
+	c := make(map[string]string, len(fields))
+	for name, value := range fields {
+		c[name] = value
+	}
+	return c
+}
+
+// providerName returns the name of a bill provider
+func (s *Store) providerName(id int) string {
+	if provider, ok := s.billProviders[id]; ok {
+		return provider.Name
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// BillTemplateRepo is an in-memory implementation of the repository.BillTemplateRepository interface
+type BillTemplateRepo struct {
+	s *Store
+}
+
+// NewBillTemplateRepository creates a new BillTemplateRepo
+func NewBillTemplateRepository(s *Store) *BillTemplateRepo {
+	return &BillTemplateRepo{s: s}
+}
+
+// Create creates a new bill template
+func (r *BillTemplateRepo) Create(ctx context.Context, template *models.BillTemplate) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[template.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create bill template: %w", errNotExist("user", template.UserID))
+	}
+	if _, ok := r.s.billProviders[template.ProviderID]; !ok {
+		return 0, fmt.Errorf("failed to create bill template: %w", errNotExist("bill provider", template.ProviderID))
+	}
+	if _, ok := r.s.accounts[template.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create bill template: %w", errNotExist("account", template.AccountID))
+	}
+
+	row := clone(template)
+	row.ID = r.s.nextID("bill_templates")
+	row.ProviderName = ""
+	row.Fields = copyFields(template.Fields)
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.billTemplates[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets a bill template by ID
+func (r *BillTemplateRepo) GetByID(ctx context.Context, id int) (*models.BillTemplate, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	template, ok := r.s.billTemplates[id]
+	if !ok {
+		return nil, fmt.Errorf("bill template not found: %w", sql.ErrNoRows)
+	}
+
+	return r.view(template), nil
+}
+
+// GetByUserID gets the bill templates of a user ordered by name
+func (r *BillTemplateRepo) GetByUserID(ctx context.Context, userID int) ([]*models.BillTemplate, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	templates := []*models.BillTemplate{}
+	for _, template := range rowsOf(r.s.billTemplates, func(t *models.BillTemplate) bool { return t.UserID == userID }) {
+		templates = append(templates, r.view(template))
+	}
+	sort.SliceStable(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	return templates, nil
+}
+
+// Delete deletes a bill template of a user
+func (r *BillTemplateRepo) Delete(ctx context.Context, id int, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	template, ok := r.s.billTemplates[id]
+	if !ok || template.UserID != userID {
+		return fmt.Errorf("bill template not found")
+	}
+
+	delete(r.s.billTemplates, id)
+
+	return nil
+}
+
+// view copies a bill template and fills in the provider name
+func (r *BillTemplateRepo) view(template *models.BillTemplate) *models.BillTemplate {
+	t := clone(template)
+	t.ProviderName = r.s.providerName(template.ProviderID)
+	t.Fields = copyFields(template.Fields)
+	return t
+}
@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CardProductRepo is an in-memory implementation of the repository.CardProductRepository interface
+type CardProductRepo struct {
+	s *Store
+}
+
+// NewCardProductRepository creates a new CardProductRepo
+func NewCardProductRepository(s *Store) *CardProductRepo {
+	return &CardProductRepo{s: s}
+}
+
+// Create saves a new card product in the tenant of the request
+func (r *CardProductRepo) Create(ctx context.Context, product *models.CardProduct) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	product.Tenant = newRecordTenant(ctx, product.Tenant)
+	if r.s.cardProductCodeTaken(product.Tenant, product.Code, 0) {
+		return 0, fmt.Errorf("failed to create card product: %w", errDuplicate("card product code"))
+	}
+
+	product.ID = r.s.nextID("card_products")
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = product.CreatedAt
+	r.s.cardProducts[product.ID] = cardProductRow(product)
+
+	return product.ID, nil
+}
+
+// GetByID gets a card product by ID
+func (r *CardProductRepo) GetByID(ctx context.Context, id int) (*models.CardProduct, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	product, ok := r.s.cardProducts[id]
+	if !ok || !inTenant(ctx, product.Tenant) {
+		return nil, fmt.Errorf("card product not found: %w", sql.ErrNoRows)
+	}
+
+	return cardProductRow(product), nil
+}
+
+// GetAll gets all card products of the request's tenant, including inactive ones
+func (r *CardProductRepo) GetAll(ctx context.Context) ([]*models.CardProduct, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	products := []*models.CardProduct{}
+	for _, product := range rowsOf(r.s.cardProducts, func(p *models.CardProduct) bool { return inTenant(ctx, p.Tenant) }) {
+		products = append(products, cardProductRow(product))
+	}
+	sort.SliceStable(products, func(i, j int) bool { return products[i].Name < products[j].Name })
+
+	return products, nil
+}
+
+// Update replaces the data of a card product; cards already issued keep their numbers and type
+func (r *CardProductRepo) Update(ctx context.Context, product *models.CardProduct) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cardProducts[product.ID]
+	if !ok || !inTenant(ctx, row.Tenant) {
+		return fmt.Errorf("card product not found: %w", sql.ErrNoRows)
+	}
+	if r.s.cardProductCodeTaken(row.Tenant, product.Code, product.ID) {
+		return fmt.Errorf("failed to update card product: %w", errDuplicate("card product code"))
+	}
+
+	updated := cardProductRow(product)
+	updated.Tenant = row.Tenant
+	updated.CreatedAt = row.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.s.cardProducts[product.ID] = updated
+
+	product.Tenant, product.CreatedAt, product.UpdatedAt = updated.Tenant, updated.CreatedAt, updated.UpdatedAt
+
+	return nil
+}
+
+// cardProductCodeTaken reports whether a card product of the tenant other than the one with the ID
+// has the code
+func (s *Store) cardProductCodeTaken(tenant, code string, id int) bool {
+	for _, other := range s.cardProducts {
+		if other.Tenant == tenant && other.Code == code && other.ID != id {
+			return true
+		}
+	}
+	return false
+}
+
+// cardProductRow copies a card product together with its lists
+func cardProductRow(product *models.CardProduct) *models.CardProduct {
+	p := clone(product)
+	p.BINs = append([]string{}, product.BINs...)
+	p.AccountTypes = append([]models.AccountType{}, product.AccountTypes...)
+	p.Cashback.Rules = append([]models.CashbackRule{}, product.Cashback.Rules...)
+	return p
+}
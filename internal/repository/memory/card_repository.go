@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CardRepo is an in-memory implementation of the repository.CardRepository interface
+type CardRepo struct {
+	s *Store
+}
+
+// NewCardRepository creates a new CardRepo
+func NewCardRepository(s *Store) *CardRepo {
+	return &CardRepo{s: s}
+}
+
+// Create creates a new card; it belongs to the tenant of its account
+func (r *CardRepo) Create(ctx context.Context, card *models.Card) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[card.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create card: %w", errNotExist("account", card.AccountID))
+	}
+	if _, ok := r.s.cardProducts[card.ProductID]; !ok {
+		return 0, fmt.Errorf("failed to create card: %w", errNotExist("card product", card.ProductID))
+	}
+	for _, other := range r.s.cards {
+		if other.CardNumberHMAC == card.CardNumberHMAC {
+			return 0, fmt.Errorf("failed to create card: %w", errDuplicate("card number"))
+		}
+	}
+
+	row := cardRow(card)
+	row.ID = r.s.nextID("cards")
+	row.CardNumber, row.ExpiryDate, row.CVV = "", "", ""
+	row.PINHash, row.PINAttempts = "", 0
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.cards[row.ID] = row
+	r.s.recordAccountEvent(row.AccountID, models.AccountEventCardCreated, &row.ID)
+
+	return row.ID, nil
+}
+
+// GetByID gets a card by ID
+func (r *CardRepo) GetByID(ctx context.Context, id int) (*models.Card, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	card, ok := r.s.cards[id]
+	if !ok || !r.s.accountInTenant(ctx, card.AccountID) {
+		return nil, fmt.Errorf("card not found: %w", sql.ErrNoRows)
+	}
+
+	return cardRow(card), nil
+}
+
+// GetByAccountID gets all cards for an account
+func (r *CardRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Card, error) {
+	return r.list(ctx, func(c *models.Card) bool { return c.AccountID == accountID })
+}
+
+// GetByUserID gets all cards for a user through their personal accounts
+func (r *CardRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Card, error) {
+	return r.list(ctx, func(c *models.Card) bool {
+		account, ok := r.s.accounts[c.AccountID]
+		return ok && account.UserID == userID && account.OrganizationID == nil
+	})
+}
+
+// GetAll gets all cards of the request's tenant, including deactivated ones
+func (r *CardRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	return r.list(ctx, func(*models.Card) bool { return true })
+}
+
+// list gets the cards of the request's tenant that match
+func (r *CardRepo) list(ctx context.Context, match func(*models.Card) bool) ([]*models.Card, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var cards []*models.Card
+	for _, card := range rowsOf(r.s.cards, func(c *models.Card) bool { return match(c) && r.s.accountInTenant(ctx, c.AccountID) }) {
+		cards = append(cards, cardRow(card))
+	}
+
+	return cards, nil
+}
+
+// Update updates a card
+func (r *CardRepo) Update(ctx context.Context, card *models.Card) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cards[card.ID]
+	if !ok {
+		return fmt.Errorf("card not found")
+	}
+
+	row.CardType = card.CardType
+	r.s.setCardActive(row, card.IsActive)
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateEncrypted replaces the encrypted card number and expiry date of a card
+func (r *CardRepo) UpdateEncrypted(ctx context.Context, card *models.Card) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cards[card.ID]
+	if !ok {
+		return fmt.Errorf("card not found")
+	}
+
+	row.CardNumberEncrypted = append([]byte(nil), card.CardNumberEncrypted...)
+	row.ExpiryDateEncrypted = append([]byte(nil), card.ExpiryDateEncrypted...)
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Delete deactivates a card; cards are never removed
+func (r *CardRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cards[id]
+	if !ok {
+		return fmt.Errorf("card not found")
+	}
+
+	r.s.setCardActive(row, false)
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// setCardActive freezes or unfreezes a card and records the change in the activity feed of its account
+func (s *Store) setCardActive(card *models.Card, active bool) {
+	if card.IsActive == active {
+		return
+	}
+
+	card.IsActive = active
+	if active {
+		s.recordAccountEvent(card.AccountID, models.AccountEventCardUnfrozen, &card.ID)
+	} else {
+		s.recordAccountEvent(card.AccountID, models.AccountEventCardFrozen, &card.ID)
+	}
+}
+
+// GetByNumberHMAC gets a card with its PIN hash and wrong PIN count by the HMAC of its number
+func (r *CardRepo) GetByNumberHMAC(ctx context.Context, numberHMAC string) (*models.Card, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, card := range r.s.cards {
+		if card.CardNumberHMAC == numberHMAC && r.s.accountInTenant(ctx, card.AccountID) {
+			return cardRow(card), nil
+		}
+	}
+
+	return nil, fmt.Errorf("card not found: %w", sql.ErrNoRows)
+}
+
+// SetPIN sets the PIN hash of a card and clears its wrong PIN count
+func (r *CardRepo) SetPIN(ctx context.Context, id int, pinHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cards[id]
+	if !ok {
+		return fmt.Errorf("card not found")
+	}
+
+	row.PINHash = pinHash
+	row.PINAttempts = 0
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RecordPINAttempt clears the wrong PIN count of a card after a correct PIN and increments it
+// after a wrong one; it returns the new count
+func (r *CardRepo) RecordPINAttempt(ctx context.Context, id int, correct bool) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.cards[id]
+	if !ok {
+		return 0, fmt.Errorf("card not found: %w", sql.ErrNoRows)
+	}
+
+	if correct {
+		row.PINAttempts = 0
+	} else {
+		row.PINAttempts++
+	}
+
+	return row.PINAttempts, nil
+}
+
+// cardRow copies a card together with its encrypted fields
+func cardRow(card *models.Card) *models.Card {
+	c := clone(card)
+	c.CardNumberEncrypted = append([]byte(nil), card.CardNumberEncrypted...)
+	c.ExpiryDateEncrypted = append([]byte(nil), card.ExpiryDateEncrypted...)
+	return c
+}
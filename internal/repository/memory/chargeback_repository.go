@@ -0,0 +1,199 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ChargebackRepo is an in-memory implementation of the repository.ChargebackRepository interface
+type ChargebackRepo struct {
+	s *Store
+}
+
+// NewChargebackRepository creates a new ChargebackRepo
+func NewChargebackRepository(s *Store) *ChargebackRepo {
+	return &ChargebackRepo{s: s}
+}
+
+// CreateTx creates a new chargeback within an existing transaction
+func (r *ChargebackRepo) CreateTx(ctx context.Context, tx *sql.Tx, chargeback *models.Chargeback, paymentIntentID int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[chargeback.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errNotExist("user", chargeback.UserID))
+	}
+	if _, ok := r.s.merchants[chargeback.MerchantID]; !ok {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errNotExist("merchant", chargeback.MerchantID))
+	}
+	if _, ok := r.s.paymentIntents[paymentIntentID]; !ok {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errNotExist("payment intent", paymentIntentID))
+	}
+	if _, ok := r.s.transactions[chargeback.OriginalTransactionID]; !ok {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errNotExist("transaction", chargeback.OriginalTransactionID))
+	}
+	if _, ok := r.s.accounts[chargeback.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errNotExist("account", chargeback.AccountID))
+	}
+	if r.exists(chargeback.OriginalTransactionID) {
+		return 0, fmt.Errorf("failed to create chargeback: %w", errDuplicate("original transaction"))
+	}
+	if chargeback.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create chargeback: amount must be positive")
+	}
+
+	row := chargebackCopy(chargeback)
+	row.ID = r.s.nextID("chargebacks")
+	row.MerchantName, row.PaymentIntentID = "", ""
+	row.Evidence, row.ResolutionNote = "", ""
+	row.ReversalTxID, row.EvidenceSubmittedAt, row.ResolvedAt = nil, nil, nil
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.chargebacks[row.ID] = &chargebackRow{Chargeback: row, paymentIntentID: paymentIntentID}
+
+	return row.ID, nil
+}
+
+// ExistsForTransaction reports whether a payment already has a chargeback
+func (r *ChargebackRepo) ExistsForTransaction(ctx context.Context, transactionID int) (bool, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	return r.exists(transactionID), nil
+}
+
+// exists reports whether a payment already has a chargeback
+func (r *ChargebackRepo) exists(transactionID int) bool {
+	for _, chargeback := range r.s.chargebacks {
+		if chargeback.OriginalTransactionID == transactionID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetByID gets a chargeback of the request's tenant, that of the cardholder's account, by ID
+func (r *ChargebackRepo) GetByID(ctx context.Context, id int) (*models.Chargeback, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	chargeback, ok := r.s.chargebacks[id]
+	if !ok || !r.s.accountInTenant(ctx, chargeback.AccountID) {
+		return nil, fmt.Errorf("chargeback not found: %w", sql.ErrNoRows)
+	}
+
+	return r.view(chargeback), nil
+}
+
+// GetByUserID gets the chargebacks opened by a cardholder, newest first
+func (r *ChargebackRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Chargeback, error) {
+	chargebacks := r.list(ctx, func(c *chargebackRow) bool { return c.UserID == userID })
+	sort.SliceStable(chargebacks, func(i, j int) bool { return chargebacks[i].CreatedAt.After(chargebacks[j].CreatedAt) })
+	return chargebacks, nil
+}
+
+// GetByMerchantID gets the chargebacks against a merchant, newest first
+func (r *ChargebackRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Chargeback, error) {
+	chargebacks := r.list(ctx, func(c *chargebackRow) bool { return c.MerchantID == merchantID })
+	sort.SliceStable(chargebacks, func(i, j int) bool { return chargebacks[i].CreatedAt.After(chargebacks[j].CreatedAt) })
+	return chargebacks, nil
+}
+
+// GetByStatus gets the chargebacks of the request's tenant in a status, all of them if the status is empty
+func (r *ChargebackRepo) GetByStatus(ctx context.Context, status models.ChargebackStatus) ([]*models.Chargeback, error) {
+	chargebacks := r.list(ctx, func(c *chargebackRow) bool { return status == "" || c.Status == status })
+	sort.SliceStable(chargebacks, func(i, j int) bool { return chargebacks[i].CreatedAt.Before(chargebacks[j].CreatedAt) })
+	return chargebacks, nil
+}
+
+// GetEvidenceOverdue gets the open chargebacks whose merchant missed the evidence deadline
+func (r *ChargebackRepo) GetEvidenceOverdue(ctx context.Context, now time.Time) ([]*models.Chargeback, error) {
+	chargebacks := r.list(ctx, func(c *chargebackRow) bool {
+		return c.Status == models.ChargebackStatusOpen && !c.EvidenceDueAt.After(now)
+	})
+	sort.SliceStable(chargebacks, func(i, j int) bool {
+		return chargebacks[i].EvidenceDueAt.Before(chargebacks[j].EvidenceDueAt)
+	})
+	return chargebacks, nil
+}
+
+// list gets the chargebacks of the request's tenant that match in ID order
+func (r *ChargebackRepo) list(ctx context.Context, match func(*chargebackRow) bool) []*models.Chargeback {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	chargebacks := []*models.Chargeback{}
+	for _, chargeback := range rowsOf(r.s.chargebacks, func(c *chargebackRow) bool {
+		return match(c) && r.s.accountInTenant(ctx, c.AccountID)
+	}) {
+		chargebacks = append(chargebacks, r.view(chargeback))
+	}
+	return chargebacks
+}
+
+// SubmitEvidence records the merchant's evidence while the chargeback is open and before the
+// deadline. It reports false if the chargeback no longer accepts evidence.
+func (r *ChargebackRepo) SubmitEvidence(ctx context.Context, id int, evidence string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	chargeback, ok := r.s.chargebacks[id]
+	if !ok || chargeback.Status != models.ChargebackStatusOpen || !chargeback.EvidenceDueAt.After(now) {
+		return false, nil
+	}
+
+	chargeback.Status = models.ChargebackStatusEvidenceSubmitted
+	chargeback.Evidence = evidence
+	chargeback.EvidenceSubmittedAt = timePtr(now)
+	chargeback.UpdatedAt = now
+
+	return true, nil
+}
+
+// ResolveTx moves a chargeback to a final status within an existing transaction. It reports
+// false if the chargeback was not in the expected status, so it can only be resolved once.
+func (r *ChargebackRepo) ResolveTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ChargebackStatus, note string, reversalTxID *int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	chargeback, ok := r.s.chargebacks[id]
+	if !ok || chargeback.Status != from {
+		return false, nil
+	}
+
+	now := time.Now()
+	chargeback.Status = to
+	chargeback.ResolutionNote = note
+	chargeback.ReversalTxID = intPtr(reversalTxID)
+	chargeback.ResolvedAt = timePtr(now)
+	chargeback.UpdatedAt = now
+
+	return true, nil
+}
+
+// view copies a chargeback and fills in the merchant name and the public ID of the payment intent
+func (r *ChargebackRepo) view(row *chargebackRow) *models.Chargeback {
+	chargeback := chargebackCopy(row.Chargeback)
+	if merchant, ok := r.s.merchants[row.MerchantID]; ok {
+		chargeback.MerchantName = merchant.Name
+	}
+	if intent, ok := r.s.paymentIntents[row.paymentIntentID]; ok {
+		chargeback.PaymentIntentID = intent.IntentID
+	}
+	return chargeback
+}
+
+// chargebackCopy copies a chargeback together with its transaction references
+func chargebackCopy(chargeback *models.Chargeback) *models.Chargeback {
+	c := clone(chargeback)
+	c.ProvisionalCreditTxID = intPtr(chargeback.ProvisionalCreditTxID)
+	c.MerchantDebitTxID = intPtr(chargeback.MerchantDebitTxID)
+	c.ReversalTxID = intPtr(chargeback.ReversalTxID)
+	return c
+}
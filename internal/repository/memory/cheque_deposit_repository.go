@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ChequeDepositRepo is an in-memory implementation of the repository.ChequeDepositRepository interface
+type ChequeDepositRepo struct {
+	s *Store
+}
+
+// NewChequeDepositRepository creates a new ChequeDepositRepo
+func NewChequeDepositRepository(s *Store) *ChequeDepositRepo {
+	return &ChequeDepositRepo{s: s}
+}
+
+// CreateTx records a deposited cheque within an existing transaction
+func (r *ChequeDepositRepo) CreateTx(ctx context.Context, tx *sql.Tx, deposit *models.ChequeDeposit) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[deposit.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create cheque deposit: %w", errNotExist("user", deposit.UserID))
+	}
+	if _, ok := r.s.accounts[deposit.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create cheque deposit: %w", errNotExist("account", deposit.AccountID))
+	}
+	if _, ok := r.s.transactions[deposit.TransactionID]; !ok {
+		return 0, fmt.Errorf("failed to create cheque deposit: %w", errNotExist("transaction", deposit.TransactionID))
+	}
+	for _, other := range r.s.chequeDeposits {
+		if other.StorageKey == deposit.StorageKey {
+			return 0, fmt.Errorf("failed to create cheque deposit: %w", errDuplicate("storage key"))
+		}
+	}
+	if deposit.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create cheque deposit: amount must be positive")
+	}
+
+	now := time.Now()
+	deposit.ID = r.s.nextID("cheque_deposits")
+	deposit.CreatedAt = now
+	deposit.UpdatedAt = now
+
+	row := chequeDepositCopy(deposit)
+	row.ReviewedBy, row.ReviewNote, row.ReviewedAt = nil, "", nil
+	r.s.chequeDeposits[row.ID] = row
+
+	return deposit.ID, nil
+}
+
+// GetByID gets a cheque deposit of the request's tenant, that of its account, by ID
+func (r *ChequeDepositRepo) GetByID(ctx context.Context, id int) (*models.ChequeDeposit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	deposit, ok := r.s.chequeDeposits[id]
+	if !ok || !r.s.accountInTenant(ctx, deposit.AccountID) {
+		return nil, fmt.Errorf("cheque deposit not found: %w", sql.ErrNoRows)
+	}
+
+	return chequeDepositCopy(deposit), nil
+}
+
+// GetByUserID gets the cheque deposits of a user, newest first
+func (r *ChequeDepositRepo) GetByUserID(ctx context.Context, userID int) ([]*models.ChequeDeposit, error) {
+	deposits := r.list(ctx, func(d *models.ChequeDeposit) bool { return d.UserID == userID })
+	sort.SliceStable(deposits, func(i, j int) bool { return deposits[i].ID > deposits[j].ID })
+	return deposits, nil
+}
+
+// GetByStatus gets the cheque deposits of the request's tenant in a status, oldest first, all of them
+// if the status is empty
+func (r *ChequeDepositRepo) GetByStatus(ctx context.Context, status models.ChequeDepositStatus) ([]*models.ChequeDeposit, error) {
+	return r.list(ctx, func(d *models.ChequeDeposit) bool { return status == "" || d.Status == status }), nil
+}
+
+// list gets the cheque deposits of the request's tenant that match, in ID order
+func (r *ChequeDepositRepo) list(ctx context.Context, match func(*models.ChequeDeposit) bool) []*models.ChequeDeposit {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	deposits := []*models.ChequeDeposit{}
+	for _, deposit := range rowsOf(r.s.chequeDeposits, func(d *models.ChequeDeposit) bool {
+		return match(d) && r.s.accountInTenant(ctx, d.AccountID)
+	}) {
+		deposits = append(deposits, chequeDepositCopy(deposit))
+	}
+	return deposits
+}
+
+// ReviewTx moves a pending cheque deposit to the status an admin decided on within an existing
+// transaction. It reports false if the deposit has been reviewed already.
+func (r *ChequeDepositRepo) ReviewTx(ctx context.Context, tx *sql.Tx, id int, to models.ChequeDepositStatus, reviewedBy int, note string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	deposit, ok := r.s.chequeDeposits[id]
+	if !ok || deposit.Status != models.ChequeDepositStatusPending {
+		return false, nil
+	}
+	if _, ok := r.s.users[reviewedBy]; !ok {
+		return false, fmt.Errorf("failed to review cheque deposit: %w", errNotExist("user", reviewedBy))
+	}
+
+	now := time.Now()
+	deposit.Status = to
+	deposit.ReviewedBy = intPtr(&reviewedBy)
+	deposit.ReviewNote = note
+	deposit.ReviewedAt = timePtr(now)
+	deposit.UpdatedAt = now
+
+	return true, nil
+}
+
+// chequeDepositCopy copies a cheque deposit together with its optional fields
+func chequeDepositCopy(deposit *models.ChequeDeposit) *models.ChequeDeposit {
+	d := clone(deposit)
+	if deposit.RecognizedAmount != nil {
+		amount := *deposit.RecognizedAmount
+		d.RecognizedAmount = &amount
+	}
+	d.ReviewedBy = intPtr(deposit.ReviewedBy)
+	if deposit.ReviewedAt != nil {
+		d.ReviewedAt = timePtr(*deposit.ReviewedAt)
+	}
+	return d
+}
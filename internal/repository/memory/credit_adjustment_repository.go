@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CreditAdjustmentRepo is an in-memory implementation of the repository.CreditAdjustmentRepository interface
+type CreditAdjustmentRepo struct {
+	s *Store
+}
+
+// NewCreditAdjustmentRepository creates a new CreditAdjustmentRepo
+func NewCreditAdjustmentRepository(s *Store) *CreditAdjustmentRepo {
+	return &CreditAdjustmentRepo{s: s}
+}
+
+// GetByCreditID gets the adjustments of a credit, oldest first
+func (r *CreditAdjustmentRepo) GetByCreditID(ctx context.Context, creditID int) ([]*models.CreditAdjustment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	adjustments := []*models.CreditAdjustment{}
+	for _, adjustment := range rowsOf(r.s.creditAdjustments, func(a *models.CreditAdjustment) bool {
+		return a.CreditID == creditID
+	}) {
+		adjustments = append(adjustments, clone(adjustment))
+	}
+
+	return adjustments, nil
+}
+
+// CreateTx records a credit adjustment within an existing transaction
+func (r *CreditAdjustmentRepo) CreateTx(ctx context.Context, tx *sql.Tx, adjustment *models.CreditAdjustment) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.credits[adjustment.CreditID]; !ok {
+		return 0, fmt.Errorf("failed to create credit adjustment: %w", errNotExist("credit", adjustment.CreditID))
+	}
+	if adjustment.PaymentID != nil {
+		if _, ok := r.s.schedules[*adjustment.PaymentID]; !ok {
+			return 0, fmt.Errorf("failed to create credit adjustment: %w", errNotExist("payment schedule", *adjustment.PaymentID))
+		}
+	}
+	if _, ok := r.s.users[adjustment.AdminID]; !ok {
+		return 0, fmt.Errorf("failed to create credit adjustment: %w", errNotExist("user", adjustment.AdminID))
+	}
+
+	adjustment.ID = r.s.nextID("credit_adjustments")
+	adjustment.CreatedAt = time.Now()
+	r.s.creditAdjustments[adjustment.ID] = clone(adjustment)
+
+	return adjustment.ID, nil
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CreditApplicationRepo is an in-memory implementation of the repository.CreditApplicationRepository interface
+type CreditApplicationRepo struct {
+	s *Store
+}
+
+// NewCreditApplicationRepository creates a new CreditApplicationRepo
+func NewCreditApplicationRepository(s *Store) *CreditApplicationRepo {
+	return &CreditApplicationRepo{s: s}
+}
+
+// Create creates a new credit application
+func (r *CreditApplicationRepo) Create(ctx context.Context, application *models.CreditApplication) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[application.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create credit application: %w", errNotExist("user", application.UserID))
+	}
+	if application.Amount <= 0 || application.TermMonths <= 0 {
+		return 0, fmt.Errorf("failed to create credit application: invalid credit terms")
+	}
+
+	application.ID = r.s.nextID("credit_applications")
+	application.CreatedAt = time.Now()
+	application.UpdatedAt = application.CreatedAt
+
+	row := clone(application)
+	row.CreditID, row.ReviewedBy = nil, nil
+	row.ReviewNote = ""
+	row.DocumentsRequired, row.MissingDocuments, row.Documents = false, nil, nil
+	r.s.applications[row.ID] = row
+
+	return application.ID, nil
+}
+
+// GetByID gets a credit application of the request's tenant, that of its applicant, by ID
+func (r *CreditApplicationRepo) GetByID(ctx context.Context, id int) (*models.CreditApplication, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	application, ok := r.s.applications[id]
+	if !ok || !r.s.userInTenant(ctx, application.UserID) {
+		return nil, fmt.Errorf("credit application not found: %w", sql.ErrNoRows)
+	}
+
+	return creditApplicationRow(application), nil
+}
+
+// GetByUserID gets the credit applications of a user, newest first
+func (r *CreditApplicationRepo) GetByUserID(ctx context.Context, userID int) ([]*models.CreditApplication, error) {
+	applications := r.list(ctx, func(a *models.CreditApplication) bool { return a.UserID == userID })
+	sort.SliceStable(applications, func(i, j int) bool {
+		return applications[i].CreatedAt.After(applications[j].CreatedAt)
+	})
+	return applications, nil
+}
+
+// GetByStatus gets the credit applications of the request's tenant in a status, all of them if the
+// status is empty, oldest first
+func (r *CreditApplicationRepo) GetByStatus(ctx context.Context, status models.CreditApplicationStatus) ([]*models.CreditApplication, error) {
+	applications := r.list(ctx, func(a *models.CreditApplication) bool { return status == "" || a.Status == status })
+	sort.SliceStable(applications, func(i, j int) bool {
+		return applications[i].CreatedAt.Before(applications[j].CreatedAt)
+	})
+	return applications, nil
+}
+
+// list gets the credit applications of the request's tenant that match in ID order
+func (r *CreditApplicationRepo) list(ctx context.Context, match func(*models.CreditApplication) bool) []*models.CreditApplication {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	applications := []*models.CreditApplication{}
+	for _, application := range rowsOf(r.s.applications, func(a *models.CreditApplication) bool {
+		return match(a) && r.s.userInTenant(ctx, a.UserID)
+	}) {
+		applications = append(applications, creditApplicationRow(application))
+	}
+	return applications
+}
+
+// UpdateStatus moves a credit application from one status to another and records the review.
+// It reports false if the application was not in the expected status.
+func (r *CreditApplicationRepo) UpdateStatus(ctx context.Context, id int, from, to models.CreditApplicationStatus, note string, reviewedBy *int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	application, ok := r.s.applications[id]
+	if !ok || application.Status != from {
+		return false, nil
+	}
+
+	application.Status = to
+	application.ReviewNote = note
+	application.ReviewedBy = intPtr(reviewedBy)
+	application.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// SetCreditID links the credit issued for an application
+func (r *CreditApplicationRepo) SetCreditID(ctx context.Context, id int, creditID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.credits[creditID]; !ok {
+		return fmt.Errorf("failed to link credit to application: %w", errNotExist("credit", creditID))
+	}
+	if application, ok := r.s.applications[id]; ok {
+		application.CreditID = &creditID
+		application.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// creditApplicationRow copies a credit application together with its references
+func creditApplicationRow(application *models.CreditApplication) *models.CreditApplication {
+	a := clone(application)
+	a.CreditID = intPtr(application.CreditID)
+	a.ReviewedBy = intPtr(application.ReviewedBy)
+	return a
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CreditDocumentRepo is an in-memory implementation of the repository.CreditDocumentRepository interface
+type CreditDocumentRepo struct {
+	s *Store
+}
+
+// NewCreditDocumentRepository creates a new CreditDocumentRepo
+func NewCreditDocumentRepository(s *Store) *CreditDocumentRepo {
+	return &CreditDocumentRepo{s: s}
+}
+
+// Create records an uploaded document
+func (r *CreditDocumentRepo) Create(ctx context.Context, doc *models.CreditDocument) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.applications[doc.ApplicationID]; !ok {
+		return 0, fmt.Errorf("failed to create credit document: %w", errNotExist("credit application", doc.ApplicationID))
+	}
+	if _, ok := r.s.users[doc.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create credit document: %w", errNotExist("user", doc.UserID))
+	}
+	for _, other := range r.s.documents {
+		if other.StorageKey == doc.StorageKey {
+			return 0, fmt.Errorf("failed to create credit document: %w", errDuplicate("storage key"))
+		}
+	}
+
+	doc.ID = r.s.nextID("credit_documents")
+	doc.CreatedAt = time.Now()
+
+	row := clone(doc)
+	row.ReviewNote = ""
+	row.ReviewedAt = nil
+	r.s.documents[row.ID] = row
+
+	return doc.ID, nil
+}
+
+// GetByID gets a credit document by ID
+func (r *CreditDocumentRepo) GetByID(ctx context.Context, id int) (*models.CreditDocument, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	doc, ok := r.s.documents[id]
+	if !ok {
+		return nil, fmt.Errorf("credit document not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(doc), nil
+}
+
+// GetByApplicationID gets the documents uploaded for an application, oldest first
+func (r *CreditDocumentRepo) GetByApplicationID(ctx context.Context, applicationID int) ([]*models.CreditDocument, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	docs := []*models.CreditDocument{}
+	for _, doc := range rowsOf(r.s.documents, func(d *models.CreditDocument) bool { return d.ApplicationID == applicationID }) {
+		docs = append(docs, clone(doc))
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].CreatedAt.Before(docs[j].CreatedAt) })
+
+	return docs, nil
+}
+
+// Review records the bank's review of a document
+func (r *CreditDocumentRepo) Review(ctx context.Context, id int, status models.CreditDocumentStatus, note string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	doc, ok := r.s.documents[id]
+	if !ok {
+		return fmt.Errorf("credit document not found")
+	}
+
+	doc.Status = status
+	doc.ReviewNote = note
+	doc.ReviewedAt = timePtr(time.Now())
+
+	return nil
+}
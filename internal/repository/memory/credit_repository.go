@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CreditRepo is an in-memory implementation of the repository.CreditRepository interface
+type CreditRepo struct {
+	s *Store
+}
+
+// NewCreditRepository creates a new CreditRepo
+func NewCreditRepository(s *Store) *CreditRepo {
+	return &CreditRepo{s: s}
+}
+
+// Create creates a new credit; it belongs to the tenant of its account
+func (r *CreditRepo) Create(ctx context.Context, credit *models.Credit) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[credit.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create credit: %w", errNotExist("user", credit.UserID))
+	}
+	if _, ok := r.s.accounts[credit.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create credit: %w", errNotExist("account", credit.AccountID))
+	}
+	if credit.Amount <= 0 || credit.TermMonths <= 0 || credit.MonthlyPayment <= 0 || credit.InterestRate < 0 {
+		return 0, fmt.Errorf("failed to create credit: invalid credit terms")
+	}
+
+	row := clone(credit)
+	row.ID = r.s.nextID("credits")
+	row.Signature, row.Insurance = nil, nil
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.credits[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets a credit by ID
+func (r *CreditRepo) GetByID(ctx context.Context, id int) (*models.Credit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	credit, ok := r.s.credits[id]
+	if !ok || !r.s.accountInTenant(ctx, credit.AccountID) {
+		return nil, fmt.Errorf("credit not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(credit), nil
+}
+
+// GetByUserID gets all credits for a user in the request's tenant, newest first
+func (r *CreditRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Credit, error) {
+	return r.list(func(c *models.Credit) bool { return c.UserID == userID && r.s.accountInTenant(ctx, c.AccountID) }, true)
+}
+
+// GetByAccountID gets all credits for an account in the request's tenant, newest first
+func (r *CreditRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Credit, error) {
+	return r.list(func(c *models.Credit) bool { return c.AccountID == accountID && r.s.accountInTenant(ctx, c.AccountID) }, true)
+}
+
+// GetChanged gets up to limit credits created or changed in [from, to) with an ID greater than
+// afterID, in ID order
+func (r *CreditRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Credit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	credits := []*models.Credit{}
+	for _, credit := range rowsOf(r.s.credits, func(c *models.Credit) bool {
+		return c.ID > afterID && !c.UpdatedAt.Before(from) && c.UpdatedAt.Before(to)
+	}) {
+		if len(credits) == limit {
+			break
+		}
+		credits = append(credits, clone(credit))
+	}
+
+	return credits, nil
+}
+
+// GetActiveCredits gets all active credits, oldest first
+func (r *CreditRepo) GetActiveCredits(ctx context.Context) ([]*models.Credit, error) {
+	return r.list(func(c *models.Credit) bool { return c.Status == models.CreditStatusActive }, false)
+}
+
+// list gets the credits that match ordered by creation time
+func (r *CreditRepo) list(match func(*models.Credit) bool, newestFirst bool) ([]*models.Credit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var credits []*models.Credit
+	for _, credit := range rowsOf(r.s.credits, match) {
+		credits = append(credits, clone(credit))
+	}
+	sort.SliceStable(credits, func(i, j int) bool {
+		if newestFirst {
+			return credits[i].CreatedAt.After(credits[j].CreatedAt)
+		}
+		return credits[i].CreatedAt.Before(credits[j].CreatedAt)
+	})
+
+	return credits, nil
+}
+
+// Update updates the status and monthly payment of a credit
+func (r *CreditRepo) Update(ctx context.Context, credit *models.Credit) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.credits[credit.ID]
+	if !ok {
+		return fmt.Errorf("credit not found")
+	}
+
+	row.Status = credit.Status
+	row.MonthlyPayment = credit.MonthlyPayment
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateTx updates the status and terms of a credit within an existing transaction
+func (r *CreditRepo) UpdateTx(ctx context.Context, tx *sql.Tx, credit *models.Credit) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.credits[credit.ID]
+	if !ok {
+		return fmt.Errorf("credit not found")
+	}
+
+	row.Status = credit.Status
+	row.TermMonths = credit.TermMonths
+	row.MonthlyPayment = credit.MonthlyPayment
+	row.EndDate = credit.EndDate
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
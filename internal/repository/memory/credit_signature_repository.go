@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// CreditSignatureRepo is an in-memory implementation of the repository.CreditSignatureRepository interface
+type CreditSignatureRepo struct {
+	s *Store
+}
+
+// NewCreditSignatureRepository creates a new CreditSignatureRepo
+func NewCreditSignatureRepository(s *Store) *CreditSignatureRepo {
+	return &CreditSignatureRepo{s: s}
+}
+
+// CreateRequest creates a new signature request for a credit agreement
+func (r *CreditSignatureRepo) CreateRequest(ctx context.Context, request *models.SignatureRequest) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.credits[request.CreditID]; !ok {
+		return 0, fmt.Errorf("failed to create signature request: %w", errNotExist("credit", request.CreditID))
+	}
+	if _, ok := r.s.users[request.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create signature request: %w", errNotExist("user", request.UserID))
+	}
+	for _, other := range r.s.signatureRequests {
+		if other.RequestID == request.RequestID {
+			return 0, fmt.Errorf("failed to create signature request: %w", errDuplicate("request ID"))
+		}
+	}
+
+	request.ID = r.s.nextID("credit_signature_requests")
+	request.CreatedAt = time.Now()
+
+	row := clone(request)
+	row.Attempts = 0
+	r.s.signatureRequests[row.ID] = row
+
+	return request.ID, nil
+}
+
+// GetRequestByRequestID gets a signature request by its public ID
+func (r *CreditSignatureRepo) GetRequestByRequestID(ctx context.Context, requestID string) (*models.SignatureRequest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, request := range r.s.signatureRequests {
+		if request.RequestID == requestID {
+			return clone(request), nil
+		}
+	}
+
+	return nil, fmt.Errorf("signature request not found: %w", sql.ErrNoRows)
+}
+
+// IncrementAttempts records a failed code check and returns the new number of attempts
+func (r *CreditSignatureRepo) IncrementAttempts(ctx context.Context, id int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	request, ok := r.s.signatureRequests[id]
+	if !ok {
+		return 0, fmt.Errorf("failed to update attempts: %w", sql.ErrNoRows)
+	}
+
+	request.Attempts++
+
+	return request.Attempts, nil
+}
+
+// UpdateRequestStatus moves a signature request from one status to another. It reports false if
+// the request was not in the expected status, so a code can only be used once.
+func (r *CreditSignatureRepo) UpdateRequestStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	request, ok := r.s.signatureRequests[id]
+	if !ok || request.Status != from {
+		return false, nil
+	}
+
+	request.Status = to
+
+	return true, nil
+}
+
+// UpdateRequestStatusTx moves a signature request from one status to another within an existing transaction
+func (r *CreditSignatureRepo) UpdateRequestStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ConfirmationStatus) (bool, error) {
+	return r.UpdateRequestStatus(ctx, id, from, to)
+}
+
+// GetByCreditID gets the signature of a credit agreement
+func (r *CreditSignatureRepo) GetByCreditID(ctx context.Context, creditID int) (*models.CreditSignature, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, signature := range r.s.signatures {
+		if signature.CreditID == creditID {
+			return clone(signature), nil
+		}
+	}
+
+	return nil, fmt.Errorf("credit signature not found: %w", sql.ErrNoRows)
+}
+
+// CreateTx records the signature of a credit agreement within an existing transaction
+func (r *CreditSignatureRepo) CreateTx(ctx context.Context, tx *sql.Tx, signature *models.CreditSignature) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.credits[signature.CreditID]; !ok {
+		return 0, fmt.Errorf("failed to create credit signature: %w", errNotExist("credit", signature.CreditID))
+	}
+	if _, ok := r.s.users[signature.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create credit signature: %w", errNotExist("user", signature.UserID))
+	}
+	for _, other := range r.s.signatures {
+		if other.CreditID == signature.CreditID {
+			return 0, fmt.Errorf("failed to create credit signature: %w", errDuplicate("credit"))
+		}
+	}
+
+	signature.ID = r.s.nextID("credit_signatures")
+	signature.SignedAt = time.Now()
+	r.s.signatures[signature.ID] = clone(signature)
+
+	return signature.ID, nil
+}
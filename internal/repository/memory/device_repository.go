@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// DeviceRepo is an in-memory implementation of the repository.DeviceRepository interface
+type DeviceRepo struct {
+	s *Store
+}
+
+// NewDeviceRepository creates a new DeviceRepo
+func NewDeviceRepository(s *Store) *DeviceRepo {
+	return &DeviceRepo{s: s}
+}
+
+// Touch records a login from a device and reports whether the device was seen for the first time
+func (r *DeviceRepo) Touch(ctx context.Context, device *models.Device) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for _, known := range r.s.devices {
+		if known.UserID == device.UserID && known.Fingerprint == device.Fingerprint {
+			known.LastSeenAt = now
+			return false, nil
+		}
+	}
+
+	if _, ok := r.s.users[device.UserID]; !ok {
+		return false, fmt.Errorf("failed to record device: %w", errNotExist("user", device.UserID))
+	}
+
+	row := clone(device)
+	row.ID = r.s.nextID("user_devices")
+	row.FirstSeenAt, row.LastSeenAt = now, now
+	r.s.devices[row.ID] = row
+
+	return true, nil
+}
+
+// CountByUserID counts the known devices of a user
+func (r *DeviceRepo) CountByUserID(ctx context.Context, userID int) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, device := range r.s.devices {
+		if device.UserID == userID {
+			count++
+		}
+	}
+
+	return count, nil
+}
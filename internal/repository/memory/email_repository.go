@@ -0,0 +1,178 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// EmailRepo is an in-memory implementation of the repository.EmailRepository interface
+type EmailRepo struct {
+	s *Store
+}
+
+// NewEmailRepository creates a new EmailRepo
+func NewEmailRepository(s *Store) *EmailRepo {
+	return &EmailRepo{s: s}
+}
+
+// CreateDelivery records an email sent to a customer
+func (r *EmailRepo) CreateDelivery(ctx context.Context, delivery *models.EmailDelivery) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if delivery.UserID != nil {
+		if _, ok := r.s.users[*delivery.UserID]; !ok {
+			return 0, fmt.Errorf("failed to create email delivery: %w", errNotExist("user", *delivery.UserID))
+		}
+	}
+	for _, other := range r.s.emailDeliveries {
+		if other.MessageID == delivery.MessageID {
+			return 0, fmt.Errorf("failed to create email delivery: %w", errDuplicate("message id"))
+		}
+	}
+
+	delivery.ID = r.s.nextID("email_deliveries")
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = delivery.CreatedAt
+	r.s.emailDeliveries[delivery.ID] = emailDeliveryRow(delivery)
+
+	return delivery.ID, nil
+}
+
+// GetDeliveries gets the most recent deliveries of the request's tenant matching a filter, newest first
+func (r *EmailRepo) GetDeliveries(ctx context.Context, filter *models.EmailDeliveryFilter) ([]*models.EmailDelivery, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	deliveries := []*models.EmailDelivery{}
+	for _, delivery := range rowsOf(r.s.emailDeliveries, func(d *models.EmailDelivery) bool {
+		return (filter.Status == "" || d.Status == filter.Status) && (filter.Recipient == "" || d.Recipient == filter.Recipient) &&
+			inTenant(ctx, d.Tenant)
+	}) {
+		deliveries = append(deliveries, emailDeliveryRow(delivery))
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool { return deliveries[i].ID > deliveries[j].ID })
+
+	if len(deliveries) > filter.Limit {
+		deliveries = deliveries[:filter.Limit]
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveryByMessageID gets the delivery of the email with a Message-ID
+func (r *EmailRepo) GetDeliveryByMessageID(ctx context.Context, messageID string) (*models.EmailDelivery, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, delivery := range r.s.emailDeliveries {
+		if delivery.MessageID == messageID {
+			return emailDeliveryRow(delivery), nil
+		}
+	}
+
+	return nil, fmt.Errorf("email delivery not found: %w", sql.ErrNoRows)
+}
+
+// UpdateDelivery saves the status, response and open time of a delivery
+func (r *EmailRepo) UpdateDelivery(ctx context.Context, delivery *models.EmailDelivery) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.emailDeliveries[delivery.ID]
+	if !ok {
+		return fmt.Errorf("email delivery not found: %w", sql.ErrNoRows)
+	}
+
+	stored.Status = delivery.Status
+	stored.Response = delivery.Response
+	stored.OpenedAt = nil
+	if delivery.OpenedAt != nil {
+		stored.OpenedAt = timePtr(*delivery.OpenedAt)
+	}
+	stored.UpdatedAt = time.Now()
+	delivery.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+// CreateSuppression stops the emails to an address, or returns 0 if it is suppressed already
+func (r *EmailRepo) CreateSuppression(ctx context.Context, suppression *models.EmailSuppression) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, other := range r.s.emailSuppressions {
+		if other.Email == suppression.Email {
+			return 0, nil
+		}
+	}
+
+	suppression.ID = r.s.nextID("email_suppressions")
+	suppression.CreatedAt = time.Now()
+	r.s.emailSuppressions[suppression.ID] = emailSuppressionRow(suppression)
+
+	return suppression.ID, nil
+}
+
+// GetSuppression gets the suppression of an address
+func (r *EmailRepo) GetSuppression(ctx context.Context, email string) (*models.EmailSuppression, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, suppression := range r.s.emailSuppressions {
+		if suppression.Email == email {
+			return emailSuppressionRow(suppression), nil
+		}
+	}
+
+	return nil, fmt.Errorf("email is not suppressed: %w", sql.ErrNoRows)
+}
+
+// GetSuppressions gets the suppressed addresses, newest first
+func (r *EmailRepo) GetSuppressions(ctx context.Context) ([]*models.EmailSuppression, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	suppressions := []*models.EmailSuppression{}
+	for _, suppression := range rowsOf(r.s.emailSuppressions, func(*models.EmailSuppression) bool { return true }) {
+		suppressions = append(suppressions, emailSuppressionRow(suppression))
+	}
+	sort.SliceStable(suppressions, func(i, j int) bool { return suppressions[i].ID > suppressions[j].ID })
+
+	return suppressions, nil
+}
+
+// DeleteSuppression lifts a suppression, reporting whether it existed
+func (r *EmailRepo) DeleteSuppression(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.emailSuppressions[id]; !ok {
+		return false, nil
+	}
+	delete(r.s.emailSuppressions, id)
+
+	return true, nil
+}
+
+// emailDeliveryRow copies an email delivery
+func emailDeliveryRow(delivery *models.EmailDelivery) *models.EmailDelivery {
+	d := clone(delivery)
+	d.UserID = intPtr(delivery.UserID)
+	if delivery.OpenedAt != nil {
+		d.OpenedAt = timePtr(*delivery.OpenedAt)
+	}
+	return d
+}
+
+// emailSuppressionRow copies an email suppression
+func emailSuppressionRow(suppression *models.EmailSuppression) *models.EmailSuppression {
+	s := clone(suppression)
+	s.DeliveryID = intPtr(suppression.DeliveryID)
+	return s
+}
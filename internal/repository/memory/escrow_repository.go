@@ -0,0 +1,182 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// EscrowRepo is an in-memory implementation of the repository.EscrowRepository interface
+type EscrowRepo struct {
+	s *Store
+}
+
+// NewEscrowRepository creates a new EscrowRepo
+func NewEscrowRepository(s *Store) *EscrowRepo {
+	return &EscrowRepo{s: s}
+}
+
+// CreateTx creates a new escrow within an existing transaction
+func (r *EscrowRepo) CreateTx(ctx context.Context, tx *sql.Tx, escrow *models.Escrow) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, userID := range []int{escrow.BuyerID, escrow.SellerID} {
+		if _, ok := r.s.users[userID]; !ok {
+			return 0, fmt.Errorf("failed to create escrow: %w", errNotExist("user", userID))
+		}
+	}
+	for _, accountID := range []int{escrow.BuyerAccountID, escrow.SellerAccountID} {
+		if _, ok := r.s.accounts[accountID]; !ok {
+			return 0, fmt.Errorf("failed to create escrow: %w", errNotExist("account", accountID))
+		}
+	}
+	if escrow.FundingTransactionID != nil {
+		if _, ok := r.s.transactions[*escrow.FundingTransactionID]; !ok {
+			return 0, fmt.Errorf("failed to create escrow: %w", errNotExist("transaction", *escrow.FundingTransactionID))
+		}
+	}
+	if escrow.BuyerID == escrow.SellerID {
+		return 0, fmt.Errorf("failed to create escrow: buyer and seller must differ")
+	}
+	if escrow.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create escrow: amount must be positive")
+	}
+
+	row := escrowCopy(escrow)
+	row.ID = r.s.nextID("escrows")
+	row.BuyerConfirmedAt, row.SellerConfirmedAt, row.SettledAt = nil, nil, nil
+	row.SettlementTransactionID, row.ResolvedBy, row.ResolutionNote = nil, nil, ""
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.escrows[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets an escrow of the request's tenant by ID
+func (r *EscrowRepo) GetByID(ctx context.Context, id int) (*models.Escrow, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	escrow, ok := r.s.escrows[id]
+	if !ok || !r.s.accountInTenant(ctx, escrow.BuyerAccountID) {
+		return nil, fmt.Errorf("escrow not found: %w", sql.ErrNoRows)
+	}
+
+	return escrowCopy(escrow), nil
+}
+
+// GetByUserID gets the escrows a user is the buyer or the seller of, newest first
+func (r *EscrowRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Escrow, error) {
+	escrows := r.list(ctx, func(e *models.Escrow) bool { return e.IsParty(userID) })
+	sort.SliceStable(escrows, func(i, j int) bool { return escrows[i].ID > escrows[j].ID })
+	return escrows, nil
+}
+
+// GetByStatus gets the escrows of the request's tenant in a status, all of them if the status is empty
+func (r *EscrowRepo) GetByStatus(ctx context.Context, status models.EscrowStatus) ([]*models.Escrow, error) {
+	return r.list(ctx, func(e *models.Escrow) bool { return status == "" || e.Status == status }), nil
+}
+
+// GetExpired gets the funded escrows that were not settled before they expired
+func (r *EscrowRepo) GetExpired(ctx context.Context, now time.Time) ([]*models.Escrow, error) {
+	escrows := r.list(ctx, func(e *models.Escrow) bool {
+		return e.Status == models.EscrowStatusFunded && !e.ExpiresAt.After(now)
+	})
+	sort.SliceStable(escrows, func(i, j int) bool { return escrows[i].ExpiresAt.Before(escrows[j].ExpiresAt) })
+	return escrows, nil
+}
+
+// list gets the escrows of the request's tenant that match in ID order; the tenant of an escrow is
+// that of the buyer's account
+func (r *EscrowRepo) list(ctx context.Context, match func(*models.Escrow) bool) []*models.Escrow {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	escrows := []*models.Escrow{}
+	for _, escrow := range rowsOf(r.s.escrows, func(e *models.Escrow) bool {
+		return match(e) && r.s.accountInTenant(ctx, e.BuyerAccountID)
+	}) {
+		escrows = append(escrows, escrowCopy(escrow))
+	}
+	return escrows
+}
+
+// ConfirmTx records that a party of a funded, unexpired escrow confirmed the deal within an existing
+// transaction and returns the escrow with both confirmations. It returns nil if the escrow can no
+// longer be confirmed.
+func (r *EscrowRepo) ConfirmTx(ctx context.Context, tx *sql.Tx, id int, userID int) (*models.Escrow, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	escrow, ok := r.s.escrows[id]
+	if !ok || escrow.Status != models.EscrowStatusFunded || !escrow.ExpiresAt.After(now) {
+		return nil, nil
+	}
+
+	if escrow.BuyerID == userID && escrow.BuyerConfirmedAt == nil {
+		escrow.BuyerConfirmedAt = timePtr(now)
+	}
+	if escrow.SellerID == userID && escrow.SellerConfirmedAt == nil {
+		escrow.SellerConfirmedAt = timePtr(now)
+	}
+	escrow.UpdatedAt = now
+
+	return escrowCopy(escrow), nil
+}
+
+// SettleTx moves a funded escrow to a final status within an existing transaction. It reports
+// false if the escrow was no longer funded, so it can only be settled once.
+func (r *EscrowRepo) SettleTx(ctx context.Context, tx *sql.Tx, id int, to models.EscrowStatus, settlementTxID *int, resolvedBy *int, note string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	escrow, ok := r.s.escrows[id]
+	if !ok || escrow.Status != models.EscrowStatusFunded {
+		return false, nil
+	}
+	if settlementTxID != nil {
+		if _, ok := r.s.transactions[*settlementTxID]; !ok {
+			return false, fmt.Errorf("failed to settle escrow: %w", errNotExist("transaction", *settlementTxID))
+		}
+	}
+	if resolvedBy != nil {
+		if _, ok := r.s.users[*resolvedBy]; !ok {
+			return false, fmt.Errorf("failed to settle escrow: %w", errNotExist("user", *resolvedBy))
+		}
+	}
+
+	now := time.Now()
+	escrow.Status = to
+	escrow.SettlementTransactionID = intPtr(settlementTxID)
+	escrow.ResolvedBy = intPtr(resolvedBy)
+	escrow.ResolutionNote = note
+	escrow.SettledAt = timePtr(now)
+	escrow.UpdatedAt = now
+
+	return true, nil
+}
+
+// escrowCopy copies an escrow together with its optional references and timestamps
+func escrowCopy(escrow *models.Escrow) *models.Escrow {
+	e := clone(escrow)
+	e.FundingTransactionID = intPtr(escrow.FundingTransactionID)
+	e.SettlementTransactionID = intPtr(escrow.SettlementTransactionID)
+	e.ResolvedBy = intPtr(escrow.ResolvedBy)
+	if escrow.BuyerConfirmedAt != nil {
+		e.BuyerConfirmedAt = timePtr(*escrow.BuyerConfirmedAt)
+	}
+	if escrow.SellerConfirmedAt != nil {
+		e.SellerConfirmedAt = timePtr(*escrow.SellerConfirmedAt)
+	}
+	if escrow.SettledAt != nil {
+		e.SettledAt = timePtr(*escrow.SettledAt)
+	}
+	return e
+}
@@ -0,0 +1,177 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// FeeRepo is an in-memory implementation of the repository.FeeRepository interface
+type FeeRepo struct {
+	s *Store
+}
+
+// NewFeeRepository creates a new FeeRepo
+func NewFeeRepository(s *Store) *FeeRepo {
+	return &FeeRepo{s: s}
+}
+
+// CreateCharge saves a new pending fee charge, or returns 0 if the period was assessed already
+func (r *FeeRepo) CreateCharge(ctx context.Context, charge *models.FeeCharge) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.accounts[charge.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create fee charge: %w", errNotExist("account", charge.AccountID))
+	}
+	if charge.CardID != nil {
+		if _, ok := r.s.cards[*charge.CardID]; !ok {
+			return 0, fmt.Errorf("failed to create fee charge: %w", errNotExist("card", *charge.CardID))
+		}
+	}
+
+	for _, other := range r.s.feeCharges {
+		if other.Kind == charge.Kind && other.ReferenceID == charge.ReferenceID && other.PeriodStart.Equal(charge.PeriodStart) {
+			return 0, nil
+		}
+	}
+
+	charge.ID = r.s.nextID("fee_charges")
+	charge.Status = models.FeeChargeStatusPending
+	charge.CreatedAt = time.Now()
+	charge.UpdatedAt = charge.CreatedAt
+	r.s.feeCharges[charge.ID] = feeChargeRow(charge)
+
+	return charge.ID, nil
+}
+
+// CreateChargeTx saves a new pending fee charge, or returns 0 if the period was assessed already
+func (r *FeeRepo) CreateChargeTx(ctx context.Context, tx *sql.Tx, charge *models.FeeCharge) (int, error) {
+	return r.CreateCharge(ctx, charge)
+}
+
+// GetCharges gets the fee charges of the request's tenant, that of their accounts, in a status, or
+// all of them if status is empty, newest first
+func (r *FeeRepo) GetCharges(ctx context.Context, status models.FeeChargeStatus) ([]*models.FeeCharge, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	charges := []*models.FeeCharge{}
+	for _, charge := range rowsOf(r.s.feeCharges, func(c *models.FeeCharge) bool {
+		return (status == "" || c.Status == status) && r.s.accountInTenant(ctx, c.AccountID)
+	}) {
+		charges = append(charges, feeChargeRow(charge))
+	}
+	sort.SliceStable(charges, func(i, j int) bool { return charges[i].ID > charges[j].ID })
+
+	return charges, nil
+}
+
+// GetCollectable gets the pending and dunning fee charges, oldest first
+func (r *FeeRepo) GetCollectable(ctx context.Context) ([]*models.FeeCharge, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	charges := []*models.FeeCharge{}
+	for _, charge := range rowsOf(r.s.feeCharges, func(c *models.FeeCharge) bool {
+		return c.Status == models.FeeChargeStatusPending || c.Status == models.FeeChargeStatusDunning
+	}) {
+		charges = append(charges, feeChargeRow(charge))
+	}
+
+	return charges, nil
+}
+
+// MarkDunning records a failed attempt to charge a fee
+func (r *FeeRepo) MarkDunning(ctx context.Context, id int, reason string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if charge, ok := r.s.feeCharges[id]; ok && charge.Status != models.FeeChargeStatusCharged {
+		charge.Status = models.FeeChargeStatusDunning
+		charge.Attempts++
+		charge.LastError = reason
+		charge.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// MarkChargedTx records that a fee was charged by a FEE transaction
+func (r *FeeRepo) MarkChargedTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	charge, ok := r.s.feeCharges[id]
+	if !ok || charge.Status == models.FeeChargeStatusCharged {
+		return errors.New("fee charge was charged already")
+	}
+
+	charge.Status = models.FeeChargeStatusCharged
+	charge.TransactionID = &transactionID
+	charge.ChargedAt = timePtr(time.Now())
+	charge.LastError = ""
+	charge.UpdatedAt = *charge.ChargedAt
+
+	return nil
+}
+
+// CreateRun saves the summary of a fee job run
+func (r *FeeRepo) CreateRun(ctx context.Context, run *models.FeeRun) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	run.ID = r.s.nextID("fee_runs")
+	r.s.feeRuns[run.ID] = feeRunRow(run)
+
+	return run.ID, nil
+}
+
+// GetRuns gets the most recent fee job runs, newest first
+func (r *FeeRepo) GetRuns(ctx context.Context, limit int) ([]*models.FeeRun, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	runs := []*models.FeeRun{}
+	for _, run := range rowsOf(r.s.feeRuns, func(*models.FeeRun) bool { return true }) {
+		runs = append(runs, feeRunRow(run))
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		if !runs[i].StartedAt.Equal(runs[j].StartedAt) {
+			return runs[i].StartedAt.After(runs[j].StartedAt)
+		}
+		return runs[i].ID > runs[j].ID
+	})
+
+	if len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	return runs, nil
+}
+
+// feeChargeRow copies a fee charge
+func feeChargeRow(charge *models.FeeCharge) *models.FeeCharge {
+	c := clone(charge)
+	c.CardID = intPtr(charge.CardID)
+	c.TransactionID = intPtr(charge.TransactionID)
+	if charge.ChargedAt != nil {
+		c.ChargedAt = timePtr(*charge.ChargedAt)
+	}
+	return c
+}
+
+// feeRunRow copies a fee run together with its totals
+func feeRunRow(run *models.FeeRun) *models.FeeRun {
+	r := clone(run)
+	r.Totals = make([]*models.FeeTotals, 0, len(run.Totals))
+	for _, totals := range run.Totals {
+		r.Totals = append(r.Totals, clone(totals))
+	}
+	return r
+}
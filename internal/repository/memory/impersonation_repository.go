@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ImpersonationRepo is an in-memory implementation of the repository.ImpersonationRepository interface
+type ImpersonationRepo struct {
+	s *Store
+}
+
+// NewImpersonationRepository creates a new ImpersonationRepo
+func NewImpersonationRepository(s *Store) *ImpersonationRepo {
+	return &ImpersonationRepo{s: s}
+}
+
+// Create creates a new impersonation
+func (r *ImpersonationRepo) Create(ctx context.Context, impersonation *models.Impersonation) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, userID := range []int{impersonation.StaffID, impersonation.CustomerID} {
+		if _, ok := r.s.users[userID]; !ok {
+			return 0, fmt.Errorf("failed to create impersonation: %w", errNotExist("user", userID))
+		}
+	}
+
+	found := false
+	for _, session := range r.s.sessions {
+		if session.SessionID == impersonation.SessionID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("failed to create impersonation: session %q does not exist", impersonation.SessionID)
+	}
+
+	impersonation.ID = r.s.nextID("impersonations")
+	impersonation.EndedAt = nil
+	impersonation.CreatedAt = time.Now()
+	r.s.impersonations[impersonation.ID] = impersonationRow(impersonation)
+
+	return impersonation.ID, nil
+}
+
+// GetByID gets an impersonation of the request's tenant, that of the customer, by ID
+func (r *ImpersonationRepo) GetByID(ctx context.Context, id int) (*models.Impersonation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	impersonation, ok := r.s.impersonations[id]
+	if !ok || !r.s.userInTenant(ctx, impersonation.CustomerID) {
+		return nil, fmt.Errorf("impersonation not found: %w", sql.ErrNoRows)
+	}
+
+	return impersonationRow(impersonation), nil
+}
+
+// GetAll gets the impersonations of the request's tenant by an employee and of a customer, newest
+// first; a zero ID matches everyone
+func (r *ImpersonationRepo) GetAll(ctx context.Context, staffID int, customerID int) ([]*models.Impersonation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	impersonations := []*models.Impersonation{}
+	for _, impersonation := range rowsOf(r.s.impersonations, func(i *models.Impersonation) bool {
+		return (staffID == 0 || i.StaffID == staffID) && (customerID == 0 || i.CustomerID == customerID) &&
+			r.s.userInTenant(ctx, i.CustomerID)
+	}) {
+		impersonations = append(impersonations, impersonationRow(impersonation))
+	}
+
+	sort.SliceStable(impersonations, func(i, j int) bool {
+		return impersonations[i].ID > impersonations[j].ID
+	})
+
+	return impersonations, nil
+}
+
+// End ends an impersonation. It reports false if the impersonation had already ended.
+func (r *ImpersonationRepo) End(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	impersonation, ok := r.s.impersonations[id]
+	if !ok || impersonation.EndedAt != nil {
+		return false, nil
+	}
+
+	impersonation.EndedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// AddRequest records a request made with an impersonation token
+func (r *ImpersonationRepo) AddRequest(ctx context.Context, request *models.ImpersonationRequest) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.impersonations[request.ImpersonationID]; !ok {
+		return 0, fmt.Errorf("failed to create impersonation request: %w", errNotExist("impersonation", request.ImpersonationID))
+	}
+
+	request.ID = r.s.nextID("impersonation_requests")
+	request.CreatedAt = time.Now()
+	r.s.impersonationReqs[request.ID] = clone(request)
+
+	return request.ID, nil
+}
+
+// GetRequests gets the audit log of an impersonation, oldest first
+func (r *ImpersonationRepo) GetRequests(ctx context.Context, impersonationID int) ([]*models.ImpersonationRequest, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	requests := []*models.ImpersonationRequest{}
+	for _, request := range rowsOf(r.s.impersonationReqs, func(req *models.ImpersonationRequest) bool {
+		return req.ImpersonationID == impersonationID
+	}) {
+		requests = append(requests, clone(request))
+	}
+
+	return requests, nil
+}
+
+// impersonationRow copies an impersonation without its audit log
+func impersonationRow(impersonation *models.Impersonation) *models.Impersonation {
+	i := clone(impersonation)
+	i.Requests = nil
+	return i
+}
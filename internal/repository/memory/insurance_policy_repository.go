@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// InsurancePolicyRepo is an in-memory implementation of the repository.InsurancePolicyRepository interface
+type InsurancePolicyRepo struct {
+	s *Store
+}
+
+// NewInsurancePolicyRepository creates a new InsurancePolicyRepo
+func NewInsurancePolicyRepository(s *Store) *InsurancePolicyRepo {
+	return &InsurancePolicyRepo{s: s}
+}
+
+// Create creates a new insurance policy
+func (r *InsurancePolicyRepo) Create(ctx context.Context, policy *models.InsurancePolicy) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.credits[policy.CreditID]; !ok {
+		return 0, fmt.Errorf("failed to create insurance policy: %w", errNotExist("credit", policy.CreditID))
+	}
+	if _, ok := r.s.users[policy.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create insurance policy: %w", errNotExist("user", policy.UserID))
+	}
+	for _, other := range r.s.insurancePolicies {
+		if other.PolicyNumber == policy.PolicyNumber {
+			return 0, fmt.Errorf("failed to create insurance policy: %w", errDuplicate("policy number"))
+		}
+		if other.CreditID == policy.CreditID {
+			return 0, fmt.Errorf("failed to create insurance policy: %w", errDuplicate("credit"))
+		}
+	}
+
+	policy.ID = r.s.nextID("insurance_policies")
+	policy.CreatedAt = time.Now()
+
+	row := clone(policy)
+	row.StartDate, row.EndDate = dateOf(policy.StartDate), dateOf(policy.EndDate)
+	row.CancelledAt = nil
+	r.s.insurancePolicies[row.ID] = row
+
+	return policy.ID, nil
+}
+
+// GetByCreditID gets the insurance policy of a credit
+func (r *InsurancePolicyRepo) GetByCreditID(ctx context.Context, creditID int) (*models.InsurancePolicy, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, policy := range r.s.insurancePolicies {
+		if policy.CreditID == creditID {
+			return clone(policy), nil
+		}
+	}
+
+	return nil, fmt.Errorf("insurance policy not found: %w", sql.ErrNoRows)
+}
+
+// CancelTx cancels an active policy within a transaction. It reports false if the policy
+// was not active, so it is only cancelled once.
+func (r *InsurancePolicyRepo) CancelTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	policy, ok := r.s.insurancePolicies[id]
+	if !ok || policy.Status != models.InsurancePolicyStatusActive {
+		return false, nil
+	}
+
+	policy.Status = models.InsurancePolicyStatusCancelled
+	policy.CancelledAt = timePtr(time.Now())
+
+	return true, nil
+}
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// InternationalTransferRepo is an in-memory implementation of the repository.InternationalTransferRepository interface
+type InternationalTransferRepo struct {
+	s *Store
+}
+
+// NewInternationalTransferRepository creates a new InternationalTransferRepo
+func NewInternationalTransferRepository(s *Store) *InternationalTransferRepo {
+	return &InternationalTransferRepo{s: s}
+}
+
+// CreateTx records a submitted international transfer within an existing transaction
+func (r *InternationalTransferRepo) CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.InternationalTransfer) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[transfer.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create international transfer: %w", errNotExist("user", transfer.UserID))
+	}
+	if _, ok := r.s.accounts[transfer.SourceAccountID]; !ok {
+		return 0, fmt.Errorf("failed to create international transfer: %w", errNotExist("account", transfer.SourceAccountID))
+	}
+	for _, transactionID := range []*int{transfer.TransactionID, transfer.FeeTransactionID} {
+		if transactionID == nil {
+			continue
+		}
+		if _, ok := r.s.transactions[*transactionID]; !ok {
+			return 0, fmt.Errorf("failed to create international transfer: %w", errNotExist("transaction", *transactionID))
+		}
+	}
+	for _, other := range r.s.intlTransfers {
+		if other.Reference == transfer.Reference {
+			return 0, fmt.Errorf("failed to create international transfer: %w", errDuplicate("reference"))
+		}
+	}
+	if transfer.Amount <= 0 || transfer.CreditedAmount <= 0 {
+		return 0, fmt.Errorf("failed to create international transfer: amounts must be positive")
+	}
+
+	row := internationalTransferCopy(transfer)
+	row.ID = r.s.nextID("international_transfers")
+	row.InTransitAt, row.SettledAt = nil, nil
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.intlTransfers[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets an international transfer of the request's tenant, that of its source account, by ID
+func (r *InternationalTransferRepo) GetByID(ctx context.Context, id int) (*models.InternationalTransfer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transfer, ok := r.s.intlTransfers[id]
+	if !ok || !r.s.accountInTenant(ctx, transfer.SourceAccountID) {
+		return nil, fmt.Errorf("international transfer not found: %w", sql.ErrNoRows)
+	}
+
+	return internationalTransferCopy(transfer), nil
+}
+
+// GetByReference gets an international transfer by its UETR
+func (r *InternationalTransferRepo) GetByReference(ctx context.Context, reference string) (*models.InternationalTransfer, error) {
+	transfers := r.list(func(t *models.InternationalTransfer) bool { return t.Reference == reference })
+	if len(transfers) == 0 {
+		return nil, fmt.Errorf("international transfer not found: %w", sql.ErrNoRows)
+	}
+
+	return transfers[0], nil
+}
+
+// GetByUserID gets the international transfers of a user, newest first
+func (r *InternationalTransferRepo) GetByUserID(ctx context.Context, userID int) ([]*models.InternationalTransfer, error) {
+	transfers := r.list(func(t *models.InternationalTransfer) bool { return t.UserID == userID })
+	sort.SliceStable(transfers, func(i, j int) bool { return transfers[i].ID > transfers[j].ID })
+	return transfers, nil
+}
+
+// GetByStatus gets the international transfers of the request's tenant in a status, all of them if
+// the status is empty
+func (r *InternationalTransferRepo) GetByStatus(ctx context.Context, status models.InternationalTransferStatus) ([]*models.InternationalTransfer, error) {
+	return r.list(func(t *models.InternationalTransfer) bool {
+		return (status == "" || t.Status == status) && r.s.accountInTenant(ctx, t.SourceAccountID)
+	}), nil
+}
+
+// GetToDispatch gets the submitted transfers whose dispatch time has come
+func (r *InternationalTransferRepo) GetToDispatch(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error) {
+	return r.list(func(t *models.InternationalTransfer) bool {
+		return t.Status == models.InternationalTransferStatusSubmitted && !t.DispatchAt.After(now)
+	}), nil
+}
+
+// GetToSettle gets the transfers in transit whose expected settlement time has come
+func (r *InternationalTransferRepo) GetToSettle(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error) {
+	return r.list(func(t *models.InternationalTransfer) bool {
+		return t.Status == models.InternationalTransferStatusInTransit && !t.ExpectedSettlementAt.After(now)
+	}), nil
+}
+
+// list gets the international transfers that match in ID order
+func (r *InternationalTransferRepo) list(match func(*models.InternationalTransfer) bool) []*models.InternationalTransfer {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transfers := []*models.InternationalTransfer{}
+	for _, transfer := range rowsOf(r.s.intlTransfers, match) {
+		transfers = append(transfers, internationalTransferCopy(transfer))
+	}
+	return transfers
+}
+
+// Dispatch moves a submitted transfer in transit. It reports false if it was dispatched already.
+func (r *InternationalTransferRepo) Dispatch(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transfer, ok := r.s.intlTransfers[id]
+	if !ok || transfer.Status != models.InternationalTransferStatusSubmitted {
+		return false, nil
+	}
+
+	now := time.Now()
+	transfer.Status = models.InternationalTransferStatusInTransit
+	transfer.InTransitAt = timePtr(now)
+	transfer.UpdatedAt = now
+
+	return true, nil
+}
+
+// SettleTx marks a transfer in transit settled within an existing transaction. It reports false if
+// it was not in transit, so it can only be settled once.
+func (r *InternationalTransferRepo) SettleTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transfer, ok := r.s.intlTransfers[id]
+	if !ok || transfer.Status != models.InternationalTransferStatusInTransit {
+		return false, nil
+	}
+
+	now := time.Now()
+	transfer.Status = models.InternationalTransferStatusSettled
+	transfer.SettledAt = timePtr(now)
+	transfer.UpdatedAt = now
+
+	return true, nil
+}
+
+// internationalTransferCopy copies an international transfer together with its optional fields
+func internationalTransferCopy(transfer *models.InternationalTransfer) *models.InternationalTransfer {
+	t := clone(transfer)
+	t.TransactionID = intPtr(transfer.TransactionID)
+	t.FeeTransactionID = intPtr(transfer.FeeTransactionID)
+	if transfer.InTransitAt != nil {
+		t.InTransitAt = timePtr(*transfer.InTransitAt)
+	}
+	if transfer.SettledAt != nil {
+		t.SettledAt = timePtr(*transfer.SettledAt)
+	}
+	return t
+}
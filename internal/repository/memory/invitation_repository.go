@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// InvitationRepo is an in-memory implementation of the repository.InvitationRepository interface
+type InvitationRepo struct {
+	s *Store
+}
+
+// NewInvitationRepository creates a new InvitationRepo
+func NewInvitationRepository(s *Store) *InvitationRepo {
+	return &InvitationRepo{s: s}
+}
+
+// Create creates a new organization invitation
+func (r *InvitationRepo) Create(ctx context.Context, invitation *models.OrganizationInvitation) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.organizations[invitation.OrganizationID]; !ok {
+		return 0, fmt.Errorf("failed to create invitation: %w", errNotExist("organization", invitation.OrganizationID))
+	}
+	if _, ok := r.s.users[invitation.InvitedBy]; !ok {
+		return 0, fmt.Errorf("failed to create invitation: %w", errNotExist("user", invitation.InvitedBy))
+	}
+
+	row := clone(invitation)
+	row.ID = r.s.nextID("organization_invitations")
+	row.OrganizationName = ""
+	row.RespondedAt = nil
+	row.CreatedAt = time.Now()
+	r.s.invitations[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets an invitation by ID
+func (r *InvitationRepo) GetByID(ctx context.Context, id int) (*models.OrganizationInvitation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	invitation, ok := r.s.invitations[id]
+	if !ok {
+		return nil, fmt.Errorf("invitation not found: %w", sql.ErrNoRows)
+	}
+
+	return r.view(invitation), nil
+}
+
+// GetByOrganizationID gets all invitations of an organization, newest first
+func (r *InvitationRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.OrganizationInvitation, error) {
+	return r.list(func(i *models.OrganizationInvitation) bool { return i.OrganizationID == organizationID })
+}
+
+// GetPendingByEmail gets the unexpired pending invitations sent to an email, newest first
+func (r *InvitationRepo) GetPendingByEmail(ctx context.Context, email string) ([]*models.OrganizationInvitation, error) {
+	now := time.Now()
+	return r.list(func(i *models.OrganizationInvitation) bool {
+		return strings.EqualFold(i.Email, email) && i.Status == models.InvitationStatusPending && i.ExpiresAt.After(now)
+	})
+}
+
+// list gets the invitations that match, newest first
+func (r *InvitationRepo) list(match func(*models.OrganizationInvitation) bool) ([]*models.OrganizationInvitation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var invitations []*models.OrganizationInvitation
+	for _, invitation := range rowsOf(r.s.invitations, match) {
+		invitations = append(invitations, r.view(invitation))
+	}
+	sort.SliceStable(invitations, func(i, j int) bool {
+		return invitations[i].CreatedAt.After(invitations[j].CreatedAt)
+	})
+
+	return invitations, nil
+}
+
+// UpdateStatus moves an invitation from one status to another. It reports false if the
+// invitation was not in the expected status.
+func (r *InvitationRepo) UpdateStatus(ctx context.Context, id int, from, to models.InvitationStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invitation, ok := r.s.invitations[id]
+	if !ok || invitation.Status != from {
+		return false, nil
+	}
+
+	invitation.Status = to
+	invitation.RespondedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// view copies an invitation and fills in the organization name
+func (r *InvitationRepo) view(invitation *models.OrganizationInvitation) *models.OrganizationInvitation {
+	i := clone(invitation)
+	if organization, ok := r.s.organizations[invitation.OrganizationID]; ok {
+		i.OrganizationName = organization.Name
+	}
+	return i
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// InvoiceRepo is an in-memory implementation of the repository.InvoiceRepository interface
+type InvoiceRepo struct {
+	s *Store
+}
+
+// NewInvoiceRepository creates a new InvoiceRepo
+func NewInvoiceRepository(s *Store) *InvoiceRepo {
+	return &InvoiceRepo{s: s}
+}
+
+// Create creates a new invoice together with its items
+func (r *InvoiceRepo) Create(ctx context.Context, invoice *models.Invoice) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, userID := range []int{invoice.IssuerID, invoice.RecipientID} {
+		if _, ok := r.s.users[userID]; !ok {
+			return 0, fmt.Errorf("failed to create invoice: %w", errNotExist("user", userID))
+		}
+	}
+	if _, ok := r.s.accounts[invoice.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create invoice: %w", errNotExist("account", invoice.AccountID))
+	}
+	if invoice.MerchantID != nil {
+		if _, ok := r.s.merchants[*invoice.MerchantID]; !ok {
+			return 0, fmt.Errorf("failed to create invoice: %w", errNotExist("merchant", *invoice.MerchantID))
+		}
+	}
+	if invoice.SubscriptionID != nil {
+		if _, ok := r.s.subscriptions[*invoice.SubscriptionID]; !ok {
+			return 0, fmt.Errorf("failed to create invoice: %w", errNotExist("subscription", *invoice.SubscriptionID))
+		}
+	}
+	for _, other := range r.s.invoices {
+		if other.Number == invoice.Number {
+			return 0, fmt.Errorf("failed to create invoice: %w", errDuplicate("invoice number"))
+		}
+	}
+	if invoice.IssuerID == invoice.RecipientID {
+		return 0, fmt.Errorf("failed to create invoice: issuer and recipient must differ")
+	}
+	if invoice.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create invoice: amount must be positive")
+	}
+
+	now := time.Now()
+	invoice.ID = r.s.nextID("invoices")
+	invoice.CreatedAt = now
+	invoice.UpdatedAt = now
+	row := invoiceCopy(invoice)
+	row.Items = nil
+	r.s.invoices[invoice.ID] = row
+
+	for _, item := range invoice.Items {
+		item.ID = r.s.nextID("invoice_items")
+		item.InvoiceID = invoice.ID
+		r.s.invoiceItems[item.ID] = clone(item)
+	}
+
+	return invoice.ID, nil
+}
+
+// GetByID gets an invoice by ID together with its items
+func (r *InvoiceRepo) GetByID(ctx context.Context, id int) (*models.Invoice, error) {
+	return r.get(func(i *models.Invoice) bool { return i.ID == id })
+}
+
+// GetByNumber gets an invoice by its public number together with its items
+func (r *InvoiceRepo) GetByNumber(ctx context.Context, number string) (*models.Invoice, error) {
+	return r.get(func(i *models.Invoice) bool { return i.Number == number })
+}
+
+// get gets the invoice that matches together with its items
+func (r *InvoiceRepo) get(match func(*models.Invoice) bool) (*models.Invoice, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	invoices := rowsOf(r.s.invoices, match)
+	if len(invoices) == 0 {
+		return nil, fmt.Errorf("invoice not found: %w", sql.ErrNoRows)
+	}
+
+	invoice := r.row(invoices[0])
+	invoice.Items = []*models.InvoiceItem{}
+	for _, item := range rowsOf(r.s.invoiceItems, func(i *models.InvoiceItem) bool { return i.InvoiceID == invoice.ID }) {
+		invoice.Items = append(invoice.Items, clone(item))
+	}
+	sort.SliceStable(invoice.Items, func(i, j int) bool { return invoice.Items[i].Position < invoice.Items[j].Position })
+
+	return invoice, nil
+}
+
+// GetByIssuerID gets the invoices a user issued without their items, newest first
+func (r *InvoiceRepo) GetByIssuerID(ctx context.Context, issuerID int) ([]*models.Invoice, error) {
+	return r.list(func(i *models.Invoice) bool { return i.IssuerID == issuerID }), nil
+}
+
+// GetByRecipientID gets the invoices sent to a user without their items, newest first
+func (r *InvoiceRepo) GetByRecipientID(ctx context.Context, recipientID int) ([]*models.Invoice, error) {
+	return r.list(func(i *models.Invoice) bool { return i.RecipientID == recipientID }), nil
+}
+
+// GetByMerchantID gets the invoices a merchant issued without their items, newest first
+func (r *InvoiceRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Invoice, error) {
+	return r.list(func(i *models.Invoice) bool { return i.MerchantID != nil && *i.MerchantID == merchantID }), nil
+}
+
+// GetBySubscriptionID gets the invoices billing the periods of a subscription without their items, newest first
+func (r *InvoiceRepo) GetBySubscriptionID(ctx context.Context, subscriptionID int) ([]*models.Invoice, error) {
+	return r.list(func(i *models.Invoice) bool { return i.SubscriptionID != nil && *i.SubscriptionID == subscriptionID }), nil
+}
+
+// list gets the invoices that match, newest first
+func (r *InvoiceRepo) list(match func(*models.Invoice) bool) []*models.Invoice {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	invoices := []*models.Invoice{}
+	for _, invoice := range rowsOf(r.s.invoices, match) {
+		invoices = append(invoices, r.row(invoice))
+	}
+	sort.SliceStable(invoices, func(i, j int) bool { return invoices[i].ID > invoices[j].ID })
+	return invoices
+}
+
+// Cancel cancels an unpaid invoice. It reports false if the invoice was already paid or cancelled.
+func (r *InvoiceRepo) Cancel(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invoice, ok := r.s.invoices[id]
+	if !ok || !invoice.Status.IsPayable() {
+		return false, nil
+	}
+
+	invoice.Status = models.InvoiceStatusCancelled
+	invoice.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// MarkOverdue moves the issued invoices due before now to OVERDUE and returns them
+func (r *InvoiceRepo) MarkOverdue(ctx context.Context, now time.Time) ([]*models.Invoice, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invoices := []*models.Invoice{}
+	for _, invoice := range rowsOf(r.s.invoices, func(i *models.Invoice) bool {
+		return i.Status == models.InvoiceStatusIssued && !i.DueAt.After(now)
+	}) {
+		invoice.Status = models.InvoiceStatusOverdue
+		invoice.UpdatedAt = time.Now()
+		invoices = append(invoices, r.row(invoice))
+	}
+	sort.SliceStable(invoices, func(i, j int) bool { return invoices[i].DueAt.Before(invoices[j].DueAt) })
+
+	return invoices, nil
+}
+
+// MarkPaidTx records the payment of an unpaid invoice within an existing transaction. It reports
+// false if the invoice was already paid or cancelled, so it can only be paid once.
+func (r *InvoiceRepo) MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, payerAccountID int, transactionID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invoice, ok := r.s.invoices[id]
+	if !ok || !invoice.Status.IsPayable() {
+		return false, nil
+	}
+	if _, ok := r.s.accounts[payerAccountID]; !ok {
+		return false, fmt.Errorf("failed to mark invoice paid: %w", errNotExist("account", payerAccountID))
+	}
+	if _, ok := r.s.transactions[transactionID]; !ok {
+		return false, fmt.Errorf("failed to mark invoice paid: %w", errNotExist("transaction", transactionID))
+	}
+
+	now := time.Now()
+	invoice.Status = models.InvoiceStatusPaid
+	invoice.PayerAccountID = &payerAccountID
+	invoice.TransactionID = &transactionID
+	invoice.PaidAt = timePtr(now)
+	invoice.UpdatedAt = now
+
+	return true, nil
+}
+
+// row copies a stored invoice and fills in the number of the account it is paid to
+func (r *InvoiceRepo) row(invoice *models.Invoice) *models.Invoice {
+	i := invoiceCopy(invoice)
+	if account, ok := r.s.accounts[invoice.AccountID]; ok {
+		i.AccountNumber = account.AccountNumber
+	}
+	return i
+}
+
+// invoiceCopy copies an invoice together with its optional references and timestamps
+func invoiceCopy(invoice *models.Invoice) *models.Invoice {
+	i := clone(invoice)
+	i.MerchantID = intPtr(invoice.MerchantID)
+	i.SubscriptionID = intPtr(invoice.SubscriptionID)
+	i.PayerAccountID = intPtr(invoice.PayerAccountID)
+	i.TransactionID = intPtr(invoice.TransactionID)
+	if invoice.PaidAt != nil {
+		i.PaidAt = timePtr(*invoice.PaidAt)
+	}
+	return i
+}
@@ -0,0 +1,149 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// LocationRepo is an in-memory implementation of the repository.LocationRepository interface
+type LocationRepo struct {
+	s *Store
+}
+
+// NewLocationRepository creates a new LocationRepo
+func NewLocationRepository(s *Store) *LocationRepo {
+	return &LocationRepo{s: s}
+}
+
+// Create saves a new location
+func (r *LocationRepo) Create(ctx context.Context, location *models.Location) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	location.ID = r.s.nextID("locations")
+	location.CreatedAt = time.Now()
+	location.UpdatedAt = location.CreatedAt
+
+	row := locationRow(location)
+	row.DistanceKm = nil
+	r.s.locations[row.ID] = row
+
+	return location.ID, nil
+}
+
+// GetByID gets a location by ID
+func (r *LocationRepo) GetByID(ctx context.Context, id int) (*models.Location, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	location, ok := r.s.locations[id]
+	if !ok {
+		return nil, fmt.Errorf("location not found: %w", sql.ErrNoRows)
+	}
+
+	return locationRow(location), nil
+}
+
+// GetAll gets all locations, including inactive ones
+func (r *LocationRepo) GetAll(ctx context.Context) ([]*models.Location, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	locations := []*models.Location{}
+	for _, location := range rowsOf(r.s.locations, func(*models.Location) bool { return true }) {
+		locations = append(locations, locationRow(location))
+	}
+	sort.SliceStable(locations, func(i, j int) bool { return locations[i].Name < locations[j].Name })
+
+	return locations, nil
+}
+
+// GetNearby gets the active locations within the query radius, nearest first
+func (r *LocationRepo) GetNearby(ctx context.Context, q *models.LocationQuery) ([]*models.Location, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	locations := []*models.Location{}
+	for _, row := range rowsOf(r.s.locations, func(l *models.Location) bool {
+		return l.IsActive && (q.Type == "" || l.Type == q.Type)
+	}) {
+		distance := haversineKm(q.Latitude, q.Longitude, row.Latitude, row.Longitude)
+		if distance > q.RadiusKm {
+			continue
+		}
+
+		location := locationRow(row)
+		location.DistanceKm = &distance
+		locations = append(locations, location)
+	}
+	sort.SliceStable(locations, func(i, j int) bool { return *locations[i].DistanceKm < *locations[j].DistanceKm })
+
+	if len(locations) > models.MaxNearbyLocations {
+		locations = locations[:models.MaxNearbyLocations]
+	}
+
+	return locations, nil
+}
+
+// Update replaces the data of a location
+func (r *LocationRepo) Update(ctx context.Context, location *models.Location) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.locations[location.ID]
+	if !ok {
+		return fmt.Errorf("location not found: %w", sql.ErrNoRows)
+	}
+
+	updated := locationRow(location)
+	updated.DistanceKm = nil
+	updated.CreatedAt = row.CreatedAt
+	updated.UpdatedAt = time.Now()
+	r.s.locations[location.ID] = updated
+
+	location.CreatedAt, location.UpdatedAt = updated.CreatedAt, updated.UpdatedAt
+
+	return nil
+}
+
+// Delete deletes a location
+func (r *LocationRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.locations[id]; !ok {
+		return fmt.Errorf("location not found")
+	}
+	delete(r.s.locations, id)
+
+	return nil
+}
+
+// locationRow copies a location together with its services and opening hours
+func locationRow(location *models.Location) *models.Location {
+	l := clone(location)
+	if location.Services != nil {
+		l.Services = append([]models.LocationService{}, location.Services...)
+	}
+	if location.OpeningHours != nil {
+		l.OpeningHours = make(map[string]string, len(location.OpeningHours))
+		for day, hours := range location.OpeningHours {
+			l.OpeningHours[day] = hours
+		}
+	}
+	return l
+}
+
+// haversineKm returns the great-circle distance between two points, as the SQL search computes it
+func haversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	rad := math.Pi / 180
+	a := math.Pow(math.Sin((lat2-lat1)*rad/2), 2) +
+		math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Pow(math.Sin((lng2-lng1)*rad/2), 2)
+	return 2 * 6371 * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// MerchantRepo is an in-memory implementation of the repository.MerchantRepository interface
+type MerchantRepo struct {
+	s *Store
+}
+
+// NewMerchantRepository creates a new MerchantRepo
+func NewMerchantRepository(s *Store) *MerchantRepo {
+	return &MerchantRepo{s: s}
+}
+
+// Create creates a new merchant
+func (r *MerchantRepo) Create(ctx context.Context, merchant *models.Merchant) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[merchant.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create merchant: %w", errNotExist("user", merchant.UserID))
+	}
+	if _, ok := r.s.accounts[merchant.SettlementAccountID]; !ok {
+		return 0, fmt.Errorf("failed to create merchant: %w", errNotExist("account", merchant.SettlementAccountID))
+	}
+	if r.hashTaken(merchant.APIKeyHash, 0) {
+		return 0, fmt.Errorf("failed to create merchant: %w", errDuplicate("API key"))
+	}
+
+	merchant.ID = r.s.nextID("merchants")
+	merchant.CreatedAt = time.Now()
+	merchant.UpdatedAt = merchant.CreatedAt
+	r.s.merchants[merchant.ID] = clone(merchant)
+
+	return merchant.ID, nil
+}
+
+// GetByID gets a merchant by ID
+func (r *MerchantRepo) GetByID(ctx context.Context, id int) (*models.Merchant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	merchant, ok := r.s.merchants[id]
+	if !ok {
+		return nil, fmt.Errorf("merchant not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(merchant), nil
+}
+
+// GetByAPIKeyHash gets a merchant by the hash of its API key
+func (r *MerchantRepo) GetByAPIKeyHash(ctx context.Context, hash string) (*models.Merchant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, merchant := range r.s.merchants {
+		if merchant.APIKeyHash == hash {
+			return clone(merchant), nil
+		}
+	}
+
+	return nil, fmt.Errorf("merchant not found: %w", sql.ErrNoRows)
+}
+
+// GetByUserID gets the merchants of a user, oldest first
+func (r *MerchantRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Merchant, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	merchants := []*models.Merchant{}
+	for _, merchant := range rowsOf(r.s.merchants, func(m *models.Merchant) bool { return m.UserID == userID }) {
+		merchants = append(merchants, clone(merchant))
+	}
+	sort.SliceStable(merchants, func(i, j int) bool { return merchants[i].CreatedAt.Before(merchants[j].CreatedAt) })
+
+	return merchants, nil
+}
+
+// UpdateAPIKey replaces the API key of a merchant
+func (r *MerchantRepo) UpdateAPIKey(ctx context.Context, id int, hash, prefix string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	merchant, ok := r.s.merchants[id]
+	if !ok {
+		return fmt.Errorf("merchant not found")
+	}
+	if r.hashTaken(hash, id) {
+		return fmt.Errorf("failed to update API key: %w", errDuplicate("API key"))
+	}
+
+	merchant.APIKeyHash = hash
+	merchant.APIKeyPrefix = prefix
+	merchant.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// hashTaken reports whether another merchant already uses the API key hash
+func (r *MerchantRepo) hashTaken(hash string, exceptID int) bool {
+	for _, other := range r.s.merchants {
+		if other.ID != exceptID && other.APIKeyHash == hash {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// MessageRepo is an in-memory implementation of the repository.MessageRepository interface
+type MessageRepo struct {
+	s *Store
+}
+
+// NewMessageRepository creates a new MessageRepo
+func NewMessageRepository(s *Store) *MessageRepo {
+	return &MessageRepo{s: s}
+}
+
+// CreateThreadTx creates a new message thread within a transaction
+func (r *MessageRepo) CreateThreadTx(ctx context.Context, tx *sql.Tx, thread *models.MessageThread) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[thread.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create message thread: %w", errNotExist("user", thread.UserID))
+	}
+
+	thread.ID = r.s.nextID("message_threads")
+	thread.CreatedAt = time.Now()
+	thread.LastMessageAt = thread.CreatedAt
+
+	row := clone(thread)
+	row.UnreadCount = 0
+	row.Messages = nil
+	r.s.threads[row.ID] = row
+
+	return thread.ID, nil
+}
+
+// CreateTx adds a message to its thread within a transaction and moves the thread's last activity
+func (r *MessageRepo) CreateTx(ctx context.Context, tx *sql.Tx, message *models.Message) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	thread, ok := r.s.threads[message.ThreadID]
+	if !ok {
+		return 0, fmt.Errorf("failed to create message: %w", errNotExist("message thread", message.ThreadID))
+	}
+
+	message.ID = r.s.nextID("messages")
+	message.CreatedAt = time.Now()
+
+	row := clone(message)
+	row.DocumentSize = 0
+	row.ReadAt = nil
+	row.Document = nil
+	if len(message.Document) > 0 {
+		row.Document = append([]byte(nil), message.Document...)
+	}
+	r.s.messages[row.ID] = row
+
+	thread.LastMessageAt = message.CreatedAt
+
+	return message.ID, nil
+}
+
+// GetThreadByID gets a message thread of the request's tenant, that of its customer, by ID with the
+// unread count for the reader
+func (r *MessageRepo) GetThreadByID(ctx context.Context, id int, reader models.MessageSender) (*models.MessageThread, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	thread, ok := r.s.threads[id]
+	if !ok || !r.s.userInTenant(ctx, thread.UserID) {
+		return nil, fmt.Errorf("message thread not found: %w", sql.ErrNoRows)
+	}
+
+	return r.threadView(thread, reader), nil
+}
+
+// GetThreadsByUserID gets the threads of a customer, most recently active first
+func (r *MessageRepo) GetThreadsByUserID(ctx context.Context, userID int) ([]*models.MessageThread, error) {
+	return r.listThreads(ctx, models.MessageSenderCustomer, func(t *models.MessageThread) bool { return t.UserID == userID }), nil
+}
+
+// GetThreads gets the threads of the request's tenant as seen by the bank, optionally only those with
+// unread customer messages
+func (r *MessageRepo) GetThreads(ctx context.Context, unreadOnly bool) ([]*models.MessageThread, error) {
+	return r.listThreads(ctx, models.MessageSenderBank, func(t *models.MessageThread) bool {
+		return !unreadOnly || r.unread(t.ID, models.MessageSenderCustomer) > 0
+	}), nil
+}
+
+// listThreads gets the threads of the request's tenant that match as seen by the reader, most
+// recently active first
+func (r *MessageRepo) listThreads(ctx context.Context, reader models.MessageSender, match func(*models.MessageThread) bool) []*models.MessageThread {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	threads := []*models.MessageThread{}
+	for _, thread := range rowsOf(r.s.threads, func(t *models.MessageThread) bool {
+		return match(t) && r.s.userInTenant(ctx, t.UserID)
+	}) {
+		threads = append(threads, r.threadView(thread, reader))
+	}
+	sort.SliceStable(threads, func(i, j int) bool { return threads[i].LastMessageAt.After(threads[j].LastMessageAt) })
+
+	return threads
+}
+
+// GetByThreadID gets the messages of a thread in order, without document contents
+func (r *MessageRepo) GetByThreadID(ctx context.Context, threadID int) ([]*models.Message, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	messages := []*models.Message{}
+	for _, row := range rowsOf(r.s.messages, func(m *models.Message) bool { return m.ThreadID == threadID }) {
+		message := clone(row)
+		message.DocumentSize = len(row.Document)
+		message.Document = nil
+		message.ReadAt = row.ReadAt
+		messages = append(messages, message)
+	}
+	sort.SliceStable(messages, func(i, j int) bool { return messages[i].CreatedAt.Before(messages[j].CreatedAt) })
+
+	return messages, nil
+}
+
+// GetDocument gets a message of a thread together with its document
+func (r *MessageRepo) GetDocument(ctx context.Context, id int, threadID int) (*models.Message, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	row, ok := r.s.messages[id]
+	if !ok || row.ThreadID != threadID || row.Document == nil {
+		return nil, fmt.Errorf("document not found: %w", sql.ErrNoRows)
+	}
+
+	return &models.Message{
+		ID:           row.ID,
+		ThreadID:     row.ThreadID,
+		Sender:       row.Sender,
+		DocumentName: row.DocumentName,
+		DocumentType: row.DocumentType,
+		Document:     append([]byte(nil), row.Document...),
+		DocumentSize: len(row.Document),
+	}, nil
+}
+
+// MarkRead marks the unread messages of a thread written by sender as read
+func (r *MessageRepo) MarkRead(ctx context.Context, threadID int, sender models.MessageSender) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for _, message := range r.s.messages {
+		if message.ThreadID == threadID && message.Sender == sender && message.ReadAt == nil {
+			message.ReadAt = timePtr(now)
+		}
+	}
+
+	return nil
+}
+
+// CountUnread counts the bank's messages the customer has not read yet
+func (r *MessageRepo) CountUnread(ctx context.Context, userID int) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, thread := range r.s.threads {
+		if thread.UserID == userID {
+			count += r.unread(thread.ID, models.MessageSenderBank)
+		}
+	}
+
+	return count, nil
+}
+
+// threadView copies a thread with the number of messages its reader has not read yet
+func (r *MessageRepo) threadView(thread *models.MessageThread, reader models.MessageSender) *models.MessageThread {
+	t := clone(thread)
+	t.UnreadCount = 0
+	for _, message := range r.s.messages {
+		if message.ThreadID == thread.ID && message.Sender != reader && message.ReadAt == nil {
+			t.UnreadCount++
+		}
+	}
+	return t
+}
+
+// unread counts the unread messages of a thread written by sender
+func (r *MessageRepo) unread(threadID int, sender models.MessageSender) int {
+	count := 0
+	for _, message := range r.s.messages {
+		if message.ThreadID == threadID && message.Sender == sender && message.ReadAt == nil {
+			count++
+		}
+	}
+	return count
+}
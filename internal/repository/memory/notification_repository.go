@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// NotificationRepo is an in-memory implementation of the repository.NotificationRepository interface
+type NotificationRepo struct {
+	s *Store
+}
+
+// NewNotificationRepository creates a new NotificationRepo
+func NewNotificationRepository(s *Store) *NotificationRepo {
+	return &NotificationRepo{s: s}
+}
+
+// Create creates a new notification
+func (r *NotificationRepo) Create(ctx context.Context, notification *models.Notification) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[notification.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create notification: %w", errNotExist("user", notification.UserID))
+	}
+
+	row := clone(notification)
+	row.ID = r.s.nextID("notifications")
+	row.IsRead = false
+	row.CreatedAt = time.Now()
+	r.s.notifications[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByUserID gets the notifications of a user, newest first, optionally only the unread ones
+func (r *NotificationRepo) GetByUserID(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var notifications []*models.Notification
+	for _, notification := range rowsOf(r.s.notifications, func(n *models.Notification) bool {
+		return n.UserID == userID && (!unreadOnly || !n.IsRead)
+	}) {
+		notifications = append(notifications, clone(notification))
+	}
+	sort.SliceStable(notifications, func(i, j int) bool {
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+
+	return notifications, nil
+}
+
+// CountSince counts the notifications of a type a user got since a time
+func (r *NotificationRepo) CountSince(ctx context.Context, userID int, notificationType models.NotificationType, since time.Time) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, notification := range r.s.notifications {
+		if notification.UserID == userID && notification.Type == notificationType && !notification.CreatedAt.Before(since) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification of a user as read
+func (r *NotificationRepo) MarkRead(ctx context.Context, id int, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	notification, ok := r.s.notifications[id]
+	if !ok || notification.UserID != userID {
+		return fmt.Errorf("notification not found")
+	}
+
+	notification.IsRead = true
+
+	return nil
+}
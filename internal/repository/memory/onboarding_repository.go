@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// OnboardingRepo is an in-memory implementation of the repository.OnboardingRepository interface
+type OnboardingRepo struct {
+	s *Store
+}
+
+// NewOnboardingRepository creates a new OnboardingRepo
+func NewOnboardingRepository(s *Store) *OnboardingRepo {
+	return &OnboardingRepo{s: s}
+}
+
+// GetByUserID gets the onboarding stages a user completed, in the order they were completed
+func (r *OnboardingRepo) GetByUserID(ctx context.Context, userID int) ([]*models.OnboardingEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*models.OnboardingEvent{}
+	for _, event := range rowsOf(r.s.onboardingEvents, func(e *models.OnboardingEvent) bool { return e.UserID == userID }) {
+		events = append(events, clone(event))
+	}
+
+	return events, nil
+}
+
+// Record records that a user completed an onboarding stage. It reports false if the stage was
+// recorded already, so every stage is completed once.
+func (r *OnboardingRepo) Record(ctx context.Context, userID int, stage models.OnboardingStage) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[userID]; !ok {
+		return false, fmt.Errorf("failed to record onboarding event: %w", errNotExist("user", userID))
+	}
+	for _, event := range r.s.onboardingEvents {
+		if event.UserID == userID && event.Stage == stage {
+			return false, nil
+		}
+	}
+
+	id := r.s.nextID("onboarding_events")
+	r.s.onboardingEvents[id] = &models.OnboardingEvent{
+		ID:          id,
+		UserID:      userID,
+		Stage:       stage,
+		CompletedAt: time.Now(),
+	}
+
+	return true, nil
+}
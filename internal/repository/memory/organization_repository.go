@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// OrganizationRepo is an in-memory implementation of the repository.OrganizationRepository interface
+type OrganizationRepo struct {
+	s *Store
+}
+
+// NewOrganizationRepository creates a new OrganizationRepo
+func NewOrganizationRepository(s *Store) *OrganizationRepo {
+	return &OrganizationRepo{s: s}
+}
+
+// Create creates a new organization and makes its creator an admin member
+func (r *OrganizationRepo) Create(ctx context.Context, organization *models.Organization) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[organization.CreatedBy]; !ok {
+		return 0, fmt.Errorf("failed to create organization: %w", errNotExist("user", organization.CreatedBy))
+	}
+
+	now := time.Now()
+	row := &models.Organization{
+		ID:        r.s.nextID("organizations"),
+		Name:      organization.Name,
+		CreatedBy: organization.CreatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.s.organizations[row.ID] = row
+	r.s.addMember(row.ID, row.CreatedBy, models.OrganizationRoleAdmin)
+
+	return row.ID, nil
+}
+
+// GetByID gets an organization by ID
+func (r *OrganizationRepo) GetByID(ctx context.Context, id int) (*models.Organization, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	organization, ok := r.s.organizations[id]
+	if !ok {
+		return nil, fmt.Errorf("organization not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(organization), nil
+}
+
+// GetByUserID gets all organizations a user is a member of, with the user's role
+func (r *OrganizationRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Organization, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var organizations []*models.Organization
+	for _, member := range rowsOf(r.s.members, func(m *models.OrganizationMember) bool { return m.UserID == userID }) {
+		organization := clone(r.s.organizations[member.OrganizationID])
+		organization.Role = member.Role
+		organizations = append(organizations, organization)
+	}
+	sort.SliceStable(organizations, func(i, j int) bool { return organizations[i].Name < organizations[j].Name })
+
+	return organizations, nil
+}
+
+// GetMember gets the membership of a user in an organization
+func (r *OrganizationRepo) GetMember(ctx context.Context, organizationID, userID int) (*models.OrganizationMember, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	member := r.s.findMember(organizationID, userID)
+	if member == nil {
+		return nil, fmt.Errorf("member not found: %w", sql.ErrNoRows)
+	}
+
+	return r.s.memberView(member), nil
+}
+
+// GetMembers gets all members of an organization
+func (r *OrganizationRepo) GetMembers(ctx context.Context, organizationID int) ([]*models.OrganizationMember, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var members []*models.OrganizationMember
+	for _, member := range rowsOf(r.s.members, func(m *models.OrganizationMember) bool {
+		return m.OrganizationID == organizationID
+	}) {
+		members = append(members, r.s.memberView(member))
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].CreatedAt.Before(members[j].CreatedAt) })
+
+	return members, nil
+}
+
+// AddMember adds a user to an organization with the given role
+func (r *OrganizationRepo) AddMember(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.organizations[organizationID]; !ok {
+		return fmt.Errorf("failed to add member: %w", errNotExist("organization", organizationID))
+	}
+	if _, ok := r.s.users[userID]; !ok {
+		return fmt.Errorf("failed to add member: %w", errNotExist("user", userID))
+	}
+	if r.s.findMember(organizationID, userID) != nil {
+		return fmt.Errorf("user is already a member")
+	}
+
+	r.s.addMember(organizationID, userID, role)
+
+	return nil
+}
+
+// UpdateMemberRole changes the role of a member. The last admin cannot be demoted.
+func (r *OrganizationRepo) UpdateMemberRole(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	member := r.s.findMember(organizationID, userID)
+	if member == nil || (role != models.OrganizationRoleAdmin && r.lastAdmin(member)) {
+		return fmt.Errorf("member not found or is the last admin")
+	}
+
+	member.Role = role
+
+	return nil
+}
+
+// RemoveMember removes a user from an organization. The last admin cannot be removed.
+func (r *OrganizationRepo) RemoveMember(ctx context.Context, organizationID, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	member := r.s.findMember(organizationID, userID)
+	if member == nil || r.lastAdmin(member) {
+		return fmt.Errorf("member not found or is the last admin")
+	}
+
+	delete(r.s.members, member.ID)
+
+	return nil
+}
+
+// lastAdmin reports whether the member is the only admin of the organization
+func (r *OrganizationRepo) lastAdmin(member *models.OrganizationMember) bool {
+	if member.Role != models.OrganizationRoleAdmin {
+		return false
+	}
+	for _, other := range r.s.members {
+		if other.OrganizationID == member.OrganizationID && other.UserID != member.UserID &&
+			other.Role == models.OrganizationRoleAdmin {
+			return false
+		}
+	}
+	return true
+}
+
+// addMember stores a membership
+func (s *Store) addMember(organizationID, userID int, role models.OrganizationRole) {
+	member := &models.OrganizationMember{
+		ID:             s.nextID("organization_members"),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      time.Now(),
+	}
+	s.members[member.ID] = member
+}
+
+// findMember returns the membership of a user in an organization, or nil
+func (s *Store) findMember(organizationID, userID int) *models.OrganizationMember {
+	for _, member := range s.members {
+		if member.OrganizationID == organizationID && member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+// memberView copies a membership and fills in the user's username and email
+func (s *Store) memberView(member *models.OrganizationMember) *models.OrganizationMember {
+	m := clone(member)
+	if user, ok := s.users[member.UserID]; ok {
+		m.Username, m.Email = user.Username, user.Email
+	}
+	return m
+}
@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// PasswordResetRepo is an in-memory implementation of the repository.PasswordResetRepository interface
+type PasswordResetRepo struct {
+	s *Store
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepo
+func NewPasswordResetRepository(s *Store) *PasswordResetRepo {
+	return &PasswordResetRepo{s: s}
+}
+
+// Create creates a new password reset token
+func (r *PasswordResetRepo) Create(ctx context.Context, reset *models.PasswordReset) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[reset.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create password reset: %w", errNotExist("user", reset.UserID))
+	}
+	for _, other := range r.s.passwordResets {
+		if other.TokenHash == reset.TokenHash {
+			return 0, fmt.Errorf("failed to create password reset: %w", errDuplicate("token"))
+		}
+	}
+
+	reset.ID = r.s.nextID("password_resets")
+	reset.UsedAt = nil
+	reset.CreatedAt = time.Now()
+	r.s.passwordResets[reset.ID] = clone(reset)
+
+	return reset.ID, nil
+}
+
+// GetByTokenHash gets a password reset by the hash of its token
+func (r *PasswordResetRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, reset := range r.s.passwordResets {
+		if reset.TokenHash == tokenHash {
+			row := clone(reset)
+			if reset.UsedAt != nil {
+				row.UsedAt = timePtr(*reset.UsedAt)
+			}
+			return row, nil
+		}
+	}
+
+	return nil, fmt.Errorf("password reset not found: %w", sql.ErrNoRows)
+}
+
+// MarkUsed marks an unused password reset as used. It reports false if the token was used
+// already, so a token can only be used once.
+func (r *PasswordResetRepo) MarkUsed(ctx context.Context, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	reset, ok := r.s.passwordResets[id]
+	if !ok || reset.UsedAt != nil {
+		return false, nil
+	}
+
+	reset.UsedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// InvalidateByUserID marks every unused password reset of a user as used
+func (r *PasswordResetRepo) InvalidateByUserID(ctx context.Context, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for _, reset := range r.s.passwordResets {
+		if reset.UserID == userID && reset.UsedAt == nil {
+			reset.UsedAt = timePtr(now)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// PaymentIntentRepo is an in-memory implementation of the repository.PaymentIntentRepository interface
+type PaymentIntentRepo struct {
+	s *Store
+}
+
+// NewPaymentIntentRepository creates a new PaymentIntentRepo
+func NewPaymentIntentRepository(s *Store) *PaymentIntentRepo {
+	return &PaymentIntentRepo{s: s}
+}
+
+// Create creates a new payment intent
+func (r *PaymentIntentRepo) Create(ctx context.Context, intent *models.PaymentIntent) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.merchants[intent.MerchantID]; !ok {
+		return 0, fmt.Errorf("failed to create payment intent: %w", errNotExist("merchant", intent.MerchantID))
+	}
+	for _, other := range r.s.paymentIntents {
+		if other.IntentID == intent.IntentID {
+			return 0, fmt.Errorf("failed to create payment intent: %w", errDuplicate("intent ID"))
+		}
+	}
+	if intent.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create payment intent: amount must be positive")
+	}
+
+	intent.ID = r.s.nextID("payment_intents")
+	intent.CreatedAt = time.Now()
+	intent.UpdatedAt = intent.CreatedAt
+
+	row := clone(intent)
+	row.MerchantName = ""
+	row.CustomerID, row.SourceAccountID, row.TransactionID, row.SettlementBatchID = nil, nil, nil, nil
+	row.PaidAt = nil
+	r.s.paymentIntents[row.ID] = row
+
+	return intent.ID, nil
+}
+
+// GetByIntentID gets a payment intent by its public ID
+func (r *PaymentIntentRepo) GetByIntentID(ctx context.Context, intentID string) (*models.PaymentIntent, error) {
+	return r.find(func(i *models.PaymentIntent) bool { return i.IntentID == intentID })
+}
+
+// GetByTransactionID gets the payment intent paid by a transaction
+func (r *PaymentIntentRepo) GetByTransactionID(ctx context.Context, transactionID int) (*models.PaymentIntent, error) {
+	return r.find(func(i *models.PaymentIntent) bool {
+		return i.TransactionID != nil && *i.TransactionID == transactionID
+	})
+}
+
+// find gets the payment intent that matches
+func (r *PaymentIntentRepo) find(match func(*models.PaymentIntent) bool) (*models.PaymentIntent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, intent := range r.s.paymentIntents {
+		if match(intent) {
+			return r.s.intentView(intent), nil
+		}
+	}
+
+	return nil, fmt.Errorf("payment intent not found: %w", sql.ErrNoRows)
+}
+
+// GetByMerchantID gets the payment intents of a merchant, newest first
+func (r *PaymentIntentRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.PaymentIntent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	intents := []*models.PaymentIntent{}
+	for _, intent := range rowsOf(r.s.paymentIntents, func(i *models.PaymentIntent) bool { return i.MerchantID == merchantID }) {
+		intents = append(intents, r.s.intentView(intent))
+	}
+	sort.SliceStable(intents, func(i, j int) bool { return intents[i].CreatedAt.After(intents[j].CreatedAt) })
+
+	return intents, nil
+}
+
+// UpdateStatus moves a payment intent from one status to another. It reports false if the
+// intent was not in the expected status.
+func (r *PaymentIntentRepo) UpdateStatus(ctx context.Context, id int, from, to models.PaymentIntentStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	intent, ok := r.s.paymentIntents[id]
+	if !ok || intent.Status != from {
+		return false, nil
+	}
+
+	intent.Status = to
+	intent.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// MarkPaidTx records the payment of an unexpired intent within an existing transaction. It reports
+// false if the intent can no longer be paid, so it is only paid once.
+func (r *PaymentIntentRepo) MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, customerID, accountID, transactionID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	intent, ok := r.s.paymentIntents[id]
+	if !ok || intent.Status != models.PaymentIntentStatusCreated || !intent.ExpiresAt.After(now) {
+		return false, nil
+	}
+
+	intent.Status = models.PaymentIntentStatusSucceeded
+	intent.CustomerID = &customerID
+	intent.SourceAccountID = &accountID
+	intent.TransactionID = &transactionID
+	intent.PaidAt = timePtr(now)
+	intent.UpdatedAt = now
+
+	return true, nil
+}
+
+// intentView copies a payment intent and fills in the merchant name
+func (s *Store) intentView(intent *models.PaymentIntent) *models.PaymentIntent {
+	i := clone(intent)
+	i.CustomerID = intPtr(intent.CustomerID)
+	i.SourceAccountID = intPtr(intent.SourceAccountID)
+	i.TransactionID = intPtr(intent.TransactionID)
+	i.SettlementBatchID = intPtr(intent.SettlementBatchID)
+	if merchant, ok := s.merchants[intent.MerchantID]; ok {
+		i.MerchantName = merchant.Name
+	}
+	return i
+}
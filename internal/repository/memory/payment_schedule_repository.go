@@ -0,0 +1,357 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// PaymentScheduleRepo is an in-memory implementation of the repository.PaymentScheduleRepository interface
+type PaymentScheduleRepo struct {
+	s *Store
+}
+
+// NewPaymentScheduleRepository creates a new PaymentScheduleRepo
+func NewPaymentScheduleRepository(s *Store) *PaymentScheduleRepo {
+	return &PaymentScheduleRepo{s: s}
+}
+
+// Create creates a new payment schedule item
+func (r *PaymentScheduleRepo) Create(ctx context.Context, schedule *models.PaymentSchedule) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if err := r.check(schedule); err != nil {
+		return 0, fmt.Errorf("failed to create payment schedule: %w", err)
+	}
+
+	return r.insert(schedule), nil
+}
+
+// CreateBatch creates payment schedule items; either all of them are created or none
+func (r *PaymentScheduleRepo) CreateBatch(ctx context.Context, schedules []*models.PaymentSchedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, schedule := range schedules {
+		if err := r.check(schedule); err != nil {
+			return fmt.Errorf("failed to insert payment schedules: %w", err)
+		}
+	}
+
+	for _, schedule := range schedules {
+		r.insert(schedule)
+	}
+
+	return nil
+}
+
+// CreateBatchTx creates multiple payment schedule items within an existing transaction
+func (r *PaymentScheduleRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error {
+	return r.CreateBatch(ctx, schedules)
+}
+
+// check validates a payment schedule item as the table constraints do
+func (r *PaymentScheduleRepo) check(schedule *models.PaymentSchedule) error {
+	if _, ok := r.s.credits[schedule.CreditID]; !ok {
+		return errNotExist("credit", schedule.CreditID)
+	}
+	if schedule.PrincipalAmount < 0 || schedule.InterestAmount < 0 || schedule.InsuranceAmount < 0 ||
+		schedule.TotalAmount < 0 || schedule.PenaltyAmount < 0 {
+		return fmt.Errorf("negative payment amount")
+	}
+	return nil
+}
+
+// insert stores a payment schedule item and returns its ID
+func (r *PaymentScheduleRepo) insert(schedule *models.PaymentSchedule) int {
+	row := clone(schedule)
+	row.ID = r.s.nextID("payment_schedules")
+	row.PaymentDate = dateOf(schedule.PaymentDate)
+	row.AccountID = 0
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.schedules[row.ID] = row
+
+	return row.ID
+}
+
+// GetByID gets a payment schedule item by ID
+func (r *PaymentScheduleRepo) GetByID(ctx context.Context, id int) (*models.PaymentSchedule, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	schedule, ok := r.s.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("payment schedule not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(schedule), nil
+}
+
+// GetByCreditID gets all payment schedule items for a credit
+func (r *PaymentScheduleRepo) GetByCreditID(ctx context.Context, creditID int) ([]*models.PaymentSchedule, error) {
+	return r.list(func(ps *models.PaymentSchedule) bool { return ps.CreditID == creditID })
+}
+
+// Update updates a payment schedule item
+func (r *PaymentScheduleRepo) Update(ctx context.Context, schedule *models.PaymentSchedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.schedules[schedule.ID]
+	if !ok {
+		return fmt.Errorf("payment schedule not found")
+	}
+
+	row.Status = schedule.Status
+	row.IsOverdue = schedule.IsOverdue
+	row.PenaltyAmount = schedule.PenaltyAmount
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetPendingPayments gets up to limit pending payments that are due on or before a specific date,
+// in ID order starting after the given ID
+func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.Time, afterID, limit int) ([]*models.PaymentSchedule, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var schedules []*models.PaymentSchedule
+	for _, schedule := range rowsOf(r.s.schedules, func(ps *models.PaymentSchedule) bool {
+		return ps.ID > afterID && ps.Status == models.PaymentStatusPending && !ps.PaymentDate.After(date)
+	}) {
+		credit, ok := r.s.credits[schedule.CreditID]
+		if !ok {
+			continue
+		}
+		if len(schedules) == limit {
+			break
+		}
+
+		row := clone(schedule)
+		row.AccountID = credit.AccountID
+		schedules = append(schedules, row)
+	}
+
+	return schedules, nil
+}
+
+// GetPaymentsToRemind gets up to limit pending payments due after from and on or before to that
+// have no reminder sent daysBefore or fewer days ahead, in ID order starting after the given ID
+func (r *PaymentScheduleRepo) GetPaymentsToRemind(ctx context.Context, from, to time.Time, daysBefore, afterID, limit int) ([]*models.PaymentSchedule, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	reminded := make(map[int]bool)
+	for key := range r.s.paymentReminders {
+		if key.daysBefore <= daysBefore {
+			reminded[key.paymentID] = true
+		}
+	}
+
+	var schedules []*models.PaymentSchedule
+	for _, schedule := range rowsOf(r.s.schedules, func(ps *models.PaymentSchedule) bool {
+		return ps.ID > afterID && ps.Status == models.PaymentStatusPending && !reminded[ps.ID] &&
+			ps.PaymentDate.After(from) && !ps.PaymentDate.After(to)
+	}) {
+		credit, ok := r.s.credits[schedule.CreditID]
+		if !ok {
+			continue
+		}
+		if len(schedules) == limit {
+			break
+		}
+
+		row := clone(schedule)
+		row.AccountID = credit.AccountID
+		schedules = append(schedules, row)
+	}
+
+	return schedules, nil
+}
+
+// MarkReminded records that the reminder sent daysBefore days ahead of a payment went out. It
+// returns false if it was already recorded.
+func (r *PaymentScheduleRepo) MarkReminded(ctx context.Context, paymentID, daysBefore int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.schedules[paymentID]; !ok {
+		return false, fmt.Errorf("failed to mark payment reminded: %w", errNotExist("payment schedule", paymentID))
+	}
+
+	key := paymentReminderKey{paymentID: paymentID, daysBefore: daysBefore}
+	if _, ok := r.s.paymentReminders[key]; ok {
+		return false, nil
+	}
+	r.s.paymentReminders[key] = time.Now()
+
+	return true, nil
+}
+
+// UnmarkReminded removes a reminder record, so the reminder is sent again
+func (r *PaymentScheduleRepo) UnmarkReminded(ctx context.Context, paymentID, daysBefore int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.paymentReminders, paymentReminderKey{paymentID: paymentID, daysBefore: daysBefore})
+
+	return nil
+}
+
+// GetDuePayments gets the pending payments that are due on or before a specific date with their
+// credit, the credit's account and the amount held for them. It returns all such payments of up to
+// limit accounts in account ID order starting after the given account, oldest first within an
+// account.
+func (r *PaymentScheduleRepo) GetDuePayments(ctx context.Context, date time.Time, afterAccountID, limit int) ([]*models.DuePayment, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	now := time.Now()
+	var payments []*models.DuePayment
+	for _, schedule := range rowsOf(r.s.schedules, func(ps *models.PaymentSchedule) bool {
+		return ps.Status == models.PaymentStatusPending && !ps.PaymentDate.After(date)
+	}) {
+		credit, ok := r.s.credits[schedule.CreditID]
+		if !ok {
+			continue
+		}
+		account, ok := r.s.accounts[credit.AccountID]
+		if !ok || account.ID <= afterAccountID {
+			continue
+		}
+
+		due := &models.DuePayment{
+			Payment: clone(schedule),
+			Credit:  clone(credit),
+			Account: &models.Account{
+				ID:               account.ID,
+				Balance:          account.Balance,
+				AvailableBalance: account.Balance - r.s.activeHolds(account.ID),
+			},
+		}
+		due.Payment.AccountID = account.ID
+		for _, hold := range r.s.holds {
+			if hold.Reason == models.HoldReasonCreditPayment && hold.ReferenceID == schedule.ID && holdActive(hold, now) {
+				due.HeldAmount += hold.Amount
+			}
+		}
+
+		payments = append(payments, due)
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		if payments[i].Account.ID != payments[j].Account.ID {
+			return payments[i].Account.ID < payments[j].Account.ID
+		}
+		return payments[i].Payment.PaymentDate.Before(payments[j].Payment.PaymentDate)
+	})
+
+	// Keep the payments of the first limit accounts
+	accounts := 0
+	for i, due := range payments {
+		if i == 0 || due.Account.ID != payments[i-1].Account.ID {
+			if accounts == limit {
+				return payments[:i], nil
+			}
+			accounts++
+		}
+	}
+
+	return payments, nil
+}
+
+// UpdateBatchTx updates the status and penalty of multiple payment schedule items within an
+// existing transaction; either all of them are updated or none
+func (r *PaymentScheduleRepo) UpdateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, schedule := range schedules {
+		if _, ok := r.s.schedules[schedule.ID]; !ok {
+			return fmt.Errorf("payment schedule not found")
+		}
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		row := r.s.schedules[schedule.ID]
+		row.Status = schedule.Status
+		row.IsOverdue = schedule.IsOverdue
+		row.PenaltyAmount = schedule.PenaltyAmount
+		row.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// RescheduleTx moves a payment schedule item to its new payment date within an existing
+// transaction, together with its status and overdue flag
+func (r *PaymentScheduleRepo) RescheduleTx(ctx context.Context, tx *sql.Tx, schedule *models.PaymentSchedule) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.schedules[schedule.ID]
+	if !ok {
+		return fmt.Errorf("payment schedule not found")
+	}
+
+	row.PaymentDate = dateOf(schedule.PaymentDate)
+	row.Status = schedule.Status
+	row.IsOverdue = schedule.IsOverdue
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// GetOverduePayments gets all overdue payments
+func (r *PaymentScheduleRepo) GetOverduePayments(ctx context.Context) ([]*models.PaymentSchedule, error) {
+	return r.list(func(ps *models.PaymentSchedule) bool {
+		return ps.Status == models.PaymentStatusOverdue && ps.IsOverdue
+	})
+}
+
+// list gets the payment schedule items that match ordered by payment date
+func (r *PaymentScheduleRepo) list(match func(*models.PaymentSchedule) bool) ([]*models.PaymentSchedule, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var schedules []*models.PaymentSchedule
+	for _, schedule := range rowsOf(r.s.schedules, match) {
+		schedules = append(schedules, clone(schedule))
+	}
+	sort.SliceStable(schedules, func(i, j int) bool {
+		return schedules[i].PaymentDate.Before(schedules[j].PaymentDate)
+	})
+
+	return schedules, nil
+}
+
+// RemoveInsuranceTx takes the insurance premium out of the credit's future pending payments
+// within a transaction and returns how many payments changed
+func (r *PaymentScheduleRepo) RemoveInsuranceTx(ctx context.Context, tx *sql.Tx, creditID int) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	today := dateOf(time.Now())
+	var changed int64
+	for _, schedule := range r.s.schedules {
+		if schedule.CreditID != creditID || schedule.Status != models.PaymentStatusPending ||
+			!schedule.PaymentDate.After(today) || schedule.InsuranceAmount <= 0 {
+			continue
+		}
+
+		schedule.TotalAmount -= schedule.InsuranceAmount
+		schedule.InsuranceAmount = 0
+		schedule.UpdatedAt = time.Now()
+		changed++
+	}
+
+	return changed, nil
+}
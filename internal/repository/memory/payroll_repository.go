@@ -0,0 +1,195 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// PayrollRepo is an in-memory implementation of the repository.PayrollRepository interface
+type PayrollRepo struct {
+	s *Store
+}
+
+// NewPayrollRepository creates a new PayrollRepo
+func NewPayrollRepository(s *Store) *PayrollRepo {
+	return &PayrollRepo{s: s}
+}
+
+// Create creates a new payroll together with its items
+func (r *PayrollRepo) Create(ctx context.Context, payroll *models.Payroll) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.organizations[payroll.OrganizationID]; !ok {
+		return 0, fmt.Errorf("failed to create payroll: %w", errNotExist("organization", payroll.OrganizationID))
+	}
+	if _, ok := r.s.accounts[payroll.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create payroll: %w", errNotExist("account", payroll.AccountID))
+	}
+	if _, ok := r.s.users[payroll.UploadedBy]; !ok {
+		return 0, fmt.Errorf("failed to create payroll: %w", errNotExist("user", payroll.UploadedBy))
+	}
+	if payroll.ItemCount <= 0 {
+		return 0, fmt.Errorf("failed to create payroll: item count must be positive")
+	}
+	for _, item := range payroll.Items {
+		if item.AccountID != nil {
+			if _, ok := r.s.accounts[*item.AccountID]; !ok {
+				return 0, fmt.Errorf("failed to create payroll item: %w", errNotExist("account", *item.AccountID))
+			}
+		}
+	}
+
+	now := time.Now()
+	payroll.ID = r.s.nextID("payrolls")
+	payroll.CreatedAt = now
+	payroll.UpdatedAt = now
+	r.s.payrolls[payroll.ID] = payrollRow(payroll)
+
+	for _, item := range payroll.Items {
+		item.ID = r.s.nextID("payroll_items")
+		item.PayrollID = payroll.ID
+		r.s.payrollItems[item.ID] = payrollItemRow(item)
+	}
+
+	return payroll.ID, nil
+}
+
+// GetByID gets a payroll by ID, without its items
+func (r *PayrollRepo) GetByID(ctx context.Context, id int) (*models.Payroll, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	payroll, ok := r.s.payrolls[id]
+	if !ok {
+		return nil, fmt.Errorf("payroll not found: %w", sql.ErrNoRows)
+	}
+
+	return payrollRow(payroll), nil
+}
+
+// GetByOrganizationID gets the payrolls of an organization without their items, newest first
+func (r *PayrollRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Payroll, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	payrolls := []*models.Payroll{}
+	for _, payroll := range rowsOf(r.s.payrolls, func(p *models.Payroll) bool {
+		return p.OrganizationID == organizationID
+	}) {
+		payrolls = append(payrolls, payrollRow(payroll))
+	}
+	sort.SliceStable(payrolls, func(i, j int) bool { return payrolls[i].ID > payrolls[j].ID })
+
+	return payrolls, nil
+}
+
+// GetItems gets the items of a payroll in file order
+func (r *PayrollRepo) GetItems(ctx context.Context, payrollID int) ([]*models.PayrollItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	items := []*models.PayrollItem{}
+	for _, item := range rowsOf(r.s.payrollItems, func(i *models.PayrollItem) bool {
+		return i.PayrollID == payrollID
+	}) {
+		items = append(items, payrollItemRow(item))
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].LineNumber < items[j].LineNumber })
+
+	return items, nil
+}
+
+// UpdateStatusTx moves a payroll from one status to another and records who executed it within an
+// existing transaction. It reports false if the payroll was not in the expected status.
+func (r *PayrollRepo) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.PayrollStatus, executedBy int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	payroll, ok := r.s.payrolls[id]
+	if !ok || payroll.Status != from {
+		return false, nil
+	}
+	if _, ok := r.s.users[executedBy]; !ok {
+		return false, fmt.Errorf("failed to update payroll status: %w", errNotExist("user", executedBy))
+	}
+
+	now := time.Now()
+	payroll.Status = to
+	payroll.ExecutedBy = &executedBy
+	payroll.ExecutedAt = timePtr(now)
+	payroll.UpdatedAt = now
+
+	return true, nil
+}
+
+// UpdateItemTx stores the outcome of a payroll item within an existing transaction
+func (r *PayrollRepo) UpdateItemTx(ctx context.Context, tx *sql.Tx, item *models.PayrollItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.payrollItems[item.ID]
+	if !ok {
+		return fmt.Errorf("payroll item not found: %w", sql.ErrNoRows)
+	}
+	if item.TransactionID != nil {
+		if _, ok := r.s.transactions[*item.TransactionID]; !ok {
+			return fmt.Errorf("failed to update payroll item: %w", errNotExist("transaction", *item.TransactionID))
+		}
+	}
+
+	row.Status = item.Status
+	row.Error = item.Error
+	row.TransactionID = intPtr(item.TransactionID)
+
+	return nil
+}
+
+// SetSettlementTx stores the paid totals and the funding transaction of an executed payroll within
+// an existing transaction
+func (r *PayrollRepo) SetSettlementTx(ctx context.Context, tx *sql.Tx, payroll *models.Payroll) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.payrolls[payroll.ID]
+	if !ok {
+		return nil
+	}
+	if payroll.FundingTransactionID != nil {
+		if _, ok := r.s.transactions[*payroll.FundingTransactionID]; !ok {
+			return fmt.Errorf("failed to update payroll settlement: %w", errNotExist("transaction", *payroll.FundingTransactionID))
+		}
+	}
+
+	row.PaidCount = payroll.PaidCount
+	row.PaidAmount = payroll.PaidAmount
+	row.FundingTransactionID = intPtr(payroll.FundingTransactionID)
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// payrollRow copies a payroll without its items
+func payrollRow(payroll *models.Payroll) *models.Payroll {
+	p := clone(payroll)
+	p.ExecutedBy = intPtr(payroll.ExecutedBy)
+	p.FundingTransactionID = intPtr(payroll.FundingTransactionID)
+	if payroll.ExecutedAt != nil {
+		p.ExecutedAt = timePtr(*payroll.ExecutedAt)
+	}
+	p.Items = nil
+	return p
+}
+
+// payrollItemRow copies a payroll item
+func payrollItemRow(item *models.PayrollItem) *models.PayrollItem {
+	i := clone(item)
+	i.AccountID = intPtr(item.AccountID)
+	i.TransactionID = intPtr(item.TransactionID)
+	return i
+}
@@ -0,0 +1,171 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// PendingTransferRepo is an in-memory implementation of the repository.PendingTransferRepository interface
+type PendingTransferRepo struct {
+	s *Store
+}
+
+// NewPendingTransferRepository creates a new PendingTransferRepo
+func NewPendingTransferRepository(s *Store) *PendingTransferRepo {
+	return &PendingTransferRepo{s: s}
+}
+
+// Create creates a new pending transfer
+func (r *PendingTransferRepo) Create(ctx context.Context, transfer *models.PendingTransfer) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.organizations[transfer.OrganizationID]; !ok {
+		return 0, fmt.Errorf("failed to create pending transfer: %w", errNotExist("organization", transfer.OrganizationID))
+	}
+	if _, ok := r.s.users[transfer.RequestedBy]; !ok {
+		return 0, fmt.Errorf("failed to create pending transfer: %w", errNotExist("user", transfer.RequestedBy))
+	}
+	for _, id := range []int{transfer.SourceAccountID, transfer.DestinationAccountID} {
+		if _, ok := r.s.accounts[id]; !ok {
+			return 0, fmt.Errorf("failed to create pending transfer: %w", errNotExist("account", id))
+		}
+	}
+	if transfer.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create pending transfer: amount must be positive")
+	}
+
+	row := clone(transfer)
+	row.ID = r.s.nextID("pending_transfers")
+	row.Approvals = nil
+	row.InvoiceID = intPtr(transfer.InvoiceID)
+	row.TransactionID = nil
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.pendingTransfers[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets a pending transfer by ID
+func (r *PendingTransferRepo) GetByID(ctx context.Context, id int) (*models.PendingTransfer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transfer, ok := r.s.pendingTransfers[id]
+	if !ok {
+		return nil, fmt.Errorf("pending transfer not found: %w", sql.ErrNoRows)
+	}
+
+	return pendingTransferRow(transfer), nil
+}
+
+// GetByOrganizationID gets all pending transfers of an organization, newest first
+func (r *PendingTransferRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.PendingTransfer, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var transfers []*models.PendingTransfer
+	for _, transfer := range rowsOf(r.s.pendingTransfers, func(t *models.PendingTransfer) bool {
+		return t.OrganizationID == organizationID
+	}) {
+		transfers = append(transfers, pendingTransferRow(transfer))
+	}
+	sort.SliceStable(transfers, func(i, j int) bool { return transfers[i].CreatedAt.After(transfers[j].CreatedAt) })
+
+	return transfers, nil
+}
+
+// AddApproval records the approval of a transfer by a user
+func (r *PendingTransferRepo) AddApproval(ctx context.Context, id int, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.pendingTransfers[id]; !ok {
+		return fmt.Errorf("failed to add approval: %w", errNotExist("pending transfer", id))
+	}
+	if _, ok := r.s.users[userID]; !ok {
+		return fmt.Errorf("failed to add approval: %w", errNotExist("user", userID))
+	}
+	for _, approval := range r.s.approvals {
+		if approval.PendingTransferID == id && approval.UserID == userID {
+			return fmt.Errorf("transfer already approved by this user")
+		}
+	}
+
+	approval := &models.TransferApproval{
+		ID:                r.s.nextID("pending_transfer_approvals"),
+		PendingTransferID: id,
+		UserID:            userID,
+		CreatedAt:         time.Now(),
+	}
+	r.s.approvals[approval.ID] = approval
+
+	return nil
+}
+
+// GetApprovals gets the approvals of a transfer, oldest first
+func (r *PendingTransferRepo) GetApprovals(ctx context.Context, id int) ([]*models.TransferApproval, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	approvals := []*models.TransferApproval{}
+	for _, approval := range rowsOf(r.s.approvals, func(a *models.TransferApproval) bool {
+		return a.PendingTransferID == id
+	}) {
+		a := clone(approval)
+		if user, ok := r.s.users[approval.UserID]; ok {
+			a.Username = user.Username
+		}
+		approvals = append(approvals, a)
+	}
+	sort.SliceStable(approvals, func(i, j int) bool { return approvals[i].CreatedAt.Before(approvals[j].CreatedAt) })
+
+	return approvals, nil
+}
+
+// UpdateStatus moves a pending transfer from one status to another. It reports false if the
+// transfer was not in the expected status, so it is only executed once.
+func (r *PendingTransferRepo) UpdateStatus(ctx context.Context, id int, from, to models.PendingTransferStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transfer, ok := r.s.pendingTransfers[id]
+	if !ok || transfer.Status != from {
+		return false, nil
+	}
+
+	transfer.Status = to
+	transfer.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// SetTransaction links the executed transaction to the pending transfer
+func (r *PendingTransferRepo) SetTransaction(ctx context.Context, id int, transactionID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.transactions[transactionID]; !ok {
+		return fmt.Errorf("failed to link transaction: %w", errNotExist("transaction", transactionID))
+	}
+	if transfer, ok := r.s.pendingTransfers[id]; ok {
+		transfer.TransactionID = &transactionID
+		transfer.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// pendingTransferRow copies a pending transfer together with its invoice and transaction references
+func pendingTransferRow(transfer *models.PendingTransfer) *models.PendingTransfer {
+	t := clone(transfer)
+	t.InvoiceID = intPtr(transfer.InvoiceID)
+	t.TransactionID = intPtr(transfer.TransactionID)
+	return t
+}
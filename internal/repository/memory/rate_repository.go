@@ -0,0 +1,134 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// RateRepo is an in-memory implementation of the repository.RateRepository interface
+type RateRepo struct {
+	s *Store
+}
+
+// NewRateRepository creates a new RateRepo
+func NewRateRepository(s *Store) *RateRepo {
+	return &RateRepo{s: s}
+}
+
+// Create saves a fetched rate
+func (r *RateRepo) Create(ctx context.Context, rate *models.Rate) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if (rate.Type == models.RateTypeKey) != (rate.Currency == "") {
+		return fmt.Errorf("failed to save rate: currency must be set for exchange rates only")
+	}
+
+	rate.ID = r.s.nextID("rates_history")
+	r.s.rates[rate.ID] = clone(rate)
+
+	return nil
+}
+
+// GetHistory gets the rates fetched in the query period, oldest first
+func (r *RateRepo) GetHistory(ctx context.Context, q *models.RateHistoryQuery) ([]*models.Rate, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	rates := []*models.Rate{}
+	for _, rate := range rowsOf(r.s.rates, func(rt *models.Rate) bool {
+		return rt.Type == q.Type && rt.Currency == q.Currency &&
+			!rt.FetchedAt.Before(q.Period.From) && rt.FetchedAt.Before(q.Period.To)
+	}) {
+		rates = append(rates, clone(rate))
+	}
+	sort.SliceStable(rates, func(i, j int) bool { return rates[i].FetchedAt.Before(rates[j].FetchedAt) })
+
+	return rates, nil
+}
+
+// GetAt gets the last rate fetched at or before a moment
+func (r *RateRepo) GetAt(ctx context.Context, rateType models.RateType, currency models.Currency, at time.Time) (*models.Rate, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	// Rows come in ID order, so the later of two rates fetched at the same moment wins
+	var last *models.Rate
+	for _, rate := range rowsOf(r.s.rates, func(rt *models.Rate) bool {
+		return rt.Type == rateType && rt.Currency == currency && !rt.FetchedAt.After(at)
+	}) {
+		if last == nil || !rate.FetchedAt.Before(last.FetchedAt) {
+			last = rate
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("rate not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(last), nil
+}
+
+// CreateKeyRateOverride saves an override of the key rate
+func (r *RateRepo) CreateKeyRateOverride(ctx context.Context, override *models.KeyRateOverride) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[override.CreatedBy]; !ok {
+		return fmt.Errorf("failed to create key rate override: %w", errNotExist("user", override.CreatedBy))
+	}
+
+	override.ID = r.s.nextID("key_rate_overrides")
+	override.CreatedAt = time.Now()
+	r.s.keyRateOverrides[override.ID] = keyRateOverrideRow(override)
+
+	return nil
+}
+
+// GetKeyRateOverrides gets all key rate overrides, including revoked ones, newest first
+func (r *RateRepo) GetKeyRateOverrides(ctx context.Context) ([]*models.KeyRateOverride, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	overrides := []*models.KeyRateOverride{}
+	for _, override := range rowsOf(r.s.keyRateOverrides, func(*models.KeyRateOverride) bool { return true }) {
+		overrides = append(overrides, keyRateOverrideRow(override))
+	}
+	sort.SliceStable(overrides, func(i, j int) bool { return overrides[i].ID > overrides[j].ID })
+
+	return overrides, nil
+}
+
+// RevokeKeyRateOverride revokes an override; it returns false if the override does not exist or
+// was revoked already
+func (r *RateRepo) RevokeKeyRateOverride(ctx context.Context, id int, adminID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	override, ok := r.s.keyRateOverrides[id]
+	if !ok || override.RevokedAt != nil {
+		return false, nil
+	}
+
+	override.RevokedBy = &adminID
+	override.RevokedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// keyRateOverrideRow copies a key rate override
+func keyRateOverrideRow(override *models.KeyRateOverride) *models.KeyRateOverride {
+	o := clone(override)
+	if override.EffectiveTo != nil {
+		o.EffectiveTo = timePtr(*override.EffectiveTo)
+	}
+	o.RevokedBy = intPtr(override.RevokedBy)
+	if override.RevokedAt != nil {
+		o.RevokedAt = timePtr(*override.RevokedAt)
+	}
+	return o
+}
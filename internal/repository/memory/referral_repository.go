@@ -0,0 +1,216 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ReferralRepo is an in-memory implementation of the repository.ReferralRepository interface
+type ReferralRepo struct {
+	s *Store
+}
+
+// NewReferralRepository creates a new ReferralRepo
+func NewReferralRepository(s *Store) *ReferralRepo {
+	return &ReferralRepo{s: s}
+}
+
+// CreateCode assigns a referral code to a user. A user keeps its first code, so a
+// concurrent call for the same user is a no-op.
+func (r *ReferralRepo) CreateCode(ctx context.Context, userID int, code string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.referralCodes[userID]; ok {
+		return nil
+	}
+	if _, ok := r.s.users[userID]; !ok {
+		return fmt.Errorf("failed to create referral code: %w", errNotExist("user", userID))
+	}
+	for _, other := range r.s.referralCodes {
+		if other == code {
+			return fmt.Errorf("failed to create referral code: %w", errDuplicate("referral code"))
+		}
+	}
+
+	r.s.referralCodes[userID] = code
+
+	return nil
+}
+
+// GetCodeByUserID gets the referral code of a user
+func (r *ReferralRepo) GetCodeByUserID(ctx context.Context, userID int) (string, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	code, ok := r.s.referralCodes[userID]
+	if !ok {
+		return "", fmt.Errorf("referral code not found: %w", sql.ErrNoRows)
+	}
+
+	return code, nil
+}
+
+// GetUserIDByCode gets the user of the request's tenant a referral code belongs to
+func (r *ReferralRepo) GetUserIDByCode(ctx context.Context, code string) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for userID, other := range r.s.referralCodes {
+		if other != code {
+			continue
+		}
+		if user, ok := r.s.users[userID]; ok && inTenant(ctx, user.Tenant) {
+			return userID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("referral code not found: %w", sql.ErrNoRows)
+}
+
+// Create records that a user registered with another user's referral code
+func (r *ReferralRepo) Create(ctx context.Context, referral *models.Referral) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, id := range []int{referral.ReferrerID, referral.RefereeID} {
+		if _, ok := r.s.users[id]; !ok {
+			return 0, fmt.Errorf("failed to create referral: %w", errNotExist("user", id))
+		}
+	}
+	if referral.ReferrerID == referral.RefereeID {
+		return 0, fmt.Errorf("failed to create referral: a user cannot refer themselves")
+	}
+	for _, other := range r.s.referrals {
+		if other.RefereeID == referral.RefereeID {
+			return 0, fmt.Errorf("failed to create referral: %w", errDuplicate("referee"))
+		}
+	}
+
+	row := &models.Referral{
+		ID:         r.s.nextID("referrals"),
+		ReferrerID: referral.ReferrerID,
+		RefereeID:  referral.RefereeID,
+		Code:       referral.Code,
+		Status:     referral.Status,
+		CreatedAt:  time.Now(),
+	}
+	r.s.referrals[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByRefereeID gets the referral a user registered with
+func (r *ReferralRepo) GetByRefereeID(ctx context.Context, refereeID int) (*models.Referral, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, referral := range r.s.referrals {
+		if referral.RefereeID == refereeID {
+			return r.view(referral), nil
+		}
+	}
+
+	return nil, fmt.Errorf("referral not found: %w", sql.ErrNoRows)
+}
+
+// GetByReferrerID gets the referrals of a user, newest first
+func (r *ReferralRepo) GetByReferrerID(ctx context.Context, referrerID int) ([]*models.Referral, error) {
+	referrals := r.list(func(ref *models.Referral) bool { return ref.ReferrerID == referrerID })
+	sort.SliceStable(referrals, func(i, j int) bool { return referrals[i].CreatedAt.After(referrals[j].CreatedAt) })
+	return referrals, nil
+}
+
+// GetByStatus gets the referrals in a status, oldest first
+func (r *ReferralRepo) GetByStatus(ctx context.Context, status models.ReferralStatus) ([]*models.Referral, error) {
+	referrals := r.list(func(ref *models.Referral) bool { return ref.Status == status })
+	sort.SliceStable(referrals, func(i, j int) bool { return referrals[i].CreatedAt.Before(referrals[j].CreatedAt) })
+	return referrals, nil
+}
+
+// list gets the referrals that match in ID order
+func (r *ReferralRepo) list(match func(*models.Referral) bool) []*models.Referral {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	referrals := []*models.Referral{}
+	for _, referral := range rowsOf(r.s.referrals, match) {
+		referrals = append(referrals, r.view(referral))
+	}
+	return referrals
+}
+
+// Qualify marks a pending referral as qualified by the referee's deposit. It reports false if
+// the referral is no longer pending, so a referral qualifies only once.
+func (r *ReferralRepo) Qualify(ctx context.Context, id int, transactionID int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	referral, ok := r.s.referrals[id]
+	if !ok || referral.Status != models.ReferralStatusPending {
+		return false, nil
+	}
+	if _, ok := r.s.transactions[transactionID]; !ok {
+		return false, fmt.Errorf("failed to qualify referral: %w", errNotExist("transaction", transactionID))
+	}
+
+	referral.Status = models.ReferralStatusQualified
+	referral.QualifyingTransactionID = &transactionID
+	referral.QualifiedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// ExpirePending expires the pending referrals created before the given time and returns how many expired
+func (r *ReferralRepo) ExpirePending(ctx context.Context, before time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var expired int64
+	for _, referral := range r.s.referrals {
+		if referral.Status == models.ReferralStatusPending && referral.CreatedAt.Before(before) {
+			referral.Status = models.ReferralStatusExpired
+			expired++
+		}
+	}
+
+	return expired, nil
+}
+
+// RewardTx records the bonus payouts of a qualified referral within an existing transaction. It
+// reports false if the referral is not qualified, so bonuses are paid only once.
+func (r *ReferralRepo) RewardTx(ctx context.Context, tx *sql.Tx, referral *models.Referral) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.referrals[referral.ID]
+	if !ok || row.Status != models.ReferralStatusQualified {
+		return false, nil
+	}
+
+	row.Status = models.ReferralStatusRewarded
+	row.ReferrerBonus = referral.ReferrerBonus
+	row.RefereeBonus = referral.RefereeBonus
+	row.ReferrerBonusTxID = intPtr(referral.ReferrerBonusTxID)
+	row.RefereeBonusTxID = intPtr(referral.RefereeBonusTxID)
+	row.RewardedAt = timePtr(time.Now())
+
+	return true, nil
+}
+
+// view copies a referral and fills in the referee's username
+func (r *ReferralRepo) view(referral *models.Referral) *models.Referral {
+	ref := clone(referral)
+	ref.QualifyingTransactionID = intPtr(referral.QualifyingTransactionID)
+	ref.ReferrerBonusTxID = intPtr(referral.ReferrerBonusTxID)
+	ref.RefereeBonusTxID = intPtr(referral.RefereeBonusTxID)
+	if user, ok := r.s.users[referral.RefereeID]; ok {
+		ref.RefereeUsername = user.Username
+	}
+	return ref
+}
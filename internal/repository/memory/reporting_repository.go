@@ -0,0 +1,302 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ReportingRepo is an in-memory implementation of the repository.ReportingRepository interface
+type ReportingRepo struct {
+	s *Store
+}
+
+// NewReportingRepository creates a new ReportingRepo
+func NewReportingRepository(s *Store) *ReportingRepo {
+	return &ReportingRepo{s: s}
+}
+
+// dayCurrency is the grouping key of the daily reports
+type dayCurrency struct {
+	date     time.Time
+	currency models.Currency
+}
+
+// sortedKeys returns the keys of a daily report in date and currency order
+func sortedKeys[T any](groups map[dayCurrency]T) []dayCurrency {
+	keys := make([]dayCurrency, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].date.Equal(keys[j].date) {
+			return keys[i].date.Before(keys[j].date)
+		}
+		return keys[i].currency < keys[j].currency
+	})
+	return keys
+}
+
+// GetTransactionVolume gets the completed transactions in [from, to) per day and currency
+func (r *ReportingRepo) GetTransactionVolume(ctx context.Context, from, to time.Time) ([]*models.DailyTransactionVolume, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	groups := make(map[dayCurrency]*models.DailyTransactionVolume)
+	for _, transaction := range r.s.transactions {
+		if transaction.Status != models.TransactionStatusCompleted ||
+			transaction.TransactionDate.Before(from) || !transaction.TransactionDate.Before(to) ||
+			!r.s.transactionInTenant(ctx, transaction) {
+			continue
+		}
+
+		key := dayCurrency{dateOf(transaction.TransactionDate), transaction.Currency}
+		volume, ok := groups[key]
+		if !ok {
+			volume = &models.DailyTransactionVolume{Date: key.date, Currency: key.currency}
+			groups[key] = volume
+		}
+		volume.Count++
+		volume.Value += transaction.Amount
+	}
+
+	volumes := []*models.DailyTransactionVolume{}
+	for _, key := range sortedKeys(groups) {
+		volumes = append(volumes, groups[key])
+	}
+
+	return volumes, nil
+}
+
+// GetNewUsers gets the number of users registered in [from, to) per day
+func (r *ReportingRepo) GetNewUsers(ctx context.Context, from, to time.Time) ([]*models.DailyNewUsers, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	groups := make(map[dayCurrency]*models.DailyNewUsers)
+	for _, user := range r.s.users {
+		if user.CreatedAt.Before(from) || !user.CreatedAt.Before(to) || !inTenant(ctx, user.Tenant) {
+			continue
+		}
+
+		key := dayCurrency{date: dateOf(user.CreatedAt)}
+		day, ok := groups[key]
+		if !ok {
+			day = &models.DailyNewUsers{Date: key.date}
+			groups[key] = day
+		}
+		day.Count++
+	}
+
+	days := []*models.DailyNewUsers{}
+	for _, key := range sortedKeys(groups) {
+		days = append(days, groups[key])
+	}
+
+	return days, nil
+}
+
+// GetCreditsIssued gets the credits issued in [from, to) per day and currency of the credit account
+func (r *ReportingRepo) GetCreditsIssued(ctx context.Context, from, to time.Time) ([]*models.DailyCreditsIssued, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	groups := make(map[dayCurrency]*models.DailyCreditsIssued)
+	for _, credit := range r.s.credits {
+		account, ok := r.s.accounts[credit.AccountID]
+		if !ok || !inTenant(ctx, account.tenant) || credit.Status == models.CreditStatusRejected ||
+			credit.CreatedAt.Before(from) || !credit.CreatedAt.Before(to) {
+			continue
+		}
+
+		key := dayCurrency{dateOf(credit.CreatedAt), account.Currency}
+		day, ok := groups[key]
+		if !ok {
+			day = &models.DailyCreditsIssued{Date: key.date, Currency: key.currency}
+			groups[key] = day
+		}
+		day.Count++
+		day.Amount += credit.Amount
+	}
+
+	days := []*models.DailyCreditsIssued{}
+	for _, key := range sortedKeys(groups) {
+		days = append(days, groups[key])
+	}
+
+	return days, nil
+}
+
+// GetCreditPortfolio gets the outstanding credits per currency as of today. A credit is
+// non-performing if its oldest unpaid installment is more than nplDays past its date.
+func (r *ReportingRepo) GetCreditPortfolio(ctx context.Context, nplDays int) ([]*models.CreditPortfolio, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	today := dateOf(time.Now())
+	nplCutoff := today.AddDate(0, 0, -nplDays)
+
+	groups := make(map[dayCurrency]*models.CreditPortfolio)
+	for _, credit := range r.s.credits {
+		account, ok := r.s.accounts[credit.AccountID]
+		if !ok || !inTenant(ctx, account.tenant) ||
+			(credit.Status != models.CreditStatusActive && credit.Status != models.CreditStatusOverdue) {
+			continue
+		}
+
+		var principal, overdue float64
+		var unpaid bool
+		var overdueSince *time.Time
+		for _, payment := range r.s.schedules {
+			if payment.CreditID != credit.ID ||
+				(payment.Status != models.PaymentStatusPending && payment.Status != models.PaymentStatusOverdue) {
+				continue
+			}
+
+			unpaid = true
+			principal += payment.PrincipalAmount
+			if payment.PaymentDate.Before(today) {
+				overdue += payment.TotalAmount + payment.PenaltyAmount
+				if overdueSince == nil || payment.PaymentDate.Before(*overdueSince) {
+					overdueSince = timePtr(payment.PaymentDate)
+				}
+			}
+		}
+		if !unpaid {
+			continue
+		}
+
+		key := dayCurrency{currency: account.Currency}
+		portfolio, ok := groups[key]
+		if !ok {
+			portfolio = &models.CreditPortfolio{Currency: key.currency}
+			groups[key] = portfolio
+		}
+		portfolio.ActiveCredits++
+		portfolio.OutstandingPrincipal += principal
+		portfolio.OverdueAmount += overdue
+		if overdueSince != nil {
+			portfolio.OverdueCredits++
+			if overdueSince.Before(nplCutoff) {
+				portfolio.NonPerformingCredits++
+				portfolio.NonPerformingPrincipal += principal
+			}
+		}
+	}
+
+	portfolios := []*models.CreditPortfolio{}
+	for _, key := range sortedKeys(groups) {
+		groups[key].SetNPLRatio()
+		portfolios = append(portfolios, groups[key])
+	}
+
+	return portfolios, nil
+}
+
+// GetCreditPortfolioItems gets every outstanding credit with its unpaid principal, overdue
+// amounts and oldest overdue installment as of the given day
+func (r *ReportingRepo) GetCreditPortfolioItems(ctx context.Context, asOf time.Time) ([]*models.CreditPortfolioItem, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	items := []*models.CreditPortfolioItem{}
+	for _, credit := range rowsOf(r.s.credits, func(c *models.Credit) bool {
+		return c.Status == models.CreditStatusActive || c.Status == models.CreditStatusOverdue
+	}) {
+		account, ok := r.s.accounts[credit.AccountID]
+		if !ok || !inTenant(ctx, account.tenant) {
+			continue
+		}
+
+		item := &models.CreditPortfolioItem{
+			AsOf:           asOf,
+			CreditID:       credit.ID,
+			UserID:         credit.UserID,
+			AccountID:      credit.AccountID,
+			Currency:       account.Currency,
+			Status:         credit.Status,
+			Amount:         credit.Amount,
+			InterestRate:   credit.InterestRate,
+			TermMonths:     credit.TermMonths,
+			MonthlyPayment: credit.MonthlyPayment,
+			StartDate:      credit.StartDate,
+			EndDate:        credit.EndDate,
+		}
+
+		var unpaid bool
+		for _, payment := range r.s.schedules {
+			if payment.CreditID != credit.ID ||
+				(payment.Status != models.PaymentStatusPending && payment.Status != models.PaymentStatusOverdue) {
+				continue
+			}
+
+			unpaid = true
+			item.OutstandingPrincipal += payment.PrincipalAmount
+			date := dateOf(payment.PaymentDate)
+			if date.Before(asOf) {
+				item.OverdueAmount += payment.TotalAmount
+				item.PenaltyAmount += payment.PenaltyAmount
+				if item.OverdueSince == nil || date.Before(*item.OverdueSince) {
+					item.OverdueSince = timePtr(date)
+				}
+			} else if item.NextPaymentDate == nil || date.Before(*item.NextPaymentDate) {
+				item.NextPaymentDate = timePtr(date)
+			}
+		}
+		if !unpaid {
+			continue
+		}
+
+		for _, policy := range r.s.insurancePolicies {
+			if policy.CreditID == credit.ID && policy.Status == models.InsurancePolicyStatusActive {
+				item.Insured = true
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// GetDepositBalances gets the balances of the active non-credit accounts per currency and type
+func (r *ReportingRepo) GetDepositBalances(ctx context.Context) ([]*models.DepositBalance, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	type currencyType struct {
+		currency    models.Currency
+		accountType models.AccountType
+	}
+
+	groups := make(map[currencyType]*models.DepositBalance)
+	for _, account := range r.s.accounts {
+		if !account.IsActive || account.AccountType == models.AccountTypeCredit || !inTenant(ctx, account.tenant) {
+			continue
+		}
+
+		key := currencyType{account.Currency, account.AccountType}
+		balance, ok := groups[key]
+		if !ok {
+			balance = &models.DepositBalance{Currency: key.currency, AccountType: key.accountType}
+			groups[key] = balance
+		}
+		balance.Accounts++
+		balance.Balance += account.Balance
+	}
+
+	balances := []*models.DepositBalance{}
+	for _, balance := range groups {
+		balances = append(balances, balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Currency != balances[j].Currency {
+			return balances[i].Currency < balances[j].Currency
+		}
+		return balances[i].AccountType < balances[j].AccountType
+	})
+
+	return balances, nil
+}
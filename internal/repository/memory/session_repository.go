@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// SessionRepo is an in-memory implementation of the repository.SessionRepository interface
+type SessionRepo struct {
+	s *Store
+}
+
+// NewSessionRepository creates a new SessionRepo
+func NewSessionRepository(s *Store) *SessionRepo {
+	return &SessionRepo{s: s}
+}
+
+// Create creates a new session
+func (r *SessionRepo) Create(ctx context.Context, session *models.Session) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[session.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create session: %w", errNotExist("user", session.UserID))
+	}
+	for _, other := range r.s.sessions {
+		if other.SessionID == session.SessionID {
+			return 0, fmt.Errorf("failed to create session: %w", errDuplicate("session ID"))
+		}
+	}
+
+	row := clone(session)
+	row.ID = r.s.nextID("sessions")
+	row.RevokedAt = nil
+	row.Current = false
+	row.CreatedAt = time.Now()
+	r.s.sessions[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetBySessionID gets a session by its session ID
+func (r *SessionRepo) GetBySessionID(ctx context.Context, sessionID string) (*models.Session, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, session := range r.s.sessions {
+		if session.SessionID == sessionID {
+			return clone(session), nil
+		}
+	}
+
+	return nil, fmt.Errorf("session not found: %w", sql.ErrNoRows)
+}
+
+// GetByUserID gets all sessions of a user, newest first
+func (r *SessionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Session, error) {
+	return r.list(func(s *models.Session) bool { return s.UserID == userID })
+}
+
+// GetActiveByUserID gets the sessions of a user that are neither revoked nor expired, newest first
+func (r *SessionRepo) GetActiveByUserID(ctx context.Context, userID int) ([]*models.Session, error) {
+	now := time.Now()
+	return r.list(func(s *models.Session) bool {
+		return s.UserID == userID && s.RevokedAt == nil && s.ExpiresAt.After(now)
+	})
+}
+
+// list gets the sessions that match, newest first
+func (r *SessionRepo) list(match func(*models.Session) bool) ([]*models.Session, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var sessions []*models.Session
+	for _, session := range rowsOf(r.s.sessions, match) {
+		sessions = append(sessions, clone(session))
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].CreatedAt.After(sessions[j].CreatedAt) })
+
+	return sessions, nil
+}
+
+// Revoke revokes a session of a user
+func (r *SessionRepo) Revoke(ctx context.Context, id int, userID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	session, ok := r.s.sessions[id]
+	if !ok || session.UserID != userID || session.RevokedAt != nil {
+		return fmt.Errorf("session not found")
+	}
+
+	session.RevokedAt = timePtr(time.Now())
+
+	return nil
+}
+
+// RevokeAllExcept revokes all sessions of a user except the given one and returns how many were revoked
+func (r *SessionRepo) RevokeAllExcept(ctx context.Context, userID int, keepSessionID string) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	revoked := 0
+	for _, session := range r.s.sessions {
+		if session.UserID == userID && session.SessionID != keepSessionID && session.RevokedAt == nil {
+			session.RevokedAt = timePtr(now)
+			revoked++
+		}
+	}
+
+	return revoked, nil
+}
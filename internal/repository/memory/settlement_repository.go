@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// SettlementRepo is an in-memory implementation of the repository.SettlementRepository interface
+type SettlementRepo struct {
+	s *Store
+}
+
+// NewSettlementRepository creates a new SettlementRepo
+func NewSettlementRepository(s *Store) *SettlementRepo {
+	return &SettlementRepo{s: s}
+}
+
+// GetMerchantsToSettle gets the merchants with unsettled payments captured before the cutoff
+func (r *SettlementRepo) GetMerchantsToSettle(ctx context.Context, before time.Time) ([]int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	var merchantIDs []int
+	for _, intent := range r.s.paymentIntents {
+		if settleable(intent, before) && !seen[intent.MerchantID] {
+			seen[intent.MerchantID] = true
+			merchantIDs = append(merchantIDs, intent.MerchantID)
+		}
+	}
+	sort.Ints(merchantIDs)
+
+	return merchantIDs, nil
+}
+
+// CreateBatchTx creates a settlement batch for a merchant within an existing transaction and assigns
+// to it every unsettled payment captured before the cutoff. The batch carries the count and total.
+func (r *SettlementRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, merchantID int, settlementDate, before time.Time) (*models.SettlementBatch, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.merchants[merchantID]; !ok {
+		return nil, fmt.Errorf("failed to create settlement batch: %w", errNotExist("merchant", merchantID))
+	}
+	settlementDate = dateOf(settlementDate)
+	for _, other := range r.s.settlements {
+		if other.MerchantID == merchantID && other.SettlementDate.Equal(settlementDate) {
+			return nil, fmt.Errorf("failed to create settlement batch: %w", errDuplicate("settlement date"))
+		}
+	}
+
+	batch := &models.SettlementBatch{
+		ID:             r.s.nextID("settlement_batches"),
+		MerchantID:     merchantID,
+		SettlementDate: settlementDate,
+		CreatedAt:      time.Now(),
+	}
+	batchID := batch.ID
+	for _, intent := range r.s.paymentIntents {
+		if intent.MerchantID == merchantID && settleable(intent, before) {
+			intent.SettlementBatchID = &batchID
+			intent.UpdatedAt = batch.CreatedAt
+			batch.PaymentCount++
+			batch.Amount += intent.Amount
+		}
+	}
+	r.s.settlements[batch.ID] = clone(batch)
+
+	return batch, nil
+}
+
+// SetTransactionTx links the payout transaction to a settlement batch within an existing transaction
+func (r *SettlementRepo) SetTransactionTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.transactions[transactionID]; !ok {
+		return fmt.Errorf("failed to link settlement transaction: %w", errNotExist("transaction", transactionID))
+	}
+	if batch, ok := r.s.settlements[id]; ok {
+		batch.TransactionID = &transactionID
+	}
+
+	return nil
+}
+
+// GetByMerchantID gets the settlement batches of a merchant, latest first
+func (r *SettlementRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.SettlementBatch, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	batches := []*models.SettlementBatch{}
+	for _, batch := range rowsOf(r.s.settlements, func(b *models.SettlementBatch) bool { return b.MerchantID == merchantID }) {
+		b := clone(batch)
+		b.TransactionID = intPtr(batch.TransactionID)
+		batches = append(batches, b)
+	}
+	sort.SliceStable(batches, func(i, j int) bool { return batches[i].SettlementDate.After(batches[j].SettlementDate) })
+
+	return batches, nil
+}
+
+// settleable reports whether a payment is captured before the cutoff and not yet settled
+func settleable(intent *models.PaymentIntent, before time.Time) bool {
+	return intent.Status == models.PaymentIntentStatusSucceeded && intent.SettlementBatchID == nil &&
+		intent.PaidAt != nil && intent.PaidAt.Before(before)
+}
@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// StatementRepo is an in-memory implementation of the repository.StatementRepository interface
+type StatementRepo struct {
+	s *Store
+}
+
+// NewStatementRepository creates a new StatementRepo
+func NewStatementRepository(s *Store) *StatementRepo {
+	return &StatementRepo{s: s}
+}
+
+// Issue computes the statement of an account for [from, to), stores it and locks the period.
+// The closing balance is derived from the current balance and the transactions posted since.
+func (r *StatementRepo) Issue(ctx context.Context, accountID int, from, to time.Time) (*models.Statement, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	account, ok := r.s.accounts[accountID]
+	if !ok {
+		return nil, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("failed to create statement: period must end after it starts")
+	}
+	for _, other := range r.s.statements {
+		if other.AccountID == accountID && other.PeriodStart.Equal(from) {
+			return nil, fmt.Errorf("failed to create statement: %w", errDuplicate("statement period"))
+		}
+	}
+
+	statement := &models.Statement{
+		AccountID:   accountID,
+		PeriodStart: from,
+		PeriodEnd:   to,
+	}
+
+	r.compute(account.Account, statement)
+	statement.ID = r.s.nextID("account_statements")
+	statement.IssuedAt = time.Now()
+	r.s.statements[statement.ID] = clone(statement)
+
+	if account.LockedUntil == nil || account.LockedUntil.Before(to) {
+		account.LockedUntil = timePtr(to)
+		account.UpdatedAt = time.Now()
+	}
+
+	return statement, nil
+}
+
+// Regenerate recomputes a statement from the transactions of its period, which are locked, and
+// the current balance, the same way Issue does
+func (r *StatementRepo) Regenerate(ctx context.Context, id int) (*models.Statement, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.statements[id]
+	if !ok {
+		return nil, fmt.Errorf("statement not found: %w", sql.ErrNoRows)
+	}
+
+	account, ok := r.s.accounts[stored.AccountID]
+	if !ok {
+		return nil, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	statement := &models.Statement{
+		ID:          stored.ID,
+		AccountID:   stored.AccountID,
+		PeriodStart: stored.PeriodStart,
+		PeriodEnd:   stored.PeriodEnd,
+		IssuedAt:    stored.IssuedAt,
+	}
+
+	r.compute(account.Account, statement)
+	statement.RegeneratedAt = timePtr(time.Now())
+	r.s.statements[statement.ID] = clone(statement)
+
+	return statement, nil
+}
+
+// compute computes the totals and balances of a statement for its period. The closing balance is
+// derived from the current balance and the transactions posted since.
+func (r *StatementRepo) compute(account *models.Account, statement *models.Statement) {
+	var netAfter float64
+	for _, transaction := range r.s.transactions {
+		if !touches(transaction, account.ID) || transaction.TransactionDate.Before(statement.PeriodStart) ||
+			transaction.Status == models.TransactionStatusFailed || transaction.Status == models.TransactionStatusCancelled {
+			continue
+		}
+
+		in := transaction.DestinationAccountID != nil && *transaction.DestinationAccountID == account.ID
+		out := transaction.SourceAccountID != nil && *transaction.SourceAccountID == account.ID
+		if !transaction.TransactionDate.Before(statement.PeriodEnd) {
+			if in {
+				netAfter += transaction.Amount
+			}
+			if out {
+				netAfter -= transaction.Amount
+			}
+			continue
+		}
+
+		if in {
+			statement.TotalCredits += transaction.Amount
+		}
+		if out {
+			statement.TotalDebits += transaction.Amount
+		}
+		statement.TransactionCount++
+	}
+
+	statement.ClosingBalance = account.Balance - netAfter
+	statement.OpeningBalance = statement.ClosingBalance - statement.TotalCredits + statement.TotalDebits
+}
+
+// GetByID gets a statement by ID
+func (r *StatementRepo) GetByID(ctx context.Context, id int) (*models.Statement, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	statement, ok := r.s.statements[id]
+	if !ok {
+		return nil, fmt.Errorf("statement not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(statement), nil
+}
+
+// GetByAccountID gets the statements of an account, newest first
+func (r *StatementRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Statement, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	statements := []*models.Statement{}
+	for _, statement := range rowsOf(r.s.statements, func(s *models.Statement) bool { return s.AccountID == accountID }) {
+		statements = append(statements, clone(statement))
+	}
+	sort.SliceStable(statements, func(i, j int) bool {
+		return statements[i].PeriodStart.After(statements[j].PeriodStart)
+	})
+
+	return statements, nil
+}
+
+// GetAccountsWithoutStatement gets the accounts opened before the end of the period that have no
+// statement for it yet
+func (r *StatementRepo) GetAccountsWithoutStatement(ctx context.Context, from, to time.Time) ([]int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	issued := make(map[int]bool)
+	for _, statement := range r.s.statements {
+		if statement.PeriodStart.Equal(from) {
+			issued[statement.AccountID] = true
+		}
+	}
+
+	ids := []int{}
+	for _, account := range rowsOf(r.s.accounts, func(a *accountRow) bool { return a.CreatedAt.Before(to) && !issued[a.ID] }) {
+		ids = append(ids, account.ID)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,546 @@
+// Package memory keeps all repository data in process memory. It backs fast service-layer tests
+// and the --storage=memory development mode, which runs the API without PostgreSQL.
+//
+// The repositories mirror the behavior of their PostgreSQL counterparts, including the checks the
+// database enforces through constraints: references to missing rows and duplicate unique keys are
+// rejected. All repositories share one Store, guarded by a single lock, so every method is atomic.
+// Transactions begun on Store.DB save the tables, and a rollback restores them. Changes still apply
+// immediately and are not isolated: a rollback also undoes what other goroutines wrote since the
+// transaction began, while ID sequences keep advancing as they do in PostgreSQL.
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// accountRow is an account with the tenant it belongs to, which models.Account does not carry
+type accountRow struct {
+	*models.Account
+	tenant string
+}
+
+// chargebackRow is a chargeback with the internal ID of the disputed payment intent
+type chargebackRow struct {
+	*models.Chargeback
+	paymentIntentID int
+}
+
+// accountSettingsKey identifies a user's settings of an account
+type accountSettingsKey struct {
+	accountID int
+	userID    int
+}
+
+// paymentReminderKey identifies the reminder sent a number of days ahead of a credit payment
+type paymentReminderKey struct {
+	paymentID  int
+	daysBefore int
+}
+
+// Store holds the tables of the in-memory repositories
+type Store struct {
+	mu  sync.RWMutex
+	db  *sql.DB
+	seq map[string]int
+
+	users              map[int]*models.User
+	organizations      map[int]*models.Organization
+	members            map[int]*models.OrganizationMember
+	invitations        map[int]*models.OrganizationInvitation
+	accounts           map[int]*accountRow
+	accountSettings    map[accountSettingsKey]*models.AccountSettings
+	accountEvents      map[int]*models.AccountEvent
+	delegations        map[int]*models.AccountDelegation
+	delegationEvents   map[int]*models.DelegationEvent
+	holds              map[int]*models.AccountHold
+	accountPlans       map[int]*models.AccountPlan
+	planPeriods        map[int]*models.AccountPlanPeriod
+	cardProducts       map[int]*models.CardProduct
+	feeCharges         map[int]*models.FeeCharge
+	feeRuns            map[int]*models.FeeRun
+	cards              map[int]*models.Card
+	transactions       map[int]*models.Transaction
+	transactionEvents  map[int]*models.TransactionEvent
+	descriptionEdits   map[int]*models.TransactionDescriptionEdit
+	credits            map[int]*models.Credit
+	schedules          map[int]*models.PaymentSchedule
+	paymentReminders   map[paymentReminderKey]time.Time
+	insurancePolicies  map[int]*models.InsurancePolicy
+	sessions           map[int]*models.Session
+	impersonations     map[int]*models.Impersonation
+	impersonationReqs  map[int]*models.ImpersonationRequest
+	devices            map[int]*models.Device
+	notifications      map[int]*models.Notification
+	emailDeliveries    map[int]*models.EmailDelivery
+	emailSuppressions  map[int]*models.EmailSuppression
+	announcements      map[int]*models.Announcement
+	announcementQueue  map[int]*models.AnnouncementRecipient
+	confirmations      map[int]*models.TransferConfirmation
+	passwordResets     map[int]*models.PasswordReset
+	onboardingEvents   map[int]*models.OnboardingEvent
+	apiKeys            map[int]*models.APIKey
+	approvalPolicies   map[int]*models.ApprovalPolicy
+	pendingTransfers   map[int]*models.PendingTransfer
+	approvals          map[int]*models.TransferApproval
+	payrolls           map[int]*models.Payroll
+	payrollItems       map[int]*models.PayrollItem
+	billProviders      map[int]*models.BillProvider
+	billPayments       map[int]*models.BillPayment
+	billTemplates      map[int]*models.BillTemplate
+	merchants          map[int]*models.Merchant
+	paymentIntents     map[int]*models.PaymentIntent
+	settlements        map[int]*models.SettlementBatch
+	chargebacks        map[int]*chargebackRow
+	escrows            map[int]*models.Escrow
+	invoices           map[int]*models.Invoice
+	invoiceItems       map[int]*models.InvoiceItem
+	subscriptionPlans  map[int]*models.SubscriptionPlan
+	subscriptions      map[int]*models.Subscription
+	chequeDeposits     map[int]*models.ChequeDeposit
+	intlTransfers      map[int]*models.InternationalTransfer
+	referralCodes      map[int]string
+	referrals          map[int]*models.Referral
+	taxDocuments       map[int]*models.TaxDocument
+	statements         map[int]*models.Statement
+	applications       map[int]*models.CreditApplication
+	documents          map[int]*models.CreditDocument
+	signatureRequests  map[int]*models.SignatureRequest
+	signatures         map[int]*models.CreditSignature
+	creditAdjustments  map[int]*models.CreditAdjustment
+	ownershipTransfers map[int]*models.OwnershipTransfer
+	ownershipEvents    map[int]*models.OwnershipTransferEvent
+	threads            map[int]*models.MessageThread
+	messages           map[int]*models.Message
+	locations          map[int]*models.Location
+	rates              map[int]*models.Rate
+	keyRateOverrides   map[int]*models.KeyRateOverride
+}
+
+// NewStore creates an empty store with the bill provider, card product and account plan catalogs
+// that schema.sql seeds
+func NewStore() *Store {
+	s := &Store{
+		seq:                make(map[string]int),
+		users:              make(map[int]*models.User),
+		organizations:      make(map[int]*models.Organization),
+		members:            make(map[int]*models.OrganizationMember),
+		invitations:        make(map[int]*models.OrganizationInvitation),
+		accounts:           make(map[int]*accountRow),
+		accountSettings:    make(map[accountSettingsKey]*models.AccountSettings),
+		accountEvents:      make(map[int]*models.AccountEvent),
+		delegations:        make(map[int]*models.AccountDelegation),
+		delegationEvents:   make(map[int]*models.DelegationEvent),
+		holds:              make(map[int]*models.AccountHold),
+		accountPlans:       make(map[int]*models.AccountPlan),
+		planPeriods:        make(map[int]*models.AccountPlanPeriod),
+		cardProducts:       make(map[int]*models.CardProduct),
+		feeCharges:         make(map[int]*models.FeeCharge),
+		feeRuns:            make(map[int]*models.FeeRun),
+		cards:              make(map[int]*models.Card),
+		transactions:       make(map[int]*models.Transaction),
+		transactionEvents:  make(map[int]*models.TransactionEvent),
+		descriptionEdits:   make(map[int]*models.TransactionDescriptionEdit),
+		credits:            make(map[int]*models.Credit),
+		schedules:          make(map[int]*models.PaymentSchedule),
+		paymentReminders:   make(map[paymentReminderKey]time.Time),
+		insurancePolicies:  make(map[int]*models.InsurancePolicy),
+		sessions:           make(map[int]*models.Session),
+		impersonations:     make(map[int]*models.Impersonation),
+		impersonationReqs:  make(map[int]*models.ImpersonationRequest),
+		devices:            make(map[int]*models.Device),
+		notifications:      make(map[int]*models.Notification),
+		emailDeliveries:    make(map[int]*models.EmailDelivery),
+		emailSuppressions:  make(map[int]*models.EmailSuppression),
+		announcements:      make(map[int]*models.Announcement),
+		announcementQueue:  make(map[int]*models.AnnouncementRecipient),
+		confirmations:      make(map[int]*models.TransferConfirmation),
+		passwordResets:     make(map[int]*models.PasswordReset),
+		onboardingEvents:   make(map[int]*models.OnboardingEvent),
+		apiKeys:            make(map[int]*models.APIKey),
+		approvalPolicies:   make(map[int]*models.ApprovalPolicy),
+		pendingTransfers:   make(map[int]*models.PendingTransfer),
+		approvals:          make(map[int]*models.TransferApproval),
+		payrolls:           make(map[int]*models.Payroll),
+		payrollItems:       make(map[int]*models.PayrollItem),
+		billProviders:      make(map[int]*models.BillProvider),
+		billPayments:       make(map[int]*models.BillPayment),
+		billTemplates:      make(map[int]*models.BillTemplate),
+		merchants:          make(map[int]*models.Merchant),
+		paymentIntents:     make(map[int]*models.PaymentIntent),
+		settlements:        make(map[int]*models.SettlementBatch),
+		chargebacks:        make(map[int]*chargebackRow),
+		escrows:            make(map[int]*models.Escrow),
+		invoices:           make(map[int]*models.Invoice),
+		invoiceItems:       make(map[int]*models.InvoiceItem),
+		subscriptionPlans:  make(map[int]*models.SubscriptionPlan),
+		subscriptions:      make(map[int]*models.Subscription),
+		chequeDeposits:     make(map[int]*models.ChequeDeposit),
+		intlTransfers:      make(map[int]*models.InternationalTransfer),
+		referralCodes:      make(map[int]string),
+		referrals:          make(map[int]*models.Referral),
+		taxDocuments:       make(map[int]*models.TaxDocument),
+		statements:         make(map[int]*models.Statement),
+		applications:       make(map[int]*models.CreditApplication),
+		documents:          make(map[int]*models.CreditDocument),
+		signatureRequests:  make(map[int]*models.SignatureRequest),
+		signatures:         make(map[int]*models.CreditSignature),
+		creditAdjustments:  make(map[int]*models.CreditAdjustment),
+		ownershipTransfers: make(map[int]*models.OwnershipTransfer),
+		ownershipEvents:    make(map[int]*models.OwnershipTransferEvent),
+		threads:            make(map[int]*models.MessageThread),
+		messages:           make(map[int]*models.Message),
+		locations:          make(map[int]*models.Location),
+		rates:              make(map[int]*models.Rate),
+		keyRateOverrides:   make(map[int]*models.KeyRateOverride),
+	}
+	s.db = sql.OpenDB(connector{s: s})
+	s.seedBillProviders()
+	s.seedCardProducts()
+	s.seedAccountPlans()
+
+	return s
+}
+
+// tables lists the tables a transaction saves and restores. A new table must be added here too,
+// or a rollback keeps the changes made to it.
+func (s *Store) tables() []table {
+	return []table{
+		rows(&s.users),
+		rows(&s.organizations),
+		rows(&s.members),
+		rows(&s.invitations),
+		rowTable[int, accountRow]{rows: &s.accounts, copy: func(row *accountRow) *accountRow {
+			return &accountRow{Account: clone(row.Account), tenant: row.tenant}
+		}},
+		rows(&s.accountSettings),
+		rows(&s.accountEvents),
+		rows(&s.delegations),
+		rows(&s.delegationEvents),
+		rows(&s.holds),
+		rows(&s.accountPlans),
+		rows(&s.planPeriods),
+		rows(&s.cardProducts),
+		rows(&s.feeCharges),
+		rows(&s.feeRuns),
+		rows(&s.cards),
+		rows(&s.transactions),
+		rows(&s.transactionEvents),
+		rows(&s.descriptionEdits),
+		rows(&s.credits),
+		rows(&s.schedules),
+		values(&s.paymentReminders),
+		rows(&s.insurancePolicies),
+		rows(&s.sessions),
+		rows(&s.impersonations),
+		rows(&s.impersonationReqs),
+		rows(&s.devices),
+		rows(&s.notifications),
+		rows(&s.emailDeliveries),
+		rows(&s.emailSuppressions),
+		rows(&s.announcements),
+		rows(&s.announcementQueue),
+		rows(&s.confirmations),
+		rows(&s.passwordResets),
+		rows(&s.onboardingEvents),
+		rows(&s.apiKeys),
+		rows(&s.approvalPolicies),
+		rows(&s.pendingTransfers),
+		rows(&s.approvals),
+		rows(&s.payrolls),
+		rows(&s.payrollItems),
+		rows(&s.billProviders),
+		rows(&s.billPayments),
+		rows(&s.billTemplates),
+		rows(&s.merchants),
+		rows(&s.paymentIntents),
+		rows(&s.settlements),
+		rowTable[int, chargebackRow]{rows: &s.chargebacks, copy: func(row *chargebackRow) *chargebackRow {
+			return &chargebackRow{Chargeback: clone(row.Chargeback), paymentIntentID: row.paymentIntentID}
+		}},
+		rows(&s.escrows),
+		rows(&s.invoices),
+		rows(&s.invoiceItems),
+		rows(&s.subscriptionPlans),
+		rows(&s.subscriptions),
+		rows(&s.chequeDeposits),
+		rows(&s.intlTransfers),
+		values(&s.referralCodes),
+		rows(&s.referrals),
+		rows(&s.taxDocuments),
+		rows(&s.statements),
+		rows(&s.applications),
+		rows(&s.documents),
+		rows(&s.signatureRequests),
+		rows(&s.signatures),
+		rows(&s.creditAdjustments),
+		rows(&s.ownershipTransfers),
+		rows(&s.ownershipEvents),
+		rows(&s.threads),
+		rows(&s.messages),
+		rows(&s.locations),
+		rows(&s.rates),
+		rows(&s.keyRateOverrides),
+	}
+}
+
+// save copies every table and returns the function that puts the copies back
+func (s *Store) save() func() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tables := s.tables()
+	restores := make([]func(), len(tables))
+	for i, t := range tables {
+		restores[i] = t.save()
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// table is a table of the store that can be saved and restored
+type table interface {
+	save() (restore func())
+}
+
+// rowTable is a table of rows. The rows are copied when it is saved, as the repositories update
+// them in place.
+type rowTable[K comparable, V any] struct {
+	rows *map[K]*V
+	copy func(*V) *V
+}
+
+// rows returns the rowTable of a table whose rows are copied with clone
+func rows[K comparable, V any](table *map[K]*V) rowTable[K, V] {
+	return rowTable[K, V]{rows: table, copy: clone[V]}
+}
+
+func (t rowTable[K, V]) save() func() {
+	saved := make(map[K]*V, len(*t.rows))
+	for key, row := range *t.rows {
+		saved[key] = t.copy(row)
+	}
+	return func() { *t.rows = saved }
+}
+
+// valueTable is a table of plain values
+type valueTable[K comparable, V any] struct {
+	values *map[K]V
+}
+
+// values returns the valueTable of a table
+func values[K comparable, V any](table *map[K]V) valueTable[K, V] {
+	return valueTable[K, V]{values: table}
+}
+
+func (t valueTable[K, V]) save() func() {
+	saved := make(map[K]V, len(*t.values))
+	for key, value := range *t.values {
+		saved[key] = value
+	}
+	return func() { *t.values = saved }
+}
+
+// DB returns the database handle services begin their transactions on
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// nextID returns the next value of a table's ID sequence
+func (s *Store) nextID(table string) int {
+	s.seq[table]++
+	return s.seq[table]
+}
+
+// errNotExist reports a reference to a missing row, as a foreign key violation would
+func errNotExist(what string, id int) error {
+	return fmt.Errorf("%s %d does not exist", what, id)
+}
+
+// errDuplicate reports a row that would violate a unique constraint
+func errDuplicate(what string) error {
+	return fmt.Errorf("duplicate %s", what)
+}
+
+// clone returns a shallow copy of a row, so callers never share memory with the store. Rows
+// with slices or maps have their own copy functions.
+func clone[T any](row *T) *T {
+	c := *row
+	return &c
+}
+
+// rowsOf returns the rows of a table that match, in ID order, which is the order they were
+// inserted in. Callers sort them further with sort.SliceStable and clone what they return.
+func rowsOf[T any](table map[int]*T, match func(*T) bool) []*T {
+	ids := make([]int, 0, len(table))
+	for id, row := range table {
+		if match(row) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	rows := make([]*T, len(ids))
+	for i, id := range ids {
+		rows[i] = table[id]
+	}
+	return rows
+}
+
+// intPtr returns a pointer to a copy of an optional ID
+func intPtr(id *int) *int {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+// timePtr returns a pointer to a time
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// dateOf truncates a time to its day, as a DATE column stores it
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// newUUID returns a random UUID, as gen_random_uuid() does in the database
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// seedBillProviders adds the bill provider catalog of schema.sql
+func (s *Store) seedBillProviders() {
+	phone := []models.BillField{{Name: "phone", Label: "Номер телефона", Pattern: `\+7[0-9]{10}`, Required: true}}
+	providers := []*models.BillProvider{
+		{Code: "mosenergosbyt", Name: "Мосэнергосбыт", Category: models.BillCategoryUtilities, MinAmount: 1, MaxAmount: 500000,
+			Fields: []models.BillField{
+				{Name: "account_number", Label: "Лицевой счет", Pattern: "[0-9]{10}", Required: true},
+				{Name: "period", Label: "Период", Pattern: `(0[1-9]|1[0-2])\.[0-9]{4}`},
+			}},
+		{Code: "mosvodokanal", Name: "Мосводоканал", Category: models.BillCategoryUtilities, MinAmount: 1, MaxAmount: 500000,
+			Fields: []models.BillField{{Name: "account_number", Label: "Лицевой счет", Pattern: "[0-9]{8,12}", Required: true}}},
+		{Code: "mts", Name: "МТС", Category: models.BillCategoryMobile, MinAmount: 10, MaxAmount: 15000, Fields: phone},
+		{Code: "beeline", Name: "Билайн", Category: models.BillCategoryMobile, MinAmount: 10, MaxAmount: 15000, Fields: phone},
+		{Code: "megafon", Name: "МегаФон", Category: models.BillCategoryMobile, MinAmount: 10, MaxAmount: 15000, Fields: phone},
+		{Code: "rostelecom", Name: "Ростелеком", Category: models.BillCategoryInternet, MinAmount: 1, MaxAmount: 100000,
+			Fields: []models.BillField{{Name: "contract_number", Label: "Номер договора", Pattern: "[0-9]{12}", Required: true}}},
+	}
+
+	now := time.Now()
+	for _, provider := range providers {
+		provider.ID = s.nextID("bill_providers")
+		provider.IsActive = true
+		provider.CreatedAt = now
+		s.billProviders[provider.ID] = provider
+	}
+}
+
+// seedCardProducts adds the card product catalog of schema.sql
+func (s *Store) seedCardProducts() {
+	products := []*models.CardProduct{
+		{Code: "MIR_VIRTUAL", Name: "Мир Виртуальная", CardType: models.CardTypeVirtual, BINs: []string{"220071"},
+			AccountTypes: []models.AccountType{models.AccountTypeChecking},
+			Limits:       models.CardLimits{Purchase: 100000, Daily: 200000}},
+		{Code: "MIR_DEBIT", Name: "Мир Дебетовая", CardType: models.CardTypeDebit, BINs: []string{"220070"},
+			AccountTypes: []models.AccountType{models.AccountTypeChecking, models.AccountTypeSavings},
+			Limits:       models.CardLimits{Withdrawal: 100000, Daily: 300000},
+			Cashback:     models.CardCashback{Rules: []models.CashbackRule{{Percent: 1}}, MonthlyCap: 3000}},
+		{Code: "MIR_CREDIT", Name: "Мир Кредитная", CardType: models.CardTypeCredit, BINs: []string{"220072"},
+			AccountTypes: []models.AccountType{models.AccountTypeCredit},
+			Limits:       models.CardLimits{Withdrawal: 50000, Daily: 300000},
+			Fees:         models.CardFees{ATMPercent: 3, ATMMin: 390}},
+	}
+
+	now := time.Now()
+	for _, product := range products {
+		product.ID = s.nextID("card_products")
+		if product.Cashback.Rules == nil {
+			product.Cashback.Rules = []models.CashbackRule{}
+		}
+		product.IsActive = true
+		product.CreatedAt = now
+		product.UpdatedAt = now
+		s.cardProducts[product.ID] = product
+	}
+}
+
+// seedAccountPlans adds the account plan catalog of schema.sql
+func (s *Store) seedAccountPlans() {
+	both := []models.AccountType{models.AccountTypeChecking, models.AccountTypeSavings}
+	plans := []*models.AccountPlan{
+		{Code: "BASIC", Name: "Базовый", AccountTypes: both, FreeTransfers: 5, TransferFee: 30,
+			InterestTiers: []models.InterestTier{}},
+		{Code: "PREMIUM", Name: "Премиум", AccountTypes: both, MonthlyFee: 490,
+			InterestTiers: []models.InterestTier{{MinBalance: 0, Rate: 3}, {MinBalance: 300000, Rate: 6}}},
+		{Code: "SAVINGS_PLUS", Name: "Накопительный+", AccountTypes: []models.AccountType{models.AccountTypeSavings}, FreeTransfers: 3, TransferFee: 50,
+			InterestTiers: []models.InterestTier{{MinBalance: 0, Rate: 4}, {MinBalance: 100000, Rate: 8}, {MinBalance: 1000000, Rate: 10}}},
+	}
+
+	now := time.Now()
+	for _, plan := range plans {
+		plan.ID = s.nextID("account_plans")
+		plan.DayCount = models.DefaultPlanDayCount
+		plan.IsActive = true
+		plan.CreatedAt = now
+		plan.UpdatedAt = now
+		s.accountPlans[plan.ID] = plan
+	}
+}
+
+// connector opens connections to a store whose transactions save the tables on Begin and restore
+// them on Rollback. Statements are rejected, since the memory repositories never run SQL.
+type connector struct {
+	s *Store
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) { return conn{s: c.s}, nil }
+func (c connector) Driver() driver.Driver                        { return memoryDriver{} }
+
+type memoryDriver struct{}
+
+func (memoryDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("memory storage is opened with NewStore")
+}
+
+type conn struct {
+	s *Store
+}
+
+func (conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("memory storage does not run SQL")
+}
+func (conn) Close() error                { return nil }
+func (c conn) Begin() (driver.Tx, error) { return tx{restore: c.s.save()}, nil }
+
+type tx struct {
+	restore func()
+}
+
+func (tx) Commit() error { return nil }
+func (t tx) Rollback() error {
+	t.restore()
+	return nil
+}
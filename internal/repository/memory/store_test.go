@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"banking-service/internal/models"
+)
+
+func TestTablesListsEveryTable(t *testing.T) {
+	s := NewStore()
+
+	// Sequences are left out on purpose: they are not rolled back in PostgreSQL either
+	want := 0
+	store := reflect.TypeOf(s).Elem()
+	for i := 0; i < store.NumField(); i++ {
+		if field := store.Field(i); field.Type.Kind() == reflect.Map && field.Name != "seq" {
+			want++
+		}
+	}
+
+	if got := len(s.tables()); got != want {
+		t.Errorf("tables lists %d tables, the store has %d", got, want)
+	}
+}
+
+func TestRollbackRestoresTables(t *testing.T) {
+	ctx := context.Background()
+	s := NewStore()
+	users := NewUserRepository(s)
+	accounts := NewAccountRepository(s, models.AccountNumberScheme{})
+
+	userID, err := users.Create(ctx, &models.User{Username: "owner", Email: "owner@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	accountID, err := accounts.Create(ctx, &models.Account{UserID: userID, AccountNumber: "40817810000000000001",
+		AccountType: models.AccountTypeChecking, Currency: models.CurrencyRUB, Balance: 100, IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	tx, err := s.DB().BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if err := accounts.UpdateBalanceTx(ctx, tx, accountID, -40); err != nil {
+		t.Fatalf("failed to update balance: %v", err)
+	}
+	if _, err := users.Create(ctx, &models.User{Username: "other", Email: "other@example.com"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+
+	account, err := accounts.GetByID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("failed to get account: %v", err)
+	}
+	if account.Balance != 100 {
+		t.Errorf("expected balance 100 after the rollback, got %.2f", account.Balance)
+	}
+	if _, err := users.GetByUsername(ctx, "other"); err == nil {
+		t.Error("expected the user created in the transaction to be gone after the rollback")
+	}
+}
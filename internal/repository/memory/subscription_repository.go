@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// SubscriptionRepo is an in-memory implementation of the repository.SubscriptionRepository interface
+type SubscriptionRepo struct {
+	s *Store
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepo
+func NewSubscriptionRepository(s *Store) *SubscriptionRepo {
+	return &SubscriptionRepo{s: s}
+}
+
+// CreatePlan creates a new subscription plan
+func (r *SubscriptionRepo) CreatePlan(ctx context.Context, plan *models.SubscriptionPlan) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.merchants[plan.MerchantID]; !ok {
+		return 0, fmt.Errorf("failed to create subscription plan: %w", errNotExist("merchant", plan.MerchantID))
+	}
+	if plan.Amount <= 0 {
+		return 0, fmt.Errorf("failed to create subscription plan: amount must be positive")
+	}
+
+	now := time.Now()
+	plan.ID = r.s.nextID("subscription_plans")
+	plan.CreatedAt = now
+	plan.UpdatedAt = now
+	r.s.subscriptionPlans[plan.ID] = clone(plan)
+
+	return plan.ID, nil
+}
+
+// GetPlanByID gets a subscription plan by ID
+func (r *SubscriptionRepo) GetPlanByID(ctx context.Context, id int) (*models.SubscriptionPlan, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	plan, ok := r.s.subscriptionPlans[id]
+	if !ok {
+		return nil, fmt.Errorf("subscription plan not found: %w", sql.ErrNoRows)
+	}
+
+	return clone(plan), nil
+}
+
+// GetPlansByMerchantID gets the subscription plans of a merchant, oldest first
+func (r *SubscriptionRepo) GetPlansByMerchantID(ctx context.Context, merchantID int) ([]*models.SubscriptionPlan, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	plans := []*models.SubscriptionPlan{}
+	for _, plan := range rowsOf(r.s.subscriptionPlans, func(p *models.SubscriptionPlan) bool { return p.MerchantID == merchantID }) {
+		plans = append(plans, clone(plan))
+	}
+	sort.SliceStable(plans, func(i, j int) bool { return plans[i].ID < plans[j].ID })
+
+	return plans, nil
+}
+
+// SetPlanActive opens a subscription plan to new subscribers or closes it
+func (r *SubscriptionRepo) SetPlanActive(ctx context.Context, id int, isActive bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if plan, ok := r.s.subscriptionPlans[id]; ok {
+		plan.IsActive = isActive
+		plan.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Create creates a new subscription
+func (r *SubscriptionRepo) Create(ctx context.Context, subscription *models.Subscription) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.subscriptionPlans[subscription.PlanID]; !ok {
+		return 0, fmt.Errorf("failed to create subscription: %w", errNotExist("subscription plan", subscription.PlanID))
+	}
+	if _, ok := r.s.users[subscription.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create subscription: %w", errNotExist("user", subscription.UserID))
+	}
+	if _, ok := r.s.accounts[subscription.AccountID]; !ok {
+		return 0, fmt.Errorf("failed to create subscription: %w", errNotExist("account", subscription.AccountID))
+	}
+
+	now := time.Now()
+	row := subscriptionCopy(subscription)
+	row.ID = r.s.nextID("subscriptions")
+	row.FailedAttempts, row.NextRetryAt, row.LastPaymentError = 0, nil, ""
+	row.CancelReason, row.CancelledAt = "", nil
+	row.CreatedAt = now
+	row.UpdatedAt = now
+	r.s.subscriptions[row.ID] = row
+
+	subscription.ID = row.ID
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	return row.ID, nil
+}
+
+// GetByID gets a subscription by ID
+func (r *SubscriptionRepo) GetByID(ctx context.Context, id int) (*models.Subscription, error) {
+	subscriptions := r.list(func(s *models.Subscription) bool { return s.ID == id })
+	if len(subscriptions) == 0 {
+		return nil, fmt.Errorf("subscription not found: %w", sql.ErrNoRows)
+	}
+
+	return subscriptions[0], nil
+}
+
+// GetByUserID gets the subscriptions of a user, newest first
+func (r *SubscriptionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Subscription, error) {
+	subscriptions := r.list(func(s *models.Subscription) bool { return s.UserID == userID })
+	sort.SliceStable(subscriptions, func(i, j int) bool { return subscriptions[i].ID > subscriptions[j].ID })
+	return subscriptions, nil
+}
+
+// GetByMerchantID gets the subscriptions to the plans of a merchant, newest first
+func (r *SubscriptionRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Subscription, error) {
+	subscriptions := []*models.Subscription{}
+	for _, subscription := range r.list(func(*models.Subscription) bool { return true }) {
+		if subscription.MerchantID == merchantID {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+	sort.SliceStable(subscriptions, func(i, j int) bool { return subscriptions[i].ID > subscriptions[j].ID })
+	return subscriptions, nil
+}
+
+// GetDue gets the active subscriptions whose next period has started and the past due ones
+// whose failed charge is due to be retried
+func (r *SubscriptionRepo) GetDue(ctx context.Context, now time.Time) ([]*models.Subscription, error) {
+	subscriptions := r.list(func(s *models.Subscription) bool {
+		switch s.Status {
+		case models.SubscriptionStatusActive:
+			return !s.NextBillingAt.After(now)
+		case models.SubscriptionStatusPastDue:
+			return s.NextRetryAt != nil && !s.NextRetryAt.After(now)
+		}
+		return false
+	})
+	sort.SliceStable(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+	return subscriptions, nil
+}
+
+// list gets the subscriptions that match with the details of their plans
+func (r *SubscriptionRepo) list(match func(*models.Subscription) bool) []*models.Subscription {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	subscriptions := []*models.Subscription{}
+	for _, subscription := range rowsOf(r.s.subscriptions, match) {
+		s := subscriptionCopy(subscription)
+		if plan, ok := r.s.subscriptionPlans[s.PlanID]; ok {
+			s.PlanName = plan.Name
+			s.MerchantID = plan.MerchantID
+			s.Amount = plan.Amount
+			s.Currency = plan.Currency
+			s.Interval = plan.Interval
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions
+}
+
+// AdvancePeriod moves the next billing date of an active subscription from one period to the
+// next. It reports false if the period has been billed already, so every period is billed once.
+func (r *SubscriptionRepo) AdvancePeriod(ctx context.Context, id int, from time.Time, to time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subscription, ok := r.s.subscriptions[id]
+	if !ok || subscription.Status != models.SubscriptionStatusActive || !subscription.NextBillingAt.Equal(from) {
+		return false, nil
+	}
+
+	subscription.NextBillingAt = to
+	subscription.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// RecordPayment returns a subscription that is not cancelled to ACTIVE and resets its failed charges
+func (r *SubscriptionRepo) RecordPayment(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subscription, ok := r.s.subscriptions[id]
+	if !ok || subscription.Status == models.SubscriptionStatusCancelled {
+		return nil
+	}
+
+	subscription.Status = models.SubscriptionStatusActive
+	subscription.FailedAttempts = 0
+	subscription.NextRetryAt = nil
+	subscription.LastPaymentError = ""
+	subscription.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RecordFailure marks a subscription that is not cancelled PAST_DUE after a failed charge and
+// schedules the retry. It returns the number of consecutive failed charges.
+func (r *SubscriptionRepo) RecordFailure(ctx context.Context, id int, reason string, nextRetryAt time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subscription, ok := r.s.subscriptions[id]
+	if !ok || subscription.Status == models.SubscriptionStatusCancelled {
+		return 0, fmt.Errorf("subscription not found: %w", sql.ErrNoRows)
+	}
+
+	subscription.Status = models.SubscriptionStatusPastDue
+	subscription.FailedAttempts++
+	subscription.NextRetryAt = timePtr(nextRetryAt)
+	subscription.LastPaymentError = reason
+	subscription.UpdatedAt = time.Now()
+
+	return subscription.FailedAttempts, nil
+}
+
+// Cancel cancels a subscription. It reports false if the subscription was already cancelled.
+func (r *SubscriptionRepo) Cancel(ctx context.Context, id int, reason string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	subscription, ok := r.s.subscriptions[id]
+	if !ok || subscription.Status == models.SubscriptionStatusCancelled {
+		return false, nil
+	}
+
+	now := time.Now()
+	subscription.Status = models.SubscriptionStatusCancelled
+	subscription.CancelReason = reason
+	subscription.CancelledAt = timePtr(now)
+	subscription.NextRetryAt = nil
+	subscription.UpdatedAt = now
+
+	return true, nil
+}
+
+// subscriptionCopy copies a subscription together with its optional timestamps
+func subscriptionCopy(subscription *models.Subscription) *models.Subscription {
+	s := clone(subscription)
+	if subscription.NextRetryAt != nil {
+		s.NextRetryAt = timePtr(*subscription.NextRetryAt)
+	}
+	if subscription.CancelledAt != nil {
+		s.CancelledAt = timePtr(*subscription.CancelledAt)
+	}
+	return s
+}
@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TaxDocumentRepo is an in-memory implementation of the repository.TaxDocumentRepository interface
+type TaxDocumentRepo struct {
+	s *Store
+}
+
+// NewTaxDocumentRepository creates a new TaxDocumentRepo
+func NewTaxDocumentRepository(s *Store) *TaxDocumentRepo {
+	return &TaxDocumentRepo{s: s}
+}
+
+// GetInterestEarned sums the interest credited to each of the user's personal accounts in [from, to)
+func (r *TaxDocumentRepo) GetInterestEarned(ctx context.Context, userID int, from, to time.Time) ([]models.TaxInterestLine, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	sums := make(map[string]*models.TaxInterestLine)
+	for _, transaction := range r.s.transactions {
+		account := r.s.interestAccount(transaction, from, to)
+		if account == nil || account.UserID != userID {
+			continue
+		}
+
+		line, ok := sums[account.AccountNumber]
+		if !ok {
+			line = &models.TaxInterestLine{
+				AccountNumber: account.AccountNumber,
+				AccountType:   account.AccountType,
+				Currency:      account.Currency,
+			}
+			sums[account.AccountNumber] = line
+		}
+		line.Amount += transaction.Amount
+	}
+
+	lines := []models.TaxInterestLine{}
+	for _, line := range sums {
+		lines = append(lines, *line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].AccountNumber < lines[j].AccountNumber })
+
+	return lines, nil
+}
+
+// GetLoanInterestPaid sums the interest and penalties of the user's credit payments made in [from, to).
+// A scheduled payment is stamped with updated_at when it is paid, which may be after its due date.
+func (r *TaxDocumentRepo) GetLoanInterestPaid(ctx context.Context, userID int, from, to time.Time) ([]models.TaxLoanLine, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	sums := make(map[int]*models.TaxLoanLine)
+	for _, schedule := range r.s.schedules {
+		credit := r.s.paidCredit(schedule, from, to)
+		if credit == nil || credit.UserID != userID {
+			continue
+		}
+
+		line, ok := sums[credit.ID]
+		if !ok {
+			line = &models.TaxLoanLine{CreditID: credit.ID}
+			sums[credit.ID] = line
+		}
+		line.InterestPaid += schedule.InterestAmount
+		line.PenaltiesPaid += schedule.PenaltyAmount
+	}
+
+	lines := []models.TaxLoanLine{}
+	for _, line := range sums {
+		lines = append(lines, *line)
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].CreditID < lines[j].CreditID })
+
+	return lines, nil
+}
+
+// GetUsersWithActivity gets the users who earned interest or paid credit interest in [from, to)
+func (r *TaxDocumentRepo) GetUsersWithActivity(ctx context.Context, from, to time.Time) ([]int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	seen := make(map[int]bool)
+	for _, transaction := range r.s.transactions {
+		if account := r.s.interestAccount(transaction, from, to); account != nil {
+			seen[account.UserID] = true
+		}
+	}
+	for _, schedule := range r.s.schedules {
+		if credit := r.s.paidCredit(schedule, from, to); credit != nil {
+			seen[credit.UserID] = true
+		}
+	}
+
+	userIDs := []int{}
+	for userID := range seen {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Ints(userIDs)
+
+	return userIDs, nil
+}
+
+// interestAccount returns the personal account a completed interest transaction dated in
+// [from, to) credited, or nil
+func (s *Store) interestAccount(transaction *models.Transaction, from, to time.Time) *accountRow {
+	if transaction.TransactionType != models.TransactionTypeInterest || transaction.Status != models.TransactionStatusCompleted ||
+		transaction.TransactionDate.Before(from) || !transaction.TransactionDate.Before(to) ||
+		transaction.DestinationAccountID == nil {
+		return nil
+	}
+
+	account, ok := s.accounts[*transaction.DestinationAccountID]
+	if !ok || account.OrganizationID != nil {
+		return nil
+	}
+	return account
+}
+
+// paidCredit returns the credit of a scheduled payment paid in [from, to), or nil
+func (s *Store) paidCredit(schedule *models.PaymentSchedule, from, to time.Time) *models.Credit {
+	if schedule.Status != models.PaymentStatusPaid || schedule.UpdatedAt.Before(from) || !schedule.UpdatedAt.Before(to) {
+		return nil
+	}
+	return s.credits[schedule.CreditID]
+}
+
+// Upsert stores a generated tax document, replacing an earlier version for the same year
+func (r *TaxDocumentRepo) Upsert(ctx context.Context, doc *models.TaxDocument) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[doc.UserID]; !ok {
+		return 0, fmt.Errorf("failed to save tax document: %w", errNotExist("user", doc.UserID))
+	}
+
+	row := taxDocumentRow(doc)
+	row.GeneratedAt = time.Now()
+	row.EmailedAt = nil
+	for _, existing := range r.s.taxDocuments {
+		if existing.UserID == doc.UserID && existing.Year == doc.Year {
+			row.ID = existing.ID
+			row.EmailedAt = existing.EmailedAt
+			break
+		}
+	}
+	if row.ID == 0 {
+		row.ID = r.s.nextID("tax_documents")
+	}
+	r.s.taxDocuments[row.ID] = row
+
+	doc.ID, doc.GeneratedAt, doc.EmailedAt = row.ID, row.GeneratedAt, row.EmailedAt
+
+	return doc.ID, nil
+}
+
+// GetByUserAndYear gets the tax document of a user for a year
+func (r *TaxDocumentRepo) GetByUserAndYear(ctx context.Context, userID int, year int) (*models.TaxDocument, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, doc := range r.s.taxDocuments {
+		if doc.UserID == userID && doc.Year == year {
+			return taxDocumentRow(doc), nil
+		}
+	}
+
+	return nil, fmt.Errorf("tax document not found: %w", sql.ErrNoRows)
+}
+
+// GetByUserID gets the tax documents of a user, newest year first
+func (r *TaxDocumentRepo) GetByUserID(ctx context.Context, userID int) ([]*models.TaxDocument, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	docs := []*models.TaxDocument{}
+	for _, doc := range rowsOf(r.s.taxDocuments, func(d *models.TaxDocument) bool { return d.UserID == userID }) {
+		docs = append(docs, taxDocumentRow(doc))
+	}
+	sort.SliceStable(docs, func(i, j int) bool { return docs[i].Year > docs[j].Year })
+
+	return docs, nil
+}
+
+// MarkEmailed records that a tax document was delivered by email
+func (r *TaxDocumentRepo) MarkEmailed(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if doc, ok := r.s.taxDocuments[id]; ok {
+		doc.EmailedAt = timePtr(time.Now())
+	}
+
+	return nil
+}
+
+// taxDocumentRow copies a tax document together with its lines
+func taxDocumentRow(doc *models.TaxDocument) *models.TaxDocument {
+	d := clone(doc)
+	d.InterestLines = append([]models.TaxInterestLine(nil), doc.InterestLines...)
+	d.LoanLines = append([]models.TaxLoanLine(nil), doc.LoanLines...)
+	return d
+}
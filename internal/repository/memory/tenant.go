@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+
+	"banking-service/internal/models"
+)
+
+// requestTenant returns the tenant the request was made for, or "" in background jobs that work
+// across tenants. Lookups are not filtered by tenant when it is empty.
+func requestTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value("tenant").(string)
+	return tenant
+}
+
+// newRecordTenant returns the tenant a new user or product is created in: the one set on it, else
+// the request's, else the default
+func newRecordTenant(ctx context.Context, tenant string) string {
+	if tenant == "" {
+		tenant = requestTenant(ctx)
+	}
+	if tenant == "" {
+		tenant = models.DefaultTenant
+	}
+	return tenant
+}
+
+// inTenant reports whether a row of the tenant is visible to the request
+func inTenant(ctx context.Context, tenant string) bool {
+	requested := requestTenant(ctx)
+	return requested == "" || requested == tenant
+}
+
+// userInTenant reports whether a user, and with it the records that belong to them, is visible to
+// the request. The caller holds the store lock.
+func (s *Store) userInTenant(ctx context.Context, userID int) bool {
+	user, ok := s.users[userID]
+	return ok && inTenant(ctx, user.Tenant)
+}
+
+// accountInTenant reports whether an account, and with it its cards and credits, is visible to the
+// request. The caller holds the store lock.
+func (s *Store) accountInTenant(ctx context.Context, accountID int) bool {
+	account, ok := s.accounts[accountID]
+	return ok && inTenant(ctx, account.tenant)
+}
+
+// transactionInTenant reports whether a transaction is visible to the request. It belongs to the
+// tenant of its source account, or of its destination account if it has none. The caller holds the
+// store lock.
+func (s *Store) transactionInTenant(ctx context.Context, transaction *models.Transaction) bool {
+	accountID := transaction.SourceAccountID
+	if accountID == nil {
+		accountID = transaction.DestinationAccountID
+	}
+
+	tenant := models.DefaultTenant
+	if accountID != nil {
+		if account, ok := s.accounts[*accountID]; ok {
+			tenant = account.tenant
+		}
+	}
+
+	return inTenant(ctx, tenant)
+}
@@ -0,0 +1,281 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// tenantContext returns a request context of a tenant
+func tenantContext(tenant string) context.Context {
+	return context.WithValue(context.Background(), "tenant", tenant)
+}
+
+func TestTenantCannotReadAnotherTenantsData(t *testing.T) {
+	s := NewStore()
+	users := NewUserRepository(s)
+	accounts := NewAccountRepository(s, models.AccountNumberScheme{})
+	transactions := NewTransactionRepository(s)
+	cards := NewCardRepository(s)
+	credits := NewCreditRepository(s)
+	products := NewCardProductRepository(s)
+	plans := NewAccountPlanRepository(s)
+	reports := NewReportingRepository(s)
+	deposits := NewChequeDepositRepository(s)
+
+	owner, intruder := tenantContext("a"), tenantContext("b")
+
+	userID, err := users.Create(owner, &models.User{Username: "owner", Email: "owner@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	accountID, err := accounts.Create(owner, &models.Account{UserID: userID, AccountNumber: "40817810000000000001",
+		AccountType: models.AccountTypeChecking, Currency: models.CurrencyRUB, Balance: 1000, IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	transactionID, err := transactions.Create(owner, &models.Transaction{DestinationAccountID: &accountID,
+		TransactionType: models.TransactionTypeDeposit, Amount: 1000, Currency: models.CurrencyRUB,
+		Status: models.TransactionStatusCompleted, TransactionDate: time.Now()})
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	productID, err := products.Create(owner, &models.CardProduct{Code: "classic", Name: "Classic", IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to create card product: %v", err)
+	}
+	cardID, err := cards.Create(owner, &models.Card{AccountID: accountID, ProductID: productID, CardNumberHMAC: "hmac"})
+	if err != nil {
+		t.Fatalf("failed to create card: %v", err)
+	}
+	creditID, err := credits.Create(owner, &models.Credit{UserID: userID, AccountID: accountID, Amount: 1000,
+		InterestRate: 10, TermMonths: 12, MonthlyPayment: 90, Status: models.CreditStatusActive})
+	if err != nil {
+		t.Fatalf("failed to create credit: %v", err)
+	}
+	planID, err := plans.Create(owner, &models.AccountPlan{Code: "premium", Name: "Premium"})
+	if err != nil {
+		t.Fatalf("failed to create account plan: %v", err)
+	}
+	depositID, err := deposits.CreateTx(owner, nil, &models.ChequeDeposit{UserID: userID, AccountID: accountID,
+		Amount: 1000, Currency: models.CurrencyRUB, StorageKey: "cheque", TransactionID: transactionID,
+		Status: models.ChequeDepositStatusPending})
+	if err != nil {
+		t.Fatalf("failed to create cheque deposit: %v", err)
+	}
+
+	notFound := map[string]error{}
+	_, notFound["transaction"] = transactions.GetByID(intruder, transactionID)
+	_, notFound["card"] = cards.GetByID(intruder, cardID)
+	_, notFound["credit"] = credits.GetByID(intruder, creditID)
+	_, notFound["card product"] = products.GetByID(intruder, productID)
+	_, notFound["account plan"] = plans.GetByID(intruder, planID)
+	_, notFound["cheque deposit"] = deposits.GetByID(intruder, depositID)
+	for name, err := range notFound {
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected the %s of another tenant not to be found, got %v", name, err)
+		}
+	}
+
+	if list, err := transactions.GetByUserID(intruder, userID); err != nil || len(list) != 0 {
+		t.Errorf("expected no transactions of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := cards.GetAll(intruder); err != nil || len(list) != 0 {
+		t.Errorf("expected no cards of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := credits.GetByUserID(intruder, userID); err != nil || len(list) != 0 {
+		t.Errorf("expected no credits of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := products.GetAll(intruder); err != nil || len(list) != 0 {
+		t.Errorf("expected no card products of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := plans.GetAll(intruder); err != nil || len(list) != 0 {
+		t.Errorf("expected no account plans of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := deposits.GetByStatus(intruder, ""); err != nil || len(list) != 0 {
+		t.Errorf("expected no cheque deposits of another tenant, got %d (%v)", len(list), err)
+	}
+
+	from, to := time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1)
+	if volumes, err := reports.GetTransactionVolume(intruder, from, to); err != nil || len(volumes) != 0 {
+		t.Errorf("expected no transaction volume of another tenant, got %d days (%v)", len(volumes), err)
+	}
+	if days, err := reports.GetNewUsers(intruder, from, to); err != nil || len(days) != 0 {
+		t.Errorf("expected no new users of another tenant, got %d days (%v)", len(days), err)
+	}
+	if days, err := reports.GetCreditsIssued(intruder, from, to); err != nil || len(days) != 0 {
+		t.Errorf("expected no credits issued by another tenant, got %d days (%v)", len(days), err)
+	}
+	if balances, err := reports.GetDepositBalances(intruder); err != nil || len(balances) != 0 {
+		t.Errorf("expected no deposit balances of another tenant, got %d (%v)", len(balances), err)
+	}
+
+	// The owner's tenant still sees its data
+	if _, err := transactions.GetByID(owner, transactionID); err != nil {
+		t.Errorf("expected the owner's tenant to get its transaction, got %v", err)
+	}
+	if _, err := cards.GetByID(owner, cardID); err != nil {
+		t.Errorf("expected the owner's tenant to get its card, got %v", err)
+	}
+	if _, err := deposits.GetByID(owner, depositID); err != nil {
+		t.Errorf("expected the owner's tenant to get its cheque deposit, got %v", err)
+	}
+	if balances, err := reports.GetDepositBalances(owner); err != nil || len(balances) != 1 {
+		t.Errorf("expected the deposit balances of the owner's tenant, got %d (%v)", len(balances), err)
+	}
+}
+
+func TestProductCodesAreUniquePerTenant(t *testing.T) {
+	s := NewStore()
+	products := NewCardProductRepository(s)
+	plans := NewAccountPlanRepository(s)
+
+	for _, tenant := range []string{"a", "b"} {
+		ctx := tenantContext(tenant)
+		if _, err := products.Create(ctx, &models.CardProduct{Code: "classic", Name: "Classic"}); err != nil {
+			t.Fatalf("failed to create card product in tenant %s: %v", tenant, err)
+		}
+		if _, err := plans.Create(ctx, &models.AccountPlan{Code: "premium", Name: "Premium"}); err != nil {
+			t.Fatalf("failed to create account plan in tenant %s: %v", tenant, err)
+		}
+	}
+
+	if _, err := products.Create(tenantContext("a"), &models.CardProduct{Code: "classic", Name: "Classic"}); err == nil {
+		t.Error("expected a duplicate card product code in a tenant to be rejected")
+	}
+	if _, err := plans.Create(tenantContext("a"), &models.AccountPlan{Code: "premium", Name: "Premium"}); err == nil {
+		t.Error("expected a duplicate account plan code in a tenant to be rejected")
+	}
+}
+
+func TestTenantCannotReadAnotherTenantsEscrows(t *testing.T) {
+	s := NewStore()
+	users := NewUserRepository(s)
+	accounts := NewAccountRepository(s, models.AccountNumberScheme{})
+	escrows := NewEscrowRepository(s)
+
+	owner, intruder := tenantContext("a"), tenantContext("b")
+
+	var userIDs, accountIDs [2]int
+	numbers := [2]string{"40817810000000000001", "40817810000000000002"}
+	for i, name := range []string{"buyer", "seller"} {
+		userID, err := users.Create(owner, &models.User{Username: name, Email: name + "@example.com"})
+		if err != nil {
+			t.Fatalf("failed to create %s: %v", name, err)
+		}
+		accountID, err := accounts.Create(owner, &models.Account{UserID: userID, AccountNumber: numbers[i],
+			AccountType: models.AccountTypeChecking, Currency: models.CurrencyRUB, Balance: 1000, IsActive: true})
+		if err != nil {
+			t.Fatalf("failed to create %s account: %v", name, err)
+		}
+		userIDs[i], accountIDs[i] = userID, accountID
+	}
+	escrowID, err := escrows.CreateTx(owner, nil, &models.Escrow{BuyerID: userIDs[0], SellerID: userIDs[1],
+		BuyerAccountID: accountIDs[0], SellerAccountID: accountIDs[1], Amount: 100, Currency: models.CurrencyRUB,
+		Status: models.EscrowStatusFunded, ExpiresAt: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("failed to create escrow: %v", err)
+	}
+
+	if _, err := escrows.GetByID(intruder, escrowID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected the escrow of another tenant not to be found, got %v", err)
+	}
+	if list, err := escrows.GetByStatus(intruder, ""); err != nil || len(list) != 0 {
+		t.Errorf("expected no escrows of another tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := escrows.GetByStatus(owner, ""); err != nil || len(list) != 1 {
+		t.Errorf("expected the escrows of the owner's tenant, got %d (%v)", len(list), err)
+	}
+	if list, err := escrows.GetExpired(context.Background(), time.Now().Add(2*time.Hour)); err != nil || len(list) != 1 {
+		t.Errorf("expected background jobs to see the escrows of all tenants, got %d (%v)", len(list), err)
+	}
+}
+
+func TestTenantCannotReviewAnotherTenantsRequests(t *testing.T) {
+	s := NewStore()
+	users := NewUserRepository(s)
+	accounts := NewAccountRepository(s, models.AccountNumberScheme{})
+	applications := NewCreditApplicationRepository(s)
+	messages := NewMessageRepository(s)
+	ownership := NewAccountOwnershipRepository(s)
+	fees := NewFeeRepository(s)
+	emails := NewEmailRepository(s)
+	announcements := NewAnnouncementRepository(s)
+
+	owner, intruder := tenantContext("a"), tenantContext("b")
+
+	userID, err := users.Create(owner, &models.User{Username: "owner", Email: "owner@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	heirID, err := users.Create(owner, &models.User{Username: "heir", Email: "heir@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	accountID, err := accounts.Create(owner, &models.Account{UserID: userID, AccountNumber: "40817810000000000001",
+		AccountType: models.AccountTypeChecking, Currency: models.CurrencyRUB, IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	applicationID, err := applications.Create(owner, &models.CreditApplication{UserID: userID, Amount: 1000, TermMonths: 12,
+		Status: models.CreditApplicationStatusPending})
+	if err != nil {
+		t.Fatalf("failed to create credit application: %v", err)
+	}
+	threadID, err := messages.CreateThreadTx(owner, nil, &models.MessageThread{UserID: userID, Subject: "Question",
+		StartedBy: models.MessageSenderCustomer})
+	if err != nil {
+		t.Fatalf("failed to create message thread: %v", err)
+	}
+	transferID, err := ownership.CreateTx(owner, nil, &models.OwnershipTransfer{AccountID: accountID, FromUserID: userID,
+		ToUserID: heirID, RequestedBy: userID, Status: models.OwnershipTransferStatusPending})
+	if err != nil {
+		t.Fatalf("failed to create ownership transfer: %v", err)
+	}
+	if _, err := fees.CreateCharge(owner, &models.FeeCharge{Kind: models.FeeKindCardService, ReferenceID: 1, AccountID: accountID,
+		PeriodStart: time.Now(), Amount: 100, Currency: models.CurrencyRUB, Status: models.FeeChargeStatusPending}); err != nil {
+		t.Fatalf("failed to create fee charge: %v", err)
+	}
+	if _, err := emails.CreateDelivery(owner, &models.EmailDelivery{MessageID: "<1@example.com>", UserID: &userID, Tenant: "a",
+		Recipient: "owner@example.com", Status: models.EmailDeliveryStatusAccepted}); err != nil {
+		t.Fatalf("failed to create email delivery: %v", err)
+	}
+	announcementID, err := announcements.Create(owner, &models.Announcement{Title: "News", Message: "News",
+		Segment: models.AnnouncementSegment{Audience: models.AnnouncementAudienceAll, Tenant: "a"}, CreatedBy: userID})
+	if err != nil {
+		t.Fatalf("failed to create announcement: %v", err)
+	}
+
+	notFound := map[string]error{}
+	_, notFound["credit application"] = applications.GetByID(intruder, applicationID)
+	_, notFound["message thread"] = messages.GetThreadByID(intruder, threadID, models.MessageSenderBank)
+	_, notFound["ownership transfer"] = ownership.GetByID(intruder, transferID)
+	_, notFound["announcement"] = announcements.GetByID(intruder, announcementID)
+	for name, err := range notFound {
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected the %s of another tenant not to be found, got %v", name, err)
+		}
+	}
+
+	for want, ctx := range []context.Context{intruder, owner} {
+		a, _ := applications.GetByStatus(ctx, "")
+		m, _ := messages.GetThreads(ctx, false)
+		o, _ := ownership.GetByStatus(ctx, "")
+		f, _ := fees.GetCharges(ctx, "")
+		e, _ := emails.GetDeliveries(ctx, &models.EmailDeliveryFilter{Limit: 10})
+		n, _ := announcements.GetAll(ctx)
+
+		counts := map[string]int{"credit applications": len(a), "message threads": len(m), "ownership transfers": len(o),
+			"fee charges": len(f), "email deliveries": len(e), "announcements": len(n)}
+		for name, got := range counts {
+			if got != want {
+				t.Errorf("expected %d %s in tenant %s, got %d", want, name, requestTenant(ctx), got)
+			}
+		}
+	}
+}
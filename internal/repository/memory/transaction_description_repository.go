@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TransactionDescriptionRepo is an in-memory implementation of the
+// repository.TransactionDescriptionRepository interface
+type TransactionDescriptionRepo struct {
+	s *Store
+}
+
+// NewTransactionDescriptionRepository creates a new TransactionDescriptionRepo
+func NewTransactionDescriptionRepository(s *Store) *TransactionDescriptionRepo {
+	return &TransactionDescriptionRepo{s: s}
+}
+
+// Create records a user's edit of the description of a transaction
+func (r *TransactionDescriptionRepo) Create(ctx context.Context, edit *models.TransactionDescriptionEdit) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.transactions[edit.TransactionID]; !ok {
+		return 0, fmt.Errorf("failed to create transaction description edit: %w", errNotExist("transaction", edit.TransactionID))
+	}
+	if _, ok := r.s.users[edit.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create transaction description edit: %w", errNotExist("user", edit.UserID))
+	}
+
+	edit.ID = r.s.nextID("transaction_description_edits")
+	edit.CreatedAt = time.Now()
+	r.s.descriptionEdits[edit.ID] = clone(edit)
+
+	return edit.ID, nil
+}
+
+// GetHistory gets a user's edits of the description of a transaction, oldest first
+func (r *TransactionDescriptionRepo) GetHistory(ctx context.Context, transactionID int, userID int) ([]*models.TransactionDescriptionEdit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	edits := []*models.TransactionDescriptionEdit{}
+	for _, edit := range rowsOf(r.s.descriptionEdits, func(e *models.TransactionDescriptionEdit) bool {
+		return e.TransactionID == transactionID && e.UserID == userID
+	}) {
+		edits = append(edits, clone(edit))
+	}
+
+	return edits, nil
+}
+
+// GetLatest gets a user's latest edit of the description of each of the transactions that has one,
+// by transaction ID
+func (r *TransactionDescriptionRepo) GetLatest(ctx context.Context, userID int, transactionIDs []int) (map[int]*models.TransactionDescriptionEdit, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	wanted := make(map[int]bool, len(transactionIDs))
+	for _, id := range transactionIDs {
+		wanted[id] = true
+	}
+
+	latest := make(map[int]*models.TransactionDescriptionEdit)
+	for _, edit := range rowsOf(r.s.descriptionEdits, func(e *models.TransactionDescriptionEdit) bool {
+		return e.UserID == userID && wanted[e.TransactionID]
+	}) {
+		latest[edit.TransactionID] = clone(edit)
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TransactionEventRepo is an in-memory implementation of the repository.TransactionEventRepository interface
+type TransactionEventRepo struct {
+	s *Store
+}
+
+// NewTransactionEventRepository creates a new TransactionEventRepo
+func NewTransactionEventRepository(s *Store) *TransactionEventRepo {
+	return &TransactionEventRepo{s: s}
+}
+
+// GetUnprocessed gets up to limit events the indexer has not processed yet, oldest first
+func (r *TransactionEventRepo) GetUnprocessed(ctx context.Context, limit int) ([]*models.TransactionEvent, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	events := []*models.TransactionEvent{}
+	for _, event := range rowsOf(r.s.transactionEvents, func(e *models.TransactionEvent) bool { return e.ProcessedAt == nil }) {
+		if len(events) == limit {
+			break
+		}
+		events = append(events, clone(event))
+	}
+
+	return events, nil
+}
+
+// GetTransactionIDsSince gets the IDs of the transactions created or changed since a time,
+// whether their events were processed or not
+func (r *TransactionEventRepo) GetTransactionIDsSince(ctx context.Context, since time.Time) ([]int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	seen := map[int]bool{}
+	ids := []int{}
+	for _, event := range rowsOf(r.s.transactionEvents, func(e *models.TransactionEvent) bool { return !e.CreatedAt.Before(since) }) {
+		if !seen[event.TransactionID] {
+			seen[event.TransactionID] = true
+			ids = append(ids, event.TransactionID)
+		}
+	}
+
+	return ids, nil
+}
+
+// MarkProcessed marks events processed
+func (r *TransactionEventRepo) MarkProcessed(ctx context.Context, ids []int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for _, id := range ids {
+		if event, ok := r.s.transactionEvents[id]; ok && event.ProcessedAt == nil {
+			event.ProcessedAt = timePtr(now)
+		}
+	}
+
+	return nil
+}
+
+// DeleteProcessed deletes the processed events created before a time and returns how many
+func (r *TransactionEventRepo) DeleteProcessed(ctx context.Context, before time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var deleted int64
+	for id, event := range r.s.transactionEvents {
+		if event.ProcessedAt != nil && event.CreatedAt.Before(before) {
+			delete(r.s.transactionEvents, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// recordTransactionEvent records that a transaction was created or changed, as the trigger on the
+// transactions table does
+func (s *Store) recordTransactionEvent(transactionID int) {
+	id := s.nextID("transaction_events")
+	s.transactionEvents[id] = &models.TransactionEvent{ID: id, TransactionID: transactionID, CreatedAt: time.Now()}
+}
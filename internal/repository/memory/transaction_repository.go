@@ -0,0 +1,589 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TransactionRepo is an in-memory implementation of the repository.TransactionRepository interface
+type TransactionRepo struct {
+	s *Store
+}
+
+// NewTransactionRepository creates a new TransactionRepo
+func NewTransactionRepository(s *Store) *TransactionRepo {
+	return &TransactionRepo{s: s}
+}
+
+// Create creates a new transaction unless it is dated in a locked statement period
+func (r *TransactionRepo) Create(ctx context.Context, transaction *models.Transaction) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.createTransaction(transaction)
+}
+
+// CreateTx creates a new transaction within an existing transaction unless it is dated in a
+// locked statement period
+func (r *TransactionRepo) CreateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (int, error) {
+	return r.Create(ctx, transaction)
+}
+
+// CreateBatch creates multiple transactions; either all of them are created or none. It sets the
+// IDs of the transactions.
+func (r *TransactionRepo) CreateBatch(ctx context.Context, transactions []*models.Transaction) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, transaction := range transactions {
+		if err := r.s.checkTransaction(transaction); err != nil {
+			return err
+		}
+	}
+
+	for _, transaction := range transactions {
+		transaction.ID = r.s.insertTransaction(transaction)
+	}
+
+	return nil
+}
+
+// CreateBatchTx creates multiple transactions within an existing transaction unless any of them
+// is dated in a locked statement period
+func (r *TransactionRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, transactions []*models.Transaction) error {
+	return r.CreateBatch(ctx, transactions)
+}
+
+// GetByID gets a transaction by ID
+func (r *TransactionRepo) GetByID(ctx context.Context, id int) (*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transaction, ok := r.s.transactions[id]
+	if !ok || !r.s.transactionInTenant(ctx, transaction) {
+		return nil, fmt.Errorf("transaction not found: %w", sql.ErrNoRows)
+	}
+
+	return transactionRow(transaction), nil
+}
+
+// GetByReference gets a transaction by its public reference
+func (r *TransactionRepo) GetByReference(ctx context.Context, reference string) (*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, transaction := range r.s.transactions {
+		if transaction.Reference == reference && r.s.transactionInTenant(ctx, transaction) {
+			return transactionRow(transaction), nil
+		}
+	}
+
+	return nil, fmt.Errorf("transaction not found: %w", sql.ErrNoRows)
+}
+
+// GetByAccountID gets all transactions for an account, newest first
+func (r *TransactionRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Transaction, error) {
+	return r.newestFirst(ctx, func(t *models.Transaction) bool { return touches(t, accountID) })
+}
+
+// GetByUserID gets all transactions for a user through their personal accounts, newest first
+func (r *TransactionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Transaction, error) {
+	return r.userTransactions(ctx, func(t *models.Transaction) bool { return r.s.touchesUser(t, userID) })
+}
+
+// GetByDateRange gets all transactions for a user dated within a date range, inclusive
+func (r *TransactionRepo) GetByDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*models.Transaction, error) {
+	return r.userTransactions(ctx, func(t *models.Transaction) bool {
+		return r.s.touchesUser(t, userID) && !t.TransactionDate.Before(startDate) && !t.TransactionDate.After(endDate)
+	})
+}
+
+// userTransactions gets the transactions of a user that match. Each transaction is matched once,
+// even a transfer between two accounts of the user.
+func (r *TransactionRepo) userTransactions(ctx context.Context, match func(*models.Transaction) bool) ([]*models.Transaction, error) {
+	transactions, err := r.newestFirst(ctx, match)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first; the reverse ID order breaks ties, as the database orders them
+	sort.SliceStable(transactions, func(i, j int) bool {
+		if transactions[i].TransactionDate.Equal(transactions[j].TransactionDate) {
+			return transactions[i].ID > transactions[j].ID
+		}
+		return transactions[i].TransactionDate.After(transactions[j].TransactionDate)
+	})
+
+	return transactions, nil
+}
+
+// newestFirst gets the transactions of the request's tenant that match, newest first
+func (r *TransactionRepo) newestFirst(ctx context.Context, match func(*models.Transaction) bool) ([]*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var transactions []*models.Transaction
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool { return match(t) && r.s.transactionInTenant(ctx, t) }) {
+		transactions = append(transactions, transactionRow(transaction))
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].TransactionDate.After(transactions[j].TransactionDate)
+	})
+
+	return transactions, nil
+}
+
+// GetByAccountAndPeriod gets the transactions of an account dated in [from, to), oldest first
+func (r *TransactionRepo) GetByAccountAndPeriod(ctx context.Context, accountID int, from, to time.Time) ([]*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	var transactions []*models.Transaction
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool {
+		return touches(t, accountID) && !t.TransactionDate.Before(from) && t.TransactionDate.Before(to) && r.s.transactionInTenant(ctx, t)
+	}) {
+		transactions = append(transactions, transactionRow(transaction))
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].TransactionDate.Before(transactions[j].TransactionDate)
+	})
+
+	return transactions, nil
+}
+
+// GetByCardID gets a page of the transactions made with a card, newest first
+func (r *TransactionRepo) GetByCardID(ctx context.Context, cardID int, filter *models.CardTransactionFilter) ([]*models.Transaction, error) {
+	transactions, err := r.newestFirst(ctx, func(t *models.Transaction) bool { return cardTransaction(t, cardID, filter) })
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first; the reverse ID order breaks ties
+	sort.SliceStable(transactions, func(i, j int) bool {
+		if transactions[i].TransactionDate.Equal(transactions[j].TransactionDate) {
+			return transactions[i].ID > transactions[j].ID
+		}
+		return transactions[i].TransactionDate.After(transactions[j].TransactionDate)
+	})
+
+	if filter.Offset >= len(transactions) {
+		return []*models.Transaction{}, nil
+	}
+	transactions = transactions[filter.Offset:]
+	if len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+	}
+
+	return transactions, nil
+}
+
+// GetCardSpending counts the transactions made with a card that match the filter's dates and sums
+// the completed debits among them
+func (r *TransactionRepo) GetCardSpending(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	spending := &models.CardSpending{}
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool {
+		return cardTransaction(t, cardID, filter) && r.s.transactionInTenant(ctx, t)
+	}) {
+		spending.Count++
+		if transaction.Status == models.TransactionStatusCompleted && transaction.SourceAccountID != nil {
+			spending.TotalSpent += transaction.Amount
+		}
+	}
+
+	return spending, nil
+}
+
+// GetCardCashback sums the completed cashback bonuses earned with a card within the filter's dates
+func (r *TransactionRepo) GetCardCashback(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (float64, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	cashback := 0.0
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool {
+		return cardTransaction(t, cardID, filter) && r.s.transactionInTenant(ctx, t)
+	}) {
+		if transaction.TransactionType == models.TransactionTypeBonus && transaction.Status == models.TransactionStatusCompleted {
+			cashback += transaction.Amount
+		}
+	}
+
+	return cashback, nil
+}
+
+// GetCardSpendingTx counts and sums the transactions made with a card within an existing transaction
+func (r *TransactionRepo) GetCardSpendingTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error) {
+	return r.GetCardSpending(ctx, cardID, filter)
+}
+
+// GetCardCashbackTx sums the cashback earned with a card within an existing transaction
+func (r *TransactionRepo) GetCardCashbackTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (float64, error) {
+	return r.GetCardCashback(ctx, cardID, filter)
+}
+
+// CountOutgoingTransfers counts the completed transfers from an account dated in [from, to)
+func (r *TransactionRepo) CountOutgoingTransfers(ctx context.Context, accountID int, from, to time.Time) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, t := range r.s.transactions {
+		if t.SourceAccountID != nil && *t.SourceAccountID == accountID && t.TransactionType == models.TransactionTypeTransfer &&
+			t.Status == models.TransactionStatusCompleted && !t.TransactionDate.Before(from) && t.TransactionDate.Before(to) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// cardTransaction reports whether a transaction was made with the card and is dated within the
+// filter's bounds
+func cardTransaction(t *models.Transaction, cardID int, filter *models.CardTransactionFilter) bool {
+	return t.CardID != nil && *t.CardID == cardID &&
+		(filter.From == nil || !t.TransactionDate.Before(*filter.From)) &&
+		(filter.To == nil || t.TransactionDate.Before(*filter.To))
+}
+
+// Update updates the status and description of a transaction. A transaction in a locked statement
+// period is never changed; a status change that voids or restores it posts an adjustment in the
+// current period instead, and other changes are rejected.
+func (r *TransactionRepo) Update(ctx context.Context, transaction *models.Transaction) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	original, ok := r.s.transactions[transaction.ID]
+	if !ok {
+		return fmt.Errorf("transaction not found: %w", sql.ErrNoRows)
+	}
+
+	if !r.s.locked(original) {
+		original.Status = transaction.Status
+		original.Description = transaction.Description
+		r.s.recordTransactionEvent(original.ID)
+		return nil
+	}
+
+	if original.Status.AffectsBalance() == transaction.Status.AffectsBalance() {
+		return fmt.Errorf("transaction %d belongs to a locked statement period", transaction.ID)
+	}
+
+	// A voided transaction is reversed, a restored one is posted again
+	adjustment := &models.Transaction{
+		TransactionType:      models.TransactionTypeAdjustment,
+		SourceAccountID:      original.SourceAccountID,
+		DestinationAccountID: original.DestinationAccountID,
+		Amount:               original.Amount,
+		Currency:             original.Currency,
+		Description:          fmt.Sprintf("Adjustment of transaction #%d: %s", transaction.ID, transaction.Status),
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      time.Now(),
+	}
+	if original.Status.AffectsBalance() {
+		adjustment.SourceAccountID, adjustment.DestinationAccountID = original.DestinationAccountID, original.SourceAccountID
+	}
+
+	_, err := r.s.createTransaction(adjustment)
+	return err
+}
+
+// UpdateTx updates the status and description of a transaction within an existing transaction,
+// with the same rules for locked statement periods as Update
+func (r *TransactionRepo) UpdateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) error {
+	return r.Update(ctx, transaction)
+}
+
+// CompleteTx completes a pending transaction within an existing transaction. It reports false if the
+// transaction is not pending. Pending and completed transactions both count towards balances, so
+// this is allowed in locked statement periods.
+func (r *TransactionRepo) CompleteTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	transaction, ok := r.s.transactions[id]
+	if !ok || transaction.Status != models.TransactionStatusPending {
+		return false, nil
+	}
+
+	transaction.Status = models.TransactionStatusCompleted
+	r.s.recordTransactionEvent(id)
+
+	return true, nil
+}
+
+// FixCurrencies sets the currency of transactions recorded in a currency other than their account's
+// to the account currency and returns how many it corrected. Transactions in a locked statement
+// period are left as they are.
+func (r *TransactionRepo) FixCurrencies(ctx context.Context) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var fixed int64
+	for _, transaction := range r.s.transactions {
+		if currency, ok := r.s.mismatchedCurrency(transaction); ok && !r.s.locked(transaction) {
+			transaction.Currency = currency
+			r.s.recordTransactionEvent(transaction.ID)
+			fixed++
+		}
+	}
+
+	return fixed, nil
+}
+
+// GetByIDs gets the transactions with the given IDs in ID order, skipping IDs that do not exist or
+// belong to another tenant
+func (r *TransactionRepo) GetByIDs(ctx context.Context, ids []int) ([]*models.Transaction, error) {
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transactions := []*models.Transaction{}
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool { return wanted[t.ID] && r.s.transactionInTenant(ctx, t) }) {
+		transactions = append(transactions, transactionRow(transaction))
+	}
+
+	return transactions, nil
+}
+
+// GetAfterID gets up to limit transactions with IDs greater than afterID, in ID order
+func (r *TransactionRepo) GetAfterID(ctx context.Context, afterID int, limit int) ([]*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	transactions := []*models.Transaction{}
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool { return t.ID > afterID }) {
+		if len(transactions) == limit {
+			break
+		}
+		transactions = append(transactions, transactionRow(transaction))
+	}
+
+	return transactions, nil
+}
+
+// GetChanged gets up to limit transactions created or changed in [from, to) with an ID greater
+// than afterID, in ID order. The transaction events record when transactions change.
+func (r *TransactionRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Transaction, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	changedAt := map[int]time.Time{}
+	for _, event := range r.s.transactionEvents {
+		if event.CreatedAt.After(changedAt[event.TransactionID]) {
+			changedAt[event.TransactionID] = event.CreatedAt
+		}
+	}
+
+	transactions := []*models.Transaction{}
+	for _, transaction := range rowsOf(r.s.transactions, func(t *models.Transaction) bool {
+		changed := changedAt[t.ID]
+		return t.ID > afterID && !changed.Before(from) && changed.Before(to)
+	}) {
+		if len(transactions) == limit {
+			break
+		}
+		transactions = append(transactions, transactionRow(transaction))
+	}
+
+	return transactions, nil
+}
+
+// GetActivity gets up to limit transactions of an account dated before a position, newest first
+func (r *TransactionRepo) GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.Transaction, error) {
+	transactions, err := r.newestFirst(ctx, func(t *models.Transaction) bool {
+		return touches(t, accountID) && before.Includes(t.TransactionDate, t.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first; the reverse ID order breaks ties
+	sort.SliceStable(transactions, func(i, j int) bool {
+		if transactions[i].TransactionDate.Equal(transactions[j].TransactionDate) {
+			return transactions[i].ID > transactions[j].ID
+		}
+		return transactions[i].TransactionDate.After(transactions[j].TransactionDate)
+	})
+
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+
+	return transactions, nil
+}
+
+// Search finds a page of the transactions of the accounts that match a search, newest first, with
+// the total number and the facets of all matching transactions. Every word of the query must
+// appear in the description, ignoring case.
+func (r *TransactionRepo) Search(ctx context.Context, accountIDs []int, search *models.TransactionSearch) (*models.TransactionSearchResult, error) {
+	matches, err := r.newestFirst(ctx, func(t *models.Transaction) bool {
+		for _, accountID := range accountIDs {
+			if touches(t, accountID) {
+				return search.Matches(t)
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transactions of the same date come newest ID first, as the database orders them
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].TransactionDate.Equal(matches[j].TransactionDate) {
+			return matches[i].TransactionDate.After(matches[j].TransactionDate)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+
+	result := &models.TransactionSearchResult{
+		Transactions: []*models.Transaction{},
+		Total:        len(matches),
+		Facets:       models.NewTransactionFacets(),
+		Source:       models.SearchSourceDatabase,
+		Limit:        search.Limit,
+		Offset:       search.Offset,
+	}
+	for i, transaction := range matches {
+		result.Facets.Type[string(transaction.TransactionType)]++
+		result.Facets.Status[string(transaction.Status)]++
+		result.Facets.Currency[string(transaction.Currency)]++
+		if i >= search.Offset && i < search.Offset+search.Limit {
+			result.Transactions = append(result.Transactions, transaction)
+		}
+	}
+
+	return result, nil
+}
+
+// CountCurrencyMismatches counts the transactions recorded in a currency other than their account's
+func (r *TransactionRepo) CountCurrencyMismatches(ctx context.Context) (int, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	count := 0
+	for _, transaction := range r.s.transactions {
+		if _, ok := r.s.mismatchedCurrency(transaction); ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// mismatchedCurrency returns the account currency of a transaction recorded in another currency.
+// Transfers only move money between accounts of the same currency, so the source account, or the
+// destination account if there is none, decides.
+func (s *Store) mismatchedCurrency(transaction *models.Transaction) (models.Currency, bool) {
+	id := transaction.SourceAccountID
+	if id == nil {
+		id = transaction.DestinationAccountID
+	}
+	if id == nil {
+		return "", false
+	}
+
+	account, ok := s.accounts[*id]
+	if !ok || account.Currency == transaction.Currency {
+		return "", false
+	}
+	return account.Currency, true
+}
+
+// createTransaction inserts a transaction after the checks the database and the locked period
+// query make
+func (s *Store) createTransaction(transaction *models.Transaction) (int, error) {
+	if err := s.checkTransaction(transaction); err != nil {
+		return 0, err
+	}
+
+	return s.insertTransaction(transaction), nil
+}
+
+// checkTransaction makes the checks of the locked period query and the table constraints
+func (s *Store) checkTransaction(transaction *models.Transaction) error {
+	if s.locked(transaction) {
+		return errors.New("transaction date falls in a locked statement period")
+	}
+
+	if transaction.Amount <= 0 {
+		return fmt.Errorf("failed to create transaction: amount must be positive")
+	}
+	for _, id := range []*int{transaction.SourceAccountID, transaction.DestinationAccountID} {
+		if id == nil {
+			continue
+		}
+		if _, ok := s.accounts[*id]; !ok {
+			return fmt.Errorf("failed to create transaction: %w", errNotExist("account", *id))
+		}
+	}
+	if transaction.CardID != nil {
+		if _, ok := s.cards[*transaction.CardID]; !ok {
+			return fmt.Errorf("failed to create transaction: %w", errNotExist("card", *transaction.CardID))
+		}
+	}
+
+	return nil
+}
+
+// insertTransaction stores a checked transaction and returns its ID
+func (s *Store) insertTransaction(transaction *models.Transaction) int {
+	row := transactionRow(transaction)
+	row.ID = s.nextID("transactions")
+	row.Reference = newUUID()
+	row.CreatedAt = time.Now()
+	transaction.Reference = row.Reference
+	s.transactions[row.ID] = row
+	s.recordTransactionEvent(row.ID)
+
+	return row.ID
+}
+
+// locked reports whether the source or destination account of a transaction has a statement
+// covering its date
+func (s *Store) locked(transaction *models.Transaction) bool {
+	for _, id := range []*int{transaction.SourceAccountID, transaction.DestinationAccountID} {
+		if id == nil {
+			continue
+		}
+		if account, ok := s.accounts[*id]; ok && account.LockedUntil != nil && account.LockedUntil.After(transaction.TransactionDate) {
+			return true
+		}
+	}
+	return false
+}
+
+// touchesUser reports whether a transaction moves money from or to a personal account of the user
+func (s *Store) touchesUser(transaction *models.Transaction, userID int) bool {
+	for _, id := range []*int{transaction.SourceAccountID, transaction.DestinationAccountID} {
+		if id == nil {
+			continue
+		}
+		if account, ok := s.accounts[*id]; ok && account.UserID == userID && account.OrganizationID == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// transactionRow copies a transaction together with its optional references
+func transactionRow(transaction *models.Transaction) *models.Transaction {
+	t := clone(transaction)
+	t.SourceAccountID = intPtr(transaction.SourceAccountID)
+	t.DestinationAccountID = intPtr(transaction.DestinationAccountID)
+	t.CardID = intPtr(transaction.CardID)
+	t.OriginalDescription = nil
+	return t
+}
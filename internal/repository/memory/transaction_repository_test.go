@@ -0,0 +1,144 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// transactionFixture is a user with two accounts and transactions between them and another user
+type transactionFixture struct {
+	transactions *TransactionRepo
+	userID       int
+	accountIDs   []int
+	internalID   int // the transfer between the two accounts of the user
+}
+
+// newTransactionFixture creates five transactions dated at the same time, so only their IDs order them
+func newTransactionFixture(t *testing.T) *transactionFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	s := NewStore()
+	users := NewUserRepository(s)
+	accounts := NewAccountRepository(s, models.AccountNumberScheme{})
+	f := &transactionFixture{transactions: NewTransactionRepository(s)}
+
+	var err error
+	if f.userID, err = users.Create(ctx, &models.User{Username: "owner", Email: "owner@example.com"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	otherID, err := users.Create(ctx, &models.User{Username: "other", Email: "other@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	var otherAccountID int
+	for i, userID := range []int{f.userID, f.userID, otherID} {
+		id, err := accounts.Create(ctx, &models.Account{UserID: userID, AccountNumber: fmt.Sprintf("4081781000000000000%d", i),
+			AccountType: models.AccountTypeChecking, Currency: models.CurrencyRUB, Balance: 1000, IsActive: true})
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		if userID == f.userID {
+			f.accountIDs = append(f.accountIDs, id)
+		} else {
+			otherAccountID = id
+		}
+	}
+
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	transfers := [][2]int{
+		{f.accountIDs[0], otherAccountID},
+		{f.accountIDs[0], f.accountIDs[1]},
+		{otherAccountID, f.accountIDs[1]},
+		{f.accountIDs[1], otherAccountID},
+		{otherAccountID, f.accountIDs[0]},
+	}
+	for i, transfer := range transfers {
+		source, destination := transfer[0], transfer[1]
+		id, err := f.transactions.Create(ctx, &models.Transaction{
+			TransactionType:      models.TransactionTypeTransfer,
+			SourceAccountID:      &source,
+			DestinationAccountID: &destination,
+			Amount:               float64(10 * (i + 1)),
+			Currency:             models.CurrencyRUB,
+			Description:          "transfer",
+			Status:               models.TransactionStatusCompleted,
+			TransactionDate:      date,
+		})
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		if i == 1 {
+			f.internalID = id
+		}
+	}
+
+	return f
+}
+
+func TestGetByUserIDReturnsInternalTransferOnce(t *testing.T) {
+	f := newTransactionFixture(t)
+
+	transactions, err := f.transactions.GetByUserID(context.Background(), f.userID)
+	if err != nil {
+		t.Fatalf("failed to get transactions: %v", err)
+	}
+
+	if len(transactions) != 5 {
+		t.Fatalf("expected 5 transactions, got %d", len(transactions))
+	}
+
+	seen := 0
+	for i, transaction := range transactions {
+		if transaction.ID == f.internalID {
+			seen++
+		}
+		// Transactions of the same date come newest ID first
+		if i > 0 && transaction.ID > transactions[i-1].ID {
+			t.Errorf("transaction %d comes after %d", transaction.ID, transactions[i-1].ID)
+		}
+	}
+	if seen != 1 {
+		t.Errorf("expected the internal transfer once, got it %d times", seen)
+	}
+}
+
+func TestSearchPagesAreStable(t *testing.T) {
+	ctx := context.Background()
+	f := newTransactionFixture(t)
+
+	all, err := f.transactions.GetByUserID(ctx, f.userID)
+	if err != nil {
+		t.Fatalf("failed to get transactions: %v", err)
+	}
+
+	// Paging through the user's accounts two at a time returns every transaction once, in the
+	// order of the full list, however often it is repeated
+	for run := 0; run < 3; run++ {
+		var paged []*models.Transaction
+		for offset := 0; offset < len(all)+2; offset += 2 {
+			result, err := f.transactions.Search(ctx, f.accountIDs, &models.TransactionSearch{Limit: 2, Offset: offset})
+			if err != nil {
+				t.Fatalf("failed to search transactions: %v", err)
+			}
+			if result.Total != len(all) {
+				t.Errorf("expected a total of %d, got %d", len(all), result.Total)
+			}
+			paged = append(paged, result.Transactions...)
+		}
+
+		if len(paged) != len(all) {
+			t.Fatalf("expected %d transactions over all pages, got %d", len(all), len(paged))
+		}
+		for i := range all {
+			if paged[i].ID != all[i].ID {
+				t.Errorf("run %d: expected transaction %d at position %d, got %d", run, all[i].ID, i, paged[i].ID)
+			}
+		}
+	}
+}
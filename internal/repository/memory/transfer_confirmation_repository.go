@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TransferConfirmationRepo is an in-memory implementation of the repository.TransferConfirmationRepository interface
+type TransferConfirmationRepo struct {
+	s *Store
+}
+
+// NewTransferConfirmationRepository creates a new TransferConfirmationRepo
+func NewTransferConfirmationRepository(s *Store) *TransferConfirmationRepo {
+	return &TransferConfirmationRepo{s: s}
+}
+
+// Create creates a new transfer confirmation
+func (r *TransferConfirmationRepo) Create(ctx context.Context, confirmation *models.TransferConfirmation) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[confirmation.UserID]; !ok {
+		return 0, fmt.Errorf("failed to create transfer confirmation: %w", errNotExist("user", confirmation.UserID))
+	}
+	for _, id := range []int{confirmation.SourceAccountID, confirmation.DestinationAccountID} {
+		if _, ok := r.s.accounts[id]; !ok {
+			return 0, fmt.Errorf("failed to create transfer confirmation: %w", errNotExist("account", id))
+		}
+	}
+	for _, other := range r.s.confirmations {
+		if other.ConfirmationID == confirmation.ConfirmationID {
+			return 0, fmt.Errorf("failed to create transfer confirmation: %w", errDuplicate("confirmation ID"))
+		}
+	}
+
+	row := clone(confirmation)
+	row.ID = r.s.nextID("transfer_confirmations")
+	row.Attempts = 0
+	row.InvoiceID = intPtr(confirmation.InvoiceID)
+	row.TransactionID = nil
+	row.CreatedAt = time.Now()
+	r.s.confirmations[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByConfirmationID gets a transfer confirmation by its public ID
+func (r *TransferConfirmationRepo) GetByConfirmationID(ctx context.Context, confirmationID string) (*models.TransferConfirmation, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, confirmation := range r.s.confirmations {
+		if confirmation.ConfirmationID == confirmationID {
+			row := clone(confirmation)
+			row.InvoiceID = intPtr(confirmation.InvoiceID)
+			row.TransactionID = intPtr(confirmation.TransactionID)
+			return row, nil
+		}
+	}
+
+	return nil, fmt.Errorf("transfer confirmation not found: %w", sql.ErrNoRows)
+}
+
+// IncrementAttempts records a failed code check and returns the new number of attempts
+func (r *TransferConfirmationRepo) IncrementAttempts(ctx context.Context, id int) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	confirmation, ok := r.s.confirmations[id]
+	if !ok {
+		return 0, fmt.Errorf("failed to update attempts: %w", sql.ErrNoRows)
+	}
+
+	confirmation.Attempts++
+
+	return confirmation.Attempts, nil
+}
+
+// UpdateStatus moves a confirmation from one status to another. It reports false if the
+// confirmation was not in the expected status, so a code can only be used once.
+func (r *TransferConfirmationRepo) UpdateStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	confirmation, ok := r.s.confirmations[id]
+	if !ok || confirmation.Status != from {
+		return false, nil
+	}
+
+	confirmation.Status = to
+
+	return true, nil
+}
+
+// SetTransaction links the executed transaction to the confirmation
+func (r *TransferConfirmationRepo) SetTransaction(ctx context.Context, id int, transactionID int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.transactions[transactionID]; !ok {
+		return fmt.Errorf("failed to link transaction: %w", errNotExist("transaction", transactionID))
+	}
+	if confirmation, ok := r.s.confirmations[id]; ok {
+		confirmation.TransactionID = &transactionID
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// UserRepo is an in-memory implementation of the repository.UserRepository interface
+type UserRepo struct {
+	s *Store
+}
+
+// NewUserRepository creates a new UserRepo
+func NewUserRepository(s *Store) *UserRepo {
+	return &UserRepo{s: s}
+}
+
+// Create creates a new user
+func (r *UserRepo) Create(ctx context.Context, user *models.User) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user.Tenant = newRecordTenant(ctx, user.Tenant)
+	if err := r.checkUnique(user, 0); err != nil {
+		return 0, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	row := userRow(user)
+	row.ID = r.s.nextID("users")
+	row.Password = ""
+	row.CreatedAt = time.Now()
+	row.UpdatedAt = row.CreatedAt
+	r.s.users[row.ID] = row
+
+	return row.ID, nil
+}
+
+// GetByID gets a user by ID
+func (r *UserRepo) GetByID(ctx context.Context, id int) (*models.User, error) {
+	return r.find(ctx, func(u *models.User) bool { return u.ID == id })
+}
+
+// GetByUsername gets a user by username
+func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.find(ctx, func(u *models.User) bool { return u.Username == username })
+}
+
+// GetByEmail gets a user by email
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return r.find(ctx, func(u *models.User) bool { return u.Email == email })
+}
+
+// find gets the first user of the request's tenant that matches
+func (r *UserRepo) find(ctx context.Context, match func(*models.User) bool) (*models.User, error) {
+	r.s.mu.RLock()
+	defer r.s.mu.RUnlock()
+
+	for _, user := range r.s.users {
+		if match(user) && inTenant(ctx, user.Tenant) {
+			return userRow(user), nil
+		}
+	}
+
+	return nil, fmt.Errorf("user not found: %w", sql.ErrNoRows)
+}
+
+// Update updates a user
+func (r *UserRepo) Update(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users[user.ID]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	updated := clone(row)
+	updated.Username, updated.Email = user.Username, user.Email
+	if err := r.checkUnique(updated, row.ID); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	row.Username = user.Username
+	row.Email = user.Email
+	row.FirstName = user.FirstName
+	row.LastName = user.LastName
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateProfile updates the personal details of a user: the name, phone, date of birth and address
+func (r *UserRepo) UpdateProfile(ctx context.Context, user *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users[user.ID]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	updated := userRow(user)
+	row.FirstName = updated.FirstName
+	row.LastName = updated.LastName
+	row.Phone = updated.Phone
+	row.BirthDate = updated.BirthDate
+	row.Address = updated.Address
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdatePassword updates the password hash of a user
+func (r *UserRepo) UpdatePassword(ctx context.Context, id int, passHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	row.PassHash = passHash
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateTimezone updates the time zone of a user
+func (r *UserRepo) UpdateTimezone(ctx context.Context, id int, timezone string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	row.Timezone = timezone
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// UpdateLanguage updates the language of a user
+func (r *UserRepo) UpdateLanguage(ctx context.Context, id int, language string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.users[id]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	row.Language = language
+	row.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Delete deletes a user by ID. Like the foreign keys in PostgreSQL, it refuses to delete a user
+// other records still refer to; referral codes and bill templates are deleted with the user.
+func (r *UserRepo) Delete(ctx context.Context, id int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, ok := r.s.users[id]; !ok {
+		return fmt.Errorf("user not found")
+	}
+
+	if r.referenced(id) {
+		return fmt.Errorf("failed to delete user: user %d is still referenced", id)
+	}
+
+	delete(r.s.users, id)
+	delete(r.s.referralCodes, id)
+	for templateID, template := range r.s.billTemplates {
+		if template.UserID == id {
+			delete(r.s.billTemplates, templateID)
+		}
+	}
+	for key := range r.s.accountSettings {
+		if key.userID == id {
+			delete(r.s.accountSettings, key)
+		}
+	}
+
+	return nil
+}
+
+// referenced reports whether any record refers to the user
+func (r *UserRepo) referenced(id int) bool {
+	for _, account := range r.s.accounts {
+		if account.UserID == id {
+			return true
+		}
+	}
+	for _, credit := range r.s.credits {
+		if credit.UserID == id {
+			return true
+		}
+	}
+	for _, session := range r.s.sessions {
+		if session.UserID == id {
+			return true
+		}
+	}
+	for _, device := range r.s.devices {
+		if device.UserID == id {
+			return true
+		}
+	}
+	for _, notification := range r.s.notifications {
+		if notification.UserID == id {
+			return true
+		}
+	}
+	for _, member := range r.s.members {
+		if member.UserID == id {
+			return true
+		}
+	}
+	for _, organization := range r.s.organizations {
+		if organization.CreatedBy == id {
+			return true
+		}
+	}
+	for _, merchant := range r.s.merchants {
+		if merchant.UserID == id {
+			return true
+		}
+	}
+	for _, referral := range r.s.referrals {
+		if referral.ReferrerID == id || referral.RefereeID == id {
+			return true
+		}
+	}
+	for _, application := range r.s.applications {
+		if application.UserID == id {
+			return true
+		}
+	}
+	for _, thread := range r.s.threads {
+		if thread.UserID == id {
+			return true
+		}
+	}
+	for _, transfer := range r.s.ownershipTransfers {
+		if transfer.FromUserID == id || transfer.ToUserID == id || transfer.RequestedBy == id {
+			return true
+		}
+	}
+	for _, delegation := range r.s.delegations {
+		if delegation.OwnerID == id || delegation.DelegateID == id {
+			return true
+		}
+	}
+	for _, payroll := range r.s.payrolls {
+		if payroll.UploadedBy == id || (payroll.ExecutedBy != nil && *payroll.ExecutedBy == id) {
+			return true
+		}
+	}
+	for _, escrow := range r.s.escrows {
+		if escrow.BuyerID == id || escrow.SellerID == id || (escrow.ResolvedBy != nil && *escrow.ResolvedBy == id) {
+			return true
+		}
+	}
+	for _, invoice := range r.s.invoices {
+		if invoice.IssuerID == id || invoice.RecipientID == id {
+			return true
+		}
+	}
+	for _, subscription := range r.s.subscriptions {
+		if subscription.UserID == id {
+			return true
+		}
+	}
+	for _, deposit := range r.s.chequeDeposits {
+		if deposit.UserID == id || (deposit.ReviewedBy != nil && *deposit.ReviewedBy == id) {
+			return true
+		}
+	}
+	for _, transfer := range r.s.intlTransfers {
+		if transfer.UserID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userRow copies a user
+func userRow(user *models.User) *models.User {
+	u := clone(user)
+	if user.BirthDate != nil {
+		u.BirthDate = timePtr(*user.BirthDate)
+	}
+	return u
+}
+
+// checkUnique rejects a username or email already taken in the user's tenant by another user
+func (r *UserRepo) checkUnique(user *models.User, exceptID int) error {
+	for _, other := range r.s.users {
+		if other.ID == exceptID || other.Tenant != user.Tenant {
+			continue
+		}
+		if other.Username == user.Username {
+			return errDuplicate("username")
+		}
+		if other.Email == user.Email {
+			return errDuplicate("email")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// accountDelegationColumns lists the columns read by scanAccountDelegation
+const accountDelegationColumns = `SELECT d.id, d.account_id, a.account_number, d.owner_id, d.delegate_id, u.email, d.scope,
+             d.transfer_limit, d.expires_at, d.revoked_at, d.revoked_by, d.created_at
+             FROM account_delegations d
+             JOIN accounts a ON a.id = d.account_id
+             JOIN users u ON u.id = d.delegate_id`
+
+// AccountDelegationRepo is a PostgreSQL implementation of the repository.AccountDelegationRepository interface
+type AccountDelegationRepo struct {
+	db *sql.DB
+}
+
+// NewAccountDelegationRepository creates a new AccountDelegationRepo
+func NewAccountDelegationRepository(db *sql.DB) *AccountDelegationRepo {
+	return &AccountDelegationRepo{db: db}
+}
+
+// GetByID gets a delegation by ID
+func (r *AccountDelegationRepo) GetByID(ctx context.Context, id int) (*models.AccountDelegation, error) {
+	query := accountDelegationColumns + ` WHERE d.id = $1`
+
+	delegation, err := scanAccountDelegation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("delegation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get delegation: %w", err)
+	}
+
+	return delegation, nil
+}
+
+// GetByOwnerID gets the delegations an account owner granted, newest first
+func (r *AccountDelegationRepo) GetByOwnerID(ctx context.Context, ownerID int) ([]*models.AccountDelegation, error) {
+	query := accountDelegationColumns + ` WHERE d.owner_id = $1 ORDER BY d.created_at DESC, d.id DESC`
+
+	return r.query(ctx, query, ownerID)
+}
+
+// GetByDelegateID gets the delegations granted to a user, newest first
+func (r *AccountDelegationRepo) GetByDelegateID(ctx context.Context, delegateID int) ([]*models.AccountDelegation, error) {
+	query := accountDelegationColumns + ` WHERE d.delegate_id = $1 ORDER BY d.created_at DESC, d.id DESC`
+
+	return r.query(ctx, query, delegateID)
+}
+
+// GetActive gets the unrevoked, unexpired delegation of an account to a user. The account must still
+// belong to the owner who granted it.
+func (r *AccountDelegationRepo) GetActive(ctx context.Context, accountID int, delegateID int) (*models.AccountDelegation, error) {
+	query := accountDelegationColumns + `
+             WHERE d.account_id = $1 AND d.delegate_id = $2 AND d.revoked_at IS NULL
+             AND d.expires_at > CURRENT_TIMESTAMP AND a.user_id = d.owner_id
+             ORDER BY d.id DESC LIMIT 1`
+
+	delegation, err := scanAccountDelegation(r.db.QueryRowContext(ctx, query, accountID, delegateID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("delegation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get delegation: %w", err)
+	}
+
+	return delegation, nil
+}
+
+// GetEvents gets the audit log of a delegation, oldest first
+func (r *AccountDelegationRepo) GetEvents(ctx context.Context, delegationID int) ([]*models.DelegationEvent, error) {
+	query := `SELECT id, delegation_id, account_id, actor_id, action, transaction_id, amount, request_id, details, created_at
+             FROM account_delegation_events
+             WHERE delegation_id = $1
+             ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, delegationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegation events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.DelegationEvent{}
+	for rows.Next() {
+		event := &models.DelegationEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.DelegationID,
+			&event.AccountID,
+			&event.ActorID,
+			&event.Action,
+			&event.TransactionID,
+			&event.Amount,
+			&event.RequestID,
+			&event.Details,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan delegation event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating delegation events: %w", err)
+	}
+
+	return events, nil
+}
+
+// AddEvent records an entry of the audit log of a delegation
+func (r *AccountDelegationRepo) AddEvent(ctx context.Context, event *models.DelegationEvent) (int, error) {
+	return addDelegationEvent(ctx, r.db, event)
+}
+
+// AddEventTx records an entry of the audit log of a delegation within an existing transaction
+func (r *AccountDelegationRepo) AddEventTx(ctx context.Context, tx *sql.Tx, event *models.DelegationEvent) (int, error) {
+	return addDelegationEvent(ctx, tx, event)
+}
+
+// SumTransferred sums the transfers made under a delegation
+func (r *AccountDelegationRepo) SumTransferred(ctx context.Context, delegationID int) (float64, error) {
+	return sumDelegatedTransfers(ctx, r.db, delegationID)
+}
+
+// SumTransferredTx sums the transfers made under a delegation within an existing transaction
+func (r *AccountDelegationRepo) SumTransferredTx(ctx context.Context, tx *sql.Tx, delegationID int) (float64, error) {
+	return sumDelegatedTransfers(ctx, tx, delegationID)
+}
+
+// CreateTx creates a new delegation within an existing transaction
+func (r *AccountDelegationRepo) CreateTx(ctx context.Context, tx *sql.Tx, delegation *models.AccountDelegation) (int, error) {
+	query := `INSERT INTO account_delegations (account_id, owner_id, delegate_id, scope, transfer_limit, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		delegation.AccountID,
+		delegation.OwnerID,
+		delegation.DelegateID,
+		delegation.Scope,
+		delegation.TransferLimit,
+		delegation.ExpiresAt,
+	).Scan(&delegation.ID, &delegation.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create delegation: %w", err)
+	}
+
+	return delegation.ID, nil
+}
+
+// RevokeTx revokes a delegation within an existing transaction. It returns false if the
+// delegation was already revoked.
+func (r *AccountDelegationRepo) RevokeTx(ctx context.Context, tx *sql.Tx, id int, revokedBy int) (bool, error) {
+	query := `UPDATE account_delegations SET revoked_at = CURRENT_TIMESTAMP, revoked_by = $1
+             WHERE id = $2 AND revoked_at IS NULL`
+
+	result, err := tx.ExecContext(ctx, query, revokedBy, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke delegation: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// RevokeByAccountTx revokes the unexpired delegations of an account within an existing
+// transaction and returns their IDs
+func (r *AccountDelegationRepo) RevokeByAccountTx(ctx context.Context, tx *sql.Tx, accountID int, revokedBy int) ([]int, error) {
+	query := `UPDATE account_delegations SET revoked_at = CURRENT_TIMESTAMP, revoked_by = $1
+             WHERE account_id = $2 AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+             RETURNING id`
+
+	rows, err := tx.QueryContext(ctx, query, revokedBy, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke delegations: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan delegation: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// query runs a query returning delegations
+func (r *AccountDelegationRepo) query(ctx context.Context, query string, args ...interface{}) ([]*models.AccountDelegation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delegations: %w", err)
+	}
+	defer rows.Close()
+
+	delegations := []*models.AccountDelegation{}
+	for rows.Next() {
+		delegation, err := scanAccountDelegation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delegation: %w", err)
+		}
+		delegations = append(delegations, delegation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return delegations, nil
+}
+
+// rowQuerier runs a query returning one row, on the database or within a transaction
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// addDelegationEvent inserts an entry of the audit log of a delegation
+func addDelegationEvent(ctx context.Context, db rowQuerier, event *models.DelegationEvent) (int, error) {
+	query := `INSERT INTO account_delegation_events (delegation_id, account_id, actor_id, action, transaction_id, amount, request_id, details)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	err := db.QueryRowContext(
+		ctx,
+		query,
+		event.DelegationID,
+		event.AccountID,
+		event.ActorID,
+		event.Action,
+		event.TransactionID,
+		event.Amount,
+		event.RequestID,
+		event.Details,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create delegation event: %w", err)
+	}
+
+	return event.ID, nil
+}
+
+// sumDelegatedTransfers sums the amounts of the TRANSFERRED entries of the audit log of a delegation
+func sumDelegatedTransfers(ctx context.Context, db rowQuerier, delegationID int) (float64, error) {
+	query := `SELECT COALESCE(SUM(amount), 0) FROM account_delegation_events
+             WHERE delegation_id = $1 AND action = $2`
+
+	var total float64
+	if err := db.QueryRowContext(ctx, query, delegationID, models.DelegationActionTransferred).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum delegated transfers: %w", err)
+	}
+
+	return total, nil
+}
+
+// scanAccountDelegation scans a single delegation row
+func scanAccountDelegation(row interface{ Scan(...interface{}) error }) (*models.AccountDelegation, error) {
+	delegation := &models.AccountDelegation{}
+	err := row.Scan(
+		&delegation.ID,
+		&delegation.AccountID,
+		&delegation.AccountNumber,
+		&delegation.OwnerID,
+		&delegation.DelegateID,
+		&delegation.DelegateEmail,
+		&delegation.Scope,
+		&delegation.TransferLimit,
+		&delegation.ExpiresAt,
+		&delegation.RevokedAt,
+		&delegation.RevokedBy,
+		&delegation.CreatedAt,
+	)
+	return delegation, err
+}
@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// AccountEventRepo is a PostgreSQL implementation of the repository.AccountEventRepository
+// interface. The record_account_event and record_card_event triggers write the account and card
+// events; ApprovalPolicyRepo.Replace writes the limit changes.
+type AccountEventRepo struct {
+	db *sql.DB
+}
+
+// NewAccountEventRepository creates a new AccountEventRepo
+func NewAccountEventRepository(db *sql.DB) *AccountEventRepo {
+	return &AccountEventRepo{db: db}
+}
+
+// GetActivity gets up to limit events of an account before a position, newest first
+func (r *AccountEventRepo) GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.AccountEvent, error) {
+	query := `SELECT id, account_id, event_type, card_id, limits, created_at FROM account_events
+             WHERE account_id = $1
+             AND ($2::timestamptz IS NULL OR created_at < $2 OR (created_at = $2 AND id < $3))
+             ORDER BY created_at DESC, id DESC
+             LIMIT $4`
+
+	var at interface{}
+	var id int
+	if before != nil {
+		at, id = before.At, before.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, at, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.AccountEvent{}
+	for rows.Next() {
+		event := &models.AccountEvent{}
+		var cardID sql.NullInt32
+		var limits []byte
+		if err := rows.Scan(&event.ID, &event.AccountID, &event.Type, &cardID, &limits, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account event: %w", err)
+		}
+
+		if cardID.Valid {
+			id := int(cardID.Int32)
+			event.CardID = &id
+		}
+
+		if limits != nil {
+			if err := json.Unmarshal(limits, &event.Limits); err != nil {
+				return nil, fmt.Errorf("failed to parse the limits of account event %d: %w", event.ID, err)
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"banking-service/internal/models"
+)
+
+// activeHolds sums the unexpired active holds of the account in the enclosing query
+const activeHolds = `COALESCE((SELECT SUM(h.amount) FROM account_holds h
+             WHERE h.account_id = accounts.id AND h.status = 'ACTIVE'
+             AND (h.expires_at IS NULL OR h.expires_at > CURRENT_TIMESTAMP)), 0)`
+
+// AccountHoldRepo is a PostgreSQL implementation of the repository.AccountHoldRepository interface
+type AccountHoldRepo struct {
+	db *sql.DB
+}
+
+// NewAccountHoldRepository creates a new AccountHoldRepo
+func NewAccountHoldRepository(db *sql.DB) *AccountHoldRepo {
+	return &AccountHoldRepo{db: db}
+}
+
+// Create places a hold if the account's available balance covers it. It returns 0 without
+// an error if the operation already has an active hold.
+func (r *AccountHoldRepo) Create(ctx context.Context, hold *models.AccountHold) (id int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if id, err = r.CreateTx(ctx, tx, hold); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return id, nil
+}
+
+// CreateTx places a hold within an existing transaction if the account's available balance covers
+// it. It returns 0 without an error if the operation already has an active hold.
+func (r *AccountHoldRepo) CreateTx(ctx context.Context, tx *sql.Tx, hold *models.AccountHold) (int, error) {
+	// Lock the account so concurrent debits and holds see each other
+	query := `SELECT balance - ` + activeHolds + ` FROM accounts WHERE id = $1 FOR UPDATE`
+
+	var available float64
+	if err := tx.QueryRowContext(ctx, query, hold.AccountID).Scan(&available); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("account not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to get available balance: %w", err)
+	}
+
+	if available < hold.Amount {
+		return 0, errors.New("insufficient funds")
+	}
+
+	query = `INSERT INTO account_holds (account_id, amount, reason, reference_id, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6)
+             ON CONFLICT (reason, reference_id) WHERE status = 'ACTIVE' DO NOTHING
+             RETURNING id, created_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		hold.AccountID,
+		hold.Amount,
+		hold.Reason,
+		hold.ReferenceID,
+		models.HoldStatusActive,
+		hold.ExpiresAt,
+	).Scan(&hold.ID, &hold.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create account hold: %w", err)
+	}
+
+	hold.Status = models.HoldStatusActive
+
+	return hold.ID, nil
+}
+
+// GetActiveByAccountID gets the unexpired active holds of an account, newest first
+func (r *AccountHoldRepo) GetActiveByAccountID(ctx context.Context, accountID int) ([]*models.AccountHold, error) {
+	query := `SELECT id, account_id, amount, reason, reference_id, status, expires_at, released_at, created_at
+             FROM account_holds
+             WHERE account_id = $1 AND status = $2 AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+             ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID, models.HoldStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []*models.AccountHold{}
+	for rows.Next() {
+		hold := &models.AccountHold{}
+		err := rows.Scan(
+			&hold.ID,
+			&hold.AccountID,
+			&hold.Amount,
+			&hold.Reason,
+			&hold.ReferenceID,
+			&hold.Status,
+			&hold.ExpiresAt,
+			&hold.ReleasedAt,
+			&hold.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account hold: %w", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return holds, nil
+}
+
+// Release ends the active hold of an operation with the given status; it reports false if there was none
+func (r *AccountHoldRepo) Release(ctx context.Context, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error) {
+	query := `UPDATE account_holds SET status = $1, released_at = CURRENT_TIMESTAMP
+             WHERE reason = $2 AND reference_id = $3 AND status = $4`
+
+	result, err := r.db.ExecContext(ctx, query, to, reason, referenceID, models.HoldStatusActive)
+	if err != nil {
+		return false, fmt.Errorf("failed to release account hold: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ReleaseTx ends the active hold of an operation with the given status within a transaction
+func (r *AccountHoldRepo) ReleaseTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error) {
+	query := `UPDATE account_holds SET status = $1, released_at = CURRENT_TIMESTAMP
+             WHERE reason = $2 AND reference_id = $3 AND status = $4`
+
+	result, err := tx.ExecContext(ctx, query, to, reason, referenceID, models.HoldStatusActive)
+	if err != nil {
+		return false, fmt.Errorf("failed to release account hold: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ReleaseBatchTx ends the active holds of several operations with the given status with one
+// statement within a transaction and returns how many holds it ended
+func (r *AccountHoldRepo) ReleaseBatchTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceIDs []int, to models.HoldStatus) (int64, error) {
+	if len(referenceIDs) == 0 {
+		return 0, nil
+	}
+
+	placeholders := make([]string, 0, len(referenceIDs))
+	args := []interface{}{to, reason, models.HoldStatusActive}
+	for _, id := range referenceIDs {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`UPDATE account_holds SET status = $1, released_at = CURRENT_TIMESTAMP
+             WHERE reason = $2 AND status = $3 AND reference_id IN (%s)`, strings.Join(placeholders, ", "))
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release account holds: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// ownershipTransferColumns lists the columns read by scanOwnershipTransfer
+const ownershipTransferColumns = `SELECT id, account_id, from_user_id, to_user_id, reason, note, documents, status,
+             requested_by, reviewed_by, review_note, reviewed_at, created_at, updated_at
+             FROM account_ownership_transfers`
+
+// AccountOwnershipRepo is a PostgreSQL implementation of the repository.AccountOwnershipRepository interface
+type AccountOwnershipRepo struct {
+	db *sql.DB
+}
+
+// NewAccountOwnershipRepository creates a new AccountOwnershipRepo
+func NewAccountOwnershipRepository(db *sql.DB) *AccountOwnershipRepo {
+	return &AccountOwnershipRepo{db: db}
+}
+
+// GetByID gets an ownership transfer of the request's tenant, that of its account, by ID
+func (r *AccountOwnershipRepo) GetByID(ctx context.Context, id int) (*models.OwnershipTransfer, error) {
+	query := ownershipTransferColumns + ` WHERE id = $1 AND ` + accountInTenant("account_id", "$2")
+
+	transfer, err := scanOwnershipTransfer(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("ownership transfer not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get ownership transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// GetByAccountID gets the ownership transfers of an account, newest first
+func (r *AccountOwnershipRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.OwnershipTransfer, error) {
+	query := ownershipTransferColumns + ` WHERE account_id = $1 ORDER BY created_at DESC, id DESC`
+
+	return r.query(ctx, query, accountID)
+}
+
+// GetByStatus gets the ownership transfers of the request's tenant with a status, oldest first; an
+// empty status returns all
+func (r *AccountOwnershipRepo) GetByStatus(ctx context.Context, status models.OwnershipTransferStatus) ([]*models.OwnershipTransfer, error) {
+	query := ownershipTransferColumns + ` WHERE ($1 = '' OR status = $1) AND ` + accountInTenant("account_id", "$2") + ` ORDER BY created_at, id`
+
+	return r.query(ctx, query, status, requestTenant(ctx))
+}
+
+// GetEvents gets the audit trail of an ownership transfer, oldest first
+func (r *AccountOwnershipRepo) GetEvents(ctx context.Context, transferID int) ([]*models.OwnershipTransferEvent, error) {
+	query := `SELECT id, transfer_id, admin_id, action, note, details, created_at
+             FROM account_ownership_transfer_events
+             WHERE transfer_id = $1
+             ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership transfer events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.OwnershipTransferEvent{}
+	for rows.Next() {
+		event := &models.OwnershipTransferEvent{}
+		if err := rows.Scan(
+			&event.ID,
+			&event.TransferID,
+			&event.AdminID,
+			&event.Action,
+			&event.Note,
+			&event.Details,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan ownership transfer event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ownership transfer events: %w", err)
+	}
+
+	return events, nil
+}
+
+// CreateTx creates a new ownership transfer within an existing transaction
+func (r *AccountOwnershipRepo) CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.OwnershipTransfer) (int, error) {
+	documents, err := json.Marshal(transfer.Documents)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode ownership transfer documents: %w", err)
+	}
+
+	query := `INSERT INTO account_ownership_transfers (account_id, from_user_id, to_user_id, reason, note, documents, status, requested_by)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		transfer.AccountID,
+		transfer.FromUserID,
+		transfer.ToUserID,
+		transfer.Reason,
+		transfer.Note,
+		documents,
+		transfer.Status,
+		transfer.RequestedBy,
+	).Scan(&transfer.ID, &transfer.CreatedAt, &transfer.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create ownership transfer: %w", err)
+	}
+
+	return transfer.ID, nil
+}
+
+// UpdateStatusTx moves an ownership transfer from one status to another and records the review
+// within an existing transaction. It returns false if the transfer was not in the expected status.
+func (r *AccountOwnershipRepo) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.OwnershipTransferStatus, reviewedBy int, note string) (bool, error) {
+	query := `UPDATE account_ownership_transfers
+             SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = CURRENT_TIMESTAMP
+             WHERE id = $4 AND status = $5`
+
+	result, err := tx.ExecContext(ctx, query, to, reviewedBy, note, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update ownership transfer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// AddEventTx records a step of an ownership transfer within an existing transaction
+func (r *AccountOwnershipRepo) AddEventTx(ctx context.Context, tx *sql.Tx, event *models.OwnershipTransferEvent) (int, error) {
+	query := `INSERT INTO account_ownership_transfer_events (transfer_id, admin_id, action, note, details)
+             VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		event.TransferID,
+		event.AdminID,
+		event.Action,
+		event.Note,
+		event.Details,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create ownership transfer event: %w", err)
+	}
+
+	return event.ID, nil
+}
+
+// query runs a query returning ownership transfers
+func (r *AccountOwnershipRepo) query(ctx context.Context, query string, args ...interface{}) ([]*models.OwnershipTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []*models.OwnershipTransfer{}
+	for rows.Next() {
+		transfer, err := scanOwnershipTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ownership transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// scanOwnershipTransfer scans a single ownership transfer row
+func scanOwnershipTransfer(row interface{ Scan(...interface{}) error }) (*models.OwnershipTransfer, error) {
+	transfer := &models.OwnershipTransfer{}
+	var documents []byte
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.AccountID,
+		&transfer.FromUserID,
+		&transfer.ToUserID,
+		&transfer.Reason,
+		&transfer.Note,
+		&documents,
+		&transfer.Status,
+		&transfer.RequestedBy,
+		&transfer.ReviewedBy,
+		&transfer.ReviewNote,
+		&transfer.ReviewedAt,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(documents, &transfer.Documents); err != nil {
+		return nil, fmt.Errorf("failed to decode ownership transfer documents: %w", err)
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,301 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountPlanRepo is a PostgreSQL implementation of the repository.AccountPlanRepository interface
+type AccountPlanRepo struct {
+	db *sql.DB
+}
+
+// NewAccountPlanRepository creates a new AccountPlanRepo
+func NewAccountPlanRepository(db *sql.DB) *AccountPlanRepo {
+	return &AccountPlanRepo{db: db}
+}
+
+// accountPlanColumns lists the columns read by scanAccountPlan
+const accountPlanColumns = `id, code, name, account_types, monthly_fee, free_transfers, transfer_fee, interest_tiers,
+             day_count, is_active, tenant, created_at, updated_at`
+
+// accountPlanPeriodColumns lists the columns read by scanAccountPlanPeriod
+const accountPlanPeriodColumns = `id, account_id, plan_id, started_at, ended_at, billed_until`
+
+// Create saves a new account plan in the tenant of the request
+func (r *AccountPlanRepo) Create(ctx context.Context, plan *models.AccountPlan) (int, error) {
+	accountTypes, tiers, err := encodeAccountPlan(plan)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO account_plans (code, name, account_types, monthly_fee, free_transfers, transfer_fee, interest_tiers, day_count, is_active, tenant)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at`
+
+	plan.Tenant = newRecordTenant(ctx, plan.Tenant)
+
+	err = r.db.QueryRowContext(ctx, query,
+		plan.Code,
+		plan.Name,
+		accountTypes,
+		plan.MonthlyFee,
+		plan.FreeTransfers,
+		plan.TransferFee,
+		tiers,
+		plan.DayCount,
+		plan.IsActive,
+		plan.Tenant,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create account plan: %w", err)
+	}
+
+	return plan.ID, nil
+}
+
+// GetByID gets an account plan by ID
+func (r *AccountPlanRepo) GetByID(ctx context.Context, id int) (*models.AccountPlan, error) {
+	query := `SELECT ` + accountPlanColumns + ` FROM account_plans WHERE id = $1 AND ($2 = '' OR tenant = $2)`
+
+	plan, err := scanAccountPlan(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("account plan not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get account plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetAll gets all account plans of the request's tenant, including inactive ones
+func (r *AccountPlanRepo) GetAll(ctx context.Context) ([]*models.AccountPlan, error) {
+	query := `SELECT ` + accountPlanColumns + ` FROM account_plans WHERE $1 = '' OR tenant = $1 ORDER BY monthly_fee, name, id`
+
+	rows, err := r.db.QueryContext(ctx, query, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []*models.AccountPlan{}
+	for rows.Next() {
+		plan, err := scanAccountPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return plans, nil
+}
+
+// Update replaces the data of an account plan
+func (r *AccountPlanRepo) Update(ctx context.Context, plan *models.AccountPlan) error {
+	accountTypes, tiers, err := encodeAccountPlan(plan)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE account_plans
+             SET code = $1, name = $2, account_types = $3, monthly_fee = $4, free_transfers = $5,
+             transfer_fee = $6, interest_tiers = $7, day_count = $8, is_active = $9
+             WHERE id = $10 AND ($11 = '' OR tenant = $11)
+             RETURNING tenant, created_at, updated_at`
+
+	err = r.db.QueryRowContext(ctx, query,
+		plan.Code,
+		plan.Name,
+		accountTypes,
+		plan.MonthlyFee,
+		plan.FreeTransfers,
+		plan.TransferFee,
+		tiers,
+		plan.DayCount,
+		plan.IsActive,
+		plan.ID,
+		requestTenant(ctx),
+	).Scan(&plan.Tenant, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("account plan not found: %w", err)
+		}
+		return fmt.Errorf("failed to update account plan: %w", err)
+	}
+
+	return nil
+}
+
+// GetCurrentPeriod gets the open plan period of an account
+func (r *AccountPlanRepo) GetCurrentPeriod(ctx context.Context, accountID int) (*models.AccountPlanPeriod, error) {
+	query := `SELECT ` + accountPlanPeriodColumns + ` FROM account_plan_periods WHERE account_id = $1 AND ended_at IS NULL`
+
+	period, err := scanAccountPlanPeriod(r.db.QueryRowContext(ctx, query, accountID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("account has no plan: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get account plan period: %w", err)
+	}
+
+	return period, nil
+}
+
+// Switch ends the open plan period of an account at a time and opens one on another plan. The
+// ended period stays unbilled until the billing job settles it.
+func (r *AccountPlanRepo) Switch(ctx context.Context, accountID int, planID int, at time.Time) (period *models.AccountPlanPeriod, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the account so two switches do not both open a period
+	if _, err = tx.ExecContext(ctx, `SELECT id FROM accounts WHERE id = $1 FOR UPDATE`, accountID); err != nil {
+		return nil, fmt.Errorf("failed to lock account: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE account_plan_periods SET ended_at = $2 WHERE account_id = $1 AND ended_at IS NULL`, accountID, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end account plan period: %w", err)
+	}
+
+	query := `INSERT INTO account_plan_periods (account_id, plan_id, started_at, billed_until)
+             VALUES ($1, $2, $3, $3) RETURNING ` + accountPlanPeriodColumns
+
+	period, err = scanAccountPlanPeriod(tx.QueryRowContext(ctx, query, accountID, planID, at))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account plan period: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return period, nil
+}
+
+// GetUnbilled gets the plan periods not yet billed up to a time, or to their end if they ended before it
+func (r *AccountPlanRepo) GetUnbilled(ctx context.Context, until time.Time) ([]*models.AccountPlanPeriod, error) {
+	query := `SELECT ` + accountPlanPeriodColumns + ` FROM account_plan_periods
+             WHERE billed_until < LEAST(COALESCE(ended_at, $1), $1)
+             ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unbilled account plan periods: %w", err)
+	}
+	defer rows.Close()
+
+	var periods []*models.AccountPlanPeriod
+	for rows.Next() {
+		period, err := scanAccountPlanPeriod(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan account plan period: %w", err)
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return periods, nil
+}
+
+// MarkBilledTx records within a transaction that a plan period is billed up to a time
+func (r *AccountPlanRepo) MarkBilledTx(ctx context.Context, tx *sql.Tx, periodID int, until time.Time) error {
+	_, err := tx.ExecContext(ctx, `UPDATE account_plan_periods SET billed_until = $2 WHERE id = $1`, periodID, until)
+	if err != nil {
+		return fmt.Errorf("failed to mark account plan period billed: %w", err)
+	}
+	return nil
+}
+
+// encodeAccountPlan encodes the account types and interest tiers of an account plan as JSON
+func encodeAccountPlan(plan *models.AccountPlan) ([]byte, []byte, error) {
+	accountTypes, err := json.Marshal(plan.AccountTypes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode account types: %w", err)
+	}
+
+	tiers, err := json.Marshal(plan.InterestTiers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode interest tiers: %w", err)
+	}
+
+	return accountTypes, tiers, nil
+}
+
+// scanAccountPlan scans an account plan row, decoding its JSON fields
+func scanAccountPlan(row interface{ Scan(...interface{}) error }) (*models.AccountPlan, error) {
+	plan := &models.AccountPlan{}
+	var accountTypes, tiers []byte
+
+	err := row.Scan(
+		&plan.ID,
+		&plan.Code,
+		&plan.Name,
+		&accountTypes,
+		&plan.MonthlyFee,
+		&plan.FreeTransfers,
+		&plan.TransferFee,
+		&tiers,
+		&plan.DayCount,
+		&plan.IsActive,
+		&plan.Tenant,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(accountTypes, &plan.AccountTypes); err != nil {
+		return nil, fmt.Errorf("failed to decode account types: %w", err)
+	}
+	if err := json.Unmarshal(tiers, &plan.InterestTiers); err != nil {
+		return nil, fmt.Errorf("failed to decode interest tiers: %w", err)
+	}
+
+	return plan, nil
+}
+
+// scanAccountPlanPeriod scans an account plan period row
+func scanAccountPlanPeriod(row interface{ Scan(...interface{}) error }) (*models.AccountPlanPeriod, error) {
+	period := &models.AccountPlanPeriod{}
+	var endedAt sql.NullTime
+
+	err := row.Scan(
+		&period.ID,
+		&period.AccountID,
+		&period.PlanID,
+		&period.StartedAt,
+		&endedAt,
+		&period.BilledUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if endedAt.Valid {
+		period.EndedAt = &endedAt.Time
+	}
+
+	return period, nil
+}
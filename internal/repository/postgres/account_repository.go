@@ -5,39 +5,79 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"banking-service/internal/models"
 )
 
 // AccountRepo is a PostgreSQL implementation of the repository.AccountRepository interface
 type AccountRepo struct {
-	db *sql.DB
+	db      *sql.DB
+	numbers models.AccountNumberScheme
 }
 
-// NewAccountRepository creates a new AccountRepo
-func NewAccountRepository(db *sql.DB) *AccountRepo {
-	return &AccountRepo{db: db}
+// NewAccountRepository creates a new AccountRepo that numbers new accounts with the scheme
+func NewAccountRepository(db *sql.DB, numbers models.AccountNumberScheme) *AccountRepo {
+	return &AccountRepo{db: db, numbers: numbers}
 }
 
-// Create creates a new account in the database
+// Create creates a new account in the database. A personal account becomes the default of its
+// currency if the user has no other active account in it. An account without a number gets a
+// generated one, and another one is generated while the number is taken.
 func (r *AccountRepo) Create(ctx context.Context, account *models.Account) (int, error) {
-	query := `INSERT INTO accounts (user_id, account_number, balance, currency, account_type, is_active) 
-			  VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	// The account belongs to the tenant of its owner. A taken number inserts nothing instead of
+	// failing, which would abort the transaction and rule out another attempt.
+	query := `INSERT INTO accounts (user_id, organization_id, account_number, balance, currency, account_type, is_active, tenant) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT tenant FROM users WHERE id = $1)) 
+			  ON CONFLICT (account_number) DO NOTHING RETURNING id`
+	
+	generate := account.AccountNumber == ""
 	
 	var id int
-	err := r.db.QueryRowContext(
-		ctx,
-		query,
-		account.UserID,
-		account.AccountNumber,
-		account.Balance,
-		account.Currency,
-		account.AccountType,
-		account.IsActive,
-	).Scan(&id)
+	for attempt := 1; ; attempt++ {
+		if generate {
+			if account.AccountNumber, err = r.numbers.Generate(account); err != nil {
+				return 0, fmt.Errorf("failed to create account: %w", err)
+			}
+		}
+		
+		err = tx.QueryRowContext(
+			ctx,
+			query,
+			account.UserID,
+			account.OrganizationID,
+			account.AccountNumber,
+			account.Balance,
+			account.Currency,
+			account.AccountType,
+			account.IsActive,
+		).Scan(&id)
+		
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("failed to create account: %w", err)
+		}
+		if !generate || attempt == models.MaxAccountNumberAttempts {
+			return 0, fmt.Errorf("failed to create account: account number %s is taken", account.AccountNumber)
+		}
+	}
 	
-	if err != nil {
-		return 0, fmt.Errorf("failed to create account: %w", err)
+	if account.OrganizationID == nil {
+		if err := assignDefaultTx(ctx, tx, account.UserID, account.Currency); err != nil {
+			return 0, err
+		}
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	
 	return id, nil
@@ -45,18 +85,25 @@ func (r *AccountRepo) Create(ctx context.Context, account *models.Account) (int,
 
 // GetByID gets an account by ID
 func (r *AccountRepo) GetByID(ctx context.Context, id int) (*models.Account, error) {
-	query := `SELECT id, user_id, account_number, balance, currency, account_type, is_active, created_at, updated_at 
-			  FROM accounts WHERE id = $1`
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE id = $1 AND ($2 = '' OR tenant = $2)`
 	
 	account := &models.Account{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)).Scan(
 		&account.ID,
 		&account.UserID,
+		&account.OrganizationID,
 		&account.AccountNumber,
 		&account.Balance,
+		&account.AvailableBalance,
 		&account.Currency,
 		&account.AccountType,
 		&account.IsActive,
+		&account.IsDefault,
+		&account.DormantSince,
+		&account.ReactivatedAt,
+		&account.LockedUntil,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -71,28 +118,72 @@ func (r *AccountRepo) GetByID(ctx context.Context, id int) (*models.Account, err
 	return account, nil
 }
 
-// GetByUserID gets all accounts for a user
+// GetByUserID gets all personal accounts for a user
 func (r *AccountRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Account, error) {
-	query := `SELECT id, user_id, account_number, balance, currency, account_type, is_active, created_at, updated_at 
-			  FROM accounts WHERE user_id = $1`
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE user_id = $1 AND organization_id IS NULL AND ($2 = '' OR tenant = $2)`
+	
+	rows, err := r.db.QueryContext(ctx, query, userID, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanAccounts(rows)
+}
+
+// GetByOrganizationID gets all accounts owned by an organization
+func (r *AccountRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Account, error) {
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE organization_id = $1 AND ($2 = '' OR tenant = $2)`
 	
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, organizationID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accounts: %w", err)
 	}
 	defer rows.Close()
 	
+	return r.scanAccounts(rows)
+}
+
+// GetChanged gets up to limit accounts created or changed in [from, to) with an ID greater than
+// afterID, in ID order
+func (r *AccountRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Account, error) {
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE updated_at >= $1 AND updated_at < $2 AND id > $3 AND ($5 = '' OR tenant = $5)
+			  ORDER BY id LIMIT $4`
+	
+	rows, err := r.db.QueryContext(ctx, query, from, to, afterID, limit, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed accounts: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanAccounts(rows)
+}
+
+// Helper function to scan multiple accounts
+func (r *AccountRepo) scanAccounts(rows *sql.Rows) ([]*models.Account, error) {
 	var accounts []*models.Account
 	for rows.Next() {
 		account := &models.Account{}
 		err := rows.Scan(
 			&account.ID,
 			&account.UserID,
+			&account.OrganizationID,
 			&account.AccountNumber,
 			&account.Balance,
+			&account.AvailableBalance,
 			&account.Currency,
 			&account.AccountType,
 			&account.IsActive,
+			&account.IsDefault,
+			&account.DormantSince,
+			&account.ReactivatedAt,
+			&account.LockedUntil,
 			&account.CreatedAt,
 			&account.UpdatedAt,
 		)
@@ -111,18 +202,25 @@ func (r *AccountRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Ac
 
 // GetByAccountNumber gets an account by account number
 func (r *AccountRepo) GetByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error) {
-	query := `SELECT id, user_id, account_number, balance, currency, account_type, is_active, created_at, updated_at 
-			  FROM accounts WHERE account_number = $1`
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE account_number = $1 AND ($2 = '' OR tenant = $2)`
 	
 	account := &models.Account{}
-	err := r.db.QueryRowContext(ctx, query, accountNumber).Scan(
+	err := r.db.QueryRowContext(ctx, query, accountNumber, requestTenant(ctx)).Scan(
 		&account.ID,
 		&account.UserID,
+		&account.OrganizationID,
 		&account.AccountNumber,
 		&account.Balance,
+		&account.AvailableBalance,
 		&account.Currency,
 		&account.AccountType,
 		&account.IsActive,
+		&account.IsDefault,
+		&account.DormantSince,
+		&account.ReactivatedAt,
+		&account.LockedUntil,
 		&account.CreatedAt,
 		&account.UpdatedAt,
 	)
@@ -152,17 +250,19 @@ func (r *AccountRepo) UpdateBalance(ctx context.Context, id int, amount float64)
 	}()
 	
 	// First get the current balance to ensure it won't go negative
-	query := `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`
-	var currentBalance float64
+	// and debits leave the funds reserved by active holds untouched
+	query := `SELECT balance, balance - ` + activeHolds + ` FROM accounts WHERE id = $1 FOR UPDATE`
+	var currentBalance, available float64
 	
-	err = tx.QueryRowContext(ctx, query, id).Scan(&currentBalance)
+	err = tx.QueryRowContext(ctx, query, id).Scan(&currentBalance, &available)
 	if err != nil {
 		return fmt.Errorf("failed to get current balance: %w", err)
 	}
 	
 	newBalance := currentBalance + amount
-	if newBalance < 0 {
-		return fmt.Errorf("insufficient funds")
+	if newBalance < 0 || (amount < 0 && available+amount < 0) {
+		err = fmt.Errorf("insufficient funds")
+		return err
 	}
 	
 	// Update the balance
@@ -180,13 +280,35 @@ func (r *AccountRepo) UpdateBalance(ctx context.Context, id int, amount float64)
 	return nil
 }
 
-// Update updates an account
+// Update updates an account. An account keeps its default flag only while it stays active and in
+// the same currency; the user's other accounts take over the default it gives up.
 func (r *AccountRepo) Update(ctx context.Context, account *models.Account) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	var userID int
+	var organizationID *int
+	var currency models.Currency
+	err = tx.QueryRowContext(ctx, `SELECT user_id, organization_id, currency FROM accounts WHERE id = $1 FOR UPDATE`, account.ID).Scan(
+		&userID,
+		&organizationID,
+		&currency,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("account not found")
+		}
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	
 	query := `UPDATE accounts 
-			  SET currency = $1, account_type = $2, is_active = $3 
+			  SET currency = $1, account_type = $2, is_active = $3, is_default = is_default AND currency = $1 AND $3
 			  WHERE id = $4`
 	
-	result, err := r.db.ExecContext(
+	_, err = tx.ExecContext(
 		ctx,
 		query,
 		account.Currency,
@@ -199,13 +321,19 @@ func (r *AccountRepo) Update(ctx context.Context, account *models.Account) error
 		return fmt.Errorf("failed to update account: %w", err)
 	}
 	
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	if organizationID == nil {
+		if err := assignDefaultTx(ctx, tx, userID, currency); err != nil {
+			return err
+		}
+		if account.Currency != currency {
+			if err := assignDefaultTx(ctx, tx, userID, account.Currency); err != nil {
+				return err
+			}
+		}
 	}
 	
-	if rows == 0 {
-		return fmt.Errorf("account not found")
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	
 	return nil
@@ -227,10 +355,13 @@ func (r *AccountRepo) Delete(ctx context.Context, id int) error {
 	}()
 	
 	// Check if the account has a balance
-	checkQuery := `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`
+	checkQuery := `SELECT balance, user_id, organization_id, currency FROM accounts WHERE id = $1 FOR UPDATE`
 	var balance float64
+	var userID int
+	var organizationID *int
+	var currency models.Currency
 	
-	err = tx.QueryRowContext(ctx, checkQuery, id).Scan(&balance)
+	err = tx.QueryRowContext(ctx, checkQuery, id).Scan(&balance, &userID, &organizationID, &currency)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return fmt.Errorf("account not found: %w", err)
@@ -258,6 +389,13 @@ func (r *AccountRepo) Delete(ctx context.Context, id int) error {
 		return fmt.Errorf("account not found")
 	}
 	
+	// Another account takes over if the deleted one was the default
+	if organizationID == nil {
+		if err = assignDefaultTx(ctx, tx, userID, currency); err != nil {
+			return err
+		}
+	}
+	
 	err = tx.Commit()
 	if err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -269,16 +407,17 @@ func (r *AccountRepo) Delete(ctx context.Context, id int) error {
 // UpdateBalanceTx updates an account's balance within an existing transaction
 func (r *AccountRepo) UpdateBalanceTx(ctx context.Context, tx *sql.Tx, id int, amount float64) error {
 	// First get the current balance to ensure it won't go negative
-	query := `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`
-	var currentBalance float64
+	// and debits leave the funds reserved by active holds untouched
+	query := `SELECT balance, balance - ` + activeHolds + ` FROM accounts WHERE id = $1 FOR UPDATE`
+	var currentBalance, available float64
 	
-	err := tx.QueryRowContext(ctx, query, id).Scan(&currentBalance)
+	err := tx.QueryRowContext(ctx, query, id).Scan(&currentBalance, &available)
 	if err != nil {
 		return fmt.Errorf("failed to get current balance: %w", err)
 	}
 	
 	newBalance := currentBalance + amount
-	if newBalance < 0 {
+	if newBalance < 0 || (amount < 0 && available+amount < 0) {
 		return fmt.Errorf("insufficient funds")
 	}
 	
@@ -289,5 +428,193 @@ func (r *AccountRepo) UpdateBalanceTx(ctx context.Context, tx *sql.Tx, id int, a
 		return fmt.Errorf("failed to update balance: %w", err)
 	}
 	
+	return nil
+}
+
+// MarkDormant flags active accounts without transactions since the cutoff as dormant and returns them.
+// Credit accounts are skipped, and a reactivation restarts the inactivity period.
+func (r *AccountRepo) MarkDormant(ctx context.Context, cutoff time.Time) ([]*models.Account, error) {
+	query := `UPDATE accounts SET dormant_since = CURRENT_TIMESTAMP
+			  WHERE is_active AND dormant_since IS NULL AND account_type <> $1
+			  AND COALESCE(reactivated_at, created_at) < $2
+			  AND NOT EXISTS (
+			      SELECT 1 FROM transactions t
+			      WHERE (t.source_account_id = accounts.id OR t.destination_account_id = accounts.id) AND t.transaction_date >= $2)
+			  RETURNING id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at`
+	
+	rows, err := r.db.QueryContext(ctx, query, models.AccountTypeCredit, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark dormant accounts: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanAccounts(rows)
+}
+
+// Reactivate lifts the dormant flag of an account; it reports false if the account was not dormant
+func (r *AccountRepo) Reactivate(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE accounts SET dormant_since = NULL, reactivated_at = CURRENT_TIMESTAMP
+			  WHERE id = $1 AND dormant_since IS NOT NULL`
+	
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to reactivate account: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rows > 0, nil
+}
+
+// GetDefault gets the default personal account of a user in a currency
+func (r *AccountRepo) GetDefault(ctx context.Context, userID int, currency models.Currency) (*models.Account, error) {
+	query := `SELECT id, user_id, organization_id, account_number, balance, balance - ` + activeHolds + `, currency, account_type, 
+			  is_active, is_default, dormant_since, reactivated_at, locked_until, created_at, updated_at
+			  FROM accounts WHERE user_id = $1 AND currency = $2 AND is_default AND ($3 = '' OR tenant = $3)`
+	
+	rows, err := r.db.QueryContext(ctx, query, userID, currency, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default account: %w", err)
+	}
+	defer rows.Close()
+	
+	accounts, err := r.scanAccounts(rows)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("default account not found: %w", sql.ErrNoRows)
+	}
+	
+	return accounts[0], nil
+}
+
+// SetDefault makes an active personal account the default of its currency in place of the
+// user's current default
+func (r *AccountRepo) SetDefault(ctx context.Context, id int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	
+	var userID int
+	var currency models.Currency
+	query := `SELECT user_id, currency FROM accounts WHERE id = $1 AND organization_id IS NULL AND is_active`
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&userID, &currency); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("account not found: %w", err)
+		}
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := lockDefaultsTx(ctx, tx, userID); err != nil {
+		return err
+	}
+	
+	clearQuery := `UPDATE accounts SET is_default = FALSE WHERE user_id = $1 AND currency = $2 AND is_default AND id <> $3`
+	if _, err := tx.ExecContext(ctx, clearQuery, userID, currency, id); err != nil {
+		return fmt.Errorf("failed to clear default account: %w", err)
+	}
+	
+	setQuery := `UPDATE accounts SET is_default = TRUE WHERE id = $1 AND NOT is_default`
+	if _, err := tx.ExecContext(ctx, setQuery, id); err != nil {
+		return fmt.Errorf("failed to set default account: %w", err)
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return nil
+}
+
+// TransferOwnershipTx moves a personal account, with its cards and history, from one user to another
+// within an existing transaction. The previous owner's display settings and bill templates for the
+// account are deleted, and both users keep exactly one default account in the currency. It returns
+// false if the account is not a personal account of fromUserID.
+func (r *AccountRepo) TransferOwnershipTx(ctx context.Context, tx *sql.Tx, accountID int, fromUserID int, toUserID int) (bool, error) {
+	// Lock both users in ID order, so concurrent transfers between them do not deadlock
+	first, second := fromUserID, toUserID
+	if second < first {
+		first, second = second, first
+	}
+	if err := lockDefaultsTx(ctx, tx, first); err != nil {
+		return false, err
+	}
+	if err := lockDefaultsTx(ctx, tx, second); err != nil {
+		return false, err
+	}
+	
+	var currency models.Currency
+	query := `UPDATE accounts SET user_id = $1, is_default = FALSE
+			  WHERE id = $2 AND user_id = $3 AND organization_id IS NULL
+			  RETURNING currency`
+	err := tx.QueryRowContext(ctx, query, toUserID, accountID, fromUserID).Scan(&currency)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to transfer account: %w", err)
+	}
+	
+	settingsQuery := `DELETE FROM account_settings WHERE account_id = $1 AND user_id = $2`
+	if _, err := tx.ExecContext(ctx, settingsQuery, accountID, fromUserID); err != nil {
+		return false, fmt.Errorf("failed to delete account settings: %w", err)
+	}
+	
+	templatesQuery := `DELETE FROM bill_templates WHERE account_id = $1 AND user_id = $2`
+	if _, err := tx.ExecContext(ctx, templatesQuery, accountID, fromUserID); err != nil {
+		return false, fmt.Errorf("failed to delete bill templates: %w", err)
+	}
+	
+	if err := assignDefaultTx(ctx, tx, fromUserID, currency); err != nil {
+		return false, err
+	}
+	if err := assignDefaultTx(ctx, tx, toUserID, currency); err != nil {
+		return false, err
+	}
+	
+	return true, nil
+}
+
+// assignDefaultTx keeps exactly one default among a user's active personal accounts in a currency:
+// inactive accounts lose the flag, and the oldest active account gets it if none has it
+func assignDefaultTx(ctx context.Context, tx *sql.Tx, userID int, currency models.Currency) error {
+	if err := lockDefaultsTx(ctx, tx, userID); err != nil {
+		return err
+	}
+	
+	clearQuery := `UPDATE accounts SET is_default = FALSE
+			  WHERE user_id = $1 AND currency = $2 AND is_default AND NOT is_active`
+	if _, err := tx.ExecContext(ctx, clearQuery, userID, currency); err != nil {
+		return fmt.Errorf("failed to clear default account: %w", err)
+	}
+	
+	assignQuery := `UPDATE accounts SET is_default = TRUE
+			  WHERE id = (
+			      SELECT id FROM accounts
+			      WHERE user_id = $1 AND currency = $2 AND organization_id IS NULL AND is_active
+			      ORDER BY id LIMIT 1)
+			  AND NOT EXISTS (SELECT 1 FROM accounts WHERE user_id = $1 AND currency = $2 AND is_default)`
+	if _, err := tx.ExecContext(ctx, assignQuery, userID, currency); err != nil {
+		return fmt.Errorf("failed to assign default account: %w", err)
+	}
+	
+	return nil
+}
+
+// lockDefaultsTx locks the user row, so concurrent changes of the user's default accounts wait
+// for each other instead of both assigning one. FOR NO KEY UPDATE does not block the foreign key
+// checks of inserts that reference the user.
+func lockDefaultsTx(ctx context.Context, tx *sql.Tx, userID int) error {
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE`, userID); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// AccountSettingsRepo is a PostgreSQL implementation of the repository.AccountSettingsRepository interface
+type AccountSettingsRepo struct {
+	db *sql.DB
+}
+
+// NewAccountSettingsRepository creates a new AccountSettingsRepo
+func NewAccountSettingsRepository(db *sql.DB) *AccountSettingsRepo {
+	return &AccountSettingsRepo{db: db}
+}
+
+// Get gets a user's settings of an account
+func (r *AccountSettingsRepo) Get(ctx context.Context, accountID int, userID int) (*models.AccountSettings, error) {
+	query := `SELECT account_id, user_id, nickname, color, sort_order, hidden, updated_at
+              FROM account_settings
+              WHERE account_id = $1 AND user_id = $2`
+
+	settings := &models.AccountSettings{}
+	err := r.db.QueryRowContext(ctx, query, accountID, userID).Scan(
+		&settings.AccountID,
+		&settings.UserID,
+		&settings.Nickname,
+		&settings.Color,
+		&settings.SortOrder,
+		&settings.Hidden,
+		&settings.UpdatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("account settings not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get account settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// GetByUserID gets a user's settings of all accounts
+func (r *AccountSettingsRepo) GetByUserID(ctx context.Context, userID int) ([]*models.AccountSettings, error) {
+	query := `SELECT account_id, user_id, nickname, color, sort_order, hidden, updated_at
+              FROM account_settings
+              WHERE user_id = $1
+              ORDER BY account_id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := []*models.AccountSettings{}
+	for rows.Next() {
+		s := &models.AccountSettings{}
+		if err := rows.Scan(
+			&s.AccountID,
+			&s.UserID,
+			&s.Nickname,
+			&s.Color,
+			&s.SortOrder,
+			&s.Hidden,
+			&s.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan account settings: %w", err)
+		}
+		settings = append(settings, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Save creates or replaces a user's settings of an account
+func (r *AccountSettingsRepo) Save(ctx context.Context, settings *models.AccountSettings) error {
+	query := `INSERT INTO account_settings (account_id, user_id, nickname, color, sort_order, hidden)
+              VALUES ($1, $2, $3, $4, $5, $6)
+              ON CONFLICT (account_id, user_id) DO UPDATE
+              SET nickname = EXCLUDED.nickname, color = EXCLUDED.color, sort_order = EXCLUDED.sort_order,
+                  hidden = EXCLUDED.hidden, updated_at = NOW()
+              RETURNING updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		settings.AccountID,
+		settings.UserID,
+		settings.Nickname,
+		settings.Color,
+		settings.SortOrder,
+		settings.Hidden,
+	).Scan(&settings.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save account settings: %w", err)
+	}
+
+	return nil
+}
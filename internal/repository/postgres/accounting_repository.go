@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// AccountingRepo is a PostgreSQL implementation of the repository.AccountingRepository interface
+type AccountingRepo struct {
+	db *sql.DB
+}
+
+// NewAccountingRepository creates a new AccountingRepo
+func NewAccountingRepository(db *sql.DB) *AccountingRepo {
+	return &AccountingRepo{db: db}
+}
+
+// GetEntries gets the completed transactions of the request's tenant in [from, to) with the numbers
+// of their accounts
+func (r *AccountingRepo) GetEntries(ctx context.Context, from, to time.Time) ([]*models.AccountingEntry, error) {
+	query := `SELECT t.id, t.transaction_date, t.transaction_type,
+             COALESCE(src.account_number, ''), COALESCE(dst.account_number, ''),
+             t.amount, t.currency, COALESCE(t.description, '')
+             FROM transactions t
+             LEFT JOIN accounts src ON src.id = t.source_account_id
+             LEFT JOIN accounts dst ON dst.id = t.destination_account_id
+             WHERE t.status = $1 AND t.transaction_date >= $2 AND t.transaction_date < $3
+             AND ($4 = '' OR t.tenant = $4)
+             ORDER BY t.transaction_date, t.id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.TransactionStatusCompleted, from, to, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounting entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AccountingEntry{}
+	for rows.Next() {
+		entry := &models.AccountingEntry{}
+		if err := rows.Scan(
+			&entry.TransactionID,
+			&entry.Date,
+			&entry.Type,
+			&entry.SourceAccount,
+			&entry.DestinationAccount,
+			&entry.Amount,
+			&entry.Currency,
+			&entry.Description,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan accounting entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetBalanceMismatches gets the accounts whose balance differs from the sum of their transactions
+// that affect balances
+func (r *AccountingRepo) GetBalanceMismatches(ctx context.Context) ([]*models.BalanceMismatch, error) {
+	query := `SELECT a.id, a.account_number, a.currency, a.balance, COALESCE(l.net, 0)
+             FROM accounts a
+             LEFT JOIN (
+                 SELECT account_id, SUM(amount) AS net
+                 FROM (
+                     SELECT destination_account_id AS account_id, amount FROM transactions
+                     WHERE destination_account_id IS NOT NULL AND status NOT IN ($1, $2)
+                     UNION ALL
+                     SELECT source_account_id, -amount FROM transactions
+                     WHERE source_account_id IS NOT NULL AND status NOT IN ($1, $2)
+                 ) movements
+                 GROUP BY account_id
+             ) l ON l.account_id = a.id
+             WHERE a.balance <> COALESCE(l.net, 0)
+             ORDER BY a.id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.TransactionStatusFailed, models.TransactionStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	mismatches := []*models.BalanceMismatch{}
+	for rows.Next() {
+		mismatch := &models.BalanceMismatch{}
+		if err := rows.Scan(
+			&mismatch.AccountID,
+			&mismatch.AccountNumber,
+			&mismatch.Currency,
+			&mismatch.Balance,
+			&mismatch.LedgerBalance,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan balance mismatch: %w", err)
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return mismatches, nil
+}
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// AnnouncementRepo is a PostgreSQL implementation of the repository.AnnouncementRepository interface
+type AnnouncementRepo struct {
+	db *sql.DB
+}
+
+// NewAnnouncementRepository creates a new AnnouncementRepo
+func NewAnnouncementRepository(db *sql.DB) *AnnouncementRepo {
+	return &AnnouncementRepo{db: db}
+}
+
+// announcementQuery selects announcements with the counts of their recipients
+const announcementQuery = `SELECT a.id, a.title, a.message, a.segment, a.send_email, a.status, a.created_by, a.created_at, a.completed_at,
+             COUNT(r.id), COUNT(r.id) FILTER (WHERE r.status = $1), COUNT(r.id) FILTER (WHERE r.status = $2)
+             FROM announcements a
+             LEFT JOIN announcement_recipients r ON r.announcement_id = a.id`
+
+// Create saves a queued announcement together with its recipients: the customers in its segment
+func (r *AnnouncementRepo) Create(ctx context.Context, announcement *models.Announcement) (id int, err error) {
+	segment, err := json.Marshal(announcement.Segment)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode announcement segment: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO announcements (title, message, segment, send_email, status, created_by)
+             VALUES ($1, $2, $3, $4, $5, $6)
+             RETURNING id, created_at`
+
+	err = tx.QueryRowContext(ctx, query,
+		announcement.Title,
+		announcement.Message,
+		segment,
+		announcement.SendEmail,
+		models.AnnouncementStatusQueued,
+		announcement.CreatedBy,
+	).Scan(&announcement.ID, &announcement.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	query = `INSERT INTO announcement_recipients (announcement_id, user_id, status)
+             SELECT $1, u.id, $2 FROM users u
+             WHERE u.role = $3 AND ($4 = '' OR u.tenant = $4)
+               AND ($5 = $6 OR EXISTS (
+                   SELECT 1 FROM credits c
+                   WHERE c.user_id = u.id AND (c.status = $7 OR ($5 = $8 AND c.status = $9))))
+             ORDER BY u.id`
+
+	result, err := tx.ExecContext(ctx, query,
+		announcement.ID,
+		models.AnnouncementRecipientStatusPending,
+		models.UserRoleCustomer,
+		announcement.Segment.Tenant,
+		announcement.Segment.Audience,
+		models.AnnouncementAudienceAll,
+		models.CreditStatusOverdue,
+		models.AnnouncementAudienceActiveCredits,
+		models.CreditStatusActive,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue announcement recipients: %w", err)
+	}
+
+	recipients, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to queue announcement recipients: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	announcement.Status = models.AnnouncementStatusQueued
+	announcement.Recipients = int(recipients)
+
+	return announcement.ID, nil
+}
+
+// GetAll gets the announcements of the request's tenant, the one of their segment, newest first
+func (r *AnnouncementRepo) GetAll(ctx context.Context) ([]*models.Announcement, error) {
+	query := announcementQuery + `
+             WHERE ($3 = '' OR a.segment->>'tenant' = $3)
+             GROUP BY a.id
+             ORDER BY a.id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, models.AnnouncementRecipientStatusSent, models.AnnouncementRecipientStatusFailed, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcements: %w", err)
+	}
+	defer rows.Close()
+
+	announcements := []*models.Announcement{}
+	for rows.Next() {
+		announcement, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// GetByID gets an announcement of the request's tenant by ID
+func (r *AnnouncementRepo) GetByID(ctx context.Context, id int) (*models.Announcement, error) {
+	query := announcementQuery + `
+             WHERE a.id = $3 AND ($4 = '' OR a.segment->>'tenant' = $4)
+             GROUP BY a.id`
+
+	announcement, err := scanAnnouncement(r.db.QueryRowContext(ctx, query, models.AnnouncementRecipientStatusSent, models.AnnouncementRecipientStatusFailed,
+		id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("announcement not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+
+	return announcement, nil
+}
+
+// Cancel stops the delivery of a queued announcement, reporting whether it was queued
+func (r *AnnouncementRepo) Cancel(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE announcements SET status = $2, completed_at = NOW()
+             WHERE id = $1 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, id, models.AnnouncementStatusCancelled, models.AnnouncementStatusQueued)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel announcement: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel announcement: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// GetPendingRecipients gets the recipients of queued announcements not reached yet, those of the
+// oldest announcement first
+func (r *AnnouncementRepo) GetPendingRecipients(ctx context.Context, limit int) ([]*models.AnnouncementRecipient, error) {
+	query := `SELECT r.id, r.announcement_id, r.user_id, r.status, r.error, r.sent_at
+             FROM announcement_recipients r
+             JOIN announcements a ON a.id = r.announcement_id
+             WHERE r.status = $1 AND a.status = $2
+             ORDER BY r.announcement_id, r.id
+             LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, models.AnnouncementRecipientStatusPending, models.AnnouncementStatusQueued, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcement recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := []*models.AnnouncementRecipient{}
+	for rows.Next() {
+		recipient := &models.AnnouncementRecipient{}
+		var sentAt sql.NullTime
+		err := rows.Scan(
+			&recipient.ID,
+			&recipient.AnnouncementID,
+			&recipient.UserID,
+			&recipient.Status,
+			&recipient.Error,
+			&sentAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement recipient: %w", err)
+		}
+		if sentAt.Valid {
+			recipient.SentAt = &sentAt.Time
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// MarkRecipient records whether an announcement reached a recipient
+func (r *AnnouncementRepo) MarkRecipient(ctx context.Context, id int, status models.AnnouncementRecipientStatus, reason string) error {
+	query := `UPDATE announcement_recipients SET status = $2, error = $3, sent_at = NOW()
+             WHERE id = $1 AND status = $4`
+
+	_, err := r.db.ExecContext(ctx, query, id, status, reason, models.AnnouncementRecipientStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to mark announcement recipient: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteDelivered completes the queued announcements with no recipients left to reach,
+// returning how many
+func (r *AnnouncementRepo) CompleteDelivered(ctx context.Context) (int, error) {
+	query := `UPDATE announcements a SET status = $1, completed_at = NOW()
+             WHERE a.status = $2 AND NOT EXISTS (
+                 SELECT 1 FROM announcement_recipients r WHERE r.announcement_id = a.id AND r.status = $3)`
+
+	result, err := r.db.ExecContext(ctx, query, models.AnnouncementStatusCompleted, models.AnnouncementStatusQueued, models.AnnouncementRecipientStatusPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete announcements: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete announcements: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// scanAnnouncement scans an announcement row with the counts of its recipients
+func scanAnnouncement(row interface{ Scan(...interface{}) error }) (*models.Announcement, error) {
+	announcement := &models.Announcement{}
+	var segment []byte
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&announcement.ID,
+		&announcement.Title,
+		&announcement.Message,
+		&segment,
+		&announcement.SendEmail,
+		&announcement.Status,
+		&announcement.CreatedBy,
+		&announcement.CreatedAt,
+		&completedAt,
+		&announcement.Recipients,
+		&announcement.Sent,
+		&announcement.Failed,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(segment, &announcement.Segment); err != nil {
+		return nil, fmt.Errorf("failed to decode announcement segment: %w", err)
+	}
+	if completedAt.Valid {
+		announcement.CompletedAt = &completedAt.Time
+	}
+
+	return announcement, nil
+}
@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+const apiKeyColumns = `SELECT id, user_id, name, key_prefix, key_hash, scopes, expires_at, last_used_at, revoked_at,
+             created_at, updated_at
+             FROM api_keys`
+
+// APIKeyRepo is a PostgreSQL implementation of the repository.APIKeyRepository interface
+type APIKeyRepo struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepo
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepo {
+	return &APIKeyRepo{db: db}
+}
+
+// Create creates a new API key in the database
+func (r *APIKeyRepo) Create(ctx context.Context, key *models.APIKey) (int, error) {
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode API key scopes: %w", err)
+	}
+
+	query := `INSERT INTO api_keys (user_id, name, key_prefix, key_hash, scopes, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		key.UserID,
+		key.Name,
+		key.KeyPrefix,
+		key.KeyHash,
+		scopes,
+		key.ExpiresAt,
+	).Scan(&key.ID, &key.CreatedAt, &key.UpdatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key.ID, nil
+}
+
+// GetByID gets an API key by ID
+func (r *APIKeyRepo) GetByID(ctx context.Context, id int) (*models.APIKey, error) {
+	return r.get(ctx, apiKeyColumns+` WHERE id = $1`, id)
+}
+
+// GetByKeyHash gets an API key by the hash of the key
+func (r *APIKeyRepo) GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	return r.get(ctx, apiKeyColumns+` WHERE key_hash = $1`, keyHash)
+}
+
+// get gets the API key a query selects
+func (r *APIKeyRepo) get(ctx context.Context, query string, args ...interface{}) (*models.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("API key not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetByUserID gets all API keys of a user, including revoked ones, newest first
+func (r *APIKeyRepo) GetByUserID(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	rows, err := r.db.QueryContext(ctx, apiKeyColumns+` WHERE user_id = $1 ORDER BY created_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// UpdateKey replaces the key of an API key that is not revoked
+func (r *APIKeyRepo) UpdateKey(ctx context.Context, id int, keyHash string, keyPrefix string) error {
+	query := `UPDATE api_keys SET key_hash = $2, key_prefix = $3, last_used_at = NULL, updated_at = NOW()
+             WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, keyHash, keyPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update API key: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("API key not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Revoke revokes an API key. It reports false if the key was revoked already.
+func (r *APIKeyRepo) Revoke(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE api_keys SET revoked_at = NOW(), updated_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// MarkUsed records when an API key was last used
+func (r *APIKeyRepo) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, usedAt); err != nil {
+		return fmt.Errorf("failed to mark API key as used: %w", err)
+	}
+
+	return nil
+}
+
+// scanAPIKey scans an API key row, decoding its scopes
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	var scopes []byte
+	var expiresAt, lastUsedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.KeyPrefix,
+		&key.KeyHash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&key.CreatedAt,
+		&key.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(scopes, &key.Scopes); err != nil {
+		return nil, fmt.Errorf("failed to decode API key scopes: %w", err)
+	}
+
+	if expiresAt.Valid {
+		key.ExpiresAt = &expiresAt.Time
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return key, nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"banking-service/internal/models"
+)
+
+// ApprovalPolicyRepo is a PostgreSQL implementation of the repository.ApprovalPolicyRepository interface
+type ApprovalPolicyRepo struct {
+	db *sql.DB
+}
+
+// NewApprovalPolicyRepository creates a new ApprovalPolicyRepo
+func NewApprovalPolicyRepository(db *sql.DB) *ApprovalPolicyRepo {
+	return &ApprovalPolicyRepo{db: db}
+}
+
+// GetByOrganizationID gets the approval policies of an organization
+func (r *ApprovalPolicyRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.ApprovalPolicy, error) {
+	query := `SELECT id, organization_id, min_amount, required_approvals, created_at
+             FROM approval_policies WHERE organization_id = $1
+             ORDER BY min_amount`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*models.ApprovalPolicy
+	for rows.Next() {
+		policy := &models.ApprovalPolicy{}
+		err := rows.Scan(
+			&policy.ID,
+			&policy.OrganizationID,
+			&policy.MinAmount,
+			&policy.RequiredApprovals,
+			&policy.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return policies, nil
+}
+
+// Replace replaces all approval policies of an organization in a single transaction and records
+// the new limits in the activity feed of the organization's accounts
+func (r *ApprovalPolicyRepo) Replace(ctx context.Context, organizationID int, rules []models.ApprovalPolicyRule) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM approval_policies WHERE organization_id = $1`, organizationID); err != nil {
+		return fmt.Errorf("failed to delete approval policies: %w", err)
+	}
+
+	query := `INSERT INTO approval_policies (organization_id, min_amount, required_approvals)
+             VALUES ($1, $2, $3)`
+
+	for _, rule := range rules {
+		if _, err = tx.ExecContext(ctx, query, organizationID, rule.MinAmount, rule.RequiredApprovals); err != nil {
+			return fmt.Errorf("failed to create approval policy: %w", err)
+		}
+	}
+
+	limits := append([]models.ApprovalPolicyRule{}, rules...)
+	sort.SliceStable(limits, func(i, j int) bool { return limits[i].MinAmount < limits[j].MinAmount })
+
+	content, err := json.Marshal(limits)
+	if err != nil {
+		return fmt.Errorf("failed to encode limits: %w", err)
+	}
+
+	query = `INSERT INTO account_events (account_id, event_type, limits)
+             SELECT id, $2, $3 FROM accounts WHERE organization_id = $1`
+
+	if _, err = tx.ExecContext(ctx, query, organizationID, models.AccountEventLimitsChanged, content); err != nil {
+		return fmt.Errorf("failed to record the limit change: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// BillPaymentRepo is a PostgreSQL implementation of the repository.BillPaymentRepository interface
+type BillPaymentRepo struct {
+	db *sql.DB
+}
+
+// NewBillPaymentRepository creates a new BillPaymentRepo
+func NewBillPaymentRepository(db *sql.DB) *BillPaymentRepo {
+	return &BillPaymentRepo{db: db}
+}
+
+// CreateTx records a bill payment within an existing transaction
+func (r *BillPaymentRepo) CreateTx(ctx context.Context, tx *sql.Tx, payment *models.BillPayment) (int, error) {
+	fields, err := json.Marshal(payment.Fields)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode payment fields: %w", err)
+	}
+
+	query := `INSERT INTO bill_payments (user_id, provider_id, account_id, transaction_id, amount, fields)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var id int
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		payment.UserID,
+		payment.ProviderID,
+		payment.AccountID,
+		payment.TransactionID,
+		payment.Amount,
+		fields,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bill payment: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets a bill payment by ID
+func (r *BillPaymentRepo) GetByID(ctx context.Context, id int) (*models.BillPayment, error) {
+	query := `SELECT b.id, b.user_id, b.provider_id, p.name, b.account_id, b.transaction_id,
+             b.amount, b.fields, b.created_at
+             FROM bill_payments b
+             JOIN bill_providers p ON p.id = b.provider_id
+             WHERE b.id = $1`
+
+	payment, err := scanBillPayment(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("bill payment not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get bill payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// GetByUserID gets the bill payments of a user, newest first
+func (r *BillPaymentRepo) GetByUserID(ctx context.Context, userID int) ([]*models.BillPayment, error) {
+	query := `SELECT b.id, b.user_id, b.provider_id, p.name, b.account_id, b.transaction_id,
+             b.amount, b.fields, b.created_at
+             FROM bill_payments b
+             JOIN bill_providers p ON p.id = b.provider_id
+             WHERE b.user_id = $1
+             ORDER BY b.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*models.BillPayment{}
+	for rows.Next() {
+		payment, err := scanBillPayment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bill payment: %w", err)
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return payments, nil
+}
+
+// scanBillPayment scans a bill payment row, decoding its field values
+func scanBillPayment(row interface{ Scan(...interface{}) error }) (*models.BillPayment, error) {
+	payment := &models.BillPayment{}
+	var fields []byte
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.UserID,
+		&payment.ProviderID,
+		&payment.ProviderName,
+		&payment.AccountID,
+		&payment.TransactionID,
+		&payment.Amount,
+		&fields,
+		&payment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fields, &payment.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode payment fields: %w", err)
+	}
+
+	return payment, nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// BillProviderRepo is a PostgreSQL implementation of the repository.BillProviderRepository interface
+type BillProviderRepo struct {
+	db *sql.DB
+}
+
+// NewBillProviderRepository creates a new BillProviderRepo
+func NewBillProviderRepository(db *sql.DB) *BillProviderRepo {
+	return &BillProviderRepo{db: db}
+}
+
+// GetByID gets a bill provider by ID
+func (r *BillProviderRepo) GetByID(ctx context.Context, id int) (*models.BillProvider, error) {
+	query := `SELECT id, code, name, category, fields, min_amount, max_amount, is_active, created_at
+             FROM bill_providers WHERE id = $1`
+
+	provider, err := scanBillProvider(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("bill provider not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get bill provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// GetActive gets the active bill providers, optionally limited to a category
+func (r *BillProviderRepo) GetActive(ctx context.Context, category models.BillCategory) ([]*models.BillProvider, error) {
+	query := `SELECT id, code, name, category, fields, min_amount, max_amount, is_active, created_at
+             FROM bill_providers
+             WHERE is_active = TRUE AND ($1 = '' OR category = $1)
+             ORDER BY category, name`
+
+	rows, err := r.db.QueryContext(ctx, query, category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill providers: %w", err)
+	}
+	defer rows.Close()
+
+	providers := []*models.BillProvider{}
+	for rows.Next() {
+		provider, err := scanBillProvider(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bill provider: %w", err)
+		}
+		providers = append(providers, provider)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return providers, nil
+}
+
+// scanBillProvider scans a bill provider row, decoding its field definitions
+func scanBillProvider(row interface{ Scan(...interface{}) error }) (*models.BillProvider, error) {
+	provider := &models.BillProvider{}
+	var fields []byte
+
+	err := row.Scan(
+		&provider.ID,
+		&provider.Code,
+		&provider.Name,
+		&provider.Category,
+		&fields,
+		&provider.MinAmount,
+		&provider.MaxAmount,
+		&provider.IsActive,
+		&provider.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fields, &provider.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode provider fields: %w", err)
+	}
+
+	return provider, nil
+}
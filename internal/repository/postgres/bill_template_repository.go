@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// BillTemplateRepo is a PostgreSQL implementation of the repository.BillTemplateRepository interface
+type BillTemplateRepo struct {
+	db *sql.DB
+}
+
+// NewBillTemplateRepository creates a new BillTemplateRepo
+func NewBillTemplateRepository(db *sql.DB) *BillTemplateRepo {
+	return &BillTemplateRepo{db: db}
+}
+
+// Create saves a new bill template
+func (r *BillTemplateRepo) Create(ctx context.Context, template *models.BillTemplate) (int, error) {
+	fields, err := json.Marshal(template.Fields)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode template fields: %w", err)
+	}
+
+	query := `INSERT INTO bill_templates (user_id, name, provider_id, account_id, amount, fields)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var id int
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		template.UserID,
+		template.Name,
+		template.ProviderID,
+		template.AccountID,
+		template.Amount,
+		fields,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bill template: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets a bill template by ID
+func (r *BillTemplateRepo) GetByID(ctx context.Context, id int) (*models.BillTemplate, error) {
+	query := `SELECT t.id, t.user_id, t.name, t.provider_id, p.name, t.account_id, t.amount,
+             t.fields, t.created_at, t.updated_at
+             FROM bill_templates t
+             JOIN bill_providers p ON p.id = t.provider_id
+             WHERE t.id = $1`
+
+	template, err := scanBillTemplate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("bill template not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get bill template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetByUserID gets the bill templates of a user
+func (r *BillTemplateRepo) GetByUserID(ctx context.Context, userID int) ([]*models.BillTemplate, error) {
+	query := `SELECT t.id, t.user_id, t.name, t.provider_id, p.name, t.account_id, t.amount,
+             t.fields, t.created_at, t.updated_at
+             FROM bill_templates t
+             JOIN bill_providers p ON p.id = t.provider_id
+             WHERE t.user_id = $1
+             ORDER BY t.name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []*models.BillTemplate{}
+	for rows.Next() {
+		template, err := scanBillTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bill template: %w", err)
+		}
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return templates, nil
+}
+
+// Delete deletes a bill template owned by the user
+func (r *BillTemplateRepo) Delete(ctx context.Context, id int, userID int) error {
+	query := `DELETE FROM bill_templates WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete bill template: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bill template not found")
+	}
+
+	return nil
+}
+
+// scanBillTemplate scans a bill template row, decoding its field values
+func scanBillTemplate(row interface{ Scan(...interface{}) error }) (*models.BillTemplate, error) {
+	template := &models.BillTemplate{}
+	var fields []byte
+
+	err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.ProviderID,
+		&template.ProviderName,
+		&template.AccountID,
+		&template.Amount,
+		&fields,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fields, &template.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode template fields: %w", err)
+	}
+
+	return template, nil
+}
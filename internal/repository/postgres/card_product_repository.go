@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// CardProductRepo is a PostgreSQL implementation of the repository.CardProductRepository interface
+type CardProductRepo struct {
+	db *sql.DB
+}
+
+// NewCardProductRepository creates a new CardProductRepo
+func NewCardProductRepository(db *sql.DB) *CardProductRepo {
+	return &CardProductRepo{db: db}
+}
+
+// cardProductColumns lists the columns read by scanCardProduct
+const cardProductColumns = `id, code, name, card_type, bins, account_types, limits, fees, cashback, is_active,
+             tenant, created_at, updated_at`
+
+// Create saves a new card product in the tenant of the request
+func (r *CardProductRepo) Create(ctx context.Context, product *models.CardProduct) (int, error) {
+	values, err := encodeCardProduct(product)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO card_products (code, name, card_type, bins, account_types, limits, fees, cashback, is_active, tenant)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at`
+
+	product.Tenant = newRecordTenant(ctx, product.Tenant)
+
+	args := append([]interface{}{product.Code, product.Name, product.CardType}, values...)
+	err = r.db.QueryRowContext(ctx, query, append(args, product.IsActive, product.Tenant)...).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create card product: %w", err)
+	}
+
+	return product.ID, nil
+}
+
+// GetByID gets a card product by ID
+func (r *CardProductRepo) GetByID(ctx context.Context, id int) (*models.CardProduct, error) {
+	query := `SELECT ` + cardProductColumns + ` FROM card_products WHERE id = $1 AND ($2 = '' OR tenant = $2)`
+
+	product, err := scanCardProduct(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("card product not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get card product: %w", err)
+	}
+
+	return product, nil
+}
+
+// GetAll gets all card products of the request's tenant, including inactive ones
+func (r *CardProductRepo) GetAll(ctx context.Context) ([]*models.CardProduct, error) {
+	query := `SELECT ` + cardProductColumns + ` FROM card_products WHERE $1 = '' OR tenant = $1 ORDER BY name, id`
+
+	rows, err := r.db.QueryContext(ctx, query, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*models.CardProduct{}
+	for rows.Next() {
+		product, err := scanCardProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return products, nil
+}
+
+// Update replaces the data of a card product; cards already issued keep their numbers and type
+func (r *CardProductRepo) Update(ctx context.Context, product *models.CardProduct) error {
+	values, err := encodeCardProduct(product)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE card_products
+             SET code = $1, name = $2, card_type = $3, bins = $4, account_types = $5, limits = $6,
+             fees = $7, cashback = $8, is_active = $9
+             WHERE id = $10 AND ($11 = '' OR tenant = $11)
+             RETURNING tenant, created_at, updated_at`
+
+	args := append([]interface{}{product.Code, product.Name, product.CardType}, values...)
+	err = r.db.QueryRowContext(ctx, query, append(args, product.IsActive, product.ID, requestTenant(ctx))...).Scan(&product.Tenant, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("card product not found: %w", err)
+		}
+		return fmt.Errorf("failed to update card product: %w", err)
+	}
+
+	return nil
+}
+
+// encodeCardProduct encodes the BINs, account types, limits, fees and cashback of a card product
+// as JSON, in the order of their columns
+func encodeCardProduct(product *models.CardProduct) ([]interface{}, error) {
+	var values []interface{}
+	for _, field := range []interface{}{product.BINs, product.AccountTypes, product.Limits, product.Fees, product.Cashback} {
+		value, err := json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode card product: %w", err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// scanCardProduct scans a card product row, decoding its JSON fields
+func scanCardProduct(row interface{ Scan(...interface{}) error }) (*models.CardProduct, error) {
+	product := &models.CardProduct{}
+	var bins, accountTypes, limits, fees, cashback []byte
+
+	err := row.Scan(
+		&product.ID,
+		&product.Code,
+		&product.Name,
+		&product.CardType,
+		&bins,
+		&accountTypes,
+		&limits,
+		&fees,
+		&cashback,
+		&product.IsActive,
+		&product.Tenant,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct {
+		data []byte
+		dest interface{}
+	}{{bins, &product.BINs}, {accountTypes, &product.AccountTypes}, {limits, &product.Limits}, {fees, &product.Fees}, {cashback, &product.Cashback}} {
+		if err := json.Unmarshal(field.data, field.dest); err != nil {
+			return nil, fmt.Errorf("failed to decode card product: %w", err)
+		}
+	}
+
+	return product, nil
+}
@@ -19,17 +19,18 @@ func NewCardRepository(db *sql.DB) *CardRepo {
 	return &CardRepo{db: db}
 }
 
-// Create creates a new card in the database
+// Create creates a new card in the database; it belongs to the tenant of its account
 func (r *CardRepo) Create(ctx context.Context, card *models.Card) (int, error) {
-	query := `INSERT INTO cards (account_id, card_number_encrypted, card_number_hmac, 
-             expiry_date_encrypted, cvv_hash, card_type, is_active) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	query := `INSERT INTO cards (account_id, product_id, card_number_encrypted, card_number_hmac, 
+             expiry_date_encrypted, cvv_hash, card_type, is_active, tenant) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT tenant FROM accounts WHERE id = $1)) RETURNING id`
 	
 	var id int
 	err := r.db.QueryRowContext(
 		ctx,
 		query,
 		card.AccountID,
+		card.ProductID,
 		card.CardNumberEncrypted,
 		card.CardNumberHMAC,
 		card.ExpiryDateEncrypted,
@@ -47,14 +48,15 @@ func (r *CardRepo) Create(ctx context.Context, card *models.Card) (int, error) {
 
 // GetByID gets a card by ID
 func (r *CardRepo) GetByID(ctx context.Context, id int) (*models.Card, error) {
-	query := `SELECT id, account_id, card_number_encrypted, card_number_hmac, 
+	query := `SELECT id, account_id, product_id, card_number_encrypted, card_number_hmac, 
               expiry_date_encrypted, cvv_hash, card_type, is_active, created_at, updated_at 
-              FROM cards WHERE id = $1`
+              FROM cards WHERE id = $1 AND ($2 = '' OR tenant = $2)`
 	
 	card := &models.Card{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)).Scan(
 		&card.ID,
 		&card.AccountID,
+		&card.ProductID,
 		&card.CardNumberEncrypted,
 		&card.CardNumberHMAC,
 		&card.ExpiryDateEncrypted,
@@ -77,11 +79,11 @@ func (r *CardRepo) GetByID(ctx context.Context, id int) (*models.Card, error) {
 
 // GetByAccountID gets all cards for an account
 func (r *CardRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Card, error) {
-	query := `SELECT id, account_id, card_number_encrypted, card_number_hmac, 
+	query := `SELECT id, account_id, product_id, card_number_encrypted, card_number_hmac, 
               expiry_date_encrypted, cvv_hash, card_type, is_active, created_at, updated_at 
-              FROM cards WHERE account_id = $1`
+              FROM cards WHERE account_id = $1 AND ($2 = '' OR tenant = $2)`
 	
-	rows, err := r.db.QueryContext(ctx, query, accountID)
+	rows, err := r.db.QueryContext(ctx, query, accountID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cards: %w", err)
 	}
@@ -93,6 +95,7 @@ func (r *CardRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models
 		err := rows.Scan(
 			&card.ID,
 			&card.AccountID,
+			&card.ProductID,
 			&card.CardNumberEncrypted,
 			&card.CardNumberHMAC,
 			&card.ExpiryDateEncrypted,
@@ -117,13 +120,13 @@ func (r *CardRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models
 
 // GetByUserID gets all cards for a user through their accounts
 func (r *CardRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Card, error) {
-	query := `SELECT c.id, c.account_id, c.card_number_encrypted, c.card_number_hmac, 
+	query := `SELECT c.id, c.account_id, c.product_id, c.card_number_encrypted, c.card_number_hmac, 
               c.expiry_date_encrypted, c.cvv_hash, c.card_type, c.is_active, c.created_at, c.updated_at 
               FROM cards c
               JOIN accounts a ON c.account_id = a.id
-              WHERE a.user_id = $1`
+              WHERE a.user_id = $1 AND a.organization_id IS NULL AND ($2 = '' OR c.tenant = $2)`
 	
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cards: %w", err)
 	}
@@ -135,6 +138,7 @@ func (r *CardRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Card,
 		err := rows.Scan(
 			&card.ID,
 			&card.AccountID,
+			&card.ProductID,
 			&card.CardNumberEncrypted,
 			&card.CardNumberHMAC,
 			&card.ExpiryDateEncrypted,
@@ -206,4 +210,140 @@ func (r *CardRepo) Delete(ctx context.Context, id int) error {
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// GetAll gets all cards of the request's tenant, including deactivated ones
+func (r *CardRepo) GetAll(ctx context.Context) ([]*models.Card, error) {
+	query := `SELECT id, account_id, product_id, card_number_encrypted, card_number_hmac, 
+              expiry_date_encrypted, cvv_hash, card_type, is_active, created_at, updated_at 
+              FROM cards WHERE $1 = '' OR tenant = $1 ORDER BY id`
+	
+	rows, err := r.db.QueryContext(ctx, query, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards: %w", err)
+	}
+	defer rows.Close()
+	
+	var cards []*models.Card
+	for rows.Next() {
+		card := &models.Card{}
+		err := rows.Scan(
+			&card.ID,
+			&card.AccountID,
+			&card.ProductID,
+			&card.CardNumberEncrypted,
+			&card.CardNumberHMAC,
+			&card.ExpiryDateEncrypted,
+			&card.CVVHash,
+			&card.CardType,
+			&card.IsActive,
+			&card.CreatedAt,
+			&card.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card: %w", err)
+		}
+		cards = append(cards, card)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	
+	return cards, nil
+}
+
+// UpdateEncrypted replaces the encrypted card number and expiry date of a card
+func (r *CardRepo) UpdateEncrypted(ctx context.Context, card *models.Card) error {
+	query := `UPDATE cards 
+              SET card_number_encrypted = $1, expiry_date_encrypted = $2 
+              WHERE id = $3`
+	
+	result, err := r.db.ExecContext(ctx, query, card.CardNumberEncrypted, card.ExpiryDateEncrypted, card.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update card encryption: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("card not found")
+	}
+	
+	return nil
+}
+
+// GetByNumberHMAC gets a card with its PIN hash and wrong PIN count by the HMAC of its number
+func (r *CardRepo) GetByNumberHMAC(ctx context.Context, numberHMAC string) (*models.Card, error) {
+	query := `SELECT id, account_id, product_id, card_number_encrypted, card_number_hmac, 
+              expiry_date_encrypted, cvv_hash, COALESCE(pin_hash, ''), pin_attempts, card_type, is_active, created_at, updated_at 
+              FROM cards WHERE card_number_hmac = $1 AND ($2 = '' OR tenant = $2)`
+	
+	card := &models.Card{}
+	err := r.db.QueryRowContext(ctx, query, numberHMAC, requestTenant(ctx)).Scan(
+		&card.ID,
+		&card.AccountID,
+		&card.ProductID,
+		&card.CardNumberEncrypted,
+		&card.CardNumberHMAC,
+		&card.ExpiryDateEncrypted,
+		&card.CVVHash,
+		&card.PINHash,
+		&card.PINAttempts,
+		&card.CardType,
+		&card.IsActive,
+		&card.CreatedAt,
+		&card.UpdatedAt,
+	)
+	
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("card not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	
+	return card, nil
+}
+
+// SetPIN sets the PIN hash of a card and clears its wrong PIN count
+func (r *CardRepo) SetPIN(ctx context.Context, id int, pinHash string) error {
+	query := `UPDATE cards SET pin_hash = $1, pin_attempts = 0 WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, pinHash, id)
+	if err != nil {
+		return fmt.Errorf("failed to set card PIN: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("card not found")
+	}
+	
+	return nil
+}
+
+// RecordPINAttempt clears the wrong PIN count of a card after a correct PIN and increments it
+// after a wrong one; it returns the new count
+func (r *CardRepo) RecordPINAttempt(ctx context.Context, id int, correct bool) (int, error) {
+	query := `UPDATE cards SET pin_attempts = CASE WHEN $1 THEN 0 ELSE pin_attempts + 1 END 
+              WHERE id = $2 RETURNING pin_attempts`
+	
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, correct, id).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("card not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to record PIN attempt: %w", err)
+	}
+	
+	return attempts, nil
+}
@@ -0,0 +1,226 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// chargebackColumns are the columns selected for a chargeback joined with its merchant and payment intent
+const chargebackColumns = `c.id, c.user_id, c.merchant_id, m.name, i.intent_id, c.original_transaction_id,
+             c.account_id, c.amount, c.reason, c.details, c.status, c.evidence, c.resolution_note,
+             c.provisional_credit_transaction_id, c.merchant_debit_transaction_id, c.reversal_transaction_id,
+             c.evidence_due_at, c.evidence_submitted_at, c.resolved_at, c.created_at, c.updated_at
+             FROM chargebacks c
+             JOIN merchants m ON m.id = c.merchant_id
+             JOIN payment_intents i ON i.id = c.payment_intent_id`
+
+// ChargebackRepo is a PostgreSQL implementation of the repository.ChargebackRepository interface
+type ChargebackRepo struct {
+	db *sql.DB
+}
+
+// NewChargebackRepository creates a new ChargebackRepo
+func NewChargebackRepository(db *sql.DB) *ChargebackRepo {
+	return &ChargebackRepo{db: db}
+}
+
+// CreateTx creates a new chargeback within an existing transaction
+func (r *ChargebackRepo) CreateTx(ctx context.Context, tx *sql.Tx, chargeback *models.Chargeback, paymentIntentID int) (int, error) {
+	query := `INSERT INTO chargebacks (user_id, merchant_id, payment_intent_id, original_transaction_id,
+             account_id, amount, reason, details, status, provisional_credit_transaction_id,
+             merchant_debit_transaction_id, evidence_due_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		chargeback.UserID,
+		chargeback.MerchantID,
+		paymentIntentID,
+		chargeback.OriginalTransactionID,
+		chargeback.AccountID,
+		chargeback.Amount,
+		chargeback.Reason,
+		chargeback.Details,
+		chargeback.Status,
+		chargeback.ProvisionalCreditTxID,
+		chargeback.MerchantDebitTxID,
+		chargeback.EvidenceDueAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create chargeback: %w", err)
+	}
+
+	return id, nil
+}
+
+// ExistsForTransaction reports whether a payment already has a chargeback
+func (r *ChargebackRepo) ExistsForTransaction(ctx context.Context, transactionID int) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM chargebacks WHERE original_transaction_id = $1)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, transactionID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check chargeback: %w", err)
+	}
+
+	return exists, nil
+}
+
+// GetByID gets a chargeback of the request's tenant, that of the cardholder's account, by ID
+func (r *ChargebackRepo) GetByID(ctx context.Context, id int) (*models.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` WHERE c.id = $1 AND ` + accountInTenant("c.account_id", "$2")
+
+	chargeback, err := scanChargeback(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("chargeback not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+
+	return chargeback, nil
+}
+
+// GetByUserID gets the chargebacks opened by a cardholder, newest first
+func (r *ChargebackRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` WHERE c.user_id = $1 AND ` + accountInTenant("c.account_id", "$2") + ` ORDER BY c.created_at DESC`
+
+	return r.getChargebacks(ctx, query, userID)
+}
+
+// GetByMerchantID gets the chargebacks against a merchant, newest first
+func (r *ChargebackRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` WHERE c.merchant_id = $1 AND ` + accountInTenant("c.account_id", "$2") + ` ORDER BY c.created_at DESC`
+
+	return r.getChargebacks(ctx, query, merchantID)
+}
+
+// GetByStatus gets the chargebacks of the request's tenant in a status, all of them if the status is empty
+func (r *ChargebackRepo) GetByStatus(ctx context.Context, status models.ChargebackStatus) ([]*models.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` WHERE ($1 = '' OR c.status = $1) AND ` +
+		accountInTenant("c.account_id", "$2") + ` ORDER BY c.created_at`
+
+	return r.getChargebacks(ctx, query, status)
+}
+
+// GetEvidenceOverdue gets the open chargebacks whose merchant missed the evidence deadline
+func (r *ChargebackRepo) GetEvidenceOverdue(ctx context.Context, now time.Time) ([]*models.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` WHERE c.status = $1 AND c.evidence_due_at <= $2 ORDER BY c.evidence_due_at`
+
+	rows, err := r.db.QueryContext(ctx, query, models.ChargebackStatusOpen, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue chargebacks: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanChargebacks(rows)
+}
+
+// getChargebacks gets the chargebacks selected by a query with an argument and the request's tenant
+func (r *ChargebackRepo) getChargebacks(ctx context.Context, query string, arg interface{}) ([]*models.Chargeback, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargebacks: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanChargebacks(rows)
+}
+
+// scanChargebacks scans chargeback rows
+func (r *ChargebackRepo) scanChargebacks(rows *sql.Rows) ([]*models.Chargeback, error) {
+	chargebacks := []*models.Chargeback{}
+	for rows.Next() {
+		chargeback, err := scanChargeback(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan chargeback: %w", err)
+		}
+		chargebacks = append(chargebacks, chargeback)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return chargebacks, nil
+}
+
+// SubmitEvidence records the merchant's evidence while the chargeback is open and before the
+// deadline. It reports false if the chargeback no longer accepts evidence.
+func (r *ChargebackRepo) SubmitEvidence(ctx context.Context, id int, evidence string) (bool, error) {
+	query := `UPDATE chargebacks
+             SET status = $1, evidence = $2, evidence_submitted_at = CURRENT_TIMESTAMP
+             WHERE id = $3 AND status = $4 AND evidence_due_at > CURRENT_TIMESTAMP`
+
+	result, err := r.db.ExecContext(ctx, query, models.ChargebackStatusEvidenceSubmitted, evidence, id, models.ChargebackStatusOpen)
+	if err != nil {
+		return false, fmt.Errorf("failed to submit evidence: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ResolveTx moves a chargeback to a final status within an existing transaction. It reports
+// false if the chargeback was not in the expected status, so it can only be resolved once.
+func (r *ChargebackRepo) ResolveTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ChargebackStatus, note string, reversalTxID *int) (bool, error) {
+	query := `UPDATE chargebacks
+             SET status = $1, resolution_note = $2, reversal_transaction_id = $3, resolved_at = CURRENT_TIMESTAMP
+             WHERE id = $4 AND status = $5`
+
+	result, err := tx.ExecContext(ctx, query, to, note, reversalTxID, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve chargeback: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanChargeback scans a chargeback row joined with its merchant and payment intent
+func scanChargeback(row interface{ Scan(...interface{}) error }) (*models.Chargeback, error) {
+	chargeback := &models.Chargeback{}
+	err := row.Scan(
+		&chargeback.ID,
+		&chargeback.UserID,
+		&chargeback.MerchantID,
+		&chargeback.MerchantName,
+		&chargeback.PaymentIntentID,
+		&chargeback.OriginalTransactionID,
+		&chargeback.AccountID,
+		&chargeback.Amount,
+		&chargeback.Reason,
+		&chargeback.Details,
+		&chargeback.Status,
+		&chargeback.Evidence,
+		&chargeback.ResolutionNote,
+		&chargeback.ProvisionalCreditTxID,
+		&chargeback.MerchantDebitTxID,
+		&chargeback.ReversalTxID,
+		&chargeback.EvidenceDueAt,
+		&chargeback.EvidenceSubmittedAt,
+		&chargeback.ResolvedAt,
+		&chargeback.CreatedAt,
+		&chargeback.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return chargeback, nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// chequeDepositColumns lists the columns read by scanChequeDeposit
+const chequeDepositColumns = `SELECT id, user_id, account_id, amount, currency, recognized_amount, file_name, content_type,
+             size, storage_key, scan_status, transaction_id, status, reviewed_by, review_note, reviewed_at,
+             created_at, updated_at
+             FROM cheque_deposits`
+
+// ChequeDepositRepo is a PostgreSQL implementation of the repository.ChequeDepositRepository interface
+type ChequeDepositRepo struct {
+	db *sql.DB
+}
+
+// NewChequeDepositRepository creates a new ChequeDepositRepo
+func NewChequeDepositRepository(db *sql.DB) *ChequeDepositRepo {
+	return &ChequeDepositRepo{db: db}
+}
+
+// CreateTx records a deposited cheque within an existing transaction
+func (r *ChequeDepositRepo) CreateTx(ctx context.Context, tx *sql.Tx, deposit *models.ChequeDeposit) (int, error) {
+	query := `INSERT INTO cheque_deposits (user_id, account_id, amount, currency, recognized_amount, file_name,
+             content_type, size, storage_key, scan_status, transaction_id, status)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		deposit.UserID,
+		deposit.AccountID,
+		deposit.Amount,
+		deposit.Currency,
+		deposit.RecognizedAmount,
+		deposit.FileName,
+		deposit.ContentType,
+		deposit.Size,
+		deposit.StorageKey,
+		deposit.ScanStatus,
+		deposit.TransactionID,
+		deposit.Status,
+	).Scan(&deposit.ID, &deposit.CreatedAt, &deposit.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create cheque deposit: %w", err)
+	}
+
+	return deposit.ID, nil
+}
+
+// GetByID gets a cheque deposit of the request's tenant, that of its account, by ID
+func (r *ChequeDepositRepo) GetByID(ctx context.Context, id int) (*models.ChequeDeposit, error) {
+	query := chequeDepositColumns + ` WHERE id = $1 AND ` + accountInTenant("account_id", "$2")
+
+	deposit, err := scanChequeDeposit(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("cheque deposit not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get cheque deposit: %w", err)
+	}
+
+	return deposit, nil
+}
+
+// GetByUserID gets the cheque deposits of a user, newest first
+func (r *ChequeDepositRepo) GetByUserID(ctx context.Context, userID int) ([]*models.ChequeDeposit, error) {
+	query := chequeDepositColumns + ` WHERE user_id = $1 AND ` + accountInTenant("account_id", "$2") + ` ORDER BY id DESC`
+	return r.getChequeDeposits(ctx, query, userID)
+}
+
+// GetByStatus gets the cheque deposits of the request's tenant in a status, oldest first, all of them
+// if the status is empty
+func (r *ChequeDepositRepo) GetByStatus(ctx context.Context, status models.ChequeDepositStatus) ([]*models.ChequeDeposit, error) {
+	query := chequeDepositColumns + ` WHERE ($1 = '' OR status = $1) AND ` + accountInTenant("account_id", "$2") + ` ORDER BY id`
+	return r.getChequeDeposits(ctx, query, status)
+}
+
+// getChequeDeposits gets the cheque deposits selected by a query with an argument and the request's tenant
+func (r *ChequeDepositRepo) getChequeDeposits(ctx context.Context, query string, arg interface{}) ([]*models.ChequeDeposit, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cheque deposits: %w", err)
+	}
+	defer rows.Close()
+
+	deposits := []*models.ChequeDeposit{}
+	for rows.Next() {
+		deposit, err := scanChequeDeposit(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cheque deposit: %w", err)
+		}
+		deposits = append(deposits, deposit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deposits, nil
+}
+
+// ReviewTx moves a pending cheque deposit to the status an admin decided on within an existing
+// transaction. It reports false if the deposit has been reviewed already.
+func (r *ChequeDepositRepo) ReviewTx(ctx context.Context, tx *sql.Tx, id int, to models.ChequeDepositStatus, reviewedBy int, note string) (bool, error) {
+	query := `UPDATE cheque_deposits SET status = $1, reviewed_by = $2, review_note = $3, reviewed_at = CURRENT_TIMESTAMP
+             WHERE id = $4 AND status = $5`
+
+	result, err := tx.ExecContext(ctx, query, to, reviewedBy, note, id, models.ChequeDepositStatusPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to review cheque deposit: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanChequeDeposit scans a single cheque deposit row
+func scanChequeDeposit(row interface{ Scan(...interface{}) error }) (*models.ChequeDeposit, error) {
+	deposit := &models.ChequeDeposit{}
+	err := row.Scan(
+		&deposit.ID,
+		&deposit.UserID,
+		&deposit.AccountID,
+		&deposit.Amount,
+		&deposit.Currency,
+		&deposit.RecognizedAmount,
+		&deposit.FileName,
+		&deposit.ContentType,
+		&deposit.Size,
+		&deposit.StorageKey,
+		&deposit.ScanStatus,
+		&deposit.TransactionID,
+		&deposit.Status,
+		&deposit.ReviewedBy,
+		&deposit.ReviewNote,
+		&deposit.ReviewedAt,
+		&deposit.CreatedAt,
+		&deposit.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return deposit, nil
+}
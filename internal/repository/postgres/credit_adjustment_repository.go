@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// CreditAdjustmentRepo is a PostgreSQL implementation of the repository.CreditAdjustmentRepository interface
+type CreditAdjustmentRepo struct {
+	db *sql.DB
+}
+
+// NewCreditAdjustmentRepository creates a new CreditAdjustmentRepo
+func NewCreditAdjustmentRepository(db *sql.DB) *CreditAdjustmentRepo {
+	return &CreditAdjustmentRepo{db: db}
+}
+
+// GetByCreditID gets the adjustments of a credit, oldest first
+func (r *CreditAdjustmentRepo) GetByCreditID(ctx context.Context, creditID int) ([]*models.CreditAdjustment, error) {
+	query := `SELECT id, credit_id, payment_id, admin_id, type, reason, note, details, created_at
+             FROM credit_adjustments
+             WHERE credit_id = $1
+             ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit adjustments: %w", err)
+	}
+	defer rows.Close()
+
+	adjustments := []*models.CreditAdjustment{}
+	for rows.Next() {
+		adjustment := &models.CreditAdjustment{}
+		var paymentID sql.NullInt64
+		if err := rows.Scan(
+			&adjustment.ID,
+			&adjustment.CreditID,
+			&paymentID,
+			&adjustment.AdminID,
+			&adjustment.Type,
+			&adjustment.Reason,
+			&adjustment.Note,
+			&adjustment.Details,
+			&adjustment.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan credit adjustment: %w", err)
+		}
+		if paymentID.Valid {
+			id := int(paymentID.Int64)
+			adjustment.PaymentID = &id
+		}
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating credit adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
+
+// CreateTx records a credit adjustment within an existing transaction
+func (r *CreditAdjustmentRepo) CreateTx(ctx context.Context, tx *sql.Tx, adjustment *models.CreditAdjustment) (int, error) {
+	query := `INSERT INTO credit_adjustments (credit_id, payment_id, admin_id, type, reason, note, details)
+             VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		adjustment.CreditID,
+		adjustment.PaymentID,
+		adjustment.AdminID,
+		adjustment.Type,
+		adjustment.Reason,
+		adjustment.Note,
+		adjustment.Details,
+	).Scan(&adjustment.ID, &adjustment.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credit adjustment: %w", err)
+	}
+
+	return adjustment.ID, nil
+}
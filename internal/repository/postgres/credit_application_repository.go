@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// creditApplicationColumns lists the columns read by scanCreditApplication
+const creditApplicationColumns = `SELECT id, user_id, amount, term_months, interest_rate, insurance, status, credit_id,
+             COALESCE(review_note, ''), reviewed_by, created_at, updated_at
+             FROM credit_applications`
+
+// CreditApplicationRepo is a PostgreSQL implementation of the repository.CreditApplicationRepository interface
+type CreditApplicationRepo struct {
+	db *sql.DB
+}
+
+// NewCreditApplicationRepository creates a new CreditApplicationRepo
+func NewCreditApplicationRepository(db *sql.DB) *CreditApplicationRepo {
+	return &CreditApplicationRepo{db: db}
+}
+
+// Create creates a new credit application in the database
+func (r *CreditApplicationRepo) Create(ctx context.Context, application *models.CreditApplication) (int, error) {
+	query := `INSERT INTO credit_applications (user_id, amount, term_months, interest_rate, insurance, status)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		application.UserID,
+		application.Amount,
+		application.TermMonths,
+		application.InterestRate,
+		application.Insurance,
+		application.Status,
+	).Scan(&application.ID, &application.CreatedAt, &application.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credit application: %w", err)
+	}
+
+	return application.ID, nil
+}
+
+// GetByID gets a credit application of the request's tenant, that of its applicant, by ID
+func (r *CreditApplicationRepo) GetByID(ctx context.Context, id int) (*models.CreditApplication, error) {
+	query := creditApplicationColumns + ` WHERE id = $1 AND ` + userInTenant("user_id", "$2")
+
+	application, err := scanCreditApplication(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("credit application not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get credit application: %w", err)
+	}
+
+	return application, nil
+}
+
+// GetByUserID gets the credit applications of a user, newest first
+func (r *CreditApplicationRepo) GetByUserID(ctx context.Context, userID int) ([]*models.CreditApplication, error) {
+	query := creditApplicationColumns + ` WHERE user_id = $1 ORDER BY created_at DESC`
+
+	return r.query(ctx, query, userID)
+}
+
+// GetByStatus gets the credit applications of the request's tenant with a status, oldest first; an
+// empty status returns all
+func (r *CreditApplicationRepo) GetByStatus(ctx context.Context, status models.CreditApplicationStatus) ([]*models.CreditApplication, error) {
+	query := creditApplicationColumns + ` WHERE ($1 = '' OR status = $1) AND ` + userInTenant("user_id", "$2") + ` ORDER BY created_at`
+
+	return r.query(ctx, query, status, requestTenant(ctx))
+}
+
+// UpdateStatus moves an application from one status to another and records the reviewer.
+// It returns false if the application was not in the expected status.
+func (r *CreditApplicationRepo) UpdateStatus(ctx context.Context, id int, from, to models.CreditApplicationStatus, note string, reviewedBy *int) (bool, error) {
+	query := `UPDATE credit_applications SET status = $1, review_note = NULLIF($2, ''), reviewed_by = $3
+             WHERE id = $4 AND status = $5`
+
+	result, err := r.db.ExecContext(ctx, query, to, note, reviewedBy, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update credit application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// SetCreditID links an approved application to the credit issued for it
+func (r *CreditApplicationRepo) SetCreditID(ctx context.Context, id int, creditID int) error {
+	query := `UPDATE credit_applications SET credit_id = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, creditID, id); err != nil {
+		return fmt.Errorf("failed to link credit to application: %w", err)
+	}
+
+	return nil
+}
+
+// query runs a query returning credit applications
+func (r *CreditApplicationRepo) query(ctx context.Context, query string, args ...interface{}) ([]*models.CreditApplication, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit applications: %w", err)
+	}
+	defer rows.Close()
+
+	applications := []*models.CreditApplication{}
+	for rows.Next() {
+		application, err := scanCreditApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit application: %w", err)
+		}
+		applications = append(applications, application)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return applications, nil
+}
+
+// scanCreditApplication scans a single credit application row
+func scanCreditApplication(row interface{ Scan(...interface{}) error }) (*models.CreditApplication, error) {
+	application := &models.CreditApplication{}
+	err := row.Scan(
+		&application.ID,
+		&application.UserID,
+		&application.Amount,
+		&application.TermMonths,
+		&application.InterestRate,
+		&application.Insurance,
+		&application.Status,
+		&application.CreditID,
+		&application.ReviewNote,
+		&application.ReviewedBy,
+		&application.CreatedAt,
+		&application.UpdatedAt,
+	)
+	return application, err
+}
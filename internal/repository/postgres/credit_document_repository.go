@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// creditDocumentColumns lists the columns read by scanCreditDocument
+const creditDocumentColumns = `SELECT id, application_id, user_id, type, file_name, content_type, size,
+             storage_key, scan_status, status, COALESCE(review_note, ''), reviewed_at, created_at
+             FROM credit_documents`
+
+// CreditDocumentRepo is a PostgreSQL implementation of the repository.CreditDocumentRepository interface
+type CreditDocumentRepo struct {
+	db *sql.DB
+}
+
+// NewCreditDocumentRepository creates a new CreditDocumentRepo
+func NewCreditDocumentRepository(db *sql.DB) *CreditDocumentRepo {
+	return &CreditDocumentRepo{db: db}
+}
+
+// Create records an uploaded document in the database
+func (r *CreditDocumentRepo) Create(ctx context.Context, doc *models.CreditDocument) (int, error) {
+	query := `INSERT INTO credit_documents (application_id, user_id, type, file_name, content_type, size,
+             storage_key, scan_status, status)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		doc.ApplicationID,
+		doc.UserID,
+		doc.Type,
+		doc.FileName,
+		doc.ContentType,
+		doc.Size,
+		doc.StorageKey,
+		doc.ScanStatus,
+		doc.Status,
+	).Scan(&doc.ID, &doc.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credit document: %w", err)
+	}
+
+	return doc.ID, nil
+}
+
+// GetByID gets a credit document by ID
+func (r *CreditDocumentRepo) GetByID(ctx context.Context, id int) (*models.CreditDocument, error) {
+	query := creditDocumentColumns + ` WHERE id = $1`
+
+	doc, err := scanCreditDocument(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("credit document not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get credit document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// GetByApplicationID gets the documents of a credit application in upload order
+func (r *CreditDocumentRepo) GetByApplicationID(ctx context.Context, applicationID int) ([]*models.CreditDocument, error) {
+	query := creditDocumentColumns + ` WHERE application_id = $1 ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*models.CreditDocument{}
+	for rows.Next() {
+		doc, err := scanCreditDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan credit document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return docs, nil
+}
+
+// Review records the bank's review of a document
+func (r *CreditDocumentRepo) Review(ctx context.Context, id int, status models.CreditDocumentStatus, note string) error {
+	query := `UPDATE credit_documents SET status = $1, review_note = NULLIF($2, ''), reviewed_at = CURRENT_TIMESTAMP
+             WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, status, note, id)
+	if err != nil {
+		return fmt.Errorf("failed to review credit document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("credit document not found")
+	}
+
+	return nil
+}
+
+// scanCreditDocument scans a single credit document row
+func scanCreditDocument(row interface{ Scan(...interface{}) error }) (*models.CreditDocument, error) {
+	doc := &models.CreditDocument{}
+	err := row.Scan(
+		&doc.ID,
+		&doc.ApplicationID,
+		&doc.UserID,
+		&doc.Type,
+		&doc.FileName,
+		&doc.ContentType,
+		&doc.Size,
+		&doc.StorageKey,
+		&doc.ScanStatus,
+		&doc.Status,
+		&doc.ReviewNote,
+		&doc.ReviewedAt,
+		&doc.CreatedAt,
+	)
+	return doc, err
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"banking-service/internal/models"
 )
@@ -19,11 +20,11 @@ func NewCreditRepository(db *sql.DB) *CreditRepo {
 	return &CreditRepo{db: db}
 }
 
-// Create creates a new credit in the database
+// Create creates a new credit in the database; it belongs to the tenant of its account
 func (r *CreditRepo) Create(ctx context.Context, credit *models.Credit) (int, error) {
 	query := `INSERT INTO credits (user_id, account_id, amount, interest_rate, term_months, 
-             monthly_payment, start_date, end_date, status) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+             monthly_payment, start_date, end_date, status, day_count, tenant) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, (SELECT tenant FROM accounts WHERE id = $2)) RETURNING id`
 	
 	var id int
 	err := r.db.QueryRowContext(
@@ -38,6 +39,7 @@ func (r *CreditRepo) Create(ctx context.Context, credit *models.Credit) (int, er
 		credit.StartDate,
 		credit.EndDate,
 		credit.Status,
+		credit.DayCount,
 	).Scan(&id)
 	
 	if err != nil {
@@ -50,11 +52,11 @@ func (r *CreditRepo) Create(ctx context.Context, credit *models.Credit) (int, er
 // GetByID gets a credit by ID
 func (r *CreditRepo) GetByID(ctx context.Context, id int) (*models.Credit, error) {
 	query := `SELECT id, user_id, account_id, amount, interest_rate, term_months, 
-             monthly_payment, start_date, end_date, status, created_at, updated_at 
-             FROM credits WHERE id = $1`
+             monthly_payment, start_date, end_date, status, day_count, created_at, updated_at 
+             FROM credits WHERE id = $1 AND ($2 = '' OR tenant = $2)`
 	
 	credit := &models.Credit{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)).Scan(
 		&credit.ID,
 		&credit.UserID,
 		&credit.AccountID,
@@ -65,6 +67,7 @@ func (r *CreditRepo) GetByID(ctx context.Context, id int) (*models.Credit, error
 		&credit.StartDate,
 		&credit.EndDate,
 		&credit.Status,
+		&credit.DayCount,
 		&credit.CreatedAt,
 		&credit.UpdatedAt,
 	)
@@ -82,11 +85,11 @@ func (r *CreditRepo) GetByID(ctx context.Context, id int) (*models.Credit, error
 // GetByUserID gets all credits for a user
 func (r *CreditRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Credit, error) {
 	query := `SELECT id, user_id, account_id, amount, interest_rate, term_months, 
-             monthly_payment, start_date, end_date, status, created_at, updated_at 
-             FROM credits WHERE user_id = $1
+             monthly_payment, start_date, end_date, status, day_count, created_at, updated_at 
+             FROM credits WHERE user_id = $1 AND ($2 = '' OR tenant = $2)
              ORDER BY created_at DESC`
 	
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credits: %w", err)
 	}
@@ -95,14 +98,31 @@ func (r *CreditRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Cre
 	return r.scanCredits(rows)
 }
 
+// GetChanged gets up to limit credits created or changed in [from, to) with an ID greater than
+// afterID, in ID order
+func (r *CreditRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Credit, error) {
+	query := `SELECT id, user_id, account_id, amount, interest_rate, term_months, 
+             monthly_payment, start_date, end_date, status, day_count, created_at, updated_at 
+             FROM credits WHERE updated_at >= $1 AND updated_at < $2 AND id > $3
+             ORDER BY id LIMIT $4`
+	
+	rows, err := r.db.QueryContext(ctx, query, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed credits: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanCredits(rows)
+}
+
 // GetByAccountID gets all credits for an account
 func (r *CreditRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Credit, error) {
 	query := `SELECT id, user_id, account_id, amount, interest_rate, term_months, 
-             monthly_payment, start_date, end_date, status, created_at, updated_at 
-             FROM credits WHERE account_id = $1
+             monthly_payment, start_date, end_date, status, day_count, created_at, updated_at 
+             FROM credits WHERE account_id = $1 AND ($2 = '' OR tenant = $2)
              ORDER BY created_at DESC`
 	
-	rows, err := r.db.QueryContext(ctx, query, accountID)
+	rows, err := r.db.QueryContext(ctx, query, accountID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credits: %w", err)
 	}
@@ -141,10 +161,42 @@ func (r *CreditRepo) Update(ctx context.Context, credit *models.Credit) error {
 	return nil
 }
 
+// UpdateTx updates the status and terms of a credit within an existing transaction
+func (r *CreditRepo) UpdateTx(ctx context.Context, tx *sql.Tx, credit *models.Credit) error {
+	query := `UPDATE credits 
+             SET status = $1, term_months = $2, monthly_payment = $3, end_date = $4
+             WHERE id = $5`
+	
+	result, err := tx.ExecContext(
+		ctx,
+		query,
+		credit.Status,
+		credit.TermMonths,
+		credit.MonthlyPayment,
+		credit.EndDate,
+		credit.ID,
+	)
+	
+	if err != nil {
+		return fmt.Errorf("failed to update credit: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("credit not found")
+	}
+	
+	return nil
+}
+
 // GetActiveCredits gets all active credits for automatic payment processing
 func (r *CreditRepo) GetActiveCredits(ctx context.Context) ([]*models.Credit, error) {
 	query := `SELECT id, user_id, account_id, amount, interest_rate, term_months, 
-             monthly_payment, start_date, end_date, status, created_at, updated_at 
+             monthly_payment, start_date, end_date, status, day_count, created_at, updated_at 
              FROM credits 
              WHERE status = $1
              ORDER BY created_at`
@@ -175,6 +227,7 @@ func (r *CreditRepo) scanCredits(rows *sql.Rows) ([]*models.Credit, error) {
 			&credit.StartDate,
 			&credit.EndDate,
 			&credit.Status,
+			&credit.DayCount,
 			&credit.CreatedAt,
 			&credit.UpdatedAt,
 		)
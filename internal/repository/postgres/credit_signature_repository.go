@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// CreditSignatureRepo is a PostgreSQL implementation of the repository.CreditSignatureRepository interface
+type CreditSignatureRepo struct {
+	db *sql.DB
+}
+
+// NewCreditSignatureRepository creates a new CreditSignatureRepo
+func NewCreditSignatureRepository(db *sql.DB) *CreditSignatureRepo {
+	return &CreditSignatureRepo{db: db}
+}
+
+// CreateRequest creates a new signature request in the database
+func (r *CreditSignatureRepo) CreateRequest(ctx context.Context, request *models.SignatureRequest) (int, error) {
+	query := `INSERT INTO credit_signature_requests (request_id, credit_id, user_id, document_hash, channel,
+             code_hash, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		request.RequestID,
+		request.CreditID,
+		request.UserID,
+		request.DocumentHash,
+		request.Channel,
+		request.CodeHash,
+		request.Status,
+		request.ExpiresAt,
+	).Scan(&request.ID, &request.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create signature request: %w", err)
+	}
+
+	return request.ID, nil
+}
+
+// GetRequestByRequestID gets a signature request by its public request ID
+func (r *CreditSignatureRepo) GetRequestByRequestID(ctx context.Context, requestID string) (*models.SignatureRequest, error) {
+	query := `SELECT id, request_id, credit_id, user_id, document_hash, channel, code_hash, attempts,
+             status, expires_at, created_at
+             FROM credit_signature_requests WHERE request_id = $1`
+
+	request := &models.SignatureRequest{}
+	err := r.db.QueryRowContext(ctx, query, requestID).Scan(
+		&request.ID,
+		&request.RequestID,
+		&request.CreditID,
+		&request.UserID,
+		&request.DocumentHash,
+		&request.Channel,
+		&request.CodeHash,
+		&request.Attempts,
+		&request.Status,
+		&request.ExpiresAt,
+		&request.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("signature request not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get signature request: %w", err)
+	}
+
+	return request, nil
+}
+
+// IncrementAttempts records a failed code check and returns the new number of attempts
+func (r *CreditSignatureRepo) IncrementAttempts(ctx context.Context, id int) (int, error) {
+	query := `UPDATE credit_signature_requests SET attempts = attempts + 1
+             WHERE id = $1 RETURNING attempts`
+
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts); err != nil {
+		return 0, fmt.Errorf("failed to update attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// UpdateRequestStatus moves a signature request from one status to another. It reports false
+// if the request was not in the expected status.
+func (r *CreditSignatureRepo) UpdateRequestStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error) {
+	query := `UPDATE credit_signature_requests SET status = $1
+             WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update signature request status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// GetByCreditID gets the signature of a credit's agreement
+func (r *CreditSignatureRepo) GetByCreditID(ctx context.Context, creditID int) (*models.CreditSignature, error) {
+	query := `SELECT id, credit_id, user_id, version, document_hash, otp_channel, ip_address, user_agent, signed_at
+             FROM credit_signatures WHERE credit_id = $1`
+
+	signature := &models.CreditSignature{}
+	err := r.db.QueryRowContext(ctx, query, creditID).Scan(
+		&signature.ID,
+		&signature.CreditID,
+		&signature.UserID,
+		&signature.Version,
+		&signature.DocumentHash,
+		&signature.OTPChannel,
+		&signature.IPAddress,
+		&signature.UserAgent,
+		&signature.SignedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("credit signature not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get credit signature: %w", err)
+	}
+
+	return signature, nil
+}
+
+// UpdateRequestStatusTx moves a signature request from one status to another within a transaction
+func (r *CreditSignatureRepo) UpdateRequestStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ConfirmationStatus) (bool, error) {
+	query := `UPDATE credit_signature_requests SET status = $1
+             WHERE id = $2 AND status = $3`
+
+	result, err := tx.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update signature request status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// CreateTx stores a signature within a transaction. A credit can only be signed once.
+func (r *CreditSignatureRepo) CreateTx(ctx context.Context, tx *sql.Tx, signature *models.CreditSignature) (int, error) {
+	query := `INSERT INTO credit_signatures (credit_id, user_id, version, document_hash, otp_channel, ip_address, user_agent)
+             VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, signed_at`
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		signature.CreditID,
+		signature.UserID,
+		signature.Version,
+		signature.DocumentHash,
+		signature.OTPChannel,
+		signature.IPAddress,
+		signature.UserAgent,
+	).Scan(&signature.ID, &signature.SignedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create credit signature: %w", err)
+	}
+
+	return signature.ID, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// DeviceRepo is a PostgreSQL implementation of the repository.DeviceRepository interface
+type DeviceRepo struct {
+	db *sql.DB
+}
+
+// NewDeviceRepository creates a new DeviceRepo
+func NewDeviceRepository(db *sql.DB) *DeviceRepo {
+	return &DeviceRepo{db: db}
+}
+
+// Touch records a login from a device and reports whether the device was seen for the first time
+func (r *DeviceRepo) Touch(ctx context.Context, device *models.Device) (bool, error) {
+	query := `INSERT INTO user_devices (user_id, fingerprint, ip_address, user_agent)
+             VALUES ($1, $2, $3, $4)
+             ON CONFLICT (user_id, fingerprint) DO UPDATE SET last_seen_at = NOW()
+             RETURNING (xmax = 0)`
+
+	var inserted bool
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		device.UserID,
+		device.Fingerprint,
+		device.IPAddress,
+		device.UserAgent,
+	).Scan(&inserted)
+
+	if err != nil {
+		return false, fmt.Errorf("failed to record device: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// CountByUserID counts the known devices of a user
+func (r *DeviceRepo) CountByUserID(ctx context.Context, userID int) (int, error) {
+	query := `SELECT COUNT(*) FROM user_devices WHERE user_id = $1`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count devices: %w", err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// EmailRepo is a PostgreSQL implementation of the repository.EmailRepository interface
+type EmailRepo struct {
+	db *sql.DB
+}
+
+// NewEmailRepository creates a new EmailRepo
+func NewEmailRepository(db *sql.DB) *EmailRepo {
+	return &EmailRepo{db: db}
+}
+
+// emailDeliveryColumns lists the columns read by scanEmailDelivery
+const emailDeliveryColumns = `id, message_id, user_id, tenant, recipient, subject, status, response, opened_at, created_at, updated_at`
+
+// CreateDelivery records an email sent to a customer
+func (r *EmailRepo) CreateDelivery(ctx context.Context, delivery *models.EmailDelivery) (int, error) {
+	query := `INSERT INTO email_deliveries (message_id, user_id, tenant, recipient, subject, status, response)
+             VALUES ($1, $2, $3, $4, $5, $6, $7)
+             RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		delivery.MessageID,
+		delivery.UserID,
+		delivery.Tenant,
+		delivery.Recipient,
+		delivery.Subject,
+		delivery.Status,
+		delivery.Response,
+	).Scan(&delivery.ID, &delivery.CreatedAt, &delivery.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create email delivery: %w", err)
+	}
+
+	return delivery.ID, nil
+}
+
+// GetDeliveries gets the most recent deliveries of the request's tenant matching a filter, newest first
+func (r *EmailRepo) GetDeliveries(ctx context.Context, filter *models.EmailDeliveryFilter) ([]*models.EmailDelivery, error) {
+	query := `SELECT ` + emailDeliveryColumns + ` FROM email_deliveries
+             WHERE ($1 = '' OR status = $1) AND ($2 = '' OR recipient = $2) AND ($4 = '' OR tenant = $4)
+             ORDER BY id DESC LIMIT $3`
+
+	rows, err := r.db.QueryContext(ctx, query, filter.Status, filter.Recipient, filter.Limit, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.EmailDelivery{}
+	for rows.Next() {
+		delivery, err := scanEmailDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveryByMessageID gets the delivery of the email with a Message-ID
+func (r *EmailRepo) GetDeliveryByMessageID(ctx context.Context, messageID string) (*models.EmailDelivery, error) {
+	query := `SELECT ` + emailDeliveryColumns + ` FROM email_deliveries WHERE message_id = $1`
+
+	delivery, err := scanEmailDelivery(r.db.QueryRowContext(ctx, query, messageID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("email delivery not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get email delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// UpdateDelivery saves the status, response and open time of a delivery
+func (r *EmailRepo) UpdateDelivery(ctx context.Context, delivery *models.EmailDelivery) error {
+	query := `UPDATE email_deliveries SET status = $2, response = $3, opened_at = $4
+             WHERE id = $1
+             RETURNING updated_at`
+
+	err := r.db.QueryRowContext(ctx, query, delivery.ID, delivery.Status, delivery.Response, delivery.OpenedAt).Scan(&delivery.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("email delivery not found: %w", err)
+		}
+		return fmt.Errorf("failed to update email delivery: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSuppression stops the emails to an address, or returns 0 if it is suppressed already
+func (r *EmailRepo) CreateSuppression(ctx context.Context, suppression *models.EmailSuppression) (int, error) {
+	query := `INSERT INTO email_suppressions (email, reason, delivery_id, details)
+             VALUES ($1, $2, $3, $4)
+             ON CONFLICT (email) DO NOTHING
+             RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		suppression.Email,
+		suppression.Reason,
+		suppression.DeliveryID,
+		suppression.Details,
+	).Scan(&suppression.ID, &suppression.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create email suppression: %w", err)
+	}
+
+	return suppression.ID, nil
+}
+
+// GetSuppression gets the suppression of an address
+func (r *EmailRepo) GetSuppression(ctx context.Context, email string) (*models.EmailSuppression, error) {
+	query := `SELECT id, email, reason, delivery_id, details, created_at
+             FROM email_suppressions WHERE email = $1`
+
+	suppression, err := scanEmailSuppression(r.db.QueryRowContext(ctx, query, email))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("email is not suppressed: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get email suppression: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// GetSuppressions gets the suppressed addresses, newest first
+func (r *EmailRepo) GetSuppressions(ctx context.Context) ([]*models.EmailSuppression, error) {
+	query := `SELECT id, email, reason, delivery_id, details, created_at
+             FROM email_suppressions ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email suppressions: %w", err)
+	}
+	defer rows.Close()
+
+	suppressions := []*models.EmailSuppression{}
+	for rows.Next() {
+		suppression, err := scanEmailSuppression(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan email suppression: %w", err)
+		}
+		suppressions = append(suppressions, suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// DeleteSuppression lifts a suppression, reporting whether it existed
+func (r *EmailRepo) DeleteSuppression(ctx context.Context, id int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM email_suppressions WHERE id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete email suppression: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete email suppression: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanEmailDelivery scans an email delivery row
+func scanEmailDelivery(row interface{ Scan(...interface{}) error }) (*models.EmailDelivery, error) {
+	delivery := &models.EmailDelivery{}
+	var userID sql.NullInt64
+	var openedAt sql.NullTime
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.MessageID,
+		&userID,
+		&delivery.Tenant,
+		&delivery.Recipient,
+		&delivery.Subject,
+		&delivery.Status,
+		&delivery.Response,
+		&openedAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		id := int(userID.Int64)
+		delivery.UserID = &id
+	}
+	if openedAt.Valid {
+		delivery.OpenedAt = &openedAt.Time
+	}
+
+	return delivery, nil
+}
+
+// scanEmailSuppression scans an email suppression row
+func scanEmailSuppression(row interface{ Scan(...interface{}) error }) (*models.EmailSuppression, error) {
+	suppression := &models.EmailSuppression{}
+	var deliveryID sql.NullInt64
+
+	err := row.Scan(
+		&suppression.ID,
+		&suppression.Email,
+		&suppression.Reason,
+		&deliveryID,
+		&suppression.Details,
+		&suppression.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deliveryID.Valid {
+		id := int(deliveryID.Int64)
+		suppression.DeliveryID = &id
+	}
+
+	return suppression, nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// escrowColumns lists the columns read by scanEscrow
+const escrowColumns = `id, buyer_id, seller_id, buyer_account_id, seller_account_id, amount, currency, description,
+             status, buyer_confirmed_at, seller_confirmed_at, expires_at, funding_transaction_id,
+             settlement_transaction_id, resolved_by, resolution_note, settled_at, created_at, updated_at`
+
+// EscrowRepo is a PostgreSQL implementation of the repository.EscrowRepository interface
+type EscrowRepo struct {
+	db *sql.DB
+}
+
+// NewEscrowRepository creates a new EscrowRepo
+func NewEscrowRepository(db *sql.DB) *EscrowRepo {
+	return &EscrowRepo{db: db}
+}
+
+// CreateTx creates a new escrow within an existing transaction
+func (r *EscrowRepo) CreateTx(ctx context.Context, tx *sql.Tx, escrow *models.Escrow) (int, error) {
+	query := `INSERT INTO escrows (buyer_id, seller_id, buyer_account_id, seller_account_id, amount, currency,
+             description, status, expires_at, funding_transaction_id)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		escrow.BuyerID,
+		escrow.SellerID,
+		escrow.BuyerAccountID,
+		escrow.SellerAccountID,
+		escrow.Amount,
+		escrow.Currency,
+		escrow.Description,
+		escrow.Status,
+		escrow.ExpiresAt,
+		escrow.FundingTransactionID,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create escrow: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets an escrow of the request's tenant, that of the buyer's account, by ID
+func (r *EscrowRepo) GetByID(ctx context.Context, id int) (*models.Escrow, error) {
+	query := `SELECT ` + escrowColumns + ` FROM escrows WHERE id = $1 AND ` + accountInTenant("buyer_account_id", "$2")
+
+	escrow, err := scanEscrow(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("escrow not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+
+	return escrow, nil
+}
+
+// GetByUserID gets the escrows a user is the buyer or the seller of, newest first
+func (r *EscrowRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Escrow, error) {
+	query := `SELECT ` + escrowColumns + ` FROM escrows
+             WHERE (buyer_id = $1 OR seller_id = $1) AND ` + accountInTenant("buyer_account_id", "$2") + `
+             ORDER BY created_at DESC, id DESC`
+
+	return r.getEscrows(ctx, query, userID)
+}
+
+// GetByStatus gets the escrows of the request's tenant in a status, all of them if the status is empty
+func (r *EscrowRepo) GetByStatus(ctx context.Context, status models.EscrowStatus) ([]*models.Escrow, error) {
+	query := `SELECT ` + escrowColumns + ` FROM escrows WHERE ($1 = '' OR status = $1) AND ` +
+		accountInTenant("buyer_account_id", "$2") + ` ORDER BY created_at, id`
+
+	return r.getEscrows(ctx, query, status)
+}
+
+// GetExpired gets the funded escrows that were not settled before they expired
+func (r *EscrowRepo) GetExpired(ctx context.Context, now time.Time) ([]*models.Escrow, error) {
+	query := `SELECT ` + escrowColumns + ` FROM escrows WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at`
+
+	rows, err := r.db.QueryContext(ctx, query, models.EscrowStatusFunded, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired escrows: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEscrows(rows)
+}
+
+// getEscrows gets the escrows selected by a query with an argument and the request's tenant
+func (r *EscrowRepo) getEscrows(ctx context.Context, query string, arg interface{}) ([]*models.Escrow, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrows: %w", err)
+	}
+	defer rows.Close()
+
+	return scanEscrows(rows)
+}
+
+// ConfirmTx records that a party of a funded, unexpired escrow confirmed the deal within an existing
+// transaction and returns the escrow with both confirmations. It returns nil if the escrow can no
+// longer be confirmed. The row stays locked until the transaction ends, so of two parties
+// confirming at once the second sees the first confirmation.
+func (r *EscrowRepo) ConfirmTx(ctx context.Context, tx *sql.Tx, id int, userID int) (*models.Escrow, error) {
+	query := `UPDATE escrows
+             SET buyer_confirmed_at = CASE WHEN buyer_id = $1 THEN COALESCE(buyer_confirmed_at, CURRENT_TIMESTAMP) ELSE buyer_confirmed_at END,
+                 seller_confirmed_at = CASE WHEN seller_id = $1 THEN COALESCE(seller_confirmed_at, CURRENT_TIMESTAMP) ELSE seller_confirmed_at END
+             WHERE id = $2 AND status = $3 AND expires_at > CURRENT_TIMESTAMP
+             RETURNING ` + escrowColumns
+
+	escrow, err := scanEscrow(tx.QueryRowContext(ctx, query, userID, id, models.EscrowStatusFunded))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to confirm escrow: %w", err)
+	}
+
+	return escrow, nil
+}
+
+// SettleTx moves a funded escrow to a final status within an existing transaction. It reports
+// false if the escrow was no longer funded, so it can only be settled once.
+func (r *EscrowRepo) SettleTx(ctx context.Context, tx *sql.Tx, id int, to models.EscrowStatus, settlementTxID *int, resolvedBy *int, note string) (bool, error) {
+	query := `UPDATE escrows
+             SET status = $1, settlement_transaction_id = $2, resolved_by = $3, resolution_note = $4,
+                 settled_at = CURRENT_TIMESTAMP
+             WHERE id = $5 AND status = $6`
+
+	result, err := tx.ExecContext(ctx, query, to, settlementTxID, resolvedBy, note, id, models.EscrowStatusFunded)
+	if err != nil {
+		return false, fmt.Errorf("failed to settle escrow: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanEscrows scans escrow rows
+func scanEscrows(rows *sql.Rows) ([]*models.Escrow, error) {
+	escrows := []*models.Escrow{}
+	for rows.Next() {
+		escrow, err := scanEscrow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan escrow: %w", err)
+		}
+		escrows = append(escrows, escrow)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return escrows, nil
+}
+
+// scanEscrow scans a single escrow row
+func scanEscrow(row interface{ Scan(...interface{}) error }) (*models.Escrow, error) {
+	escrow := &models.Escrow{}
+	err := row.Scan(
+		&escrow.ID,
+		&escrow.BuyerID,
+		&escrow.SellerID,
+		&escrow.BuyerAccountID,
+		&escrow.SellerAccountID,
+		&escrow.Amount,
+		&escrow.Currency,
+		&escrow.Description,
+		&escrow.Status,
+		&escrow.BuyerConfirmedAt,
+		&escrow.SellerConfirmedAt,
+		&escrow.ExpiresAt,
+		&escrow.FundingTransactionID,
+		&escrow.SettlementTransactionID,
+		&escrow.ResolvedBy,
+		&escrow.ResolutionNote,
+		&escrow.SettledAt,
+		&escrow.CreatedAt,
+		&escrow.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return escrow, nil
+}
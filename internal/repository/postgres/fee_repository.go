@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// FeeRepo is a PostgreSQL implementation of the repository.FeeRepository interface
+type FeeRepo struct {
+	db *sql.DB
+}
+
+// NewFeeRepository creates a new FeeRepo
+func NewFeeRepository(db *sql.DB) *FeeRepo {
+	return &FeeRepo{db: db}
+}
+
+// feeChargeColumns lists the columns read by scanFeeCharge
+const feeChargeColumns = `id, kind, reference_id, account_id, card_id, period_start, period_end, amount, currency,
+             description, status, attempts, last_error, transaction_id, charged_at, created_at, updated_at`
+
+// CreateCharge saves a new pending fee charge, or returns 0 if the period was assessed already
+func (r *FeeRepo) CreateCharge(ctx context.Context, charge *models.FeeCharge) (int, error) {
+	return createFeeCharge(ctx, r.db, charge)
+}
+
+// CreateChargeTx saves a new pending fee charge within a transaction, or returns 0 if the period
+// was assessed already
+func (r *FeeRepo) CreateChargeTx(ctx context.Context, tx *sql.Tx, charge *models.FeeCharge) (int, error) {
+	return createFeeCharge(ctx, tx, charge)
+}
+
+// GetCharges gets the fee charges of the request's tenant, that of their accounts, in a status, or
+// all of them if status is empty, newest first
+func (r *FeeRepo) GetCharges(ctx context.Context, status models.FeeChargeStatus) ([]*models.FeeCharge, error) {
+	query := `SELECT ` + feeChargeColumns + ` FROM fee_charges
+             WHERE ($1 = '' OR status = $1) AND ` + accountInTenant("account_id", "$2") + `
+             ORDER BY id DESC`
+
+	return r.queryCharges(ctx, query, status, requestTenant(ctx))
+}
+
+// GetCollectable gets the pending and dunning fee charges, oldest first
+func (r *FeeRepo) GetCollectable(ctx context.Context) ([]*models.FeeCharge, error) {
+	query := `SELECT ` + feeChargeColumns + ` FROM fee_charges
+             WHERE status IN ($1, $2)
+             ORDER BY id`
+
+	return r.queryCharges(ctx, query, models.FeeChargeStatusPending, models.FeeChargeStatusDunning)
+}
+
+// MarkDunning records a failed attempt to charge a fee
+func (r *FeeRepo) MarkDunning(ctx context.Context, id int, reason string) error {
+	query := `UPDATE fee_charges SET status = $2, attempts = attempts + 1, last_error = $3
+             WHERE id = $1 AND status <> $4`
+
+	_, err := r.db.ExecContext(ctx, query, id, models.FeeChargeStatusDunning, reason, models.FeeChargeStatusCharged)
+	if err != nil {
+		return fmt.Errorf("failed to mark fee charge dunning: %w", err)
+	}
+
+	return nil
+}
+
+// MarkChargedTx records within a transaction that a fee was charged by a FEE transaction
+func (r *FeeRepo) MarkChargedTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error {
+	query := `UPDATE fee_charges SET status = $2, transaction_id = $3, charged_at = NOW(), last_error = ''
+             WHERE id = $1 AND status <> $2`
+
+	result, err := tx.ExecContext(ctx, query, id, models.FeeChargeStatusCharged, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to mark fee charge charged: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to mark fee charge charged: %w", err)
+	}
+	if rows == 0 {
+		return errors.New("fee charge was charged already")
+	}
+
+	return nil
+}
+
+// CreateRun saves the summary of a fee job run
+func (r *FeeRepo) CreateRun(ctx context.Context, run *models.FeeRun) (int, error) {
+	totals, err := json.Marshal(run.Totals)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode fee totals: %w", err)
+	}
+
+	query := `INSERT INTO fee_runs (started_at, finished_at, assessed, charged, dunning, failed, totals)
+             VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	err = r.db.QueryRowContext(ctx, query,
+		run.StartedAt,
+		run.FinishedAt,
+		run.Assessed,
+		run.Charged,
+		run.Dunning,
+		run.Failed,
+		totals,
+	).Scan(&run.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create fee run: %w", err)
+	}
+
+	return run.ID, nil
+}
+
+// GetRuns gets the most recent fee job runs, newest first
+func (r *FeeRepo) GetRuns(ctx context.Context, limit int) ([]*models.FeeRun, error) {
+	query := `SELECT id, started_at, finished_at, assessed, charged, dunning, failed, totals
+             FROM fee_runs ORDER BY started_at DESC, id DESC LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []*models.FeeRun{}
+	for rows.Next() {
+		run := &models.FeeRun{}
+		var totals []byte
+		err := rows.Scan(
+			&run.ID,
+			&run.StartedAt,
+			&run.FinishedAt,
+			&run.Assessed,
+			&run.Charged,
+			&run.Dunning,
+			&run.Failed,
+			&totals,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fee run: %w", err)
+		}
+		if err := json.Unmarshal(totals, &run.Totals); err != nil {
+			return nil, fmt.Errorf("failed to decode fee totals: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return runs, nil
+}
+
+// queryCharges runs a query returning fee charges
+func (r *FeeRepo) queryCharges(ctx context.Context, query string, args ...interface{}) ([]*models.FeeCharge, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee charges: %w", err)
+	}
+	defer rows.Close()
+
+	charges := []*models.FeeCharge{}
+	for rows.Next() {
+		charge, err := scanFeeCharge(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan fee charge: %w", err)
+		}
+		charges = append(charges, charge)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return charges, nil
+}
+
+// createFeeCharge inserts a pending fee charge unless its product was assessed for the period already
+func createFeeCharge(ctx context.Context, db rowQuerier, charge *models.FeeCharge) (int, error) {
+	query := `INSERT INTO fee_charges (kind, reference_id, account_id, card_id, period_start, period_end, amount, currency, description, status)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+             ON CONFLICT (kind, reference_id, period_start) DO NOTHING
+             RETURNING id, created_at, updated_at`
+
+	err := db.QueryRowContext(ctx, query,
+		charge.Kind,
+		charge.ReferenceID,
+		charge.AccountID,
+		charge.CardID,
+		charge.PeriodStart,
+		charge.PeriodEnd,
+		charge.Amount,
+		charge.Currency,
+		charge.Description,
+		models.FeeChargeStatusPending,
+	).Scan(&charge.ID, &charge.CreatedAt, &charge.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to create fee charge: %w", err)
+	}
+
+	charge.Status = models.FeeChargeStatusPending
+
+	return charge.ID, nil
+}
+
+// scanFeeCharge scans a fee charge row
+func scanFeeCharge(row interface{ Scan(...interface{}) error }) (*models.FeeCharge, error) {
+	charge := &models.FeeCharge{}
+	var cardID, transactionID sql.NullInt64
+	var chargedAt sql.NullTime
+
+	err := row.Scan(
+		&charge.ID,
+		&charge.Kind,
+		&charge.ReferenceID,
+		&charge.AccountID,
+		&cardID,
+		&charge.PeriodStart,
+		&charge.PeriodEnd,
+		&charge.Amount,
+		&charge.Currency,
+		&charge.Description,
+		&charge.Status,
+		&charge.Attempts,
+		&charge.LastError,
+		&transactionID,
+		&chargedAt,
+		&charge.CreatedAt,
+		&charge.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if cardID.Valid {
+		id := int(cardID.Int64)
+		charge.CardID = &id
+	}
+	if transactionID.Valid {
+		id := int(transactionID.Int64)
+		charge.TransactionID = &id
+	}
+	if chargedAt.Valid {
+		charge.ChargedAt = &chargedAt.Time
+	}
+
+	return charge, nil
+}
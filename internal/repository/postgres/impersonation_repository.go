@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// impersonationColumns lists the columns read by scanImpersonation
+const impersonationColumns = `SELECT id, staff_id, customer_id, reason, session_id, expires_at, ended_at, created_at
+             FROM impersonations`
+
+// ImpersonationRepo is a PostgreSQL implementation of the repository.ImpersonationRepository interface
+type ImpersonationRepo struct {
+	db *sql.DB
+}
+
+// NewImpersonationRepository creates a new ImpersonationRepo
+func NewImpersonationRepository(db *sql.DB) *ImpersonationRepo {
+	return &ImpersonationRepo{db: db}
+}
+
+// Create creates a new impersonation in the database
+func (r *ImpersonationRepo) Create(ctx context.Context, impersonation *models.Impersonation) (int, error) {
+	query := `INSERT INTO impersonations (staff_id, customer_id, reason, session_id, expires_at)
+             VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		impersonation.StaffID,
+		impersonation.CustomerID,
+		impersonation.Reason,
+		impersonation.SessionID,
+		impersonation.ExpiresAt,
+	).Scan(&impersonation.ID, &impersonation.CreatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create impersonation: %w", err)
+	}
+
+	return impersonation.ID, nil
+}
+
+// GetByID gets an impersonation of the request's tenant, that of the customer, by ID
+func (r *ImpersonationRepo) GetByID(ctx context.Context, id int) (*models.Impersonation, error) {
+	query := impersonationColumns + ` WHERE id = $1 AND ` + userInTenant("customer_id", "$2")
+
+	impersonation, err := scanImpersonation(r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("impersonation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get impersonation: %w", err)
+	}
+
+	return impersonation, nil
+}
+
+// GetAll gets the impersonations of the request's tenant by an employee and of a customer, newest
+// first; a zero ID matches everyone
+func (r *ImpersonationRepo) GetAll(ctx context.Context, staffID int, customerID int) ([]*models.Impersonation, error) {
+	query := impersonationColumns + ` WHERE ($1 = 0 OR staff_id = $1) AND ($2 = 0 OR customer_id = $2)
+             AND ` + userInTenant("customer_id", "$3") + `
+             ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, staffID, customerID, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impersonations: %w", err)
+	}
+	defer rows.Close()
+
+	impersonations := []*models.Impersonation{}
+	for rows.Next() {
+		impersonation, err := scanImpersonation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan impersonation: %w", err)
+		}
+		impersonations = append(impersonations, impersonation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating impersonations: %w", err)
+	}
+
+	return impersonations, nil
+}
+
+// End ends an impersonation. It reports false if the impersonation had already ended.
+func (r *ImpersonationRepo) End(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE impersonations SET ended_at = CURRENT_TIMESTAMP WHERE id = $1 AND ended_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to end impersonation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// AddRequest records a request made with an impersonation token
+func (r *ImpersonationRepo) AddRequest(ctx context.Context, request *models.ImpersonationRequest) (int, error) {
+	query := `INSERT INTO impersonation_requests (impersonation_id, method, path, allowed)
+             VALUES ($1, $2, $3, $4) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		request.ImpersonationID,
+		request.Method,
+		request.Path,
+		request.Allowed,
+	).Scan(&request.ID, &request.CreatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create impersonation request: %w", err)
+	}
+
+	return request.ID, nil
+}
+
+// GetRequests gets the audit log of an impersonation, oldest first
+func (r *ImpersonationRepo) GetRequests(ctx context.Context, impersonationID int) ([]*models.ImpersonationRequest, error) {
+	query := `SELECT id, impersonation_id, method, path, allowed, created_at
+             FROM impersonation_requests
+             WHERE impersonation_id = $1
+             ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, impersonationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get impersonation requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*models.ImpersonationRequest{}
+	for rows.Next() {
+		request := &models.ImpersonationRequest{}
+		if err := rows.Scan(
+			&request.ID,
+			&request.ImpersonationID,
+			&request.Method,
+			&request.Path,
+			&request.Allowed,
+			&request.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan impersonation request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating impersonation requests: %w", err)
+	}
+
+	return requests, nil
+}
+
+// scanImpersonation scans a row selected with impersonationColumns
+func scanImpersonation(row interface{ Scan(...interface{}) error }) (*models.Impersonation, error) {
+	impersonation := &models.Impersonation{}
+	err := row.Scan(
+		&impersonation.ID,
+		&impersonation.StaffID,
+		&impersonation.CustomerID,
+		&impersonation.Reason,
+		&impersonation.SessionID,
+		&impersonation.ExpiresAt,
+		&impersonation.EndedAt,
+		&impersonation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return impersonation, nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// InsurancePolicyRepo is a PostgreSQL implementation of the repository.InsurancePolicyRepository interface
+type InsurancePolicyRepo struct {
+	db *sql.DB
+}
+
+// NewInsurancePolicyRepository creates a new InsurancePolicyRepo
+func NewInsurancePolicyRepository(db *sql.DB) *InsurancePolicyRepo {
+	return &InsurancePolicyRepo{db: db}
+}
+
+// Create creates a new insurance policy in the database
+func (r *InsurancePolicyRepo) Create(ctx context.Context, policy *models.InsurancePolicy) (int, error) {
+	query := `INSERT INTO insurance_policies (policy_number, credit_id, user_id, rate, monthly_premium,
+             status, start_date, end_date)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		policy.PolicyNumber,
+		policy.CreditID,
+		policy.UserID,
+		policy.Rate,
+		policy.MonthlyPremium,
+		policy.Status,
+		policy.StartDate,
+		policy.EndDate,
+	).Scan(&policy.ID, &policy.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create insurance policy: %w", err)
+	}
+
+	return policy.ID, nil
+}
+
+// GetByCreditID gets the insurance policy of a credit
+func (r *InsurancePolicyRepo) GetByCreditID(ctx context.Context, creditID int) (*models.InsurancePolicy, error) {
+	query := `SELECT id, policy_number, credit_id, user_id, rate, monthly_premium, status,
+             start_date, end_date, cancelled_at, created_at
+             FROM insurance_policies WHERE credit_id = $1`
+
+	policy := &models.InsurancePolicy{}
+	err := r.db.QueryRowContext(ctx, query, creditID).Scan(
+		&policy.ID,
+		&policy.PolicyNumber,
+		&policy.CreditID,
+		&policy.UserID,
+		&policy.Rate,
+		&policy.MonthlyPremium,
+		&policy.Status,
+		&policy.StartDate,
+		&policy.EndDate,
+		&policy.CancelledAt,
+		&policy.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("insurance policy not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get insurance policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// CancelTx cancels an active policy within a transaction. It reports false if the policy
+// was not active, so it is only cancelled once.
+func (r *InsurancePolicyRepo) CancelTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	query := `UPDATE insurance_policies SET status = $1, cancelled_at = CURRENT_TIMESTAMP
+             WHERE id = $2 AND status = $3`
+
+	result, err := tx.ExecContext(ctx, query, models.InsurancePolicyStatusCancelled, id, models.InsurancePolicyStatusActive)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel insurance policy: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
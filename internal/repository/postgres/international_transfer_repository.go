@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// internationalTransferColumns lists the columns read by scanInternationalTransfer
+const internationalTransferColumns = `id, user_id, source_account_id, reference, beneficiary_name, beneficiary_iban,
+             beneficiary_bic, beneficiary_country, amount, currency, charge_bearer, fee, correspondent_fee,
+             debited_amount, credited_amount, purpose, status, transaction_id, fee_transaction_id, dispatch_at,
+             expected_settlement_at, in_transit_at, settled_at, created_at, updated_at`
+
+// InternationalTransferRepo is a PostgreSQL implementation of the repository.InternationalTransferRepository interface
+type InternationalTransferRepo struct {
+	db *sql.DB
+}
+
+// NewInternationalTransferRepository creates a new InternationalTransferRepo
+func NewInternationalTransferRepository(db *sql.DB) *InternationalTransferRepo {
+	return &InternationalTransferRepo{db: db}
+}
+
+// CreateTx records a submitted international transfer within an existing transaction
+func (r *InternationalTransferRepo) CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.InternationalTransfer) (int, error) {
+	query := `INSERT INTO international_transfers (user_id, source_account_id, reference, beneficiary_name,
+             beneficiary_iban, beneficiary_bic, beneficiary_country, amount, currency, charge_bearer, fee,
+             correspondent_fee, debited_amount, credited_amount, purpose, status, transaction_id,
+             fee_transaction_id, dispatch_at, expected_settlement_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+             RETURNING id`
+
+	var id int
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		transfer.UserID,
+		transfer.SourceAccountID,
+		transfer.Reference,
+		transfer.BeneficiaryName,
+		transfer.BeneficiaryIBAN,
+		transfer.BeneficiaryBIC,
+		transfer.BeneficiaryCountry,
+		transfer.Amount,
+		transfer.Currency,
+		transfer.ChargeBearer,
+		transfer.Fee,
+		transfer.CorrespondentFee,
+		transfer.DebitedAmount,
+		transfer.CreditedAmount,
+		transfer.Purpose,
+		transfer.Status,
+		transfer.TransactionID,
+		transfer.FeeTransactionID,
+		transfer.DispatchAt,
+		transfer.ExpectedSettlementAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create international transfer: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets an international transfer of the request's tenant, that of its source account, by ID
+func (r *InternationalTransferRepo) GetByID(ctx context.Context, id int) (*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers
+             WHERE id = $1 AND ` + accountInTenant("source_account_id", "$2")
+
+	return r.getOne(ctx, query, id, requestTenant(ctx))
+}
+
+// GetByReference gets an international transfer by its UETR
+func (r *InternationalTransferRepo) GetByReference(ctx context.Context, reference string) (*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers WHERE reference = $1`
+
+	return r.getOne(ctx, query, reference)
+}
+
+// getOne gets the international transfer selected by a query
+func (r *InternationalTransferRepo) getOne(ctx context.Context, query string, args ...interface{}) (*models.InternationalTransfer, error) {
+	transfer, err := scanInternationalTransfer(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("international transfer not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get international transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// GetByUserID gets the international transfers of a user, newest first
+func (r *InternationalTransferRepo) GetByUserID(ctx context.Context, userID int) ([]*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers
+             WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+	return r.getMany(ctx, query, userID)
+}
+
+// GetByStatus gets the international transfers of the request's tenant in a status, all of them if
+// the status is empty
+func (r *InternationalTransferRepo) GetByStatus(ctx context.Context, status models.InternationalTransferStatus) ([]*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers
+             WHERE ($1 = '' OR status = $1) AND ` + accountInTenant("source_account_id", "$2") + ` ORDER BY created_at, id`
+
+	return r.getMany(ctx, query, status, requestTenant(ctx))
+}
+
+// GetToDispatch gets the submitted transfers whose dispatch time has come
+func (r *InternationalTransferRepo) GetToDispatch(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers
+             WHERE status = $1 AND dispatch_at <= $2 ORDER BY dispatch_at, id`
+
+	return r.getMany(ctx, query, models.InternationalTransferStatusSubmitted, now)
+}
+
+// GetToSettle gets the transfers in transit whose expected settlement time has come
+func (r *InternationalTransferRepo) GetToSettle(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error) {
+	query := `SELECT ` + internationalTransferColumns + ` FROM international_transfers
+             WHERE status = $1 AND expected_settlement_at <= $2 ORDER BY expected_settlement_at, id`
+
+	return r.getMany(ctx, query, models.InternationalTransferStatusInTransit, now)
+}
+
+// getMany gets the international transfers selected by a query
+func (r *InternationalTransferRepo) getMany(ctx context.Context, query string, args ...interface{}) ([]*models.InternationalTransfer, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get international transfers: %w", err)
+	}
+	defer rows.Close()
+
+	transfers := []*models.InternationalTransfer{}
+	for rows.Next() {
+		transfer, err := scanInternationalTransfer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan international transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// Dispatch moves a submitted transfer in transit. It reports false if it was dispatched already.
+func (r *InternationalTransferRepo) Dispatch(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE international_transfers SET status = $1, in_transit_at = CURRENT_TIMESTAMP
+             WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, models.InternationalTransferStatusInTransit, id, models.InternationalTransferStatusSubmitted)
+	if err != nil {
+		return false, fmt.Errorf("failed to dispatch international transfer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// SettleTx marks a transfer in transit settled within an existing transaction. It reports false if
+// it was not in transit, so it can only be settled once.
+func (r *InternationalTransferRepo) SettleTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	query := `UPDATE international_transfers SET status = $1, settled_at = CURRENT_TIMESTAMP
+             WHERE id = $2 AND status = $3`
+
+	result, err := tx.ExecContext(ctx, query, models.InternationalTransferStatusSettled, id, models.InternationalTransferStatusInTransit)
+	if err != nil {
+		return false, fmt.Errorf("failed to settle international transfer: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanInternationalTransfer scans a single international transfer row
+func scanInternationalTransfer(row interface{ Scan(...interface{}) error }) (*models.InternationalTransfer, error) {
+	transfer := &models.InternationalTransfer{}
+	err := row.Scan(
+		&transfer.ID,
+		&transfer.UserID,
+		&transfer.SourceAccountID,
+		&transfer.Reference,
+		&transfer.BeneficiaryName,
+		&transfer.BeneficiaryIBAN,
+		&transfer.BeneficiaryBIC,
+		&transfer.BeneficiaryCountry,
+		&transfer.Amount,
+		&transfer.Currency,
+		&transfer.ChargeBearer,
+		&transfer.Fee,
+		&transfer.CorrespondentFee,
+		&transfer.DebitedAmount,
+		&transfer.CreditedAmount,
+		&transfer.Purpose,
+		&transfer.Status,
+		&transfer.TransactionID,
+		&transfer.FeeTransactionID,
+		&transfer.DispatchAt,
+		&transfer.ExpectedSettlementAt,
+		&transfer.InTransitAt,
+		&transfer.SettledAt,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// InvitationRepo is a PostgreSQL implementation of the repository.InvitationRepository interface
+type InvitationRepo struct {
+	db *sql.DB
+}
+
+// NewInvitationRepository creates a new InvitationRepo
+func NewInvitationRepository(db *sql.DB) *InvitationRepo {
+	return &InvitationRepo{db: db}
+}
+
+// Create creates a new invitation in the database
+func (r *InvitationRepo) Create(ctx context.Context, invitation *models.OrganizationInvitation) (int, error) {
+	query := `INSERT INTO organization_invitations (organization_id, email, role, invited_by, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		invitation.OrganizationID,
+		invitation.Email,
+		invitation.Role,
+		invitation.InvitedBy,
+		invitation.Status,
+		invitation.ExpiresAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets an invitation by ID
+func (r *InvitationRepo) GetByID(ctx context.Context, id int) (*models.OrganizationInvitation, error) {
+	query := `SELECT i.id, i.organization_id, o.name, i.email, i.role, i.invited_by, i.status,
+             i.expires_at, i.responded_at, i.created_at
+             FROM organization_invitations i
+             JOIN organizations o ON o.id = i.organization_id
+             WHERE i.id = $1`
+
+	invitation := &models.OrganizationInvitation{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&invitation.ID,
+		&invitation.OrganizationID,
+		&invitation.OrganizationName,
+		&invitation.Email,
+		&invitation.Role,
+		&invitation.InvitedBy,
+		&invitation.Status,
+		&invitation.ExpiresAt,
+		&invitation.RespondedAt,
+		&invitation.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invitation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// GetByOrganizationID gets all invitations of an organization
+func (r *InvitationRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.OrganizationInvitation, error) {
+	query := `SELECT i.id, i.organization_id, o.name, i.email, i.role, i.invited_by, i.status,
+             i.expires_at, i.responded_at, i.created_at
+             FROM organization_invitations i
+             JOIN organizations o ON o.id = i.organization_id
+             WHERE i.organization_id = $1
+             ORDER BY i.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanInvitations(rows)
+}
+
+// GetPendingByEmail gets the pending, unexpired invitations sent to an email address
+func (r *InvitationRepo) GetPendingByEmail(ctx context.Context, email string) ([]*models.OrganizationInvitation, error) {
+	query := `SELECT i.id, i.organization_id, o.name, i.email, i.role, i.invited_by, i.status,
+             i.expires_at, i.responded_at, i.created_at
+             FROM organization_invitations i
+             JOIN organizations o ON o.id = i.organization_id
+             WHERE LOWER(i.email) = LOWER($1) AND i.status = $2 AND i.expires_at > NOW()
+             ORDER BY i.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, email, models.InvitationStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanInvitations(rows)
+}
+
+// UpdateStatus moves an invitation from one status to another. It reports false if the
+// invitation was not in the expected status, so an invitation can only be answered once.
+func (r *InvitationRepo) UpdateStatus(ctx context.Context, id int, from, to models.InvitationStatus) (bool, error) {
+	query := `UPDATE organization_invitations SET status = $1, responded_at = NOW()
+             WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update invitation status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// Helper function to scan multiple invitations
+func (r *InvitationRepo) scanInvitations(rows *sql.Rows) ([]*models.OrganizationInvitation, error) {
+	var invitations []*models.OrganizationInvitation
+
+	for rows.Next() {
+		invitation := &models.OrganizationInvitation{}
+		err := rows.Scan(
+			&invitation.ID,
+			&invitation.OrganizationID,
+			&invitation.OrganizationName,
+			&invitation.Email,
+			&invitation.Role,
+			&invitation.InvitedBy,
+			&invitation.Status,
+			&invitation.ExpiresAt,
+			&invitation.RespondedAt,
+			&invitation.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+
+		invitations = append(invitations, invitation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return invitations, nil
+}
@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// invoiceColumns lists the columns read by scanInvoice
+const invoiceColumns = `SELECT i.id, i.number, i.issuer_id, i.merchant_id, i.subscription_id, i.recipient_id,
+             i.recipient_email, i.account_id, a.account_number, i.amount, i.currency, i.description, i.due_at,
+             i.status, i.payer_account_id, i.transaction_id, i.paid_at, i.created_at, i.updated_at
+             FROM invoices i
+             JOIN accounts a ON a.id = i.account_id`
+
+// InvoiceRepo is a PostgreSQL implementation of the repository.InvoiceRepository interface
+type InvoiceRepo struct {
+	db *sql.DB
+}
+
+// NewInvoiceRepository creates a new InvoiceRepo
+func NewInvoiceRepository(db *sql.DB) *InvoiceRepo {
+	return &InvoiceRepo{db: db}
+}
+
+// Create creates a new invoice together with its items
+func (r *InvoiceRepo) Create(ctx context.Context, invoice *models.Invoice) (id int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO invoices (number, issuer_id, merchant_id, subscription_id, recipient_id, recipient_email,
+             account_id, amount, currency, description, due_at, status)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		invoice.Number,
+		invoice.IssuerID,
+		invoice.MerchantID,
+		invoice.SubscriptionID,
+		invoice.RecipientID,
+		invoice.RecipientEmail,
+		invoice.AccountID,
+		invoice.Amount,
+		invoice.Currency,
+		invoice.Description,
+		invoice.DueAt,
+		invoice.Status,
+	).Scan(&invoice.ID, &invoice.CreatedAt, &invoice.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	itemQuery := `INSERT INTO invoice_items (invoice_id, position, description, quantity, unit_price, amount)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	for _, item := range invoice.Items {
+		item.InvoiceID = invoice.ID
+		err = tx.QueryRowContext(
+			ctx,
+			itemQuery,
+			item.InvoiceID,
+			item.Position,
+			item.Description,
+			item.Quantity,
+			item.UnitPrice,
+			item.Amount,
+		).Scan(&item.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create invoice item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return invoice.ID, nil
+}
+
+// GetByID gets an invoice by ID together with its items
+func (r *InvoiceRepo) GetByID(ctx context.Context, id int) (*models.Invoice, error) {
+	return r.getInvoice(ctx, invoiceColumns+` WHERE i.id = $1`, id)
+}
+
+// GetByNumber gets an invoice by its public number together with its items
+func (r *InvoiceRepo) GetByNumber(ctx context.Context, number string) (*models.Invoice, error) {
+	return r.getInvoice(ctx, invoiceColumns+` WHERE i.number = $1`, number)
+}
+
+// getInvoice gets the invoice selected by a query with a single argument together with its items
+func (r *InvoiceRepo) getInvoice(ctx context.Context, query string, arg interface{}) (*models.Invoice, error) {
+	invoice, err := scanInvoice(r.db.QueryRowContext(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("invoice not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	invoice.Items, err = r.getItems(ctx, invoice.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// getItems gets the items of an invoice in order
+func (r *InvoiceRepo) getItems(ctx context.Context, invoiceID int) ([]*models.InvoiceItem, error) {
+	query := `SELECT id, invoice_id, position, description, quantity, unit_price, amount
+             FROM invoice_items
+             WHERE invoice_id = $1
+             ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.InvoiceItem{}
+	for rows.Next() {
+		item := &models.InvoiceItem{}
+		err := rows.Scan(
+			&item.ID,
+			&item.InvoiceID,
+			&item.Position,
+			&item.Description,
+			&item.Quantity,
+			&item.UnitPrice,
+			&item.Amount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetByIssuerID gets the invoices a user issued without their items, newest first
+func (r *InvoiceRepo) GetByIssuerID(ctx context.Context, issuerID int) ([]*models.Invoice, error) {
+	return r.getInvoices(ctx, invoiceColumns+` WHERE i.issuer_id = $1 ORDER BY i.created_at DESC, i.id DESC`, issuerID)
+}
+
+// GetByRecipientID gets the invoices sent to a user without their items, newest first
+func (r *InvoiceRepo) GetByRecipientID(ctx context.Context, recipientID int) ([]*models.Invoice, error) {
+	return r.getInvoices(ctx, invoiceColumns+` WHERE i.recipient_id = $1 ORDER BY i.created_at DESC, i.id DESC`, recipientID)
+}
+
+// GetByMerchantID gets the invoices a merchant issued without their items, newest first
+func (r *InvoiceRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Invoice, error) {
+	return r.getInvoices(ctx, invoiceColumns+` WHERE i.merchant_id = $1 ORDER BY i.created_at DESC, i.id DESC`, merchantID)
+}
+
+// GetBySubscriptionID gets the invoices billing the periods of a subscription without their items, newest first
+func (r *InvoiceRepo) GetBySubscriptionID(ctx context.Context, subscriptionID int) ([]*models.Invoice, error) {
+	return r.getInvoices(ctx, invoiceColumns+` WHERE i.subscription_id = $1 ORDER BY i.created_at DESC, i.id DESC`, subscriptionID)
+}
+
+// getInvoices gets the invoices selected by a query with a single argument
+func (r *InvoiceRepo) getInvoices(ctx context.Context, query string, arg interface{}) ([]*models.Invoice, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoices: %w", err)
+	}
+	defer rows.Close()
+
+	return scanInvoices(rows)
+}
+
+// Cancel cancels an unpaid invoice. It reports false if the invoice was already paid or cancelled.
+func (r *InvoiceRepo) Cancel(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE invoices SET status = $1 WHERE id = $2 AND status IN ($3, $4)`
+
+	result, err := r.db.ExecContext(ctx, query, models.InvoiceStatusCancelled, id,
+		models.InvoiceStatusIssued, models.InvoiceStatusOverdue)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel invoice: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// MarkOverdue moves the issued invoices due before now to OVERDUE and returns them
+func (r *InvoiceRepo) MarkOverdue(ctx context.Context, now time.Time) ([]*models.Invoice, error) {
+	query := `WITH i AS (
+                 UPDATE invoices SET status = $1
+                 WHERE status = $2 AND due_at <= $3
+                 RETURNING *
+             )
+             SELECT i.id, i.number, i.issuer_id, i.merchant_id, i.subscription_id, i.recipient_id,
+                    i.recipient_email, i.account_id, a.account_number, i.amount, i.currency, i.description, i.due_at,
+                    i.status, i.payer_account_id, i.transaction_id, i.paid_at, i.created_at, i.updated_at
+             FROM i
+             JOIN accounts a ON a.id = i.account_id
+             ORDER BY i.due_at, i.id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.InvoiceStatusOverdue, models.InvoiceStatusIssued, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark invoices overdue: %w", err)
+	}
+	defer rows.Close()
+
+	return scanInvoices(rows)
+}
+
+// MarkPaidTx records the payment of an unpaid invoice within an existing transaction. It reports
+// false if the invoice was already paid or cancelled, so it can only be paid once.
+func (r *InvoiceRepo) MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, payerAccountID int, transactionID int) (bool, error) {
+	query := `UPDATE invoices
+             SET status = $1, payer_account_id = $2, transaction_id = $3, paid_at = CURRENT_TIMESTAMP
+             WHERE id = $4 AND status IN ($5, $6)`
+
+	result, err := tx.ExecContext(ctx, query, models.InvoiceStatusPaid, payerAccountID, transactionID, id,
+		models.InvoiceStatusIssued, models.InvoiceStatusOverdue)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark invoice paid: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanInvoices scans invoice rows
+func scanInvoices(rows *sql.Rows) ([]*models.Invoice, error) {
+	invoices := []*models.Invoice{}
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan invoice: %w", err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return invoices, nil
+}
+
+// scanInvoice scans a single invoice row
+func scanInvoice(row interface{ Scan(...interface{}) error }) (*models.Invoice, error) {
+	invoice := &models.Invoice{}
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&invoice.IssuerID,
+		&invoice.MerchantID,
+		&invoice.SubscriptionID,
+		&invoice.RecipientID,
+		&invoice.RecipientEmail,
+		&invoice.AccountID,
+		&invoice.AccountNumber,
+		&invoice.Amount,
+		&invoice.Currency,
+		&invoice.Description,
+		&invoice.DueAt,
+		&invoice.Status,
+		&invoice.PayerAccountID,
+		&invoice.TransactionID,
+		&invoice.PaidAt,
+		&invoice.CreatedAt,
+		&invoice.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// LocationRepo is a PostgreSQL implementation of the repository.LocationRepository interface
+type LocationRepo struct {
+	db *sql.DB
+}
+
+// NewLocationRepository creates a new LocationRepo
+func NewLocationRepository(db *sql.DB) *LocationRepo {
+	return &LocationRepo{db: db}
+}
+
+// Create saves a new location
+func (r *LocationRepo) Create(ctx context.Context, location *models.Location) (int, error) {
+	services, hours, err := encodeLocation(location)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO locations (type, name, address, latitude, longitude, services, opening_hours, is_active)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		location.Type,
+		location.Name,
+		location.Address,
+		location.Latitude,
+		location.Longitude,
+		services,
+		hours,
+		location.IsActive,
+	).Scan(&location.ID, &location.CreatedAt, &location.UpdatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	return location.ID, nil
+}
+
+// GetByID gets a location by ID
+func (r *LocationRepo) GetByID(ctx context.Context, id int) (*models.Location, error) {
+	query := `SELECT id, type, name, address, latitude, longitude, services, opening_hours, is_active,
+             created_at, updated_at
+             FROM locations WHERE id = $1`
+
+	location, err := scanLocation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("location not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+
+	return location, nil
+}
+
+// GetAll gets all locations, including inactive ones
+func (r *LocationRepo) GetAll(ctx context.Context) ([]*models.Location, error) {
+	query := `SELECT id, type, name, address, latitude, longitude, services, opening_hours, is_active,
+             created_at, updated_at
+             FROM locations
+             ORDER BY name, id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []*models.Location{}
+	for rows.Next() {
+		location, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return locations, nil
+}
+
+// GetNearby gets the active locations within the query radius, nearest first. The bounding box
+// narrows the search on the coordinates index before the haversine distance is computed.
+func (r *LocationRepo) GetNearby(ctx context.Context, q *models.LocationQuery) ([]*models.Location, error) {
+	query := `SELECT id, type, name, address, latitude, longitude, services, opening_hours, is_active,
+             created_at, updated_at, distance
+             FROM (
+                 SELECT *, 2 * 6371 * ASIN(SQRT(
+                     POWER(SIN(RADIANS(latitude - $1) / 2), 2) +
+                     COS(RADIANS($1)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $2) / 2), 2)
+                 )) AS distance
+                 FROM locations
+                 WHERE is_active = TRUE AND ($4 = '' OR type = $4)
+                 AND latitude BETWEEN $5 AND $6 AND longitude BETWEEN $7 AND $8
+             ) l
+             WHERE distance <= $3
+             ORDER BY distance, id
+             LIMIT $9`
+
+	minLat, maxLat, minLng, maxLng := q.BoundingBox()
+
+	rows, err := r.db.QueryContext(ctx, query, q.Latitude, q.Longitude, q.RadiusKm, q.Type,
+		minLat, maxLat, minLng, maxLng, models.MaxNearbyLocations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []*models.Location{}
+	for rows.Next() {
+		var distance float64
+		location, err := scanLocation(rows, &distance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		location.DistanceKm = &distance
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return locations, nil
+}
+
+// Update replaces the data of a location
+func (r *LocationRepo) Update(ctx context.Context, location *models.Location) error {
+	services, hours, err := encodeLocation(location)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE locations
+             SET type = $1, name = $2, address = $3, latitude = $4, longitude = $5,
+             services = $6, opening_hours = $7, is_active = $8
+             WHERE id = $9
+             RETURNING created_at, updated_at`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		location.Type,
+		location.Name,
+		location.Address,
+		location.Latitude,
+		location.Longitude,
+		services,
+		hours,
+		location.IsActive,
+		location.ID,
+	).Scan(&location.CreatedAt, &location.UpdatedAt)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("location not found: %w", err)
+		}
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a location
+func (r *LocationRepo) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM locations WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("location not found")
+	}
+
+	return nil
+}
+
+// encodeLocation encodes the services and opening hours of a location as JSON
+func encodeLocation(location *models.Location) ([]byte, []byte, error) {
+	services, err := json.Marshal(location.Services)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode location services: %w", err)
+	}
+
+	hours, err := json.Marshal(location.OpeningHours)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode opening hours: %w", err)
+	}
+
+	return services, hours, nil
+}
+
+// scanLocation scans a location row followed by any extra columns, decoding its JSON fields
+func scanLocation(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*models.Location, error) {
+	location := &models.Location{}
+	var services, hours []byte
+
+	dest := []interface{}{
+		&location.ID,
+		&location.Type,
+		&location.Name,
+		&location.Address,
+		&location.Latitude,
+		&location.Longitude,
+		&services,
+		&hours,
+		&location.IsActive,
+		&location.CreatedAt,
+		&location.UpdatedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(services, &location.Services); err != nil {
+		return nil, fmt.Errorf("failed to decode location services: %w", err)
+	}
+
+	if err := json.Unmarshal(hours, &location.OpeningHours); err != nil {
+		return nil, fmt.Errorf("failed to decode opening hours: %w", err)
+	}
+
+	return location, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// MerchantRepo is a PostgreSQL implementation of the repository.MerchantRepository interface
+type MerchantRepo struct {
+	db *sql.DB
+}
+
+// NewMerchantRepository creates a new MerchantRepo
+func NewMerchantRepository(db *sql.DB) *MerchantRepo {
+	return &MerchantRepo{db: db}
+}
+
+// Create creates a new merchant in the database
+func (r *MerchantRepo) Create(ctx context.Context, merchant *models.Merchant) (int, error) {
+	query := `INSERT INTO merchants (user_id, name, settlement_account_id, api_key_hash, api_key_prefix, is_active)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		merchant.UserID,
+		merchant.Name,
+		merchant.SettlementAccountID,
+		merchant.APIKeyHash,
+		merchant.APIKeyPrefix,
+		merchant.IsActive,
+	).Scan(&merchant.ID, &merchant.CreatedAt, &merchant.UpdatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create merchant: %w", err)
+	}
+
+	return merchant.ID, nil
+}
+
+// GetByID gets a merchant by ID
+func (r *MerchantRepo) GetByID(ctx context.Context, id int) (*models.Merchant, error) {
+	query := `SELECT id, user_id, name, settlement_account_id, api_key_hash, api_key_prefix,
+             is_active, created_at, updated_at
+             FROM merchants WHERE id = $1`
+
+	return r.getMerchant(ctx, query, id)
+}
+
+// GetByAPIKeyHash gets a merchant by the hash of its API key
+func (r *MerchantRepo) GetByAPIKeyHash(ctx context.Context, hash string) (*models.Merchant, error) {
+	query := `SELECT id, user_id, name, settlement_account_id, api_key_hash, api_key_prefix,
+             is_active, created_at, updated_at
+             FROM merchants WHERE api_key_hash = $1`
+
+	return r.getMerchant(ctx, query, hash)
+}
+
+// getMerchant gets a single merchant by the given query
+func (r *MerchantRepo) getMerchant(ctx context.Context, query string, arg interface{}) (*models.Merchant, error) {
+	merchant := &models.Merchant{}
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&merchant.ID,
+		&merchant.UserID,
+		&merchant.Name,
+		&merchant.SettlementAccountID,
+		&merchant.APIKeyHash,
+		&merchant.APIKeyPrefix,
+		&merchant.IsActive,
+		&merchant.CreatedAt,
+		&merchant.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("merchant not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	return merchant, nil
+}
+
+// GetByUserID gets the merchants owned by a user
+func (r *MerchantRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Merchant, error) {
+	query := `SELECT id, user_id, name, settlement_account_id, api_key_hash, api_key_prefix,
+             is_active, created_at, updated_at
+             FROM merchants WHERE user_id = $1
+             ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchants: %w", err)
+	}
+	defer rows.Close()
+
+	merchants := []*models.Merchant{}
+	for rows.Next() {
+		merchant := &models.Merchant{}
+		err := rows.Scan(
+			&merchant.ID,
+			&merchant.UserID,
+			&merchant.Name,
+			&merchant.SettlementAccountID,
+			&merchant.APIKeyHash,
+			&merchant.APIKeyPrefix,
+			&merchant.IsActive,
+			&merchant.CreatedAt,
+			&merchant.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan merchant: %w", err)
+		}
+		merchants = append(merchants, merchant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return merchants, nil
+}
+
+// UpdateAPIKey replaces the API key of a merchant
+func (r *MerchantRepo) UpdateAPIKey(ctx context.Context, id int, hash, prefix string) error {
+	query := `UPDATE merchants SET api_key_hash = $1, api_key_prefix = $2 WHERE id = $3`
+
+	result, err := r.db.ExecContext(ctx, query, hash, prefix, id)
+	if err != nil {
+		return fmt.Errorf("failed to update merchant API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("merchant not found")
+	}
+
+	return nil
+}
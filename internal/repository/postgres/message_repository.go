@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// threadColumns selects a thread with the number of messages its reader ($1) has not read yet
+const threadColumns = `SELECT t.id, t.user_id, t.subject, t.started_by, t.last_message_at, t.created_at,
+             (SELECT COUNT(*) FROM messages m WHERE m.thread_id = t.id AND m.sender <> $1 AND m.read_at IS NULL)
+             FROM message_threads t`
+
+// MessageRepo is a PostgreSQL implementation of the repository.MessageRepository interface
+type MessageRepo struct {
+	db *sql.DB
+}
+
+// NewMessageRepository creates a new MessageRepo
+func NewMessageRepository(db *sql.DB) *MessageRepo {
+	return &MessageRepo{db: db}
+}
+
+// CreateThreadTx creates a new message thread within a transaction
+func (r *MessageRepo) CreateThreadTx(ctx context.Context, tx *sql.Tx, thread *models.MessageThread) (int, error) {
+	query := `INSERT INTO message_threads (user_id, subject, started_by)
+             VALUES ($1, $2, $3) RETURNING id, last_message_at, created_at`
+
+	err := tx.QueryRowContext(ctx, query, thread.UserID, thread.Subject, thread.StartedBy).
+		Scan(&thread.ID, &thread.LastMessageAt, &thread.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create message thread: %w", err)
+	}
+
+	return thread.ID, nil
+}
+
+// CreateTx adds a message to its thread within a transaction and moves the thread's last activity
+func (r *MessageRepo) CreateTx(ctx context.Context, tx *sql.Tx, message *models.Message) (int, error) {
+	query := `INSERT INTO messages (thread_id, sender, author_id, body, document_name, document_type, document)
+             VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7) RETURNING id, created_at`
+
+	var document interface{}
+	if len(message.Document) > 0 {
+		document = message.Document
+	}
+
+	err := tx.QueryRowContext(
+		ctx,
+		query,
+		message.ThreadID,
+		message.Sender,
+		message.AuthorID,
+		message.Body,
+		message.DocumentName,
+		message.DocumentType,
+		document,
+	).Scan(&message.ID, &message.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create message: %w", err)
+	}
+
+	query = `UPDATE message_threads SET last_message_at = $1 WHERE id = $2`
+	if _, err := tx.ExecContext(ctx, query, message.CreatedAt, message.ThreadID); err != nil {
+		return 0, fmt.Errorf("failed to update message thread: %w", err)
+	}
+
+	return message.ID, nil
+}
+
+// GetThreadByID gets a message thread of the request's tenant, that of its customer, by ID with the
+// unread count for the reader
+func (r *MessageRepo) GetThreadByID(ctx context.Context, id int, reader models.MessageSender) (*models.MessageThread, error) {
+	query := threadColumns + ` WHERE t.id = $2 AND ` + userInTenant("t.user_id", "$3")
+
+	thread, err := scanThread(r.db.QueryRowContext(ctx, query, reader, id, requestTenant(ctx)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("message thread not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get message thread: %w", err)
+	}
+
+	return thread, nil
+}
+
+// GetThreadsByUserID gets the threads of a customer, most recently active first
+func (r *MessageRepo) GetThreadsByUserID(ctx context.Context, userID int) ([]*models.MessageThread, error) {
+	query := threadColumns + ` WHERE t.user_id = $2 ORDER BY t.last_message_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, models.MessageSenderCustomer, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message threads: %w", err)
+	}
+	defer rows.Close()
+
+	return scanThreads(rows)
+}
+
+// GetThreads gets the threads of the request's tenant as seen by the bank, optionally only those with
+// unread customer messages
+func (r *MessageRepo) GetThreads(ctx context.Context, unreadOnly bool) ([]*models.MessageThread, error) {
+	query := threadColumns + ` WHERE (NOT $2 OR EXISTS (
+                 SELECT 1 FROM messages m WHERE m.thread_id = t.id AND m.sender = $3 AND m.read_at IS NULL))
+             AND ` + userInTenant("t.user_id", "$4") + `
+             ORDER BY t.last_message_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, models.MessageSenderBank, unreadOnly, models.MessageSenderCustomer, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message threads: %w", err)
+	}
+	defer rows.Close()
+
+	return scanThreads(rows)
+}
+
+// GetByThreadID gets the messages of a thread in order, without document contents
+func (r *MessageRepo) GetByThreadID(ctx context.Context, threadID int) ([]*models.Message, error) {
+	query := `SELECT id, thread_id, sender, author_id, body, COALESCE(document_name, ''),
+             COALESCE(document_type, ''), COALESCE(octet_length(document), 0), read_at, created_at
+             FROM messages
+             WHERE thread_id = $1
+             ORDER BY created_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	defer rows.Close()
+
+	messages := []*models.Message{}
+	for rows.Next() {
+		message := &models.Message{}
+		err := rows.Scan(
+			&message.ID,
+			&message.ThreadID,
+			&message.Sender,
+			&message.AuthorID,
+			&message.Body,
+			&message.DocumentName,
+			&message.DocumentType,
+			&message.DocumentSize,
+			&message.ReadAt,
+			&message.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan message: %w", err)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return messages, nil
+}
+
+// GetDocument gets a message of a thread together with its document
+func (r *MessageRepo) GetDocument(ctx context.Context, id int, threadID int) (*models.Message, error) {
+	query := `SELECT id, thread_id, sender, document_name, document_type, document
+             FROM messages
+             WHERE id = $1 AND thread_id = $2 AND document IS NOT NULL`
+
+	message := &models.Message{}
+	err := r.db.QueryRowContext(ctx, query, id, threadID).Scan(
+		&message.ID,
+		&message.ThreadID,
+		&message.Sender,
+		&message.DocumentName,
+		&message.DocumentType,
+		&message.Document,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("document not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+
+	message.DocumentSize = len(message.Document)
+
+	return message, nil
+}
+
+// MarkRead marks the unread messages of a thread written by sender as read
+func (r *MessageRepo) MarkRead(ctx context.Context, threadID int, sender models.MessageSender) error {
+	query := `UPDATE messages SET read_at = CURRENT_TIMESTAMP
+             WHERE thread_id = $1 AND sender = $2 AND read_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, threadID, sender); err != nil {
+		return fmt.Errorf("failed to mark messages as read: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnread counts the bank's messages the customer has not read yet
+func (r *MessageRepo) CountUnread(ctx context.Context, userID int) (int, error) {
+	query := `SELECT COUNT(*)
+             FROM messages m
+             JOIN message_threads t ON t.id = m.thread_id
+             WHERE t.user_id = $1 AND m.sender = $2 AND m.read_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID, models.MessageSenderBank).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// scanThreads scans message thread rows
+func scanThreads(rows *sql.Rows) ([]*models.MessageThread, error) {
+	threads := []*models.MessageThread{}
+	for rows.Next() {
+		thread, err := scanThread(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan message thread: %w", err)
+		}
+		threads = append(threads, thread)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return threads, nil
+}
+
+// scanThread scans a single message thread row
+func scanThread(row interface{ Scan(...interface{}) error }) (*models.MessageThread, error) {
+	thread := &models.MessageThread{}
+	err := row.Scan(
+		&thread.ID,
+		&thread.UserID,
+		&thread.Subject,
+		&thread.StartedBy,
+		&thread.LastMessageAt,
+		&thread.CreatedAt,
+		&thread.UnreadCount,
+	)
+	return thread, err
+}
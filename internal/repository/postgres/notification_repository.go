@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// NotificationRepo is a PostgreSQL implementation of the repository.NotificationRepository interface
+type NotificationRepo struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepo
+func NewNotificationRepository(db *sql.DB) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// Create creates a new notification in the database
+func (r *NotificationRepo) Create(ctx context.Context, notification *models.Notification) (int, error) {
+	query := `INSERT INTO notifications (user_id, type, title, message)
+             VALUES ($1, $2, $3, $4) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByUserID gets the notifications of a user, newest first
+func (r *NotificationRepo) GetByUserID(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error) {
+	query := `SELECT id, user_id, type, title, message, is_read, created_at
+             FROM notifications
+             WHERE user_id = $1 AND (NOT $2 OR is_read = FALSE)
+             ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+
+	for rows.Next() {
+		notification := &models.Notification{}
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&notification.IsRead,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountSince counts the notifications of a type a user got since a time
+func (r *NotificationRepo) CountSince(ctx context.Context, userID int, notificationType models.NotificationType, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND type = $2 AND created_at >= $3`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID, notificationType, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkRead marks a notification of a user as read
+func (r *NotificationRepo) MarkRead(ctx context.Context, id int, userID int) error {
+	query := `UPDATE notifications SET is_read = TRUE WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
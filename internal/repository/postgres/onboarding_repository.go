@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// OnboardingRepo is a PostgreSQL implementation of the repository.OnboardingRepository interface
+type OnboardingRepo struct {
+	db *sql.DB
+}
+
+// NewOnboardingRepository creates a new OnboardingRepo
+func NewOnboardingRepository(db *sql.DB) *OnboardingRepo {
+	return &OnboardingRepo{db: db}
+}
+
+// GetByUserID gets the onboarding stages a user completed, in the order they were completed
+func (r *OnboardingRepo) GetByUserID(ctx context.Context, userID int) ([]*models.OnboardingEvent, error) {
+	query := `SELECT id, user_id, stage, completed_at
+             FROM onboarding_events WHERE user_id = $1
+             ORDER BY completed_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.OnboardingEvent{}
+	for rows.Next() {
+		event := &models.OnboardingEvent{}
+		if err := rows.Scan(&event.ID, &event.UserID, &event.Stage, &event.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan onboarding event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// Record records that a user completed an onboarding stage. It reports false if the stage was
+// recorded already, so every stage is completed once.
+func (r *OnboardingRepo) Record(ctx context.Context, userID int, stage models.OnboardingStage) (bool, error) {
+	query := `INSERT INTO onboarding_events (user_id, stage) VALUES ($1, $2)
+             ON CONFLICT (user_id, stage) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, userID, stage)
+	if err != nil {
+		return false, fmt.Errorf("failed to record onboarding event: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record onboarding event: %w", err)
+	}
+
+	return rows > 0, nil
+}
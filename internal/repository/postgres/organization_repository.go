@@ -0,0 +1,249 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// OrganizationRepo is a PostgreSQL implementation of the repository.OrganizationRepository interface
+type OrganizationRepo struct {
+	db *sql.DB
+}
+
+// NewOrganizationRepository creates a new OrganizationRepo
+func NewOrganizationRepository(db *sql.DB) *OrganizationRepo {
+	return &OrganizationRepo{db: db}
+}
+
+// Create creates a new organization and makes its creator an admin member
+func (r *OrganizationRepo) Create(ctx context.Context, organization *models.Organization) (int, error) {
+	query := `WITH org AS (
+                 INSERT INTO organizations (name, created_by) VALUES ($1, $2) RETURNING id
+             )
+             INSERT INTO organization_members (organization_id, user_id, role)
+             SELECT id, $2, $3 FROM org
+             RETURNING organization_id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		organization.Name,
+		organization.CreatedBy,
+		models.OrganizationRoleAdmin,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets an organization by ID
+func (r *OrganizationRepo) GetByID(ctx context.Context, id int) (*models.Organization, error) {
+	query := `SELECT id, name, created_by, created_at, updated_at
+             FROM organizations WHERE id = $1`
+
+	organization := &models.Organization{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&organization.ID,
+		&organization.Name,
+		&organization.CreatedBy,
+		&organization.CreatedAt,
+		&organization.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("organization not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	return organization, nil
+}
+
+// GetByUserID gets all organizations a user is a member of, with the user's role
+func (r *OrganizationRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Organization, error) {
+	query := `SELECT o.id, o.name, o.created_by, m.role, o.created_at, o.updated_at
+             FROM organizations o
+             JOIN organization_members m ON m.organization_id = o.id
+             WHERE m.user_id = $1
+             ORDER BY o.name`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	defer rows.Close()
+
+	var organizations []*models.Organization
+	for rows.Next() {
+		organization := &models.Organization{}
+		err := rows.Scan(
+			&organization.ID,
+			&organization.Name,
+			&organization.CreatedBy,
+			&organization.Role,
+			&organization.CreatedAt,
+			&organization.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		organizations = append(organizations, organization)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return organizations, nil
+}
+
+// GetMember gets the membership of a user in an organization
+func (r *OrganizationRepo) GetMember(ctx context.Context, organizationID, userID int) (*models.OrganizationMember, error) {
+	query := `SELECT m.id, m.organization_id, m.user_id, u.username, u.email, m.role, m.created_at
+             FROM organization_members m
+             JOIN users u ON u.id = m.user_id
+             WHERE m.organization_id = $1 AND m.user_id = $2`
+
+	member := &models.OrganizationMember{}
+	err := r.db.QueryRowContext(ctx, query, organizationID, userID).Scan(
+		&member.ID,
+		&member.OrganizationID,
+		&member.UserID,
+		&member.Username,
+		&member.Email,
+		&member.Role,
+		&member.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("member not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return member, nil
+}
+
+// GetMembers gets all members of an organization
+func (r *OrganizationRepo) GetMembers(ctx context.Context, organizationID int) ([]*models.OrganizationMember, error) {
+	query := `SELECT m.id, m.organization_id, m.user_id, u.username, u.email, m.role, m.created_at
+             FROM organization_members m
+             JOIN users u ON u.id = m.user_id
+             WHERE m.organization_id = $1
+             ORDER BY m.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*models.OrganizationMember
+	for rows.Next() {
+		member := &models.OrganizationMember{}
+		err := rows.Scan(
+			&member.ID,
+			&member.OrganizationID,
+			&member.UserID,
+			&member.Username,
+			&member.Email,
+			&member.Role,
+			&member.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return members, nil
+}
+
+// AddMember adds a user to an organization with the given role
+func (r *OrganizationRepo) AddMember(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error {
+	query := `INSERT INTO organization_members (organization_id, user_id, role)
+             VALUES ($1, $2, $3)
+             ON CONFLICT (organization_id, user_id) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, organizationID, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to add member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user is already a member")
+	}
+
+	return nil
+}
+
+// UpdateMemberRole changes the role of a member. The last admin cannot be demoted.
+func (r *OrganizationRepo) UpdateMemberRole(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error {
+	query := `UPDATE organization_members SET role = $1
+             WHERE organization_id = $2 AND user_id = $3
+             AND ($1 = 'ADMIN' OR role <> 'ADMIN' OR EXISTS (
+                 SELECT 1 FROM organization_members
+                 WHERE organization_id = $2 AND user_id <> $3 AND role = 'ADMIN'
+             ))`
+
+	result, err := r.db.ExecContext(ctx, query, role, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("member not found or is the last admin")
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from an organization. The last admin cannot be removed.
+func (r *OrganizationRepo) RemoveMember(ctx context.Context, organizationID, userID int) error {
+	query := `DELETE FROM organization_members
+             WHERE organization_id = $1 AND user_id = $2
+             AND (role <> 'ADMIN' OR EXISTS (
+                 SELECT 1 FROM organization_members
+                 WHERE organization_id = $1 AND user_id <> $2 AND role = 'ADMIN'
+             ))`
+
+	result, err := r.db.ExecContext(ctx, query, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("member not found or is the last admin")
+	}
+
+	return nil
+}
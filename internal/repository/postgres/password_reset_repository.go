@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// PasswordResetRepo is a PostgreSQL implementation of the repository.PasswordResetRepository interface
+type PasswordResetRepo struct {
+	db *sql.DB
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepo
+func NewPasswordResetRepository(db *sql.DB) *PasswordResetRepo {
+	return &PasswordResetRepo{db: db}
+}
+
+// Create creates a new password reset token in the database
+func (r *PasswordResetRepo) Create(ctx context.Context, reset *models.PasswordReset) (int, error) {
+	query := `INSERT INTO password_resets (user_id, token_hash, expires_at)
+             VALUES ($1, $2, $3) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, reset.UserID, reset.TokenHash, reset.ExpiresAt).Scan(&reset.ID, &reset.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create password reset: %w", err)
+	}
+
+	return reset.ID, nil
+}
+
+// GetByTokenHash gets a password reset by the hash of its token
+func (r *PasswordResetRepo) GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error) {
+	query := `SELECT id, user_id, token_hash, expires_at, used_at, created_at
+             FROM password_resets WHERE token_hash = $1`
+
+	reset := &models.PasswordReset{}
+	var usedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&reset.ID,
+		&reset.UserID,
+		&reset.TokenHash,
+		&reset.ExpiresAt,
+		&usedAt,
+		&reset.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("password reset not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get password reset: %w", err)
+	}
+
+	if usedAt.Valid {
+		reset.UsedAt = &usedAt.Time
+	}
+
+	return reset, nil
+}
+
+// MarkUsed marks an unused password reset as used. It reports false if the token was used
+// already, so a token can only be used once.
+func (r *PasswordResetRepo) MarkUsed(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE password_resets SET used_at = NOW() WHERE id = $1 AND used_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset as used: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark password reset as used: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// InvalidateByUserID marks every unused password reset of a user as used
+func (r *PasswordResetRepo) InvalidateByUserID(ctx context.Context, userID int) error {
+	query := `UPDATE password_resets SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to invalidate password resets: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// PaymentIntentRepo is a PostgreSQL implementation of the repository.PaymentIntentRepository interface
+type PaymentIntentRepo struct {
+	db *sql.DB
+}
+
+// NewPaymentIntentRepository creates a new PaymentIntentRepo
+func NewPaymentIntentRepository(db *sql.DB) *PaymentIntentRepo {
+	return &PaymentIntentRepo{db: db}
+}
+
+// Create creates a new payment intent in the database
+func (r *PaymentIntentRepo) Create(ctx context.Context, intent *models.PaymentIntent) (int, error) {
+	query := `INSERT INTO payment_intents (intent_id, merchant_id, amount, currency, description,
+             order_reference, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		intent.IntentID,
+		intent.MerchantID,
+		intent.Amount,
+		intent.Currency,
+		intent.Description,
+		intent.OrderReference,
+		intent.Status,
+		intent.ExpiresAt,
+	).Scan(&intent.ID, &intent.CreatedAt, &intent.UpdatedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	return intent.ID, nil
+}
+
+// GetByIntentID gets a payment intent by its public identifier
+func (r *PaymentIntentRepo) GetByIntentID(ctx context.Context, intentID string) (*models.PaymentIntent, error) {
+	query := `SELECT i.id, i.intent_id, i.merchant_id, m.name, i.amount, i.currency, i.description,
+             i.order_reference, i.status, i.customer_id, i.source_account_id, i.transaction_id,
+             i.settlement_batch_id, i.expires_at, i.paid_at, i.created_at, i.updated_at
+             FROM payment_intents i
+             JOIN merchants m ON m.id = i.merchant_id
+             WHERE i.intent_id = $1`
+
+	intent, err := scanPaymentIntent(r.db.QueryRowContext(ctx, query, intentID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment intent not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	return intent, nil
+}
+
+// GetByTransactionID gets the payment intent paid by a transaction
+func (r *PaymentIntentRepo) GetByTransactionID(ctx context.Context, transactionID int) (*models.PaymentIntent, error) {
+	query := `SELECT i.id, i.intent_id, i.merchant_id, m.name, i.amount, i.currency, i.description,
+             i.order_reference, i.status, i.customer_id, i.source_account_id, i.transaction_id,
+             i.settlement_batch_id, i.expires_at, i.paid_at, i.created_at, i.updated_at
+             FROM payment_intents i
+             JOIN merchants m ON m.id = i.merchant_id
+             WHERE i.transaction_id = $1`
+
+	intent, err := scanPaymentIntent(r.db.QueryRowContext(ctx, query, transactionID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment intent not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	return intent, nil
+}
+
+// GetByMerchantID gets the payment intents of a merchant, newest first
+func (r *PaymentIntentRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.PaymentIntent, error) {
+	query := `SELECT i.id, i.intent_id, i.merchant_id, m.name, i.amount, i.currency, i.description,
+             i.order_reference, i.status, i.customer_id, i.source_account_id, i.transaction_id,
+             i.settlement_batch_id, i.expires_at, i.paid_at, i.created_at, i.updated_at
+             FROM payment_intents i
+             JOIN merchants m ON m.id = i.merchant_id
+             WHERE i.merchant_id = $1
+             ORDER BY i.created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intents: %w", err)
+	}
+	defer rows.Close()
+
+	intents := []*models.PaymentIntent{}
+	for rows.Next() {
+		intent, err := scanPaymentIntent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment intent: %w", err)
+		}
+		intents = append(intents, intent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return intents, nil
+}
+
+// UpdateStatus moves a payment intent from one status to another. It reports false if
+// the intent was not in the expected status.
+func (r *PaymentIntentRepo) UpdateStatus(ctx context.Context, id int, from, to models.PaymentIntentStatus) (bool, error) {
+	query := `UPDATE payment_intents SET status = $1 WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update payment intent status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// MarkPaidTx records the customer payment of an intent within an existing transaction. It reports
+// false if the intent is no longer payable, so an intent can only be paid once.
+func (r *PaymentIntentRepo) MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, customerID, accountID, transactionID int) (bool, error) {
+	query := `UPDATE payment_intents
+             SET status = $1, customer_id = $2, source_account_id = $3, transaction_id = $4, paid_at = CURRENT_TIMESTAMP
+             WHERE id = $5 AND status = $6 AND expires_at > CURRENT_TIMESTAMP`
+
+	result, err := tx.ExecContext(ctx, query, models.PaymentIntentStatusSucceeded, customerID, accountID,
+		transactionID, id, models.PaymentIntentStatusCreated)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark payment intent as paid: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanPaymentIntent scans a payment intent row joined with its merchant name
+func scanPaymentIntent(row interface{ Scan(...interface{}) error }) (*models.PaymentIntent, error) {
+	intent := &models.PaymentIntent{}
+	err := row.Scan(
+		&intent.ID,
+		&intent.IntentID,
+		&intent.MerchantID,
+		&intent.MerchantName,
+		&intent.Amount,
+		&intent.Currency,
+		&intent.Description,
+		&intent.OrderReference,
+		&intent.Status,
+		&intent.CustomerID,
+		&intent.SourceAccountID,
+		&intent.TransactionID,
+		&intent.SettlementBatchID,
+		&intent.ExpiresAt,
+		&intent.PaidAt,
+		&intent.CreatedAt,
+		&intent.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return intent, nil
+}
@@ -13,19 +13,20 @@ import (
 
 // PaymentScheduleRepo is a PostgreSQL implementation of the repository.PaymentScheduleRepository interface
 type PaymentScheduleRepo struct {
-	db *sql.DB
+	db   *sql.DB
+	bank *time.Location // payment dates start at midnight in it
 }
 
 // NewPaymentScheduleRepository creates a new PaymentScheduleRepo
-func NewPaymentScheduleRepository(db *sql.DB) *PaymentScheduleRepo {
-	return &PaymentScheduleRepo{db: db}
+func NewPaymentScheduleRepository(db *sql.DB, bank *time.Location) *PaymentScheduleRepo {
+	return &PaymentScheduleRepo{db: db, bank: bank}
 }
 
 // Create creates a new payment schedule item in the database
 func (r *PaymentScheduleRepo) Create(ctx context.Context, schedule *models.PaymentSchedule) (int, error) {
 	query := `INSERT INTO payment_schedules (credit_id, payment_date, principal_amount, 
-             interest_amount, total_amount, status, is_overdue, penalty_amount) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+             interest_amount, insurance_amount, total_amount, status, is_overdue, penalty_amount) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 	
 	var id int
 	err := r.db.QueryRowContext(
@@ -35,6 +36,7 @@ func (r *PaymentScheduleRepo) Create(ctx context.Context, schedule *models.Payme
 		schedule.PaymentDate,
 		schedule.PrincipalAmount,
 		schedule.InterestAmount,
+		schedule.InsuranceAmount,
 		schedule.TotalAmount,
 		schedule.Status,
 		schedule.IsOverdue,
@@ -62,34 +64,8 @@ func (r *PaymentScheduleRepo) CreateBatch(ctx context.Context, schedules []*mode
 		}
 	}()
 	
-	// Prepare the SQL statement for batch insert
-	valueStrings := make([]string, 0, len(schedules))
-	valueArgs := make([]interface{}, 0, len(schedules)*8)
-	
-	for i, schedule := range schedules {
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
-			i*8+1, i*8+2, i*8+3, i*8+4, i*8+5, i*8+6, i*8+7, i*8+8))
-		
-		valueArgs = append(valueArgs, 
-			schedule.CreditID,
-			schedule.PaymentDate,
-			schedule.PrincipalAmount,
-			schedule.InterestAmount,
-			schedule.TotalAmount,
-			schedule.Status,
-			schedule.IsOverdue,
-			schedule.PenaltyAmount,
-		)
-	}
-	
-	stmt := fmt.Sprintf(`INSERT INTO payment_schedules 
-                       (credit_id, payment_date, principal_amount, interest_amount, 
-                        total_amount, status, is_overdue, penalty_amount) 
-                       VALUES %s`, strings.Join(valueStrings, ","))
-	
-	_, err = tx.ExecContext(ctx, stmt, valueArgs...)
-	if err != nil {
-		return fmt.Errorf("failed to insert payment schedules: %w", err)
+	if err = r.CreateBatchTx(ctx, tx, schedules); err != nil {
+		return err
 	}
 	
 	err = tx.Commit()
@@ -103,7 +79,7 @@ func (r *PaymentScheduleRepo) CreateBatch(ctx context.Context, schedules []*mode
 // GetByID gets a payment schedule item by ID
 func (r *PaymentScheduleRepo) GetByID(ctx context.Context, id int) (*models.PaymentSchedule, error) {
 	query := `SELECT id, credit_id, payment_date, principal_amount, interest_amount, 
-             total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
+             insurance_amount, total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
              FROM payment_schedules WHERE id = $1`
 	
 	schedule := &models.PaymentSchedule{}
@@ -113,6 +89,7 @@ func (r *PaymentScheduleRepo) GetByID(ctx context.Context, id int) (*models.Paym
 		&schedule.PaymentDate,
 		&schedule.PrincipalAmount,
 		&schedule.InterestAmount,
+		&schedule.InsuranceAmount,
 		&schedule.TotalAmount,
 		&schedule.Status,
 		&schedule.IsOverdue,
@@ -127,6 +104,7 @@ func (r *PaymentScheduleRepo) GetByID(ctx context.Context, id int) (*models.Paym
 		}
 		return nil, fmt.Errorf("failed to get payment schedule: %w", err)
 	}
+	schedule.PaymentDate = models.BusinessDate(schedule.PaymentDate, r.bank)
 	
 	return schedule, nil
 }
@@ -134,7 +112,7 @@ func (r *PaymentScheduleRepo) GetByID(ctx context.Context, id int) (*models.Paym
 // GetByCreditID gets all payment schedule items for a credit
 func (r *PaymentScheduleRepo) GetByCreditID(ctx context.Context, creditID int) ([]*models.PaymentSchedule, error) {
 	query := `SELECT id, credit_id, payment_date, principal_amount, interest_amount, 
-             total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
+             insurance_amount, total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
              FROM payment_schedules 
              WHERE credit_id = $1
              ORDER BY payment_date`
@@ -179,17 +157,19 @@ func (r *PaymentScheduleRepo) Update(ctx context.Context, schedule *models.Payme
 	return nil
 }
 
-// GetPendingPayments gets all pending payments that are due on or before a specific date
-func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.Time) ([]*models.PaymentSchedule, error) {
+// GetPendingPayments gets up to limit pending payments that are due on or before a specific date,
+// in ID order starting after the given ID
+func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.Time, afterID, limit int) ([]*models.PaymentSchedule, error) {
 	query := `SELECT ps.id, ps.credit_id, ps.payment_date, ps.principal_amount, ps.interest_amount, 
-             ps.total_amount, ps.status, ps.is_overdue, ps.penalty_amount, ps.created_at, ps.updated_at,
+             ps.insurance_amount, ps.total_amount, ps.status, ps.is_overdue, ps.penalty_amount, ps.created_at, ps.updated_at,
              c.account_id
              FROM payment_schedules ps
              JOIN credits c ON ps.credit_id = c.id
-             WHERE ps.status = $1 AND ps.payment_date <= $2
-             ORDER BY ps.payment_date`
+             WHERE ps.status = $1 AND ps.payment_date <= $2 AND ps.id > $3
+             ORDER BY ps.id
+             LIMIT $4`
 	
-	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, date)
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, date, afterID, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pending payments: %w", err)
 	}
@@ -199,7 +179,6 @@ func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.
 	
 	for rows.Next() {
 		schedule := &models.PaymentSchedule{}
-		var accountID int
 		
 		err := rows.Scan(
 			&schedule.ID,
@@ -207,17 +186,74 @@ func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.
 			&schedule.PaymentDate,
 			&schedule.PrincipalAmount,
 			&schedule.InterestAmount,
+			&schedule.InsuranceAmount,
+			&schedule.TotalAmount,
+			&schedule.Status,
+			&schedule.IsOverdue,
+			&schedule.PenaltyAmount,
+			&schedule.CreatedAt,
+			&schedule.UpdatedAt,
+			&schedule.AccountID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment schedule: %w", err)
+		}
+		schedule.PaymentDate = models.BusinessDate(schedule.PaymentDate, r.bank)
+		
+		schedules = append(schedules, schedule)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	
+	return schedules, nil
+}
+
+// GetPaymentsToRemind gets up to limit pending payments due after from and on or before to that
+// have no reminder sent daysBefore or fewer days ahead, in ID order starting after the given ID
+func (r *PaymentScheduleRepo) GetPaymentsToRemind(ctx context.Context, from, to time.Time, daysBefore, afterID, limit int) ([]*models.PaymentSchedule, error) {
+	query := `SELECT ps.id, ps.credit_id, ps.payment_date, ps.principal_amount, ps.interest_amount, 
+             ps.insurance_amount, ps.total_amount, ps.status, ps.is_overdue, ps.penalty_amount, ps.created_at, ps.updated_at,
+             c.account_id
+             FROM payment_schedules ps
+             JOIN credits c ON ps.credit_id = c.id
+             WHERE ps.status = $1 AND ps.payment_date > $2 AND ps.payment_date <= $3 AND ps.id > $5
+             AND NOT EXISTS (SELECT 1 FROM payment_reminders pr 
+                             WHERE pr.payment_id = ps.id AND pr.days_before <= $4)
+             ORDER BY ps.id
+             LIMIT $6`
+	
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, from, to, daysBefore, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments to remind: %w", err)
+	}
+	defer rows.Close()
+	
+	var schedules []*models.PaymentSchedule
+	
+	for rows.Next() {
+		schedule := &models.PaymentSchedule{}
+		
+		err := rows.Scan(
+			&schedule.ID,
+			&schedule.CreditID,
+			&schedule.PaymentDate,
+			&schedule.PrincipalAmount,
+			&schedule.InterestAmount,
+			&schedule.InsuranceAmount,
 			&schedule.TotalAmount,
 			&schedule.Status,
 			&schedule.IsOverdue,
 			&schedule.PenaltyAmount,
 			&schedule.CreatedAt,
 			&schedule.UpdatedAt,
-			&accountID,
+			&schedule.AccountID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan payment schedule: %w", err)
 		}
+		schedule.PaymentDate = models.BusinessDate(schedule.PaymentDate, r.bank)
 		
 		schedules = append(schedules, schedule)
 	}
@@ -229,10 +265,172 @@ func (r *PaymentScheduleRepo) GetPendingPayments(ctx context.Context, date time.
 	return schedules, nil
 }
 
+// MarkReminded records that the reminder sent daysBefore days ahead of a payment went out. It
+// returns false if it was already recorded.
+func (r *PaymentScheduleRepo) MarkReminded(ctx context.Context, paymentID, daysBefore int) (bool, error) {
+	query := `INSERT INTO payment_reminders (payment_id, days_before) 
+             VALUES ($1, $2) 
+             ON CONFLICT (payment_id, days_before) DO NOTHING`
+	
+	result, err := r.db.ExecContext(ctx, query, paymentID, daysBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark payment reminded: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected == 1, nil
+}
+
+// UnmarkReminded removes a reminder record, so the reminder is sent again
+func (r *PaymentScheduleRepo) UnmarkReminded(ctx context.Context, paymentID, daysBefore int) error {
+	query := `DELETE FROM payment_reminders WHERE payment_id = $1 AND days_before = $2`
+	
+	if _, err := r.db.ExecContext(ctx, query, paymentID, daysBefore); err != nil {
+		return fmt.Errorf("failed to unmark payment reminded: %w", err)
+	}
+	
+	return nil
+}
+
+// GetDuePayments gets the pending payments that are due on or before a specific date with their
+// credit, the credit's account and the amount held for them in one query. It returns all such
+// payments of up to limit accounts in account ID order starting after the given account, oldest
+// first within an account.
+func (r *PaymentScheduleRepo) GetDuePayments(ctx context.Context, date time.Time, afterAccountID, limit int) ([]*models.DuePayment, error) {
+	query := `SELECT ps.id, ps.credit_id, ps.payment_date, ps.principal_amount, ps.interest_amount, 
+             ps.insurance_amount, ps.total_amount, ps.status, ps.is_overdue, ps.penalty_amount, ps.created_at, ps.updated_at,
+             c.id, c.user_id, c.account_id, c.amount, c.interest_rate, c.term_months, 
+             c.monthly_payment, c.start_date, c.end_date, c.status, c.created_at, c.updated_at,
+             accounts.id, accounts.balance, accounts.balance - ` + activeHolds + `,
+             COALESCE((SELECT SUM(h.amount) FROM account_holds h 
+                       WHERE h.reason = $3 AND h.reference_id = ps.id AND h.status = $4
+                       AND (h.expires_at IS NULL OR h.expires_at > CURRENT_TIMESTAMP)), 0)
+             FROM payment_schedules ps
+             JOIN credits c ON ps.credit_id = c.id
+             JOIN accounts ON c.account_id = accounts.id
+             WHERE ps.status = $1 AND ps.payment_date <= $2
+             AND c.account_id IN (
+                 SELECT DISTINCT dc.account_id FROM payment_schedules dps
+                 JOIN credits dc ON dps.credit_id = dc.id
+                 WHERE dps.status = $1 AND dps.payment_date <= $2 AND dc.account_id > $5
+                 ORDER BY dc.account_id
+                 LIMIT $6)
+             ORDER BY c.account_id, ps.payment_date, ps.id`
+	
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, date,
+		models.HoldReasonCreditPayment, models.HoldStatusActive, afterAccountID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due payments: %w", err)
+	}
+	defer rows.Close()
+	
+	var payments []*models.DuePayment
+	
+	for rows.Next() {
+		due := &models.DuePayment{
+			Payment: &models.PaymentSchedule{},
+			Credit:  &models.Credit{},
+			Account: &models.Account{},
+		}
+		
+		err := rows.Scan(
+			&due.Payment.ID,
+			&due.Payment.CreditID,
+			&due.Payment.PaymentDate,
+			&due.Payment.PrincipalAmount,
+			&due.Payment.InterestAmount,
+			&due.Payment.InsuranceAmount,
+			&due.Payment.TotalAmount,
+			&due.Payment.Status,
+			&due.Payment.IsOverdue,
+			&due.Payment.PenaltyAmount,
+			&due.Payment.CreatedAt,
+			&due.Payment.UpdatedAt,
+			&due.Credit.ID,
+			&due.Credit.UserID,
+			&due.Credit.AccountID,
+			&due.Credit.Amount,
+			&due.Credit.InterestRate,
+			&due.Credit.TermMonths,
+			&due.Credit.MonthlyPayment,
+			&due.Credit.StartDate,
+			&due.Credit.EndDate,
+			&due.Credit.Status,
+			&due.Credit.CreatedAt,
+			&due.Credit.UpdatedAt,
+			&due.Account.ID,
+			&due.Account.Balance,
+			&due.Account.AvailableBalance,
+			&due.HeldAmount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan due payment: %w", err)
+		}
+		due.Payment.PaymentDate = models.BusinessDate(due.Payment.PaymentDate, r.bank)
+		due.Payment.AccountID = due.Account.ID
+		
+		payments = append(payments, due)
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	
+	return payments, nil
+}
+
+// UpdateBatchTx updates the status and penalty of multiple payment schedule items with one
+// statement within an existing transaction
+func (r *PaymentScheduleRepo) UpdateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	
+	valueStrings := make([]string, 0, len(schedules))
+	valueArgs := make([]interface{}, 0, len(schedules)*4)
+	
+	for i, schedule := range schedules {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d::int, $%d::varchar, $%d::boolean, $%d::numeric)",
+			i*4+1, i*4+2, i*4+3, i*4+4))
+		
+		valueArgs = append(valueArgs,
+			schedule.ID,
+			schedule.Status,
+			schedule.IsOverdue,
+			schedule.PenaltyAmount,
+		)
+	}
+	
+	stmt := fmt.Sprintf(`UPDATE payment_schedules ps 
+                       SET status = v.status, is_overdue = v.is_overdue, penalty_amount = v.penalty_amount 
+                       FROM (VALUES %s) AS v (id, status, is_overdue, penalty_amount) 
+                       WHERE ps.id = v.id`, strings.Join(valueStrings, ","))
+	
+	result, err := tx.ExecContext(ctx, stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to update payment schedules: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows != int64(len(schedules)) {
+		return fmt.Errorf("payment schedule not found")
+	}
+	
+	return nil
+}
+
 // GetOverduePayments gets all overdue payments
 func (r *PaymentScheduleRepo) GetOverduePayments(ctx context.Context) ([]*models.PaymentSchedule, error) {
 	query := `SELECT id, credit_id, payment_date, principal_amount, interest_amount, 
-             total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
+             insurance_amount, total_amount, status, is_overdue, penalty_amount, created_at, updated_at 
              FROM payment_schedules 
              WHERE status = $1 AND is_overdue = true
              ORDER BY payment_date`
@@ -246,6 +444,90 @@ func (r *PaymentScheduleRepo) GetOverduePayments(ctx context.Context) ([]*models
 	return r.scanPaymentSchedules(rows)
 }
 
+// CreateBatchTx creates multiple payment schedule items within an existing transaction
+func (r *PaymentScheduleRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+	
+	// Prepare the SQL statement for batch insert
+	valueStrings := make([]string, 0, len(schedules))
+	valueArgs := make([]interface{}, 0, len(schedules)*9)
+	
+	for i, schedule := range schedules {
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			i*9+1, i*9+2, i*9+3, i*9+4, i*9+5, i*9+6, i*9+7, i*9+8, i*9+9))
+		
+		valueArgs = append(valueArgs, 
+			schedule.CreditID,
+			schedule.PaymentDate,
+			schedule.PrincipalAmount,
+			schedule.InterestAmount,
+			schedule.InsuranceAmount,
+			schedule.TotalAmount,
+			schedule.Status,
+			schedule.IsOverdue,
+			schedule.PenaltyAmount,
+		)
+	}
+	
+	stmt := fmt.Sprintf(`INSERT INTO payment_schedules 
+                       (credit_id, payment_date, principal_amount, interest_amount, 
+                        insurance_amount, total_amount, status, is_overdue, penalty_amount) 
+                       VALUES %s`, strings.Join(valueStrings, ","))
+	
+	_, err := tx.ExecContext(ctx, stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to insert payment schedules: %w", err)
+	}
+	
+	return nil
+}
+
+// RescheduleTx moves a payment schedule item to its new payment date within an existing
+// transaction, together with its status and overdue flag
+func (r *PaymentScheduleRepo) RescheduleTx(ctx context.Context, tx *sql.Tx, schedule *models.PaymentSchedule) error {
+	query := `UPDATE payment_schedules 
+             SET payment_date = $1, status = $2, is_overdue = $3 
+             WHERE id = $4`
+	
+	result, err := tx.ExecContext(ctx, query, schedule.PaymentDate, schedule.Status, schedule.IsOverdue, schedule.ID)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule payment: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("payment schedule not found")
+	}
+	
+	return nil
+}
+
+// RemoveInsuranceTx takes the insurance premium out of the pending payments of a credit that are not due yet
+// within a transaction and returns the number of payments changed
+func (r *PaymentScheduleRepo) RemoveInsuranceTx(ctx context.Context, tx *sql.Tx, creditID int) (int64, error) {
+	query := `UPDATE payment_schedules 
+             SET total_amount = total_amount - insurance_amount, insurance_amount = 0 
+             WHERE credit_id = $1 AND status = $2 AND payment_date > CURRENT_DATE AND insurance_amount > 0`
+	
+	result, err := tx.ExecContext(ctx, query, creditID, models.PaymentStatusPending)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove insurance from payment schedule: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rows, nil
+}
+
 // Helper function to scan multiple payment schedules
 func (r *PaymentScheduleRepo) scanPaymentSchedules(rows *sql.Rows) ([]*models.PaymentSchedule, error) {
 	var schedules []*models.PaymentSchedule
@@ -258,6 +540,7 @@ func (r *PaymentScheduleRepo) scanPaymentSchedules(rows *sql.Rows) ([]*models.Pa
 			&schedule.PaymentDate,
 			&schedule.PrincipalAmount,
 			&schedule.InterestAmount,
+			&schedule.InsuranceAmount,
 			&schedule.TotalAmount,
 			&schedule.Status,
 			&schedule.IsOverdue,
@@ -268,6 +551,7 @@ func (r *PaymentScheduleRepo) scanPaymentSchedules(rows *sql.Rows) ([]*models.Pa
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan payment schedule: %w", err)
 		}
+		schedule.PaymentDate = models.BusinessDate(schedule.PaymentDate, r.bank)
 		
 		schedules = append(schedules, schedule)
 	}
@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// payrollColumns lists the columns read by scanPayroll
+const payrollColumns = `SELECT id, organization_id, account_id, currency, file_name, description, status, item_count,
+             total_amount, invalid_count, paid_count, paid_amount, uploaded_by, executed_by, funding_transaction_id,
+             executed_at, created_at, updated_at
+             FROM payrolls`
+
+// PayrollRepo is a PostgreSQL implementation of the repository.PayrollRepository interface
+type PayrollRepo struct {
+	db *sql.DB
+}
+
+// NewPayrollRepository creates a new PayrollRepo
+func NewPayrollRepository(db *sql.DB) *PayrollRepo {
+	return &PayrollRepo{db: db}
+}
+
+// Create creates a new payroll together with its items
+func (r *PayrollRepo) Create(ctx context.Context, payroll *models.Payroll) (id int, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `INSERT INTO payrolls (organization_id, account_id, currency, file_name, description, status,
+             item_count, total_amount, invalid_count, uploaded_by)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at, updated_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		payroll.OrganizationID,
+		payroll.AccountID,
+		payroll.Currency,
+		payroll.FileName,
+		payroll.Description,
+		payroll.Status,
+		payroll.ItemCount,
+		payroll.TotalAmount,
+		payroll.InvalidCount,
+		payroll.UploadedBy,
+	).Scan(&payroll.ID, &payroll.CreatedAt, &payroll.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create payroll: %w", err)
+	}
+
+	itemQuery := `INSERT INTO payroll_items (payroll_id, line_number, account_number, employee_name, amount,
+             account_id, status, error)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	for _, item := range payroll.Items {
+		item.PayrollID = payroll.ID
+		err = tx.QueryRowContext(
+			ctx,
+			itemQuery,
+			item.PayrollID,
+			item.LineNumber,
+			item.AccountNumber,
+			item.EmployeeName,
+			item.Amount,
+			item.AccountID,
+			item.Status,
+			item.Error,
+		).Scan(&item.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create payroll item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return payroll.ID, nil
+}
+
+// GetByID gets a payroll by ID, without its items
+func (r *PayrollRepo) GetByID(ctx context.Context, id int) (*models.Payroll, error) {
+	query := payrollColumns + ` WHERE id = $1`
+
+	payroll, err := scanPayroll(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payroll not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get payroll: %w", err)
+	}
+
+	return payroll, nil
+}
+
+// GetByOrganizationID gets the payrolls of an organization without their items, newest first
+func (r *PayrollRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Payroll, error) {
+	query := payrollColumns + ` WHERE organization_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payrolls: %w", err)
+	}
+	defer rows.Close()
+
+	payrolls := []*models.Payroll{}
+	for rows.Next() {
+		payroll, err := scanPayroll(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payroll: %w", err)
+		}
+		payrolls = append(payrolls, payroll)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return payrolls, nil
+}
+
+// GetItems gets the items of a payroll in file order
+func (r *PayrollRepo) GetItems(ctx context.Context, payrollID int) ([]*models.PayrollItem, error) {
+	query := `SELECT id, payroll_id, line_number, account_number, employee_name, amount, account_id, status,
+             error, transaction_id
+             FROM payroll_items
+             WHERE payroll_id = $1
+             ORDER BY line_number, id`
+
+	rows, err := r.db.QueryContext(ctx, query, payrollID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payroll items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.PayrollItem{}
+	for rows.Next() {
+		item := &models.PayrollItem{}
+		if err := rows.Scan(
+			&item.ID,
+			&item.PayrollID,
+			&item.LineNumber,
+			&item.AccountNumber,
+			&item.EmployeeName,
+			&item.Amount,
+			&item.AccountID,
+			&item.Status,
+			&item.Error,
+			&item.TransactionID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan payroll item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
+
+// UpdateStatusTx moves a payroll from one status to another and records who executed it within an
+// existing transaction. It reports false if the payroll was not in the expected status.
+func (r *PayrollRepo) UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.PayrollStatus, executedBy int) (bool, error) {
+	query := `UPDATE payrolls SET status = $1, executed_by = $2, executed_at = CURRENT_TIMESTAMP
+             WHERE id = $3 AND status = $4`
+
+	result, err := tx.ExecContext(ctx, query, to, executedBy, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update payroll status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// UpdateItemTx stores the outcome of a payroll item within an existing transaction
+func (r *PayrollRepo) UpdateItemTx(ctx context.Context, tx *sql.Tx, item *models.PayrollItem) error {
+	query := `UPDATE payroll_items SET status = $1, error = $2, transaction_id = $3 WHERE id = $4`
+
+	result, err := tx.ExecContext(ctx, query, item.Status, item.Error, item.TransactionID, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update payroll item: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("payroll item not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// SetSettlementTx stores the paid totals and the funding transaction of an executed payroll within
+// an existing transaction
+func (r *PayrollRepo) SetSettlementTx(ctx context.Context, tx *sql.Tx, payroll *models.Payroll) error {
+	query := `UPDATE payrolls SET paid_count = $1, paid_amount = $2, funding_transaction_id = $3 WHERE id = $4`
+
+	_, err := tx.ExecContext(ctx, query, payroll.PaidCount, payroll.PaidAmount, payroll.FundingTransactionID, payroll.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update payroll settlement: %w", err)
+	}
+
+	return nil
+}
+
+// scanPayroll scans a single payroll row
+func scanPayroll(row interface{ Scan(...interface{}) error }) (*models.Payroll, error) {
+	payroll := &models.Payroll{}
+	err := row.Scan(
+		&payroll.ID,
+		&payroll.OrganizationID,
+		&payroll.AccountID,
+		&payroll.Currency,
+		&payroll.FileName,
+		&payroll.Description,
+		&payroll.Status,
+		&payroll.ItemCount,
+		&payroll.TotalAmount,
+		&payroll.InvalidCount,
+		&payroll.PaidCount,
+		&payroll.PaidAmount,
+		&payroll.UploadedBy,
+		&payroll.ExecutedBy,
+		&payroll.FundingTransactionID,
+		&payroll.ExecutedAt,
+		&payroll.CreatedAt,
+		&payroll.UpdatedAt,
+	)
+	return payroll, err
+}
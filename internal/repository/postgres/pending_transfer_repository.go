@@ -0,0 +1,218 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// PendingTransferRepo is a PostgreSQL implementation of the repository.PendingTransferRepository interface
+type PendingTransferRepo struct {
+	db *sql.DB
+}
+
+// NewPendingTransferRepository creates a new PendingTransferRepo
+func NewPendingTransferRepository(db *sql.DB) *PendingTransferRepo {
+	return &PendingTransferRepo{db: db}
+}
+
+// Create creates a new pending transfer in the database
+func (r *PendingTransferRepo) Create(ctx context.Context, transfer *models.PendingTransfer) (int, error) {
+	query := `INSERT INTO pending_transfers (organization_id, requested_by, source_account_id,
+             destination_account_id, amount, description, invoice_id, required_approvals, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		transfer.OrganizationID,
+		transfer.RequestedBy,
+		transfer.SourceAccountID,
+		transfer.DestinationAccountID,
+		transfer.Amount,
+		transfer.Description,
+		transfer.InvoiceID,
+		transfer.RequiredApprovals,
+		transfer.Status,
+		transfer.ExpiresAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create pending transfer: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByID gets a pending transfer by ID
+func (r *PendingTransferRepo) GetByID(ctx context.Context, id int) (*models.PendingTransfer, error) {
+	query := `SELECT id, organization_id, requested_by, source_account_id, destination_account_id,
+             amount, description, invoice_id, required_approvals, status, transaction_id, expires_at, created_at, updated_at
+             FROM pending_transfers WHERE id = $1`
+
+	transfer := &models.PendingTransfer{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&transfer.ID,
+		&transfer.OrganizationID,
+		&transfer.RequestedBy,
+		&transfer.SourceAccountID,
+		&transfer.DestinationAccountID,
+		&transfer.Amount,
+		&transfer.Description,
+		&transfer.InvoiceID,
+		&transfer.RequiredApprovals,
+		&transfer.Status,
+		&transfer.TransactionID,
+		&transfer.ExpiresAt,
+		&transfer.CreatedAt,
+		&transfer.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("pending transfer not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get pending transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// GetByOrganizationID gets the pending transfers of an organization, newest first
+func (r *PendingTransferRepo) GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.PendingTransfer, error) {
+	query := `SELECT id, organization_id, requested_by, source_account_id, destination_account_id,
+             amount, description, invoice_id, required_approvals, status, transaction_id, expires_at, created_at, updated_at
+             FROM pending_transfers WHERE organization_id = $1
+             ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []*models.PendingTransfer
+	for rows.Next() {
+		transfer := &models.PendingTransfer{}
+		err := rows.Scan(
+			&transfer.ID,
+			&transfer.OrganizationID,
+			&transfer.RequestedBy,
+			&transfer.SourceAccountID,
+			&transfer.DestinationAccountID,
+			&transfer.Amount,
+			&transfer.Description,
+			&transfer.InvoiceID,
+			&transfer.RequiredApprovals,
+			&transfer.Status,
+			&transfer.TransactionID,
+			&transfer.ExpiresAt,
+			&transfer.CreatedAt,
+			&transfer.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pending transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// AddApproval records a member's approval of a pending transfer
+func (r *PendingTransferRepo) AddApproval(ctx context.Context, id int, userID int) error {
+	query := `INSERT INTO pending_transfer_approvals (pending_transfer_id, user_id)
+             VALUES ($1, $2)
+             ON CONFLICT (pending_transfer_id, user_id) DO NOTHING`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to add approval: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("transfer already approved by this user")
+	}
+
+	return nil
+}
+
+// GetApprovals gets the approvals of a pending transfer
+func (r *PendingTransferRepo) GetApprovals(ctx context.Context, id int) ([]*models.TransferApproval, error) {
+	query := `SELECT a.id, a.pending_transfer_id, a.user_id, u.username, a.created_at
+             FROM pending_transfer_approvals a
+             JOIN users u ON u.id = a.user_id
+             WHERE a.pending_transfer_id = $1
+             ORDER BY a.created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+	defer rows.Close()
+
+	approvals := []*models.TransferApproval{}
+	for rows.Next() {
+		approval := &models.TransferApproval{}
+		err := rows.Scan(
+			&approval.ID,
+			&approval.PendingTransferID,
+			&approval.UserID,
+			&approval.Username,
+			&approval.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan approval: %w", err)
+		}
+		approvals = append(approvals, approval)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// UpdateStatus moves a pending transfer from one status to another. It reports false if
+// the transfer was not in the expected status, so it can only be executed once.
+func (r *PendingTransferRepo) UpdateStatus(ctx context.Context, id int, from, to models.PendingTransferStatus) (bool, error) {
+	query := `UPDATE pending_transfers SET status = $1
+             WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update pending transfer status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// SetTransaction links the executed transaction to the pending transfer
+func (r *PendingTransferRepo) SetTransaction(ctx context.Context, id int, transactionID int) error {
+	query := `UPDATE pending_transfers SET transaction_id = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, transactionID, id); err != nil {
+		return fmt.Errorf("failed to link transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// RateRepo is a PostgreSQL implementation of the repository.RateRepository interface
+type RateRepo struct {
+	db *sql.DB
+}
+
+// NewRateRepository creates a new RateRepo
+func NewRateRepository(db *sql.DB) *RateRepo {
+	return &RateRepo{db: db}
+}
+
+// Create saves a fetched rate
+func (r *RateRepo) Create(ctx context.Context, rate *models.Rate) error {
+	query := `INSERT INTO rates_history (rate_type, currency, rate, fetched_at)
+             VALUES ($1, NULLIF($2, ''), $3, $4) RETURNING id`
+
+	err := r.db.QueryRowContext(ctx, query, rate.Type, rate.Currency, rate.Rate, rate.FetchedAt).Scan(&rate.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save rate: %w", err)
+	}
+
+	return nil
+}
+
+// GetHistory gets the rates fetched in the query period, oldest first
+func (r *RateRepo) GetHistory(ctx context.Context, q *models.RateHistoryQuery) ([]*models.Rate, error) {
+	query := `SELECT id, rate_type, COALESCE(currency, ''), rate, fetched_at
+             FROM rates_history
+             WHERE rate_type = $1 AND COALESCE(currency, '') = $2
+             AND fetched_at >= $3 AND fetched_at < $4
+             ORDER BY fetched_at, id`
+
+	rows, err := r.db.QueryContext(ctx, query, q.Type, q.Currency, q.Period.From, q.Period.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate history: %w", err)
+	}
+	defer rows.Close()
+
+	rates := []*models.Rate{}
+	for rows.Next() {
+		rate, err := scanRate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan rate: %w", err)
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return rates, nil
+}
+
+// GetAt gets the last rate fetched at or before a moment
+func (r *RateRepo) GetAt(ctx context.Context, rateType models.RateType, currency models.Currency, at time.Time) (*models.Rate, error) {
+	query := `SELECT id, rate_type, COALESCE(currency, ''), rate, fetched_at
+             FROM rates_history
+             WHERE rate_type = $1 AND COALESCE(currency, '') = $2 AND fetched_at <= $3
+             ORDER BY fetched_at DESC, id DESC
+             LIMIT 1`
+
+	rate, err := scanRate(r.db.QueryRowContext(ctx, query, rateType, currency, at))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("rate not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get rate: %w", err)
+	}
+
+	return rate, nil
+}
+
+// CreateKeyRateOverride saves an override of the key rate
+func (r *RateRepo) CreateKeyRateOverride(ctx context.Context, override *models.KeyRateOverride) error {
+	query := `INSERT INTO key_rate_overrides (rate, effective_from, effective_to, reason, created_by)
+             VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query,
+		override.Rate,
+		override.EffectiveFrom,
+		override.EffectiveTo,
+		override.Reason,
+		override.CreatedBy,
+	).Scan(&override.ID, &override.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create key rate override: %w", err)
+	}
+
+	return nil
+}
+
+// GetKeyRateOverrides gets all key rate overrides, including revoked ones, newest first
+func (r *RateRepo) GetKeyRateOverrides(ctx context.Context) ([]*models.KeyRateOverride, error) {
+	query := `SELECT id, rate, effective_from, effective_to, reason, created_by, revoked_by, revoked_at, created_at
+             FROM key_rate_overrides ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key rate overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := []*models.KeyRateOverride{}
+	for rows.Next() {
+		override := &models.KeyRateOverride{}
+		var effectiveTo, revokedAt sql.NullTime
+		var revokedBy sql.NullInt64
+		err := rows.Scan(
+			&override.ID,
+			&override.Rate,
+			&override.EffectiveFrom,
+			&effectiveTo,
+			&override.Reason,
+			&override.CreatedBy,
+			&revokedBy,
+			&revokedAt,
+			&override.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan key rate override: %w", err)
+		}
+		if effectiveTo.Valid {
+			override.EffectiveTo = &effectiveTo.Time
+		}
+		if revokedBy.Valid {
+			id := int(revokedBy.Int64)
+			override.RevokedBy = &id
+		}
+		if revokedAt.Valid {
+			override.RevokedAt = &revokedAt.Time
+		}
+		overrides = append(overrides, override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// RevokeKeyRateOverride revokes an override; it returns false if the override does not exist or
+// was revoked already
+func (r *RateRepo) RevokeKeyRateOverride(ctx context.Context, id int, adminID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE key_rate_overrides SET revoked_by = $2, revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`,
+		id, adminID)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke key rate override: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke key rate override: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanRate scans a rates_history row
+func scanRate(row interface{ Scan(...interface{}) error }) (*models.Rate, error) {
+	rate := &models.Rate{}
+	err := row.Scan(&rate.ID, &rate.Type, &rate.Currency, &rate.Rate, &rate.FetchedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ReferralRepo is a PostgreSQL implementation of the repository.ReferralRepository interface
+type ReferralRepo struct {
+	db *sql.DB
+}
+
+// NewReferralRepository creates a new ReferralRepo
+func NewReferralRepository(db *sql.DB) *ReferralRepo {
+	return &ReferralRepo{db: db}
+}
+
+// CreateCode assigns a referral code to a user. A user keeps its first code, so a
+// concurrent call for the same user is a no-op.
+func (r *ReferralRepo) CreateCode(ctx context.Context, userID int, code string) error {
+	query := `INSERT INTO referral_codes (user_id, code) VALUES ($1, $2) ON CONFLICT (user_id) DO NOTHING`
+
+	if _, err := r.db.ExecContext(ctx, query, userID, code); err != nil {
+		return fmt.Errorf("failed to create referral code: %w", err)
+	}
+
+	return nil
+}
+
+// GetCodeByUserID gets the referral code of a user
+func (r *ReferralRepo) GetCodeByUserID(ctx context.Context, userID int) (string, error) {
+	query := `SELECT code FROM referral_codes WHERE user_id = $1`
+
+	var code string
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&code); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("referral code not found: %w", err)
+		}
+		return "", fmt.Errorf("failed to get referral code: %w", err)
+	}
+
+	return code, nil
+}
+
+// GetUserIDByCode gets the user of the request's tenant a referral code belongs to
+func (r *ReferralRepo) GetUserIDByCode(ctx context.Context, code string) (int, error) {
+	query := `SELECT c.user_id FROM referral_codes c
+             JOIN users u ON u.id = c.user_id
+             WHERE c.code = $1 AND ($2 = '' OR u.tenant = $2)`
+
+	var userID int
+	if err := r.db.QueryRowContext(ctx, query, code, requestTenant(ctx)).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("referral code not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to get referral code: %w", err)
+	}
+
+	return userID, nil
+}
+
+// Create records that a user registered with another user's referral code
+func (r *ReferralRepo) Create(ctx context.Context, referral *models.Referral) (int, error) {
+	query := `INSERT INTO referrals (referrer_id, referee_id, code, status)
+             VALUES ($1, $2, $3, $4) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		referral.ReferrerID,
+		referral.RefereeID,
+		referral.Code,
+		referral.Status,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create referral: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByRefereeID gets the referral a user registered with
+func (r *ReferralRepo) GetByRefereeID(ctx context.Context, refereeID int) (*models.Referral, error) {
+	query := `SELECT r.id, r.referrer_id, r.referee_id, u.username, r.code, r.status, r.qualifying_transaction_id,
+             r.referrer_bonus, r.referee_bonus, r.referrer_bonus_transaction_id, r.referee_bonus_transaction_id,
+             r.qualified_at, r.rewarded_at, r.created_at
+             FROM referrals r
+             JOIN users u ON u.id = r.referee_id
+             WHERE r.referee_id = $1`
+
+	referral, err := scanReferral(r.db.QueryRowContext(ctx, query, refereeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("referral not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get referral: %w", err)
+	}
+
+	return referral, nil
+}
+
+// GetByReferrerID gets the referrals of a user, newest first
+func (r *ReferralRepo) GetByReferrerID(ctx context.Context, referrerID int) ([]*models.Referral, error) {
+	query := `SELECT r.id, r.referrer_id, r.referee_id, u.username, r.code, r.status, r.qualifying_transaction_id,
+             r.referrer_bonus, r.referee_bonus, r.referrer_bonus_transaction_id, r.referee_bonus_transaction_id,
+             r.qualified_at, r.rewarded_at, r.created_at
+             FROM referrals r
+             JOIN users u ON u.id = r.referee_id
+             WHERE r.referrer_id = $1
+             ORDER BY r.created_at DESC`
+
+	return r.getReferrals(ctx, query, referrerID)
+}
+
+// GetByStatus gets the referrals in a status, oldest first
+func (r *ReferralRepo) GetByStatus(ctx context.Context, status models.ReferralStatus) ([]*models.Referral, error) {
+	query := `SELECT r.id, r.referrer_id, r.referee_id, u.username, r.code, r.status, r.qualifying_transaction_id,
+             r.referrer_bonus, r.referee_bonus, r.referrer_bonus_transaction_id, r.referee_bonus_transaction_id,
+             r.qualified_at, r.rewarded_at, r.created_at
+             FROM referrals r
+             JOIN users u ON u.id = r.referee_id
+             WHERE r.status = $1
+             ORDER BY r.created_at`
+
+	return r.getReferrals(ctx, query, status)
+}
+
+// getReferrals gets the referrals selected by a query with a single argument
+func (r *ReferralRepo) getReferrals(ctx context.Context, query string, arg interface{}) ([]*models.Referral, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrals: %w", err)
+	}
+	defer rows.Close()
+
+	referrals := []*models.Referral{}
+	for rows.Next() {
+		referral, err := scanReferral(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referral: %w", err)
+		}
+		referrals = append(referrals, referral)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return referrals, nil
+}
+
+// Qualify marks a pending referral as qualified by the referee's deposit. It reports false if
+// the referral is no longer pending, so a referral qualifies only once.
+func (r *ReferralRepo) Qualify(ctx context.Context, id int, transactionID int) (bool, error) {
+	query := `UPDATE referrals
+             SET status = $1, qualifying_transaction_id = $2, qualified_at = CURRENT_TIMESTAMP
+             WHERE id = $3 AND status = $4`
+
+	result, err := r.db.ExecContext(ctx, query, models.ReferralStatusQualified, transactionID, id, models.ReferralStatusPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to qualify referral: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// ExpirePending expires the pending referrals created before the given time and returns how many expired
+func (r *ReferralRepo) ExpirePending(ctx context.Context, before time.Time) (int64, error) {
+	query := `UPDATE referrals SET status = $1 WHERE status = $2 AND created_at < $3`
+
+	result, err := r.db.ExecContext(ctx, query, models.ReferralStatusExpired, models.ReferralStatusPending, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire referrals: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
+
+// RewardTx records the bonus payouts of a qualified referral within an existing transaction. It
+// reports false if the referral is not qualified, so bonuses are paid only once.
+func (r *ReferralRepo) RewardTx(ctx context.Context, tx *sql.Tx, referral *models.Referral) (bool, error) {
+	query := `UPDATE referrals
+             SET status = $1, referrer_bonus = $2, referee_bonus = $3, referrer_bonus_transaction_id = $4,
+             referee_bonus_transaction_id = $5, rewarded_at = CURRENT_TIMESTAMP
+             WHERE id = $6 AND status = $7`
+
+	result, err := tx.ExecContext(ctx, query, models.ReferralStatusRewarded, referral.ReferrerBonus, referral.RefereeBonus,
+		referral.ReferrerBonusTxID, referral.RefereeBonusTxID, referral.ID, models.ReferralStatusQualified)
+	if err != nil {
+		return false, fmt.Errorf("failed to reward referral: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanReferral scans a referral row joined with the referee's username
+func scanReferral(row interface{ Scan(...interface{}) error }) (*models.Referral, error) {
+	referral := &models.Referral{}
+	err := row.Scan(
+		&referral.ID,
+		&referral.ReferrerID,
+		&referral.RefereeID,
+		&referral.RefereeUsername,
+		&referral.Code,
+		&referral.Status,
+		&referral.QualifyingTransactionID,
+		&referral.ReferrerBonus,
+		&referral.RefereeBonus,
+		&referral.ReferrerBonusTxID,
+		&referral.RefereeBonusTxID,
+		&referral.QualifiedAt,
+		&referral.RewardedAt,
+		&referral.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return referral, nil
+}
@@ -0,0 +1,273 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ReportingRepo is a PostgreSQL implementation of the repository.ReportingRepository interface.
+// Every report is a single aggregate query so the dashboard never loads individual rows. Reports
+// cover the request's tenant, or all tenants in background jobs.
+type ReportingRepo struct {
+	db   *sql.DB
+	bank *time.Location // payment dates start at midnight in it
+}
+
+// NewReportingRepository creates a new ReportingRepo
+func NewReportingRepository(db *sql.DB, bank *time.Location) *ReportingRepo {
+	return &ReportingRepo{db: db, bank: bank}
+}
+
+// GetTransactionVolume gets the completed transactions in [from, to) per day and currency
+func (r *ReportingRepo) GetTransactionVolume(ctx context.Context, from, to time.Time) ([]*models.DailyTransactionVolume, error) {
+	query := `SELECT date_trunc('day', transaction_date), currency, COUNT(*), SUM(amount)
+             FROM transactions
+             WHERE status = $1 AND transaction_date >= $2 AND transaction_date < $3 AND ($4 = '' OR tenant = $4)
+             GROUP BY 1, 2
+             ORDER BY 1, 2`
+
+	rows, err := r.db.QueryContext(ctx, query, models.TransactionStatusCompleted, from, to, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction volume: %w", err)
+	}
+	defer rows.Close()
+
+	volumes := []*models.DailyTransactionVolume{}
+	for rows.Next() {
+		volume := &models.DailyTransactionVolume{}
+		if err := rows.Scan(&volume.Date, &volume.Currency, &volume.Count, &volume.Value); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction volume: %w", err)
+		}
+		volumes = append(volumes, volume)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return volumes, nil
+}
+
+// GetNewUsers gets the number of users registered in [from, to) per day
+func (r *ReportingRepo) GetNewUsers(ctx context.Context, from, to time.Time) ([]*models.DailyNewUsers, error) {
+	query := `SELECT date_trunc('day', created_at), COUNT(*)
+             FROM users
+             WHERE created_at >= $1 AND created_at < $2 AND ($3 = '' OR tenant = $3)
+             GROUP BY 1
+             ORDER BY 1`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get new users: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.DailyNewUsers{}
+	for rows.Next() {
+		day := &models.DailyNewUsers{}
+		if err := rows.Scan(&day.Date, &day.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan new users: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetCreditsIssued gets the credits issued in [from, to) per day and currency of the credit account
+func (r *ReportingRepo) GetCreditsIssued(ctx context.Context, from, to time.Time) ([]*models.DailyCreditsIssued, error) {
+	query := `SELECT date_trunc('day', c.created_at), a.currency, COUNT(*), SUM(c.amount)
+             FROM credits c
+             JOIN accounts a ON a.id = c.account_id
+             WHERE c.status <> $1 AND c.created_at >= $2 AND c.created_at < $3 AND ($4 = '' OR c.tenant = $4)
+             GROUP BY 1, 2
+             ORDER BY 1, 2`
+
+	rows, err := r.db.QueryContext(ctx, query, models.CreditStatusRejected, from, to, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credits issued: %w", err)
+	}
+	defer rows.Close()
+
+	days := []*models.DailyCreditsIssued{}
+	for rows.Next() {
+		day := &models.DailyCreditsIssued{}
+		if err := rows.Scan(&day.Date, &day.Currency, &day.Count, &day.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan credits issued: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetCreditPortfolio gets the outstanding credits per currency as of today. A credit is
+// non-performing if its oldest unpaid installment is more than nplDays past its date.
+func (r *ReportingRepo) GetCreditPortfolio(ctx context.Context, nplDays int) ([]*models.CreditPortfolio, error) {
+	query := `WITH unpaid AS (
+                 SELECT credit_id,
+                 SUM(principal_amount) AS principal,
+                 SUM(total_amount + COALESCE(penalty_amount, 0)) FILTER (WHERE payment_date < CURRENT_DATE) AS overdue,
+                 MIN(payment_date) FILTER (WHERE payment_date < CURRENT_DATE) AS overdue_since
+                 FROM payment_schedules
+                 WHERE status IN ($1, $2)
+                 GROUP BY credit_id
+             )
+             SELECT a.currency, COUNT(*), SUM(u.principal),
+             COUNT(*) FILTER (WHERE u.overdue_since IS NOT NULL), COALESCE(SUM(u.overdue), 0),
+             COUNT(*) FILTER (WHERE u.overdue_since < CURRENT_DATE - $5::int),
+             COALESCE(SUM(u.principal) FILTER (WHERE u.overdue_since < CURRENT_DATE - $5::int), 0)
+             FROM credits c
+             JOIN unpaid u ON u.credit_id = c.id
+             JOIN accounts a ON a.id = c.account_id
+             WHERE c.status IN ($3, $4) AND ($6 = '' OR c.tenant = $6)
+             GROUP BY a.currency
+             ORDER BY a.currency`
+
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, models.PaymentStatusOverdue,
+		models.CreditStatusActive, models.CreditStatusOverdue, nplDays, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit portfolio: %w", err)
+	}
+	defer rows.Close()
+
+	portfolios := []*models.CreditPortfolio{}
+	for rows.Next() {
+		portfolio := &models.CreditPortfolio{}
+		if err := rows.Scan(
+			&portfolio.Currency,
+			&portfolio.ActiveCredits,
+			&portfolio.OutstandingPrincipal,
+			&portfolio.OverdueCredits,
+			&portfolio.OverdueAmount,
+			&portfolio.NonPerformingCredits,
+			&portfolio.NonPerformingPrincipal,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan credit portfolio: %w", err)
+		}
+		portfolio.SetNPLRatio()
+		portfolios = append(portfolios, portfolio)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return portfolios, nil
+}
+
+// GetCreditPortfolioItems gets every outstanding credit with its unpaid principal, overdue
+// amounts and oldest overdue installment as of the given day
+func (r *ReportingRepo) GetCreditPortfolioItems(ctx context.Context, asOf time.Time) ([]*models.CreditPortfolioItem, error) {
+	query := `WITH unpaid AS (
+                 SELECT credit_id,
+                 SUM(principal_amount) AS principal,
+                 SUM(total_amount) FILTER (WHERE payment_date < $3) AS overdue,
+                 SUM(COALESCE(penalty_amount, 0)) FILTER (WHERE payment_date < $3) AS penalty,
+                 MIN(payment_date) FILTER (WHERE payment_date < $3) AS overdue_since,
+                 MIN(payment_date) FILTER (WHERE payment_date >= $3) AS next_payment
+                 FROM payment_schedules
+                 WHERE status IN ($1, $2)
+                 GROUP BY credit_id
+             )
+             SELECT c.id, c.user_id, c.account_id, a.currency, c.status, c.amount, c.interest_rate,
+             c.term_months, c.monthly_payment, c.start_date, c.end_date,
+             u.principal, COALESCE(u.overdue, 0), COALESCE(u.penalty, 0), u.overdue_since, u.next_payment,
+             EXISTS (SELECT 1 FROM insurance_policies p WHERE p.credit_id = c.id AND p.status = $6)
+             FROM credits c
+             JOIN unpaid u ON u.credit_id = c.id
+             JOIN accounts a ON a.id = c.account_id
+             WHERE c.status IN ($4, $5) AND ($7 = '' OR c.tenant = $7)
+             ORDER BY c.id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentStatusPending, models.PaymentStatusOverdue, asOf,
+		models.CreditStatusActive, models.CreditStatusOverdue, models.InsurancePolicyStatusActive, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit portfolio items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.CreditPortfolioItem{}
+	for rows.Next() {
+		item := &models.CreditPortfolioItem{AsOf: asOf}
+		var overdueSince, nextPayment sql.NullTime
+		if err := rows.Scan(
+			&item.CreditID,
+			&item.UserID,
+			&item.AccountID,
+			&item.Currency,
+			&item.Status,
+			&item.Amount,
+			&item.InterestRate,
+			&item.TermMonths,
+			&item.MonthlyPayment,
+			&item.StartDate,
+			&item.EndDate,
+			&item.OutstandingPrincipal,
+			&item.OverdueAmount,
+			&item.PenaltyAmount,
+			&overdueSince,
+			&nextPayment,
+			&item.Insured,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan credit portfolio item: %w", err)
+		}
+		if overdueSince.Valid {
+			date := models.BusinessDate(overdueSince.Time, r.bank)
+			item.OverdueSince = &date
+		}
+		if nextPayment.Valid {
+			date := models.BusinessDate(nextPayment.Time, r.bank)
+			item.NextPaymentDate = &date
+		}
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
+
+// GetDepositBalances gets the balances of the active non-credit accounts per currency and type
+func (r *ReportingRepo) GetDepositBalances(ctx context.Context) ([]*models.DepositBalance, error) {
+	query := `SELECT currency, account_type, COUNT(*), SUM(balance)
+             FROM accounts
+             WHERE is_active = TRUE AND account_type <> $1 AND ($2 = '' OR tenant = $2)
+             GROUP BY currency, account_type
+             ORDER BY currency, account_type`
+
+	rows, err := r.db.QueryContext(ctx, query, models.AccountTypeCredit, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deposit balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := []*models.DepositBalance{}
+	for rows.Next() {
+		balance := &models.DepositBalance{}
+		if err := rows.Scan(&balance.Currency, &balance.AccountType, &balance.Accounts, &balance.Balance); err != nil {
+			return nil, fmt.Errorf("failed to scan deposit balance: %w", err)
+		}
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// SessionRepo is a PostgreSQL implementation of the repository.SessionRepository interface
+type SessionRepo struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a new SessionRepo
+func NewSessionRepository(db *sql.DB) *SessionRepo {
+	return &SessionRepo{db: db}
+}
+
+// Create creates a new session in the database
+func (r *SessionRepo) Create(ctx context.Context, session *models.Session) (int, error) {
+	query := `INSERT INTO sessions (user_id, session_id, ip_address, user_agent, location, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		session.UserID,
+		session.SessionID,
+		session.IPAddress,
+		session.UserAgent,
+		session.Location,
+		session.ExpiresAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetBySessionID gets a session by the session ID stored in the token
+func (r *SessionRepo) GetBySessionID(ctx context.Context, sessionID string) (*models.Session, error) {
+	query := `SELECT id, user_id, session_id, ip_address, user_agent, location,
+             created_at, expires_at, revoked_at
+             FROM sessions WHERE session_id = $1`
+
+	session := &models.Session{}
+	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.SessionID,
+		&session.IPAddress,
+		&session.UserAgent,
+		&session.Location,
+		&session.CreatedAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// GetByUserID gets all sessions (login history) for a user
+func (r *SessionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Session, error) {
+	query := `SELECT id, user_id, session_id, ip_address, user_agent, location,
+             created_at, expires_at, revoked_at
+             FROM sessions WHERE user_id = $1
+             ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// GetActiveByUserID gets sessions of a user that are neither revoked nor expired
+func (r *SessionRepo) GetActiveByUserID(ctx context.Context, userID int) ([]*models.Session, error) {
+	query := `SELECT id, user_id, session_id, ip_address, user_agent, location,
+             created_at, expires_at, revoked_at
+             FROM sessions
+             WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+             ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active sessions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// Revoke marks a session of a user as revoked
+func (r *SessionRepo) Revoke(ctx context.Context, id int, userID int) error {
+	query := `UPDATE sessions
+             SET revoked_at = NOW()
+             WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// RevokeAllExcept revokes every active session of a user except the given one
+func (r *SessionRepo) RevokeAllExcept(ctx context.Context, userID int, keepSessionID string) (int, error) {
+	query := `UPDATE sessions
+             SET revoked_at = NOW()
+             WHERE user_id = $1 AND session_id <> $2 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, userID, keepSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+// Helper function to scan multiple sessions
+func (r *SessionRepo) scanSessions(rows *sql.Rows) ([]*models.Session, error) {
+	var sessions []*models.Session
+
+	for rows.Next() {
+		session := &models.Session{}
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.SessionID,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.Location,
+			&session.CreatedAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return sessions, nil
+}
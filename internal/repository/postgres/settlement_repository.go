@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// SettlementRepo is a PostgreSQL implementation of the repository.SettlementRepository interface
+type SettlementRepo struct {
+	db *sql.DB
+}
+
+// NewSettlementRepository creates a new SettlementRepo
+func NewSettlementRepository(db *sql.DB) *SettlementRepo {
+	return &SettlementRepo{db: db}
+}
+
+// GetMerchantsToSettle gets the merchants with unsettled payments captured before the cutoff
+func (r *SettlementRepo) GetMerchantsToSettle(ctx context.Context, before time.Time) ([]int, error) {
+	query := `SELECT DISTINCT merchant_id FROM payment_intents
+             WHERE status = $1 AND settlement_batch_id IS NULL AND paid_at < $2
+             ORDER BY merchant_id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.PaymentIntentStatusSucceeded, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchants to settle: %w", err)
+	}
+	defer rows.Close()
+
+	var merchantIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan merchant ID: %w", err)
+		}
+		merchantIDs = append(merchantIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return merchantIDs, nil
+}
+
+// CreateBatchTx creates a settlement batch for a merchant within an existing transaction and assigns
+// to it every unsettled payment captured before the cutoff. The batch carries the count and total.
+func (r *SettlementRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, merchantID int, settlementDate, before time.Time) (*models.SettlementBatch, error) {
+	batch := &models.SettlementBatch{MerchantID: merchantID}
+
+	query := `INSERT INTO settlement_batches (merchant_id, settlement_date)
+             VALUES ($1, $2) RETURNING id, settlement_date, created_at`
+
+	err := tx.QueryRowContext(ctx, query, merchantID, settlementDate).Scan(&batch.ID, &batch.SettlementDate, &batch.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settlement batch: %w", err)
+	}
+
+	query = `WITH settled AS (
+                 UPDATE payment_intents SET settlement_batch_id = $1
+                 WHERE merchant_id = $2 AND status = $3 AND settlement_batch_id IS NULL AND paid_at < $4
+                 RETURNING amount
+             )
+             UPDATE settlement_batches
+             SET payment_count = (SELECT COUNT(*) FROM settled),
+                 amount = (SELECT COALESCE(SUM(amount), 0) FROM settled)
+             WHERE id = $1
+             RETURNING payment_count, amount`
+
+	err = tx.QueryRowContext(ctx, query, batch.ID, merchantID, models.PaymentIntentStatusSucceeded, before).
+		Scan(&batch.PaymentCount, &batch.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign payments to settlement batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// SetTransactionTx links the payout transaction to a settlement batch within an existing transaction
+func (r *SettlementRepo) SetTransactionTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error {
+	query := `UPDATE settlement_batches SET transaction_id = $1 WHERE id = $2`
+
+	if _, err := tx.ExecContext(ctx, query, transactionID, id); err != nil {
+		return fmt.Errorf("failed to link settlement transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByMerchantID gets the settlement batches of a merchant, newest first
+func (r *SettlementRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.SettlementBatch, error) {
+	query := `SELECT id, merchant_id, settlement_date, payment_count, amount, transaction_id, created_at
+             FROM settlement_batches WHERE merchant_id = $1
+             ORDER BY settlement_date DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement batches: %w", err)
+	}
+	defer rows.Close()
+
+	batches := []*models.SettlementBatch{}
+	for rows.Next() {
+		batch := &models.SettlementBatch{}
+		err := rows.Scan(
+			&batch.ID,
+			&batch.MerchantID,
+			&batch.SettlementDate,
+			&batch.PaymentCount,
+			&batch.Amount,
+			&batch.TransactionID,
+			&batch.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan settlement batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,275 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// StatementRepo is a PostgreSQL implementation of the repository.StatementRepository interface
+type StatementRepo struct {
+	db *sql.DB
+}
+
+// NewStatementRepository creates a new StatementRepo
+func NewStatementRepository(db *sql.DB) *StatementRepo {
+	return &StatementRepo{db: db}
+}
+
+// Issue computes the statement of an account for [from, to), stores it and locks the period.
+// The closing balance is derived from the current balance and the transactions posted since.
+func (r *StatementRepo) Issue(ctx context.Context, accountID int, from, to time.Time) (*models.Statement, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	statement := &models.Statement{
+		AccountID:   accountID,
+		PeriodStart: from,
+		PeriodEnd:   to,
+	}
+
+	if err = computeStatement(ctx, tx, statement); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO account_statements (account_id, period_start, period_end, opening_balance,
+             total_credits, total_debits, closing_balance, transaction_count)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, issued_at`
+
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		statement.AccountID,
+		statement.PeriodStart,
+		statement.PeriodEnd,
+		statement.OpeningBalance,
+		statement.TotalCredits,
+		statement.TotalDebits,
+		statement.ClosingBalance,
+		statement.TransactionCount,
+	).Scan(&statement.ID, &statement.IssuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create statement: %w", err)
+	}
+
+	query = `UPDATE accounts SET locked_until = $1
+             WHERE id = $2 AND (locked_until IS NULL OR locked_until < $1)`
+	if _, err = tx.ExecContext(ctx, query, to, accountID); err != nil {
+		return nil, fmt.Errorf("failed to lock statement period: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return statement, nil
+}
+
+// Regenerate recomputes a statement from the transactions of its period, which are locked, and
+// the current balance, the same way Issue does
+func (r *StatementRepo) Regenerate(ctx context.Context, id int) (*models.Statement, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	query := `SELECT id, account_id, period_start, period_end, opening_balance, total_credits,
+             total_debits, closing_balance, transaction_count, issued_at, regenerated_at
+             FROM account_statements WHERE id = $1`
+
+	statement, err := scanStatement(tx.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("statement not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	if err = computeStatement(ctx, tx, statement); err != nil {
+		return nil, err
+	}
+
+	query = `UPDATE account_statements SET opening_balance = $2, total_credits = $3, total_debits = $4,
+             closing_balance = $5, transaction_count = $6, regenerated_at = NOW()
+             WHERE id = $1 RETURNING regenerated_at`
+
+	var regeneratedAt time.Time
+	err = tx.QueryRowContext(
+		ctx,
+		query,
+		statement.ID,
+		statement.OpeningBalance,
+		statement.TotalCredits,
+		statement.TotalDebits,
+		statement.ClosingBalance,
+		statement.TransactionCount,
+	).Scan(&regeneratedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update statement: %w", err)
+	}
+	statement.RegeneratedAt = &regeneratedAt
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return statement, nil
+}
+
+// computeStatement computes the totals and balances of a statement for its period. It locks the
+// account so no transaction is posted meanwhile; the closing balance is derived from the current
+// balance and the transactions posted since.
+func computeStatement(ctx context.Context, tx *sql.Tx, statement *models.Statement) error {
+	var balance float64
+	err := tx.QueryRowContext(ctx, `SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`, statement.AccountID).Scan(&balance)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("account not found: %w", err)
+		}
+		return fmt.Errorf("failed to get account balance: %w", err)
+	}
+
+	query := `SELECT
+             COALESCE(SUM(amount) FILTER (WHERE destination_account_id = $1 AND transaction_date >= $3), 0)
+             - COALESCE(SUM(amount) FILTER (WHERE source_account_id = $1 AND transaction_date >= $3), 0),
+             COALESCE(SUM(amount) FILTER (WHERE destination_account_id = $1 AND transaction_date < $3), 0),
+             COALESCE(SUM(amount) FILTER (WHERE source_account_id = $1 AND transaction_date < $3), 0),
+             COUNT(*) FILTER (WHERE transaction_date < $3)
+             FROM transactions
+             WHERE (source_account_id = $1 OR destination_account_id = $1)
+             AND transaction_date >= $2 AND status NOT IN ($4, $5)`
+
+	var netAfter float64
+	err = tx.QueryRowContext(ctx, query, statement.AccountID, statement.PeriodStart, statement.PeriodEnd,
+		models.TransactionStatusFailed, models.TransactionStatusCancelled).Scan(
+		&netAfter,
+		&statement.TotalCredits,
+		&statement.TotalDebits,
+		&statement.TransactionCount,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to compute statement: %w", err)
+	}
+
+	statement.ClosingBalance = balance - netAfter
+	statement.OpeningBalance = statement.ClosingBalance - statement.TotalCredits + statement.TotalDebits
+
+	return nil
+}
+
+// GetByID gets a statement by ID
+func (r *StatementRepo) GetByID(ctx context.Context, id int) (*models.Statement, error) {
+	query := `SELECT id, account_id, period_start, period_end, opening_balance, total_credits,
+             total_debits, closing_balance, transaction_count, issued_at, regenerated_at
+             FROM account_statements WHERE id = $1`
+
+	statement, err := scanStatement(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("statement not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get statement: %w", err)
+	}
+
+	return statement, nil
+}
+
+// GetByAccountID gets the statements of an account, newest first
+func (r *StatementRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Statement, error) {
+	query := `SELECT id, account_id, period_start, period_end, opening_balance, total_credits,
+             total_debits, closing_balance, transaction_count, issued_at, regenerated_at
+             FROM account_statements WHERE account_id = $1
+             ORDER BY period_start DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
+	defer rows.Close()
+
+	statements := []*models.Statement{}
+	for rows.Next() {
+		statement, err := scanStatement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan statement: %w", err)
+		}
+		statements = append(statements, statement)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return statements, nil
+}
+
+// GetAccountsWithoutStatement gets the accounts opened before the end of the period that have no
+// statement for it yet
+func (r *StatementRepo) GetAccountsWithoutStatement(ctx context.Context, from, to time.Time) ([]int, error) {
+	query := `SELECT a.id FROM accounts a
+             WHERE a.created_at < $2 AND NOT EXISTS (
+                 SELECT 1 FROM account_statements s WHERE s.account_id = a.id AND s.period_start = $1)
+             ORDER BY a.id`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts without statement: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan account ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// scanStatement scans a single statement row
+func scanStatement(row interface{ Scan(...interface{}) error }) (*models.Statement, error) {
+	statement := &models.Statement{}
+	var regeneratedAt sql.NullTime
+	err := row.Scan(
+		&statement.ID,
+		&statement.AccountID,
+		&statement.PeriodStart,
+		&statement.PeriodEnd,
+		&statement.OpeningBalance,
+		&statement.TotalCredits,
+		&statement.TotalDebits,
+		&statement.ClosingBalance,
+		&statement.TransactionCount,
+		&statement.IssuedAt,
+		&regeneratedAt,
+	)
+	if regeneratedAt.Valid {
+		statement.RegeneratedAt = &regeneratedAt.Time
+	}
+	return statement, err
+}
@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// subscriptionPlanColumns lists the columns read by scanSubscriptionPlan
+const subscriptionPlanColumns = `SELECT id, merchant_id, name, amount, currency, billing_interval, is_active, created_at, updated_at
+             FROM subscription_plans`
+
+// subscriptionColumns lists the columns read by scanSubscription. The price comes from the plan.
+const subscriptionColumns = `SELECT s.id, s.plan_id, p.name, p.merchant_id, s.user_id, s.account_id, p.amount, p.currency,
+             p.billing_interval, s.status, s.next_billing_at, s.failed_attempts, s.next_retry_at, s.last_payment_error,
+             s.cancel_reason, s.cancelled_at, s.created_at, s.updated_at
+             FROM subscriptions s
+             JOIN subscription_plans p ON p.id = s.plan_id`
+
+// SubscriptionRepo is a PostgreSQL implementation of the repository.SubscriptionRepository interface
+type SubscriptionRepo struct {
+	db *sql.DB
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepo
+func NewSubscriptionRepository(db *sql.DB) *SubscriptionRepo {
+	return &SubscriptionRepo{db: db}
+}
+
+// CreatePlan creates a new subscription plan
+func (r *SubscriptionRepo) CreatePlan(ctx context.Context, plan *models.SubscriptionPlan) (int, error) {
+	query := `INSERT INTO subscription_plans (merchant_id, name, amount, currency, billing_interval, is_active)
+             VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		plan.MerchantID,
+		plan.Name,
+		plan.Amount,
+		plan.Currency,
+		plan.Interval,
+		plan.IsActive,
+	).Scan(&plan.ID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create subscription plan: %w", err)
+	}
+
+	return plan.ID, nil
+}
+
+// GetPlanByID gets a subscription plan by ID
+func (r *SubscriptionRepo) GetPlanByID(ctx context.Context, id int) (*models.SubscriptionPlan, error) {
+	plan, err := scanSubscriptionPlan(r.db.QueryRowContext(ctx, subscriptionPlanColumns+` WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("subscription plan not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get subscription plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetPlansByMerchantID gets the subscription plans of a merchant, oldest first
+func (r *SubscriptionRepo) GetPlansByMerchantID(ctx context.Context, merchantID int) ([]*models.SubscriptionPlan, error) {
+	rows, err := r.db.QueryContext(ctx, subscriptionPlanColumns+` WHERE merchant_id = $1 ORDER BY id`, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := []*models.SubscriptionPlan{}
+	for rows.Next() {
+		plan, err := scanSubscriptionPlan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription plan: %w", err)
+		}
+		plans = append(plans, plan)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return plans, nil
+}
+
+// SetPlanActive opens a subscription plan to new subscribers or closes it
+func (r *SubscriptionRepo) SetPlanActive(ctx context.Context, id int, isActive bool) error {
+	query := `UPDATE subscription_plans SET is_active = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, isActive, id); err != nil {
+		return fmt.Errorf("failed to update subscription plan: %w", err)
+	}
+
+	return nil
+}
+
+// Create creates a new subscription
+func (r *SubscriptionRepo) Create(ctx context.Context, subscription *models.Subscription) (int, error) {
+	query := `INSERT INTO subscriptions (plan_id, user_id, account_id, status, next_billing_at)
+             VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`
+
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		subscription.PlanID,
+		subscription.UserID,
+		subscription.AccountID,
+		subscription.Status,
+		subscription.NextBillingAt,
+	).Scan(&subscription.ID, &subscription.CreatedAt, &subscription.UpdatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return subscription.ID, nil
+}
+
+// GetByID gets a subscription by ID
+func (r *SubscriptionRepo) GetByID(ctx context.Context, id int) (*models.Subscription, error) {
+	subscription, err := scanSubscription(r.db.QueryRowContext(ctx, subscriptionColumns+` WHERE s.id = $1`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("subscription not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// GetByUserID gets the subscriptions of a user, newest first
+func (r *SubscriptionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Subscription, error) {
+	return r.getSubscriptions(ctx, subscriptionColumns+` WHERE s.user_id = $1 ORDER BY s.id DESC`, userID)
+}
+
+// GetByMerchantID gets the subscriptions to the plans of a merchant, newest first
+func (r *SubscriptionRepo) GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Subscription, error) {
+	return r.getSubscriptions(ctx, subscriptionColumns+` WHERE p.merchant_id = $1 ORDER BY s.id DESC`, merchantID)
+}
+
+// GetDue gets the active subscriptions whose next period has started and the past due ones
+// whose failed charge is due to be retried
+func (r *SubscriptionRepo) GetDue(ctx context.Context, now time.Time) ([]*models.Subscription, error) {
+	query := subscriptionColumns + ` WHERE (s.status = $1 AND s.next_billing_at <= $3)
+             OR (s.status = $2 AND s.next_retry_at <= $3)
+             ORDER BY s.id`
+
+	rows, err := r.db.QueryContext(ctx, query, models.SubscriptionStatusActive, models.SubscriptionStatusPastDue, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+// getSubscriptions gets the subscriptions selected by a query with a single argument
+func (r *SubscriptionRepo) getSubscriptions(ctx context.Context, query string, arg interface{}) ([]*models.Subscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSubscriptions(rows)
+}
+
+// AdvancePeriod moves the next billing date of an active subscription from one period to the
+// next. It reports false if the period has been billed already, so every period is billed once.
+func (r *SubscriptionRepo) AdvancePeriod(ctx context.Context, id int, from time.Time, to time.Time) (bool, error) {
+	query := `UPDATE subscriptions SET next_billing_at = $1 WHERE id = $2 AND status = $3 AND next_billing_at = $4`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, models.SubscriptionStatusActive, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance subscription period: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// RecordPayment returns a subscription that is not cancelled to ACTIVE and resets its failed charges
+func (r *SubscriptionRepo) RecordPayment(ctx context.Context, id int) error {
+	query := `UPDATE subscriptions
+             SET status = $1, failed_attempts = 0, next_retry_at = NULL, last_payment_error = ''
+             WHERE id = $2 AND status <> $3`
+
+	if _, err := r.db.ExecContext(ctx, query, models.SubscriptionStatusActive, id, models.SubscriptionStatusCancelled); err != nil {
+		return fmt.Errorf("failed to record subscription payment: %w", err)
+	}
+
+	return nil
+}
+
+// RecordFailure marks a subscription that is not cancelled PAST_DUE after a failed charge and
+// schedules the retry. It returns the number of consecutive failed charges.
+func (r *SubscriptionRepo) RecordFailure(ctx context.Context, id int, reason string, nextRetryAt time.Time) (int, error) {
+	query := `UPDATE subscriptions
+             SET status = $1, failed_attempts = failed_attempts + 1, next_retry_at = $2, last_payment_error = $3
+             WHERE id = $4 AND status <> $5
+             RETURNING failed_attempts`
+
+	var attempts int
+	err := r.db.QueryRowContext(ctx, query, models.SubscriptionStatusPastDue, nextRetryAt, reason, id,
+		models.SubscriptionStatusCancelled).Scan(&attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("subscription not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to record subscription failure: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// Cancel cancels a subscription. It reports false if the subscription was already cancelled.
+func (r *SubscriptionRepo) Cancel(ctx context.Context, id int, reason string) (bool, error) {
+	query := `UPDATE subscriptions
+             SET status = $1, cancel_reason = $2, cancelled_at = CURRENT_TIMESTAMP, next_retry_at = NULL
+             WHERE id = $3 AND status <> $1`
+
+	result, err := r.db.ExecContext(ctx, query, models.SubscriptionStatusCancelled, reason, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel subscription: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// scanSubscriptions scans subscription rows
+func scanSubscriptions(rows *sql.Rows) ([]*models.Subscription, error) {
+	subscriptions := []*models.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// scanSubscription scans a single subscription row
+func scanSubscription(row interface{ Scan(...interface{}) error }) (*models.Subscription, error) {
+	subscription := &models.Subscription{}
+	err := row.Scan(
+		&subscription.ID,
+		&subscription.PlanID,
+		&subscription.PlanName,
+		&subscription.MerchantID,
+		&subscription.UserID,
+		&subscription.AccountID,
+		&subscription.Amount,
+		&subscription.Currency,
+		&subscription.Interval,
+		&subscription.Status,
+		&subscription.NextBillingAt,
+		&subscription.FailedAttempts,
+		&subscription.NextRetryAt,
+		&subscription.LastPaymentError,
+		&subscription.CancelReason,
+		&subscription.CancelledAt,
+		&subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// scanSubscriptionPlan scans a single subscription plan row
+func scanSubscriptionPlan(row interface{ Scan(...interface{}) error }) (*models.SubscriptionPlan, error) {
+	plan := &models.SubscriptionPlan{}
+	err := row.Scan(
+		&plan.ID,
+		&plan.MerchantID,
+		&plan.Name,
+		&plan.Amount,
+		&plan.Currency,
+		&plan.Interval,
+		&plan.IsActive,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TaxDocumentRepo is a PostgreSQL implementation of the repository.TaxDocumentRepository interface
+type TaxDocumentRepo struct {
+	db *sql.DB
+}
+
+// NewTaxDocumentRepository creates a new TaxDocumentRepo
+func NewTaxDocumentRepository(db *sql.DB) *TaxDocumentRepo {
+	return &TaxDocumentRepo{db: db}
+}
+
+// GetInterestEarned sums the interest credited to each of the user's personal accounts in [from, to)
+func (r *TaxDocumentRepo) GetInterestEarned(ctx context.Context, userID int, from, to time.Time) ([]models.TaxInterestLine, error) {
+	query := `SELECT a.account_number, a.account_type, a.currency, SUM(t.amount)
+             FROM transactions t
+             JOIN accounts a ON a.id = t.destination_account_id
+             WHERE a.user_id = $1 AND a.organization_id IS NULL
+             AND t.transaction_type = $2 AND t.status = $3
+             AND t.transaction_date >= $4 AND t.transaction_date < $5
+             GROUP BY a.account_number, a.account_type, a.currency
+             ORDER BY a.account_number`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, models.TransactionTypeInterest,
+		models.TransactionStatusCompleted, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interest earned: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []models.TaxInterestLine{}
+	for rows.Next() {
+		var line models.TaxInterestLine
+		if err := rows.Scan(&line.AccountNumber, &line.AccountType, &line.Currency, &line.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan interest earned: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetLoanInterestPaid sums the interest and penalties of the user's credit payments made in [from, to).
+// A scheduled payment is stamped with updated_at when it is paid, which may be after its due date.
+func (r *TaxDocumentRepo) GetLoanInterestPaid(ctx context.Context, userID int, from, to time.Time) ([]models.TaxLoanLine, error) {
+	query := `SELECT c.id, SUM(ps.interest_amount), SUM(COALESCE(ps.penalty_amount, 0))
+             FROM payment_schedules ps
+             JOIN credits c ON c.id = ps.credit_id
+             WHERE c.user_id = $1 AND ps.status = $2
+             AND ps.updated_at >= $3 AND ps.updated_at < $4
+             GROUP BY c.id
+             ORDER BY c.id`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, models.PaymentStatusPaid, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan interest paid: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []models.TaxLoanLine{}
+	for rows.Next() {
+		var line models.TaxLoanLine
+		if err := rows.Scan(&line.CreditID, &line.InterestPaid, &line.PenaltiesPaid); err != nil {
+			return nil, fmt.Errorf("failed to scan loan interest paid: %w", err)
+		}
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetUsersWithActivity gets the users who earned interest or paid credit interest in [from, to)
+func (r *TaxDocumentRepo) GetUsersWithActivity(ctx context.Context, from, to time.Time) ([]int, error) {
+	query := `SELECT a.user_id
+             FROM transactions t
+             JOIN accounts a ON a.id = t.destination_account_id
+             WHERE a.organization_id IS NULL AND t.transaction_type = $1 AND t.status = $2
+             AND t.transaction_date >= $3 AND t.transaction_date < $4
+             UNION
+             SELECT c.user_id
+             FROM payment_schedules ps
+             JOIN credits c ON c.id = ps.credit_id
+             WHERE ps.status = $5 AND ps.updated_at >= $3 AND ps.updated_at < $4`
+
+	rows, err := r.db.QueryContext(ctx, query, models.TransactionTypeInterest, models.TransactionStatusCompleted,
+		from, to, models.PaymentStatusPaid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with tax activity: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// Upsert stores a generated tax document, replacing an earlier version for the same year
+func (r *TaxDocumentRepo) Upsert(ctx context.Context, doc *models.TaxDocument) (int, error) {
+	details, err := json.Marshal(taxDocumentDetails{InterestLines: doc.InterestLines, LoanLines: doc.LoanLines})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode tax document details: %w", err)
+	}
+
+	query := `INSERT INTO tax_documents (user_id, year, interest_earned, loan_interest_paid, loan_penalties_paid, details)
+             VALUES ($1, $2, $3, $4, $5, $6)
+             ON CONFLICT (user_id, year) DO UPDATE
+             SET interest_earned = EXCLUDED.interest_earned, loan_interest_paid = EXCLUDED.loan_interest_paid,
+             loan_penalties_paid = EXCLUDED.loan_penalties_paid, details = EXCLUDED.details,
+             generated_at = CURRENT_TIMESTAMP
+             RETURNING id, generated_at, emailed_at`
+
+	err = r.db.QueryRowContext(
+		ctx,
+		query,
+		doc.UserID,
+		doc.Year,
+		doc.InterestEarned,
+		doc.LoanInterestPaid,
+		doc.LoanPenaltiesPaid,
+		details,
+	).Scan(&doc.ID, &doc.GeneratedAt, &doc.EmailedAt)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to save tax document: %w", err)
+	}
+
+	return doc.ID, nil
+}
+
+// GetByUserAndYear gets the tax document of a user for a year
+func (r *TaxDocumentRepo) GetByUserAndYear(ctx context.Context, userID int, year int) (*models.TaxDocument, error) {
+	query := `SELECT id, user_id, year, interest_earned, loan_interest_paid, loan_penalties_paid, details,
+             generated_at, emailed_at
+             FROM tax_documents
+             WHERE user_id = $1 AND year = $2`
+
+	doc, err := scanTaxDocument(r.db.QueryRowContext(ctx, query, userID, year))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("tax document not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get tax document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// GetByUserID gets the tax documents of a user, newest year first
+func (r *TaxDocumentRepo) GetByUserID(ctx context.Context, userID int) ([]*models.TaxDocument, error) {
+	query := `SELECT id, user_id, year, interest_earned, loan_interest_paid, loan_penalties_paid, details,
+             generated_at, emailed_at
+             FROM tax_documents
+             WHERE user_id = $1
+             ORDER BY year DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*models.TaxDocument{}
+	for rows.Next() {
+		doc, err := scanTaxDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tax document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return docs, nil
+}
+
+// MarkEmailed records that a tax document was delivered by email
+func (r *TaxDocumentRepo) MarkEmailed(ctx context.Context, id int) error {
+	query := `UPDATE tax_documents SET emailed_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark tax document as emailed: %w", err)
+	}
+
+	return nil
+}
+
+// taxDocumentDetails is the JSONB layout of a tax document's lines
+type taxDocumentDetails struct {
+	InterestLines []models.TaxInterestLine `json:"interest_lines"`
+	LoanLines     []models.TaxLoanLine     `json:"loan_lines"`
+}
+
+// scanTaxDocument scans a tax document row, decoding its lines
+func scanTaxDocument(row interface{ Scan(...interface{}) error }) (*models.TaxDocument, error) {
+	doc := &models.TaxDocument{}
+	var details []byte
+	err := row.Scan(
+		&doc.ID,
+		&doc.UserID,
+		&doc.Year,
+		&doc.InterestEarned,
+		&doc.LoanInterestPaid,
+		&doc.LoanPenaltiesPaid,
+		&details,
+		&doc.GeneratedAt,
+		&doc.EmailedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded taxDocumentDetails
+	if err := json.Unmarshal(details, &decoded); err != nil {
+		return nil, fmt.Errorf("failed to decode tax document details: %w", err)
+	}
+	doc.InterestLines = decoded.InterestLines
+	doc.LoanLines = decoded.LoanLines
+
+	return doc, nil
+}
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// requestTenant returns the tenant the request was made for, or "" in background jobs that work
+// across tenants. Queries are not filtered by tenant when it is empty.
+//
+// Only users, accounts, transactions, cards, credits and products store their tenant. The records
+// admins look up by ID or list, like chargebacks or credit applications, are filtered by the tenant
+// of their account or user. Locations, rates, bill providers, fee runs and email suppressions are
+// the bank's own and shared by all tenants. The other records of a user, like sessions, API keys or
+// invoices, are only reached through their user's token or an account already filtered by tenant.
+func requestTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value("tenant").(string)
+	return tenant
+}
+
+// accountInTenant returns the condition that the account in a column belongs to the tenant in a query
+// argument, for records that take their tenant from an account. Records of all tenants match an
+// empty tenant.
+func accountInTenant(column, arg string) string {
+	return fmt.Sprintf(`(%[2]s = '' OR %[1]s IN (SELECT id FROM accounts WHERE tenant = %[2]s))`, column, arg)
+}
+
+// userInTenant returns the condition that the user in a column belongs to the tenant in a query
+// argument, for records that take their tenant from their user. Records of all tenants match an
+// empty tenant.
+func userInTenant(column, arg string) string {
+	return fmt.Sprintf(`(%[2]s = '' OR %[1]s IN (SELECT id FROM users WHERE tenant = %[2]s))`, column, arg)
+}
+
+// newRecordTenant returns the tenant a new user or product is created in: the one set on it, else
+// the request's, else the default
+func newRecordTenant(ctx context.Context, tenant string) string {
+	if tenant == "" {
+		tenant = requestTenant(ctx)
+	}
+	if tenant == "" {
+		tenant = models.DefaultTenant
+	}
+	return tenant
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"banking-service/internal/models"
+)
+
+// TransactionDescriptionRepo is a PostgreSQL implementation of the
+// repository.TransactionDescriptionRepository interface
+type TransactionDescriptionRepo struct {
+	db *sql.DB
+}
+
+// NewTransactionDescriptionRepository creates a new TransactionDescriptionRepo
+func NewTransactionDescriptionRepository(db *sql.DB) *TransactionDescriptionRepo {
+	return &TransactionDescriptionRepo{db: db}
+}
+
+// Create records a user's edit of the description of a transaction
+func (r *TransactionDescriptionRepo) Create(ctx context.Context, edit *models.TransactionDescriptionEdit) (int, error) {
+	query := `INSERT INTO transaction_description_edits (transaction_id, user_id, description)
+             VALUES ($1, $2, $3) RETURNING id, created_at`
+
+	err := r.db.QueryRowContext(ctx, query, edit.TransactionID, edit.UserID, edit.Description).Scan(&edit.ID, &edit.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transaction description edit: %w", err)
+	}
+
+	return edit.ID, nil
+}
+
+// GetHistory gets a user's edits of the description of a transaction, oldest first
+func (r *TransactionDescriptionRepo) GetHistory(ctx context.Context, transactionID int, userID int) ([]*models.TransactionDescriptionEdit, error) {
+	query := `SELECT id, transaction_id, user_id, description, created_at
+             FROM transaction_description_edits
+             WHERE transaction_id = $1 AND user_id = $2
+             ORDER BY id`
+
+	return r.query(ctx, query, transactionID, userID)
+}
+
+// GetLatest gets a user's latest edit of the description of each of the transactions that has one,
+// by transaction ID
+func (r *TransactionDescriptionRepo) GetLatest(ctx context.Context, userID int, transactionIDs []int) (map[int]*models.TransactionDescriptionEdit, error) {
+	latest := make(map[int]*models.TransactionDescriptionEdit)
+	if len(transactionIDs) == 0 {
+		return latest, nil
+	}
+
+	placeholders := make([]string, 0, len(transactionIDs))
+	args := []interface{}{userID}
+	for _, id := range transactionIDs {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`SELECT DISTINCT ON (transaction_id) id, transaction_id, user_id, description, created_at
+             FROM transaction_description_edits
+             WHERE user_id = $1 AND transaction_id IN (%s)
+             ORDER BY transaction_id, id DESC`, strings.Join(placeholders, ", "))
+
+	edits, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, edit := range edits {
+		latest[edit.TransactionID] = edit
+	}
+
+	return latest, nil
+}
+
+// query gets the transaction description edits a query selects
+func (r *TransactionDescriptionRepo) query(ctx context.Context, query string, args ...interface{}) ([]*models.TransactionDescriptionEdit, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction description edits: %w", err)
+	}
+	defer rows.Close()
+
+	edits := []*models.TransactionDescriptionEdit{}
+	for rows.Next() {
+		edit := &models.TransactionDescriptionEdit{}
+		if err := rows.Scan(&edit.ID, &edit.TransactionID, &edit.UserID, &edit.Description, &edit.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction description edit: %w", err)
+		}
+		edits = append(edits, edit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return edits, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// TransactionEventRepo is a PostgreSQL implementation of the repository.TransactionEventRepository
+// interface. The events are written by the record_transaction_event trigger on the transactions table.
+type TransactionEventRepo struct {
+	db *sql.DB
+}
+
+// NewTransactionEventRepository creates a new TransactionEventRepo
+func NewTransactionEventRepository(db *sql.DB) *TransactionEventRepo {
+	return &TransactionEventRepo{db: db}
+}
+
+// GetUnprocessed gets up to limit events the indexer has not processed yet, oldest first
+func (r *TransactionEventRepo) GetUnprocessed(ctx context.Context, limit int) ([]*models.TransactionEvent, error) {
+	query := `SELECT id, transaction_id, created_at, processed_at FROM transaction_events
+             WHERE processed_at IS NULL ORDER BY id LIMIT $1`
+
+	rows, err := r.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.TransactionEvent{}
+	for rows.Next() {
+		event := &models.TransactionEvent{}
+		if err := rows.Scan(&event.ID, &event.TransactionID, &event.CreatedAt, &event.ProcessedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetTransactionIDsSince gets the IDs of the transactions created or changed since a time,
+// whether their events were processed or not
+func (r *TransactionEventRepo) GetTransactionIDsSince(ctx context.Context, since time.Time) ([]int, error) {
+	query := `SELECT DISTINCT transaction_id FROM transaction_events WHERE created_at >= $1 ORDER BY transaction_id`
+
+	rows, err := r.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction events: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction event: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return ids, nil
+}
+
+// MarkProcessed marks events processed
+func (r *TransactionEventRepo) MarkProcessed(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`UPDATE transaction_events SET processed_at = CURRENT_TIMESTAMP
+             WHERE id IN (%s) AND processed_at IS NULL`, strings.Join(placeholders, ", "))
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to mark transaction events processed: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProcessed deletes the processed events created before a time and returns how many
+func (r *TransactionEventRepo) DeleteProcessed(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM transaction_events WHERE processed_at IS NOT NULL AND created_at < $1`
+
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete transaction events: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows, nil
+}
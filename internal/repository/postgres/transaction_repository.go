@@ -5,11 +5,45 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"banking-service/internal/models"
 )
 
+// lockedPeriodQuery reports whether the source ($1) or destination ($2) account has a statement
+// covering the date ($3)
+const lockedPeriodQuery = `SELECT EXISTS (SELECT 1 FROM accounts WHERE (id = $1 OR id = $2) AND locked_until > $3)`
+
+// transactionBatchSize is the number of rows CreateBatch inserts per statement, well below the
+// limit of 65535 bind parameters
+const transactionBatchSize = 1000
+
+// transactionColumns lists the columns read by scanTransactions
+const transactionColumns = `id, transaction_type, source_account_id, destination_account_id, 
+             amount, currency, description, status, card_id, transaction_date, created_at,
+             reference, COALESCE(decline_reason, '') AS decline_reason`
+
+// userTransactionsQuery selects the transactions of the request's tenant ($2) moving money from or to
+// the personal accounts of the user ($1) that match the extra condition, newest first with the reverse ID order breaking ties
+// so the order is stable. Each side is looked up separately so the source and destination indexes
+// are used, and UNION drops the duplicate of a transfer between two accounts of the user.
+const userTransactionsQuery = `WITH user_accounts AS (
+                 SELECT id FROM accounts WHERE user_id = $1 AND organization_id IS NULL
+             )
+             SELECT t.id, t.transaction_type, t.source_account_id, t.destination_account_id, 
+             t.amount, t.currency, t.description, t.status, t.card_id, t.transaction_date, t.created_at,
+             t.reference, COALESCE(t.decline_reason, '') AS decline_reason
+             FROM transactions t
+             WHERE t.source_account_id IN (SELECT id FROM user_accounts) AND ($2 = '' OR t.tenant = $2) %[1]s
+             UNION
+             SELECT t.id, t.transaction_type, t.source_account_id, t.destination_account_id, 
+             t.amount, t.currency, t.description, t.status, t.card_id, t.transaction_date, t.created_at,
+             t.reference, COALESCE(t.decline_reason, '') AS decline_reason
+             FROM transactions t
+             WHERE t.destination_account_id IN (SELECT id FROM user_accounts) AND ($2 = '' OR t.tenant = $2) %[1]s
+             ORDER BY transaction_date DESC, id DESC`
+
 // TransactionRepo is a PostgreSQL implementation of the repository.TransactionRepository interface
 type TransactionRepo struct {
 	db *sql.DB
@@ -20,14 +54,25 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepo {
 	return &TransactionRepo{db: db}
 }
 
-// Create creates a new transaction in the database
+// Create creates a new transaction in the database unless it is dated in a locked statement period
 func (r *TransactionRepo) Create(ctx context.Context, transaction *models.Transaction) (int, error) {
+	var locked bool
+	err := r.db.QueryRowContext(ctx, lockedPeriodQuery, transaction.SourceAccountID,
+		transaction.DestinationAccountID, transaction.TransactionDate).Scan(&locked)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check statement period: %w", err)
+	}
+	
+	if locked {
+		return 0, errors.New("transaction date falls in a locked statement period")
+	}
+	
 	query := `INSERT INTO transactions (transaction_type, source_account_id, destination_account_id, 
-             amount, currency, description, status, card_id, transaction_date) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+             amount, currency, description, status, card_id, transaction_date, decline_reason) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')) RETURNING id, reference`
 	
 	var id int
-	err := r.db.QueryRowContext(
+	err = r.db.QueryRowContext(
 		ctx,
 		query,
 		transaction.TransactionType,
@@ -39,7 +84,8 @@ func (r *TransactionRepo) Create(ctx context.Context, transaction *models.Transa
 		transaction.Status,
 		transaction.CardID,
 		transaction.TransactionDate,
-	).Scan(&id)
+		transaction.DeclineReason,
+	).Scan(&id, &transaction.Reference)
 	
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction: %w", err)
@@ -51,13 +97,14 @@ func (r *TransactionRepo) Create(ctx context.Context, transaction *models.Transa
 // GetByID gets a transaction by ID
 func (r *TransactionRepo) GetByID(ctx context.Context, id int) (*models.Transaction, error) {
 	query := `SELECT id, transaction_type, source_account_id, destination_account_id, 
-             amount, currency, description, status, card_id, transaction_date, created_at
-             FROM transactions WHERE id = $1`
+             amount, currency, description, status, card_id, transaction_date, created_at,
+             reference, COALESCE(decline_reason, '') AS decline_reason
+             FROM transactions WHERE id = $1 AND ($2 = '' OR tenant = $2)`
 	
 	transaction := &models.Transaction{}
 	var sourceAccountID, destinationAccountID, cardID sql.NullInt32
 	
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)).Scan(
 		&transaction.ID,
 		&transaction.TransactionType,
 		&sourceAccountID,
@@ -69,6 +116,8 @@ func (r *TransactionRepo) GetByID(ctx context.Context, id int) (*models.Transact
 		&cardID,
 		&transaction.TransactionDate,
 		&transaction.CreatedAt,
+		&transaction.Reference,
+		&transaction.DeclineReason,
 	)
 	
 	if err != nil {
@@ -97,15 +146,38 @@ func (r *TransactionRepo) GetByID(ctx context.Context, id int) (*models.Transact
 	return transaction, nil
 }
 
+// GetByReference gets a transaction by its public reference
+func (r *TransactionRepo) GetByReference(ctx context.Context, reference string) (*models.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE reference = $1 AND ($2 = '' OR tenant = $2)`
+	
+	rows, err := r.db.QueryContext(ctx, query, reference, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	defer rows.Close()
+	
+	transactions, err := r.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	
+	if len(transactions) == 0 {
+		return nil, fmt.Errorf("transaction not found: %w", sql.ErrNoRows)
+	}
+	
+	return transactions[0], nil
+}
+
 // GetByAccountID gets all transactions for an account
 func (r *TransactionRepo) GetByAccountID(ctx context.Context, accountID int) ([]*models.Transaction, error) {
 	query := `SELECT id, transaction_type, source_account_id, destination_account_id, 
-             amount, currency, description, status, card_id, transaction_date, created_at
+             amount, currency, description, status, card_id, transaction_date, created_at,
+             reference, COALESCE(decline_reason, '') AS decline_reason
              FROM transactions 
-             WHERE source_account_id = $1 OR destination_account_id = $1
+             WHERE (source_account_id = $1 OR destination_account_id = $1) AND ($2 = '' OR tenant = $2)
              ORDER BY transaction_date DESC`
 	
-	rows, err := r.db.QueryContext(ctx, query, accountID)
+	rows, err := r.db.QueryContext(ctx, query, accountID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -116,14 +188,9 @@ func (r *TransactionRepo) GetByAccountID(ctx context.Context, accountID int) ([]
 
 // GetByUserID gets all transactions for a user through their accounts
 func (r *TransactionRepo) GetByUserID(ctx context.Context, userID int) ([]*models.Transaction, error) {
-	query := `SELECT t.id, t.transaction_type, t.source_account_id, t.destination_account_id, 
-             t.amount, t.currency, t.description, t.status, t.card_id, t.transaction_date, t.created_at
-             FROM transactions t
-             JOIN accounts a ON t.source_account_id = a.id OR t.destination_account_id = a.id
-             WHERE a.user_id = $1
-             ORDER BY t.transaction_date DESC`
+	query := fmt.Sprintf(userTransactionsQuery, "")
 	
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, userID, requestTenant(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -134,14 +201,9 @@ func (r *TransactionRepo) GetByUserID(ctx context.Context, userID int) ([]*model
 
 // GetByDateRange gets all transactions for a user within a date range
 func (r *TransactionRepo) GetByDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*models.Transaction, error) {
-	query := `SELECT t.id, t.transaction_type, t.source_account_id, t.destination_account_id, 
-             t.amount, t.currency, t.description, t.status, t.card_id, t.transaction_date, t.created_at
-             FROM transactions t
-             JOIN accounts a ON t.source_account_id = a.id OR t.destination_account_id = a.id
-             WHERE a.user_id = $1 AND t.transaction_date BETWEEN $2 AND $3
-             ORDER BY t.transaction_date DESC`
+	query := fmt.Sprintf(userTransactionsQuery, "AND t.transaction_date BETWEEN $3 AND $4")
 	
-	rows, err := r.db.QueryContext(ctx, query, userID, startDate, endDate)
+	rows, err := r.db.QueryContext(ctx, query, userID, requestTenant(ctx), startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -150,34 +212,472 @@ func (r *TransactionRepo) GetByDateRange(ctx context.Context, userID int, startD
 	return r.scanTransactions(rows)
 }
 
-// Update updates a transaction
+// currencyMismatch matches the transactions of the enclosing query (t) whose currency differs from
+// the currency of their account (a). Transfers only move money between accounts of the same currency,
+// so the source account, or the destination account if there is none, decides.
+const currencyMismatch = `a.id = COALESCE(t.source_account_id, t.destination_account_id) AND t.currency <> a.currency`
+
+// FixCurrencies sets the currency of transactions recorded in a currency other than their account's
+// to the account currency and returns how many it corrected. Transactions in a locked statement
+// period are left as they are.
+func (r *TransactionRepo) FixCurrencies(ctx context.Context) (int64, error) {
+	query := `UPDATE transactions t SET currency = a.currency
+             FROM accounts a
+             WHERE ` + currencyMismatch + `
+             AND NOT EXISTS (SELECT 1 FROM accounts l 
+                 WHERE (l.id = t.source_account_id OR l.id = t.destination_account_id) AND l.locked_until > t.transaction_date)`
+	
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fix transaction currencies: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rows, nil
+}
+
+// GetByIDs gets the transactions with the given IDs in ID order, skipping IDs that do not exist or
+// belong to another tenant
+func (r *TransactionRepo) GetByIDs(ctx context.Context, ids []int) ([]*models.Transaction, error) {
+	if len(ids) == 0 {
+		return []*models.Transaction{}, nil
+	}
+	
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+	
+	args = append(args, requestTenant(ctx))
+	query := fmt.Sprintf(`SELECT `+transactionColumns+` FROM transactions WHERE id IN (%s) AND ($%[2]d = '' OR tenant = $%[2]d) ORDER BY id`,
+		strings.Join(placeholders, ", "), len(args))
+	
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanTransactions(rows)
+}
+
+// GetAfterID gets up to limit transactions with IDs greater than afterID, in ID order
+func (r *TransactionRepo) GetAfterID(ctx context.Context, afterID int, limit int) ([]*models.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE id > $1 ORDER BY id LIMIT $2`
+	
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanTransactions(rows)
+}
+
+// GetChanged gets up to limit transactions created or changed in [from, to) with an ID greater
+// than afterID, in ID order
+func (r *TransactionRepo) GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions
+             WHERE updated_at >= $1 AND updated_at < $2 AND id > $3 ORDER BY id LIMIT $4`
+	
+	rows, err := r.db.QueryContext(ctx, query, from, to, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanTransactions(rows)
+}
+
+// GetActivity gets up to limit transactions of an account dated before a position, newest first
+func (r *TransactionRepo) GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.Transaction, error) {
+	query := `SELECT ` + transactionColumns + ` FROM transactions
+             WHERE (source_account_id = $1 OR destination_account_id = $1)
+             AND ($2::timestamptz IS NULL OR transaction_date < $2 OR (transaction_date = $2 AND id < $3))
+             AND ($5 = '' OR tenant = $5)
+             ORDER BY transaction_date DESC, id DESC
+             LIMIT $4`
+	
+	var at interface{}
+	var id int
+	if before != nil {
+		at, id = before.At, before.ID
+	}
+	
+	rows, err := r.db.QueryContext(ctx, query, accountID, at, id, limit, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account activity: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanTransactions(rows)
+}
+
+// Search finds a page of the transactions of the accounts that match a search, newest first, with
+// the total number and the facets of all matching transactions. Every word of the query must
+// appear in the description, ignoring case.
+func (r *TransactionRepo) Search(ctx context.Context, accountIDs []int, search *models.TransactionSearch) (*models.TransactionSearchResult, error) {
+	result := &models.TransactionSearchResult{
+		Transactions: []*models.Transaction{},
+		Facets:       models.NewTransactionFacets(),
+		Source:       models.SearchSourceDatabase,
+		Limit:        search.Limit,
+		Offset:       search.Offset,
+	}
+	if len(accountIDs) == 0 {
+		return result, nil
+	}
+	
+	where, args := transactionSearchConditions(requestTenant(ctx), accountIDs, search)
+	
+	// One grouping set per facet; the columns grouped by the other sets are NULL in its rows
+	facetQuery := `SELECT transaction_type, status, currency, COUNT(*) FROM transactions t
+             WHERE ` + where + `
+             GROUP BY GROUPING SETS ((transaction_type), (status), (currency))`
+	
+	rows, err := r.db.QueryContext(ctx, facetQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	for rows.Next() {
+		var transactionType, status, currency sql.NullString
+		var count int
+		if err := rows.Scan(&transactionType, &status, &currency, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction facet: %w", err)
+		}
+		
+		switch {
+		case transactionType.Valid:
+			result.Facets.Type[transactionType.String] = count
+			result.Total += count
+		case status.Valid:
+			result.Facets.Status[status.String] = count
+		case currency.Valid:
+			result.Facets.Currency[currency.String] = count
+		}
+	}
+	
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	
+	if result.Total == 0 {
+		return result, nil
+	}
+	
+	args = append(args, search.Limit, search.Offset)
+	pageQuery := fmt.Sprintf(`SELECT `+transactionColumns+` FROM transactions t
+             WHERE %s
+             ORDER BY transaction_date DESC, id DESC
+             LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args))
+	
+	pageRows, err := r.db.QueryContext(ctx, pageQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	defer pageRows.Close()
+	
+	transactions, err := r.scanTransactions(pageRows)
+	if err != nil {
+		return nil, err
+	}
+	if transactions != nil {
+		result.Transactions = transactions
+	}
+	
+	return result, nil
+}
+
+// transactionSearchConditions builds the WHERE conditions of a search on the transactions (t) of
+// the accounts in the tenant with their arguments
+func transactionSearchConditions(tenant string, accountIDs []int, search *models.TransactionSearch) (string, []interface{}) {
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	
+	placeholders := make([]string, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		placeholders = append(placeholders, arg(id))
+	}
+	accounts := strings.Join(placeholders, ", ")
+	conditions := []string{fmt.Sprintf("(t.source_account_id IN (%s) OR t.destination_account_id IN (%s))", accounts, accounts)}
+	
+	tenantArg := arg(tenant)
+	conditions = append(conditions, fmt.Sprintf("(%[1]s = '' OR t.tenant = %[1]s)", tenantArg))
+	
+	// LIKE wildcards typed by the user match themselves
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	for _, word := range search.Words() {
+		conditions = append(conditions, "COALESCE(t.description, '') ILIKE "+arg("%"+escape.Replace(word)+"%"))
+	}
+	
+	if search.Type != "" {
+		conditions = append(conditions, "t.transaction_type = "+arg(search.Type))
+	}
+	if search.Status != "" {
+		conditions = append(conditions, "t.status = "+arg(search.Status))
+	}
+	if search.Currency != "" {
+		conditions = append(conditions, "t.currency = "+arg(search.Currency))
+	}
+	if search.From != nil {
+		conditions = append(conditions, "t.transaction_date >= "+arg(*search.From))
+	}
+	if search.To != nil {
+		conditions = append(conditions, "t.transaction_date < "+arg(*search.To))
+	}
+	if search.MinAmount != nil {
+		conditions = append(conditions, "t.amount >= "+arg(*search.MinAmount))
+	}
+	if search.MaxAmount != nil {
+		conditions = append(conditions, "t.amount <= "+arg(*search.MaxAmount))
+	}
+	
+	return strings.Join(conditions, " AND "), args
+}
+
+// CountCurrencyMismatches counts the transactions recorded in a currency other than their account's
+func (r *TransactionRepo) CountCurrencyMismatches(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM transactions t JOIN accounts a ON ` + currencyMismatch
+	
+	var count int
+	if err := r.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count currency mismatches: %w", err)
+	}
+	
+	return count, nil
+}
+
+// Update updates the status and description of a transaction. A transaction in a locked statement
+// period is never changed; a status change that voids or restores it posts an adjustment in the
+// current period instead, and other changes are rejected.
 func (r *TransactionRepo) Update(ctx context.Context, transaction *models.Transaction) error {
-	query := `UPDATE transactions 
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	
+	if err = r.UpdateTx(ctx, tx, transaction); err != nil {
+		return err
+	}
+	
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return nil
+}
+
+// UpdateTx updates the status and description of a transaction within an existing transaction,
+// with the same rules for locked statement periods as Update
+func (r *TransactionRepo) UpdateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) error {
+	query := `SELECT t.transaction_type, t.source_account_id, t.destination_account_id, t.amount, t.currency, t.status,
+             EXISTS (SELECT 1 FROM accounts a
+                 WHERE (a.id = t.source_account_id OR a.id = t.destination_account_id) AND a.locked_until > t.transaction_date)
+             FROM transactions t WHERE t.id = $1 FOR UPDATE OF t`
+	
+	original := &models.Transaction{ID: transaction.ID}
+	var locked bool
+	
+	err := tx.QueryRowContext(ctx, query, transaction.ID).Scan(
+		&original.TransactionType,
+		&original.SourceAccountID,
+		&original.DestinationAccountID,
+		&original.Amount,
+		&original.Currency,
+		&original.Status,
+		&locked,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("transaction not found: %w", err)
+		}
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	
+	if !locked {
+		query = `UPDATE transactions 
              SET status = $1, description = $2 
              WHERE id = $3`
+		
+		if _, err = tx.ExecContext(ctx, query, transaction.Status, transaction.Description, transaction.ID); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+	} else {
+		if original.Status.AffectsBalance() == transaction.Status.AffectsBalance() {
+			return fmt.Errorf("transaction %d belongs to a locked statement period", transaction.ID)
+		}
+		
+		// A voided transaction is reversed, a restored one is posted again
+		adjustment := &models.Transaction{
+			TransactionType:      models.TransactionTypeAdjustment,
+			SourceAccountID:      original.SourceAccountID,
+			DestinationAccountID: original.DestinationAccountID,
+			Amount:               original.Amount,
+			Currency:             original.Currency,
+			Description:          fmt.Sprintf("Adjustment of transaction #%d: %s", transaction.ID, transaction.Status),
+			Status:               models.TransactionStatusCompleted,
+			TransactionDate:      time.Now(),
+		}
+		if original.Status.AffectsBalance() {
+			adjustment.SourceAccountID, adjustment.DestinationAccountID = original.DestinationAccountID, original.SourceAccountID
+		}
+		
+		if _, err = r.CreateTx(ctx, tx, adjustment); err != nil {
+			return err
+		}
+	}
 	
-	result, err := r.db.ExecContext(
-		ctx,
-		query,
-		transaction.Status,
-		transaction.Description,
-		transaction.ID,
-	)
+	return nil
+}
+
+// CompleteTx completes a pending transaction within an existing transaction. It reports false if the
+// transaction is not pending. Pending and completed transactions both count towards balances, so
+// this is allowed in locked statement periods.
+func (r *TransactionRepo) CompleteTx(ctx context.Context, tx *sql.Tx, id int) (bool, error) {
+	query := `UPDATE transactions SET status = $1 WHERE id = $2 AND status = $3`
 	
+	result, err := tx.ExecContext(ctx, query, models.TransactionStatusCompleted, id, models.TransactionStatusPending)
 	if err != nil {
-		return fmt.Errorf("failed to update transaction: %w", err)
+		return false, fmt.Errorf("failed to complete transaction: %w", err)
 	}
 	
 	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 	
-	if rows == 0 {
-		return fmt.Errorf("transaction not found")
+	return rows > 0, nil
+}
+
+// GetByAccountAndPeriod gets the transactions of an account dated in [from, to), oldest first
+func (r *TransactionRepo) GetByAccountAndPeriod(ctx context.Context, accountID int, from, to time.Time) ([]*models.Transaction, error) {
+	query := `SELECT id, transaction_type, source_account_id, destination_account_id, 
+             amount, currency, description, status, card_id, transaction_date, created_at,
+             reference, COALESCE(decline_reason, '') AS decline_reason
+             FROM transactions 
+             WHERE (source_account_id = $1 OR destination_account_id = $1)
+             AND transaction_date >= $2 AND transaction_date < $3 AND ($4 = '' OR tenant = $4)
+             ORDER BY transaction_date, id`
+	
+	rows, err := r.db.QueryContext(ctx, query, accountID, from, to, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
+	defer rows.Close()
 	
-	return nil
+	return r.scanTransactions(rows)
+}
+
+// cardTransactions matches the transactions made with card $1 dated in [$2, $3); a missing bound is open
+const cardTransactions = `card_id = $1
+             AND ($2::timestamptz IS NULL OR transaction_date >= $2)
+             AND ($3::timestamptz IS NULL OR transaction_date < $3)`
+
+// GetByCardID gets a page of the transactions made with a card, newest first
+func (r *TransactionRepo) GetByCardID(ctx context.Context, cardID int, filter *models.CardTransactionFilter) ([]*models.Transaction, error) {
+	query := `SELECT id, transaction_type, source_account_id, destination_account_id, 
+             amount, currency, description, status, card_id, transaction_date, created_at,
+             reference, COALESCE(decline_reason, '') AS decline_reason
+             FROM transactions 
+             WHERE ` + cardTransactions + ` AND ($6 = '' OR tenant = $6)
+             ORDER BY transaction_date DESC, id DESC
+             LIMIT $4 OFFSET $5`
+	
+	rows, err := r.db.QueryContext(ctx, query, cardID, filter.From, filter.To, filter.Limit, filter.Offset, requestTenant(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	return r.scanTransactions(rows)
+}
+
+// GetCardSpending counts the transactions made with a card that match the filter's dates and sums
+// the completed debits among them
+func (r *TransactionRepo) GetCardSpending(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error) {
+	return cardSpending(ctx, r.db, cardID, filter)
+}
+
+// GetCardSpendingTx counts and sums the transactions made with a card within an existing transaction
+func (r *TransactionRepo) GetCardSpendingTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error) {
+	return cardSpending(ctx, tx, cardID, filter)
+}
+
+// cardSpending counts the transactions made with a card that match the filter's dates and sums
+// the completed debits among them
+func cardSpending(ctx context.Context, db rowQuerier, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error) {
+	query := `SELECT COUNT(*), COALESCE(SUM(amount) FILTER (WHERE status = $4 AND source_account_id IS NOT NULL), 0)
+             FROM transactions 
+             WHERE ` + cardTransactions + ` AND ($5 = '' OR tenant = $5)`
+	
+	spending := &models.CardSpending{}
+	err := db.QueryRowContext(ctx, query, cardID, filter.From, filter.To, models.TransactionStatusCompleted, requestTenant(ctx)).Scan(
+		&spending.Count,
+		&spending.TotalSpent,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card spending: %w", err)
+	}
+	
+	return spending, nil
+}
+
+// GetCardCashback sums the completed cashback bonuses earned with a card within the filter's dates
+func (r *TransactionRepo) GetCardCashback(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (float64, error) {
+	return cardCashback(ctx, r.db, cardID, filter)
+}
+
+// GetCardCashbackTx sums the cashback earned with a card within an existing transaction
+func (r *TransactionRepo) GetCardCashbackTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (float64, error) {
+	return cardCashback(ctx, tx, cardID, filter)
+}
+
+// cardCashback sums the completed cashback bonuses earned with a card within the filter's dates
+func cardCashback(ctx context.Context, db rowQuerier, cardID int, filter *models.CardTransactionFilter) (float64, error) {
+	query := `SELECT COALESCE(SUM(amount), 0)
+             FROM transactions 
+             WHERE ` + cardTransactions + ` AND transaction_type = $4 AND status = $5 AND ($6 = '' OR tenant = $6)`
+	
+	var cashback float64
+	err := db.QueryRowContext(ctx, query, cardID, filter.From, filter.To,
+		models.TransactionTypeBonus, models.TransactionStatusCompleted, requestTenant(ctx)).Scan(&cashback)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get card cashback: %w", err)
+	}
+	
+	return cashback, nil
+}
+
+// CountOutgoingTransfers counts the completed transfers from an account dated in [from, to)
+func (r *TransactionRepo) CountOutgoingTransfers(ctx context.Context, accountID int, from, to time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM transactions 
+             WHERE source_account_id = $1 AND transaction_type = $2 AND status = $3
+             AND transaction_date >= $4 AND transaction_date < $5`
+	
+	var count int
+	err := r.db.QueryRowContext(ctx, query, accountID, models.TransactionTypeTransfer, models.TransactionStatusCompleted, from, to).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count transfers: %w", err)
+	}
+	
+	return count, nil
 }
 
 // Helper function to scan multiple transactions
@@ -200,6 +700,8 @@ func (r *TransactionRepo) scanTransactions(rows *sql.Rows) ([]*models.Transactio
 			&cardID,
 			&transaction.TransactionDate,
 			&transaction.CreatedAt,
+			&transaction.Reference,
+			&transaction.DeclineReason,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
@@ -232,13 +734,25 @@ func (r *TransactionRepo) scanTransactions(rows *sql.Rows) ([]*models.Transactio
 }
 
 // CreateTx creates a new transaction in the database within an existing transaction
+// unless it is dated in a locked statement period
 func (r *TransactionRepo) CreateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (int, error) {
+	var locked bool
+	err := tx.QueryRowContext(ctx, lockedPeriodQuery, transaction.SourceAccountID,
+		transaction.DestinationAccountID, transaction.TransactionDate).Scan(&locked)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check statement period: %w", err)
+	}
+	
+	if locked {
+		return 0, errors.New("transaction date falls in a locked statement period")
+	}
+	
 	query := `INSERT INTO transactions (transaction_type, source_account_id, destination_account_id, 
-             amount, currency, description, status, card_id, transaction_date) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+             amount, currency, description, status, card_id, transaction_date, decline_reason) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')) RETURNING id, reference`
 	
 	var id int
-	err := tx.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		transaction.TransactionType,
@@ -250,11 +764,175 @@ func (r *TransactionRepo) CreateTx(ctx context.Context, tx *sql.Tx, transaction
 		transaction.Status,
 		transaction.CardID,
 		transaction.TransactionDate,
-	).Scan(&id)
+		transaction.DeclineReason,
+	).Scan(&id, &transaction.Reference)
 	
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction: %w", err)
 	}
 	
 	return id, nil
+}
+
+// CreateBatch creates multiple transactions in a single database transaction using multi-row
+// inserts; either all of them are created or none. It sets the IDs of the transactions.
+func (r *TransactionRepo) CreateBatch(ctx context.Context, transactions []*models.Transaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+	}()
+	
+	if err = r.CreateBatchTx(ctx, tx, transactions); err != nil {
+		return err
+	}
+	
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	return nil
+}
+
+// CreateBatchTx creates multiple transactions within an existing transaction unless any of them
+// is dated in a locked statement period. It sets the IDs of the transactions.
+func (r *TransactionRepo) CreateBatchTx(ctx context.Context, tx *sql.Tx, transactions []*models.Transaction) error {
+	for start := 0; start < len(transactions); start += transactionBatchSize {
+		end := start + transactionBatchSize
+		if end > len(transactions) {
+			end = len(transactions)
+		}
+		
+		if err := r.insertBatch(ctx, tx, transactions[start:end]); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// insertBatch checks the statement periods of a chunk of transactions and inserts it with one statement
+func (r *TransactionRepo) insertBatch(ctx context.Context, tx *sql.Tx, transactions []*models.Transaction) error {
+	// Check the whole chunk against the locked periods at once
+	periodStrings := make([]string, 0, len(transactions))
+	periodArgs := make([]interface{}, 0, len(transactions)*3)
+	
+	for i, transaction := range transactions {
+		periodStrings = append(periodStrings, fmt.Sprintf("($%d::int, $%d::int, $%d::timestamptz)", i*3+1, i*3+2, i*3+3))
+		periodArgs = append(periodArgs, transaction.SourceAccountID, transaction.DestinationAccountID, transaction.TransactionDate)
+	}
+	
+	periodQuery := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM accounts a 
+                 JOIN (VALUES %s) AS t (source_account_id, destination_account_id, transaction_date) 
+                 ON a.id = t.source_account_id OR a.id = t.destination_account_id 
+                 WHERE a.locked_until > t.transaction_date)`, strings.Join(periodStrings, ","))
+	
+	var locked bool
+	err := tx.QueryRowContext(ctx, periodQuery, periodArgs...).Scan(&locked)
+	if err != nil {
+		return fmt.Errorf("failed to check statement period: %w", err)
+	}
+	
+	if locked {
+		return errors.New("transaction date falls in a locked statement period")
+	}
+	
+	// A multi-row INSERT does not promise to return its rows in the order of the VALUES list, so
+	// the IDs are taken from the sequence first and the returned references matched up by ID
+	if err := r.reserveIDs(ctx, tx, transactions); err != nil {
+		return err
+	}
+	
+	byID := make(map[int]*models.Transaction, len(transactions))
+	valueStrings := make([]string, 0, len(transactions))
+	valueArgs := make([]interface{}, 0, len(transactions)*10)
+	
+	for i, transaction := range transactions {
+		byID[transaction.ID] = transaction
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			i*10+1, i*10+2, i*10+3, i*10+4, i*10+5, i*10+6, i*10+7, i*10+8, i*10+9, i*10+10))
+		
+		valueArgs = append(valueArgs,
+			transaction.ID,
+			transaction.TransactionType,
+			transaction.SourceAccountID,
+			transaction.DestinationAccountID,
+			transaction.Amount,
+			transaction.Currency,
+			transaction.Description,
+			transaction.Status,
+			transaction.CardID,
+			transaction.TransactionDate,
+		)
+	}
+	
+	stmt := fmt.Sprintf(`INSERT INTO transactions (id, transaction_type, source_account_id, destination_account_id, 
+                       amount, currency, description, status, card_id, transaction_date) 
+                       VALUES %s RETURNING id, reference`, strings.Join(valueStrings, ","))
+	
+	rows, err := tx.QueryContext(ctx, stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to insert transactions: %w", err)
+	}
+	defer rows.Close()
+	
+	returned := 0
+	for rows.Next() {
+		var id int
+		var reference string
+		if err := rows.Scan(&id, &reference); err != nil {
+			return fmt.Errorf("failed to scan transaction reference: %w", err)
+		}
+		
+		transaction, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("failed to insert transactions: unexpected ID %d returned", id)
+		}
+		transaction.Reference = reference
+		returned++
+	}
+	
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to insert transactions: %w", err)
+	}
+	
+	if returned != len(transactions) {
+		return errors.New("failed to insert transactions: fewer rows returned than inserted")
+	}
+	
+	return nil
+}
+
+// reserveIDs takes IDs for a chunk of transactions from the sequence of the transactions table
+func (r *TransactionRepo) reserveIDs(ctx context.Context, tx *sql.Tx, transactions []*models.Transaction) error {
+	query := `SELECT nextval(pg_get_serial_sequence('transactions', 'id')) FROM generate_series(1, $1)`
+	
+	rows, err := tx.QueryContext(ctx, query, len(transactions))
+	if err != nil {
+		return fmt.Errorf("failed to reserve transaction IDs: %w", err)
+	}
+	defer rows.Close()
+	
+	for _, transaction := range transactions {
+		if !rows.Next() {
+			return errors.New("failed to reserve transaction IDs: fewer IDs returned than requested")
+		}
+		
+		if err := rows.Scan(&transaction.ID); err != nil {
+			return fmt.Errorf("failed to scan transaction ID: %w", err)
+		}
+	}
+	
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to reserve transaction IDs: %w", err)
+	}
+	
+	return nil
 }
\ No newline at end of file
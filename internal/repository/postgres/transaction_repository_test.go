@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"banking-service/internal/models"
+)
+
+// openTestDB connects to the database in TEST_DATABASE_URL, which must have schema.sql applied.
+// Tests and benchmarks that need PostgreSQL are skipped without it.
+func openTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		tb.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("failed to open database: %v", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	if err := db.Ping(); err != nil {
+		tb.Fatalf("failed to connect to database: %v", err)
+	}
+	return db
+}
+
+// newTestTransactions returns deposits with distinct amounts, so a row can be told by its amount
+func newTestTransactions(n int) []*models.Transaction {
+	now := time.Now()
+	transactions := make([]*models.Transaction, 0, n)
+	for i := 0; i < n; i++ {
+		transactions = append(transactions, &models.Transaction{
+			TransactionType: models.TransactionTypeDeposit,
+			Amount:          float64(i + 1),
+			Currency:        models.CurrencyRUB,
+			Description:     fmt.Sprintf("batch row %d", i),
+			Status:          models.TransactionStatusCompleted,
+			TransactionDate: now,
+		})
+	}
+	return transactions
+}
+
+func TestCreateBatchTxSetsIDsAndReferences(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := NewTransactionRepository(db)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// More rows than one statement inserts, so the IDs of several chunks are checked
+	transactions := newTestTransactions(transactionBatchSize + 10)
+	if err := repo.CreateBatchTx(ctx, tx, transactions); err != nil {
+		t.Fatalf("failed to create transactions: %v", err)
+	}
+
+	for _, transaction := range transactions {
+		var amount float64
+		var reference string
+		err := tx.QueryRowContext(ctx, `SELECT amount, reference FROM transactions WHERE id = $1`, transaction.ID).
+			Scan(&amount, &reference)
+		if err != nil {
+			t.Fatalf("failed to get transaction %d: %v", transaction.ID, err)
+		}
+		if amount != transaction.Amount || reference != transaction.Reference {
+			t.Fatalf("transaction %d: expected amount %.2f and reference %s, got %.2f and %s",
+				transaction.ID, transaction.Amount, transaction.Reference, amount, reference)
+		}
+	}
+}
+
+// BenchmarkCreateBatch compares inserting transactions with multi-row statements to inserting them
+// one by one. Each iteration is rolled back.
+func BenchmarkCreateBatch(b *testing.B) {
+	ctx := context.Background()
+	db := openTestDB(b)
+	repo := NewTransactionRepository(db)
+
+	for _, rows := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("batch/%d", rows), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				transactions := newTestTransactions(rows)
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					b.Fatalf("failed to begin transaction: %v", err)
+				}
+				b.StartTimer()
+
+				if err := repo.CreateBatchTx(ctx, tx, transactions); err != nil {
+					b.Fatalf("failed to create transactions: %v", err)
+				}
+
+				b.StopTimer()
+				tx.Rollback()
+				b.StartTimer()
+			}
+		})
+
+		b.Run(fmt.Sprintf("rows/%d", rows), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				transactions := newTestTransactions(rows)
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					b.Fatalf("failed to begin transaction: %v", err)
+				}
+				b.StartTimer()
+
+				for _, transaction := range transactions {
+					if _, err := repo.CreateTx(ctx, tx, transaction); err != nil {
+						b.Fatalf("failed to create transaction: %v", err)
+					}
+				}
+
+				b.StopTimer()
+				tx.Rollback()
+				b.StartTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+)
+
+// TransferConfirmationRepo is a PostgreSQL implementation of the repository.TransferConfirmationRepository interface
+type TransferConfirmationRepo struct {
+	db *sql.DB
+}
+
+// NewTransferConfirmationRepository creates a new TransferConfirmationRepo
+func NewTransferConfirmationRepository(db *sql.DB) *TransferConfirmationRepo {
+	return &TransferConfirmationRepo{db: db}
+}
+
+// Create creates a new transfer confirmation in the database
+func (r *TransferConfirmationRepo) Create(ctx context.Context, confirmation *models.TransferConfirmation) (int, error) {
+	query := `INSERT INTO transfer_confirmations (confirmation_id, user_id, source_account_id,
+             destination_account_id, amount, description, invoice_id, code_hash, status, expires_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+	var id int
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		confirmation.ConfirmationID,
+		confirmation.UserID,
+		confirmation.SourceAccountID,
+		confirmation.DestinationAccountID,
+		confirmation.Amount,
+		confirmation.Description,
+		confirmation.InvoiceID,
+		confirmation.CodeHash,
+		confirmation.Status,
+		confirmation.ExpiresAt,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transfer confirmation: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetByConfirmationID gets a transfer confirmation by its public confirmation ID
+func (r *TransferConfirmationRepo) GetByConfirmationID(ctx context.Context, confirmationID string) (*models.TransferConfirmation, error) {
+	query := `SELECT id, confirmation_id, user_id, source_account_id, destination_account_id,
+             amount, description, invoice_id, code_hash, attempts, status, transaction_id, expires_at, created_at
+             FROM transfer_confirmations WHERE confirmation_id = $1`
+
+	confirmation := &models.TransferConfirmation{}
+	err := r.db.QueryRowContext(ctx, query, confirmationID).Scan(
+		&confirmation.ID,
+		&confirmation.ConfirmationID,
+		&confirmation.UserID,
+		&confirmation.SourceAccountID,
+		&confirmation.DestinationAccountID,
+		&confirmation.Amount,
+		&confirmation.Description,
+		&confirmation.InvoiceID,
+		&confirmation.CodeHash,
+		&confirmation.Attempts,
+		&confirmation.Status,
+		&confirmation.TransactionID,
+		&confirmation.ExpiresAt,
+		&confirmation.CreatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("transfer confirmation not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get transfer confirmation: %w", err)
+	}
+
+	return confirmation, nil
+}
+
+// IncrementAttempts records a failed code check and returns the new number of attempts
+func (r *TransferConfirmationRepo) IncrementAttempts(ctx context.Context, id int) (int, error) {
+	query := `UPDATE transfer_confirmations SET attempts = attempts + 1
+             WHERE id = $1 RETURNING attempts`
+
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&attempts); err != nil {
+		return 0, fmt.Errorf("failed to update attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// UpdateStatus moves a confirmation from one status to another. It reports false if the
+// confirmation was not in the expected status, so a code can only be used once.
+func (r *TransferConfirmationRepo) UpdateStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error) {
+	query := `UPDATE transfer_confirmations SET status = $1
+             WHERE id = $2 AND status = $3`
+
+	result, err := r.db.ExecContext(ctx, query, to, id, from)
+	if err != nil {
+		return false, fmt.Errorf("failed to update transfer confirmation status: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// SetTransaction links the executed transaction to the confirmation
+func (r *TransferConfirmationRepo) SetTransaction(ctx context.Context, id int, transactionID int) error {
+	query := `UPDATE transfer_confirmations SET transaction_id = $1 WHERE id = $2`
+
+	if _, err := r.db.ExecContext(ctx, query, transactionID, id); err != nil {
+		return fmt.Errorf("failed to link transaction: %w", err)
+	}
+
+	return nil
+}
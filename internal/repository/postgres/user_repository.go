@@ -21,8 +21,11 @@ func NewUserRepository(db *sql.DB) *UserRepo {
 
 // Create creates a new user in the database
 func (r *UserRepo) Create(ctx context.Context, user *models.User) (int, error) {
-	query := `INSERT INTO users (username, email, password_hash, first_name, last_name) 
-			  VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	query := `INSERT INTO users (username, email, password_hash, first_name, last_name, phone, birth_date,
+			  address_country, address_city, address_street, address_postal_code, role, tenant) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id`
+	
+	user.Tenant = newRecordTenant(ctx, user.Tenant)
 	
 	var id int
 	err := r.db.QueryRowContext(
@@ -33,6 +36,14 @@ func (r *UserRepo) Create(ctx context.Context, user *models.User) (int, error) {
 		user.PassHash,
 		user.FirstName,
 		user.LastName,
+		user.Phone,
+		user.BirthDate,
+		user.Address.Country,
+		user.Address.City,
+		user.Address.Street,
+		user.Address.PostalCode,
+		user.Role,
+		user.Tenant,
 	).Scan(&id)
 	
 	if err != nil {
@@ -44,17 +55,28 @@ func (r *UserRepo) Create(ctx context.Context, user *models.User) (int, error) {
 
 // GetByID gets a user by ID
 func (r *UserRepo) GetByID(ctx context.Context, id int) (*models.User, error) {
-	query := `SELECT id, username, email, password_hash, first_name, last_name, created_at, updated_at 
-			  FROM users WHERE id = $1`
+	query := `SELECT id, username, email, password_hash, first_name, last_name, phone, birth_date, address_country, address_city, address_street, address_postal_code, role, tenant, timezone, language, created_at, updated_at 
+			  FROM users WHERE id = $1 AND ($2 = '' OR tenant = $2)`
 	
 	user := &models.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	var birthDate sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, id, requestTenant(ctx)).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.PassHash,
 		&user.FirstName,
 		&user.LastName,
+		&user.Phone,
+		&birthDate,
+		&user.Address.Country,
+		&user.Address.City,
+		&user.Address.Street,
+		&user.Address.PostalCode,
+		&user.Role,
+		&user.Tenant,
+		&user.Timezone,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -66,22 +88,37 @@ func (r *UserRepo) GetByID(ctx context.Context, id int) (*models.User, error) {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
+	if birthDate.Valid {
+		user.BirthDate = &birthDate.Time
+	}
+	
 	return user, nil
 }
 
 // GetByUsername gets a user by username
 func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
-	query := `SELECT id, username, email, password_hash, first_name, last_name, created_at, updated_at 
-			  FROM users WHERE username = $1`
+	query := `SELECT id, username, email, password_hash, first_name, last_name, phone, birth_date, address_country, address_city, address_street, address_postal_code, role, tenant, timezone, language, created_at, updated_at 
+			  FROM users WHERE username = $1 AND ($2 = '' OR tenant = $2)`
 	
 	user := &models.User{}
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
+	var birthDate sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, username, requestTenant(ctx)).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.PassHash,
 		&user.FirstName,
 		&user.LastName,
+		&user.Phone,
+		&birthDate,
+		&user.Address.Country,
+		&user.Address.City,
+		&user.Address.Street,
+		&user.Address.PostalCode,
+		&user.Role,
+		&user.Tenant,
+		&user.Timezone,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -93,22 +130,37 @@ func (r *UserRepo) GetByUsername(ctx context.Context, username string) (*models.
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
+	if birthDate.Valid {
+		user.BirthDate = &birthDate.Time
+	}
+	
 	return user, nil
 }
 
 // GetByEmail gets a user by email
 func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User, error) {
-	query := `SELECT id, username, email, password_hash, first_name, last_name, created_at, updated_at 
-			  FROM users WHERE email = $1`
+	query := `SELECT id, username, email, password_hash, first_name, last_name, phone, birth_date, address_country, address_city, address_street, address_postal_code, role, tenant, timezone, language, created_at, updated_at 
+			  FROM users WHERE email = $1 AND ($2 = '' OR tenant = $2)`
 	
 	user := &models.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	var birthDate sql.NullTime
+	err := r.db.QueryRowContext(ctx, query, email, requestTenant(ctx)).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.PassHash,
 		&user.FirstName,
 		&user.LastName,
+		&user.Phone,
+		&birthDate,
+		&user.Address.Country,
+		&user.Address.City,
+		&user.Address.Street,
+		&user.Address.PostalCode,
+		&user.Role,
+		&user.Tenant,
+		&user.Timezone,
+		&user.Language,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -120,6 +172,10 @@ func (r *UserRepo) GetByEmail(ctx context.Context, email string) (*models.User,
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 	
+	if birthDate.Valid {
+		user.BirthDate = &birthDate.Time
+	}
+	
 	return user, nil
 }
 
@@ -155,6 +211,106 @@ func (r *UserRepo) Update(ctx context.Context, user *models.User) error {
 	return nil
 }
 
+// UpdateProfile updates the personal details of a user: the name, phone, date of birth and address
+func (r *UserRepo) UpdateProfile(ctx context.Context, user *models.User) error {
+	query := `UPDATE users 
+			  SET first_name = $1, last_name = $2, phone = $3, birth_date = $4, address_country = $5,
+			  address_city = $6, address_street = $7, address_postal_code = $8, updated_at = NOW() 
+			  WHERE id = $9`
+	
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		user.FirstName,
+		user.LastName,
+		user.Phone,
+		user.BirthDate,
+		user.Address.Country,
+		user.Address.City,
+		user.Address.Street,
+		user.Address.PostalCode,
+		user.ID,
+	)
+	
+	if err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// UpdatePassword updates the password hash of a user
+func (r *UserRepo) UpdatePassword(ctx context.Context, id int, passHash string) error {
+	query := `UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, passHash, id)
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// UpdateTimezone updates the time zone of a user
+func (r *UserRepo) UpdateTimezone(ctx context.Context, id int, timezone string) error {
+	query := `UPDATE users SET timezone = $1, updated_at = NOW() WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, timezone, id)
+	if err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
+// UpdateLanguage updates the language of a user
+func (r *UserRepo) UpdateLanguage(ctx context.Context, id int, language string) error {
+	query := `UPDATE users SET language = $1, updated_at = NOW() WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, language, id)
+	if err != nil {
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+	
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rows == 0 {
+		return fmt.Errorf("user not found")
+	}
+	
+	return nil
+}
+
 // Delete deletes a user by ID
 func (r *UserRepo) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"banking-service/internal/models"
+	"banking-service/internal/repository/memory"
 	"banking-service/internal/repository/postgres"
 )
 
@@ -23,6 +24,10 @@ type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	UpdateProfile(ctx context.Context, user *models.User) error
+	UpdatePassword(ctx context.Context, id int, passHash string) error
+	UpdateTimezone(ctx context.Context, id int, timezone string) error
+	UpdateLanguage(ctx context.Context, id int, language string) error
 	Delete(ctx context.Context, id int) error
 }
 
@@ -31,13 +36,57 @@ type AccountRepository interface {
 	Create(ctx context.Context, account *models.Account) (int, error)
 	GetByID(ctx context.Context, id int) (*models.Account, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Account, error)
+	GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Account, error)
 	GetByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error)
 	UpdateBalance(ctx context.Context, id int, amount float64) error
 	Update(ctx context.Context, account *models.Account) error
 	Delete(ctx context.Context, id int) error
+	MarkDormant(ctx context.Context, cutoff time.Time) ([]*models.Account, error)
+	Reactivate(ctx context.Context, id int) (bool, error)
+	GetDefault(ctx context.Context, userID int, currency models.Currency) (*models.Account, error)
+	SetDefault(ctx context.Context, id int) error
+	GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Account, error)
 	
 	// Transaction-specific methods
 	UpdateBalanceTx(ctx context.Context, tx *sql.Tx, id int, amount float64) error
+	TransferOwnershipTx(ctx context.Context, tx *sql.Tx, accountID int, fromUserID int, toUserID int) (bool, error)
+}
+
+// AccountHoldRepository defines methods for account hold repository
+type AccountHoldRepository interface {
+	Create(ctx context.Context, hold *models.AccountHold) (int, error)
+	GetActiveByAccountID(ctx context.Context, accountID int) ([]*models.AccountHold, error)
+	Release(ctx context.Context, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, hold *models.AccountHold) (int, error)
+	ReleaseTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceID int, to models.HoldStatus) (bool, error)
+	ReleaseBatchTx(ctx context.Context, tx *sql.Tx, reason models.HoldReason, referenceIDs []int, to models.HoldStatus) (int64, error)
+}
+
+// AccountSettingsRepository defines methods for the users' account display settings
+type AccountSettingsRepository interface {
+	Get(ctx context.Context, accountID int, userID int) (*models.AccountSettings, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.AccountSettings, error)
+	Save(ctx context.Context, settings *models.AccountSettings) error
+}
+
+// AccountDelegationRepository defines methods for powers of attorney over accounts and their audit log
+type AccountDelegationRepository interface {
+	GetByID(ctx context.Context, id int) (*models.AccountDelegation, error)
+	GetByOwnerID(ctx context.Context, ownerID int) ([]*models.AccountDelegation, error)
+	GetByDelegateID(ctx context.Context, delegateID int) ([]*models.AccountDelegation, error)
+	GetActive(ctx context.Context, accountID int, delegateID int) (*models.AccountDelegation, error)
+	GetEvents(ctx context.Context, delegationID int) ([]*models.DelegationEvent, error)
+	AddEvent(ctx context.Context, event *models.DelegationEvent) (int, error)
+	SumTransferred(ctx context.Context, delegationID int) (float64, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, delegation *models.AccountDelegation) (int, error)
+	RevokeTx(ctx context.Context, tx *sql.Tx, id int, revokedBy int) (bool, error)
+	RevokeByAccountTx(ctx context.Context, tx *sql.Tx, accountID int, revokedBy int) ([]int, error)
+	AddEventTx(ctx context.Context, tx *sql.Tx, event *models.DelegationEvent) (int, error)
+	SumTransferredTx(ctx context.Context, tx *sql.Tx, delegationID int) (float64, error)
 }
 
 // CardRepository defines methods for card repository
@@ -47,20 +96,102 @@ type CardRepository interface {
 	GetByAccountID(ctx context.Context, accountID int) ([]*models.Card, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Card, error)
 	Update(ctx context.Context, card *models.Card) error
+	UpdateEncrypted(ctx context.Context, card *models.Card) error
 	Delete(ctx context.Context, id int) error
+	GetAll(ctx context.Context) ([]*models.Card, error)
+	GetByNumberHMAC(ctx context.Context, numberHMAC string) (*models.Card, error)
+	SetPIN(ctx context.Context, id int, pinHash string) error
+	RecordPINAttempt(ctx context.Context, id int, correct bool) (int, error)
+}
+
+// AccountPlanRepository defines methods for the account plan catalog and the plan periods of
+// accounts; plans are deactivated rather than deleted, since plan periods keep referencing them
+type AccountPlanRepository interface {
+	Create(ctx context.Context, plan *models.AccountPlan) (int, error)
+	GetByID(ctx context.Context, id int) (*models.AccountPlan, error)
+	GetAll(ctx context.Context) ([]*models.AccountPlan, error)
+	Update(ctx context.Context, plan *models.AccountPlan) error
+	GetCurrentPeriod(ctx context.Context, accountID int) (*models.AccountPlanPeriod, error)
+	Switch(ctx context.Context, accountID int, planID int, at time.Time) (*models.AccountPlanPeriod, error)
+	GetUnbilled(ctx context.Context, until time.Time) ([]*models.AccountPlanPeriod, error)
+	MarkBilledTx(ctx context.Context, tx *sql.Tx, periodID int, until time.Time) error
+}
+
+// CardProductRepository defines methods for the card product catalog; products are deactivated
+// rather than deleted, since issued cards keep referencing them
+type CardProductRepository interface {
+	Create(ctx context.Context, product *models.CardProduct) (int, error)
+	GetByID(ctx context.Context, id int) (*models.CardProduct, error)
+	GetAll(ctx context.Context) ([]*models.CardProduct, error)
+	Update(ctx context.Context, product *models.CardProduct) error
+}
+
+// FeeRepository defines methods for recurring fee charges and the runs of the job charging them.
+// A fee is assessed once per product and period: creating a charge assessed already returns ID 0.
+type FeeRepository interface {
+	CreateCharge(ctx context.Context, charge *models.FeeCharge) (int, error)
+	GetCharges(ctx context.Context, status models.FeeChargeStatus) ([]*models.FeeCharge, error)
+	GetCollectable(ctx context.Context) ([]*models.FeeCharge, error)
+	MarkDunning(ctx context.Context, id int, reason string) error
+	CreateRun(ctx context.Context, run *models.FeeRun) (int, error)
+	GetRuns(ctx context.Context, limit int) ([]*models.FeeRun, error)
+
+	// Transaction-specific methods
+	CreateChargeTx(ctx context.Context, tx *sql.Tx, charge *models.FeeCharge) (int, error)
+	MarkChargedTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error
 }
 
 // TransactionRepository defines methods for transaction repository
 type TransactionRepository interface {
 	Create(ctx context.Context, transaction *models.Transaction) (int, error)
 	GetByID(ctx context.Context, id int) (*models.Transaction, error)
+	GetByReference(ctx context.Context, reference string) (*models.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID int) ([]*models.Transaction, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Transaction, error)
 	GetByDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*models.Transaction, error)
+	GetByAccountAndPeriod(ctx context.Context, accountID int, from, to time.Time) ([]*models.Transaction, error)
+	GetByCardID(ctx context.Context, cardID int, filter *models.CardTransactionFilter) ([]*models.Transaction, error)
+	GetCardSpending(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error)
+	GetCardCashback(ctx context.Context, cardID int, filter *models.CardTransactionFilter) (float64, error)
+	CountOutgoingTransfers(ctx context.Context, accountID int, from, to time.Time) (int, error)
 	Update(ctx context.Context, transaction *models.Transaction) error
+	CreateBatch(ctx context.Context, transactions []*models.Transaction) error
+	FixCurrencies(ctx context.Context) (int64, error)
+	CountCurrencyMismatches(ctx context.Context) (int, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*models.Transaction, error)
+	GetAfterID(ctx context.Context, afterID int, limit int) ([]*models.Transaction, error)
+	GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Transaction, error)
+	Search(ctx context.Context, accountIDs []int, search *models.TransactionSearch) (*models.TransactionSearchResult, error)
+	GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.Transaction, error)
 	
 	// Transaction-specific methods
 	CreateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (int, error)
+	CreateBatchTx(ctx context.Context, tx *sql.Tx, transactions []*models.Transaction) error
+	UpdateTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) error
+	CompleteTx(ctx context.Context, tx *sql.Tx, id int) (bool, error)
+	GetCardSpendingTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (*models.CardSpending, error)
+	GetCardCashbackTx(ctx context.Context, tx *sql.Tx, cardID int, filter *models.CardTransactionFilter) (float64, error)
+}
+
+// TransactionDescriptionRepository defines methods for the history of the descriptions users give
+// their transactions
+type TransactionDescriptionRepository interface {
+	Create(ctx context.Context, edit *models.TransactionDescriptionEdit) (int, error)
+	GetHistory(ctx context.Context, transactionID int, userID int) ([]*models.TransactionDescriptionEdit, error)
+	GetLatest(ctx context.Context, userID int, transactionIDs []int) (map[int]*models.TransactionDescriptionEdit, error)
+}
+
+// TransactionEventRepository defines methods for the changes to transactions the search index follows
+type TransactionEventRepository interface {
+	GetUnprocessed(ctx context.Context, limit int) ([]*models.TransactionEvent, error)
+	GetTransactionIDsSince(ctx context.Context, since time.Time) ([]int, error)
+	MarkProcessed(ctx context.Context, ids []int) error
+	DeleteProcessed(ctx context.Context, before time.Time) (int64, error)
+}
+
+// AccountEventRepository defines methods for the changes to accounts shown in their activity feed
+type AccountEventRepository interface {
+	GetActivity(ctx context.Context, accountID int, before *models.ActivityPosition, limit int) ([]*models.AccountEvent, error)
 }
 
 // CreditRepository defines methods for credit repository
@@ -71,6 +202,10 @@ type CreditRepository interface {
 	GetByAccountID(ctx context.Context, accountID int) ([]*models.Credit, error)
 	Update(ctx context.Context, credit *models.Credit) error
 	GetActiveCredits(ctx context.Context) ([]*models.Credit, error)
+	GetChanged(ctx context.Context, from, to time.Time, afterID int, limit int) ([]*models.Credit, error)
+	
+	// Transaction-specific methods
+	UpdateTx(ctx context.Context, tx *sql.Tx, credit *models.Credit) error
 }
 
 // PaymentScheduleRepository defines methods for payment schedule repository
@@ -80,8 +215,450 @@ type PaymentScheduleRepository interface {
 	GetByID(ctx context.Context, id int) (*models.PaymentSchedule, error)
 	GetByCreditID(ctx context.Context, creditID int) ([]*models.PaymentSchedule, error)
 	Update(ctx context.Context, schedule *models.PaymentSchedule) error
-	GetPendingPayments(ctx context.Context, date time.Time) ([]*models.PaymentSchedule, error)
+	GetPendingPayments(ctx context.Context, date time.Time, afterID, limit int) ([]*models.PaymentSchedule, error)
 	GetOverduePayments(ctx context.Context) ([]*models.PaymentSchedule, error)
+	GetDuePayments(ctx context.Context, date time.Time, afterAccountID, limit int) ([]*models.DuePayment, error)
+	GetPaymentsToRemind(ctx context.Context, from, to time.Time, daysBefore, afterID, limit int) ([]*models.PaymentSchedule, error)
+	MarkReminded(ctx context.Context, paymentID, daysBefore int) (bool, error)
+	UnmarkReminded(ctx context.Context, paymentID, daysBefore int) error
+	
+	// Transaction-specific methods
+	RemoveInsuranceTx(ctx context.Context, tx *sql.Tx, creditID int) (int64, error)
+	UpdateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error
+	CreateBatchTx(ctx context.Context, tx *sql.Tx, schedules []*models.PaymentSchedule) error
+	RescheduleTx(ctx context.Context, tx *sql.Tx, schedule *models.PaymentSchedule) error
+}
+
+// InsurancePolicyRepository defines methods for credit insurance policy repository
+type InsurancePolicyRepository interface {
+	Create(ctx context.Context, policy *models.InsurancePolicy) (int, error)
+	GetByCreditID(ctx context.Context, creditID int) (*models.InsurancePolicy, error)
+	
+	// Transaction-specific methods
+	CancelTx(ctx context.Context, tx *sql.Tx, id int) (bool, error)
+}
+
+// SessionRepository defines methods for session repository
+type SessionRepository interface {
+	Create(ctx context.Context, session *models.Session) (int, error)
+	GetBySessionID(ctx context.Context, sessionID string) (*models.Session, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Session, error)
+	GetActiveByUserID(ctx context.Context, userID int) ([]*models.Session, error)
+	Revoke(ctx context.Context, id int, userID int) error
+	RevokeAllExcept(ctx context.Context, userID int, keepSessionID string) (int, error)
+}
+
+// DeviceRepository defines methods for device repository
+type DeviceRepository interface {
+	Touch(ctx context.Context, device *models.Device) (bool, error)
+	CountByUserID(ctx context.Context, userID int) (int, error)
+}
+
+// NotificationRepository defines methods for notification repository
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *models.Notification) (int, error)
+	GetByUserID(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error)
+	MarkRead(ctx context.Context, id int, userID int) error
+	CountSince(ctx context.Context, userID int, notificationType models.NotificationType, since time.Time) (int, error)
+}
+
+// EmailRepository defines methods for the deliveries of customer emails and the addresses no
+// longer emailed
+type EmailRepository interface {
+	CreateDelivery(ctx context.Context, delivery *models.EmailDelivery) (int, error)
+	GetDeliveries(ctx context.Context, filter *models.EmailDeliveryFilter) ([]*models.EmailDelivery, error)
+	GetDeliveryByMessageID(ctx context.Context, messageID string) (*models.EmailDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.EmailDelivery) error
+	CreateSuppression(ctx context.Context, suppression *models.EmailSuppression) (int, error)
+	GetSuppression(ctx context.Context, email string) (*models.EmailSuppression, error)
+	GetSuppressions(ctx context.Context) ([]*models.EmailSuppression, error)
+	DeleteSuppression(ctx context.Context, id int) (bool, error)
+}
+
+// AnnouncementRepository defines methods for the announcements admins send to segments of
+// customers and the queue of their recipients
+type AnnouncementRepository interface {
+	Create(ctx context.Context, announcement *models.Announcement) (int, error)
+	GetAll(ctx context.Context) ([]*models.Announcement, error)
+	GetByID(ctx context.Context, id int) (*models.Announcement, error)
+	Cancel(ctx context.Context, id int) (bool, error)
+	GetPendingRecipients(ctx context.Context, limit int) ([]*models.AnnouncementRecipient, error)
+	MarkRecipient(ctx context.Context, id int, status models.AnnouncementRecipientStatus, reason string) error
+	CompleteDelivered(ctx context.Context) (int, error)
+}
+
+// TransferConfirmationRepository defines methods for transfer confirmation repository
+type TransferConfirmationRepository interface {
+	Create(ctx context.Context, confirmation *models.TransferConfirmation) (int, error)
+	GetByConfirmationID(ctx context.Context, confirmationID string) (*models.TransferConfirmation, error)
+	IncrementAttempts(ctx context.Context, id int) (int, error)
+	UpdateStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error)
+	SetTransaction(ctx context.Context, id int, transactionID int) error
+}
+
+// PasswordResetRepository defines methods for password reset repository
+type PasswordResetRepository interface {
+	Create(ctx context.Context, reset *models.PasswordReset) (int, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.PasswordReset, error)
+	MarkUsed(ctx context.Context, id int) (bool, error)
+	InvalidateByUserID(ctx context.Context, userID int) error
+}
+
+// OnboardingRepository defines methods for onboarding repository
+type OnboardingRepository interface {
+	GetByUserID(ctx context.Context, userID int) ([]*models.OnboardingEvent, error)
+	Record(ctx context.Context, userID int, stage models.OnboardingStage) (bool, error)
+}
+
+// APIKeyRepository defines methods for API key repository
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *models.APIKey) (int, error)
+	GetByID(ctx context.Context, id int) (*models.APIKey, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.APIKey, error)
+	GetByKeyHash(ctx context.Context, keyHash string) (*models.APIKey, error)
+	UpdateKey(ctx context.Context, id int, keyHash string, keyPrefix string) error
+	Revoke(ctx context.Context, id int) (bool, error)
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+}
+
+// OrganizationRepository defines methods for organization repository
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization *models.Organization) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Organization, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Organization, error)
+	GetMember(ctx context.Context, organizationID, userID int) (*models.OrganizationMember, error)
+	GetMembers(ctx context.Context, organizationID int) ([]*models.OrganizationMember, error)
+	AddMember(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error
+	UpdateMemberRole(ctx context.Context, organizationID, userID int, role models.OrganizationRole) error
+	RemoveMember(ctx context.Context, organizationID, userID int) error
+}
+
+// InvitationRepository defines methods for organization invitation repository
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *models.OrganizationInvitation) (int, error)
+	GetByID(ctx context.Context, id int) (*models.OrganizationInvitation, error)
+	GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.OrganizationInvitation, error)
+	GetPendingByEmail(ctx context.Context, email string) ([]*models.OrganizationInvitation, error)
+	UpdateStatus(ctx context.Context, id int, from, to models.InvitationStatus) (bool, error)
+}
+
+// ApprovalPolicyRepository defines methods for approval policy repository
+type ApprovalPolicyRepository interface {
+	GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.ApprovalPolicy, error)
+	Replace(ctx context.Context, organizationID int, rules []models.ApprovalPolicyRule) error
+}
+
+// PendingTransferRepository defines methods for pending transfer repository
+type PendingTransferRepository interface {
+	Create(ctx context.Context, transfer *models.PendingTransfer) (int, error)
+	GetByID(ctx context.Context, id int) (*models.PendingTransfer, error)
+	GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.PendingTransfer, error)
+	AddApproval(ctx context.Context, id int, userID int) error
+	GetApprovals(ctx context.Context, id int) ([]*models.TransferApproval, error)
+	UpdateStatus(ctx context.Context, id int, from, to models.PendingTransferStatus) (bool, error)
+	SetTransaction(ctx context.Context, id int, transactionID int) error
+}
+
+// PayrollRepository defines methods for payroll repository
+type PayrollRepository interface {
+	Create(ctx context.Context, payroll *models.Payroll) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Payroll, error)
+	GetByOrganizationID(ctx context.Context, organizationID int) ([]*models.Payroll, error)
+	GetItems(ctx context.Context, payrollID int) ([]*models.PayrollItem, error)
+	UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.PayrollStatus, executedBy int) (bool, error)
+	UpdateItemTx(ctx context.Context, tx *sql.Tx, item *models.PayrollItem) error
+	SetSettlementTx(ctx context.Context, tx *sql.Tx, payroll *models.Payroll) error
+}
+
+// BillProviderRepository defines methods for bill provider catalog repository
+type BillProviderRepository interface {
+	GetByID(ctx context.Context, id int) (*models.BillProvider, error)
+	GetActive(ctx context.Context, category models.BillCategory) ([]*models.BillProvider, error)
+}
+
+// BillPaymentRepository defines methods for bill payment repository
+type BillPaymentRepository interface {
+	GetByID(ctx context.Context, id int) (*models.BillPayment, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.BillPayment, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, payment *models.BillPayment) (int, error)
+}
+
+// BillTemplateRepository defines methods for bill template repository
+type BillTemplateRepository interface {
+	Create(ctx context.Context, template *models.BillTemplate) (int, error)
+	GetByID(ctx context.Context, id int) (*models.BillTemplate, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.BillTemplate, error)
+	Delete(ctx context.Context, id int, userID int) error
+}
+
+// MerchantRepository defines methods for merchant repository
+type MerchantRepository interface {
+	Create(ctx context.Context, merchant *models.Merchant) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Merchant, error)
+	GetByAPIKeyHash(ctx context.Context, hash string) (*models.Merchant, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Merchant, error)
+	UpdateAPIKey(ctx context.Context, id int, hash, prefix string) error
+}
+
+// PaymentIntentRepository defines methods for payment intent repository
+type PaymentIntentRepository interface {
+	Create(ctx context.Context, intent *models.PaymentIntent) (int, error)
+	GetByIntentID(ctx context.Context, intentID string) (*models.PaymentIntent, error)
+	GetByTransactionID(ctx context.Context, transactionID int) (*models.PaymentIntent, error)
+	GetByMerchantID(ctx context.Context, merchantID int) ([]*models.PaymentIntent, error)
+	UpdateStatus(ctx context.Context, id int, from, to models.PaymentIntentStatus) (bool, error)
+	
+	// Transaction-specific methods
+	MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, customerID, accountID, transactionID int) (bool, error)
+}
+
+// SettlementRepository defines methods for merchant settlement repository
+type SettlementRepository interface {
+	GetMerchantsToSettle(ctx context.Context, before time.Time) ([]int, error)
+	GetByMerchantID(ctx context.Context, merchantID int) ([]*models.SettlementBatch, error)
+	
+	// Transaction-specific methods
+	CreateBatchTx(ctx context.Context, tx *sql.Tx, merchantID int, settlementDate, before time.Time) (*models.SettlementBatch, error)
+	SetTransactionTx(ctx context.Context, tx *sql.Tx, id int, transactionID int) error
+}
+
+// ChargebackRepository defines methods for card chargeback repository
+type ChargebackRepository interface {
+	GetByID(ctx context.Context, id int) (*models.Chargeback, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Chargeback, error)
+	GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Chargeback, error)
+	GetByStatus(ctx context.Context, status models.ChargebackStatus) ([]*models.Chargeback, error)
+	GetEvidenceOverdue(ctx context.Context, now time.Time) ([]*models.Chargeback, error)
+	ExistsForTransaction(ctx context.Context, transactionID int) (bool, error)
+	SubmitEvidence(ctx context.Context, id int, evidence string) (bool, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, chargeback *models.Chargeback, paymentIntentID int) (int, error)
+	ResolveTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ChargebackStatus, note string, reversalTxID *int) (bool, error)
+}
+
+// EscrowRepository defines methods for escrow payment repository
+type EscrowRepository interface {
+	GetByID(ctx context.Context, id int) (*models.Escrow, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Escrow, error)
+	GetByStatus(ctx context.Context, status models.EscrowStatus) ([]*models.Escrow, error)
+	GetExpired(ctx context.Context, now time.Time) ([]*models.Escrow, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, escrow *models.Escrow) (int, error)
+	ConfirmTx(ctx context.Context, tx *sql.Tx, id int, userID int) (*models.Escrow, error)
+	SettleTx(ctx context.Context, tx *sql.Tx, id int, to models.EscrowStatus, settlementTxID *int, resolvedBy *int, note string) (bool, error)
+}
+
+// InvoiceRepository defines methods for invoice repository
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *models.Invoice) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Invoice, error)
+	GetByNumber(ctx context.Context, number string) (*models.Invoice, error)
+	GetByIssuerID(ctx context.Context, issuerID int) ([]*models.Invoice, error)
+	GetByRecipientID(ctx context.Context, recipientID int) ([]*models.Invoice, error)
+	GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Invoice, error)
+	GetBySubscriptionID(ctx context.Context, subscriptionID int) ([]*models.Invoice, error)
+	Cancel(ctx context.Context, id int) (bool, error)
+	MarkOverdue(ctx context.Context, now time.Time) ([]*models.Invoice, error)
+	
+	// Transaction-specific methods
+	MarkPaidTx(ctx context.Context, tx *sql.Tx, id int, payerAccountID int, transactionID int) (bool, error)
+}
+
+// SubscriptionRepository defines methods for subscription plan and subscription repository
+type SubscriptionRepository interface {
+	CreatePlan(ctx context.Context, plan *models.SubscriptionPlan) (int, error)
+	GetPlanByID(ctx context.Context, id int) (*models.SubscriptionPlan, error)
+	GetPlansByMerchantID(ctx context.Context, merchantID int) ([]*models.SubscriptionPlan, error)
+	SetPlanActive(ctx context.Context, id int, isActive bool) error
+	Create(ctx context.Context, subscription *models.Subscription) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Subscription, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Subscription, error)
+	GetByMerchantID(ctx context.Context, merchantID int) ([]*models.Subscription, error)
+	GetDue(ctx context.Context, now time.Time) ([]*models.Subscription, error)
+	AdvancePeriod(ctx context.Context, id int, from time.Time, to time.Time) (bool, error)
+	RecordPayment(ctx context.Context, id int) error
+	RecordFailure(ctx context.Context, id int, reason string, nextRetryAt time.Time) (int, error)
+	Cancel(ctx context.Context, id int, reason string) (bool, error)
+}
+
+// ChequeDepositRepository defines methods for cheque deposit repository
+type ChequeDepositRepository interface {
+	GetByID(ctx context.Context, id int) (*models.ChequeDeposit, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.ChequeDeposit, error)
+	GetByStatus(ctx context.Context, status models.ChequeDepositStatus) ([]*models.ChequeDeposit, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, deposit *models.ChequeDeposit) (int, error)
+	ReviewTx(ctx context.Context, tx *sql.Tx, id int, to models.ChequeDepositStatus, reviewedBy int, note string) (bool, error)
+}
+
+// InternationalTransferRepository defines methods for international transfer repository
+type InternationalTransferRepository interface {
+	GetByID(ctx context.Context, id int) (*models.InternationalTransfer, error)
+	GetByReference(ctx context.Context, reference string) (*models.InternationalTransfer, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.InternationalTransfer, error)
+	GetByStatus(ctx context.Context, status models.InternationalTransferStatus) ([]*models.InternationalTransfer, error)
+	GetToDispatch(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error)
+	GetToSettle(ctx context.Context, now time.Time) ([]*models.InternationalTransfer, error)
+	Dispatch(ctx context.Context, id int) (bool, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.InternationalTransfer) (int, error)
+	SettleTx(ctx context.Context, tx *sql.Tx, id int) (bool, error)
+}
+
+// ReferralRepository defines methods for referral program repository
+type ReferralRepository interface {
+	CreateCode(ctx context.Context, userID int, code string) error
+	GetCodeByUserID(ctx context.Context, userID int) (string, error)
+	GetUserIDByCode(ctx context.Context, code string) (int, error)
+	Create(ctx context.Context, referral *models.Referral) (int, error)
+	GetByRefereeID(ctx context.Context, refereeID int) (*models.Referral, error)
+	GetByReferrerID(ctx context.Context, referrerID int) ([]*models.Referral, error)
+	GetByStatus(ctx context.Context, status models.ReferralStatus) ([]*models.Referral, error)
+	Qualify(ctx context.Context, id int, transactionID int) (bool, error)
+	ExpirePending(ctx context.Context, before time.Time) (int64, error)
+	
+	// Transaction-specific methods
+	RewardTx(ctx context.Context, tx *sql.Tx, referral *models.Referral) (bool, error)
+}
+
+// TaxDocumentRepository defines methods for yearly tax document repository
+type TaxDocumentRepository interface {
+	GetInterestEarned(ctx context.Context, userID int, from, to time.Time) ([]models.TaxInterestLine, error)
+	GetLoanInterestPaid(ctx context.Context, userID int, from, to time.Time) ([]models.TaxLoanLine, error)
+	GetUsersWithActivity(ctx context.Context, from, to time.Time) ([]int, error)
+	Upsert(ctx context.Context, doc *models.TaxDocument) (int, error)
+	GetByUserAndYear(ctx context.Context, userID int, year int) (*models.TaxDocument, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.TaxDocument, error)
+	MarkEmailed(ctx context.Context, id int) error
+}
+
+// CreditSignatureRepository defines methods for credit agreement signature repository
+type CreditSignatureRepository interface {
+	CreateRequest(ctx context.Context, request *models.SignatureRequest) (int, error)
+	GetRequestByRequestID(ctx context.Context, requestID string) (*models.SignatureRequest, error)
+	IncrementAttempts(ctx context.Context, id int) (int, error)
+	UpdateRequestStatus(ctx context.Context, id int, from, to models.ConfirmationStatus) (bool, error)
+	GetByCreditID(ctx context.Context, creditID int) (*models.CreditSignature, error)
+	
+	// Transaction-specific methods
+	UpdateRequestStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.ConfirmationStatus) (bool, error)
+	CreateTx(ctx context.Context, tx *sql.Tx, signature *models.CreditSignature) (int, error)
+}
+
+// CreditAdjustmentRepository defines methods for the audit trail of credit adjustments
+type CreditAdjustmentRepository interface {
+	GetByCreditID(ctx context.Context, creditID int) ([]*models.CreditAdjustment, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, adjustment *models.CreditAdjustment) (int, error)
+}
+
+// ImpersonationRepository defines methods for impersonations of customers by support staff and
+// the audit log of their requests
+type ImpersonationRepository interface {
+	Create(ctx context.Context, impersonation *models.Impersonation) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Impersonation, error)
+	GetAll(ctx context.Context, staffID int, customerID int) ([]*models.Impersonation, error)
+	End(ctx context.Context, id int) (bool, error)
+	AddRequest(ctx context.Context, request *models.ImpersonationRequest) (int, error)
+	GetRequests(ctx context.Context, impersonationID int) ([]*models.ImpersonationRequest, error)
+}
+
+// AccountOwnershipRepository defines methods for account ownership transfers and their audit trail
+type AccountOwnershipRepository interface {
+	GetByID(ctx context.Context, id int) (*models.OwnershipTransfer, error)
+	GetByAccountID(ctx context.Context, accountID int) ([]*models.OwnershipTransfer, error)
+	GetByStatus(ctx context.Context, status models.OwnershipTransferStatus) ([]*models.OwnershipTransfer, error)
+	GetEvents(ctx context.Context, transferID int) ([]*models.OwnershipTransferEvent, error)
+	
+	// Transaction-specific methods
+	CreateTx(ctx context.Context, tx *sql.Tx, transfer *models.OwnershipTransfer) (int, error)
+	UpdateStatusTx(ctx context.Context, tx *sql.Tx, id int, from, to models.OwnershipTransferStatus, reviewedBy int, note string) (bool, error)
+	AddEventTx(ctx context.Context, tx *sql.Tx, event *models.OwnershipTransferEvent) (int, error)
+}
+
+// CreditApplicationRepository defines methods for credit application repository
+type CreditApplicationRepository interface {
+	Create(ctx context.Context, application *models.CreditApplication) (int, error)
+	GetByID(ctx context.Context, id int) (*models.CreditApplication, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.CreditApplication, error)
+	GetByStatus(ctx context.Context, status models.CreditApplicationStatus) ([]*models.CreditApplication, error)
+	UpdateStatus(ctx context.Context, id int, from, to models.CreditApplicationStatus, note string, reviewedBy *int) (bool, error)
+	SetCreditID(ctx context.Context, id int, creditID int) error
+}
+
+// CreditDocumentRepository defines methods for credit application document repository
+type CreditDocumentRepository interface {
+	Create(ctx context.Context, doc *models.CreditDocument) (int, error)
+	GetByID(ctx context.Context, id int) (*models.CreditDocument, error)
+	GetByApplicationID(ctx context.Context, applicationID int) ([]*models.CreditDocument, error)
+	Review(ctx context.Context, id int, status models.CreditDocumentStatus, note string) error
+}
+
+// MessageRepository defines methods for secure message repository
+type MessageRepository interface {
+	GetThreadByID(ctx context.Context, id int, reader models.MessageSender) (*models.MessageThread, error)
+	GetThreadsByUserID(ctx context.Context, userID int) ([]*models.MessageThread, error)
+	GetThreads(ctx context.Context, unreadOnly bool) ([]*models.MessageThread, error)
+	GetByThreadID(ctx context.Context, threadID int) ([]*models.Message, error)
+	GetDocument(ctx context.Context, id int, threadID int) (*models.Message, error)
+	MarkRead(ctx context.Context, threadID int, sender models.MessageSender) error
+	CountUnread(ctx context.Context, userID int) (int, error)
+	
+	// Transaction-specific methods
+	CreateThreadTx(ctx context.Context, tx *sql.Tx, thread *models.MessageThread) (int, error)
+	CreateTx(ctx context.Context, tx *sql.Tx, message *models.Message) (int, error)
+}
+
+// AccountingRepository defines methods for accounting export repository
+type AccountingRepository interface {
+	GetEntries(ctx context.Context, from, to time.Time) ([]*models.AccountingEntry, error)
+	GetBalanceMismatches(ctx context.Context) ([]*models.BalanceMismatch, error)
+}
+
+// StatementRepository defines methods for account statement repository
+type StatementRepository interface {
+	Issue(ctx context.Context, accountID int, from, to time.Time) (*models.Statement, error)
+	GetByID(ctx context.Context, id int) (*models.Statement, error)
+	GetByAccountID(ctx context.Context, accountID int) ([]*models.Statement, error)
+	Regenerate(ctx context.Context, id int) (*models.Statement, error)
+	GetAccountsWithoutStatement(ctx context.Context, from, to time.Time) ([]int, error)
+}
+
+// ReportingRepository defines methods for admin dashboard report repository
+type ReportingRepository interface {
+	GetTransactionVolume(ctx context.Context, from, to time.Time) ([]*models.DailyTransactionVolume, error)
+	GetNewUsers(ctx context.Context, from, to time.Time) ([]*models.DailyNewUsers, error)
+	GetCreditsIssued(ctx context.Context, from, to time.Time) ([]*models.DailyCreditsIssued, error)
+	GetCreditPortfolio(ctx context.Context, nplDays int) ([]*models.CreditPortfolio, error)
+	GetCreditPortfolioItems(ctx context.Context, asOf time.Time) ([]*models.CreditPortfolioItem, error)
+	GetDepositBalances(ctx context.Context) ([]*models.DepositBalance, error)
+}
+
+// RateRepository defines methods for the history of central bank rates
+type RateRepository interface {
+	Create(ctx context.Context, rate *models.Rate) error
+	GetHistory(ctx context.Context, query *models.RateHistoryQuery) ([]*models.Rate, error)
+	GetAt(ctx context.Context, rateType models.RateType, currency models.Currency, at time.Time) (*models.Rate, error)
+	CreateKeyRateOverride(ctx context.Context, override *models.KeyRateOverride) error
+	GetKeyRateOverrides(ctx context.Context) ([]*models.KeyRateOverride, error)
+	RevokeKeyRateOverride(ctx context.Context, id int, adminID int) (bool, error)
+}
+
+// LocationRepository defines methods for branch and ATM location repository
+type LocationRepository interface {
+	Create(ctx context.Context, location *models.Location) (int, error)
+	GetByID(ctx context.Context, id int) (*models.Location, error)
+	GetAll(ctx context.Context) ([]*models.Location, error)
+	GetNearby(ctx context.Context, query *models.LocationQuery) ([]*models.Location, error)
+	Update(ctx context.Context, location *models.Location) error
+	Delete(ctx context.Context, id int) error
 }
 
 // Repository is a composition of all repositories
@@ -89,22 +666,189 @@ type Repository struct {
 	DB             *sql.DB
 	User           UserRepository
 	Account        AccountRepository
+	AccountHold    AccountHoldRepository
+	AccountSettings AccountSettingsRepository
+	AccountEvent   AccountEventRepository
+	AccountPlan    AccountPlanRepository
 	Card           CardRepository
+	CardProduct    CardProductRepository
+	Fee            FeeRepository
 	Transaction    TransactionRepository
+	TransactionEvent TransactionEventRepository
+	TransactionDescription TransactionDescriptionRepository
 	Credit         CreditRepository
 	PaymentSchedule PaymentScheduleRepository
+	Session        SessionRepository
+	Impersonation  ImpersonationRepository
+	Device         DeviceRepository
+	Notification   NotificationRepository
+	Email          EmailRepository
+	Announcement   AnnouncementRepository
+	TransferConfirmation TransferConfirmationRepository
+	PasswordReset  PasswordResetRepository
+	Onboarding     OnboardingRepository
+	APIKey         APIKeyRepository
+	Organization   OrganizationRepository
+	Invitation     InvitationRepository
+	ApprovalPolicy ApprovalPolicyRepository
+	PendingTransfer PendingTransferRepository
+	Payroll        PayrollRepository
+	BillProvider   BillProviderRepository
+	BillPayment    BillPaymentRepository
+	BillTemplate   BillTemplateRepository
+	Merchant       MerchantRepository
+	PaymentIntent  PaymentIntentRepository
+	Settlement     SettlementRepository
+	Chargeback     ChargebackRepository
+	Escrow         EscrowRepository
+	Invoice        InvoiceRepository
+	Subscription   SubscriptionRepository
+	ChequeDeposit  ChequeDepositRepository
+	InternationalTransfer InternationalTransferRepository
+	Referral       ReferralRepository
+	TaxDocument    TaxDocumentRepository
+	Accounting     AccountingRepository
+	Message        MessageRepository
+	CreditApplication CreditApplicationRepository
+	CreditDocument CreditDocumentRepository
+	CreditSignature CreditSignatureRepository
+	CreditAdjustment CreditAdjustmentRepository
+	AccountOwnership AccountOwnershipRepository
+	AccountDelegation AccountDelegationRepository
+	InsurancePolicy InsurancePolicyRepository
+	Statement      StatementRepository
+	Reporting      ReportingRepository
+	Location       LocationRepository
+	Rate           RateRepository
 }
 
-// NewRepository creates a new repository with all sub-repositories
-func NewRepository(db *sql.DB) *Repository {
+// NewRepository creates a new repository with all sub-repositories; new accounts are numbered
+// with the scheme, and dates read from DATE columns start at midnight in the bank's time zone
+func NewRepository(db *sql.DB, numbers models.AccountNumberScheme, bank *time.Location) *Repository {
 	return &Repository{
 		DB:             db,
 		User:           postgres.NewUserRepository(db),
-		Account:        postgres.NewAccountRepository(db),
+		Account:        postgres.NewAccountRepository(db, numbers),
+		AccountHold:    postgres.NewAccountHoldRepository(db),
+		AccountSettings: postgres.NewAccountSettingsRepository(db),
+		AccountEvent:   postgres.NewAccountEventRepository(db),
+		AccountPlan:    postgres.NewAccountPlanRepository(db),
 		Card:           postgres.NewCardRepository(db),
+		CardProduct:    postgres.NewCardProductRepository(db),
+		Fee:            postgres.NewFeeRepository(db),
 		Transaction:    postgres.NewTransactionRepository(db),
+		TransactionEvent: postgres.NewTransactionEventRepository(db),
+		TransactionDescription: postgres.NewTransactionDescriptionRepository(db),
 		Credit:         postgres.NewCreditRepository(db),
-		PaymentSchedule: postgres.NewPaymentScheduleRepository(db),
+		PaymentSchedule: postgres.NewPaymentScheduleRepository(db, bank),
+		Session:        postgres.NewSessionRepository(db),
+		Impersonation:  postgres.NewImpersonationRepository(db),
+		Device:         postgres.NewDeviceRepository(db),
+		Notification:   postgres.NewNotificationRepository(db),
+		Email:          postgres.NewEmailRepository(db),
+		Announcement:   postgres.NewAnnouncementRepository(db),
+		TransferConfirmation: postgres.NewTransferConfirmationRepository(db),
+		PasswordReset:  postgres.NewPasswordResetRepository(db),
+		Onboarding:     postgres.NewOnboardingRepository(db),
+		APIKey:         postgres.NewAPIKeyRepository(db),
+		Organization:   postgres.NewOrganizationRepository(db),
+		Invitation:     postgres.NewInvitationRepository(db),
+		ApprovalPolicy: postgres.NewApprovalPolicyRepository(db),
+		PendingTransfer: postgres.NewPendingTransferRepository(db),
+		Payroll:        postgres.NewPayrollRepository(db),
+		BillProvider:   postgres.NewBillProviderRepository(db),
+		BillPayment:    postgres.NewBillPaymentRepository(db),
+		BillTemplate:   postgres.NewBillTemplateRepository(db),
+		Merchant:       postgres.NewMerchantRepository(db),
+		PaymentIntent:  postgres.NewPaymentIntentRepository(db),
+		Settlement:     postgres.NewSettlementRepository(db),
+		Chargeback:     postgres.NewChargebackRepository(db),
+		Escrow:         postgres.NewEscrowRepository(db),
+		Invoice:        postgres.NewInvoiceRepository(db),
+		Subscription:   postgres.NewSubscriptionRepository(db),
+		ChequeDeposit:  postgres.NewChequeDepositRepository(db),
+		InternationalTransfer: postgres.NewInternationalTransferRepository(db),
+		Referral:       postgres.NewReferralRepository(db),
+		TaxDocument:    postgres.NewTaxDocumentRepository(db),
+		Accounting:     postgres.NewAccountingRepository(db),
+		Message:        postgres.NewMessageRepository(db),
+		CreditApplication: postgres.NewCreditApplicationRepository(db),
+		CreditDocument: postgres.NewCreditDocumentRepository(db),
+		CreditSignature: postgres.NewCreditSignatureRepository(db),
+		CreditAdjustment: postgres.NewCreditAdjustmentRepository(db),
+		AccountOwnership: postgres.NewAccountOwnershipRepository(db),
+		AccountDelegation: postgres.NewAccountDelegationRepository(db),
+		InsurancePolicy: postgres.NewInsurancePolicyRepository(db),
+		Statement:      postgres.NewStatementRepository(db),
+		Reporting:      postgres.NewReportingRepository(db, bank),
+		Location:       postgres.NewLocationRepository(db),
+		Rate:           postgres.NewRateRepository(db),
+	}
+}
+
+// NewMemoryRepository creates a repository that keeps all data in memory, for tests and
+// running the API without a database
+func NewMemoryRepository(numbers models.AccountNumberScheme) *Repository {
+	store := memory.NewStore()
+
+	return &Repository{
+		DB:             store.DB(),
+		User:           memory.NewUserRepository(store),
+		Account:        memory.NewAccountRepository(store, numbers),
+		AccountHold:    memory.NewAccountHoldRepository(store),
+		AccountSettings: memory.NewAccountSettingsRepository(store),
+		AccountEvent:   memory.NewAccountEventRepository(store),
+		AccountPlan:    memory.NewAccountPlanRepository(store),
+		Card:           memory.NewCardRepository(store),
+		CardProduct:    memory.NewCardProductRepository(store),
+		Fee:            memory.NewFeeRepository(store),
+		Transaction:    memory.NewTransactionRepository(store),
+		TransactionEvent: memory.NewTransactionEventRepository(store),
+		TransactionDescription: memory.NewTransactionDescriptionRepository(store),
+		Credit:         memory.NewCreditRepository(store),
+		PaymentSchedule: memory.NewPaymentScheduleRepository(store),
+		Session:        memory.NewSessionRepository(store),
+		Impersonation:  memory.NewImpersonationRepository(store),
+		Device:         memory.NewDeviceRepository(store),
+		Notification:   memory.NewNotificationRepository(store),
+		Email:          memory.NewEmailRepository(store),
+		Announcement:   memory.NewAnnouncementRepository(store),
+		TransferConfirmation: memory.NewTransferConfirmationRepository(store),
+		PasswordReset:  memory.NewPasswordResetRepository(store),
+		Onboarding:     memory.NewOnboardingRepository(store),
+		APIKey:         memory.NewAPIKeyRepository(store),
+		Organization:   memory.NewOrganizationRepository(store),
+		Invitation:     memory.NewInvitationRepository(store),
+		ApprovalPolicy: memory.NewApprovalPolicyRepository(store),
+		PendingTransfer: memory.NewPendingTransferRepository(store),
+		Payroll:        memory.NewPayrollRepository(store),
+		BillProvider:   memory.NewBillProviderRepository(store),
+		BillPayment:    memory.NewBillPaymentRepository(store),
+		BillTemplate:   memory.NewBillTemplateRepository(store),
+		Merchant:       memory.NewMerchantRepository(store),
+		PaymentIntent:  memory.NewPaymentIntentRepository(store),
+		Settlement:     memory.NewSettlementRepository(store),
+		Chargeback:     memory.NewChargebackRepository(store),
+		Escrow:         memory.NewEscrowRepository(store),
+		Invoice:        memory.NewInvoiceRepository(store),
+		Subscription:   memory.NewSubscriptionRepository(store),
+		ChequeDeposit:  memory.NewChequeDepositRepository(store),
+		InternationalTransfer: memory.NewInternationalTransferRepository(store),
+		Referral:       memory.NewReferralRepository(store),
+		TaxDocument:    memory.NewTaxDocumentRepository(store),
+		Accounting:     memory.NewAccountingRepository(store),
+		Message:        memory.NewMessageRepository(store),
+		CreditApplication: memory.NewCreditApplicationRepository(store),
+		CreditDocument: memory.NewCreditDocumentRepository(store),
+		CreditSignature: memory.NewCreditSignatureRepository(store),
+		CreditAdjustment: memory.NewCreditAdjustmentRepository(store),
+		AccountOwnership: memory.NewAccountOwnershipRepository(store),
+		AccountDelegation: memory.NewAccountDelegationRepository(store),
+		InsurancePolicy: memory.NewInsurancePolicyRepository(store),
+		Statement:      memory.NewStatementRepository(store),
+		Reporting:      memory.NewReportingRepository(store),
+		Location:       memory.NewLocationRepository(store),
+		Rate:           memory.NewRateRepository(store),
 	}
 }
 
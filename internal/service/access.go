@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// accountAccess defines what a user wants to do with an account
+type accountAccess int
+
+const (
+	// accessView allows reading balances, transactions and cards
+	accessView accountAccess = iota
+	// accessTransact allows moving money out of the account
+	accessTransact
+	// accessManage allows changing the account itself and its cards
+	accessManage
+)
+
+// checkAccountAccess verifies that the user may perform the requested action on the account.
+// Personal accounts are accessible to their owner, and for viewing to users the owner delegated
+// access to; organization accounts are accessible to members whose role permits the action.
+// Delegates make transfers through checkTransferAccess, which knows the amount.
+func checkAccountAccess(ctx context.Context, repos *repository.Repository, account *models.Account, userID int, access accountAccess) error {
+	if account.OrganizationID == nil {
+		if account.UserID == userID {
+			return nil
+		}
+
+		delegation, err := repos.AccountDelegation.GetActive(ctx, account.ID, userID)
+		if err != nil {
+			return errors.New("access denied: account belongs to another user")
+		}
+		if access != accessView {
+			return errors.New("access denied: delegated access does not allow this")
+		}
+
+		// Every use of a delegation is attributed to the delegate in its audit log
+		return recordDelegatedAction(ctx, repos, nil, &models.DelegationEvent{
+			DelegationID: delegation.ID,
+			AccountID:    account.ID,
+			ActorID:      userID,
+			Action:       models.DelegationActionViewed,
+		})
+	}
+
+	member, err := repos.Organization.GetMember(ctx, *account.OrganizationID, userID)
+	if err != nil {
+		return errors.New("access denied: account belongs to another organization")
+	}
+
+	return checkOrganizationRole(member.Role, access)
+}
+
+// checkOrganizationRole verifies that an organization role permits the requested action
+func checkOrganizationRole(role models.OrganizationRole, access accountAccess) error {
+	switch access {
+	case accessTransact:
+		if !role.CanTransact() {
+			return errors.New("access denied: your organization role does not allow transactions")
+		}
+	case accessManage:
+		if !role.CanManage() {
+			return errors.New("access denied: only organization admins can do this")
+		}
+	}
+
+	return nil
+}
+
+// errDelegatedLimitExceeded is returned to a delegate transferring more than is left of their limit
+var errDelegatedLimitExceeded = errors.New("access denied: amount exceeds your delegated transfer limit")
+
+// checkTransferAccess verifies that the user may transfer an amount from the account, within tx
+// when it is set. Besides the users checkAccountAccess lets transact, delegates with the TRANSFER
+// scope may make transfers as long as, with the earlier ones under the delegation, they stay within
+// its limit; their delegation is returned so the transfer can be recorded in its audit log. Within
+// the transaction that debits the account, the row lock on it keeps concurrent transfers by the
+// same delegate from both fitting under the limit.
+func checkTransferAccess(ctx context.Context, repos *repository.Repository, tx *sql.Tx, account *models.Account, userID int, amount float64) (*models.AccountDelegation, error) {
+	if account.OrganizationID != nil || account.UserID == userID {
+		return nil, checkAccountAccess(ctx, repos, account, userID, accessTransact)
+	}
+
+	delegation, err := repos.AccountDelegation.GetActive(ctx, account.ID, userID)
+	if err != nil {
+		return nil, errors.New("access denied: account belongs to another user")
+	}
+
+	if delegation.Scope != models.DelegationScopeTransfer {
+		return nil, errors.New("access denied: your delegated access is view-only")
+	}
+
+	var transferred float64
+	if tx != nil {
+		transferred, err = repos.AccountDelegation.SumTransferredTx(ctx, tx, delegation.ID)
+	} else {
+		transferred, err = repos.AccountDelegation.SumTransferred(ctx, delegation.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if transferred+amount > delegation.TransferLimit {
+		return nil, errDelegatedLimitExceeded
+	}
+
+	return delegation, nil
+}
+
+// recordDelegatedAction adds an entry to the audit log of a delegation, within tx when it is set,
+// with the ID of the request the delegate made
+func recordDelegatedAction(ctx context.Context, repos *repository.Repository, tx *sql.Tx, event *models.DelegationEvent) error {
+	event.RequestID, _ = ctx.Value("request_id").(string)
+
+	var err error
+	if tx != nil {
+		_, err = repos.AccountDelegation.AddEventTx(ctx, tx, event)
+	} else {
+		_, err = repos.AccountDelegation.AddEvent(ctx, event)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record delegated access: %w", err)
+	}
+
+	return nil
+}
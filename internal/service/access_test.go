@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+func TestCheckTransferAccessCapsDelegatedTransfersInTotal(t *testing.T) {
+	ctx := context.Background()
+	deps := newTestDependencies()
+
+	ownerID := createTestUser(t, ctx, deps.Repos, "owner")
+	delegateID := createTestUser(t, ctx, deps.Repos, "delegate")
+	account := createTestAccount(t, ctx, deps.Repos, ownerID, 10000)
+
+	delegationID, err := deps.Repos.AccountDelegation.CreateTx(ctx, nil, &models.AccountDelegation{
+		AccountID:     account.ID,
+		OwnerID:       ownerID,
+		DelegateID:    delegateID,
+		Scope:         models.DelegationScopeTransfer,
+		TransferLimit: 1000,
+		ExpiresAt:     time.Now().AddDate(0, 1, 0),
+	})
+	if err != nil {
+		t.Fatalf("failed to create delegation: %v", err)
+	}
+
+	transfer := func(amount float64) error {
+		delegation, err := checkTransferAccess(ctx, deps.Repos, nil, account, delegateID, amount)
+		if err != nil {
+			return err
+		}
+		return recordDelegatedAction(ctx, deps.Repos, nil, &models.DelegationEvent{
+			DelegationID: delegation.ID,
+			AccountID:    account.ID,
+			ActorID:      delegateID,
+			Action:       models.DelegationActionTransferred,
+			Amount:       amount,
+		})
+	}
+
+	if err := transfer(600); err != nil {
+		t.Fatalf("expected the first transfer to be allowed, got %v", err)
+	}
+
+	// Each transfer is below the limit, but together they are not
+	if err := transfer(600); !errors.Is(err, errDelegatedLimitExceeded) {
+		t.Fatalf("expected the second transfer to exceed the limit, got %v", err)
+	}
+
+	if err := transfer(400); err != nil {
+		t.Fatalf("expected a transfer of what is left of the limit to be allowed, got %v", err)
+	}
+
+	if err := transfer(0.01); !errors.Is(err, errDelegatedLimitExceeded) {
+		t.Fatalf("expected a transfer beyond the used up limit to be declined, got %v", err)
+	}
+
+	transferred, err := deps.Repos.AccountDelegation.SumTransferred(ctx, delegationID)
+	if err != nil {
+		t.Fatalf("failed to sum delegated transfers: %v", err)
+	}
+	if transferred != 1000 {
+		t.Errorf("expected 1000.00 transferred under the delegation, got %.2f", transferred)
+	}
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// AccountOwnershipSvc is an implementation of the service.AccountOwnershipService interface
+type AccountOwnershipSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewAccountOwnershipService creates a new AccountOwnershipSvc
+func NewAccountOwnershipService(deps Dependencies) *AccountOwnershipSvc {
+	return &AccountOwnershipSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Request asks to transfer a personal account to another user. The transfer waits for a second
+// bank employee to approve it.
+func (s *AccountOwnershipSvc) Request(ctx context.Context, accountID int, request *models.OwnershipTransferRequest, adminID int) (*models.OwnershipTransfer, error) {
+	if err := request.ValidateOwnershipTransferRequest(); err != nil {
+		return nil, fmt.Errorf("invalid ownership transfer: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkTransferable(ctx, account, request.ToUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repos.AccountOwnership.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, transfer := range existing {
+		if transfer.Status == models.OwnershipTransferStatusPending {
+			return nil, errors.New("account already has a pending ownership transfer")
+		}
+	}
+
+	transfer := &models.OwnershipTransfer{
+		AccountID:   account.ID,
+		FromUserID:  account.UserID,
+		ToUserID:    request.ToUserID,
+		Reason:      request.Reason,
+		Note:        request.Note,
+		Documents:   request.Documents,
+		Status:      models.OwnershipTransferStatusPending,
+		RequestedBy: adminID,
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = s.repos.AccountOwnership.CreateTx(ctx, tx, transfer); err != nil {
+		return nil, err
+	}
+
+	event := &models.OwnershipTransferEvent{
+		TransferID: transfer.ID,
+		AdminID:    adminID,
+		Action:     models.OwnershipTransferActionRequested,
+		Note:       request.Note,
+		Details: fmt.Sprintf("account %s from user %d to user %d (%s), documents: %s",
+			account.AccountNumber, transfer.FromUserID, transfer.ToUserID, transfer.Reason, strings.Join(transfer.Documents, ", ")),
+	}
+	if _, err = s.repos.AccountOwnership.AddEventTx(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Ownership transfer %d of account %d requested by %d: user %d to user %d (%s)",
+		transfer.ID, account.ID, adminID, transfer.FromUserID, transfer.ToUserID, transfer.Reason)
+
+	transfer.Events = []*models.OwnershipTransferEvent{event}
+	return transfer, nil
+}
+
+// Approve completes a pending transfer: the account, with its cards and history, moves to the new
+// owner. The employee who requested the transfer cannot approve it.
+func (s *AccountOwnershipSvc) Approve(ctx context.Context, id int, decision *models.OwnershipTransferDecision, adminID int) (*models.OwnershipTransfer, error) {
+	if err := decision.ValidateOwnershipTransferDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	transfer, err := s.pending(ctx, id, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The account may have changed since the transfer was requested
+	account, err := s.repos.Account.GetByID(ctx, transfer.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.UserID != transfer.FromUserID {
+		return nil, errors.New("account owner changed since the transfer was requested")
+	}
+	if err := s.checkTransferable(ctx, account, transfer.ToUserID); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.repos.Card.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	ok, err := s.repos.AccountOwnership.UpdateStatusTx(ctx, tx, id, models.OwnershipTransferStatusPending,
+		models.OwnershipTransferStatusCompleted, adminID, decision.Note)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		err = errors.New("ownership transfer is no longer pending")
+		return nil, err
+	}
+
+	ok, err = s.repos.Account.TransferOwnershipTx(ctx, tx, account.ID, transfer.FromUserID, transfer.ToUserID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		err = errors.New("account owner changed since the transfer was requested")
+		return nil, err
+	}
+
+	// Powers of attorney the previous owner granted end with the ownership
+	revoked, err := s.repos.AccountDelegation.RevokeByAccountTx(ctx, tx, account.ID, adminID)
+	if err != nil {
+		return nil, err
+	}
+	for _, delegationID := range revoked {
+		err = recordDelegatedAction(ctx, s.repos, tx, &models.DelegationEvent{
+			DelegationID: delegationID,
+			AccountID:    account.ID,
+			ActorID:      adminID,
+			Action:       models.DelegationActionRevoked,
+			Details:      fmt.Sprintf("account transferred to another owner, ownership transfer %d", id),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	event := &models.OwnershipTransferEvent{
+		TransferID: id,
+		AdminID:    adminID,
+		Action:     models.OwnershipTransferActionApproved,
+		Note:       decision.Note,
+		Details: fmt.Sprintf("account %s moved from user %d to user %d with %d card(s), balance %.2f %s, %d delegation(s) revoked",
+			account.AccountNumber, transfer.FromUserID, transfer.ToUserID, len(cards), account.Balance, account.Currency, len(revoked)),
+	}
+	if _, err = s.repos.AccountOwnership.AddEventTx(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Ownership transfer %d approved by %d: account %d moved from user %d to user %d",
+		id, adminID, account.ID, transfer.FromUserID, transfer.ToUserID)
+
+	s.notify(transfer.ToUserID, "account_ownership_transferred", account.AccountNumber, len(cards))
+
+	return s.Get(ctx, id)
+}
+
+// Reject closes a pending transfer without moving the account. The employee who requested the
+// transfer cannot reject it either; they are expected to ask a colleague.
+func (s *AccountOwnershipSvc) Reject(ctx context.Context, id int, decision *models.OwnershipTransferDecision, adminID int) (*models.OwnershipTransfer, error) {
+	if err := decision.ValidateOwnershipTransferDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+	if decision.Note == "" {
+		return nil, errors.New("note is required to reject an ownership transfer")
+	}
+
+	if _, err := s.pending(ctx, id, adminID); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	ok, err := s.repos.AccountOwnership.UpdateStatusTx(ctx, tx, id, models.OwnershipTransferStatusPending,
+		models.OwnershipTransferStatusRejected, adminID, decision.Note)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		err = errors.New("ownership transfer is no longer pending")
+		return nil, err
+	}
+
+	event := &models.OwnershipTransferEvent{
+		TransferID: id,
+		AdminID:    adminID,
+		Action:     models.OwnershipTransferActionRejected,
+		Note:       decision.Note,
+		Details:    "account left with its owner",
+	}
+	if _, err = s.repos.AccountOwnership.AddEventTx(ctx, tx, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Ownership transfer %d rejected by %d", id, adminID)
+
+	return s.Get(ctx, id)
+}
+
+// Get gets an ownership transfer with its audit trail
+func (s *AccountOwnershipSvc) Get(ctx context.Context, id int) (*models.OwnershipTransfer, error) {
+	transfer, err := s.repos.AccountOwnership.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Events, err = s.repos.AccountOwnership.GetEvents(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// GetByAccountID gets the ownership transfers of an account with their audit trails, newest first
+func (s *AccountOwnershipSvc) GetByAccountID(ctx context.Context, accountID int) ([]*models.OwnershipTransfer, error) {
+	if _, err := s.repos.Account.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	transfers, err := s.repos.AccountOwnership.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withEvents(ctx, transfers)
+}
+
+// GetAll gets the ownership transfers in a status, all of them if the status is empty, oldest first
+func (s *AccountOwnershipSvc) GetAll(ctx context.Context, status models.OwnershipTransferStatus) ([]*models.OwnershipTransfer, error) {
+	status = models.OwnershipTransferStatus(strings.ToUpper(string(status)))
+	if status != "" && !status.IsValid() {
+		return nil, errors.New("status must be one of PENDING_APPROVAL, COMPLETED, REJECTED")
+	}
+
+	transfers, err := s.repos.AccountOwnership.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withEvents(ctx, transfers)
+}
+
+// withEvents loads the audit trails of ownership transfers
+func (s *AccountOwnershipSvc) withEvents(ctx context.Context, transfers []*models.OwnershipTransfer) ([]*models.OwnershipTransfer, error) {
+	for _, transfer := range transfers {
+		events, err := s.repos.AccountOwnership.GetEvents(ctx, transfer.ID)
+		if err != nil {
+			return nil, err
+		}
+		transfer.Events = events
+	}
+
+	return transfers, nil
+}
+
+// pending gets a pending transfer a second employee can review
+func (s *AccountOwnershipSvc) pending(ctx context.Context, id int, adminID int) (*models.OwnershipTransfer, error) {
+	transfer, err := s.repos.AccountOwnership.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != models.OwnershipTransferStatusPending {
+		return nil, errors.New("ownership transfer is no longer pending")
+	}
+
+	if transfer.RequestedBy == adminID {
+		return nil, errors.New("ownership transfer must be reviewed by another employee")
+	}
+
+	return transfer, nil
+}
+
+// checkTransferable checks that an account can move to a user: a personal account without open
+// credits that is not a merchant's settlement account, and a new owner in the same tenant
+func (s *AccountOwnershipSvc) checkTransferable(ctx context.Context, account *models.Account, toUserID int) error {
+	if account.OrganizationID != nil {
+		return errors.New("organization accounts cannot change owners")
+	}
+
+	if account.UserID == toUserID {
+		return errors.New("account already belongs to the user")
+	}
+
+	owner, err := s.repos.User.GetByID(ctx, account.UserID)
+	if err != nil {
+		return err
+	}
+	recipient, err := s.repos.User.GetByID(ctx, toUserID)
+	if err != nil {
+		return fmt.Errorf("new owner not found: %w", err)
+	}
+	if recipient.Tenant != owner.Tenant {
+		return errors.New("new owner must be a customer of the same bank")
+	}
+
+	credits, err := s.repos.Credit.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	for _, credit := range credits {
+		if credit.Status == models.CreditStatusActive || credit.Status == models.CreditStatusOverdue {
+			return errors.New("account has an open credit, which must be settled or restructured first")
+		}
+	}
+
+	merchants, err := s.repos.Merchant.GetByUserID(ctx, account.UserID)
+	if err != nil {
+		return err
+	}
+	for _, merchant := range merchants {
+		if merchant.SettlementAccountID == account.ID {
+			return errors.New("account is the settlement account of a merchant")
+		}
+	}
+
+	return nil
+}
+
+// notify tells the new owner about the account in the background
+func (s *AccountOwnershipSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("account-ownership-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeAccount, template, args...); err != nil {
+			return fmt.Errorf("failed to send account ownership notification: %w", err)
+		}
+		return nil
+	})
+}
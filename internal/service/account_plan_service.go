@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+)
+
+// AccountPlanSvc is an implementation of the service.AccountPlanService interface
+type AccountPlanSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+	bank   *time.Location
+}
+
+// NewAccountPlanService creates a new AccountPlanSvc
+func NewAccountPlanService(deps Dependencies) *AccountPlanSvc {
+	return &AccountPlanSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+		bank:   deps.Bank,
+	}
+}
+
+// GetActive gets the account plans accounts can switch to
+func (s *AccountPlanSvc) GetActive(ctx context.Context) ([]*models.AccountPlan, error) {
+	plans, err := s.repos.AccountPlan.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []*models.AccountPlan{}
+	for _, plan := range plans {
+		if plan.IsActive {
+			active = append(active, plan)
+		}
+	}
+
+	return active, nil
+}
+
+// GetAll gets all account plans for the admin
+func (s *AccountPlanSvc) GetAll(ctx context.Context) ([]*models.AccountPlan, error) {
+	return s.repos.AccountPlan.GetAll(ctx)
+}
+
+// Create adds an account plan
+func (s *AccountPlanSvc) Create(ctx context.Context, request *models.AccountPlanRequest) (*models.AccountPlan, error) {
+	if err := request.ValidateAccountPlanRequest(); err != nil {
+		return nil, fmt.Errorf("invalid account plan: %w", err)
+	}
+
+	plan := request.ToAccountPlan()
+	if _, err := s.repos.AccountPlan.Create(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Account plan %d created: %s", plan.ID, plan.Code)
+
+	return plan, nil
+}
+
+// Update replaces the data of an account plan. The new fee applies to the part of the current
+// month not yet billed, the new quota and interest from now on.
+func (s *AccountPlanSvc) Update(ctx context.Context, id int, request *models.AccountPlanRequest) (*models.AccountPlan, error) {
+	if err := request.ValidateAccountPlanRequest(); err != nil {
+		return nil, fmt.Errorf("invalid account plan: %w", err)
+	}
+
+	plan := request.ToAccountPlan()
+	plan.ID = id
+	if err := s.repos.AccountPlan.Update(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Account plan %d updated, active: %v", id, plan.IsActive)
+
+	return plan, nil
+}
+
+// GetForAccount gets the current plan of an account and its use this month
+func (s *AccountPlanSvc) GetForAccount(ctx context.Context, accountID int, userID int) (*models.AccountPlanStatus, error) {
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
+	}
+
+	period, err := s.repos.AccountPlan.GetCurrentPeriod(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.status(ctx, account, period)
+}
+
+// Switch moves an account to another active plan. The old plan's monthly fee is billed for the
+// time up to the switch and the new plan's from then on, so neither is charged for a full month.
+func (s *AccountPlanSvc) Switch(ctx context.Context, accountID int, userID int, request *models.AccountPlanSwitch) (*models.AccountPlanStatus, error) {
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return nil, err
+	}
+
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	if _, err := s.availablePlan(ctx, request.PlanID, account.AccountType); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repos.AccountPlan.GetCurrentPeriod(ctx, accountID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if current != nil && current.PlanID == request.PlanID {
+		return nil, errors.New("account is already on this plan")
+	}
+
+	period, err := s.repos.AccountPlan.Switch(ctx, accountID, request.PlanID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Account %d switched to plan %d by user %d", accountID, request.PlanID, userID)
+
+	return s.status(ctx, account, period)
+}
+
+// availablePlan gets a plan an account of the type can be put on
+func (s *AccountPlanSvc) availablePlan(ctx context.Context, planID int, accountType models.AccountType) (*models.AccountPlan, error) {
+	plan, err := s.repos.AccountPlan.GetByID(ctx, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account plan: %w", err)
+	}
+
+	if !plan.IsActive {
+		return nil, errors.New("account plan is no longer offered")
+	}
+
+	if !plan.AllowsAccountType(accountType) {
+		return nil, i18n.Errorf("plan_is_not_available_for_accounts", plan.Code, string(accountType))
+	}
+
+	return plan, nil
+}
+
+// status describes the plan period of an account
+func (s *AccountPlanSvc) status(ctx context.Context, account *models.Account, period *models.AccountPlanPeriod) (*models.AccountPlanStatus, error) {
+	plan, err := s.repos.AccountPlan.GetByID(ctx, period.PlanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account plan: %w", err)
+	}
+
+	from, to := monthBounds(time.Now().In(s.bank))
+	transfers, err := s.repos.Transaction.CountOutgoingTransfers(ctx, account.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AccountPlanStatus{
+		Plan:          plan,
+		Since:         period.StartedAt,
+		TransfersUsed: transfers,
+		InterestRate:  plan.InterestRate(account.Balance),
+	}, nil
+}
+
+// planBillingChunkSize is the number of plan periods billed in one database transaction, with one
+// multi-row insert of their interest transactions
+const planBillingChunkSize = 500
+
+// BillMonthly settles the plan periods of past months: each is paid the interest accrued daily on
+// the account's end-of-day balances and assessed its plan's monthly fee, prorated to the time the account was on the plan.
+// The recurring fee job charges the assessed fees. Periods are billed in chunks; when a chunk
+// fails, its periods are billed one by one so that one bad period does not hold up the others.
+func (s *AccountPlanSvc) BillMonthly(ctx context.Context) error {
+	until, _ := monthBounds(time.Now().In(s.bank))
+
+	periods, err := s.repos.AccountPlan.GetUnbilled(ctx, until)
+	if err != nil {
+		return err
+	}
+
+	if len(periods) == 0 {
+		return nil
+	}
+
+	s.logger.Infof("Billing %d account plan periods up to %s", len(periods), until.Format("2006-01-02"))
+
+	for start := 0; start < len(periods); start += planBillingChunkSize {
+		end := start + planBillingChunkSize
+		if end > len(periods) {
+			end = len(periods)
+		}
+
+		chunk := periods[start:end]
+		err := s.bill(ctx, chunk, until)
+		if err == nil {
+			continue
+		}
+		if len(chunk) > 1 {
+			s.logger.Warnf("Failed to bill %d plan periods at once, billing them one by one: %v", len(chunk), err)
+		}
+
+		for _, period := range chunk {
+			if err := s.bill(ctx, []*models.AccountPlanPeriod{period}, until); err != nil {
+				s.logger.Warnf("Failed to bill plan period %d of account %d: %v", period.ID, period.AccountID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planBill is what billing a plan period up to a time pays and assesses
+type planBill struct {
+	period   *models.AccountPlanPeriod
+	plan     *models.AccountPlan
+	account  *models.Account
+	until    time.Time
+	span     string
+	fee      float64
+	interest float64
+}
+
+// bill pays the interest and assesses the fee of plan periods up to a time, or to their end if they
+// ended before, in one database transaction
+func (s *AccountPlanSvc) bill(ctx context.Context, periods []*models.AccountPlanPeriod, until time.Time) (err error) {
+	plans := make(map[int]*models.AccountPlan)
+	bills := make([]*planBill, 0, len(periods))
+	for _, period := range periods {
+		bill := &planBill{period: period, until: until}
+		if period.EndedAt != nil && period.EndedAt.Before(until) {
+			bill.until = *period.EndedAt
+		}
+
+		if bill.plan = plans[period.PlanID]; bill.plan == nil {
+			bill.plan, err = s.repos.AccountPlan.GetByID(ctx, period.PlanID)
+			if err != nil {
+				return fmt.Errorf("failed to get account plan: %w", err)
+			}
+			plans[period.PlanID] = bill.plan
+		}
+
+		bill.account, err = s.repos.Account.GetByID(ctx, period.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		bill.fee = bill.plan.MonthlyFeeBetween(period.BilledUntil, bill.until)
+		bill.interest, err = s.accruedInterest(ctx, bill.plan, bill.account, period.BilledUntil, bill.until)
+		if err != nil {
+			return err
+		}
+		bill.span = feeSpan(period.BilledUntil, bill.until)
+
+		bills = append(bills, bill)
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	interests := []*models.Transaction{}
+	for _, bill := range bills {
+		if bill.interest > 0 {
+			if err = s.repos.Account.UpdateBalanceTx(ctx, tx, bill.account.ID, bill.interest); err != nil {
+				return fmt.Errorf("failed to update account balance: %w", err)
+			}
+
+			interests = append(interests, &models.Transaction{
+				TransactionType:      models.TransactionTypeInterest,
+				DestinationAccountID: &bill.account.ID,
+				Amount:               bill.interest,
+				Currency:             bill.account.Currency,
+				Description:          fmt.Sprintf("Interest on plan %s, %s", bill.plan.Name, bill.span),
+				Status:               models.TransactionStatusCompleted,
+				TransactionDate:      time.Now(),
+			})
+		}
+
+		if bill.fee > 0 {
+			_, err = s.repos.Fee.CreateChargeTx(ctx, tx, &models.FeeCharge{
+				Kind:        models.FeeKindAccountMaintenance,
+				ReferenceID: bill.period.ID,
+				AccountID:   bill.account.ID,
+				PeriodStart: bill.period.BilledUntil,
+				PeriodEnd:   bill.until,
+				Amount:      bill.fee,
+				Currency:    bill.account.Currency,
+				Description: fmt.Sprintf("Monthly fee of plan %s, %s", bill.plan.Name, bill.span),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = s.repos.AccountPlan.MarkBilledTx(ctx, tx, bill.period.ID, bill.until); err != nil {
+			return err
+		}
+	}
+
+	if err = s.repos.Transaction.CreateBatchTx(ctx, tx, interests); err != nil {
+		return fmt.Errorf("failed to create interest transactions: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, bill := range bills {
+		s.logger.Infof("Plan period %d of account %d billed for %s: fee %.2f, interest %.2f",
+			bill.period.ID, bill.account.ID, bill.span, bill.fee, bill.interest)
+	}
+
+	return nil
+}
+
+// accruedInterest returns the interest a plan pays an account for the bank days ending in
+// (from, until]. Each day earns the plan's rates on its end-of-day balance, worked back from the
+// current balance through the transactions posted since, so money that only arrived before billing
+// earns interest for the days it was there.
+func (s *AccountPlanSvc) accruedInterest(ctx context.Context, plan *models.AccountPlan, account *models.Account, from, until time.Time) (float64, error) {
+	if len(plan.InterestTiers) == 0 {
+		return 0, nil
+	}
+
+	transactions, err := s.repos.Transaction.GetByAccountAndPeriod(ctx, account.ID, from, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	start := from.In(s.bank)
+	ends := []time.Time{}
+	for end := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, s.bank); !end.After(until); end = end.AddDate(0, 0, 1) {
+		ends = append(ends, end)
+	}
+
+	balance := account.Balance
+	next := len(transactions) - 1
+	interest := 0.0
+	for i := len(ends) - 1; i >= 0; i-- {
+		for ; next >= 0 && !transactions[next].TransactionDate.Before(ends[i]); next-- {
+			balance -= balanceChange(transactions[next], account.ID)
+		}
+		if balance > 0 {
+			interest += plan.DailyInterest(balance, ends[i])
+		}
+	}
+
+	return math.Round(interest*100) / 100, nil
+}
+
+// balanceChange returns how much a transaction changed the balance of an account
+func balanceChange(transaction *models.Transaction, accountID int) float64 {
+	if !transaction.Status.AffectsBalance() {
+		return 0
+	}
+
+	change := 0.0
+	if transaction.DestinationAccountID != nil && *transaction.DestinationAccountID == accountID {
+		change += transaction.Amount
+	}
+	if transaction.SourceAccountID != nil && *transaction.SourceAccountID == accountID {
+		change -= transaction.Amount
+	}
+	return change
+}
+
+// planTransferFee returns the fee the plan of an account charges for its next outgoing transfer,
+// counting the transfers of the month of now; accounts without a plan transfer for free
+func planTransferFee(ctx context.Context, repos *repository.Repository, account *models.Account, now time.Time) (float64, error) {
+	period, err := repos.AccountPlan.GetCurrentPeriod(ctx, account.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	plan, err := repos.AccountPlan.GetByID(ctx, period.PlanID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account plan: %w", err)
+	}
+
+	if plan.TransferFee == 0 {
+		return 0, nil
+	}
+
+	from, to := monthBounds(now)
+	transfers, err := repos.Transaction.CountOutgoingTransfers(ctx, account.ID, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	return plan.TransferFeeAfter(transfers), nil
+}
+
+// monthBounds returns the start of the calendar month of a time and of the next month
+func monthBounds(t time.Time) (time.Time, time.Time) {
+	from := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return from, from.AddDate(0, 1, 0)
+}
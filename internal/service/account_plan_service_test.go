@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+func TestPlanInterestAccruesOnEndOfDayBalances(t *testing.T) {
+	ctx := context.Background()
+	deps := newTestDependencies()
+	svc := NewAccountPlanService(deps)
+
+	userID := createTestUser(t, ctx, deps.Repos, "saver")
+	account := createTestAccount(t, ctx, deps.Repos, userID, 73000)
+	plan := &models.AccountPlan{
+		InterestTiers: []models.InterestTier{{MinBalance: 0, Rate: 5}},
+		DayCount:      models.DayCountACT365,
+	}
+
+	from, until := monthBounds(time.Now().AddDate(0, -1, 0).In(deps.Bank))
+
+	// Half of the balance arrived on the last day of the month, so it earns one day of interest
+	_, err := deps.Repos.Transaction.Create(ctx, &models.Transaction{
+		TransactionType:      models.TransactionTypeDeposit,
+		DestinationAccountID: &account.ID,
+		Amount:               36500,
+		Currency:             account.Currency,
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      until.Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("failed to create deposit: %v", err)
+	}
+
+	interest, err := svc.accruedInterest(ctx, plan, account, from, until)
+	if err != nil {
+		t.Fatalf("failed to accrue interest: %v", err)
+	}
+
+	// At 5% ACT/365 each 36500 earns 5.00 a day
+	days := until.Sub(from).Hours() / 24
+	want := 5*days + 5
+	if interest != want {
+		t.Errorf("expected interest of %.2f over %.0f days, got %.2f", want, days, interest)
+	}
+}
@@ -2,29 +2,48 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
 )
 
 // AccountSvc is an implementation of the service.AccountService interface
 type AccountSvc struct {
-	repos  *repository.Repository
-	logger *logrus.Logger
-	config *configs.Config
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	referrals     ReferralService
+	lifecycle     *lifecycle.Manager
+	hasher        *crypto.Argon2Hasher
+	notifications NotificationService
+	onboarding    OnboardingService
+	declines      *declines
 }
 
 // NewAccountService creates a new AccountSvc
 func NewAccountService(deps Dependencies) *AccountSvc {
 	return &AccountSvc{
-		repos:  deps.Repos,
-		logger: deps.Logger,
-		config: deps.Config,
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		referrals:     NewReferralService(deps),
+		lifecycle:     deps.Lifecycle,
+		hasher:        newPasswordHasher(deps.Config.Password),
+		notifications: NewNotificationService(deps),
+		onboarding:    NewOnboardingService(deps),
+		declines:      newDeclines(deps),
 	}
 }
 
@@ -41,6 +60,28 @@ func (s *AccountSvc) Create(ctx context.Context, accountCreate *models.AccountCr
 		return 0, fmt.Errorf("user not found: %w", err)
 	}
 	
+	// Only organization admins can open accounts for an organization
+	if accountCreate.OrganizationID != nil {
+		member, err := s.repos.Organization.GetMember(ctx, *accountCreate.OrganizationID, accountCreate.UserID)
+		if err != nil {
+			return 0, errors.New("access denied: you are not a member of this organization")
+		}
+		
+		if err := checkOrganizationRole(member.Role, accessManage); err != nil {
+			return 0, err
+		}
+	}
+	
+	// When onboarding is required, accounts need a verified email and a complete profile
+	if err := s.onboarding.Require(ctx, accountCreate.UserID, models.OnboardingStageFirstAccount); err != nil {
+		return 0, err
+	}
+	
+	plan, err := s.initialPlan(ctx, accountCreate)
+	if err != nil {
+		return 0, err
+	}
+	
 	// Convert AccountCreate to Account
 	account := accountCreate.ToAccount()
 	
@@ -52,34 +93,251 @@ func (s *AccountSvc) Create(ctx context.Context, accountCreate *models.AccountCr
 	
 	s.logger.Infof("Account created: %d for user: %d", id, accountCreate.UserID)
 	
+	if plan != nil {
+		if _, err := s.repos.AccountPlan.Switch(ctx, id, plan.ID, time.Now()); err != nil {
+			return 0, fmt.Errorf("failed to put account on plan: %w", err)
+		}
+	}
+	
+	// The first account completes a stage of onboarding
+	s.lifecycle.Background("onboarding-progress", func(ctx context.Context) error {
+		if _, err := s.onboarding.Advance(ctx, accountCreate.UserID); err != nil {
+			return fmt.Errorf("failed to advance onboarding: %w", err)
+		}
+		return nil
+	})
+	
 	return id, nil
 }
 
-// GetByID gets an account by ID and verifies ownership
+// initialPlan picks the plan of a new account: the requested one, or else the cheapest active plan
+// for the account type. Credit accounts have no plan.
+func (s *AccountSvc) initialPlan(ctx context.Context, accountCreate *models.AccountCreate) (*models.AccountPlan, error) {
+	if accountCreate.AccountType == models.AccountTypeCredit {
+		if accountCreate.PlanID != nil {
+			return nil, errors.New("credit accounts have no plan")
+		}
+		return nil, nil
+	}
+	
+	plans, err := s.repos.AccountPlan.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Plans come cheapest first
+	for _, plan := range plans {
+		if accountCreate.PlanID != nil && plan.ID != *accountCreate.PlanID {
+			continue
+		}
+		if plan.IsActive && plan.AllowsAccountType(accountCreate.AccountType) {
+			return plan, nil
+		}
+	}
+	
+	if accountCreate.PlanID != nil {
+		return nil, i18n.Errorf("plan_is_not_available_for_accounts", strconv.Itoa(*accountCreate.PlanID), string(accountCreate.AccountType))
+	}
+	
+	return nil, nil
+}
+
+// GetByID gets an account by ID with the user's display settings and verifies that the user may view it
 func (s *AccountSvc) GetByID(ctx context.Context, id int, userID int) (*models.Account, error) {
+	account, err := s.getAccount(ctx, id, userID, accessView)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := attachAccountSettings(ctx, s.repos, userID, []*models.Account{account}); err != nil {
+		return nil, err
+	}
+	
+	return account, nil
+}
+
+// getAccount gets an account by ID and verifies that the user may perform the action on it
+func (s *AccountSvc) getAccount(ctx context.Context, id int, userID int, access accountAccess) (*models.Account, error) {
 	account, err := s.repos.Account.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	// Verify ownership
-	if account.UserID != userID {
-		return nil, errors.New("access denied: account belongs to another user")
+	// Verify ownership or organization role
+	if err := checkAccountAccess(ctx, s.repos, account, userID, access); err != nil {
+		return nil, err
 	}
 	
 	return account, nil
 }
 
-// GetByUserID gets all accounts for a user
+// GetHolds gets the active holds that make up the difference between the ledger and available balance
+func (s *AccountSvc) GetHolds(ctx context.Context, id int, userID int) ([]*models.AccountHold, error) {
+	if _, err := s.getAccount(ctx, id, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	return s.repos.AccountHold.GetActiveByAccountID(ctx, id)
+}
+
+// GetActivity gets a page of the activity feed of an account: its transactions and the changes to
+// the account, its cards and its limits, newest first. The page continues after the last item of
+// the previous page, or starts at the newest item if there is none.
+func (s *AccountSvc) GetActivity(ctx context.Context, id int, userID int, after *models.AccountActivityCursor, limit int) (*models.AccountActivityPage, error) {
+	limit, err := models.ValidateAccountActivityLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	
+	if _, err := s.getAccount(ctx, id, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	// One more item than the page holds tells whether another page follows
+	transactions, err := s.repos.Transaction.GetActivity(ctx, id, after.Before(models.AccountActivityTransaction), limit+1)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, transactions); err != nil {
+		return nil, err
+	}
+	
+	events, err := s.repos.AccountEvent.GetActivity(ctx, id, after.Before(models.AccountActivityEvent), limit+1)
+	if err != nil {
+		return nil, err
+	}
+	
+	items := make([]*models.AccountActivityItem, 0, len(transactions)+len(events))
+	for _, transaction := range transactions {
+		items = append(items, &models.AccountActivityItem{Kind: models.AccountActivityTransaction, At: transaction.TransactionDate, Transaction: transaction})
+	}
+	for _, event := range events {
+		items = append(items, &models.AccountActivityItem{Kind: models.AccountActivityEvent, At: event.CreatedAt, Event: event})
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Newer(items[j]) })
+	
+	page := &models.AccountActivityPage{Items: items, Limit: limit}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = models.CursorOf(page.Items[limit-1]).String()
+	}
+	
+	return page, nil
+}
+
+// GetByUserID gets all accounts for a user with their display settings, in the user's sort order
 func (s *AccountSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Account, error) {
 	accounts, err := s.repos.Account.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get accounts: %w", err)
 	}
 	
+	if err := attachAccountSettings(ctx, s.repos, userID, accounts); err != nil {
+		return nil, err
+	}
+	
 	return accounts, nil
 }
 
+// GetDefault gets the user's default personal account in a currency
+func (s *AccountSvc) GetDefault(ctx context.Context, userID int, currency models.Currency) (*models.Account, error) {
+	account, err := s.repos.Account.GetDefault(ctx, userID, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default account: %w", err)
+	}
+	
+	if err := attachAccountSettings(ctx, s.repos, userID, []*models.Account{account}); err != nil {
+		return nil, err
+	}
+	
+	return account, nil
+}
+
+// SetDefault makes a personal account the user's default in its currency. The default receives
+// incoming transfers addressed to the user rather than to an account, so organization accounts
+// and inactive accounts cannot be one.
+func (s *AccountSvc) SetDefault(ctx context.Context, id int, userID int) (*models.Account, error) {
+	account, err := s.getAccount(ctx, id, userID, accessManage)
+	if err != nil {
+		return nil, err
+	}
+	
+	if account.OrganizationID != nil {
+		return nil, errors.New("an organization account cannot be a default account")
+	}
+	
+	if !account.IsActive {
+		return nil, errors.New("account is not active")
+	}
+	
+	if err := s.repos.Account.SetDefault(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to set default account: %w", err)
+	}
+	
+	s.logger.Infof("Account %d is now the default %s account of user %d", id, account.Currency, userID)
+	
+	return s.GetByID(ctx, id, userID)
+}
+
+// UpdateSettings changes how the user displays an account. Anyone who may view the account has
+// their own settings for it.
+func (s *AccountSvc) UpdateSettings(ctx context.Context, id int, userID int, update *models.AccountSettingsUpdate) (*models.AccountSettings, error) {
+	if err := update.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid account settings: %w", err)
+	}
+	
+	if _, err := s.getAccount(ctx, id, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	settings, err := s.repos.AccountSettings.Get(ctx, id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		settings = &models.AccountSettings{AccountID: id, UserID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+	
+	update.Apply(settings)
+	if err := s.repos.AccountSettings.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+	
+	return settings, nil
+}
+
+// attachAccountSettings sets the user's display settings on the accounts and sorts them by the
+// user's sort order. Accounts without settings sort as order 0 and keep their order otherwise.
+func attachAccountSettings(ctx context.Context, repos *repository.Repository, userID int, accounts []*models.Account) error {
+	settings, err := repos.AccountSettings.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get account settings: %w", err)
+	}
+	
+	byAccount := make(map[int]*models.AccountSettings, len(settings))
+	for _, s := range settings {
+		byAccount[s.AccountID] = s
+	}
+	
+	for _, account := range accounts {
+		account.Settings = byAccount[account.ID]
+	}
+	
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return sortOrderOf(accounts[i]) < sortOrderOf(accounts[j])
+	})
+	
+	return nil
+}
+
+// sortOrderOf returns the position the user gave an account
+func sortOrderOf(account *models.Account) int {
+	if account.Settings == nil {
+		return 0
+	}
+	return account.Settings.SortOrder
+}
+
 // Deposit adds funds to an account
 func (s *AccountSvc) Deposit(ctx context.Context, accountID int, userID int, deposit *models.DepositRequest) (int, error) {
 	// Validate deposit request
@@ -87,8 +345,8 @@ func (s *AccountSvc) Deposit(ctx context.Context, accountID int, userID int, dep
 		return 0, fmt.Errorf("invalid deposit request: %w", err)
 	}
 	
-	// Verify account ownership
-	account, err := s.GetByID(ctx, accountID, userID)
+	// Verify account access
+	account, err := s.getAccount(ctx, accountID, userID, accessTransact)
 	if err != nil {
 		return 0, err
 	}
@@ -98,6 +356,11 @@ func (s *AccountSvc) Deposit(ctx context.Context, accountID int, userID int, dep
 		return 0, errors.New("account is inactive")
 	}
 	
+	// The deposit is recorded in the account currency
+	if deposit.Currency != "" && deposit.Currency != account.Currency {
+		return 0, i18n.Errorf("deposit_currency_does_not_match_account_currency", string(deposit.Currency), string(account.Currency))
+	}
+	
 	// Start a transaction
 	tx, err := s.repos.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -117,7 +380,7 @@ func (s *AccountSvc) Deposit(ctx context.Context, accountID int, userID int, dep
 	}
 	
 	// Create transaction record
-	transaction := deposit.ToTransaction()
+	transaction := deposit.ToTransaction(account.Currency)
 	transactionID, err := s.repos.Transaction.Create(ctx, transaction)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction record: %w", err)
@@ -131,6 +394,14 @@ func (s *AccountSvc) Deposit(ctx context.Context, accountID int, userID int, dep
 	s.logger.Infof("Deposit of %f to account %d completed, transaction: %d", 
 		deposit.Amount, accountID, transactionID)
 	
+	// A first deposit may qualify the user's referral for bonuses
+	s.lifecycle.Background("referral-qualification", func(ctx context.Context) error {
+		if err := s.referrals.QualifyDeposit(ctx, userID, transactionID, deposit.Amount); err != nil {
+			return fmt.Errorf("failed to qualify referral: %w", err)
+		}
+		return nil
+	})
+	
 	return transactionID, nil
 }
 
@@ -141,20 +412,33 @@ func (s *AccountSvc) Withdraw(ctx context.Context, accountID int, userID int, wi
 		return 0, fmt.Errorf("invalid withdrawal request: %w", err)
 	}
 	
-	// Verify account ownership
-	account, err := s.GetByID(ctx, accountID, userID)
+	// Verify account access
+	account, err := s.getAccount(ctx, accountID, userID, accessTransact)
 	if err != nil {
 		return 0, err
 	}
 	
+	// The withdrawal is recorded in the account currency
+	if withdrawal.Currency != "" && withdrawal.Currency != account.Currency {
+		return 0, i18n.Errorf("withdrawal_currency_does_not_match_account_currency", string(withdrawal.Currency), string(account.Currency))
+	}
+	
+	// The withdrawal is recorded as a failed transaction if it is declined
+	declined := withdrawal.ToTransaction(account.Currency)
+	
 	// Check if account is active
 	if !account.IsActive {
-		return 0, errors.New("account is inactive")
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "account is inactive")
+	}
+	
+	// Dormant accounts accept deposits but no outgoing operations
+	if account.IsDormant() {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "account is dormant, reactivate it first")
 	}
 	
 	// Check if there are sufficient funds
-	if account.Balance < withdrawal.Amount {
-		return 0, errors.New("insufficient funds")
+	if account.AvailableBalance < withdrawal.Amount {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonInsufficientFunds, "insufficient funds")
 	}
 	
 	// Start a transaction
@@ -176,7 +460,7 @@ func (s *AccountSvc) Withdraw(ctx context.Context, accountID int, userID int, wi
 	}
 	
 	// Create transaction record
-	transaction := withdrawal.ToTransaction()
+	transaction := withdrawal.ToTransaction(account.Currency)
 	transactionID, err := s.repos.Transaction.Create(ctx, transaction)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction record: %w", err)
@@ -195,14 +479,15 @@ func (s *AccountSvc) Withdraw(ctx context.Context, accountID int, userID int, wi
 
 // Update updates an account
 func (s *AccountSvc) Update(ctx context.Context, account *models.Account, userID int) error {
-	// Verify account ownership
-	originalAccount, err := s.GetByID(ctx, account.ID, userID)
+	// Verify account access
+	originalAccount, err := s.getAccount(ctx, account.ID, userID, accessManage)
 	if err != nil {
 		return err
 	}
 	
 	// Prevent modification of critical fields
 	account.UserID = originalAccount.UserID
+	account.OrganizationID = originalAccount.OrganizationID
 	account.AccountNumber = originalAccount.AccountNumber
 	account.Balance = originalAccount.Balance
 	
@@ -219,8 +504,8 @@ func (s *AccountSvc) Update(ctx context.Context, account *models.Account, userID
 
 // Delete deletes an account
 func (s *AccountSvc) Delete(ctx context.Context, id int, userID int) error {
-	// Verify account ownership
-	_, err := s.GetByID(ctx, id, userID)
+	// Verify account access
+	_, err := s.getAccount(ctx, id, userID, accessManage)
 	if err != nil {
 		return err
 	}
@@ -245,5 +530,85 @@ func (s *AccountSvc) Delete(ctx context.Context, id int, userID int) error {
 	
 	s.logger.Infof("Account deleted: %d", id)
 	
+	return nil
+}
+
+// Reactivate lifts the dormant flag of an account after the user confirms their password
+func (s *AccountSvc) Reactivate(ctx context.Context, id int, userID int, reactivation *models.AccountReactivation) (*models.Account, error) {
+	account, err := s.getAccount(ctx, id, userID, accessManage)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Reactivation unblocks outgoing operations, so the user has to authenticate again
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	if !s.hasher.CheckPasswordHash(reactivation.Password, user.PassHash) {
+		return nil, errors.New("invalid password")
+	}
+	
+	reactivated, err := s.repos.Account.Reactivate(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !reactivated {
+		return nil, errors.New("account is not dormant")
+	}
+	
+	s.logger.Infof("Dormant account %d reactivated by user %d", account.ID, userID)
+	
+	return s.repos.Account.GetByID(ctx, account.ID)
+}
+
+// DetectDormant flags accounts without activity for the configured number of months as dormant
+// and notifies their owners
+func (s *AccountSvc) DetectDormant(ctx context.Context) error {
+	months := s.config.Dormancy.Months
+	if months <= 0 {
+		return nil
+	}
+	
+	accounts, err := s.repos.Account.MarkDormant(ctx, time.Now().AddDate(0, -months, 0))
+	if err != nil {
+		return err
+	}
+	
+	for _, account := range accounts {
+		s.logger.Infof("Account %d marked dormant after %d months without activity", account.ID, months)
+		
+		if err := s.notifications.Notify(ctx, account.UserID, models.NotificationTypeAccount, "account_dormant", account.AccountNumber, months); err != nil {
+			s.logger.Warnf("Failed to notify user %d about dormant account %d: %v", account.UserID, account.ID, err)
+		}
+	}
+	
+	return nil
+}
+
+// CheckCurrencies corrects transactions recorded in a currency other than their account's, as
+// deposits and withdrawals once were, and reports those it may not change because their statement
+// period is locked
+func (s *AccountSvc) CheckCurrencies(ctx context.Context) error {
+	fixed, err := s.repos.Transaction.FixCurrencies(ctx)
+	if err != nil {
+		return err
+	}
+	
+	if fixed > 0 {
+		s.logger.Infof("Corrected the currency of %d transactions to their account currency", fixed)
+	}
+	
+	remaining, err := s.repos.Transaction.CountCurrencyMismatches(ctx)
+	if err != nil {
+		return err
+	}
+	
+	if remaining > 0 {
+		s.logger.Warnf("%d transactions in locked statement periods have a currency other than their account's", remaining)
+	}
+	
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/upload"
+)
+
+// Export files use semicolons, decimal commas, DD.MM.YYYY dates and a UTF-8 byte order mark,
+// which is what the 1C tabular document import and Russian-locale spreadsheets expect
+const (
+	accountingDateFormat = "02.01.2006 15:04:05"
+	accountingFileDate   = "2006-01-02"
+	utf8BOM              = "\ufeff"
+)
+
+// AccountingSvc is an implementation of the service.AccountingService interface
+type AccountingSvc struct {
+	repos    *repository.Repository
+	logger   *logrus.Logger
+	config   *configs.Config
+	uploader upload.Uploader
+	bank     *time.Location
+}
+
+// NewAccountingService creates a new AccountingSvc
+func NewAccountingService(deps Dependencies) *AccountingSvc {
+	return &AccountingSvc{
+		repos:    deps.Repos,
+		logger:   deps.Logger,
+		config:   deps.Config,
+		uploader: deps.Uploader,
+		bank:     deps.Bank,
+	}
+}
+
+// Export builds a zip archive with the transactions and ledger entries of [from, to) and returns
+// its file name and content
+func (s *AccountingSvc) Export(ctx context.Context, from, to time.Time) (string, []byte, error) {
+	if err := models.ValidateAccountingPeriod(from, to); err != nil {
+		return "", nil, err
+	}
+
+	entries, err := s.repos.Accounting.GetEntries(ctx, from, to)
+	if err != nil {
+		return "", nil, err
+	}
+
+	transactions, err := accountingTransactionsCSV(entries)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write transactions: %w", err)
+	}
+
+	ledger, err := accountingLedgerCSV(entries)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write ledger: %w", err)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := []struct {
+		name    string
+		content []byte
+	}{
+		{"transactions.csv", transactions},
+		{"ledger.csv", ledger},
+	}
+	for _, f := range files {
+		file, err := archive.Create(f.name)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to add %s to export: %w", f.name, err)
+		}
+
+		if _, err := file.Write(f.content); err != nil {
+			return "", nil, fmt.Errorf("failed to add %s to export: %w", f.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return "", nil, fmt.Errorf("failed to write export: %w", err)
+	}
+
+	// The file name carries the inclusive last day of the period
+	name := fmt.Sprintf("accounting_%s_%s.zip", from.Format(accountingFileDate), to.AddDate(0, 0, -1).Format(accountingFileDate))
+
+	s.logger.Infof("Accounting export %s built with %d transactions", name, len(entries))
+
+	return name, buf.Bytes(), nil
+}
+
+// DropDaily exports the previous day and uploads it to the configured S3 bucket or SFTP directory.
+// Uploading the same day again replaces the file, so reruns are safe.
+func (s *AccountingSvc) DropDaily(ctx context.Context) error {
+	if s.uploader == nil {
+		return nil
+	}
+
+	now := time.Now().In(s.bank)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.bank)
+
+	name, content, err := s.Export(ctx, today.AddDate(0, 0, -1), today)
+	if err != nil {
+		return fmt.Errorf("failed to build accounting export: %w", err)
+	}
+
+	if err := s.uploader.Upload(ctx, name, content); err != nil {
+		return fmt.Errorf("failed to upload accounting export %s: %w", name, err)
+	}
+
+	s.logger.Infof("Accounting export %s uploaded to %s", name, s.config.AccountingExport.Target)
+
+	return nil
+}
+
+// accountingTransactionsCSV renders the transactions register
+func accountingTransactionsCSV(entries []*models.AccountingEntry) ([]byte, error) {
+	rows := [][]string{{"id", "date", "type", "source_account", "destination_account", "amount", "currency", "description"}}
+	for _, e := range entries {
+		rows = append(rows, []string{
+			strconv.Itoa(e.TransactionID),
+			e.Date.Local().Format(accountingDateFormat),
+			string(e.Type),
+			e.SourceAccount,
+			e.DestinationAccount,
+			accountingAmount(e.Amount),
+			string(e.Currency),
+			e.Description,
+		})
+	}
+
+	return accountingCSV(rows)
+}
+
+// accountingLedgerCSV renders the double-entry postings
+func accountingLedgerCSV(entries []*models.AccountingEntry) ([]byte, error) {
+	rows := [][]string{{"transaction_id", "date", "debit", "credit", "amount", "currency", "description"}}
+	for _, e := range entries {
+		posting := e.ToLedgerEntry()
+		rows = append(rows, []string{
+			strconv.Itoa(posting.TransactionID),
+			posting.Date.Local().Format(accountingDateFormat),
+			posting.Debit,
+			posting.Credit,
+			accountingAmount(posting.Amount),
+			string(posting.Currency),
+			posting.Description,
+		})
+	}
+
+	return accountingCSV(rows)
+}
+
+// accountingCSV writes rows in the export CSV dialect
+func accountingCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	w.UseCRLF = true
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// accountingAmount formats an amount with two decimals and a decimal comma
+func accountingAmount(amount float64) string {
+	return strings.Replace(strconv.FormatFloat(amount, 'f', 2, 64), ".", ",", 1)
+}
@@ -2,8 +2,9 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,17 +17,23 @@ import (
 
 // AnalyticsSvc is an implementation of the service.AnalyticsService interface
 type AnalyticsSvc struct {
-	repos  *repository.Repository
-	logger *logrus.Logger
-	config *configs.Config
+	repos   *repository.Repository
+	logger  *logrus.Logger
+	config  *configs.Config
+	rates   RateService
+	credits CreditService
+	bank    *time.Location
 }
 
 // NewAnalyticsService creates a new AnalyticsSvc
 func NewAnalyticsService(deps Dependencies) *AnalyticsSvc {
 	return &AnalyticsSvc{
-		repos:  deps.Repos,
-		logger: deps.Logger,
-		config: deps.Config,
+		repos:   deps.Repos,
+		logger:  deps.Logger,
+		config:  deps.Config,
+		rates:   NewRateService(deps),
+		credits: NewCreditService(deps),
+		bank:    deps.Bank,
 	}
 }
 
@@ -34,7 +41,10 @@ func NewAnalyticsService(deps Dependencies) *AnalyticsSvc {
 func (s *AnalyticsSvc) GetStatistics(ctx context.Context, userID int, period string) (map[string]interface{}, error) {
 	// Define time range based on period
 	var startDate, endDate time.Time
-	now := time.Now()
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 	
 	switch period {
 	case "week":
@@ -51,6 +61,7 @@ func (s *AnalyticsSvc) GetStatistics(ctx context.Context, userID int, period str
 		startDate = now.AddDate(0, -1, 0)
 	}
 	
+	startDate = startOfDay(startDate)
 	endDate = now
 	
 	// Get transactions for the specified period
@@ -92,8 +103,8 @@ func (s *AnalyticsSvc) PredictBalance(ctx context.Context, accountID int, userID
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return nil, errors.New("access denied: account belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
 	}
 	
 	// Set reasonable limit for prediction days
@@ -103,9 +114,22 @@ func (s *AnalyticsSvc) PredictBalance(ctx context.Context, accountID int, userID
 		days = 365 // Max 1 year
 	}
 	
+	prediction, err := s.predictBalance(ctx, account, days, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	s.logger.Infof("Generated balance prediction for account %d for %d days", accountID, days)
+	
+	return prediction, nil
+}
+
+// predictBalance predicts the balance of an account for future days from its recent transactions
+// and the pending payments of its credits, plus any extra payments to be made from it
+func (s *AnalyticsSvc) predictBalance(ctx context.Context, account *models.Account, days int, extraPayments []*models.PaymentSchedule) (map[string]interface{}, error) {
 	// Get upcoming credit payments for this account
-	var creditPayments []*models.PaymentSchedule
-	credits, err := s.repos.Credit.GetByAccountID(ctx, accountID)
+	creditPayments := append([]*models.PaymentSchedule(nil), extraPayments...)
+	credits, err := s.repos.Credit.GetByAccountID(ctx, account.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credits: %w", err)
 	}
@@ -132,7 +156,7 @@ func (s *AnalyticsSvc) PredictBalance(ctx context.Context, accountID int, userID
 	
 	// Get historical transactions for trend analysis
 	startDate := time.Now().AddDate(0, -3, 0) // Last 3 months
-	transactions, err := s.repos.Transaction.GetByAccountID(ctx, accountID)
+	transactions, err := s.repos.Transaction.GetByAccountID(ctx, account.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
@@ -146,11 +170,7 @@ func (s *AnalyticsSvc) PredictBalance(ctx context.Context, accountID int, userID
 	}
 	
 	// Calculate prediction
-	prediction := predictAccountBalance(account, recentTransactions, creditPayments, days)
-	
-	s.logger.Infof("Generated balance prediction for account %d for %d days", accountID, days)
-	
-	return prediction, nil
+	return predictAccountBalance(account, recentTransactions, creditPayments, days), nil
 }
 
 // GetCreditAnalytics gets credit analysis for a user
@@ -237,6 +257,489 @@ func (s *AnalyticsSvc) GetCreditAnalytics(ctx context.Context, userID int) (map[
 	return creditAnalysis, nil
 }
 
+// GetCardAnalytics breaks down the spending of a card over a period by category and by month
+func (s *AnalyticsSvc) GetCardAnalytics(ctx context.Context, cardID int, userID int, period string) (map[string]interface{}, error) {
+	card, err := s.repos.Card.GetByID(ctx, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	
+	account, err := s.repos.Account.GetByID(ctx, card.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	var startDate time.Time
+	switch period {
+	case "week":
+		startDate = now.AddDate(0, 0, -7)
+	case "month":
+		startDate = now.AddDate(0, -1, 0)
+	case "quarter":
+		startDate = now.AddDate(0, -3, 0)
+	default:
+		// Default to a year, so the monthly breakdown shows the card's whole recent use
+		period = "year"
+		startDate = now.AddDate(-1, 0, 0)
+	}
+	startDate = startOfDay(startDate)
+	
+	// Read all of the period's transactions page by page
+	filter := &models.CardTransactionFilter{From: &startDate, Limit: models.MaxCardTransactionLimit}
+	var transactions []*models.Transaction
+	for {
+		page, err := s.repos.Transaction.GetByCardID(ctx, card.ID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions: %w", err)
+		}
+		transactions = append(transactions, page...)
+		if len(page) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+	
+	analysis := calculateCardSpending(transactions, startDate, now)
+	analysis["card_id"] = card.ID
+	analysis["card_type"] = card.CardType
+	analysis["is_active"] = card.IsActive
+	analysis["currency"] = account.Currency
+	analysis["period"] = period
+	analysis["start_date"] = startDate.Format("2006-01-02")
+	analysis["end_date"] = now.Format("2006-01-02")
+	
+	s.logger.Infof("Generated card analytics for card %d for period: %s", card.ID, period)
+	
+	return analysis, nil
+}
+
+// GetSpendingForecast forecasts the month-end spending of a user by category from the spending so
+// far this month, how it usually spreads over a month and what the same month cost a year ago, so
+// the user can see early which category is heading over its usual amount
+func (s *AnalyticsSvc) GetSpendingForecast(ctx context.Context, userID int) (map[string]interface{}, error) {
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// The current month and the twelve before it, for the usual monthly amounts and last year's month
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	startDate := monthStart.AddDate(0, -forecastHistoryMonths, 0)
+	
+	transactions, err := s.repos.Transaction.GetByDateRange(ctx, userID, startDate, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	
+	forecast := forecastCategorySpending(transactions, now)
+	forecast["month"] = monthStart.Format("2006-01")
+	forecast["as_of"] = now.Format("2006-01-02")
+	
+	s.logger.Infof("Generated spending forecast for user %d", userID)
+	
+	return forecast, nil
+}
+
+// GetRecommendations suggests what a user could change to save money, each suggestion with its
+// estimated annual savings in the currency it is given in: cancelling subscriptions that look
+// unused, moving idle checking balances to a savings plan and refinancing credits priced above
+// today's rate
+func (s *AnalyticsSvc) GetRecommendations(ctx context.Context, userID int) (map[string]interface{}, error) {
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	recommendations := []map[string]interface{}{}
+	
+	subscriptions, err := s.recommendCancellations(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	recommendations = append(recommendations, subscriptions...)
+	
+	savings, err := s.recommendSavings(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	recommendations = append(recommendations, savings...)
+	
+	refinancing, err := s.recommendRefinancing(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	recommendations = append(recommendations, refinancing...)
+	
+	// Totals are kept per currency, since subscriptions may be billed in another one
+	totals := map[models.Currency]float64{}
+	for _, recommendation := range recommendations {
+		currency := recommendation["currency"].(models.Currency)
+		totals[currency] = math.Round((totals[currency]+recommendation["annual_savings"].(float64))*100) / 100
+	}
+	
+	s.logger.Infof("Generated %d recommendations for user %d", len(recommendations), userID)
+	
+	return map[string]interface{}{
+		"recommendations":      recommendations,
+		"total_annual_savings": totals,
+		"as_of":                now.Format("2006-01-02"),
+	}, nil
+}
+
+// recommendCancellations suggests cancelling active subscriptions that look unused. The bank does
+// not see how a service is used, so a subscription counts as unused when it has run for
+// unusedSubscriptionDays and the user paid the merchant nothing besides it in that time.
+func (s *AnalyticsSvc) recommendCancellations(ctx context.Context, userID int, now time.Time) ([]map[string]interface{}, error) {
+	subscriptions, err := s.repos.Subscription.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	
+	since := now.AddDate(0, 0, -unusedSubscriptionDays)
+	lastPayments := map[int]*time.Time{} // by merchant, nil if none
+	recommendations := []map[string]interface{}{}
+	
+	for _, subscription := range subscriptions {
+		if subscription.Status != models.SubscriptionStatusActive || subscription.CreatedAt.After(since) {
+			continue
+		}
+		
+		lastPayment, ok := lastPayments[subscription.MerchantID]
+		if !ok {
+			intents, err := s.repos.PaymentIntent.GetByMerchantID(ctx, subscription.MerchantID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get merchant payments: %w", err)
+			}
+			for _, intent := range intents {
+				if intent.CustomerID != nil && *intent.CustomerID == userID && intent.PaidAt != nil &&
+					(lastPayment == nil || intent.PaidAt.After(*lastPayment)) {
+					lastPayment = intent.PaidAt
+				}
+			}
+			lastPayments[subscription.MerchantID] = lastPayment
+		}
+		if lastPayment != nil && lastPayment.After(since) {
+			continue
+		}
+		
+		recommendation := map[string]interface{}{
+			"type":            "cancel_subscription",
+			"subscription_id": subscription.ID,
+			"merchant_id":     subscription.MerchantID,
+			"plan_name":       subscription.PlanName,
+			"amount":          subscription.Amount,
+			"interval":        subscription.Interval,
+			"subscribed_at":   subscription.CreatedAt.Format("2006-01-02"),
+			"annual_savings":  math.Round(subscription.Interval.MonthlyAmount(subscription.Amount)*12*100) / 100,
+			"currency":        subscription.Currency,
+		}
+		if lastPayment != nil {
+			recommendation["last_merchant_payment"] = lastPayment.Format("2006-01-02")
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+	
+	return recommendations, nil
+}
+
+// recommendSavings suggests moving the idle part of checking balances to a savings account on the
+// best-paying active savings plan. A month of the account's usual outflow stays on checking; the
+// savings are the interest the rest would earn on savings less what it earns on checking now.
+func (s *AnalyticsSvc) recommendSavings(ctx context.Context, userID int, now time.Time) ([]map[string]interface{}, error) {
+	accounts, err := s.repos.Account.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	
+	plans, err := s.repos.AccountPlan.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account plans: %w", err)
+	}
+	
+	startDate := now.AddDate(0, -idleBalanceMonths, 0)
+	transactions, err := s.repos.Transaction.GetByDateRange(ctx, userID, startDate, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	
+	recommendations := []map[string]interface{}{}
+	
+	for _, account := range accounts {
+		if account.AccountType != models.AccountTypeChecking || !account.IsActive || account.Balance <= 0 {
+			continue
+		}
+		
+		outflow := 0.0
+		for _, tx := range transactions {
+			if tx.SourceAccountID != nil && *tx.SourceAccountID == account.ID && tx.Status.AffectsBalance() {
+				outflow += tx.Amount
+			}
+		}
+		idle := math.Round((account.Balance-outflow/idleBalanceMonths)*100) / 100
+		if idle <= 0 {
+			continue
+		}
+		
+		var best *models.AccountPlan
+		for _, plan := range plans {
+			if plan.IsActive && plan.AllowsAccountType(models.AccountTypeSavings) &&
+				(best == nil || plan.AnnualInterest(idle) > best.AnnualInterest(idle)) {
+				best = plan
+			}
+		}
+		if best == nil {
+			continue
+		}
+		
+		// The interest the idle part earns on the checking account's plan now, if any
+		earned := 0.0
+		if period, err := s.repos.AccountPlan.GetCurrentPeriod(ctx, account.ID); err == nil {
+			if current, err := s.repos.AccountPlan.GetByID(ctx, period.PlanID); err == nil {
+				earned = current.AnnualInterest(account.Balance) - current.AnnualInterest(account.Balance-idle)
+			}
+		}
+		
+		annualSavings := math.Round((best.AnnualInterest(idle)-earned)*100) / 100
+		if annualSavings < 1 {
+			continue
+		}
+		
+		recommendations = append(recommendations, map[string]interface{}{
+			"type":           "move_to_savings",
+			"account_id":     account.ID,
+			"amount":         idle,
+			"plan_id":        best.ID,
+			"plan_name":      best.Name,
+			"interest_rate":  best.InterestRate(idle),
+			"annual_savings": annualSavings,
+			"currency":       account.Currency,
+		})
+	}
+	
+	return recommendations, nil
+}
+
+// recommendRefinancing suggests refinancing active credits whose rate is at least
+// refinanceMinRateGap above the rate a credit is priced at today. The savings are how much the
+// payments of the next twelve months would drop if the remaining principal were refinanced over
+// the remaining term at today's rate.
+func (s *AnalyticsSvc) recommendRefinancing(ctx context.Context, userID int) ([]map[string]interface{}, error) {
+	credits, err := s.repos.Credit.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credits: %w", err)
+	}
+	
+	recommendations := []map[string]interface{}{}
+	if len(credits) == 0 {
+		return recommendations, nil
+	}
+	
+	keyRate, err := s.rates.GetKeyRate(ctx)
+	if err != nil {
+		// Without today's rate there is nothing to compare credits to
+		s.logger.Warnf("Failed to get key rate for refinancing recommendations: %v", err)
+		return recommendations, nil
+	}
+	todayRate := keyRate + models.CreditRateMargin
+	
+	for _, credit := range credits {
+		if credit.Status != models.CreditStatusActive || credit.InterestRate-todayRate < refinanceMinRateGap {
+			continue
+		}
+		
+		schedules, err := s.repos.PaymentSchedule.GetByCreditID(ctx, credit.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payment schedule: %w", err)
+		}
+		
+		summary := models.CalculatePaymentScheduleSummary(schedules)
+		if summary.RemainingPayments == 0 || summary.RemainingPrincipal <= 0 {
+			continue
+		}
+		
+		account, err := s.repos.Account.GetByID(ctx, credit.AccountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+		
+		payment := models.CalculateMonthlyPayment(summary.RemainingPrincipal, credit.InterestRate, summary.RemainingPayments)
+		refinanced := models.CalculateMonthlyPayment(summary.RemainingPrincipal, todayRate, summary.RemainingPayments)
+		months := summary.RemainingPayments
+		if months > 12 {
+			months = 12
+		}
+		
+		recommendations = append(recommendations, map[string]interface{}{
+			"type":                "refinance_credit",
+			"credit_id":           credit.ID,
+			"interest_rate":       credit.InterestRate,
+			"current_rate":        todayRate,
+			"remaining_principal": math.Round(summary.RemainingPrincipal*100) / 100,
+			"remaining_payments":  summary.RemainingPayments,
+			"monthly_payment":     math.Round(refinanced*100) / 100,
+			"annual_savings":      math.Round((payment-refinanced)*float64(months)*100) / 100,
+			"currency":            account.Currency,
+		})
+	}
+	
+	return recommendations, nil
+}
+
+// SimulateCredit shows how a credit the user has not taken would change their finances: the
+// credit is calculated like the credit calculator does, its monthly payment added to the current
+// obligations and debt-to-income ratio of the credit analytics, and the balance of the account
+// the payments would be made from predicted with and without them
+func (s *AnalyticsSvc) SimulateCredit(ctx context.Context, userID int, request *models.CreditSimulationRequest) (map[string]interface{}, error) {
+	if err := request.ValidateCreditSimulation(); err != nil {
+		return nil, fmt.Errorf("invalid credit simulation: %w", err)
+	}
+	
+	account, err := s.repos.Account.GetByID(ctx, request.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Without a rate the credit is priced like a new one today
+	creditReq := request.CreditRequest()
+	if creditReq.InterestRate == 0 {
+		keyRate, err := s.rates.GetKeyRate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key rate: %w", err)
+		}
+		creditReq.InterestRate = keyRate + models.CreditRateMargin
+	}
+	
+	calculation, err := s.credits.Calculate(ctx, creditReq)
+	if err != nil {
+		return nil, err
+	}
+	
+	analysis, err := s.GetCreditAnalytics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	obligations := analysis["total_monthly_payment"].(float64)
+	obligationsWithCredit := obligations + calculation.MonthlyPayment
+	
+	debtToIncome := analysis["debt_to_income_ratio"].(float64)
+	debtToIncomeWithCredit := 0.0
+	monthlyIncome := estimateMonthlyIncome(ctx, s.repos, userID)
+	if monthlyIncome > 0 {
+		debtToIncomeWithCredit = obligationsWithCredit / monthlyIncome
+	}
+	
+	// The payments of the simulated credit, as the balance prediction expects them
+	payments := make([]*models.PaymentSchedule, 0, len(calculation.Schedule))
+	for _, payment := range calculation.Schedule {
+		payments = append(payments, &models.PaymentSchedule{
+			PaymentDate: payment.PaymentDate,
+			TotalAmount: payment.TotalAmount,
+			Status:      models.PaymentStatusPending,
+		})
+	}
+	
+	balance, err := s.predictBalance(ctx, account, request.Days, nil)
+	if err != nil {
+		return nil, err
+	}
+	balanceWithCredit, err := s.predictBalance(ctx, account, request.Days, payments)
+	if err != nil {
+		return nil, err
+	}
+	
+	simulation := map[string]interface{}{
+		"credit":                           calculation,
+		"monthly_income":                   monthlyIncome,
+		"monthly_obligations":              obligations,
+		"monthly_obligations_with_credit":  obligationsWithCredit,
+		"debt_to_income_ratio":             debtToIncome,
+		"debt_to_income_ratio_with_credit": debtToIncomeWithCredit,
+		"high_debt_to_income_with_credit":  monthlyIncome == 0 || debtToIncomeWithCredit > highDebtToIncomeRatio,
+		"balance_prediction":               balance,
+		"balance_prediction_with_credit":   balanceWithCredit,
+		"eligible":                         true,
+	}
+	if err := user.CheckCreditEligibility(time.Now()); err != nil {
+		simulation["eligible"] = false
+		simulation["ineligible_reason"] = err.Error()
+	}
+	
+	s.logger.Infof("Simulated a credit of %.2f for %d months for user %d", creditReq.Amount, creditReq.TermMonths, userID)
+	
+	return simulation, nil
+}
+
+// GetCashFlow builds the cash flow statement of a user's accounts over a period, bucket by
+// bucket: the money that came in and went out by category and by account, with the balances each
+// bucket opened and closed with. Balances are worked back from the current ones, so they match
+// the account statements.
+func (s *AnalyticsSvc) GetCashFlow(ctx context.Context, userID int, granularity, from, to string) (*models.CashFlowStatement, error) {
+	now, err := s.userNow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	query, err := models.ParseCashFlowQuery(granularity, from, to, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cash flow query: %w", err)
+	}
+	
+	accounts, err := s.repos.Account.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	
+	// Transactions up to now, so the balances at the end of the period can be worked back to
+	until := now
+	if query.Period.To.After(until) {
+		until = query.Period.To
+	}
+	transactions, err := s.repos.Transaction.GetByDateRange(ctx, userID, query.Period.From, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	
+	statement := buildCashFlow(query, accounts, transactions)
+	
+	s.logger.Infof("Generated cash flow statement for user %d by %s", userID, query.Granularity)
+	
+	return statement, nil
+}
+
+// userNow returns the current time in the user's time zone, so statistics periods start and months
+// change at the user's midnight
+func (s *AnalyticsSvc) userNow(ctx context.Context, userID int) (time.Time, error) {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	return time.Now().In(user.Location(s.bank)), nil
+}
+
+// startOfDay returns the midnight that starts the day of a time in its time zone
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
 // Helper function to calculate statistics
 func calculateStatistics(transactions []*models.Transaction, accounts []*models.Account, credits []*models.Credit) map[string]interface{} {
 	totalBalance := 0.0
@@ -264,7 +767,7 @@ func calculateStatistics(transactions []*models.Transaction, accounts []*models.
 	for _, tx := range transactions {
 		category := categorizeTransaction(tx)
 		
-		if tx.TransactionType == models.TransactionTypeDeposit {
+		if isIncome(tx) {
 			totalIncome += tx.Amount
 			categoryIncome[category] += tx.Amount
 		} else if tx.TransactionType == models.TransactionTypeWithdrawal || 
@@ -288,6 +791,416 @@ func calculateStatistics(transactions []*models.Transaction, accounts []*models.
 	return stats
 }
 
+// Helper function to break down card spending. Only completed payments and withdrawals count as
+// spending, as in the card transaction totals; months without spending are listed with zeros.
+func calculateCardSpending(transactions []*models.Transaction, startDate, endDate time.Time) map[string]interface{} {
+	totalSpent := 0.0
+	count := 0
+	largest := 0.0
+	categorySpending := make(map[string]float64)
+	monthlyAmounts := make(map[string]float64)
+	monthlyCounts := make(map[string]int)
+	var lastUsedAt *time.Time
+	
+	for _, tx := range transactions {
+		if lastUsedAt == nil || tx.TransactionDate.After(*lastUsedAt) {
+			date := tx.TransactionDate
+			lastUsedAt = &date
+		}
+		
+		if tx.Status != models.TransactionStatusCompleted || tx.SourceAccountID == nil {
+			continue
+		}
+		
+		month := tx.TransactionDate.In(startDate.Location()).Format("2006-01")
+		totalSpent += tx.Amount
+		count++
+		categorySpending[categorizeTransaction(tx)] += tx.Amount
+		monthlyAmounts[month] += tx.Amount
+		monthlyCounts[month]++
+		if tx.Amount > largest {
+			largest = tx.Amount
+		}
+	}
+	
+	monthly := []map[string]interface{}{}
+	for month := time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, startDate.Location()); !month.After(endDate); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		monthly = append(monthly, map[string]interface{}{
+			"month":             key,
+			"amount":            monthlyAmounts[key],
+			"transaction_count": monthlyCounts[key],
+		})
+	}
+	
+	averageTransaction := 0.0
+	if count > 0 {
+		averageTransaction = totalSpent / float64(count)
+	}
+	
+	return map[string]interface{}{
+		"total_spent":         totalSpent,
+		"transaction_count":   count,
+		"average_transaction": averageTransaction,
+		"largest_transaction": largest,
+		"category_spending":   categorySpending,
+		"monthly_spending":    monthly,
+		"last_used_at":        lastUsedAt,
+	}
+}
+
+// forecastHistoryMonths is how many full months before the current one the spending forecast
+// learns from
+const forecastHistoryMonths = 12
+
+const (
+	// unusedSubscriptionDays is how long a subscription must run without any other payment to its
+	// merchant before cancelling it is suggested
+	unusedSubscriptionDays = 90
+
+	// idleBalanceMonths is how many months of outflow the usual monthly outflow of a checking
+	// account is averaged over
+	idleBalanceMonths = 3
+
+	// refinanceMinRateGap is how many percentage points a credit's rate must exceed today's rate by
+	// for refinancing to be suggested
+	refinanceMinRateGap = 1.0
+
+	// highDebtToIncomeRatio is the share of monthly income going to credit payments above which a
+	// borrower counts as highly indebted
+	highDebtToIncomeRatio = 0.5
+)
+
+// isSpending reports whether a transaction is spending that moved money out: a payment or a
+// withdrawal that did not fail and was not cancelled
+func isSpending(tx *models.Transaction) bool {
+	return (tx.TransactionType == models.TransactionTypeWithdrawal || tx.TransactionType == models.TransactionTypePayment) &&
+		tx.Status.AffectsBalance()
+}
+
+// Helper function to forecast the month-end spending by category. The pace of the month so far is
+// projected to the month end, adjusted by the share of a month's spending a category usually has
+// reached by this day (rent is paid at the start, groceries bought all month). Early in the month,
+// when the pace says little, the forecast leans on the usual monthly amount, scaled by how the
+// same month a year ago compared to an average month.
+func forecastCategorySpending(transactions []*models.Transaction, now time.Time) map[string]interface{} {
+	cents := func(value float64) float64 { return math.Round(value*100) / 100 }
+	location := now.Location()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	progress := float64(now.Sub(monthStart)) / float64(monthEnd.Sub(monthStart))
+	
+	spent := make(map[string]float64)
+	monthly := make(map[string]map[string]float64) // category -> month -> amount
+	byNow := make(map[string]map[string]float64)   // category -> month -> amount up to the same point of the month
+	firstMonth := monthStart
+	
+	for _, tx := range transactions {
+		if !isSpending(tx) {
+			continue
+		}
+		
+		date := tx.TransactionDate.In(location)
+		category := categorizeTransaction(tx)
+		if !date.Before(monthStart) {
+			spent[category] += tx.Amount
+			continue
+		}
+		
+		start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, location)
+		month := start.Format("2006-01")
+		if start.Before(firstMonth) {
+			firstMonth = start
+		}
+		if monthly[category] == nil {
+			monthly[category] = make(map[string]float64)
+			byNow[category] = make(map[string]float64)
+		}
+		monthly[category][month] += tx.Amount
+		
+		// The same point of that month, as a share of its length
+		end := start.AddDate(0, 1, 0)
+		if float64(date.Sub(start))/float64(end.Sub(start)) <= progress {
+			byNow[category][month] += tx.Amount
+		}
+	}
+	
+	// Months of history count from the first one with any spending, so a new customer's empty months
+	// do not drag the usual amounts down
+	historyMonths := 0
+	for month := firstMonth; month.Before(monthStart); month = month.AddDate(0, 1, 0) {
+		historyMonths++
+	}
+	lastYear := monthStart.AddDate(-1, 0, 0).Format("2006-01")
+	
+	categories := make(map[string]bool)
+	for category := range spent {
+		categories[category] = true
+	}
+	for category := range monthly {
+		categories[category] = true
+	}
+	
+	totalSpent, totalForecast, totalUsual := 0.0, 0.0, 0.0
+	forecasts := []map[string]interface{}{}
+	for category := range categories {
+		usual := 0.0
+		if historyMonths > 0 {
+			total := 0.0
+			for _, amount := range monthly[category] {
+				total += amount
+			}
+			usual = total / float64(historyMonths)
+			
+			// Seasonality: scale by how the same month last year compared to an average month,
+			// within limits so one unusual month does not dominate
+			if historyMonths >= forecastHistoryMonths && usual > 0 {
+				if amount, ok := monthly[category][lastYear]; ok {
+					usual *= math.Max(0.5, math.Min(2, amount/usual))
+				}
+			}
+		}
+		
+		// The share of a month's spending usually reached by now; the elapsed part of the month if unknown
+		share := progress
+		totalByNow, totalMonths := 0.0, 0.0
+		for month, amount := range monthly[category] {
+			totalByNow += byNow[category][month]
+			totalMonths += amount
+		}
+		if totalMonths > 0 && totalByNow > 0 {
+			share = totalByNow / totalMonths
+		}
+		
+		pace := spent[category]
+		if share > 0 {
+			pace = spent[category] / share
+		}
+		
+		forecast := pace
+		if historyMonths > 0 {
+			forecast = progress*pace + (1-progress)*usual
+		}
+		forecast = math.Max(forecast, spent[category])
+		
+		entry := map[string]interface{}{
+			"category": category,
+			"spent":    cents(spent[category]),
+			"forecast": cents(forecast),
+		}
+		if historyMonths > 0 {
+			entry["usual"] = cents(usual)
+			if forecast > usual {
+				entry["over_usual"] = cents(forecast - usual)
+			}
+		}
+		forecasts = append(forecasts, entry)
+		
+		totalSpent += spent[category]
+		totalForecast += forecast
+		totalUsual += usual
+	}
+	
+	// Largest forecasts first, so the categories that matter most lead
+	sort.SliceStable(forecasts, func(i, j int) bool {
+		a, b := forecasts[i]["forecast"].(float64), forecasts[j]["forecast"].(float64)
+		if a != b {
+			return a > b
+		}
+		return forecasts[i]["category"].(string) < forecasts[j]["category"].(string)
+	})
+	
+	return map[string]interface{}{
+		"categories":     forecasts,
+		"total_spent":    cents(totalSpent),
+		"total_forecast": cents(totalForecast),
+		"total_usual":    cents(totalUsual),
+		"month_progress": cents(progress),
+		"history_months": historyMonths,
+	}
+}
+
+// Helper function to build a cash flow statement. Like account statements, money credited to an
+// account flows in and money debited flows out, failed and cancelled transactions aside; transfers
+// between the user's own accounts fall in the "Own Accounts" category on both sides.
+func buildCashFlow(query *models.CashFlowQuery, accounts []*models.Account, transactions []*models.Transaction) *models.CashFlowStatement {
+	cents := func(value float64) float64 { return math.Round(value*100) / 100 }
+	
+	statement := &models.CashFlowStatement{
+		Granularity: query.Granularity,
+		From:        query.Period.From,
+		To:          query.Period.To,
+		Buckets:     []*models.CashFlowBucket{},
+	}
+	
+	for start := query.Period.From; start.Before(query.Period.To); {
+		end := query.Granularity.Next(query.Granularity.BucketStart(start))
+		if end.After(query.Period.To) {
+			end = query.Period.To
+		}
+		statement.Buckets = append(statement.Buckets, &models.CashFlowBucket{Start: start, End: end})
+		start = end
+	}
+	
+	// bucketOf returns the index of the bucket a time falls in, -1 before the period and
+	// len(buckets) after it
+	bucketOf := func(t time.Time) int {
+		if t.Before(query.Period.From) {
+			return -1
+		}
+		return sort.Search(len(statement.Buckets), func(i int) bool { return t.Before(statement.Buckets[i].End) })
+	}
+	
+	own := map[int]*models.Account{}
+	for _, account := range accounts {
+		own[account.ID] = account
+	}
+	
+	// Flows of every account by bucket, the last entry holding those after the period
+	inflows := map[int][]float64{}
+	outflows := map[int][]float64{}
+	for _, account := range accounts {
+		inflows[account.ID] = make([]float64, len(statement.Buckets)+1)
+		outflows[account.ID] = make([]float64, len(statement.Buckets)+1)
+	}
+	
+	type categoryKey struct {
+		bucket   int
+		in       bool
+		category string
+		currency models.Currency
+	}
+	categories := map[categoryKey]*models.CashFlowCategory{}
+	addCategory := func(key categoryKey, amount float64) {
+		category, ok := categories[key]
+		if !ok {
+			category = &models.CashFlowCategory{Category: key.category, Currency: key.currency}
+			categories[key] = category
+		}
+		category.Amount += amount
+		category.TransactionCount++
+	}
+	
+	for _, tx := range transactions {
+		if !tx.Status.AffectsBalance() {
+			continue
+		}
+		bucket := bucketOf(tx.TransactionDate)
+		if bucket < 0 {
+			continue
+		}
+		
+		var source, destination *models.Account
+		if tx.SourceAccountID != nil {
+			source = own[*tx.SourceAccountID]
+		}
+		if tx.DestinationAccountID != nil {
+			destination = own[*tx.DestinationAccountID]
+		}
+		
+		category := categorizeTransaction(tx)
+		if source != nil && destination != nil {
+			category = "Own Accounts"
+		}
+		
+		if destination != nil {
+			inflows[destination.ID][bucket] += tx.Amount
+			if bucket < len(statement.Buckets) {
+				addCategory(categoryKey{bucket, true, category, destination.Currency}, tx.Amount)
+			}
+		}
+		if source != nil {
+			outflows[source.ID][bucket] += tx.Amount
+			if bucket < len(statement.Buckets) {
+				addCategory(categoryKey{bucket, false, category, source.Currency}, tx.Amount)
+			}
+		}
+	}
+	
+	// Work every account's balances back from its current one, bucket by bucket
+	sorted := append([]*models.Account(nil), accounts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	for _, account := range sorted {
+		in, out := inflows[account.ID], outflows[account.ID]
+		closing := account.Balance - in[len(statement.Buckets)] + out[len(statement.Buckets)]
+		flows := make([]*models.CashFlowAccount, len(statement.Buckets))
+		for i := len(statement.Buckets) - 1; i >= 0; i-- {
+			opening := closing - in[i] + out[i]
+			flows[i] = &models.CashFlowAccount{
+				AccountID:     account.ID,
+				AccountNumber: account.AccountNumber,
+				AccountType:   account.AccountType,
+				Currency:      account.Currency,
+				CashFlowBalances: models.CashFlowBalances{
+					OpeningBalance: cents(opening),
+					Inflow:         cents(in[i]),
+					Outflow:        cents(out[i]),
+					NetFlow:        cents(in[i] - out[i]),
+					ClosingBalance: cents(closing),
+				},
+			}
+			closing = opening
+		}
+		for i, bucket := range statement.Buckets {
+			bucket.Accounts = append(bucket.Accounts, flows[i])
+		}
+	}
+	
+	for _, bucket := range statement.Buckets {
+		if bucket.Accounts == nil {
+			bucket.Accounts = []*models.CashFlowAccount{}
+		}
+		
+		// Totals by currency, in the order the currencies first appear among the accounts
+		bucket.Totals = []*models.CashFlowTotal{}
+		totals := map[models.Currency]*models.CashFlowTotal{}
+		for _, account := range bucket.Accounts {
+			total, ok := totals[account.Currency]
+			if !ok {
+				total = &models.CashFlowTotal{Currency: account.Currency}
+				totals[account.Currency] = total
+				bucket.Totals = append(bucket.Totals, total)
+			}
+			total.OpeningBalance = cents(total.OpeningBalance + account.OpeningBalance)
+			total.Inflow = cents(total.Inflow + account.Inflow)
+			total.Outflow = cents(total.Outflow + account.Outflow)
+			total.NetFlow = cents(total.NetFlow + account.NetFlow)
+			total.ClosingBalance = cents(total.ClosingBalance + account.ClosingBalance)
+		}
+		
+		bucket.Inflows = []*models.CashFlowCategory{}
+		bucket.Outflows = []*models.CashFlowCategory{}
+	}
+	
+	for key, category := range categories {
+		category.Amount = cents(category.Amount)
+		bucket := statement.Buckets[key.bucket]
+		if key.in {
+			bucket.Inflows = append(bucket.Inflows, category)
+		} else {
+			bucket.Outflows = append(bucket.Outflows, category)
+		}
+	}
+	
+	largestFirst := func(categories []*models.CashFlowCategory) {
+		sort.SliceStable(categories, func(i, j int) bool {
+			if categories[i].Amount != categories[j].Amount {
+				return categories[i].Amount > categories[j].Amount
+			}
+			if categories[i].Category != categories[j].Category {
+				return categories[i].Category < categories[j].Category
+			}
+			return categories[i].Currency < categories[j].Currency
+		})
+	}
+	for _, bucket := range statement.Buckets {
+		largestFirst(bucket.Inflows)
+		largestFirst(bucket.Outflows)
+	}
+	
+	return statement
+}
+
 // Helper function to predict account balance
 func predictAccountBalance(account *models.Account, transactions []*models.Transaction, creditPayments []*models.PaymentSchedule, days int) map[string]interface{} {
 	now := time.Now()
@@ -310,7 +1223,7 @@ func predictAccountBalance(account *models.Account, transactions []*models.Trans
 		var incomeCount, expenseCount int
 		
 		for _, tx := range transactions {
-			if tx.TransactionType == models.TransactionTypeDeposit {
+			if isIncome(tx) {
 				totalIncome += tx.Amount
 				incomeCount++
 			} else if tx.TransactionType == models.TransactionTypeWithdrawal || 
@@ -403,6 +1316,13 @@ func isSameDay(date1, date2 time.Time) bool {
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
+// isIncome reports whether a transaction brings money in from outside: deposits and the salaries
+// paid out by payrolls
+func isIncome(tx *models.Transaction) bool {
+	return tx.TransactionType == models.TransactionTypeDeposit ||
+		(tx.TransactionType == models.TransactionTypePayroll && tx.SourceAccountID == nil)
+}
+
 // Helper function to categorize a transaction
 func categorizeTransaction(tx *models.Transaction) string {
 	// Simple keyword-based categorization
@@ -471,7 +1391,7 @@ func estimateMonthlyIncome(ctx context.Context, repos *repository.Repository, us
 	// Find deposit transactions that might represent income
 	var incomeTransactions []*models.Transaction
 	for _, tx := range transactions {
-		if tx.TransactionType == models.TransactionTypeDeposit {
+		if isIncome(tx) {
 			if categorizeTransaction(tx) == "Salary" {
 				incomeTransactions = append(incomeTransactions, tx)
 			}
@@ -481,7 +1401,7 @@ func estimateMonthlyIncome(ctx context.Context, repos *repository.Repository, us
 	// If we have no salary transactions, use all deposits
 	if len(incomeTransactions) == 0 {
 		for _, tx := range transactions {
-			if tx.TransactionType == models.TransactionTypeDeposit {
+			if isIncome(tx) {
 				incomeTransactions = append(incomeTransactions, tx)
 			}
 		}
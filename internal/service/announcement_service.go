@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+)
+
+// AnnouncementSvc is an implementation of the service.AnnouncementService interface
+type AnnouncementSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+	email  EmailService
+}
+
+// NewAnnouncementService creates a new AnnouncementSvc
+func NewAnnouncementService(deps Dependencies) *AnnouncementSvc {
+	return &AnnouncementSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+		email:  NewEmailService(deps),
+	}
+}
+
+// Create queues an announcement for the customers in its segment. The announcements job delivers
+// it at the throttled rate of notification.announcements_per_minute.
+func (s *AnnouncementSvc) Create(ctx context.Context, request *models.AnnouncementRequest, adminID int) (*models.Announcement, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid announcement data: %w", err)
+	}
+
+	// The admin of a brand reaches only its customers
+	if tenant := requestTenant(ctx); tenant != "" {
+		if request.Segment.Tenant != "" && request.Segment.Tenant != tenant {
+			return nil, fmt.Errorf("invalid announcement data: %w", errors.New("announcements can only reach the customers of your tenant"))
+		}
+		request.Segment.Tenant = tenant
+	}
+
+	if request.Segment.Tenant != "" {
+		if _, ok := s.config.Tenant(request.Segment.Tenant); !ok {
+			return nil, fmt.Errorf("invalid announcement data: %w", i18n.Errorf("unknown_tenant_named", request.Segment.Tenant))
+		}
+	}
+
+	announcement := &models.Announcement{
+		Title:     request.Title,
+		Message:   request.Message,
+		Segment:   request.Segment,
+		SendEmail: request.SendEmail,
+		CreatedBy: adminID,
+	}
+
+	if _, err := s.repos.Announcement.Create(ctx, announcement); err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+
+	s.logger.Infof("Announcement %d queued by admin %d for %d customers", announcement.ID, adminID, announcement.Recipients)
+
+	return announcement, nil
+}
+
+// GetAll gets the announcements of the request's tenant with their delivery progress, newest first
+func (s *AnnouncementSvc) GetAll(ctx context.Context) ([]*models.Announcement, error) {
+	announcements, err := s.repos.Announcement.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// GetByID gets an announcement with its delivery progress
+func (s *AnnouncementSvc) GetByID(ctx context.Context, id int) (*models.Announcement, error) {
+	announcement, err := s.repos.Announcement.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+
+	return announcement, nil
+}
+
+// Cancel stops delivering a queued announcement; the customers reached already keep it
+func (s *AnnouncementSvc) Cancel(ctx context.Context, id int, adminID int) (*models.Announcement, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.repos.Announcement.Cancel(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel announcement: %w", err)
+	}
+
+	announcement, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, errors.New("only a queued announcement can be cancelled")
+	}
+
+	s.logger.Warnf("Announcement %d cancelled by admin %d after reaching %d of %d customers",
+		id, adminID, announcement.Sent, announcement.Recipients)
+
+	return announcement, nil
+}
+
+// Deliver sends queued announcements to at most notification.announcements_per_minute customers,
+// personalized for each, as an in-app notification and, if asked, an email
+func (s *AnnouncementSvc) Deliver(ctx context.Context) error {
+	recipients, err := s.repos.Announcement.GetPendingRecipients(ctx, s.config.Notification.AnnouncementsPerMinute)
+	if err != nil {
+		return fmt.Errorf("failed to get announcement recipients: %w", err)
+	}
+
+	announcements := map[int]*models.Announcement{}
+	sent, failed := 0, 0
+	for _, recipient := range recipients {
+		if ctx.Err() != nil {
+			break
+		}
+
+		announcement, ok := announcements[recipient.AnnouncementID]
+		if !ok {
+			announcement, err = s.repos.Announcement.GetByID(ctx, recipient.AnnouncementID)
+			if err != nil {
+				return fmt.Errorf("failed to get announcement: %w", err)
+			}
+			announcements[announcement.ID] = announcement
+		}
+
+		status, reason := models.AnnouncementRecipientStatusSent, ""
+		if err := s.deliver(ctx, announcement, recipient.UserID); err != nil {
+			s.logger.Warnf("Failed to deliver announcement %d to user %d: %v", announcement.ID, recipient.UserID, err)
+			status, reason = models.AnnouncementRecipientStatusFailed, err.Error()
+			failed++
+		} else {
+			sent++
+		}
+
+		if err := s.repos.Announcement.MarkRecipient(ctx, recipient.ID, status, reason); err != nil {
+			return fmt.Errorf("failed to mark announcement recipient: %w", err)
+		}
+	}
+
+	completed, err := s.repos.Announcement.CompleteDelivered(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to complete announcements: %w", err)
+	}
+
+	if sent > 0 || failed > 0 || completed > 0 {
+		s.logger.Infof("Announcements delivered to %d customers, %d failed, %d announcements completed", sent, failed, completed)
+	}
+
+	return nil
+}
+
+// deliver notifies one customer of an announcement and emails it if asked
+func (s *AnnouncementSvc) deliver(ctx context.Context, announcement *models.Announcement, userID int) error {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	title, message := announcement.Personalize(user)
+	if runes := []rune(title); len(runes) > 200 {
+		// A long name can push the personalized title past what a notification holds
+		title = string(runes[:200])
+	}
+
+	_, err = s.repos.Notification.Create(ctx, &models.Notification{
+		UserID:  userID,
+		Type:    models.NotificationTypeAnnouncement,
+		Title:   title,
+		Message: message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	if announcement.SendEmail {
+		if err := s.email.SendAnnouncement(ctx, userID, title, message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+const (
+	// apiKeyPrefix starts every API key, so a leaked one is easy to recognize
+	apiKeyPrefix = "ak_"
+
+	// maxActiveAPIKeys is how many API keys a user may have that are not revoked or expired
+	maxActiveAPIKeys = 20
+
+	// apiKeyUsageInterval is how often the last use of an API key is recorded, so a busy
+	// integration does not write on every request
+	apiKeyUsageInterval = time.Minute
+)
+
+// APIKeySvc is an implementation of the service.APIKeyService interface
+type APIKeySvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewAPIKeyService creates a new APIKeySvc
+func NewAPIKeyService(deps Dependencies) *APIKeySvc {
+	return &APIKeySvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Create issues an API key limited to the requested scopes. The key is returned only here; the
+// user is notified in-app, so a key issued by someone else with their login does not go unnoticed.
+func (s *APIKeySvc) Create(ctx context.Context, userID int, request *models.APIKeyCreate) (*models.APIKeyCredentials, error) {
+	if err := request.ValidateAPIKeyCreate(); err != nil {
+		return nil, fmt.Errorf("invalid API key data: %w", err)
+	}
+
+	keys, err := s.repos.APIKey.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	now := time.Now()
+	active := 0
+	for _, key := range keys {
+		if key.IsActive(now) {
+			active++
+		}
+	}
+	if active >= maxActiveAPIKeys {
+		return nil, errors.New("too many active API keys, revoke one first")
+	}
+
+	apiKey, hash, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key := &models.APIKey{
+		UserID:    userID,
+		Name:      request.Name,
+		KeyPrefix: apiKey[:len(apiKeyPrefix)+8],
+		KeyHash:   hash,
+		Scopes:    request.Scopes,
+	}
+	if request.ExpiresIn > 0 {
+		expiresAt := now.Add(time.Duration(request.ExpiresIn) * time.Second)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if _, err := s.repos.APIKey.Create(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	s.logger.Infof("User %d issued API key %d with scopes %v", userID, key.ID, key.Scopes)
+
+	s.lifecycle.Background("api-key-alert", func(ctx context.Context) error {
+		return s.notifications.Notify(ctx, userID, models.NotificationTypeSecurity, "api_key_created", key.Name, scopeList(key.Scopes))
+	})
+
+	return &models.APIKeyCredentials{APIKey: key, Key: apiKey}, nil
+}
+
+// GetByUserID gets all API keys of a user, including revoked and expired ones
+func (s *APIKeySvc) GetByUserID(ctx context.Context, userID int) ([]*models.APIKey, error) {
+	keys, err := s.repos.APIKey.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetByID gets an API key of the user
+func (s *APIKeySvc) GetByID(ctx context.Context, id int, userID int) (*models.APIKey, error) {
+	key, err := s.repos.APIKey.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	if key.UserID != userID {
+		return nil, errors.New("access denied: API key does not belong to user")
+	}
+
+	return key, nil
+}
+
+// Rotate replaces the key of an API key, keeping its name, scopes and expiry; the old key stops
+// working immediately. To switch an integration over without downtime, issue a second key and
+// revoke the first once it is no longer used.
+func (s *APIKeySvc) Rotate(ctx context.Context, id int, userID int) (*models.APIKeyCredentials, error) {
+	key, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.IsActive(time.Now()) {
+		return nil, errors.New("only an active API key can be rotated")
+	}
+
+	apiKey, hash, err := newAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	key.KeyHash = hash
+	key.KeyPrefix = apiKey[:len(apiKeyPrefix)+8]
+	key.LastUsedAt = nil
+
+	if err := s.repos.APIKey.UpdateKey(ctx, id, key.KeyHash, key.KeyPrefix); err != nil {
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+
+	s.logger.Infof("API key %d rotated by user %d", id, userID)
+
+	return &models.APIKeyCredentials{APIKey: key, Key: apiKey}, nil
+}
+
+// Revoke revokes an API key of the user; requests with it are refused from then on
+func (s *APIKeySvc) Revoke(ctx context.Context, id int, userID int) error {
+	if _, err := s.GetByID(ctx, id, userID); err != nil {
+		return err
+	}
+
+	revoked, err := s.repos.APIKey.Revoke(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if !revoked {
+		return errors.New("API key is already revoked")
+	}
+
+	s.logger.Infof("API key %d revoked by user %d", id, userID)
+
+	return nil
+}
+
+// Authenticate resolves an active API key and the user it acts for, and records its use
+func (s *APIKeySvc) Authenticate(ctx context.Context, apiKey string) (*models.APIKey, *models.User, error) {
+	key, err := s.repos.APIKey.GetByKeyHash(ctx, hashAPIKey(apiKey))
+	if err != nil {
+		return nil, nil, errors.New("invalid API key")
+	}
+
+	now := time.Now()
+	if !key.IsActive(now) {
+		return nil, nil, errors.New("API key has been revoked or expired")
+	}
+
+	user, err := s.repos.User.GetByID(ctx, key.UserID)
+	if err != nil {
+		return nil, nil, errors.New("invalid API key")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUsageInterval {
+		if err := s.repos.APIKey.MarkUsed(ctx, key.ID, now); err != nil {
+			s.logger.Warnf("Failed to record use of API key %d: %v", key.ID, err)
+		}
+	}
+
+	return key, user, nil
+}
+
+// newAPIKey generates an API key and the hash stored in its place
+func newAPIKey() (string, string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	apiKey := apiKeyPrefix + hex.EncodeToString(b)
+	return apiKey, hashAPIKey(apiKey), nil
+}
+
+// hashAPIKey hashes an API key for storage and lookup. Keys are random, so a fast hash is enough.
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// scopeList lists scopes for a message, comma separated
+func scopeList(scopes []models.Scope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
+)
+
+// BillSvc is an implementation of the service.BillService interface
+type BillSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	email     EmailService
+	lifecycle *lifecycle.Manager
+}
+
+// NewBillService creates a new BillSvc
+func NewBillService(deps Dependencies) *BillSvc {
+	return &BillSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		email:     NewEmailService(deps),
+		lifecycle: deps.Lifecycle,
+	}
+}
+
+// GetProviders lists the active bill providers, optionally limited to a category
+func (s *BillSvc) GetProviders(ctx context.Context, category models.BillCategory) ([]*models.BillProvider, error) {
+	if category != "" && !category.IsValid() {
+		return nil, i18n.Errorf("unknown_category", string(category))
+	}
+
+	providers, err := s.repos.BillProvider.GetActive(ctx, category)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill providers: %w", err)
+	}
+
+	return providers, nil
+}
+
+// GetProvider gets a bill provider with its payment fields
+func (s *BillSvc) GetProvider(ctx context.Context, id int) (*models.BillProvider, error) {
+	provider, err := s.repos.BillProvider.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill provider: %w", err)
+	}
+
+	return provider, nil
+}
+
+// Pay pays a bill from one of the user's accounts and records it as a PAYMENT transaction
+func (s *BillSvc) Pay(ctx context.Context, request *models.BillPaymentRequest, userID int) (*models.BillPayment, error) {
+	if err := request.ValidateBillPaymentRequest(); err != nil {
+		return nil, fmt.Errorf("invalid bill payment: %w", err)
+	}
+
+	provider, account, err := s.checkPayment(ctx, request, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Deduct the payment from the account
+	err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -request.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	// Create transaction record
+	transaction := request.ToTransaction(provider)
+	transaction.Status = models.TransactionStatusCompleted
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	payment := &models.BillPayment{
+		UserID:        userID,
+		ProviderID:    provider.ID,
+		ProviderName:  provider.Name,
+		AccountID:     account.ID,
+		TransactionID: transactionID,
+		Amount:        request.Amount,
+		Fields:        request.Fields,
+	}
+
+	payment.ID, err = s.repos.BillPayment.CreateTx(ctx, tx, payment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record bill payment: %w", err)
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Bill payment of %f to provider %d from account %d completed, transaction: %d",
+		request.Amount, provider.ID, account.ID, transactionID)
+
+	// Send notification email
+	transaction.ID = transactionID
+	s.lifecycle.Background("transaction-notification", func(ctx context.Context) error {
+		err := s.email.SendTransactionNotification(ctx, userID, transaction)
+		if err != nil {
+			return fmt.Errorf("failed to send transaction notification: %w", err)
+		}
+		return nil
+	})
+
+	return payment, nil
+}
+
+// checkPayment validates a bill payment against its provider and the source account
+func (s *BillSvc) checkPayment(ctx context.Context, request *models.BillPaymentRequest, userID int) (*models.BillProvider, *models.Account, error) {
+	provider, err := s.repos.BillProvider.GetByID(ctx, request.ProviderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get bill provider: %w", err)
+	}
+
+	if request.Fields == nil {
+		request.Fields = map[string]string{}
+	}
+
+	if err := provider.ValidatePayment(request.Amount, request.Fields); err != nil {
+		return nil, nil, fmt.Errorf("invalid bill payment: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, request.AccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, nil, err
+	}
+
+	if !account.IsActive {
+		return nil, nil, errors.New("account is inactive")
+	}
+
+	if account.IsDormant() {
+		return nil, nil, errors.New("account is dormant, reactivate it first")
+	}
+
+	if account.Currency != models.CurrencyRUB {
+		return nil, nil, errors.New("bills can only be paid from RUB accounts")
+	}
+
+	if account.AvailableBalance < request.Amount {
+		return nil, nil, errors.New("insufficient funds")
+	}
+
+	return provider, account, nil
+}
+
+// GetPayments gets the bill payment history of a user
+func (s *BillSvc) GetPayments(ctx context.Context, userID int) ([]*models.BillPayment, error) {
+	payments, err := s.repos.BillPayment.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// PayAgain repeats a previous bill payment, optionally with another amount or account
+func (s *BillSvc) PayAgain(ctx context.Context, paymentID int, repeat *models.BillRepeatRequest, userID int) (*models.BillPayment, error) {
+	if err := repeat.ValidateBillRepeatRequest(); err != nil {
+		return nil, fmt.Errorf("invalid repeat request: %w", err)
+	}
+
+	payment, err := s.repos.BillPayment.GetByID(ctx, paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill payment: %w", err)
+	}
+
+	if payment.UserID != userID {
+		return nil, errors.New("access denied: bill payment belongs to another user")
+	}
+
+	return s.Pay(ctx, payment.ToBillPaymentRequest(repeat), userID)
+}
+
+// CreateTemplate saves a bill payment as a template after validating it against the provider
+func (s *BillSvc) CreateTemplate(ctx context.Context, templateCreate *models.BillTemplateCreate, userID int) (int, error) {
+	if err := templateCreate.ValidateBillTemplate(); err != nil {
+		return 0, fmt.Errorf("invalid bill template: %w", err)
+	}
+
+	provider, err := s.repos.BillProvider.GetByID(ctx, templateCreate.ProviderID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bill provider: %w", err)
+	}
+
+	if templateCreate.Fields == nil {
+		templateCreate.Fields = map[string]string{}
+	}
+
+	if err := provider.ValidatePayment(templateCreate.Amount, templateCreate.Fields); err != nil {
+		return 0, fmt.Errorf("invalid bill template: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, templateCreate.AccountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repos.BillTemplate.Create(ctx, templateCreate.ToBillTemplate(userID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create bill template: %w", err)
+	}
+
+	s.logger.Infof("Bill template created: %d for user: %d", id, userID)
+
+	return id, nil
+}
+
+// GetTemplates gets the saved bill templates of a user
+func (s *BillSvc) GetTemplates(ctx context.Context, userID int) ([]*models.BillTemplate, error) {
+	templates, err := s.repos.BillTemplate.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplate deletes a saved bill template
+func (s *BillSvc) DeleteTemplate(ctx context.Context, id int, userID int) error {
+	if err := s.repos.BillTemplate.Delete(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to delete bill template: %w", err)
+	}
+
+	s.logger.Infof("Bill template deleted: %d by user: %d", id, userID)
+
+	return nil
+}
+
+// PayTemplate pays a bill from a saved template, optionally with another amount or account
+func (s *BillSvc) PayTemplate(ctx context.Context, id int, repeat *models.BillRepeatRequest, userID int) (*models.BillPayment, error) {
+	if err := repeat.ValidateBillRepeatRequest(); err != nil {
+		return nil, fmt.Errorf("invalid repeat request: %w", err)
+	}
+
+	template, err := s.repos.BillTemplate.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill template: %w", err)
+	}
+
+	if template.UserID != userID {
+		return nil, errors.New("access denied: bill template belongs to another user")
+	}
+
+	return s.Pay(ctx, template.ToBillPaymentRequest(repeat), userID)
+}
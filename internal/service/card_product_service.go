@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// CardProductSvc is an implementation of the service.CardProductService interface
+type CardProductSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+}
+
+// NewCardProductService creates a new CardProductSvc
+func NewCardProductService(deps Dependencies) *CardProductSvc {
+	return &CardProductSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+	}
+}
+
+// GetActive gets the card products cards can be issued for
+func (s *CardProductSvc) GetActive(ctx context.Context) ([]*models.CardProduct, error) {
+	products, err := s.repos.CardProduct.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []*models.CardProduct{}
+	for _, product := range products {
+		if product.IsActive {
+			active = append(active, product)
+		}
+	}
+
+	return active, nil
+}
+
+// GetAll gets all card products for the admin
+func (s *CardProductSvc) GetAll(ctx context.Context) ([]*models.CardProduct, error) {
+	return s.repos.CardProduct.GetAll(ctx)
+}
+
+// Create adds a card product
+func (s *CardProductSvc) Create(ctx context.Context, request *models.CardProductRequest) (*models.CardProduct, error) {
+	if err := request.ValidateCardProductRequest(); err != nil {
+		return nil, fmt.Errorf("invalid card product: %w", err)
+	}
+
+	product := request.ToCardProduct()
+	if _, err := s.repos.CardProduct.Create(ctx, product); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Card product %d created: %s", product.ID, product.Code)
+
+	return product, nil
+}
+
+// Update replaces the data of a card product. The new limits, fees and cashback apply to the
+// cards already issued; the BINs and account types only to cards issued from now on.
+func (s *CardProductSvc) Update(ctx context.Context, id int, request *models.CardProductRequest) (*models.CardProduct, error) {
+	if err := request.ValidateCardProductRequest(); err != nil {
+		return nil, fmt.Errorf("invalid card product: %w", err)
+	}
+
+	product := request.ToCardProduct()
+	product.ID = id
+	if err := s.repos.CardProduct.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Card product %d updated, active: %v", id, product.IsActive)
+
+	return product, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// cardRules applies the limits, fees and cashback of a card's product to the payments and
+// withdrawals made with the card
+type cardRules struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	bank   *time.Location
+}
+
+// newCardRules creates the card rules of a service
+func newCardRules(deps Dependencies) *cardRules {
+	return &cardRules{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		bank:   deps.Bank,
+	}
+}
+
+// product gets the product of a card
+func (r *cardRules) product(ctx context.Context, card *models.Card) (*models.CardProduct, error) {
+	product, err := r.repos.CardProduct.GetByID(ctx, card.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card product: %w", err)
+	}
+	return product, nil
+}
+
+// checkLimits returns why a payment or, if withdrawal is set, an ATM withdrawal of the amount
+// exceeds the limits of the card's product, or "" if it does not. The daily limit covers the
+// card's completed payments, withdrawals and fees since midnight. It is summed within tx when it is
+// set: within the transaction that debits the account, the row lock on it keeps concurrent
+// payments with the card from both fitting under the limit.
+func (r *cardRules) checkLimits(ctx context.Context, tx *sql.Tx, card *models.Card, product *models.CardProduct, amount float64, withdrawal bool) (string, error) {
+	single, name := product.Limits.Purchase, "purchase"
+	if withdrawal {
+		single, name = product.Limits.Withdrawal, "withdrawal"
+	}
+	if single > 0 && amount > single {
+		return fmt.Sprintf("amount exceeds the card's %s limit of %.2f", name, single), nil
+	}
+
+	if product.Limits.Daily == 0 {
+		return "", nil
+	}
+
+	now := time.Now().In(r.bank)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 0, 1)
+	filter := &models.CardTransactionFilter{From: &from, To: &to}
+
+	var spending *models.CardSpending
+	var err error
+	if tx != nil {
+		spending, err = r.repos.Transaction.GetCardSpendingTx(ctx, tx, card.ID, filter)
+	} else {
+		spending, err = r.repos.Transaction.GetCardSpending(ctx, card.ID, filter)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if spending.TotalSpent+amount > product.Limits.Daily {
+		return fmt.Sprintf("amount exceeds the card's daily limit, %.2f left today", math.Max(product.Limits.Daily-spending.TotalSpent, 0)), nil
+	}
+
+	return "", nil
+}
+
+// chargeFee debits a fee for the card from its account as a completed FEE transaction
+func (r *cardRules) chargeFee(ctx context.Context, account *models.Account, card *models.Card, amount float64, description string) (err error) {
+	tx, err := r.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = r.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -amount); err != nil {
+		return fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	fee := &models.Transaction{
+		TransactionType: models.TransactionTypeFee,
+		SourceAccountID: &account.ID,
+		Amount:          amount,
+		Currency:        account.Currency,
+		Description:     description,
+		Status:          models.TransactionStatusCompleted,
+		CardID:          &card.ID,
+		TransactionDate: time.Now(),
+	}
+
+	if _, err = r.repos.Transaction.CreateTx(ctx, tx, fee); err != nil {
+		return fmt.Errorf("failed to create fee transaction: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.Infof("Fee of %.2f %s charged for card %d: %s", amount, account.Currency, card.ID, description)
+
+	return nil
+}
+
+// payCashback credits the cashback a completed payment earns under the card's product to the
+// payment's account as a completed BONUS transaction, up to what is left of the monthly cap. It
+// runs within the payment's transaction, after the debit locked the account row, so concurrent
+// payments with the card cannot both be paid cashback under the cap.
+func (r *cardRules) payCashback(ctx context.Context, tx *sql.Tx, card *models.Card, product *models.CardProduct, payment *models.Transaction) error {
+	percent := product.Cashback.Percent(categorizeTransaction(payment))
+	amount := math.Round(payment.Amount*percent) / 100
+	if amount <= 0 {
+		return nil
+	}
+
+	if product.Cashback.MonthlyCap > 0 {
+		from, to := monthBounds(time.Now().In(r.bank))
+		earned, err := r.repos.Transaction.GetCardCashbackTx(ctx, tx, card.ID, &models.CardTransactionFilter{From: &from, To: &to})
+		if err != nil {
+			return fmt.Errorf("failed to get card cashback: %w", err)
+		}
+
+		amount = math.Min(amount, math.Round((product.Cashback.MonthlyCap-earned)*100)/100)
+		if amount <= 0 {
+			return nil
+		}
+	}
+
+	if err := r.repos.Account.UpdateBalanceTx(ctx, tx, card.AccountID, amount); err != nil {
+		return fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	cashback := &models.Transaction{
+		TransactionType:      models.TransactionTypeBonus,
+		DestinationAccountID: &card.AccountID,
+		Amount:               amount,
+		Currency:             payment.Currency,
+		Description:          fmt.Sprintf("Cashback %.4g%% for payment %d", percent, payment.ID),
+		Status:               models.TransactionStatusCompleted,
+		CardID:               &card.ID,
+		TransactionDate:      time.Now(),
+	}
+
+	if _, err := r.repos.Transaction.CreateTx(ctx, tx, cashback); err != nil {
+		return fmt.Errorf("failed to create cashback transaction: %w", err)
+	}
+
+	r.logger.Infof("Cashback of %.2f credited for payment %d with card %d", amount, payment.ID, card.ID)
+
+	return nil
+}
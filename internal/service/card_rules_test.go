@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+func TestCardLimitsCountPaymentsMadeWithinTheDebitTransaction(t *testing.T) {
+	ctx := context.Background()
+	deps := newTestDependencies()
+	rules := newCardRules(deps)
+
+	userID := createTestUser(t, ctx, deps.Repos, "shopper")
+	account := createTestAccount(t, ctx, deps.Repos, userID, 1000)
+	product := &models.CardProduct{
+		Code:     "DAILY",
+		Limits:   models.CardLimits{Daily: 100},
+		Cashback: models.CardCashback{Rules: []models.CashbackRule{{Percent: 10}}, MonthlyCap: 5},
+	}
+	if _, err := deps.Repos.CardProduct.Create(ctx, product); err != nil {
+		t.Fatalf("failed to create card product: %v", err)
+	}
+
+	card := &models.Card{AccountID: account.ID, ProductID: product.ID, CardNumberHMAC: "card"}
+	cardID, err := deps.Repos.Card.Create(ctx, card)
+	if err != nil {
+		t.Fatalf("failed to create card: %v", err)
+	}
+	card.ID = cardID
+
+	tx, err := deps.Repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// A payment committed by a concurrent request leaves 40 of the daily limit
+	payment := &models.Transaction{
+		TransactionType: models.TransactionTypePayment,
+		SourceAccountID: &account.ID,
+		Amount:          60,
+		Currency:        account.Currency,
+		Description:     "shop",
+		Status:          models.TransactionStatusCompleted,
+		CardID:          &card.ID,
+		TransactionDate: time.Now(),
+	}
+	if payment.ID, err = deps.Repos.Transaction.CreateTx(ctx, tx, payment); err != nil {
+		t.Fatalf("failed to create payment: %v", err)
+	}
+
+	exceeded, err := rules.checkLimits(ctx, tx, card, product, 50, false)
+	if err != nil {
+		t.Fatalf("failed to check limits: %v", err)
+	}
+	if exceeded == "" {
+		t.Error("expected the payment to exceed the daily limit")
+	}
+
+	// Cashback of 6 is capped at 5, and nothing is left for the next payment
+	for i := 0; i < 2; i++ {
+		if err := rules.payCashback(ctx, tx, card, product, payment); err != nil {
+			t.Fatalf("failed to pay cashback: %v", err)
+		}
+	}
+
+	earned, err := deps.Repos.Transaction.GetCardCashbackTx(ctx, tx, card.ID, &models.CardTransactionFilter{})
+	if err != nil {
+		t.Fatalf("failed to get cashback: %v", err)
+	}
+	if earned != 5 {
+		t.Errorf("expected cashback of 5, got %.2f", earned)
+	}
+}
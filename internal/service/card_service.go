@@ -2,8 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"database/sql"
 	"errors"
 	"fmt"
+	"math/big"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -11,6 +15,8 @@ import (
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
 	"banking-service/pkg/crypto"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
 )
 
 // CardSvc is an implementation of the service.CardService interface
@@ -20,7 +26,11 @@ type CardSvc struct {
 	config     *configs.Config
 	pgp        *crypto.PGPCrypto
 	hmac       *crypto.HMACSigner
-	hasher     *crypto.PasswordHasher
+	hasher     *crypto.Argon2Hasher
+	declines   *declines
+	rules      *cardRules
+	onboarding OnboardingService
+	lifecycle  *lifecycle.Manager
 }
 
 // NewCardService creates a new CardSvc
@@ -43,7 +53,11 @@ func NewCardService(deps Dependencies) *CardSvc {
 		config:     deps.Config,
 		pgp:        pgpCrypto,
 		hmac:       hmacSigner,
-		hasher:     crypto.NewPasswordHasher(),
+		hasher:     newPasswordHasher(deps.Config.Password),
+		declines:   newDeclines(deps),
+		rules:      newCardRules(deps),
+		onboarding: NewOnboardingService(deps),
+		lifecycle:  deps.Lifecycle,
 	}
 }
 
@@ -60,8 +74,8 @@ func (s *CardSvc) Create(ctx context.Context, cardCreate *models.CardCreate, use
 		return 0, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return 0, errors.New("access denied: account belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return 0, err
 	}
 	
 	// Check if account is active
@@ -69,8 +83,34 @@ func (s *CardSvc) Create(ctx context.Context, cardCreate *models.CardCreate, use
 		return 0, errors.New("account is inactive")
 	}
 	
-	// Convert CardCreate to Card and generate card details
-	card := cardCreate.ToCard()
+	// When onboarding is required, cards need a verified email and a complete profile
+	if err := s.onboarding.Require(ctx, userID, models.OnboardingStageFirstCard); err != nil {
+		return 0, err
+	}
+	
+	// The product must be issued and allow cards on accounts of this type
+	product, err := s.repos.CardProduct.GetByID(ctx, cardCreate.ProductID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get card product: %w", err)
+	}
+	
+	if !product.IsActive {
+		return 0, errors.New("card product is no longer issued")
+	}
+	
+	if !product.AllowsAccountType(account.AccountType) {
+		return 0, i18n.Errorf("cards_cannot_be_linked_to_accounts", product.Code, string(account.AccountType))
+	}
+	
+	if product.Fees.Issue > 0 && account.AvailableBalance < product.Fees.Issue {
+		return 0, errors.New("insufficient funds for the card issue fee")
+	}
+	
+	// Convert CardCreate to Card and generate card details with a number no other card has
+	card, err := s.newCard(ctx, cardCreate, product)
+	if err != nil {
+		return 0, err
+	}
 	
 	// Encrypt card number
 	encryptedCardNumber, err := s.pgp.Encrypt(card.CardNumber)
@@ -79,10 +119,6 @@ func (s *CardSvc) Create(ctx context.Context, cardCreate *models.CardCreate, use
 	}
 	card.CardNumberEncrypted = encryptedCardNumber
 	
-	// Create HMAC of card number for validation/lookup
-	cardNumberHMAC := s.hmac.Sign(card.CardNumber)
-	card.CardNumberHMAC = cardNumberHMAC
-	
 	// Encrypt expiry date
 	encryptedExpiryDate, err := s.pgp.Encrypt(card.ExpiryDate)
 	if err != nil {
@@ -103,11 +139,60 @@ func (s *CardSvc) Create(ctx context.Context, cardCreate *models.CardCreate, use
 		return 0, fmt.Errorf("failed to create card: %w", err)
 	}
 	
-	s.logger.Infof("Card created: %d for account: %d", id, cardCreate.AccountID)
+	s.logger.Infof("Card created: %d for account: %d, product: %s", id, cardCreate.AccountID, product.Code)
+	
+	if product.Fees.Issue > 0 {
+		card.ID = id
+		if err := s.rules.chargeFee(ctx, account, card, product.Fees.Issue, fmt.Sprintf("%s card issue fee", product.Name)); err != nil {
+			return 0, fmt.Errorf("failed to charge card issue fee: %w", err)
+		}
+	}
+	
+	// The first card completes onboarding
+	s.lifecycle.Background("onboarding-progress", func(ctx context.Context) error {
+		if _, err := s.onboarding.Advance(ctx, account.UserID); err != nil {
+			return fmt.Errorf("failed to advance onboarding: %w", err)
+		}
+		return nil
+	})
 	
 	return id, nil
 }
 
+// newCard generates the details of a new card of the product in one of its BINs. The HMAC of the
+// number, which is unique across cards, tells whether another card already has the number.
+func (s *CardSvc) newCard(ctx context.Context, cardCreate *models.CardCreate, product *models.CardProduct) (*models.Card, error) {
+	bins := product.BINs
+	if len(bins) == 0 {
+		return nil, fmt.Errorf("card product %s has no BINs", product.Code)
+	}
+	
+	for attempt := 0; attempt < models.MaxCardNumberAttempts; attempt++ {
+		bin, err := rand.Int(rand.Reader, big.NewInt(int64(len(bins))))
+		if err != nil {
+			return nil, fmt.Errorf("failed to pick BIN: %w", err)
+		}
+		
+		card, err := cardCreate.ToCard(product, bins[bin.Int64()])
+		if err != nil {
+			return nil, err
+		}
+		
+		// Create HMAC of card number for validation/lookup
+		card.CardNumberHMAC = s.hmac.Sign(card.CardNumber)
+		
+		_, err = s.repos.Card.GetByNumberHMAC(ctx, card.CardNumberHMAC)
+		if errors.Is(err, sql.ErrNoRows) {
+			return card, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check card number: %w", err)
+		}
+	}
+	
+	return nil, errors.New("failed to generate a card number no other card has")
+}
+
 // GetByID gets a card by ID and verifies ownership
 func (s *CardSvc) GetByID(ctx context.Context, id int, userID int) (*models.CardResponse, error) {
 	// Get the card
@@ -122,8 +207,8 @@ func (s *CardSvc) GetByID(ctx context.Context, id int, userID int) (*models.Card
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return nil, errors.New("access denied: card belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
 	}
 	
 	// Decrypt card number
@@ -189,8 +274,8 @@ func (s *CardSvc) GetByAccountID(ctx context.Context, accountID int, userID int)
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return nil, errors.New("access denied: account belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
 	}
 	
 	// Get all cards for the account
@@ -240,8 +325,8 @@ func (s *CardSvc) Update(ctx context.Context, card *models.Card, userID int) err
 		return fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return errors.New("access denied: card belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return err
 	}
 	
 	// Only allow updating isActive status
@@ -276,8 +361,8 @@ func (s *CardSvc) Delete(ctx context.Context, id int, userID int) error {
 		return fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return errors.New("access denied: card belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return err
 	}
 	
 	// Delete the card (soft delete)
@@ -288,5 +373,262 @@ func (s *CardSvc) Delete(ctx context.Context, id int, userID int) error {
 	
 	s.logger.Infof("Card deleted (deactivated): %d", id)
 	
+	return nil
+}
+
+// SetPIN sets the PIN of a card, which also unblocks a card blocked after wrong PINs
+func (s *CardSvc) SetPIN(ctx context.Context, id int, userID int, pin *models.CardPIN) error {
+	if err := pin.ValidatePIN(); err != nil {
+		return fmt.Errorf("invalid PIN: %w", err)
+	}
+	
+	card, err := s.repos.Card.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get card: %w", err)
+	}
+	
+	account, err := s.repos.Account.GetByID(ctx, card.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return err
+	}
+	
+	if !card.IsActive {
+		return errors.New("card is inactive")
+	}
+	
+	// Hash PIN (we never need to decrypt this)
+	pinHash, err := s.hasher.HashPassword(pin.PIN)
+	if err != nil {
+		return fmt.Errorf("failed to hash PIN: %w", err)
+	}
+	
+	if err := s.repos.Card.SetPIN(ctx, card.ID, pinHash); err != nil {
+		return err
+	}
+	
+	s.logger.Infof("PIN set for card %d", card.ID)
+	
+	return nil
+}
+
+// ATMWithdraw simulates a cash withdrawal at an ATM: the card is identified by its number, its PIN
+// is checked and the amount is withdrawn from the card's account. A card is blocked at ATMs after
+// models.MaxPINAttempts wrong PINs in a row until its owner sets a new PIN.
+func (s *CardSvc) ATMWithdraw(ctx context.Context, userID int, withdrawal *models.ATMWithdrawalRequest) (int, error) {
+	if err := withdrawal.ValidateATMWithdrawalRequest(); err != nil {
+		return 0, fmt.Errorf("invalid withdrawal request: %w", err)
+	}
+	
+	card, err := s.repos.Card.GetByNumberHMAC(ctx, s.hmac.Sign(withdrawal.CardNumber))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get card: %w", err)
+	}
+	
+	// Check access before the PIN, so nobody can block another user's card with wrong PINs
+	account, err := s.repos.Account.GetByID(ctx, card.AccountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return 0, err
+	}
+	
+	request := &models.WithdrawalRequest{
+		AccountID:   card.AccountID,
+		Amount:      withdrawal.Amount,
+		Description: "ATM cash withdrawal",
+		CardID:      &card.ID,
+	}
+	
+	// The withdrawal is recorded as a failed transaction if the card is declined
+	declined := request.ToTransaction(account.Currency)
+	
+	if !card.IsActive {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonCardFrozen, "card is inactive")
+	}
+	
+	if card.CardType == models.CardTypeVirtual {
+		return 0, errors.New("virtual cards cannot be used at ATMs")
+	}
+	
+	if err := s.checkExpiry(card); err != nil {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonCardExpired, err.Error())
+	}
+	
+	if card.PINHash == "" {
+		return 0, errors.New("card has no PIN, set one first")
+	}
+	
+	if card.PINAttempts >= models.MaxPINAttempts {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonFraudBlock, "card is blocked after too many wrong PINs, set a new PIN to unblock it")
+	}
+	
+	correct := s.hasher.CheckPasswordHash(withdrawal.PIN, card.PINHash)
+	attempts, err := s.repos.Card.RecordPINAttempt(ctx, card.ID, correct)
+	if err != nil {
+		return 0, err
+	}
+	
+	if !correct {
+		s.logger.Warnf("Wrong PIN for card %d, attempt %d", card.ID, attempts)
+		if attempts >= models.MaxPINAttempts {
+			return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonFraudBlock, "wrong PIN, the card is now blocked")
+		}
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonIncorrectPIN, fmt.Sprintf("wrong PIN, %d attempts left", models.MaxPINAttempts-attempts))
+	}
+	
+	if !account.IsActive {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "account is inactive")
+	}
+	
+	if account.IsDormant() {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "account is dormant, reactivate it first")
+	}
+	
+	product, err := s.rules.product(ctx, card)
+	if err != nil {
+		return 0, err
+	}
+	
+	exceeded, err := s.rules.checkLimits(ctx, nil, card, product, withdrawal.Amount, true)
+	if err != nil {
+		return 0, err
+	}
+	if exceeded != "" {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonLimitExceeded, exceeded)
+	}
+	
+	// The ATM fee of the card's product is charged on top of the amount
+	fee := product.Fees.ATMFee(withdrawal.Amount)
+	if account.AvailableBalance < withdrawal.Amount+fee {
+		return 0, s.declines.decline(ctx, userID, declined, models.DeclineReasonInsufficientFunds, "insufficient funds")
+	}
+	
+	transactionID, err := s.withdraw(ctx, card, product, account, userID, request)
+	if err != nil {
+		return 0, err
+	}
+	
+	if fee > 0 {
+		if err := s.rules.chargeFee(ctx, account, card, fee, fmt.Sprintf("ATM withdrawal fee for transaction %d", transactionID)); err != nil {
+			s.logger.Errorf("Failed to charge ATM fee of %.2f for transaction %d: %v", fee, transactionID, err)
+		}
+	}
+	
+	return transactionID, nil
+}
+
+// withdraw debits an ATM withdrawal from the card's account as a completed transaction, as the cash
+// is handed out at once. The debit locks the account row, so the daily limit is checked again
+// against the withdrawals and payments that went through meanwhile.
+func (s *CardSvc) withdraw(ctx context.Context, card *models.Card, product *models.CardProduct, account *models.Account, userID int, request *models.WithdrawalRequest) (transactionID int, err error) {
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+	
+	if err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -request.Amount); err != nil {
+		return 0, fmt.Errorf("failed to update balance: %w", err)
+	}
+	
+	exceeded, err := s.rules.checkLimits(ctx, tx, card, product, request.Amount, true)
+	if err != nil {
+		return 0, err
+	}
+	if exceeded != "" {
+		tx.Rollback()
+		return 0, s.declines.decline(ctx, userID, request.ToTransaction(account.Currency), models.DeclineReasonLimitExceeded, exceeded)
+	}
+	
+	transaction := request.ToTransaction(account.Currency)
+	transaction.Status = models.TransactionStatusCompleted
+	
+	transactionID, err = s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+	
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	s.logger.Infof("ATM withdrawal of %f from account %d using card %d completed, transaction: %d", 
+		request.Amount, account.ID, card.ID, transactionID)
+	
+	return transactionID, nil
+}
+
+// GetTransactions gets a page of the transactions made with a card together with the card's totals
+// over all transactions the filter matches
+func (s *CardSvc) GetTransactions(ctx context.Context, id int, userID int, filter *models.CardTransactionFilter) (*models.CardTransactionPage, error) {
+	if err := filter.ValidateCardTransactionFilter(); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	
+	card, err := s.repos.Card.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card: %w", err)
+	}
+	
+	account, err := s.repos.Account.GetByID(ctx, card.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
+	}
+	
+	transactions, err := s.repos.Transaction.GetByCardID(ctx, card.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, transactions); err != nil {
+		return nil, err
+	}
+	
+	spending, err := s.repos.Transaction.GetCardSpending(ctx, card.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	spending.Currency = account.Currency
+	
+	return &models.CardTransactionPage{
+		Transactions: transactions,
+		Spending:     spending,
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	}, nil
+}
+
+// checkExpiry rejects a card past its expiry month
+func (s *CardSvc) checkExpiry(card *models.Card) error {
+	expiryDate, err := s.pgp.Decrypt(card.ExpiryDateEncrypted)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt expiry date: %w", err)
+	}
+	
+	expiry, err := time.Parse("01/06", expiryDate)
+	if err != nil {
+		return fmt.Errorf("invalid expiry date: %w", err)
+	}
+	
+	// The card is valid through the last day of its expiry month
+	if !time.Now().Before(expiry.AddDate(0, 1, 0)) {
+		return errors.New("card has expired")
+	}
+	
 	return nil
 }
\ No newline at end of file
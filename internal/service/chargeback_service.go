@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
+)
+
+// ChargebackSvc is an implementation of the service.ChargebackService interface
+type ChargebackSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewChargebackService creates a new ChargebackSvc
+func NewChargebackService(deps Dependencies) *ChargebackSvc {
+	return &ChargebackSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Create charges back a card payment to a merchant. The cardholder is credited provisionally
+// and the merchant's settlement account is debited until the chargeback is resolved.
+func (s *ChargebackSvc) Create(ctx context.Context, chargebackCreate *models.ChargebackCreate, userID int) (*models.Chargeback, error) {
+	if err := chargebackCreate.ValidateChargebackCreate(); err != nil {
+		return nil, fmt.Errorf("invalid chargeback data: %w", err)
+	}
+
+	payment, err := s.repos.Transaction.GetByID(ctx, chargebackCreate.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if payment.TransactionType != models.TransactionTypePayment || payment.CardID == nil || payment.SourceAccountID == nil {
+		return nil, errors.New("only card payments can be charged back")
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, *payment.SourceAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	// Chargebacks go through the card scheme, so only merchant payments qualify
+	intent, err := s.repos.PaymentIntent.GetByTransactionID(ctx, payment.ID)
+	if err != nil {
+		return nil, errors.New("only card payments to merchants can be charged back")
+	}
+
+	if intent.SettlementBatchID == nil {
+		return nil, errors.New("payment has not been settled to the merchant yet")
+	}
+
+	window := time.Duration(s.config.Chargeback.WindowDays) * 24 * time.Hour
+	if time.Since(payment.TransactionDate) > window {
+		return nil, i18n.Errorf("card_payments_can_only_be_charged_back_within_days", strconv.Itoa(s.config.Chargeback.WindowDays))
+	}
+
+	amount := chargebackCreate.Amount
+	if amount == 0 {
+		amount = payment.Amount
+	}
+
+	if amount > payment.Amount {
+		return nil, errors.New("chargeback amount exceeds the payment amount")
+	}
+
+	exists, err := s.repos.Chargeback.ExistsForTransaction(ctx, payment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, errors.New("payment has already been charged back")
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, intent.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	chargeback := &models.Chargeback{
+		UserID:                userID,
+		MerchantID:            merchant.ID,
+		OriginalTransactionID: payment.ID,
+		AccountID:             account.ID,
+		Amount:                amount,
+		Reason:                chargebackCreate.Reason,
+		Details:               chargebackCreate.Details,
+		Status:                models.ChargebackStatusOpen,
+		EvidenceDueAt:         time.Now().AddDate(0, 0, s.config.Chargeback.EvidenceDays),
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Provisionally credit the cardholder
+	credit := &models.Transaction{
+		TransactionType:      models.TransactionTypeChargeback,
+		DestinationAccountID: &account.ID,
+		Amount:               amount,
+		Currency:             payment.Currency,
+		Description:          fmt.Sprintf("Chargeback provisional credit for payment #%d to %s", payment.ID, merchant.Name),
+		Status:               models.TransactionStatusCompleted,
+		CardID:               payment.CardID,
+		TransactionDate:      time.Now(),
+	}
+
+	chargeback.ProvisionalCreditTxID, err = s.moveFundsTx(ctx, tx, credit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Debit the merchant's settlement account
+	debit := &models.Transaction{
+		TransactionType: models.TransactionTypeChargeback,
+		SourceAccountID: &merchant.SettlementAccountID,
+		Amount:          amount,
+		Currency:        payment.Currency,
+		Description:     fmt.Sprintf("Chargeback of payment %s", intent.IntentID),
+		Status:          models.TransactionStatusCompleted,
+		TransactionDate: time.Now(),
+	}
+
+	chargeback.MerchantDebitTxID, err = s.moveFundsTx(ctx, tx, debit)
+	if err != nil {
+		return nil, err
+	}
+
+	chargeback.ID, err = s.repos.Chargeback.CreateTx(ctx, tx, chargeback, intent.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Chargeback %d of %f opened by user %d for transaction %d, merchant: %d",
+		chargeback.ID, amount, userID, payment.ID, merchant.ID)
+
+	s.notify(merchant.UserID, "chargeback_opened",
+		intent.IntentID, merchant.Name, amount, payment.Currency, chargeback.Reason, chargeback.EvidenceDueAt.Format("2006-01-02 15:04"))
+
+	return s.repos.Chargeback.GetByID(ctx, chargeback.ID)
+}
+
+// GetByID gets a chargeback opened by the user
+func (s *ChargebackSvc) GetByID(ctx context.Context, id int, userID int) (*models.Chargeback, error) {
+	chargeback, err := s.repos.Chargeback.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+
+	if chargeback.UserID != userID {
+		return nil, errors.New("access denied: chargeback belongs to another user")
+	}
+
+	return chargeback, nil
+}
+
+// GetByUserID gets the chargebacks opened by the user
+func (s *ChargebackSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Chargeback, error) {
+	chargebacks, err := s.repos.Chargeback.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargebacks: %w", err)
+	}
+
+	return chargebacks, nil
+}
+
+// GetForMerchant gets the chargebacks against a merchant
+func (s *ChargebackSvc) GetForMerchant(ctx context.Context, merchantID int) ([]*models.Chargeback, error) {
+	chargebacks, err := s.repos.Chargeback.GetByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargebacks: %w", err)
+	}
+
+	return chargebacks, nil
+}
+
+// getMerchantChargeback gets a chargeback against the merchant
+func (s *ChargebackSvc) getMerchantChargeback(ctx context.Context, id int, merchantID int) (*models.Chargeback, error) {
+	chargeback, err := s.repos.Chargeback.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+
+	if chargeback.MerchantID != merchantID {
+		return nil, errors.New("chargeback not found")
+	}
+
+	return chargeback, nil
+}
+
+// SubmitEvidence records the merchant's evidence; the bank then decides the chargeback
+func (s *ChargebackSvc) SubmitEvidence(ctx context.Context, id int, merchantID int, evidence *models.ChargebackEvidence) error {
+	if err := evidence.ValidateChargebackEvidence(); err != nil {
+		return fmt.Errorf("invalid evidence: %w", err)
+	}
+
+	chargeback, err := s.getMerchantChargeback(ctx, id, merchantID)
+	if err != nil {
+		return err
+	}
+
+	submitted, err := s.repos.Chargeback.SubmitEvidence(ctx, id, evidence.Evidence)
+	if err != nil {
+		return err
+	}
+
+	if !submitted {
+		if chargeback.Status != models.ChargebackStatusOpen {
+			return i18n.Errorf("chargeback_is_already", string(chargeback.Status))
+		}
+		return errors.New("evidence deadline has passed")
+	}
+
+	s.logger.Infof("Evidence submitted for chargeback %d by merchant: %d", id, merchantID)
+
+	s.notify(chargeback.UserID, "chargeback_under_review", chargeback.MerchantName, chargeback.ID)
+
+	return nil
+}
+
+// Accept closes an open chargeback in the cardholder's favour; the provisional credit becomes final
+func (s *ChargebackSvc) Accept(ctx context.Context, id int, merchantID int) error {
+	chargeback, err := s.getMerchantChargeback(ctx, id, merchantID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.resolve(ctx, chargeback, chargeback.Status, models.ChargebackStatusMerchantAccepted, "Accepted by merchant"); err != nil {
+		return err
+	}
+
+	s.logger.Infof("Chargeback %d accepted by merchant: %d", id, merchantID)
+
+	return nil
+}
+
+// GetAll gets the chargebacks in a status for review, all chargebacks if the status is empty
+func (s *ChargebackSvc) GetAll(ctx context.Context, status models.ChargebackStatus) ([]*models.Chargeback, error) {
+	chargebacks, err := s.repos.Chargeback.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargebacks: %w", err)
+	}
+
+	return chargebacks, nil
+}
+
+// Resolve records the bank's final decision. If the merchant wins, the provisional credit is reversed.
+func (s *ChargebackSvc) Resolve(ctx context.Context, id int, resolution *models.ChargebackResolution) (*models.Chargeback, error) {
+	status, err := resolution.ValidateChargebackResolution()
+	if err != nil {
+		return nil, fmt.Errorf("invalid resolution: %w", err)
+	}
+
+	chargeback, err := s.repos.Chargeback.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+
+	if err := s.resolve(ctx, chargeback, chargeback.Status, status, resolution.Note); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Chargeback %d resolved as %s", id, status)
+
+	return s.repos.Chargeback.GetByID(ctx, id)
+}
+
+// ExpireEvidenceDeadlines resolves open chargebacks in the cardholder's favour once the merchant
+// has missed the evidence deadline
+func (s *ChargebackSvc) ExpireEvidenceDeadlines(ctx context.Context) error {
+	chargebacks, err := s.repos.Chargeback.GetEvidenceOverdue(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get overdue chargebacks: %w", err)
+	}
+
+	s.logger.Infof("Found %d chargebacks past the evidence deadline", len(chargebacks))
+
+	for _, chargeback := range chargebacks {
+		err := s.resolve(ctx, chargeback, models.ChargebackStatusOpen, models.ChargebackStatusCardholderWon,
+			"Merchant did not submit evidence in time")
+		if err != nil {
+			s.logger.Warnf("Failed to resolve chargeback %d: %v", chargeback.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// resolve moves a pending chargeback to a final status, reversing the money if the merchant won,
+// and notifies both parties
+func (s *ChargebackSvc) resolve(ctx context.Context, chargeback *models.Chargeback, from, to models.ChargebackStatus, note string) error {
+	if from.IsFinal() {
+		return i18n.Errorf("chargeback_is_already", string(from))
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, chargeback.MerchantID)
+	if err != nil {
+		return fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// The merchant gets the money back from the cardholder
+	var reversalTxID *int
+	if to == models.ChargebackStatusMerchantWon {
+		reversal := &models.Transaction{
+			TransactionType:      models.TransactionTypeChargeback,
+			SourceAccountID:      &chargeback.AccountID,
+			DestinationAccountID: &merchant.SettlementAccountID,
+			Amount:               chargeback.Amount,
+			Currency:             models.CurrencyRUB,
+			Description:          fmt.Sprintf("Chargeback #%d reversed in favour of %s", chargeback.ID, merchant.Name),
+			Status:               models.TransactionStatusCompleted,
+			TransactionDate:      time.Now(),
+		}
+
+		reversalTxID, err = s.moveFundsTx(ctx, tx, reversal)
+		if err != nil {
+			return err
+		}
+	}
+
+	resolved, err := s.repos.Chargeback.ResolveTx(ctx, tx, chargeback.ID, from, to, note, reversalTxID)
+	if err != nil {
+		return err
+	}
+
+	if !resolved {
+		err = errors.New("chargeback has already been resolved")
+		return err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.notify(chargeback.UserID, "chargeback_resolved", chargeback.ID, chargeback.Amount, chargeback.PaymentIntentID, merchant.Name, to)
+	s.notify(merchant.UserID, "chargeback_resolved", chargeback.ID, chargeback.Amount, chargeback.PaymentIntentID, merchant.Name, to)
+
+	return nil
+}
+
+// moveFundsTx applies a transaction to the balances of its accounts and records it
+func (s *ChargebackSvc) moveFundsTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (*int, error) {
+	if transaction.SourceAccountID != nil {
+		if err := s.repos.Account.UpdateBalanceTx(ctx, tx, *transaction.SourceAccountID, -transaction.Amount); err != nil {
+			return nil, fmt.Errorf("failed to update account %d balance: %w", *transaction.SourceAccountID, err)
+		}
+	}
+
+	if transaction.DestinationAccountID != nil {
+		if err := s.repos.Account.UpdateBalanceTx(ctx, tx, *transaction.DestinationAccountID, transaction.Amount); err != nil {
+			return nil, fmt.Errorf("failed to update account %d balance: %w", *transaction.DestinationAccountID, err)
+		}
+	}
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	return &transactionID, nil
+}
+
+// notify sends an in-app chargeback notification in the background
+func (s *ChargebackSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("chargeback-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeChargeback, template, args...); err != nil {
+			return fmt.Errorf("failed to send chargeback notification: %w", err)
+		}
+		return nil
+	})
+}
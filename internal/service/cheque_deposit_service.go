@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/antivirus"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/ocr"
+	"banking-service/pkg/storage"
+)
+
+// allowedChequeImageTypes lists the content types accepted for cheque and payment order images
+var allowedChequeImageTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// ChequeDepositSvc is an implementation of the service.ChequeDepositService interface
+type ChequeDepositSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	storage       storage.Storage
+	scanner       antivirus.Scanner
+	ocr           ocr.Reader
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewChequeDepositService creates a new ChequeDepositSvc
+func NewChequeDepositService(deps Dependencies) *ChequeDepositSvc {
+	return &ChequeDepositSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		storage:       deps.Storage,
+		scanner:       deps.Scanner,
+		ocr:           deps.OCR,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Submit deposits a cheque or payment order by its image. The amount is credited to the account
+// by a pending deposit and held until an admin clears the deposit, so it cannot be spent before.
+func (s *ChequeDepositSvc) Submit(ctx context.Context, deposit *models.ChequeDeposit, content []byte, userID int) (*models.ChequeDeposit, error) {
+	deposit.Size = len(content)
+	if err := deposit.ValidateChequeDeposit(); err != nil {
+		return nil, fmt.Errorf("invalid cheque deposit: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, deposit.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	// The client's content type is not trusted; the stored type is sniffed from the content
+	deposit.ContentType = http.DetectContentType(content)
+	if !allowedChequeImageTypes[deposit.ContentType] {
+		return nil, errors.New("cheque image must be a PDF, JPEG or PNG file")
+	}
+
+	deposit.ScanStatus = models.ScanStatusNotScanned
+	if s.scanner != nil {
+		if err := s.scanner.Scan(ctx, content); err != nil {
+			if errors.Is(err, antivirus.ErrInfected) {
+				s.logger.Warnf("Infected cheque image rejected for account %d: %v", account.ID, err)
+				return nil, errors.New("document was rejected by the virus scan")
+			}
+			s.logger.Errorf("Failed to scan cheque image for account %d: %v", account.ID, err)
+			return nil, errors.New("virus scan is unavailable, please try again later")
+		}
+		deposit.ScanStatus = models.ScanStatusClean
+	}
+
+	// The amount entered by the user wins; the recognized one is kept for the reviewer to compare
+	if s.ocr != nil {
+		amount, err := s.ocr.ReadAmount(ctx, content)
+		switch {
+		case err == nil:
+			deposit.RecognizedAmount = &amount
+		case errors.Is(err, ocr.ErrUnreadable):
+			s.logger.Infof("No amount recognized on cheque image for account %d", account.ID)
+		default:
+			s.logger.Errorf("Failed to recognize cheque amount for account %d: %v", account.ID, err)
+		}
+	}
+
+	if deposit.Amount == 0 {
+		if deposit.RecognizedAmount == nil {
+			return nil, errors.New("amount could not be recognized on the image, please enter it")
+		}
+		deposit.Amount = *deposit.RecognizedAmount
+		if err := deposit.ValidateChequeDeposit(); err != nil {
+			return nil, fmt.Errorf("invalid cheque deposit: %w", err)
+		}
+	}
+
+	if deposit.AmountMismatch() {
+		s.logger.Warnf("Cheque amount %f entered for account %d differs from the recognized %f",
+			deposit.Amount, account.ID, *deposit.RecognizedAmount)
+	}
+
+	if deposit.Amount > s.config.Cheque.MaxAmount {
+		return nil, errors.New("cheque amount exceeds the deposit limit")
+	}
+
+	name, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+
+	deposit.UserID = userID
+	deposit.Currency = account.Currency
+	deposit.Status = models.ChequeDepositStatusPending
+	deposit.StorageKey = fmt.Sprintf("cheque-deposits/%d/%s", account.ID, name)
+
+	if err := s.storage.Put(ctx, deposit.StorageKey, content, deposit.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store cheque image: %w", err)
+	}
+
+	if err := s.create(ctx, deposit); err != nil {
+		// Do not leave an object behind that nothing refers to
+		if delErr := s.storage.Delete(ctx, deposit.StorageKey); delErr != nil {
+			s.logger.Errorf("Failed to delete orphaned cheque image %s: %v", deposit.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	s.logger.Infof("Cheque deposit %d of %f %s to account %d submitted by user %d, transaction: %d",
+		deposit.ID, deposit.Amount, deposit.Currency, account.ID, userID, deposit.TransactionID)
+
+	return s.repos.ChequeDeposit.GetByID(ctx, deposit.ID)
+}
+
+// create credits a cheque deposit with a pending transaction and holds the amount until clearing
+func (s *ChequeDepositSvc) create(ctx context.Context, deposit *models.ChequeDeposit) (err error) {
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = s.repos.Account.UpdateBalanceTx(ctx, tx, deposit.AccountID, deposit.Amount); err != nil {
+		return fmt.Errorf("failed to update balance: %w", err)
+	}
+
+	transaction := &models.Transaction{
+		TransactionType:      models.TransactionTypeDeposit,
+		DestinationAccountID: &deposit.AccountID,
+		Amount:               deposit.Amount,
+		Currency:             deposit.Currency,
+		Description:          fmt.Sprintf("Cheque deposit: %s", deposit.FileName),
+		Status:               models.TransactionStatusPending,
+		TransactionDate:      time.Now(),
+	}
+
+	deposit.TransactionID, err = s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	if _, err = s.repos.ChequeDeposit.CreateTx(ctx, tx, deposit); err != nil {
+		return err
+	}
+
+	hold := &models.AccountHold{
+		AccountID:   deposit.AccountID,
+		Amount:      deposit.Amount,
+		Reason:      models.HoldReasonChequeDeposit,
+		ReferenceID: deposit.ID,
+	}
+
+	if _, err = s.repos.AccountHold.CreateTx(ctx, tx, hold); err != nil {
+		return fmt.Errorf("failed to hold cheque amount: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID gets one of the user's cheque deposits
+func (s *ChequeDepositSvc) GetByID(ctx context.Context, id int, userID int) (*models.ChequeDeposit, error) {
+	deposit, err := s.repos.ChequeDeposit.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deposit.UserID != userID {
+		return nil, errors.New("access denied: cheque deposit belongs to another user")
+	}
+
+	return deposit, nil
+}
+
+// GetByUserID gets the user's cheque deposits
+func (s *ChequeDepositSvc) GetByUserID(ctx context.Context, userID int) ([]*models.ChequeDeposit, error) {
+	deposits, err := s.repos.ChequeDeposit.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cheque deposits: %w", err)
+	}
+
+	return deposits, nil
+}
+
+// GetAll gets the cheque deposits of all users, optionally filtered by status
+func (s *ChequeDepositSvc) GetAll(ctx context.Context, status models.ChequeDepositStatus) ([]*models.ChequeDeposit, error) {
+	deposits, err := s.repos.ChequeDeposit.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cheque deposits: %w", err)
+	}
+
+	return deposits, nil
+}
+
+// GetImage gets any cheque deposit together with its image for review
+func (s *ChequeDepositSvc) GetImage(ctx context.Context, id int) (*models.ChequeDeposit, []byte, error) {
+	deposit, err := s.repos.ChequeDeposit.GetByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.storage.Get(ctx, deposit.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read cheque image %d: %w", deposit.ID, err)
+	}
+
+	return deposit, content, nil
+}
+
+// Approve clears a pending cheque deposit: the deposit is completed and its amount becomes available
+func (s *ChequeDepositSvc) Approve(ctx context.Context, id int, decision *models.ChequeDepositDecision, adminID int) (*models.ChequeDeposit, error) {
+	if err := decision.ValidateChequeDepositDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	deposit, err := s.review(ctx, id, models.ChequeDepositStatusCleared, decision, adminID,
+		func(tx *sql.Tx, deposit *models.ChequeDeposit) error {
+			if _, err := s.repos.Transaction.CompleteTx(ctx, tx, deposit.TransactionID); err != nil {
+				return err
+			}
+
+			if _, err := s.repos.AccountHold.ReleaseTx(ctx, tx, models.HoldReasonChequeDeposit, deposit.ID, models.HoldStatusReleased); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Cheque deposit %d of %f %s cleared by admin %d", deposit.ID, deposit.Amount, deposit.Currency, adminID)
+
+	s.notify(deposit.UserID, "cheque_deposit_cleared", deposit.ID, deposit.Amount, deposit.Currency)
+
+	return deposit, nil
+}
+
+// Reject rejects a pending cheque deposit: the held amount is debited again and the deposit is cancelled
+func (s *ChequeDepositSvc) Reject(ctx context.Context, id int, decision *models.ChequeDepositDecision, adminID int) (*models.ChequeDeposit, error) {
+	if err := decision.ValidateChequeDepositDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	if decision.Note == "" {
+		return nil, errors.New("a note is required to reject a cheque deposit")
+	}
+
+	deposit, err := s.review(ctx, id, models.ChequeDepositStatusRejected, decision, adminID,
+		func(tx *sql.Tx, deposit *models.ChequeDeposit) error {
+			if _, err := s.repos.AccountHold.ReleaseTx(ctx, tx, models.HoldReasonChequeDeposit, deposit.ID, models.HoldStatusCaptured); err != nil {
+				return err
+			}
+
+			if err := s.repos.Account.UpdateBalanceTx(ctx, tx, deposit.AccountID, -deposit.Amount); err != nil {
+				return fmt.Errorf("failed to update balance: %w", err)
+			}
+
+			transaction, err := s.repos.Transaction.GetByID(ctx, deposit.TransactionID)
+			if err != nil {
+				return err
+			}
+
+			transaction.Status = models.TransactionStatusCancelled
+
+			return s.repos.Transaction.UpdateTx(ctx, tx, transaction)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Cheque deposit %d of %f %s rejected by admin %d", deposit.ID, deposit.Amount, deposit.Currency, adminID)
+
+	s.notify(deposit.UserID, "cheque_deposit_rejected", deposit.ID, deposit.Amount, deposit.Currency, decision.Note)
+
+	return deposit, nil
+}
+
+// review records an admin's decision on a pending cheque deposit and applies it to the ledger in
+// the same transaction
+func (s *ChequeDepositSvc) review(ctx context.Context, id int, to models.ChequeDepositStatus, decision *models.ChequeDepositDecision,
+	adminID int, apply func(tx *sql.Tx, deposit *models.ChequeDeposit) error) (*models.ChequeDeposit, error) {
+	deposit, err := s.repos.ChequeDeposit.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deposit.Status != models.ChequeDepositStatusPending {
+		return nil, errors.New("cheque deposit has already been reviewed")
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	reviewed, err := s.repos.ChequeDeposit.ReviewTx(ctx, tx, id, to, adminID, decision.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	if !reviewed {
+		err = errors.New("cheque deposit has already been reviewed")
+		return nil, err
+	}
+
+	if err = apply(tx, deposit); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.repos.ChequeDeposit.GetByID(ctx, id)
+}
+
+// notify sends an in-app cheque deposit notification in the background
+func (s *ChequeDepositSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("cheque-deposit-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeAccount, template, args...); err != nil {
+			return fmt.Errorf("failed to send cheque deposit notification: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
+)
+
+// CreditAdjustmentSvc is an implementation of the service.CreditAdjustmentService interface
+type CreditAdjustmentSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	live          *configs.Live
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	bank          *time.Location
+}
+
+// NewCreditAdjustmentService creates a new CreditAdjustmentSvc
+func NewCreditAdjustmentService(deps Dependencies) *CreditAdjustmentSvc {
+	return &CreditAdjustmentSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		live:          deps.Live,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		bank:          deps.Bank,
+	}
+}
+
+// Get gets any credit with its payment schedule and adjustment history
+func (s *CreditAdjustmentSvc) Get(ctx context.Context, creditID int) (*models.CreditAdjustments, error) {
+	credit, schedule, err := s.load(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments, err := s.repos.CreditAdjustment.GetByCreditID(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.CreditAdjustments{Credit: credit, Schedule: schedule, Adjustments: adjustments}, nil
+}
+
+// WaivePenalty waives all or part of the penalty of a schedule item
+func (s *CreditAdjustmentSvc) WaivePenalty(ctx context.Context, creditID int, paymentID int, request *models.PenaltyWaiverRequest, adminID int) (*models.CreditAdjustment, error) {
+	if err := request.ValidatePenaltyWaiverRequest(); err != nil {
+		return nil, fmt.Errorf("invalid penalty waiver: %w", err)
+	}
+
+	credit, schedule, err := s.loadOpen(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := unpaidPayment(schedule, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.PenaltyAmount <= 0 {
+		return nil, errors.New("payment has no penalty")
+	}
+
+	amount := request.Amount
+	if amount == 0 {
+		amount = payment.PenaltyAmount
+	}
+	if amount > payment.PenaltyAmount {
+		return nil, i18n.Errorf("amount_exceeds_the_penalty_of", strconv.FormatFloat(payment.PenaltyAmount, 'f', 2, 64))
+	}
+
+	previous := payment.PenaltyAmount
+	payment.PenaltyAmount = math.Round((previous-amount)*100) / 100
+
+	adjustment := &models.CreditAdjustment{
+		CreditID:  creditID,
+		PaymentID: &payment.ID,
+		AdminID:   adminID,
+		Type:      models.CreditAdjustmentTypePenaltyWaiver,
+		Reason:    request.Reason,
+		Note:      request.Note,
+		Details: fmt.Sprintf("penalty of the payment due %s reduced from %.2f to %.2f",
+			payment.PaymentDate.Format("2006-01-02"), previous, payment.PenaltyAmount),
+	}
+
+	err = s.apply(ctx, credit, schedule, adjustment, func(tx *sql.Tx) error {
+		return s.repos.PaymentSchedule.UpdateBatchTx(ctx, tx, []*models.PaymentSchedule{payment})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(credit.UserID, "credit_penalty_waived",
+		amount, creditID, payment.PaymentDate.Format("02.01.2006"))
+
+	return adjustment, nil
+}
+
+// Reschedule moves an unpaid schedule item to a later date before the next installment. An
+// overdue item becomes pending again; its penalty has to be waived first.
+func (s *CreditAdjustmentSvc) Reschedule(ctx context.Context, creditID int, paymentID int, request *models.RescheduleRequest, adminID int) (*models.CreditAdjustment, error) {
+	if err := request.ValidateRescheduleRequest(s.bank); err != nil {
+		return nil, fmt.Errorf("invalid reschedule: %w", err)
+	}
+
+	credit, schedule, err := s.loadOpen(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := unpaidPayment(schedule, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.PenaltyAmount > 0 {
+		return nil, errors.New("payment has a penalty, waive it first")
+	}
+
+	now := time.Now().In(s.bank)
+	if !request.Date.After(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())) {
+		return nil, errors.New("payment_date must be in the future")
+	}
+
+	if !request.Date.After(payment.PaymentDate) {
+		return nil, errors.New("payment_date must be after the current payment date")
+	}
+
+	// The item keeps its place in the schedule
+	for _, next := range schedule {
+		if isUnpaid(next) && next.PaymentDate.After(payment.PaymentDate) && !request.Date.Before(next.PaymentDate) {
+			return nil, i18n.Errorf("payment_date_must_be_before_the_next_payment_on", next.PaymentDate.Format("2006-01-02"))
+		}
+	}
+
+	previous := payment.PaymentDate
+	payment.PaymentDate = request.Date
+	payment.Status = models.PaymentStatusPending
+	payment.IsOverdue = false
+
+	adjustment := &models.CreditAdjustment{
+		CreditID:  creditID,
+		PaymentID: &payment.ID,
+		AdminID:   adminID,
+		Type:      models.CreditAdjustmentTypeReschedule,
+		Reason:    request.Reason,
+		Note:      request.Note,
+		Details: fmt.Sprintf("payment of %.2f moved from %s to %s",
+			payment.TotalAmount, previous.Format("2006-01-02"), request.Date.Format("2006-01-02")),
+	}
+
+	err = s.apply(ctx, credit, schedule, adjustment, func(tx *sql.Tx) error {
+		if err := s.repos.PaymentSchedule.RescheduleTx(ctx, tx, payment); err != nil {
+			return err
+		}
+		// The funds reserved for the old date are freed; a new hold is placed closer to the new one
+		_, err := s.repos.AccountHold.ReleaseTx(ctx, tx, models.HoldReasonCreditPayment, payment.ID, models.HoldStatusReleased)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.resetReminders(ctx, []int{payment.ID})
+
+	s.notify(credit.UserID, "credit_payment_rescheduled",
+		payment.TotalAmount, creditID, previous.Format("02.01.2006"), request.Date.Format("02.01.2006"))
+
+	return adjustment, nil
+}
+
+// Restructure replaces the unpaid schedule items of a credit with a new annuity schedule over the
+// given number of months. The unpaid principal and the interest of installments already due are
+// spread at the credit's interest rate; penalties have to be waived first.
+func (s *CreditAdjustmentSvc) Restructure(ctx context.Context, creditID int, request *models.RestructureRequest, adminID int) (*models.CreditAdjustment, error) {
+	if err := request.ValidateRestructureRequest(); err != nil {
+		return nil, fmt.Errorf("invalid restructuring: %w", err)
+	}
+
+	credit, schedule, err := s.loadOpen(ctx, creditID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(s.bank)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var unpaid []*models.PaymentSchedule
+	var unpaidIDs []int
+	var principal float64
+	var first time.Time
+	paid := 0
+	for _, payment := range schedule {
+		if payment.Status == models.PaymentStatusPaid {
+			paid++
+		}
+		if !isUnpaid(payment) {
+			continue
+		}
+
+		if payment.PenaltyAmount > 0 {
+			return nil, errors.New("credit has payments with penalties, waive them first")
+		}
+
+		principal += payment.PrincipalAmount
+		if payment.PaymentDate.After(today) {
+			if first.IsZero() {
+				first = payment.PaymentDate
+			}
+		} else {
+			principal += payment.InterestAmount
+		}
+
+		unpaid = append(unpaid, payment)
+		unpaidIDs = append(unpaidIDs, payment.ID)
+	}
+
+	if len(unpaid) == 0 {
+		return nil, errors.New("credit has no unpaid payments")
+	}
+	if first.IsZero() {
+		first = today.AddDate(0, 1, 0)
+	}
+	principal = math.Round(principal*100) / 100
+
+	// The insurance premium stays part of every payment while the policy is active
+	var premium float64
+	if policy, err := s.repos.InsurancePolicy.GetByCreditID(ctx, creditID); err == nil && policy.Status == models.InsurancePolicyStatusActive {
+		premium = policy.MonthlyPremium
+	}
+
+	restructured := models.RestructureSchedule(credit, principal, request.TermMonths, first, premium)
+	calendar, roll := paymentCalendar(s.live)
+	calendar.RollSchedule(restructured, roll)
+
+	for _, payment := range unpaid {
+		payment.Status = models.PaymentStatusCancelled
+		payment.IsOverdue = false
+	}
+
+	credit.TermMonths = paid + request.TermMonths
+	credit.MonthlyPayment = models.CalculateMonthlyPayment(principal, credit.InterestRate, request.TermMonths)
+	credit.EndDate = restructured[len(restructured)-1].PaymentDate
+
+	adjustment := &models.CreditAdjustment{
+		CreditID: creditID,
+		AdminID:  adminID,
+		Type:     models.CreditAdjustmentTypeRestructure,
+		Reason:   request.Reason,
+		Note:     request.Note,
+		Details: fmt.Sprintf("%d unpaid payments replaced by %d monthly payments of %.2f from %s, principal %.2f",
+			len(unpaid), request.TermMonths, restructured[0].TotalAmount, restructured[0].PaymentDate.Format("2006-01-02"), principal),
+	}
+
+	err = s.apply(ctx, credit, append(schedule, restructured...), adjustment, func(tx *sql.Tx) error {
+		if err := s.repos.PaymentSchedule.UpdateBatchTx(ctx, tx, unpaid); err != nil {
+			return err
+		}
+		if _, err := s.repos.AccountHold.ReleaseBatchTx(ctx, tx, models.HoldReasonCreditPayment, unpaidIDs, models.HoldStatusReleased); err != nil {
+			return err
+		}
+		return s.repos.PaymentSchedule.CreateBatchTx(ctx, tx, restructured)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.resetReminders(ctx, unpaidIDs)
+
+	s.notify(credit.UserID, "credit_restructured",
+		creditID, request.TermMonths, restructured[0].TotalAmount, first.Format("02.01.2006"))
+
+	return adjustment, nil
+}
+
+// load gets a credit with its payment schedule
+func (s *CreditAdjustmentSvc) load(ctx context.Context, creditID int) (*models.Credit, []*models.PaymentSchedule, error) {
+	credit, err := s.repos.Credit.GetByID(ctx, creditID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule, err := s.repos.PaymentSchedule.GetByCreditID(ctx, creditID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return credit, schedule, nil
+}
+
+// loadOpen gets a credit that can still be adjusted with its payment schedule
+func (s *CreditAdjustmentSvc) loadOpen(ctx context.Context, creditID int) (*models.Credit, []*models.PaymentSchedule, error) {
+	credit, schedule, err := s.load(ctx, creditID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if credit.Status != models.CreditStatusActive && credit.Status != models.CreditStatusOverdue {
+		return nil, nil, i18n.Errorf("credit_is", string(credit.Status))
+	}
+
+	return credit, schedule, nil
+}
+
+// apply makes a change and records its audit entry in one database transaction. The credit is
+// active again once the change leaves no overdue payments.
+func (s *CreditAdjustmentSvc) apply(ctx context.Context, credit *models.Credit, schedule []*models.PaymentSchedule, adjustment *models.CreditAdjustment, change func(tx *sql.Tx) error) (err error) {
+	credit.Status = models.CreditStatusActive
+	for _, payment := range schedule {
+		if isUnpaid(payment) && payment.IsOverdue {
+			credit.Status = models.CreditStatusOverdue
+			break
+		}
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = change(tx); err != nil {
+		return err
+	}
+
+	if err = s.repos.Credit.UpdateTx(ctx, tx, credit); err != nil {
+		return err
+	}
+
+	if _, err = s.repos.CreditAdjustment.CreateTx(ctx, tx, adjustment); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Credit %d adjusted by %d: %s (%s), %s",
+		credit.ID, adjustment.AdminID, adjustment.Type, adjustment.Reason, adjustment.Details)
+
+	return nil
+}
+
+// resetReminders forgets the reminders sent for payments whose due date changed, so the borrower
+// is reminded of the new date
+func (s *CreditAdjustmentSvc) resetReminders(ctx context.Context, paymentIDs []int) {
+	for _, id := range paymentIDs {
+		for _, days := range paymentReminderDays {
+			if err := s.repos.PaymentSchedule.UnmarkReminded(ctx, id, days); err != nil {
+				s.logger.Warnf("Failed to reset reminders of payment %d: %v", id, err)
+			}
+		}
+	}
+}
+
+// notify tells the borrower about an adjustment in the background
+func (s *CreditAdjustmentSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("credit-adjustment-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeCredit, template, args...); err != nil {
+			return fmt.Errorf("failed to send credit adjustment notification: %w", err)
+		}
+		return nil
+	})
+}
+
+// unpaidPayment finds an unpaid item of a schedule
+func unpaidPayment(schedule []*models.PaymentSchedule, paymentID int) (*models.PaymentSchedule, error) {
+	for _, payment := range schedule {
+		if payment.ID != paymentID {
+			continue
+		}
+		if !isUnpaid(payment) {
+			return nil, i18n.Errorf("payment_is", string(payment.Status))
+		}
+		return payment, nil
+	}
+
+	return nil, fmt.Errorf("payment not found: %w", sql.ErrNoRows)
+}
+
+// isUnpaid reports whether a schedule item is still to be paid
+func isUnpaid(payment *models.PaymentSchedule) bool {
+	return payment.Status == models.PaymentStatusPending || payment.Status == models.PaymentStatusOverdue
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/lifecycle"
+)
+
+// CreditAgreementSvc is an implementation of the service.CreditAgreementService interface
+type CreditAgreementSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	live      *configs.Live
+	credits   *CreditSvc
+	email     EmailService
+	lifecycle *lifecycle.Manager
+	hasher    *crypto.Argon2Hasher
+}
+
+// NewCreditAgreementService creates a new CreditAgreementSvc
+func NewCreditAgreementService(deps Dependencies) *CreditAgreementSvc {
+	return &CreditAgreementSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		live:      deps.Live,
+		credits:   NewCreditService(deps),
+		email:     NewEmailService(deps),
+		lifecycle: deps.Lifecycle,
+		hasher:    newPasswordHasher(deps.Config.Password),
+	}
+}
+
+// GetAgreement renders the agreement of one of the user's credits with its signature, if signed
+func (s *CreditAgreementSvc) GetAgreement(ctx context.Context, creditID int, userID int) (*models.CreditAgreement, error) {
+	credit, err := s.credits.GetByID(ctx, creditID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repos.User.GetByID(ctx, credit.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, credit.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit account: %w", err)
+	}
+
+	agreement := models.NewCreditAgreement(credit, user, account)
+	agreement.Signature = credit.Signature
+
+	return agreement, nil
+}
+
+// RequestSignature sends a one-time code with the hash of the agreement to the user's email
+func (s *CreditAgreementSvc) RequestSignature(ctx context.Context, creditID int, userID int) (*models.SignatureRequest, error) {
+	agreement, err := s.GetAgreement(ctx, creditID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if agreement.Signature != nil {
+		return nil, errors.New("credit agreement is already signed")
+	}
+
+	code, err := newOTPCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing code: %w", err)
+	}
+
+	codeHash, err := s.hasher.HashPassword(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash signing code: %w", err)
+	}
+
+	requestID, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate request ID: %w", err)
+	}
+
+	request := &models.SignatureRequest{
+		RequestID:    requestID,
+		CreditID:     creditID,
+		UserID:       userID,
+		DocumentHash: agreement.Hash,
+		Channel:      models.SignatureChannelEmail,
+		CodeHash:     codeHash,
+		Status:       models.ConfirmationStatusPending,
+		ExpiresAt:    time.Now().Add(time.Duration(s.live.Credit().SignatureOTPTTL) * time.Second),
+	}
+
+	if _, err := s.repos.CreditSignature.CreateRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Signing of credit %d agreement %s requested, request %s", creditID, agreement.Hash, requestID)
+
+	// Send the code by email
+	s.lifecycle.Background("credit-signature-code", func(ctx context.Context) error {
+		if err := s.email.SendSignatureCode(ctx, userID, code, request); err != nil {
+			return fmt.Errorf("failed to send credit signature code: %w", err)
+		}
+		return nil
+	})
+
+	return request, nil
+}
+
+// ConfirmSignature verifies the one-time code and stores the signature of the agreement
+func (s *CreditAgreementSvc) ConfirmSignature(ctx context.Context, creditID int, confirm *models.SignatureConfirmRequest, userID int, client models.ClientInfo) (*models.CreditSignature, error) {
+	if err := confirm.ValidateSignatureConfirmRequest(); err != nil {
+		return nil, fmt.Errorf("invalid signature request: %w", err)
+	}
+
+	request, err := s.repos.CreditSignature.GetRequestByRequestID(ctx, confirm.RequestID)
+	if err != nil || request.UserID != userID || request.CreditID != creditID {
+		return nil, errors.New("signature request not found")
+	}
+
+	if request.Status != models.ConfirmationStatusPending {
+		return nil, errors.New("signature request is no longer pending")
+	}
+
+	if time.Now().After(request.ExpiresAt) {
+		s.failRequest(ctx, request)
+		return nil, errors.New("signing code has expired")
+	}
+
+	// Verify the code and cancel the request after too many wrong attempts
+	if !s.hasher.CheckPasswordHash(confirm.Code, request.CodeHash) {
+		attempts, err := s.repos.CreditSignature.IncrementAttempts(ctx, request.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify code: %w", err)
+		}
+
+		if attempts >= s.live.Credit().SignatureOTPMaxAttempts {
+			s.failRequest(ctx, request)
+			return nil, errors.New("too many invalid codes, signing cancelled")
+		}
+
+		return nil, errors.New("invalid signing code")
+	}
+
+	// The code only signs the agreement whose hash was sent with it
+	agreement, err := s.GetAgreement(ctx, creditID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if agreement.Hash != request.DocumentHash {
+		s.failRequest(ctx, request)
+		return nil, errors.New("credit agreement has changed, request a new code")
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Claim the request so the code cannot be used twice
+	claimed, err := s.repos.CreditSignature.UpdateRequestStatusTx(ctx, tx, request.ID,
+		models.ConfirmationStatusPending, models.ConfirmationStatusConfirmed)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		err = errors.New("signature request is no longer pending")
+		return nil, err
+	}
+
+	signature := request.ToCreditSignature(client)
+	if _, err = s.repos.CreditSignature.CreateTx(ctx, tx, signature); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Credit %d agreement %s signed by user %d from %s", creditID, signature.DocumentHash, userID, client.IPAddress)
+
+	return signature, nil
+}
+
+// failRequest cancels a pending signature request
+func (s *CreditAgreementSvc) failRequest(ctx context.Context, request *models.SignatureRequest) {
+	if _, err := s.repos.CreditSignature.UpdateRequestStatus(ctx, request.ID,
+		models.ConfirmationStatusPending, models.ConfirmationStatusFailed); err != nil {
+		s.logger.Warnf("Failed to mark signature request %d as failed: %v", request.ID, err)
+	}
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/antivirus"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/storage"
+)
+
+// allowedCreditDocumentTypes lists the content types accepted for credit documents
+var allowedCreditDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// CreditApplicationSvc is an implementation of the service.CreditApplicationService interface
+type CreditApplicationSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	live          *configs.Live
+	credits       *CreditSvc
+	storage       storage.Storage
+	scanner       antivirus.Scanner
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewCreditApplicationService creates a new CreditApplicationSvc
+func NewCreditApplicationService(deps Dependencies) *CreditApplicationSvc {
+	return &CreditApplicationSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		live:          deps.Live,
+		credits:       NewCreditService(deps),
+		storage:       deps.Storage,
+		scanner:       deps.Scanner,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Create submits a credit application for the bank's review
+func (s *CreditApplicationSvc) Create(ctx context.Context, creditReq *models.CreditRequest) (*models.CreditApplication, error) {
+	if err := creditReq.ValidateCreditRequest(); err != nil {
+		return nil, fmt.Errorf("invalid credit request: %w", err)
+	}
+
+	if creditReq.Insurance && s.live.Credit().InsuranceRate <= 0 {
+		return nil, errors.New("credit insurance is not offered at the moment")
+	}
+
+	user, err := s.repos.User.GetByID(ctx, creditReq.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	// Check that the user may take a credit before the bank reviews the documents
+	if err := user.CheckCreditEligibility(time.Now()); err != nil {
+		return nil, err
+	}
+
+	application := creditReq.ToCreditApplication()
+	if _, err := s.repos.CreditApplication.Create(ctx, application); err != nil {
+		return nil, err
+	}
+
+	application.CheckDocuments(s.live.Credit().DocumentThreshold)
+
+	s.logger.Infof("Credit application %d submitted by user %d, amount: %f", application.ID, application.UserID, application.Amount)
+
+	return application, nil
+}
+
+// GetByID gets one of the user's credit applications with its documents
+func (s *CreditApplicationSvc) GetByID(ctx context.Context, id int, userID int) (*models.CreditApplication, error) {
+	application, err := s.getUserApplication(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.load(ctx, application)
+}
+
+// GetByUserID gets the user's credit applications
+func (s *CreditApplicationSvc) GetByUserID(ctx context.Context, userID int) ([]*models.CreditApplication, error) {
+	applications, err := s.repos.CreditApplication.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit applications: %w", err)
+	}
+
+	return applications, nil
+}
+
+// UploadDocument scans and stores a document for one of the user's pending applications
+func (s *CreditApplicationSvc) UploadDocument(ctx context.Context, id int, doc *models.CreditDocument, content []byte, userID int) (*models.CreditDocument, error) {
+	application, err := s.getUserApplication(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status != models.CreditApplicationStatusPending {
+		return nil, errors.New("documents can only be added to pending applications")
+	}
+
+	doc.Size = len(content)
+	if err := doc.ValidateCreditDocument(); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	// The client's content type is not trusted; the stored type is sniffed from the content
+	doc.ContentType = http.DetectContentType(content)
+	if !allowedCreditDocumentTypes[doc.ContentType] {
+		return nil, errors.New("document must be a PDF, JPEG or PNG file")
+	}
+
+	doc.ScanStatus = models.ScanStatusNotScanned
+	if s.scanner != nil {
+		if err := s.scanner.Scan(ctx, content); err != nil {
+			if errors.Is(err, antivirus.ErrInfected) {
+				s.logger.Warnf("Infected document rejected for credit application %d: %v", id, err)
+				return nil, errors.New("document was rejected by the virus scan")
+			}
+			s.logger.Errorf("Failed to scan document for credit application %d: %v", id, err)
+			return nil, errors.New("virus scan is unavailable, please try again later")
+		}
+		doc.ScanStatus = models.ScanStatusClean
+	}
+
+	name, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+
+	doc.ApplicationID = application.ID
+	doc.UserID = userID
+	doc.Status = models.CreditDocumentStatusPending
+	doc.StorageKey = fmt.Sprintf("credit-applications/%d/%s", application.ID, name)
+
+	if err := s.storage.Put(ctx, doc.StorageKey, content, doc.ContentType); err != nil {
+		return nil, fmt.Errorf("failed to store document: %w", err)
+	}
+
+	if _, err := s.repos.CreditDocument.Create(ctx, doc); err != nil {
+		// Do not leave an object behind that nothing refers to
+		if delErr := s.storage.Delete(ctx, doc.StorageKey); delErr != nil {
+			s.logger.Errorf("Failed to delete orphaned document %s: %v", doc.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	s.logger.Infof("Document %d (%s) uploaded to credit application %d", doc.ID, doc.Type, application.ID)
+
+	return doc, nil
+}
+
+// GetDocument gets a document of one of the user's applications together with its content
+func (s *CreditApplicationSvc) GetDocument(ctx context.Context, id int, documentID int, userID int) (*models.CreditDocument, []byte, error) {
+	if _, err := s.getUserApplication(ctx, id, userID); err != nil {
+		return nil, nil, err
+	}
+
+	return s.getDocument(ctx, id, documentID)
+}
+
+// GetAll gets the credit applications of all users, optionally filtered by status
+func (s *CreditApplicationSvc) GetAll(ctx context.Context, status models.CreditApplicationStatus) ([]*models.CreditApplication, error) {
+	applications, err := s.repos.CreditApplication.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credit applications: %w", err)
+	}
+
+	return applications, nil
+}
+
+// GetForReview gets any credit application with its documents
+func (s *CreditApplicationSvc) GetForReview(ctx context.Context, id int) (*models.CreditApplication, error) {
+	application, err := s.repos.CreditApplication.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.load(ctx, application)
+}
+
+// GetDocumentForReview gets a document of any application of the request's tenant together with its content
+func (s *CreditApplicationSvc) GetDocumentForReview(ctx context.Context, id int, documentID int) (*models.CreditDocument, []byte, error) {
+	if _, err := s.repos.CreditApplication.GetByID(ctx, id); err != nil {
+		return nil, nil, err
+	}
+
+	return s.getDocument(ctx, id, documentID)
+}
+
+// ReviewDocument accepts or rejects a document of a pending application
+func (s *CreditApplicationSvc) ReviewDocument(ctx context.Context, id int, documentID int, review *models.CreditDocumentReview) (*models.CreditApplication, error) {
+	if err := review.ValidateCreditDocumentReview(); err != nil {
+		return nil, fmt.Errorf("invalid review: %w", err)
+	}
+
+	application, err := s.repos.CreditApplication.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status != models.CreditApplicationStatusPending {
+		return nil, errors.New("only documents of pending applications can be reviewed")
+	}
+
+	doc, err := s.repos.CreditDocument.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if doc.ApplicationID != application.ID {
+		return nil, errors.New("credit document not found")
+	}
+
+	if err := s.repos.CreditDocument.Review(ctx, doc.ID, review.Status, review.Note); err != nil {
+		return nil, err
+	}
+
+	if review.Status == models.CreditDocumentStatusRejected {
+		if review.Note != "" {
+			s.notify(application.UserID, "document_rejected_note", doc.FileName, application.ID, review.Note)
+		} else {
+			s.notify(application.UserID, "document_rejected", doc.FileName, application.ID)
+		}
+	}
+
+	return s.load(ctx, application)
+}
+
+// Approve issues the credit of a pending application once all required documents are accepted
+func (s *CreditApplicationSvc) Approve(ctx context.Context, id int, decision *models.CreditApplicationDecision, adminID int) (*models.CreditApplication, error) {
+	if err := decision.ValidateCreditApplicationDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	application, err := s.GetForReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.Status != models.CreditApplicationStatusPending {
+		return nil, i18n.Errorf("credit_application_is_already", string(application.Status))
+	}
+
+	if len(application.MissingDocuments) > 0 {
+		return nil, i18n.Errorf("accepted_documents_are_missing", fmt.Sprint(application.MissingDocuments))
+	}
+
+	// Claim the application first so a concurrent approval cannot issue the credit twice
+	claimed, err := s.repos.CreditApplication.UpdateStatus(ctx, id, models.CreditApplicationStatusPending, models.CreditApplicationStatusApproved, decision.Note, &adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, errors.New("credit application is no longer pending")
+	}
+
+	creditID, err := s.credits.issue(ctx, application.ToCreditRequest())
+	if err != nil {
+		if _, revertErr := s.repos.CreditApplication.UpdateStatus(ctx, id, models.CreditApplicationStatusApproved, models.CreditApplicationStatusPending, "", nil); revertErr != nil {
+			s.logger.Errorf("Failed to return credit application %d to review: %v", id, revertErr)
+		}
+		return nil, fmt.Errorf("failed to issue credit: %w", err)
+	}
+
+	if err := s.repos.CreditApplication.SetCreditID(ctx, id, creditID); err != nil {
+		s.logger.Errorf("Credit %d issued but not linked to application %d: %v", creditID, id, err)
+	}
+
+	application.Status = models.CreditApplicationStatusApproved
+	application.CreditID = &creditID
+	application.ReviewNote = decision.Note
+	application.ReviewedBy = &adminID
+
+	s.logger.Infof("Credit application %d approved by %d, credit %d issued", id, adminID, creditID)
+
+	s.notify(application.UserID, "credit_application_approved", id, creditID)
+
+	return application, nil
+}
+
+// Reject closes a pending application without issuing a credit
+func (s *CreditApplicationSvc) Reject(ctx context.Context, id int, decision *models.CreditApplicationDecision, adminID int) (*models.CreditApplication, error) {
+	if err := decision.ValidateCreditApplicationDecision(); err != nil {
+		return nil, fmt.Errorf("invalid decision: %w", err)
+	}
+
+	claimed, err := s.repos.CreditApplication.UpdateStatus(ctx, id, models.CreditApplicationStatusPending, models.CreditApplicationStatusRejected, decision.Note, &adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, errors.New("credit application not found or no longer pending")
+	}
+
+	application, err := s.GetForReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Credit application %d rejected by %d", id, adminID)
+
+	if decision.Note != "" {
+		s.notify(application.UserID, "credit_application_rejected_note", id, decision.Note)
+	} else {
+		s.notify(application.UserID, "credit_application_rejected", id)
+	}
+
+	return application, nil
+}
+
+// getUserApplication gets an application and checks that it belongs to the user
+func (s *CreditApplicationSvc) getUserApplication(ctx context.Context, id int, userID int) (*models.CreditApplication, error) {
+	application, err := s.repos.CreditApplication.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if application.UserID != userID {
+		return nil, errors.New("credit application not found")
+	}
+
+	return application, nil
+}
+
+// load attaches the documents of an application and works out which required ones are missing
+func (s *CreditApplicationSvc) load(ctx context.Context, application *models.CreditApplication) (*models.CreditApplication, error) {
+	docs, err := s.repos.CreditDocument.GetByApplicationID(ctx, application.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	application.Documents = docs
+	application.CheckDocuments(s.live.Credit().DocumentThreshold)
+
+	return application, nil
+}
+
+// getDocument gets a document of an application and reads its content from storage
+func (s *CreditApplicationSvc) getDocument(ctx context.Context, id int, documentID int) (*models.CreditDocument, []byte, error) {
+	doc, err := s.repos.CreditDocument.GetByID(ctx, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if doc.ApplicationID != id {
+		return nil, nil, errors.New("credit document not found")
+	}
+
+	content, err := s.storage.Get(ctx, doc.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read document %d: %w", doc.ID, err)
+	}
+
+	return doc, content, nil
+}
+
+// notify sends an in-app credit notification in the background
+func (s *CreditApplicationSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("credit-application-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeCredit, template, args...); err != nil {
+			return fmt.Errorf("failed to send credit application notification: %w", err)
+		}
+		return nil
+	})
+}
@@ -2,70 +2,116 @@ package service
 
 import (
 	"context"
-	"encoding/xml"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"math"
+	"strconv"
 	"time"
 
-	"github.com/beevik/etree"
 	"github.com/sirupsen/logrus"
 
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
 )
 
-// CBRResponse represents the XML response from Central Bank of Russia
-type CBRResponse struct {
-	XMLName xml.Name `xml:"envelope"`
-	Body    struct {
-		XMLName      xml.Name `xml:"Body"`
-		GetRateResp  struct {
-			XMLName xml.Name `xml:"GetCursOnDateXMLResponse"`
-			Result  struct {
-				XMLName xml.Name `xml:"GetCursOnDateXMLResult"`
-				Rates   string   `xml:",innerxml"`
-			}
-		}
-	}
-}
+// paymentBatchSize bounds what the payment scheduler loads at once: the due payments of this many
+// accounts, or this many upcoming payments to hold
+const paymentBatchSize = 500
+
+// paymentReminderDays are the days before a pending payment is due on which its reminder is sent,
+// nearest first
+var paymentReminderDays = []int{1, 3}
 
 // CreditSvc is an implementation of the service.CreditService interface
 type CreditSvc struct {
-	repos  *repository.Repository
-	logger *logrus.Logger
-	config *configs.Config
-	email  EmailService
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	live      *configs.Live
+	email     EmailService
+	rates     RateService
+	lifecycle *lifecycle.Manager
+	bank      *time.Location
 }
 
 // NewCreditService creates a new CreditSvc
 func NewCreditService(deps Dependencies) *CreditSvc {
 	return &CreditSvc{
-		repos:  deps.Repos,
-		logger: deps.Logger,
-		config: deps.Config,
-		email:  NewEmailService(deps),
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		live:      deps.Live,
+		email:     NewEmailService(deps),
+		rates:     NewRateService(deps),
+		lifecycle: deps.Lifecycle,
+		bank:      deps.Bank,
 	}
 }
 
-// Create creates a new credit
+// Create creates a new credit; large credits must go through a credit application instead
 func (s *CreditSvc) Create(ctx context.Context, creditReq *models.CreditRequest) (int, error) {
 	// Validate credit request
 	if err := creditReq.ValidateCreditRequest(); err != nil {
 		return 0, fmt.Errorf("invalid credit request: %w", err)
 	}
 	
+	if threshold := s.live.Credit().DocumentThreshold; threshold > 0 && creditReq.Amount >= threshold {
+		return 0, i18n.Errorf("credits_require_supporting_documents", strconv.FormatFloat(threshold, 'f', 2, 64))
+	}
+	
+	return s.issue(ctx, creditReq)
+}
+
+// Calculate previews a credit without issuing it: the payment, totals and schedule are computed
+// exactly as for an issued credit
+func (s *CreditSvc) Calculate(ctx context.Context, creditReq *models.CreditRequest) (*models.CreditCalculation, error) {
+	if err := creditReq.ValidateCalculationRequest(); err != nil {
+		return nil, fmt.Errorf("invalid credit request: %w", err)
+	}
+	
+	insuranceRate := s.live.Credit().InsuranceRate
+	if creditReq.Insurance && insuranceRate <= 0 {
+		return nil, errors.New("credit insurance is not offered at the moment")
+	}
+	
+	credit := creditReq.ToCredit(0, 0)
+	credit.DayCount = models.DayCountConvention(s.live.Credit().DayCount)
+	schedule := models.GeneratePaymentSchedule(credit)
+	calendar, roll := paymentCalendar(s.live)
+	calendar.RollSchedule(schedule, roll)
+	
+	var premium float64
+	if creditReq.Insurance {
+		premium = models.NewInsurancePolicy(credit, insuranceRate).MonthlyPremium
+		models.ApplyInsurance(schedule, premium)
+	}
+	
+	return models.NewCreditCalculation(credit, schedule, premium), nil
+}
+
+// issue opens the credit account, stores the credit with its schedule and pays out the loan
+func (s *CreditSvc) issue(ctx context.Context, creditReq *models.CreditRequest) (int, error) {
+	insuranceRate := s.live.Credit().InsuranceRate
+	if creditReq.Insurance && insuranceRate <= 0 {
+		return 0, errors.New("credit insurance is not offered at the moment")
+	}
+	
 	// Check if user exists
 	user, err := s.repos.User.GetByID(ctx, creditReq.UserID)
 	if err != nil {
 		return 0, fmt.Errorf("user not found: %w", err)
 	}
 	
+	// Check that the user may take a credit
+	if err := user.CheckCreditEligibility(time.Now()); err != nil {
+		return 0, err
+	}
+	
 	// Get base interest rate from Central Bank
-	baseRate, err := s.GetKeyRate(ctx)
+	baseRate, err := s.rates.GetKeyRate(ctx)
 	if err != nil {
 		s.logger.Warnf("Failed to get base interest rate: %v. Using default rate of 7%%.", err)
 		baseRate = 7.0 // Default rate if CBR API fails
@@ -86,7 +132,6 @@ func (s *CreditSvc) Create(ctx context.Context, creditReq *models.CreditRequest)
 	// Create a credit account
 	creditAccount := &models.Account{
 		UserID:        creditReq.UserID,
-		AccountNumber: models.GenerateAccountNumber(),
 		Balance:       0,
 		Currency:      models.CurrencyRUB,
 		AccountType:   models.AccountTypeCredit,
@@ -100,6 +145,7 @@ func (s *CreditSvc) Create(ctx context.Context, creditReq *models.CreditRequest)
 	
 	// Create the credit
 	credit := creditReq.ToCredit(accountID, baseRate)
+	credit.DayCount = models.DayCountConvention(s.live.Credit().DayCount)
 	
 	creditID, err := s.repos.Credit.Create(ctx, credit)
 	if err != nil {
@@ -109,6 +155,18 @@ func (s *CreditSvc) Create(ctx context.Context, creditReq *models.CreditRequest)
 	// Generate payment schedule
 	credit.ID = creditID
 	schedule := models.GeneratePaymentSchedule(credit)
+	calendar, roll := paymentCalendar(s.live)
+	calendar.RollSchedule(schedule, roll)
+	
+	// Payment protection insurance adds its premium to every payment
+	if creditReq.Insurance {
+		policy := models.NewInsurancePolicy(credit, insuranceRate)
+		if _, err = s.repos.InsurancePolicy.Create(ctx, policy); err != nil {
+			return 0, err
+		}
+		models.ApplyInsurance(schedule, policy.MonthlyPremium)
+		credit.Insurance = policy
+	}
 	
 	// Store payment schedule
 	err = s.repos.PaymentSchedule.CreateBatch(ctx, schedule)
@@ -147,13 +205,13 @@ func (s *CreditSvc) Create(ctx context.Context, creditReq *models.CreditRequest)
 		creditID, creditReq.UserID, creditReq.Amount, creditReq.TermMonths, credit.InterestRate)
 	
 	// Send email notification
-	go func() {
-		ctx := context.Background()
+	s.lifecycle.Background("credit-approval-notification", func(ctx context.Context) error {
 		err := s.email.SendCreditApproval(ctx, user.ID, credit)
 		if err != nil {
-			s.logger.Warnf("Failed to send credit approval notification: %v", err)
+			return fmt.Errorf("failed to send credit approval notification: %w", err)
 		}
-	}()
+		return nil
+	})
 	
 	return creditID, nil
 }
@@ -169,6 +227,16 @@ func (s *CreditSvc) GetByID(ctx context.Context, id int, userID int) (*models.Cr
 		return nil, errors.New("access denied: credit belongs to another user")
 	}
 	
+	// Attach the agreement signature, if the credit has been signed
+	if signature, err := s.repos.CreditSignature.GetByCreditID(ctx, id); err == nil {
+		credit.Signature = signature
+	}
+	
+	// Attach the insurance policy, if insurance was taken
+	if policy, err := s.repos.InsurancePolicy.GetByCreditID(ctx, id); err == nil {
+		credit.Insurance = policy
+	}
+	
 	return credit, nil
 }
 
@@ -197,11 +265,12 @@ func (s *CreditSvc) GetSchedule(ctx context.Context, creditID int, userID int) (
 	}
 	
 	// Check for any overdue payments and update them
+	calendar, roll := paymentCalendar(s.live)
 	updated := false
 	for _, schedule := range schedules {
 		if schedule.Status == models.PaymentStatusPending {
 			prevStatus := schedule.Status
-			models.UpdateScheduleStatus(schedule)
+			models.UpdateScheduleStatus(schedule, models.BusinessDate(calendar.Roll(schedule.PaymentDate, roll), s.bank), s.live.Credit().PenaltyRate)
 			
 			if prevStatus != schedule.Status {
 				err := s.repos.PaymentSchedule.Update(ctx, schedule)
@@ -236,201 +305,459 @@ func (s *CreditSvc) GetSchedule(ctx context.Context, creditID int, userID int) (
 	return responses, summary, nil
 }
 
-// ProcessPayments processes all pending payments that are due today
-func (s *CreditSvc) ProcessPayments(ctx context.Context) error {
-	today := time.Now()
-	s.logger.Infof("Processing payments for date: %s", today.Format("2006-01-02"))
+// CancelInsurance cancels the insurance of one of the user's credits and removes its
+// GetPayoffQuote calculates how an extra payment towards the principal would shorten the term of
+// one of the user's credits and how much interest it would save, without changing anything. The
+// prepayment is assumed to be made before the next payment, which keeps its date.
+func (s *CreditSvc) GetPayoffQuote(ctx context.Context, creditID int, userID int, extraPayment float64) (*models.PayoffQuote, error) {
+	if err := models.ValidateExtraPayment(extraPayment); err != nil {
+		return nil, err
+	}
 	
-	// Get all pending payments due today or earlier
-	pendingPayments, err := s.repos.PaymentSchedule.GetPendingPayments(ctx, today)
+	credit, err := s.GetByID(ctx, creditID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get pending payments: %w", err)
+		return nil, err
 	}
 	
-	s.logger.Infof("Found %d pending payments to process", len(pendingPayments))
+	if credit.Status != models.CreditStatusActive {
+		return nil, i18n.Errorf("credit_is_only_active_credits_can_be_repaid_early", string(credit.Status))
+	}
 	
-	for _, payment := range pendingPayments {
-		// Get the credit for this payment
-		credit, err := s.repos.Credit.GetByID(ctx, payment.CreditID)
-		if err != nil {
-			s.logger.Warnf("Failed to get credit %d for payment %d: %v", payment.CreditID, payment.ID, err)
+	schedules, err := s.repos.PaymentSchedule.GetByCreditID(ctx, creditID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment schedule: %w", err)
+	}
+	
+	quote := &models.PayoffQuote{
+		CreditID:     creditID,
+		ExtraPayment: extraPayment,
+	}
+	
+	var first time.Time
+	var premium, currentInsurance float64
+	for _, payment := range schedules {
+		if payment.Status == models.PaymentStatusOverdue {
+			return nil, errors.New("credit has overdue payments, pay them first")
+		}
+		if payment.Status != models.PaymentStatusPending {
 			continue
 		}
 		
-		// Get the account for this credit
-		account, err := s.repos.Account.GetByID(ctx, credit.AccountID)
+		if first.IsZero() {
+			first = payment.PaymentDate
+			quote.MonthlyPayment = payment.TotalAmount
+			premium = payment.InsuranceAmount
+		}
+		quote.RemainingPrincipal += payment.PrincipalAmount
+		quote.CurrentInterest += payment.InterestAmount
+		currentInsurance += payment.InsuranceAmount
+		quote.CurrentPayments++
+		quote.CurrentEndDate = payment.PaymentDate
+	}
+	
+	if quote.CurrentPayments == 0 {
+		return nil, errors.New("credit has no payments left")
+	}
+	
+	quote.RemainingPrincipal = math.Round(quote.RemainingPrincipal*100) / 100
+	quote.CurrentInterest = math.Round(quote.CurrentInterest*100) / 100
+	quote.PayoffAmount = quote.RemainingPrincipal
+	
+	principal := quote.RemainingPrincipal - extraPayment
+	if principal <= 0 {
+		principal = 0
+		quote.PaidOff = true
+	}
+	
+	recalculated := models.PrepaymentSchedule(credit, principal, first, premium)
+	calendar, roll := paymentCalendar(s.live)
+	calendar.RollSchedule(recalculated, roll)
+	
+	var newInsurance float64
+	quote.Schedule = make([]*models.PaymentScheduleResponse, 0, len(recalculated))
+	for i, payment := range recalculated {
+		quote.NewInterest += payment.InterestAmount
+		newInsurance += payment.InsuranceAmount
+		quote.Schedule = append(quote.Schedule, payment.ToPaymentScheduleResponse(i+1))
+	}
+	if len(recalculated) > 0 {
+		quote.NewEndDate = &recalculated[len(recalculated)-1].PaymentDate
+	}
+	
+	quote.NewPayments = len(recalculated)
+	quote.MonthsSaved = quote.CurrentPayments - quote.NewPayments
+	quote.NewInterest = math.Round(quote.NewInterest*100) / 100
+	quote.InterestSaved = math.Round((quote.CurrentInterest-quote.NewInterest)*100) / 100
+	quote.InsuranceSaved = math.Round((currentInsurance-newInsurance)*100) / 100
+	
+	return quote, nil
+}
+
+// premium from the payments that are not due yet
+func (s *CreditSvc) CancelInsurance(ctx context.Context, creditID int, userID int) (*models.InsurancePolicy, error) {
+	credit, err := s.GetByID(ctx, creditID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if credit.Insurance == nil || credit.Insurance.Status != models.InsurancePolicyStatusActive {
+		return nil, errors.New("credit has no active insurance")
+	}
+	policy := credit.Insurance
+	
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	defer func() {
 		if err != nil {
-			s.logger.Warnf("Failed to get account %d for credit %d: %v", credit.AccountID, credit.ID, err)
-			continue
+			tx.Rollback()
+		}
+	}()
+	
+	cancelled, err := s.repos.InsurancePolicy.CancelTx(ctx, tx, policy.ID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !cancelled {
+		err = errors.New("credit has no active insurance")
+		return nil, err
+	}
+	
+	payments, err := s.repos.PaymentSchedule.RemoveInsuranceTx(ctx, tx, creditID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	
+	now := time.Now()
+	policy.Status = models.InsurancePolicyStatusCancelled
+	policy.CancelledAt = &now
+	
+	s.logger.Infof("Insurance policy %s of credit %d cancelled, premium removed from %d payments",
+		policy.PolicyNumber, creditID, payments)
+	
+	return policy, nil
+}
+
+// ProcessPayments processes all pending payments that are due today. The payments of each account
+// are collected in one database transaction with a fixed number of statements, however many
+// installments are due. A payment dated on a weekend or holiday is due on the business day the
+// calendar's roll convention moves it to.
+func (s *CreditSvc) ProcessPayments(ctx context.Context) error {
+	today := time.Now().In(s.bank)
+	s.logger.Infof("Processing payments for date: %s", today.Format("2006-01-02"))
+	
+	// Reserve the upcoming payments so the funds cannot be spent before the due date
+	s.holdUpcomingPayments(ctx, today)
+	
+	calendar, roll := paymentCalendar(s.live)
+	dueUntil := calendar.DueUntil(today, roll)
+	
+	// Get the pending payments due today or earlier with their credits and accounts, a batch of
+	// accounts at a time; the account ID is the key of the next batch
+	found, afterAccountID := 0, 0
+	for {
+		duePayments, err := s.repos.PaymentSchedule.GetDuePayments(ctx, dueUntil, afterAccountID, paymentBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get pending payments: %w", err)
 		}
 		
-		// Check if payment is overdue and apply penalty if needed
-		models.UpdateScheduleStatus(payment)
-		
-		// Try to process the payment
-		totalAmount := payment.TotalAmount
-		if payment.IsOverdue {
-			totalAmount += payment.PenaltyAmount
+		if len(duePayments) == 0 {
+			break
 		}
+		found += len(duePayments)
 		
-		// Start a transaction
-		tx, err := s.repos.DB.BeginTx(ctx, nil)
-		if err != nil {
-			s.logger.Warnf("Failed to begin transaction for payment %d: %v", payment.ID, err)
-			continue
+		// The payments come grouped by account
+		for start := 0; start < len(duePayments); {
+			end := start + 1
+			for end < len(duePayments) && duePayments[end].Account.ID == duePayments[start].Account.ID {
+				end++
+			}
+			
+			if err := s.collectPayments(ctx, duePayments[start:end]); err != nil {
+				s.logger.Warnf("Failed to process payments of account %d: %v", duePayments[start].Account.ID, err)
+			}
+			
+			start = end
 		}
 		
-		// Deduct payment from account
-		err = s.repos.Account.UpdateBalance(ctx, account.ID, -totalAmount)
-		if err != nil {
-			s.logger.Warnf("Failed to update account balance for payment %d: %v", payment.ID, err)
-			tx.Rollback()
+		afterAccountID = duePayments[len(duePayments)-1].Account.ID
+	}
+	
+	s.logger.Infof("Found %d pending payments to process", found)
+	
+	return nil
+}
+
+// collectPayments debits the payments of one account that its balance covers, oldest first, and
+// marks the rest overdue with a penalty. If the transaction fails, the payments stay pending and
+// are tried again on the next run.
+func (s *CreditSvc) collectPayments(ctx context.Context, payments []*models.DuePayment) (err error) {
+	penaltyRate := s.live.Credit().PenaltyRate
+	calendar, roll := paymentCalendar(s.live)
+	account := payments[0].Account
+	
+	// The holds of the payments are captured or released either way, so their funds count as available
+	available := account.AvailableBalance
+	for _, due := range payments {
+		available += due.HeldAmount
+	}
+	
+	var paidIDs, missedIDs []int
+	var missed []*models.DuePayment
+	var transactions []*models.Transaction
+	var total float64
+	schedules := make([]*models.PaymentSchedule, 0, len(payments))
+	
+	for _, due := range payments {
+		payment := due.Payment
+		schedules = append(schedules, payment)
+		
+		// Check if payment is overdue and apply penalty if needed
+		models.UpdateScheduleStatus(payment, models.BusinessDate(calendar.Roll(payment.PaymentDate, roll), s.bank), penaltyRate)
+		amount := due.AmountDue()
+		
+		// If insufficient funds, mark as overdue
+		if available < amount {
+			payment.Status = models.PaymentStatusOverdue
+			payment.IsOverdue = true
 			
-			// If insufficient funds, mark as overdue
-			if strings.Contains(err.Error(), "insufficient funds") {
-				payment.Status = models.PaymentStatusOverdue
-				payment.IsOverdue = true
-				
-				if payment.PenaltyAmount == 0 {
-					payment.PenaltyAmount = payment.TotalAmount * 0.1 // 10% penalty
-				}
-				
-				err = s.repos.PaymentSchedule.Update(ctx, payment)
-				if err != nil {
-					s.logger.Warnf("Failed to update payment status to overdue: %v", err)
-				}
-				
-				// Update credit status to overdue
-				credit.Status = models.CreditStatusOverdue
-				err = s.repos.Credit.Update(ctx, credit)
-				if err != nil {
-					s.logger.Warnf("Failed to update credit status to overdue: %v", err)
-				}
-				
-				// Send reminder email
-				go func(userID int, payment *models.PaymentSchedule, credit *models.Credit) {
-					ctx := context.Background()
-					err := s.email.SendPaymentReminder(ctx, userID, payment, credit)
-					if err != nil {
-						s.logger.Warnf("Failed to send payment reminder: %v", err)
-					}
-				}(credit.UserID, payment, credit)
+			if payment.PenaltyAmount == 0 {
+				payment.PenaltyAmount = payment.TotalAmount * penaltyRate
 			}
 			
+			missedIDs = append(missedIDs, payment.ID)
+			missed = append(missed, due)
 			continue
 		}
 		
-		// Create a payment transaction
-		paymentTransaction := &models.Transaction{
-			TransactionType:  models.TransactionTypePayment,
+		available -= amount
+		total += amount
+		payment.Status = models.PaymentStatusPaid
+		paidIDs = append(paidIDs, payment.ID)
+		
+		transactions = append(transactions, &models.Transaction{
+			TransactionType: models.TransactionTypePayment,
 			SourceAccountID: &account.ID,
-			Amount:          totalAmount,
+			Amount:          amount,
 			Currency:        models.CurrencyRUB,
-			Description:     fmt.Sprintf("Credit payment for credit #%d", credit.ID),
+			Description:     fmt.Sprintf("Credit payment for credit #%d", due.Credit.ID),
 			Status:          models.TransactionStatusCompleted,
 			TransactionDate: time.Now(),
-		}
-		
-		_, err = s.repos.Transaction.Create(ctx, paymentTransaction)
+		})
+	}
+	
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	
+	defer func() {
 		if err != nil {
-			s.logger.Warnf("Failed to create payment transaction: %v", err)
 			tx.Rollback()
-			continue
 		}
-		
-		// Update payment status
-		payment.Status = models.PaymentStatusPaid
-		err = s.repos.PaymentSchedule.Update(ctx, payment)
+	}()
+	
+	if len(paidIDs) > 0 {
+		// Capture the payments' holds so the debit can use the reserved funds
+		_, err = s.repos.AccountHold.ReleaseBatchTx(ctx, tx, models.HoldReasonCreditPayment, paidIDs, models.HoldStatusCaptured)
 		if err != nil {
-			s.logger.Warnf("Failed to update payment status: %v", err)
-			tx.Rollback()
-			continue
+			return err
 		}
 		
-		// Commit the transaction
-		err = tx.Commit()
+		// Deduct the payments from the account
+		err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -total)
 		if err != nil {
-			s.logger.Warnf("Failed to commit transaction: %v", err)
-			continue
+			return fmt.Errorf("failed to update account balance: %w", err)
 		}
 		
-		s.logger.Infof("Processed payment %d for credit %d, amount: %f", payment.ID, credit.ID, totalAmount)
+		err = s.repos.Transaction.CreateBatchTx(ctx, tx, transactions)
+		if err != nil {
+			return fmt.Errorf("failed to create payment transactions: %w", err)
+		}
 	}
 	
-	return nil
-}
-
-// GetKeyRate gets the key interest rate from Central Bank of Russia
-func (s *CreditSvc) GetKeyRate(ctx context.Context) (float64, error) {
-	// Prepare SOAP request
-	soapEnvelope := `
-	<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:web="http://web.cbr.ru/">
-		<soapenv:Header/>
-		<soapenv:Body>
-			<web:GetCursOnDateXML>
-				<web:On_date>` + time.Now().Format("2006-01-02") + `</web:On_date>
-			</web:GetCursOnDateXML>
-		</soapenv:Body>
-	</soapenv:Envelope>`
-	
-	// Create the HTTP request
-	req, err := http.NewRequestWithContext(ctx, "POST", s.config.CBR.APIURL, strings.NewReader(soapEnvelope))
-	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+	if len(missedIDs) > 0 {
+		// The holds would otherwise keep blocking the account after the payments failed
+		_, err = s.repos.AccountHold.ReleaseBatchTx(ctx, tx, models.HoldReasonCreditPayment, missedIDs, models.HoldStatusReleased)
+		if err != nil {
+			return err
+		}
 	}
 	
-	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
-	req.Header.Set("SOAPAction", "http://web.cbr.ru/GetCursOnDateXML")
-	
-	// Send the request
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(req)
+	// Update payment statuses
+	err = s.repos.PaymentSchedule.UpdateBatchTx(ctx, tx, schedules)
 	if err != nil {
-		return 0, fmt.Errorf("failed to send request: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 	
-	// Read the response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return 0, fmt.Errorf("failed to read response: %w", err)
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 	
-	// Parse the XML response
-	var cbrResp CBRResponse
-	err = xml.Unmarshal(body, &cbrResp)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse XML response: %w", err)
+	for i, id := range paidIDs {
+		s.logger.Infof("Processed payment %d from account %d, amount: %f", id, account.ID, transactions[i].Amount)
+	}
+	
+	s.remindOverdue(ctx, missed)
+	
+	return nil
+}
+
+// remindOverdue marks the credits of missed payments overdue and sends the payment reminders
+func (s *CreditSvc) remindOverdue(ctx context.Context, missed []*models.DuePayment) {
+	updated := make(map[int]bool)
+	
+	for _, due := range missed {
+		s.logger.Warnf("Insufficient funds for payment %d of credit %d", due.Payment.ID, due.Credit.ID)
+		
+		// Update credit status to overdue
+		if !updated[due.Credit.ID] {
+			updated[due.Credit.ID] = true
+			
+			due.Credit.Status = models.CreditStatusOverdue
+			if err := s.repos.Credit.Update(ctx, due.Credit); err != nil {
+				s.logger.Warnf("Failed to update credit status to overdue: %v", err)
+			}
+		}
+		
+		// Send reminder email
+		userID, payment, credit := due.Credit.UserID, due.Payment, due.Credit
+		s.lifecycle.Background("payment-reminder", func(ctx context.Context) error {
+			err := s.email.SendPaymentReminder(ctx, userID, payment, credit)
+			if err != nil {
+				return fmt.Errorf("failed to send payment reminder: %w", err)
+			}
+			return nil
+		})
+	}
+}
+
+// holdUpcomingPayments places holds for the pending payments due within the configured number of days.
+// Payments the account cannot cover yet are skipped and tried again on the next run.
+func (s *CreditSvc) holdUpcomingPayments(ctx context.Context, today time.Time) {
+	days := s.live.Credit().PaymentHoldDays
+	if days <= 0 {
+		return
+	}
+	
+	// Load the payments in batches; the payment ID is the key of the next batch
+	afterID := 0
+	for {
+		payments, err := s.repos.PaymentSchedule.GetPendingPayments(ctx, today.AddDate(0, 0, days), afterID, paymentBatchSize)
+		if err != nil {
+			s.logger.Warnf("Failed to get upcoming payments to hold: %v", err)
+			return
+		}
+		
+		if len(payments) == 0 {
+			return
+		}
+		
+		for _, payment := range payments {
+			hold := &models.AccountHold{
+				AccountID:   payment.AccountID,
+				Amount:      payment.TotalAmount,
+				Reason:      models.HoldReasonCreditPayment,
+				ReferenceID: payment.ID,
+			}
+			
+			if _, err := s.repos.AccountHold.Create(ctx, hold); err != nil {
+				s.logger.Debugf("Could not hold payment %d on account %d: %v", payment.ID, payment.AccountID, err)
+			}
+		}
+		
+		afterID = payments[len(payments)-1].ID
 	}
+}
+
+// paymentCalendar returns the business-day calendar and roll convention of credit payment dates
+func paymentCalendar(live *configs.Live) (*models.BusinessCalendar, models.RollConvention) {
+	c := live.Credit().Calendar
 	
-	// Use etree to parse the inner XML content
-	doc := etree.NewDocument()
-	err = doc.ReadFromString(cbrResp.Body.GetRateResp.Result.Rates)
+	calendar, err := models.NewBusinessCalendar(c.Holidays, c.Workdays)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate data: %w", err)
+		// The dates are validated with the configuration, so only the built-in holidays are lost
+		calendar, _ = models.NewBusinessCalendar(nil, nil)
 	}
 	
-	// Find the key rate element (usually has ID R01010 for CBR key rate)
-	keyRateElem := doc.FindElement("//ValCurs/Valute[@ID='R01010']")
-	if keyRateElem == nil {
-		return 0, errors.New("key rate element not found in response")
+	return calendar, models.RollConvention(c.Roll)
+}
+
+// SendReminders emails a reminder 3 days and 1 day before each pending payment is due. Each reminder
+// is recorded before it is sent and goes out once; a payment found closer to its due date than a
+// missed reminder only gets the nearer one.
+func (s *CreditSvc) SendReminders(ctx context.Context) error {
+	now := time.Now().In(s.bank)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	
+	sent := 0
+	for _, days := range paymentReminderDays {
+		// Load the payments in batches; the payment ID is the key of the next batch
+		afterID := 0
+		for {
+			payments, err := s.repos.PaymentSchedule.GetPaymentsToRemind(ctx, today, today.AddDate(0, 0, days), days, afterID, paymentBatchSize)
+			if err != nil {
+				return fmt.Errorf("failed to get payments to remind: %w", err)
+			}
+			
+			if len(payments) == 0 {
+				break
+			}
+			
+			for _, payment := range payments {
+				if s.remind(ctx, payment, days) {
+					sent++
+				}
+			}
+			
+			afterID = payments[len(payments)-1].ID
+		}
 	}
 	
-	// Extract the value
-	valueElem := keyRateElem.FindElement("Value")
-	if valueElem == nil {
-		return 0, errors.New("value element not found in key rate")
+	s.logger.Infof("Sent %d payment reminders", sent)
+	
+	return nil
+}
+
+// remind sends the reminder of a payment due within days and reports whether it went out. The
+// reminder is recorded first and the record removed if sending fails, so the next run retries it.
+func (s *CreditSvc) remind(ctx context.Context, payment *models.PaymentSchedule, days int) bool {
+	credit, err := s.repos.Credit.GetByID(ctx, payment.CreditID)
+	if err != nil {
+		s.logger.Warnf("Failed to get credit %d for payment reminder: %v", payment.CreditID, err)
+		return false
 	}
 	
-	// Parse the value string to float (replace comma with dot)
-	var keyRate float64
-	valueStr := strings.Replace(valueElem.Text(), ",", ".", 1)
-	_, err = fmt.Sscanf(valueStr, "%f", &keyRate)
+	marked, err := s.repos.PaymentSchedule.MarkReminded(ctx, payment.ID, days)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse key rate value: %w", err)
+		s.logger.Warnf("Failed to record reminder of payment %d: %v", payment.ID, err)
+		return false
+	}
+	if !marked {
+		return false
 	}
 	
-	s.logger.Infof("Retrieved key rate from CBR: %f%%", keyRate)
+	if err := s.email.SendPaymentReminder(ctx, credit.UserID, payment, credit); err != nil {
+		s.logger.Warnf("Failed to send reminder of payment %d: %v", payment.ID, err)
+		if err := s.repos.PaymentSchedule.UnmarkReminded(ctx, payment.ID, days); err != nil {
+			s.logger.Errorf("Failed to remove reminder record of payment %d, it will not be retried: %v", payment.ID, err)
+		}
+		return false
+	}
 	
-	return keyRate, nil
+	return true
+}
+
+// GetKeyRate gets the key rate credits are priced on, pinned by an admin or from Central Bank of Russia
+func (s *CreditSvc) GetKeyRate(ctx context.Context) (*models.KeyRate, error) {
+	return s.rates.GetCurrentKeyRate(ctx)
 }
\ No newline at end of file
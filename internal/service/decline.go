@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+)
+
+// declines records declined payments, withdrawals and transfers as failed transactions with the
+// reason they were declined, so they show up in the account and card history. Card declines are
+// also notified, as the user is not in the app to see them: a user gets at most
+// notification.declines_per_day decline notifications a day; later declines are only recorded.
+type declines struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	perDay        int
+	notifications NotificationService
+	bank          *time.Location
+}
+
+// newDeclines creates the decline recorder of a service
+func newDeclines(deps Dependencies) *declines {
+	return &declines{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		perDay:        deps.Config.Notification.DeclinesPerDay,
+		notifications: NewNotificationService(deps),
+		bank:          deps.Bank,
+	}
+}
+
+// decline records the operation as a failed transaction with the decline reason, notifies the user
+// of a card decline and returns the message as the error for the caller. Failing to record or notify
+// is logged and does not change the error.
+func (d *declines) decline(ctx context.Context, userID int, transaction *models.Transaction, declineReason models.DeclineReason, reason string) error {
+	transaction.Status = models.TransactionStatusFailed
+	transaction.DeclineReason = declineReason
+	if transaction.Description == "" {
+		transaction.Description = "Declined: " + reason
+	} else {
+		transaction.Description += " (declined: " + reason + ")"
+	}
+
+	id, err := d.repos.Transaction.Create(ctx, transaction)
+	if err != nil {
+		d.logger.Errorf("Failed to record declined %s of user %d: %v", transaction.TransactionType, userID, err)
+	} else {
+		transaction.ID = id
+		d.logger.Infof("Declined %s of %.2f recorded as transaction %d: %s (%s)", transaction.TransactionType, transaction.Amount, id, declineReason, reason)
+	}
+
+	if transaction.CardID == nil {
+		return errors.New(reason)
+	}
+
+	if err := d.notify(ctx, userID, transaction, reason); err != nil {
+		d.logger.Warnf("Failed to notify user %d about a declined %s: %v", userID, transaction.TransactionType, err)
+	}
+
+	return errors.New(reason)
+}
+
+// notify tells the user about a decline unless they already got the day's allowance of decline notifications
+func (d *declines) notify(ctx context.Context, userID int, transaction *models.Transaction, reason string) error {
+	if d.perDay == 0 {
+		return nil
+	}
+
+	now := time.Now().In(d.bank)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sent, err := d.repos.Notification.CountSince(ctx, userID, models.NotificationTypeDecline, today)
+	if err != nil {
+		return err
+	}
+	if sent >= d.perDay {
+		return nil
+	}
+
+	template := "card_payment_declined"
+	if transaction.TransactionType == models.TransactionTypeWithdrawal {
+		template = "atm_withdrawal_declined"
+	}
+	if sent+1 == d.perDay {
+		template += "_last"
+	}
+
+	return d.notifications.Notify(ctx, userID, models.NotificationTypeDecline, template,
+		transaction.Amount, transaction.Currency, i18n.Message(reason))
+}
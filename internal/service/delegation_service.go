@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// DelegationSvc is an implementation of the service.DelegationService interface
+type DelegationSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	bank          *time.Location
+}
+
+// NewDelegationService creates a new DelegationSvc
+func NewDelegationService(deps Dependencies) *DelegationSvc {
+	return &DelegationSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		bank:          deps.Bank,
+	}
+}
+
+// Grant gives another user, found by email, access to one of the owner's personal accounts until
+// the end of the expiry date
+func (s *DelegationSvc) Grant(ctx context.Context, request *models.DelegationCreate, ownerID int) (*models.AccountDelegation, error) {
+	if err := request.ValidateDelegationCreate(time.Now().In(s.bank)); err != nil {
+		return nil, fmt.Errorf("invalid delegation: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, request.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if account.OrganizationID != nil {
+		return nil, errors.New("only personal accounts can be delegated")
+	}
+	if account.UserID != ownerID {
+		return nil, errors.New("access denied: account belongs to another user")
+	}
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	delegate, err := s.repos.User.GetByEmail(ctx, request.Email)
+	if err != nil {
+		return nil, errors.New("delegate not found")
+	}
+	if delegate.ID == ownerID {
+		return nil, errors.New("you cannot delegate access to yourself")
+	}
+
+	if _, err := s.repos.AccountDelegation.GetActive(ctx, account.ID, delegate.ID); err == nil {
+		return nil, errors.New("user already has access to this account, revoke it first")
+	}
+
+	owner, err := s.repos.User.GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	delegation := &models.AccountDelegation{
+		AccountID:     account.ID,
+		AccountNumber: account.AccountNumber,
+		OwnerID:       ownerID,
+		DelegateID:    delegate.ID,
+		DelegateEmail: delegate.Email,
+		Scope:         request.Scope,
+		TransferLimit: request.TransferLimit,
+		ExpiresAt:     request.ExpiresAt,
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if _, err = s.repos.AccountDelegation.CreateTx(ctx, tx, delegation); err != nil {
+		return nil, err
+	}
+
+	details := fmt.Sprintf("%s access for user %d until %s", delegation.Scope, delegate.ID, request.ExpiresOn)
+	if delegation.Scope == models.DelegationScopeTransfer {
+		details += fmt.Sprintf(", transfers of up to %.2f %s in total", delegation.TransferLimit, account.Currency)
+	}
+	err = recordDelegatedAction(ctx, s.repos, tx, &models.DelegationEvent{
+		DelegationID: delegation.ID,
+		AccountID:    account.ID,
+		ActorID:      ownerID,
+		Action:       models.DelegationActionGranted,
+		Details:      details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("User %d delegated %s access to account %d to user %d, delegation %d",
+		ownerID, delegation.Scope, account.ID, delegate.ID, delegation.ID)
+
+	s.notify(delegate.ID, "delegation_granted", owner.Email, account.AccountNumber, request.ExpiresOn)
+
+	return delegation, nil
+}
+
+// Revoke ends a delegation. Both the owner and the delegate can revoke it.
+func (s *DelegationSvc) Revoke(ctx context.Context, id int, userID int) (*models.AccountDelegation, error) {
+	delegation, err := s.get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delegation.RevokedAt != nil {
+		return nil, errors.New("delegation is already revoked")
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	revoked, err := s.repos.AccountDelegation.RevokeTx(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !revoked {
+		err = errors.New("delegation is already revoked")
+		return nil, err
+	}
+
+	details := "revoked by the owner"
+	if userID == delegation.DelegateID {
+		details = "renounced by the delegate"
+	}
+	err = recordDelegatedAction(ctx, s.repos, tx, &models.DelegationEvent{
+		DelegationID: id,
+		AccountID:    delegation.AccountID,
+		ActorID:      userID,
+		Action:       models.DelegationActionRevoked,
+		Details:      details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Delegation %d of account %d revoked by user %d", id, delegation.AccountID, userID)
+
+	// Tell the other party
+	other := delegation.DelegateID
+	if userID == delegation.DelegateID {
+		other = delegation.OwnerID
+	}
+	s.notify(other, "delegation_revoked", delegation.AccountNumber)
+
+	return s.repos.AccountDelegation.GetByID(ctx, id)
+}
+
+// GetGranted gets the delegations the user granted, newest first
+func (s *DelegationSvc) GetGranted(ctx context.Context, userID int) ([]*models.AccountDelegation, error) {
+	return s.repos.AccountDelegation.GetByOwnerID(ctx, userID)
+}
+
+// GetReceived gets the delegations granted to the user, newest first
+func (s *DelegationSvc) GetReceived(ctx context.Context, userID int) ([]*models.AccountDelegation, error) {
+	return s.repos.AccountDelegation.GetByDelegateID(ctx, userID)
+}
+
+// GetEvents gets the audit log of a delegation, for its owner or delegate
+func (s *DelegationSvc) GetEvents(ctx context.Context, id int, userID int) ([]*models.DelegationEvent, error) {
+	if _, err := s.get(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repos.AccountDelegation.GetEvents(ctx, id)
+}
+
+// get gets a delegation the user granted or received
+func (s *DelegationSvc) get(ctx context.Context, id int, userID int) (*models.AccountDelegation, error) {
+	delegation, err := s.repos.AccountDelegation.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if delegation.OwnerID != userID && delegation.DelegateID != userID {
+		return nil, errors.New("access denied: delegation belongs to another user")
+	}
+
+	return delegation, nil
+}
+
+// notify sends a delegation notification in the background
+func (s *DelegationSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("delegation-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeAccount, template, args...); err != nil {
+			return fmt.Errorf("failed to send delegation notification: %w", err)
+		}
+		return nil
+	})
+}
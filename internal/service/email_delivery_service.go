@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"banking-service/internal/models"
+)
+
+// ErrEmailWebhookDisabled is returned for provider events while no webhook secret is configured
+var ErrEmailWebhookDisabled = errors.New("email webhook is disabled")
+
+// ErrEmailWebhookSignature is returned for provider events whose signature does not match
+var ErrEmailWebhookSignature = errors.New("email webhook signature is invalid")
+
+// smtpReply matches the reply code and the enhanced status code of an SMTP error, e.g.
+// "550 5.1.1 user unknown"
+var smtpReply = regexp.MustCompile(`\b([245][0-9]{2})[ -](?:([245]\.[0-9]{1,3}\.[0-9]{1,3})\b)?`)
+
+// GetDeliveries gets the most recent emails sent, optionally by status or recipient
+func (s *EmailSvc) GetDeliveries(ctx context.Context, status models.EmailDeliveryStatus, recipient string) ([]*models.EmailDelivery, error) {
+	switch status {
+	case "", models.EmailDeliveryStatusAccepted, models.EmailDeliveryStatusDelivered, models.EmailDeliveryStatusFailed,
+		models.EmailDeliveryStatusBounced, models.EmailDeliveryStatusComplained, models.EmailDeliveryStatusSuppressed:
+	default:
+		return nil, errors.New("status must be one of ACCEPTED, DELIVERED, FAILED, BOUNCED, COMPLAINED, SUPPRESSED")
+	}
+
+	return s.repos.Email.GetDeliveries(ctx, &models.EmailDeliveryFilter{
+		Status:    status,
+		Recipient: models.NormalizeEmail(recipient),
+		Limit:     models.MaxEmailDeliveries,
+	})
+}
+
+// GetSuppressions gets the addresses emails are no longer sent to
+func (s *EmailSvc) GetSuppressions(ctx context.Context) ([]*models.EmailSuppression, error) {
+	return s.repos.Email.GetSuppressions(ctx)
+}
+
+// DeleteSuppression lets emails be sent to a suppressed address again, e.g. once the customer
+// has fixed their mailbox
+func (s *EmailSvc) DeleteSuppression(ctx context.Context, id int, adminID int) error {
+	deleted, err := s.repos.Email.DeleteSuppression(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New("email suppression not found")
+	}
+
+	s.logger.Warnf("Email suppression %d lifted by admin %d", id, adminID)
+
+	return nil
+}
+
+// VerifyWebhook checks that a webhook payload is signed by the email provider: the signature is
+// the hex HMAC-SHA256 of the payload with the webhook secret
+func (s *EmailSvc) VerifyWebhook(payload []byte, signature string) error {
+	secret := s.live.Email().WebhookSecret
+	if secret == "" {
+		return ErrEmailWebhookDisabled
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return ErrEmailWebhookSignature
+	}
+
+	return nil
+}
+
+// HandleEvents records the delivery outcomes the email provider reports. Hard bounces and
+// complaints put the address on the suppression list.
+func (s *EmailSvc) HandleEvents(ctx context.Context, events []*models.EmailEvent) error {
+	for i, event := range events {
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("event %d: %w", i+1, err)
+		}
+	}
+
+	for _, event := range events {
+		if err := s.applyEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyEvent updates the delivery an event is about and suppresses its address if needed
+func (s *EmailSvc) applyEvent(ctx context.Context, event *models.EmailEvent) error {
+	var delivery *models.EmailDelivery
+	if event.MessageID != "" {
+		found, err := s.repos.Email.GetDeliveryByMessageID(ctx, strings.Trim(event.MessageID, "<>"))
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		delivery = found
+	}
+
+	email := models.NormalizeEmail(event.Email)
+	if delivery == nil {
+		if event.MessageID != "" {
+			s.logger.Infof("Email provider reported %s of unknown message %q", event.Event, event.MessageID)
+		}
+		if email == "" {
+			return nil
+		}
+	} else {
+		email = delivery.Recipient
+		if err := s.updateDelivery(ctx, delivery, event); err != nil {
+			return err
+		}
+	}
+
+	if event.Suppresses() {
+		reason := models.EmailSuppressionReasonHardBounce
+		if event.Event == models.EmailEventComplained {
+			reason = models.EmailSuppressionReasonComplaint
+		}
+		var deliveryID *int
+		if delivery != nil {
+			deliveryID = &delivery.ID
+		}
+		s.suppress(ctx, email, reason, deliveryID, event.Reason)
+	}
+
+	return nil
+}
+
+// updateDelivery applies a provider event to a delivery, unless it bounced or was complained
+// about already
+func (s *EmailSvc) updateDelivery(ctx context.Context, delivery *models.EmailDelivery, event *models.EmailEvent) error {
+	if delivery.Final() {
+		return nil
+	}
+
+	switch event.Event {
+	case models.EmailEventDelivered:
+		delivery.Status = models.EmailDeliveryStatusDelivered
+	case models.EmailEventOpened:
+		delivery.Status = models.EmailDeliveryStatusDelivered
+		if delivery.OpenedAt == nil {
+			openedAt := time.Now()
+			if event.OccurredAt != nil {
+				openedAt = *event.OccurredAt
+			}
+			delivery.OpenedAt = &openedAt
+		}
+	case models.EmailEventBounced:
+		delivery.Status = models.EmailDeliveryStatusFailed
+		if event.Suppresses() {
+			delivery.Status = models.EmailDeliveryStatusBounced
+		}
+		delivery.Response = event.Reason
+	case models.EmailEventComplained:
+		delivery.Status = models.EmailDeliveryStatusComplained
+		delivery.Response = event.Reason
+	}
+
+	return s.repos.Email.UpdateDelivery(ctx, delivery)
+}
+
+// suppression gets the suppression of an address, nil if emails may be sent to it
+func (s *EmailSvc) suppression(ctx context.Context, email string) (*models.EmailSuppression, error) {
+	suppression, err := s.repos.Email.GetSuppression(ctx, email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check email suppression: %w", err)
+	}
+
+	return suppression, nil
+}
+
+// suppress stops the emails to an address; a failure is logged rather than failing the caller
+func (s *EmailSvc) suppress(ctx context.Context, email string, reason models.EmailSuppressionReason, deliveryID *int, details string) {
+	if deliveryID != nil && *deliveryID == 0 {
+		deliveryID = nil
+	}
+
+	id, err := s.repos.Email.CreateSuppression(ctx, &models.EmailSuppression{
+		Email:      email,
+		Reason:     reason,
+		DeliveryID: deliveryID,
+		Details:    details,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to suppress emails to %s: %v", email, err)
+		return
+	}
+	if id != 0 {
+		s.logger.Warnf("Emails to %s suppressed after %s", email, reason)
+	}
+}
+
+// recordDelivery stores the outcome of sending an email; a failure is logged rather than failing
+// the caller, as the email was sent or not regardless
+func (s *EmailSvc) recordDelivery(ctx context.Context, delivery *models.EmailDelivery) {
+	if delivery.MessageID == "" {
+		messageID, err := newMessageID("")
+		if err != nil {
+			s.logger.Errorf("Failed to record email delivery to %s: %v", delivery.Recipient, err)
+			return
+		}
+		delivery.MessageID = messageID
+	}
+
+	if _, err := s.repos.Email.CreateDelivery(ctx, delivery); err != nil {
+		s.logger.Errorf("Failed to record email delivery to %s: %v", delivery.Recipient, err)
+	}
+}
+
+// newMessageID generates the Message-ID of an email, in the domain of the sender
+func newMessageID(sender string) (string, error) {
+	id, err := newRandomID()
+	if err != nil {
+		return "", err
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(sender, "@"); at >= 0 && at < len(sender)-1 {
+		domain = sender[at+1:]
+	}
+
+	return id + "@" + domain, nil
+}
+
+// hardBounce reports whether an SMTP error rejects the recipient permanently: a 5.1.x enhanced
+// status (bad destination mailbox), or, without one, reply 550, 551 or 553
+func hardBounce(err error) bool {
+	match := smtpReply.FindStringSubmatch(err.Error())
+	if match == nil {
+		return false
+	}
+
+	if match[2] != "" {
+		return strings.HasPrefix(match[2], "5.1.")
+	}
+
+	switch match[1] {
+	case "550", "551", "553":
+		return true
+	}
+	return false
+}
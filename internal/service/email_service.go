@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"html"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -11,6 +14,7 @@ import (
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
 )
 
 // EmailSvc is an implementation of the service.EmailService interface
@@ -18,6 +22,7 @@ type EmailSvc struct {
 	repos  *repository.Repository
 	logger *logrus.Logger
 	config *configs.Config
+	live   *configs.Live
 }
 
 // NewEmailService creates a new EmailSvc
@@ -26,6 +31,7 @@ func NewEmailService(deps Dependencies) *EmailSvc {
 		repos:  deps.Repos,
 		logger: deps.Logger,
 		config: deps.Config,
+		live:   deps.Live,
 	}
 }
 
@@ -42,6 +48,8 @@ func (s *EmailSvc) SendTransactionNotification(ctx context.Context, userID int,
 		return nil
 	}
 	
+	lang := s.language(user)
+	
 	// Prepare transaction details
 	var accountID int
 	var transactionType string
@@ -52,7 +60,7 @@ func (s *EmailSvc) SendTransactionNotification(ctx context.Context, userID int,
 			return fmt.Errorf("deposit transaction has no destination account")
 		}
 		accountID = *transaction.DestinationAccountID
-		transactionType = "Deposit"
+		transactionType = i18n.Text(lang, "email.transaction.deposit")
 		amountStr = fmt.Sprintf("+%.2f %s", transaction.Amount, transaction.Currency)
 	} else if transaction.TransactionType == models.TransactionTypeWithdrawal || 
 		transaction.TransactionType == models.TransactionTypePayment ||
@@ -63,11 +71,11 @@ func (s *EmailSvc) SendTransactionNotification(ctx context.Context, userID int,
 		accountID = *transaction.SourceAccountID
 		
 		if transaction.TransactionType == models.TransactionTypeWithdrawal {
-			transactionType = "Withdrawal"
+			transactionType = i18n.Text(lang, "email.transaction.withdrawal")
 		} else if transaction.TransactionType == models.TransactionTypePayment {
-			transactionType = "Payment"
+			transactionType = i18n.Text(lang, "email.transaction.payment")
 		} else {
-			transactionType = "Transfer"
+			transactionType = i18n.Text(lang, "email.transaction.transfer")
 		}
 		
 		amountStr = fmt.Sprintf("-%.2f %s", transaction.Amount, transaction.Currency)
@@ -83,50 +91,9 @@ func (s *EmailSvc) SendTransactionNotification(ctx context.Context, userID int,
 	}
 	
 	// Create email content
-	subject := fmt.Sprintf("%s Notification: %s", transactionType, amountStr)
-	
-	body := fmt.Sprintf(`
-	<h2>Transaction Notification</h2>
-	<p>Dear %s %s,</p>
-	
-	<p>We are informing you about a recent transaction on your account:</p>
-	
-	<table style="border-collapse: collapse; width: 100%%;">
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Transaction Type:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Amount:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Account:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Balance:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f %s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Date:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Description:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-	</table>
-	
-	<p>If you did not authorize this transaction, please contact our support immediately.</p>
-	
-	<p>Thank you for using our banking services.</p>
-	
-	<p>
-	Best regards,<br>
-	Banking Service Team
-	</p>
-	`,
+	subject := i18n.Sprintf(lang, "email.transaction.subject", transactionType, amountStr)
+	
+	body := i18n.Sprintf(lang, "email.transaction.body",
 		user.FirstName, user.LastName,
 		transactionType,
 		amountStr,
@@ -137,7 +104,7 @@ func (s *EmailSvc) SendTransactionNotification(ctx context.Context, userID int,
 	)
 	
 	// Send the email
-	err = s.sendEmail(user.Email, subject, body)
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -160,6 +127,8 @@ func (s *EmailSvc) SendPaymentReminder(ctx context.Context, userID int, payment
 		return nil
 	}
 	
+	lang := s.language(user)
+	
 	// Get account details
 	account, err := s.repos.Account.GetByID(ctx, credit.AccountID)
 	if err != nil {
@@ -172,9 +141,9 @@ func (s *EmailSvc) SendPaymentReminder(ctx context.Context, userID int, payment
 	// Create email content
 	var subject string
 	if isOverdue {
-		subject = fmt.Sprintf("OVERDUE Payment Reminder: Credit #%d", credit.ID)
+		subject = i18n.Sprintf(lang, "email.payment_reminder.subject_overdue", credit.ID)
 	} else {
-		subject = fmt.Sprintf("Upcoming Payment Reminder: Credit #%d", credit.ID)
+		subject = i18n.Sprintf(lang, "email.payment_reminder.subject_upcoming", credit.ID)
 	}
 	
 	// Calculate total amount with penalty if overdue
@@ -186,72 +155,13 @@ func (s *EmailSvc) SendPaymentReminder(ctx context.Context, userID int, payment
 	var overdueText string
 	if isOverdue {
 		daysOverdue := int(time.Now().Sub(payment.PaymentDate).Hours() / 24)
-		overdueText = fmt.Sprintf(`
-		<p style="color: red; font-weight: bold;">
-			This payment is OVERDUE by %d days. A penalty of %.2f RUB has been applied.
-		</p>
-		`, daysOverdue, payment.PenaltyAmount)
+		overdueText = i18n.Sprintf(lang, "email.payment_reminder.overdue", daysOverdue, payment.PenaltyAmount)
 	} else {
 		daysUntil := int(payment.PaymentDate.Sub(time.Now()).Hours() / 24)
-		overdueText = fmt.Sprintf(`
-		<p>
-			This payment is due in %d days. Please ensure you have sufficient funds in your account.
-		</p>
-		`, daysUntil)
-	}
-	
-	body := fmt.Sprintf(`
-	<h2>Credit Payment Reminder</h2>
-	<p>Dear %s %s,</p>
-	
-	%s
-	
-	<p>Here are the details of your credit payment:</p>
-	
-	<table style="border-collapse: collapse; width: 100%%;">
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit ID:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Payment Date:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Principal Amount:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Interest Amount:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Penalty Amount:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Total Amount Due:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Account Number:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Account Balance:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-	</table>
-	
-	<p>Please ensure you have sufficient funds in your account to cover this payment.</p>
-	
-	<p>Thank you for using our banking services.</p>
-	
-	<p>
-	Best regards,<br>
-	Banking Service Team
-	</p>
-	`,
+		overdueText = i18n.Sprintf(lang, "email.payment_reminder.upcoming", daysUntil)
+	}
+	
+	body := i18n.Sprintf(lang, "email.payment_reminder.body",
 		user.FirstName, user.LastName,
 		overdueText,
 		credit.ID,
@@ -265,7 +175,7 @@ func (s *EmailSvc) SendPaymentReminder(ctx context.Context, userID int, payment
 	)
 	
 	// Send the email
-	err = s.sendEmail(user.Email, subject, body)
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -288,6 +198,8 @@ func (s *EmailSvc) SendCreditApproval(ctx context.Context, userID int, credit *m
 		return nil
 	}
 	
+	lang := s.language(user)
+	
 	// Get account details
 	account, err := s.repos.Account.GetByID(ctx, credit.AccountID)
 	if err != nil {
@@ -304,64 +216,13 @@ func (s *EmailSvc) SendCreditApproval(ctx context.Context, userID int, credit *m
 	if len(schedules) > 0 {
 		firstPaymentDate = schedules[0].PaymentDate.Format("2006-01-02")
 	} else {
-		firstPaymentDate = "See your payment schedule for details"
+		firstPaymentDate = i18n.Text(lang, "email.credit_approval.no_schedule")
 	}
 	
 	// Create email content
-	subject := fmt.Sprintf("Credit Approved: %.2f RUB", credit.Amount)
-	
-	body := fmt.Sprintf(`
-	<h2>Credit Approval Notification</h2>
-	<p>Dear %s %s,</p>
-	
-	<p>We are pleased to inform you that your credit application has been approved!</p>
-	
-	<p>Here are the details of your new credit:</p>
-	
-	<table style="border-collapse: collapse; width: 100%%;">
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit ID:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Amount:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Interest Rate:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f%%</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Term:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%d months</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Monthly Payment:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>First Payment Date:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit Account:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
-		</tr>
-		<tr>
-			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Account Balance:</strong></td>
-			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
-		</tr>
-	</table>
-	
-	<p>The approved amount has been deposited to your credit account. You can view your payment schedule in your online banking portal.</p>
-	
-	<p>Thank you for choosing our banking services.</p>
-	
-	<p>
-	Best regards,<br>
-	Banking Service Team
-	</p>
-	`,
+	subject := i18n.Sprintf(lang, "email.credit_approval.subject", credit.Amount)
+	
+	body := i18n.Sprintf(lang, "email.credit_approval.body",
 		user.FirstName, user.LastName,
 		credit.ID,
 		credit.Amount,
@@ -374,7 +235,7 @@ func (s *EmailSvc) SendCreditApproval(ctx context.Context, userID int, credit *m
 	)
 	
 	// Send the email
-	err = s.sendEmail(user.Email, subject, body)
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -384,27 +245,473 @@ func (s *EmailSvc) SendCreditApproval(ctx context.Context, userID int, credit *m
 	return nil
 }
 
-// sendEmail sends an email using the SMTP server
-func (s *EmailSvc) sendEmail(to, subject, body string) error {
+// SendNewDeviceAlert sends a security alert for a login from a previously unseen device
+func (s *EmailSvc) SendNewDeviceAlert(ctx context.Context, userID int, session *models.Session, revokeURL string) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.new_device.subject")
+	
+	body := i18n.Sprintf(lang, "email.new_device.body",
+		user.FirstName, user.LastName,
+		time.Now().Format("2006-01-02 15:04:05"),
+		session.IPAddress,
+		session.Location,
+		session.UserAgent,
+		revokeURL,
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("New device alert email sent to %s for session %d", user.Email, session.ID)
+	
+	return nil
+}
+
+// SendTransferCode sends the one-time code that confirms a high-value transfer
+func (s *EmailSvc) SendTransferCode(ctx context.Context, userID int, code string, confirmation *models.TransferConfirmation) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Get destination account details
+	destAccount, err := s.repos.Account.GetByID(ctx, confirmation.DestinationAccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.transfer_code.subject")
+	
+	body := i18n.Sprintf(lang, "email.transfer_code.body",
+		user.FirstName, user.LastName,
+		code,
+		confirmation.Amount,
+		destAccount.AccountNumber,
+		confirmation.ExpiresAt.Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Transfer confirmation code sent to %s for confirmation %s", user.Email, confirmation.ConfirmationID)
+	
+	return nil
+}
+
+// SendPasswordChanged sends a confirmation that the account password was changed
+func (s *EmailSvc) SendPasswordChanged(ctx context.Context, userID int) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.password_changed.subject")
+	
+	body := i18n.Sprintf(lang, "email.password_changed.body",
+		user.FirstName, user.LastName,
+		time.Now().Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Password change confirmation sent to %s", user.Email)
+	
+	return nil
+}
+
+// SendEmailVerification sends the link that confirms the email address of a user
+func (s *EmailSvc) SendEmailVerification(ctx context.Context, userID int, verifyURL string, expiresAt time.Time) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.email_verification.subject")
+	
+	body := i18n.Sprintf(lang, "email.email_verification.body",
+		user.FirstName, user.LastName,
+		verifyURL,
+		expiresAt.Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Email verification link sent to %s", user.Email)
+	
+	return nil
+}
+
+// SendPasswordReset sends the link that lets a user who lost their password set a new one
+func (s *EmailSvc) SendPasswordReset(ctx context.Context, userID int, resetURL string, expiresAt time.Time) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.password_reset.subject")
+	
+	body := i18n.Sprintf(lang, "email.password_reset.body",
+		user.FirstName, user.LastName,
+		resetURL,
+		expiresAt.Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Password reset link sent to %s", user.Email)
+	
+	return nil
+}
+
+// SendOrganizationInvitation sends an invitation to join an organization. The invitee
+// may not have an account yet, so the email goes to the invited address.
+func (s *EmailSvc) SendOrganizationInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error {
+	// Get the inviting user
+	inviter, err := s.repos.User.GetByID(ctx, invitation.InvitedBy)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// The invitee may not have an account, so the invitation is written in the bank's language
+	lang := s.config.Bank.DefaultLanguage()
+	
+	// Create email content
+	subject := i18n.Sprintf(lang, "email.organization_invitation.subject", invitation.OrganizationName)
+	
+	body := i18n.Sprintf(lang, "email.organization_invitation.body",
+		inviter.FirstName, inviter.LastName,
+		html.EscapeString(invitation.OrganizationName),
+		invitation.Role,
+		invitation.ExpiresAt.Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, nil, lang, inviter.Tenant, invitation.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Organization invitation %d sent to %s", invitation.ID, invitation.Email)
+	
+	return nil
+}
+
+// SendTaxDocument sends the yearly tax document with the rendered certificate attached
+func (s *EmailSvc) SendTaxDocument(ctx context.Context, doc *models.TaxDocument) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, doc.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	content, err := doc.Render(user)
+	if err != nil {
+		return fmt.Errorf("failed to render tax document: %w", err)
+	}
+	
+	// Create email content
+	subject := i18n.Sprintf(lang, "email.tax_document.subject", doc.Year)
+	
+	body := i18n.Sprintf(lang, "email.tax_document.body",
+		doc.Year,
+		user.FirstName, user.LastName,
+		doc.InterestEarned,
+		doc.LoanInterestPaid,
+		doc.LoanPenaltiesPaid,
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body, emailAttachment{Name: doc.FileName(), Content: content})
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Tax document %d for %d sent to %s", doc.ID, doc.Year, user.Email)
+	
+	return nil
+}
+
+// SendNewMessage tells the customer that the bank has written in a message thread. The message
+// itself is only readable after signing in.
+func (s *EmailSvc) SendNewMessage(ctx context.Context, userID int, thread *models.MessageThread) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.new_message.subject")
+	
+	body := i18n.Sprintf(lang, "email.new_message.body",
+		user.FirstName, user.LastName,
+		html.EscapeString(thread.Subject),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("New message prompt for thread %d sent to %s", thread.ID, user.Email)
+	
+	return nil
+}
+
+// SendSignatureCode sends the one-time code that signs a credit agreement together with the
+// hash of the agreement being signed
+func (s *EmailSvc) SendSignatureCode(ctx context.Context, userID int, code string, request *models.SignatureRequest) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	subject := i18n.Text(lang, "email.signature_code.subject")
+	
+	body := i18n.Sprintf(lang, "email.signature_code.body",
+		user.FirstName, user.LastName,
+		request.CreditID,
+		code,
+		request.DocumentHash,
+		request.ExpiresAt.Format("2006-01-02 15:04:05"),
+	)
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, subject, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Credit agreement signing code sent to %s for request %s", user.Email, request.RequestID)
+	
+	return nil
+}
+
+// SendAnnouncement emails an announcement an admin sent, already personalized for the customer.
+// The message is plain text; each blank-line separated paragraph becomes an HTML paragraph.
+func (s *EmailSvc) SendAnnouncement(ctx context.Context, userID int, title, message string) error {
+	// Get the user
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Skip if email is empty
+	if user.Email == "" {
+		return nil
+	}
+	
+	lang := s.language(user)
+	
+	// Create email content
+	var paragraphs strings.Builder
+	for _, paragraph := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs.WriteString("<p>" + strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>") + "</p>\n")
+		}
+	}
+	
+	body := i18n.Sprintf(lang, "email.announcement.body", html.EscapeString(title), paragraphs.String())
+	
+	// Send the email
+	err = s.sendEmail(ctx, &user.ID, lang, user.Tenant, user.Email, title, body)
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	
+	s.logger.Infof("Announcement email sent to %s", user.Email)
+	
+	return nil
+}
+
+// emailAttachment is a file attached to an email
+type emailAttachment struct {
+	Name    string
+	Content []byte
+}
+
+// brandPlaceholder marks where email templates name the bank; sendEmail fills in the tenant's brand
+const brandPlaceholder = "{{brand}}"
+
+// language returns the language emails to the user are written in: their preference, or the bank's
+func (s *EmailSvc) language(user *models.User) i18n.Language {
+	if lang, ok := i18n.Parse(user.Language); ok {
+		return lang
+	}
+	return s.config.Bank.DefaultLanguage()
+}
+
+// sendEmail sends an email branded for the tenant through its SMTP server, or the global one.
+// Every email is recorded as a delivery; an address on the suppression list is not emailed, and
+// one the SMTP server rejects permanently is added to it.
+func (s *EmailSvc) sendEmail(ctx context.Context, userID *int, lang i18n.Language, tenant, to, subject, body string, attachments ...emailAttachment) error {
+	delivery := &models.EmailDelivery{UserID: userID, Tenant: tenant, Recipient: models.NormalizeEmail(to), Subject: subject}
+	
+	if suppressed, err := s.suppression(ctx, delivery.Recipient); err != nil {
+		return err
+	} else if suppressed != nil {
+		delivery.Status = models.EmailDeliveryStatusSuppressed
+		delivery.Response = string(suppressed.Reason)
+		s.recordDelivery(ctx, delivery)
+		s.logger.Infof("Email %q to %s not sent: the address is suppressed after %s", subject, to, suppressed.Reason)
+		return nil
+	}
+	
+	brand, ok := s.config.Tenant(tenant)
+	if !ok {
+		brand, _ = s.config.Tenant(configs.DefaultTenant)
+	}
+	
+	body = strings.ReplaceAll(body, brandPlaceholder, html.EscapeString(brand.Name))
+	if brand.LogoURL != "" {
+		body = fmt.Sprintf(`<p><img src="%s" alt="%s"></p>`, html.EscapeString(brand.LogoURL), html.EscapeString(brand.Name)) + body
+	}
+	if brand.SupportEmail != "" {
+		body += i18n.Sprintf(lang, "email.support", html.EscapeString(brand.SupportEmail))
+	}
+	
+	// SMTP settings can be reloaded at runtime; a tenant with its own server uses that instead
+	settings := s.live.Email()
+	if brand.Email.SMTPHost != "" {
+		settings = brand.Email
+	}
+	
+	messageID, err := newMessageID(settings.SenderEmail)
+	if err != nil {
+		return fmt.Errorf("failed to generate message ID: %w", err)
+	}
+	delivery.MessageID = messageID
+	
 	// Create a new message
 	m := gomail.NewMessage()
-	m.SetHeader("From", s.config.Email.SenderEmail)
+	m.SetHeader("Message-ID", "<"+messageID+">")
+	m.SetHeader("From", settings.SenderEmail)
 	m.SetHeader("To", to)
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body)
+	for _, attachment := range attachments {
+		content := attachment.Content
+		m.Attach(attachment.Name, gomail.SetCopyFunc(func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		}))
+	}
 	
 	// Create a new dialer
 	d := gomail.NewDialer(
-		s.config.Email.SMTPHost,
-		s.config.Email.SMTPPort,
-		s.config.Email.SMTPUser,
-		s.config.Email.SMTPPassword,
+		settings.SMTPHost,
+		settings.SMTPPort,
+		settings.SMTPUser,
+		settings.SMTPPassword,
 	)
 	
 	// Send the email
 	if err := d.DialAndSend(m); err != nil {
+		delivery.Status = models.EmailDeliveryStatusFailed
+		delivery.Response = err.Error()
+		if hardBounce(err) {
+			delivery.Status = models.EmailDeliveryStatusBounced
+		}
+		s.recordDelivery(ctx, delivery)
+		if delivery.Status == models.EmailDeliveryStatusBounced {
+			s.suppress(ctx, delivery.Recipient, models.EmailSuppressionReasonHardBounce, &delivery.ID, delivery.Response)
+		}
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 	
+	delivery.Status = models.EmailDeliveryStatusAccepted
+	s.recordDelivery(ctx, delivery)
+	
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,398 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// EscrowSvc is an implementation of the service.EscrowService interface
+type EscrowSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewEscrowService creates a new EscrowSvc
+func NewEscrowService(deps Dependencies) *EscrowSvc {
+	return &EscrowSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Create pays into escrow for a deal with the owner of a personal account. The buyer is debited at
+// once and the funds are held by the bank until both parties confirm the deal, an admin arbiter
+// settles it, or it expires and the buyer is refunded.
+func (s *EscrowSvc) Create(ctx context.Context, escrowCreate *models.EscrowCreate, userID int) (*models.Escrow, error) {
+	if err := escrowCreate.ValidateEscrowCreate(); err != nil {
+		return nil, fmt.Errorf("invalid escrow data: %w", err)
+	}
+
+	buyerAccount, err := s.repos.Account.GetByID(ctx, escrowCreate.SourceAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, buyerAccount, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	if !buyerAccount.IsActive {
+		return nil, errors.New("source account is inactive")
+	}
+
+	if buyerAccount.IsDormant() {
+		return nil, errors.New("source account is dormant, reactivate it first")
+	}
+
+	sellerAccount, err := s.repos.Account.GetByID(ctx, escrowCreate.DestinationAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination account: %w", err)
+	}
+
+	// The seller confirms the deal, so the funds can only go to an account of a single person
+	if sellerAccount.OrganizationID != nil {
+		return nil, errors.New("escrow can only be paid to personal accounts")
+	}
+
+	if sellerAccount.UserID == userID {
+		return nil, errors.New("buyer and seller must be different users")
+	}
+
+	if !sellerAccount.IsActive {
+		return nil, errors.New("destination account is inactive")
+	}
+
+	if sellerAccount.Currency != buyerAccount.Currency {
+		return nil, errors.New("source and destination accounts must have the same currency")
+	}
+
+	if buyerAccount.AvailableBalance < escrowCreate.Amount {
+		return nil, errors.New("insufficient funds")
+	}
+
+	escrow := &models.Escrow{
+		BuyerID:         userID,
+		SellerID:        sellerAccount.UserID,
+		BuyerAccountID:  buyerAccount.ID,
+		SellerAccountID: sellerAccount.ID,
+		Amount:          escrowCreate.Amount,
+		Currency:        buyerAccount.Currency,
+		Description:     escrowCreate.Description,
+		Status:          models.EscrowStatusFunded,
+		ExpiresAt:       time.Now().AddDate(0, 0, s.config.Escrow.TimeoutDays),
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Debit the buyer; the bank holds the funds until the deal is settled
+	funding := &models.Transaction{
+		TransactionType: models.TransactionTypeEscrow,
+		SourceAccountID: &buyerAccount.ID,
+		Amount:          escrow.Amount,
+		Currency:        escrow.Currency,
+		Description:     fmt.Sprintf("Escrow payment to %s: %s", sellerAccount.AccountNumber, escrow.Description),
+		Status:          models.TransactionStatusCompleted,
+		TransactionDate: time.Now(),
+	}
+
+	escrow.FundingTransactionID, err = s.moveFundsTx(ctx, tx, funding)
+	if err != nil {
+		return nil, err
+	}
+
+	escrow.ID, err = s.repos.Escrow.CreateTx(ctx, tx, escrow)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Escrow %d of %f %s funded by user %d from account %d for user %d",
+		escrow.ID, escrow.Amount, escrow.Currency, userID, buyerAccount.ID, escrow.SellerID)
+
+	s.notify(escrow.SellerID, "escrow_created",
+		escrow.ID, escrow.Amount, escrow.Currency, escrow.Description, escrow.ExpiresAt.Format("2006-01-02 15:04"))
+
+	return s.repos.Escrow.GetByID(ctx, escrow.ID)
+}
+
+// GetByID gets an escrow the user is a party of
+func (s *EscrowSvc) GetByID(ctx context.Context, id int, userID int) (*models.Escrow, error) {
+	escrow, err := s.repos.Escrow.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+
+	if !escrow.IsParty(userID) {
+		return nil, errors.New("access denied: escrow belongs to other users")
+	}
+
+	return escrow, nil
+}
+
+// GetByUserID gets the escrows the user is the buyer or the seller of
+func (s *EscrowSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Escrow, error) {
+	escrows, err := s.repos.Escrow.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrows: %w", err)
+	}
+
+	return escrows, nil
+}
+
+// Confirm records that a party considers the deal done. Once both the buyer and the seller have
+// confirmed, the funds are released to the seller.
+func (s *EscrowSvc) Confirm(ctx context.Context, id int, userID int) (*models.Escrow, error) {
+	escrow, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if escrow.Status != models.EscrowStatusFunded {
+		return nil, errors.New("escrow has already been settled")
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	confirmed, err := s.repos.Escrow.ConfirmTx(ctx, tx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmed == nil {
+		err = errors.New("escrow has expired or has already been settled")
+		return nil, err
+	}
+
+	released := confirmed.BuyerConfirmedAt != nil && confirmed.SellerConfirmedAt != nil
+	if released {
+		if err = s.settleTx(ctx, tx, confirmed, models.EscrowStatusReleased, nil, "Confirmed by both parties"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Escrow %d confirmed by user %d", id, userID)
+
+	if released {
+		s.notifyParties(confirmed, models.EscrowStatusReleased, "")
+	} else {
+		counterparty := confirmed.SellerID
+		if userID == confirmed.SellerID {
+			counterparty = confirmed.BuyerID
+		}
+		s.notify(counterparty, "escrow_confirmed", confirmed.ID, confirmed.Amount, confirmed.Currency)
+	}
+
+	return s.repos.Escrow.GetByID(ctx, id)
+}
+
+// GetAll gets the escrows in a status for arbitration, all escrows if the status is empty
+func (s *EscrowSvc) GetAll(ctx context.Context, status models.EscrowStatus) ([]*models.Escrow, error) {
+	if status != "" && !status.IsValid() {
+		return nil, errors.New("status must be one of FUNDED, RELEASED, REFUNDED")
+	}
+
+	escrows, err := s.repos.Escrow.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrows: %w", err)
+	}
+
+	return escrows, nil
+}
+
+// Resolve records an admin arbiter's decision on a deal the parties do not agree on: the funds
+// are released to the seller or refunded to the buyer without waiting for the confirmations
+func (s *EscrowSvc) Resolve(ctx context.Context, id int, resolution *models.EscrowResolution, adminID int) (*models.Escrow, error) {
+	status, err := resolution.ValidateEscrowResolution()
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow resolution: %w", err)
+	}
+
+	escrow, err := s.repos.Escrow.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+
+	if err := s.settle(ctx, escrow, status, &adminID, resolution.Note); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Escrow %d resolved as %s by admin %d", id, status, adminID)
+
+	return s.repos.Escrow.GetByID(ctx, id)
+}
+
+// RefundExpired refunds the buyers of the funded escrows that were not settled in time
+func (s *EscrowSvc) RefundExpired(ctx context.Context) error {
+	escrows, err := s.repos.Escrow.GetExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get expired escrows: %w", err)
+	}
+
+	s.logger.Infof("Found %d expired escrows", len(escrows))
+
+	for _, escrow := range escrows {
+		err := s.settle(ctx, escrow, models.EscrowStatusRefunded, nil, "Deal was not confirmed in time")
+		if err != nil {
+			s.logger.Warnf("Failed to refund escrow %d: %v", escrow.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// settle releases or refunds a funded escrow in its own transaction and notifies both parties
+func (s *EscrowSvc) settle(ctx context.Context, escrow *models.Escrow, to models.EscrowStatus, resolvedBy *int, note string) error {
+	if escrow.Status != models.EscrowStatusFunded {
+		return errors.New("escrow has already been settled")
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = s.settleTx(ctx, tx, escrow, to, resolvedBy, note); err != nil {
+		return err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.notifyParties(escrow, to, note)
+
+	return nil
+}
+
+// settleTx credits the held funds to the seller or back to the buyer and moves the escrow to its
+// final status within an existing transaction
+func (s *EscrowSvc) settleTx(ctx context.Context, tx *sql.Tx, escrow *models.Escrow, to models.EscrowStatus, resolvedBy *int, note string) error {
+	accountID := escrow.SellerAccountID
+	description := fmt.Sprintf("Escrow #%d released: %s", escrow.ID, escrow.Description)
+	if to == models.EscrowStatusRefunded {
+		accountID = escrow.BuyerAccountID
+		description = fmt.Sprintf("Escrow #%d refunded: %s", escrow.ID, escrow.Description)
+	}
+
+	settlement := &models.Transaction{
+		TransactionType:      models.TransactionTypeEscrow,
+		DestinationAccountID: &accountID,
+		Amount:               escrow.Amount,
+		Currency:             escrow.Currency,
+		Description:          description,
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      time.Now(),
+	}
+
+	settlementTxID, err := s.moveFundsTx(ctx, tx, settlement)
+	if err != nil {
+		return err
+	}
+
+	settled, err := s.repos.Escrow.SettleTx(ctx, tx, escrow.ID, to, settlementTxID, resolvedBy, note)
+	if err != nil {
+		return err
+	}
+
+	if !settled {
+		return errors.New("escrow has already been settled")
+	}
+
+	return nil
+}
+
+// moveFundsTx applies a transaction to the balances of its accounts and records it
+func (s *EscrowSvc) moveFundsTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (*int, error) {
+	if transaction.SourceAccountID != nil {
+		if err := s.repos.Account.UpdateBalanceTx(ctx, tx, *transaction.SourceAccountID, -transaction.Amount); err != nil {
+			return nil, fmt.Errorf("failed to update account %d balance: %w", *transaction.SourceAccountID, err)
+		}
+	}
+
+	if transaction.DestinationAccountID != nil {
+		if err := s.repos.Account.UpdateBalanceTx(ctx, tx, *transaction.DestinationAccountID, transaction.Amount); err != nil {
+			return nil, fmt.Errorf("failed to update account %d balance: %w", *transaction.DestinationAccountID, err)
+		}
+	}
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	return &transactionID, nil
+}
+
+// notifyParties tells the buyer and the seller how an escrow was settled
+func (s *EscrowSvc) notifyParties(escrow *models.Escrow, to models.EscrowStatus, note string) {
+	for _, userID := range []int{escrow.BuyerID, escrow.SellerID} {
+		if to == models.EscrowStatusReleased {
+			s.notify(userID, "escrow_released", escrow.ID, escrow.Amount, escrow.Currency)
+		} else {
+			s.notify(userID, "escrow_refunded", escrow.ID, escrow.Amount, escrow.Currency, note)
+		}
+	}
+}
+
+// notify sends an in-app escrow notification in the background
+func (s *EscrowSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("escrow-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeEscrow, template, args...); err != nil {
+			return fmt.Errorf("failed to send escrow notification: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// FeeSvc is an implementation of the service.FeeService interface
+type FeeSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	bank          *time.Location
+}
+
+// NewFeeService creates a new FeeSvc
+func NewFeeService(deps Dependencies) *FeeSvc {
+	return &FeeSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		bank:          deps.Bank,
+	}
+}
+
+// GetCharges gets the recurring fee charges in a status, or all of them if status is empty
+func (s *FeeSvc) GetCharges(ctx context.Context, status models.FeeChargeStatus) ([]*models.FeeCharge, error) {
+	switch status {
+	case "", models.FeeChargeStatusPending, models.FeeChargeStatusCharged, models.FeeChargeStatusDunning:
+	default:
+		return nil, errors.New("status must be one of PENDING, CHARGED, DUNNING")
+	}
+
+	return s.repos.Fee.GetCharges(ctx, status)
+}
+
+// GetRuns gets the summaries of the most recent runs of the recurring fee job
+func (s *FeeSvc) GetRuns(ctx context.Context) ([]*models.FeeRun, error) {
+	return s.repos.Fee.GetRuns(ctx, models.MaxFeeRuns)
+}
+
+// ChargeRecurring assesses last month's service fees of the active cards, then charges every
+// pending fee, including the plan fees the account plan billing assessed. A fee the account cannot
+// cover puts the charge in dunning; it is retried on every run until the account is topped up.
+// The run is summarized for admins.
+func (s *FeeSvc) ChargeRecurring(ctx context.Context) error {
+	run := &models.FeeRun{StartedAt: time.Now(), Totals: []*models.FeeTotals{}}
+
+	if err := s.assessCardFees(ctx, run.StartedAt.In(s.bank)); err != nil {
+		return err
+	}
+
+	charges, err := s.repos.Fee.GetCollectable(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, charge := range charges {
+		if charge.Status == models.FeeChargeStatusPending {
+			run.Assessed++
+		}
+		if err := s.collect(ctx, charge); err != nil {
+			s.logger.Errorf("Failed to charge fee %d of account %d: %v", charge.ID, charge.AccountID, err)
+			run.Failed++
+			continue
+		}
+		run.Add(charge)
+	}
+
+	run.FinishedAt = time.Now()
+	if _, err := s.repos.Fee.CreateRun(ctx, run); err != nil {
+		return err
+	}
+
+	s.logger.Infof("Recurring fees: %d new, %d charged, %d in dunning, %d failed", run.Assessed, run.Charged, run.Dunning, run.Failed)
+
+	return nil
+}
+
+// assessCardFees creates the charges of last month's service fees of the active cards whose
+// product has one, prorated for cards issued during the month
+func (s *FeeSvc) assessCardFees(ctx context.Context, now time.Time) error {
+	until, _ := monthBounds(now)
+	from := until.AddDate(0, -1, 0)
+
+	cards, err := s.repos.Card.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	products := map[int]*models.CardProduct{}
+	for _, card := range cards {
+		if !card.IsActive || !card.CreatedAt.Before(until) {
+			continue
+		}
+
+		product, ok := products[card.ProductID]
+		if !ok {
+			if product, err = s.repos.CardProduct.GetByID(ctx, card.ProductID); err != nil {
+				return fmt.Errorf("failed to get card product: %w", err)
+			}
+			products[card.ProductID] = product
+		}
+
+		start := from
+		if card.CreatedAt.After(start) {
+			start = card.CreatedAt
+		}
+		amount := product.Fees.MonthlyBetween(start, until)
+		if amount == 0 {
+			continue
+		}
+
+		account, err := s.repos.Account.GetByID(ctx, card.AccountID)
+		if err != nil {
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		cardID := card.ID
+		_, err = s.repos.Fee.CreateCharge(ctx, &models.FeeCharge{
+			Kind:        models.FeeKindCardService,
+			ReferenceID: card.ID,
+			AccountID:   account.ID,
+			CardID:      &cardID,
+			PeriodStart: from,
+			PeriodEnd:   until,
+			Amount:      amount,
+			Currency:    account.Currency,
+			Description: fmt.Sprintf("%s card %d service fee, %s", product.Name, card.ID, feeSpan(start, until)),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collect charges a fee to its account as a completed FEE transaction, or puts it in dunning if
+// the account's available balance does not cover it
+func (s *FeeSvc) collect(ctx context.Context, charge *models.FeeCharge) (err error) {
+	account, err := s.repos.Account.GetByID(ctx, charge.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if account.AvailableBalance < charge.Amount {
+		if err := s.repos.Fee.MarkDunning(ctx, charge.ID, "insufficient funds"); err != nil {
+			return err
+		}
+		if charge.Status == models.FeeChargeStatusPending {
+			s.notify(account.UserID, "fee_dunning", charge.Description, charge.Amount, charge.Currency, account.AccountNumber)
+		}
+		charge.Status = models.FeeChargeStatusDunning
+		return nil
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -charge.Amount); err != nil {
+		return fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, &models.Transaction{
+		TransactionType: models.TransactionTypeFee,
+		SourceAccountID: &account.ID,
+		Amount:          charge.Amount,
+		Currency:        charge.Currency,
+		Description:     charge.Description,
+		Status:          models.TransactionStatusCompleted,
+		CardID:          charge.CardID,
+		TransactionDate: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create fee transaction: %w", err)
+	}
+
+	if err = s.repos.Fee.MarkChargedTx(ctx, tx, charge.ID, transactionID); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	charge.Status = models.FeeChargeStatusCharged
+	s.logger.Infof("Fee %d of %.2f %s charged to account %d: %s", charge.ID, charge.Amount, charge.Currency, account.ID, charge.Description)
+
+	return nil
+}
+
+// notify sends a fee notification in the background
+func (s *FeeSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("fee-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeAccount, template, args...); err != nil {
+			return fmt.Errorf("failed to send fee notification: %w", err)
+		}
+		return nil
+	})
+}
+
+// feeSpan formats the days a fee is charged for
+func feeSpan(from, until time.Time) string {
+	return fmt.Sprintf("%s - %s", from.Format("02.01.2006"), until.Add(-time.Second).Format("02.01.2006"))
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+)
+
+// ImpersonationSvc is an implementation of the service.ImpersonationService interface
+type ImpersonationSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	jwt    *crypto.JWTKeys
+}
+
+// NewImpersonationService creates a new ImpersonationSvc
+func NewImpersonationService(deps Dependencies) *ImpersonationSvc {
+	return &ImpersonationSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		jwt:    deps.JWT,
+	}
+}
+
+// Start issues a support employee a read-only token to act as a customer. The token carries both
+// users, is bound to the employee's session and expires with it at the latest.
+func (s *ImpersonationSvc) Start(ctx context.Context, staffID int, sessionID string, request *models.ImpersonationStart) (*models.ImpersonationToken, error) {
+	if err := request.ValidateImpersonationStart(); err != nil {
+		return nil, fmt.Errorf("invalid impersonation: %w", err)
+	}
+
+	if request.CustomerID == staffID {
+		return nil, errors.New("cannot impersonate yourself")
+	}
+
+	customer, err := s.repos.User.GetByID(ctx, request.CustomerID)
+	if err != nil {
+		return nil, errors.New("customer not found")
+	}
+	if customer.Role != "" && customer.Role != models.UserRoleCustomer {
+		return nil, errors.New("only customers can be impersonated")
+	}
+
+	session, err := s.repos.Session.GetBySessionID(ctx, sessionID)
+	if err != nil || session.UserID != staffID || !session.IsActive(time.Now()) {
+		return nil, errors.New("session not found")
+	}
+
+	expiresAt := time.Now().Add(time.Duration(request.ExpiresIn) * time.Second)
+	if session.ExpiresAt.Before(expiresAt) {
+		expiresAt = session.ExpiresAt
+	}
+
+	impersonation := &models.Impersonation{
+		StaffID:    staffID,
+		CustomerID: customer.ID,
+		Reason:     request.Reason,
+		SessionID:  sessionID,
+		ExpiresAt:  expiresAt,
+	}
+	if _, err := s.repos.Impersonation.Create(ctx, impersonation); err != nil {
+		return nil, err
+	}
+
+	// The token is the customer's, marked with the impersonation and the employee behind it
+	role := customer.Role
+	if role == "" {
+		role = models.UserRoleCustomer
+	}
+	tokenString, err := s.jwt.Sign(jwt.MapClaims{
+		"user_id":          customer.ID,
+		"role":             string(role),
+		"tenant":           customer.Tenant,
+		"sid":              sessionID,
+		"exp":              expiresAt.Unix(),
+		"impersonation_id": impersonation.ID,
+		"impersonator_id":  staffID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.logger.Warnf("Support employee %d started impersonation %d of customer %d until %s: %s",
+		staffID, impersonation.ID, customer.ID, expiresAt.Format(time.RFC3339), impersonation.Reason)
+
+	return &models.ImpersonationToken{
+		Token:         tokenString,
+		ExpiresAt:     expiresAt.Unix(),
+		Impersonation: impersonation,
+	}, nil
+}
+
+// End revokes the token of an impersonation before it expires. Only the employee who started the
+// impersonation can end it.
+func (s *ImpersonationSvc) End(ctx context.Context, id int, staffID int) (*models.Impersonation, error) {
+	impersonation, err := s.repos.Impersonation.GetByID(ctx, id)
+	if err != nil || impersonation.StaffID != staffID {
+		return nil, errors.New("impersonation not found")
+	}
+
+	ended, err := s.repos.Impersonation.End(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ended {
+		return nil, errors.New("impersonation has already ended")
+	}
+
+	s.logger.Infof("Support employee %d ended impersonation %d of customer %d", staffID, id, impersonation.CustomerID)
+
+	return s.Get(ctx, id)
+}
+
+// Audit records a request made with an impersonation token. It refuses the request, without
+// recording it, once the impersonation has ended or expired.
+func (s *ImpersonationSvc) Audit(ctx context.Context, request *models.ImpersonationRequest) error {
+	impersonation, err := s.repos.Impersonation.GetByID(ctx, request.ImpersonationID)
+	if err != nil {
+		return errors.New("impersonation not found")
+	}
+
+	if !impersonation.IsActive(time.Now()) {
+		return errors.New("impersonation has ended")
+	}
+
+	if _, err := s.repos.Impersonation.AddRequest(ctx, request); err != nil {
+		return fmt.Errorf("failed to audit impersonation request: %w", err)
+	}
+
+	return nil
+}
+
+// Get gets an impersonation with the audit log of its requests
+func (s *ImpersonationSvc) Get(ctx context.Context, id int) (*models.Impersonation, error) {
+	impersonation, err := s.repos.Impersonation.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonation.Requests, err = s.repos.Impersonation.GetRequests(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return impersonation, nil
+}
+
+// GetAll gets the impersonations by an employee and of a customer, newest first; a zero ID matches
+// everyone
+func (s *ImpersonationSvc) GetAll(ctx context.Context, staffID int, customerID int) ([]*models.Impersonation, error) {
+	return s.repos.Impersonation.GetAll(ctx, staffID, customerID)
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// InternationalTransferSvc is an implementation of the service.InternationalTransferService interface
+type InternationalTransferSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	live          *configs.Live
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	bank          *time.Location
+}
+
+// NewInternationalTransferService creates a new InternationalTransferSvc
+func NewInternationalTransferService(deps Dependencies) *InternationalTransferSvc {
+	return &InternationalTransferSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		live:          deps.Live,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		bank:          deps.Bank,
+	}
+}
+
+// Quote calculates the fees and the amounts of an international transfer without sending it
+func (s *InternationalTransferSvc) Quote(ctx context.Context, transferCreate *models.InternationalTransferCreate, userID int) (*models.InternationalTransfer, error) {
+	transfer, _, err := s.prepare(ctx, transferCreate, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// Create sends money to an account at a foreign bank. The sender is debited the amount and the fees
+// they bear at once; the payout stays pending until the worker settles it after its settlement days.
+func (s *InternationalTransferSvc) Create(ctx context.Context, transferCreate *models.InternationalTransferCreate, userID int) (*models.InternationalTransfer, error) {
+	transfer, account, err := s.prepare(ctx, transferCreate, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.AvailableBalance < transfer.DebitedAmount {
+		return nil, errors.New("insufficient funds")
+	}
+
+	transfer.Reference, err = newUETR()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate transfer reference: %w", err)
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// The payout leaves for the correspondent bank and completes when the beneficiary is credited
+	payout := &models.Transaction{
+		TransactionType: models.TransactionTypeInternational,
+		SourceAccountID: &account.ID,
+		Amount:          transfer.PayoutAmount(),
+		Currency:        transfer.Currency,
+		Description: fmt.Sprintf("International transfer to %s, %s (%s): %s",
+			transfer.BeneficiaryName, transfer.BeneficiaryIBAN, transfer.ChargeBearer, transfer.Purpose),
+		Status:          models.TransactionStatusPending,
+		TransactionDate: time.Now(),
+	}
+
+	transfer.TransactionID, err = s.moveFundsTx(ctx, tx, payout)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Fee > 0 {
+		fee := &models.Transaction{
+			TransactionType: models.TransactionTypeFee,
+			SourceAccountID: &account.ID,
+			Amount:          transfer.Fee,
+			Currency:        transfer.Currency,
+			Description:     fmt.Sprintf("Fee for international transfer %s", transfer.Reference),
+			Status:          models.TransactionStatusCompleted,
+			TransactionDate: time.Now(),
+		}
+
+		transfer.FeeTransactionID, err = s.moveFundsTx(ctx, tx, fee)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transfer.ID, err = s.repos.InternationalTransfer.CreateTx(ctx, tx, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("International transfer %d (%s) of %.2f %s from account %d to %s submitted by user %d, %s",
+		transfer.ID, transfer.Reference, transfer.Amount, transfer.Currency, account.ID, transfer.BeneficiaryIBAN, userID, transfer.ChargeBearer)
+
+	return s.repos.InternationalTransfer.GetByID(ctx, transfer.ID)
+}
+
+// prepare validates an international transfer from one of the user's accounts and applies the
+// charges to it
+func (s *InternationalTransferSvc) prepare(ctx context.Context, transferCreate *models.InternationalTransferCreate, userID int) (*models.InternationalTransfer, *models.Account, error) {
+	if err := transferCreate.ValidateInternationalTransferCreate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid international transfer data: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, transferCreate.SourceAccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get source account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, nil, err
+	}
+
+	if !account.IsActive {
+		return nil, nil, errors.New("source account is inactive")
+	}
+
+	if account.IsDormant() {
+		return nil, nil, errors.New("source account is dormant, reactivate it first")
+	}
+
+	transfer := &models.InternationalTransfer{
+		UserID:             userID,
+		SourceAccountID:    account.ID,
+		BeneficiaryName:    transferCreate.BeneficiaryName,
+		BeneficiaryIBAN:    transferCreate.BeneficiaryIBAN,
+		BeneficiaryBIC:     transferCreate.BeneficiaryBIC,
+		BeneficiaryCountry: transferCreate.BeneficiaryIBAN[:2],
+		Amount:             transferCreate.Amount,
+		Currency:           account.Currency,
+		ChargeBearer:       transferCreate.ChargeBearer,
+		Purpose:            transferCreate.Purpose,
+		Status:             models.InternationalTransferStatusSubmitted,
+	}
+
+	international := s.config.International
+	if err := transfer.ApplyCharges(international.FeePercent, international.MinFee, international.CorrespondentFee); err != nil {
+		return nil, nil, fmt.Errorf("invalid international transfer data: %w", err)
+	}
+
+	// Payments leave the bank on the next business day and settle some business days later
+	calendar, _ := paymentCalendar(s.live)
+	transfer.DispatchAt = calendar.AddBusinessDays(models.BusinessDate(time.Now(), s.bank), 1)
+	transfer.ExpectedSettlementAt = calendar.AddBusinessDays(transfer.DispatchAt, international.SettlementDays)
+
+	return transfer, account, nil
+}
+
+// GetByID gets an international transfer the user sent
+func (s *InternationalTransferSvc) GetByID(ctx context.Context, id int, userID int) (*models.InternationalTransfer, error) {
+	transfer, err := s.repos.InternationalTransfer.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get international transfer: %w", err)
+	}
+
+	if transfer.UserID != userID {
+		return nil, errors.New("access denied: international transfer belongs to another user")
+	}
+
+	return transfer, nil
+}
+
+// GetByUserID gets the international transfers the user sent, newest first
+func (s *InternationalTransferSvc) GetByUserID(ctx context.Context, userID int) ([]*models.InternationalTransfer, error) {
+	transfers, err := s.repos.InternationalTransfer.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get international transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// Track shows where an international transfer the user sent is by its UETR
+func (s *InternationalTransferSvc) Track(ctx context.Context, reference string, userID int) (*models.InternationalTransferTracking, error) {
+	transfer, err := s.repos.InternationalTransfer.GetByReference(ctx, reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get international transfer: %w", err)
+	}
+
+	if transfer.UserID != userID {
+		return nil, errors.New("access denied: international transfer belongs to another user")
+	}
+
+	return transfer.Tracking(), nil
+}
+
+// GetAll gets the international transfers in a status, all transfers if the status is empty
+func (s *InternationalTransferSvc) GetAll(ctx context.Context, status models.InternationalTransferStatus) ([]*models.InternationalTransfer, error) {
+	if status != "" && !status.IsValid() {
+		return nil, errors.New("status must be one of SUBMITTED, IN_TRANSIT, SETTLED")
+	}
+
+	transfers, err := s.repos.InternationalTransfer.GetByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get international transfers: %w", err)
+	}
+
+	return transfers, nil
+}
+
+// Advance sends the submitted transfers due for dispatch to the correspondent bank and settles the
+// transfers in transit whose settlement days have passed. A transfer both are due for, because the
+// worker did not run for a while, is dispatched and settled in the same run.
+func (s *InternationalTransferSvc) Advance(ctx context.Context) error {
+	now := time.Now()
+
+	toDispatch, err := s.repos.InternationalTransfer.GetToDispatch(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get international transfers to dispatch: %w", err)
+	}
+
+	for _, transfer := range toDispatch {
+		if _, err := s.repos.InternationalTransfer.Dispatch(ctx, transfer.ID); err != nil {
+			s.logger.Warnf("Failed to dispatch international transfer %d: %v", transfer.ID, err)
+		}
+	}
+
+	toSettle, err := s.repos.InternationalTransfer.GetToSettle(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get international transfers to settle: %w", err)
+	}
+
+	s.logger.Infof("Dispatched %d and settling %d international transfers", len(toDispatch), len(toSettle))
+
+	for _, transfer := range toSettle {
+		if err := s.settle(ctx, transfer); err != nil {
+			s.logger.Warnf("Failed to settle international transfer %d: %v", transfer.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// settle completes the payout of a transfer in transit in its own transaction and notifies the sender
+func (s *InternationalTransferSvc) settle(ctx context.Context, transfer *models.InternationalTransfer) error {
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	settled, err := s.repos.InternationalTransfer.SettleTx(ctx, tx, transfer.ID)
+	if err != nil {
+		return err
+	}
+
+	if !settled {
+		err = errors.New("international transfer has already been settled")
+		return err
+	}
+
+	if transfer.TransactionID != nil {
+		if _, err = s.repos.Transaction.CompleteTx(ctx, tx, *transfer.TransactionID); err != nil {
+			return err
+		}
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("International transfer %d (%s) settled", transfer.ID, transfer.Reference)
+
+	s.lifecycle.Background("international-transfer-notification", func(ctx context.Context) error {
+		err := s.notifications.Notify(ctx, transfer.UserID, models.NotificationTypeTransfer, "international_transfer_settled",
+			transfer.Reference, transfer.CreditedAmount, transfer.Currency, transfer.BeneficiaryName)
+		if err != nil {
+			return fmt.Errorf("failed to send international transfer notification: %w", err)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// moveFundsTx debits the source account of a transaction and records it
+func (s *InternationalTransferSvc) moveFundsTx(ctx context.Context, tx *sql.Tx, transaction *models.Transaction) (*int, error) {
+	if err := s.repos.Account.UpdateBalanceTx(ctx, tx, *transaction.SourceAccountID, -transaction.Amount); err != nil {
+		return nil, fmt.Errorf("failed to update account %d balance: %w", *transaction.SourceAccountID, err)
+	}
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	return &transactionID, nil
+}
+
+// newUETR generates a unique end-to-end transaction reference: a random UUID, as SWIFT gpi uses
+func newUETR() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// InvoiceSvc is an implementation of the service.InvoiceService interface
+type InvoiceSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	transfers     TransactionService
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	publicURL     string
+	bank          *time.Location
+}
+
+// NewInvoiceService creates a new InvoiceSvc
+func NewInvoiceService(deps Dependencies) *InvoiceSvc {
+	return &InvoiceSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		transfers:     NewTransactionService(deps),
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		publicURL:     strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+		bank:          deps.Bank,
+	}
+}
+
+// Create issues an invoice to be paid to an account the user can make transfers from
+func (s *InvoiceSvc) Create(ctx context.Context, invoiceCreate *models.InvoiceCreate, userID int) (*models.Invoice, error) {
+	if err := invoiceCreate.ValidateInvoiceCreate(time.Now().In(s.bank)); err != nil {
+		return nil, fmt.Errorf("invalid invoice data: %w", err)
+	}
+
+	if invoiceCreate.AccountID <= 0 {
+		return nil, errors.New("account_id is required")
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, invoiceCreate.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	return s.issue(ctx, invoiceCreate, userID, nil, account)
+}
+
+// CreateForMerchant issues an invoice of a merchant, paid to its settlement account
+func (s *InvoiceSvc) CreateForMerchant(ctx context.Context, invoiceCreate *models.InvoiceCreate, merchantID int) (*models.Invoice, error) {
+	if err := invoiceCreate.ValidateInvoiceCreate(time.Now().In(s.bank)); err != nil {
+		return nil, fmt.Errorf("invalid invoice data: %w", err)
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, merchant.SettlementAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement account: %w", err)
+	}
+
+	return s.issue(ctx, invoiceCreate, merchant.UserID, &merchant.ID, account)
+}
+
+// issue stores an invoice paid to the account and notifies its recipient
+func (s *InvoiceSvc) issue(ctx context.Context, invoiceCreate *models.InvoiceCreate, issuerID int, merchantID *int, account *models.Account) (*models.Invoice, error) {
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	recipient, err := s.repos.User.GetByEmail(ctx, invoiceCreate.RecipientEmail)
+	if err != nil {
+		return nil, errors.New("recipient not found")
+	}
+
+	if recipient.ID == issuerID {
+		return nil, errors.New("you cannot issue an invoice to yourself")
+	}
+
+	number, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invoice number: %w", err)
+	}
+
+	invoice := &models.Invoice{
+		Number:         number,
+		IssuerID:       issuerID,
+		MerchantID:     merchantID,
+		RecipientID:    recipient.ID,
+		RecipientEmail: recipient.Email,
+		AccountID:      account.ID,
+		AccountNumber:  account.AccountNumber,
+		Amount:         invoiceCreate.Amount,
+		Currency:       account.Currency,
+		Description:    invoiceCreate.Description,
+		DueAt:          invoiceCreate.DueAt,
+		Status:         models.InvoiceStatusIssued,
+		Items:          invoiceCreate.Items,
+	}
+
+	if _, err := s.repos.Invoice.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Invoice %s of %f %s issued by user %d to user %d", invoice.Number, invoice.Amount,
+		invoice.Currency, issuerID, recipient.ID)
+
+	s.notify(recipient.ID, "invoice_issued", invoice.Number, invoice.Amount, invoice.Currency,
+		invoice.DueAt.AddDate(0, 0, -1).Format("2006-01-02"))
+
+	return s.withPaymentDetails(ctx, invoice), nil
+}
+
+// GetByNumber gets an invoice the user issued or has to pay, with its items and payment details
+func (s *InvoiceSvc) GetByNumber(ctx context.Context, number string, userID int) (*models.Invoice, error) {
+	invoice, err := s.repos.Invoice.GetByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if invoice.IssuerID != userID && invoice.RecipientID != userID {
+		return nil, errors.New("invoice not found")
+	}
+
+	return s.withPaymentDetails(ctx, invoice), nil
+}
+
+// GetIssued gets the invoices the user issued, directly or through a merchant
+func (s *InvoiceSvc) GetIssued(ctx context.Context, userID int) ([]*models.Invoice, error) {
+	invoices, err := s.repos.Invoice.GetByIssuerID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withPaymentLinks(invoices), nil
+}
+
+// GetReceived gets the invoices the user has been sent
+func (s *InvoiceSvc) GetReceived(ctx context.Context, userID int) ([]*models.Invoice, error) {
+	invoices, err := s.repos.Invoice.GetByRecipientID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withPaymentLinks(invoices), nil
+}
+
+// GetForMerchant gets the invoices a merchant issued
+func (s *InvoiceSvc) GetForMerchant(ctx context.Context, merchantID int) ([]*models.Invoice, error) {
+	invoices, err := s.repos.Invoice.GetByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withPaymentLinks(invoices), nil
+}
+
+// Pay pays an invoice sent to the user with a transfer from one of their accounts. The transfer
+// follows the usual rules: high-value payments wait for a one-time code and organization payments
+// for approvals, and the invoice is marked paid when the transfer is executed.
+func (s *InvoiceSvc) Pay(ctx context.Context, number string, payRequest *models.InvoicePayRequest, userID int) (*models.TransferResult, error) {
+	invoice, err := s.repos.Invoice.GetByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if invoice.RecipientID != userID {
+		return nil, errors.New("invoice not found")
+	}
+
+	if !invoice.Status.IsPayable() {
+		return nil, errors.New("invoice is no longer payable")
+	}
+
+	transfer := &models.TransferRequest{
+		SourceAccountID:      payRequest.AccountID,
+		DestinationAccountID: invoice.AccountID,
+		Amount:               invoice.Amount,
+		Description:          s.purpose(invoice),
+		InvoiceID:            &invoice.ID,
+	}
+
+	return s.transfers.Transfer(ctx, transfer, userID)
+}
+
+// Cancel cancels an unpaid invoice the user issued
+func (s *InvoiceSvc) Cancel(ctx context.Context, number string, userID int) error {
+	invoice, err := s.repos.Invoice.GetByNumber(ctx, number)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if invoice.IssuerID != userID {
+		return errors.New("invoice not found")
+	}
+
+	return s.cancel(ctx, invoice)
+}
+
+// CancelForMerchant cancels an unpaid invoice a merchant issued
+func (s *InvoiceSvc) CancelForMerchant(ctx context.Context, number string, merchantID int) error {
+	invoice, err := s.repos.Invoice.GetByNumber(ctx, number)
+	if err != nil {
+		return fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	if invoice.MerchantID == nil || *invoice.MerchantID != merchantID {
+		return errors.New("invoice not found")
+	}
+
+	return s.cancel(ctx, invoice)
+}
+
+// cancel cancels an invoice unless it has been paid or cancelled already and notifies its recipient
+func (s *InvoiceSvc) cancel(ctx context.Context, invoice *models.Invoice) error {
+	cancelled, err := s.repos.Invoice.Cancel(ctx, invoice.ID)
+	if err != nil {
+		return err
+	}
+
+	if !cancelled {
+		return errors.New("only unpaid invoices can be cancelled")
+	}
+
+	s.logger.Infof("Invoice %s cancelled", invoice.Number)
+
+	s.notify(invoice.RecipientID, "invoice_cancelled", invoice.Number, invoice.Amount, invoice.Currency)
+
+	return nil
+}
+
+// MarkOverdue flags the invoices not paid by their due date and reminds both parties
+func (s *InvoiceSvc) MarkOverdue(ctx context.Context) error {
+	invoices, err := s.repos.Invoice.MarkOverdue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Marked %d invoices overdue", len(invoices))
+
+	for _, invoice := range invoices {
+		for _, userID := range []int{invoice.IssuerID, invoice.RecipientID} {
+			s.notify(userID, "invoice_overdue", invoice.Number, invoice.Amount, invoice.Currency)
+		}
+	}
+
+	return nil
+}
+
+// withPaymentLinks sets the payment links of invoices
+func (s *InvoiceSvc) withPaymentLinks(invoices []*models.Invoice) []*models.Invoice {
+	for _, invoice := range invoices {
+		invoice.PaymentLink = s.publicURL + "/api/invoices/" + invoice.Number
+	}
+	return invoices
+}
+
+// withPaymentDetails sets the payment link of an invoice and, for rouble invoices when the bank
+// details are configured, the ST00012 payment string banking apps read from QR codes
+func (s *InvoiceSvc) withPaymentDetails(ctx context.Context, invoice *models.Invoice) *models.Invoice {
+	s.withPaymentLinks([]*models.Invoice{invoice})
+
+	bank := s.config.Bank
+	if bank.BIC == "" || invoice.Currency != models.CurrencyRUB {
+		return invoice
+	}
+
+	payee, err := s.payeeName(ctx, invoice)
+	if err != nil {
+		s.logger.Warnf("Failed to get the payee of invoice %s: %v", invoice.Number, err)
+		return invoice
+	}
+
+	fields := []string{
+		"ST00012",
+		"Name=" + payee,
+		"PersonalAcc=" + invoice.AccountNumber,
+		"BankName=" + bank.Name,
+		"BIC=" + bank.BIC,
+		"CorrespAcc=" + bank.CorrespondentAccount,
+		fmt.Sprintf("Sum=%d", int64(math.Round(invoice.Amount*100))),
+		"Purpose=" + s.purpose(invoice),
+	}
+	for i, field := range fields {
+		fields[i] = strings.ReplaceAll(field, "|", " ")
+	}
+	invoice.QRPayload = strings.Join(fields, "|")
+
+	return invoice
+}
+
+// payeeName gets the name of the merchant or the user an invoice is paid to
+func (s *InvoiceSvc) payeeName(ctx context.Context, invoice *models.Invoice) (string, error) {
+	if invoice.MerchantID != nil {
+		merchant, err := s.repos.Merchant.GetByID(ctx, *invoice.MerchantID)
+		if err != nil {
+			return "", err
+		}
+		return merchant.Name, nil
+	}
+
+	issuer, err := s.repos.User.GetByID(ctx, invoice.IssuerID)
+	if err != nil {
+		return "", err
+	}
+	if name := strings.TrimSpace(issuer.FirstName + " " + issuer.LastName); name != "" {
+		return name, nil
+	}
+	return issuer.Username, nil
+}
+
+// purpose describes the payment of an invoice in its transfer and QR code
+func (s *InvoiceSvc) purpose(invoice *models.Invoice) string {
+	if invoice.Description == "" {
+		return "Invoice " + invoice.Number
+	}
+	return "Invoice " + invoice.Number + ": " + invoice.Description
+}
+
+// notify sends an in-app invoice notification in the background
+func (s *InvoiceSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("invoice-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeInvoice, template, args...); err != nil {
+			return fmt.Errorf("failed to send invoice notification: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// LocationSvc is an implementation of the service.LocationService interface
+type LocationSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+}
+
+// NewLocationService creates a new LocationSvc
+func NewLocationService(deps Dependencies) *LocationSvc {
+	return &LocationSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+	}
+}
+
+// GetNearby gets the active branches and ATMs around a point, nearest first
+func (s *LocationSvc) GetNearby(ctx context.Context, query *models.LocationQuery) ([]*models.Location, error) {
+	return s.repos.Location.GetNearby(ctx, query)
+}
+
+// GetAll gets all locations for the admin
+func (s *LocationSvc) GetAll(ctx context.Context) ([]*models.Location, error) {
+	return s.repos.Location.GetAll(ctx)
+}
+
+// Create adds a branch or ATM
+func (s *LocationSvc) Create(ctx context.Context, request *models.LocationRequest) (*models.Location, error) {
+	if err := request.ValidateLocationRequest(); err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	location := request.ToLocation()
+	if _, err := s.repos.Location.Create(ctx, location); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Location %d created: %s %s", location.ID, location.Type, location.Name)
+
+	return location, nil
+}
+
+// Update replaces the data of a branch or ATM
+func (s *LocationSvc) Update(ctx context.Context, id int, request *models.LocationRequest) (*models.Location, error) {
+	if err := request.ValidateLocationRequest(); err != nil {
+		return nil, fmt.Errorf("invalid location: %w", err)
+	}
+
+	location := request.ToLocation()
+	location.ID = id
+	if err := s.repos.Location.Update(ctx, location); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Location %d updated", id)
+
+	return location, nil
+}
+
+// Delete removes a branch or ATM
+func (s *LocationSvc) Delete(ctx context.Context, id int) error {
+	if err := s.repos.Location.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Infof("Location %d deleted", id)
+
+	return nil
+}
@@ -0,0 +1,499 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
+)
+
+// merchantKeyPrefix marks merchant API keys so they are recognisable in logs and secret scanners
+const merchantKeyPrefix = "mk_"
+
+// MerchantSvc is an implementation of the service.MerchantService interface
+type MerchantSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	email     EmailService
+	lifecycle *lifecycle.Manager
+	bank      *time.Location
+}
+
+// NewMerchantService creates a new MerchantSvc
+func NewMerchantService(deps Dependencies) *MerchantSvc {
+	return &MerchantSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		email:     NewEmailService(deps),
+		lifecycle: deps.Lifecycle,
+		bank:      deps.Bank,
+	}
+}
+
+// Create registers a merchant owned by the user and returns its API key, which is only shown once
+func (s *MerchantSvc) Create(ctx context.Context, merchantCreate *models.MerchantCreate, userID int) (*models.MerchantCredentials, error) {
+	if err := merchantCreate.ValidateMerchantCreate(); err != nil {
+		return nil, fmt.Errorf("invalid merchant data: %w", err)
+	}
+
+	// Payouts go to an account the user can manage
+	account, err := s.repos.Account.GetByID(ctx, merchantCreate.SettlementAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return nil, err
+	}
+
+	if !account.IsActive {
+		return nil, errors.New("settlement account is inactive")
+	}
+
+	if account.Currency != models.CurrencyRUB {
+		return nil, errors.New("settlement account must be a RUB account")
+	}
+
+	apiKey, hash, err := newMerchantAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	merchant := merchantCreate.ToMerchant(userID)
+	merchant.APIKeyHash = hash
+	merchant.APIKeyPrefix = apiKey[:len(merchantKeyPrefix)+8]
+
+	if _, err := s.repos.Merchant.Create(ctx, merchant); err != nil {
+		return nil, fmt.Errorf("failed to create merchant: %w", err)
+	}
+
+	s.logger.Infof("Merchant created: %d by user: %d", merchant.ID, userID)
+
+	return &models.MerchantCredentials{Merchant: merchant, APIKey: apiKey}, nil
+}
+
+// GetByID gets a merchant owned by the user
+func (s *MerchantSvc) GetByID(ctx context.Context, id int, userID int) (*models.Merchant, error) {
+	merchant, err := s.repos.Merchant.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	if merchant.UserID != userID {
+		return nil, errors.New("access denied: merchant belongs to another user")
+	}
+
+	return merchant, nil
+}
+
+// GetByUserID gets the merchants owned by the user
+func (s *MerchantSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Merchant, error) {
+	merchants, err := s.repos.Merchant.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchants: %w", err)
+	}
+
+	return merchants, nil
+}
+
+// RotateAPIKey replaces the API key of a merchant; the old key stops working immediately
+func (s *MerchantSvc) RotateAPIKey(ctx context.Context, id int, userID int) (*models.MerchantCredentials, error) {
+	merchant, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, hash, err := newMerchantAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	merchant.APIKeyHash = hash
+	merchant.APIKeyPrefix = apiKey[:len(merchantKeyPrefix)+8]
+
+	if err := s.repos.Merchant.UpdateAPIKey(ctx, id, merchant.APIKeyHash, merchant.APIKeyPrefix); err != nil {
+		return nil, fmt.Errorf("failed to rotate API key: %w", err)
+	}
+
+	s.logger.Infof("Merchant %d API key rotated by user: %d", id, userID)
+
+	return &models.MerchantCredentials{Merchant: merchant, APIKey: apiKey}, nil
+}
+
+// GetSettlements gets the settlement batches of a merchant owned by the user
+func (s *MerchantSvc) GetSettlements(ctx context.Context, id int, userID int) ([]*models.SettlementBatch, error) {
+	if _, err := s.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	batches, err := s.repos.Settlement.GetByMerchantID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlements: %w", err)
+	}
+
+	return batches, nil
+}
+
+// Authenticate resolves an active merchant from its API key
+func (s *MerchantSvc) Authenticate(ctx context.Context, apiKey string) (*models.Merchant, error) {
+	merchant, err := s.repos.Merchant.GetByAPIKeyHash(ctx, hashMerchantAPIKey(apiKey))
+	if err != nil {
+		return nil, errors.New("invalid API key")
+	}
+
+	if !merchant.IsActive {
+		return nil, errors.New("merchant is inactive")
+	}
+
+	return merchant, nil
+}
+
+// CreateIntent creates a payment intent the merchant's customer can pay within the configured TTL
+func (s *MerchantSvc) CreateIntent(ctx context.Context, merchantID int, intentCreate *models.PaymentIntentCreate) (*models.PaymentIntent, error) {
+	if err := intentCreate.ValidatePaymentIntentCreate(); err != nil {
+		return nil, fmt.Errorf("invalid payment intent: %w", err)
+	}
+
+	intentID, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate payment intent ID: %w", err)
+	}
+
+	expiresAt := time.Now().Add(time.Duration(s.config.Merchant.IntentTTL) * time.Second)
+	intent := intentCreate.ToPaymentIntent(merchantID, "pi_"+intentID, expiresAt)
+
+	if _, err := s.repos.PaymentIntent.Create(ctx, intent); err != nil {
+		return nil, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	s.logger.Infof("Payment intent %s of %f created by merchant: %d", intent.IntentID, intent.Amount, merchantID)
+
+	return s.repos.PaymentIntent.GetByIntentID(ctx, intent.IntentID)
+}
+
+// GetIntents gets the payment intents of a merchant
+func (s *MerchantSvc) GetIntents(ctx context.Context, merchantID int) ([]*models.PaymentIntent, error) {
+	intents, err := s.repos.PaymentIntent.GetByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intents: %w", err)
+	}
+
+	return intents, nil
+}
+
+// GetIntent gets a payment intent of the merchant
+func (s *MerchantSvc) GetIntent(ctx context.Context, merchantID int, intentID string) (*models.PaymentIntent, error) {
+	intent, err := s.repos.PaymentIntent.GetByIntentID(ctx, intentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	if intent.MerchantID != merchantID {
+		return nil, errors.New("payment intent not found")
+	}
+
+	return intent, nil
+}
+
+// CancelIntent cancels a payment intent that has not been paid yet
+func (s *MerchantSvc) CancelIntent(ctx context.Context, merchantID int, intentID string) error {
+	intent, err := s.GetIntent(ctx, merchantID, intentID)
+	if err != nil {
+		return err
+	}
+
+	cancelled, err := s.repos.PaymentIntent.UpdateStatus(ctx, intent.ID, models.PaymentIntentStatusCreated, models.PaymentIntentStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to cancel payment intent: %w", err)
+	}
+
+	if !cancelled {
+		return i18n.Errorf("payment_intent_is_already", string(intent.Status))
+	}
+
+	s.logger.Infof("Payment intent %s cancelled by merchant: %d", intentID, merchantID)
+
+	return nil
+}
+
+// GetIntentForCustomer gets a payment intent as shown to a paying customer
+func (s *MerchantSvc) GetIntentForCustomer(ctx context.Context, intentID string, userID int) (*models.PaymentIntent, error) {
+	intent, err := s.repos.PaymentIntent.GetByIntentID(ctx, intentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	// Only the paying customer sees who paid and from which account
+	if intent.CustomerID == nil || *intent.CustomerID != userID {
+		intent.CustomerID = nil
+		intent.SourceAccountID = nil
+		intent.TransactionID = nil
+	}
+	intent.SettlementBatchID = nil
+
+	return intent, nil
+}
+
+// PayIntent pays a payment intent from one of the customer's accounts. The merchant
+// receives the money in its next daily settlement batch.
+func (s *MerchantSvc) PayIntent(ctx context.Context, intentID string, payRequest *models.PaymentIntentPayRequest, userID int) (*models.PaymentIntent, error) {
+	intent, err := s.repos.PaymentIntent.GetByIntentID(ctx, intentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment intent: %w", err)
+	}
+
+	if !intent.IsPayable(time.Now()) {
+		return nil, errors.New("payment intent is no longer payable")
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, payRequest.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	if account.IsDormant() {
+		return nil, errors.New("account is dormant, reactivate it first")
+	}
+
+	if account.Currency != intent.Currency {
+		return nil, i18n.Errorf("payment_intent_must_be_paid_from_an_account_in", string(intent.Currency))
+	}
+
+	if account.AvailableBalance < intent.Amount {
+		return nil, errors.New("insufficient funds")
+	}
+
+	// Card payments must use an active card of the paying account
+	if payRequest.CardID != nil {
+		card, err := s.repos.Card.GetByID(ctx, *payRequest.CardID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card: %w", err)
+		}
+
+		if card.AccountID != account.ID {
+			return nil, errors.New("card does not belong to specified account")
+		}
+
+		if !card.IsActive {
+			return nil, errors.New("card is inactive")
+		}
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Deduct the payment from the customer's account
+	err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -intent.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	// Create transaction record
+	transaction := intent.ToTransaction(account.ID, payRequest.CardID)
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+
+	// Claim the intent; a concurrent payment or cancellation wins the race
+	paid, err := s.repos.PaymentIntent.MarkPaidTx(ctx, tx, intent.ID, userID, account.ID, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !paid {
+		err = errors.New("payment intent is no longer payable")
+		return nil, err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Payment intent %s of %f paid from account %d, transaction: %d",
+		intentID, intent.Amount, account.ID, transactionID)
+
+	// Send notification email
+	transaction.ID = transactionID
+	s.lifecycle.Background("transaction-notification", func(ctx context.Context) error {
+		err := s.email.SendTransactionNotification(ctx, userID, transaction)
+		if err != nil {
+			return fmt.Errorf("failed to send transaction notification: %w", err)
+		}
+		return nil
+	})
+
+	return s.GetIntentForCustomer(ctx, intentID, userID)
+}
+
+// settlementChunkSize is the number of merchants settled in one database transaction, with one
+// multi-row insert of their settlement transactions
+const settlementChunkSize = 200
+
+// SettlePayments pays out every merchant's payments captured before today to its settlement
+// account. Merchants are settled in chunks; when a chunk fails, its merchants are settled one by
+// one so that one bad merchant does not hold up the others.
+func (s *MerchantSvc) SettlePayments(ctx context.Context) error {
+	now := time.Now().In(s.bank)
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	merchantIDs, err := s.repos.Settlement.GetMerchantsToSettle(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to get merchants to settle: %w", err)
+	}
+
+	s.logger.Infof("Found %d merchants to settle", len(merchantIDs))
+
+	for start := 0; start < len(merchantIDs); start += settlementChunkSize {
+		end := start + settlementChunkSize
+		if end > len(merchantIDs) {
+			end = len(merchantIDs)
+		}
+
+		chunk := merchantIDs[start:end]
+		err := s.settleMerchants(ctx, chunk, cutoff)
+		if err == nil {
+			continue
+		}
+		if len(chunk) > 1 {
+			s.logger.Warnf("Failed to settle %d merchants at once, settling them one by one: %v", len(chunk), err)
+		}
+
+		for _, merchantID := range chunk {
+			if err := s.settleMerchants(ctx, []int{merchantID}, cutoff); err != nil {
+				s.logger.Warnf("Failed to settle merchant %d: %v", merchantID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// settleMerchants creates one settlement batch for each of the merchants with payments to settle
+// and credits their settlement accounts, in one database transaction
+func (s *MerchantSvc) settleMerchants(ctx context.Context, merchantIDs []int, cutoff time.Time) error {
+	merchants := make([]*models.Merchant, 0, len(merchantIDs))
+	for _, merchantID := range merchantIDs {
+		merchant, err := s.repos.Merchant.GetByID(ctx, merchantID)
+		if err != nil {
+			return fmt.Errorf("failed to get merchant %d: %w", merchantID, err)
+		}
+		merchants = append(merchants, merchant)
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	batches := make([]*models.SettlementBatch, 0, len(merchants))
+	transactions := make([]*models.Transaction, 0, len(merchants))
+	for _, merchant := range merchants {
+		var batch *models.SettlementBatch
+		batch, err = s.repos.Settlement.CreateBatchTx(ctx, tx, merchant.ID, cutoff, cutoff)
+		if err != nil {
+			return err
+		}
+
+		// Payments may have been settled since the merchants were listed; the empty batch is not kept
+		if batch.PaymentCount == 0 {
+			err = fmt.Errorf("no payments to settle for merchant %d", merchant.ID)
+			return err
+		}
+
+		// Credit the settlement account
+		err = s.repos.Account.UpdateBalanceTx(ctx, tx, merchant.SettlementAccountID, batch.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to update settlement account balance: %w", err)
+		}
+
+		batches = append(batches, batch)
+		transactions = append(transactions, &models.Transaction{
+			TransactionType:      models.TransactionTypeDeposit,
+			DestinationAccountID: &merchant.SettlementAccountID,
+			Amount:               batch.Amount,
+			Currency:             models.CurrencyRUB,
+			Description:          fmt.Sprintf("Settlement batch #%d for %s", batch.ID, cutoff.Format("2006-01-02")),
+			Status:               models.TransactionStatusCompleted,
+			TransactionDate:      time.Now(),
+		})
+	}
+
+	if err = s.repos.Transaction.CreateBatchTx(ctx, tx, transactions); err != nil {
+		return fmt.Errorf("failed to create settlement transactions: %w", err)
+	}
+
+	for i, batch := range batches {
+		if err = s.repos.Settlement.SetTransactionTx(ctx, tx, batch.ID, transactions[i].ID); err != nil {
+			return err
+		}
+		batch.TransactionID = &transactions[i].ID
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, batch := range batches {
+		s.logger.Infof("Settled %d payments for merchant %d, amount: %f, batch: %d",
+			batch.PaymentCount, batch.MerchantID, batch.Amount, batch.ID)
+	}
+
+	return nil
+}
+
+// newMerchantAPIKey generates a merchant API key and the hash stored in its place
+func newMerchantAPIKey() (string, string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	apiKey := merchantKeyPrefix + hex.EncodeToString(b)
+	return apiKey, hashMerchantAPIKey(apiKey), nil
+}
+
+// hashMerchantAPIKey hashes an API key for storage and lookup. Keys are random, so a fast hash is enough.
+func hashMerchantAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
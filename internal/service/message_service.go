@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// MessageSvc is an implementation of the service.MessageService interface
+type MessageSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	email     EmailService
+	lifecycle *lifecycle.Manager
+}
+
+// NewMessageService creates a new MessageSvc
+func NewMessageService(deps Dependencies) *MessageSvc {
+	return &MessageSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		email:     NewEmailService(deps),
+		lifecycle: deps.Lifecycle,
+	}
+}
+
+// CreateThread starts a conversation with the bank on behalf of a customer
+func (s *MessageSvc) CreateThread(ctx context.Context, create *models.ThreadCreate, userID int) (*models.MessageThread, error) {
+	if err := create.ValidateThreadCreate(); err != nil {
+		return nil, fmt.Errorf("invalid message data: %w", err)
+	}
+
+	return s.createThread(ctx, create, userID, models.MessageSenderCustomer, userID)
+}
+
+// GetThreads gets the customer's threads with the number of unread bank messages in each
+func (s *MessageSvc) GetThreads(ctx context.Context, userID int) ([]*models.MessageThread, error) {
+	threads, err := s.repos.Message.GetThreadsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message threads: %w", err)
+	}
+
+	return threads, nil
+}
+
+// GetThread gets a customer's thread with its messages and marks the bank's messages as read
+func (s *MessageSvc) GetThread(ctx context.Context, id int, userID int) (*models.MessageThread, error) {
+	thread, err := s.getCustomerThread(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.open(ctx, thread, models.MessageSenderBank)
+}
+
+// Reply adds a customer's message to one of their threads
+func (s *MessageSvc) Reply(ctx context.Context, id int, request *models.MessageRequest, userID int) (*models.Message, error) {
+	if err := request.ValidateMessageRequest(); err != nil {
+		return nil, fmt.Errorf("invalid message data: %w", err)
+	}
+
+	thread, err := s.getCustomerThread(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.post(ctx, thread, request, models.MessageSenderCustomer, userID)
+}
+
+// GetUnreadCount counts the bank's messages the customer has not read yet
+func (s *MessageSvc) GetUnreadCount(ctx context.Context, userID int) (int, error) {
+	count, err := s.repos.Message.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetDocument gets a document attached to a message in one of the customer's threads
+func (s *MessageSvc) GetDocument(ctx context.Context, threadID int, messageID int, userID int) (*models.Message, error) {
+	if _, err := s.getCustomerThread(ctx, threadID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repos.Message.GetDocument(ctx, messageID, threadID)
+}
+
+// SendToCustomer starts a conversation from the bank and emails the customer a prompt to read it
+func (s *MessageSvc) SendToCustomer(ctx context.Context, create *models.ThreadCreate, adminID int) (*models.MessageThread, error) {
+	if err := create.ValidateThreadCreate(); err != nil {
+		return nil, fmt.Errorf("invalid message data: %w", err)
+	}
+
+	if create.UserID <= 0 {
+		return nil, errors.New("user_id is required")
+	}
+
+	if _, err := s.repos.User.GetByID(ctx, create.UserID); err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	thread, err := s.createThread(ctx, create, create.UserID, models.MessageSenderBank, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.prompt(thread)
+
+	return thread, nil
+}
+
+// GetAllThreads gets the threads of all customers with the number of unread customer messages in each
+func (s *MessageSvc) GetAllThreads(ctx context.Context, unreadOnly bool) ([]*models.MessageThread, error) {
+	threads, err := s.repos.Message.GetThreads(ctx, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message threads: %w", err)
+	}
+
+	return threads, nil
+}
+
+// GetThreadForBank gets a thread with its messages and marks the customer's messages as read
+func (s *MessageSvc) GetThreadForBank(ctx context.Context, id int) (*models.MessageThread, error) {
+	thread, err := s.repos.Message.GetThreadByID(ctx, id, models.MessageSenderBank)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.open(ctx, thread, models.MessageSenderCustomer)
+}
+
+// ReplyAsBank adds the bank's message to a thread and emails the customer a prompt to read it
+func (s *MessageSvc) ReplyAsBank(ctx context.Context, id int, request *models.MessageRequest, adminID int) (*models.Message, error) {
+	if err := request.ValidateMessageRequest(); err != nil {
+		return nil, fmt.Errorf("invalid message data: %w", err)
+	}
+
+	thread, err := s.repos.Message.GetThreadByID(ctx, id, models.MessageSenderBank)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.post(ctx, thread, request, models.MessageSenderBank, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.prompt(thread)
+
+	return message, nil
+}
+
+// GetDocumentForBank gets a document attached to a message in any thread of the request's tenant
+func (s *MessageSvc) GetDocumentForBank(ctx context.Context, threadID int, messageID int) (*models.Message, error) {
+	if _, err := s.repos.Message.GetThreadByID(ctx, threadID, models.MessageSenderBank); err != nil {
+		return nil, err
+	}
+
+	return s.repos.Message.GetDocument(ctx, messageID, threadID)
+}
+
+// getCustomerThread gets a thread and checks that it belongs to the customer
+func (s *MessageSvc) getCustomerThread(ctx context.Context, id int, userID int) (*models.MessageThread, error) {
+	thread, err := s.repos.Message.GetThreadByID(ctx, id, models.MessageSenderCustomer)
+	if err != nil {
+		return nil, err
+	}
+
+	if thread.UserID != userID {
+		return nil, errors.New("message thread not found")
+	}
+
+	return thread, nil
+}
+
+// createThread stores a new thread together with its first message
+func (s *MessageSvc) createThread(ctx context.Context, create *models.ThreadCreate, userID int, sender models.MessageSender, authorID int) (*models.MessageThread, error) {
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	thread := &models.MessageThread{
+		UserID:    userID,
+		Subject:   create.Subject,
+		StartedBy: sender,
+	}
+
+	if _, err = s.repos.Message.CreateThreadTx(ctx, tx, thread); err != nil {
+		return nil, err
+	}
+
+	message := create.ToMessage(thread.ID, sender, authorID)
+	if _, err = s.repos.Message.CreateTx(ctx, tx, message); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	thread.LastMessageAt = message.CreatedAt
+	thread.Messages = []*models.Message{message}
+
+	s.logger.Infof("Message thread %d started by %s for user %d", thread.ID, sender, userID)
+
+	return thread, nil
+}
+
+// post adds a message to an existing thread
+func (s *MessageSvc) post(ctx context.Context, thread *models.MessageThread, request *models.MessageRequest, sender models.MessageSender, authorID int) (*models.Message, error) {
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	message := request.ToMessage(thread.ID, sender, authorID)
+	if _, err = s.repos.Message.CreateTx(ctx, tx, message); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Message %d posted by %s to thread %d", message.ID, sender, thread.ID)
+
+	return message, nil
+}
+
+// open marks the messages written by the other side as read and loads the thread's messages
+func (s *MessageSvc) open(ctx context.Context, thread *models.MessageThread, other models.MessageSender) (*models.MessageThread, error) {
+	if err := s.repos.Message.MarkRead(ctx, thread.ID, other); err != nil {
+		return nil, err
+	}
+
+	messages, err := s.repos.Message.GetByThreadID(ctx, thread.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread.UnreadCount = 0
+	thread.Messages = messages
+
+	return thread, nil
+}
+
+// prompt emails the customer that a new message is waiting, without its content
+func (s *MessageSvc) prompt(thread *models.MessageThread) {
+	s.lifecycle.Background("message-email", func(ctx context.Context) error {
+		if err := s.email.SendNewMessage(ctx, thread.UserID, thread); err != nil {
+			return fmt.Errorf("failed to send new message email: %w", err)
+		}
+		return nil
+	})
+}
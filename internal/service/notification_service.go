@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/i18n"
+)
+
+// NotificationSvc is an implementation of the service.NotificationService interface
+type NotificationSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+}
+
+// NewNotificationService creates a new NotificationSvc
+func NewNotificationService(deps Dependencies) *NotificationSvc {
+	return &NotificationSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+	}
+}
+
+// Notify creates an in-app notification for a user from a notification template of the catalog,
+// written in the language of the user or else the bank's
+func (s *NotificationSvc) Notify(ctx context.Context, userID int, notificationType models.NotificationType, template string, args ...interface{}) error {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	lang, ok := i18n.Parse(user.Language)
+	if !ok {
+		lang = s.config.Bank.DefaultLanguage()
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   i18n.Text(lang, "notification."+template+".title"),
+		Message: i18n.Sprintf(lang, "notification."+template+".message", args...),
+	}
+
+	id, err := s.repos.Notification.Create(ctx, notification)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	s.logger.Infof("Notification %d created for user %d", id, userID)
+
+	return nil
+}
+
+// GetByUserID gets the notifications of a user
+func (s *NotificationSvc) GetByUserID(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error) {
+	notifications, err := s.repos.Notification.GetByUserID(ctx, userID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// MarkRead marks a notification of a user as read
+func (s *NotificationSvc) MarkRead(ctx context.Context, id int, userID int) error {
+	if err := s.repos.Notification.MarkRead(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
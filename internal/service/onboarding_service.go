@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/i18n"
+)
+
+// emailVerificationTTL is how long an email verification link stays valid
+const emailVerificationTTL = 24 * time.Hour
+
+// OnboardingSvc is an implementation of the service.OnboardingService interface
+type OnboardingSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	signer        *crypto.HMACSigner
+	email         EmailService
+	notifications NotificationService
+	publicURL     string
+}
+
+// NewOnboardingService creates a new OnboardingSvc
+func NewOnboardingService(deps Dependencies) *OnboardingSvc {
+	return &OnboardingSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		signer:        crypto.NewHMACSigner([]byte(deps.Config.JWT.Secret)),
+		email:         NewEmailService(deps),
+		notifications: NewNotificationService(deps),
+		publicURL:     strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+	}
+}
+
+// GetStatus gets how far a user has got through onboarding, completing the stages they have
+// reached since it was last checked
+func (s *OnboardingSvc) GetStatus(ctx context.Context, userID int) (*models.OnboardingStatus, error) {
+	return s.Advance(ctx, userID)
+}
+
+// Advance completes, in order, the onboarding stages whose conditions the user now meets. Each
+// completed stage is recorded once, with an in-app notification.
+func (s *OnboardingSvc) Advance(ctx context.Context, userID int) (*models.OnboardingStatus, error) {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	events, err := s.repos.Onboarding.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get onboarding events: %w", err)
+	}
+
+	status := models.ToOnboardingStatus(events)
+	for !status.Completed {
+		met, err := s.meets(ctx, user, status.Stage)
+		if err != nil {
+			return nil, err
+		}
+		if !met {
+			break
+		}
+
+		if err := s.complete(ctx, userID, status.Stage); err != nil {
+			return nil, err
+		}
+
+		events = append(events, &models.OnboardingEvent{UserID: userID, Stage: status.Stage, CompletedAt: time.Now()})
+		status = models.ToOnboardingStatus(events)
+	}
+
+	status.MissingProfile = user.MissingProfile()
+
+	return status, nil
+}
+
+// Require checks, when onboarding is required, that the user has completed every stage before the
+// given one
+func (s *OnboardingSvc) Require(ctx context.Context, userID int, stage models.OnboardingStage) error {
+	if !s.config.Onboarding.Required {
+		return nil
+	}
+
+	status, err := s.Advance(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if status.Reached(stage) {
+		return nil
+	}
+
+	switch status.Stage {
+	case models.OnboardingStageEmailVerified:
+		return errors.New("onboarding is not complete: verify your email address first")
+	case models.OnboardingStageKYC:
+		return fmt.Errorf("onboarding is not complete: %w", i18n.Errorf("complete_your_profile_first_missing", strings.Join(status.MissingProfile, ", ")))
+	default:
+		return errors.New("onboarding is not complete: open an account first")
+	}
+}
+
+// SendEmailVerification emails the user a link that verifies their email address
+func (s *OnboardingSvc) SendEmailVerification(ctx context.Context, userID int) error {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Email == "" {
+		return errors.New("user has no email address")
+	}
+
+	events, err := s.repos.Onboarding.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get onboarding events: %w", err)
+	}
+	if models.ToOnboardingStatus(events).Done(models.OnboardingStageEmailVerified) {
+		return errors.New("email address is already verified")
+	}
+
+	expiresAt := time.Now().Add(emailVerificationTTL)
+	verifyURL := fmt.Sprintf("%s/email/verify?token=%s", s.publicURL, url.QueryEscape(s.verificationToken(user, expiresAt)))
+
+	if err := s.email.SendEmailVerification(ctx, userID, verifyURL, expiresAt); err != nil {
+		return err
+	}
+
+	s.logger.Infof("Email verification link sent to user %d", userID)
+
+	return nil
+}
+
+// VerifyEmail verifies the email address a link from SendEmailVerification was sent to
+func (s *OnboardingSvc) VerifyEmail(ctx context.Context, token string) error {
+	parts := strings.SplitN(token, ".", 3)
+	if len(parts) != 3 {
+		return errors.New("invalid verification token")
+	}
+
+	userID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return errors.New("invalid verification token")
+	}
+	expires, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return errors.New("invalid verification token")
+	}
+
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return errors.New("invalid verification token")
+	}
+
+	// The link is only good for the address it was sent to
+	expiresAt := time.Unix(expires, 0)
+	if subtle.ConstantTimeCompare([]byte(s.verificationToken(user, expiresAt)), []byte(token)) != 1 {
+		return errors.New("invalid verification token")
+	}
+	if time.Now().After(expiresAt) {
+		return errors.New("verification link has expired")
+	}
+
+	if err := s.complete(ctx, userID, models.OnboardingStageEmailVerified); err != nil {
+		return err
+	}
+
+	// The stages after it may be met already
+	_, err = s.Advance(ctx, userID)
+	return err
+}
+
+// meets reports whether a user meets the condition of an onboarding stage. The email address is
+// verified only by following the link sent to it.
+func (s *OnboardingSvc) meets(ctx context.Context, user *models.User, stage models.OnboardingStage) (bool, error) {
+	switch stage {
+	case models.OnboardingStageKYC:
+		return len(user.MissingProfile()) == 0, nil
+	case models.OnboardingStageFirstAccount:
+		accounts, err := s.repos.Account.GetByUserID(ctx, user.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get accounts: %w", err)
+		}
+		return len(accounts) > 0, nil
+	case models.OnboardingStageFirstCard:
+		cards, err := s.repos.Card.GetByUserID(ctx, user.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get cards: %w", err)
+		}
+		return len(cards) > 0, nil
+	}
+	return false, nil
+}
+
+// complete records a completed onboarding stage and notifies the user the first time
+func (s *OnboardingSvc) complete(ctx context.Context, userID int, stage models.OnboardingStage) error {
+	recorded, err := s.repos.Onboarding.Record(ctx, userID, stage)
+	if err != nil {
+		return fmt.Errorf("failed to record onboarding stage: %w", err)
+	}
+	if !recorded {
+		return nil
+	}
+
+	s.logger.Infof("User %d completed onboarding stage %s", userID, stage)
+
+	template := "onboarding_" + strings.ToLower(string(stage))
+	if err := s.notifications.Notify(ctx, userID, models.NotificationTypeOnboarding, template); err != nil {
+		s.logger.Warnf("Failed to notify user %d of onboarding stage %s: %v", userID, stage, err)
+	}
+
+	return nil
+}
+
+// verificationToken builds the token of an email verification link: the user ID and the expiry,
+// signed together with the email address
+func (s *OnboardingSvc) verificationToken(user *models.User, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d", user.ID, expiresAt.Unix())
+	return payload + "." + s.signer.Sign(payload+"."+user.Email)
+}
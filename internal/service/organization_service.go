@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// invitationTTL is how long an organization invitation can be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+// OrganizationSvc is an implementation of the service.OrganizationService interface
+type OrganizationSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	email         EmailService
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewOrganizationService creates a new OrganizationSvc
+func NewOrganizationService(deps Dependencies) *OrganizationSvc {
+	return &OrganizationSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		email:         NewEmailService(deps),
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// Create creates a new organization with the user as its first admin
+func (s *OrganizationSvc) Create(ctx context.Context, organizationCreate *models.OrganizationCreate, userID int) (int, error) {
+	if err := organizationCreate.ValidateOrganizationCreate(); err != nil {
+		return 0, fmt.Errorf("invalid organization data: %w", err)
+	}
+
+	id, err := s.repos.Organization.Create(ctx, organizationCreate.ToOrganization(userID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	s.logger.Infof("Organization created: %d by user: %d", id, userID)
+
+	return id, nil
+}
+
+// GetByID gets an organization with its members; any member may view it
+func (s *OrganizationSvc) GetByID(ctx context.Context, id int, userID int) (*models.Organization, error) {
+	member, err := s.getMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, err := s.repos.Organization.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	members, err := s.repos.Organization.GetMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members: %w", err)
+	}
+
+	organization.Role = member.Role
+	organization.Members = members
+
+	return organization, nil
+}
+
+// GetByUserID gets all organizations the user is a member of
+func (s *OrganizationSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Organization, error) {
+	organizations, err := s.repos.Organization.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	return organizations, nil
+}
+
+// GetAccounts gets the accounts owned by an organization; any member may view them
+func (s *OrganizationSvc) GetAccounts(ctx context.Context, id int, userID int) ([]*models.Account, error) {
+	if _, err := s.getMember(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.repos.Account.GetByOrganizationID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	if err := attachAccountSettings(ctx, s.repos, userID, accounts); err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// GetApprovalPolicies gets the transfer approval policies; any member may view them
+func (s *OrganizationSvc) GetApprovalPolicies(ctx context.Context, id int, userID int) ([]*models.ApprovalPolicy, error) {
+	if _, err := s.getMember(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	policies, err := s.repos.ApprovalPolicy.GetByOrganizationID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// SetApprovalPolicies replaces the transfer approval policies; only admins may do this.
+// Transfers already waiting for approvals keep the requirement they were created with.
+func (s *OrganizationSvc) SetApprovalPolicies(ctx context.Context, id int, update *models.ApprovalPoliciesUpdate, userID int) error {
+	if err := update.ValidateApprovalPolicies(); err != nil {
+		return fmt.Errorf("invalid approval policies: %w", err)
+	}
+
+	if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repos.ApprovalPolicy.Replace(ctx, id, update.Policies); err != nil {
+		return fmt.Errorf("failed to update approval policies: %w", err)
+	}
+
+	s.logger.Infof("Approval policies of organization %d updated by user %d", id, userID)
+
+	return nil
+}
+
+// UpdateMemberRole changes the role of a member; only admins may do this
+func (s *OrganizationSvc) UpdateMemberRole(ctx context.Context, id int, memberUserID int, update *models.MemberRoleUpdate, userID int) error {
+	if err := update.ValidateMemberRoleUpdate(); err != nil {
+		return fmt.Errorf("invalid role update: %w", err)
+	}
+
+	if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repos.Organization.UpdateMemberRole(ctx, id, memberUserID, update.Role); err != nil {
+		return fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	s.logger.Infof("Member %d of organization %d is now %s (changed by user %d)", memberUserID, id, update.Role, userID)
+
+	return nil
+}
+
+// RemoveMember removes a member from an organization. Admins may remove anyone;
+// other members may only leave themselves.
+func (s *OrganizationSvc) RemoveMember(ctx context.Context, id int, memberUserID int, userID int) error {
+	if memberUserID == userID {
+		if _, err := s.getMember(ctx, id, userID); err != nil {
+			return err
+		}
+	} else if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := s.repos.Organization.RemoveMember(ctx, id, memberUserID); err != nil {
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	s.logger.Infof("Member %d removed from organization %d by user %d", memberUserID, id, userID)
+
+	return nil
+}
+
+// Invite invites a user by email to join the organization; only admins may do this
+func (s *OrganizationSvc) Invite(ctx context.Context, id int, invite *models.InvitationCreate, userID int) (int, error) {
+	if err := invite.ValidateInvitationCreate(); err != nil {
+		return 0, fmt.Errorf("invalid invitation: %w", err)
+	}
+
+	if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return 0, err
+	}
+
+	// Refuse to invite existing members; the invitee may not be registered yet
+	invitee, err := s.repos.User.GetByEmail(ctx, invite.Email)
+	if err != nil {
+		invitee = nil
+	} else if _, err := s.repos.Organization.GetMember(ctx, id, invitee.ID); err == nil {
+		return 0, errors.New("user is already a member")
+	}
+
+	invitation := invite.ToInvitation(id, userID, time.Now().Add(invitationTTL))
+
+	invitationID, err := s.repos.Invitation.Create(ctx, invitation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	// Reload to get the organization name for the email
+	invitation, err = s.repos.Invitation.GetByID(ctx, invitationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	s.logger.Infof("Invitation %d to organization %d created for %s by user %d", invitationID, id, invitation.Email, userID)
+
+	s.lifecycle.Background("organization-invitation", func(ctx context.Context) error {
+		if err := s.email.SendOrganizationInvitation(ctx, invitation); err != nil {
+			return fmt.Errorf("failed to send organization invitation: %w", err)
+		}
+
+		// Users who already have an account also see the invitation in the app
+		if invitee != nil {
+			if err := s.notifications.Notify(ctx, invitee.ID, models.NotificationTypeOrganization, "organization_invitation",
+				invitation.OrganizationName, invitation.Role); err != nil {
+				return fmt.Errorf("failed to notify invitee: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return invitationID, nil
+}
+
+// GetInvitations gets all invitations of an organization; only admins may view them
+func (s *OrganizationSvc) GetInvitations(ctx context.Context, id int, userID int) ([]*models.OrganizationInvitation, error) {
+	if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.repos.Invitation.GetByOrganizationID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// RevokeInvitation cancels a pending invitation; only admins may do this
+func (s *OrganizationSvc) RevokeInvitation(ctx context.Context, id int, invitationID int, userID int) error {
+	if _, err := s.getAdmin(ctx, id, userID); err != nil {
+		return err
+	}
+
+	invitation, err := s.repos.Invitation.GetByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	if invitation.OrganizationID != id {
+		return errors.New("invitation not found")
+	}
+
+	revoked, err := s.repos.Invitation.UpdateStatus(ctx, invitationID, models.InvitationStatusPending, models.InvitationStatusRevoked)
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+
+	if !revoked {
+		return errors.New("invitation is no longer pending")
+	}
+
+	s.logger.Infof("Invitation %d to organization %d revoked by user %d", invitationID, id, userID)
+
+	return nil
+}
+
+// GetPendingInvitations gets the pending invitations sent to the user's email address
+func (s *OrganizationSvc) GetPendingInvitations(ctx context.Context, userID int) ([]*models.OrganizationInvitation, error) {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitations, err := s.repos.Invitation.GetPendingByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+
+	return invitations, nil
+}
+
+// RespondToInvitation accepts or declines an invitation sent to the user's email address
+func (s *OrganizationSvc) RespondToInvitation(ctx context.Context, invitationID int, userID int, accept bool) error {
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	invitation, err := s.repos.Invitation.GetByID(ctx, invitationID)
+	if err != nil {
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	// Invitations are bound to the invited address
+	if !strings.EqualFold(invitation.Email, user.Email) {
+		return errors.New("invitation not found")
+	}
+
+	if invitation.Status != models.InvitationStatusPending {
+		return errors.New("invitation is no longer pending")
+	}
+
+	if time.Now().After(invitation.ExpiresAt) {
+		return errors.New("invitation has expired")
+	}
+
+	status := models.InvitationStatusDeclined
+	if accept {
+		status = models.InvitationStatusAccepted
+	}
+
+	// Move out of PENDING first so the invitation cannot be answered twice
+	updated, err := s.repos.Invitation.UpdateStatus(ctx, invitationID, models.InvitationStatusPending, status)
+	if err != nil {
+		return fmt.Errorf("failed to update invitation: %w", err)
+	}
+
+	if !updated {
+		return errors.New("invitation is no longer pending")
+	}
+
+	if !accept {
+		s.logger.Infof("Invitation %d declined by user %d", invitationID, userID)
+		return nil
+	}
+
+	if err := s.repos.Organization.AddMember(ctx, invitation.OrganizationID, userID, invitation.Role); err != nil {
+		return fmt.Errorf("failed to join organization: %w", err)
+	}
+
+	s.logger.Infof("User %d joined organization %d as %s", userID, invitation.OrganizationID, invitation.Role)
+
+	return nil
+}
+
+// getMember gets the user's membership, denying access to non-members
+func (s *OrganizationSvc) getMember(ctx context.Context, id int, userID int) (*models.OrganizationMember, error) {
+	member, err := s.repos.Organization.GetMember(ctx, id, userID)
+	if err != nil {
+		return nil, errors.New("access denied: you are not a member of this organization")
+	}
+
+	return member, nil
+}
+
+// getAdmin gets the user's membership, denying access to non-admins
+func (s *OrganizationSvc) getAdmin(ctx context.Context, id int, userID int) (*models.OrganizationMember, error) {
+	member, err := s.getMember(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkOrganizationRole(member.Role, accessManage); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
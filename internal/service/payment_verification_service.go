@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+)
+
+// ErrVerificationSecretMismatch is returned when a payment is verified with a secret that is not
+// the payment's
+var ErrVerificationSecretMismatch = errors.New("verification secret does not match the payment")
+
+// PaymentVerificationSvc is an implementation of the service.PaymentVerificationService interface
+type PaymentVerificationSvc struct {
+	repos        *repository.Repository
+	logger       *logrus.Logger
+	transactions *TransactionSvc
+	signer       *crypto.HMACSigner
+	publicURL    string
+}
+
+// NewPaymentVerificationService creates a new PaymentVerificationSvc
+func NewPaymentVerificationService(deps Dependencies) *PaymentVerificationSvc {
+	return &PaymentVerificationSvc{
+		repos:        deps.Repos,
+		logger:       deps.Logger,
+		transactions: NewTransactionService(deps),
+		signer:       crypto.NewHMACSigner([]byte(deps.Config.JWT.Secret)),
+		publicURL:    strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+	}
+}
+
+// GetSecret returns the reference and verification secret of a transaction the user can view, for
+// the user to pass on to whoever should be able to see the payment's details
+func (s *PaymentVerificationSvc) GetSecret(ctx context.Context, id int, userID int) (*models.PaymentVerificationSecret, error) {
+	transaction, err := s.transactions.getViewable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentVerificationSecret{
+		Reference: transaction.Reference,
+		Secret:    s.secret(transaction.Reference),
+		URL:       s.publicURL + "/payments/" + transaction.Reference,
+	}, nil
+}
+
+// Verify tells whether the payment with the reference exists and is completed. The amount and the
+// parties are only disclosed when the payment's secret is given as well.
+func (s *PaymentVerificationSvc) Verify(ctx context.Context, reference string, secret string) (*models.PaymentVerification, error) {
+	reference = strings.ToLower(reference)
+
+	transaction, err := s.repos.Transaction.GetByReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &models.PaymentVerification{
+		Reference: transaction.Reference,
+		Completed: transaction.Status == models.TransactionStatusCompleted,
+	}
+	if secret == "" {
+		return verification, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(s.secret(transaction.Reference)), []byte(secret)) != 1 {
+		return nil, ErrVerificationSecretMismatch
+	}
+
+	details := &models.PaymentDetails{
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		TransactionDate: transaction.TransactionDate,
+	}
+	if transaction.SourceAccountID != nil {
+		if account, err := s.repos.Account.GetByID(ctx, *transaction.SourceAccountID); err == nil {
+			details.PayerName = accountOwnerName(ctx, s.repos, account)
+			details.PayerAccount = models.MaskAccountNumber(account.AccountNumber)
+		}
+	}
+	if transaction.DestinationAccountID != nil {
+		if account, err := s.repos.Account.GetByID(ctx, *transaction.DestinationAccountID); err == nil {
+			details.PayeeName = accountOwnerName(ctx, s.repos, account)
+			details.PayeeAccount = models.MaskAccountNumber(account.AccountNumber)
+		}
+	}
+	verification.Details = details
+
+	return verification, nil
+}
+
+// secret derives the verification secret of a payment from its reference
+func (s *PaymentVerificationSvc) secret(reference string) string {
+	return s.signer.Sign("payment." + reference)
+}
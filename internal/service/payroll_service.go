@@ -0,0 +1,444 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/iso20022"
+	"banking-service/pkg/lifecycle"
+)
+
+// PayrollSvc is an implementation of the service.PayrollService interface
+type PayrollSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	bank          *time.Location
+}
+
+// NewPayrollService creates a new PayrollSvc
+func NewPayrollService(deps Dependencies) *PayrollSvc {
+	return &PayrollSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		bank:          deps.Bank,
+	}
+}
+
+// Upload parses and validates a payroll file for one of the organization's accounts and stores it.
+// A payroll whose lines are all valid can be executed; otherwise the invalid lines explain what to fix.
+func (s *PayrollSvc) Upload(ctx context.Context, organizationID int, payroll *models.Payroll, content []byte, userID int) (*models.Payroll, error) {
+	if err := payroll.ValidatePayrollUpload(); err != nil {
+		return nil, fmt.Errorf("invalid payroll: %w", err)
+	}
+
+	if len(content) > models.MaxPayrollFileSize {
+		return nil, errors.New("payroll file is larger than 1 MB")
+	}
+
+	items, initiation, err := parsePayroll(content, s.bank)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payroll: %w", err)
+	}
+
+	if _, err := s.getMember(ctx, organizationID, userID, accessTransact); err != nil {
+		return nil, err
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, payroll.AccountID)
+	if err != nil || account.OrganizationID == nil || *account.OrganizationID != organizationID {
+		return nil, errors.New("account does not belong to this organization")
+	}
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	// A pain.001 message names the account it debits and the currency of every transfer
+	var transfers []*iso20022.CreditTransfer
+	if initiation != nil {
+		for _, payment := range initiation.Payments {
+			if payment.DebtorAccount != account.AccountNumber {
+				return nil, errors.New("debtor account of the payment does not match the payroll account")
+			}
+		}
+		transfers = initiation.Transfers()
+	}
+
+	for i, item := range items {
+		if item.Status != models.PayrollItemStatusValid {
+			continue
+		}
+		if transfers != nil && models.Currency(transfers[i].Currency) != account.Currency {
+			item.Reject("payment currency does not match the payroll currency")
+			continue
+		}
+		payee, reason := s.checkPayee(ctx, item, account.Currency)
+		if reason != "" {
+			item.Reject(reason)
+			continue
+		}
+		item.AccountID = &payee.ID
+	}
+
+	payroll.OrganizationID = organizationID
+	payroll.Currency = account.Currency
+	payroll.UploadedBy = userID
+	payroll.Items = items
+	payroll.SummarizeItems()
+
+	if _, err := s.repos.Payroll.Create(ctx, payroll); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Payroll %d of organization %d uploaded by user %d: %d payment(s) of %.2f %s, %d invalid",
+		payroll.ID, organizationID, userID, payroll.ItemCount, payroll.TotalAmount, payroll.Currency, payroll.InvalidCount)
+
+	return payroll, nil
+}
+
+// Execute pays a validated payroll: the funding account is debited once for the whole batch and
+// every employee's account is credited in the same transaction. Accounts that became unavailable
+// since the upload are skipped and reported as failed. A payroll covered by an approval policy of
+// the organization must be executed by a member other than the one who uploaded it.
+func (s *PayrollSvc) Execute(ctx context.Context, id int, userID int) (*models.Payroll, error) {
+	payroll, err := s.get(ctx, id, userID, accessTransact)
+	if err != nil {
+		return nil, err
+	}
+
+	switch payroll.Status {
+	case models.PayrollStatusInvalid:
+		return nil, errors.New("payroll has invalid lines, fix the file and upload it again")
+	case models.PayrollStatusCompleted:
+		return nil, errors.New("payroll is already executed")
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, payroll.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+	if account.IsDormant() {
+		return nil, errors.New("account is dormant, reactivate it first")
+	}
+
+	policies, err := s.repos.ApprovalPolicy.GetByOrganizationID(ctx, payroll.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approval policies: %w", err)
+	}
+	if models.RequiredApprovalsFor(policies, payroll.TotalAmount) > 0 && payroll.UploadedBy == userID {
+		return nil, errors.New("payroll must be executed by a member other than the one who uploaded it")
+	}
+
+	organization, err := s.repos.Organization.GetByID(ctx, payroll.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	items, err := s.repos.Payroll.GetItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Employees' accounts may have been closed since the upload
+	payees := make(map[int]*models.Account)
+	for _, item := range items {
+		payee, reason := s.checkPayee(ctx, item, payroll.Currency)
+		if reason != "" {
+			item.Status = models.PayrollItemStatusFailed
+			item.Error = reason
+			continue
+		}
+		payees[item.ID] = payee
+		payroll.PaidCount++
+		payroll.PaidAmount += item.Amount
+	}
+	payroll.PaidAmount = math.Round(payroll.PaidAmount*100) / 100
+
+	if payroll.PaidCount == 0 {
+		return nil, errors.New("none of the payroll accounts can receive payments")
+	}
+	if account.AvailableBalance < payroll.PaidAmount {
+		return nil, errors.New("insufficient funds")
+	}
+
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Claim the payroll first so that it cannot be executed twice
+	executed, err := s.repos.Payroll.UpdateStatusTx(ctx, tx, id, models.PayrollStatusValidated, models.PayrollStatusCompleted, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !executed {
+		err = errors.New("payroll is already executed")
+		return nil, err
+	}
+
+	if err = s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, -payroll.PaidAmount); err != nil {
+		return nil, fmt.Errorf("failed to update source account balance: %w", err)
+	}
+
+	now := time.Now()
+	fundingID, err := s.repos.Transaction.CreateTx(ctx, tx, &models.Transaction{
+		TransactionType: models.TransactionTypePayroll,
+		SourceAccountID: &account.ID,
+		Amount:          payroll.PaidAmount,
+		Currency:        payroll.Currency,
+		Description:     fmt.Sprintf("Payroll %d: %d payment(s)", id, payroll.PaidCount),
+		Status:          models.TransactionStatusCompleted,
+		TransactionDate: now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction record: %w", err)
+	}
+	payroll.FundingTransactionID = &fundingID
+
+	description := "Salary from " + organization.Name
+	if payroll.Description != "" {
+		description += ": " + payroll.Description
+	}
+
+	// The payments are recorded with multi-row inserts rather than one statement per employee
+	credits := make(map[int]*models.Transaction, len(payees))
+	batch := make([]*models.Transaction, 0, len(payees))
+	for _, item := range items {
+		payee, ok := payees[item.ID]
+		if !ok {
+			continue
+		}
+
+		if err = s.repos.Account.UpdateBalanceTx(ctx, tx, payee.ID, item.Amount); err != nil {
+			return nil, fmt.Errorf("failed to update destination account balance: %w", err)
+		}
+
+		credit := &models.Transaction{
+			TransactionType:      models.TransactionTypePayroll,
+			DestinationAccountID: &payee.ID,
+			Amount:               item.Amount,
+			Currency:             payroll.Currency,
+			Description:          description,
+			Status:               models.TransactionStatusCompleted,
+			TransactionDate:      now,
+		}
+		credits[item.ID] = credit
+		batch = append(batch, credit)
+	}
+
+	if err = s.repos.Transaction.CreateBatchTx(ctx, tx, batch); err != nil {
+		return nil, fmt.Errorf("failed to create transaction records: %w", err)
+	}
+
+	for _, item := range items {
+		if credit, ok := credits[item.ID]; ok {
+			item.Status = models.PayrollItemStatusPaid
+			item.TransactionID = &credit.ID
+		}
+
+		if err = s.repos.Payroll.UpdateItemTx(ctx, tx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.repos.Payroll.SetSettlementTx(ctx, tx, payroll); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Payroll %d executed by user %d: %d of %d payment(s), %.2f %s debited from account %d, transaction: %d",
+		id, userID, payroll.PaidCount, payroll.ItemCount, payroll.PaidAmount, payroll.Currency, account.ID, fundingID)
+
+	s.notifyEmployees(organization, payroll, items, payees)
+
+	payroll.Status = models.PayrollStatusCompleted
+	payroll.ExecutedBy = &userID
+	payroll.ExecutedAt = &now
+	payroll.Items = items
+
+	return payroll, nil
+}
+
+// GetByID gets a payroll with its items; any organization member may view it. Once executed, the
+// items make up its settlement report.
+func (s *PayrollSvc) GetByID(ctx context.Context, id int, userID int) (*models.Payroll, error) {
+	payroll, err := s.get(ctx, id, userID, accessView)
+	if err != nil {
+		return nil, err
+	}
+
+	if payroll.Items, err = s.repos.Payroll.GetItems(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return payroll, nil
+}
+
+// GetByOrganizationID gets the payrolls of an organization without their items; any member may view them
+func (s *PayrollSvc) GetByOrganizationID(ctx context.Context, organizationID int, userID int) ([]*models.Payroll, error) {
+	if _, err := s.getMember(ctx, organizationID, userID, accessView); err != nil {
+		return nil, err
+	}
+
+	return s.repos.Payroll.GetByOrganizationID(ctx, organizationID)
+}
+
+// GetReport builds the settlement report of a payroll as CSV in the accounting export dialect and
+// returns its file name and content
+func (s *PayrollSvc) GetReport(ctx context.Context, id int, userID int) (string, []byte, error) {
+	payroll, err := s.GetByID(ctx, id, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows := [][]string{{
+		"line", "account_number", "employee_name", "amount", "currency", "status", "transaction_id", "error",
+	}}
+	for _, item := range payroll.Items {
+		transactionID := ""
+		if item.TransactionID != nil {
+			transactionID = strconv.Itoa(*item.TransactionID)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(item.LineNumber),
+			item.AccountNumber,
+			item.EmployeeName,
+			accountingAmount(item.Amount),
+			string(payroll.Currency),
+			string(item.Status),
+			transactionID,
+			item.Error,
+		})
+	}
+
+	content, err := accountingCSV(rows)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write payroll report: %w", err)
+	}
+
+	return fmt.Sprintf("payroll_%d.csv", id), content, nil
+}
+
+// parsePayroll reads the payments of a payroll file: a CSV file or a pain.001 credit transfer
+// initiation, which is returned as well. Dates without a time zone are read in loc.
+func parsePayroll(content []byte, loc *time.Location) ([]*models.PayrollItem, *iso20022.PaymentInitiation, error) {
+	if !iso20022.IsXML(content) {
+		items, err := models.ParsePayrollFile(content)
+		return items, nil, err
+	}
+
+	initiation, err := iso20022.ParsePain001(content, loc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records := [][]string{}
+	for _, transfer := range initiation.Transfers() {
+		records = append(records, []string{
+			transfer.CreditorAccount,
+			strconv.FormatFloat(transfer.Amount, 'f', -1, 64),
+			transfer.CreditorName,
+		})
+	}
+
+	items, err := models.PayrollItemsFromRecords(records)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, initiation, nil
+}
+
+// checkPayee finds the personal account a payroll line pays to. It returns the reason the line
+// cannot be paid, if any.
+func (s *PayrollSvc) checkPayee(ctx context.Context, item *models.PayrollItem, currency models.Currency) (*models.Account, string) {
+	account, err := s.repos.Account.GetByAccountNumber(ctx, item.AccountNumber)
+	if err != nil {
+		return nil, "account not found"
+	}
+
+	switch {
+	case account.OrganizationID != nil:
+		return nil, "salaries can only be paid to personal accounts"
+	case !account.IsActive:
+		return nil, "account is inactive"
+	case account.Currency != currency:
+		return nil, "account currency does not match the payroll currency"
+	}
+
+	return account, ""
+}
+
+// get gets a payroll after checking that the user's organization role allows the access
+func (s *PayrollSvc) get(ctx context.Context, id int, userID int, access accountAccess) (*models.Payroll, error) {
+	payroll, err := s.repos.Payroll.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getMember(ctx, payroll.OrganizationID, userID, access); err != nil {
+		return nil, err
+	}
+
+	return payroll, nil
+}
+
+// getMember gets the user's membership, denying access to non-members and to roles that do not allow the access
+func (s *PayrollSvc) getMember(ctx context.Context, organizationID int, userID int, access accountAccess) (*models.OrganizationMember, error) {
+	member, err := s.repos.Organization.GetMember(ctx, organizationID, userID)
+	if err != nil {
+		return nil, errors.New("access denied: you are not a member of this organization")
+	}
+
+	if err := checkOrganizationRole(member.Role, access); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// notifyEmployees tells the owner of every paid account about the salary in the background
+func (s *PayrollSvc) notifyEmployees(organization *models.Organization, payroll *models.Payroll, items []*models.PayrollItem, payees map[int]*models.Account) {
+	s.lifecycle.Background("payroll-notifications", func(ctx context.Context) error {
+		failed := 0
+		for _, item := range items {
+			payee, ok := payees[item.ID]
+			if !ok {
+				continue
+			}
+			err := s.notifications.Notify(ctx, payee.UserID, models.NotificationTypeAccount, "payroll_credited",
+				item.Amount, payroll.Currency, organization.Name, payee.AccountNumber)
+			if err != nil {
+				s.logger.Warnf("Failed to notify user %d about payroll %d: %v", payee.UserID, payroll.ID, err)
+				failed++
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to send %d payroll notification(s)", failed)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/cbr"
+	"banking-service/pkg/httpclient"
+	"banking-service/pkg/i18n"
+)
+
+// CBRResponse represents the XML response from Central Bank of Russia
+type CBRResponse struct {
+	XMLName xml.Name `xml:"envelope"`
+	Body    struct {
+		XMLName     xml.Name `xml:"Body"`
+		GetRateResp struct {
+			XMLName xml.Name `xml:"GetCursOnDateXMLResponse"`
+			Result  struct {
+				XMLName xml.Name `xml:"GetCursOnDateXMLResult"`
+				Rates   string   `xml:",innerxml"`
+			}
+		}
+	}
+}
+
+// RateSvc is an implementation of the service.RateService interface. Every rate fetched from
+// the Central Bank is stored in the rates history.
+type RateSvc struct {
+	repos    *repository.Repository
+	logger   *logrus.Logger
+	config   *configs.Config
+	keyRates cbr.KeyRateProvider
+	client   httpclient.Doer
+	bank     *time.Location
+}
+
+// NewRateService creates a new RateSvc
+func NewRateService(deps Dependencies) *RateSvc {
+	s := &RateSvc{
+		repos:    deps.Repos,
+		logger:   deps.Logger,
+		config:   deps.Config,
+		keyRates: deps.KeyRates,
+		client:   deps.HTTP,
+		bank:     deps.Bank,
+	}
+	if s.client == nil {
+		s.client = http.DefaultClient
+	}
+
+	return s
+}
+
+// GetKeyRate gets the key rate the bank uses as its base rate
+func (s *RateSvc) GetKeyRate(ctx context.Context) (float64, error) {
+	keyRate, err := s.GetCurrentKeyRate(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return keyRate.Rate, nil
+}
+
+// GetCurrentKeyRate gets the key rate the bank uses as its base rate: the rate an admin pinned for
+// today, or else the central bank's through the configured providers
+func (s *RateSvc) GetCurrentKeyRate(ctx context.Context) (*models.KeyRate, error) {
+	override, err := s.overrideAt(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if override != nil {
+		return &models.KeyRate{Rate: override.Rate, Source: models.KeyRateSourceOverride, Override: override}, nil
+	}
+
+	keyRate, err := s.fetchKeyRate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.KeyRate{Rate: keyRate, Source: models.KeyRateSourceCBR}, nil
+}
+
+// GetKeyRateOverrides gets all key rate overrides, including revoked ones
+func (s *RateSvc) GetKeyRateOverrides(ctx context.Context) ([]*models.KeyRateOverride, error) {
+	return s.repos.Rate.GetKeyRateOverrides(ctx)
+}
+
+// CreateKeyRateOverride pins the base rate for a period. Overrides in effect cannot overlap, so
+// an open-ended one has to be revoked before another is pinned after it.
+func (s *RateSvc) CreateKeyRateOverride(ctx context.Context, request *models.KeyRateOverrideRequest, adminID int) (*models.KeyRateOverride, error) {
+	override, err := request.ValidateKeyRateOverrideRequest(s.bank)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key rate override: %w", err)
+	}
+	override.CreatedBy = adminID
+
+	existing, err := s.repos.Rate.GetKeyRateOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.RevokedAt == nil && override.Overlaps(other) {
+			return nil, i18n.Errorf("the_period_overlaps_key_rate_override", strconv.Itoa(other.ID))
+		}
+	}
+
+	if err := s.repos.Rate.CreateKeyRateOverride(ctx, override); err != nil {
+		return nil, err
+	}
+
+	s.logger.Warnf("Key rate pinned at %.2f%% from %s by admin %d: %s", override.Rate,
+		override.EffectiveFrom.Format("2006-01-02"), adminID, override.Reason)
+
+	return override, nil
+}
+
+// RevokeKeyRateOverride revokes a key rate override; the central bank's rate applies again
+func (s *RateSvc) RevokeKeyRateOverride(ctx context.Context, id int, adminID int) error {
+	revoked, err := s.repos.Rate.RevokeKeyRateOverride(ctx, id, adminID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return errors.New("key rate override not found or revoked already")
+	}
+
+	s.logger.Warnf("Key rate override %d revoked by admin %d", id, adminID)
+
+	return nil
+}
+
+// overrideAt gets the key rate override in effect at a time, nil if there is none
+func (s *RateSvc) overrideAt(ctx context.Context, at time.Time) (*models.KeyRateOverride, error) {
+	overrides, err := s.repos.Rate.GetKeyRateOverrides(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key rate overrides: %w", err)
+	}
+
+	for _, override := range overrides {
+		if override.InEffect(at) {
+			return override, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// fetchKeyRate gets the key rate from Central Bank of Russia through the configured providers and
+// stores it in the history
+func (s *RateSvc) fetchKeyRate(ctx context.Context) (float64, error) {
+	keyRate, err := s.keyRates.KeyRate(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get key rate: %w", err)
+	}
+
+	s.logger.Infof("Retrieved key rate from CBR: %f%%", keyRate)
+	s.record(ctx, models.RateTypeKey, "", keyRate)
+
+	return keyRate, nil
+}
+
+// GetExchangeRates gets the RUB exchange rates of the tracked currencies from Central Bank of Russia
+func (s *RateSvc) GetExchangeRates(ctx context.Context) (map[models.Currency]float64, error) {
+	doc, err := s.fetchRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[models.Currency]float64, len(models.ExchangeRateCurrencies))
+	for _, currency := range models.ExchangeRateCurrencies {
+		valuteElem := doc.FindElement(fmt.Sprintf("//ValCurs/Valute[CharCode='%s']", currency))
+		if valuteElem == nil {
+			return nil, fmt.Errorf("%s rate not found in response", currency)
+		}
+
+		valueElem := valuteElem.FindElement("Value")
+		if valueElem == nil {
+			return nil, fmt.Errorf("value element not found in %s rate", currency)
+		}
+
+		value, err := parseRateValue(valueElem.Text())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s rate value: %w", currency, err)
+		}
+
+		// Rates of weak currencies are quoted for 10 or 100 units
+		nominal := 1.0
+		if nominalElem := valuteElem.FindElement("Nominal"); nominalElem != nil {
+			if nominal, err = parseRateValue(nominalElem.Text()); err != nil || nominal <= 0 {
+				return nil, fmt.Errorf("invalid %s rate nominal %q", currency, nominalElem.Text())
+			}
+		}
+
+		rates[currency] = value / nominal
+	}
+
+	for currency, rate := range rates {
+		s.record(ctx, models.RateTypeFX, currency, rate)
+	}
+
+	return rates, nil
+}
+
+// GetHistory gets the stored history of the key rate or of an exchange rate
+func (s *RateSvc) GetHistory(ctx context.Context, query *models.RateHistoryQuery) ([]*models.Rate, error) {
+	return s.repos.Rate.GetHistory(ctx, query)
+}
+
+// GetRateAt gets the rate that was in effect at a moment, i.e. the last one fetched before it
+func (s *RateSvc) GetRateAt(ctx context.Context, rateType models.RateType, currency models.Currency, at time.Time) (*models.Rate, error) {
+	return s.repos.Rate.GetAt(ctx, rateType, currency, at)
+}
+
+// RecordRates fetches the key rate and the exchange rates so the history has no gaps when
+// nothing else asks for them. The central bank's key rate is recorded even while an override is in effect.
+func (s *RateSvc) RecordRates(ctx context.Context) error {
+	_, keyErr := s.fetchKeyRate(ctx)
+	_, fxErr := s.GetExchangeRates(ctx)
+
+	return errors.Join(keyErr, fxErr)
+}
+
+// fetchRates requests today's exchange rates from Central Bank of Russia
+func (s *RateSvc) fetchRates(ctx context.Context) (*etree.Document, error) {
+	// Prepare SOAP request
+	soapEnvelope := `
+	<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/" xmlns:web="http://web.cbr.ru/">
+		<soapenv:Header/>
+		<soapenv:Body>
+			<web:GetCursOnDateXML>
+				<web:On_date>` + time.Now().Format("2006-01-02") + `</web:On_date>
+			</web:GetCursOnDateXML>
+		</soapenv:Body>
+	</soapenv:Envelope>`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(s.config.CBR.Timeout)*time.Second)
+	defer cancel()
+
+	// Create the HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.CBR.APIURL, strings.NewReader(soapEnvelope))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", "http://web.cbr.ru/GetCursOnDateXML")
+
+	// Send the request
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	// Read the response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Parse the XML response
+	var cbrResp CBRResponse
+	err = xml.Unmarshal(body, &cbrResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Use etree to parse the inner XML content
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(cbrResp.Body.GetRateResp.Result.Rates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rate data: %w", err)
+	}
+
+	return doc, nil
+}
+
+// record stores a fetched rate; a failure is logged rather than failing the caller that needed the rate
+func (s *RateSvc) record(ctx context.Context, rateType models.RateType, currency models.Currency, value float64) {
+	rate := &models.Rate{Type: rateType, Currency: currency, Rate: value, FetchedAt: time.Now()}
+	if err := s.repos.Rate.Create(ctx, rate); err != nil {
+		s.logger.Warnf("Failed to store %s rate %s: %v", rateType, currency, err)
+	}
+}
+
+// parseRateValue parses a CBR number, which uses a decimal comma
+func parseRateValue(text string) (float64, error) {
+	var value float64
+	valueStr := strings.Replace(strings.TrimSpace(text), ",", ".", 1)
+	if _, err := fmt.Sscanf(valueStr, "%f", &value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/pdf"
+	"banking-service/pkg/qr"
+)
+
+// Layout of the receipt page in points
+const (
+	receiptMargin     = 56
+	receiptValueX     = 200
+	receiptLineHeight = 22
+	receiptQRSize     = 120
+)
+
+// receiptStamps are the words stamped on receipts by transaction status
+var receiptStamps = map[models.TransactionStatus]string{
+	models.TransactionStatusCompleted: "PAID",
+	models.TransactionStatusPending:   "PENDING",
+	models.TransactionStatusFailed:    "DECLINED",
+	models.TransactionStatusCancelled: "CANCELLED",
+}
+
+// ReceiptSvc is an implementation of the service.ReceiptService interface
+type ReceiptSvc struct {
+	repos        *repository.Repository
+	logger       *logrus.Logger
+	config       *configs.Config
+	transactions *TransactionSvc
+	signer       *crypto.HMACSigner
+	publicURL    string
+}
+
+// NewReceiptService creates a new ReceiptSvc
+func NewReceiptService(deps Dependencies) *ReceiptSvc {
+	return &ReceiptSvc{
+		repos:        deps.Repos,
+		logger:       deps.Logger,
+		config:       deps.Config,
+		transactions: NewTransactionService(deps),
+		signer:       crypto.NewHMACSigner([]byte(deps.Config.JWT.Secret)),
+		publicURL:    strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+	}
+}
+
+// Get renders the receipt of a transaction the user can view as a PDF and returns its file name
+// and content. The receipt carries a QR code linking to its public verification.
+func (s *ReceiptSvc) Get(ctx context.Context, id int, userID int) (string, []byte, error) {
+	transaction, err := s.transactions.getViewable(ctx, id, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	brand, _ := s.config.Tenant(configs.DefaultTenant)
+	if user, err := s.repos.User.GetByID(ctx, userID); err == nil {
+		if tenant, ok := s.config.Tenant(user.Tenant); ok {
+			brand = tenant
+		}
+	}
+
+	verifyURL := fmt.Sprintf("%s/receipts/verify?token=%s", s.publicURL, url.QueryEscape(s.verificationToken(transaction.ID)))
+	code, err := qr.Encode([]byte(verifyURL))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to encode receipt QR code: %w", err)
+	}
+
+	doc := pdf.New(fmt.Sprintf("Receipt %d", transaction.ID))
+	page := doc.AddPage()
+
+	// Header
+	y := pdf.PageHeight - 72.0
+	page.Text(receiptMargin, y, pdf.HelveticaBold, 20, brand.Name)
+	page.TextRight(pdf.PageWidth-receiptMargin, y, pdf.Helvetica, 9, "Issued "+time.Now().Format("2006-01-02 15:04 MST"))
+	y -= 26
+	page.Text(receiptMargin, y, pdf.Helvetica, 14, "Transaction receipt")
+	y -= 14
+	page.StrokeColor(0.7, 0.7, 0.7)
+	page.Line(receiptMargin, y, pdf.PageWidth-receiptMargin, y, 1)
+	y -= 30
+
+	// Details
+	row := func(label, value string, font pdf.Font) {
+		page.Text(receiptMargin, y, pdf.Helvetica, 10, label)
+		page.Text(receiptValueX, y, font, 11, fitText(value, font, 11, pdf.PageWidth-receiptMargin-receiptValueX))
+		y -= receiptLineHeight
+	}
+
+	row("Receipt number", strconv.Itoa(transaction.ID), pdf.HelveticaBold)
+	if transaction.Reference != "" {
+		row("Payment reference", transaction.Reference, pdf.Helvetica)
+	}
+	row("Date", transaction.TransactionDate.Local().Format("2006-01-02 15:04:05 MST"), pdf.Helvetica)
+	row("Type", string(transaction.TransactionType), pdf.Helvetica)
+	row("Status", string(transaction.Status), pdf.Helvetica)
+	if transaction.DeclineReason != "" {
+		row("Decline reason", string(transaction.DeclineReason), pdf.Helvetica)
+	}
+	row("Amount", fmt.Sprintf("%.2f %s", transaction.Amount, transaction.Currency), pdf.HelveticaBold)
+	y -= receiptLineHeight / 2
+
+	for _, party := range []struct {
+		label     string
+		accountID *int
+	}{{"Payer", transaction.SourceAccountID}, {"Payee", transaction.DestinationAccountID}} {
+		if party.accountID == nil {
+			continue
+		}
+		account, err := s.repos.Account.GetByID(ctx, *party.accountID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get account: %w", err)
+		}
+		if name := accountOwnerName(ctx, s.repos, account); name != "" {
+			row(party.label, name, pdf.Helvetica)
+		}
+		row(party.label+" account", models.MaskAccountNumber(account.AccountNumber), pdf.Helvetica)
+	}
+
+	if transaction.Description != "" {
+		row("Description", transaction.Description, pdf.Helvetica)
+	}
+
+	// Stamp
+	stampX, stampY := pdf.PageWidth-receiptMargin-80, pdf.PageHeight-250.0
+	page.Save()
+	page.Rotate(stampX, stampY, 12)
+	page.StrokeColor(0.1, 0.3, 0.7)
+	page.FillColor(0.1, 0.3, 0.7)
+	page.StrokeCircle(stampX, stampY, 62, 2.5)
+	page.StrokeCircle(stampX, stampY, 56, 1)
+	page.TextCenter(stampX, stampY+28, pdf.HelveticaBold, 8, fitText(strings.ToUpper(brand.Name), pdf.HelveticaBold, 8, 84))
+	page.TextCenter(stampX, stampY-7, pdf.HelveticaBold, 20, receiptStamps[transaction.Status])
+	page.TextCenter(stampX, stampY-34, pdf.Helvetica, 8, transaction.TransactionDate.Local().Format("2006-01-02"))
+	page.Restore()
+
+	// Verification QR code with the light border readers need
+	module := float64(receiptQRSize) / float64(code.Size+2*qr.Quiet)
+	qrX, qrY := float64(receiptMargin), 90.0
+	page.FillColor(0, 0, 0)
+	for line := 0; line < code.Size; line++ {
+		for column := 0; column < code.Size; column++ {
+			if code.Dark(column, line) {
+				page.FillRect(qrX+float64(qr.Quiet+column)*module, qrY+receiptQRSize-float64(qr.Quiet+line+1)*module, module, module)
+			}
+		}
+	}
+
+	textX := qrX + receiptQRSize + 16
+	page.Text(textX, qrY+80, pdf.HelveticaBold, 10, "Verify this receipt")
+	page.Text(textX, qrY+64, pdf.Helvetica, 9, "Scan the code or open the link below to check the receipt")
+	page.Text(textX, qrY+52, pdf.Helvetica, 9, "against the records of "+brand.Name+".")
+	for i, line := range wrapText(verifyURL, pdf.Helvetica, 7, pdf.PageWidth-receiptMargin-textX) {
+		page.Text(textX, qrY+34-float64(i)*9, pdf.Helvetica, 7, line)
+	}
+
+	page.FillColor(0.4, 0.4, 0.4)
+	page.Text(receiptMargin, 56, pdf.Helvetica, 8, "This receipt was issued electronically and is valid without a signature.")
+
+	content, err := doc.Bytes()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write receipt: %w", err)
+	}
+
+	s.logger.Infof("Receipt of transaction %d issued to user %d", transaction.ID, userID)
+
+	return fmt.Sprintf("receipt_%d.pdf", transaction.ID), content, nil
+}
+
+// Verify checks the token of a receipt's verification link and returns what the bank has on record
+// for the transaction
+func (s *ReceiptSvc) Verify(ctx context.Context, token string) (*models.ReceiptVerification, error) {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid receipt token")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(s.signer.Sign("receipt."+parts[0])), []byte(parts[1])) != 1 {
+		return nil, errors.New("invalid receipt token")
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, errors.New("invalid receipt token")
+	}
+
+	transaction, err := s.repos.Transaction.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := &models.ReceiptVerification{
+		TransactionID:   transaction.ID,
+		TransactionType: transaction.TransactionType,
+		Amount:          transaction.Amount,
+		Currency:        transaction.Currency,
+		Status:          transaction.Status,
+		TransactionDate: transaction.TransactionDate,
+	}
+	if transaction.SourceAccountID != nil {
+		if account, err := s.repos.Account.GetByID(ctx, *transaction.SourceAccountID); err == nil {
+			verification.PayerAccount = models.MaskAccountNumber(account.AccountNumber)
+		}
+	}
+	if transaction.DestinationAccountID != nil {
+		if account, err := s.repos.Account.GetByID(ctx, *transaction.DestinationAccountID); err == nil {
+			verification.PayeeAccount = models.MaskAccountNumber(account.AccountNumber)
+		}
+	}
+
+	return verification, nil
+}
+
+// verificationToken creates the signed token of the verification link of a transaction's receipt
+func (s *ReceiptSvc) verificationToken(id int) string {
+	payload := strconv.Itoa(id)
+	return payload + "." + s.signer.Sign("receipt."+payload)
+}
+
+// wrapText breaks text that has no spaces, such as a link, into lines that fit the width
+func wrapText(text string, font pdf.Font, size, width float64) []string {
+	var lines []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		n := len(runes)
+		for n > 1 && pdf.TextWidth(font, size, string(runes[:n])) > width {
+			n--
+		}
+		lines = append(lines, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return lines
+}
+
+// fitText shortens text with an ellipsis until it fits the width
+func fitText(text string, font pdf.Font, size, width float64) string {
+	if pdf.TextWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.TextWidth(font, size, string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// referralCodeAlphabet leaves out characters that are easy to confuse when a code is typed in
+const referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// ReferralSvc is an implementation of the service.ReferralService interface
+type ReferralSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	notifications NotificationService
+}
+
+// NewReferralService creates a new ReferralSvc
+func NewReferralService(deps Dependencies) *ReferralSvc {
+	return &ReferralSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		notifications: NewNotificationService(deps),
+	}
+}
+
+// GetSummary gets the user's referral code, creating it on first use, and what it has earned
+func (s *ReferralSvc) GetSummary(ctx context.Context, userID int) (*models.ReferralSummary, error) {
+	code, err := s.getOrCreateCode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	referrals, err := s.repos.Referral.GetByReferrerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrals: %w", err)
+	}
+
+	summary := models.ToReferralSummary(code, referrals)
+	summary.ReferrerBonus = s.config.Referral.ReferrerBonus
+	summary.RefereeBonus = s.config.Referral.RefereeBonus
+	summary.MinDeposit = s.config.Referral.MinDeposit
+	summary.QualifyDays = s.config.Referral.QualifyDays
+
+	return summary, nil
+}
+
+// GetReferrals gets the users the user has invited
+func (s *ReferralSvc) GetReferrals(ctx context.Context, userID int) ([]*models.Referral, error) {
+	referrals, err := s.repos.Referral.GetByReferrerID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrals: %w", err)
+	}
+
+	return referrals, nil
+}
+
+// QualifyDeposit qualifies the user's referral on its first deposit of at least the minimum
+// amount and pays out the bonuses
+func (s *ReferralSvc) QualifyDeposit(ctx context.Context, userID int, transactionID int, amount float64) error {
+	referral, err := s.repos.Referral.GetByRefereeID(ctx, userID)
+	if err != nil {
+		// Most users were not referred
+		return nil
+	}
+
+	if referral.Status != models.ReferralStatusPending || amount < s.config.Referral.MinDeposit {
+		return nil
+	}
+
+	deadline := referral.CreatedAt.AddDate(0, 0, s.config.Referral.QualifyDays)
+	if time.Now().After(deadline) {
+		return nil
+	}
+
+	qualified, err := s.repos.Referral.Qualify(ctx, referral.ID, transactionID)
+	if err != nil {
+		return err
+	}
+
+	if !qualified {
+		return nil
+	}
+
+	s.logger.Infof("Referral %d qualified by deposit transaction %d", referral.ID, transactionID)
+
+	referral.Status = models.ReferralStatusQualified
+	return s.reward(ctx, referral)
+}
+
+// ProcessReferrals expires referrals that did not qualify in time and retries bonus payouts,
+// e.g. for users who had no RUB account when their referral qualified
+func (s *ReferralSvc) ProcessReferrals(ctx context.Context) error {
+	before := time.Now().AddDate(0, 0, -s.config.Referral.QualifyDays)
+	expired, err := s.repos.Referral.ExpirePending(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if expired > 0 {
+		s.logger.Infof("Expired %d referrals", expired)
+	}
+
+	referrals, err := s.repos.Referral.GetByStatus(ctx, models.ReferralStatusQualified)
+	if err != nil {
+		return fmt.Errorf("failed to get qualified referrals: %w", err)
+	}
+
+	for _, referral := range referrals {
+		if err := s.reward(ctx, referral); err != nil {
+			s.logger.Warnf("Failed to pay referral %d bonuses: %v", referral.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// reward pays the bonuses of a qualified referral to the referrer and the referee
+func (s *ReferralSvc) reward(ctx context.Context, referral *models.Referral) error {
+	referrerAccount, err := s.bonusAccount(ctx, referral.ReferrerID)
+	if err != nil {
+		return err
+	}
+
+	var refereeAccount *models.Account
+	if s.config.Referral.RefereeBonus > 0 {
+		refereeAccount, err = s.bonusAccount(ctx, referral.RefereeID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Start a transaction
+	tx, err := s.repos.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	referral.ReferrerBonus = s.config.Referral.ReferrerBonus
+	referral.ReferrerBonusTxID, err = s.payBonusTx(ctx, tx, referrerAccount, referral.ReferrerBonus,
+		fmt.Sprintf("Referral bonus for inviting %s", referral.RefereeUsername))
+	if err != nil {
+		return err
+	}
+
+	if refereeAccount != nil {
+		referral.RefereeBonus = s.config.Referral.RefereeBonus
+		referral.RefereeBonusTxID, err = s.payBonusTx(ctx, tx, refereeAccount, referral.RefereeBonus, "Welcome bonus")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Claim the referral; a concurrent payout wins the race
+	rewarded, err := s.repos.Referral.RewardTx(ctx, tx, referral)
+	if err != nil {
+		return err
+	}
+
+	if !rewarded {
+		err = errors.New("referral bonuses have already been paid")
+		return err
+	}
+
+	// Commit the transaction
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.logger.Infof("Referral %d rewarded: %f to user %d, %f to user %d",
+		referral.ID, referral.ReferrerBonus, referral.ReferrerID, referral.RefereeBonus, referral.RefereeID)
+
+	if err := s.notifications.Notify(ctx, referral.ReferrerID, models.NotificationTypeReferral, "referral_bonus",
+		referral.RefereeUsername, referral.ReferrerBonus, referrerAccount.AccountNumber); err != nil {
+		s.logger.Warnf("Failed to notify user %d about referral bonus: %v", referral.ReferrerID, err)
+	}
+
+	if refereeAccount != nil {
+		if err := s.notifications.Notify(ctx, referral.RefereeID, models.NotificationTypeReferral, "welcome_bonus",
+			referral.RefereeBonus, refereeAccount.AccountNumber); err != nil {
+			s.logger.Warnf("Failed to notify user %d about welcome bonus: %v", referral.RefereeID, err)
+		}
+	}
+
+	return nil
+}
+
+// payBonusTx credits a bonus to an account and records it as a BONUS transaction
+func (s *ReferralSvc) payBonusTx(ctx context.Context, tx *sql.Tx, account *models.Account, amount float64, description string) (*int, error) {
+	if err := s.repos.Account.UpdateBalanceTx(ctx, tx, account.ID, amount); err != nil {
+		return nil, fmt.Errorf("failed to update account balance: %w", err)
+	}
+
+	transaction := &models.Transaction{
+		TransactionType:      models.TransactionTypeBonus,
+		DestinationAccountID: &account.ID,
+		Amount:               amount,
+		Currency:             models.CurrencyRUB,
+		Description:          description,
+		Status:               models.TransactionStatusCompleted,
+		TransactionDate:      time.Now(),
+	}
+
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bonus transaction: %w", err)
+	}
+
+	return &transactionID, nil
+}
+
+// bonusAccount picks the personal RUB account a user receives bonuses on
+func (s *ReferralSvc) bonusAccount(ctx context.Context, userID int) (*models.Account, error) {
+	accounts, err := s.repos.Account.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		if account.IsActive && account.Currency == models.CurrencyRUB {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("user %d has no active RUB account for the bonus", userID)
+}
+
+// getOrCreateCode gets the user's referral code, generating one if the user has none yet
+func (s *ReferralSvc) getOrCreateCode(ctx context.Context, userID int) (string, error) {
+	if code, err := s.repos.Referral.GetCodeByUserID(ctx, userID); err == nil {
+		return code, nil
+	}
+
+	code, err := newReferralCode()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate referral code: %w", err)
+	}
+
+	if err := s.repos.Referral.CreateCode(ctx, userID, code); err != nil {
+		return "", err
+	}
+
+	// Read back in case a concurrent request created the code first
+	return s.repos.Referral.GetCodeByUserID(ctx, userID)
+}
+
+// newReferralCode generates a random 8-character referral code
+func newReferralCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	for i := range b {
+		b[i] = referralCodeAlphabet[int(b[i])%len(referralCodeAlphabet)]
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/storage"
+)
+
+// ReportingSvc is an implementation of the service.ReportingService interface. Reports cover the
+// tenant of the request and are cached in memory per tenant for the configured TTL, so a
+// dashboard refreshing often does not repeat the aggregate queries.
+type ReportingSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	lifecycle *lifecycle.Manager
+	storage   storage.Storage
+	bank      *time.Location
+
+	mu    sync.Mutex
+	cache map[string]cachedReport
+}
+
+// cachedReport is a computed report and when it stops being served
+type cachedReport struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+// NewReportingService creates a new ReportingSvc
+func NewReportingService(deps Dependencies) *ReportingSvc {
+	return &ReportingSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		lifecycle: deps.Lifecycle,
+		storage:   deps.Storage,
+		bank:      deps.Bank,
+		cache:     make(map[string]cachedReport),
+	}
+}
+
+// GetTransactionVolume gets the daily volume and value of completed transactions
+func (s *ReportingSvc) GetTransactionVolume(ctx context.Context, period models.ReportPeriod) ([]*models.DailyTransactionVolume, error) {
+	value, err := s.cached(ctx, periodKey("transactions", period), func() (interface{}, error) {
+		return s.repos.Reporting.GetTransactionVolume(ctx, period.From, period.To)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*models.DailyTransactionVolume), nil
+}
+
+// GetNewUsers gets the daily number of registered users
+func (s *ReportingSvc) GetNewUsers(ctx context.Context, period models.ReportPeriod) ([]*models.DailyNewUsers, error) {
+	value, err := s.cached(ctx, periodKey("users", period), func() (interface{}, error) {
+		return s.repos.Reporting.GetNewUsers(ctx, period.From, period.To)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*models.DailyNewUsers), nil
+}
+
+// GetCreditsIssued gets the daily number and principal of issued credits
+func (s *ReportingSvc) GetCreditsIssued(ctx context.Context, period models.ReportPeriod) ([]*models.DailyCreditsIssued, error) {
+	value, err := s.cached(ctx, periodKey("credits", period), func() (interface{}, error) {
+		return s.repos.Reporting.GetCreditsIssued(ctx, period.From, period.To)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*models.DailyCreditsIssued), nil
+}
+
+// GetCreditPortfolio gets the current outstanding, overdue and non-performing credits
+func (s *ReportingSvc) GetCreditPortfolio(ctx context.Context) ([]*models.CreditPortfolio, error) {
+	value, err := s.cached(ctx, "portfolio", func() (interface{}, error) {
+		return s.repos.Reporting.GetCreditPortfolio(ctx, s.config.Reporting.NPLDays)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*models.CreditPortfolio), nil
+}
+
+// GetDepositBalances gets the current balances of deposit accounts
+func (s *ReportingSvc) GetDepositBalances(ctx context.Context) ([]*models.DepositBalance, error) {
+	value, err := s.cached(ctx, "deposits", func() (interface{}, error) {
+		return s.repos.Reporting.GetDepositBalances(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]*models.DepositBalance), nil
+}
+
+// GetDashboard gets all reports for a period
+func (s *ReportingSvc) GetDashboard(ctx context.Context, period models.ReportPeriod) (*models.Dashboard, error) {
+	var err error
+	dashboard := &models.Dashboard{Period: period}
+
+	if dashboard.TransactionVolume, err = s.GetTransactionVolume(ctx, period); err != nil {
+		return nil, err
+	}
+
+	if dashboard.NewUsers, err = s.GetNewUsers(ctx, period); err != nil {
+		return nil, err
+	}
+
+	if dashboard.CreditsIssued, err = s.GetCreditsIssued(ctx, period); err != nil {
+		return nil, err
+	}
+
+	if dashboard.CreditPortfolio, err = s.GetCreditPortfolio(ctx); err != nil {
+		return nil, err
+	}
+
+	if dashboard.DepositBalances, err = s.GetDepositBalances(ctx); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
+}
+
+// ExportCreditPortfolio builds the CSV of all outstanding credits as of a day for the risk models
+// and returns its file name and content. Unlike the accounting export it is plain machine-readable
+// CSV: commas, decimal points and YYYY-MM-DD dates.
+func (s *ReportingSvc) ExportCreditPortfolio(ctx context.Context, asOf time.Time) (string, []byte, error) {
+	items, err := s.repos.Reporting.GetCreditPortfolioItems(ctx, asOf)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rows := [][]string{{
+		"as_of", "credit_id", "user_id", "account_id", "currency", "status", "amount", "interest_rate",
+		"term_months", "monthly_payment", "start_date", "end_date", "outstanding_principal", "overdue_amount",
+		"penalty_amount", "days_past_due", "dpd_bucket", "next_payment_date", "insured",
+	}}
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.AsOf.Format(portfolioDateFormat),
+			strconv.Itoa(item.CreditID),
+			strconv.Itoa(item.UserID),
+			strconv.Itoa(item.AccountID),
+			string(item.Currency),
+			string(item.Status),
+			portfolioAmount(item.Amount),
+			portfolioAmount(item.InterestRate),
+			strconv.Itoa(item.TermMonths),
+			portfolioAmount(item.MonthlyPayment),
+			item.StartDate.Format(portfolioDateFormat),
+			item.EndDate.Format(portfolioDateFormat),
+			portfolioAmount(item.OutstandingPrincipal),
+			portfolioAmount(item.OverdueAmount),
+			portfolioAmount(item.PenaltyAmount),
+			strconv.Itoa(item.DaysPastDue()),
+			item.DPDBucket(),
+			portfolioDate(item.NextPaymentDate),
+			strconv.FormatBool(item.Insured),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := csv.NewWriter(&buf).WriteAll(rows); err != nil {
+		return "", nil, fmt.Errorf("failed to write credit portfolio: %w", err)
+	}
+
+	name := fmt.Sprintf("credit_portfolio_%s.csv", asOf.Format(portfolioDateFormat))
+
+	s.logger.Infof("Credit portfolio export %s built with %d credits", name, len(items))
+
+	return name, buf.Bytes(), nil
+}
+
+// DropCreditPortfolio exports today's credit portfolio of the request's tenant to object storage,
+// or one file per tenant in background jobs. Exporting the same day again replaces the file, so
+// reruns are safe.
+func (s *ReportingSvc) DropCreditPortfolio(ctx context.Context) error {
+	if tenant := requestTenant(ctx); tenant != "" {
+		return s.dropCreditPortfolio(ctx, tenant)
+	}
+
+	tenants := []string{models.DefaultTenant}
+	for _, tenant := range s.config.Tenants {
+		if tenant.Code != models.DefaultTenant {
+			tenants = append(tenants, tenant.Code)
+		}
+	}
+
+	for _, tenant := range tenants {
+		if err := s.dropCreditPortfolio(context.WithValue(ctx, "tenant", tenant), tenant); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dropCreditPortfolio exports today's credit portfolio of a tenant, which ctx must carry
+func (s *ReportingSvc) dropCreditPortfolio(ctx context.Context, tenant string) error {
+	now := time.Now().In(s.bank)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.bank)
+
+	_, content, err := s.ExportCreditPortfolio(ctx, today)
+	if err != nil {
+		return fmt.Errorf("failed to build credit portfolio export: %w", err)
+	}
+
+	key := creditPortfolioKey(tenant, today)
+	if err := s.storage.Put(ctx, key, content, "text/csv"); err != nil {
+		return fmt.Errorf("failed to store credit portfolio export %s: %w", key, err)
+	}
+
+	s.logger.Infof("Credit portfolio export stored as %s", key)
+
+	return nil
+}
+
+// StartCreditPortfolioExport exports today's credit portfolio of the request's tenant in the
+// background and returns the storage key the file will be available under
+func (s *ReportingSvc) StartCreditPortfolioExport(ctx context.Context) string {
+	tenant := requestTenantOrDefault(ctx)
+	now := time.Now().In(s.bank)
+	s.lifecycle.Background("credit-portfolio-export", func(ctx context.Context) error {
+		return s.dropCreditPortfolio(context.WithValue(ctx, "tenant", tenant), tenant)
+	})
+
+	return creditPortfolioKey(tenant, time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, s.bank))
+}
+
+// GetCreditPortfolioExport reads the stored credit portfolio export of the request's tenant of a
+// day and returns its file name and content; storage.ErrNotFound means it was not exported that day
+func (s *ReportingSvc) GetCreditPortfolioExport(ctx context.Context, day time.Time) (string, []byte, error) {
+	content, err := s.storage.Get(ctx, creditPortfolioKey(requestTenantOrDefault(ctx), day))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("credit_portfolio_%s.csv", day.Format(portfolioDateFormat)), content, nil
+}
+
+// cached returns the report of the request's tenant stored under key, computing and storing it if
+// it is missing or expired
+func (s *ReportingSvc) cached(ctx context.Context, key string, compute func() (interface{}, error)) (interface{}, error) {
+	ttl := time.Duration(s.config.Reporting.CacheTTL) * time.Second
+	now := time.Now()
+	key = requestTenant(ctx) + "/" + key
+
+	s.mu.Lock()
+	entry, ok := s.cache[key]
+	s.mu.Unlock()
+
+	if ok && now.Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl > 0 {
+		s.mu.Lock()
+		// Drop expired entries so reports for old periods do not accumulate
+		for k, e := range s.cache {
+			if !now.Before(e.expiresAt) {
+				delete(s.cache, k)
+			}
+		}
+		s.cache[key] = cachedReport{value: value, expiresAt: now.Add(ttl)}
+		s.mu.Unlock()
+	}
+
+	return value, nil
+}
+
+// periodKey builds the cache key of a report over a period
+func periodKey(report string, period models.ReportPeriod) string {
+	return fmt.Sprintf("%s:%d:%d", report, period.From.Unix(), period.To.Unix())
+}
+
+// portfolioDateFormat is the date format of the credit portfolio export and its file names
+const portfolioDateFormat = "2006-01-02"
+
+// creditPortfolioKey returns the storage key of the credit portfolio export of a tenant of a day.
+// The default tenant keeps the key it had before tenants existed, which the risk models read.
+func creditPortfolioKey(tenant string, day time.Time) string {
+	if tenant == models.DefaultTenant {
+		return fmt.Sprintf("%s_%s.csv", models.CreditPortfolioExportPrefix, day.Format(portfolioDateFormat))
+	}
+	return fmt.Sprintf("%s_%s_%s.csv", models.CreditPortfolioExportPrefix, tenant, day.Format(portfolioDateFormat))
+}
+
+// portfolioAmount formats an amount with two decimals and a decimal point
+func portfolioAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// portfolioDate formats an optional date, empty if it is not set
+func portfolioDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(portfolioDateFormat)
+}
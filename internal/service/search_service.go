@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/search"
+)
+
+const (
+	// searchIndexBatch is how many transaction events the indexer handles per bulk request
+	searchIndexBatch = 500
+	// searchReindexBatch is how many transactions a reindex reads and indexes at a time
+	searchReindexBatch = 1000
+	// searchEventRetention is how long processed transaction events are kept
+	searchEventRetention = 24 * time.Hour
+	// searchReindexOverlap is how far before the start of a reindex its catch-up goes back, so
+	// transactions committed while the reindex started are not missed
+	searchReindexOverlap = time.Minute
+)
+
+// SearchSvc is an implementation of the service.SearchService interface
+type SearchSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+	index  search.Index
+}
+
+// NewSearchService creates a new SearchSvc
+func NewSearchService(deps Dependencies) *SearchSvc {
+	return &SearchSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+		index:  deps.Search,
+	}
+}
+
+// SearchTransactions finds a page of the transactions of one account the user can view, or of all
+// their personal accounts, with facets. It searches the index and falls back to the database when
+// the index is disabled or unavailable.
+func (s *SearchSvc) SearchTransactions(ctx context.Context, userID int, transactionSearch *models.TransactionSearch) (*models.TransactionSearchResult, error) {
+	if err := transactionSearch.ValidateTransactionSearch(); err != nil {
+		return nil, fmt.Errorf("invalid search data: %w", err)
+	}
+
+	accountIDs, err := s.searchAccountIDs(ctx, userID, transactionSearch.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(accountIDs) == 0 {
+		return &models.TransactionSearchResult{
+			Transactions: []*models.Transaction{},
+			Facets:       models.NewTransactionFacets(),
+			Source:       models.SearchSourceDatabase,
+			Limit:        transactionSearch.Limit,
+			Offset:       transactionSearch.Offset,
+		}, nil
+	}
+
+	var result *models.TransactionSearchResult
+	if s.index != nil {
+		result, err = s.searchIndex(ctx, accountIDs, transactionSearch)
+		if err != nil {
+			s.logger.Warnf("Transaction search index unavailable, searching the database: %v", err)
+		}
+	}
+
+	if result == nil {
+		result, err = s.repos.Transaction.Search(ctx, accountIDs, transactionSearch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search transactions: %w", err)
+		}
+		result.Source = models.SearchSourceDatabase
+	}
+
+	// Matches are found by the original descriptions and shown with the user's
+	if err := applyDescriptionEdits(ctx, s.repos, userID, result.Transactions); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// searchAccountIDs gets the accounts a search covers
+func (s *SearchSvc) searchAccountIDs(ctx context.Context, userID int, accountID int) ([]int, error) {
+	if accountID != 0 {
+		account, err := s.repos.Account.GetByID(ctx, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+
+		if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+			return nil, err
+		}
+
+		return []int{account.ID}, nil
+	}
+
+	accounts, err := s.repos.Account.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	accountIDs := make([]int, 0, len(accounts))
+	for _, account := range accounts {
+		accountIDs = append(accountIDs, account.ID)
+	}
+
+	return accountIDs, nil
+}
+
+// searchIndex searches the index and loads the found transactions from the database, so the
+// results are never staler than the page they are shown on
+func (s *SearchSvc) searchIndex(ctx context.Context, accountIDs []int, transactionSearch *models.TransactionSearch) (*models.TransactionSearchResult, error) {
+	found, err := s.index.Search(ctx, &search.Query{
+		AccountIDs: accountIDs,
+		Text:       transactionSearch.Query,
+		Type:       string(transactionSearch.Type),
+		Status:     string(transactionSearch.Status),
+		Currency:   string(transactionSearch.Currency),
+		From:       transactionSearch.From,
+		To:         transactionSearch.To,
+		MinAmount:  transactionSearch.MinAmount,
+		MaxAmount:  transactionSearch.MaxAmount,
+		Limit:      transactionSearch.Limit,
+		Offset:     transactionSearch.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.repos.Transaction.GetByIDs(ctx, found.IDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	byID := make(map[int]*models.Transaction, len(transactions))
+	for _, transaction := range transactions {
+		byID[transaction.ID] = transaction
+	}
+
+	result := &models.TransactionSearchResult{
+		Transactions: make([]*models.Transaction, 0, len(found.IDs)),
+		Total:        found.Total,
+		Facets:       models.NewTransactionFacets(),
+		Source:       models.SearchSourceIndex,
+		Limit:        transactionSearch.Limit,
+		Offset:       transactionSearch.Offset,
+	}
+	for _, id := range found.IDs {
+		if transaction, ok := byID[id]; ok {
+			result.Transactions = append(result.Transactions, transaction)
+		}
+	}
+	for facet, counts := range map[string]map[string]int{
+		search.FacetType:     result.Facets.Type,
+		search.FacetStatus:   result.Facets.Status,
+		search.FacetCurrency: result.Facets.Currency,
+	} {
+		for value, count := range found.Facets[facet] {
+			counts[value] = count
+		}
+	}
+
+	return result, nil
+}
+
+// IndexPending indexes the transactions created or changed since the last run, in the order of
+// their events. Events stay queued when the index is unavailable and are retried on the next run.
+func (s *SearchSvc) IndexPending(ctx context.Context) error {
+	if s.index == nil {
+		return errors.New("search index is disabled")
+	}
+
+	indexed := 0
+	for {
+		events, err := s.repos.TransactionEvent.GetUnprocessed(ctx, searchIndexBatch)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction events: %w", err)
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		eventIDs := make([]int, 0, len(events))
+		transactionIDs := make([]int, 0, len(events))
+		seen := map[int]bool{}
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.ID)
+			if !seen[event.TransactionID] {
+				seen[event.TransactionID] = true
+				transactionIDs = append(transactionIDs, event.TransactionID)
+			}
+		}
+
+		count, err := s.put(ctx, transactionIDs)
+		if err != nil {
+			s.logger.Warnf("Failed to index %d transactions, will retry: %v", len(transactionIDs), err)
+			return nil
+		}
+
+		if err := s.repos.TransactionEvent.MarkProcessed(ctx, eventIDs); err != nil {
+			return fmt.Errorf("failed to mark transaction events processed: %w", err)
+		}
+		indexed += count
+
+		if len(events) < searchIndexBatch {
+			break
+		}
+	}
+
+	if indexed > 0 {
+		s.logger.Infof("Indexed %d transactions", indexed)
+	}
+
+	deleted, err := s.repos.TransactionEvent.DeleteProcessed(ctx, time.Now().Add(-searchEventRetention))
+	if err != nil {
+		return fmt.Errorf("failed to delete processed transaction events: %w", err)
+	}
+	if deleted > 0 {
+		s.logger.Infof("Deleted %d processed transaction events", deleted)
+	}
+
+	return nil
+}
+
+// Reindex builds a new index of all transactions and puts it in place of the current one, then
+// indexes the transactions that changed while it was being built. It returns how many
+// transactions were indexed.
+func (s *SearchSvc) Reindex(ctx context.Context) (int, error) {
+	if s.index == nil {
+		return 0, errors.New("search index is disabled")
+	}
+
+	started := time.Now()
+	indexed := 0
+	err := s.index.Rebuild(ctx, func(put func(docs []*search.Document) error) error {
+		lastID := 0
+		for {
+			transactions, err := s.repos.Transaction.GetAfterID(ctx, lastID, searchReindexBatch)
+			if err != nil {
+				return fmt.Errorf("failed to get transactions: %w", err)
+			}
+			if len(transactions) == 0 {
+				return nil
+			}
+
+			if err := put(transactionDocuments(transactions)); err != nil {
+				return err
+			}
+
+			indexed += len(transactions)
+			lastID = transactions[len(transactions)-1].ID
+			s.logger.Infof("Reindexed %d transactions", indexed)
+		}
+	})
+	if err != nil {
+		return indexed, fmt.Errorf("failed to rebuild search index: %w", err)
+	}
+
+	changed, err := s.repos.TransactionEvent.GetTransactionIDsSince(ctx, started.Add(-searchReindexOverlap))
+	if err != nil {
+		return indexed, fmt.Errorf("failed to get transaction events: %w", err)
+	}
+	if _, err := s.put(ctx, changed); err != nil {
+		return indexed, err
+	}
+
+	s.logger.Infof("Search index rebuilt with %d transactions, %d changed during the rebuild", indexed, len(changed))
+
+	return indexed, nil
+}
+
+// put indexes the current state of transactions and returns how many were found
+func (s *SearchSvc) put(ctx context.Context, ids []int) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	transactions, err := s.repos.Transaction.GetByIDs(ctx, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	if err := s.index.Put(ctx, transactionDocuments(transactions)); err != nil {
+		return 0, err
+	}
+
+	return len(transactions), nil
+}
+
+// transactionDocuments converts transactions to search documents
+func transactionDocuments(transactions []*models.Transaction) []*search.Document {
+	docs := make([]*search.Document, 0, len(transactions))
+	for _, transaction := range transactions {
+		doc := &search.Document{
+			ID:          transaction.ID,
+			Type:        string(transaction.TransactionType),
+			Status:      string(transaction.Status),
+			Currency:    string(transaction.Currency),
+			Description: transaction.Description,
+			Amount:      transaction.Amount,
+			AccountIDs:  []int{},
+			CardID:      transaction.CardID,
+			Date:        transaction.TransactionDate,
+		}
+		if transaction.SourceAccountID != nil {
+			doc.AccountIDs = append(doc.AccountIDs, *transaction.SourceAccountID)
+		}
+		if transaction.DestinationAccountID != nil && (transaction.SourceAccountID == nil || *transaction.DestinationAccountID != *transaction.SourceAccountID) {
+			doc.AccountIDs = append(doc.AccountIDs, *transaction.DestinationAccountID)
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs
+}
@@ -9,14 +9,68 @@ import (
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
+	"banking-service/pkg/antivirus"
+	"banking-service/pkg/cbr"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/httpclient"
+	"banking-service/pkg/lifecycle"
+	"banking-service/pkg/ocr"
+	"banking-service/pkg/search"
+	"banking-service/pkg/storage"
+	"banking-service/pkg/upload"
 )
 
 // UserService defines methods for user service
 type UserService interface {
 	Register(ctx context.Context, user *models.UserRegistration) (int, error)
-	Login(ctx context.Context, login *models.UserLogin) (*models.TokenResponse, error)
+	CheckAvailability(ctx context.Context, request *models.AvailabilityRequest) (*models.Availability, error)
+	Login(ctx context.Context, login *models.UserLogin, client models.ClientInfo) (*models.TokenResponse, error)
+	IssueScopedToken(ctx context.Context, userID int, sessionID string, request *models.ScopedTokenRequest) (*models.TokenResponse, error)
 	GetByID(ctx context.Context, id int) (*models.User, error)
 	Update(ctx context.Context, user *models.User) error
+	ChangePassword(ctx context.Context, userID int, currentSessionID string, change *models.PasswordChangeRequest) error
+	ForgotPassword(ctx context.Context, forgot *models.PasswordForgotRequest) error
+	ResetPassword(ctx context.Context, reset *models.PasswordResetRequest) error
+	UpdateProfile(ctx context.Context, userID int, profile *models.ProfileRequest) (*models.User, error)
+	SetTimezone(ctx context.Context, userID int, timezone string) error
+	SetLanguage(ctx context.Context, userID int, language string) error
+}
+
+// OnboardingService defines methods for the onboarding of new customers
+type OnboardingService interface {
+	GetStatus(ctx context.Context, userID int) (*models.OnboardingStatus, error)
+	Advance(ctx context.Context, userID int) (*models.OnboardingStatus, error)
+	Require(ctx context.Context, userID int, stage models.OnboardingStage) error
+	SendEmailVerification(ctx context.Context, userID int) error
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+// SessionService defines methods for session service
+type SessionService interface {
+	Start(ctx context.Context, userID int, client models.ClientInfo, expiresAt time.Time) (string, error)
+	GetByUserID(ctx context.Context, userID int, currentSessionID string, history bool) ([]*models.Session, error)
+	Revoke(ctx context.Context, id int, userID int) error
+	GetByRevokeToken(ctx context.Context, token string) (*models.Session, error)
+	RevokeByToken(ctx context.Context, token string) error
+	Logout(ctx context.Context, sessionID string, userID int, everywhere bool) (int, error)
+	Validate(ctx context.Context, sessionID string, userID int) error
+}
+
+// APIKeyService defines methods for the API keys backend partners call the API with
+type APIKeyService interface {
+	Create(ctx context.Context, userID int, request *models.APIKeyCreate) (*models.APIKeyCredentials, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.APIKey, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.APIKey, error)
+	Rotate(ctx context.Context, id int, userID int) (*models.APIKeyCredentials, error)
+	Revoke(ctx context.Context, id int, userID int) error
+	Authenticate(ctx context.Context, apiKey string) (*models.APIKey, *models.User, error)
+}
+
+// NotificationService defines methods for in-app notification service
+type NotificationService interface {
+	Notify(ctx context.Context, userID int, notificationType models.NotificationType, template string, args ...interface{}) error
+	GetByUserID(ctx context.Context, userID int, unreadOnly bool) ([]*models.Notification, error)
+	MarkRead(ctx context.Context, id int, userID int) error
 }
 
 // AccountService defines methods for account service
@@ -24,10 +78,18 @@ type AccountService interface {
 	Create(ctx context.Context, account *models.AccountCreate) (int, error)
 	GetByID(ctx context.Context, id int, userID int) (*models.Account, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Account, error)
+	GetHolds(ctx context.Context, id int, userID int) ([]*models.AccountHold, error)
+	GetActivity(ctx context.Context, id int, userID int, after *models.AccountActivityCursor, limit int) (*models.AccountActivityPage, error)
+	UpdateSettings(ctx context.Context, id int, userID int, update *models.AccountSettingsUpdate) (*models.AccountSettings, error)
+	GetDefault(ctx context.Context, userID int, currency models.Currency) (*models.Account, error)
+	SetDefault(ctx context.Context, id int, userID int) (*models.Account, error)
 	Deposit(ctx context.Context, accountID int, userID int, deposit *models.DepositRequest) (int, error)
 	Withdraw(ctx context.Context, accountID int, userID int, withdrawal *models.WithdrawalRequest) (int, error)
 	Update(ctx context.Context, account *models.Account, userID int) error
 	Delete(ctx context.Context, id int, userID int) error
+	Reactivate(ctx context.Context, id int, userID int, reactivation *models.AccountReactivation) (*models.Account, error)
+	DetectDormant(ctx context.Context) error
+	CheckCurrencies(ctx context.Context) error
 }
 
 // CardService defines methods for card service
@@ -38,26 +100,365 @@ type CardService interface {
 	GetByAccountID(ctx context.Context, accountID int, userID int) ([]*models.CardResponse, error)
 	Update(ctx context.Context, card *models.Card, userID int) error
 	Delete(ctx context.Context, id int, userID int) error
+	SetPIN(ctx context.Context, id int, userID int, pin *models.CardPIN) error
+	ATMWithdraw(ctx context.Context, userID int, withdrawal *models.ATMWithdrawalRequest) (int, error)
+	GetTransactions(ctx context.Context, id int, userID int, filter *models.CardTransactionFilter) (*models.CardTransactionPage, error)
 }
 
 // TransactionService defines methods for transaction service
 type TransactionService interface {
-	Transfer(ctx context.Context, transfer *models.TransferRequest, userID int) (int, error)
+	Transfer(ctx context.Context, transfer *models.TransferRequest, userID int) (*models.TransferResult, error)
+	ConfirmTransfer(ctx context.Context, confirm *models.TransferConfirmRequest, userID int) (int, error)
 	Pay(ctx context.Context, payment *models.PaymentRequest, userID int) (int, error)
 	GetByID(ctx context.Context, id int, userID int) (*models.Transaction, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Transaction, error)
 	GetByAccountID(ctx context.Context, accountID int, userID int) ([]*models.Transaction, error)
 	GetByDateRange(ctx context.Context, userID int, startDate, endDate time.Time) ([]*models.Transaction, error)
+	EditDescription(ctx context.Context, id int, userID int, update *models.TransactionDescriptionUpdate) (*models.Transaction, error)
+	GetDescriptionHistory(ctx context.Context, id int, userID int) (*models.TransactionDescriptionHistory, error)
+	GetPendingTransfer(ctx context.Context, id int, userID int) (*models.PendingTransfer, error)
+	GetPendingTransfers(ctx context.Context, organizationID int, userID int) ([]*models.PendingTransfer, error)
+	ApproveTransfer(ctx context.Context, id int, userID int) (*models.PendingTransfer, error)
+	RejectTransfer(ctx context.Context, id int, userID int) error
 }
 
 // CreditService defines methods for credit service
 type CreditService interface {
 	Create(ctx context.Context, credit *models.CreditRequest) (int, error)
+	Calculate(ctx context.Context, credit *models.CreditRequest) (*models.CreditCalculation, error)
 	GetByID(ctx context.Context, id int, userID int) (*models.Credit, error)
 	GetByUserID(ctx context.Context, userID int) ([]*models.Credit, error)
 	GetSchedule(ctx context.Context, creditID int, userID int) ([]*models.PaymentScheduleResponse, *models.PaymentScheduleSummary, error)
+	GetPayoffQuote(ctx context.Context, creditID int, userID int, extraPayment float64) (*models.PayoffQuote, error)
+	CancelInsurance(ctx context.Context, creditID int, userID int) (*models.InsurancePolicy, error)
 	ProcessPayments(ctx context.Context) error
+	SendReminders(ctx context.Context) error
+	GetKeyRate(ctx context.Context) (*models.KeyRate, error)
+}
+
+// OrganizationService defines methods for organization service
+type OrganizationService interface {
+	Create(ctx context.Context, organization *models.OrganizationCreate, userID int) (int, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.Organization, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Organization, error)
+	GetAccounts(ctx context.Context, id int, userID int) ([]*models.Account, error)
+	GetApprovalPolicies(ctx context.Context, id int, userID int) ([]*models.ApprovalPolicy, error)
+	SetApprovalPolicies(ctx context.Context, id int, update *models.ApprovalPoliciesUpdate, userID int) error
+	UpdateMemberRole(ctx context.Context, id int, memberUserID int, update *models.MemberRoleUpdate, userID int) error
+	RemoveMember(ctx context.Context, id int, memberUserID int, userID int) error
+	Invite(ctx context.Context, id int, invite *models.InvitationCreate, userID int) (int, error)
+	GetInvitations(ctx context.Context, id int, userID int) ([]*models.OrganizationInvitation, error)
+	RevokeInvitation(ctx context.Context, id int, invitationID int, userID int) error
+	GetPendingInvitations(ctx context.Context, userID int) ([]*models.OrganizationInvitation, error)
+	RespondToInvitation(ctx context.Context, invitationID int, userID int, accept bool) error
+}
+
+// PayrollService defines methods for salary payrolls of organizations
+type PayrollService interface {
+	Upload(ctx context.Context, organizationID int, payroll *models.Payroll, content []byte, userID int) (*models.Payroll, error)
+	Execute(ctx context.Context, id int, userID int) (*models.Payroll, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.Payroll, error)
+	GetByOrganizationID(ctx context.Context, organizationID int, userID int) ([]*models.Payroll, error)
+	GetReport(ctx context.Context, id int, userID int) (string, []byte, error)
+}
+
+// BillService defines methods for bill payment service
+type BillService interface {
+	GetProviders(ctx context.Context, category models.BillCategory) ([]*models.BillProvider, error)
+	GetProvider(ctx context.Context, id int) (*models.BillProvider, error)
+	Pay(ctx context.Context, request *models.BillPaymentRequest, userID int) (*models.BillPayment, error)
+	GetPayments(ctx context.Context, userID int) ([]*models.BillPayment, error)
+	PayAgain(ctx context.Context, paymentID int, repeat *models.BillRepeatRequest, userID int) (*models.BillPayment, error)
+	CreateTemplate(ctx context.Context, template *models.BillTemplateCreate, userID int) (int, error)
+	GetTemplates(ctx context.Context, userID int) ([]*models.BillTemplate, error)
+	DeleteTemplate(ctx context.Context, id int, userID int) error
+	PayTemplate(ctx context.Context, id int, repeat *models.BillRepeatRequest, userID int) (*models.BillPayment, error)
+}
+
+// MerchantService defines methods for merchant acquiring service
+type MerchantService interface {
+	Create(ctx context.Context, merchant *models.MerchantCreate, userID int) (*models.MerchantCredentials, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.Merchant, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Merchant, error)
+	RotateAPIKey(ctx context.Context, id int, userID int) (*models.MerchantCredentials, error)
+	GetSettlements(ctx context.Context, id int, userID int) ([]*models.SettlementBatch, error)
+	Authenticate(ctx context.Context, apiKey string) (*models.Merchant, error)
+	CreateIntent(ctx context.Context, merchantID int, intent *models.PaymentIntentCreate) (*models.PaymentIntent, error)
+	GetIntents(ctx context.Context, merchantID int) ([]*models.PaymentIntent, error)
+	GetIntent(ctx context.Context, merchantID int, intentID string) (*models.PaymentIntent, error)
+	CancelIntent(ctx context.Context, merchantID int, intentID string) error
+	GetIntentForCustomer(ctx context.Context, intentID string, userID int) (*models.PaymentIntent, error)
+	PayIntent(ctx context.Context, intentID string, pay *models.PaymentIntentPayRequest, userID int) (*models.PaymentIntent, error)
+	SettlePayments(ctx context.Context) error
+}
+
+// ChargebackService defines methods for card chargeback service
+type ChargebackService interface {
+	Create(ctx context.Context, chargeback *models.ChargebackCreate, userID int) (*models.Chargeback, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.Chargeback, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Chargeback, error)
+	GetForMerchant(ctx context.Context, merchantID int) ([]*models.Chargeback, error)
+	SubmitEvidence(ctx context.Context, id int, merchantID int, evidence *models.ChargebackEvidence) error
+	Accept(ctx context.Context, id int, merchantID int) error
+	GetAll(ctx context.Context, status models.ChargebackStatus) ([]*models.Chargeback, error)
+	Resolve(ctx context.Context, id int, resolution *models.ChargebackResolution) (*models.Chargeback, error)
+	ExpireEvidenceDeadlines(ctx context.Context) error
+}
+
+// EscrowService defines methods for escrow payment service
+type EscrowService interface {
+	Create(ctx context.Context, escrow *models.EscrowCreate, userID int) (*models.Escrow, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.Escrow, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Escrow, error)
+	Confirm(ctx context.Context, id int, userID int) (*models.Escrow, error)
+	GetAll(ctx context.Context, status models.EscrowStatus) ([]*models.Escrow, error)
+	Resolve(ctx context.Context, id int, resolution *models.EscrowResolution, adminID int) (*models.Escrow, error)
+	RefundExpired(ctx context.Context) error
+}
+
+// InvoiceService defines methods for invoice service
+type InvoiceService interface {
+	Create(ctx context.Context, invoice *models.InvoiceCreate, userID int) (*models.Invoice, error)
+	CreateForMerchant(ctx context.Context, invoice *models.InvoiceCreate, merchantID int) (*models.Invoice, error)
+	GetByNumber(ctx context.Context, number string, userID int) (*models.Invoice, error)
+	GetIssued(ctx context.Context, userID int) ([]*models.Invoice, error)
+	GetReceived(ctx context.Context, userID int) ([]*models.Invoice, error)
+	GetForMerchant(ctx context.Context, merchantID int) ([]*models.Invoice, error)
+	Pay(ctx context.Context, number string, payRequest *models.InvoicePayRequest, userID int) (*models.TransferResult, error)
+	Cancel(ctx context.Context, number string, userID int) error
+	CancelForMerchant(ctx context.Context, number string, merchantID int) error
+	MarkOverdue(ctx context.Context) error
+}
+
+// SubscriptionService defines methods for subscription billing service
+type SubscriptionService interface {
+	CreatePlan(ctx context.Context, plan *models.SubscriptionPlanCreate, merchantID int) (*models.SubscriptionPlan, error)
+	GetPlans(ctx context.Context, merchantID int) ([]*models.SubscriptionPlan, error)
+	GetPlan(ctx context.Context, id int) (*models.SubscriptionPlan, error)
+	DeactivatePlan(ctx context.Context, id int, merchantID int) error
+	Subscribe(ctx context.Context, subscription *models.SubscriptionCreate, userID int) (*models.Subscription, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.Subscription, error)
+	GetForMerchant(ctx context.Context, merchantID int) ([]*models.Subscription, error)
+	Cancel(ctx context.Context, id int, userID int) error
+	CancelForMerchant(ctx context.Context, id int, merchantID int) error
+	Report(ctx context.Context, merchantID int) (*models.SubscriptionReport, error)
+	BillDue(ctx context.Context) error
+}
+
+// ChequeDepositService defines methods for cheque and payment order image deposit service
+type ChequeDepositService interface {
+	Submit(ctx context.Context, deposit *models.ChequeDeposit, content []byte, userID int) (*models.ChequeDeposit, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.ChequeDeposit, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.ChequeDeposit, error)
+	GetAll(ctx context.Context, status models.ChequeDepositStatus) ([]*models.ChequeDeposit, error)
+	GetImage(ctx context.Context, id int) (*models.ChequeDeposit, []byte, error)
+	Approve(ctx context.Context, id int, decision *models.ChequeDepositDecision, adminID int) (*models.ChequeDeposit, error)
+	Reject(ctx context.Context, id int, decision *models.ChequeDepositDecision, adminID int) (*models.ChequeDeposit, error)
+}
+
+// InternationalTransferService defines methods for SWIFT/SEPA transfers to foreign banks
+type InternationalTransferService interface {
+	Quote(ctx context.Context, transferCreate *models.InternationalTransferCreate, userID int) (*models.InternationalTransfer, error)
+	Create(ctx context.Context, transferCreate *models.InternationalTransferCreate, userID int) (*models.InternationalTransfer, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.InternationalTransfer, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.InternationalTransfer, error)
+	Track(ctx context.Context, reference string, userID int) (*models.InternationalTransferTracking, error)
+	GetAll(ctx context.Context, status models.InternationalTransferStatus) ([]*models.InternationalTransfer, error)
+	Advance(ctx context.Context) error
+}
+
+// SearchService defines methods for full-text search of transactions
+type SearchService interface {
+	SearchTransactions(ctx context.Context, userID int, search *models.TransactionSearch) (*models.TransactionSearchResult, error)
+	IndexPending(ctx context.Context) error
+	Reindex(ctx context.Context) (int, error)
+}
+
+// WarehouseService defines methods for the data warehouse export
+type WarehouseService interface {
+	Export(ctx context.Context) error
+}
+
+// ReferralService defines methods for referral program service
+type ReferralService interface {
+	GetSummary(ctx context.Context, userID int) (*models.ReferralSummary, error)
+	GetReferrals(ctx context.Context, userID int) ([]*models.Referral, error)
+	QualifyDeposit(ctx context.Context, userID int, transactionID int, amount float64) error
+	ProcessReferrals(ctx context.Context) error
+}
+
+// TaxDocumentService defines methods for yearly tax document service
+type TaxDocumentService interface {
+	GetDocuments(ctx context.Context, userID int) ([]*models.TaxDocument, error)
+	GetDocument(ctx context.Context, userID int, year int) (*models.TaxDocument, error)
+	Download(ctx context.Context, userID int, year int) (string, []byte, error)
+	DeliverYearly(ctx context.Context) error
+	Resend(ctx context.Context, userID int, year int) (*models.TaxDocument, error)
+}
+
+// StatementService defines methods for monthly account statement service
+type StatementService interface {
+	GetByAccountID(ctx context.Context, accountID int, userID int) ([]*models.Statement, error)
+	GetByID(ctx context.Context, accountID int, id int, userID int) (*models.Statement, error)
+	GetCamt053(ctx context.Context, accountID int, id int, userID int) (string, []byte, error)
+	Regenerate(ctx context.Context, accountID int, request *models.StatementRegenerateRequest, userID int) (*models.Statement, error)
+	AdminRegenerate(ctx context.Context, accountID int, request *models.StatementRegenerateRequest, adminID int) (*models.Statement, error)
+	IssueMonthly(ctx context.Context) error
+}
+
+// ReceiptService defines methods for transaction receipts and their public verification
+type ReceiptService interface {
+	Get(ctx context.Context, id int, userID int) (string, []byte, error)
+	Verify(ctx context.Context, token string) (*models.ReceiptVerification, error)
+}
+
+// PaymentVerificationService defines methods for the public verification of payments by reference
+type PaymentVerificationService interface {
+	GetSecret(ctx context.Context, id int, userID int) (*models.PaymentVerificationSecret, error)
+	Verify(ctx context.Context, reference string, secret string) (*models.PaymentVerification, error)
+}
+
+// MessageService defines methods for secure messaging between the bank and customers
+type MessageService interface {
+	CreateThread(ctx context.Context, create *models.ThreadCreate, userID int) (*models.MessageThread, error)
+	GetThreads(ctx context.Context, userID int) ([]*models.MessageThread, error)
+	GetThread(ctx context.Context, id int, userID int) (*models.MessageThread, error)
+	Reply(ctx context.Context, id int, request *models.MessageRequest, userID int) (*models.Message, error)
+	GetUnreadCount(ctx context.Context, userID int) (int, error)
+	GetDocument(ctx context.Context, threadID int, messageID int, userID int) (*models.Message, error)
+	SendToCustomer(ctx context.Context, create *models.ThreadCreate, adminID int) (*models.MessageThread, error)
+	GetAllThreads(ctx context.Context, unreadOnly bool) ([]*models.MessageThread, error)
+	GetThreadForBank(ctx context.Context, id int) (*models.MessageThread, error)
+	ReplyAsBank(ctx context.Context, id int, request *models.MessageRequest, adminID int) (*models.Message, error)
+	GetDocumentForBank(ctx context.Context, threadID int, messageID int) (*models.Message, error)
+}
+
+// CreditAgreementService defines methods for signing credit agreements with a one-time code
+type CreditAgreementService interface {
+	GetAgreement(ctx context.Context, creditID int, userID int) (*models.CreditAgreement, error)
+	RequestSignature(ctx context.Context, creditID int, userID int) (*models.SignatureRequest, error)
+	ConfirmSignature(ctx context.Context, creditID int, confirm *models.SignatureConfirmRequest, userID int, client models.ClientInfo) (*models.CreditSignature, error)
+}
+
+// CreditAdjustmentService defines methods for bank employees to waive penalties, reschedule payments
+// and restructure credits
+type CreditAdjustmentService interface {
+	Get(ctx context.Context, creditID int) (*models.CreditAdjustments, error)
+	WaivePenalty(ctx context.Context, creditID int, paymentID int, request *models.PenaltyWaiverRequest, adminID int) (*models.CreditAdjustment, error)
+	Reschedule(ctx context.Context, creditID int, paymentID int, request *models.RescheduleRequest, adminID int) (*models.CreditAdjustment, error)
+	Restructure(ctx context.Context, creditID int, request *models.RestructureRequest, adminID int) (*models.CreditAdjustment, error)
+}
+
+// AccountOwnershipService defines methods for bank employees to move accounts between users, e.g.
+// to the heir of a deceased customer, with the approval of a second employee
+type AccountOwnershipService interface {
+	Request(ctx context.Context, accountID int, request *models.OwnershipTransferRequest, adminID int) (*models.OwnershipTransfer, error)
+	Approve(ctx context.Context, id int, decision *models.OwnershipTransferDecision, adminID int) (*models.OwnershipTransfer, error)
+	Reject(ctx context.Context, id int, decision *models.OwnershipTransferDecision, adminID int) (*models.OwnershipTransfer, error)
+	Get(ctx context.Context, id int) (*models.OwnershipTransfer, error)
+	GetByAccountID(ctx context.Context, accountID int) ([]*models.OwnershipTransfer, error)
+	GetAll(ctx context.Context, status models.OwnershipTransferStatus) ([]*models.OwnershipTransfer, error)
+}
+
+// ImpersonationService defines methods for support staff acting as customers with read-only tokens
+type ImpersonationService interface {
+	Start(ctx context.Context, staffID int, sessionID string, request *models.ImpersonationStart) (*models.ImpersonationToken, error)
+	End(ctx context.Context, id int, staffID int) (*models.Impersonation, error)
+	Audit(ctx context.Context, request *models.ImpersonationRequest) error
+	Get(ctx context.Context, id int) (*models.Impersonation, error)
+	GetAll(ctx context.Context, staffID int, customerID int) ([]*models.Impersonation, error)
+}
+
+// DelegationService defines methods for powers of attorney: access account owners grant other users
+type DelegationService interface {
+	Grant(ctx context.Context, request *models.DelegationCreate, ownerID int) (*models.AccountDelegation, error)
+	Revoke(ctx context.Context, id int, userID int) (*models.AccountDelegation, error)
+	GetGranted(ctx context.Context, userID int) ([]*models.AccountDelegation, error)
+	GetReceived(ctx context.Context, userID int) ([]*models.AccountDelegation, error)
+	GetEvents(ctx context.Context, id int, userID int) ([]*models.DelegationEvent, error)
+}
+
+// CreditApplicationService defines methods for credit applications and their supporting documents
+type CreditApplicationService interface {
+	Create(ctx context.Context, creditReq *models.CreditRequest) (*models.CreditApplication, error)
+	GetByID(ctx context.Context, id int, userID int) (*models.CreditApplication, error)
+	GetByUserID(ctx context.Context, userID int) ([]*models.CreditApplication, error)
+	UploadDocument(ctx context.Context, id int, doc *models.CreditDocument, content []byte, userID int) (*models.CreditDocument, error)
+	GetDocument(ctx context.Context, id int, documentID int, userID int) (*models.CreditDocument, []byte, error)
+	GetAll(ctx context.Context, status models.CreditApplicationStatus) ([]*models.CreditApplication, error)
+	GetForReview(ctx context.Context, id int) (*models.CreditApplication, error)
+	GetDocumentForReview(ctx context.Context, id int, documentID int) (*models.CreditDocument, []byte, error)
+	ReviewDocument(ctx context.Context, id int, documentID int, review *models.CreditDocumentReview) (*models.CreditApplication, error)
+	Approve(ctx context.Context, id int, decision *models.CreditApplicationDecision, adminID int) (*models.CreditApplication, error)
+	Reject(ctx context.Context, id int, decision *models.CreditApplicationDecision, adminID int) (*models.CreditApplication, error)
+}
+
+// AccountingService defines methods for the accounting department export service
+type AccountingService interface {
+	Export(ctx context.Context, from, to time.Time) (string, []byte, error)
+	DropDaily(ctx context.Context) error
+}
+
+// ReportingService defines methods for admin dashboard reports
+type ReportingService interface {
+	GetTransactionVolume(ctx context.Context, period models.ReportPeriod) ([]*models.DailyTransactionVolume, error)
+	GetNewUsers(ctx context.Context, period models.ReportPeriod) ([]*models.DailyNewUsers, error)
+	GetCreditsIssued(ctx context.Context, period models.ReportPeriod) ([]*models.DailyCreditsIssued, error)
+	GetCreditPortfolio(ctx context.Context) ([]*models.CreditPortfolio, error)
+	GetDepositBalances(ctx context.Context) ([]*models.DepositBalance, error)
+	GetDashboard(ctx context.Context, period models.ReportPeriod) (*models.Dashboard, error)
+	ExportCreditPortfolio(ctx context.Context, asOf time.Time) (string, []byte, error)
+	DropCreditPortfolio(ctx context.Context) error
+	StartCreditPortfolioExport(ctx context.Context) string
+	GetCreditPortfolioExport(ctx context.Context, day time.Time) (string, []byte, error)
+}
+
+// RateService defines methods for central bank rates and their history
+type RateService interface {
 	GetKeyRate(ctx context.Context) (float64, error)
+	GetCurrentKeyRate(ctx context.Context) (*models.KeyRate, error)
+	GetKeyRateOverrides(ctx context.Context) ([]*models.KeyRateOverride, error)
+	CreateKeyRateOverride(ctx context.Context, request *models.KeyRateOverrideRequest, adminID int) (*models.KeyRateOverride, error)
+	RevokeKeyRateOverride(ctx context.Context, id int, adminID int) error
+	GetExchangeRates(ctx context.Context) (map[models.Currency]float64, error)
+	GetHistory(ctx context.Context, query *models.RateHistoryQuery) ([]*models.Rate, error)
+	GetRateAt(ctx context.Context, rateType models.RateType, currency models.Currency, at time.Time) (*models.Rate, error)
+	RecordRates(ctx context.Context) error
+}
+
+// AccountPlanService defines methods for account plans
+type AccountPlanService interface {
+	GetActive(ctx context.Context) ([]*models.AccountPlan, error)
+	GetAll(ctx context.Context) ([]*models.AccountPlan, error)
+	Create(ctx context.Context, request *models.AccountPlanRequest) (*models.AccountPlan, error)
+	Update(ctx context.Context, id int, request *models.AccountPlanRequest) (*models.AccountPlan, error)
+	GetForAccount(ctx context.Context, accountID int, userID int) (*models.AccountPlanStatus, error)
+	Switch(ctx context.Context, accountID int, userID int, request *models.AccountPlanSwitch) (*models.AccountPlanStatus, error)
+	BillMonthly(ctx context.Context) error
+}
+
+// CardProductService defines methods for the card product catalog
+type CardProductService interface {
+	GetActive(ctx context.Context) ([]*models.CardProduct, error)
+	GetAll(ctx context.Context) ([]*models.CardProduct, error)
+	Create(ctx context.Context, request *models.CardProductRequest) (*models.CardProduct, error)
+	Update(ctx context.Context, id int, request *models.CardProductRequest) (*models.CardProduct, error)
+}
+
+// FeeService defines methods for the recurring fees of card products and account plans
+type FeeService interface {
+	GetCharges(ctx context.Context, status models.FeeChargeStatus) ([]*models.FeeCharge, error)
+	GetRuns(ctx context.Context) ([]*models.FeeRun, error)
+	ChargeRecurring(ctx context.Context) error
+}
+
+// LocationService defines methods for the branch and ATM locator
+type LocationService interface {
+	GetNearby(ctx context.Context, query *models.LocationQuery) ([]*models.Location, error)
+	GetAll(ctx context.Context) ([]*models.Location, error)
+	Create(ctx context.Context, request *models.LocationRequest) (*models.Location, error)
+	Update(ctx context.Context, id int, request *models.LocationRequest) (*models.Location, error)
+	Delete(ctx context.Context, id int) error
 }
 
 // AnalyticsService defines methods for analytics service
@@ -65,6 +466,11 @@ type AnalyticsService interface {
 	GetStatistics(ctx context.Context, userID int, period string) (map[string]interface{}, error)
 	PredictBalance(ctx context.Context, accountID int, userID int, days int) (map[string]interface{}, error)
 	GetCreditAnalytics(ctx context.Context, userID int) (map[string]interface{}, error)
+	GetCardAnalytics(ctx context.Context, cardID int, userID int, period string) (map[string]interface{}, error)
+	GetSpendingForecast(ctx context.Context, userID int) (map[string]interface{}, error)
+	GetRecommendations(ctx context.Context, userID int) (map[string]interface{}, error)
+	SimulateCredit(ctx context.Context, userID int, request *models.CreditSimulationRequest) (map[string]interface{}, error)
+	GetCashFlow(ctx context.Context, userID int, granularity, from, to string) (*models.CashFlowStatement, error)
 }
 
 // EmailService defines methods for email service
@@ -72,35 +478,142 @@ type EmailService interface {
 	SendTransactionNotification(ctx context.Context, userID int, transaction *models.Transaction) error
 	SendPaymentReminder(ctx context.Context, userID int, payment *models.PaymentSchedule, credit *models.Credit) error
 	SendCreditApproval(ctx context.Context, userID int, credit *models.Credit) error
+	SendNewDeviceAlert(ctx context.Context, userID int, session *models.Session, revokeURL string) error
+	SendTransferCode(ctx context.Context, userID int, code string, confirmation *models.TransferConfirmation) error
+	SendPasswordChanged(ctx context.Context, userID int) error
+	SendPasswordReset(ctx context.Context, userID int, resetURL string, expiresAt time.Time) error
+	SendEmailVerification(ctx context.Context, userID int, verifyURL string, expiresAt time.Time) error
+	SendOrganizationInvitation(ctx context.Context, invitation *models.OrganizationInvitation) error
+	SendTaxDocument(ctx context.Context, doc *models.TaxDocument) error
+	SendNewMessage(ctx context.Context, userID int, thread *models.MessageThread) error
+	SendSignatureCode(ctx context.Context, userID int, code string, request *models.SignatureRequest) error
+	GetDeliveries(ctx context.Context, status models.EmailDeliveryStatus, recipient string) ([]*models.EmailDelivery, error)
+	GetSuppressions(ctx context.Context) ([]*models.EmailSuppression, error)
+	DeleteSuppression(ctx context.Context, id int, adminID int) error
+	VerifyWebhook(payload []byte, signature string) error
+	HandleEvents(ctx context.Context, events []*models.EmailEvent) error
+	SendAnnouncement(ctx context.Context, userID int, title, message string) error
+}
+
+// AnnouncementService defines methods for the announcements admins send to segments of customers
+type AnnouncementService interface {
+	Create(ctx context.Context, request *models.AnnouncementRequest, adminID int) (*models.Announcement, error)
+	GetAll(ctx context.Context) ([]*models.Announcement, error)
+	GetByID(ctx context.Context, id int) (*models.Announcement, error)
+	Cancel(ctx context.Context, id int, adminID int) (*models.Announcement, error)
+	Deliver(ctx context.Context) error
 }
 
 // Dependencies contains dependencies for services
 type Dependencies struct {
-	Repos  *repository.Repository
-	Logger *logrus.Logger
-	Config *configs.Config
+	Repos     *repository.Repository
+	Logger    *logrus.Logger
+	Config    *configs.Config
+	Live      *configs.Live
+	Lifecycle *lifecycle.Manager
+	Uploader  upload.Uploader // nil when the daily accounting drop is disabled
+	Storage   storage.Storage
+	Scanner   antivirus.Scanner // nil when virus scanning is disabled
+	OCR       ocr.Reader        // nil when amounts are not recognized from images
+	Search    search.Index      // nil when the search index is disabled
+	KeyRates  cbr.KeyRateProvider
+	HTTP      httpclient.Doer // client of the calls to external services
+	JWT       *crypto.JWTKeys // signs the tokens users log in with; nil in the worker, which issues none
+	Bank      *time.Location  // the bank's time zone; business days, due dates and reports start at its midnight
 }
 
 // Service is a composition of all services
 type Service struct {
 	User       UserService
+	Session    SessionService
+	Onboarding OnboardingService
+	APIKey     APIKeyService
+	Impersonation ImpersonationService
 	Account    AccountService
+	AccountPlan AccountPlanService
 	Card       CardService
+	CardProduct CardProductService
+	Fee        FeeService
 	Transaction TransactionService
 	Credit     CreditService
 	Analytics  AnalyticsService
 	Email      EmailService
+	Notification NotificationService
+	Announcement AnnouncementService
+	Organization OrganizationService
+	Payroll    PayrollService
+	Bill       BillService
+	Merchant   MerchantService
+	Chargeback ChargebackService
+	Escrow     EscrowService
+	Invoice    InvoiceService
+	Subscription SubscriptionService
+	ChequeDeposit ChequeDepositService
+	InternationalTransfer InternationalTransferService
+	Search     SearchService
+	Warehouse  WarehouseService
+	Referral   ReferralService
+	TaxDocument TaxDocumentService
+	Statement  StatementService
+	Receipt    ReceiptService
+	PaymentVerification PaymentVerificationService
+	Accounting AccountingService
+	Reporting  ReportingService
+	Location   LocationService
+	Rate       RateService
+	Message    MessageService
+	CreditApplication CreditApplicationService
+	CreditAgreement CreditAgreementService
+	CreditAdjustment CreditAdjustmentService
+	AccountOwnership AccountOwnershipService
+	Delegation DelegationService
 }
 
 // NewService creates a new service with all sub-services
 func NewService(deps Dependencies) *Service {
 	return &Service{
 		User:       NewUserService(deps),
+		Session:    NewSessionService(deps),
+		Onboarding: NewOnboardingService(deps),
+		APIKey:     NewAPIKeyService(deps),
+		Impersonation: NewImpersonationService(deps),
 		Account:    NewAccountService(deps),
+		AccountPlan: NewAccountPlanService(deps),
 		Card:       NewCardService(deps),
+		CardProduct: NewCardProductService(deps),
+		Fee:        NewFeeService(deps),
 		Transaction: NewTransactionService(deps),
 		Credit:     NewCreditService(deps),
 		Analytics:  NewAnalyticsService(deps),
 		Email:      NewEmailService(deps),
+		Notification: NewNotificationService(deps),
+		Announcement: NewAnnouncementService(deps),
+		Organization: NewOrganizationService(deps),
+		Payroll:    NewPayrollService(deps),
+		Bill:       NewBillService(deps),
+		Merchant:   NewMerchantService(deps),
+		Chargeback: NewChargebackService(deps),
+		Escrow:     NewEscrowService(deps),
+		Invoice:    NewInvoiceService(deps),
+		Subscription: NewSubscriptionService(deps),
+		ChequeDeposit: NewChequeDepositService(deps),
+		InternationalTransfer: NewInternationalTransferService(deps),
+		Search:     NewSearchService(deps),
+		Warehouse:  NewWarehouseService(deps),
+		Referral:   NewReferralService(deps),
+		TaxDocument: NewTaxDocumentService(deps),
+		Statement:  NewStatementService(deps),
+		Receipt:    NewReceiptService(deps),
+		PaymentVerification: NewPaymentVerificationService(deps),
+		Accounting: NewAccountingService(deps),
+		Reporting:  NewReportingService(deps),
+		Location:   NewLocationService(deps),
+		Rate:       NewRateService(deps),
+		Message:    NewMessageService(deps),
+		CreditApplication: NewCreditApplicationService(deps),
+		CreditAgreement: NewCreditAgreementService(deps),
+		CreditAdjustment: NewCreditAdjustmentService(deps),
+		AccountOwnership: NewAccountOwnershipService(deps),
+		Delegation: NewDelegationService(deps),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// newTestDependencies returns the dependencies of services running on the memory repositories
+func newTestDependencies() Dependencies {
+	cfg := &configs.Config{}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return Dependencies{
+		Repos:     repository.NewMemoryRepository(models.AccountNumberScheme{}),
+		Logger:    logger,
+		Config:    cfg,
+		Live:      configs.NewLive(cfg),
+		Lifecycle: lifecycle.NewManager(logger),
+		Bank:      time.UTC,
+	}
+}
+
+// createTestUser creates a user with a unique username and email
+func createTestUser(t *testing.T, ctx context.Context, repos *repository.Repository, username string) int {
+	t.Helper()
+
+	id, err := repos.User.Create(ctx, &models.User{
+		Username:  username,
+		Email:     username + "@example.com",
+		FirstName: "Test",
+		LastName:  "User",
+	})
+	if err != nil {
+		t.Fatalf("failed to create user %s: %v", username, err)
+	}
+	return id
+}
+
+// createTestAccount creates a checking account of a user funded with a balance
+func createTestAccount(t *testing.T, ctx context.Context, repos *repository.Repository, userID int, balance float64) *models.Account {
+	t.Helper()
+
+	account := &models.Account{
+		UserID:        userID,
+		AccountNumber: fmt.Sprintf("40817810%012d", userID*1000+len(mustAccounts(t, ctx, repos, userID))),
+		AccountType:   models.AccountTypeChecking,
+		Currency:      models.CurrencyRUB,
+		Balance:       balance,
+		IsActive:      true,
+	}
+
+	id, err := repos.Account.Create(ctx, account)
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+
+	created, err := repos.Account.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("failed to get account %d: %v", id, err)
+	}
+	return created
+}
+
+// mustAccounts gets the accounts of a user
+func mustAccounts(t *testing.T, ctx context.Context, repos *repository.Repository, userID int) []*models.Account {
+	t.Helper()
+
+	accounts, err := repos.Account.GetByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("failed to get accounts of user %d: %v", userID, err)
+	}
+	return accounts
+}
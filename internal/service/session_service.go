@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/lifecycle"
+)
+
+// revokeLinkTTL is how long the revoke link of a new device alert can be used
+const revokeLinkTTL = 7 * 24 * time.Hour
+
+// SessionSvc is an implementation of the service.SessionService interface
+type SessionSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	email         EmailService
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+	signer        *crypto.HMACSigner
+	publicURL     string
+}
+
+// NewSessionService creates a new SessionSvc
+func NewSessionService(deps Dependencies) *SessionSvc {
+	return &SessionSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		email:         NewEmailService(deps),
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+		signer:        crypto.NewHMACSigner([]byte(deps.Config.JWT.Secret)),
+		publicURL:     strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+	}
+}
+
+// Start records a successful login and returns the session ID to embed in the token
+func (s *SessionSvc) Start(ctx context.Context, userID int, client models.ClientInfo, expiresAt time.Time) (string, error) {
+	sessionID, err := newRandomID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	session := &models.Session{
+		UserID:    userID,
+		SessionID: sessionID,
+		IPAddress: client.IPAddress,
+		UserAgent: client.UserAgent,
+		Location:  client.GuessLocation(),
+		ExpiresAt: expiresAt,
+	}
+
+	id, err := s.repos.Session.Create(ctx, session)
+	if err != nil {
+		return "", err
+	}
+	session.ID = id
+
+	s.checkDevice(ctx, session, client)
+
+	return sessionID, nil
+}
+
+// checkDevice records the device of a login and alerts the user when it has not been seen before.
+// Failures are logged and never block the login.
+func (s *SessionSvc) checkDevice(ctx context.Context, session *models.Session, client models.ClientInfo) {
+	device := &models.Device{
+		UserID:      session.UserID,
+		Fingerprint: client.Fingerprint(),
+		IPAddress:   client.IPAddress,
+		UserAgent:   client.UserAgent,
+	}
+
+	isNew, err := s.repos.Device.Touch(ctx, device)
+	if err != nil {
+		s.logger.Warnf("Failed to record device for user %d: %v", session.UserID, err)
+		return
+	}
+	if !isNew {
+		return
+	}
+
+	// The very first login of a user is not suspicious
+	count, err := s.repos.Device.CountByUserID(ctx, session.UserID)
+	if err != nil {
+		s.logger.Warnf("Failed to count devices for user %d: %v", session.UserID, err)
+		return
+	}
+	if count <= 1 {
+		return
+	}
+
+	s.lifecycle.Background("new-device-alert", func(ctx context.Context) error {
+		return s.alertNewDevice(ctx, session)
+	})
+}
+
+// alertNewDevice sends the security email and in-app notification for a login from a new device
+func (s *SessionSvc) alertNewDevice(ctx context.Context, session *models.Session) error {
+	revokeURL := fmt.Sprintf("%s/sessions/revoke?token=%s", s.publicURL, url.QueryEscape(s.revokeToken(session, time.Now().Add(revokeLinkTTL))))
+
+	if err := s.notifications.Notify(ctx, session.UserID, models.NotificationTypeSecurity, "new_device_login",
+		session.IPAddress, session.Location, session.UserAgent, session.ID); err != nil {
+		s.logger.Warnf("Failed to create new device notification: %v", err)
+	}
+
+	return s.email.SendNewDeviceAlert(ctx, session.UserID, session, revokeURL)
+}
+
+// revokeToken creates a signed token that revokes the session without logging in until expiresAt
+func (s *SessionSvc) revokeToken(session *models.Session, expiresAt time.Time) string {
+	payload := fmt.Sprintf("%d.%d.%d", session.ID, session.UserID, expiresAt.Unix())
+	return payload + "." + s.signer.Sign(payload)
+}
+
+// parseRevokeToken checks the signature and expiry of a revoke token and returns the IDs of the
+// session and the user it references
+func (s *SessionSvc) parseRevokeToken(token string) (int, int, error) {
+	parts := strings.SplitN(token, ".", 4)
+	if len(parts) != 4 {
+		return 0, 0, errors.New("invalid revoke token")
+	}
+
+	payload := strings.Join(parts[:3], ".")
+	if subtle.ConstantTimeCompare([]byte(s.signer.Sign(payload)), []byte(parts[3])) != 1 {
+		return 0, 0, errors.New("invalid revoke token")
+	}
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, errors.New("invalid revoke token")
+	}
+	userID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, errors.New("invalid revoke token")
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid revoke token")
+	}
+
+	if time.Now().After(time.Unix(expiresAt, 0)) {
+		return 0, 0, errors.New("revoke link has expired")
+	}
+
+	return id, userID, nil
+}
+
+// GetByRevokeToken gets the session referenced by a token from a security alert email, for the
+// page that asks to confirm revoking it
+func (s *SessionSvc) GetByRevokeToken(ctx context.Context, token string) (*models.Session, error) {
+	id, userID, err := s.parseRevokeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.repos.Session.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+
+	return nil, fmt.Errorf("session not found: %w", sql.ErrNoRows)
+}
+
+// RevokeByToken revokes the session referenced by a token from a security alert email
+func (s *SessionSvc) RevokeByToken(ctx context.Context, token string) error {
+	id, userID, err := s.parseRevokeToken(token)
+	if err != nil {
+		return err
+	}
+
+	return s.Revoke(ctx, id, userID)
+}
+
+// GetByUserID gets the sessions of a user. Without history only active sessions are returned.
+// The session the request was made with is marked as current.
+func (s *SessionSvc) GetByUserID(ctx context.Context, userID int, currentSessionID string, history bool) ([]*models.Session, error) {
+	var sessions []*models.Session
+	var err error
+
+	if history {
+		sessions, err = s.repos.Session.GetByUserID(ctx, userID)
+	} else {
+		sessions, err = s.repos.Session.GetActiveByUserID(ctx, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		session.Current = session.SessionID == currentSessionID
+	}
+
+	return sessions, nil
+}
+
+// Revoke revokes a session of a user; tokens issued for it stop working immediately
+func (s *SessionSvc) Revoke(ctx context.Context, id int, userID int) error {
+	if err := s.repos.Session.Revoke(ctx, id, userID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.logger.Infof("Session %d revoked by user %d", id, userID)
+
+	return nil
+}
+
+// Logout ends the session a token was issued for, and with everywhere every other session of the
+// user too, returning how many sessions were revoked. Tokens issued for them, including limited
+// ones, stop working immediately.
+func (s *SessionSvc) Logout(ctx context.Context, sessionID string, userID int, everywhere bool) (int, error) {
+	session, err := s.repos.Session.GetBySessionID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return 0, errors.New("session not found")
+	}
+
+	revoked := 0
+	if everywhere {
+		revoked, err = s.repos.Session.RevokeAllExcept(ctx, userID, sessionID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+	}
+
+	if err := s.repos.Session.Revoke(ctx, session.ID, userID); err != nil {
+		return revoked, fmt.Errorf("failed to revoke session: %w", err)
+	}
+	revoked++
+
+	s.logger.Infof("User %d logged out, %d sessions revoked", userID, revoked)
+
+	return revoked, nil
+}
+
+// Validate checks that the session a token was issued for belongs to the user and is still active
+func (s *SessionSvc) Validate(ctx context.Context, sessionID string, userID int) error {
+	session, err := s.repos.Session.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return errors.New("session not found")
+	}
+
+	if session.UserID != userID {
+		return errors.New("session does not belong to user")
+	}
+
+	if !session.IsActive(time.Now()) {
+		return errors.New("session has been revoked or expired")
+	}
+
+	return nil
+}
+
+// newRandomID generates a random hex identifier for sessions and confirmations
+func newRandomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/iso20022"
+)
+
+// StatementSvc is an implementation of the service.StatementService interface
+type StatementSvc struct {
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	publicURL string
+	bank      *time.Location
+}
+
+// NewStatementService creates a new StatementSvc
+func NewStatementService(deps Dependencies) *StatementSvc {
+	return &StatementSvc{
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		publicURL: strings.TrimRight(deps.Config.Server.PublicURL, "/"),
+		bank:      deps.Bank,
+	}
+}
+
+// GetByAccountID gets the statements issued for an account, newest first, with their links
+func (s *StatementSvc) GetByAccountID(ctx context.Context, accountID int, userID int) ([]*models.Statement, error) {
+	if err := s.checkAccess(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	statements, err := s.repos.Statement.GetByAccountID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
+
+	for _, statement := range statements {
+		s.addLinks(statement)
+	}
+
+	return statements, nil
+}
+
+// GetByID gets a statement of an account together with the transactions of its period
+func (s *StatementSvc) GetByID(ctx context.Context, accountID int, id int, userID int) (*models.Statement, error) {
+	if err := s.checkAccess(ctx, accountID, userID); err != nil {
+		return nil, err
+	}
+
+	statement, err := s.repos.Statement.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if statement.AccountID != accountID {
+		return nil, errors.New("statement not found")
+	}
+
+	statement.Transactions, err = s.repos.Transaction.GetByAccountAndPeriod(ctx, accountID, statement.PeriodStart, statement.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	s.addLinks(statement)
+
+	return statement, nil
+}
+
+// Regenerate rebuilds the statement of a past month for an account the user manages, or issues
+// it if the month has none, e.g. because the account predates monthly statements
+func (s *StatementSvc) Regenerate(ctx context.Context, accountID int, request *models.StatementRegenerateRequest, userID int) (*models.Statement, error) {
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessManage); err != nil {
+		return nil, err
+	}
+
+	return s.regenerate(ctx, account, request, userID)
+}
+
+// AdminRegenerate rebuilds or issues the statement of a past month for any account
+func (s *StatementSvc) AdminRegenerate(ctx context.Context, accountID int, request *models.StatementRegenerateRequest, adminID int) (*models.Statement, error) {
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.regenerate(ctx, account, request, adminID)
+}
+
+// regenerate rebuilds the statement of the requested month from the transactions of the month,
+// which are locked, or issues it and locks the month if it has no statement yet
+func (s *StatementSvc) regenerate(ctx context.Context, account *models.Account, request *models.StatementRegenerateRequest, userID int) (*models.Statement, error) {
+	from, to, err := request.ValidateStatementRegenerate(time.Now().In(s.bank))
+	if err != nil {
+		return nil, fmt.Errorf("invalid statement period: %w", err)
+	}
+
+	statements, err := s.repos.Statement.GetByAccountID(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statements: %w", err)
+	}
+
+	var statement *models.Statement
+	for _, issued := range statements {
+		if issued.PeriodStart.Equal(from) {
+			statement, err = s.repos.Statement.Regenerate(ctx, issued.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to regenerate statement: %w", err)
+			}
+			break
+		}
+	}
+
+	if statement == nil {
+		if !account.CreatedAt.Before(to) {
+			return nil, errors.New("account was opened after the period")
+		}
+
+		statement, err = s.repos.Statement.Issue(ctx, account.ID, from, to)
+		if err != nil {
+			return nil, fmt.Errorf("failed to issue statement: %w", err)
+		}
+	}
+	s.addLinks(statement)
+
+	s.logger.Infof("Statement %d of account %d for %s regenerated by user %d", statement.ID, account.ID, request.Period, userID)
+
+	return statement, nil
+}
+
+// addLinks sets the links to view and download a statement
+func (s *StatementSvc) addLinks(statement *models.Statement) {
+	statement.DetailsURL = fmt.Sprintf("%s/api/accounts/%d/statements/%d", s.publicURL, statement.AccountID, statement.ID)
+	statement.DownloadURL = statement.DetailsURL + "/camt053"
+}
+
+// GetCamt053 renders a statement as a camt.053 message for the accounting systems of corporate
+// clients and returns its file name and content. Only the transactions that moved the balance are
+// listed; pending ones are marked as such.
+func (s *StatementSvc) GetCamt053(ctx context.Context, accountID int, id int, userID int) (string, []byte, error) {
+	statement, err := s.GetByID(ctx, accountID, id, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	reference := strconv.Itoa(statement.ID)
+	stmt := &iso20022.Statement{
+		ID:             reference,
+		CreatedAt:      statement.IssuedAt,
+		From:           statement.PeriodStart,
+		To:             statement.PeriodEnd.Add(-time.Second),
+		Account:        account.AccountNumber,
+		Currency:       string(account.Currency),
+		OwnerName:      accountOwnerName(ctx, s.repos, account),
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Entries:        []*iso20022.Entry{},
+	}
+
+	for _, transaction := range statement.Transactions {
+		if !transaction.Status.AffectsBalance() {
+			continue
+		}
+		stmt.Entries = append(stmt.Entries, &iso20022.Entry{
+			Reference:   strconv.Itoa(transaction.ID),
+			Amount:      transaction.Amount,
+			Credit:      transaction.DestinationAccountID != nil && *transaction.DestinationAccountID == accountID,
+			Pending:     transaction.Status == models.TransactionStatusPending,
+			BookingDate: transaction.TransactionDate,
+			Code:        string(transaction.TransactionType),
+			Description: transaction.Description,
+		})
+	}
+
+	content, err := iso20022.MarshalCamt053(&iso20022.StatementMessage{
+		MessageID:  "STMT-" + reference,
+		CreatedAt:  time.Now(),
+		Statements: []*iso20022.Statement{stmt},
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to write camt.053 statement: %w", err)
+	}
+
+	return fmt.Sprintf("statement_%d.xml", statement.ID), content, nil
+}
+
+// accountOwnerName returns the name of the organization or the customer that owns an account, if known
+func accountOwnerName(ctx context.Context, repos *repository.Repository, account *models.Account) string {
+	if account.OrganizationID != nil {
+		if organization, err := repos.Organization.GetByID(ctx, *account.OrganizationID); err == nil {
+			return organization.Name
+		}
+		return ""
+	}
+
+	user, err := repos.User.GetByID(ctx, account.UserID)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(user.FirstName + " " + user.LastName)
+}
+
+// IssueMonthly issues last month's statement for every account that does not have one yet and
+// locks the period. It is safe to run daily.
+func (s *StatementSvc) IssueMonthly(ctx context.Context) error {
+	from, to := models.StatementMonthBounds(time.Now().In(s.bank))
+
+	accountIDs, err := s.repos.Statement.GetAccountsWithoutStatement(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	s.logger.Infof("Issuing %s statements for %d accounts", from.Format("2006-01"), len(accountIDs))
+
+	for _, accountID := range accountIDs {
+		if _, err := s.repos.Statement.Issue(ctx, accountID, from, to); err != nil {
+			s.logger.Warnf("Failed to issue statement for account %d: %v", accountID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkAccess verifies that the user can view the account
+func (s *StatementSvc) checkAccess(ctx context.Context, accountID int, userID int) error {
+	account, err := s.repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	return checkAccountAccess(ctx, s.repos, account, userID, accessView)
+}
@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/lifecycle"
+)
+
+// subscriptionUnpaidReason is the cancel reason of subscriptions whose charges kept failing
+const subscriptionUnpaidReason = "payment failed"
+
+// SubscriptionSvc is an implementation of the service.SubscriptionService interface
+type SubscriptionSvc struct {
+	repos         *repository.Repository
+	logger        *logrus.Logger
+	config        *configs.Config
+	transfers     *TransactionSvc
+	notifications NotificationService
+	lifecycle     *lifecycle.Manager
+}
+
+// NewSubscriptionService creates a new SubscriptionSvc
+func NewSubscriptionService(deps Dependencies) *SubscriptionSvc {
+	return &SubscriptionSvc{
+		repos:         deps.Repos,
+		logger:        deps.Logger,
+		config:        deps.Config,
+		transfers:     NewTransactionService(deps),
+		notifications: NewNotificationService(deps),
+		lifecycle:     deps.Lifecycle,
+	}
+}
+
+// CreatePlan creates a plan billed in the currency of the merchant's settlement account
+func (s *SubscriptionSvc) CreatePlan(ctx context.Context, planCreate *models.SubscriptionPlanCreate, merchantID int) (*models.SubscriptionPlan, error) {
+	if err := planCreate.ValidateSubscriptionPlanCreate(); err != nil {
+		return nil, fmt.Errorf("invalid subscription plan data: %w", err)
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, merchant.SettlementAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement account: %w", err)
+	}
+
+	plan := &models.SubscriptionPlan{
+		MerchantID: merchant.ID,
+		Name:       planCreate.Name,
+		Amount:     planCreate.Amount,
+		Currency:   account.Currency,
+		Interval:   planCreate.Interval,
+		IsActive:   true,
+	}
+
+	if _, err := s.repos.Subscription.CreatePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Subscription plan %d of %f %s per %s created by merchant %d", plan.ID, plan.Amount,
+		plan.Currency, plan.Interval, merchant.ID)
+
+	return plan, nil
+}
+
+// GetPlans gets the plans of a merchant
+func (s *SubscriptionSvc) GetPlans(ctx context.Context, merchantID int) ([]*models.SubscriptionPlan, error) {
+	return s.repos.Subscription.GetPlansByMerchantID(ctx, merchantID)
+}
+
+// GetPlan gets a plan customers can subscribe to
+func (s *SubscriptionSvc) GetPlan(ctx context.Context, id int) (*models.SubscriptionPlan, error) {
+	plan, err := s.repos.Subscription.GetPlanByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription plan: %w", err)
+	}
+
+	if !plan.IsActive {
+		return nil, errors.New("subscription plan not found")
+	}
+
+	return plan, nil
+}
+
+// DeactivatePlan closes a plan of a merchant to new subscribers. Existing subscribers keep being billed.
+func (s *SubscriptionSvc) DeactivatePlan(ctx context.Context, id int, merchantID int) error {
+	plan, err := s.repos.Subscription.GetPlanByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription plan: %w", err)
+	}
+
+	if plan.MerchantID != merchantID {
+		return errors.New("subscription plan not found")
+	}
+
+	return s.repos.Subscription.SetPlanActive(ctx, plan.ID, false)
+}
+
+// Subscribe subscribes the user to a plan, giving the merchant a mandate to charge one of the
+// user's personal accounts every period. The first period is charged at once.
+func (s *SubscriptionSvc) Subscribe(ctx context.Context, subscriptionCreate *models.SubscriptionCreate, userID int) (*models.Subscription, error) {
+	plan, err := s.GetPlan(ctx, subscriptionCreate.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, plan.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	if merchant.UserID == userID {
+		return nil, errors.New("you cannot subscribe to your own plan")
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, subscriptionCreate.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	// Charges are made without the holder's involvement, which approval policies and
+	// powers of attorney do not allow, so only the holder's own personal accounts qualify
+	if account.UserID != userID || account.OrganizationID != nil {
+		return nil, errors.New("subscriptions can only be paid from your own personal accounts")
+	}
+
+	if !account.IsActive {
+		return nil, errors.New("account is inactive")
+	}
+
+	if account.Currency != plan.Currency {
+		return nil, errors.New("account currency does not match the plan currency")
+	}
+
+	if account.AvailableBalance < plan.Amount {
+		return nil, errors.New("insufficient funds")
+	}
+
+	subscription := &models.Subscription{
+		PlanID:        plan.ID,
+		UserID:        userID,
+		AccountID:     account.ID,
+		Status:        models.SubscriptionStatusActive,
+		NextBillingAt: time.Now(),
+	}
+
+	if _, err := s.repos.Subscription.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("User %d subscribed to plan %d, subscription: %d", userID, plan.ID, subscription.ID)
+
+	// Reload the subscription so it carries the details of its plan
+	subscription, err = s.repos.Subscription.GetByID(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.bill(ctx, subscription)
+
+	return s.repos.Subscription.GetByID(ctx, subscription.ID)
+}
+
+// GetByUserID gets the subscriptions of the user
+func (s *SubscriptionSvc) GetByUserID(ctx context.Context, userID int) ([]*models.Subscription, error) {
+	return s.repos.Subscription.GetByUserID(ctx, userID)
+}
+
+// GetForMerchant gets the subscriptions to the plans of a merchant
+func (s *SubscriptionSvc) GetForMerchant(ctx context.Context, merchantID int) ([]*models.Subscription, error) {
+	return s.repos.Subscription.GetByMerchantID(ctx, merchantID)
+}
+
+// Cancel cancels a subscription of the user, revoking the merchant's mandate
+func (s *SubscriptionSvc) Cancel(ctx context.Context, id int, userID int) error {
+	subscription, err := s.repos.Subscription.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if subscription.UserID != userID {
+		return errors.New("subscription not found")
+	}
+
+	return s.cancel(ctx, subscription, "cancelled by the subscriber")
+}
+
+// CancelForMerchant cancels a subscription to a plan of a merchant
+func (s *SubscriptionSvc) CancelForMerchant(ctx context.Context, id int, merchantID int) error {
+	subscription, err := s.repos.Subscription.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	if subscription.MerchantID != merchantID {
+		return errors.New("subscription not found")
+	}
+
+	return s.cancel(ctx, subscription, "cancelled by the merchant")
+}
+
+// cancel cancels a subscription, voids its unpaid invoices and notifies both parties
+func (s *SubscriptionSvc) cancel(ctx context.Context, subscription *models.Subscription, reason string) error {
+	cancelled, err := s.repos.Subscription.Cancel(ctx, subscription.ID, reason)
+	if err != nil {
+		return err
+	}
+
+	if !cancelled {
+		return errors.New("subscription is already cancelled")
+	}
+
+	s.logger.Infof("Subscription %d cancelled: %s", subscription.ID, reason)
+
+	invoices, err := s.repos.Invoice.GetBySubscriptionID(ctx, subscription.ID)
+	if err != nil {
+		s.logger.Warnf("Failed to get the invoices of subscription %d: %v", subscription.ID, err)
+	}
+	for _, invoice := range invoices {
+		if !invoice.Status.IsPayable() {
+			continue
+		}
+		if _, err := s.repos.Invoice.Cancel(ctx, invoice.ID); err != nil {
+			s.logger.Warnf("Failed to cancel invoice %s of subscription %d: %v", invoice.Number, subscription.ID, err)
+		}
+	}
+
+	template := "subscription_cancelled"
+	if reason == subscriptionUnpaidReason {
+		template = "subscription_cancelled_unpaid"
+	}
+
+	if merchant, err := s.repos.Merchant.GetByID(ctx, subscription.MerchantID); err != nil {
+		s.logger.Warnf("Failed to get merchant %d: %v", subscription.MerchantID, err)
+	} else {
+		s.notify(merchant.UserID, template, subscription.ID, subscription.PlanName)
+	}
+	s.notify(subscription.UserID, template, subscription.ID, subscription.PlanName)
+
+	return nil
+}
+
+// BillDue charges the subscriptions whose next period has started and retries the failed charges
+// that are due. A subscription whose charges keep failing is cancelled after the configured attempts.
+func (s *SubscriptionSvc) BillDue(ctx context.Context) error {
+	subscriptions, err := s.repos.Subscription.GetDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Billing %d due subscriptions", len(subscriptions))
+
+	for _, subscription := range subscriptions {
+		s.bill(ctx, subscription)
+	}
+
+	return nil
+}
+
+// bill charges the due period of a subscription. An active subscription is invoiced for its next
+// period first; a past due one is charged again for its unpaid invoice.
+func (s *SubscriptionSvc) bill(ctx context.Context, subscription *models.Subscription) {
+	var invoice *models.Invoice
+	var err error
+
+	if subscription.Status == models.SubscriptionStatusActive {
+		invoice, err = s.invoicePeriod(ctx, subscription)
+	} else {
+		invoice, err = s.unpaidInvoice(ctx, subscription)
+	}
+	if err != nil {
+		s.logger.Errorf("Failed to bill subscription %d: %v", subscription.ID, err)
+		return
+	}
+	if invoice == nil {
+		return
+	}
+
+	if err := s.charge(ctx, subscription, invoice); err != nil {
+		s.logger.Warnf("Failed to charge subscription %d for invoice %s: %v", subscription.ID, invoice.Number, err)
+		s.recordFailure(ctx, subscription, err)
+		return
+	}
+
+	if err := s.repos.Subscription.RecordPayment(ctx, subscription.ID); err != nil {
+		s.logger.Errorf("Failed to record the payment of subscription %d: %v", subscription.ID, err)
+	}
+}
+
+// invoicePeriod claims the period of an active subscription that has started and issues its invoice.
+// It returns nil if the period has been billed already.
+func (s *SubscriptionSvc) invoicePeriod(ctx context.Context, subscription *models.Subscription) (*models.Invoice, error) {
+	start := subscription.NextBillingAt
+	advanced, err := s.repos.Subscription.AdvancePeriod(ctx, subscription.ID, start, subscription.Interval.Next(start))
+	if err != nil || !advanced {
+		return nil, err
+	}
+
+	merchant, err := s.repos.Merchant.GetByID(ctx, subscription.MerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	account, err := s.repos.Account.GetByID(ctx, merchant.SettlementAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settlement account: %w", err)
+	}
+
+	user, err := s.repos.User.GetByID(ctx, subscription.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	number, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invoice number: %w", err)
+	}
+
+	// The invoice stays payable by hand for as long as the failed charges are retried
+	invoice := &models.Invoice{
+		Number:         number,
+		IssuerID:       merchant.UserID,
+		MerchantID:     &merchant.ID,
+		SubscriptionID: &subscription.ID,
+		RecipientID:    user.ID,
+		RecipientEmail: user.Email,
+		AccountID:      account.ID,
+		AccountNumber:  account.AccountNumber,
+		Amount:         subscription.Amount,
+		Currency:       subscription.Currency,
+		Description:    fmt.Sprintf("%s, %s", subscription.PlanName, start.Format("2006-01-02")),
+		DueAt:          time.Now().AddDate(0, 0, s.config.Subscription.RetryDays*s.config.Subscription.MaxAttempts),
+		Status:         models.InvoiceStatusIssued,
+		Items: []*models.InvoiceItem{{
+			Position:    1,
+			Description: subscription.PlanName,
+			Quantity:    1,
+			UnitPrice:   subscription.Amount,
+			Amount:      subscription.Amount,
+		}},
+	}
+
+	if _, err := s.repos.Invoice.Create(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Invoice %s issued for the period of subscription %d starting %s", invoice.Number,
+		subscription.ID, start.Format("2006-01-02"))
+
+	return invoice, nil
+}
+
+// unpaidInvoice gets the invoice a past due subscription is retried for. It returns nil and brings the
+// subscription back to ACTIVE if the invoice has been settled meanwhile, e.g. paid by the subscriber.
+func (s *SubscriptionSvc) unpaidInvoice(ctx context.Context, subscription *models.Subscription) (*models.Invoice, error) {
+	invoices, err := s.repos.Invoice.GetBySubscriptionID(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) > 0 && invoices[0].Status.IsPayable() {
+		return invoices[0], nil
+	}
+
+	s.logger.Infof("Subscription %d has no unpaid invoice left, resuming it", subscription.ID)
+
+	return nil, s.repos.Subscription.RecordPayment(ctx, subscription.ID)
+}
+
+// charge pays an invoice of a subscription from the subscriber's account. The subscription is the
+// subscriber's standing mandate, so the charge skips the one-time code asked for manual transfers.
+func (s *SubscriptionSvc) charge(ctx context.Context, subscription *models.Subscription, invoice *models.Invoice) error {
+	transfer := &models.TransferRequest{
+		SourceAccountID:      subscription.AccountID,
+		DestinationAccountID: invoice.AccountID,
+		Amount:               invoice.Amount,
+		Description:          "Invoice " + invoice.Number + ": " + invoice.Description,
+		InvoiceID:            &invoice.ID,
+	}
+
+	sourceAccount, err := s.transfers.checkTransfer(ctx, transfer, subscription.UserID, 0)
+	if err != nil {
+		return err
+	}
+
+	if sourceAccount.OrganizationID != nil {
+		return errors.New("subscriptions can only be paid from your own personal accounts")
+	}
+
+	_, err = s.transfers.executeTransfer(ctx, transfer, subscription.UserID, sourceAccount, nil)
+	return err
+}
+
+// recordFailure schedules the retry of a failed charge and notifies the subscriber, or cancels the
+// subscription once the charges have failed the configured number of times
+func (s *SubscriptionSvc) recordFailure(ctx context.Context, subscription *models.Subscription, cause error) {
+	retryAt := time.Now().AddDate(0, 0, s.config.Subscription.RetryDays)
+	attempts, err := s.repos.Subscription.RecordFailure(ctx, subscription.ID, cause.Error(), retryAt)
+	if err != nil {
+		s.logger.Errorf("Failed to record the failed charge of subscription %d: %v", subscription.ID, err)
+		return
+	}
+
+	if attempts >= s.config.Subscription.MaxAttempts {
+		if err := s.cancel(ctx, subscription, subscriptionUnpaidReason); err != nil {
+			s.logger.Errorf("Failed to cancel subscription %d: %v", subscription.ID, err)
+		}
+		return
+	}
+
+	s.notify(subscription.UserID, "subscription_payment_failed", subscription.Amount, subscription.Currency,
+		subscription.PlanName, retryAt.Format("2006-01-02"))
+}
+
+// Report summarizes the subscriptions of a merchant: their number, the churn over the last 30 days
+// and the monthly recurring revenue of the subscriptions not cancelled in each currency
+func (s *SubscriptionSvc) Report(ctx context.Context, merchantID int) (*models.SubscriptionReport, error) {
+	subscriptions, err := s.repos.Subscription.GetByMerchantID(ctx, merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, 0, -30)
+	report := &models.SubscriptionReport{MRR: []*models.RecurringRevenue{}}
+	revenue := map[models.Currency]float64{}
+	activeBefore, churned := 0, 0
+
+	for _, subscription := range subscriptions {
+		cancelledSince := subscription.CancelledAt != nil && subscription.CancelledAt.After(since)
+		if subscription.CreatedAt.After(since) {
+			report.NewLast30Days++
+		} else if subscription.CancelledAt == nil || cancelledSince {
+			activeBefore++
+			if cancelledSince {
+				churned++
+			}
+		}
+
+		if subscription.Status == models.SubscriptionStatusCancelled {
+			if cancelledSince {
+				report.CancelledLast30Days++
+			}
+			continue
+		}
+
+		report.ActiveSubscriptions++
+		if subscription.Status == models.SubscriptionStatusPastDue {
+			report.PastDueSubscriptions++
+		}
+		revenue[subscription.Currency] += subscription.Interval.MonthlyAmount(subscription.Amount)
+	}
+
+	if activeBefore > 0 {
+		report.ChurnRate = math.Round(float64(churned)/float64(activeBefore)*10000) / 10000
+	}
+
+	for currency, amount := range revenue {
+		report.MRR = append(report.MRR, &models.RecurringRevenue{Currency: currency, Amount: math.Round(amount*100) / 100})
+	}
+	sort.Slice(report.MRR, func(i, j int) bool { return report.MRR[i].Currency < report.MRR[j].Currency })
+
+	return report, nil
+}
+
+// notify sends an in-app subscription notification in the background
+func (s *SubscriptionSvc) notify(userID int, template string, args ...interface{}) {
+	s.lifecycle.Background("subscription-notification", func(ctx context.Context) error {
+		if err := s.notifications.Notify(ctx, userID, models.NotificationTypeSubscription, template, args...); err != nil {
+			return fmt.Errorf("failed to send subscription notification: %w", err)
+		}
+		return nil
+	})
+}
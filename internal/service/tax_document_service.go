@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+)
+
+// TaxDocumentSvc is an implementation of the service.TaxDocumentService interface
+type TaxDocumentSvc struct {
+	repos  *repository.Repository
+	logger *logrus.Logger
+	config *configs.Config
+	email  EmailService
+	bank   *time.Location
+}
+
+// NewTaxDocumentService creates a new TaxDocumentSvc
+func NewTaxDocumentService(deps Dependencies) *TaxDocumentSvc {
+	return &TaxDocumentSvc{
+		repos:  deps.Repos,
+		logger: deps.Logger,
+		config: deps.Config,
+		email:  NewEmailService(deps),
+		bank:   deps.Bank,
+	}
+}
+
+// GetDocuments gets the tax documents generated for the user
+func (s *TaxDocumentSvc) GetDocuments(ctx context.Context, userID int) ([]*models.TaxDocument, error) {
+	docs, err := s.repos.TaxDocument.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax documents: %w", err)
+	}
+
+	return docs, nil
+}
+
+// GetDocument gets the user's tax document for a completed year, generating it on first request
+func (s *TaxDocumentSvc) GetDocument(ctx context.Context, userID int, year int) (*models.TaxDocument, error) {
+	if err := models.ValidateTaxYear(year, time.Now().In(s.bank)); err != nil {
+		return nil, err
+	}
+
+	if doc, err := s.repos.TaxDocument.GetByUserAndYear(ctx, userID, year); err == nil {
+		return doc, nil
+	}
+
+	return s.generate(ctx, userID, year)
+}
+
+// Download renders the user's tax document for a year and returns its file name and content
+func (s *TaxDocumentSvc) Download(ctx context.Context, userID int, year int) (string, []byte, error) {
+	doc, err := s.GetDocument(ctx, userID, year)
+	if err != nil {
+		return "", nil, err
+	}
+
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	content, err := doc.Render(user)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to render tax document: %w", err)
+	}
+
+	return doc.FileName(), content, nil
+}
+
+// DeliverYearly generates the previous year's tax documents and emails them. It only acts in
+// January and skips documents that were already sent, so it is safe to run daily.
+func (s *TaxDocumentSvc) DeliverYearly(ctx context.Context) error {
+	now := time.Now().In(s.bank)
+	if now.Month() != time.January {
+		return nil
+	}
+
+	year := now.Year() - 1
+	from, to := models.TaxYearBounds(year, s.bank)
+
+	userIDs, err := s.repos.TaxDocument.GetUsersWithActivity(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Infof("Found %d users with tax documents for %d", len(userIDs), year)
+
+	for _, userID := range userIDs {
+		doc, err := s.repos.TaxDocument.GetByUserAndYear(ctx, userID, year)
+		if err == nil && doc.EmailedAt != nil {
+			continue
+		}
+
+		if err != nil {
+			doc, err = s.generate(ctx, userID, year)
+			if err != nil {
+				s.logger.Warnf("Failed to generate %d tax document for user %d: %v", year, userID, err)
+				continue
+			}
+		}
+
+		if err := s.email.SendTaxDocument(ctx, doc); err != nil {
+			s.logger.Warnf("Failed to send tax document %d: %v", doc.ID, err)
+			continue
+		}
+
+		if err := s.repos.TaxDocument.MarkEmailed(ctx, doc.ID); err != nil {
+			s.logger.Warnf("Failed to mark tax document %d as emailed: %v", doc.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Resend emails the user's tax document for a year again, e.g. after delivery failed
+func (s *TaxDocumentSvc) Resend(ctx context.Context, userID int, year int) (*models.TaxDocument, error) {
+	doc, err := s.repos.TaxDocument.GetByUserAndYear(ctx, userID, year)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.email.SendTaxDocument(ctx, doc); err != nil {
+		return nil, fmt.Errorf("failed to send tax document: %w", err)
+	}
+
+	if err := s.repos.TaxDocument.MarkEmailed(ctx, doc.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark tax document as emailed: %w", err)
+	}
+
+	s.logger.Infof("Tax document %d resent to user %d", doc.ID, userID)
+
+	return doc, nil
+}
+
+// generate collects the user's interest for a year and stores the tax document
+func (s *TaxDocumentSvc) generate(ctx context.Context, userID int, year int) (*models.TaxDocument, error) {
+	from, to := models.TaxYearBounds(year, s.bank)
+
+	interestLines, err := s.repos.TaxDocument.GetInterestEarned(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	loanLines, err := s.repos.TaxDocument.GetLoanInterestPaid(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := models.NewTaxDocument(userID, year, interestLines, loanLines)
+	if _, err := s.repos.TaxDocument.Upsert(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	s.logger.Infof("Tax document %d for %d generated for user %d", doc.ID, year, userID)
+
+	return doc, nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"banking-service/internal/models"
+)
+
+// requestTenant returns the tenant the request was made for, or "" in background jobs that work
+// across tenants
+func requestTenant(ctx context.Context) string {
+	tenant, _ := ctx.Value("tenant").(string)
+	return tenant
+}
+
+// requestTenantOrDefault returns the tenant of the request, or the default tenant outside requests
+func requestTenantOrDefault(ctx context.Context) string {
+	if tenant := requestTenant(ctx); tenant != "" {
+		return tenant
+	}
+	return models.DefaultTenant
+}
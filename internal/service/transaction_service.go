@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -11,69 +13,546 @@ import (
 	"banking-service/configs"
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
+	"banking-service/pkg/crypto"
+	"banking-service/pkg/lifecycle"
 )
 
 // TransactionSvc is an implementation of the service.TransactionService interface
 type TransactionSvc struct {
-	repos  *repository.Repository
-	logger *logrus.Logger
-	config *configs.Config
-	email  EmailService
+	repos     *repository.Repository
+	logger    *logrus.Logger
+	config    *configs.Config
+	email     EmailService
+	notifications NotificationService
+	lifecycle *lifecycle.Manager
+	hasher    *crypto.Argon2Hasher
+	declines  *declines
+	cardRules *cardRules
+	bank      *time.Location
 }
 
 // NewTransactionService creates a new TransactionSvc
 func NewTransactionService(deps Dependencies) *TransactionSvc {
 	return &TransactionSvc{
-		repos:  deps.Repos,
-		logger: deps.Logger,
-		config: deps.Config,
-		email:  NewEmailService(deps),
+		repos:     deps.Repos,
+		logger:    deps.Logger,
+		config:    deps.Config,
+		email:     NewEmailService(deps),
+		notifications: NewNotificationService(deps),
+		lifecycle: deps.Lifecycle,
+		hasher:    newPasswordHasher(deps.Config.Password),
+		declines:  newDeclines(deps),
+		cardRules: newCardRules(deps),
+		bank:      deps.Bank,
 	}
 }
 
-// Transfer performs a money transfer between accounts
-func (s *TransactionSvc) Transfer(ctx context.Context, transfer *models.TransferRequest, userID int) (int, error) {
+// Transfer performs a money transfer between accounts. Transfers at or above the
+// configured threshold are held until confirmed with a one-time code. Organization
+// transfers covered by an approval policy are held until enough members approve them.
+func (s *TransactionSvc) Transfer(ctx context.Context, transfer *models.TransferRequest, userID int) (*models.TransferResult, error) {
+	sourceAccount, err := s.checkTransfer(ctx, transfer, userID, 0)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Organization transfers may need approvals from other members
+	if sourceAccount.OrganizationID != nil {
+		policies, err := s.repos.ApprovalPolicy.GetByOrganizationID(ctx, *sourceAccount.OrganizationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get approval policies: %w", err)
+		}
+		
+		if required := models.RequiredApprovalsFor(policies, transfer.Amount); required > 0 {
+			return s.requestApproval(ctx, transfer, userID, *sourceAccount.OrganizationID, required)
+		}
+	}
+	
+	// High-value transfers need a one-time code
+	if threshold := s.config.Transfer.OTPThreshold; threshold > 0 && transfer.Amount >= threshold {
+		return s.requestConfirmation(ctx, transfer, userID)
+	}
+	
+	transactionID, err := s.executeTransfer(ctx, transfer, userID, sourceAccount, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &models.TransferResult{TransactionID: transactionID}, nil
+}
+
+// ConfirmTransfer executes a pending high-value transfer after verifying its one-time code
+func (s *TransactionSvc) ConfirmTransfer(ctx context.Context, confirm *models.TransferConfirmRequest, userID int) (int, error) {
+	if err := confirm.ValidateTransferConfirmRequest(); err != nil {
+		return 0, fmt.Errorf("invalid confirmation request: %w", err)
+	}
+	
+	confirmation, err := s.repos.TransferConfirmation.GetByConfirmationID(ctx, confirm.ConfirmationID)
+	if err != nil {
+		return 0, errors.New("transfer confirmation not found")
+	}
+	
+	if confirmation.UserID != userID {
+		return 0, errors.New("access denied: confirmation belongs to another user")
+	}
+	
+	if confirmation.Status != models.ConfirmationStatusPending {
+		return 0, errors.New("transfer confirmation is no longer pending")
+	}
+	
+	if time.Now().After(confirmation.ExpiresAt) {
+		s.failConfirmation(ctx, confirmation, models.ConfirmationStatusPending)
+		return 0, errors.New("confirmation code has expired")
+	}
+	
+	// Verify the code and cancel the transfer after too many wrong attempts
+	if !s.hasher.CheckPasswordHash(confirm.Code, confirmation.CodeHash) {
+		attempts, err := s.repos.TransferConfirmation.IncrementAttempts(ctx, confirmation.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to verify code: %w", err)
+		}
+		
+		if attempts >= s.config.Transfer.OTPMaxAttempts {
+			s.failConfirmation(ctx, confirmation, models.ConfirmationStatusPending)
+			return 0, errors.New("too many invalid codes, transfer cancelled")
+		}
+		
+		return 0, errors.New("invalid confirmation code")
+	}
+	
+	// Claim the confirmation so the code cannot be used twice
+	claimed, err := s.repos.TransferConfirmation.UpdateStatus(ctx, confirmation.ID,
+		models.ConfirmationStatusPending, models.ConfirmationStatusConfirmed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to confirm transfer: %w", err)
+	}
+	if !claimed {
+		return 0, errors.New("transfer confirmation is no longer pending")
+	}
+	
+	// Balances may have changed since the transfer was requested; the hold still covers its amount
+	transfer := confirmation.ToTransferRequest()
+	sourceAccount, err := s.checkTransfer(ctx, transfer, userID, confirmation.Amount)
+	if err != nil {
+		s.failConfirmation(ctx, confirmation, models.ConfirmationStatusConfirmed)
+		return 0, err
+	}
+	
+	hold := &models.AccountHold{Reason: models.HoldReasonTransferConfirmation, ReferenceID: confirmation.ID}
+	transactionID, err := s.executeTransfer(ctx, transfer, userID, sourceAccount, hold)
+	if err != nil {
+		s.failConfirmation(ctx, confirmation, models.ConfirmationStatusConfirmed)
+		return 0, err
+	}
+	
+	if err := s.repos.TransferConfirmation.SetTransaction(ctx, confirmation.ID, transactionID); err != nil {
+		s.logger.Warnf("Failed to link transaction %d to confirmation %d: %v", transactionID, confirmation.ID, err)
+	}
+	
+	return transactionID, nil
+}
+
+// checkTransfer validates a transfer request and returns the source account. held is the
+// part of the amount already reserved by the transfer's own hold.
+func (s *TransactionSvc) checkTransfer(ctx context.Context, transfer *models.TransferRequest, userID int, held float64) (*models.Account, error) {
 	// Validate transfer request
 	if err := transfer.ValidateTransferRequest(); err != nil {
-		return 0, fmt.Errorf("invalid transfer request: %w", err)
+		return nil, fmt.Errorf("invalid transfer request: %w", err)
 	}
 	
 	// Verify source account ownership
 	sourceAccount, err := s.repos.Account.GetByID(ctx, transfer.SourceAccountID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get source account: %w", err)
+		return nil, fmt.Errorf("failed to get source account: %w", err)
 	}
 	
-	if sourceAccount.UserID != userID {
-		return 0, errors.New("access denied: source account belongs to another user")
+	// The transfer is recorded as a failed transaction if it is declined. The record only names the
+	// source account, so the recipient does not see the attempt.
+	declined := transfer.ToTransaction()
+	declined.Currency = sourceAccount.Currency
+	declined.DestinationAccountID = nil
+	
+	if _, err := checkTransferAccess(ctx, s.repos, nil, sourceAccount, userID, transfer.Amount); err != nil {
+		if errors.Is(err, errDelegatedLimitExceeded) {
+			return nil, s.declines.decline(ctx, userID, declined, models.DeclineReasonLimitExceeded, err.Error())
+		}
+		return nil, err
 	}
 	
 	// Check if source account is active
 	if !sourceAccount.IsActive {
-		return 0, errors.New("source account is inactive")
+		return nil, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "source account is inactive")
 	}
 	
-	// Check if there are sufficient funds
-	if sourceAccount.Balance < transfer.Amount {
-		return 0, errors.New("insufficient funds")
+	// Dormant accounts accept incoming transfers but no outgoing ones
+	if sourceAccount.IsDormant() {
+		return nil, s.declines.decline(ctx, userID, declined, models.DeclineReasonAccountFrozen, "source account is dormant, reactivate it first")
+	}
+	
+	// Transfers beyond the free ones of the account's plan cost a fee
+	transfer.Fee, err = planTransferFee(ctx, s.repos, sourceAccount, time.Now().In(s.bank))
+	if err != nil {
+		return nil, err
+	}
+	
+	// Check if there are sufficient funds not reserved by other operations
+	if sourceAccount.AvailableBalance+held < transfer.Amount+transfer.Fee {
+		return nil, s.declines.decline(ctx, userID, declined, models.DeclineReasonInsufficientFunds, "insufficient funds")
 	}
 	
 	// Get destination account (no ownership check required for destination)
 	destAccount, err := s.repos.Account.GetByID(ctx, transfer.DestinationAccountID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get destination account: %w", err)
+		return nil, fmt.Errorf("failed to get destination account: %w", err)
 	}
 	
 	// Check if destination account is active
 	if !destAccount.IsActive {
-		return 0, errors.New("destination account is inactive")
+		return nil, errors.New("destination account is inactive")
 	}
 	
 	// Check if currencies match
 	if sourceAccount.Currency != destAccount.Currency {
-		return 0, errors.New("currency mismatch between accounts")
+		return nil, errors.New("currency mismatch between accounts")
+	}
+	
+	return sourceAccount, nil
+}
+
+// requestConfirmation stores a pending transfer and sends the one-time code to the user
+func (s *TransactionSvc) requestConfirmation(ctx context.Context, transfer *models.TransferRequest, userID int) (*models.TransferResult, error) {
+	code, err := newOTPCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate confirmation code: %w", err)
+	}
+	
+	codeHash, err := s.hasher.HashPassword(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash confirmation code: %w", err)
+	}
+	
+	confirmationID, err := newRandomID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate confirmation ID: %w", err)
+	}
+	
+	confirmation := &models.TransferConfirmation{
+		ConfirmationID:       confirmationID,
+		UserID:               userID,
+		SourceAccountID:      transfer.SourceAccountID,
+		DestinationAccountID: transfer.DestinationAccountID,
+		Amount:               transfer.Amount,
+		Description:          transfer.Description,
+		InvoiceID:            transfer.InvoiceID,
+		CodeHash:             codeHash,
+		Status:               models.ConfirmationStatusPending,
+		ExpiresAt:            time.Now().Add(time.Duration(s.config.Transfer.OTPTTL) * time.Second),
+	}
+	
+	if _, err := s.repos.TransferConfirmation.Create(ctx, confirmation); err != nil {
+		return nil, err
+	}
+	
+	// Reserve the amount so it cannot be spent while the code is on its way
+	hold := &models.AccountHold{
+		AccountID:   transfer.SourceAccountID,
+		Amount:      transfer.Amount,
+		Reason:      models.HoldReasonTransferConfirmation,
+		ReferenceID: confirmation.ID,
+		ExpiresAt:   &confirmation.ExpiresAt,
+	}
+	if _, err := s.repos.AccountHold.Create(ctx, hold); err != nil {
+		s.failConfirmation(ctx, confirmation, models.ConfirmationStatusPending)
+		return nil, err
+	}
+	
+	s.logger.Infof("Transfer of %f from account %d requires confirmation %s", 
+		transfer.Amount, transfer.SourceAccountID, confirmationID)
+	
+	// Send the code by email
+	s.lifecycle.Background("transfer-confirmation-code", func(ctx context.Context) error {
+		err := s.email.SendTransferCode(ctx, userID, code, confirmation)
+		if err != nil {
+			return fmt.Errorf("failed to send transfer confirmation code: %w", err)
+		}
+		return nil
+	})
+	
+	return &models.TransferResult{
+		ConfirmationRequired: true,
+		ConfirmationID:       confirmationID,
+		ExpiresAt:            &confirmation.ExpiresAt,
+	}, nil
+}
+
+// failConfirmation marks a confirmation as failed if it is still in the given status and releases its hold
+func (s *TransactionSvc) failConfirmation(ctx context.Context, confirmation *models.TransferConfirmation, from models.ConfirmationStatus) {
+	if _, err := s.repos.TransferConfirmation.UpdateStatus(ctx, confirmation.ID, from, models.ConfirmationStatusFailed); err != nil {
+		s.logger.Warnf("Failed to mark confirmation %d as failed: %v", confirmation.ID, err)
+	}
+	
+	s.releaseHold(ctx, models.HoldReasonTransferConfirmation, confirmation.ID)
+}
+
+// requestApproval stores an organization transfer that waits for approvals and
+// notifies the members who can approve it
+func (s *TransactionSvc) requestApproval(ctx context.Context, transfer *models.TransferRequest, userID int, organizationID int, required int) (*models.TransferResult, error) {
+	pending := &models.PendingTransfer{
+		OrganizationID:       organizationID,
+		RequestedBy:          userID,
+		SourceAccountID:      transfer.SourceAccountID,
+		DestinationAccountID: transfer.DestinationAccountID,
+		Amount:               transfer.Amount,
+		Description:          transfer.Description,
+		InvoiceID:            transfer.InvoiceID,
+		RequiredApprovals:    required,
+		Status:               models.PendingTransferStatusPendingApproval,
+		ExpiresAt:            time.Now().Add(time.Duration(s.config.Transfer.ApprovalTTL) * time.Second),
+	}
+	
+	id, err := s.repos.PendingTransfer.Create(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	pending.ID = id
+	
+	// Reserve the amount until the approvers decide
+	hold := &models.AccountHold{
+		AccountID:   transfer.SourceAccountID,
+		Amount:      transfer.Amount,
+		Reason:      models.HoldReasonTransferApproval,
+		ReferenceID: id,
+		ExpiresAt:   &pending.ExpiresAt,
+	}
+	if _, err := s.repos.AccountHold.Create(ctx, hold); err != nil {
+		s.failPendingTransfer(ctx, pending, models.PendingTransferStatusPendingApproval)
+		return nil, err
+	}
+	
+	s.logger.Infof("Transfer of %f from account %d requires %d approvals, pending transfer %d", 
+		transfer.Amount, transfer.SourceAccountID, required, id)
+	
+	s.lifecycle.Background("transfer-approval-request", func(ctx context.Context) error {
+		return s.notifyApprovers(ctx, pending)
+	})
+	
+	return &models.TransferResult{
+		ApprovalRequired:  true,
+		PendingTransferID: id,
+		RequiredApprovals: required,
+		ExpiresAt:         &pending.ExpiresAt,
+	}, nil
+}
+
+// notifyApprovers sends an in-app notification to every member who can approve the transfer
+func (s *TransactionSvc) notifyApprovers(ctx context.Context, pending *models.PendingTransfer) error {
+	members, err := s.repos.Organization.GetMembers(ctx, pending.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get members: %w", err)
+	}
+	
+	for _, member := range members {
+		if member.UserID == pending.RequestedBy || !member.Role.CanTransact() {
+			continue
+		}
+		
+		if err := s.notifications.Notify(ctx, member.UserID, models.NotificationTypeApproval, "transfer_awaiting_approval",
+			pending.Amount, pending.SourceAccountID, pending.RequiredApprovals, pending.ID); err != nil {
+			s.logger.Warnf("Failed to notify member %d about pending transfer %d: %v", member.UserID, pending.ID, err)
+		}
+	}
+	
+	return nil
+}
+
+// GetPendingTransfer gets a pending transfer with its approvals; any organization member may view it
+func (s *TransactionSvc) GetPendingTransfer(ctx context.Context, id int, userID int) (*models.PendingTransfer, error) {
+	pending, err := s.repos.PendingTransfer.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transfer: %w", err)
+	}
+	
+	if _, err := s.repos.Organization.GetMember(ctx, pending.OrganizationID, userID); err != nil {
+		return nil, errors.New("access denied: you are not a member of this organization")
+	}
+	
+	pending.Approvals, err = s.repos.PendingTransfer.GetApprovals(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+	
+	return pending, nil
+}
+
+// GetPendingTransfers gets the pending transfers of an organization; any member may view them
+func (s *TransactionSvc) GetPendingTransfers(ctx context.Context, organizationID int, userID int) ([]*models.PendingTransfer, error) {
+	if _, err := s.repos.Organization.GetMember(ctx, organizationID, userID); err != nil {
+		return nil, errors.New("access denied: you are not a member of this organization")
+	}
+	
+	transfers, err := s.repos.PendingTransfer.GetByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transfers: %w", err)
+	}
+	
+	for _, pending := range transfers {
+		pending.Approvals, err = s.repos.PendingTransfer.GetApprovals(ctx, pending.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get approvals: %w", err)
+		}
+	}
+	
+	return transfers, nil
+}
+
+// ApproveTransfer records the user's approval and executes the transfer once the
+// required number of approvals is reached. The requester cannot approve their own transfer.
+func (s *TransactionSvc) ApproveTransfer(ctx context.Context, id int, userID int) (*models.PendingTransfer, error) {
+	pending, err := s.getPendingForApprover(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if pending.RequestedBy == userID {
+		return nil, errors.New("you cannot approve your own transfer")
+	}
+	
+	if err := s.repos.PendingTransfer.AddApproval(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	
+	s.logger.Infof("Pending transfer %d approved by user %d", id, userID)
+	
+	pending.Approvals, err = s.repos.PendingTransfer.GetApprovals(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approvals: %w", err)
+	}
+	
+	if len(pending.Approvals) < pending.RequiredApprovals {
+		return pending, nil
+	}
+	
+	// Claim the transfer so concurrent approvals cannot execute it twice
+	claimed, err := s.repos.PendingTransfer.UpdateStatus(ctx, id,
+		models.PendingTransferStatusPendingApproval, models.PendingTransferStatusApproved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve transfer: %w", err)
+	}
+	if !claimed {
+		return s.GetPendingTransfer(ctx, id, userID)
+	}
+	
+	// The requester must still be allowed to transfer and balances may have changed
+	transfer := pending.ToTransferRequest()
+	sourceAccount, err := s.checkTransfer(ctx, transfer, pending.RequestedBy, pending.Amount)
+	if err != nil {
+		s.failPendingTransfer(ctx, pending, models.PendingTransferStatusApproved)
+		return nil, err
+	}
+	
+	hold := &models.AccountHold{Reason: models.HoldReasonTransferApproval, ReferenceID: pending.ID}
+	transactionID, err := s.executeTransfer(ctx, transfer, pending.RequestedBy, sourceAccount, hold)
+	if err != nil {
+		s.failPendingTransfer(ctx, pending, models.PendingTransferStatusApproved)
+		return nil, err
 	}
 	
+	if err := s.repos.PendingTransfer.SetTransaction(ctx, id, transactionID); err != nil {
+		s.logger.Warnf("Failed to link transaction %d to pending transfer %d: %v", transactionID, id, err)
+	}
+	
+	pending.Status = models.PendingTransferStatusApproved
+	pending.TransactionID = &transactionID
+	
+	return pending, nil
+}
+
+// RejectTransfer rejects a pending transfer; any approver or the requester may do this
+func (s *TransactionSvc) RejectTransfer(ctx context.Context, id int, userID int) error {
+	pending, err := s.repos.PendingTransfer.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get pending transfer: %w", err)
+	}
+	
+	if pending.RequestedBy != userID {
+		if _, err := s.getPendingForApprover(ctx, id, userID); err != nil {
+			return err
+		}
+	}
+	
+	rejected, err := s.repos.PendingTransfer.UpdateStatus(ctx, id,
+		models.PendingTransferStatusPendingApproval, models.PendingTransferStatusRejected)
+	if err != nil {
+		return fmt.Errorf("failed to reject transfer: %w", err)
+	}
+	if !rejected {
+		return errors.New("transfer is no longer pending approval")
+	}
+	
+	s.releaseHold(ctx, models.HoldReasonTransferApproval, id)
+	
+	s.logger.Infof("Pending transfer %d rejected by user %d", id, userID)
+	
+	return nil
+}
+
+// getPendingForApprover gets a transfer that is still waiting for approvals and verifies
+// that the user's organization role allows approving transfers
+func (s *TransactionSvc) getPendingForApprover(ctx context.Context, id int, userID int) (*models.PendingTransfer, error) {
+	pending, err := s.repos.PendingTransfer.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending transfer: %w", err)
+	}
+	
+	member, err := s.repos.Organization.GetMember(ctx, pending.OrganizationID, userID)
+	if err != nil {
+		return nil, errors.New("access denied: you are not a member of this organization")
+	}
+	
+	if err := checkOrganizationRole(member.Role, accessTransact); err != nil {
+		return nil, err
+	}
+	
+	if pending.Status != models.PendingTransferStatusPendingApproval {
+		return nil, errors.New("transfer is no longer pending approval")
+	}
+	
+	if time.Now().After(pending.ExpiresAt) {
+		s.failPendingTransfer(ctx, pending, models.PendingTransferStatusPendingApproval)
+		return nil, errors.New("transfer approval has expired")
+	}
+	
+	return pending, nil
+}
+
+// failPendingTransfer marks a pending transfer as failed if it is still in the given status and releases its hold
+func (s *TransactionSvc) failPendingTransfer(ctx context.Context, pending *models.PendingTransfer, from models.PendingTransferStatus) {
+	if _, err := s.repos.PendingTransfer.UpdateStatus(ctx, pending.ID, from, models.PendingTransferStatusFailed); err != nil {
+		s.logger.Warnf("Failed to mark pending transfer %d as failed: %v", pending.ID, err)
+	}
+	
+	s.releaseHold(ctx, models.HoldReasonTransferApproval, pending.ID)
+}
+
+// releaseHold makes the funds reserved for a cancelled operation available again
+func (s *TransactionSvc) releaseHold(ctx context.Context, reason models.HoldReason, referenceID int) {
+	if _, err := s.repos.AccountHold.Release(ctx, reason, referenceID, models.HoldStatusReleased); err != nil {
+		s.logger.Warnf("Failed to release %s hold %d: %v", reason, referenceID, err)
+	}
+}
+
+// newOTPCode generates a random 6-digit one-time code
+func newOTPCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// executeTransfer moves the money and records the transaction. A non-nil hold identifies
+// the hold reserving the amount; it is captured together with the debit.
+func (s *TransactionSvc) executeTransfer(ctx context.Context, transfer *models.TransferRequest, userID int, sourceAccount *models.Account, hold *models.AccountHold) (int, error) {
 	// Start a transaction
 	tx, err := s.repos.DB.BeginTx(ctx, nil)
 	if err != nil {
@@ -86,14 +565,21 @@ func (s *TransactionSvc) Transfer(ctx context.Context, transfer *models.Transfer
 		}
 	}()
 	
+	// Capture the hold first so the debit below can use the reserved funds
+	if hold != nil {
+		if _, err = s.repos.AccountHold.ReleaseTx(ctx, tx, hold.Reason, hold.ReferenceID, models.HoldStatusCaptured); err != nil {
+			return 0, err
+		}
+	}
+	
 	// Deduct from source account
-	err = s.repos.Account.UpdateBalance(ctx, transfer.SourceAccountID, -transfer.Amount)
+	err = s.repos.Account.UpdateBalanceTx(ctx, tx, transfer.SourceAccountID, -transfer.Amount)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update source account balance: %w", err)
 	}
 	
 	// Add to destination account
-	err = s.repos.Account.UpdateBalance(ctx, transfer.DestinationAccountID, transfer.Amount)
+	err = s.repos.Account.UpdateBalanceTx(ctx, tx, transfer.DestinationAccountID, transfer.Amount)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update destination account balance: %w", err)
 	}
@@ -103,11 +589,65 @@ func (s *TransactionSvc) Transfer(ctx context.Context, transfer *models.Transfer
 	transaction.Currency = sourceAccount.Currency
 	transaction.Status = models.TransactionStatusCompleted
 	
-	transactionID, err := s.repos.Transaction.Create(ctx, transaction)
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction record: %w", err)
 	}
 	
+	// Charge the plan's transfer fee
+	if transfer.Fee > 0 {
+		err = s.repos.Account.UpdateBalanceTx(ctx, tx, transfer.SourceAccountID, -transfer.Fee)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update source account balance: %w", err)
+		}
+		
+		_, err = s.repos.Transaction.CreateTx(ctx, tx, &models.Transaction{
+			TransactionType: models.TransactionTypeFee,
+			SourceAccountID: &transfer.SourceAccountID,
+			Amount:          transfer.Fee,
+			Currency:        sourceAccount.Currency,
+			Description:     fmt.Sprintf("Transfer fee for transaction %d", transactionID),
+			Status:          models.TransactionStatusCompleted,
+			TransactionDate: time.Now(),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to create fee transaction: %w", err)
+		}
+	}
+	
+	// An invoice is paid only once; a payment racing another one or a cancellation fails
+	if transfer.InvoiceID != nil {
+		var paid bool
+		paid, err = s.repos.Invoice.MarkPaidTx(ctx, tx, *transfer.InvoiceID, transfer.SourceAccountID, transactionID)
+		if err != nil {
+			return 0, err
+		}
+		if !paid {
+			err = errors.New("invoice is no longer payable")
+			return 0, err
+		}
+	}
+	
+	// A transfer made under a power of attorney is attributed to the delegate in its audit log
+	delegation, err := checkTransferAccess(ctx, s.repos, tx, sourceAccount, userID, transfer.Amount)
+	if err != nil {
+		return 0, err
+	}
+	if delegation != nil {
+		err = recordDelegatedAction(ctx, s.repos, tx, &models.DelegationEvent{
+			DelegationID:  delegation.ID,
+			AccountID:     sourceAccount.ID,
+			ActorID:       userID,
+			Action:        models.DelegationActionTransferred,
+			TransactionID: &transactionID,
+			Amount:        transfer.Amount,
+			Details:       fmt.Sprintf("to account %d", transfer.DestinationAccountID),
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
@@ -116,15 +656,44 @@ func (s *TransactionSvc) Transfer(ctx context.Context, transfer *models.Transfer
 	s.logger.Infof("Transfer of %f from account %d to account %d completed, transaction: %d", 
 		transfer.Amount, transfer.SourceAccountID, transfer.DestinationAccountID, transactionID)
 	
+	if delegation != nil {
+		s.logger.Infof("Transaction %d made by user %d under delegation %d of user %d",
+			transactionID, userID, delegation.ID, sourceAccount.UserID)
+		s.lifecycle.Background("delegated-transfer-notification", func(ctx context.Context) error {
+			err := s.notifications.Notify(ctx, sourceAccount.UserID, models.NotificationTypeAccount, "delegated_transfer",
+				delegation.DelegateEmail, transfer.Amount, sourceAccount.Currency, sourceAccount.AccountNumber)
+			if err != nil {
+				return fmt.Errorf("failed to send delegated transfer notification: %w", err)
+			}
+			return nil
+		})
+	}
+	
+	if transfer.InvoiceID != nil {
+		invoiceID := *transfer.InvoiceID
+		s.lifecycle.Background("invoice-notification", func(ctx context.Context) error {
+			invoice, err := s.repos.Invoice.GetByID(ctx, invoiceID)
+			if err != nil {
+				return fmt.Errorf("failed to get invoice: %w", err)
+			}
+			err = s.notifications.Notify(ctx, invoice.IssuerID, models.NotificationTypeInvoice, "invoice_paid",
+				invoice.Number, invoice.Amount, invoice.Currency, invoice.RecipientEmail)
+			if err != nil {
+				return fmt.Errorf("failed to send invoice notification: %w", err)
+			}
+			return nil
+		})
+	}
+	
 	// Send notification emails
 	transaction.ID = transactionID
-	go func() {
-		ctx := context.Background()
+	s.lifecycle.Background("transaction-notification", func(ctx context.Context) error {
 		err := s.email.SendTransactionNotification(ctx, userID, transaction)
 		if err != nil {
-			s.logger.Warnf("Failed to send transaction notification: %v", err)
+			return fmt.Errorf("failed to send transaction notification: %w", err)
 		}
-	}()
+		return nil
+	})
 	
 	return transactionID, nil
 }
@@ -142,16 +711,11 @@ func (s *TransactionSvc) Pay(ctx context.Context, payment *models.PaymentRequest
 		return 0, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return 0, errors.New("access denied: account belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessTransact); err != nil {
+		return 0, err
 	}
 	
-	// Check if account is active
-	if !account.IsActive {
-		return 0, errors.New("account is inactive")
-	}
-	
-	// Verify card ownership and status
+	// Verify card ownership
 	card, err := s.repos.Card.GetByID(ctx, payment.CardID)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get card: %w", err)
@@ -161,13 +725,40 @@ func (s *TransactionSvc) Pay(ctx context.Context, payment *models.PaymentRequest
 		return 0, errors.New("card does not belong to specified account")
 	}
 	
+	// The payment is recorded as a failed transaction if it is declined
+	transaction := payment.ToTransaction()
+	transaction.Currency = account.Currency
+	
+	// Check if account is active
+	if !account.IsActive {
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonAccountFrozen, "account is inactive")
+	}
+	
+	if account.IsDormant() {
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonAccountFrozen, "account is dormant, reactivate it first")
+	}
+	
 	if !card.IsActive {
-		return 0, errors.New("card is inactive")
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonCardFrozen, "card is inactive")
+	}
+	
+	// Check the limits of the card's product
+	product, err := s.cardRules.product(ctx, card)
+	if err != nil {
+		return 0, err
+	}
+	
+	exceeded, err := s.cardRules.checkLimits(ctx, nil, card, product, payment.Amount, false)
+	if err != nil {
+		return 0, err
+	}
+	if exceeded != "" {
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonLimitExceeded, exceeded)
 	}
 	
 	// Check if there are sufficient funds
-	if account.Balance < payment.Amount {
-		return 0, errors.New("insufficient funds")
+	if account.AvailableBalance < payment.Amount {
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonInsufficientFunds, "insufficient funds")
 	}
 	
 	// Start a transaction
@@ -183,21 +774,35 @@ func (s *TransactionSvc) Pay(ctx context.Context, payment *models.PaymentRequest
 	}()
 	
 	// Update account balance
-	err = s.repos.Account.UpdateBalance(ctx, payment.AccountID, -payment.Amount)
+	err = s.repos.Account.UpdateBalanceTx(ctx, tx, payment.AccountID, -payment.Amount)
 	if err != nil {
 		return 0, fmt.Errorf("failed to update account balance: %w", err)
 	}
 	
+	// The debit locked the account row, so the daily limit is checked again against the payments
+	// that went through meanwhile
+	exceeded, err = s.cardRules.checkLimits(ctx, tx, card, product, payment.Amount, false)
+	if err != nil {
+		return 0, err
+	}
+	if exceeded != "" {
+		tx.Rollback()
+		return 0, s.declines.decline(ctx, userID, transaction, models.DeclineReasonLimitExceeded, exceeded)
+	}
+	
 	// Create transaction record
-	transaction := payment.ToTransaction()
-	transaction.Currency = account.Currency
 	transaction.Status = models.TransactionStatusCompleted
 	
-	transactionID, err := s.repos.Transaction.Create(ctx, transaction)
+	transactionID, err := s.repos.Transaction.CreateTx(ctx, tx, transaction)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create transaction record: %w", err)
 	}
 	
+	transaction.ID = transactionID
+	if err = s.cardRules.payCashback(ctx, tx, card, product, transaction); err != nil {
+		return 0, fmt.Errorf("failed to pay cashback: %w", err)
+	}
+	
 	// Commit the transaction
 	if err = tx.Commit(); err != nil {
 		return 0, fmt.Errorf("failed to commit transaction: %w", err)
@@ -207,27 +812,40 @@ func (s *TransactionSvc) Pay(ctx context.Context, payment *models.PaymentRequest
 		payment.Amount, payment.AccountID, payment.CardID, transactionID)
 	
 	// Send notification email
-	transaction.ID = transactionID
-	go func() {
-		ctx := context.Background()
+	s.lifecycle.Background("transaction-notification", func(ctx context.Context) error {
 		err := s.email.SendTransactionNotification(ctx, userID, transaction)
 		if err != nil {
-			s.logger.Warnf("Failed to send transaction notification: %v", err)
+			return fmt.Errorf("failed to send transaction notification: %w", err)
 		}
-	}()
+		return nil
+	})
 	
 	return transactionID, nil
 }
 
 // GetByID gets a transaction by ID and verifies ownership
 func (s *TransactionSvc) GetByID(ctx context.Context, id int, userID int) (*models.Transaction, error) {
+	transaction, err := s.getViewable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, []*models.Transaction{transaction}); err != nil {
+		return nil, err
+	}
+	
+	return transaction, nil
+}
+
+// getViewable gets a transaction with its original description if the user can view one of its accounts
+func (s *TransactionSvc) getViewable(ctx context.Context, id int, userID int) (*models.Transaction, error) {
 	// Get the transaction
 	transaction, err := s.repos.Transaction.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transaction: %w", err)
 	}
 	
-	// Check ownership - either source or destination account must belong to user
+	// Check access - the user must be able to view either the source or destination account
 	var accountIDs []int
 	
 	if transaction.SourceAccountID != nil {
@@ -249,7 +867,7 @@ func (s *TransactionSvc) GetByID(ctx context.Context, id int, userID int) (*mode
 			continue
 		}
 		
-		if account.UserID == userID {
+		if checkAccountAccess(ctx, s.repos, account, userID, accessView) == nil {
 			owned = true
 			break
 		}
@@ -269,6 +887,10 @@ func (s *TransactionSvc) GetByUserID(ctx context.Context, userID int) ([]*models
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, transactions); err != nil {
+		return nil, err
+	}
+	
 	return transactions, nil
 }
 
@@ -280,8 +902,8 @@ func (s *TransactionSvc) GetByAccountID(ctx context.Context, accountID int, user
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 	
-	if account.UserID != userID {
-		return nil, errors.New("access denied: account belongs to another user")
+	if err := checkAccountAccess(ctx, s.repos, account, userID, accessView); err != nil {
+		return nil, err
 	}
 	
 	// Get transactions for the account
@@ -290,6 +912,10 @@ func (s *TransactionSvc) GetByAccountID(ctx context.Context, accountID int, user
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, transactions); err != nil {
+		return nil, err
+	}
+	
 	return transactions, nil
 }
 
@@ -300,5 +926,92 @@ func (s *TransactionSvc) GetByDateRange(ctx context.Context, userID int, startDa
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 	
+	if err := applyDescriptionEdits(ctx, s.repos, userID, transactions); err != nil {
+		return nil, err
+	}
+	
 	return transactions, nil
+}
+
+// EditDescription changes the description the user sees for one of their transactions. The original
+// description is kept and still used by statements, the accounting export and categorization; each
+// user of a shared transaction has their own description.
+func (s *TransactionSvc) EditDescription(ctx context.Context, id int, userID int, update *models.TransactionDescriptionUpdate) (*models.Transaction, error) {
+	if err := update.ValidateTransactionDescriptionUpdate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction description: %w", err)
+	}
+	
+	transaction, err := s.getViewable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Setting the original description again is the same as restoring it
+	description := update.Description
+	if description == transaction.Description {
+		description = ""
+	}
+	
+	edit := &models.TransactionDescriptionEdit{
+		TransactionID: transaction.ID,
+		UserID:        userID,
+		Description:   description,
+	}
+	if _, err := s.repos.TransactionDescription.Create(ctx, edit); err != nil {
+		return nil, err
+	}
+	
+	s.logger.Infof("User %d changed the description of transaction %d", userID, transaction.ID)
+	
+	transaction.ApplyDescriptionEdit(edit)
+	return transaction, nil
+}
+
+// GetDescriptionHistory gets the original description of one of the user's transactions with the
+// user's edits of it
+func (s *TransactionSvc) GetDescriptionHistory(ctx context.Context, id int, userID int) (*models.TransactionDescriptionHistory, error) {
+	transaction, err := s.getViewable(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	edits, err := s.repos.TransactionDescription.GetHistory(ctx, transaction.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	
+	history := &models.TransactionDescriptionHistory{
+		TransactionID: transaction.ID,
+		Original:      transaction.Description,
+		Current:       transaction.Description,
+		Edits:         edits,
+	}
+	if len(edits) > 0 && edits[len(edits)-1].Description != "" {
+		history.Current = edits[len(edits)-1].Description
+	}
+	
+	return history, nil
+}
+
+// applyDescriptionEdits shows a user the descriptions they gave transactions in place of the originals
+func applyDescriptionEdits(ctx context.Context, repos *repository.Repository, userID int, transactions []*models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+	
+	ids := make([]int, len(transactions))
+	for i, transaction := range transactions {
+		ids[i] = transaction.ID
+	}
+	
+	latest, err := repos.TransactionDescription.GetLatest(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction descriptions: %w", err)
+	}
+	
+	for _, transaction := range transactions {
+		transaction.ApplyDescriptionEdit(latest[transaction.ID])
+	}
+	
+	return nil
 }
\ No newline at end of file
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"banking-service/internal/models"
+)
+
+func TestExecuteTransferRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	deps := newTestDependencies()
+	svc := NewTransactionService(deps)
+
+	userID := createTestUser(t, ctx, deps.Repos, "sender")
+	source := createTestAccount(t, ctx, deps.Repos, userID, 1000)
+	destination := createTestAccount(t, ctx, deps.Repos, userID, 0)
+
+	// The fee does not fit in the balance left after the transfer, so the last debit fails after
+	// both balances have changed and the transaction was recorded
+	transfer := &models.TransferRequest{
+		SourceAccountID:      source.ID,
+		DestinationAccountID: destination.ID,
+		Amount:               1000,
+		Fee:                  30,
+		Description:          "rent",
+	}
+
+	if _, err := svc.executeTransfer(ctx, transfer, userID, source, nil); err == nil {
+		t.Fatal("expected the transfer to fail")
+	}
+
+	assertBalance(t, ctx, deps, source.ID, 1000)
+	assertBalance(t, ctx, deps, destination.ID, 0)
+
+	transactions, err := deps.Repos.Transaction.GetByAccountID(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("failed to get transactions: %v", err)
+	}
+	if len(transactions) != 0 {
+		t.Errorf("expected no transactions after the rollback, got %d", len(transactions))
+	}
+}
+
+// assertBalance checks the balance of an account
+func assertBalance(t *testing.T, ctx context.Context, deps Dependencies, accountID int, want float64) {
+	t.Helper()
+
+	account, err := deps.Repos.Account.GetByID(ctx, accountID)
+	if err != nil {
+		t.Fatalf("failed to get account %d: %v", accountID, err)
+	}
+	if account.Balance != want {
+		t.Errorf("account %d: expected balance %.2f, got %.2f", accountID, want, account.Balance)
+	}
+}
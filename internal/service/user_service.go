@@ -2,8 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -13,27 +19,46 @@ import (
 	"banking-service/internal/models"
 	"banking-service/internal/repository"
 	"banking-service/pkg/crypto"
+	"banking-service/pkg/i18n"
+	"banking-service/pkg/lifecycle"
 )
 
+// passwordResetTTL is how long a password reset link stays valid
+const passwordResetTTL = time.Hour
+
+// availabilityCheckTime is how long an availability check takes whatever the answer, so response
+// times do not tell which lookups found an account
+const availabilityCheckTime = 200 * time.Millisecond
+
 // UserService is an implementation of the service.UserService interface
 type UserSvc struct {
 	repos      *repository.Repository
 	logger     *logrus.Logger
 	config     *configs.Config
-	hasher     *crypto.PasswordHasher
-	jwtSecret  string
+	hasher     *crypto.Argon2Hasher
+	sessions   SessionService
+	email      EmailService
+	lifecycle  *lifecycle.Manager
+	onboarding OnboardingService
+	jwt        *crypto.JWTKeys
 	jwtTTL     time.Duration
+	publicURL  string
 }
 
 // NewUserService creates a new UserSvc
 func NewUserService(deps Dependencies) *UserSvc {
 	return &UserSvc{
-		repos:     deps.Repos,
-		logger:    deps.Logger,
-		config:    deps.Config,
-		hasher:    crypto.NewPasswordHasher(),
-		jwtSecret: deps.Config.JWT.Secret,
-		jwtTTL:    time.Duration(deps.Config.JWT.TTL) * time.Hour,
+		repos:      deps.Repos,
+		logger:     deps.Logger,
+		config:     deps.Config,
+		hasher:     newPasswordHasher(deps.Config.Password),
+		sessions:   NewSessionService(deps),
+		email:      NewEmailService(deps),
+		lifecycle:  deps.Lifecycle,
+		onboarding: NewOnboardingService(deps),
+		jwt:        deps.JWT,
+		jwtTTL:     time.Duration(deps.Config.JWT.TTL) * time.Hour,
+		publicURL:  strings.TrimRight(deps.Config.Server.PublicURL, "/"),
 	}
 }
 
@@ -56,6 +81,15 @@ func (s *UserSvc) Register(ctx context.Context, userReg *models.UserRegistration
 		return 0, errors.New("email already exists")
 	}
 	
+	// Resolve the referral code before creating the user so a mistyped code is reported
+	var referrerID int
+	if userReg.ReferralCode != "" {
+		referrerID, err = s.repos.Referral.GetUserIDByCode(ctx, userReg.ReferralCode)
+		if err != nil {
+			return 0, errors.New("invalid referral code")
+		}
+	}
+	
 	// Create a user object from registration data
 	user := userReg.ToUser()
 	
@@ -75,11 +109,62 @@ func (s *UserSvc) Register(ctx context.Context, userReg *models.UserRegistration
 	
 	s.logger.Infof("User registered: %d", id)
 	
+	// Attribute the new user to the referrer; registration succeeds even if this fails
+	if referrerID != 0 {
+		referral := &models.Referral{
+			ReferrerID: referrerID,
+			RefereeID:  id,
+			Code:       userReg.ReferralCode,
+			Status:     models.ReferralStatusPending,
+		}
+		if _, err := s.repos.Referral.Create(ctx, referral); err != nil {
+			s.logger.Warnf("Failed to record referral of user %d by user %d: %v", id, referrerID, err)
+		}
+	}
+	
 	return id, nil
 }
 
-// Login logs in a user and returns a JWT token
-func (s *UserSvc) Login(ctx context.Context, login *models.UserLogin) (*models.TokenResponse, error) {
+// CheckAvailability reports whether a username and an email can still be registered. Every check
+// takes availabilityCheckTime, found or not.
+func (s *UserSvc) CheckAvailability(ctx context.Context, request *models.AvailabilityRequest) (*models.Availability, error) {
+	deadline := time.NewTimer(availabilityCheckTime)
+	defer deadline.Stop()
+	
+	if err := request.ValidateAvailability(); err != nil {
+		return nil, fmt.Errorf("invalid availability data: %w", err)
+	}
+	
+	availability := &models.Availability{}
+	
+	if request.Username != "" {
+		available, err := isFree(s.repos.User.GetByUsername(ctx, request.Username))
+		if err != nil {
+			return nil, err
+		}
+		availability.UsernameAvailable = &available
+	}
+	
+	if request.Email != "" {
+		available, err := isFree(s.repos.User.GetByEmail(ctx, request.Email))
+		if err != nil {
+			return nil, err
+		}
+		availability.EmailAvailable = &available
+	}
+	
+	// Answer only once the fixed check time has passed
+	select {
+	case <-deadline.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	
+	return availability, nil
+}
+
+// Login logs in a user, records the session and returns a JWT token bound to it
+func (s *UserSvc) Login(ctx context.Context, login *models.UserLogin, client models.ClientInfo) (*models.TokenResponse, error) {
 	// Get user by username
 	user, err := s.repos.User.GetByUsername(ctx, login.Username)
 	if err != nil {
@@ -91,27 +176,89 @@ func (s *UserSvc) Login(ctx context.Context, login *models.UserLogin) (*models.T
 		return nil, errors.New("invalid credentials")
 	}
 	
+	// Migrate legacy bcrypt hashes and outdated parameters while the password is known
+	if s.hasher.NeedsRehash(user.PassHash) {
+		s.rehashPassword(ctx, user.ID, login.Password)
+	}
+	
 	// Generate JWT token
 	expirationTime := time.Now().Add(s.jwtTTL)
 	
+	// Record the login so the session can be listed and revoked
+	sessionID, err := s.sessions.Start(ctx, user.ID, client, expirationTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	
+	tokenResponse, err := s.signToken(user, sessionID, expirationTime, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	s.logger.Infof("User logged in: %d from %s", user.ID, client.IPAddress)
+	
+	return tokenResponse, nil
+}
+
+// IssueScopedToken issues a token limited to the requested scopes, for clients such as an
+// analytics widget that must not move money. It is bound to the session of the token used to
+// request it, so revoking that session revokes it too, and expires with the session at the latest.
+func (s *UserSvc) IssueScopedToken(ctx context.Context, userID int, sessionID string, request *models.ScopedTokenRequest) (*models.TokenResponse, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid token data: %w", err)
+	}
+	
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	session, err := s.repos.Session.GetBySessionID(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return nil, errors.New("session not found")
+	}
+	
+	expirationTime := session.ExpiresAt
+	if request.ExpiresIn > 0 {
+		if requested := time.Now().Add(time.Duration(request.ExpiresIn) * time.Second); requested.Before(expirationTime) {
+			expirationTime = requested
+		}
+	}
+	
+	tokenResponse, err := s.signToken(user, sessionID, expirationTime, request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	
+	s.logger.Infof("User %d issued a token with scopes %v", userID, request.Scopes)
+	
+	return tokenResponse, nil
+}
+
+// signToken signs a JWT token of a user bound to a session. A token with scopes may only use the
+// endpoints that require one of them; one without has full access.
+func (s *UserSvc) signToken(user *models.User, sessionID string, expirationTime time.Time, scopes []models.Scope) (*models.TokenResponse, error) {
 	claims := jwt.MapClaims{
 		"user_id": user.ID,
+		"role":    string(user.Role),
+		"tenant":  user.Tenant,
+		"sid":     sessionID,
 		"exp":     expirationTime.Unix(),
 	}
+	if len(scopes) > 0 {
+		claims["scopes"] = scopes
+	}
 	
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	
-	// Sign the token with our secret
-	tokenString, err := token.SignedString([]byte(s.jwtSecret))
+	// Sign the token with the configured secret or private key
+	tokenString, err := s.jwt.Sign(claims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 	
-	s.logger.Infof("User logged in: %d", user.ID)
-	
 	return &models.TokenResponse{
 		Token:     tokenString,
 		ExpiresAt: expirationTime.Unix(),
+		Scopes:    scopes,
 	}, nil
 }
 
@@ -161,4 +308,292 @@ func (s *UserSvc) Update(ctx context.Context, user *models.User) error {
 	s.logger.Infof("User updated: %d", user.ID)
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// ChangePassword changes the password of a user after checking the current one.
+// All other sessions are revoked and the user is notified by email.
+func (s *UserSvc) ChangePassword(ctx context.Context, userID int, currentSessionID string, change *models.PasswordChangeRequest) error {
+	// Validate password change data
+	if err := change.ValidatePasswordChange(); err != nil {
+		return fmt.Errorf("invalid password data: %w", err)
+	}
+	
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	// Verify the current password
+	if !s.hasher.CheckPasswordHash(change.CurrentPassword, user.PassHash) {
+		return errors.New("current password is incorrect")
+	}
+	
+	// Hash the new password
+	hashedPassword, err := s.hasher.HashPassword(change.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	
+	if err := s.repos.User.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	
+	// Sign out every other device
+	revoked, err := s.repos.Session.RevokeAllExcept(ctx, userID, currentSessionID)
+	if err != nil {
+		s.logger.Warnf("Failed to revoke sessions after password change for user %d: %v", userID, err)
+	}
+	
+	s.logger.Infof("Password changed for user %d, %d other sessions revoked", userID, revoked)
+	
+	// Send confirmation email
+	s.lifecycle.Background("password-changed-notification", func(ctx context.Context) error {
+		err := s.email.SendPasswordChanged(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to send password change confirmation: %w", err)
+		}
+		return nil
+	})
+	
+	return nil
+}
+
+// ForgotPassword emails a one-time link to set a new password to the user with the given email.
+// It succeeds whether or not such a user exists, so the endpoint cannot be used to find out
+// which addresses have an account; a new link invalidates the ones sent before.
+func (s *UserSvc) ForgotPassword(ctx context.Context, forgot *models.PasswordForgotRequest) error {
+	if err := forgot.ValidatePasswordForgot(); err != nil {
+		return fmt.Errorf("invalid password reset data: %w", err)
+	}
+	
+	user, err := s.repos.User.GetByEmail(ctx, forgot.Email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			s.logger.Infof("Password reset requested for unknown email %s", forgot.Email)
+			return nil
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	if err := s.repos.PasswordReset.InvalidateByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate password resets: %w", err)
+	}
+	
+	token, hash, err := newPasswordResetToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	
+	reset := &models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(passwordResetTTL),
+	}
+	if _, err := s.repos.PasswordReset.Create(ctx, reset); err != nil {
+		return fmt.Errorf("failed to create password reset: %w", err)
+	}
+	
+	s.logger.Infof("Password reset %d requested for user %d", reset.ID, user.ID)
+	
+	// Send the link in the background so the response takes as long for unknown addresses
+	resetURL := fmt.Sprintf("%s/password/reset?token=%s", s.publicURL, url.QueryEscape(token))
+	s.lifecycle.Background("password-reset-email", func(ctx context.Context) error {
+		err := s.email.SendPasswordReset(ctx, user.ID, resetURL, reset.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to send password reset link: %w", err)
+		}
+		return nil
+	})
+	
+	return nil
+}
+
+// ResetPassword sets a new password with the token from a password reset link. The token can be
+// used once; every session of the user is revoked and the user is notified by email.
+func (s *UserSvc) ResetPassword(ctx context.Context, reset *models.PasswordResetRequest) error {
+	if err := reset.ValidatePasswordReset(); err != nil {
+		return fmt.Errorf("invalid password reset data: %w", err)
+	}
+	
+	passwordReset, err := s.repos.PasswordReset.GetByTokenHash(ctx, hashPasswordResetToken(reset.Token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("invalid or expired password reset token")
+		}
+		return fmt.Errorf("failed to get password reset: %w", err)
+	}
+	
+	if passwordReset.UsedAt != nil || time.Now().After(passwordReset.ExpiresAt) {
+		return errors.New("invalid or expired password reset token")
+	}
+	
+	// Hash the new password
+	hashedPassword, err := s.hasher.HashPassword(reset.NewPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	
+	// Use the token up first, so two requests with it cannot both succeed
+	used, err := s.repos.PasswordReset.MarkUsed(ctx, passwordReset.ID)
+	if err != nil {
+		return fmt.Errorf("failed to use password reset: %w", err)
+	}
+	if !used {
+		return errors.New("invalid or expired password reset token")
+	}
+	
+	userID := passwordReset.UserID
+	if err := s.repos.User.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	
+	if err := s.repos.PasswordReset.InvalidateByUserID(ctx, userID); err != nil {
+		s.logger.Warnf("Failed to invalidate password resets of user %d: %v", userID, err)
+	}
+	
+	// Sign out every device, the one that lost the password included
+	revoked, err := s.repos.Session.RevokeAllExcept(ctx, userID, "")
+	if err != nil {
+		s.logger.Warnf("Failed to revoke sessions after password reset for user %d: %v", userID, err)
+	}
+	
+	s.logger.Infof("Password reset %d used by user %d, %d sessions revoked", passwordReset.ID, userID, revoked)
+	
+	// Send confirmation email
+	s.lifecycle.Background("password-changed-notification", func(ctx context.Context) error {
+		err := s.email.SendPasswordChanged(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to send password change confirmation: %w", err)
+		}
+		return nil
+	})
+	
+	return nil
+}
+
+// SetTimezone sets the time zone used for the statistics of a user; an empty one resets it to the
+// bank's time zone
+func (s *UserSvc) SetTimezone(ctx context.Context, userID int, timezone string) error {
+	timezone = strings.TrimSpace(timezone)
+	if err := models.ValidateTimezone(timezone); err != nil {
+		return err
+	}
+	
+	if err := s.repos.User.UpdateTimezone(ctx, userID, timezone); err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+	
+	s.logger.Infof("Timezone of user %d set to %q", userID, timezone)
+	
+	return nil
+}
+
+// UpdateProfile replaces the personal details of a user: the name, phone, date of birth and
+// address. Credits require the phone, the date of birth and the address.
+func (s *UserSvc) UpdateProfile(ctx context.Context, userID int, profile *models.ProfileRequest) (*models.User, error) {
+	if err := profile.ValidateProfile(time.Now()); err != nil {
+		return nil, fmt.Errorf("invalid profile data: %w", err)
+	}
+	
+	user, err := s.repos.User.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	
+	profile.Apply(user)
+	
+	if err := s.repos.User.UpdateProfile(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	
+	s.logger.Infof("Profile of user %d updated", userID)
+	
+	// A complete profile passes the KYC stage of onboarding
+	s.lifecycle.Background("onboarding-progress", func(ctx context.Context) error {
+		if _, err := s.onboarding.Advance(ctx, userID); err != nil {
+			return fmt.Errorf("failed to advance onboarding: %w", err)
+		}
+		return nil
+	})
+	
+	// Don't expose the password hash
+	user.PassHash = ""
+	
+	return user, nil
+}
+
+// SetLanguage sets the language of the responses, notifications and emails of a user; an empty one
+// resets it to the language of each request
+func (s *UserSvc) SetLanguage(ctx context.Context, userID int, language string) error {
+	language = strings.TrimSpace(language)
+	if language != "" {
+		lang, ok := i18n.Parse(language)
+		if !ok {
+			return errors.New("invalid language, expected en or ru")
+		}
+		language = string(lang)
+	}
+	
+	if err := s.repos.User.UpdateLanguage(ctx, userID, language); err != nil {
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+	
+	s.logger.Infof("Language of user %d set to %q", userID, language)
+	
+	return nil
+}
+
+// rehashPassword stores a new Argon2id hash for the password. Failures are logged
+// and do not affect the login; the rehash is retried on the next login.
+func (s *UserSvc) rehashPassword(ctx context.Context, userID int, password string) {
+	hashedPassword, err := s.hasher.HashPassword(password)
+	if err != nil {
+		s.logger.Warnf("Failed to rehash password for user %d: %v", userID, err)
+		return
+	}
+	
+	if err := s.repos.User.UpdatePassword(ctx, userID, hashedPassword); err != nil {
+		s.logger.Warnf("Failed to store rehashed password for user %d: %v", userID, err)
+		return
+	}
+	
+	s.logger.Infof("Password hash upgraded for user %d", userID)
+}
+
+// newPasswordHasher creates the Argon2id hasher from the configuration
+func newPasswordHasher(cfg configs.PasswordConfig) *crypto.Argon2Hasher {
+	params := crypto.DefaultArgon2Params()
+	params.Memory = uint32(cfg.Memory)
+	params.Iterations = uint32(cfg.Iterations)
+	params.Parallelism = uint8(cfg.Parallelism)
+	
+	return crypto.NewArgon2Hasher(params)
+}
+
+// newPasswordResetToken generates a password reset token and the hash stored in its place
+func newPasswordResetToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	
+	token := hex.EncodeToString(b)
+	return token, hashPasswordResetToken(token), nil
+}
+
+// hashPasswordResetToken hashes a reset token for storage and lookup. Tokens are random, so a fast hash is enough.
+func hashPasswordResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// isFree tells from a user lookup whether the username or email it looked for is unused
+func isFree(_ *models.User, err error) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return true, nil
+	}
+	return false, fmt.Errorf("failed to check availability: %w", err)
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"banking-service/configs"
+	"banking-service/internal/models"
+	"banking-service/internal/repository"
+	"banking-service/pkg/parquet"
+	"banking-service/pkg/storage"
+)
+
+// warehouseExportLag leaves the latest changes to the next export, so rows written by database
+// transactions still in progress when an export starts are not skipped
+const warehouseExportLag = 5 * time.Minute
+
+// warehouseTable is a table exported to the warehouse: its Parquet columns and how to read a
+// batch of the rows changed in a period as values in column order
+type warehouseTable struct {
+	name    string
+	columns []parquet.Column
+	read    func(ctx context.Context, from, to time.Time, afterID, limit int) (rows [][]interface{}, lastID int, err error)
+}
+
+// WarehouseSvc is an implementation of the service.WarehouseService interface
+type WarehouseSvc struct {
+	repos   *repository.Repository
+	logger  *logrus.Logger
+	config  *configs.Config
+	storage storage.Storage
+}
+
+// NewWarehouseService creates a new WarehouseSvc
+func NewWarehouseService(deps Dependencies) *WarehouseSvc {
+	return &WarehouseSvc{
+		repos:   deps.Repos,
+		logger:  deps.Logger,
+		config:  deps.Config,
+		storage: deps.Storage,
+	}
+}
+
+// Export writes the transactions, credits and accounts created or changed since the previous
+// export to Parquet files in object storage and records them in the manifest. The first export of
+// a table, and the first after an incompatible schema change, is a full snapshot.
+func (s *WarehouseSvc) Export(ctx context.Context) error {
+	prefix := strings.Trim(s.config.WarehouseExport.Prefix, "/")
+
+	manifest, err := s.loadManifest(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	until := time.Now().Add(-warehouseExportLag).Truncate(time.Second)
+	for _, table := range s.tables() {
+		if err := s.exportTable(ctx, prefix, manifest, table, until); err != nil {
+			return fmt.Errorf("failed to export %s to the warehouse: %w", table.name, err)
+		}
+	}
+
+	return nil
+}
+
+// exportTable exports the rows of a table changed since its watermark and saves the manifest
+func (s *WarehouseSvc) exportTable(ctx context.Context, prefix string, manifest *models.WarehouseManifest, table warehouseTable, until time.Time) error {
+	columns := make([]models.WarehouseColumn, len(table.columns))
+	for i, column := range table.columns {
+		columns[i] = models.WarehouseColumn{Name: column.Name, Type: column.Type.String(), Nullable: column.Optional}
+	}
+
+	state, change := manifest.Table(table.name, columns)
+	switch change {
+	case models.WarehouseSchemaCompatible:
+		s.logger.Infof("Warehouse schema of %s changed compatibly, now version %d", table.name, state.SchemaVersion)
+	case models.WarehouseSchemaIncompatible:
+		s.logger.Warnf("Warehouse schema of %s changed incompatibly, exporting a full snapshot as generation %d", table.name, state.Generation)
+	}
+
+	var from time.Time
+	if state.Watermark != nil {
+		if !state.Watermark.Before(until) {
+			return nil
+		}
+		from = *state.Watermark
+	}
+
+	var buf bytes.Buffer
+	writer, err := parquet.NewWriter(&buf, table.columns, map[string]string{
+		"table":          table.name,
+		"generation":     strconv.Itoa(state.Generation),
+		"schema_version": strconv.Itoa(state.SchemaVersion),
+	})
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	afterID := 0
+	batchSize := s.config.WarehouseExport.RowGroupSize
+	for {
+		rows, lastID, err := table.read(ctx, from, until, afterID, batchSize)
+		if err != nil {
+			return err
+		}
+
+		if err := writer.WriteRowGroup(rows); err != nil {
+			return err
+		}
+		count += len(rows)
+		afterID = lastID
+
+		if len(rows) < batchSize {
+			break
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	exportedAt := time.Now()
+	if count > 0 {
+		key := models.WarehouseKey(prefix, table.name, state.Generation, exportedAt)
+		if err := s.storage.Put(ctx, key, buf.Bytes(), "application/vnd.apache.parquet"); err != nil {
+			return fmt.Errorf("failed to store %s: %w", key, err)
+		}
+
+		state.Partitions = append(state.Partitions, &models.WarehousePartition{
+			Key:           key,
+			Date:          exportedAt.Format("2006-01-02"),
+			Generation:    state.Generation,
+			SchemaVersion: state.SchemaVersion,
+			Snapshot:      state.Watermark == nil,
+			Rows:          count,
+			ChangedFrom:   state.Watermark,
+			ChangedTo:     until,
+			ExportedAt:    exportedAt,
+		})
+
+		s.logger.Infof("Warehouse export of %s stored as %s with %d rows", table.name, key, count)
+	}
+
+	state.Watermark = &until
+	manifest.UpdatedAt = exportedAt
+
+	return s.saveManifest(ctx, prefix, manifest)
+}
+
+// loadManifest reads the manifest, or starts one if nothing was exported yet
+func (s *WarehouseSvc) loadManifest(ctx context.Context, prefix string) (*models.WarehouseManifest, error) {
+	content, err := s.storage.Get(ctx, models.WarehouseManifestKey(prefix))
+	if errors.Is(err, storage.ErrNotFound) {
+		return models.NewWarehouseManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read warehouse manifest: %w", err)
+	}
+
+	manifest := models.NewWarehouseManifest()
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse warehouse manifest: %w", err)
+	}
+	if manifest.Tables == nil {
+		manifest.Tables = map[string]*models.WarehouseTable{}
+	}
+
+	return manifest, nil
+}
+
+// saveManifest writes the manifest
+func (s *WarehouseSvc) saveManifest(ctx context.Context, prefix string, manifest *models.WarehouseManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.Put(ctx, models.WarehouseManifestKey(prefix), content, "application/json"); err != nil {
+		return fmt.Errorf("failed to store warehouse manifest: %w", err)
+	}
+
+	return nil
+}
+
+// tables lists the exported tables. Columns may be added freely; removing, renaming or retyping
+// one starts a new generation of the table with a full snapshot.
+func (s *WarehouseSvc) tables() []warehouseTable {
+	return []warehouseTable{
+		{
+			name: models.WarehouseTableTransactions,
+			columns: []parquet.Column{
+				{Name: "id", Type: parquet.Int64},
+				{Name: "transaction_type", Type: parquet.String},
+				{Name: "source_account_id", Type: parquet.Int64, Optional: true},
+				{Name: "destination_account_id", Type: parquet.Int64, Optional: true},
+				{Name: "amount", Type: parquet.Decimal},
+				{Name: "currency", Type: parquet.String},
+				{Name: "description", Type: parquet.String, Optional: true},
+				{Name: "status", Type: parquet.String},
+				{Name: "card_id", Type: parquet.Int64, Optional: true},
+				{Name: "transaction_date", Type: parquet.Timestamp},
+				{Name: "created_at", Type: parquet.Timestamp},
+				{Name: "decline_reason", Type: parquet.String, Optional: true},
+			},
+			read: func(ctx context.Context, from, to time.Time, afterID, limit int) ([][]interface{}, int, error) {
+				transactions, err := s.repos.Transaction.GetChanged(ctx, from, to, afterID, limit)
+				if err != nil || len(transactions) == 0 {
+					return nil, afterID, err
+				}
+
+				rows := make([][]interface{}, len(transactions))
+				for i, t := range transactions {
+					rows[i] = []interface{}{
+						t.ID, string(t.TransactionType), optionalID(t.SourceAccountID), optionalID(t.DestinationAccountID),
+						t.Amount, string(t.Currency), optionalString(t.Description), string(t.Status), optionalID(t.CardID),
+						t.TransactionDate, t.CreatedAt, optionalString(string(t.DeclineReason)),
+					}
+				}
+				return rows, transactions[len(transactions)-1].ID, nil
+			},
+		},
+		{
+			name: models.WarehouseTableCredits,
+			columns: []parquet.Column{
+				{Name: "id", Type: parquet.Int64},
+				{Name: "user_id", Type: parquet.Int64},
+				{Name: "account_id", Type: parquet.Int64},
+				{Name: "amount", Type: parquet.Decimal},
+				{Name: "interest_rate", Type: parquet.Decimal},
+				{Name: "term_months", Type: parquet.Int64},
+				{Name: "monthly_payment", Type: parquet.Decimal},
+				{Name: "start_date", Type: parquet.Date},
+				{Name: "end_date", Type: parquet.Date},
+				{Name: "status", Type: parquet.String},
+				{Name: "day_count", Type: parquet.String},
+				{Name: "created_at", Type: parquet.Timestamp},
+				{Name: "updated_at", Type: parquet.Timestamp},
+			},
+			read: func(ctx context.Context, from, to time.Time, afterID, limit int) ([][]interface{}, int, error) {
+				credits, err := s.repos.Credit.GetChanged(ctx, from, to, afterID, limit)
+				if err != nil || len(credits) == 0 {
+					return nil, afterID, err
+				}
+
+				rows := make([][]interface{}, len(credits))
+				for i, c := range credits {
+					rows[i] = []interface{}{
+						c.ID, c.UserID, c.AccountID, c.Amount, c.InterestRate, c.TermMonths, c.MonthlyPayment,
+						c.StartDate, c.EndDate, string(c.Status), string(c.DayCount), c.CreatedAt, c.UpdatedAt,
+					}
+				}
+				return rows, credits[len(credits)-1].ID, nil
+			},
+		},
+		{
+			name: models.WarehouseTableAccounts,
+			columns: []parquet.Column{
+				{Name: "id", Type: parquet.Int64},
+				{Name: "user_id", Type: parquet.Int64},
+				{Name: "organization_id", Type: parquet.Int64, Optional: true},
+				{Name: "currency", Type: parquet.String},
+				{Name: "account_type", Type: parquet.String},
+				{Name: "balance", Type: parquet.Decimal},
+				{Name: "is_active", Type: parquet.Boolean},
+				{Name: "is_default", Type: parquet.Boolean},
+				{Name: "dormant_since", Type: parquet.Timestamp, Optional: true},
+				{Name: "created_at", Type: parquet.Timestamp},
+				{Name: "updated_at", Type: parquet.Timestamp},
+			},
+			read: func(ctx context.Context, from, to time.Time, afterID, limit int) ([][]interface{}, int, error) {
+				accounts, err := s.repos.Account.GetChanged(ctx, from, to, afterID, limit)
+				if err != nil || len(accounts) == 0 {
+					return nil, afterID, err
+				}
+
+				rows := make([][]interface{}, len(accounts))
+				for i, a := range accounts {
+					var dormantSince interface{}
+					if a.DormantSince != nil {
+						dormantSince = *a.DormantSince
+					}
+					rows[i] = []interface{}{
+						a.ID, a.UserID, optionalID(a.OrganizationID), string(a.Currency), string(a.AccountType),
+						a.Balance, a.IsActive, a.IsDefault, dormantSince, a.CreatedAt, a.UpdatedAt,
+					}
+				}
+				return rows, accounts[len(accounts)-1].ID, nil
+			},
+		},
+	}
+}
+
+// optionalID returns an optional ID as a Parquet value, nil if it is not set
+func optionalID(id *int) interface{} {
+	if id == nil {
+		return nil
+	}
+	return *id
+}
+
+// optionalString returns a string as a Parquet value, nil if it is empty
+func optionalString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
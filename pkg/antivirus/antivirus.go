@@ -0,0 +1,32 @@
+package antivirus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Supported scanners
+const (
+	ProviderClamAV = "clamav"
+)
+
+// ErrInfected is returned when a scanner finds malware in the content
+var ErrInfected = errors.New("malware detected")
+
+// Scanner checks uploaded content for malware before it is stored
+type Scanner interface {
+	Scan(ctx context.Context, content []byte) error
+}
+
+// NewScanner creates a Scanner for the given provider
+func NewScanner(provider, address string, timeout time.Duration) (Scanner, error) {
+	switch strings.ToLower(provider) {
+	case ProviderClamAV:
+		return newClamdScanner(address, timeout)
+	default:
+		return nil, fmt.Errorf("unsupported antivirus provider %q", provider)
+	}
+}
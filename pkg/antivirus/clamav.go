@@ -0,0 +1,88 @@
+package antivirus
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize stays well below clamd's default StreamMaxLength chunking
+const clamdChunkSize = 64 * 1024
+
+// clamdScanner streams content to a clamd daemon with the INSTREAM command
+type clamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// newClamdScanner creates a new clamdScanner; the address is host:port or a unix socket path
+func newClamdScanner(address string, timeout time.Duration) (*clamdScanner, error) {
+	if address == "" {
+		return nil, errors.New("clamav scanner requires the clamd address")
+	}
+
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+
+	return &clamdScanner{network: network, address: address, timeout: timeout}, nil
+}
+
+// Scan sends the content to clamd and returns ErrInfected when a signature matches
+func (s *clamdScanner) Scan(ctx context.Context, content []byte) error {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("failed to send clamd command: %w", err)
+	}
+
+	for offset := 0; offset < len(content); offset += clamdChunkSize {
+		end := offset + clamdChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		chunk := binary.BigEndian.AppendUint32(nil, uint32(end-offset))
+		if _, err := conn.Write(append(chunk, content[offset:end]...)); err != nil {
+			return fmt.Errorf("failed to stream content to clamd: %w", err)
+		}
+	}
+
+	// A zero-length chunk ends the stream
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to stream content to clamd: %w", err)
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+
+	result := strings.TrimSpace(string(bytes.TrimRight(reply, "\x00")))
+	switch {
+	case strings.HasSuffix(result, "FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(result, "stream: "), " FOUND")
+		return fmt.Errorf("%w: %s", ErrInfected, signature)
+	case strings.HasSuffix(result, "OK"):
+		return nil
+	default:
+		return fmt.Errorf("unexpected clamd reply %q", result)
+	}
+}
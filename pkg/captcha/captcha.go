@@ -0,0 +1,126 @@
+package captcha
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// Supported CAPTCHA providers
+const (
+	ProviderReCaptcha = "recaptcha"
+	ProviderHCaptcha  = "hcaptcha"
+)
+
+// Verification endpoints of the supported providers
+const (
+	reCaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+	hCaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+)
+
+// ErrMissingToken is returned when no CAPTCHA token was supplied
+var ErrMissingToken = errors.New("captcha token is required")
+
+// ErrInvalidToken is returned when the provider rejects the CAPTCHA token
+var ErrInvalidToken = errors.New("captcha verification failed")
+
+// Verifier checks a CAPTCHA token solved by the client
+type Verifier interface {
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// NewVerifier creates a Verifier for the given provider. Tokens are verified with client, each
+// verification taking at most timeout including the client's retries.
+func NewVerifier(provider, secret string, timeout time.Duration, client httpclient.Doer) (Verifier, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	switch strings.ToLower(provider) {
+	case ProviderReCaptcha:
+		return newSiteVerifier(reCaptchaVerifyURL, secret, timeout, client), nil
+	case ProviderHCaptcha:
+		return newSiteVerifier(hCaptchaVerifyURL, secret, timeout, client), nil
+	default:
+		return nil, fmt.Errorf("unsupported captcha provider %q", provider)
+	}
+}
+
+// siteVerifier implements the siteverify API shared by reCAPTCHA and hCaptcha
+type siteVerifier struct {
+	verifyURL string
+	secret    string
+	timeout   time.Duration
+	client    httpclient.Doer
+}
+
+// siteVerifyResponse is the response of the siteverify API
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// newSiteVerifier creates a new siteVerifier
+func newSiteVerifier(verifyURL, secret string, timeout time.Duration, client httpclient.Doer) *siteVerifier {
+	return &siteVerifier{
+		verifyURL: verifyURL,
+		secret:    secret,
+		timeout:   timeout,
+		client:    client,
+	}
+}
+
+// Verify sends the token to the provider and checks the result
+func (v *siteVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrMissingToken
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha provider returned status %d", resp.StatusCode)
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+
+	if !result.Success {
+		if len(result.ErrorCodes) > 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidToken, strings.Join(result.ErrorCodes, ", "))
+		}
+		return ErrInvalidToken
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package captcha
+
+import (
+	"sync"
+	"time"
+)
+
+// FailureTracker counts recent failed attempts per key (client IP, login name) so that
+// a CAPTCHA is only demanded once a key has failed too often within the window
+type FailureTracker struct {
+	mu        sync.Mutex
+	threshold int
+	window    time.Duration
+	failures  map[string]*failureEntry
+	lastSweep time.Time
+}
+
+// failureEntry holds the failures of a single key within the current window
+type failureEntry struct {
+	count int
+	first time.Time
+}
+
+// NewFailureTracker creates a new FailureTracker
+func NewFailureTracker(threshold int, window time.Duration) *FailureTracker {
+	return &FailureTracker{
+		threshold: threshold,
+		window:    window,
+		failures:  make(map[string]*failureEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Required reports whether any of the keys reached the failure threshold
+func (t *FailureTracker) Required(keys ...string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		if entry, ok := t.failures[key]; ok && now.Sub(entry.first) <= t.window && entry.count >= t.threshold {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Fail records a failed attempt for every key
+func (t *FailureTracker) Fail(keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.sweep(now)
+
+	for _, key := range keys {
+		entry, ok := t.failures[key]
+		if !ok || now.Sub(entry.first) > t.window {
+			entry = &failureEntry{first: now}
+			t.failures[key] = entry
+		}
+		entry.count++
+	}
+}
+
+// Reset forgets the failures of the keys after a successful attempt
+func (t *FailureTracker) Reset(keys ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, key := range keys {
+		delete(t.failures, key)
+	}
+}
+
+// sweep evicts entries whose window has passed
+func (t *FailureTracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < time.Minute {
+		return
+	}
+	t.lastSweep = now
+
+	for key, entry := range t.failures {
+		if now.Sub(entry.first) > t.window {
+			delete(t.failures, key)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package cbr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// Supported key rate providers
+const (
+	ProviderSOAP = "soap" // KeyRate method of the CBR DailyInfo web service
+	ProviderJSON = "json" // HTTP endpoint returning {"key_rate": 16.0}
+)
+
+// KeyRateProvider gets the current key rate of the Central Bank of Russia, in percent
+type KeyRateProvider interface {
+	KeyRate(ctx context.Context) (float64, error)
+}
+
+// Options holds the endpoints of the key rate providers and the client they are called with
+type Options struct {
+	SOAPURL string // CBR DailyInfo web service
+	JSONURL string
+	Timeout time.Duration // per provider request, including the client's retries
+	Client  httpclient.Doer
+}
+
+// NewKeyRateProvider creates a KeyRateProvider that tries the given providers in order and
+// returns the first rate one of them gets
+func NewKeyRateProvider(providers []string, opts Options) (KeyRateProvider, error) {
+	if len(providers) == 0 {
+		return nil, errors.New("at least one key rate provider is required")
+	}
+
+	var chain fallbackProvider
+	for _, name := range providers {
+		provider, err := newProvider(name, opts)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, provider)
+	}
+
+	if len(chain) == 1 {
+		return chain[0], nil
+	}
+
+	return chain, nil
+}
+
+// newProvider creates a single KeyRateProvider
+func newProvider(name string, opts Options) (KeyRateProvider, error) {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	switch strings.ToLower(name) {
+	case ProviderSOAP:
+		if opts.SOAPURL == "" {
+			return nil, errors.New("soap key rate provider requires the CBR API URL")
+		}
+		return newSOAPProvider(opts.SOAPURL, opts.Timeout, opts.Client), nil
+	case ProviderJSON:
+		if opts.JSONURL == "" {
+			return nil, errors.New("json key rate provider requires its URL")
+		}
+		return newJSONProvider(opts.JSONURL, opts.Timeout, opts.Client), nil
+	default:
+		return nil, fmt.Errorf("unsupported key rate provider %q", name)
+	}
+}
+
+// fallbackProvider asks each provider in turn until one succeeds
+type fallbackProvider []KeyRateProvider
+
+// KeyRate returns the rate of the first provider that succeeds, or all their errors
+func (f fallbackProvider) KeyRate(ctx context.Context) (float64, error) {
+	var errs []error
+	for _, provider := range f {
+		rate, err := provider.KeyRate(ctx)
+		if err == nil {
+			return rate, nil
+		}
+		errs = append(errs, err)
+	}
+
+	return 0, errors.Join(errs...)
+}
+
+// validateRate rejects values that cannot be a key rate
+func validateRate(rate float64) (float64, error) {
+	if rate <= 0 || rate >= 100 {
+		return 0, fmt.Errorf("implausible key rate %v", rate)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,64 @@
+package cbr
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// jsonProvider gets the key rate from an HTTP endpoint, e.g. an internal rates service or a
+// mirror of the CBR data, that responds with {"key_rate": 16.0}
+type jsonProvider struct {
+	url     string
+	timeout time.Duration
+	client  httpclient.Doer
+}
+
+// jsonKeyRateResponse is the response of the JSON endpoint
+type jsonKeyRateResponse struct {
+	KeyRate *float64 `json:"key_rate"`
+}
+
+// newJSONProvider creates a new jsonProvider
+func newJSONProvider(url string, timeout time.Duration, client httpclient.Doer) *jsonProvider {
+	return &jsonProvider{url: url, timeout: timeout, client: client}
+}
+
+// KeyRate requests the key rate from the endpoint
+func (p *jsonProvider) KeyRate(ctx context.Context) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to request key rate: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("key rate request failed with status %d", resp.StatusCode)
+	}
+
+	var result jsonKeyRateResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to decode key rate response: %w", err)
+	}
+
+	if result.KeyRate == nil {
+		return 0, errors.New("key_rate missing in response")
+	}
+
+	return validateRate(*result.KeyRate)
+}
@@ -0,0 +1,110 @@
+package cbr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+
+	"banking-service/pkg/httpclient"
+)
+
+// keyRateLookback is how far back the KeyRate method is asked, so the response contains the
+// rate in effect even after weekends and holidays, when no new value is published
+const keyRateLookback = 14 * 24 * time.Hour
+
+// soapProvider calls the KeyRate method of the CBR DailyInfo web service
+type soapProvider struct {
+	url     string
+	timeout time.Duration
+	client  httpclient.Doer
+}
+
+// newSOAPProvider creates a new soapProvider
+func newSOAPProvider(url string, timeout time.Duration, client httpclient.Doer) *soapProvider {
+	return &soapProvider{url: url, timeout: timeout, client: client}
+}
+
+// KeyRate requests the key rates of the last days and returns the latest one
+func (p *soapProvider) KeyRate(ctx context.Context) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	now := time.Now()
+	envelope := `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:web="http://web.cbr.ru/">
+	<soap:Body>
+		<web:KeyRate>
+			<web:fromDate>` + now.Add(-keyRateLookback).Format("2006-01-02") + `</web:fromDate>
+			<web:ToDate>` + now.Format("2006-01-02") + `</web:ToDate>
+		</web:KeyRate>
+	</soap:Body>
+</soap:Envelope>`
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, strings.NewReader(envelope))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", "http://web.cbr.ru/KeyRate")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send KeyRate request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("KeyRate request failed with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	return parseKeyRateResponse(body)
+}
+
+// parseKeyRateResponse finds the latest of the KR rows (DT date, Rate value) in a KeyRate response
+func parseKeyRateResponse(body []byte) (float64, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(body); err != nil {
+		return 0, fmt.Errorf("failed to parse KeyRate response: %w", err)
+	}
+
+	var latest time.Time
+	var rate float64
+	for _, row := range doc.FindElements("//KR") {
+		dateElem, rateElem := row.SelectElement("DT"), row.SelectElement("Rate")
+		if dateElem == nil || rateElem == nil {
+			continue
+		}
+
+		date, err := time.Parse(time.RFC3339, strings.TrimSpace(dateElem.Text()))
+		if err != nil {
+			return 0, fmt.Errorf("invalid key rate date %q", dateElem.Text())
+		}
+
+		if date.After(latest) {
+			value, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(rateElem.Text()), ",", ".", 1), 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid key rate value %q", rateElem.Text())
+			}
+			latest, rate = date, value
+		}
+	}
+
+	if latest.IsZero() {
+		return 0, errors.New("key rate not found in KeyRate response")
+	}
+
+	return validateRate(rate)
+}
@@ -0,0 +1,101 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWT signing algorithms
+const (
+	JWTAlgorithmHS256 = "HS256" // HMAC with the shared secret
+	JWTAlgorithmRS256 = "RS256" // RSA private key
+	JWTAlgorithmEdDSA = "EdDSA" // Ed25519 private key
+)
+
+// JWTKeys signs and verifies JWT tokens. Tokens are signed with the shared HMAC secret or with an
+// RSA or Ed25519 private key, so other services can verify them with the public key alone.
+// Verification only accepts tokens of the configured algorithm. After switching to a key pair,
+// HMAC tokens issued before can be accepted until a deadline, so sessions survive the switch.
+type JWTKeys struct {
+	method          jwt.SigningMethod
+	signingKey      interface{}
+	secret          []byte
+	publicKey       interface{} // nil with HS256
+	legacyHMACUntil time.Time   // zero rejects HMAC tokens with a key pair
+}
+
+// NewJWTKeys creates the JWT keys of an algorithm. RS256 and EdDSA sign with the PEM private key
+// in privateKeyFile: PKCS#1 or PKCS#8 for RSA, PKCS#8 for Ed25519. With them, HMAC tokens are
+// still accepted until legacyHMACUntil.
+func NewJWTKeys(algorithm string, secret []byte, privateKeyFile string, legacyHMACUntil time.Time) (*JWTKeys, error) {
+	keys := &JWTKeys{secret: secret, legacyHMACUntil: legacyHMACUntil}
+
+	if algorithm == JWTAlgorithmHS256 {
+		keys.method = jwt.SigningMethodHS256
+		keys.signingKey = secret
+		return keys, nil
+	}
+
+	if algorithm != JWTAlgorithmRS256 && algorithm != JWTAlgorithmEdDSA {
+		return nil, fmt.Errorf("unknown JWT algorithm %q, expected HS256, RS256 or EdDSA", algorithm)
+	}
+
+	pem, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT private key: %w", err)
+	}
+
+	if algorithm == JWTAlgorithmRS256 {
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA private key: %w", err)
+		}
+		if key.N.BitLen() < 2048 {
+			return nil, errors.New("RSA private key must be at least 2048 bits")
+		}
+		keys.method = jwt.SigningMethodRS256
+		keys.signingKey = key
+		keys.publicKey = &key.PublicKey
+		return keys, nil
+	}
+
+	key, err := jwt.ParseEdPrivateKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ed25519 private key: %w", err)
+	}
+	edKey := key.(ed25519.PrivateKey)
+	keys.method = jwt.SigningMethodEdDSA
+	keys.signingKey = edKey
+	keys.publicKey = edKey.Public()
+
+	return keys, nil
+}
+
+// Sign signs a token with the claims
+func (k *JWTKeys) Sign(claims jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(k.method, claims).SignedString(k.signingKey)
+}
+
+// Parse parses a token and verifies its signature: an HMAC one with the secret, an RSA or Ed25519
+// one with the public key of the configured key pair
+func (k *JWTKeys) Parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if k.publicKey == nil || time.Now().Before(k.legacyHMACUntil) {
+				return k.secret, nil
+			}
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodEd25519:
+			if k.publicKey != nil && token.Method.Alg() == k.method.Alg() {
+				return k.publicKey, nil
+			}
+		}
+
+		return nil, errors.New("unexpected signing method")
+	})
+}
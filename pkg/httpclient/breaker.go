@@ -0,0 +1,160 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the state of the circuit breaker of a host
+type State string
+
+const (
+	StateClosed   State = "closed"    // requests are sent
+	StateOpen     State = "open"      // requests are rejected until the cooldown passes
+	StateHalfOpen State = "half_open" // a single trial request decides whether to close or reopen
+)
+
+// HostStats are the counters of the requests sent to a host since the start
+type HostStats struct {
+	Host                string     `json:"host"`
+	State               State      `json:"state"`
+	Requests            int64      `json:"requests"` // calls of Do
+	Attempts            int64      `json:"attempts"` // requests sent, including retries
+	Retries             int64      `json:"retries"`
+	Failures            int64      `json:"failures"`             // attempts failed with a network error, 429 or 5xx
+	Rejected            int64      `json:"rejected"`             // calls not sent because the breaker was open
+	ConsecutiveFailures int        `json:"consecutive_failures"` // since the last success
+	AvgLatencyMs        float64    `json:"avg_latency_ms"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+}
+
+// host holds the circuit breaker and the counters of a host
+type host struct {
+	mu sync.Mutex
+
+	name     string
+	state    State
+	openedAt time.Time
+	probing  bool // a trial request of the half-open breaker is in flight
+
+	requests, attempts, retries, failures, rejected int64
+	consecutive                                     int
+	latency                                         time.Duration
+	lastError                                       string
+	lastFailureAt                                   time.Time
+}
+
+// start counts a call of Do
+func (h *host) start() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requests++
+}
+
+// allow reports whether an attempt may be sent, moving an open breaker to half-open once the
+// cooldown has passed
+func (h *host) allow(cooldown time.Duration) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.state == StateOpen && time.Since(h.openedAt) >= cooldown {
+		h.state = StateHalfOpen
+	}
+
+	switch h.state {
+	case StateOpen:
+		h.rejected++
+		return false
+	case StateHalfOpen:
+		if h.probing {
+			h.rejected++
+			return false
+		}
+		h.probing = true
+	}
+
+	h.attempts++
+	return true
+}
+
+// success records an attempt that got a response, closing the breaker
+func (h *host) success(elapsed time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latency += elapsed
+	h.consecutive = 0
+	h.probing = false
+	h.state = StateClosed
+}
+
+// failure records a failed attempt, opening the breaker after threshold consecutive failures or
+// when the trial request of a half-open breaker fails
+func (h *host) failure(threshold int, elapsed time.Duration, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.latency += elapsed
+	h.failures++
+	h.consecutive++
+	h.lastError = reason
+	h.lastFailureAt = time.Now()
+
+	if h.state == StateHalfOpen || h.consecutive >= threshold {
+		h.state = StateOpen
+		h.openedAt = h.lastFailureAt
+	}
+	h.probing = false
+}
+
+// release forgets an attempt that was not sent or was canceled by the caller, so it counts
+// neither for nor against the host
+func (h *host) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.attempts--
+	h.probing = false
+}
+
+// retry counts a repeated attempt
+func (h *host) retry() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.retries++
+}
+
+// snapshot copies the counters of the host
+func (h *host) snapshot() HostStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats := HostStats{
+		Host:                h.name,
+		State:               h.state,
+		Requests:            h.requests,
+		Attempts:            h.attempts,
+		Retries:             h.retries,
+		Failures:            h.failures,
+		Rejected:            h.rejected,
+		ConsecutiveFailures: h.consecutive,
+		LastError:           h.lastError,
+	}
+	if h.attempts > 0 {
+		stats.AvgLatencyMs = float64(h.latency.Microseconds()) / float64(h.attempts) / 1000
+	}
+	if !h.lastFailureAt.IsZero() {
+		at := h.lastFailureAt
+		stats.LastFailureAt = &at
+	}
+	if h.state == StateOpen {
+		at := h.openedAt
+		stats.OpenedAt = &at
+	}
+
+	return stats
+}
@@ -0,0 +1,200 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit breaker of its host is open
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Doer sends HTTP requests; both *http.Client and *Client implement it
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Options configures a Client
+type Options struct {
+	Timeout          time.Duration // per attempt
+	MaxAttempts      int           // attempts per request, including the first one
+	BaseDelay        time.Duration // backoff before the first retry, doubled on every next one
+	MaxDelay         time.Duration // cap of the backoff and of a Retry-After the server asks for
+	BreakerThreshold int           // consecutive failures that open the breaker of a host
+	BreakerCooldown  time.Duration // how long an open breaker rejects requests before letting a trial one through
+}
+
+// Client is the HTTP client shared by the outbound integrations. It retries network errors,
+// 429 and 5xx responses with jittered exponential backoff, and stops calling a host that keeps
+// failing until its circuit breaker lets a trial request through. Requests are retried only
+// when their body can be replayed, so the endpoints called must be safe to repeat.
+type Client struct {
+	opts   Options
+	client *http.Client
+
+	mu    sync.Mutex
+	hosts map[string]*host
+}
+
+// New creates a new Client
+func New(opts Options) *Client {
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	if opts.BreakerThreshold < 1 {
+		opts.BreakerThreshold = 1
+	}
+
+	return &Client{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		hosts:  make(map[string]*host),
+	}
+}
+
+// Do sends a request, retrying it while the attempts last. The response of the last attempt is
+// returned even if it has a retryable status, so the caller sees what the server answered.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	h := c.host(req.URL.Host)
+	h.start()
+
+	for attempt := 1; ; attempt++ {
+		if !h.allow(c.opts.BreakerCooldown) {
+			return nil, fmt.Errorf("%s: %w", req.URL.Host, ErrCircuitOpen)
+		}
+		if attempt > 1 {
+			h.retry()
+		}
+
+		attemptReq, err := rewind(req, attempt)
+		if err != nil {
+			h.release()
+			return nil, err
+		}
+
+		started := time.Now()
+		resp, err := c.client.Do(attemptReq)
+		elapsed := time.Since(started)
+
+		switch {
+		case err != nil && errors.Is(err, context.Canceled):
+			h.release()
+			return nil, err
+		case err != nil:
+			h.failure(c.opts.BreakerThreshold, elapsed, err.Error())
+		case retryableStatus(resp.StatusCode):
+			h.failure(c.opts.BreakerThreshold, elapsed, resp.Status)
+		default:
+			h.success(elapsed)
+			return resp, nil
+		}
+
+		if attempt >= c.opts.MaxAttempts || !replayable(req) {
+			return resp, err
+		}
+
+		delay := c.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Stats returns the counters of every host called so far, sorted by host
+func (c *Client) Stats() []HostStats {
+	c.mu.Lock()
+	hosts := make([]*host, 0, len(c.hosts))
+	for _, h := range c.hosts {
+		hosts = append(hosts, h)
+	}
+	c.mu.Unlock()
+
+	stats := make([]HostStats, 0, len(hosts))
+	for _, h := range hosts {
+		stats = append(stats, h.snapshot())
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Host < stats[j].Host })
+
+	return stats
+}
+
+// host gets the breaker and counters of a host, creating them on the first request
+func (c *Client) host(name string) *host {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	h, ok := c.hosts[name]
+	if !ok {
+		h = &host{name: name, state: StateClosed}
+		c.hosts[name] = h
+	}
+
+	return h
+}
+
+// backoff returns how long to wait before the next attempt: the Retry-After the server asked for
+// if it is within MaxDelay, otherwise an exponential delay with equal jitter
+func (c *Client) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if delay := time.Duration(seconds) * time.Second; delay <= c.opts.MaxDelay {
+				return delay
+			}
+		}
+	}
+
+	delay := c.opts.BaseDelay << (attempt - 1)
+	if delay > c.opts.MaxDelay || delay <= 0 {
+		delay = c.opts.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// rewind returns the request to send on an attempt, with a fresh copy of the body on retries
+func rewind(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewind request body: %w", err)
+	}
+
+	retry := req.Clone(req.Context())
+	retry.Body = body
+
+	return retry, nil
+}
+
+// replayable reports whether the body of a request can be sent again
+func replayable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// retryableStatus reports whether a response status is worth another attempt
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// catalogs holds the messages and templates of every supported language
+var catalogs = map[Language][]map[string]string{
+	English: append(messagesEN, notificationsEN, emailsEN, pagesEN),
+	Russian: append(messagesRU, notificationsRU, emailsRU, pagesRU),
+}
+
+// codes maps English API messages to their codes
+var codes = func() map[string]string {
+	index := make(map[string]string)
+	for _, catalog := range messagesEN {
+		for code, message := range catalog {
+			index[message] = code
+		}
+	}
+	return index
+}()
+
+// Message is an English API message passed as a Sprintf argument; it is translated together with
+// the template, like the reason of a declined payment in its notification
+type Message string
+
+// Text returns the catalog entry of a key, falling back to English and then to the key itself
+func Text(lang Language, key string) string {
+	if text, ok := lookup(lang, key); ok {
+		return text
+	}
+	if text, ok := lookup(English, key); ok {
+		return text
+	}
+	return key
+}
+
+// Sprintf formats the template of a key in the language
+func Sprintf(lang Language, key string, args ...interface{}) string {
+	translated := make([]interface{}, len(args))
+	for i, arg := range args {
+		if message, ok := arg.(Message); ok {
+			arg = Translate(lang, string(message))
+		}
+		translated[i] = arg
+	}
+	return fmt.Sprintf(Text(lang, key), translated...)
+}
+
+// Code returns the code of an English API message. Wrapped errors like "invalid card data: card
+// has expired" get the code of their innermost known cause.
+func Code(message string) (string, bool) {
+	if code, ok := codes[message]; ok {
+		return code, true
+	}
+
+	code, found := "", false
+	eachPart(message, func(part string, known bool) {
+		if known {
+			code, found = codes[part], true
+		}
+	})
+
+	return code, found
+}
+
+// Translate translates an English API message. Wrapped errors are translated part by part; parts
+// missing from the catalog, like errors of other systems, stay in English.
+func Translate(lang Language, message string) string {
+	if code, ok := codes[message]; ok {
+		return Text(lang, code)
+	}
+
+	var parts []string
+	eachPart(message, func(part string, known bool) {
+		if known {
+			part = Text(lang, codes[part])
+		}
+		parts = append(parts, part)
+	})
+
+	return strings.Join(parts, ": ")
+}
+
+// eachPart splits a wrapped error message at its ": " separators into the longest runs of parts
+// that are catalog messages, like "access denied: insufficient role", and the unknown parts between
+func eachPart(message string, fn func(part string, known bool)) {
+	parts := strings.Split(message, ": ")
+	for i := 0; i < len(parts); {
+		j := len(parts)
+		for ; j > i; j-- {
+			if _, ok := codes[strings.Join(parts[i:j], ": ")]; ok {
+				break
+			}
+		}
+
+		if j == i {
+			fn(parts[i], false)
+			i++
+			continue
+		}
+
+		fn(strings.Join(parts[i:j], ": "), true)
+		i = j
+	}
+}
+
+// lookup finds a key in the catalog of a language
+func lookup(lang Language, key string) (string, bool) {
+	for _, catalog := range catalogs[lang] {
+		if text, ok := catalog[key]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,399 @@
+package i18n
+
+// emailsEN are the English email templates. Bodies are HTML; {{brand}} is replaced with the name
+// of the tenant's bank when the email is sent.
+var emailsEN = map[string]string{
+	"email.support": `<p>Support: <a href="mailto:%[1]s">%[1]s</a></p>`,
+
+	"email.transaction.deposit":    "Deposit",
+	"email.transaction.withdrawal": "Withdrawal",
+	"email.transaction.payment":    "Payment",
+	"email.transaction.transfer":   "Transfer",
+	"email.transaction.subject":    "%s Notification: %s",
+	"email.transaction.body": `
+	<h2>Transaction Notification</h2>
+	<p>Dear %s %s,</p>
+
+	<p>We are informing you about a recent transaction on your account:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Transaction Type:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Account:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Balance:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f %s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Date:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Description:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>If you did not authorize this transaction, please contact our support immediately.</p>
+
+	<p>Thank you for using our banking services.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.payment_reminder.subject_overdue":  "OVERDUE Payment Reminder: Credit #%d",
+	"email.payment_reminder.subject_upcoming": "Upcoming Payment Reminder: Credit #%d",
+	"email.payment_reminder.overdue": `
+		<p style="color: red; font-weight: bold;">
+			This payment is OVERDUE by %d days. A penalty of %.2f RUB has been applied.
+		</p>
+		`,
+	"email.payment_reminder.upcoming": `
+		<p>
+			This payment is due in %d days. Please ensure you have sufficient funds in your account.
+		</p>
+		`,
+	"email.payment_reminder.body": `
+	<h2>Credit Payment Reminder</h2>
+	<p>Dear %s %s,</p>
+
+	%s
+
+	<p>Here are the details of your credit payment:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit ID:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Payment Date:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Principal Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Interest Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Penalty Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Total Amount Due:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Account Number:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Account Balance:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>Please ensure you have sufficient funds in your account to cover this payment.</p>
+
+	<p>Thank you for using our banking services.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.credit_approval.no_schedule": "See your payment schedule for details",
+	"email.credit_approval.subject":     "Credit Approved: %.2f RUB",
+	"email.credit_approval.body": `
+	<h2>Credit Approval Notification</h2>
+	<p>Dear %s %s,</p>
+
+	<p>We are pleased to inform you that your credit application has been approved!</p>
+
+	<p>Here are the details of your new credit:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit ID:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Interest Rate:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f%%</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Term:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d months</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Monthly Payment:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>First Payment Date:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Credit Account:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Current Account Balance:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>The approved amount has been deposited to your credit account. You can view your payment schedule in your online banking portal.</p>
+
+	<p>Thank you for choosing our banking services.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.new_device.subject": "Security Alert: New Device Login",
+	"email.new_device.body": `
+	<h2>New Device Login</h2>
+	<p>Dear %s %s,</p>
+
+	<p>Your account was just accessed from a device we have not seen before:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Date:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>IP Address:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Location:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Device:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>If this was you, no action is needed.</p>
+
+	<p>If you do not recognize this login, <a href="%s">end this session immediately</a> and change your password.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.transfer_code.subject": "Transfer Confirmation Code",
+	"email.transfer_code.body": `
+	<h2>Confirm Your Transfer</h2>
+	<p>Dear %s %s,</p>
+
+	<p>Use the code below to confirm your transfer:</p>
+
+	<p style="font-size: 24px; font-weight: bold; letter-spacing: 4px;">%s</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Amount:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>To Account:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Valid Until:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>If you did not request this transfer, do not share this code and contact our support immediately.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.password_changed.subject": "Your Password Has Been Changed",
+	"email.password_changed.body": `
+	<h2>Password Changed</h2>
+	<p>Dear %s %s,</p>
+
+	<p>The password for your account was changed on %s. All other devices have been signed out.</p>
+
+	<p>If you did not make this change, please contact our support immediately.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.email_verification.subject": "Confirm Your Email Address",
+	"email.email_verification.body": `
+	<h2>Confirm Your Email Address</h2>
+	<p>Dear %s %s,</p>
+
+	<p>Please <a href="%s">confirm that this is your email address</a> before %s to continue setting up your account.</p>
+
+	<p>If you did not sign up with us, ignore this email.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.password_reset.subject": "Reset Your Password",
+	"email.password_reset.body": `
+	<h2>Password Reset</h2>
+	<p>Dear %s %s,</p>
+
+	<p>We received a request to reset the password for your account. <a href="%s">Set a new password</a> before %s; the link can be used only once.</p>
+
+	<p>If you did not ask to reset your password, ignore this email - your password stays the same.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.organization_invitation.subject": "Invitation to Join %s",
+	"email.organization_invitation.body": `
+	<h2>You Have Been Invited</h2>
+	<p>Hello,</p>
+
+	<p>%s %s has invited you to join <strong>%s</strong> on {{brand}}.</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Role:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Valid Until:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Sign in with this email address (or register with it) and accept the invitation in your organization invitations.</p>
+
+	<p>If you were not expecting this invitation, you can ignore this email.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.tax_document.subject": "Your Interest Certificate for %d",
+	"email.tax_document.body": `
+	<h2>Interest Certificate for %d</h2>
+	<p>Dear %s %s,</p>
+
+	<p>Your yearly summary of interest for tax purposes is attached.</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Interest Earned:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Loan Interest Paid:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Loan Penalties Paid:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>You can download this document at any time in the tax documents section.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.new_message.subject": "You Have a New Message",
+	"email.new_message.body": `
+	<h2>New Secure Message</h2>
+	<p>Dear %s %s,</p>
+
+	<p>The bank has sent you a message about <strong>%s</strong>.</p>
+
+	<p>For your security the message is not included in this email. Sign in and open your messages to read it.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.signature_code.subject": "Credit Agreement Signing Code",
+	"email.signature_code.body": `
+	<h2>Sign Your Credit Agreement</h2>
+	<p>Dear %s %s,</p>
+
+	<p>Use the code below to sign the agreement for credit #%d with a simple electronic signature:</p>
+
+	<p style="font-size: 24px; font-weight: bold; letter-spacing: 4px;">%s</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Agreement SHA-256:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd; font-family: monospace;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Valid Until:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Check that the hash matches the agreement shown in the app before entering the code. If you did not request to sign, do not share this code and contact our support immediately.</p>
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+
+	"email.announcement.body": `
+	<h2>%s</h2>
+	%s
+
+	<p>
+	Best regards,<br>
+	{{brand}} Team
+	</p>
+	`,
+}
@@ -0,0 +1,398 @@
+package i18n
+
+// emailsRU are the Russian email templates, with the arguments of the English ones
+var emailsRU = map[string]string{
+	"email.support": `<p>Поддержка: <a href="mailto:%[1]s">%[1]s</a></p>`,
+
+	"email.transaction.deposit":    "Пополнение",
+	"email.transaction.withdrawal": "Снятие",
+	"email.transaction.payment":    "Платеж",
+	"email.transaction.transfer":   "Перевод",
+	"email.transaction.subject":    "Уведомление об операции «%s»: %s",
+	"email.transaction.body": `
+	<h2>Уведомление об операции</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Сообщаем о недавней операции по вашему счету:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Тип операции:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Сумма:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Счет:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Текущий баланс:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f %s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Дата:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Описание:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Если вы не совершали эту операцию, немедленно обратитесь в нашу поддержку.</p>
+
+	<p>Спасибо, что пользуетесь нашими услугами.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.payment_reminder.subject_overdue":  "ПРОСРОЧЕННЫЙ платеж: кредит №%d",
+	"email.payment_reminder.subject_upcoming": "Напоминание о платеже: кредит №%d",
+	"email.payment_reminder.overdue": `
+		<p style="color: red; font-weight: bold;">
+			Платеж ПРОСРОЧЕН на %d дн. Начислен штраф %.2f RUB.
+		</p>
+		`,
+	"email.payment_reminder.upcoming": `
+		<p>
+			Срок платежа наступит через %d дн. Пожалуйста, убедитесь, что на счете достаточно средств.
+		</p>
+		`,
+	"email.payment_reminder.body": `
+	<h2>Напоминание о платеже по кредиту</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	%s
+
+	<p>Детали платежа по кредиту:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Номер кредита:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Дата платежа:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Основной долг:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Проценты:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Штраф:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Итого к оплате:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Номер счета:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Текущий баланс счета:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>Пожалуйста, убедитесь, что на счете достаточно средств для этого платежа.</p>
+
+	<p>Спасибо, что пользуетесь нашими услугами.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.credit_approval.no_schedule": "См. график платежей",
+	"email.credit_approval.subject":     "Кредит одобрен: %.2f RUB",
+	"email.credit_approval.body": `
+	<h2>Кредит одобрен</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Рады сообщить, что ваша кредитная заявка одобрена!</p>
+
+	<p>Условия вашего нового кредита:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Номер кредита:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Сумма:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Процентная ставка:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f%%</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Срок:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%d мес.</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Ежемесячный платеж:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Дата первого платежа:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Кредитный счет:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Текущий баланс счета:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>Одобренная сумма зачислена на ваш кредитный счет. График платежей доступен в интернет-банке.</p>
+
+	<p>Спасибо, что выбрали нас.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.new_device.subject": "Безопасность: вход с нового устройства",
+	"email.new_device.body": `
+	<h2>Вход с нового устройства</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>В ваш аккаунт только что вошли с устройства, которое мы раньше не видели:</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Дата:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>IP-адрес:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Местоположение:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Устройство:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Если это были вы, ничего делать не нужно.</p>
+
+	<p>Если вы не узнаете этот вход, <a href="%s">немедленно завершите эту сессию</a> и смените пароль.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.transfer_code.subject": "Код подтверждения перевода",
+	"email.transfer_code.body": `
+	<h2>Подтвердите перевод</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Введите этот код, чтобы подтвердить перевод:</p>
+
+	<p style="font-size: 24px; font-weight: bold; letter-spacing: 4px;">%s</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Сумма:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Счет получателя:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Действует до:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Если вы не запрашивали этот перевод, никому не сообщайте код и немедленно обратитесь в нашу поддержку.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.password_changed.subject": "Ваш пароль изменен",
+	"email.password_changed.body": `
+	<h2>Пароль изменен</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Пароль от вашего аккаунта был изменен %s. На всех остальных устройствах выполнен выход.</p>
+
+	<p>Если вы не меняли пароль, немедленно обратитесь в нашу поддержку.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.email_verification.subject": "Подтвердите адрес электронной почты",
+	"email.email_verification.body": `
+	<h2>Подтвердите адрес электронной почты</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Чтобы продолжить настройку аккаунта, <a href="%s">подтвердите, что это ваш адрес</a> до %s.</p>
+
+	<p>Если вы не регистрировались у нас, просто проигнорируйте это письмо.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.password_reset.subject": "Восстановление пароля",
+	"email.password_reset.body": `
+	<h2>Восстановление пароля</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Мы получили запрос на восстановление пароля от вашего аккаунта. <a href="%s">Задайте новый пароль</a> до %s; ссылкой можно воспользоваться только один раз.</p>
+
+	<p>Если вы не запрашивали восстановление пароля, просто проигнорируйте это письмо - ваш пароль останется прежним.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.organization_invitation.subject": "Приглашение в %s",
+	"email.organization_invitation.body": `
+	<h2>Вас пригласили</h2>
+	<p>Здравствуйте!</p>
+
+	<p>%s %s приглашает вас в <strong>%s</strong> в {{brand}}.</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Роль:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Действует до:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Войдите с этим адресом email (или зарегистрируйтесь с ним) и примите приглашение в разделе приглашений в организации.</p>
+
+	<p>Если вы не ждали этого приглашения, просто проигнорируйте письмо.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.tax_document.subject": "Справка о процентах за %d год",
+	"email.tax_document.body": `
+	<h2>Справка о процентах за %d год</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>К письму приложена годовая сводка процентов для налоговых целей.</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Получено процентов:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Уплачено процентов по кредитам:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Уплачено штрафов по кредитам:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%.2f RUB</td>
+		</tr>
+	</table>
+
+	<p>Справку можно скачать в любое время в разделе налоговых справок.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.new_message.subject": "У вас новое сообщение",
+	"email.new_message.body": `
+	<h2>Новое защищенное сообщение</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Банк отправил вам сообщение на тему <strong>%s</strong>.</p>
+
+	<p>В целях безопасности сообщение не включено в письмо. Войдите и откройте раздел сообщений, чтобы прочитать его.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.signature_code.subject": "Код подписания кредитного договора",
+	"email.signature_code.body": `
+	<h2>Подпишите кредитный договор</h2>
+	<p>Здравствуйте, %s %s!</p>
+
+	<p>Введите этот код, чтобы подписать договор по кредиту №%d простой электронной подписью:</p>
+
+	<p style="font-size: 24px; font-weight: bold; letter-spacing: 4px;">%s</p>
+
+	<table style="border-collapse: collapse; width: 100%%;">
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>SHA-256 договора:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd; font-family: monospace;">%s</td>
+		</tr>
+		<tr>
+			<td style="padding: 8px; border: 1px solid #ddd;"><strong>Действует до:</strong></td>
+			<td style="padding: 8px; border: 1px solid #ddd;">%s</td>
+		</tr>
+	</table>
+
+	<p>Перед вводом кода проверьте, что хеш совпадает с договором, показанным в приложении. Если вы не запрашивали подписание, никому не сообщайте код и немедленно обратитесь в нашу поддержку.</p>
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+
+	"email.announcement.body": `
+	<h2>%s</h2>
+	%s
+
+	<p>
+	С уважением,<br>
+	команда {{brand}}
+	</p>
+	`,
+}
@@ -0,0 +1,52 @@
+package i18n
+
+import "strings"
+
+// Error is an API message given by its code and the values it is formatted with, like the amount
+// a limit was exceeded by. It is translated by its code, as its English text is not in the catalog.
+type Error struct {
+	Code   string
+	Params []string
+}
+
+// Errorf returns the message of a code formatted with its parameters. Its template may only use
+// string verbs, as the parameters reach the translation as text. Parameters that are API messages
+// themselves, like the cause of an invalid token, are translated with it.
+func Errorf(code string, params ...string) error {
+	return &Error{Code: code, Params: params}
+}
+
+// Error returns the English message
+func (e *Error) Error() string {
+	return e.Text(English)
+}
+
+// Text returns the message in a language
+func (e *Error) Text(lang Language) string {
+	args := make([]interface{}, len(e.Params))
+	for i, param := range e.Params {
+		args[i] = Message(param)
+	}
+	return Sprintf(lang, e.Code, args...)
+}
+
+// TranslateError translates the message of an API error with its code and parameters. A message
+// with parameters ends with the text of its code, after the causes it was wrapped in; it is
+// translated by the code and the causes by their English text. Other messages are translated by
+// their English text.
+func TranslateError(lang Language, code, message string, params []string) string {
+	if len(params) == 0 {
+		return Translate(lang, message)
+	}
+
+	e := &Error{Code: code, Params: params}
+	prefix, ok := strings.CutSuffix(message, e.Error())
+	if !ok {
+		return Translate(lang, message)
+	}
+	if prefix == "" {
+		return e.Text(lang)
+	}
+
+	return Translate(lang, strings.TrimSuffix(prefix, ": ")) + ": " + e.Text(lang)
+}
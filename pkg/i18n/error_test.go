@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTranslateErrorUsesCodeAndParams(t *testing.T) {
+	err := fmt.Errorf("onboarding is not complete: %w", Errorf("complete_your_profile_first_missing", "phone"))
+
+	got := TranslateError(Russian, "complete_your_profile_first_missing", err.Error(), []string{"phone"})
+	if want := "онбординг не завершен: сначала заполните профиль: phone"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	got = TranslateError(Russian, "invalid_token_reason", "invalid token: session has been revoked or expired", []string{"session has been revoked or expired"})
+	if !strings.HasPrefix(got, "недействительный токен: ") || strings.Contains(got, "session has been revoked or expired") {
+		t.Errorf("expected the token error and its cause to be translated, got %q", got)
+	}
+}
+
+func TestCatalogsHaveTheSameMessages(t *testing.T) {
+	for i, catalog := range messagesEN {
+		for code, text := range catalog {
+			translated, ok := messagesRU[i][code]
+			if !ok {
+				t.Errorf("message %s has no Russian translation in the same feature", code)
+				continue
+			}
+			if strings.Count(text, "%") != strings.Count(translated, "%") {
+				t.Errorf("message %s has different parameters in English and Russian", code)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package i18n translates API messages, notifications and emails. Catalogs are kept per language
+// and keyed by codes: API messages by their error code, templates by dotted names like
+// "email.password_changed.subject". Handlers and services keep writing English messages; the
+// English catalog maps them back to their codes. Messages with values, like an amount or the cause
+// of an invalid token, are Errors with a code and params instead.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Language is a supported language as an ISO 639-1 code
+type Language string
+
+const (
+	English Language = "en"
+	Russian Language = "ru"
+)
+
+// Parse returns the supported language of a tag like "ru" or "ru-RU"
+func Parse(tag string) (Language, bool) {
+	base := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(base, "-_"); i >= 0 {
+		base = base[:i]
+	}
+
+	lang := Language(base)
+	if _, ok := catalogs[lang]; !ok {
+		return "", false
+	}
+
+	return lang, true
+}
+
+// Negotiate picks the supported language the client prefers most in an Accept-Language header
+func Negotiate(acceptLanguage string) (Language, bool) {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			q, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = q
+		}
+
+		if lang, ok := Parse(tag); ok && quality > 0 {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].lang, true
+}
+
+// contextKey is the context key of the request language
+type contextKey struct{}
+
+// WithLanguage adds the language of a request to its context
+func WithLanguage(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext returns the language of a request, if one was chosen
+func FromContext(ctx context.Context) (Language, bool) {
+	lang, ok := ctx.Value(contextKey{}).(Language)
+	return lang, ok
+}
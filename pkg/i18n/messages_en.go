@@ -0,0 +1,1090 @@
+package i18n
+
+// messagesEN are the English API messages keyed by their codes, one map per feature
+var messagesEN = []map[string]string{
+	commonMessagesEN,
+	accessMessagesEN,
+	authMessagesEN,
+	userMessagesEN,
+	accountMessagesEN,
+	cardMessagesEN,
+	transferMessagesEN,
+	creditMessagesEN,
+	paymentMessagesEN,
+	merchantMessagesEN,
+	organizationMessagesEN,
+	supportMessagesEN,
+	adminMessagesEN,
+}
+
+// commonMessagesEN are the English messages of request validation shared by the features
+var commonMessagesEN = map[string]string{
+	"days_must_be_between_1_and_365":                        "days must be between 1 and 365",
+	"description_is_required":                               "description is required",
+	"description_must_be_at_most_255_characters":            "description must be at most 255 characters",
+	"description_must_be_at_most_500_characters":            "description must be at most 500 characters",
+	"details_must_be_at_most_2000_characters":               "details must be at most 2000 characters",
+	"expires_in_cannot_be_negative":                         "expires_in cannot be negative",
+	"expires_in_must_be_between_1_and_3600_seconds":         "expires_in must be between 1 and 3600 seconds",
+	"expires_on_must_be_at_most_3_years_ahead":              "expires_on must be at most 3 years ahead",
+	"expires_on_must_be_in_yyyy_mm_dd_format":               "expires_on must be in YYYY-MM-DD format",
+	"expires_on_must_not_be_in_the_past":                    "expires_on must not be in the past",
+	"failed_to_generate_request_id":                         "failed to generate request ID",
+	"failed_to_select_fields":                               "failed to select fields",
+	"from_must_be_before_to":                                "from must be before to",
+	"invalid_cursor":                                        "invalid cursor",
+	"invalid_date_expected_yyyy_mm_dd":                      "invalid date, expected YYYY-MM-DD",
+	"invalid_days_parameter":                                "invalid days parameter",
+	"invalid_decision":                                      "invalid decision",
+	"invalid_end_date_format":                               "invalid end date format",
+	"invalid_filter":                                        "invalid filter",
+	"invalid_from_date_expected_yyyy_mm_dd":                 "invalid from date, expected YYYY-MM-DD",
+	"invalid_from_date_format":                              "invalid from date format",
+	"invalid_limit":                                         "invalid limit",
+	"invalid_offset":                                        "invalid offset",
+	"invalid_request_payload":                               "invalid request payload",
+	"invalid_review":                                        "invalid review",
+	"invalid_start_date_format":                             "invalid start date format",
+	"invalid_template_id":                                   "invalid template ID",
+	"invalid_to_date_expected_yyyy_mm_dd":                   "invalid to date, expected YYYY-MM-DD",
+	"invalid_to_date_format":                                "invalid to date format",
+	"limit_must_be_between_1_and":                           "limit must be between 1 and %s",
+	"note_is_required":                                      "note is required",
+	"note_is_required_when_the_reason_is_other":             "note is required when the reason is OTHER",
+	"note_must_be_at_most_1000_characters":                  "note must be at most 1000 characters",
+	"note_must_be_at_most_2000_characters":                  "note must be at most 2000 characters",
+	"note_must_be_at_most_500_characters":                   "note must be at most 500 characters",
+	"offset_cannot_be_negative":                             "offset cannot be negative",
+	"reason_is_required":                                    "reason is required",
+	"reason_is_required_and_must_be_at_most_500_characters": "reason is required and must be at most 500 characters",
+	"reason_must_be_at_most_500_characters":                 "reason must be at most 500 characters",
+	"request_id_is_required":                                "request_id is required",
+	"retry_after_cannot_be_negative":                        "retry_after cannot be negative",
+	"status_must_be_accepted_or_rejected":                   "status must be ACCEPTED or REJECTED",
+	"status_must_be_one_of_accepted_delivered_failed_bounced_complained_suppressed": "status must be one of ACCEPTED, DELIVERED, FAILED, BOUNCED, COMPLAINED, SUPPRESSED",
+	"status_must_be_one_of_funded_released_refunded":                                "status must be one of FUNDED, RELEASED, REFUNDED",
+	"status_must_be_one_of_submitted_in_transit_settled":                            "status must be one of SUBMITTED, IN_TRANSIT, SETTLED",
+	"type_must_be_branch_or_atm":                                                    "type must be BRANCH or ATM",
+}
+
+// accessMessagesEN are the English messages of access checks and delegated access
+var accessMessagesEN = map[string]string{
+	"access_denied_account_belongs_to_another_user":                "access denied: account belongs to another user",
+	"access_denied_account_of_another_organization":                "access denied: account belongs to another organization",
+	"access_denied_amount_exceeds_your_delegated_transfer_limit":   "access denied: amount exceeds your delegated transfer limit",
+	"access_denied_api_key_does_not_belong_to_user":                "access denied: API key does not belong to user",
+	"access_denied_bill_payment_belongs_to_another_user":           "access denied: bill payment belongs to another user",
+	"access_denied_bill_template_of_another_user":                  "access denied: bill template belongs to another user",
+	"access_denied_chargeback_belongs_to_another_user":             "access denied: chargeback belongs to another user",
+	"access_denied_cheque_deposit_belongs_to_another_user":         "access denied: cheque deposit belongs to another user",
+	"access_denied_client_certificate_required":                    "access denied: client certificate required",
+	"access_denied_confirmation_belongs_to_another_user":           "access denied: confirmation belongs to another user",
+	"access_denied_credit_belongs_to_another_user":                 "access denied: credit belongs to another user",
+	"access_denied_delegated_access_does_not_allow_this":           "access denied: delegated access does not allow this",
+	"access_denied_delegation_belongs_to_another_user":             "access denied: delegation belongs to another user",
+	"access_denied_escrow_belongs_to_other_users":                  "access denied: escrow belongs to other users",
+	"access_denied_foreign_transaction":                            "access denied: transaction does not involve your accounts",
+	"access_denied_impersonation_tokens_are_read_only":             "access denied: impersonation tokens are read-only",
+	"access_denied_insufficient_role":                              "access denied: insufficient role",
+	"access_denied_international_transfer_belongs_to_another_user": "access denied: international transfer belongs to another user",
+	"access_denied_merchant_belongs_to_another_user":               "access denied: merchant belongs to another user",
+	"access_denied_not_organization_member":                        "access denied: you are not a member of this organization",
+	"access_denied_only_organization_admins_can_do_this":           "access denied: only organization admins can do this",
+	"access_denied_role_cannot_transact":                           "access denied: your organization role does not allow transactions",
+	"access_denied_source_address_not_allowed":                     "access denied: source address not allowed",
+	"access_denied_token_scope_does_not_allow_this_endpoint":       "access denied: token scope does not allow this endpoint",
+	"access_denied_your_delegated_access_is_view_only":             "access denied: your delegated access is view-only",
+	"at_least_one_scope_is_required":                               "at least one scope is required",
+	"delegate_not_found":                                           "delegate not found",
+	"delegation_events_retrieved_successfully":                     "delegation events retrieved successfully",
+	"delegation_granted_successfully":                              "delegation granted successfully",
+	"delegation_is_already_revoked":                                "delegation is already revoked",
+	"delegation_not_found":                                         "delegation not found",
+	"delegation_revoked_successfully":                              "delegation revoked successfully",
+	"delegations_retrieved_successfully":                           "delegations retrieved successfully",
+	"failed_to_create_delegation":                                  "failed to create delegation",
+	"failed_to_create_delegation_event":                            "failed to create delegation event",
+	"failed_to_get_delegations":                                    "failed to get delegations",
+	"failed_to_record_delegated_access":                            "failed to record delegated access",
+	"failed_to_send_delegated_transfer_notification":               "failed to send delegated transfer notification",
+	"failed_to_send_delegation_notification":                       "failed to send delegation notification",
+	"invalid_delegation":                                           "invalid delegation",
+	"invalid_delegation_id":                                        "invalid delegation ID",
+	"invalid_role":                                                 "invalid role",
+	"invalid_role_update":                                          "invalid role update",
+	"method_not_allowed":                                           "method not allowed",
+	"scope_is_listed_twice":                                        "scope %q is listed twice",
+	"scope_must_be_view_or_transfer":                               "scope must be VIEW or TRANSFER",
+	"unknown_scope":                                                "unknown scope %q",
+	"you_cannot_delegate_access_to_yourself":                       "you cannot delegate access to yourself",
+}
+
+// authMessagesEN are the English messages of sign in, sessions, tokens and API keys
+var authMessagesEN = map[string]string{
+	"api_key_has_been_revoked_or_expired":                           "API key has been revoked or expired",
+	"api_key_is_already_revoked":                                    "API key is already revoked",
+	"api_key_issued_successfully_store_it_securely":                 "API key issued successfully, store it securely",
+	"api_key_not_found":                                             "API key not found",
+	"api_key_revoked_successfully":                                  "API key revoked successfully",
+	"api_key_rotated_successfully_store_it_securely":                "API key rotated successfully, store it securely",
+	"api_keys_retrieved_successfully":                               "API keys retrieved successfully",
+	"cannot_impersonate_yourself":                                   "cannot impersonate yourself",
+	"captcha_token_is_required":                                     "captcha token is required",
+	"captcha_verification_failed":                                   "captcha verification failed",
+	"captcha_verification_is_unavailable":                           "captcha verification is unavailable",
+	"code_is_required":                                              "code is required",
+	"current_password_is_incorrect":                                 "current password is incorrect",
+	"current_password_is_required":                                  "current password is required",
+	"failed_to_create_api_key":                                      "failed to create API key",
+	"failed_to_generate_api_key":                                    "failed to generate API key",
+	"failed_to_generate_salt":                                       "failed to generate salt",
+	"failed_to_generate_session_id":                                 "failed to generate session ID",
+	"failed_to_generate_token":                                      "failed to generate token",
+	"failed_to_get_api_key":                                         "failed to get API key",
+	"failed_to_get_api_keys":                                        "failed to get API keys",
+	"failed_to_get_impersonations":                                  "failed to get impersonations",
+	"failed_to_get_sessions":                                        "failed to get sessions",
+	"failed_to_hash_password":                                       "failed to hash password",
+	"failed_to_log_out":                                             "failed to log out",
+	"failed_to_revoke_api_key":                                      "failed to revoke API key",
+	"failed_to_revoke_invitation":                                   "failed to revoke invitation",
+	"failed_to_revoke_session":                                      "failed to revoke session",
+	"failed_to_rotate_api_key":                                      "failed to rotate API key",
+	"failed_to_send_password_change_confirmation":                   "failed to send password change confirmation",
+	"failed_to_start_session":                                       "failed to start session",
+	"failed_to_update_password":                                     "failed to update password",
+	"failed_to_verify_captcha":                                      "failed to verify captcha",
+	"failed_to_verify_code":                                         "failed to verify code",
+	"impersonation_claims_have_wrong_type":                          "impersonation claims have wrong type",
+	"impersonation_ended_successfully":                              "impersonation ended successfully",
+	"impersonation_has_already_ended":                               "impersonation has already ended",
+	"impersonation_has_ended":                                       "impersonation has ended",
+	"impersonation_not_found":                                       "impersonation not found",
+	"impersonation_retrieved_successfully":                          "impersonation retrieved successfully",
+	"impersonation_started_successfully":                            "impersonation started successfully",
+	"impersonations_retrieved_successfully":                         "impersonations retrieved successfully",
+	"invalid_api_key":                                               "invalid API key",
+	"invalid_api_key_data":                                          "invalid API key data",
+	"invalid_api_key_id":                                            "invalid API key ID",
+	"invalid_api_key_issued_for_another_tenant":                     "invalid API key: issued for another tenant",
+	"invalid_authorization_header_format":                           "invalid authorization header format",
+	"invalid_credentials":                                           "invalid credentials",
+	"invalid_impersonation":                                         "invalid impersonation",
+	"invalid_impersonation_id":                                      "invalid impersonation ID",
+	"invalid_or_already_used_revoke_link":                           "invalid or already used revoke link",
+	"invalid_or_expired_password_reset_token":                       "invalid or expired password reset token",
+	"invalid_password":                                              "invalid password",
+	"invalid_password_data":                                         "invalid password data",
+	"invalid_password_reset_data":                                   "invalid password reset data",
+	"invalid_revoke_token":                                          "invalid revoke token",
+	"invalid_session_id":                                            "invalid session ID",
+	"invalid_token":                                                 "invalid token",
+	"invalid_token_data":                                            "invalid token data",
+	"invalid_token_issued_for_another_tenant":                       "invalid token: issued for another tenant",
+	"invalid_token_missing_sid_claim":                               "invalid token: missing sid claim",
+	"invalid_token_missing_user_id_claim":                           "invalid token: missing user_id claim",
+	"invalid_token_reason":                                          "invalid token: %s",
+	"invalid_token_scopes_claim_has_wrong_type":                     "invalid token: scopes claim has wrong type",
+	"invalid_token_user_id_has_wrong_type":                          "invalid token: user_id has wrong type",
+	"logged_out_successfully":                                       "logged out successfully",
+	"login_successful":                                              "login successful",
+	"new_password_must_differ_from_the_current_password":            "new password must differ from the current password",
+	"no_api_key_provided":                                           "no API key provided",
+	"no_authorization_header_provided":                              "no authorization header provided",
+	"only_an_active_api_key_can_be_rotated":                         "only an active API key can be rotated",
+	"password_changed_successfully":                                 "password changed successfully",
+	"password_is_required":                                          "password is required",
+	"password_must_be_at_least_8_characters":                        "password must be at least 8 characters",
+	"password_reset_successfully":                                   "password reset successfully",
+	"password_too_simple":                                           "password must contain at least one uppercase letter, one lowercase letter, and one number",
+	"provide_either_an_authorization_header_or_an_api_key_not_both": "provide either an authorization header or an API key, not both",
+	"session_does_not_belong_to_user":                               "session does not belong to user",
+	"session_has_been_revoked_or_expired":                           "session has been revoked or expired",
+	"session_id_not_found_in_context":                               "session ID not found in context",
+	"session_not_found":                                             "session not found",
+	"session_revoked_change_password":                               "session revoked successfully, please change your password",
+	"session_revoked_successfully":                                  "session revoked successfully",
+	"sessions_retrieved_successfully":                               "sessions retrieved successfully",
+	"token_is_required":                                             "token is required",
+	"token_issued_successfully":                                     "token issued successfully",
+	"too_many_active_api_keys_revoke_one_first":                     "too many active API keys, revoke one first",
+}
+
+// userMessagesEN are the English messages of users, their profiles and documents
+var userMessagesEN = map[string]string{
+	"address_city_is_required_and_must_be_at_most_100_characters":    "address.city is required and must be at most 100 characters",
+	"address_country_must_be_an_iso_3166_1_alpha_2_code_e_g_ru":      "address.country must be an ISO 3166-1 alpha-2 code, e.g. RU",
+	"address_postal_code_must_be_at_most_20_characters":              "address.postal_code must be at most 20 characters",
+	"address_street_is_required_and_must_be_at_most_200_characters":  "address.street is required and must be at most 200 characters",
+	"availability_checked_successfully":                              "availability checked successfully",
+	"birth_date_must_be_at_most_120_years_ago":                       "birth_date must be at most 120 years ago",
+	"birth_date_must_be_in_the_past":                                 "birth_date must be in the past",
+	"birth_date_must_be_in_yyyy_mm_dd_format":                        "birth_date must be in YYYY-MM-DD format",
+	"bounce_type_must_be_hard_or_soft":                               "bounce_type must be hard or soft",
+	"buyer_and_seller_must_be_different_users":                       "buyer and seller must be different users",
+	"complete_your_profile_first":                                    "complete your profile first",
+	"complete_your_profile_first_missing":                            "complete your profile first: %s",
+	"document_must_be_a_pdf_jpeg_or_png_file":                        "document must be a PDF, JPEG or PNG file",
+	"document_must_be_at_most_5_mb":                                  "document must be at most 5 MB",
+	"document_not_found":                                             "document not found",
+	"document_references_must_be_between_1_and_200_characters":       "document references must be between 1 and 200 characters",
+	"document_reviewed_successfully":                                 "document reviewed successfully",
+	"document_type_must_be_a_valid_mime_type":                        "document_type must be a valid MIME type",
+	"document_uploaded_successfully":                                 "document uploaded successfully",
+	"document_was_rejected_by_the_virus_scan":                        "document was rejected by the virus scan",
+	"documents_must_list_between_1_and_10_document_references":       "documents must list between 1 and 10 document references",
+	"email_address_is_already_verified":                              "email address is already verified",
+	"email_already_exists":                                           "email already exists",
+	"email_deliveries_retrieved_successfully":                        "email deliveries retrieved successfully",
+	"email_events_processed_successfully":                            "email events processed successfully",
+	"email_is_required":                                              "email is required",
+	"email_suppression_deleted_successfully":                         "email suppression deleted successfully",
+	"email_suppression_not_found":                                    "email suppression not found",
+	"email_suppressions_retrieved_successfully":                      "email suppressions retrieved successfully",
+	"email_verified_successfully":                                    "email verified successfully",
+	"email_webhook_is_disabled":                                      "email webhook is disabled",
+	"email_webhook_signature_is_invalid":                             "email webhook signature is invalid",
+	"event_must_be_one_of_delivered_opened_bounced_complained":       "event must be one of delivered, opened, bounced, complained",
+	"failed_to_check_availability":                                   "failed to check availability",
+	"failed_to_create_notification":                                  "failed to create notification",
+	"failed_to_create_user":                                          "failed to create user",
+	"failed_to_generate_referral_code":                               "failed to generate referral code",
+	"failed_to_get_email_suppressions":                               "failed to get email suppressions",
+	"failed_to_get_notifications":                                    "failed to get notifications",
+	"failed_to_get_onboarding_status":                                "failed to get onboarding status",
+	"failed_to_get_qualified_referrals":                              "failed to get qualified referrals",
+	"failed_to_get_referral_summary":                                 "failed to get referral summary",
+	"failed_to_get_referrals":                                        "failed to get referrals",
+	"failed_to_get_user":                                             "failed to get user",
+	"failed_to_get_user_details":                                     "failed to get user details",
+	"failed_to_mark_notification_as_read":                            "failed to mark notification as read",
+	"failed_to_qualify_referral":                                     "failed to qualify referral",
+	"failed_to_read_file":                                            "failed to read file",
+	"failed_to_send_email":                                           "failed to send email",
+	"failed_to_store_document":                                       "failed to store document",
+	"failed_to_update_language":                                      "failed to update language",
+	"failed_to_update_profile":                                       "failed to update profile",
+	"failed_to_update_timezone":                                      "failed to update timezone",
+	"failed_to_update_user":                                          "failed to update user",
+	"file_is_empty":                                                  "file is empty",
+	"file_is_not_an_iso_20022_message_of_the_expected_type":          "file is not an ISO 20022 message of the expected type",
+	"file_is_required":                                               "file is required",
+	"file_must_be_at_most_10_mb":                                     "file must be at most 10 MB",
+	"file_name_must_be_at_most_255_characters":                       "file name must be at most 255 characters",
+	"first_name_and_last_name_must_be_at_most_100_characters":        "first_name and last_name must be at most 100 characters",
+	"if_the_email_is_registered_a_password_reset_link_has_been_sent": "if the email is registered, a password reset link has been sent",
+	"invalid_address":                                                "address is required and must be at most 255 characters",
+	"invalid_availability_data":                                      "invalid availability data",
+	"invalid_document":                                               "invalid document",
+	"invalid_document_id":                                            "invalid document ID",
+	"invalid_document_name":                                          "document_name is required with a document and must be at most 255 characters",
+	"invalid_document_type":                                          "type must be one of PASSPORT, INCOME_STATEMENT, OTHER",
+	"invalid_email_format":                                           "invalid email format",
+	"invalid_email_suppression_id":                                   "invalid email suppression ID",
+	"invalid_file_name":                                              "file name is required and must be at most 255 characters",
+	"invalid_language":                                               "invalid language, expected en or ru",
+	"invalid_multipart_form_or_file_larger_than_10_mb":               "invalid multipart form or file larger than 10 MB",
+	"invalid_multipart_form_or_file_larger_than_1_mb":                "invalid multipart form or file larger than 1 MB",
+	"invalid_name":                                                   "name is required and must be at most 100 characters",
+	"invalid_notification_id":                                        "invalid notification ID",
+	"invalid_profile_data":                                           "invalid profile data",
+	"invalid_referral_code":                                          "invalid referral code",
+	"invalid_timezone":                                               "invalid timezone, expected an IANA name like Europe/Moscow",
+	"invalid_user_data":                                              "invalid user data",
+	"invalid_user_id":                                                "invalid user ID",
+	"invalid_verification_token":                                     "invalid verification token",
+	"language_updated_successfully":                                  "language updated successfully",
+	"malware_detected":                                               "malware detected",
+	"name_is_required":                                               "name is required",
+	"name_must_be_at_most_100_characters":                            "name must be at most 100 characters",
+	"name_must_be_between_2_and_100_characters":                      "name must be between 2 and 100 characters",
+	"new_users_retrieved_successfully":                               "new users retrieved successfully",
+	"nickname_is_too_long":                                           "nickname is too long",
+	"no_settings_to_update":                                          "no settings to update",
+	"notification_marked_as_read":                                    "notification marked as read",
+	"notification_not_found":                                         "notification not found",
+	"notifications_retrieved_successfully":                           "notifications retrieved successfully",
+	"onboarding_is_not_complete":                                     "onboarding is not complete",
+	"onboarding_status_retrieved_successfully":                       "onboarding status retrieved successfully",
+	"phone_must_be_in_e_164_format_e_g_79161234567":                  "phone must be in E.164 format, e.g. +79161234567",
+	"profile_is_incomplete_for_a_credit":                             "profile is incomplete for a credit",
+	"profile_updated_successfully":                                   "profile updated successfully",
+	"referral_bonuses_have_already_been_paid":                        "referral bonuses have already been paid",
+	"referral_summary_retrieved_successfully":                        "referral summary retrieved successfully",
+	"referrals_retrieved_successfully":                               "referrals retrieved successfully",
+	"timezone_updated_successfully":                                  "timezone updated successfully",
+	"to_user_id_is_required":                                         "to_user_id is required",
+	"user_already_has_access_to_this_account_revoke_it_first":        "user already has access to this account, revoke it first",
+	"user_details_retrieved_successfully":                            "user details retrieved successfully",
+	"user_has_no_email_address":                                      "user has no email address",
+	"user_id_is_required":                                            "user_id is required",
+	"user_id_not_found_in_context":                                   "user ID not found in context",
+	"user_is_already_a_member":                                       "user is already a member",
+	"user_not_found":                                                 "user not found",
+	"user_registered_successfully":                                   "user registered successfully",
+	"user_updated_successfully":                                      "user updated successfully",
+	"username_already_exists":                                        "username already exists",
+	"username_must_be_between_3_and_50_characters":                   "username must be between 3 and 50 characters",
+	"username_or_email_is_required":                                  "username or email is required",
+	"verification_link_has_expired":                                  "verification link has expired",
+	"verification_link_sent_successfully":                            "verification link sent successfully",
+	"verification_secret_is_invalid":                                 "verification secret is invalid",
+	"verify_your_email_address_first":                                "verify your email address first",
+	"virus_scan_is_unavailable_please_try_again_later":               "virus scan is unavailable, please try again later",
+}
+
+// accountMessagesEN are the English messages of accounts, plans and statements
+var accountMessagesEN = map[string]string{
+	"account_activity_retrieved_successfully":                                "account activity retrieved successfully",
+	"account_already_belongs_to_the_user":                                    "account already belongs to the user",
+	"account_already_has_a_pending_ownership_transfer":                       "account already has a pending ownership transfer",
+	"account_created_successfully":                                           "account created successfully",
+	"account_currency_does_not_match_the_plan_currency":                      "account currency does not match the plan currency",
+	"account_deleted_successfully":                                           "account deleted successfully",
+	"account_does_not_belong_to_this_organization":                           "account does not belong to this organization",
+	"account_has_an_open_credit_which_must_be_settled_or_restructured_first": "account has an open credit, which must be settled or restructured first",
+	"account_holds_retrieved_successfully":                                   "account holds retrieved successfully",
+	"account_id_is_required":                                                 "account_id is required",
+	"account_is_already_on_this_plan":                                        "account is already on this plan",
+	"account_is_dormant_reactivate_it_first":                                 "account is dormant, reactivate it first",
+	"account_is_inactive":                                                    "account is inactive",
+	"account_is_not_active":                                                  "account is not active",
+	"account_is_not_dormant":                                                 "account is not dormant",
+	"account_is_the_settlement_account_of_a_merchant":                        "account is the settlement account of a merchant",
+	"account_not_found":                                                      "account not found",
+	"account_owner_changed_since_the_transfer_was_requested":                 "account owner changed since the transfer was requested",
+	"account_plan_created_successfully":                                      "account plan created successfully",
+	"account_plan_is_no_longer_offered":                                      "account plan is no longer offered",
+	"account_plan_not_found":                                                 "account plan not found",
+	"account_plan_retrieved_successfully":                                    "account plan retrieved successfully",
+	"account_plan_switched_successfully":                                     "account plan switched successfully",
+	"account_plan_updated_successfully":                                      "account plan updated successfully",
+	"account_plans_retrieved_successfully":                                   "account plans retrieved successfully",
+	"account_reactivated_successfully":                                       "account reactivated successfully",
+	"account_retrieved_successfully":                                         "account retrieved successfully",
+	"account_settings_updated_successfully":                                  "account settings updated successfully",
+	"account_was_opened_after_the_period":                                    "account was opened after the period",
+	"accounts_retrieved_successfully":                                        "accounts retrieved successfully",
+	"balance_prediction_retrieved_successfully":                              "balance prediction retrieved successfully",
+	"balance_updated_successfully":                                           "balance updated successfully",
+	"cannot_delete_account_with_active_cards":                                "cannot delete account with active cards",
+	"creditor_account_is_required":                                           "creditor account is required",
+	"currency_is_required":                                                   "currency is required",
+	"currency_mismatch_between_accounts":                                     "currency mismatch between accounts",
+	"currency_must_be_usd_or_eur":                                            "currency must be USD or EUR",
+	"debtor_account_is_required":                                             "debtor account is required",
+	"debtor_account_of_the_payment_does_not_match_the_payroll_account":       "debtor account of the payment does not match the payroll account",
+	"default_account_not_found":                                              "default account not found",
+	"default_account_retrieved_successfully":                                 "default account retrieved successfully",
+	"default_account_set_successfully":                                       "default account set successfully",
+	"deposit_balances_retrieved_successfully":                                "deposit balances retrieved successfully",
+	"deposit_currency_does_not_match_account_currency":                       "deposit currency %s does not match account currency %s",
+	"deposit_transaction_has_no_destination_account":                         "deposit transaction has no destination account",
+	"destination_account_is_inactive":                                        "destination account is inactive",
+	"failed_to_create_account":                                               "failed to create account",
+	"failed_to_create_deposit_transaction":                                   "failed to create deposit transaction",
+	"failed_to_delete_account":                                               "failed to delete account",
+	"failed_to_get_account":                                                  "failed to get account",
+	"failed_to_get_account_activity":                                         "failed to get account activity",
+	"failed_to_get_account_plan":                                             "failed to get account plan",
+	"failed_to_get_account_plans":                                            "failed to get account plans",
+	"failed_to_get_account_settings":                                         "failed to get account settings",
+	"failed_to_get_accounts":                                                 "failed to get accounts",
+	"failed_to_get_default_account":                                          "failed to get default account",
+	"failed_to_get_destination_account":                                      "failed to get destination account",
+	"failed_to_get_recommendations":                                          "failed to get recommendations",
+	"failed_to_get_source_account":                                           "failed to get source account",
+	"failed_to_get_spending_forecast":                                        "failed to get spending forecast",
+	"failed_to_get_statements":                                               "failed to get statements",
+	"failed_to_get_statistics":                                               "failed to get statistics",
+	"failed_to_get_tax_documents":                                            "failed to get tax documents",
+	"failed_to_hold_cheque_amount":                                           "failed to hold cheque amount",
+	"failed_to_issue_statement":                                              "failed to issue statement",
+	"failed_to_mark_tax_document_as_emailed":                                 "failed to mark tax document as emailed",
+	"failed_to_predict_balance":                                              "failed to predict balance",
+	"failed_to_put_account_on_plan":                                          "failed to put account on plan",
+	"failed_to_regenerate_statement":                                         "failed to regenerate statement",
+	"failed_to_render_tax_document":                                          "failed to render tax document",
+	"failed_to_send_account_ownership_notification":                          "failed to send account ownership notification",
+	"failed_to_send_tax_document":                                            "failed to send tax document",
+	"failed_to_set_default_account":                                          "failed to set default account",
+	"failed_to_update_account":                                               "failed to update account",
+	"failed_to_update_account_balance":                                       "failed to update account balance",
+	"failed_to_update_balance":                                               "failed to update balance",
+	"failed_to_update_destination_account_balance":                           "failed to update destination account balance",
+	"failed_to_update_source_account_balance":                                "failed to update source account balance",
+	"failed_to_update_statement":                                             "failed to update statement",
+	"granularity_must_be_one_of_day_week_month_quarter":                      "granularity must be one of day, week, month, quarter",
+	"initial_balance_cannot_be_negative":                                     "initial balance cannot be negative",
+	"interval_must_be_week_month_or_year":                                    "interval must be WEEK, MONTH or YEAR",
+	"invalid_account_data":                                                   "invalid account data",
+	"invalid_account_id":                                                     "invalid account ID",
+	"invalid_account_plan":                                                   "invalid account plan",
+	"invalid_account_plan_id":                                                "invalid account plan ID",
+	"invalid_account_settings":                                               "invalid account settings",
+	"invalid_account_type":                                                   "invalid account type",
+	"invalid_account_type_plans_apply_to_checking_and_savings_accounts":      "invalid account type %q, plans apply to checking and savings accounts",
+	"invalid_currency":                                                       "invalid currency",
+	"invalid_deposit_request":                                                "invalid deposit request",
+	"invalid_ownership_transfer":                                             "invalid ownership transfer",
+	"invalid_ownership_transfer_id":                                          "invalid ownership transfer ID",
+	"invalid_period":                                                         "invalid period. Must be one of: week, month, quarter, year",
+	"invalid_statement_id":                                                   "invalid statement ID",
+	"invalid_statement_period":                                               "invalid statement period",
+	"invalid_withdrawal_request":                                             "invalid withdrawal request",
+	"invalid_year":                                                           "invalid year",
+	"more_than_one_interest_tier_starts_at":                                  "more than one interest tier starts at %s",
+	"new_owner_must_be_a_customer_of_the_same_bank":                          "new owner must be a customer of the same bank",
+	"new_owner_not_found":                                                    "new owner not found",
+	"note_is_required_to_reject_an_ownership_transfer":                       "note is required to reject an ownership transfer",
+	"only_personal_accounts_can_be_delegated":                                "only personal accounts can be delegated",
+	"open_an_account_first":                                                  "open an account first",
+	"ownership_transfer_approved_successfully":                               "ownership transfer approved successfully",
+	"ownership_transfer_is_no_longer_pending":                                "ownership transfer is no longer pending",
+	"ownership_transfer_must_be_reviewed_by_another_employee":                "ownership transfer must be reviewed by another employee",
+	"ownership_transfer_not_found":                                           "ownership transfer not found",
+	"ownership_transfer_rejected_successfully":                               "ownership transfer rejected successfully",
+	"ownership_transfer_requested_successfully":                              "ownership transfer requested successfully",
+	"ownership_transfer_retrieved_successfully":                              "ownership transfer retrieved successfully",
+	"ownership_transfers_retrieved_successfully":                             "ownership transfers retrieved successfully",
+	"period_must_be_a_month_that_has_ended":                                  "period must be a month that has ended",
+	"period_must_be_in_yyyy_mm_format":                                       "period must be in YYYY-MM format",
+	"plan_is_not_available_for_accounts":                                     "plan %s is not available for %s accounts",
+	"recommendations_retrieved_successfully":                                 "recommendations retrieved successfully",
+	"source_account_id_and_destination_account_id_are_required":              "source_account_id and destination_account_id are required",
+	"source_account_id_is_required":                                          "source_account_id is required",
+	"source_account_is_dormant_reactivate_it_first":                          "source account is dormant, reactivate it first",
+	"source_account_is_inactive":                                             "source account is inactive",
+	"source_and_destination_accounts_cannot_be_the_same":                     "source and destination accounts cannot be the same",
+	"source_and_destination_accounts_must_have_the_same_currency":            "source and destination accounts must have the same currency",
+	"spending_forecast_retrieved_successfully":                               "spending forecast retrieved successfully",
+	"statement_not_found":                                                    "statement not found",
+	"statement_regenerated_successfully":                                     "statement regenerated successfully",
+	"statement_retrieved_successfully":                                       "statement retrieved successfully",
+	"statements_retrieved_successfully":                                      "statements retrieved successfully",
+	"statistics_retrieved_successfully":                                      "statistics retrieved successfully",
+	"tax_document_belongs_to_another_user":                                   "tax document belongs to another user",
+	"tax_document_retrieved_successfully":                                    "tax document retrieved successfully",
+	"tax_documents_retrieved_successfully":                                   "tax documents retrieved successfully",
+	"the_period_cannot_be_longer_than_366_days":                              "the period cannot be longer than 366 days",
+	"the_period_must_end_after_it_starts":                                    "the period must end after it starts",
+	"withdrawal_completed_successfully":                                      "withdrawal completed successfully",
+	"withdrawal_currency_does_not_match_account_currency":                    "withdrawal currency %s does not match account currency %s",
+	"year_must_be_between_2000_and":                                          "year must be between 2000 and %s",
+	"you_cannot_subscribe_to_your_own_plan":                                  "you cannot subscribe to your own plan",
+}
+
+// cardMessagesEN are the English messages of cards and card products
+var cardMessagesEN = map[string]string{
+	"bin_must_have_6_or_8_digits":                  "BIN %q must have 6 or 8 digits",
+	"card_analytics_retrieved_successfully":        "card analytics retrieved successfully",
+	"card_blocked_wrong_pins":                      "card is blocked after too many wrong PINs, set a new PIN to unblock it",
+	"card_created_successfully":                    "card created successfully",
+	"card_deleted_successfully":                    "card deleted successfully",
+	"card_does_not_belong_to_specified_account":    "card does not belong to specified account",
+	"card_has_expired":                             "card has expired",
+	"card_has_no_pin_set_one_first":                "card has no PIN, set one first",
+	"card_is_inactive":                             "card is inactive",
+	"card_not_found":                               "card not found",
+	"card_number_and_pin_are_required":             "card number and PIN are required",
+	"card_product_created_successfully":            "card product created successfully",
+	"card_product_is_no_longer_issued":             "card product is no longer issued",
+	"card_product_updated_successfully":            "card product updated successfully",
+	"card_products_retrieved_successfully":         "card products retrieved successfully",
+	"card_retrieved_successfully":                  "card retrieved successfully",
+	"card_transactions_retrieved_successfully":     "card transactions retrieved successfully",
+	"card_updated_successfully":                    "card updated successfully",
+	"cards_cannot_be_linked_to_accounts":           "%s cards cannot be linked to %s accounts",
+	"cards_retrieved_successfully":                 "cards retrieved successfully",
+	"cashback_has_more_than_one_rule_for_category": "cashback has more than one rule for category %q",
+	"failed_to_create_card":                        "failed to create card",
+	"failed_to_decrypt_card_number":                "failed to decrypt card number",
+	"failed_to_decrypt_expiry_date":                "failed to decrypt expiry date",
+	"failed_to_delete_card":                        "failed to delete card",
+	"failed_to_encrypt_card_number":                "failed to encrypt card number",
+	"failed_to_encrypt_expiry_date":                "failed to encrypt expiry date",
+	"failed_to_get_card":                           "failed to get card",
+	"failed_to_get_card_product":                   "failed to get card product",
+	"failed_to_get_card_products":                  "failed to get card products",
+	"failed_to_get_cards":                          "failed to get cards",
+	"failed_to_hash_cvv":                           "failed to hash CVV",
+	"failed_to_hash_pin":                           "failed to hash PIN",
+	"failed_to_update_card":                        "failed to update card",
+	"invalid_account_type_named":                   "invalid account type %q",
+	"invalid_card_data":                            "invalid card data",
+	"invalid_card_id":                              "invalid card ID",
+	"invalid_card_product":                         "invalid card product",
+	"invalid_card_product_id":                      "invalid card product ID",
+	"invalid_card_type":                            "invalid card type",
+	"invalid_expiry_date":                          "invalid expiry date",
+	"invalid_pin":                                  "invalid PIN",
+	"only_card_payments_can_be_charged_back":       "only card payments can be charged back",
+	"pin_must_be_4_digits":                         "PIN must be 4 digits",
+	"pin_set_successfully":                         "PIN set successfully",
+	"virtual_cards_cannot_be_used_at_atms":         "virtual cards cannot be used at ATMs",
+	"wrong_pin_the_card_is_now_blocked":            "wrong PIN, the card is now blocked",
+}
+
+// transferMessagesEN are the English messages of transactions and transfers
+var transferMessagesEN = map[string]string{
+	"a_note_is_required_to_reject_a_cheque_deposit":                                                "a note is required to reject a cheque deposit",
+	"action_must_be_release_or_refund":                                                             "action must be RELEASE or REFUND",
+	"amount_could_not_be_recognized_on_the_image_please_enter_it":                                  "amount could not be recognized on the image, please enter it",
+	"amount_does_not_cover_the_fees_deducted_from_it":                                              "amount does not cover the fees deducted from it",
+	"amount_must_be_a_positive_number_with_at_most_2_decimal_places":                               "amount must be a positive number with at most 2 decimal places",
+	"amount_must_be_positive":                                                                      "amount must be positive",
+	"amount_must_be_positive_use_withdraw":                                                         "amount must be positive, use the withdraw endpoint for withdrawals",
+	"application_not_pending_for_documents":                                                        "documents can only be added to pending applications",
+	"application_not_pending_for_review":                                                           "only documents of pending applications can be reviewed",
+	"approval_policies_retrieved_successfully":                                                     "approval policies retrieved successfully",
+	"approval_policies_updated_successfully":                                                       "approval policies updated successfully",
+	"beneficiary_bic_and_iban_are_of_different_countries":                                          "beneficiary BIC and IBAN are of different countries",
+	"beneficiary_bic_must_have_8_or_11_characters_bank_country_location_and_optional_branch_codes": "beneficiary BIC must have 8 or 11 characters: bank, country, location and optional branch codes",
+	"beneficiary_iban_is_invalid":                                                                  "beneficiary IBAN is invalid",
+	"beneficiary_name_is_required":                                                                 "beneficiary name is required",
+	"beneficiary_name_must_be_at_most_140_characters":                                              "beneficiary name must be at most 140 characters",
+	"card_payments_can_only_be_charged_back_within_days":                                           "card payments can only be charged back within %s days",
+	"cash_flow_statement_retrieved_successfully":                                                   "cash flow statement retrieved successfully",
+	"cash_withdrawn_successfully":                                                                  "cash withdrawn successfully",
+	"charge_bearer_must_be_our_sha_or_ben":                                                         "charge bearer must be OUR, SHA or BEN",
+	"chargeback_accepted":                                                                          "chargeback accepted",
+	"chargeback_amount_exceeds_the_payment_amount":                                                 "chargeback amount exceeds the payment amount",
+	"chargeback_has_already_been_resolved":                                                         "chargeback has already been resolved",
+	"chargeback_is_already":                                                                        "chargeback is already %s",
+	"chargeback_not_found":                                                                         "chargeback not found",
+	"chargeback_opened":                                                                            "chargeback opened, the amount has been provisionally credited",
+	"chargeback_resolved":                                                                          "chargeback resolved",
+	"chargeback_retrieved_successfully":                                                            "chargeback retrieved successfully",
+	"chargebacks_retrieved_successfully":                                                           "chargebacks retrieved successfully",
+	"cheque_amount_exceeds_the_deposit_limit":                                                      "cheque amount exceeds the deposit limit",
+	"cheque_deposit_cleared_successfully":                                                          "cheque deposit cleared successfully",
+	"cheque_deposit_has_already_been_reviewed":                                                     "cheque deposit has already been reviewed",
+	"cheque_deposit_not_found":                                                                     "cheque deposit not found",
+	"cheque_deposit_rejected_successfully":                                                         "cheque deposit rejected successfully",
+	"cheque_deposit_retrieved_successfully":                                                        "cheque deposit retrieved successfully",
+	"cheque_deposit_submitted_successfully":                                                        "cheque deposit submitted successfully",
+	"cheque_deposits_retrieved_successfully":                                                       "cheque deposits retrieved successfully",
+	"cheque_image_must_be_a_pdf_jpeg_or_png_file":                                                  "cheque image must be a PDF, JPEG or PNG file",
+	"confirmation_code_has_expired":                                                                "confirmation code has expired",
+	"confirmation_id_is_required":                                                                  "confirmation_id is required",
+	"escrow_can_only_be_paid_to_personal_accounts":                                                 "escrow can only be paid to personal accounts",
+	"escrow_confirmed":                                                                             "escrow confirmed",
+	"escrow_funded_the_amount_is_held_until_both_parties_confirm_the_deal":                         "escrow funded, the amount is held until both parties confirm the deal",
+	"escrow_has_already_been_settled":                                                              "escrow has already been settled",
+	"escrow_has_expired_or_has_already_been_settled":                                               "escrow has expired or has already been settled",
+	"escrow_not_found":                                                                             "escrow not found",
+	"escrow_resolved":                                                                              "escrow resolved",
+	"escrow_retrieved_successfully":                                                                "escrow retrieved successfully",
+	"escrows_retrieved_successfully":                                                               "escrows retrieved successfully",
+	"evidence_deadline_has_passed":                                                                 "evidence deadline has passed",
+	"evidence_is_required":                                                                         "evidence is required",
+	"evidence_must_be_at_most_10000_characters":                                                    "evidence must be at most 10000 characters",
+	"evidence_submitted_the_chargeback_is_under_review":                                            "evidence submitted, the chargeback is under review",
+	"failed_to_approve_transfer":                                                                   "failed to approve transfer",
+	"failed_to_begin_transaction":                                                                  "failed to begin transaction",
+	"failed_to_charge_card_issue_fee":                                                              "failed to charge card issue fee",
+	"failed_to_commit_transaction":                                                                 "failed to commit transaction",
+	"failed_to_confirm_escrow":                                                                     "failed to confirm escrow",
+	"failed_to_confirm_transfer":                                                                   "failed to confirm transfer",
+	"failed_to_create_bonus_transaction":                                                           "failed to create bonus transaction",
+	"failed_to_create_escrow":                                                                      "failed to create escrow",
+	"failed_to_create_transaction_record":                                                          "failed to create transaction record",
+	"failed_to_generate_confirmation_code":                                                         "failed to generate confirmation code",
+	"failed_to_generate_confirmation_id":                                                           "failed to generate confirmation ID",
+	"failed_to_get_approval_policies":                                                              "failed to get approval policies",
+	"failed_to_get_approvals":                                                                      "failed to get approvals",
+	"failed_to_get_chargeback":                                                                     "failed to get chargeback",
+	"failed_to_get_chargebacks":                                                                    "failed to get chargebacks",
+	"failed_to_get_cheque_deposits":                                                                "failed to get cheque deposits",
+	"failed_to_get_escrow":                                                                         "failed to get escrow",
+	"failed_to_get_escrows":                                                                        "failed to get escrows",
+	"failed_to_get_expired_escrows":                                                                "failed to get expired escrows",
+	"failed_to_get_international_transfers":                                                        "failed to get international transfers",
+	"failed_to_get_pending_payments":                                                               "failed to get pending payments",
+	"failed_to_get_pending_transfer":                                                               "failed to get pending transfer",
+	"failed_to_get_pending_transfers":                                                              "failed to get pending transfers",
+	"failed_to_get_transaction":                                                                    "failed to get transaction",
+	"failed_to_get_transactions":                                                                   "failed to get transactions",
+	"failed_to_hash_confirmation_code":                                                             "failed to hash confirmation code",
+	"failed_to_reject_transfer":                                                                    "failed to reject transfer",
+	"failed_to_send_chargeback_notification":                                                       "failed to send chargeback notification",
+	"failed_to_send_transaction_notification":                                                      "failed to send transaction notification",
+	"failed_to_send_transfer_confirmation_code":                                                    "failed to send transfer confirmation code",
+	"failed_to_store_cheque_image":                                                                 "failed to store cheque image",
+	"failed_to_update_approval_policies":                                                           "failed to update approval policies",
+	"insufficient_funds":                                                                           "insufficient funds",
+	"insufficient_funds_for_the_card_issue_fee":                                                    "insufficient funds for the card issue fee",
+	"international_transfer_not_found":                                                             "international transfer not found",
+	"international_transfer_quoted_successfully":                                                   "international transfer quoted successfully",
+	"international_transfer_retrieved_successfully":                                                "international transfer retrieved successfully",
+	"international_transfer_submitted_track_it_by_its_reference":                                   "international transfer submitted, track it by its reference",
+	"international_transfer_tracking_retrieved_successfully":                                       "international transfer tracking retrieved successfully",
+	"international_transfers_retrieved_successfully":                                               "international transfers retrieved successfully",
+	"invalid_amount":                                                                               "invalid amount",
+	"invalid_approval_policies":                                                                    "invalid approval policies",
+	"invalid_cash_flow_query":                                                                      "invalid cash flow query",
+	"invalid_chargeback_data":                                                                      "invalid chargeback data",
+	"invalid_chargeback_id":                                                                        "invalid chargeback ID",
+	"invalid_chargeback_reason":                                                                    "reason must be one of NOT_RECEIVED, NOT_AS_DESCRIBED, DUPLICATE, FRAUD, OTHER",
+	"invalid_cheque_deposit":                                                                       "invalid cheque deposit",
+	"invalid_cheque_deposit_id":                                                                    "invalid cheque deposit ID",
+	"invalid_confirmation_code":                                                                    "invalid confirmation code",
+	"invalid_confirmation_request":                                                                 "invalid confirmation request",
+	"invalid_escrow_data":                                                                          "invalid escrow data",
+	"invalid_escrow_id":                                                                            "invalid escrow ID",
+	"invalid_escrow_resolution":                                                                    "invalid escrow resolution",
+	"invalid_evidence":                                                                             "invalid evidence",
+	"invalid_international_transfer_data":                                                          "invalid international transfer data",
+	"invalid_international_transfer_id":                                                            "invalid international transfer ID",
+	"invalid_max_amount":                                                                           "invalid max_amount",
+	"invalid_min_amount":                                                                           "invalid min_amount",
+	"invalid_pending_transfer_id":                                                                  "invalid pending transfer ID",
+	"invalid_repeat_request":                                                                       "invalid repeat request",
+	"invalid_resolution":                                                                           "invalid resolution",
+	"invalid_transaction_description":                                                              "invalid transaction description",
+	"invalid_transaction_id":                                                                       "invalid transaction ID",
+	"invalid_transaction_no_accounts":                                                              "invalid transaction: no source or destination account",
+	"invalid_transfer_request":                                                                     "invalid transfer request",
+	"min_amount_cannot_be_greater_than_max_amount":                                                 "min_amount cannot be greater than max_amount",
+	"min_amount_must_be_positive":                                                                  "min_amount must be positive",
+	"min_amount_values_must_be_unique":                                                             "min_amount values must be unique",
+	"number_of_transactions_does_not_match_the_credit_transfers":                                   "number of transactions does not match the credit transfers",
+	"pending_transfer_not_found":                                                                   "pending transfer not found",
+	"pending_transfer_retrieved_successfully":                                                      "pending transfer retrieved successfully",
+	"pending_transfers_retrieved_successfully":                                                     "pending transfers retrieved successfully",
+	"purpose_is_required":                                                                          "purpose is required",
+	"purpose_must_be_at_most_140_characters":                                                       "purpose must be at most 140 characters",
+	"recipient_not_found":                                                                          "recipient not found",
+	"requested_execution_date_is_invalid":                                                          "requested execution date is invalid",
+	"required_approvals_must_be_between_1_and_10":                                                  "required_approvals must be between 1 and 10",
+	"status_must_be_one_of_pending_approval_completed_rejected":                                    "status must be one of PENDING_APPROVAL, COMPLETED, REJECTED",
+	"status_must_be_one_of_pending_charged_dunning":                                                "status must be one of PENDING, CHARGED, DUNNING",
+	"too_many_invalid_codes_transfer_cancelled":                                                    "too many invalid codes, transfer cancelled",
+	"transaction_description_history_retrieved_successfully":                                       "transaction description history retrieved successfully",
+	"transaction_description_updated_successfully":                                                 "transaction description updated successfully",
+	"transaction_has_no_source_account":                                                            "withdrawal/payment/transfer transaction has no source account",
+	"transaction_id_is_required":                                                                   "transaction_id is required",
+	"transaction_not_found":                                                                        "transaction not found",
+	"transaction_retrieved_successfully":                                                           "transaction retrieved successfully",
+	"transaction_volume_retrieved_successfully":                                                    "transaction volume retrieved successfully",
+	"transactions_found_successfully":                                                              "transactions found successfully",
+	"transactions_retrieved_successfully":                                                          "transactions retrieved successfully",
+	"transfer_approval_has_expired":                                                                "transfer approval has expired",
+	"transfer_completed_successfully":                                                              "transfer completed successfully",
+	"transfer_confirmation_is_no_longer_pending":                                                   "transfer confirmation is no longer pending",
+	"transfer_confirmation_not_found":                                                              "transfer confirmation not found",
+	"transfer_is_no_longer_pending_approval":                                                       "transfer is no longer pending approval",
+	"transfer_is_waiting_for_approval":                                                             "transfer is waiting for approval",
+	"transfer_limit_is_only_allowed_for_the_transfer_scope":                                        "transfer_limit is only allowed for the TRANSFER scope",
+	"transfer_limit_must_be_positive_for_the_transfer_scope":                                       "transfer_limit must be positive for the TRANSFER scope",
+	"transfer_rejected":                                                                            "transfer rejected",
+	"transfer_requires_confirmation":                                                               "transfer requires confirmation, a code has been sent to your email",
+	"you_cannot_approve_your_own_transfer":                                                         "you cannot approve your own transfer",
+}
+
+// creditMessagesEN are the English messages of credits, their schedules and insurance
+var creditMessagesEN = map[string]string{
+	"accepted_documents_are_missing":                    "accepted documents are missing: %s",
+	"amount_exceeds_the_penalty_of":                     "amount exceeds the penalty of %s",
+	"control_sum_does_not_match_the_credit_transfers":   "control sum does not match the credit transfers",
+	"credit_accounts_have_no_plan":                      "credit accounts have no plan",
+	"credit_agreement_has_changed_request_a_new_code":   "credit agreement has changed, request a new code",
+	"credit_agreement_is_already_signed":                "credit agreement is already signed",
+	"credit_agreement_retrieved_successfully":           "credit agreement retrieved successfully",
+	"credit_agreement_signed_successfully":              "credit agreement signed successfully",
+	"credit_analytics_retrieved_successfully":           "credit analytics retrieved successfully",
+	"credit_application_is_already":                     "credit application is already %s",
+	"credit_application_is_no_longer_pending":           "credit application is no longer pending",
+	"credit_application_not_found":                      "credit application not found",
+	"credit_application_not_found_or_no_longer_pending": "credit application not found or no longer pending",
+	"credit_application_retrieved_successfully":         "credit application retrieved successfully",
+	"credit_application_submitted_successfully":         "credit application submitted successfully",
+	"credit_applications_retrieved_successfully":        "credit applications retrieved successfully",
+	"credit_calculated_successfully":                    "credit calculated successfully",
+	"credit_created_successfully":                       "credit created successfully",
+	"credit_document_not_found":                         "credit document not found",
+	"credit_has_no_active_insurance":                    "credit has no active insurance",
+	"credit_has_no_payments_left":                       "credit has no payments left",
+	"credit_has_no_unpaid_payments":                     "credit has no unpaid payments",
+	"credit_has_overdue_payments_pay_them_first":        "credit has overdue payments, pay them first",
+	"credit_has_penalties_waive_them_first":             "credit has payments with penalties, waive them first",
+	"credit_insurance_cancelled_successfully":           "credit insurance cancelled successfully",
+	"credit_insurance_is_not_offered_at_the_moment":     "credit insurance is not offered at the moment",
+	"credit_is": "credit is %s",
+	"credit_is_only_active_credits_can_be_repaid_early": "credit is %s, only active credits can be repaid early",
+	"credit_not_found":                                         "credit not found",
+	"credit_portfolio_export_not_found":                        "credit portfolio export not found",
+	"credit_portfolio_export_started":                          "credit portfolio export started",
+	"credit_portfolio_retrieved_successfully":                  "credit portfolio retrieved successfully",
+	"credit_restructured_successfully":                         "credit restructured successfully",
+	"credit_retrieved_successfully":                            "credit retrieved successfully",
+	"credit_simulation_retrieved_successfully":                 "credit simulation retrieved successfully",
+	"credit_transfer_amount_must_be_a_positive_decimal_number": "credit transfer amount must be a positive decimal number",
+	"credit_transfer_currency_is_required":                     "credit transfer currency is required",
+	"credits_are_available_from_the_age_of_18":                 "credits are available from the age of 18",
+	"credits_issued_retrieved_successfully":                    "credits issued retrieved successfully",
+	"credits_require_supporting_documents":                     "credits of %s or more require supporting documents: submit a credit application via /api/credit-applications",
+	"credits_retrieved_successfully":                           "credits retrieved successfully",
+	"extra_payment_must_be_a_number":                           "extra_payment must be a number",
+	"extra_payment_must_be_positive":                           "extra_payment must be positive",
+	"failed_to_build_credit_portfolio_export":                  "failed to build credit portfolio export",
+	"failed_to_create_credit":                                  "failed to create credit",
+	"failed_to_create_credit_account":                          "failed to create credit account",
+	"failed_to_generate_signing_code":                          "failed to generate signing code",
+	"failed_to_get_credit":                                     "failed to get credit",
+	"failed_to_get_credit_account":                             "failed to get credit account",
+	"failed_to_get_credit_analytics":                           "failed to get credit analytics",
+	"failed_to_get_credit_applications":                        "failed to get credit applications",
+	"failed_to_get_credits":                                    "failed to get credits",
+	"failed_to_get_overdue_chargebacks":                        "failed to get overdue chargebacks",
+	"failed_to_hash_signing_code":                              "failed to hash signing code",
+	"failed_to_issue_credit":                                   "failed to issue credit",
+	"failed_to_read_credit_portfolio_export":                   "failed to read credit portfolio export",
+	"failed_to_send_credit_adjustment_notification":            "failed to send credit adjustment notification",
+	"failed_to_send_credit_application_notification":           "failed to send credit application notification",
+	"failed_to_send_credit_approval_notification":              "failed to send credit approval notification",
+	"failed_to_send_credit_signature_code":                     "failed to send credit signature code",
+	"failed_to_update_credit_account_balance":                  "failed to update credit account balance",
+	"interest_rate_cannot_be_negative":                         "interest rate cannot be negative",
+	"interest_rate_must_be_at_most_100":                        "interest rate must be at most 100",
+	"interest_rate_out_of_range":                               "interest rate must be greater than 0 and at most 100",
+	"invalid_adjustment_reason":                                "reason must be one of FINANCIAL_HARDSHIP, BANK_ERROR, GOODWILL, BORROWER_REQUEST, OTHER",
+	"invalid_credit_application_id":                            "invalid credit application ID",
+	"invalid_credit_id":                                        "invalid credit ID",
+	"invalid_credit_request":                                   "invalid credit request",
+	"invalid_credit_simulation":                                "invalid credit simulation",
+	"invalid_penalty_waiver":                                   "invalid penalty waiver",
+	"invalid_reschedule":                                       "invalid reschedule",
+	"invalid_restructuring":                                    "invalid restructuring",
+	"invalid_signature_request":                                "invalid signature request",
+	"invalid_signing_code":                                     "invalid signing code",
+	"only_credit_transfers_payment_method_trf_are_supported":   "only credit transfers (payment method TRF) are supported",
+	"payment_date_must_be_before_the_next_payment_on":          "payment_date must be before the next payment on %s",
+	"payment_is":                           "payment is %s",
+	"payoff_quote_calculated_successfully": "payoff quote calculated successfully",
+	"penalty_waived_successfully":          "penalty waived successfully",
+	"segment_audience_must_be_one_of_all_active_credits_overdue_credits": "segment.audience must be one of ALL, ACTIVE_CREDITS, OVERDUE_CREDITS",
+	"signature_request_is_no_longer_pending":                             "signature request is no longer pending",
+	"signature_request_not_found":                                        "signature request not found",
+	"signing_code_has_expired":                                           "signing code has expired",
+	"signing_code_sent_confirm_it_to_sign_the_agreement":                 "signing code sent, confirm it to sign the agreement",
+	"term_months_must_be_between_1_and":                                  "term_months must be between 1 and %s",
+	"term_must_be_between_1_and_360_months":                              "term must be between 1 and 360 months",
+	"too_many_invalid_codes_signing_cancelled":                           "too many invalid codes, signing cancelled",
+}
+
+// paymentMessagesEN are the English messages of bills, invoices and subscriptions
+var paymentMessagesEN = map[string]string{
+	"amount_must_be_at_least":                                                 "amount must be at least %s",
+	"amount_must_be_at_most":                                                  "amount must be at most %s",
+	"bill_paid_successfully":                                                  "bill paid successfully",
+	"bill_payments_retrieved_successfully":                                    "bill payments retrieved successfully",
+	"bill_provider_not_found":                                                 "bill provider not found",
+	"bill_provider_retrieved_successfully":                                    "bill provider retrieved successfully",
+	"bill_providers_retrieved_successfully":                                   "bill providers retrieved successfully",
+	"bill_template_created_successfully":                                      "bill template created successfully",
+	"bill_template_deleted_successfully":                                      "bill template deleted successfully",
+	"bill_template_not_found":                                                 "bill template not found",
+	"bill_templates_retrieved_successfully":                                   "bill templates retrieved successfully",
+	"bills_can_only_be_paid_from_rub_accounts":                                "bills can only be paid from RUB accounts",
+	"customer_id_is_required":                                                 "customer_id is required",
+	"customer_not_found":                                                      "customer not found",
+	"due_date_must_be_at_most_a_year_ahead":                                   "due_date must be at most a year ahead",
+	"due_date_must_be_in_yyyy_mm_dd_format":                                   "due_date must be in YYYY-MM-DD format",
+	"due_date_must_not_be_in_the_past":                                        "due_date must not be in the past",
+	"failed_to_cancel_invoice":                                                "failed to cancel invoice",
+	"failed_to_cancel_payment_intent":                                         "failed to cancel payment intent",
+	"failed_to_create_bill_template":                                          "failed to create bill template",
+	"failed_to_create_invoice":                                                "failed to create invoice",
+	"failed_to_create_invoice_item":                                           "failed to create invoice item",
+	"failed_to_create_payment_intent":                                         "failed to create payment intent",
+	"failed_to_create_payment_schedule":                                       "failed to create payment schedule",
+	"failed_to_create_payment_transactions":                                   "failed to create payment transactions",
+	"failed_to_delete_bill_template":                                          "failed to delete bill template",
+	"failed_to_generate_invoice_number":                                       "failed to generate invoice number",
+	"failed_to_generate_payment_intent_id":                                    "failed to generate payment intent ID",
+	"failed_to_get_bill_payment":                                              "failed to get bill payment",
+	"failed_to_get_bill_payments":                                             "failed to get bill payments",
+	"failed_to_get_bill_provider":                                             "failed to get bill provider",
+	"failed_to_get_bill_providers":                                            "failed to get bill providers",
+	"failed_to_get_bill_template":                                             "failed to get bill template",
+	"failed_to_get_bill_templates":                                            "failed to get bill templates",
+	"failed_to_get_invoice":                                                   "failed to get invoice",
+	"failed_to_get_invoice_items":                                             "failed to get invoice items",
+	"failed_to_get_invoices":                                                  "failed to get invoices",
+	"failed_to_get_payment_intent":                                            "failed to get payment intent",
+	"failed_to_get_payment_intents":                                           "failed to get payment intents",
+	"failed_to_get_payment_schedule":                                          "failed to get payment schedule",
+	"failed_to_get_payments_to_remind":                                        "failed to get payments to remind",
+	"failed_to_get_subscription":                                              "failed to get subscription",
+	"failed_to_get_subscription_plan":                                         "failed to get subscription plan",
+	"failed_to_get_subscription_plans":                                        "failed to get subscription plans",
+	"failed_to_get_subscription_report":                                       "failed to get subscription report",
+	"failed_to_get_subscriptions":                                             "failed to get subscriptions",
+	"failed_to_mark_invoice_paid":                                             "failed to mark invoice paid",
+	"failed_to_mark_invoices_overdue":                                         "failed to mark invoices overdue",
+	"failed_to_record_bill_payment":                                           "failed to record bill payment",
+	"failed_to_send_payment_reminder":                                         "failed to send payment reminder",
+	"field_is_required":                                                       "%s is required",
+	"invalid_bill_payment":                                                    "invalid bill payment",
+	"invalid_bill_template":                                                   "invalid bill template",
+	"invalid_customer_id":                                                     "invalid customer_id",
+	"invalid_field":                                                           "invalid %s",
+	"invalid_invoice_data":                                                    "invalid invoice data",
+	"invalid_payment_id":                                                      "invalid payment ID",
+	"invalid_payment_intent":                                                  "invalid payment intent",
+	"invalid_payment_request":                                                 "invalid payment request",
+	"invalid_provider_id":                                                     "invalid provider ID",
+	"invalid_subscription_id":                                                 "invalid subscription ID",
+	"invalid_subscription_plan_data":                                          "invalid subscription plan data",
+	"invalid_subscription_plan_id":                                            "invalid subscription plan ID",
+	"invoice_cancelled_successfully":                                          "invoice cancelled successfully",
+	"invoice_is_no_longer_payable":                                            "invoice is no longer payable",
+	"invoice_issued_successfully":                                             "invoice issued successfully",
+	"invoice_items_must_not_be_empty":                                         "invoice items must not be empty",
+	"invoice_must_have_at_least_one_item":                                     "invoice must have at least one item",
+	"invoice_must_have_at_most_100_items":                                     "invoice must have at most 100 items",
+	"invoice_not_found":                                                       "invoice not found",
+	"invoice_paid_successfully":                                               "invoice paid successfully",
+	"invoice_retrieved_successfully":                                          "invoice retrieved successfully",
+	"invoice_total_is_too_large":                                              "invoice total is too large",
+	"invoices_retrieved_successfully":                                         "invoices retrieved successfully",
+	"item_description_is_required":                                            "item description is required",
+	"item_description_must_be_at_most_255_characters":                         "item description must be at most 255 characters",
+	"item_quantity_must_be_positive":                                          "item quantity must be positive",
+	"item_unit_price_must_be_a_positive_number_with_at_most_2_decimal_places": "item unit_price must be a positive number with at most 2 decimal places",
+	"no_payments_to_settle":                                                   "no payments to settle",
+	"only_customers_can_be_impersonated":                                      "only customers can be impersonated",
+	"only_unpaid_invoices_can_be_cancelled":                                   "only unpaid invoices can be cancelled",
+	"payment_completed_successfully":                                          "payment completed successfully",
+	"payment_currency_does_not_match_the_payroll_currency":                    "payment currency does not match the payroll currency",
+	"payment_date_must_be_after_current":                                      "payment_date must be after the current payment date",
+	"payment_date_must_be_in_the_future":                                      "payment_date must be in the future",
+	"payment_date_must_be_in_yyyy_mm_dd_format":                               "payment_date must be in YYYY-MM-DD format",
+	"payment_has_a_penalty_waive_it_first":                                    "payment has a penalty, waive it first",
+	"payment_has_already_been_charged_back":                                   "payment has already been charged back",
+	"payment_has_no_penalty":                                                  "payment has no penalty",
+	"payment_has_not_been_settled_to_the_merchant_yet":                        "payment has not been settled to the merchant yet",
+	"payment_intent_cancelled":                                                "payment intent cancelled",
+	"payment_intent_created_successfully":                                     "payment intent created successfully",
+	"payment_intent_is_no_longer_payable":                                     "payment intent is no longer payable",
+	"payment_intent_not_found":                                                "payment intent not found",
+	"payment_intent_retrieved_successfully":                                   "payment intent retrieved successfully",
+	"payment_intents_retrieved_successfully":                                  "payment intents retrieved successfully",
+	"payment_not_found":                                                       "payment not found",
+	"payment_rescheduled_successfully":                                        "payment rescheduled successfully",
+	"payment_schedule_not_found":                                              "payment schedule not found",
+	"payment_schedule_retrieved_successfully":                                 "payment schedule retrieved successfully",
+	"payment_verification_secret_retrieved_successfully":                      "payment verification secret retrieved successfully",
+	"payment_verified":                                                        "payment verified",
+	"provider_id_is_required":                                                 "provider_id is required",
+	"provider_is_not_available":                                               "provider is not available",
+	"subscribed_successfully":                                                 "subscribed successfully",
+	"subscription_cancelled_successfully":                                     "subscription cancelled successfully",
+	"subscription_is_already_cancelled":                                       "subscription is already cancelled",
+	"subscription_not_found":                                                  "subscription not found",
+	"subscription_plan_created_successfully":                                  "subscription plan created successfully",
+	"subscription_plan_deactivated_successfully":                              "subscription plan deactivated successfully",
+	"subscription_plan_not_found":                                             "subscription plan not found",
+	"subscription_plan_retrieved_successfully":                                "subscription plan retrieved successfully",
+	"subscription_plans_retrieved_successfully":                               "subscription plans retrieved successfully",
+	"subscription_report_retrieved_successfully":                              "subscription report retrieved successfully",
+	"subscriptions_can_only_be_paid_from_your_own_personal_accounts":          "subscriptions can only be paid from your own personal accounts",
+	"subscriptions_retrieved_successfully":                                    "subscriptions retrieved successfully",
+	"unknown_category":                                                        "unknown category: %s",
+	"unknown_field":                                                           "unknown field %s",
+	"you_cannot_issue_an_invoice_to_yourself":                                 "you cannot issue an invoice to yourself",
+}
+
+// merchantMessagesEN are the English messages of merchants, payment intents and settlements
+var merchantMessagesEN = map[string]string{
+	"failed_to_create_merchant":                       "failed to create merchant",
+	"failed_to_create_settlement_transaction":         "failed to create settlement transaction",
+	"failed_to_get_merchant":                          "failed to get merchant",
+	"failed_to_get_merchants":                         "failed to get merchants",
+	"failed_to_get_merchants_to_settle":               "failed to get merchants to settle",
+	"failed_to_get_settlement_account":                "failed to get settlement account",
+	"failed_to_get_settlements":                       "failed to get settlements",
+	"failed_to_settle_escrow":                         "failed to settle escrow",
+	"failed_to_update_settlement_account_balance":     "failed to update settlement account balance",
+	"in_favor_of_must_be_cardholder_or_merchant":      "in_favor_of must be CARDHOLDER or MERCHANT",
+	"invalid_merchant_data":                           "invalid merchant data",
+	"invalid_merchant_id":                             "invalid merchant ID",
+	"invalid_receipt_token":                           "invalid receipt token",
+	"merchant_created_successfully":                   "merchant created successfully, store the API key securely",
+	"merchant_id_not_found_in_context":                "merchant ID not found in context",
+	"merchant_is_inactive":                            "merchant is inactive",
+	"merchant_not_found":                              "merchant not found",
+	"merchant_retrieved_successfully":                 "merchant retrieved successfully",
+	"merchants_retrieved_successfully":                "merchants retrieved successfully",
+	"only_merchant_card_payments_can_be_charged_back": "only card payments to merchants can be charged back",
+	"order_reference_must_be_at_most_100_characters":  "order_reference must be at most 100 characters",
+	"payment_intent_is_already":                       "payment intent is already %s",
+	"payment_intent_must_be_paid_from_an_account_in":  "payment intent must be paid from a %s account",
+	"reason_must_be_one_of_estate_court_order_other":  "reason must be one of ESTATE, COURT_ORDER, OTHER",
+	"receipt_is_genuine":                              "receipt is genuine",
+	"receipt_not_found":                               "receipt not found",
+	"settlement_account_id_is_required":               "settlement_account_id is required",
+	"settlement_account_is_inactive":                  "settlement account is inactive",
+	"settlement_account_must_be_a_rub_account":        "settlement account must be a RUB account",
+	"settlements_retrieved_successfully":              "settlements retrieved successfully",
+	"sort_order_cannot_be_negative":                   "sort order cannot be negative",
+}
+
+// organizationMessagesEN are the English messages of organizations and their members
+var organizationMessagesEN = map[string]string{
+	"failed_to_create_invitation":                                             "failed to create invitation",
+	"failed_to_create_organization":                                           "failed to create organization",
+	"failed_to_create_payroll":                                                "failed to create payroll",
+	"failed_to_create_payroll_item":                                           "failed to create payroll item",
+	"failed_to_get_invitation":                                                "failed to get invitation",
+	"failed_to_get_invitations":                                               "failed to get invitations",
+	"failed_to_get_members":                                                   "failed to get members",
+	"failed_to_get_organization":                                              "failed to get organization",
+	"failed_to_get_organizations":                                             "failed to get organizations",
+	"failed_to_get_payroll":                                                   "failed to get payroll",
+	"failed_to_get_payroll_items":                                             "failed to get payroll items",
+	"failed_to_get_payrolls":                                                  "failed to get payrolls",
+	"failed_to_join_organization":                                             "failed to join organization",
+	"failed_to_notify_invitee":                                                "failed to notify invitee",
+	"failed_to_remove_member":                                                 "failed to remove member",
+	"failed_to_send_organization_invitation":                                  "failed to send organization invitation",
+	"failed_to_update_invitation":                                             "failed to update invitation",
+	"failed_to_update_member_role":                                            "failed to update member role",
+	"failed_to_update_payroll_item":                                           "failed to update payroll item",
+	"failed_to_update_payroll_settlement":                                     "failed to update payroll settlement",
+	"failed_to_update_payroll_status":                                         "failed to update payroll status",
+	"failed_to_write_payroll_report":                                          "failed to write payroll report",
+	"invalid_invitation":                                                      "invalid invitation",
+	"invalid_invitation_id":                                                   "invalid invitation ID",
+	"invalid_organization_data":                                               "invalid organization data",
+	"invalid_organization_id":                                                 "invalid organization ID",
+	"invalid_payroll":                                                         "invalid payroll",
+	"invalid_payroll_id":                                                      "invalid payroll ID",
+	"invalid_staff_id":                                                        "invalid staff_id",
+	"invitation_has_expired":                                                  "invitation has expired",
+	"invitation_is_no_longer_pending":                                         "invitation is no longer pending",
+	"invitation_not_found":                                                    "invitation not found",
+	"invitation_revoked_successfully":                                         "invitation revoked successfully",
+	"invitation_sent_successfully":                                            "invitation sent successfully",
+	"invitations_retrieved_successfully":                                      "invitations retrieved successfully",
+	"member_removed_successfully":                                             "member removed successfully",
+	"member_role_updated_successfully":                                        "member role updated successfully",
+	"none_of_the_payroll_accounts_can_receive_payments":                       "none of the payroll accounts can receive payments",
+	"organization_account_cannot_be_default":                                  "an organization account cannot be a default account",
+	"organization_accounts_cannot_change_owners":                              "organization accounts cannot change owners",
+	"organization_created_successfully":                                       "organization created successfully",
+	"organization_not_found":                                                  "organization not found",
+	"organization_retrieved_successfully":                                     "organization retrieved successfully",
+	"organizations_retrieved_successfully":                                    "organizations retrieved successfully",
+	"payroll_executed_successfully":                                           "payroll executed successfully",
+	"payroll_file_has_more_than_5000_payments":                                "payroll file has more than 5000 payments",
+	"payroll_file_has_no_payments":                                            "payroll file has no payments",
+	"payroll_file_is_larger_than_1_mb":                                        "payroll file is larger than 1 MB",
+	"payroll_file_is_not_a_valid_csv_file":                                    "payroll file is not a valid CSV file",
+	"payroll_has_invalid_lines_fix_the_file_and_upload_it_again":              "payroll has invalid lines, fix the file and upload it again",
+	"payroll_is_already_executed":                                             "payroll is already executed",
+	"payroll_item_not_found":                                                  "payroll item not found",
+	"payroll_must_be_executed_by_a_member_other_than_the_one_who_uploaded_it": "payroll must be executed by a member other than the one who uploaded it",
+	"payroll_not_found":                                                       "payroll not found",
+	"payroll_retrieved_successfully":                                          "payroll retrieved successfully",
+	"payroll_uploaded_successfully":                                           "payroll uploaded successfully",
+	"payrolls_retrieved_successfully":                                         "payrolls retrieved successfully",
+}
+
+// supportMessagesEN are the English messages of support messages, announcements and branch locations
+var supportMessagesEN = map[string]string{
+	"announcement_cancelled_successfully":                       "announcement cancelled successfully",
+	"announcement_not_found":                                    "announcement not found",
+	"announcement_queued_successfully":                          "announcement queued successfully",
+	"announcement_retrieved_successfully":                       "announcement retrieved successfully",
+	"announcements_can_only_reach_the_customers_of_your_tenant": "announcements can only reach the customers of your tenant",
+	"announcements_retrieved_successfully":                      "announcements retrieved successfully",
+	"body_is_required":                                          "body is required",
+	"body_must_be_at_most_5000_characters":                      "body must be at most 5000 characters",
+	"color_must_be_in_rrggbb_format":                            "color must be in #RRGGBB format",
+	"failed_to_count_unread_messages":                           "failed to count unread messages",
+	"failed_to_get_announcements":                               "failed to get announcements",
+	"failed_to_get_locations":                                   "failed to get locations",
+	"failed_to_get_message_threads":                             "failed to get message threads",
+	"failed_to_search_transactions":                             "failed to search transactions",
+	"failed_to_send_new_message_email":                          "failed to send new message email",
+	"invalid_announcement_data":                                 "invalid announcement data",
+	"invalid_announcement_id":                                   "invalid announcement ID",
+	"invalid_location":                                          "invalid location",
+	"invalid_location_id":                                       "invalid location ID",
+	"invalid_message_data":                                      "invalid message data",
+	"invalid_message_id":                                        "invalid message ID",
+	"invalid_search_data":                                       "invalid search data",
+	"invalid_thread_id":                                         "invalid thread ID",
+	"lat_is_required_and_must_be_a_number":                      "lat is required and must be a number",
+	"latitude_must_be_between_90_and_90":                        "latitude must be between -90 and 90",
+	"lng_is_required_and_must_be_a_number":                      "lng is required and must be a number",
+	"location_created_successfully":                             "location created successfully",
+	"location_deleted_successfully":                             "location deleted successfully",
+	"location_not_found":                                        "location not found",
+	"location_updated_successfully":                             "location updated successfully",
+	"locations_retrieved_successfully":                          "locations retrieved successfully",
+	"longitude_must_be_between_180_and_180":                     "longitude must be between -180 and 180",
+	"message_creation_time_is_invalid":                          "message creation time is invalid",
+	"message_has_no_credit_transfers":                           "message has no credit transfers",
+	"message_id_is_required":                                    "message ID is required",
+	"message_id_or_email_is_required":                           "message_id or email is required",
+	"message_is_required_and_must_be_at_most_5000_characters":   "message is required and must be at most 5000 characters",
+	"message_sent_successfully":                                 "message sent successfully",
+	"message_thread_not_found":                                  "message thread not found",
+	"message_thread_retrieved_successfully":                     "message thread retrieved successfully",
+	"message_threads_retrieved_successfully":                    "message threads retrieved successfully",
+	"offset_and_limit_must_not_go_past_the_first_results_narrow_the_search_instead": "offset and limit must not go past the first %s results, narrow the search instead",
+	"only_a_queued_announcement_can_be_cancelled":                                   "only a queued announcement can be cancelled",
+	"opening_hours_must_be_hh_mm_hh_mm_24h_or_closed":                               "opening_hours: %s must be HH:MM-HH:MM, 24h or closed",
+	"opening_hours_unknown_day":                                                     "opening_hours: unknown day %q, expected mon..sun",
+	"query_must_be_at_most_characters":                                              "query must be at most %s characters",
+	"radius_must_be_a_number_of_km_between_0_and":                                   "radius must be a number of km between 0 and %s",
+	"subject_is_required":                                                           "subject is required",
+	"subject_must_be_at_most_200_characters":                                        "subject must be at most 200 characters",
+	"title_is_required_and_must_be_at_most_200_characters":                          "title is required and must be at most 200 characters",
+	"unknown_placeholder":                                                           "unknown placeholder %s, expected one of {{name}}, {{first_name}}, {{last_name}}, {{username}}",
+	"unknown_service":                                                               "unknown service %q",
+	"unread_messages_counted_successfully":                                          "unread messages counted successfully",
+}
+
+// adminMessagesEN are the English messages of reports, rates, fees and other admin tools
+var adminMessagesEN = map[string]string{
+	"configuration_reloaded_successfully":            "configuration reloaded successfully",
+	"dashboard_retrieved_successfully":               "dashboard retrieved successfully",
+	"effective_from_must_be_in_yyyy_mm_dd_format":    "effective_from must be in YYYY-MM-DD format",
+	"effective_to_cannot_be_before_effective_from":   "effective_to cannot be before effective_from",
+	"effective_to_must_be_in_yyyy_mm_dd_format":      "effective_to must be in YYYY-MM-DD format",
+	"failed_to_build_accounting_export":              "failed to build accounting export",
+	"failed_to_generate_storage_key":                 "failed to generate storage key",
+	"failed_to_get_credit_portfolio_report":          "failed to get credit portfolio report",
+	"failed_to_get_credits_issued_report":            "failed to get credits issued report",
+	"failed_to_get_dashboard_report":                 "failed to get dashboard report",
+	"failed_to_get_deposit_balances_report":          "failed to get deposit balances report",
+	"failed_to_get_fee_runs":                         "failed to get fee runs",
+	"failed_to_get_key_rate":                         "failed to get key rate",
+	"failed_to_get_key_rate_overrides":               "failed to get key rate overrides",
+	"failed_to_get_new_users_report":                 "failed to get new users report",
+	"failed_to_get_rate_history":                     "failed to get rate history",
+	"failed_to_get_transaction_volume_report":        "failed to get transaction volume report",
+	"fee_charges_retrieved_successfully":             "fee charges retrieved successfully",
+	"fee_runs_retrieved_successfully":                "fee runs retrieved successfully",
+	"invalid_key_rate_override":                      "invalid key rate override",
+	"invalid_key_rate_override_id":                   "invalid key rate override ID",
+	"key_rate_override_created_successfully":         "key rate override created successfully",
+	"key_rate_override_not_found_or_revoked_already": "key rate override not found or revoked already",
+	"key_rate_override_revoked_successfully":         "key rate override revoked successfully",
+	"key_rate_overrides_retrieved_successfully":      "key rate overrides retrieved successfully",
+	"key_rate_retrieved_successfully":                "key rate retrieved successfully",
+	"maintenance_state_retrieved_successfully":       "maintenance state retrieved successfully",
+	"maintenance_state_updated_successfully":         "maintenance state updated successfully",
+	"outbound_http_stats_retrieved_successfully":     "outbound http stats retrieved successfully",
+	"rate_history_retrieved_successfully":            "rate history retrieved successfully",
+	"rate_limit_exceeded":                            "rate limit exceeded",
+	"rate_must_be_above_0_and_at_most_100":           "rate must be above 0 and at most 100",
+	"service_is_under_maintenance":                   "service is under maintenance",
+	"the_period_overlaps_key_rate_override":          "the period overlaps key rate override %s",
+	"unknown_tenant":                                 "unknown tenant",
+	"unknown_tenant_named":                           "unknown tenant %q",
+}
@@ -0,0 +1,1090 @@
+package i18n
+
+// messagesRU are the Russian API messages keyed by their codes, one map per feature
+var messagesRU = []map[string]string{
+	commonMessagesRU,
+	accessMessagesRU,
+	authMessagesRU,
+	userMessagesRU,
+	accountMessagesRU,
+	cardMessagesRU,
+	transferMessagesRU,
+	creditMessagesRU,
+	paymentMessagesRU,
+	merchantMessagesRU,
+	organizationMessagesRU,
+	supportMessagesRU,
+	adminMessagesRU,
+}
+
+// commonMessagesRU are the Russian messages of request validation shared by the features
+var commonMessagesRU = map[string]string{
+	"days_must_be_between_1_and_365":                        "количество дней должно быть от 1 до 365",
+	"description_is_required":                               "описание обязательно",
+	"description_must_be_at_most_255_characters":            "описание должно быть не длиннее 255 символов",
+	"description_must_be_at_most_500_characters":            "описание должно быть не длиннее 500 символов",
+	"details_must_be_at_most_2000_characters":               "подробности должны быть не длиннее 2000 символов",
+	"expires_in_cannot_be_negative":                         "expires_in не может быть отрицательным",
+	"expires_in_must_be_between_1_and_3600_seconds":         "expires_in должен быть от 1 до 3600 секунд",
+	"expires_on_must_be_at_most_3_years_ahead":              "expires_on не может быть позже чем через 3 года",
+	"expires_on_must_be_in_yyyy_mm_dd_format":               "expires_on должен быть в формате ГГГГ-ММ-ДД",
+	"expires_on_must_not_be_in_the_past":                    "expires_on не может быть в прошлом",
+	"failed_to_generate_request_id":                         "не удалось создать идентификатор запроса",
+	"failed_to_select_fields":                               "не удалось выбрать поля",
+	"from_must_be_before_to":                                "начало периода должно быть раньше его конца",
+	"invalid_cursor":                                        "некорректный параметр cursor",
+	"invalid_date_expected_yyyy_mm_dd":                      "некорректная дата, ожидается YYYY-MM-DD",
+	"invalid_days_parameter":                                "некорректный параметр days",
+	"invalid_decision":                                      "некорректное решение",
+	"invalid_end_date_format":                               "некорректный формат даты окончания",
+	"invalid_filter":                                        "некорректный фильтр",
+	"invalid_from_date_expected_yyyy_mm_dd":                 "некорректная дата начала, ожидается YYYY-MM-DD",
+	"invalid_from_date_format":                              "некорректный формат даты начала",
+	"invalid_limit":                                         "некорректный параметр limit",
+	"invalid_offset":                                        "некорректный параметр offset",
+	"invalid_request_payload":                               "некорректное тело запроса",
+	"invalid_review":                                        "некорректная проверка документа",
+	"invalid_start_date_format":                             "некорректный формат даты начала",
+	"invalid_template_id":                                   "некорректный ID шаблона",
+	"invalid_to_date_expected_yyyy_mm_dd":                   "некорректная дата окончания, ожидается YYYY-MM-DD",
+	"invalid_to_date_format":                                "некорректный формат даты окончания",
+	"limit_must_be_between_1_and":                           "параметр limit должен быть от 1 до %s",
+	"note_is_required":                                      "комментарий обязателен",
+	"note_is_required_when_the_reason_is_other":             "комментарий обязателен, если причина OTHER",
+	"note_must_be_at_most_1000_characters":                  "комментарий должен быть не длиннее 1000 символов",
+	"note_must_be_at_most_2000_characters":                  "комментарий должен быть не длиннее 2000 символов",
+	"note_must_be_at_most_500_characters":                   "комментарий должен быть не длиннее 500 символов",
+	"offset_cannot_be_negative":                             "параметр offset не может быть отрицательным",
+	"reason_is_required":                                    "укажите причину",
+	"reason_is_required_and_must_be_at_most_500_characters": "причина обязательна и должна быть не длиннее 500 символов",
+	"reason_must_be_at_most_500_characters":                 "причина должна быть не длиннее 500 символов",
+	"request_id_is_required":                                "поле request_id обязательно",
+	"retry_after_cannot_be_negative":                        "поле retry_after не может быть отрицательным",
+	"status_must_be_accepted_or_rejected":                   "статус должен быть ACCEPTED или REJECTED",
+	"status_must_be_one_of_accepted_delivered_failed_bounced_complained_suppressed": "статус должен быть одним из ACCEPTED, DELIVERED, FAILED, BOUNCED, COMPLAINED, SUPPRESSED",
+	"status_must_be_one_of_funded_released_refunded":                                "status должен быть одним из FUNDED, RELEASED, REFUNDED",
+	"status_must_be_one_of_submitted_in_transit_settled":                            "статус должен быть одним из SUBMITTED, IN_TRANSIT, SETTLED",
+	"type_must_be_branch_or_atm":                                                    "тип должен быть BRANCH или ATM",
+}
+
+// accessMessagesRU are the Russian messages of access checks and delegated access
+var accessMessagesRU = map[string]string{
+	"access_denied_account_belongs_to_another_user":                "доступ запрещен: счет принадлежит другому пользователю",
+	"access_denied_account_of_another_organization":                "доступ запрещен: счет принадлежит другой организации",
+	"access_denied_amount_exceeds_your_delegated_transfer_limit":   "доступ запрещен: сумма превышает лимит перевода по доверенности",
+	"access_denied_api_key_does_not_belong_to_user":                "доступ запрещен: API-ключ принадлежит другому пользователю",
+	"access_denied_bill_payment_belongs_to_another_user":           "доступ запрещен: оплата принадлежит другому пользователю",
+	"access_denied_bill_template_of_another_user":                  "доступ запрещен: шаблон оплаты принадлежит другому пользователю",
+	"access_denied_chargeback_belongs_to_another_user":             "доступ запрещен: чарджбэк принадлежит другому пользователю",
+	"access_denied_cheque_deposit_belongs_to_another_user":         "доступ запрещен: чек принадлежит другому пользователю",
+	"access_denied_client_certificate_required":                    "доступ запрещен: требуется клиентский сертификат",
+	"access_denied_confirmation_belongs_to_another_user":           "доступ запрещен: подтверждение принадлежит другому пользователю",
+	"access_denied_credit_belongs_to_another_user":                 "доступ запрещен: кредит принадлежит другому пользователю",
+	"access_denied_delegated_access_does_not_allow_this":           "доступ запрещен: доверенность не позволяет это действие",
+	"access_denied_delegation_belongs_to_another_user":             "доступ запрещен: доверенность принадлежит другому пользователю",
+	"access_denied_escrow_belongs_to_other_users":                  "доступ запрещен: эскроу-сделка принадлежит другим пользователям",
+	"access_denied_foreign_transaction":                            "доступ запрещен: транзакция не относится к вашим счетам",
+	"access_denied_impersonation_tokens_are_read_only":             "доступ запрещен: токен имперсонации позволяет только просмотр",
+	"access_denied_insufficient_role":                              "доступ запрещен: недостаточно прав",
+	"access_denied_international_transfer_belongs_to_another_user": "доступ запрещен: международный перевод принадлежит другому пользователю",
+	"access_denied_merchant_belongs_to_another_user":               "доступ запрещен: мерчант принадлежит другому пользователю",
+	"access_denied_not_organization_member":                        "доступ запрещен: вы не состоите в этой организации",
+	"access_denied_only_organization_admins_can_do_this":           "доступ запрещен: это могут делать только администраторы организации",
+	"access_denied_role_cannot_transact":                           "доступ запрещен: ваша роль в организации не позволяет проводить операции",
+	"access_denied_source_address_not_allowed":                     "доступ запрещен: адрес источника не разрешен",
+	"access_denied_token_scope_does_not_allow_this_endpoint":       "доступ запрещен: область действия токена не позволяет использовать этот метод",
+	"access_denied_your_delegated_access_is_view_only":             "доступ запрещен: ваша доверенность дает право только на просмотр",
+	"at_least_one_scope_is_required":                               "нужна хотя бы одна область действия",
+	"delegate_not_found":                                           "доверенное лицо не найдено",
+	"delegation_events_retrieved_successfully":                     "журнал доверенности успешно получен",
+	"delegation_granted_successfully":                              "доверенность успешно выдана",
+	"delegation_is_already_revoked":                                "доверенность уже отозвана",
+	"delegation_not_found":                                         "доверенность не найдена",
+	"delegation_revoked_successfully":                              "доверенность успешно отозвана",
+	"delegations_retrieved_successfully":                           "доверенности успешно получены",
+	"failed_to_create_delegation":                                  "не удалось создать доверенность",
+	"failed_to_create_delegation_event":                            "не удалось записать событие доверенности",
+	"failed_to_get_delegations":                                    "не удалось получить доверенности",
+	"failed_to_record_delegated_access":                            "не удалось записать действие по доверенности",
+	"failed_to_send_delegated_transfer_notification":               "не удалось отправить уведомление о переводе по доверенности",
+	"failed_to_send_delegation_notification":                       "не удалось отправить уведомление о доверенности",
+	"invalid_delegation":                                           "некорректная доверенность",
+	"invalid_delegation_id":                                        "некорректный ID доверенности",
+	"invalid_role":                                                 "некорректная роль",
+	"invalid_role_update":                                          "некорректное изменение роли",
+	"method_not_allowed":                                           "метод не поддерживается",
+	"scope_is_listed_twice":                                        "область действия %q указана дважды",
+	"scope_must_be_view_or_transfer":                               "scope должен быть VIEW или TRANSFER",
+	"unknown_scope":                                                "неизвестная область действия %q",
+	"you_cannot_delegate_access_to_yourself":                       "нельзя выдать доверенность самому себе",
+}
+
+// authMessagesRU are the Russian messages of sign in, sessions, tokens and API keys
+var authMessagesRU = map[string]string{
+	"api_key_has_been_revoked_or_expired":                           "API-ключ отозван или истек",
+	"api_key_is_already_revoked":                                    "API-ключ уже отозван",
+	"api_key_issued_successfully_store_it_securely":                 "API-ключ успешно выпущен, сохраните его в надежном месте",
+	"api_key_not_found":                                             "API-ключ не найден",
+	"api_key_revoked_successfully":                                  "API-ключ успешно отозван",
+	"api_key_rotated_successfully_store_it_securely":                "API-ключ успешно обновлен, сохраните его в надежном месте",
+	"api_keys_retrieved_successfully":                               "API-ключи получены",
+	"cannot_impersonate_yourself":                                   "нельзя имперсонировать самого себя",
+	"captcha_token_is_required":                                     "требуется токен CAPTCHA",
+	"captcha_verification_failed":                                   "проверка CAPTCHA не пройдена",
+	"captcha_verification_is_unavailable":                           "проверка CAPTCHA недоступна",
+	"code_is_required":                                              "код обязателен",
+	"current_password_is_incorrect":                                 "текущий пароль неверен",
+	"current_password_is_required":                                  "текущий пароль обязателен",
+	"failed_to_create_api_key":                                      "не удалось создать API-ключ",
+	"failed_to_generate_api_key":                                    "не удалось создать API-ключ",
+	"failed_to_generate_salt":                                       "не удалось создать соль",
+	"failed_to_generate_session_id":                                 "не удалось создать идентификатор сессии",
+	"failed_to_generate_token":                                      "не удалось создать токен",
+	"failed_to_get_api_key":                                         "не удалось получить API-ключ",
+	"failed_to_get_api_keys":                                        "не удалось получить API-ключи",
+	"failed_to_get_impersonations":                                  "не удалось получить имперсонации",
+	"failed_to_get_sessions":                                        "не удалось получить сессии",
+	"failed_to_hash_password":                                       "не удалось захешировать пароль",
+	"failed_to_log_out":                                             "не удалось выйти",
+	"failed_to_revoke_api_key":                                      "не удалось отозвать API-ключ",
+	"failed_to_revoke_invitation":                                   "не удалось отозвать приглашение",
+	"failed_to_revoke_session":                                      "не удалось завершить сессию",
+	"failed_to_rotate_api_key":                                      "не удалось обновить API-ключ",
+	"failed_to_send_password_change_confirmation":                   "не удалось отправить подтверждение смены пароля",
+	"failed_to_start_session":                                       "не удалось начать сессию",
+	"failed_to_update_password":                                     "не удалось обновить пароль",
+	"failed_to_verify_captcha":                                      "не удалось проверить CAPTCHA",
+	"failed_to_verify_code":                                         "не удалось проверить код",
+	"impersonation_claims_have_wrong_type":                          "claims имперсонации имеют неверный тип",
+	"impersonation_ended_successfully":                              "имперсонация завершена",
+	"impersonation_has_already_ended":                               "имперсонация уже завершена",
+	"impersonation_has_ended":                                       "имперсонация завершена или истекла",
+	"impersonation_not_found":                                       "имперсонация не найдена",
+	"impersonation_retrieved_successfully":                          "имперсонация получена",
+	"impersonation_started_successfully":                            "имперсонация начата",
+	"impersonations_retrieved_successfully":                         "имперсонации получены",
+	"invalid_api_key":                                               "неверный API-ключ",
+	"invalid_api_key_data":                                          "неверные данные API-ключа",
+	"invalid_api_key_id":                                            "неверный ID API-ключа",
+	"invalid_api_key_issued_for_another_tenant":                     "неверный API-ключ: выпущен для другого бренда",
+	"invalid_authorization_header_format":                           "некорректный формат заголовка Authorization",
+	"invalid_credentials":                                           "неверное имя пользователя или пароль",
+	"invalid_impersonation":                                         "некорректные данные имперсонации",
+	"invalid_impersonation_id":                                      "некорректный ID имперсонации",
+	"invalid_or_already_used_revoke_link":                           "недействительная или уже использованная ссылка для завершения сессии",
+	"invalid_or_expired_password_reset_token":                       "недействительный или просроченный токен восстановления пароля",
+	"invalid_password":                                              "неверный пароль",
+	"invalid_password_data":                                         "некорректные данные пароля",
+	"invalid_password_reset_data":                                   "некорректные данные для восстановления пароля",
+	"invalid_revoke_token":                                          "недействительный токен завершения сессии",
+	"invalid_session_id":                                            "некорректный ID сессии",
+	"invalid_token":                                                 "недействительный токен",
+	"invalid_token_data":                                            "некорректные данные токена",
+	"invalid_token_issued_for_another_tenant":                       "недействительный токен: выдан для другого бренда",
+	"invalid_token_missing_sid_claim":                               "недействительный токен: отсутствует claim sid",
+	"invalid_token_missing_user_id_claim":                           "недействительный токен: отсутствует claim user_id",
+	"invalid_token_reason":                                          "недействительный токен: %s",
+	"invalid_token_scopes_claim_has_wrong_type":                     "недействительный токен: claim scopes имеет неверный тип",
+	"invalid_token_user_id_has_wrong_type":                          "недействительный токен: claim user_id имеет неверный тип",
+	"logged_out_successfully":                                       "выход выполнен",
+	"login_successful":                                              "вход выполнен успешно",
+	"new_password_must_differ_from_the_current_password":            "новый пароль должен отличаться от текущего",
+	"no_api_key_provided":                                           "API-ключ не передан",
+	"no_authorization_header_provided":                              "заголовок Authorization не передан",
+	"only_an_active_api_key_can_be_rotated":                         "обновить можно только активный API-ключ",
+	"password_changed_successfully":                                 "пароль успешно изменен",
+	"password_is_required":                                          "пароль обязателен",
+	"password_must_be_at_least_8_characters":                        "пароль должен быть не короче 8 символов",
+	"password_reset_successfully":                                   "пароль восстановлен",
+	"password_too_simple":                                           "пароль должен содержать хотя бы одну заглавную букву, одну строчную букву и одну цифру",
+	"provide_either_an_authorization_header_or_an_api_key_not_both": "укажите либо заголовок авторизации, либо API-ключ, но не оба",
+	"session_does_not_belong_to_user":                               "сессия не принадлежит пользователю",
+	"session_has_been_revoked_or_expired":                           "сессия завершена или истекла",
+	"session_id_not_found_in_context":                               "ID сессии не найден в контексте",
+	"session_not_found":                                             "сессия не найдена",
+	"session_revoked_change_password":                               "сессия успешно завершена, смените пароль",
+	"session_revoked_successfully":                                  "сессия успешно завершена",
+	"sessions_retrieved_successfully":                               "сессии получены",
+	"token_is_required":                                             "токен обязателен",
+	"token_issued_successfully":                                     "токен выпущен",
+	"too_many_active_api_keys_revoke_one_first":                     "слишком много активных API-ключей, сначала отзовите один из них",
+}
+
+// userMessagesRU are the Russian messages of users, their profiles and documents
+var userMessagesRU = map[string]string{
+	"address_city_is_required_and_must_be_at_most_100_characters":    "address.city обязателен и должен быть не длиннее 100 символов",
+	"address_country_must_be_an_iso_3166_1_alpha_2_code_e_g_ru":      "address.country должен быть кодом ISO 3166-1 alpha-2, например RU",
+	"address_postal_code_must_be_at_most_20_characters":              "address.postal_code должен быть не длиннее 20 символов",
+	"address_street_is_required_and_must_be_at_most_200_characters":  "address.street обязателен и должен быть не длиннее 200 символов",
+	"availability_checked_successfully":                              "проверка выполнена",
+	"birth_date_must_be_at_most_120_years_ago":                       "birth_date должна быть не ранее чем 120 лет назад",
+	"birth_date_must_be_in_the_past":                                 "birth_date должна быть в прошлом",
+	"birth_date_must_be_in_yyyy_mm_dd_format":                        "birth_date должна быть в формате YYYY-MM-DD",
+	"bounce_type_must_be_hard_or_soft":                               "bounce_type должен быть hard или soft",
+	"buyer_and_seller_must_be_different_users":                       "покупатель и продавец должны быть разными пользователями",
+	"complete_your_profile_first":                                    "сначала заполните профиль",
+	"complete_your_profile_first_missing":                            "сначала заполните профиль: %s",
+	"document_must_be_a_pdf_jpeg_or_png_file":                        "документ должен быть файлом PDF, JPEG или PNG",
+	"document_must_be_at_most_5_mb":                                  "документ должен быть не больше 5 МБ",
+	"document_not_found":                                             "документ не найден",
+	"document_references_must_be_between_1_and_200_characters":       "ссылка на документ должна содержать от 1 до 200 символов",
+	"document_reviewed_successfully":                                 "документ успешно проверен",
+	"document_type_must_be_a_valid_mime_type":                        "поле document_type должно быть корректным MIME-типом",
+	"document_uploaded_successfully":                                 "документ успешно загружен",
+	"document_was_rejected_by_the_virus_scan":                        "документ отклонен антивирусной проверкой",
+	"documents_must_list_between_1_and_10_document_references":       "documents должен содержать от 1 до 10 ссылок на документы",
+	"email_address_is_already_verified":                              "адрес электронной почты уже подтвержден",
+	"email_already_exists":                                           "email уже зарегистрирован",
+	"email_deliveries_retrieved_successfully":                        "доставки писем получены",
+	"email_events_processed_successfully":                            "события доставки писем обработаны",
+	"email_is_required":                                              "email обязателен",
+	"email_suppression_deleted_successfully":                         "адрес исключен из списка блокировки",
+	"email_suppression_not_found":                                    "блокировка адреса не найдена",
+	"email_suppressions_retrieved_successfully":                      "заблокированные адреса получены",
+	"email_verified_successfully":                                    "адрес электронной почты подтвержден",
+	"email_webhook_is_disabled":                                      "вебхук почтового провайдера отключен",
+	"email_webhook_signature_is_invalid":                             "неверная подпись вебхука почтового провайдера",
+	"event_must_be_one_of_delivered_opened_bounced_complained":       "event должен быть одним из delivered, opened, bounced, complained",
+	"failed_to_check_availability":                                   "не удалось проверить доступность",
+	"failed_to_create_notification":                                  "не удалось создать уведомление",
+	"failed_to_create_user":                                          "не удалось создать пользователя",
+	"failed_to_generate_referral_code":                               "не удалось создать реферальный код",
+	"failed_to_get_email_suppressions":                               "не удалось получить заблокированные адреса",
+	"failed_to_get_notifications":                                    "не удалось получить уведомления",
+	"failed_to_get_onboarding_status":                                "не удалось получить статус онбординга",
+	"failed_to_get_qualified_referrals":                              "не удалось получить засчитанные приглашения",
+	"failed_to_get_referral_summary":                                 "не удалось получить сводку реферальной программы",
+	"failed_to_get_referrals":                                        "не удалось получить приглашения",
+	"failed_to_get_user":                                             "не удалось получить пользователя",
+	"failed_to_get_user_details":                                     "не удалось получить данные пользователя",
+	"failed_to_mark_notification_as_read":                            "не удалось отметить уведомление как прочитанное",
+	"failed_to_qualify_referral":                                     "не удалось засчитать приглашение",
+	"failed_to_read_file":                                            "не удалось прочитать файл",
+	"failed_to_send_email":                                           "не удалось отправить email",
+	"failed_to_store_document":                                       "не удалось сохранить документ",
+	"failed_to_update_language":                                      "не удалось обновить язык",
+	"failed_to_update_profile":                                       "не удалось обновить профиль",
+	"failed_to_update_timezone":                                      "не удалось обновить часовой пояс",
+	"failed_to_update_user":                                          "не удалось обновить пользователя",
+	"file_is_empty":                                                  "файл пустой",
+	"file_is_not_an_iso_20022_message_of_the_expected_type":          "файл не является сообщением ISO 20022 ожидаемого типа",
+	"file_is_required":                                               "файл обязателен",
+	"file_must_be_at_most_10_mb":                                     "файл должен быть не больше 10 МБ",
+	"file_name_must_be_at_most_255_characters":                       "имя файла должно быть не длиннее 255 символов",
+	"first_name_and_last_name_must_be_at_most_100_characters":        "first_name и last_name должны быть не длиннее 100 символов",
+	"if_the_email_is_registered_a_password_reset_link_has_been_sent": "если email зарегистрирован, на него отправлена ссылка для восстановления пароля",
+	"invalid_address":                                                "адрес обязателен и должен быть не длиннее 255 символов",
+	"invalid_availability_data":                                      "некорректные данные для проверки",
+	"invalid_document":                                               "некорректный документ",
+	"invalid_document_id":                                            "некорректный ID документа",
+	"invalid_document_name":                                          "поле document_name обязательно вместе с документом и должно быть не длиннее 255 символов",
+	"invalid_document_type":                                          "тип должен быть одним из PASSPORT, INCOME_STATEMENT, OTHER",
+	"invalid_email_format":                                           "некорректный формат email",
+	"invalid_email_suppression_id":                                   "неверный ID блокировки адреса",
+	"invalid_file_name":                                              "имя файла обязательно и должно быть не длиннее 255 символов",
+	"invalid_language":                                               "некорректный язык, ожидается en или ru",
+	"invalid_multipart_form_or_file_larger_than_10_mb":               "некорректная multipart-форма или файл больше 10 МБ",
+	"invalid_multipart_form_or_file_larger_than_1_mb":                "некорректная multipart-форма или файл больше 1 МБ",
+	"invalid_name":                                                   "название обязательно и должно быть не длиннее 100 символов",
+	"invalid_notification_id":                                        "некорректный ID уведомления",
+	"invalid_profile_data":                                           "некорректные данные профиля",
+	"invalid_referral_code":                                          "неверный реферальный код",
+	"invalid_timezone":                                               "некорректный часовой пояс, ожидается имя IANA, например Europe/Moscow",
+	"invalid_user_data":                                              "некорректные данные пользователя",
+	"invalid_user_id":                                                "некорректный ID пользователя",
+	"invalid_verification_token":                                     "недействительный токен подтверждения",
+	"language_updated_successfully":                                  "язык успешно обновлен",
+	"malware_detected":                                               "обнаружено вредоносное ПО",
+	"name_is_required":                                               "название обязательно",
+	"name_must_be_at_most_100_characters":                            "название должно быть не длиннее 100 символов",
+	"name_must_be_between_2_and_100_characters":                      "название должно быть от 2 до 100 символов",
+	"new_users_retrieved_successfully":                               "новые пользователи получены",
+	"nickname_is_too_long":                                           "название слишком длинное",
+	"no_settings_to_update":                                          "нет настроек для обновления",
+	"notification_marked_as_read":                                    "уведомление отмечено как прочитанное",
+	"notification_not_found":                                         "уведомление не найдено",
+	"notifications_retrieved_successfully":                           "уведомления получены",
+	"onboarding_is_not_complete":                                     "онбординг не завершен",
+	"onboarding_status_retrieved_successfully":                       "статус онбординга получен",
+	"phone_must_be_in_e_164_format_e_g_79161234567":                  "phone должен быть в формате E.164, например +79161234567",
+	"profile_is_incomplete_for_a_credit":                             "для кредита не заполнен профиль",
+	"profile_updated_successfully":                                   "профиль обновлен",
+	"referral_bonuses_have_already_been_paid":                        "реферальные бонусы уже выплачены",
+	"referral_summary_retrieved_successfully":                        "сводка реферальной программы получена",
+	"referrals_retrieved_successfully":                               "приглашения получены",
+	"timezone_updated_successfully":                                  "часовой пояс успешно обновлен",
+	"to_user_id_is_required":                                         "to_user_id обязателен",
+	"user_already_has_access_to_this_account_revoke_it_first":        "у пользователя уже есть доступ к этому счету, сначала отзовите его",
+	"user_details_retrieved_successfully":                            "данные пользователя получены",
+	"user_has_no_email_address":                                      "у пользователя нет адреса электронной почты",
+	"user_id_is_required":                                            "поле user_id обязательно",
+	"user_id_not_found_in_context":                                   "ID пользователя не найден в контексте",
+	"user_is_already_a_member":                                       "пользователь уже состоит в организации",
+	"user_not_found":                                                 "пользователь не найден",
+	"user_registered_successfully":                                   "пользователь успешно зарегистрирован",
+	"user_updated_successfully":                                      "пользователь успешно обновлен",
+	"username_already_exists":                                        "имя пользователя уже занято",
+	"username_must_be_between_3_and_50_characters":                   "имя пользователя должно быть от 3 до 50 символов",
+	"username_or_email_is_required":                                  "требуется username или email",
+	"verification_link_has_expired":                                  "срок действия ссылки подтверждения истек",
+	"verification_link_sent_successfully":                            "ссылка для подтверждения отправлена",
+	"verification_secret_is_invalid":                                 "неверный секрет проверки",
+	"verify_your_email_address_first":                                "сначала подтвердите адрес электронной почты",
+	"virus_scan_is_unavailable_please_try_again_later":               "антивирусная проверка недоступна, попробуйте позже",
+}
+
+// accountMessagesRU are the Russian messages of accounts, plans and statements
+var accountMessagesRU = map[string]string{
+	"account_activity_retrieved_successfully":                                "история операций по счету получена",
+	"account_already_belongs_to_the_user":                                    "счет уже принадлежит этому пользователю",
+	"account_already_has_a_pending_ownership_transfer":                       "по счету уже есть передача, ожидающая согласования",
+	"account_created_successfully":                                           "счет успешно открыт",
+	"account_currency_does_not_match_the_plan_currency":                      "валюта счета не совпадает с валютой тарифа",
+	"account_deleted_successfully":                                           "счет успешно удален",
+	"account_does_not_belong_to_this_organization":                           "счет не принадлежит этой организации",
+	"account_has_an_open_credit_which_must_be_settled_or_restructured_first": "по счету есть непогашенный кредит, сначала погасите или реструктурируйте его",
+	"account_holds_retrieved_successfully":                                   "блокировки средств на счете получены",
+	"account_id_is_required":                                                 "поле account_id обязательно",
+	"account_is_already_on_this_plan":                                        "счет уже подключен к этому плану",
+	"account_is_dormant_reactivate_it_first":                                 "счет неактивен из-за отсутствия операций, сначала возобновите его",
+	"account_is_inactive":                                                    "счет неактивен",
+	"account_is_not_active":                                                  "счет не активен",
+	"account_is_not_dormant":                                                 "счет не переведен в спящий режим",
+	"account_is_the_settlement_account_of_a_merchant":                        "счет является расчетным счетом мерчанта",
+	"account_not_found":                                                      "счет не найден",
+	"account_owner_changed_since_the_transfer_was_requested":                 "владелец счета изменился после запроса передачи",
+	"account_plan_created_successfully":                                      "тарифный план успешно создан",
+	"account_plan_is_no_longer_offered":                                      "тарифный план больше не подключается",
+	"account_plan_not_found":                                                 "тарифный план не найден",
+	"account_plan_retrieved_successfully":                                    "тарифный план счета получен",
+	"account_plan_switched_successfully":                                     "тарифный план счета успешно изменен",
+	"account_plan_updated_successfully":                                      "тарифный план успешно обновлен",
+	"account_plans_retrieved_successfully":                                   "тарифные планы получены",
+	"account_reactivated_successfully":                                       "счет успешно возобновлен",
+	"account_retrieved_successfully":                                         "счет получен",
+	"account_settings_updated_successfully":                                  "настройки счета успешно обновлены",
+	"account_was_opened_after_the_period":                                    "счет открыт после этого периода",
+	"accounts_retrieved_successfully":                                        "счета получены",
+	"balance_prediction_retrieved_successfully":                              "прогноз баланса получен",
+	"balance_updated_successfully":                                           "баланс успешно обновлен",
+	"cannot_delete_account_with_active_cards":                                "нельзя удалить счет с активными картами",
+	"creditor_account_is_required":                                           "требуется счет получателя",
+	"currency_is_required":                                                   "валюта обязательна",
+	"currency_mismatch_between_accounts":                                     "валюты счетов не совпадают",
+	"currency_must_be_usd_or_eur":                                            "валюта должна быть USD или EUR",
+	"debtor_account_is_required":                                             "требуется счет плательщика",
+	"debtor_account_of_the_payment_does_not_match_the_payroll_account":       "счет плательщика не совпадает со счетом ведомости",
+	"default_account_not_found":                                              "счет по умолчанию не найден",
+	"default_account_retrieved_successfully":                                 "счет по умолчанию получен",
+	"default_account_set_successfully":                                       "счет по умолчанию успешно установлен",
+	"deposit_balances_retrieved_successfully":                                "остатки по вкладам получены",
+	"deposit_currency_does_not_match_account_currency":                       "валюта пополнения %s не совпадает с валютой счета %s",
+	"deposit_transaction_has_no_destination_account":                         "у операции пополнения нет счета зачисления",
+	"destination_account_is_inactive":                                        "счет получателя неактивен",
+	"failed_to_create_account":                                               "не удалось открыть счет",
+	"failed_to_create_deposit_transaction":                                   "не удалось создать операцию пополнения",
+	"failed_to_delete_account":                                               "не удалось удалить счет",
+	"failed_to_get_account":                                                  "не удалось получить счет",
+	"failed_to_get_account_activity":                                         "не удалось получить историю операций по счету",
+	"failed_to_get_account_plan":                                             "не удалось получить тарифный план",
+	"failed_to_get_account_plans":                                            "не удалось получить тарифные планы",
+	"failed_to_get_account_settings":                                         "не удалось получить настройки счета",
+	"failed_to_get_accounts":                                                 "не удалось получить счета",
+	"failed_to_get_default_account":                                          "не удалось получить счет по умолчанию",
+	"failed_to_get_destination_account":                                      "не удалось получить счет получателя",
+	"failed_to_get_recommendations":                                          "не удалось получить рекомендации",
+	"failed_to_get_source_account":                                           "не удалось получить счет отправителя",
+	"failed_to_get_spending_forecast":                                        "не удалось получить прогноз расходов",
+	"failed_to_get_statements":                                               "не удалось получить выписки",
+	"failed_to_get_statistics":                                               "не удалось получить статистику",
+	"failed_to_get_tax_documents":                                            "не удалось получить налоговые справки",
+	"failed_to_hold_cheque_amount":                                           "не удалось заблокировать сумму чека",
+	"failed_to_issue_statement":                                              "не удалось сформировать выписку",
+	"failed_to_mark_tax_document_as_emailed":                                 "не удалось отметить отправку налоговой справки",
+	"failed_to_predict_balance":                                              "не удалось спрогнозировать баланс",
+	"failed_to_put_account_on_plan":                                          "не удалось подключить счет к тарифному плану",
+	"failed_to_regenerate_statement":                                         "не удалось пересобрать выписку",
+	"failed_to_render_tax_document":                                          "не удалось сформировать налоговую справку",
+	"failed_to_send_account_ownership_notification":                          "не удалось отправить уведомление о передаче счета",
+	"failed_to_send_tax_document":                                            "не удалось отправить налоговую справку",
+	"failed_to_set_default_account":                                          "не удалось установить счет по умолчанию",
+	"failed_to_update_account":                                               "не удалось обновить счет",
+	"failed_to_update_account_balance":                                       "не удалось обновить баланс счета",
+	"failed_to_update_balance":                                               "не удалось обновить баланс",
+	"failed_to_update_destination_account_balance":                           "не удалось обновить баланс счета получателя",
+	"failed_to_update_source_account_balance":                                "не удалось обновить баланс счета отправителя",
+	"failed_to_update_statement":                                             "не удалось обновить выписку",
+	"granularity_must_be_one_of_day_week_month_quarter":                      "granularity должен быть одним из: day, week, month, quarter",
+	"initial_balance_cannot_be_negative":                                     "начальный баланс не может быть отрицательным",
+	"interval_must_be_week_month_or_year":                                    "период должен быть WEEK, MONTH или YEAR",
+	"invalid_account_data":                                                   "некорректные данные счета",
+	"invalid_account_id":                                                     "некорректный ID счета",
+	"invalid_account_plan":                                                   "некорректный тарифный план",
+	"invalid_account_plan_id":                                                "некорректный ID тарифного плана",
+	"invalid_account_settings":                                               "некорректные настройки счета",
+	"invalid_account_type":                                                   "некорректный тип счета",
+	"invalid_account_type_plans_apply_to_checking_and_savings_accounts":      "некорректный тип счета %q, тарифные планы применяются к текущим и сберегательным счетам",
+	"invalid_currency":                                                       "некорректная валюта",
+	"invalid_deposit_request":                                                "некорректный запрос пополнения",
+	"invalid_ownership_transfer":                                             "некорректная передача счета",
+	"invalid_ownership_transfer_id":                                          "некорректный ID передачи счета",
+	"invalid_period":                                                         "некорректный период. Допустимые значения: week, month, quarter, year",
+	"invalid_statement_id":                                                   "некорректный ID выписки",
+	"invalid_statement_period":                                               "неверный период выписки",
+	"invalid_withdrawal_request":                                             "некорректный запрос снятия средств",
+	"invalid_year":                                                           "некорректный год",
+	"more_than_one_interest_tier_starts_at":                                  "несколько уровней ставки начинаются с %s",
+	"new_owner_must_be_a_customer_of_the_same_bank":                          "новый владелец должен быть клиентом того же банка",
+	"new_owner_not_found":                                                    "новый владелец не найден",
+	"note_is_required_to_reject_an_ownership_transfer":                       "для отклонения передачи счета нужен комментарий",
+	"only_personal_accounts_can_be_delegated":                                "доверенность можно выдать только на личный счет",
+	"open_an_account_first":                                                  "сначала откройте счет",
+	"ownership_transfer_approved_successfully":                               "передача счета успешно одобрена",
+	"ownership_transfer_is_no_longer_pending":                                "передача счета уже не ожидает согласования",
+	"ownership_transfer_must_be_reviewed_by_another_employee":                "передачу счета должен рассмотреть другой сотрудник",
+	"ownership_transfer_not_found":                                           "передача счета не найдена",
+	"ownership_transfer_rejected_successfully":                               "передача счета успешно отклонена",
+	"ownership_transfer_requested_successfully":                              "запрос на передачу счета успешно создан",
+	"ownership_transfer_retrieved_successfully":                              "передача счета успешно получена",
+	"ownership_transfers_retrieved_successfully":                             "передачи счетов успешно получены",
+	"period_must_be_a_month_that_has_ended":                                  "период должен быть завершившимся месяцем",
+	"period_must_be_in_yyyy_mm_format":                                       "период должен быть в формате YYYY-MM",
+	"plan_is_not_available_for_accounts":                                     "тарифный план %s недоступен для счетов типа %s",
+	"recommendations_retrieved_successfully":                                 "рекомендации получены",
+	"source_account_id_and_destination_account_id_are_required":              "source_account_id и destination_account_id обязательны",
+	"source_account_id_is_required":                                          "требуется source_account_id",
+	"source_account_is_dormant_reactivate_it_first":                          "счет отправителя неактивен из-за отсутствия операций, сначала возобновите его",
+	"source_account_is_inactive":                                             "счет отправителя неактивен",
+	"source_and_destination_accounts_cannot_be_the_same":                     "счета отправителя и получателя не могут совпадать",
+	"source_and_destination_accounts_must_have_the_same_currency":            "валюты счета списания и счета зачисления должны совпадать",
+	"spending_forecast_retrieved_successfully":                               "прогноз расходов получен",
+	"statement_not_found":                                                    "выписка не найдена",
+	"statement_regenerated_successfully":                                     "выписка успешно пересобрана",
+	"statement_retrieved_successfully":                                       "выписка получена",
+	"statements_retrieved_successfully":                                      "выписки получены",
+	"statistics_retrieved_successfully":                                      "статистика получена",
+	"tax_document_belongs_to_another_user":                                   "налоговая справка принадлежит другому пользователю",
+	"tax_document_retrieved_successfully":                                    "налоговая справка получена",
+	"tax_documents_retrieved_successfully":                                   "налоговые справки получены",
+	"the_period_cannot_be_longer_than_366_days":                              "период не может быть длиннее 366 дней",
+	"the_period_must_end_after_it_starts":                                    "период должен заканчиваться позже, чем начинается",
+	"withdrawal_completed_successfully":                                      "снятие средств успешно выполнено",
+	"withdrawal_currency_does_not_match_account_currency":                    "валюта снятия %s не совпадает с валютой счета %s",
+	"year_must_be_between_2000_and":                                          "год должен быть от 2000 до %s",
+	"you_cannot_subscribe_to_your_own_plan":                                  "нельзя подписаться на собственный тариф",
+}
+
+// cardMessagesRU are the Russian messages of cards and card products
+var cardMessagesRU = map[string]string{
+	"bin_must_have_6_or_8_digits":                  "BIN %q должен состоять из 6 или 8 цифр",
+	"card_analytics_retrieved_successfully":        "аналитика по карте получена",
+	"card_blocked_wrong_pins":                      "карта заблокирована после нескольких неверных PIN-кодов, установите новый PIN-код, чтобы разблокировать ее",
+	"card_created_successfully":                    "карта успешно выпущена",
+	"card_deleted_successfully":                    "карта успешно удалена",
+	"card_does_not_belong_to_specified_account":    "карта не принадлежит указанному счету",
+	"card_has_expired":                             "срок действия карты истек",
+	"card_has_no_pin_set_one_first":                "у карты нет PIN-кода, сначала установите его",
+	"card_is_inactive":                             "карта неактивна",
+	"card_not_found":                               "карта не найдена",
+	"card_number_and_pin_are_required":             "номер карты и PIN-код обязательны",
+	"card_product_created_successfully":            "карточный продукт успешно создан",
+	"card_product_is_no_longer_issued":             "карты этого продукта больше не выпускаются",
+	"card_product_updated_successfully":            "карточный продукт успешно обновлен",
+	"card_products_retrieved_successfully":         "карточные продукты получены",
+	"card_retrieved_successfully":                  "карта получена",
+	"card_transactions_retrieved_successfully":     "операции по карте получены",
+	"card_updated_successfully":                    "карта успешно обновлена",
+	"cards_cannot_be_linked_to_accounts":           "карты %s нельзя привязать к счетам типа %s",
+	"cards_retrieved_successfully":                 "карты получены",
+	"cashback_has_more_than_one_rule_for_category": "у кешбэка несколько правил для категории %q",
+	"failed_to_create_card":                        "не удалось выпустить карту",
+	"failed_to_decrypt_card_number":                "не удалось расшифровать номер карты",
+	"failed_to_decrypt_expiry_date":                "не удалось расшифровать срок действия карты",
+	"failed_to_delete_card":                        "не удалось удалить карту",
+	"failed_to_encrypt_card_number":                "не удалось зашифровать номер карты",
+	"failed_to_encrypt_expiry_date":                "не удалось зашифровать срок действия карты",
+	"failed_to_get_card":                           "не удалось получить карту",
+	"failed_to_get_card_product":                   "не удалось получить карточный продукт",
+	"failed_to_get_card_products":                  "не удалось получить карточные продукты",
+	"failed_to_get_cards":                          "не удалось получить карты",
+	"failed_to_hash_cvv":                           "не удалось захешировать CVV",
+	"failed_to_hash_pin":                           "не удалось захешировать PIN-код",
+	"failed_to_update_card":                        "не удалось обновить карту",
+	"invalid_account_type_named":                   "некорректный тип счета %q",
+	"invalid_card_data":                            "некорректные данные карты",
+	"invalid_card_id":                              "некорректный ID карты",
+	"invalid_card_product":                         "некорректный карточный продукт",
+	"invalid_card_product_id":                      "некорректный ID карточного продукта",
+	"invalid_card_type":                            "некорректный тип карты",
+	"invalid_expiry_date":                          "некорректный срок действия",
+	"invalid_pin":                                  "неверный PIN-код",
+	"only_card_payments_can_be_charged_back":       "оспорить можно только платежи картой",
+	"pin_must_be_4_digits":                         "PIN-код должен состоять из 4 цифр",
+	"pin_set_successfully":                         "PIN-код успешно установлен",
+	"virtual_cards_cannot_be_used_at_atms":         "виртуальные карты нельзя использовать в банкоматах",
+	"wrong_pin_the_card_is_now_blocked":            "неверный PIN-код, карта заблокирована",
+}
+
+// transferMessagesRU are the Russian messages of transactions and transfers
+var transferMessagesRU = map[string]string{
+	"a_note_is_required_to_reject_a_cheque_deposit":                                                "для отклонения чека нужен комментарий",
+	"action_must_be_release_or_refund":                                                             "action должен быть RELEASE или REFUND",
+	"amount_could_not_be_recognized_on_the_image_please_enter_it":                                  "не удалось распознать сумму на изображении, укажите ее вручную",
+	"amount_does_not_cover_the_fees_deducted_from_it":                                              "сумма не покрывает удерживаемые из нее комиссии",
+	"amount_must_be_a_positive_number_with_at_most_2_decimal_places":                               "сумма должна быть положительным числом не более чем с 2 знаками после запятой",
+	"amount_must_be_positive":                                                                      "сумма должна быть положительной",
+	"amount_must_be_positive_use_withdraw":                                                         "сумма должна быть положительной, для снятия средств используйте метод withdraw",
+	"application_not_pending_for_documents":                                                        "документы можно добавлять только к заявкам на рассмотрении",
+	"application_not_pending_for_review":                                                           "проверять можно только документы заявок на рассмотрении",
+	"approval_policies_retrieved_successfully":                                                     "правила согласования получены",
+	"approval_policies_updated_successfully":                                                       "правила согласования успешно обновлены",
+	"beneficiary_bic_and_iban_are_of_different_countries":                                          "BIC и IBAN получателя относятся к разным странам",
+	"beneficiary_bic_must_have_8_or_11_characters_bank_country_location_and_optional_branch_codes": "BIC получателя должен содержать 8 или 11 символов: коды банка, страны, местоположения и необязательный код филиала",
+	"beneficiary_iban_is_invalid":                                                                  "некорректный IBAN получателя",
+	"beneficiary_name_is_required":                                                                 "требуется имя получателя",
+	"beneficiary_name_must_be_at_most_140_characters":                                              "имя получателя должно быть не длиннее 140 символов",
+	"card_payments_can_only_be_charged_back_within_days":                                           "оспорить платеж по карте можно только в течение %s дней",
+	"cash_flow_statement_retrieved_successfully":                                                   "отчет о движении денежных средств получен",
+	"cash_withdrawn_successfully":                                                                  "наличные успешно выданы",
+	"charge_bearer_must_be_our_sha_or_ben":                                                         "плательщик комиссий должен быть OUR, SHA или BEN",
+	"chargeback_accepted":                                                                          "чарджбэк принят",
+	"chargeback_amount_exceeds_the_payment_amount":                                                 "сумма чарджбэка превышает сумму платежа",
+	"chargeback_has_already_been_resolved":                                                         "по чарджбэку уже принято решение",
+	"chargeback_is_already":                                                                        "чарджбэк уже в статусе %s",
+	"chargeback_not_found":                                                                         "чарджбэк не найден",
+	"chargeback_opened":                                                                            "чарджбэк открыт, сумма предварительно зачислена",
+	"chargeback_resolved":                                                                          "по чарджбэку принято решение",
+	"chargeback_retrieved_successfully":                                                            "чарджбэк получен",
+	"chargebacks_retrieved_successfully":                                                           "чарджбэки получены",
+	"cheque_amount_exceeds_the_deposit_limit":                                                      "сумма чека превышает лимит зачисления",
+	"cheque_deposit_cleared_successfully":                                                          "чек зачислен",
+	"cheque_deposit_has_already_been_reviewed":                                                     "чек уже рассмотрен",
+	"cheque_deposit_not_found":                                                                     "чек не найден",
+	"cheque_deposit_rejected_successfully":                                                         "чек отклонен",
+	"cheque_deposit_retrieved_successfully":                                                        "чек получен",
+	"cheque_deposit_submitted_successfully":                                                        "чек отправлен на зачисление",
+	"cheque_deposits_retrieved_successfully":                                                       "чеки получены",
+	"cheque_image_must_be_a_pdf_jpeg_or_png_file":                                                  "изображение чека должно быть файлом PDF, JPEG или PNG",
+	"confirmation_code_has_expired":                                                                "срок действия кода подтверждения истек",
+	"confirmation_id_is_required":                                                                  "поле confirmation_id обязательно",
+	"escrow_can_only_be_paid_to_personal_accounts":                                                 "эскроу-платеж можно направить только на личный счет",
+	"escrow_confirmed":                                                                             "эскроу-сделка подтверждена",
+	"escrow_funded_the_amount_is_held_until_both_parties_confirm_the_deal":                         "эскроу-сделка оплачена, сумма удерживается до подтверждения сделки обеими сторонами",
+	"escrow_has_already_been_settled":                                                              "эскроу-сделка уже завершена",
+	"escrow_has_expired_or_has_already_been_settled":                                               "срок эскроу-сделки истек или она уже завершена",
+	"escrow_not_found":                                                                             "эскроу-сделка не найдена",
+	"escrow_resolved":                                                                              "решение по эскроу-сделке принято",
+	"escrow_retrieved_successfully":                                                                "эскроу-сделка получена",
+	"escrows_retrieved_successfully":                                                               "эскроу-сделки получены",
+	"evidence_deadline_has_passed":                                                                 "срок представления доказательств истек",
+	"evidence_is_required":                                                                         "доказательства обязательны",
+	"evidence_must_be_at_most_10000_characters":                                                    "доказательства должны быть не длиннее 10000 символов",
+	"evidence_submitted_the_chargeback_is_under_review":                                            "доказательства представлены, чарджбэк на рассмотрении",
+	"failed_to_approve_transfer":                                                                   "не удалось согласовать перевод",
+	"failed_to_begin_transaction":                                                                  "не удалось начать транзакцию",
+	"failed_to_charge_card_issue_fee":                                                              "не удалось списать плату за выпуск карты",
+	"failed_to_commit_transaction":                                                                 "не удалось завершить транзакцию",
+	"failed_to_confirm_escrow":                                                                     "не удалось подтвердить эскроу-сделку",
+	"failed_to_confirm_transfer":                                                                   "не удалось подтвердить перевод",
+	"failed_to_create_bonus_transaction":                                                           "не удалось создать бонусную операцию",
+	"failed_to_create_escrow":                                                                      "не удалось создать эскроу-сделку",
+	"failed_to_create_transaction_record":                                                          "не удалось сохранить операцию",
+	"failed_to_generate_confirmation_code":                                                         "не удалось создать код подтверждения",
+	"failed_to_generate_confirmation_id":                                                           "не удалось создать идентификатор подтверждения",
+	"failed_to_get_approval_policies":                                                              "не удалось получить правила согласования",
+	"failed_to_get_approvals":                                                                      "не удалось получить согласования",
+	"failed_to_get_chargeback":                                                                     "не удалось получить чарджбэк",
+	"failed_to_get_chargebacks":                                                                    "не удалось получить чарджбэки",
+	"failed_to_get_cheque_deposits":                                                                "не удалось получить чеки",
+	"failed_to_get_escrow":                                                                         "не удалось получить эскроу-сделку",
+	"failed_to_get_escrows":                                                                        "не удалось получить эскроу-сделки",
+	"failed_to_get_expired_escrows":                                                                "не удалось получить просроченные эскроу-сделки",
+	"failed_to_get_international_transfers":                                                        "не удалось получить международные переводы",
+	"failed_to_get_pending_payments":                                                               "не удалось получить ожидающие платежи",
+	"failed_to_get_pending_transfer":                                                               "не удалось получить перевод на согласовании",
+	"failed_to_get_pending_transfers":                                                              "не удалось получить переводы на согласовании",
+	"failed_to_get_transaction":                                                                    "не удалось получить операцию",
+	"failed_to_get_transactions":                                                                   "не удалось получить операции",
+	"failed_to_hash_confirmation_code":                                                             "не удалось захешировать код подтверждения",
+	"failed_to_reject_transfer":                                                                    "не удалось отклонить перевод",
+	"failed_to_send_chargeback_notification":                                                       "не удалось отправить уведомление о чарджбэке",
+	"failed_to_send_transaction_notification":                                                      "не удалось отправить уведомление об операции",
+	"failed_to_send_transfer_confirmation_code":                                                    "не удалось отправить код подтверждения перевода",
+	"failed_to_store_cheque_image":                                                                 "не удалось сохранить изображение чека",
+	"failed_to_update_approval_policies":                                                           "не удалось обновить правила согласования",
+	"insufficient_funds":                                                                           "недостаточно средств",
+	"insufficient_funds_for_the_card_issue_fee":                                                    "недостаточно средств для оплаты выпуска карты",
+	"international_transfer_not_found":                                                             "международный перевод не найден",
+	"international_transfer_quoted_successfully":                                                   "стоимость международного перевода рассчитана",
+	"international_transfer_retrieved_successfully":                                                "международный перевод получен",
+	"international_transfer_submitted_track_it_by_its_reference":                                   "международный перевод отправлен, отслеживайте его по референсу",
+	"international_transfer_tracking_retrieved_successfully":                                       "статус международного перевода получен",
+	"international_transfers_retrieved_successfully":                                               "международные переводы получены",
+	"invalid_amount":                                                                               "некорректная сумма",
+	"invalid_approval_policies":                                                                    "некорректные правила согласования",
+	"invalid_cash_flow_query":                                                                      "неверные параметры отчета о движении денежных средств",
+	"invalid_chargeback_data":                                                                      "некорректные данные чарджбэка",
+	"invalid_chargeback_id":                                                                        "некорректный ID чарджбэка",
+	"invalid_chargeback_reason":                                                                    "причина должна быть одной из NOT_RECEIVED, NOT_AS_DESCRIBED, DUPLICATE, FRAUD, OTHER",
+	"invalid_cheque_deposit":                                                                       "некорректный депозит чека",
+	"invalid_cheque_deposit_id":                                                                    "некорректный ID чека",
+	"invalid_confirmation_code":                                                                    "неверный код подтверждения",
+	"invalid_confirmation_request":                                                                 "некорректный запрос подтверждения",
+	"invalid_escrow_data":                                                                          "некорректные данные эскроу-сделки",
+	"invalid_escrow_id":                                                                            "некорректный ID эскроу-сделки",
+	"invalid_escrow_resolution":                                                                    "некорректное решение по эскроу-сделке",
+	"invalid_evidence":                                                                             "некорректные доказательства",
+	"invalid_international_transfer_data":                                                          "некорректные данные международного перевода",
+	"invalid_international_transfer_id":                                                            "некорректный ID международного перевода",
+	"invalid_max_amount":                                                                           "некорректный параметр max_amount",
+	"invalid_min_amount":                                                                           "некорректный параметр min_amount",
+	"invalid_pending_transfer_id":                                                                  "некорректный ID перевода на согласовании",
+	"invalid_repeat_request":                                                                       "некорректный запрос повтора",
+	"invalid_resolution":                                                                           "некорректное решение по чарджбэку",
+	"invalid_transaction_description":                                                              "некорректное описание операции",
+	"invalid_transaction_id":                                                                       "некорректный ID операции",
+	"invalid_transaction_no_accounts":                                                              "некорректная операция: нет счета отправителя или получателя",
+	"invalid_transfer_request":                                                                     "некорректный запрос перевода",
+	"min_amount_cannot_be_greater_than_max_amount":                                                 "min_amount не может быть больше max_amount",
+	"min_amount_must_be_positive":                                                                  "поле min_amount должно быть положительным",
+	"min_amount_values_must_be_unique":                                                             "значения min_amount должны быть уникальными",
+	"number_of_transactions_does_not_match_the_credit_transfers":                                   "количество операций не совпадает с переводами",
+	"pending_transfer_not_found":                                                                   "перевод на согласовании не найден",
+	"pending_transfer_retrieved_successfully":                                                      "перевод на согласовании получен",
+	"pending_transfers_retrieved_successfully":                                                     "переводы на согласовании получены",
+	"purpose_is_required":                                                                          "требуется назначение платежа",
+	"purpose_must_be_at_most_140_characters":                                                       "назначение платежа должно быть не длиннее 140 символов",
+	"recipient_not_found":                                                                          "получатель не найден",
+	"requested_execution_date_is_invalid":                                                          "некорректная дата исполнения",
+	"required_approvals_must_be_between_1_and_10":                                                  "поле required_approvals должно быть от 1 до 10",
+	"status_must_be_one_of_pending_approval_completed_rejected":                                    "статус должен быть одним из PENDING_APPROVAL, COMPLETED, REJECTED",
+	"status_must_be_one_of_pending_charged_dunning":                                                "статус должен быть одним из PENDING, CHARGED, DUNNING",
+	"too_many_invalid_codes_transfer_cancelled":                                                    "слишком много неверных кодов, перевод отменен",
+	"transaction_description_history_retrieved_successfully":                                       "история описания операции получена",
+	"transaction_description_updated_successfully":                                                 "описание операции изменено",
+	"transaction_has_no_source_account":                                                            "у операции снятия, платежа или перевода нет счета отправителя",
+	"transaction_id_is_required":                                                                   "поле transaction_id обязательно",
+	"transaction_not_found":                                                                        "операция не найдена",
+	"transaction_retrieved_successfully":                                                           "операция получена",
+	"transaction_volume_retrieved_successfully":                                                    "объем операций получен",
+	"transactions_found_successfully":                                                              "транзакции найдены",
+	"transactions_retrieved_successfully":                                                          "операции получены",
+	"transfer_approval_has_expired":                                                                "срок согласования перевода истек",
+	"transfer_completed_successfully":                                                              "перевод успешно выполнен",
+	"transfer_confirmation_is_no_longer_pending":                                                   "подтверждение перевода уже не действует",
+	"transfer_confirmation_not_found":                                                              "подтверждение перевода не найдено",
+	"transfer_is_no_longer_pending_approval":                                                       "перевод уже не ожидает согласования",
+	"transfer_is_waiting_for_approval":                                                             "перевод ожидает согласования",
+	"transfer_limit_is_only_allowed_for_the_transfer_scope":                                        "transfer_limit допустим только для scope TRANSFER",
+	"transfer_limit_must_be_positive_for_the_transfer_scope":                                       "для scope TRANSFER transfer_limit должен быть положительным",
+	"transfer_rejected":                                                                            "перевод отклонен",
+	"transfer_requires_confirmation":                                                               "перевод требует подтверждения, код отправлен на ваш email",
+	"you_cannot_approve_your_own_transfer":                                                         "нельзя согласовать собственный перевод",
+}
+
+// creditMessagesRU are the Russian messages of credits, their schedules and insurance
+var creditMessagesRU = map[string]string{
+	"accepted_documents_are_missing":                    "не хватает принятых документов: %s",
+	"amount_exceeds_the_penalty_of":                     "сумма превышает штраф %s",
+	"control_sum_does_not_match_the_credit_transfers":   "контрольная сумма не совпадает с переводами",
+	"credit_accounts_have_no_plan":                      "у кредитных счетов нет тарифного плана",
+	"credit_agreement_has_changed_request_a_new_code":   "кредитный договор изменился, запросите новый код",
+	"credit_agreement_is_already_signed":                "кредитный договор уже подписан",
+	"credit_agreement_retrieved_successfully":           "кредитный договор получен",
+	"credit_agreement_signed_successfully":              "кредитный договор успешно подписан",
+	"credit_analytics_retrieved_successfully":           "кредитная аналитика получена",
+	"credit_application_is_already":                     "кредитная заявка уже в статусе %s",
+	"credit_application_is_no_longer_pending":           "кредитная заявка уже рассмотрена",
+	"credit_application_not_found":                      "кредитная заявка не найдена",
+	"credit_application_not_found_or_no_longer_pending": "кредитная заявка не найдена или уже рассмотрена",
+	"credit_application_retrieved_successfully":         "кредитная заявка получена",
+	"credit_application_submitted_successfully":         "кредитная заявка успешно подана",
+	"credit_applications_retrieved_successfully":        "кредитные заявки получены",
+	"credit_calculated_successfully":                    "кредит успешно рассчитан",
+	"credit_created_successfully":                       "кредит успешно оформлен",
+	"credit_document_not_found":                         "документ кредитной заявки не найден",
+	"credit_has_no_active_insurance":                    "у кредита нет действующей страховки",
+	"credit_has_no_payments_left":                       "по кредиту не осталось платежей",
+	"credit_has_no_unpaid_payments":                     "у кредита нет неоплаченных платежей",
+	"credit_has_overdue_payments_pay_them_first":        "по кредиту есть просроченные платежи, сначала погасите их",
+	"credit_has_penalties_waive_them_first":             "по кредиту есть платежи со штрафами, сначала спишите их",
+	"credit_insurance_cancelled_successfully":           "страховка по кредиту успешно отменена",
+	"credit_insurance_is_not_offered_at_the_moment":     "страхование кредитов сейчас не предлагается",
+	"credit_is": "кредит в статусе %s",
+	"credit_is_only_active_credits_can_be_repaid_early": "кредит в статусе %s, досрочно погасить можно только активный кредит",
+	"credit_not_found":                                         "кредит не найден",
+	"credit_portfolio_export_not_found":                        "выгрузка кредитного портфеля не найдена",
+	"credit_portfolio_export_started":                          "выгрузка кредитного портфеля запущена",
+	"credit_portfolio_retrieved_successfully":                  "кредитный портфель получен",
+	"credit_restructured_successfully":                         "кредит успешно реструктурирован",
+	"credit_retrieved_successfully":                            "кредит получен",
+	"credit_simulation_retrieved_successfully":                 "симуляция кредита получена",
+	"credit_transfer_amount_must_be_a_positive_decimal_number": "сумма перевода должна быть положительным десятичным числом",
+	"credit_transfer_currency_is_required":                     "требуется валюта перевода",
+	"credits_are_available_from_the_age_of_18":                 "кредиты доступны с 18 лет",
+	"credits_issued_retrieved_successfully":                    "выданные кредиты получены",
+	"credits_require_supporting_documents":                     "для кредитов от %s нужны подтверждающие документы: подайте кредитную заявку через /api/credit-applications",
+	"credits_retrieved_successfully":                           "кредиты получены",
+	"extra_payment_must_be_a_number":                           "поле extra_payment должно быть числом",
+	"extra_payment_must_be_positive":                           "поле extra_payment должно быть положительным",
+	"failed_to_build_credit_portfolio_export":                  "не удалось сформировать выгрузку кредитного портфеля",
+	"failed_to_create_credit":                                  "не удалось оформить кредит",
+	"failed_to_create_credit_account":                          "не удалось открыть кредитный счет",
+	"failed_to_generate_signing_code":                          "не удалось создать код подписания",
+	"failed_to_get_credit":                                     "не удалось получить кредит",
+	"failed_to_get_credit_account":                             "не удалось получить кредитный счет",
+	"failed_to_get_credit_analytics":                           "не удалось получить кредитную аналитику",
+	"failed_to_get_credit_applications":                        "не удалось получить кредитные заявки",
+	"failed_to_get_credits":                                    "не удалось получить кредиты",
+	"failed_to_get_overdue_chargebacks":                        "не удалось получить просроченные чарджбэки",
+	"failed_to_hash_signing_code":                              "не удалось захешировать код подписания",
+	"failed_to_issue_credit":                                   "не удалось выдать кредит",
+	"failed_to_read_credit_portfolio_export":                   "не удалось прочитать выгрузку кредитного портфеля",
+	"failed_to_send_credit_adjustment_notification":            "не удалось отправить уведомление об изменении кредита",
+	"failed_to_send_credit_application_notification":           "не удалось отправить уведомление о кредитной заявке",
+	"failed_to_send_credit_approval_notification":              "не удалось отправить уведомление об одобрении кредита",
+	"failed_to_send_credit_signature_code":                     "не удалось отправить код подписания кредитного договора",
+	"failed_to_update_credit_account_balance":                  "не удалось обновить баланс кредитного счета",
+	"interest_rate_cannot_be_negative":                         "процентная ставка не может быть отрицательной",
+	"interest_rate_must_be_at_most_100":                        "процентная ставка должна быть не больше 100",
+	"interest_rate_out_of_range":                               "процентная ставка должна быть больше 0 и не больше 100",
+	"invalid_adjustment_reason":                                "причина должна быть одной из FINANCIAL_HARDSHIP, BANK_ERROR, GOODWILL, BORROWER_REQUEST, OTHER",
+	"invalid_credit_application_id":                            "некорректный ID кредитной заявки",
+	"invalid_credit_id":                                        "некорректный ID кредита",
+	"invalid_credit_request":                                   "некорректный запрос кредита",
+	"invalid_credit_simulation":                                "неверные данные симуляции кредита",
+	"invalid_penalty_waiver":                                   "некорректное списание штрафа",
+	"invalid_reschedule":                                       "некорректный перенос платежа",
+	"invalid_restructuring":                                    "некорректная реструктуризация",
+	"invalid_signature_request":                                "некорректный запрос подписания",
+	"invalid_signing_code":                                     "неверный код подписания",
+	"only_credit_transfers_payment_method_trf_are_supported":   "поддерживаются только кредитовые переводы (способ платежа TRF)",
+	"payment_date_must_be_before_the_next_payment_on":          "payment_date должна быть раньше следующего платежа %s",
+	"payment_is":                           "платеж в статусе %s",
+	"payoff_quote_calculated_successfully": "расчет досрочного погашения выполнен",
+	"penalty_waived_successfully":          "штраф успешно списан",
+	"segment_audience_must_be_one_of_all_active_credits_overdue_credits": "segment.audience должен быть одним из ALL, ACTIVE_CREDITS, OVERDUE_CREDITS",
+	"signature_request_is_no_longer_pending":                             "запрос подписания уже не действует",
+	"signature_request_not_found":                                        "запрос подписания не найден",
+	"signing_code_has_expired":                                           "срок действия кода подписания истек",
+	"signing_code_sent_confirm_it_to_sign_the_agreement":                 "код подписания отправлен, подтвердите его, чтобы подписать договор",
+	"term_months_must_be_between_1_and":                                  "term_months должен быть от 1 до %s",
+	"term_must_be_between_1_and_360_months":                              "срок должен быть от 1 до 360 месяцев",
+	"too_many_invalid_codes_signing_cancelled":                           "слишком много неверных кодов, подписание отменено",
+}
+
+// paymentMessagesRU are the Russian messages of bills, invoices and subscriptions
+var paymentMessagesRU = map[string]string{
+	"amount_must_be_at_least":                                                 "сумма должна быть не меньше %s",
+	"amount_must_be_at_most":                                                  "сумма должна быть не больше %s",
+	"bill_paid_successfully":                                                  "счет успешно оплачен",
+	"bill_payments_retrieved_successfully":                                    "оплаты услуг получены",
+	"bill_provider_not_found":                                                 "поставщик услуг не найден",
+	"bill_provider_retrieved_successfully":                                    "поставщик услуг получен",
+	"bill_providers_retrieved_successfully":                                   "поставщики услуг получены",
+	"bill_template_created_successfully":                                      "шаблон оплаты успешно создан",
+	"bill_template_deleted_successfully":                                      "шаблон оплаты успешно удален",
+	"bill_template_not_found":                                                 "шаблон оплаты не найден",
+	"bill_templates_retrieved_successfully":                                   "шаблоны оплаты получены",
+	"bills_can_only_be_paid_from_rub_accounts":                                "услуги можно оплачивать только с рублевых счетов",
+	"customer_id_is_required":                                                 "укажите customer_id",
+	"customer_not_found":                                                      "клиент не найден",
+	"due_date_must_be_at_most_a_year_ahead":                                   "due_date не может быть позже чем через год",
+	"due_date_must_be_in_yyyy_mm_dd_format":                                   "due_date должен быть в формате ГГГГ-ММ-ДД",
+	"due_date_must_not_be_in_the_past":                                        "due_date не может быть в прошлом",
+	"failed_to_cancel_invoice":                                                "не удалось отменить счет на оплату",
+	"failed_to_cancel_payment_intent":                                         "не удалось отменить платежное намерение",
+	"failed_to_create_bill_template":                                          "не удалось создать шаблон оплаты",
+	"failed_to_create_invoice":                                                "не удалось создать счет на оплату",
+	"failed_to_create_invoice_item":                                           "не удалось создать позицию счета",
+	"failed_to_create_payment_intent":                                         "не удалось создать платежное намерение",
+	"failed_to_create_payment_schedule":                                       "не удалось создать график платежей",
+	"failed_to_create_payment_transactions":                                   "не удалось создать платежные операции",
+	"failed_to_delete_bill_template":                                          "не удалось удалить шаблон оплаты",
+	"failed_to_generate_invoice_number":                                       "не удалось сгенерировать номер счета",
+	"failed_to_generate_payment_intent_id":                                    "не удалось создать идентификатор платежного намерения",
+	"failed_to_get_bill_payment":                                              "не удалось получить оплату",
+	"failed_to_get_bill_payments":                                             "не удалось получить оплаты услуг",
+	"failed_to_get_bill_provider":                                             "не удалось получить поставщика услуг",
+	"failed_to_get_bill_providers":                                            "не удалось получить поставщиков услуг",
+	"failed_to_get_bill_template":                                             "не удалось получить шаблон оплаты",
+	"failed_to_get_bill_templates":                                            "не удалось получить шаблоны оплаты",
+	"failed_to_get_invoice":                                                   "не удалось получить счет на оплату",
+	"failed_to_get_invoice_items":                                             "не удалось получить позиции счета",
+	"failed_to_get_invoices":                                                  "не удалось получить счета на оплату",
+	"failed_to_get_payment_intent":                                            "не удалось получить платежное намерение",
+	"failed_to_get_payment_intents":                                           "не удалось получить платежные намерения",
+	"failed_to_get_payment_schedule":                                          "не удалось получить график платежей",
+	"failed_to_get_payments_to_remind":                                        "не удалось получить платежи для напоминаний",
+	"failed_to_get_subscription":                                              "не удалось получить подписку",
+	"failed_to_get_subscription_plan":                                         "не удалось получить тариф",
+	"failed_to_get_subscription_plans":                                        "не удалось получить тарифы",
+	"failed_to_get_subscription_report":                                       "не удалось получить отчет по подпискам",
+	"failed_to_get_subscriptions":                                             "не удалось получить подписки",
+	"failed_to_mark_invoice_paid":                                             "не удалось отметить счет оплаченным",
+	"failed_to_mark_invoices_overdue":                                         "не удалось отметить счета просроченными",
+	"failed_to_record_bill_payment":                                           "не удалось сохранить оплату",
+	"failed_to_send_payment_reminder":                                         "не удалось отправить напоминание о платеже",
+	"field_is_required":                                                       "поле %s обязательно",
+	"invalid_bill_payment":                                                    "некорректная оплата услуг",
+	"invalid_bill_template":                                                   "некорректный шаблон оплаты",
+	"invalid_customer_id":                                                     "некорректный customer_id",
+	"invalid_field":                                                           "некорректное значение поля %s",
+	"invalid_invoice_data":                                                    "некорректные данные счета на оплату",
+	"invalid_payment_id":                                                      "некорректный ID платежа",
+	"invalid_payment_intent":                                                  "некорректное платежное намерение",
+	"invalid_payment_request":                                                 "некорректный запрос платежа",
+	"invalid_provider_id":                                                     "некорректный ID поставщика услуг",
+	"invalid_subscription_id":                                                 "некорректный ID подписки",
+	"invalid_subscription_plan_data":                                          "некорректные данные тарифа",
+	"invalid_subscription_plan_id":                                            "некорректный ID тарифа",
+	"invoice_cancelled_successfully":                                          "счет на оплату отменен",
+	"invoice_is_no_longer_payable":                                            "счет больше нельзя оплатить",
+	"invoice_issued_successfully":                                             "счет на оплату выставлен",
+	"invoice_items_must_not_be_empty":                                         "позиции счета не могут быть пустыми",
+	"invoice_must_have_at_least_one_item":                                     "в счете должна быть хотя бы одна позиция",
+	"invoice_must_have_at_most_100_items":                                     "в счете может быть не более 100 позиций",
+	"invoice_not_found":                                                       "счет на оплату не найден",
+	"invoice_paid_successfully":                                               "счет оплачен",
+	"invoice_retrieved_successfully":                                          "счет на оплату получен",
+	"invoice_total_is_too_large":                                              "сумма счета слишком велика",
+	"invoices_retrieved_successfully":                                         "счета на оплату получены",
+	"item_description_is_required":                                            "описание позиции обязательно",
+	"item_description_must_be_at_most_255_characters":                         "описание позиции должно быть не длиннее 255 символов",
+	"item_quantity_must_be_positive":                                          "количество в позиции должно быть положительным",
+	"item_unit_price_must_be_a_positive_number_with_at_most_2_decimal_places": "цена позиции должна быть положительным числом не более чем с 2 знаками после запятой",
+	"no_payments_to_settle":                                                   "нет платежей для расчетов",
+	"only_customers_can_be_impersonated":                                      "имперсонировать можно только клиентов",
+	"only_unpaid_invoices_can_be_cancelled":                                   "отменить можно только неоплаченный счет",
+	"payment_completed_successfully":                                          "платеж успешно выполнен",
+	"payment_currency_does_not_match_the_payroll_currency":                    "валюта выплаты не совпадает с валютой ведомости",
+	"payment_date_must_be_after_current":                                      "поле payment_date должно быть позже текущей даты платежа",
+	"payment_date_must_be_in_the_future":                                      "поле payment_date должно быть в будущем",
+	"payment_date_must_be_in_yyyy_mm_dd_format":                               "поле payment_date должно быть в формате YYYY-MM-DD",
+	"payment_has_a_penalty_waive_it_first":                                    "по платежу начислен штраф, сначала спишите его",
+	"payment_has_already_been_charged_back":                                   "платеж уже оспорен",
+	"payment_has_no_penalty":                                                  "по платежу нет штрафа",
+	"payment_has_not_been_settled_to_the_merchant_yet":                        "платеж еще не перечислен мерчанту",
+	"payment_intent_cancelled":                                                "платежное намерение отменено",
+	"payment_intent_created_successfully":                                     "платежное намерение успешно создано",
+	"payment_intent_is_no_longer_payable":                                     "платежное намерение больше нельзя оплатить",
+	"payment_intent_not_found":                                                "платежное намерение не найдено",
+	"payment_intent_retrieved_successfully":                                   "платежное намерение получено",
+	"payment_intents_retrieved_successfully":                                  "платежные намерения получены",
+	"payment_not_found":                                                       "платеж не найден",
+	"payment_rescheduled_successfully":                                        "платеж успешно перенесен",
+	"payment_schedule_not_found":                                              "график платежей не найден",
+	"payment_schedule_retrieved_successfully":                                 "график платежей получен",
+	"payment_verification_secret_retrieved_successfully":                      "секрет проверки платежа получен",
+	"payment_verified":                                                        "платеж проверен",
+	"provider_id_is_required":                                                 "поле provider_id обязательно",
+	"provider_is_not_available":                                               "поставщик услуг недоступен",
+	"subscribed_successfully":                                                 "подписка оформлена",
+	"subscription_cancelled_successfully":                                     "подписка отменена",
+	"subscription_is_already_cancelled":                                       "подписка уже отменена",
+	"subscription_not_found":                                                  "подписка не найдена",
+	"subscription_plan_created_successfully":                                  "тариф создан",
+	"subscription_plan_deactivated_successfully":                              "тариф отключен",
+	"subscription_plan_not_found":                                             "тариф не найден",
+	"subscription_plan_retrieved_successfully":                                "тариф получен",
+	"subscription_plans_retrieved_successfully":                               "тарифы получены",
+	"subscription_report_retrieved_successfully":                              "отчет по подпискам получен",
+	"subscriptions_can_only_be_paid_from_your_own_personal_accounts":          "подписку можно оплачивать только со своего личного счета",
+	"subscriptions_retrieved_successfully":                                    "подписки получены",
+	"unknown_category":                                                        "неизвестная категория: %s",
+	"unknown_field":                                                           "неизвестное поле %s",
+	"you_cannot_issue_an_invoice_to_yourself":                                 "нельзя выставить счет самому себе",
+}
+
+// merchantMessagesRU are the Russian messages of merchants, payment intents and settlements
+var merchantMessagesRU = map[string]string{
+	"failed_to_create_merchant":                       "не удалось создать мерчанта",
+	"failed_to_create_settlement_transaction":         "не удалось создать операцию расчетов",
+	"failed_to_get_merchant":                          "не удалось получить мерчанта",
+	"failed_to_get_merchants":                         "не удалось получить мерчантов",
+	"failed_to_get_merchants_to_settle":               "не удалось получить мерчантов для расчетов",
+	"failed_to_get_settlement_account":                "не удалось получить расчетный счет",
+	"failed_to_get_settlements":                       "не удалось получить расчеты",
+	"failed_to_settle_escrow":                         "не удалось завершить эскроу-сделку",
+	"failed_to_update_settlement_account_balance":     "не удалось обновить баланс расчетного счета",
+	"in_favor_of_must_be_cardholder_or_merchant":      "поле in_favor_of должно быть CARDHOLDER или MERCHANT",
+	"invalid_merchant_data":                           "некорректные данные мерчанта",
+	"invalid_merchant_id":                             "некорректный ID мерчанта",
+	"invalid_receipt_token":                           "некорректный токен квитанции",
+	"merchant_created_successfully":                   "мерчант успешно создан, сохраните API-ключ в надежном месте",
+	"merchant_id_not_found_in_context":                "ID мерчанта не найден в контексте",
+	"merchant_is_inactive":                            "мерчант неактивен",
+	"merchant_not_found":                              "мерчант не найден",
+	"merchant_retrieved_successfully":                 "мерчант получен",
+	"merchants_retrieved_successfully":                "мерчанты получены",
+	"only_merchant_card_payments_can_be_charged_back": "оспорить можно только платежи картой в пользу мерчантов",
+	"order_reference_must_be_at_most_100_characters":  "поле order_reference должно быть не длиннее 100 символов",
+	"payment_intent_is_already":                       "платежное намерение уже в статусе %s",
+	"payment_intent_must_be_paid_from_an_account_in":  "платежное намерение нужно оплатить со счета в %s",
+	"reason_must_be_one_of_estate_court_order_other":  "причина должна быть одной из ESTATE, COURT_ORDER, OTHER",
+	"receipt_is_genuine":                              "квитанция подлинная",
+	"receipt_not_found":                               "квитанция не найдена",
+	"settlement_account_id_is_required":               "поле settlement_account_id обязательно",
+	"settlement_account_is_inactive":                  "расчетный счет неактивен",
+	"settlement_account_must_be_a_rub_account":        "расчетный счет должен быть рублевым",
+	"settlements_retrieved_successfully":              "расчеты получены",
+	"sort_order_cannot_be_negative":                   "порядок сортировки не может быть отрицательным",
+}
+
+// organizationMessagesRU are the Russian messages of organizations and their members
+var organizationMessagesRU = map[string]string{
+	"failed_to_create_invitation":                                             "не удалось создать приглашение",
+	"failed_to_create_organization":                                           "не удалось создать организацию",
+	"failed_to_create_payroll":                                                "не удалось создать ведомость",
+	"failed_to_create_payroll_item":                                           "не удалось создать строку ведомости",
+	"failed_to_get_invitation":                                                "не удалось получить приглашение",
+	"failed_to_get_invitations":                                               "не удалось получить приглашения",
+	"failed_to_get_members":                                                   "не удалось получить участников",
+	"failed_to_get_organization":                                              "не удалось получить организацию",
+	"failed_to_get_organizations":                                             "не удалось получить организации",
+	"failed_to_get_payroll":                                                   "не удалось получить ведомость",
+	"failed_to_get_payroll_items":                                             "не удалось получить строки ведомости",
+	"failed_to_get_payrolls":                                                  "не удалось получить ведомости",
+	"failed_to_join_organization":                                             "не удалось вступить в организацию",
+	"failed_to_notify_invitee":                                                "не удалось уведомить приглашенного",
+	"failed_to_remove_member":                                                 "не удалось исключить участника",
+	"failed_to_send_organization_invitation":                                  "не удалось отправить приглашение в организацию",
+	"failed_to_update_invitation":                                             "не удалось обновить приглашение",
+	"failed_to_update_member_role":                                            "не удалось изменить роль участника",
+	"failed_to_update_payroll_item":                                           "не удалось обновить строку ведомости",
+	"failed_to_update_payroll_settlement":                                     "не удалось сохранить итоги ведомости",
+	"failed_to_update_payroll_status":                                         "не удалось обновить статус ведомости",
+	"failed_to_write_payroll_report":                                          "не удалось сформировать отчет по ведомости",
+	"invalid_invitation":                                                      "некорректное приглашение",
+	"invalid_invitation_id":                                                   "некорректный ID приглашения",
+	"invalid_organization_data":                                               "некорректные данные организации",
+	"invalid_organization_id":                                                 "некорректный ID организации",
+	"invalid_payroll":                                                         "некорректная зарплатная ведомость",
+	"invalid_payroll_id":                                                      "некорректный ID ведомости",
+	"invalid_staff_id":                                                        "некорректный staff_id",
+	"invitation_has_expired":                                                  "срок действия приглашения истек",
+	"invitation_is_no_longer_pending":                                         "приглашение уже не действует",
+	"invitation_not_found":                                                    "приглашение не найдено",
+	"invitation_revoked_successfully":                                         "приглашение успешно отозвано",
+	"invitation_sent_successfully":                                            "приглашение успешно отправлено",
+	"invitations_retrieved_successfully":                                      "приглашения получены",
+	"member_removed_successfully":                                             "участник успешно исключен",
+	"member_role_updated_successfully":                                        "роль участника успешно изменена",
+	"none_of_the_payroll_accounts_can_receive_payments":                       "ни один из счетов ведомости не может принять выплату",
+	"organization_account_cannot_be_default":                                  "счет организации не может быть счетом по умолчанию",
+	"organization_accounts_cannot_change_owners":                              "владельца счета организации нельзя сменить",
+	"organization_created_successfully":                                       "организация успешно создана",
+	"organization_not_found":                                                  "организация не найдена",
+	"organization_retrieved_successfully":                                     "организация получена",
+	"organizations_retrieved_successfully":                                    "организации получены",
+	"payroll_executed_successfully":                                           "ведомость успешно исполнена",
+	"payroll_file_has_more_than_5000_payments":                                "в файле ведомости больше 5000 выплат",
+	"payroll_file_has_no_payments":                                            "в файле ведомости нет выплат",
+	"payroll_file_is_larger_than_1_mb":                                        "файл ведомости больше 1 МБ",
+	"payroll_file_is_not_a_valid_csv_file":                                    "файл ведомости не является корректным CSV-файлом",
+	"payroll_has_invalid_lines_fix_the_file_and_upload_it_again":              "в ведомости есть некорректные строки, исправьте файл и загрузите его заново",
+	"payroll_is_already_executed":                                             "ведомость уже исполнена",
+	"payroll_item_not_found":                                                  "строка ведомости не найдена",
+	"payroll_must_be_executed_by_a_member_other_than_the_one_who_uploaded_it": "ведомость должен исполнить другой участник, а не тот, кто ее загрузил",
+	"payroll_not_found":                                                       "ведомость не найдена",
+	"payroll_retrieved_successfully":                                          "ведомость успешно получена",
+	"payroll_uploaded_successfully":                                           "ведомость успешно загружена",
+	"payrolls_retrieved_successfully":                                         "ведомости успешно получены",
+}
+
+// supportMessagesRU are the Russian messages of support messages, announcements and branch locations
+var supportMessagesRU = map[string]string{
+	"announcement_cancelled_successfully":                       "объявление отменено",
+	"announcement_not_found":                                    "объявление не найдено",
+	"announcement_queued_successfully":                          "объявление поставлено в очередь",
+	"announcement_retrieved_successfully":                       "объявление получено",
+	"announcements_can_only_reach_the_customers_of_your_tenant": "объявления могут получить только клиенты вашего бренда",
+	"announcements_retrieved_successfully":                      "объявления получены",
+	"body_is_required":                                          "текст сообщения обязателен",
+	"body_must_be_at_most_5000_characters":                      "текст сообщения должен быть не длиннее 5000 символов",
+	"color_must_be_in_rrggbb_format":                            "цвет должен быть в формате #RRGGBB",
+	"failed_to_count_unread_messages":                           "не удалось подсчитать непрочитанные сообщения",
+	"failed_to_get_announcements":                               "не удалось получить объявления",
+	"failed_to_get_locations":                                   "не удалось получить отделения и банкоматы",
+	"failed_to_get_message_threads":                             "не удалось получить переписки",
+	"failed_to_search_transactions":                             "не удалось выполнить поиск транзакций",
+	"failed_to_send_new_message_email":                          "не удалось отправить письмо о новом сообщении",
+	"invalid_announcement_data":                                 "неверные данные объявления",
+	"invalid_announcement_id":                                   "неверный ID объявления",
+	"invalid_location":                                          "некорректное отделение или банкомат",
+	"invalid_location_id":                                       "некорректный ID отделения или банкомата",
+	"invalid_message_data":                                      "некорректные данные сообщения",
+	"invalid_message_id":                                        "некорректный ID сообщения",
+	"invalid_search_data":                                       "некорректные параметры поиска",
+	"invalid_thread_id":                                         "некорректный ID переписки",
+	"lat_is_required_and_must_be_a_number":                      "параметр lat обязателен и должен быть числом",
+	"latitude_must_be_between_90_and_90":                        "широта должна быть от -90 до 90",
+	"lng_is_required_and_must_be_a_number":                      "параметр lng обязателен и должен быть числом",
+	"location_created_successfully":                             "отделение или банкомат успешно добавлен",
+	"location_deleted_successfully":                             "отделение или банкомат успешно удален",
+	"location_not_found":                                        "отделение или банкомат не найден",
+	"location_updated_successfully":                             "отделение или банкомат успешно обновлен",
+	"locations_retrieved_successfully":                          "отделения и банкоматы получены",
+	"longitude_must_be_between_180_and_180":                     "долгота должна быть от -180 до 180",
+	"message_creation_time_is_invalid":                          "некорректное время создания сообщения",
+	"message_has_no_credit_transfers":                           "в сообщении нет переводов",
+	"message_id_is_required":                                    "требуется идентификатор сообщения",
+	"message_id_or_email_is_required":                           "требуется message_id или email",
+	"message_is_required_and_must_be_at_most_5000_characters":   "текст обязателен и должен быть не длиннее 5000 символов",
+	"message_sent_successfully":                                 "сообщение успешно отправлено",
+	"message_thread_not_found":                                  "переписка не найдена",
+	"message_thread_retrieved_successfully":                     "переписка получена",
+	"message_threads_retrieved_successfully":                    "переписки получены",
+	"offset_and_limit_must_not_go_past_the_first_results_narrow_the_search_instead": "offset и limit не должны выходить за первые %s результатов, уточните поиск",
+	"only_a_queued_announcement_can_be_cancelled":                                   "отменить можно только объявление в очереди",
+	"opening_hours_must_be_hh_mm_hh_mm_24h_or_closed":                               "opening_hours: %s должно быть HH:MM-HH:MM, 24h или closed",
+	"opening_hours_unknown_day":                                                     "opening_hours: неизвестный день %q, ожидается mon..sun",
+	"query_must_be_at_most_characters":                                              "поисковый запрос должен быть не длиннее %s символов",
+	"radius_must_be_a_number_of_km_between_0_and":                                   "радиус должен быть числом километров от 0 до %s",
+	"subject_is_required":                                                           "тема обязательна",
+	"subject_must_be_at_most_200_characters":                                        "тема должна быть не длиннее 200 символов",
+	"title_is_required_and_must_be_at_most_200_characters":                          "заголовок обязателен и должен быть не длиннее 200 символов",
+	"unknown_placeholder":                                                           "неизвестная подстановка %s, ожидается одна из {{name}}, {{first_name}}, {{last_name}}, {{username}}",
+	"unknown_service":                                                               "неизвестная услуга %q",
+	"unread_messages_counted_successfully":                                          "непрочитанные сообщения подсчитаны",
+}
+
+// adminMessagesRU are the Russian messages of reports, rates, fees and other admin tools
+var adminMessagesRU = map[string]string{
+	"configuration_reloaded_successfully":            "конфигурация успешно перезагружена",
+	"dashboard_retrieved_successfully":               "сводка получена",
+	"effective_from_must_be_in_yyyy_mm_dd_format":    "effective_from должен быть в формате ГГГГ-ММ-ДД",
+	"effective_to_cannot_be_before_effective_from":   "effective_to не может быть раньше effective_from",
+	"effective_to_must_be_in_yyyy_mm_dd_format":      "effective_to должен быть в формате ГГГГ-ММ-ДД",
+	"failed_to_build_accounting_export":              "не удалось сформировать выгрузку для бухгалтерии",
+	"failed_to_generate_storage_key":                 "не удалось создать ключ хранилища",
+	"failed_to_get_credit_portfolio_report":          "не удалось получить отчет по кредитному портфелю",
+	"failed_to_get_credits_issued_report":            "не удалось получить отчет по выданным кредитам",
+	"failed_to_get_dashboard_report":                 "не удалось получить сводку",
+	"failed_to_get_deposit_balances_report":          "не удалось получить отчет по остаткам на счетах",
+	"failed_to_get_fee_runs":                         "не удалось получить запуски списания комиссий",
+	"failed_to_get_key_rate":                         "не удалось получить ключевую ставку",
+	"failed_to_get_key_rate_overrides":               "не удалось получить переопределения ключевой ставки",
+	"failed_to_get_new_users_report":                 "не удалось получить отчет по новым пользователям",
+	"failed_to_get_rate_history":                     "не удалось получить историю ставок",
+	"failed_to_get_transaction_volume_report":        "не удалось получить отчет по объему транзакций",
+	"fee_charges_retrieved_successfully":             "комиссии получены",
+	"fee_runs_retrieved_successfully":                "запуски списания комиссий получены",
+	"invalid_key_rate_override":                      "некорректное переопределение ключевой ставки",
+	"invalid_key_rate_override_id":                   "некорректный ID переопределения ключевой ставки",
+	"key_rate_override_created_successfully":         "ключевая ставка закреплена",
+	"key_rate_override_not_found_or_revoked_already": "переопределение ключевой ставки не найдено или уже отозвано",
+	"key_rate_override_revoked_successfully":         "переопределение ключевой ставки отозвано",
+	"key_rate_overrides_retrieved_successfully":      "переопределения ключевой ставки получены",
+	"key_rate_retrieved_successfully":                "ключевая ставка получена",
+	"maintenance_state_retrieved_successfully":       "состояние режима обслуживания получено",
+	"maintenance_state_updated_successfully":         "состояние режима обслуживания успешно обновлено",
+	"outbound_http_stats_retrieved_successfully":     "статистика внешних HTTP-запросов получена",
+	"rate_history_retrieved_successfully":            "история ставок получена",
+	"rate_limit_exceeded":                            "превышен лимит запросов",
+	"rate_must_be_above_0_and_at_most_100":           "ставка должна быть больше 0 и не больше 100",
+	"service_is_under_maintenance":                   "ведутся технические работы",
+	"the_period_overlaps_key_rate_override":          "период пересекается с переопределением ключевой ставки %s",
+	"unknown_tenant":                                 "неизвестный бренд",
+	"unknown_tenant_named":                           "неизвестный бренд %q",
+}
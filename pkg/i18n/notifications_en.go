@@ -0,0 +1,97 @@
+package i18n
+
+// notificationsEN are the English notification templates: the title and the message of each
+var notificationsEN = map[string]string{
+	"notification.new_device_login.title":                   "New device login",
+	"notification.new_device_login.message":                 "New login from %s (%s, %s). If this wasn't you, revoke session %d in your active sessions and change your password.",
+	"notification.credit_penalty_waived.title":              "Credit penalty waived",
+	"notification.credit_penalty_waived.message":            "A penalty of %.2f on the payment of credit #%d due %s was waived",
+	"notification.credit_payment_rescheduled.title":         "Credit payment rescheduled",
+	"notification.credit_payment_rescheduled.message":       "The payment of %.2f on credit #%d due %s is now due %s",
+	"notification.credit_restructured.title":                "Credit restructured",
+	"notification.credit_restructured.message":              "Credit #%d was restructured: %d monthly payments of %.2f starting %s. See the new payment schedule for details",
+	"notification.transfer_awaiting_approval.title":         "Transfer awaiting approval",
+	"notification.transfer_awaiting_approval.message":       "A transfer of %.2f from account %d needs %d approvals (pending transfer %d).",
+	"notification.referral_bonus.title":                     "Referral bonus",
+	"notification.referral_bonus.message":                   "%s made a first deposit. %.2f RUB has been credited to account %s.",
+	"notification.welcome_bonus.title":                      "Welcome bonus",
+	"notification.welcome_bonus.message":                    "%.2f RUB welcome bonus has been credited to account %s.",
+	"notification.account_dormant.title":                    "Account is dormant",
+	"notification.account_dormant.message":                  "Account %s has had no activity for %d months and is now dormant. Incoming payments are still accepted; reactivate the account to make outgoing payments.",
+	"notification.organization_invitation.title":            "Organization invitation",
+	"notification.organization_invitation.message":          "You have been invited to join %s as %s.",
+	"notification.card_payment_declined.title":              "Payment declined",
+	"notification.card_payment_declined.message":            "A card payment of %.2f %s was declined: %s.",
+	"notification.card_payment_declined_last.title":         "Payment declined",
+	"notification.card_payment_declined_last.message":       "A card payment of %.2f %s was declined: %s. Further declines today are recorded in your transaction history without a notification.",
+	"notification.atm_withdrawal_declined.title":            "Payment declined",
+	"notification.atm_withdrawal_declined.message":          "An ATM withdrawal of %.2f %s was declined: %s.",
+	"notification.atm_withdrawal_declined_last.title":       "Payment declined",
+	"notification.atm_withdrawal_declined_last.message":     "An ATM withdrawal of %.2f %s was declined: %s. Further declines today are recorded in your transaction history without a notification.",
+	"notification.document_rejected.title":                  "Document rejected",
+	"notification.document_rejected.message":                "Your document %q for credit application #%d was rejected",
+	"notification.document_rejected_note.title":             "Document rejected",
+	"notification.document_rejected_note.message":           "Your document %q for credit application #%d was rejected: %s",
+	"notification.credit_application_approved.title":        "Credit application approved",
+	"notification.credit_application_approved.message":      "Your credit application #%d was approved and credit #%d was issued",
+	"notification.credit_application_rejected.title":        "Credit application rejected",
+	"notification.credit_application_rejected.message":      "Your credit application #%d was rejected",
+	"notification.credit_application_rejected_note.title":   "Credit application rejected",
+	"notification.credit_application_rejected_note.message": "Your credit application #%d was rejected: %s",
+	"notification.chargeback_opened.title":                  "New chargeback",
+	"notification.chargeback_opened.message":                "Payment %s to %s was charged back for %.2f %s (%s). Submit evidence by %s.",
+	"notification.chargeback_under_review.title":            "Chargeback under review",
+	"notification.chargeback_under_review.message":          "%s responded to your chargeback #%d. The bank will review the evidence and decide.",
+	"notification.chargeback_resolved.title":                "Chargeback resolved",
+	"notification.chargeback_resolved.message":              "Chargeback #%d of %.2f for payment %s to %s was resolved: %s.",
+	"notification.account_ownership_transferred.title":      "Account transferred to you",
+	"notification.account_ownership_transferred.message":    "Account %s with %d card(s) has been transferred to you. You can now see it in your accounts.",
+	"notification.delegation_granted.title":                 "Account access granted",
+	"notification.delegation_granted.message":               "%s gave you access to account %s until %s.",
+	"notification.delegation_revoked.title":                 "Account access revoked",
+	"notification.delegation_revoked.message":               "Delegated access to account %s has been revoked.",
+	"notification.delegated_transfer.title":                 "Transfer under power of attorney",
+	"notification.delegated_transfer.message":               "%s transferred %.2f %s from account %s under your power of attorney.",
+	"notification.payroll_credited.title":                   "Salary received",
+	"notification.payroll_credited.message":                 "Salary of %.2f %s from %s was credited to account %s.",
+	"notification.escrow_created.title":                     "Payment into escrow",
+	"notification.escrow_created.message":                   "Escrow deal #%d of %.2f %s was paid for you: %s. Confirm it once the deal is done; if the deal is not settled by %s, the buyer is refunded.",
+	"notification.escrow_confirmed.title":                   "Escrow deal confirmed by the other party",
+	"notification.escrow_confirmed.message":                 "The other party confirmed escrow deal #%d of %.2f %s. Confirm it too to release the funds to the seller.",
+	"notification.escrow_released.title":                    "Escrow released",
+	"notification.escrow_released.message":                  "The funds of escrow deal #%d of %.2f %s were released to the seller.",
+	"notification.escrow_refunded.title":                    "Escrow refunded",
+	"notification.escrow_refunded.message":                  "The funds of escrow deal #%d of %.2f %s were refunded to the buyer: %s.",
+	"notification.invoice_issued.title":                     "New invoice",
+	"notification.invoice_issued.message":                   "You received invoice %s for %.2f %s, due by %s.",
+	"notification.invoice_paid.title":                       "Invoice paid",
+	"notification.invoice_paid.message":                     "Invoice %s for %.2f %s was paid by %s.",
+	"notification.invoice_overdue.title":                    "Invoice overdue",
+	"notification.invoice_overdue.message":                  "Invoice %s for %.2f %s was not paid by its due date.",
+	"notification.invoice_cancelled.title":                  "Invoice cancelled",
+	"notification.invoice_cancelled.message":                "Invoice %s for %.2f %s was cancelled by its issuer.",
+	"notification.subscription_payment_failed.title":        "Subscription payment failed",
+	"notification.subscription_payment_failed.message":      "The payment of %.2f %s for subscription %s failed. It will be retried on %s, make sure the account has enough funds.",
+	"notification.subscription_cancelled.title":             "Subscription cancelled",
+	"notification.subscription_cancelled.message":           "Subscription %d to %s was cancelled.",
+	"notification.subscription_cancelled_unpaid.title":      "Subscription cancelled for non-payment",
+	"notification.subscription_cancelled_unpaid.message":    "Subscription %d to %s was cancelled because its payment failed repeatedly.",
+	"notification.cheque_deposit_cleared.title":             "Cheque cleared",
+	"notification.cheque_deposit_cleared.message":           "Cheque deposit #%d of %.2f %s has cleared; the funds are available.",
+	"notification.cheque_deposit_rejected.title":            "Cheque rejected",
+	"notification.cheque_deposit_rejected.message":          "Cheque deposit #%d of %.2f %s was rejected and the amount was debited back: %s",
+	"notification.international_transfer_settled.title":     "International transfer settled",
+	"notification.international_transfer_settled.message":   "International transfer %s of %.2f %s to %s has been credited by the beneficiary's bank.",
+	"notification.fee_dunning.title":                        "Fee not charged",
+	"notification.fee_dunning.message":                      "%s of %.2f %s could not be charged to account %s: not enough funds. It will be charged once the account is topped up.",
+	"notification.onboarding_email_verified.title":          "Email confirmed",
+	"notification.onboarding_email_verified.message":        "Your email address is confirmed. Next, fill in your phone, date of birth and address in your profile.",
+	"notification.onboarding_kyc.title":                     "Profile complete",
+	"notification.onboarding_kyc.message":                   "Your profile is complete. Next, open your first account.",
+	"notification.onboarding_first_account.title":           "First account opened",
+	"notification.onboarding_first_account.message":         "Your first account is open. Next, issue a card for it.",
+	"notification.onboarding_first_card.title":              "First card issued",
+	"notification.onboarding_first_card.message":            "Your first card is issued. You have completed the setup of your account.",
+	"notification.api_key_created.title":                    "API key issued",
+	"notification.api_key_created.message":                  "API key %q was issued with scopes %s. If this wasn't you, revoke it in your API keys and change your password.",
+}
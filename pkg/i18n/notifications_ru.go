@@ -0,0 +1,97 @@
+package i18n
+
+// notificationsRU are the Russian notification templates: the title and the message of each
+var notificationsRU = map[string]string{
+	"notification.new_device_login.title":                   "Вход с нового устройства",
+	"notification.new_device_login.message":                 "Новый вход с %s (%s, %s). Если это были не вы, завершите сессию %d в списке активных сессий и смените пароль.",
+	"notification.credit_penalty_waived.title":              "Штраф по кредиту списан",
+	"notification.credit_penalty_waived.message":            "Штраф %.2f по платежу кредита №%d со сроком %s списан",
+	"notification.credit_payment_rescheduled.title":         "Платеж по кредиту перенесен",
+	"notification.credit_payment_rescheduled.message":       "Платеж %.2f по кредиту №%d со сроком %s перенесен на %s",
+	"notification.credit_restructured.title":                "Кредит реструктурирован",
+	"notification.credit_restructured.message":              "Кредит №%d реструктурирован: %d ежемесячных платежей по %.2f начиная с %s. Подробности в новом графике платежей",
+	"notification.transfer_awaiting_approval.title":         "Перевод ожидает согласования",
+	"notification.transfer_awaiting_approval.message":       "Перевод %.2f со счета %d требует %d согласований (перевод на согласовании %d).",
+	"notification.referral_bonus.title":                     "Реферальный бонус",
+	"notification.referral_bonus.message":                   "Первое пополнение от %[1]s. На счет %[3]s зачислено %.2[2]f RUB.",
+	"notification.welcome_bonus.title":                      "Приветственный бонус",
+	"notification.welcome_bonus.message":                    "Приветственный бонус %.2f RUB зачислен на счет %s.",
+	"notification.account_dormant.title":                    "Счет переведен в спящий режим",
+	"notification.account_dormant.message":                  "По счету %s не было операций %d мес., он переведен в спящий режим. Входящие платежи по-прежнему принимаются; чтобы проводить исходящие платежи, возобновите счет.",
+	"notification.organization_invitation.title":            "Приглашение в организацию",
+	"notification.organization_invitation.message":          "Вас пригласили в %s с ролью %s.",
+	"notification.card_payment_declined.title":              "Операция отклонена",
+	"notification.card_payment_declined.message":            "Оплата картой на %.2f %s отклонена: %s.",
+	"notification.card_payment_declined_last.title":         "Операция отклонена",
+	"notification.card_payment_declined_last.message":       "Оплата картой на %.2f %s отклонена: %s. Следующие отказы сегодня будут видны в истории операций без уведомлений.",
+	"notification.atm_withdrawal_declined.title":            "Операция отклонена",
+	"notification.atm_withdrawal_declined.message":          "Снятие наличных в банкомате на %.2f %s отклонено: %s.",
+	"notification.atm_withdrawal_declined_last.title":       "Операция отклонена",
+	"notification.atm_withdrawal_declined_last.message":     "Снятие наличных в банкомате на %.2f %s отклонено: %s. Следующие отказы сегодня будут видны в истории операций без уведомлений.",
+	"notification.document_rejected.title":                  "Документ отклонен",
+	"notification.document_rejected.message":                "Ваш документ %q к кредитной заявке №%d отклонен",
+	"notification.document_rejected_note.title":             "Документ отклонен",
+	"notification.document_rejected_note.message":           "Ваш документ %q к кредитной заявке №%d отклонен: %s",
+	"notification.credit_application_approved.title":        "Кредитная заявка одобрена",
+	"notification.credit_application_approved.message":      "Ваша кредитная заявка №%d одобрена, выдан кредит №%d",
+	"notification.credit_application_rejected.title":        "Кредитная заявка отклонена",
+	"notification.credit_application_rejected.message":      "Ваша кредитная заявка №%d отклонена",
+	"notification.credit_application_rejected_note.title":   "Кредитная заявка отклонена",
+	"notification.credit_application_rejected_note.message": "Ваша кредитная заявка №%d отклонена: %s",
+	"notification.chargeback_opened.title":                  "Новый чарджбэк",
+	"notification.chargeback_opened.message":                "Платеж %s в пользу %s оспорен на %.2f %s (%s). Представьте доказательства до %s.",
+	"notification.chargeback_under_review.title":            "Чарджбэк на рассмотрении",
+	"notification.chargeback_under_review.message":          "%s ответил на ваш чарджбэк №%d. Банк рассмотрит доказательства и примет решение.",
+	"notification.chargeback_resolved.title":                "По чарджбэку принято решение",
+	"notification.chargeback_resolved.message":              "По чарджбэку №%d на %.2f по платежу %s в пользу %s принято решение: %s.",
+	"notification.account_ownership_transferred.title":      "Счет передан вам",
+	"notification.account_ownership_transferred.message":    "Вам передан счет %s с картами (%d шт.). Теперь он отображается в списке ваших счетов.",
+	"notification.delegation_granted.title":                 "Вам выдана доверенность",
+	"notification.delegation_granted.message":               "%s: вам выдан доступ к счету %s до %s.",
+	"notification.delegation_revoked.title":                 "Доверенность отозвана",
+	"notification.delegation_revoked.message":               "Доступ к счету %s по доверенности отозван.",
+	"notification.delegated_transfer.title":                 "Перевод по доверенности",
+	"notification.delegated_transfer.message":               "%s: перевод %.2f %s со счета %s по вашей доверенности.",
+	"notification.payroll_credited.title":                   "Поступила зарплата",
+	"notification.payroll_credited.message":                 "Зарплата %.2f %s от %s зачислена на счет %s.",
+	"notification.escrow_created.title":                     "Оплата через эскроу",
+	"notification.escrow_created.message":                   "Эскроу-сделка №%d на %.2f %s оплачена в вашу пользу: %s. Подтвердите ее после выполнения сделки; если сделка не будет завершена до %s, сумма вернется покупателю.",
+	"notification.escrow_confirmed.title":                   "Другая сторона подтвердила эскроу-сделку",
+	"notification.escrow_confirmed.message":                 "Другая сторона подтвердила эскроу-сделку №%d на %.2f %s. Подтвердите ее, чтобы сумма была перечислена продавцу.",
+	"notification.escrow_released.title":                    "Эскроу-сделка завершена",
+	"notification.escrow_released.message":                  "Сумма эскроу-сделки №%d (%.2f %s) перечислена продавцу.",
+	"notification.escrow_refunded.title":                    "Эскроу-сделка отменена",
+	"notification.escrow_refunded.message":                  "Сумма эскроу-сделки №%d (%.2f %s) возвращена покупателю: %s.",
+	"notification.invoice_issued.title":                     "Новый счет на оплату",
+	"notification.invoice_issued.message":                   "Вам выставлен счет %s на %.2f %s, срок оплаты - до %s включительно.",
+	"notification.invoice_paid.title":                       "Счет оплачен",
+	"notification.invoice_paid.message":                     "Счет %s на %.2f %s оплачен получателем %s.",
+	"notification.invoice_overdue.title":                    "Счет просрочен",
+	"notification.invoice_overdue.message":                  "Счет %s на %.2f %s не оплачен в срок.",
+	"notification.invoice_cancelled.title":                  "Счет отменен",
+	"notification.invoice_cancelled.message":                "Счет %s на %.2f %s отменен выставителем.",
+	"notification.subscription_payment_failed.title":        "Не удалось оплатить подписку",
+	"notification.subscription_payment_failed.message":      "Не удалось списать %.2f %s за подписку %s. Повторная попытка будет %s, пополните счет заранее.",
+	"notification.subscription_cancelled.title":             "Подписка отменена",
+	"notification.subscription_cancelled.message":           "Подписка %d на %s отменена.",
+	"notification.subscription_cancelled_unpaid.title":      "Подписка отменена из-за неоплаты",
+	"notification.subscription_cancelled_unpaid.message":    "Подписка %d на %s отменена: оплату не удалось списать несколько раз.",
+	"notification.cheque_deposit_cleared.title":             "Чек зачислен",
+	"notification.cheque_deposit_cleared.message":           "Чек №%d на %.2f %s зачислен; средства доступны.",
+	"notification.cheque_deposit_rejected.title":            "Чек отклонен",
+	"notification.cheque_deposit_rejected.message":          "Чек №%d на %.2f %s отклонен, сумма списана обратно: %s",
+	"notification.international_transfer_settled.title":     "Международный перевод зачислен",
+	"notification.international_transfer_settled.message":   "Международный перевод %s на %.2f %s получателю %s зачислен банком получателя.",
+	"notification.fee_dunning.title":                        "Комиссия не списана",
+	"notification.fee_dunning.message":                      "Не удалось списать со счета %[4]s: %[1]s, %.2[2]f %[3]s - недостаточно средств. Комиссия будет списана после пополнения счета.",
+	"notification.onboarding_email_verified.title":          "Email подтвержден",
+	"notification.onboarding_email_verified.message":        "Ваш адрес электронной почты подтвержден. Следующий шаг - укажите в профиле телефон, дату рождения и адрес.",
+	"notification.onboarding_kyc.title":                     "Профиль заполнен",
+	"notification.onboarding_kyc.message":                   "Ваш профиль заполнен. Следующий шаг - откройте первый счет.",
+	"notification.onboarding_first_account.title":           "Первый счет открыт",
+	"notification.onboarding_first_account.message":         "Ваш первый счет открыт. Следующий шаг - выпустите к нему карту.",
+	"notification.onboarding_first_card.title":              "Первая карта выпущена",
+	"notification.onboarding_first_card.message":            "Ваша первая карта выпущена. Настройка аккаунта завершена.",
+	"notification.api_key_created.title":                    "Выпущен API-ключ",
+	"notification.api_key_created.message":                  "Выпущен API-ключ %q с правами %s. Если это были не вы, отзовите его в списке API-ключей и смените пароль.",
+}
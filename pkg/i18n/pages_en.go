@@ -0,0 +1,23 @@
+package i18n
+
+// pagesEN are the English texts of the HTML pages opened from links in emails
+var pagesEN = map[string]string{
+	"page.session.date":       "Date",
+	"page.session.ip_address": "IP Address",
+	"page.session.location":   "Location",
+	"page.session.device":     "Device",
+
+	"page.revoke_session.title":   "End Session",
+	"page.revoke_session.confirm": "Your account was accessed from the device below. If it was not you, end the session and change your password.",
+	"page.revoke_session.button":  "End session",
+	"page.revoke_session.ended":   "This session has already ended.",
+	"page.revoke_session.done":    "The session has been ended. Please change your password.",
+	"page.revoke_session.invalid": "The link is invalid or has expired.",
+
+	"page.reset_password.title":        "Reset Password",
+	"page.reset_password.prompt":       "Enter a new password for your account.",
+	"page.reset_password.new_password": "New password",
+	"page.reset_password.button":       "Set password",
+	"page.reset_password.done":         "Your password has been changed. You can now log in with it.",
+	"page.reset_password.invalid":      "The link is invalid or has expired.",
+}
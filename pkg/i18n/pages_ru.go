@@ -0,0 +1,23 @@
+package i18n
+
+// pagesRU are the Russian texts of the HTML pages opened from links in emails
+var pagesRU = map[string]string{
+	"page.session.date":       "Дата",
+	"page.session.ip_address": "IP-адрес",
+	"page.session.location":   "Местоположение",
+	"page.session.device":     "Устройство",
+
+	"page.revoke_session.title":   "Завершение сессии",
+	"page.revoke_session.confirm": "В ваш аккаунт выполнен вход с устройства ниже. Если это были не вы, завершите сессию и смените пароль.",
+	"page.revoke_session.button":  "Завершить сессию",
+	"page.revoke_session.ended":   "Эта сессия уже завершена.",
+	"page.revoke_session.done":    "Сессия завершена. Пожалуйста, смените пароль.",
+	"page.revoke_session.invalid": "Ссылка недействительна или устарела.",
+
+	"page.reset_password.title":        "Сброс пароля",
+	"page.reset_password.prompt":       "Введите новый пароль для вашего аккаунта.",
+	"page.reset_password.new_password": "Новый пароль",
+	"page.reset_password.button":       "Сохранить пароль",
+	"page.reset_password.done":         "Пароль изменен. Теперь вы можете войти с ним.",
+	"page.reset_password.invalid":      "Ссылка недействительна или устарела.",
+}
@@ -0,0 +1,333 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Balance types and credit/debit indicators of camt.053 statements
+const (
+	balanceOpening  = "OPBD" // opening booked
+	balanceClosing  = "CLBD" // closing booked
+	indicatorCredit = "CRDT"
+	indicatorDebit  = "DBIT"
+	statusBooked    = "BOOK"
+	statusPending   = "PDNG"
+)
+
+// StatementMessage is a camt.053 bank-to-customer statement message
+type StatementMessage struct {
+	MessageID  string
+	CreatedAt  time.Time
+	Statements []*Statement
+}
+
+// Statement is the statement of one account for a period
+type Statement struct {
+	ID             string
+	CreatedAt      time.Time
+	From           time.Time
+	To             time.Time
+	Account        string
+	Currency       string
+	OwnerName      string
+	OpeningBalance float64 // negative for a debit balance
+	ClosingBalance float64
+	Entries        []*Entry
+}
+
+// Entry is a booking on the account
+type Entry struct {
+	Reference   string // the bank's reference of the booking
+	Amount      float64
+	Credit      bool // the account was credited rather than debited
+	Pending     bool // not booked finally yet
+	BookingDate time.Time
+	Code        string // the bank's proprietary transaction code
+	EndToEndID  string
+	Description string
+}
+
+// camt053Document is the XML layout of a camt.053 message
+type camt053Document struct {
+	XMLName   xml.Name        `xml:"Document"`
+	Namespace string          `xml:"xmlns,attr,omitempty"`
+	Message   *camt053Message `xml:"BkToCstmrStmt"`
+}
+
+type camt053Message struct {
+	GroupHeader camt053GroupHeader `xml:"GrpHdr"`
+	Statements  []camt053Statement `xml:"Stmt"`
+}
+
+type camt053GroupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+type camt053Statement struct {
+	ID        string           `xml:"Id"`
+	CreatedAt string           `xml:"CreDtTm"`
+	From      string           `xml:"FrToDt>FrDtTm,omitempty"`
+	To        string           `xml:"FrToDt>ToDtTm,omitempty"`
+	Account   camt053Account   `xml:"Acct"`
+	Balances  []camt053Balance `xml:"Bal"`
+	Summary   *camt053Summary  `xml:"TxsSummry,omitempty"`
+	Entries   []camt053Entry   `xml:"Ntry"`
+}
+
+type camt053Account struct {
+	accountID
+	Currency string `xml:"Ccy,omitempty"`
+	Owner    *party `xml:"Ownr,omitempty"`
+}
+
+type camt053Balance struct {
+	Type      string `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    amount `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+	Date      date   `xml:"Dt"`
+}
+
+type camt053Summary struct {
+	Total   camt053Totals `xml:"TtlNtries"`
+	Credits camt053Totals `xml:"TtlCdtNtries"`
+	Debits  camt053Totals `xml:"TtlDbtNtries"`
+}
+
+type camt053Totals struct {
+	Count string `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+type camt053Entry struct {
+	Amount      amount          `xml:"Amt"`
+	Indicator   string          `xml:"CdtDbtInd"`
+	Status      string          `xml:"Sts"`
+	BookingDate date            `xml:"BookgDt"`
+	ValueDate   date            `xml:"ValDt"`
+	Reference   string          `xml:"AcctSvcrRef,omitempty"`
+	Code        string          `xml:"BkTxCd>Prtry>Cd"`
+	Details     *camt053Details `xml:"NtryDtls>TxDtls,omitempty"`
+}
+
+type camt053Details struct {
+	References *camt053References `xml:"Refs,omitempty"`
+	Remittance *remittance        `xml:"RmtInf,omitempty"`
+}
+
+type camt053References struct {
+	Reference  string `xml:"AcctSvcrRef,omitempty"`
+	EndToEndID string `xml:"EndToEndId,omitempty"`
+}
+
+// MarshalCamt053 writes a statement message as a camt.053.001.02 message with the opening and
+// closing booked balances and the totals of the entries. Dates and times are written without a time
+// zone, in the location they are in.
+func MarshalCamt053(m *StatementMessage) ([]byte, error) {
+	doc := camt053Document{
+		XMLName:   xml.Name{Local: "Document"},
+		Namespace: Camt053Namespace,
+		Message: &camt053Message{
+			GroupHeader: camt053GroupHeader{MessageID: m.MessageID, CreatedAt: m.CreatedAt.Format(isoDateTime)},
+		},
+	}
+
+	for _, statement := range m.Statements {
+		stmt := camt053Statement{
+			ID:        statement.ID,
+			CreatedAt: statement.CreatedAt.Format(isoDateTime),
+			From:      statement.From.Format(isoDateTime),
+			To:        statement.To.Format(isoDateTime),
+			Account: camt053Account{
+				accountID: accountID{Other: statement.Account},
+				Currency:  statement.Currency,
+			},
+			Balances: []camt053Balance{
+				newBalance(balanceOpening, statement.OpeningBalance, statement.Currency, statement.From),
+				newBalance(balanceClosing, statement.ClosingBalance, statement.Currency, statement.To),
+			},
+		}
+		if statement.OwnerName != "" {
+			stmt.Account.Owner = &party{Name: statement.OwnerName}
+		}
+
+		var credits, debits []float64
+		for _, entry := range statement.Entries {
+			ntry := camt053Entry{
+				Amount:      newAmount(entry.Amount, statement.Currency),
+				Indicator:   indicatorDebit,
+				Status:      statusBooked,
+				BookingDate: date{DateTime: entry.BookingDate.Format(isoDateTime)},
+				ValueDate:   date{Date: entry.BookingDate.Format(isoDate)},
+				Reference:   entry.Reference,
+				Code:        entry.Code,
+			}
+			if entry.Credit {
+				ntry.Indicator = indicatorCredit
+				credits = append(credits, entry.Amount)
+			} else {
+				debits = append(debits, entry.Amount)
+			}
+			if entry.Pending {
+				ntry.Status = statusPending
+			}
+			if entry.Reference != "" || entry.EndToEndID != "" || entry.Description != "" {
+				ntry.Details = &camt053Details{Remittance: newRemittance(entry.Description)}
+				if entry.Reference != "" || entry.EndToEndID != "" {
+					ntry.Details.References = &camt053References{Reference: entry.Reference, EndToEndID: entry.EndToEndID}
+				}
+			}
+			stmt.Entries = append(stmt.Entries, ntry)
+		}
+
+		stmt.Summary = &camt053Summary{
+			Total:   newTotals(append(append([]float64{}, credits...), debits...)),
+			Credits: newTotals(credits),
+			Debits:  newTotals(debits),
+		}
+
+		doc.Message.Statements = append(doc.Message.Statements, stmt)
+	}
+
+	return marshalDocument(doc)
+}
+
+// newBalance writes a signed balance as an amount with a credit/debit indicator
+func newBalance(balanceType string, value float64, currency string, at time.Time) camt053Balance {
+	indicator := indicatorCredit
+	if value < 0 {
+		indicator = indicatorDebit
+	}
+	return camt053Balance{
+		Type:      balanceType,
+		Amount:    newAmount(value, currency),
+		Indicator: indicator,
+		Date:      date{Date: at.Format(isoDate)},
+	}
+}
+
+// newTotals counts and sums entry amounts
+func newTotals(amounts []float64) camt053Totals {
+	var sum float64
+	for _, a := range amounts {
+		sum += a
+	}
+	return camt053Totals{Count: strconv.Itoa(len(amounts)), Sum: strconv.FormatFloat(math.Round(sum*100)/100, 'f', 2, 64)}
+}
+
+// ParseCamt053 reads a camt.053 message of any version. Dates and times without a time zone are
+// read in loc.
+func ParseCamt053(content []byte, loc *time.Location) (*StatementMessage, error) {
+	var doc camt053Document
+	if err := xml.Unmarshal(content, &doc); err != nil || doc.Message == nil {
+		return nil, ErrNotISO20022
+	}
+	if doc.XMLName.Space != "" && !strings.HasPrefix(doc.XMLName.Space, camt053Prefix) {
+		return nil, ErrNotISO20022
+	}
+
+	message := &StatementMessage{
+		MessageID:  strings.TrimSpace(doc.Message.GroupHeader.MessageID),
+		Statements: []*Statement{},
+	}
+
+	var err error
+	if message.CreatedAt, err = parseDateTime(doc.Message.GroupHeader.CreatedAt, loc); err != nil {
+		return nil, errors.New("message creation time is invalid")
+	}
+
+	for _, stmt := range doc.Message.Statements {
+		statement, err := parseCamt053Statement(stmt, loc)
+		if err != nil {
+			return nil, err
+		}
+		message.Statements = append(message.Statements, statement)
+	}
+
+	return message, nil
+}
+
+// parseCamt053Statement reads the statement of one account
+func parseCamt053Statement(stmt camt053Statement, loc *time.Location) (*Statement, error) {
+	statement := &Statement{
+		ID:       strings.TrimSpace(stmt.ID),
+		Account:  stmt.Account.number(),
+		Currency: strings.TrimSpace(stmt.Account.Currency),
+		Entries:  []*Entry{},
+	}
+	if stmt.Account.Owner != nil {
+		statement.OwnerName = strings.TrimSpace(stmt.Account.Owner.Name)
+	}
+
+	var err error
+	if statement.CreatedAt, err = parseDateTime(stmt.CreatedAt, loc); err != nil {
+		return nil, errors.New("statement creation time is invalid")
+	}
+	if stmt.From != "" {
+		if statement.From, err = parseDateTime(stmt.From, loc); err != nil {
+			return nil, errors.New("statement period is invalid")
+		}
+	}
+	if stmt.To != "" {
+		if statement.To, err = parseDateTime(stmt.To, loc); err != nil {
+			return nil, errors.New("statement period is invalid")
+		}
+	}
+
+	for _, balance := range stmt.Balances {
+		value, err := parseSigned(balance.Amount.Value, balance.Indicator)
+		if err != nil {
+			return nil, errors.New("statement balance is invalid")
+		}
+		switch strings.TrimSpace(balance.Type) {
+		case balanceOpening:
+			statement.OpeningBalance = value
+		case balanceClosing:
+			statement.ClosingBalance = value
+		}
+	}
+
+	for _, ntry := range stmt.Entries {
+		entry := &Entry{
+			Reference: strings.TrimSpace(ntry.Reference),
+			Credit:    strings.TrimSpace(ntry.Indicator) == indicatorCredit,
+			Pending:   strings.TrimSpace(ntry.Status) == statusPending,
+			Code:      strings.TrimSpace(ntry.Code),
+		}
+		if entry.Amount, err = parseAmount(ntry.Amount.Value); err != nil {
+			return nil, errors.New("statement entry amount is invalid")
+		}
+		if entry.BookingDate, err = ntry.BookingDate.time(loc); err != nil {
+			return nil, errors.New("statement entry booking date is invalid")
+		}
+		if ntry.Details != nil {
+			entry.Description = ntry.Details.Remittance.text()
+			if refs := ntry.Details.References; refs != nil {
+				entry.EndToEndID = strings.TrimSpace(refs.EndToEndID)
+				if entry.Reference == "" {
+					entry.Reference = strings.TrimSpace(refs.Reference)
+				}
+			}
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+
+	return statement, nil
+}
+
+// parseSigned parses an amount with a credit/debit indicator into a signed value
+func parseSigned(value string, indicator string) (float64, error) {
+	parsed, err := parseAmount(value)
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimSpace(indicator) == indicatorDebit {
+		return -parsed, nil
+	}
+	return parsed, nil
+}
@@ -0,0 +1,128 @@
+// Package iso20022 reads and writes the ISO 20022 messages exchanged with corporate clients:
+// pain.001 customer credit transfer initiations and camt.053 bank-to-customer statements.
+// Only the elements the bank uses are mapped; the rest of a message is ignored when reading.
+package iso20022
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message namespaces. Any version of a message is read; messages are written in the version
+// most banks still exchange.
+const (
+	pain001Prefix    = "urn:iso:std:iso:20022:tech:xsd:pain.001.001."
+	camt053Prefix    = "urn:iso:std:iso:20022:tech:xsd:camt.053.001."
+	Pain001Namespace = pain001Prefix + "03"
+	Camt053Namespace = camt053Prefix + "02"
+)
+
+const (
+	isoDate     = "2006-01-02"
+	isoDateTime = "2006-01-02T15:04:05"
+)
+
+// ErrNotISO20022 is returned when the content is not an ISO 20022 message of the expected type
+var ErrNotISO20022 = errors.New("file is not an ISO 20022 message of the expected type")
+
+// IsXML reports whether the content looks like an XML document rather than e.g. a CSV file
+func IsXML(content []byte) bool {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	return bytes.HasPrefix(bytes.TrimSpace(content), []byte("<"))
+}
+
+// remittance is the unstructured remittance information of a payment
+type remittance struct {
+	Unstructured string `xml:"Ustrd"`
+}
+
+// newRemittance omits the remittance information when there is none
+func newRemittance(text string) *remittance {
+	if text == "" {
+		return nil
+	}
+	return &remittance{Unstructured: text}
+}
+
+// text returns the remittance information, if any
+func (r *remittance) text() string {
+	if r == nil {
+		return ""
+	}
+	return strings.TrimSpace(r.Unstructured)
+}
+
+// amount is an amount with its currency, as in InstdAmt, Amt and the balances
+type amount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// newAmount formats an amount with two decimals; the sign is carried by the credit/debit indicator
+func newAmount(value float64, currency string) amount {
+	return amount{Value: strconv.FormatFloat(math.Abs(value), 'f', 2, 64), Currency: currency}
+}
+
+// parseAmount parses a non-negative decimal amount
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || parsed < 0 || strings.ContainsAny(value, "eE+-") {
+		return 0, errors.New("amount must be a non-negative decimal number")
+	}
+	return parsed, nil
+}
+
+// accountID identifies an account by IBAN or, as Russian accounts are, by another identifier
+type accountID struct {
+	IBAN  string `xml:"Id>IBAN,omitempty"`
+	Other string `xml:"Id>Othr>Id,omitempty"`
+}
+
+// number returns the account number, whichever way the account is identified
+func (a *accountID) number() string {
+	if a == nil {
+		return ""
+	}
+	if a.IBAN != "" {
+		return strings.TrimSpace(a.IBAN)
+	}
+	return strings.TrimSpace(a.Other)
+}
+
+// date is an ISODate, or a choice of Dt and DtTm as later message versions wrap it in
+type date struct {
+	Value    string `xml:",chardata"`
+	Date     string `xml:"Dt,omitempty"`
+	DateTime string `xml:"DtTm,omitempty"`
+}
+
+// time parses whichever form the date was given in, in loc unless it has a time zone; a missing
+// date is the zero time
+func (d *date) time(loc *time.Location) (time.Time, error) {
+	if d == nil {
+		return time.Time{}, nil
+	}
+	for _, value := range []string{d.Date, d.DateTime, d.Value} {
+		if value = strings.TrimSpace(value); value != "" {
+			return parseDateTime(value, loc)
+		}
+	}
+	return time.Time{}, nil
+}
+
+// parseDateTime parses an ISODate or an ISODateTime with or without a time zone; without one it is
+// read in loc
+func parseDateTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, isoDateTime, isoDateTime + ".999999999", isoDate} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("invalid date")
+}
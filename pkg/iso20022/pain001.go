@@ -0,0 +1,312 @@
+package iso20022
+
+import (
+	"encoding/xml"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentInitiation is a pain.001 customer credit transfer initiation: the payments a customer
+// asks the bank to make, grouped by the account they are debited from
+type PaymentInitiation struct {
+	MessageID       string
+	CreatedAt       time.Time
+	InitiatingParty string
+	Payments        []*Payment
+}
+
+// Payment is a payment information block: credit transfers debited from one account
+type Payment struct {
+	ID             string
+	ExecutionDate  time.Time // requested execution date
+	DebtorName     string
+	DebtorAccount  string
+	DebtorAgentBIC string
+	Transfers      []*CreditTransfer
+}
+
+// CreditTransfer is a single credit transfer of a payment
+type CreditTransfer struct {
+	EndToEndID       string
+	Amount           float64
+	Currency         string
+	CreditorName     string
+	CreditorAccount  string
+	CreditorAgentBIC string
+	RemittanceInfo   string
+}
+
+// Transfers returns the credit transfers of all payments in message order
+func (p *PaymentInitiation) Transfers() []*CreditTransfer {
+	transfers := []*CreditTransfer{}
+	for _, payment := range p.Payments {
+		transfers = append(transfers, payment.Transfers...)
+	}
+	return transfers
+}
+
+// pain001Document is the XML layout of a pain.001 message
+type pain001Document struct {
+	XMLName    xml.Name           `xml:"Document"`
+	Namespace  string             `xml:"xmlns,attr,omitempty"`
+	Initiation *pain001Initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type pain001Initiation struct {
+	GroupHeader pain001GroupHeader `xml:"GrpHdr"`
+	Payments    []pain001Payment   `xml:"PmtInf"`
+}
+
+type pain001GroupHeader struct {
+	MessageID       string `xml:"MsgId"`
+	CreatedAt       string `xml:"CreDtTm"`
+	NumberOfTxs     string `xml:"NbOfTxs"`
+	ControlSum      string `xml:"CtrlSum,omitempty"`
+	InitiatingParty party  `xml:"InitgPty"`
+}
+
+type pain001Payment struct {
+	ID            string            `xml:"PmtInfId"`
+	Method        string            `xml:"PmtMtd"`
+	NumberOfTxs   string            `xml:"NbOfTxs,omitempty"`
+	ControlSum    string            `xml:"CtrlSum,omitempty"`
+	ExecutionDate date              `xml:"ReqdExctnDt"`
+	Debtor        party             `xml:"Dbtr"`
+	DebtorAccount accountID         `xml:"DbtrAcct"`
+	DebtorAgent   agent             `xml:"DbtrAgt"`
+	Transfers     []pain001Transfer `xml:"CdtTrfTxInf"`
+}
+
+type pain001Transfer struct {
+	EndToEndID      string      `xml:"PmtId>EndToEndId"`
+	Amount          amount      `xml:"Amt>InstdAmt"`
+	CreditorAgent   *agent      `xml:"CdtrAgt,omitempty"`
+	Creditor        party       `xml:"Cdtr"`
+	CreditorAccount accountID   `xml:"CdtrAcct"`
+	Remittance      *remittance `xml:"RmtInf,omitempty"`
+}
+
+// party is a debtor, creditor or initiating party identified by name
+type party struct {
+	Name string `xml:"Nm,omitempty"`
+}
+
+// agent is the bank of a party. Version 03 names its BIC element BIC, later versions BICFI.
+type agent struct {
+	BIC   string `xml:"FinInstnId>BIC,omitempty"`
+	BICFI string `xml:"FinInstnId>BICFI,omitempty"`
+	Other string `xml:"FinInstnId>Othr>Id,omitempty"`
+}
+
+// newAgent identifies a bank by its BIC or, as the schema requires an agent, as not provided
+func newAgent(bic string) agent {
+	if bic == "" {
+		return agent{Other: "NOTPROVIDED"}
+	}
+	return agent{BIC: bic}
+}
+
+// bic returns the BIC of the agent, whichever version of the element it was given in
+func (a *agent) bic() string {
+	if a == nil {
+		return ""
+	}
+	if a.BICFI != "" {
+		return strings.TrimSpace(a.BICFI)
+	}
+	return strings.TrimSpace(a.BIC)
+}
+
+// ParsePain001 reads a pain.001 message of any version and checks that its transaction counts
+// and control sums add up. Dates and times without a time zone are read in loc.
+func ParsePain001(content []byte, loc *time.Location) (*PaymentInitiation, error) {
+	var doc pain001Document
+	if err := xml.Unmarshal(content, &doc); err != nil || doc.Initiation == nil {
+		return nil, ErrNotISO20022
+	}
+	if doc.XMLName.Space != "" && !strings.HasPrefix(doc.XMLName.Space, pain001Prefix) {
+		return nil, ErrNotISO20022
+	}
+
+	header := doc.Initiation.GroupHeader
+	initiation := &PaymentInitiation{
+		MessageID:       strings.TrimSpace(header.MessageID),
+		InitiatingParty: strings.TrimSpace(header.InitiatingParty.Name),
+		Payments:        []*Payment{},
+	}
+	if initiation.MessageID == "" {
+		return nil, errors.New("message ID is required")
+	}
+
+	var err error
+	if initiation.CreatedAt, err = parseDateTime(header.CreatedAt, loc); err != nil {
+		return nil, errors.New("message creation time is invalid")
+	}
+
+	for _, p := range doc.Initiation.Payments {
+		payment, err := parsePain001Payment(p, loc)
+		if err != nil {
+			return nil, err
+		}
+		initiation.Payments = append(initiation.Payments, payment)
+	}
+
+	transfers := initiation.Transfers()
+	if len(transfers) == 0 {
+		return nil, errors.New("message has no credit transfers")
+	}
+
+	if err := checkTotals(header.NumberOfTxs, header.ControlSum, transfers); err != nil {
+		return nil, err
+	}
+
+	return initiation, nil
+}
+
+// parsePain001Payment reads a payment information block
+func parsePain001Payment(p pain001Payment, loc *time.Location) (*Payment, error) {
+	if method := strings.TrimSpace(p.Method); method != "TRF" {
+		return nil, errors.New("only credit transfers (payment method TRF) are supported")
+	}
+
+	payment := &Payment{
+		ID:             strings.TrimSpace(p.ID),
+		DebtorName:     strings.TrimSpace(p.Debtor.Name),
+		DebtorAccount:  p.DebtorAccount.number(),
+		DebtorAgentBIC: p.DebtorAgent.bic(),
+		Transfers:      []*CreditTransfer{},
+	}
+	if payment.DebtorAccount == "" {
+		return nil, errors.New("debtor account is required")
+	}
+
+	var err error
+	if payment.ExecutionDate, err = p.ExecutionDate.time(loc); err != nil {
+		return nil, errors.New("requested execution date is invalid")
+	}
+
+	for _, t := range p.Transfers {
+		transfer := &CreditTransfer{
+			EndToEndID:       strings.TrimSpace(t.EndToEndID),
+			Currency:         strings.ToUpper(strings.TrimSpace(t.Amount.Currency)),
+			CreditorName:     strings.TrimSpace(t.Creditor.Name),
+			CreditorAccount:  t.CreditorAccount.number(),
+			CreditorAgentBIC: t.CreditorAgent.bic(),
+			RemittanceInfo:   t.Remittance.text(),
+		}
+
+		transfer.Amount, err = parseAmount(t.Amount.Value)
+		if err != nil || transfer.Amount == 0 {
+			return nil, errors.New("credit transfer amount must be a positive decimal number")
+		}
+		if transfer.Currency == "" {
+			return nil, errors.New("credit transfer currency is required")
+		}
+		if transfer.CreditorAccount == "" {
+			return nil, errors.New("creditor account is required")
+		}
+
+		payment.Transfers = append(payment.Transfers, transfer)
+	}
+
+	if err := checkTotals(p.NumberOfTxs, p.ControlSum, payment.Transfers); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// checkTotals checks a declared number of transactions and an optional control sum against transfers
+func checkTotals(numberOfTxs string, controlSum string, transfers []*CreditTransfer) error {
+	if numberOfTxs = strings.TrimSpace(numberOfTxs); numberOfTxs != "" {
+		if count, err := strconv.Atoi(numberOfTxs); err != nil || count != len(transfers) {
+			return errors.New("number of transactions does not match the credit transfers")
+		}
+	}
+
+	if controlSum = strings.TrimSpace(controlSum); controlSum != "" {
+		declared, err := parseAmount(controlSum)
+		if err != nil || math.Abs(declared-sumTransfers(transfers)) >= 0.005 {
+			return errors.New("control sum does not match the credit transfers")
+		}
+	}
+
+	return nil
+}
+
+// sumTransfers adds up the amounts of credit transfers, rounded to cents
+func sumTransfers(transfers []*CreditTransfer) float64 {
+	var sum float64
+	for _, transfer := range transfers {
+		sum += transfer.Amount
+	}
+	return math.Round(sum*100) / 100
+}
+
+// MarshalPain001 writes a payment initiation as a pain.001.001.03 message with its transaction
+// counts and control sums. Dates and times are written without a time zone, in the location they
+// are in.
+func MarshalPain001(p *PaymentInitiation) ([]byte, error) {
+	transfers := p.Transfers()
+	doc := pain001Document{
+		XMLName:   xml.Name{Local: "Document"},
+		Namespace: Pain001Namespace,
+		Initiation: &pain001Initiation{
+			GroupHeader: pain001GroupHeader{
+				MessageID:       p.MessageID,
+				CreatedAt:       p.CreatedAt.Format(isoDateTime),
+				NumberOfTxs:     strconv.Itoa(len(transfers)),
+				ControlSum:      strconv.FormatFloat(sumTransfers(transfers), 'f', 2, 64),
+				InitiatingParty: party{Name: p.InitiatingParty},
+			},
+		},
+	}
+
+	for _, payment := range p.Payments {
+		block := pain001Payment{
+			ID:            payment.ID,
+			Method:        "TRF",
+			NumberOfTxs:   strconv.Itoa(len(payment.Transfers)),
+			ControlSum:    strconv.FormatFloat(sumTransfers(payment.Transfers), 'f', 2, 64),
+			ExecutionDate: date{Value: payment.ExecutionDate.Format(isoDate)},
+			Debtor:        party{Name: payment.DebtorName},
+			DebtorAccount: accountID{Other: payment.DebtorAccount},
+			DebtorAgent:   newAgent(payment.DebtorAgentBIC),
+		}
+
+		for _, transfer := range payment.Transfers {
+			t := pain001Transfer{
+				EndToEndID:      transfer.EndToEndID,
+				Amount:          newAmount(transfer.Amount, transfer.Currency),
+				Creditor:        party{Name: transfer.CreditorName},
+				CreditorAccount: accountID{Other: transfer.CreditorAccount},
+				Remittance:      newRemittance(transfer.RemittanceInfo),
+			}
+			if t.EndToEndID == "" {
+				t.EndToEndID = "NOTPROVIDED"
+			}
+			if transfer.CreditorAgentBIC != "" {
+				creditorAgent := newAgent(transfer.CreditorAgentBIC)
+				t.CreditorAgent = &creditorAgent
+			}
+			block.Transfers = append(block.Transfers, t)
+		}
+
+		doc.Initiation.Payments = append(doc.Initiation.Payments, block)
+	}
+
+	return marshalDocument(doc)
+}
+
+// marshalDocument writes a message document with the XML declaration
+func marshalDocument(doc interface{}) ([]byte, error) {
+	content, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
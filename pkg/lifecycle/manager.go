@@ -0,0 +1,136 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrShutdownTimeout is returned when background work does not finish before the shutdown deadline
+var ErrShutdownTimeout = errors.New("shutdown timed out waiting for background tasks")
+
+// Manager tracks long-running loops and short-lived background tasks so that
+// the application can stop accepting new work and wait for in-flight work on shutdown
+type Manager struct {
+	logger *logrus.Logger
+
+	// stopCtx is cancelled when shutdown begins; loops use it to stop scheduling new runs
+	stopCtx context.Context
+	stop    context.CancelFunc
+
+	// workCtx is cancelled only when the shutdown deadline expires, so in-flight work
+	// (a scheduler run, an email send) is allowed to complete
+	workCtx context.Context
+	abort   context.CancelFunc
+
+	group *errgroup.Group
+
+	mu       sync.Mutex
+	stopping bool
+}
+
+// NewManager creates a new Manager
+func NewManager(logger *logrus.Logger) *Manager {
+	stopCtx, stop := context.WithCancel(context.Background())
+	workCtx, abort := context.WithCancel(context.Background())
+
+	group, groupCtx := errgroup.WithContext(stopCtx)
+
+	return &Manager{
+		logger:  logger,
+		stopCtx: groupCtx,
+		stop:    stop,
+		workCtx: workCtx,
+		abort:   abort,
+		group:   group,
+	}
+}
+
+// Done returns a channel that is closed when shutdown begins or a loop fails
+func (m *Manager) Done() <-chan struct{} {
+	return m.stopCtx.Done()
+}
+
+// Go starts a long-running loop. The loop receives a context that is cancelled when
+// shutdown begins and must return once it is. A non-nil error triggers shutdown.
+func (m *Manager) Go(name string, fn func(ctx context.Context) error) {
+	m.group.Go(func() error {
+		m.logger.Infof("Background loop %s started", name)
+		err := fn(m.stopCtx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			m.logger.Errorf("Background loop %s failed: %v", name, err)
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		m.logger.Infof("Background loop %s stopped", name)
+		return nil
+	})
+}
+
+// Every runs fn immediately and then on every interval until shutdown begins.
+// A run that is in progress when shutdown begins is allowed to finish.
+func (m *Manager) Every(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	m.Go(name, func(stopCtx context.Context) error {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if err := fn(m.workCtx); err != nil {
+				m.logger.Warnf("Job %s failed: %v", name, err)
+			}
+
+			select {
+			case <-stopCtx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	})
+}
+
+// Background runs a short-lived task (e.g. sending a notification) that shutdown waits for.
+// Tasks submitted after shutdown has begun are still run so that no notification is lost.
+func (m *Manager) Background(name string, fn func(ctx context.Context) error) {
+	m.mu.Lock()
+	stopping := m.stopping
+	m.mu.Unlock()
+
+	if stopping {
+		m.logger.Warnf("Background task %s submitted during shutdown", name)
+	}
+
+	m.group.Go(func() error {
+		if err := fn(m.workCtx); err != nil {
+			m.logger.Warnf("Background task %s failed: %v", name, err)
+		}
+		return nil
+	})
+}
+
+// Shutdown stops all loops and waits for in-flight work until ctx expires.
+// When the deadline is reached the context passed to running work is cancelled.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopping = true
+	m.mu.Unlock()
+
+	m.stop()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.group.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		m.abort()
+		return err
+	case <-ctx.Done():
+		m.abort()
+		return ErrShutdownTimeout
+	}
+}
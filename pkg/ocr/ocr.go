@@ -0,0 +1,31 @@
+package ocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Supported recognizers
+const (
+	ProviderStub = "stub"
+)
+
+// ErrUnreadable is returned when no amount can be recognized in the image
+var ErrUnreadable = errors.New("amount could not be recognized")
+
+// Reader recognizes the amount of a cheque or payment order from its image
+type Reader interface {
+	ReadAmount(ctx context.Context, content []byte) (float64, error)
+}
+
+// NewReader creates a Reader for the given provider
+func NewReader(provider string) (Reader, error) {
+	switch strings.ToLower(provider) {
+	case ProviderStub:
+		return stubReader{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported OCR provider %q", provider)
+	}
+}
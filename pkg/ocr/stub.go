@@ -0,0 +1,30 @@
+package ocr
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+)
+
+// stubSum matches the amount field of an ST00012 payment string, in kopecks
+var stubSum = regexp.MustCompile(`(?:^|\|)Sum=([0-9]{1,15})(?:\||$|\s)`)
+
+// stubReader stands in for a real OCR service. It does not look at the picture; it finds the
+// ST00012 payment string that payment orders carry in their QR code when it is embedded in
+// the file as text, e.g. in a PDF or in image metadata.
+type stubReader struct{}
+
+// ReadAmount returns the amount of the first ST00012 "Sum=" field in the content
+func (stubReader) ReadAmount(ctx context.Context, content []byte) (float64, error) {
+	match := stubSum.FindSubmatch(content)
+	if match == nil {
+		return 0, ErrUnreadable
+	}
+
+	kopecks, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil || kopecks == 0 {
+		return 0, ErrUnreadable
+	}
+
+	return float64(kopecks) / 100, nil
+}
@@ -0,0 +1,468 @@
+// Package parquet writes Parquet files with flat schemas of required and optional columns. Every
+// column chunk is one data page with PLAIN encoding and GZIP compression, which all Parquet readers
+// support.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// magic starts and ends every Parquet file
+const magic = "PAR1"
+
+// createdBy is recorded in the file metadata as the writer of the file
+const createdBy = "banking-service"
+
+// Type is the type of a column and the Go type of its values
+type Type int
+
+// Column types
+const (
+	Int64     Type = iota // int64 or int
+	Double                // float64
+	String                // string, UTF-8
+	Boolean               // bool
+	Timestamp             // time.Time, stored as milliseconds since the epoch in UTC
+	Date                  // time.Time, stored as the calendar day
+	Decimal               // float64, stored as decimal(18,2) for amounts
+)
+
+// decimalScale and decimalPrecision define the Decimal type
+const (
+	decimalScale     = 2
+	decimalPrecision = 18
+)
+
+// Parquet physical types, converted types, encodings and codecs the writer uses
+const (
+	physicalBoolean   = 0
+	physicalInt32     = 1
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedDecimal         = 5
+	convertedDate            = 6
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecGzip = 2
+
+	pageTypeData = 0
+)
+
+// String returns the name of the type, as recorded in schema manifests
+func (t Type) String() string {
+	switch t {
+	case Int64:
+		return "INT64"
+	case Double:
+		return "DOUBLE"
+	case String:
+		return "STRING"
+	case Boolean:
+		return "BOOLEAN"
+	case Timestamp:
+		return "TIMESTAMP"
+	case Date:
+		return "DATE"
+	case Decimal:
+		return fmt.Sprintf("DECIMAL(%d,%d)", decimalPrecision, decimalScale)
+	default:
+		return fmt.Sprintf("Type(%d)", int(t))
+	}
+}
+
+// physical returns the Parquet physical type the values are stored as
+func (t Type) physical() int32 {
+	switch t {
+	case Double:
+		return physicalDouble
+	case String:
+		return physicalByteArray
+	case Boolean:
+		return physicalBoolean
+	case Date:
+		return physicalInt32
+	default:
+		return physicalInt64
+	}
+}
+
+// Column is a column of a file
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool // values may be nil
+}
+
+// columnChunk is where a column of a row group was written
+type columnChunk struct {
+	offset       int64
+	compressed   int64
+	uncompressed int64
+	values       int
+}
+
+// rowGroup is a written row group
+type rowGroup struct {
+	chunks []columnChunk
+	rows   int
+	size   int64
+}
+
+// Writer writes a Parquet file. Rows are written in row groups; Close writes the file metadata.
+type Writer struct {
+	w         io.Writer
+	offset    int64
+	columns   []Column
+	metadata  map[string]string
+	rowGroups []rowGroup
+	rows      int64
+	closed    bool
+}
+
+// NewWriter starts a Parquet file with the given columns. The metadata is stored in the file as
+// key-value pairs.
+func NewWriter(w io.Writer, columns []Column, metadata map[string]string) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("a Parquet file needs at least one column")
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if column.Name == "" || seen[column.Name] {
+			return nil, fmt.Errorf("invalid or duplicate column name %q", column.Name)
+		}
+		if column.Type < Int64 || column.Type > Decimal {
+			return nil, fmt.Errorf("column %s has an unknown type", column.Name)
+		}
+		seen[column.Name] = true
+	}
+
+	writer := &Writer{w: w, columns: columns, metadata: metadata}
+	if err := writer.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// WriteRowGroup writes rows as one row group. Each row has a value for every column, in column
+// order; nil is null and allowed in optional columns only.
+func (w *Writer) WriteRowGroup(rows [][]interface{}) error {
+	if w.closed {
+		return errors.New("parquet writer is closed")
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	for i, row := range rows {
+		if len(row) != len(w.columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(w.columns))
+		}
+	}
+
+	// Encode every column before writing any, so an invalid value does not leave a partial row group
+	pages := make([]*page, len(w.columns))
+	for i, column := range w.columns {
+		encoded, err := encodeColumn(column, rows, i)
+		if err != nil {
+			return err
+		}
+		pages[i] = encoded
+	}
+
+	group := rowGroup{rows: len(rows)}
+	for _, encoded := range pages {
+		chunk := columnChunk{
+			offset:       w.offset,
+			compressed:   int64(len(encoded.header) + len(encoded.data)),
+			uncompressed: int64(len(encoded.header) + encoded.size),
+			values:       len(rows),
+		}
+		if err := w.write(encoded.header); err != nil {
+			return err
+		}
+		if err := w.write(encoded.data); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+		group.size += chunk.uncompressed
+	}
+
+	w.rowGroups = append(w.rowGroups, group)
+	w.rows += int64(len(rows))
+
+	return nil
+}
+
+// page is an encoded data page
+type page struct {
+	header []byte
+	data   []byte // compressed
+	size   int    // uncompressed size of the data
+}
+
+// encodeColumn encodes the values of one column of a row group as a single data page
+func encodeColumn(column Column, rows [][]interface{}, index int) (*page, error) {
+	var values bytes.Buffer
+	var levels []byte
+	var bits []bool
+	for _, row := range rows {
+		value := row[index]
+		if value == nil {
+			if !column.Optional {
+				return nil, fmt.Errorf("column %s is required", column.Name)
+			}
+			levels = append(levels, 0)
+			continue
+		}
+		if column.Optional {
+			levels = append(levels, 1)
+		}
+
+		if column.Type == Boolean {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("column %s expects bool, got %T", column.Name, value)
+			}
+			bits = append(bits, b)
+			continue
+		}
+		if err := encodePlain(&values, column, value); err != nil {
+			return nil, err
+		}
+	}
+	if column.Type == Boolean {
+		values.Write(packBits(bits))
+	}
+
+	var body bytes.Buffer
+	if column.Optional {
+		encoded := encodeLevels(levels)
+		binary.Write(&body, binary.LittleEndian, uint32(len(encoded)))
+		body.Write(encoded)
+	}
+	body.Write(values.Bytes())
+
+	var compressed bytes.Buffer
+	zw := gzip.NewWriter(&compressed)
+	if _, err := zw.Write(body.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	header := &compactWriter{}
+	header.i32(1, pageTypeData)
+	header.i32(2, int32(body.Len()))
+	header.i32(3, int32(compressed.Len()))
+	header.begin(5)
+	header.i32(1, int32(len(rows)))
+	header.i32(2, encodingPlain)
+	header.i32(3, encodingRLE)
+	header.i32(4, encodingRLE)
+	header.end()
+
+	return &page{header: header.bytes(), data: compressed.Bytes(), size: body.Len()}, nil
+}
+
+// encodePlain appends a value with the PLAIN encoding of its column type
+func encodePlain(buf *bytes.Buffer, column Column, value interface{}) error {
+	var b [8]byte
+	switch column.Type {
+	case Int64:
+		var v int64
+		switch n := value.(type) {
+		case int64:
+			v = n
+		case int:
+			v = int64(n)
+		default:
+			return fmt.Errorf("column %s expects int64, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		buf.Write(b[:8])
+	case Double, Decimal:
+		v, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("column %s expects float64, got %T", column.Name, value)
+		}
+		if column.Type == Decimal {
+			binary.LittleEndian.PutUint64(b[:], uint64(int64(math.Round(v*math.Pow10(decimalScale)))))
+		} else {
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		}
+		buf.Write(b[:8])
+	case String:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("column %s expects string, got %T", column.Name, value)
+		}
+		binary.LittleEndian.PutUint32(b[:], uint32(len(v)))
+		buf.Write(b[:4])
+		buf.WriteString(v)
+	case Timestamp, Date:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("column %s expects time.Time, got %T", column.Name, value)
+		}
+		if column.Type == Date {
+			days := time.Date(v.Year(), v.Month(), v.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+			binary.LittleEndian.PutUint32(b[:], uint32(int32(days)))
+			buf.Write(b[:4])
+		} else {
+			binary.LittleEndian.PutUint64(b[:], uint64(v.UnixMilli()))
+			buf.Write(b[:8])
+		}
+	}
+
+	return nil
+}
+
+// packBits packs booleans one bit each, least significant bit first
+func packBits(bits []bool) []byte {
+	packed := make([]byte, (len(bits)+7)/8)
+	for i, bit := range bits {
+		if bit {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return packed
+}
+
+// encodeLevels encodes definition levels of bit width 1 as runs of the RLE/bit-packing hybrid
+func encodeLevels(levels []byte) []byte {
+	out := &compactWriter{}
+	for i := 0; i < len(levels); {
+		j := i
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		out.uvarint(uint64(j-i) << 1)
+		out.buf.WriteByte(levels[i])
+		i = j
+	}
+	return out.buf.Bytes()
+}
+
+// Close writes the file metadata and the footer. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	meta := &compactWriter{}
+	meta.i32(1, 1)
+
+	meta.list(2, thriftStruct, len(w.columns)+1)
+	meta.begin(0)
+	meta.string(4, "schema")
+	meta.i32(5, int32(len(w.columns)))
+	meta.end()
+	for _, column := range w.columns {
+		meta.begin(0)
+		meta.i32(1, column.Type.physical())
+		if column.Optional {
+			meta.i32(3, repetitionOptional)
+		} else {
+			meta.i32(3, repetitionRequired)
+		}
+		meta.string(4, column.Name)
+		switch column.Type {
+		case String:
+			meta.i32(6, convertedUTF8)
+		case Timestamp:
+			meta.i32(6, convertedTimestampMillis)
+		case Date:
+			meta.i32(6, convertedDate)
+		case Decimal:
+			meta.i32(6, convertedDecimal)
+			meta.i32(7, decimalScale)
+			meta.i32(8, decimalPrecision)
+		}
+		meta.end()
+	}
+
+	meta.i64(3, w.rows)
+
+	meta.list(4, thriftStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		meta.begin(0)
+		meta.list(1, thriftStruct, len(group.chunks))
+		for i, chunk := range group.chunks {
+			meta.begin(0)
+			meta.i64(2, chunk.offset)
+			meta.begin(3)
+			meta.i32(1, w.columns[i].Type.physical())
+			meta.listI32(2, encodingPlain, encodingRLE)
+			meta.listString(3, w.columns[i].Name)
+			meta.i32(4, codecGzip)
+			meta.i64(5, int64(chunk.values))
+			meta.i64(6, chunk.uncompressed)
+			meta.i64(7, chunk.compressed)
+			meta.i64(9, chunk.offset)
+			meta.end()
+			meta.end()
+		}
+		meta.i64(2, group.size)
+		meta.i64(3, int64(group.rows))
+		meta.end()
+	}
+
+	if len(w.metadata) > 0 {
+		keys := make([]string, 0, len(w.metadata))
+		for key := range w.metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		meta.list(5, thriftStruct, len(keys))
+		for _, key := range keys {
+			meta.begin(0)
+			meta.string(1, key)
+			meta.string(2, w.metadata[key])
+			meta.end()
+		}
+	}
+
+	meta.string(6, createdBy)
+
+	footer := meta.bytes()
+	if err := w.write(footer); err != nil {
+		return err
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	if err := w.write(length[:]); err != nil {
+		return err
+	}
+
+	return w.write([]byte(magic))
+}
+
+// write writes to the underlying writer and keeps track of the offset
+func (w *Writer) write(p []byte) error {
+	n, err := w.w.Write(p)
+	w.offset += int64(n)
+	return err
+}
@@ -0,0 +1,93 @@
+package pdf
+
+import (
+	"strings"
+	"unicode"
+)
+
+// helveticaWidths and helveticaBoldWidths are the widths of the printable ASCII characters, from
+// space to tilde, in thousandths of the font size
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// defaultWidth is used for the characters beyond ASCII
+const defaultWidth = 556
+
+// TextWidth returns the width of a line of text in points
+func TextWidth(font Font, size float64, text string) float64 {
+	widths := &helveticaWidths
+	if font == HelveticaBold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, c := range encode(text) {
+		if c >= ' ' && c <= '~' {
+			total += widths[c-' ']
+		} else {
+			total += defaultWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// winAnsi maps the characters of WinAnsiEncoding outside Latin-1 to their codes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// cyrillic transliterates Russian letters; capitals are derived from these
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu",
+	'я': "ia",
+}
+
+// encode converts text to WinAnsiEncoding, transliterating Cyrillic and replacing what the
+// encoding lacks with "?"
+func encode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, c := range text {
+		switch {
+		case c >= ' ' && c <= '~', c >= 0xA0 && c <= 0xFF:
+			out = append(out, byte(c))
+		case c == '\t' || c == '\n' || c == '\r':
+			out = append(out, ' ')
+		case c == '№':
+			out = append(out, "No."...)
+		default:
+			if code, ok := winAnsi[c]; ok {
+				out = append(out, code)
+			} else if latin, ok := cyrillic[c]; ok {
+				out = append(out, latin...)
+			} else if latin, ok := cyrillic[unicode.ToLower(c)]; ok {
+				if latin != "" {
+					latin = strings.ToUpper(latin[:1]) + latin[1:]
+				}
+				out = append(out, latin...)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,207 @@
+// Package pdf writes simple PDF documents: A4 pages with text in the standard Helvetica fonts,
+// lines, rectangles and circles. The standard fonts need no embedding but only cover Western
+// European characters, so Cyrillic text is transliterated and other characters print as "?".
+// Coordinates are in points from the bottom left corner of the page, as in PDF itself.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// A4 page size in points
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// producer is recorded in the document information as the program that wrote the file
+const producer = "banking-service"
+
+// Font is one of the standard fonts
+type Font int
+
+// Fonts
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+// resource returns the name the page resources give the font
+func (f Font) resource() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Document is a PDF document being written
+type Document struct {
+	title   string
+	created time.Time
+	pages   []*Page
+}
+
+// New creates an empty document with a title shown by PDF viewers
+func New(title string) *Document {
+	return &Document{title: title, created: time.Now()}
+}
+
+// AddPage adds an A4 portrait page to the document
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Page is a page of a document; its methods append drawing operations to the page content
+type Page struct {
+	content bytes.Buffer
+}
+
+// Text draws a line of text starting at x on the baseline y
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		font.resource(), number(size), number(x), number(y), escape(encode(text)))
+}
+
+// TextRight draws a line of text ending at x on the baseline y
+func (p *Page) TextRight(x, y float64, font Font, size float64, text string) {
+	p.Text(x-TextWidth(font, size, text), y, font, size, text)
+}
+
+// TextCenter draws a line of text centered on x on the baseline y
+func (p *Page) TextCenter(x, y float64, font Font, size float64, text string) {
+	p.Text(x-TextWidth(font, size, text)/2, y, font, size, text)
+}
+
+// FillColor sets the color of text and filled shapes, with components from 0 to 1
+func (p *Page) FillColor(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", number(r), number(g), number(b))
+}
+
+// StrokeColor sets the color of lines and outlines, with components from 0 to 1
+func (p *Page) StrokeColor(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s RG\n", number(r), number(g), number(b))
+}
+
+// Line draws a line of the width
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		number(width), number(x1), number(y1), number(x2), number(y2))
+}
+
+// FillRect fills a rectangle given by its bottom left corner and size
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", number(x), number(y), number(w), number(h))
+}
+
+// StrokeCircle outlines a circle, drawn as four Bezier curves
+func (p *Page) StrokeCircle(cx, cy, r, width float64) {
+	k := r * 0.5523 // distance of the control points approximating a quarter circle
+	fmt.Fprintf(&p.content, "%s w %s %s m\n", number(width), number(cx+r), number(cy))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c\n", number(cx+r), number(cy+k), number(cx+k), number(cy+r), number(cx), number(cy+r))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c\n", number(cx-k), number(cy+r), number(cx-r), number(cy+k), number(cx-r), number(cy))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c\n", number(cx-r), number(cy-k), number(cx-k), number(cy-r), number(cx), number(cy-r))
+	fmt.Fprintf(&p.content, "%s %s %s %s %s %s c S\n", number(cx+k), number(cy-r), number(cx+r), number(cy-k), number(cx+r), number(cy))
+}
+
+// Save saves the colors and the coordinate system, to be restored by Restore
+func (p *Page) Save() {
+	p.content.WriteString("q\n")
+}
+
+// Restore restores the colors and the coordinate system saved by the last Save
+func (p *Page) Restore() {
+	p.content.WriteString("Q\n")
+}
+
+// Rotate rotates what is drawn next counterclockwise around a point, until Restore
+func (p *Page) Rotate(cx, cy, degrees float64) {
+	sin, cos := math.Sincos(degrees * math.Pi / 180)
+	fmt.Fprintf(&p.content, "1 0 0 1 %s %s cm %.4f %.4f %.4f %.4f 0 0 cm 1 0 0 1 %s %s cm\n",
+		number(cx), number(cy), cos, sin, -sin, cos, number(-cx), number(-cy))
+}
+
+// Bytes writes the document
+func (d *Document) Bytes() ([]byte, error) {
+	var out bytes.Buffer
+	var offsets []int
+
+	// Objects are numbered from 1 in the order they are written
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, 5 document information, then each page and its content
+	const firstPage = 6
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (%s) /CreationDate (%s) >>",
+		escape(encode(d.title)), producer, d.created.UTC().Format("D:20060102150405Z")))
+
+	for i, page := range d.pages {
+		var content bytes.Buffer
+		writer := zlib.NewWriter(&content)
+		if _, err := writer.Write(page.content.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		if err := writer.Close(); err != nil {
+			return nil, fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			number(PageWidth), number(PageHeight), firstPage+2*i+1))
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			content.Len(), content.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f\r\n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n\r\n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes(), nil
+}
+
+// number formats a coordinate or size with at most two decimals
+func number(value float64) string {
+	s := fmt.Sprintf("%.2f", value)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// escape escapes a PDF literal string
+func escape(text []byte) string {
+	var b strings.Builder
+	for _, c := range text {
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,456 @@
+// Package qr encodes data as QR codes (ISO/IEC 18004) in byte mode with error correction level M,
+// which survives about 15% of the code being damaged. Versions 1 to 10 are supported, enough for
+// up to 213 bytes such as the links printed on documents.
+package qr
+
+import (
+	"errors"
+	"math"
+)
+
+// MaxBytes is the most data a code can hold
+const MaxBytes = 213
+
+// Quiet is the width in modules of the light border readers need around a code
+const Quiet = 4
+
+// version describes the error correction blocks of a version at level M
+type version struct {
+	ecPerBlock int    // error correction codewords of each block
+	blocks     [2]int // number of short and long blocks
+	shortData  int    // data codewords of a short block; long blocks hold one more
+	alignment  []int  // centers of the alignment patterns
+}
+
+// versions lists versions 1 to 10 at level M
+var versions = []version{
+	{10, [2]int{1, 0}, 16, nil},
+	{16, [2]int{1, 0}, 28, []int{6, 18}},
+	{26, [2]int{1, 0}, 44, []int{6, 22}},
+	{18, [2]int{2, 0}, 32, []int{6, 26}},
+	{24, [2]int{2, 0}, 43, []int{6, 30}},
+	{16, [2]int{4, 0}, 27, []int{6, 34}},
+	{18, [2]int{4, 0}, 31, []int{6, 22, 38}},
+	{22, [2]int{2, 2}, 38, []int{6, 24, 42}},
+	{22, [2]int{3, 2}, 36, []int{6, 26, 46}},
+	{26, [2]int{4, 1}, 43, []int{6, 28, 50}},
+}
+
+// dataCodewords returns the number of data codewords of the version
+func (v version) dataCodewords() int {
+	return v.blocks[0]*v.shortData + v.blocks[1]*(v.shortData+1)
+}
+
+// Code is an encoded QR code, without the quiet zone
+type Code struct {
+	Size    int // modules per side
+	modules []bool
+}
+
+// Dark reports whether the module in column x and row y is dark
+func (c *Code) Dark(x, y int) bool {
+	return c.modules[y*c.Size+x]
+}
+
+// Encode encodes data in the smallest version that holds it
+func Encode(data []byte) (*Code, error) {
+	for i, v := range versions {
+		number := i + 1
+		countBits := 8
+		if number >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) > 8*v.dataCodewords() {
+			continue
+		}
+
+		codewords := v.addErrorCorrection(v.encodeData(data, countBits))
+		return build(number, v, codewords), nil
+	}
+
+	return nil, errors.New("data is too long for a QR code")
+}
+
+// encodeData lays out the byte mode segment, the terminator and the padding
+func (v version) encodeData(data []byte, countBits int) []byte {
+	bits := &bitBuffer{}
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := 8 * v.dataCodewords()
+	terminator := capacity - bits.len
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-bits.len%8)%8)
+
+	codewords := bits.bytes
+	for pad := byte(0xEC); len(codewords) < v.dataCodewords(); pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	return codewords
+}
+
+// addErrorCorrection splits the data into blocks, adds their error correction codewords and
+// interleaves them
+func (v version) addErrorCorrection(data []byte) []byte {
+	divisor := reedSolomonDivisor(v.ecPerBlock)
+
+	var blocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < v.blocks[0]+v.blocks[1]; i++ {
+		size := v.shortData
+		if i >= v.blocks[0] {
+			size++
+		}
+		block := data[offset : offset+size]
+		offset += size
+
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+	}
+
+	result := make([]byte, 0, len(data)+len(blocks)*v.ecPerBlock)
+	for i := 0; i <= v.shortData; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < v.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+
+	return result
+}
+
+// matrix is a code being built, tracking which modules belong to function patterns
+type matrix struct {
+	size     int
+	modules  []bool
+	function []bool
+}
+
+// set sets a function module
+func (m *matrix) set(x, y int, dark bool) {
+	m.modules[y*m.size+x] = dark
+	m.function[y*m.size+x] = true
+}
+
+// build draws the function patterns and the codewords, and applies the mask with the lowest penalty
+func build(number int, v version, codewords []byte) *Code {
+	size := 17 + 4*number
+	m := &matrix{size: size, modules: make([]bool, size*size), function: make([]bool, size*size)}
+
+	// Timing patterns
+	for i := 0; i < size; i++ {
+		m.set(6, i, i%2 == 0)
+		m.set(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns with their separators
+	for _, center := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x < 0 || x >= size || y < 0 || y >= size {
+					continue
+				}
+				distance := max(abs(dx), abs(dy))
+				m.set(x, y, distance != 2 && distance != 4)
+			}
+		}
+	}
+
+	// Alignment patterns, except where they would overlap the finder patterns
+	last := len(v.alignment) - 1
+	for i, cx := range v.alignment {
+		for j, cy := range v.alignment {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					m.set(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+
+	// Reserve the format areas; the real bits depend on the mask
+	m.drawFormat(0)
+	m.drawVersion(number)
+
+	m.drawCodewords(codewords)
+
+	best, bestPenalty := 0, math.MaxInt
+	for mask := 0; mask < 8; mask++ {
+		m.applyMask(mask)
+		m.drawFormat(mask)
+		if penalty := m.penalty(); penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		m.applyMask(mask) // masking twice restores the modules
+	}
+	m.applyMask(best)
+	m.drawFormat(best)
+
+	return &Code{Size: size, modules: m.modules}
+}
+
+// drawFormat draws both copies of the format information for level M and the mask
+func (m *matrix) drawFormat(mask int) {
+	data := mask // level M is 00
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		m.set(8, i, bit(i))
+	}
+	m.set(8, 7, bit(6))
+	m.set(8, 8, bit(7))
+	m.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.set(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		m.set(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.set(8, m.size-15+i, bit(i))
+	}
+	m.set(8, m.size-8, true) // always dark
+}
+
+// drawVersion draws both copies of the version information of versions 7 and up
+func (m *matrix) drawVersion(number int) {
+	if number < 7 {
+		return
+	}
+
+	remainder := number
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	bits := number<<12 | remainder
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := m.size-11+i%3, i/3
+		m.set(a, b, dark)
+		m.set(b, a, dark)
+	}
+}
+
+// drawCodewords fills the data modules in the zigzag order, two columns at a time from the bottom
+// right corner; modules left over stay light
+func (m *matrix) drawCodewords(codewords []byte) {
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vertical := 0; vertical < m.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = m.size - 1 - vertical // upwards
+				}
+				if m.function[y*m.size+x] || i >= len(codewords)*8 {
+					continue
+				}
+				m.modules[y*m.size+x] = (codewords[i>>3]>>(7-(i&7)))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips the data modules selected by the mask pattern
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (x+y)%2 == 0
+			case 1:
+				flip = y%2 == 0
+			case 2:
+				flip = x%3 == 0
+			case 3:
+				flip = (x+y)%3 == 0
+			case 4:
+				flip = (x/3+y/2)%2 == 0
+			case 5:
+				flip = x*y%2+x*y%3 == 0
+			case 6:
+				flip = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				flip = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if flip && !m.function[y*m.size+x] {
+				m.modules[y*m.size+x] = !m.modules[y*m.size+x]
+			}
+		}
+	}
+}
+
+// penalty scores how hard the code is to read: long runs, 2x2 blocks, finder-like patterns and an
+// unbalanced share of dark modules
+func (m *matrix) penalty() int {
+	dark := func(x, y int) bool { return m.modules[y*m.size+x] }
+	penalty := 0
+
+	for _, horizontal := range []bool{true, false} {
+		for a := 0; a < m.size; a++ {
+			line := make([]bool, m.size)
+			for b := 0; b < m.size; b++ {
+				if horizontal {
+					line[b] = dark(b, a)
+				} else {
+					line[b] = dark(a, b)
+				}
+			}
+
+			run := 1
+			for b := 1; b <= m.size; b++ {
+				if b < m.size && line[b] == line[b-1] {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+
+			for b := 0; b+11 <= m.size; b++ {
+				if matches(line[b:b+11], "10111010000") || matches(line[b:b+11], "00001011101") {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	darkCount := 0
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if dark(x, y) {
+				darkCount++
+			}
+			if x+1 < m.size && y+1 < m.size {
+				d := dark(x, y)
+				if dark(x+1, y) == d && dark(x, y+1) == d && dark(x+1, y+1) == d {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	percent := darkCount * 100 / (m.size * m.size)
+	penalty += abs(percent-50) / 5 * 10
+
+	return penalty
+}
+
+// matches reports whether the modules follow a pattern of 1s (dark) and 0s (light)
+func matches(modules []bool, pattern string) bool {
+	for i, module := range modules {
+		if module != (pattern[i] == '1') {
+			return false
+		}
+	}
+	return true
+}
+
+// bitBuffer collects bits most significant first
+type bitBuffer struct {
+	bytes []byte
+	len   int
+}
+
+// append appends the lowest n bits of the value
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if b.len%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if (value>>i)&1 != 0 {
+			b.bytes[len(b.bytes)-1] |= 0x80 >> (b.len % 8)
+		}
+		b.len++
+	}
+}
+
+// reedSolomonDivisor returns the generator polynomial of the degree, without its leading term
+func reedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+
+	return result
+}
+
+// reedSolomonRemainder returns the error correction codewords of a block
+func reedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
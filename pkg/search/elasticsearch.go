@@ -0,0 +1,358 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// maxErrorBody limits how much of an error response ends up in the error message
+const maxErrorBody = 512
+
+// indexMapping types the document fields: keywords for the facets and filters, analyzed text for
+// the description. Unknown fields are rejected rather than guessed.
+var indexMapping = map[string]interface{}{
+	"mappings": map[string]interface{}{
+		"dynamic": "strict",
+		"properties": map[string]interface{}{
+			"id":          map[string]string{"type": "integer"},
+			"type":        map[string]string{"type": "keyword"},
+			"status":      map[string]string{"type": "keyword"},
+			"currency":    map[string]string{"type": "keyword"},
+			"description": map[string]string{"type": "text"},
+			"amount":      map[string]interface{}{"type": "scaled_float", "scaling_factor": 100},
+			"account_ids": map[string]string{"type": "integer"},
+			"card_id":     map[string]string{"type": "integer"},
+			"date":        map[string]string{"type": "date"},
+		},
+	},
+}
+
+// httpIndex talks to Elasticsearch or OpenSearch over their REST API. Searches and writes go
+// through an alias, which Rebuild moves from the old index to the new one.
+type httpIndex struct {
+	baseURL  *url.URL
+	alias    string
+	username string
+	password string
+	timeout  time.Duration
+	client   httpclient.Doer
+}
+
+// newHTTPIndex creates a new httpIndex
+func newHTTPIndex(opts Options) (*httpIndex, error) {
+	parsed, err := url.Parse(strings.TrimRight(opts.URL, "/"))
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid search URL %q", opts.URL)
+	}
+
+	if opts.Index == "" || strings.ToLower(opts.Index) != opts.Index || strings.ContainsAny(opts.Index, `/\*?"<>| ,#`) {
+		return nil, fmt.Errorf("invalid search index name %q", opts.Index)
+	}
+
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &httpIndex{
+		baseURL:  parsed,
+		alias:    opts.Index,
+		username: opts.Username,
+		password: opts.Password,
+		timeout:  opts.Timeout,
+		client:   opts.Client,
+	}, nil
+}
+
+// Put indexes the documents with one bulk request. The alias must exist, so documents are never
+// written to an index created on the fly with guessed field types before the first Rebuild.
+func (x *httpIndex) Put(ctx context.Context, docs []*Document) error {
+	return x.bulk(ctx, x.alias+"/_bulk?require_alias=true", docs)
+}
+
+// bulk sends index actions for the documents to a _bulk endpoint
+func (x *httpIndex) bulk(ctx context.Context, path string, docs []*Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, doc := range docs {
+		action := map[string]map[string]string{"index": {"_id": strconv.Itoa(doc.ID)}}
+		if err := encoder.Encode(action); err != nil {
+			return err
+		}
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string          `json:"_id"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := x.do(ctx, http.MethodPost, path, "application/x-ndjson", body.Bytes(), &response); err != nil {
+		return fmt.Errorf("failed to index documents: %w", err)
+	}
+
+	if response.Errors {
+		for _, item := range response.Items {
+			for _, result := range item {
+				if result.Status >= 300 {
+					return fmt.Errorf("failed to index document %s: %s", result.ID, truncate(result.Error))
+				}
+			}
+		}
+		return errors.New("failed to index documents")
+	}
+
+	return nil
+}
+
+// Search runs a bool query: the words of the text must all match the description, the other
+// fields filter. The facets are terms aggregations over all matches.
+func (x *httpIndex) Search(ctx context.Context, query *Query) (*Result, error) {
+	filters := []interface{}{
+		map[string]interface{}{"terms": map[string]interface{}{"account_ids": query.AccountIDs}},
+	}
+	for field, value := range map[string]string{"type": query.Type, "status": query.Status, "currency": query.Currency} {
+		if value != "" {
+			filters = append(filters, map[string]interface{}{"term": map[string]string{field: value}})
+		}
+	}
+	if query.From != nil || query.To != nil {
+		dates := map[string]string{}
+		if query.From != nil {
+			dates["gte"] = query.From.Format(time.RFC3339Nano)
+		}
+		if query.To != nil {
+			dates["lt"] = query.To.Format(time.RFC3339Nano)
+		}
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"date": dates}})
+	}
+	if query.MinAmount != nil || query.MaxAmount != nil {
+		amounts := map[string]float64{}
+		if query.MinAmount != nil {
+			amounts["gte"] = *query.MinAmount
+		}
+		if query.MaxAmount != nil {
+			amounts["lte"] = *query.MaxAmount
+		}
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"amount": amounts}})
+	}
+
+	boolQuery := map[string]interface{}{"filter": filters}
+	if query.Text != "" {
+		boolQuery["must"] = map[string]interface{}{
+			"match": map[string]interface{}{
+				"description": map[string]string{"query": query.Text, "operator": "and", "fuzziness": "AUTO"},
+			},
+		}
+	}
+
+	aggregations := map[string]interface{}{}
+	for _, facet := range []string{FacetType, FacetStatus, FacetCurrency} {
+		aggregations[facet] = map[string]interface{}{"terms": map[string]interface{}{"field": facet, "size": 100}}
+	}
+
+	request := map[string]interface{}{
+		"query":            map[string]interface{}{"bool": boolQuery},
+		"sort":             []interface{}{map[string]string{"date": "desc"}, map[string]string{"id": "desc"}},
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"_source":          false,
+		"aggs":             aggregations,
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+		Aggregations map[string]struct {
+			Buckets []struct {
+				Key   string `json:"key"`
+				Count int    `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"aggregations"`
+	}
+	if err := x.do(ctx, http.MethodPost, x.alias+"/_search", "application/json", body, &response); err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	result := &Result{
+		IDs:    make([]int, 0, len(response.Hits.Hits)),
+		Total:  response.Hits.Total.Value,
+		Facets: map[string]map[string]int{},
+	}
+	for _, hit := range response.Hits.Hits {
+		id, err := strconv.Atoi(hit.ID)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected document ID %q", hit.ID)
+		}
+		result.IDs = append(result.IDs, id)
+	}
+	for facet, aggregation := range response.Aggregations {
+		counts := map[string]int{}
+		for _, bucket := range aggregation.Buckets {
+			counts[bucket.Key] = bucket.Count
+		}
+		result.Facets[facet] = counts
+	}
+
+	return result, nil
+}
+
+// Rebuild fills a new timestamped index and then atomically moves the alias to it and deletes
+// the indexes it pointed to. A failed build is deleted and the alias left where it was.
+func (x *httpIndex) Rebuild(ctx context.Context, fill func(put func(docs []*Document) error) error) error {
+	previous, err := x.aliasedIndexes(ctx)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s", x.alias, time.Now().UTC().Format("20060102150405"))
+	mapping, err := json.Marshal(indexMapping)
+	if err != nil {
+		return err
+	}
+	if err := x.do(ctx, http.MethodPut, name, "application/json", mapping, nil); err != nil {
+		return fmt.Errorf("failed to create index %s: %w", name, err)
+	}
+
+	err = fill(func(docs []*Document) error {
+		return x.bulk(ctx, name+"/_bulk", docs)
+	})
+	if err == nil {
+		err = x.do(ctx, http.MethodPost, name+"/_refresh", "", nil, nil)
+	}
+	if err != nil {
+		x.do(ctx, http.MethodDelete, name, "", nil, nil)
+		return err
+	}
+
+	actions := []interface{}{}
+	for _, index := range previous {
+		actions = append(actions, map[string]interface{}{"remove": map[string]string{"index": index, "alias": x.alias}})
+	}
+	actions = append(actions, map[string]interface{}{"add": map[string]string{"index": name, "alias": x.alias}})
+	body, err := json.Marshal(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return err
+	}
+	if err := x.do(ctx, http.MethodPost, "_aliases", "application/json", body, nil); err != nil {
+		return fmt.Errorf("failed to switch alias %s to %s: %w", x.alias, name, err)
+	}
+
+	if len(previous) > 0 {
+		if err := x.do(ctx, http.MethodDelete, strings.Join(previous, ","), "", nil, nil); err != nil {
+			return fmt.Errorf("failed to delete the previous index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// aliasedIndexes lists the indexes the alias points to, none if it does not exist yet
+func (x *httpIndex) aliasedIndexes(ctx context.Context) ([]string, error) {
+	var response map[string]json.RawMessage
+	err := x.do(ctx, http.MethodGet, "_alias/"+x.alias, "", nil, &response)
+
+	var status *statusError
+	if errors.As(err, &status) && status.code == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alias %s: %w", x.alias, err)
+	}
+
+	indexes := make([]string, 0, len(response))
+	for index := range response {
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// statusError is an error response of the search engine
+type statusError struct {
+	code int
+	body string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("search engine responded with status %d: %s", e.code, e.body)
+}
+
+// do sends a request and decodes a successful JSON response into out, if it is not nil
+func (x *httpIndex) do(ctx context.Context, method, path, contentType string, body []byte, out interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, x.timeout)
+	defer cancel()
+
+	target := *x.baseURL
+	target.Path = x.baseURL.Path + "/" + strings.SplitN(path, "?", 2)[0]
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		target.RawQuery = path[i+1:]
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if x.username != "" {
+		req.SetBasicAuth(x.username, x.password)
+	}
+
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &statusError{code: resp.StatusCode, body: strings.TrimSpace(string(content))}
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode search engine response: %w", err)
+	}
+	return nil
+}
+
+// truncate shortens a raw JSON error for a message
+func truncate(raw json.RawMessage) string {
+	if len(raw) > maxErrorBody {
+		return string(raw[:maxErrorBody])
+	}
+	return string(raw)
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// Supported search engines. OpenSearch speaks the Elasticsearch REST API, so both use the same client.
+const (
+	ProviderElasticsearch = "elasticsearch"
+	ProviderOpenSearch    = "opensearch"
+)
+
+// MaxResultWindow is the deepest result a search can page to, the engines' default max_result_window
+const MaxResultWindow = 10000
+
+// Facets of a search result, counted over all documents that match
+const (
+	FacetType     = "type"
+	FacetStatus   = "status"
+	FacetCurrency = "currency"
+)
+
+// Document is a transaction as it is indexed
+type Document struct {
+	ID          int       `json:"id"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Currency    string    `json:"currency"`
+	Description string    `json:"description"`
+	Amount      float64   `json:"amount"`
+	AccountIDs  []int     `json:"account_ids"` // the source and destination accounts
+	CardID      *int      `json:"card_id,omitempty"`
+	Date        time.Time `json:"date"`
+}
+
+// Query selects a page of the transactions of some accounts, newest first
+type Query struct {
+	AccountIDs []int  // required, documents of other accounts never match
+	Text       string // full text of the description, every word must match
+	Type       string
+	Status     string
+	Currency   string
+	From       *time.Time // inclusive
+	To         *time.Time // exclusive
+	MinAmount  *float64
+	MaxAmount  *float64
+	Limit      int
+	Offset     int
+}
+
+// Result is a page of matching transaction IDs with the total and the facets of all matches
+type Result struct {
+	IDs    []int
+	Total  int
+	Facets map[string]map[string]int // by facet, the number of matches of each value
+}
+
+// Index keeps transactions searchable
+type Index interface {
+	// Put adds or replaces documents
+	Put(ctx context.Context, docs []*Document) error
+
+	// Search finds the documents matching a query
+	Search(ctx context.Context, query *Query) (*Result, error)
+
+	// Rebuild creates an empty index, fills it with fill and only then puts it in place of the
+	// current one, so searches keep working on the old index while it is being built
+	Rebuild(ctx context.Context, fill func(put func(docs []*Document) error) error) error
+}
+
+// Options configures an Index
+type Options struct {
+	URL      string // e.g. http://localhost:9200
+	Index    string // name searches and writes go through, an alias of the current index
+	Username string // basic authentication, optional
+	Password string
+	Timeout  time.Duration   // per request, including the client's retries
+	Client   httpclient.Doer // requests are sent with it, http.DefaultClient when nil
+}
+
+// NewIndex creates an Index for the given provider
+func NewIndex(provider string, opts Options) (Index, error) {
+	switch strings.ToLower(provider) {
+	case ProviderElasticsearch, ProviderOpenSearch:
+		return newHTTPIndex(opts)
+	default:
+		return nil, fmt.Errorf("unsupported search provider %q", provider)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// Supported storage backends
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("object not found")
+
+// Storage keeps binary objects under slash-separated keys
+type Storage interface {
+	Put(ctx context.Context, key string, content []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Options configures a Storage; only the fields of the chosen backend are used
+type Options struct {
+	// Local filesystem
+	Dir string
+
+	// S3 and S3-compatible storage
+	Endpoint  string // e.g. https://s3.eu-central-1.amazonaws.com, defaults to AWS for the region
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// Prefix is prepended to keys
+	Prefix  string
+	Timeout time.Duration   // per S3 call, including the client's retries
+	Client  httpclient.Doer // S3 requests are sent with it, http.DefaultClient when nil
+}
+
+// NewStorage creates a Storage for the given backend
+func NewStorage(backend string, opts Options) (Storage, error) {
+	switch strings.ToLower(backend) {
+	case BackendLocal:
+		return newLocalStorage(opts)
+	case BackendS3:
+		return newS3Storage(opts)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend %q", backend)
+	}
+}
+
+// validKey reports whether a key is a relative path without empty, "." or ".." segments
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return false
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package upload
+
+import (
+	"context"
+
+	"banking-service/pkg/storage"
+)
+
+// s3Uploader puts files into an S3 bucket through the S3 storage backend
+type s3Uploader struct {
+	store storage.Storage
+}
+
+// newS3Uploader creates a new s3Uploader
+func newS3Uploader(opts Options) (*s3Uploader, error) {
+	store, err := storage.NewStorage(storage.BackendS3, storage.Options{
+		Endpoint:  opts.Endpoint,
+		Region:    opts.Region,
+		Bucket:    opts.Bucket,
+		AccessKey: opts.AccessKey,
+		SecretKey: opts.SecretKey,
+		Prefix:    opts.Prefix,
+		Timeout:   opts.Timeout,
+		Client:    opts.Client,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Uploader{store: store}, nil
+}
+
+// Upload puts the file into the bucket under the configured prefix
+func (u *s3Uploader) Upload(ctx context.Context, name string, content []byte) error {
+	return u.store.Put(ctx, name, content, "application/octet-stream")
+}
@@ -0,0 +1,299 @@
+package upload
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SFTP protocol version 3 packet types and flags used for uploads
+const (
+	sftpInit    = 1
+	sftpVersion = 2
+	sftpOpen    = 3
+	sftpClose   = 4
+	sftpWrite   = 6
+	sftpStatus  = 101
+	sftpHandle  = 102
+
+	sftpFlagWrite    = 0x02
+	sftpFlagCreate   = 0x08
+	sftpFlagTruncate = 0x10
+
+	sftpStatusOK = 0
+
+	// sftpChunkSize keeps write packets well below the 34000 bytes every server must accept
+	sftpChunkSize = 32 * 1024
+)
+
+// sftpUploader writes files to a directory on an SFTP server
+type sftpUploader struct {
+	host   string
+	dir    string
+	config *ssh.ClientConfig
+}
+
+// newSFTPUploader creates a new sftpUploader
+func newSFTPUploader(opts Options) (*sftpUploader, error) {
+	if opts.Host == "" || opts.User == "" {
+		return nil, errors.New("sftp upload requires host and user")
+	}
+
+	if opts.HostKey == "" {
+		return nil, errors.New("sftp upload requires the server host key")
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(opts.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sftp host key: %w", err)
+	}
+
+	var auth []ssh.AuthMethod
+	if opts.PrivateKeyFile != "" {
+		pem, err := os.ReadFile(opts.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sftp private key: %w", err)
+		}
+
+		signer, err := ssh.ParsePrivateKey(pem)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sftp private key: %w", err)
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if opts.Password != "" {
+		auth = append(auth, ssh.Password(opts.Password))
+	}
+	if len(auth) == 0 {
+		return nil, errors.New("sftp upload requires a password or a private key")
+	}
+
+	host := opts.Host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "22")
+	}
+
+	return &sftpUploader{
+		host: host,
+		dir:  opts.Prefix,
+		config: &ssh.ClientConfig{
+			User:            opts.User,
+			Auth:            auth,
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         opts.Timeout,
+		},
+	}, nil
+}
+
+// Upload writes the file into the configured directory, replacing an existing file
+func (u *sftpUploader) Upload(ctx context.Context, name string, content []byte) error {
+	client, err := ssh.Dial("tcp", u.host, u.config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to sftp server: %w", err)
+	}
+	defer client.Close()
+
+	// Abort a stalled transfer when the context ends
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			client.Close()
+		case <-done:
+		}
+	}()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	defer session.Close()
+
+	conn := &sftpConn{}
+	if conn.w, err = session.StdinPipe(); err != nil {
+		return fmt.Errorf("failed to open sftp input: %w", err)
+	}
+	if conn.r, err = session.StdoutPipe(); err != nil {
+		return fmt.Errorf("failed to open sftp output: %w", err)
+	}
+
+	if err := session.RequestSubsystem("sftp"); err != nil {
+		return fmt.Errorf("failed to start sftp subsystem: %w", err)
+	}
+
+	if err := conn.init(); err != nil {
+		return err
+	}
+
+	if err := conn.writeFile(path.Join(u.dir, name), content); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// sftpConn speaks the subset of SFTP version 3 needed to write a file
+type sftpConn struct {
+	r      io.Reader
+	w      io.Writer
+	nextID uint32
+}
+
+// init negotiates the protocol version
+func (c *sftpConn) init() error {
+	if err := c.send(sftpInit, binary.BigEndian.AppendUint32(nil, 3)); err != nil {
+		return err
+	}
+
+	packetType, _, err := c.receive()
+	if err != nil {
+		return err
+	}
+
+	if packetType != sftpVersion {
+		return fmt.Errorf("unexpected sftp packet %d during init", packetType)
+	}
+
+	return nil
+}
+
+// writeFile creates or truncates a file and writes the content to it
+func (c *sftpConn) writeFile(name string, content []byte) error {
+	open := c.request()
+	open = appendString(open, []byte(name))
+	open = binary.BigEndian.AppendUint32(open, sftpFlagWrite|sftpFlagCreate|sftpFlagTruncate)
+	open = binary.BigEndian.AppendUint32(open, 0) // no attributes
+
+	handle, err := c.call(sftpOpen, open, sftpHandle)
+	if err != nil {
+		return err
+	}
+
+	handle, err = readString(handle)
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset < len(content); offset += sftpChunkSize {
+		end := offset + sftpChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		write := c.request()
+		write = appendString(write, handle)
+		write = binary.BigEndian.AppendUint64(write, uint64(offset))
+		write = appendString(write, content[offset:end])
+
+		if _, err := c.call(sftpWrite, write, sftpStatus); err != nil {
+			return err
+		}
+	}
+
+	closeFile := appendString(c.request(), handle)
+	_, err = c.call(sftpClose, closeFile, sftpStatus)
+	return err
+}
+
+// request starts a request payload with a new request ID
+func (c *sftpConn) request() []byte {
+	c.nextID++
+	return binary.BigEndian.AppendUint32(nil, c.nextID)
+}
+
+// call sends a request and returns the response payload after the request ID. A status
+// response is turned into an error unless it reports success.
+func (c *sftpConn) call(packetType byte, payload []byte, expected byte) ([]byte, error) {
+	if err := c.send(packetType, payload); err != nil {
+		return nil, err
+	}
+
+	responseType, response, err := c.receive()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response) < 4 {
+		return nil, errors.New("short sftp response")
+	}
+	response = response[4:]
+
+	if responseType == sftpStatus {
+		if len(response) < 4 {
+			return nil, errors.New("short sftp status")
+		}
+
+		code := binary.BigEndian.Uint32(response)
+		if code != sftpStatusOK {
+			message, _ := readString(response[4:])
+			return nil, fmt.Errorf("sftp error %d: %s", code, message)
+		}
+	}
+
+	if responseType != expected {
+		return nil, fmt.Errorf("unexpected sftp packet %d", responseType)
+	}
+
+	return response, nil
+}
+
+// send writes one packet
+func (c *sftpConn) send(packetType byte, payload []byte) error {
+	packet := binary.BigEndian.AppendUint32(nil, uint32(len(payload)+1))
+	packet = append(packet, packetType)
+	packet = append(packet, payload...)
+
+	if _, err := c.w.Write(packet); err != nil {
+		return fmt.Errorf("failed to send sftp packet: %w", err)
+	}
+
+	return nil
+}
+
+// receive reads one packet and returns its type and payload
+func (c *sftpConn) receive() (byte, []byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, nil, fmt.Errorf("failed to read sftp packet: %w", err)
+	}
+
+	length := binary.BigEndian.Uint32(header[:4])
+	if length < 1 || length > 256*1024 {
+		return 0, nil, fmt.Errorf("invalid sftp packet length %d", length)
+	}
+
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, nil, fmt.Errorf("failed to read sftp packet: %w", err)
+	}
+
+	return header[4], payload, nil
+}
+
+// appendString appends an SFTP string: a length followed by the bytes
+func appendString(b []byte, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+// readString reads an SFTP string from the start of b
+func readString(b []byte) ([]byte, error) {
+	if len(b) < 4 {
+		return nil, errors.New("short sftp string")
+	}
+
+	length := binary.BigEndian.Uint32(b)
+	if uint32(len(b)-4) < length {
+		return nil, errors.New("short sftp string")
+	}
+
+	return b[4 : 4+length], nil
+}
@@ -0,0 +1,55 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"banking-service/pkg/httpclient"
+)
+
+// Supported upload targets
+const (
+	TargetS3   = "s3"
+	TargetSFTP = "sftp"
+)
+
+// Uploader stores a file at a remote location
+type Uploader interface {
+	Upload(ctx context.Context, name string, content []byte) error
+}
+
+// Options configures an Uploader; only the fields of the chosen target are used
+type Options struct {
+	// S3 and S3-compatible storage
+	Endpoint  string // e.g. https://s3.eu-central-1.amazonaws.com, defaults to AWS for the region
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// SFTP
+	Host           string // host[:port], port 22 by default
+	User           string
+	Password       string
+	PrivateKeyFile string
+	HostKey        string // expected server key in authorized_keys format
+
+	// Prefix is prepended to file names: a key prefix for S3, a directory for SFTP
+	Prefix  string
+	Timeout time.Duration
+	Client  httpclient.Doer // S3 requests are sent with it, http.DefaultClient when nil
+}
+
+// NewUploader creates an Uploader for the given target
+func NewUploader(target string, opts Options) (Uploader, error) {
+	switch strings.ToLower(target) {
+	case TargetS3:
+		return newS3Uploader(opts)
+	case TargetSFTP:
+		return newSFTPUploader(opts)
+	default:
+		return nil, fmt.Errorf("unsupported upload target %q", target)
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"banking-service/pkg/i18n"
+)
+
+// RequestIDHeader carries the ID of a request. The request ID middleware sets it on the response
+// before the handler runs, so responses report it without needing the request.
+const RequestIDHeader = "X-Request-ID"
+
+// Envelope is the body of every JSON API response: the data of a success or the errors of a
+// failure, with the metadata of the response
+type Envelope struct {
+	Data   interface{} `json:"data"`
+	Meta   Meta        `json:"meta"`
+	Errors []Error     `json:"errors"`
+}
+
+// Meta describes a response
+type Meta struct {
+	Message    string      `json:"message,omitempty"`
+	RequestID  string      `json:"request_id,omitempty"`
+	Timestamp  time.Time   `json:"timestamp"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination describes the page of a list a response holds
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// Error is a reason a request failed. The code does not depend on the language of the message;
+// the params are the values the message of the code was formatted with.
+type Error struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Params  []string    `json:"params,omitempty"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Respond writes a successful response with its data
+func Respond(w http.ResponseWriter, code int, message string, data interface{}) {
+	RespondEnvelope(w, code, Envelope{Data: data, Meta: Meta{Message: message}})
+}
+
+// RespondPage writes a successful response with a page of a list
+func RespondPage(w http.ResponseWriter, code int, message string, data interface{}, page Pagination) {
+	RespondEnvelope(w, code, Envelope{Data: data, Meta: Meta{Message: message, Pagination: &page}})
+}
+
+// RespondError writes a failed response
+func RespondError(w http.ResponseWriter, code int, message string) {
+	RespondErrorDetails(w, code, message, nil)
+}
+
+// RespondErrorFrom writes a failed response with the message of an error. An i18n.Error, even a
+// wrapped one, gives the code and the params of the response, so the message is translated by them.
+func RespondErrorFrom(w http.ResponseWriter, code int, err error) {
+	var message *i18n.Error
+	if !errors.As(err, &message) {
+		RespondError(w, code, err.Error())
+		return
+	}
+
+	RespondEnvelope(w, code, Envelope{
+		Errors: []Error{{Code: message.Code, Message: err.Error(), Params: message.Params}},
+	})
+}
+
+// RespondErrorDetails writes a failed response with details a client can act on, like when to retry
+func RespondErrorDetails(w http.ResponseWriter, code int, message string, details interface{}) {
+	RespondEnvelope(w, code, Envelope{
+		Errors: []Error{{Code: errorCode(code, message), Message: message, Details: details}},
+	})
+}
+
+// RespondEnvelope fills in the request ID and timestamp of an envelope and writes it
+func RespondEnvelope(w http.ResponseWriter, code int, envelope Envelope) {
+	envelope.Meta.RequestID = w.Header().Get(RequestIDHeader)
+	envelope.Meta.Timestamp = time.Now().UTC()
+	if envelope.Errors == nil {
+		envelope.Errors = []Error{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(envelope)
+}
+
+// errorCode returns the code of an error message from the message catalog, or one named after
+// the status for messages missing from it
+func errorCode(status int, message string) string {
+	if code, ok := i18n.Code(message); ok {
+		return code
+	}
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
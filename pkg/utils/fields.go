@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ParseFields parses the comma-separated ?fields= query parameter
+func ParseFields(r *http.Request) []string {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// SelectFields reduces a JSON-serializable value (an object or a list of objects)
+// to the requested top-level fields. Fields that do not exist are ignored.
+func SelectFields(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 || data == nil {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	// Decode numbers as json.Number to keep their original representation
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to decode data: %w", err)
+	}
+
+	allowed := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		allowed[field] = true
+	}
+
+	switch value := generic.(type) {
+	case []interface{}:
+		for i, item := range value {
+			value[i] = filterObject(item, allowed)
+		}
+		return value, nil
+	default:
+		return filterObject(value, allowed), nil
+	}
+}
+
+// RespondWithFields responds with success, applying the ?fields= sparse fieldset if present
+func RespondWithFields(w http.ResponseWriter, r *http.Request, code int, message string, data interface{}) {
+	selected, err := SelectFields(data, ParseFields(r))
+	if err != nil {
+		RespondError(w, http.StatusInternalServerError, "failed to select fields")
+		return
+	}
+
+	Respond(w, code, message, selected)
+}
+
+// filterObject keeps only the allowed keys of a JSON object; other values are returned as is
+func filterObject(value interface{}, allowed map[string]bool) interface{} {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return value
+	}
+
+	filtered := make(map[string]interface{}, len(allowed))
+	for key, fieldValue := range object {
+		if allowed[key] {
+			filtered[key] = fieldValue
+		}
+	}
+
+	return filtered
+}
@@ -10,37 +10,148 @@ CREATE EXTENSION IF NOT EXISTS pgcrypto;
 -- Create tables
 CREATE TABLE users (
     id SERIAL PRIMARY KEY,
-    username VARCHAR(50) UNIQUE NOT NULL,
-    email VARCHAR(100) UNIQUE NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    email VARCHAR(100) NOT NULL,
     password_hash VARCHAR(255) NOT NULL,
     first_name VARCHAR(100),
     last_name VARCHAR(100),
+    phone VARCHAR(16) NOT NULL DEFAULT '',
+    birth_date DATE,
+    address_country CHAR(2) NOT NULL DEFAULT '',
+    address_city VARCHAR(100) NOT NULL DEFAULT '',
+    address_street VARCHAR(200) NOT NULL DEFAULT '',
+    address_postal_code VARCHAR(20) NOT NULL DEFAULT '',
+    role VARCHAR(20) NOT NULL DEFAULT 'CUSTOMER',
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default',
+    timezone VARCHAR(64) NOT NULL DEFAULT '',
+    language VARCHAR(5) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant, username),
+    UNIQUE (tenant, email)
+);
+
+CREATE TABLE organizations (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE organization_members (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, user_id)
+);
+
+CREATE TABLE organization_invitations (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    invited_by INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    responded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE accounts (
     id SERIAL PRIMARY KEY,
     user_id INTEGER NOT NULL REFERENCES users(id),
+    organization_id INTEGER REFERENCES organizations(id),
     account_number VARCHAR(20) UNIQUE NOT NULL,
     balance DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
     currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
     account_type VARCHAR(20) NOT NULL,
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default',
+    dormant_since TIMESTAMP WITH TIME ZONE,
+    reactivated_at TIMESTAMP WITH TIME ZONE,
+    locked_until TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     CHECK (balance >= 0.00)
 );
 
+CREATE TABLE account_settings (
+    account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    nickname VARCHAR(50) NOT NULL DEFAULT '',
+    color VARCHAR(7) NOT NULL DEFAULT '',
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, user_id),
+    CHECK (sort_order >= 0)
+);
+
+CREATE TABLE account_plans (
+    id SERIAL PRIMARY KEY,
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default',
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    account_types JSONB NOT NULL DEFAULT '[]',
+    monthly_fee DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    free_transfers INTEGER NOT NULL DEFAULT 0,
+    transfer_fee DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    interest_tiers JSONB NOT NULL DEFAULT '[]',
+    day_count VARCHAR(10) NOT NULL DEFAULT 'ACT/365',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant, code),
+    CHECK (monthly_fee >= 0.00 AND free_transfers >= 0 AND transfer_fee >= 0.00),
+    CHECK (day_count IN ('ACT/365', 'ACT/360', '30/360'))
+);
+
+-- The plans an account has been on; the open period (ended_at IS NULL) is its current plan
+CREATE TABLE account_plan_periods (
+    id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    plan_id INTEGER NOT NULL REFERENCES account_plans(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    billed_until TIMESTAMP WITH TIME ZONE NOT NULL, -- monthly fee and interest are settled up to here
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE TABLE card_products (
+    id SERIAL PRIMARY KEY,
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default',
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    card_type VARCHAR(20) NOT NULL,
+    bins JSONB NOT NULL DEFAULT '[]',
+    account_types JSONB NOT NULL DEFAULT '[]',
+    limits JSONB NOT NULL DEFAULT '{}',
+    fees JSONB NOT NULL DEFAULT '{}',
+    cashback JSONB NOT NULL DEFAULT '{"rules": []}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant, code),
+    CHECK (card_type IN ('VIRTUAL', 'DEBIT', 'CREDIT'))
+);
+
 CREATE TABLE cards (
     id SERIAL PRIMARY KEY,
     account_id INTEGER NOT NULL REFERENCES accounts(id),
+    product_id INTEGER NOT NULL REFERENCES card_products(id),
     card_number_encrypted BYTEA NOT NULL,
-    card_number_hmac VARCHAR(255) NOT NULL,
+    card_number_hmac VARCHAR(255) NOT NULL UNIQUE, -- lookup key; no two cards share a number
     expiry_date_encrypted BYTEA NOT NULL,
     cvv_hash VARCHAR(255) NOT NULL,
+    pin_hash VARCHAR(255),
+    pin_attempts INTEGER NOT NULL DEFAULT 0,
     card_type VARCHAR(20) NOT NULL,
     is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default', -- the tenant of the account
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -57,7 +168,110 @@ CREATE TABLE transactions (
     card_id INTEGER REFERENCES cards(id),
     transaction_date TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
-    CHECK (amount > 0.00)
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    reference UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(), -- public reference payments are verified by
+    decline_reason VARCHAR(30), -- why a FAILED transaction was declined
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default', -- set by a trigger from the accounts
+    CHECK (amount > 0.00),
+    CHECK (decline_reason IS NULL OR status = 'FAILED')
+);
+
+-- Recurring fees of card products and account plans, assessed once per product and period and
+-- charged as FEE transactions; charges the account cannot cover stay in DUNNING and are retried
+CREATE TABLE fee_charges (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL,
+    reference_id INTEGER NOT NULL, -- the card or the account plan period
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    card_id INTEGER REFERENCES cards(id),
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    description TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    transaction_id INTEGER REFERENCES transactions(id),
+    charged_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, reference_id, period_start),
+    CHECK (kind IN ('CARD_SERVICE', 'ACCOUNT_MAINTENANCE')),
+    CHECK (status IN ('PENDING', 'CHARGED', 'DUNNING')),
+    CHECK (amount > 0.00),
+    CHECK (transaction_id IS NOT NULL OR status <> 'CHARGED')
+);
+
+-- Summaries of the runs of the recurring fee job
+CREATE TABLE fee_runs (
+    id SERIAL PRIMARY KEY,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    assessed INTEGER NOT NULL DEFAULT 0,
+    charged INTEGER NOT NULL DEFAULT 0,
+    dunning INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    totals JSONB NOT NULL DEFAULT '[]' -- charged and dunning amounts by currency
+);
+
+-- The descriptions users give their transactions; transactions keep the original. Entries are never changed
+CREATE TABLE transaction_description_edits (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Every insert and update of a transaction, written by a trigger; the worker feeds them to the search
+-- index and deletes them a day after processing
+CREATE TABLE transaction_events (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Changes to accounts, their cards and their limits for the account activity feed; triggers write
+-- the account and card events, the approval policy repository the limit changes
+CREATE TABLE account_events (
+    id BIGSERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    event_type VARCHAR(30) NOT NULL,
+    card_id INTEGER,
+    limits JSONB, -- the approval thresholds after a LIMITS_CHANGED event
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Powers of attorney: access an account owner grants another user until it expires or is revoked
+CREATE TABLE account_delegations (
+    id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    owner_id INTEGER NOT NULL REFERENCES users(id),
+    delegate_id INTEGER NOT NULL REFERENCES users(id),
+    scope VARCHAR(20) NOT NULL,
+    transfer_limit DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    revoked_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (owner_id <> delegate_id),
+    CHECK (transfer_limit >= 0.00)
+);
+
+-- The audit log of delegations: grants, revocations and everything delegates do; entries are never changed
+CREATE TABLE account_delegation_events (
+    id SERIAL PRIMARY KEY,
+    delegation_id INTEGER NOT NULL REFERENCES account_delegations(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    actor_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(20) NOT NULL,
+    transaction_id INTEGER REFERENCES transactions(id),
+    amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE credits (
@@ -71,12 +285,15 @@ CREATE TABLE credits (
     start_date DATE NOT NULL,
     end_date DATE NOT NULL,
     status VARCHAR(20) NOT NULL,
+    day_count VARCHAR(10) NOT NULL DEFAULT '30/360',
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default', -- the tenant of the account
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     CHECK (amount > 0.00),
     CHECK (interest_rate >= 0.00),
     CHECK (term_months > 0),
-    CHECK (monthly_payment > 0.00)
+    CHECK (monthly_payment > 0.00),
+    CHECK (day_count IN ('ACT/365', 'ACT/360', '30/360'))
 );
 
 CREATE TABLE payment_schedules (
@@ -85,6 +302,7 @@ CREATE TABLE payment_schedules (
     payment_date DATE NOT NULL,
     principal_amount DECIMAL(15, 2) NOT NULL,
     interest_amount DECIMAL(15, 2) NOT NULL,
+    insurance_amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
     total_amount DECIMAL(15, 2) NOT NULL,
     status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
     is_overdue BOOLEAN NOT NULL DEFAULT FALSE,
@@ -93,18 +311,875 @@ CREATE TABLE payment_schedules (
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     CHECK (principal_amount >= 0.00),
     CHECK (interest_amount >= 0.00),
+    CHECK (insurance_amount >= 0.00),
     CHECK (total_amount >= 0.00),
     CHECK (penalty_amount >= 0.00)
 );
 
+-- Reminders sent ahead of credit payments, one per payment and number of days before it is due
+CREATE TABLE payment_reminders (
+    payment_id INTEGER NOT NULL REFERENCES payment_schedules(id) ON DELETE CASCADE,
+    days_before INTEGER NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (payment_id, days_before),
+    CHECK (days_before > 0)
+);
+
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    session_id VARCHAR(64) UNIQUE NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    location VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One-time password reset links; only the SHA-256 of the token is stored
+CREATE TABLE password_resets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Onboarding stages customers completed (EMAIL_VERIFIED, KYC, FIRST_ACCOUNT, FIRST_CARD), each once
+CREATE TABLE onboarding_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    stage VARCHAR(20) NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, stage)
+);
+
+-- API keys backend partners call the API with on behalf of a user; only the SHA-256 of the key is stored
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    key_prefix VARCHAR(20) NOT NULL,
+    key_hash VARCHAR(64) UNIQUE NOT NULL,
+    scopes JSONB NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Support employees acting as customers with read-only tokens, bound to the employee's session
+CREATE TABLE impersonations (
+    id SERIAL PRIMARY KEY,
+    staff_id INTEGER NOT NULL REFERENCES users(id),
+    customer_id INTEGER NOT NULL REFERENCES users(id),
+    reason TEXT NOT NULL,
+    session_id VARCHAR(64) NOT NULL REFERENCES sessions(session_id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The audit log of every request made with an impersonation token; entries are never changed
+CREATE TABLE impersonation_requests (
+    id BIGSERIAL PRIMARY KEY,
+    impersonation_id INTEGER NOT NULL REFERENCES impersonations(id),
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    allowed BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE user_devices (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    fingerprint VARCHAR(64) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    first_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, fingerprint)
+);
+
+CREATE TABLE notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    type VARCHAR(20) NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE email_deliveries (
+    id SERIAL PRIMARY KEY,
+    message_id VARCHAR(255) UNIQUE NOT NULL,
+    user_id INTEGER REFERENCES users(id),
+    tenant VARCHAR(50) NOT NULL DEFAULT 'default',
+    recipient VARCHAR(255) NOT NULL,
+    subject TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    response TEXT NOT NULL DEFAULT '',
+    opened_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE email_suppressions (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) UNIQUE NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    delivery_id INTEGER REFERENCES email_deliveries(id),
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE announcements (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    segment JSONB NOT NULL,
+    send_email BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL DEFAULT 'QUEUED',
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE announcement_recipients (
+    id SERIAL PRIMARY KEY,
+    announcement_id INTEGER NOT NULL REFERENCES announcements(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    error TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (announcement_id, user_id)
+);
+
+CREATE TABLE transfer_confirmations (
+    id SERIAL PRIMARY KEY,
+    confirmation_id VARCHAR(64) UNIQUE NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    source_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    destination_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    invoice_id INTEGER, -- the invoice the transfer pays, see invoices
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    transaction_id INTEGER REFERENCES transactions(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE approval_policies (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    min_amount DECIMAL(15, 2) NOT NULL,
+    required_approvals INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, min_amount),
+    CHECK (min_amount > 0.00),
+    CHECK (required_approvals > 0)
+);
+
+CREATE TABLE pending_transfers (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id),
+    requested_by INTEGER NOT NULL REFERENCES users(id),
+    source_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    destination_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    invoice_id INTEGER, -- the invoice the transfer pays, see invoices
+    required_approvals INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING_APPROVAL',
+    transaction_id INTEGER REFERENCES transactions(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE pending_transfer_approvals (
+    id SERIAL PRIMARY KEY,
+    pending_transfer_id INTEGER NOT NULL REFERENCES pending_transfers(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (pending_transfer_id, user_id)
+);
+
+CREATE TABLE payrolls (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    currency VARCHAR(3) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    item_count INTEGER NOT NULL,
+    total_amount DECIMAL(15, 2) NOT NULL,
+    invalid_count INTEGER NOT NULL DEFAULT 0,
+    paid_count INTEGER NOT NULL DEFAULT 0,
+    paid_amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    uploaded_by INTEGER NOT NULL REFERENCES users(id),
+    executed_by INTEGER REFERENCES users(id),
+    funding_transaction_id INTEGER REFERENCES transactions(id),
+    executed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('VALIDATED', 'INVALID', 'COMPLETED')),
+    CHECK (item_count > 0)
+);
+
+CREATE TABLE payroll_items (
+    id SERIAL PRIMARY KEY,
+    payroll_id INTEGER NOT NULL REFERENCES payrolls(id) ON DELETE CASCADE,
+    line_number INTEGER NOT NULL,
+    account_number TEXT NOT NULL,
+    employee_name TEXT NOT NULL DEFAULT '',
+    amount DECIMAL(15, 2) NOT NULL,
+    account_id INTEGER REFERENCES accounts(id),
+    status VARCHAR(20) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    transaction_id INTEGER REFERENCES transactions(id),
+    CHECK (status IN ('VALID', 'INVALID', 'PAID', 'FAILED'))
+);
+
+CREATE TABLE account_holds (
+    id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    reference_id INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    released_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE cheque_deposits (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    recognized_amount DECIMAL(15, 2),
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    scan_status VARCHAR(20) NOT NULL,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    reviewed_by INTEGER REFERENCES users(id),
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('PENDING', 'CLEARED', 'REJECTED')),
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE international_transfers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    source_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    reference VARCHAR(36) UNIQUE NOT NULL,
+    beneficiary_name VARCHAR(140) NOT NULL,
+    beneficiary_iban VARCHAR(34) NOT NULL,
+    beneficiary_bic VARCHAR(11) NOT NULL,
+    beneficiary_country VARCHAR(2) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    charge_bearer VARCHAR(3) NOT NULL,
+    fee DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    correspondent_fee DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    debited_amount DECIMAL(15, 2) NOT NULL,
+    credited_amount DECIMAL(15, 2) NOT NULL,
+    purpose VARCHAR(140) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'SUBMITTED',
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    fee_transaction_id INTEGER REFERENCES transactions(id),
+    dispatch_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expected_settlement_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    in_transit_at TIMESTAMP WITH TIME ZONE,
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('SUBMITTED', 'IN_TRANSIT', 'SETTLED')),
+    CHECK (charge_bearer IN ('OUR', 'SHA', 'BEN')),
+    CHECK (amount > 0.00 AND credited_amount > 0.00)
+);
+
+CREATE TABLE locations (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(10) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    address VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    services JSONB NOT NULL DEFAULT '[]',
+    opening_hours JSONB NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (latitude BETWEEN -90 AND 90),
+    CHECK (longitude BETWEEN -180 AND 180)
+);
+
+CREATE TABLE rates_history (
+    id SERIAL PRIMARY KEY,
+    rate_type VARCHAR(10) NOT NULL,
+    currency VARCHAR(3),
+    rate DECIMAL(15, 6) NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((rate_type = 'KEY') = (currency IS NULL))
+);
+
+-- Base rates pinned by admins for the days from effective_from to effective_to, used instead of
+-- the central bank's key rate when it is wrong or cannot be fetched
+CREATE TABLE key_rate_overrides (
+    id SERIAL PRIMARY KEY,
+    rate DECIMAL(15, 6) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE, -- the last day in effect, NULL until revoked
+    reason TEXT NOT NULL,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    revoked_by INTEGER REFERENCES users(id),
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (rate > 0 AND rate <= 100),
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE TABLE bill_providers (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(20) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '[]',
+    min_amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    max_amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE bill_payments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    provider_id INTEGER NOT NULL REFERENCES bill_providers(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE bill_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    provider_id INTEGER NOT NULL REFERENCES bill_providers(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    amount DECIMAL(15, 2) NOT NULL,
+    fields JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE merchants (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    name VARCHAR(100) NOT NULL,
+    settlement_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    api_key_hash VARCHAR(64) UNIQUE NOT NULL,
+    api_key_prefix VARCHAR(20) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE settlement_batches (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    settlement_date DATE NOT NULL,
+    payment_count INTEGER NOT NULL DEFAULT 0,
+    amount DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    transaction_id INTEGER REFERENCES transactions(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (merchant_id, settlement_date)
+);
+
+CREATE TABLE payment_intents (
+    id SERIAL PRIMARY KEY,
+    intent_id VARCHAR(64) UNIQUE NOT NULL,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
+    description TEXT NOT NULL DEFAULT '',
+    order_reference VARCHAR(100) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'CREATED',
+    customer_id INTEGER REFERENCES users(id),
+    source_account_id INTEGER REFERENCES accounts(id),
+    transaction_id INTEGER REFERENCES transactions(id),
+    settlement_batch_id INTEGER REFERENCES settlement_batches(id),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE chargebacks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    payment_intent_id INTEGER NOT NULL REFERENCES payment_intents(id),
+    original_transaction_id INTEGER UNIQUE NOT NULL REFERENCES transactions(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    evidence TEXT NOT NULL DEFAULT '',
+    resolution_note TEXT NOT NULL DEFAULT '',
+    provisional_credit_transaction_id INTEGER REFERENCES transactions(id),
+    merchant_debit_transaction_id INTEGER REFERENCES transactions(id),
+    reversal_transaction_id INTEGER REFERENCES transactions(id),
+    evidence_due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    evidence_submitted_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE escrows (
+    id SERIAL PRIMARY KEY,
+    buyer_id INTEGER NOT NULL REFERENCES users(id),
+    seller_id INTEGER NOT NULL REFERENCES users(id),
+    buyer_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    seller_account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'FUNDED',
+    buyer_confirmed_at TIMESTAMP WITH TIME ZONE,
+    seller_confirmed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    funding_transaction_id INTEGER REFERENCES transactions(id),
+    settlement_transaction_id INTEGER REFERENCES transactions(id),
+    resolved_by INTEGER REFERENCES users(id),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    settled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('FUNDED', 'RELEASED', 'REFUNDED')),
+    CHECK (buyer_id <> seller_id),
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE subscription_plans (
+    id SERIAL PRIMARY KEY,
+    merchant_id INTEGER NOT NULL REFERENCES merchants(id),
+    name VARCHAR(100) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    billing_interval VARCHAR(10) NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (billing_interval IN ('WEEK', 'MONTH', 'YEAR')),
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE subscriptions (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES subscription_plans(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    next_billing_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    next_retry_at TIMESTAMP WITH TIME ZONE,
+    last_payment_error TEXT NOT NULL DEFAULT '',
+    cancel_reason TEXT NOT NULL DEFAULT '',
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('ACTIVE', 'PAST_DUE', 'CANCELLED'))
+);
+
+CREATE TABLE invoices (
+    id SERIAL PRIMARY KEY,
+    number VARCHAR(64) UNIQUE NOT NULL,
+    issuer_id INTEGER NOT NULL REFERENCES users(id),
+    merchant_id INTEGER REFERENCES merchants(id),
+    subscription_id INTEGER REFERENCES subscriptions(id),
+    recipient_id INTEGER NOT NULL REFERENCES users(id),
+    recipient_email VARCHAR(100) NOT NULL,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'ISSUED',
+    payer_account_id INTEGER REFERENCES accounts(id),
+    transaction_id INTEGER REFERENCES transactions(id),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (status IN ('ISSUED', 'PAID', 'OVERDUE', 'CANCELLED')),
+    CHECK (issuer_id <> recipient_id),
+    CHECK (amount > 0.00)
+);
+
+CREATE TABLE invoice_items (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    unit_price DECIMAL(15, 2) NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    CHECK (quantity > 0),
+    CHECK (unit_price > 0.00)
+);
+
+CREATE TABLE referral_codes (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    code VARCHAR(16) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE referrals (
+    id SERIAL PRIMARY KEY,
+    referrer_id INTEGER NOT NULL REFERENCES users(id),
+    referee_id INTEGER UNIQUE NOT NULL REFERENCES users(id),
+    code VARCHAR(16) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    qualifying_transaction_id INTEGER REFERENCES transactions(id),
+    referrer_bonus DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    referee_bonus DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    referrer_bonus_transaction_id INTEGER REFERENCES transactions(id),
+    referee_bonus_transaction_id INTEGER REFERENCES transactions(id),
+    qualified_at TIMESTAMP WITH TIME ZONE,
+    rewarded_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (referrer_id <> referee_id)
+);
+
+CREATE TABLE tax_documents (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    year INTEGER NOT NULL,
+    interest_earned DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    loan_interest_paid DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    loan_penalties_paid DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    details JSONB NOT NULL DEFAULT '{}',
+    generated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    emailed_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (user_id, year)
+);
+
+CREATE TABLE account_statements (
+    id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+    opening_balance DECIMAL(15, 2) NOT NULL,
+    total_credits DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    total_debits DECIMAL(15, 2) NOT NULL DEFAULT 0.00,
+    closing_balance DECIMAL(15, 2) NOT NULL,
+    transaction_count INTEGER NOT NULL DEFAULT 0,
+    issued_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    regenerated_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (account_id, period_start),
+    CHECK (period_end > period_start)
+);
+
+CREATE TABLE credit_applications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    amount DECIMAL(15, 2) NOT NULL,
+    term_months INTEGER NOT NULL,
+    interest_rate DECIMAL(5, 2) NOT NULL DEFAULT 0.00,
+    insurance BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL,
+    credit_id INTEGER REFERENCES credits(id),
+    review_note TEXT,
+    reviewed_by INTEGER REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (amount > 0.00),
+    CHECK (term_months > 0)
+);
+
+CREATE TABLE credit_documents (
+    id SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL REFERENCES credit_applications(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    type VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key VARCHAR(255) UNIQUE NOT NULL,
+    scan_status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    review_note TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE credit_signature_requests (
+    id SERIAL PRIMARY KEY,
+    request_id VARCHAR(64) UNIQUE NOT NULL,
+    credit_id INTEGER NOT NULL REFERENCES credits(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    document_hash CHAR(64) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    code_hash VARCHAR(255) NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Signatures are evidence and are never changed; see prevent_credit_signature_change
+CREATE TABLE credit_signatures (
+    id SERIAL PRIMARY KEY,
+    credit_id INTEGER UNIQUE NOT NULL REFERENCES credits(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    version INTEGER NOT NULL,
+    document_hash CHAR(64) NOT NULL,
+    otp_channel VARCHAR(20) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    signed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Penalty waivers, reschedulings and restructurings made by bank employees; entries are never changed
+CREATE TABLE credit_adjustments (
+    id SERIAL PRIMARY KEY,
+    credit_id INTEGER NOT NULL REFERENCES credits(id),
+    payment_id INTEGER REFERENCES payment_schedules(id),
+    admin_id INTEGER NOT NULL REFERENCES users(id),
+    type VARCHAR(20) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Account ownership transfers, e.g. to the heir of a deceased customer; one employee requests
+-- a transfer and another one approves it
+CREATE TABLE account_ownership_transfers (
+    id SERIAL PRIMARY KEY,
+    account_id INTEGER NOT NULL REFERENCES accounts(id),
+    from_user_id INTEGER NOT NULL REFERENCES users(id),
+    to_user_id INTEGER NOT NULL REFERENCES users(id),
+    reason VARCHAR(30) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    documents JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING_APPROVAL',
+    requested_by INTEGER NOT NULL REFERENCES users(id),
+    reviewed_by INTEGER REFERENCES users(id),
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (from_user_id <> to_user_id),
+    CHECK (reviewed_by IS NULL OR reviewed_by <> requested_by)
+);
+
+-- The audit trail of account ownership transfers; entries are never changed
+CREATE TABLE account_ownership_transfer_events (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER NOT NULL REFERENCES account_ownership_transfers(id),
+    admin_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(20) NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE insurance_policies (
+    id SERIAL PRIMARY KEY,
+    policy_number VARCHAR(20) UNIQUE NOT NULL,
+    credit_id INTEGER UNIQUE NOT NULL REFERENCES credits(id),
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    rate DECIMAL(7, 6) NOT NULL,
+    monthly_premium DECIMAL(15, 2) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (monthly_premium >= 0.00)
+);
+
+CREATE TABLE message_threads (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    subject VARCHAR(200) NOT NULL,
+    started_by VARCHAR(20) NOT NULL,
+    last_message_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE messages (
+    id SERIAL PRIMARY KEY,
+    thread_id INTEGER NOT NULL REFERENCES message_threads(id),
+    sender VARCHAR(20) NOT NULL,
+    author_id INTEGER NOT NULL REFERENCES users(id),
+    body TEXT NOT NULL,
+    document_name VARCHAR(255),
+    document_type VARCHAR(100),
+    document BYTEA,
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Seed the bill provider catalog
+INSERT INTO bill_providers (code, name, category, fields, min_amount, max_amount) VALUES
+    ('mosenergosbyt', 'Мосэнергосбыт', 'UTILITIES',
+     '[{"name": "account_number", "label": "Лицевой счет", "pattern": "[0-9]{10}", "required": true}, {"name": "period", "label": "Период", "pattern": "(0[1-9]|1[0-2])\\.[0-9]{4}", "required": false}]',
+     1.00, 500000.00),
+    ('mosvodokanal', 'Мосводоканал', 'UTILITIES',
+     '[{"name": "account_number", "label": "Лицевой счет", "pattern": "[0-9]{8,12}", "required": true}]',
+     1.00, 500000.00),
+    ('mts', 'МТС', 'MOBILE',
+     '[{"name": "phone", "label": "Номер телефона", "pattern": "\\+7[0-9]{10}", "required": true}]',
+     10.00, 15000.00),
+    ('beeline', 'Билайн', 'MOBILE',
+     '[{"name": "phone", "label": "Номер телефона", "pattern": "\\+7[0-9]{10}", "required": true}]',
+     10.00, 15000.00),
+    ('megafon', 'МегаФон', 'MOBILE',
+     '[{"name": "phone", "label": "Номер телефона", "pattern": "\\+7[0-9]{10}", "required": true}]',
+     10.00, 15000.00),
+    ('rostelecom', 'Ростелеком', 'INTERNET',
+     '[{"name": "contract_number", "label": "Номер договора", "pattern": "[0-9]{12}", "required": true}]',
+     1.00, 100000.00);
+
+-- Seed the account plan catalog
+INSERT INTO account_plans (code, name, account_types, monthly_fee, free_transfers, transfer_fee, interest_tiers) VALUES
+    ('BASIC', 'Базовый', '["CHECKING", "SAVINGS"]', 0.00, 5, 30.00, '[]'),
+    ('PREMIUM', 'Премиум', '["CHECKING", "SAVINGS"]', 490.00, 0, 0.00,
+     '[{"min_balance": 0, "rate": 3}, {"min_balance": 300000, "rate": 6}]'),
+    ('SAVINGS_PLUS', 'Накопительный+', '["SAVINGS"]', 0.00, 3, 50.00,
+     '[{"min_balance": 0, "rate": 4}, {"min_balance": 100000, "rate": 8}, {"min_balance": 1000000, "rate": 10}]');
+
+-- Seed the card product catalog
+INSERT INTO card_products (code, name, card_type, bins, account_types, limits, fees, cashback) VALUES
+    ('MIR_VIRTUAL', 'Мир Виртуальная', 'VIRTUAL', '["220071"]', '["CHECKING"]',
+     '{"purchase": 100000, "withdrawal": 0, "daily": 200000}',
+     '{"issue": 0, "monthly": 0, "atm_percent": 0, "atm_min": 0}',
+     '{"rules": [], "monthly_cap": 0}'),
+    ('MIR_DEBIT', 'Мир Дебетовая', 'DEBIT', '["220070"]', '["CHECKING", "SAVINGS"]',
+     '{"purchase": 0, "withdrawal": 100000, "daily": 300000}',
+     '{"issue": 0, "monthly": 0, "atm_percent": 0, "atm_min": 0}',
+     '{"rules": [{"percent": 1}], "monthly_cap": 3000}'),
+    ('MIR_CREDIT', 'Мир Кредитная', 'CREDIT', '["220072"]', '["CREDIT"]',
+     '{"purchase": 0, "withdrawal": 50000, "daily": 300000}',
+     '{"issue": 0, "monthly": 0, "atm_percent": 3, "atm_min": 390}',
+     '{"rules": [], "monthly_cap": 0}');
+
 -- Create indexes for better performance
 CREATE INDEX idx_accounts_user_id ON accounts(user_id);
+CREATE INDEX idx_accounts_organization_id ON accounts(organization_id);
+CREATE UNIQUE INDEX idx_accounts_default ON accounts(user_id, currency) WHERE is_default;
+CREATE INDEX idx_account_settings_user_id ON account_settings(user_id);
+CREATE INDEX idx_account_delegations_account_id ON account_delegations(account_id, delegate_id) WHERE revoked_at IS NULL;
+CREATE INDEX idx_account_delegations_delegate_id ON account_delegations(delegate_id);
+CREATE UNIQUE INDEX idx_account_plan_periods_current ON account_plan_periods(account_id) WHERE ended_at IS NULL;
+CREATE INDEX idx_account_plan_periods_unbilled ON account_plan_periods(billed_until);
+CREATE INDEX idx_fee_charges_collectable ON fee_charges(id) WHERE status IN ('PENDING', 'DUNNING');
+CREATE INDEX idx_fee_charges_account_id ON fee_charges(account_id);
+CREATE INDEX idx_account_delegations_owner_id ON account_delegations(owner_id);
+CREATE INDEX idx_account_delegation_events_delegation_id ON account_delegation_events(delegation_id);
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+CREATE INDEX idx_organization_invitations_email ON organization_invitations(LOWER(email));
+CREATE INDEX idx_pending_transfers_organization_id ON pending_transfers(organization_id);
+CREATE INDEX idx_payrolls_organization_id ON payrolls(organization_id);
+CREATE INDEX idx_payroll_items_payroll_id ON payroll_items(payroll_id, line_number);
 CREATE INDEX idx_cards_account_id ON cards(account_id);
 CREATE INDEX idx_transactions_source_account_id ON transactions(source_account_id);
 CREATE INDEX idx_transactions_destination_account_id ON transactions(destination_account_id);
+CREATE INDEX idx_transactions_card_id ON transactions(card_id, transaction_date) WHERE card_id IS NOT NULL;
 CREATE INDEX idx_credits_user_id ON credits(user_id);
 CREATE INDEX idx_credits_account_id ON credits(account_id);
 CREATE INDEX idx_payment_schedules_credit_id ON payment_schedules(credit_id);
+CREATE INDEX idx_sessions_user_id ON sessions(user_id);
+CREATE INDEX idx_password_resets_user_id ON password_resets(user_id);
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+CREATE INDEX idx_impersonations_staff_id ON impersonations(staff_id);
+CREATE INDEX idx_impersonations_customer_id ON impersonations(customer_id);
+CREATE INDEX idx_impersonation_requests_impersonation_id ON impersonation_requests(impersonation_id);
+CREATE INDEX idx_notifications_user_id ON notifications(user_id);
+CREATE INDEX idx_email_deliveries_recipient ON email_deliveries(recipient);
+CREATE INDEX idx_email_deliveries_status ON email_deliveries(status);
+CREATE INDEX idx_announcement_recipients_pending ON announcement_recipients(announcement_id, id) WHERE status = 'PENDING';
+CREATE INDEX idx_bill_payments_user_id ON bill_payments(user_id);
+CREATE INDEX idx_bill_templates_user_id ON bill_templates(user_id);
+CREATE INDEX idx_merchants_user_id ON merchants(user_id);
+CREATE INDEX idx_payment_intents_merchant_id ON payment_intents(merchant_id);
+CREATE INDEX idx_payment_intents_unsettled ON payment_intents(merchant_id, paid_at) WHERE status = 'SUCCEEDED' AND settlement_batch_id IS NULL;
+CREATE INDEX idx_chargebacks_user_id ON chargebacks(user_id);
+CREATE INDEX idx_chargebacks_merchant_id ON chargebacks(merchant_id);
+CREATE INDEX idx_chargebacks_open ON chargebacks(evidence_due_at) WHERE status = 'OPEN';
+CREATE INDEX idx_escrows_buyer_id ON escrows(buyer_id);
+CREATE INDEX idx_escrows_seller_id ON escrows(seller_id);
+CREATE INDEX idx_escrows_funded ON escrows(expires_at) WHERE status = 'FUNDED';
+CREATE INDEX idx_subscription_plans_merchant_id ON subscription_plans(merchant_id);
+CREATE INDEX idx_subscriptions_plan_id ON subscriptions(plan_id);
+CREATE INDEX idx_subscriptions_user_id ON subscriptions(user_id);
+CREATE INDEX idx_subscriptions_due ON subscriptions(next_billing_at) WHERE status <> 'CANCELLED';
+CREATE INDEX idx_invoices_issuer_id ON invoices(issuer_id);
+CREATE INDEX idx_invoices_recipient_id ON invoices(recipient_id);
+CREATE INDEX idx_invoices_merchant_id ON invoices(merchant_id) WHERE merchant_id IS NOT NULL;
+CREATE INDEX idx_invoices_issued ON invoices(due_at) WHERE status = 'ISSUED';
+CREATE INDEX idx_invoices_subscription_id ON invoices(subscription_id) WHERE subscription_id IS NOT NULL;
+CREATE INDEX idx_invoice_items_invoice_id ON invoice_items(invoice_id);
+CREATE INDEX idx_referrals_referrer_id ON referrals(referrer_id);
+CREATE INDEX idx_referrals_status ON referrals(status) WHERE status IN ('PENDING', 'QUALIFIED');
+CREATE INDEX idx_credit_applications_user_id ON credit_applications(user_id);
+CREATE INDEX idx_credit_applications_status ON credit_applications(status);
+CREATE INDEX idx_credit_documents_application_id ON credit_documents(application_id);
+CREATE INDEX idx_credit_signature_requests_credit_id ON credit_signature_requests(credit_id);
+CREATE INDEX idx_credit_adjustments_credit_id ON credit_adjustments(credit_id);
+CREATE UNIQUE INDEX idx_account_ownership_transfers_pending ON account_ownership_transfers(account_id) WHERE status = 'PENDING_APPROVAL';
+CREATE INDEX idx_account_ownership_transfer_events_transfer_id ON account_ownership_transfer_events(transfer_id);
+CREATE INDEX idx_message_threads_user_id ON message_threads(user_id);
+CREATE INDEX idx_messages_thread_id ON messages(thread_id);
+CREATE INDEX idx_messages_unread ON messages(thread_id, sender) WHERE read_at IS NULL;
+CREATE INDEX idx_account_holds_active ON account_holds(account_id) WHERE status = 'ACTIVE';
+CREATE UNIQUE INDEX idx_account_holds_reference ON account_holds(reason, reference_id) WHERE status = 'ACTIVE';
+CREATE INDEX idx_cheque_deposits_user_id ON cheque_deposits(user_id);
+CREATE INDEX idx_cheque_deposits_pending ON cheque_deposits(created_at) WHERE status = 'PENDING';
+CREATE INDEX idx_international_transfers_user_id ON international_transfers(user_id);
+CREATE INDEX idx_international_transfers_unsettled ON international_transfers(status) WHERE status <> 'SETTLED';
+CREATE INDEX idx_users_created_at ON users(created_at);
+CREATE INDEX idx_transactions_transaction_date ON transactions(transaction_date);
+CREATE INDEX idx_transactions_updated_at ON transactions(updated_at);
+CREATE INDEX idx_account_events_account ON account_events(account_id, created_at, id);
+CREATE INDEX idx_transaction_description_edits_user ON transaction_description_edits(user_id, transaction_id, id);
+CREATE INDEX idx_accounts_updated_at ON accounts(updated_at);
+CREATE INDEX idx_credits_updated_at ON credits(updated_at);
+CREATE INDEX idx_transaction_events_unprocessed ON transaction_events(id) WHERE processed_at IS NULL;
+CREATE INDEX idx_transaction_events_created_at ON transaction_events(created_at);
+CREATE INDEX idx_credits_created_at ON credits(created_at);
+CREATE INDEX idx_payment_schedules_unpaid ON payment_schedules(credit_id, payment_date) WHERE status IN ('PENDING', 'OVERDUE');
+CREATE INDEX idx_locations_coordinates ON locations(latitude, longitude) WHERE is_active = TRUE;
+CREATE INDEX idx_rates_history_lookup ON rates_history(rate_type, (COALESCE(currency, '')), fetched_at);
 
 -- Create functions for updating timestamps
 CREATE OR REPLACE FUNCTION update_modified_column()
@@ -120,18 +1195,253 @@ CREATE TRIGGER update_users_modtime
 BEFORE UPDATE ON users
 FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
 
+CREATE TRIGGER update_organizations_modtime
+BEFORE UPDATE ON organizations
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
 CREATE TRIGGER update_accounts_modtime
 BEFORE UPDATE ON accounts
 FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
 
+CREATE TRIGGER update_account_plans_modtime
+BEFORE UPDATE ON account_plans
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_card_products_modtime
+BEFORE UPDATE ON card_products
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_fee_charges_modtime
+BEFORE UPDATE ON fee_charges
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_email_deliveries_modtime
+BEFORE UPDATE ON email_deliveries
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
 CREATE TRIGGER update_cards_modtime
 BEFORE UPDATE ON cards
 FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
 
+CREATE TRIGGER update_pending_transfers_modtime
+BEFORE UPDATE ON pending_transfers
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_payrolls_modtime
+BEFORE UPDATE ON payrolls
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_locations_modtime
+BEFORE UPDATE ON locations
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_bill_templates_modtime
+BEFORE UPDATE ON bill_templates
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_merchants_modtime
+BEFORE UPDATE ON merchants
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_payment_intents_modtime
+BEFORE UPDATE ON payment_intents
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_chargebacks_modtime
+BEFORE UPDATE ON chargebacks
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_escrows_modtime
+BEFORE UPDATE ON escrows
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_subscription_plans_modtime
+BEFORE UPDATE ON subscription_plans
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_subscriptions_modtime
+BEFORE UPDATE ON subscriptions
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_invoices_modtime
+BEFORE UPDATE ON invoices
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_cheque_deposits_modtime
+BEFORE UPDATE ON cheque_deposits
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_international_transfers_modtime
+BEFORE UPDATE ON international_transfers
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
 CREATE TRIGGER update_credits_modtime
 BEFORE UPDATE ON credits
 FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
 
+CREATE TRIGGER update_credit_applications_modtime
+BEFORE UPDATE ON credit_applications
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_account_ownership_transfers_modtime
+BEFORE UPDATE ON account_ownership_transfers
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
 CREATE TRIGGER update_payment_schedules_modtime
 BEFORE UPDATE ON payment_schedules
-FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
\ No newline at end of file
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+CREATE TRIGGER update_transactions_modtime
+BEFORE UPDATE ON transactions
+FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+-- Reject any change to a stored signature
+CREATE OR REPLACE FUNCTION prevent_credit_signature_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'credit signatures are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER credit_signatures_immutable
+BEFORE UPDATE OR DELETE ON credit_signatures
+FOR EACH ROW EXECUTE PROCEDURE prevent_credit_signature_change();
+
+-- Reject any change to a credit adjustment, which is an audit entry
+CREATE OR REPLACE FUNCTION prevent_credit_adjustment_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'credit adjustments are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER credit_adjustments_immutable
+BEFORE UPDATE OR DELETE ON credit_adjustments
+FOR EACH ROW EXECUTE PROCEDURE prevent_credit_adjustment_change();
+
+-- Reject any change to an ownership transfer event, which is an audit entry
+CREATE OR REPLACE FUNCTION prevent_account_ownership_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'account ownership transfer events are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER account_ownership_transfer_events_immutable
+BEFORE UPDATE OR DELETE ON account_ownership_transfer_events
+FOR EACH ROW EXECUTE PROCEDURE prevent_account_ownership_event_change();
+
+-- Reject any change to a transaction description edit, which is part of the edit history
+CREATE OR REPLACE FUNCTION prevent_transaction_description_edit_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'transaction description edits are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER transaction_description_edits_immutable
+BEFORE UPDATE OR DELETE ON transaction_description_edits
+FOR EACH ROW EXECUTE PROCEDURE prevent_transaction_description_edit_change();
+
+-- Reject any change to an impersonation request, which is an audit entry
+CREATE OR REPLACE FUNCTION prevent_impersonation_request_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'impersonation requests are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER impersonation_requests_immutable
+BEFORE UPDATE OR DELETE ON impersonation_requests
+FOR EACH ROW EXECUTE PROCEDURE prevent_impersonation_request_change();
+
+-- Reject any change to a delegation event, which is an audit entry
+CREATE OR REPLACE FUNCTION prevent_account_delegation_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'account delegation events are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER account_delegation_events_immutable
+BEFORE UPDATE OR DELETE ON account_delegation_events
+FOR EACH ROW EXECUTE PROCEDURE prevent_account_delegation_event_change();
+
+-- A transaction belongs to the tenant of its source account, or of its destination account if it
+-- has none. Set here rather than by each insert, so single and batch inserts cannot miss it.
+CREATE OR REPLACE FUNCTION set_transaction_tenant()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.tenant = COALESCE(
+        (SELECT tenant FROM accounts WHERE id = COALESCE(NEW.source_account_id, NEW.destination_account_id)),
+        'default');
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER transactions_set_tenant
+BEFORE INSERT ON transactions
+FOR EACH ROW EXECUTE PROCEDURE set_transaction_tenant();
+
+-- Record every new or changed transaction for the search index
+CREATE OR REPLACE FUNCTION record_transaction_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO transaction_events (transaction_id) VALUES (NEW.id);
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER transactions_record_event
+AFTER INSERT OR UPDATE ON transactions
+FOR EACH ROW EXECUTE PROCEDURE record_transaction_event();
+
+-- Record the changes to accounts shown in their activity feed
+CREATE OR REPLACE FUNCTION record_account_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO account_events (account_id, event_type) VALUES (NEW.id, 'ACCOUNT_OPENED');
+        RETURN NEW;
+    END IF;
+
+    IF NEW.is_active <> OLD.is_active THEN
+        INSERT INTO account_events (account_id, event_type)
+        VALUES (NEW.id, CASE WHEN NEW.is_active THEN 'ACCOUNT_ACTIVATED' ELSE 'ACCOUNT_DEACTIVATED' END);
+    END IF;
+
+    IF (NEW.dormant_since IS NULL) <> (OLD.dormant_since IS NULL) THEN
+        INSERT INTO account_events (account_id, event_type)
+        VALUES (NEW.id, CASE WHEN NEW.dormant_since IS NULL THEN 'ACCOUNT_REACTIVATED' ELSE 'ACCOUNT_DORMANT' END);
+    END IF;
+
+    IF NEW.user_id <> OLD.user_id THEN
+        INSERT INTO account_events (account_id, event_type) VALUES (NEW.id, 'OWNER_CHANGED');
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER accounts_record_event
+AFTER INSERT OR UPDATE ON accounts
+FOR EACH ROW EXECUTE PROCEDURE record_account_event();
+
+-- Record new, frozen and unfrozen cards in the activity feed of their account
+CREATE OR REPLACE FUNCTION record_card_event()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO account_events (account_id, event_type, card_id) VALUES (NEW.account_id, 'CARD_CREATED', NEW.id);
+    ELSIF NEW.is_active <> OLD.is_active THEN
+        INSERT INTO account_events (account_id, event_type, card_id)
+        VALUES (NEW.account_id, CASE WHEN NEW.is_active THEN 'CARD_UNFROZEN' ELSE 'CARD_FROZEN' END, NEW.id);
+    END IF;
+
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER cards_record_event
+AFTER INSERT OR UPDATE ON cards
+FOR EACH ROW EXECUTE PROCEDURE record_card_event();